@@ -43,6 +43,20 @@ func NewInsufficientPermissionsErrorResponse() (int, ErrorResponse) {
 	return http.StatusForbidden, NewErrorResponse("insufficient permissions to perform the requested action")
 }
 
+// CommittedResponse wraps the data of a response whose changes are committed even though its status is not OK.
+// The changes made by any other response without an OK status are rolled back.
+type CommittedResponse struct {
+	Data interface{}
+}
+
+// NewCommittedResponse returns the provided status and the data wrapped in a CommittedResponse.
+// Only use it for changes that need to outlast the failed request, such as revoking a reused refresh token.
+func NewCommittedResponse(status int, data interface{}) (int, CommittedResponse) {
+	return status, CommittedResponse{
+		Data: data,
+	}
+}
+
 // DataResponse represents a response with a true/false success field and generic data.
 type DataResponse struct {
 	Success bool        `json:"success"`
@@ -56,3 +70,22 @@ func NewSuccessDataResponse(data interface{}) (int, DataResponse) {
 		Data:    data,
 	}
 }
+
+// PageResponse represents a response with a true/false success field, a page of generic data, and where the page is in its list.
+// NextCursor is omitted on the last page.
+type PageResponse struct {
+	Success    bool        `json:"success"`
+	Data       interface{} `json:"data"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	Total      int         `json:"total"`
+}
+
+// NewSuccessPageResponse returns an http OK status and a new PageResponse with the provided data, next cursor, and total.
+func NewSuccessPageResponse(data interface{}, nextCursor string, total int) (int, PageResponse) {
+	return http.StatusOK, PageResponse{
+		Success:    true,
+		Data:       data,
+		NextCursor: nextCursor,
+		Total:      total,
+	}
+}
@@ -17,38 +17,208 @@ type Config struct {
 	// AppName is a client facing name to refer to the app as.
 	AppName string `yaml:"app_name"`
 
-	// DataAdapter is the name of the data adapter the app will use.
+	// DataAdapter is the name of the data adapter the app will use, one of "database", "firestore", or "memory".
+	// The memory adapter keeps its data in memory only, so it is lost when the app stops.
 	DataAdapter string `yaml:"data_adapter,omitempty"`
 
+	ServerConfig           ServerConfig           `yaml:"server"`
+	TLSConfig              TLSConfig              `yaml:"tls"`
 	TokenConfig            TokenConfig            `yaml:"token"`
+	SessionConfig          SessionConfig          `yaml:"session"`
+	TwoFactorConfig        TwoFactorConfig        `yaml:"two_factor"`
+	LockoutConfig          LockoutConfig          `yaml:"lockout"`
 	PermissionConfig       PermissionConfig       `yaml:"permissions"`
+	PasswordResetConfig    PasswordResetConfig    `yaml:"password_reset"`
+	InvitationConfig       InvitationConfig       `yaml:"invitation"`
+	MailConfig             MailConfig             `yaml:"mail"`
 	DatabaseConfig         DatabaseConfig         `yaml:"database,omitempty"`
 	FirestoreConfig        FirestoreConfig        `yaml:"firestore,omitempty"`
 	PasswordCriteriaConfig PasswordCriteriaConfig `yaml:"password_criteria"`
 }
 
+type ServerConfig struct {
+	// ShutdownTimeout is the length of time in seconds the server waits for in-flight requests to finish when shutting down.
+	// A value of zero means the server waits for them indefinitely.
+	ShutdownTimeout int64 `yaml:"shutdown_timeout"`
+
+	// PublicURL is the scheme and host users reach the app at, e.g. "https://auth.example.com".
+	// Links sent in emails are always built from it, never from the request, so clients can't change where they point.
+	PublicURL string `yaml:"public_url"`
+
+	// TrustedProxies is the number of proxies in front of the app that append to the X-Forwarded-For, X-Forwarded-Proto, and X-Forwarded-Host headers.
+	// The client ip address and the scheme and host of the app's base url are read from the values added by the outermost of them.
+	// A value of zero ignores the headers, otherwise clients can spoof their ip address and change the urls the app links to.
+	TrustedProxies int `yaml:"trusted_proxies"`
+}
+
+type TLSConfig struct {
+	// Enabled determines if the server is served over https instead of http.
+	Enabled bool `yaml:"enabled"`
+
+	// CertFile is the location of the PEM encoded certificate chain, relative to the app root.
+	CertFile string `yaml:"cert_file,omitempty"`
+
+	// KeyFile is the location of the PEM encoded private key for the certificate, relative to the app root.
+	KeyFile string `yaml:"key_file,omitempty"`
+
+	// MinVersion is the minimum TLS version clients can connect with, either "1.2" or "1.3".
+	// An empty value defaults to "1.2".
+	MinVersion string `yaml:"min_version,omitempty"`
+
+	// ClientCAFile is the location of the PEM encoded CA certificates used to verify client certificates, relative to the app root.
+	// If set, clients must present a certificate signed by one of the CAs to connect.
+	ClientCAFile string `yaml:"client_ca_file,omitempty"`
+
+	// ReloadInterval is the length of time in seconds between checks for changes to the certificate and key files.
+	// The certificate is reloaded without restarting the server when either file changes. A value of zero disables reloading.
+	ReloadInterval int64 `yaml:"reload_interval"`
+
+	// RedirectPort is the port to serve http on, which redirects every request to https.
+	// An empty value disables the redirect.
+	RedirectPort string `yaml:"redirect_port,omitempty"`
+}
+
 type TokenConfig struct {
 	// DefaultIssuer is the the value that will go in the "issuer" field for default tokens.
 	DefaultIssuer string `yaml:"default_issuer"`
 
 	// Lifetime is the length of time a token will be valid for.
 	Lifetime int64 `yaml:"lifetime"`
+
+	// AuthorizationCodeLifetime is the length of time in seconds an authorization code can be exchanged for a token.
+	AuthorizationCodeLifetime int64 `yaml:"authorization_code_lifetime"`
+
+	// RefreshTokenLifetime is the length of time in seconds a refresh token can be redeemed for a new token.
+	// Each rotation issues a new refresh token with a full lifetime.
+	RefreshTokenLifetime int64 `yaml:"refresh_token_lifetime"`
+
+	// IncludeProfileClaims is whether default tokens include the user's email and display name claims.
+	IncludeProfileClaims bool `yaml:"include_profile_claims"`
+
+	// IncludeSingleRoleClaim is whether tokens also include the user's first role as the single "role" claim.
+	// This keeps tokens compatible with clients that were built before users could have multiple roles.
+	// Defaults to true if not set, so upgrading doesn't remove the claim those clients rely on.
+	IncludeSingleRoleClaim bool `yaml:"include_single_role_claim"`
+}
+
+type SessionConfig struct {
+	// Lifetime is the maximum length of time in seconds a session will be valid for after it is created.
+	// A value of zero means sessions never reach an absolute expiry.
+	Lifetime int64 `yaml:"lifetime"`
+
+	// IdleTimeout is the length of time in seconds a session can go unused before it expires.
+	// A value of zero means sessions never expire from being idle.
+	IdleTimeout int64 `yaml:"idle_timeout"`
+
+	// SweepInterval is the length of time in seconds between each purge of expired sessions.
+	// A value of zero disables the sweeper.
+	SweepInterval int64 `yaml:"sweep_interval"`
+}
+
+type TwoFactorConfig struct {
+	// EncryptionKey is the base64 encoded 32 byte key used to encrypt TOTP secrets and two-factor challenges.
+	EncryptionKey string `yaml:"encryption_key"`
+
+	// ChallengeLifetime is the length of time in seconds a user has to complete the next step of logging in, either entering their two-factor code or changing their expired password.
+	ChallengeLifetime int64 `yaml:"challenge_lifetime"`
+
+	// RequiredRank is the minimum rank a user must have to be required to enable two-factor authentication.
+	// A value of zero means two-factor authentication is never required.
+	RequiredRank int `yaml:"required_rank"`
+}
+
+type LockoutConfig struct {
+	// MaxUserAttempts is the number of failed logins for a username before it is locked out.
+	// A value of zero disables username lockouts.
+	MaxUserAttempts int `yaml:"max_user_attempts"`
+
+	// MaxIPAttempts is the number of failed logins from an ip address before it is locked out.
+	// A value of zero disables ip lockouts.
+	MaxIPAttempts int `yaml:"max_ip_attempts"`
+
+	// Duration is the length of time in seconds of the first lockout. It doubles with every failed login after that.
+	Duration int64 `yaml:"duration"`
+
+	// MaxDuration is the longest length of time in seconds a lockout can grow to.
+	MaxDuration int64 `yaml:"max_duration"`
+
+	// ResetAfter is the length of time in seconds without a failed login before the count of failed logins starts over.
+	ResetAfter int64 `yaml:"reset_after"`
 }
 
 type PermissionConfig struct {
-	// MinClientRank is the minimum rank a user must have to manage clients.
+	// These ranks are only used by the permission migrator, which gives users at or above them the matching built-in admin roles.
+
+	// MinClientRank is the minimum rank a user needed to manage clients.
 	MinClientRank int `yaml:"min_client_rank"`
+
+	// MinLockoutRank is the minimum rank a user needed to view and clear login lockouts.
+	MinLockoutRank int `yaml:"min_lockout_rank"`
+
+	// MinAuditRank is the minimum rank a user needed to view the audit log.
+	MinAuditRank int `yaml:"min_audit_rank"`
+}
+
+type PasswordResetConfig struct {
+	// TokenLifetime is the length of time in seconds a password reset link can be used.
+	TokenLifetime int64 `yaml:"token_lifetime"`
+}
+
+type InvitationConfig struct {
+	// Lifetime is the length of time in seconds an invitation can be accepted.
+	Lifetime int64 `yaml:"lifetime"`
+}
+
+type MailConfig struct {
+	// Type is the type of mailer used to send emails, either "smtp" or "file".
+	// The file mailer writes emails to a file instead of sending them, so it is only meant for development and tests.
+	// An empty value uses the file mailer.
+	Type string `yaml:"type"`
+
+	// From is the address emails are sent from.
+	From string `yaml:"from"`
+
+	// SMTPHost is the host of the smtp server.
+	SMTPHost string `yaml:"smtp_host,omitempty"`
+
+	// SMTPPort is the port of the smtp server.
+	SMTPPort string `yaml:"smtp_port,omitempty"`
+
+	// SMTPUsername is the username used to authenticate with the smtp server.
+	// An empty value means emails are sent without authenticating.
+	SMTPUsername string `yaml:"smtp_username,omitempty"`
+
+	// SMTPPassword is the password used to authenticate with the smtp server.
+	SMTPPassword string `yaml:"smtp_password,omitempty"`
+
+	// File is the location of the file the file mailer appends emails to, relative to the app root.
+	// An empty value writes the emails to the log instead.
+	File string `yaml:"file,omitempty"`
 }
 
 type DatabaseConfig struct {
-	// Driver is the database driver to use.
+	// Driver is the database driver to use, one of "postgres", "mysql", or "sqlite".
+	// It can be overridden with the CFG_DATABASE_DRIVER environment variable.
 	Driver string `yaml:"driver"`
 
 	// ConnectionStrings is a string map that maps db keys to the connection string of the database.
+	// A key prefixed with a driver name and colon (e.g. "sqlite:core") is used instead of the plain key when that driver is selected.
 	ConnectionStrings map[string]string `yaml:"connection_strings"`
 
 	// Timeout is the default timeout all database requests should use.
 	Timeout int `yaml:"timeout"`
+
+	// MaxOpenConnections is the max number of connections the pool will have open to the database at once.
+	// A value of zero means there is no limit.
+	MaxOpenConnections int `yaml:"max_open_connections"`
+
+	// MaxIdleConnections is the max number of unused connections the pool will keep open for reuse.
+	// A value of zero uses the driver's default.
+	MaxIdleConnections int `yaml:"max_idle_connections"`
+
+	// ConnectionMaxLifetime is the length of time in seconds a connection can be reused before it is closed and replaced.
+	// A value of zero means connections are reused forever.
+	ConnectionMaxLifetime int64 `yaml:"connection_max_lifetime"`
 }
 
 type FirestoreConfig struct {
@@ -74,6 +244,26 @@ type PasswordCriteriaConfig struct {
 
 	// RequireSymbol determines if at least one symbol must be present.
 	RequireSymbol bool `yaml:"require_symbol"`
+
+	// HistorySize is the number of the user's most recent passwords, including their current one, that a new password cannot match.
+	// A value of zero allows any previous password to be reused.
+	HistorySize int `yaml:"history_size"`
+
+	// MaxAge is the length of time in seconds after which a password expires and must be changed at the user's next login.
+	// A value of zero means passwords never expire.
+	MaxAge int64 `yaml:"max_age"`
+
+	// MinAge is the length of time in seconds a user must wait after their password is updated before they can change it again.
+	// A value of zero means there is no wait.
+	MinAge int64 `yaml:"min_age"`
+
+	// BreachedPasswordsPath is the location of a corpus of breached or common passwords that are rejected, relative to the app root.
+	// It can be a file with one password or hex encoded SHA-1 hash per line, or a directory of SHA-1 range files. Leave empty to disable.
+	BreachedPasswordsPath string `yaml:"breached_passwords_path,omitempty"`
+
+	// BreachedPasswordsFormat is the format of the breached password corpus, either "plain" for plain text passwords or "sha1" for SHA-1 hashes.
+	// It is required when the path is set, and a directory of range files must use "sha1".
+	BreachedPasswordsFormat string `yaml:"breached_passwords_format,omitempty"`
 }
 
 // InitConfig sets the default config values and binds environment variables.
@@ -87,6 +277,7 @@ func InitConfig(dir string) error {
 	viper.SetEnvPrefix("cfg")
 	viper.BindEnv("env")
 	viper.BindEnv("data_adapter")
+	viper.BindEnv("database_driver")
 
 	//calc the root dir using the provided path and the current working directory
 	wd, err := os.Getwd()
@@ -101,19 +292,36 @@ func InitConfig(dir string) error {
 		return common.ChainError("error loading config file", err)
 	}
 
-	//parse the yaml
-	var cfg Config
+	//parse the yaml, keeping the defaults for values not set in the file
+	cfg := Config{
+		TokenConfig: TokenConfig{
+			IncludeSingleRoleClaim: true,
+		},
+	}
 	err = yaml.Unmarshal(data, &cfg)
 	if err != nil {
 		return common.ChainError("error parsing config file", err)
 	}
 
+	//override the database driver if set in the environment
+	if driver := viper.GetString("database_driver"); driver != "" {
+		cfg.DatabaseConfig.Driver = driver
+	}
+
 	//set the config
 	viper.Set("root_dir", rootDir)
 	viper.Set("app_name", cfg.AppName)
 	viper.SetDefault("data_adapter", cfg.DataAdapter)
+	viper.Set("server", cfg.ServerConfig)
+	viper.Set("tls", cfg.TLSConfig)
 	viper.Set("token", cfg.TokenConfig)
+	viper.Set("session", cfg.SessionConfig)
+	viper.Set("two_factor", cfg.TwoFactorConfig)
+	viper.Set("lockout", cfg.LockoutConfig)
 	viper.Set("permission", cfg.PermissionConfig)
+	viper.Set("password_reset", cfg.PasswordResetConfig)
+	viper.Set("invitation", cfg.InvitationConfig)
+	viper.Set("mail", cfg.MailConfig)
 	viper.Set("database", cfg.DatabaseConfig)
 	viper.Set("firestore", cfg.FirestoreConfig)
 	viper.Set("password_criteria", cfg.PasswordCriteriaConfig)
@@ -137,16 +345,56 @@ func GetDataAdapter() string {
 	return viper.GetString("data_adapter")
 }
 
+// GetServerConfig gets the server config object.
+func GetServerConfig() ServerConfig {
+	return viper.Get("server").(ServerConfig)
+}
+
+// GetTLSConfig gets the tls config object.
+func GetTLSConfig() TLSConfig {
+	return viper.Get("tls").(TLSConfig)
+}
+
 // GetTokenConfig gets the token config object.
 func GetTokenConfig() TokenConfig {
 	return viper.Get("token").(TokenConfig)
 }
 
+// GetSessionConfig gets the session config object.
+func GetSessionConfig() SessionConfig {
+	return viper.Get("session").(SessionConfig)
+}
+
+// GetTwoFactorConfig gets the two-factor config object.
+func GetTwoFactorConfig() TwoFactorConfig {
+	return viper.Get("two_factor").(TwoFactorConfig)
+}
+
+// GetLockoutConfig gets the lockout config object.
+func GetLockoutConfig() LockoutConfig {
+	return viper.Get("lockout").(LockoutConfig)
+}
+
 // GetPermissionConfig gets the permissions config object.
 func GetPermissionConfig() PermissionConfig {
 	return viper.Get("permission").(PermissionConfig)
 }
 
+// GetPasswordResetConfig gets the password reset config object.
+func GetPasswordResetConfig() PasswordResetConfig {
+	return viper.Get("password_reset").(PasswordResetConfig)
+}
+
+// GetInvitationConfig gets the invitation config object.
+func GetInvitationConfig() InvitationConfig {
+	return viper.Get("invitation").(InvitationConfig)
+}
+
+// GetMailConfig gets the mail config object.
+func GetMailConfig() MailConfig {
+	return viper.Get("mail").(MailConfig)
+}
+
 // GetDatabaseConfig gets the database config object.
 func GetDatabaseConfig() DatabaseConfig {
 	return viper.Get("database").(DatabaseConfig)
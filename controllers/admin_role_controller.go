@@ -0,0 +1,362 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+// The names of the built-in admin roles.
+const (
+	AdminRoleAdmin        = "admin"
+	AdminRoleUserAdmin    = "user_admin"
+	AdminRoleClientAdmin  = "client_admin"
+	AdminRoleLockoutAdmin = "lockout_admin"
+	AdminRoleAuditor      = "auditor"
+)
+
+// builtInAdminRoles creates the built-in admin roles, which grant the same access the old rank-based checks did.
+func builtInAdminRoles() []*models.AdminRole {
+	return []*models.AdminRole{
+		models.CreateAdminRole(AdminRoleAdmin, "Has every permission", models.Permissions...),
+		models.CreateAdminRole(AdminRoleUserAdmin, "Manages users of lesser rank, their roles, and their groups",
+			models.PermissionUsersRead, models.PermissionUsersWrite, models.PermissionRolesRead, models.PermissionRolesWrite, models.PermissionInvitationsWrite,
+			models.PermissionGroupsRead, models.PermissionGroupsWrite,
+		),
+		models.CreateAdminRole(AdminRoleClientAdmin, "Manages clients, their role definitions, and their client admins",
+			models.PermissionClientsRead, models.PermissionClientsWrite, models.PermissionClientAdminsRead, models.PermissionClientAdminsWrite,
+		),
+		models.CreateAdminRole(AdminRoleLockoutAdmin, "Views and clears login lockouts",
+			models.PermissionLockoutsRead, models.PermissionLockoutsWrite,
+		),
+		models.CreateAdminRole(AdminRoleAuditor, "Views the audit log", models.PermissionAuditRead),
+	}
+}
+
+// isBuiltInAdminRole returns if the admin role with the given name is one of the built-in admin roles.
+func isBuiltInAdminRole(name string) bool {
+	for _, role := range builtInAdminRoles() {
+		if role.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+type CoreAdminRoleController struct{}
+
+func (c CoreAdminRoleController) CreateAdminRole(CRUD AdminRoleControllerCRUD, role *models.AdminRole) common.CustomError {
+	//validate the model
+	cerr := c.validateAdminRole(role)
+	if cerr.Type != common.ErrorTypeNone {
+		return cerr
+	}
+
+	//verify the admin role does not already exist
+	existingRole, err := CRUD.GetAdminRoleByName(role.Name)
+	if err != nil {
+		log.Println(common.ChainError("error getting admin role by name", err))
+		return common.InternalError()
+	}
+	if existingRole != nil {
+		return common.ClientError(fmt.Sprintf("admin role %s already exists", role.Name))
+	}
+
+	//create the admin role
+	err = CRUD.CreateAdminRole(role)
+	if err != nil {
+		log.Println(common.ChainError("error creating admin role", err))
+		return common.InternalError()
+	}
+
+	return common.NoError()
+}
+
+func (CoreAdminRoleController) GetAdminRoles(CRUD AdminRoleControllerCRUD) ([]*models.AdminRole, common.CustomError) {
+	roles, err := CRUD.GetAdminRoles()
+	if err != nil {
+		log.Println(common.ChainError("error getting admin roles", err))
+		return nil, common.InternalError()
+	}
+
+	return roles, common.NoError()
+}
+
+func (c CoreAdminRoleController) UpdateAdminRole(CRUD AdminRoleControllerCRUD, role *models.AdminRole) common.CustomError {
+	//validate the model
+	cerr := c.validateAdminRole(role)
+	if cerr.Type != common.ErrorTypeNone {
+		return cerr
+	}
+
+	//the built-in admin roles are kept in sync with the built-in permissions, so they cannot be changed
+	if isBuiltInAdminRole(role.Name) {
+		return common.ClientError(fmt.Sprintf("built-in admin role %s cannot be updated", role.Name))
+	}
+
+	//update the admin role
+	res, err := CRUD.UpdateAdminRole(role)
+	if err != nil {
+		log.Println(common.ChainError("error updating admin role", err))
+		return common.InternalError()
+	}
+
+	//verify the admin role was found
+	if !res {
+		return common.ClientError(fmt.Sprintf("admin role %s not found", role.Name))
+	}
+
+	return common.NoError()
+}
+
+func (CoreAdminRoleController) DeleteAdminRole(CRUD AdminRoleControllerCRUD, name string) common.CustomError {
+	if isBuiltInAdminRole(name) {
+		return common.ClientError(fmt.Sprintf("built-in admin role %s cannot be deleted", name))
+	}
+
+	res, err := CRUD.DeleteAdminRole(name)
+	if err != nil {
+		log.Println(common.ChainError("error deleting admin role", err))
+		return common.InternalError()
+	}
+
+	//verify the admin role was found
+	if !res {
+		return common.ClientError(fmt.Sprintf("admin role %s not found", name))
+	}
+
+	return common.NoError()
+}
+
+func (CoreAdminRoleController) VerifyAdminRoleRank(CRUD AdminRoleControllerCRUD, name string, username string, rank int) (bool, common.CustomError) {
+	//get the users the admin role was given to
+	usernames, err := CRUD.GetAdminRoleUsernames(name)
+	if err != nil {
+		log.Println(common.ChainError("error getting admin role usernames", err))
+		return false, common.InternalError()
+	}
+
+	//verify the rank of each user
+	for _, holder := range usernames {
+		if holder == username {
+			continue
+		}
+
+		user, err := CRUD.GetUserByUsername(holder)
+		if err != nil {
+			log.Println(common.ChainError("error getting user by username", err))
+			return false, common.InternalError()
+		}
+
+		//admin roles are taken away along with their user, so in practice the user should always be found
+		if user != nil && user.Rank >= rank {
+			return false, common.NoError()
+		}
+	}
+
+	return true, common.NoError()
+}
+
+func (c CoreAdminRoleController) GetUserAdminRoles(CRUD AdminRoleControllerCRUD, username string) ([]*models.AdminRole, common.CustomError) {
+	//verify the user exists
+	cerr := c.verifyUserExists(CRUD, username)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
+	}
+
+	//get the user's admin roles
+	roles, err := CRUD.GetAdminRolesByUsername(username)
+	if err != nil {
+		log.Println(common.ChainError("error getting admin roles by username", err))
+		return nil, common.InternalError()
+	}
+
+	return roles, common.NoError()
+}
+
+func (c CoreAdminRoleController) UpdateUserAdminRoles(CRUD AdminRoleControllerCRUD, username string, names []string) ([]*models.AdminRole, common.CustomError) {
+	//verify the user exists
+	cerr := c.verifyUserExists(CRUD, username)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
+	}
+
+	//verify each of the admin roles exist
+	seen := map[string]bool{}
+	for _, name := range names {
+		if seen[name] {
+			return nil, common.ClientError("admin roles cannot contain duplicates")
+		}
+		seen[name] = true
+
+		role, err := CRUD.GetAdminRoleByName(name)
+		if err != nil {
+			log.Println(common.ChainError("error getting admin role by name", err))
+			return nil, common.InternalError()
+		}
+		if role == nil {
+			return nil, common.ClientError(fmt.Sprintf("admin role %s not found", name))
+		}
+	}
+
+	//replace the user's admin roles
+	err := CRUD.SetUserAdminRoles(username, names)
+	if err != nil {
+		log.Println(common.ChainError("error setting user admin roles", err))
+		return nil, common.InternalError()
+	}
+
+	//get the user's new admin roles
+	roles, err := CRUD.GetAdminRolesByUsername(username)
+	if err != nil {
+		log.Println(common.ChainError("error getting admin roles by username", err))
+		return nil, common.InternalError()
+	}
+
+	return roles, common.NoError()
+}
+
+func (CoreAdminRoleController) CreateBuiltInAdminRoles(CRUD AdminRoleControllerCRUD) common.CustomError {
+	for _, role := range builtInAdminRoles() {
+		existingRole, err := CRUD.GetAdminRoleByName(role.Name)
+		if err != nil {
+			log.Println(common.ChainError("error getting admin role by name", err))
+			return common.InternalError()
+		}
+
+		//give the admin roles that already exist any built-in permissions added since they were created
+		if existingRole != nil {
+			missing := false
+			for _, permission := range role.Permissions {
+				if !models.HasPermission(existingRole.Permissions, permission, uuid.Nil) {
+					existingRole.Permissions = append(existingRole.Permissions, permission)
+					missing = true
+				}
+			}
+
+			if missing {
+				_, err = CRUD.UpdateAdminRole(existingRole)
+				if err != nil {
+					log.Println(common.ChainError("error updating built-in admin role", err))
+					return common.InternalError()
+				}
+			}
+			continue
+		}
+
+		err = CRUD.CreateAdminRole(role)
+		if err != nil {
+			log.Println(common.ChainError("error creating built-in admin role", err))
+			return common.InternalError()
+		}
+	}
+
+	return common.NoError()
+}
+
+func (c CoreAdminRoleController) AssignAdminRolesByRank(CRUD AdminRoleControllerCRUD) common.CustomError {
+	cerr := c.CreateBuiltInAdminRoles(CRUD)
+	if cerr.Type != common.ErrorTypeNone {
+		return cerr
+	}
+
+	//get every user (ranks are stored as small ints, so every rank is less than the max)
+	users, err := CRUD.GetUsersWithLesserRank(math.MaxInt16, models.PageQuery{})
+	if err != nil {
+		log.Println(common.ChainError("error getting users with lesser rank", err))
+		return common.InternalError()
+	}
+
+	//the users with the highest rank get every permission
+	maxRank := 0
+	for _, user := range users {
+		if user.Rank > maxRank {
+			maxRank = user.Rank
+		}
+	}
+
+	cfg := config.GetPermissionConfig()
+	for _, user := range users {
+		//keep the admin roles the user already has
+		roles, err := CRUD.GetAdminRolesByUsername(user.Username)
+		if err != nil {
+			log.Println(common.ChainError("error getting admin roles by username", err))
+			return common.InternalError()
+		}
+
+		names := []string{}
+		seen := map[string]bool{}
+		for _, role := range roles {
+			names = append(names, role.Name)
+			seen[role.Name] = true
+		}
+
+		//add the admin roles for the user's rank
+		added := false
+		add := func(name string, hasRank bool) {
+			if hasRank && !seen[name] {
+				names = append(names, name)
+				seen[name] = true
+				added = true
+			}
+		}
+		add(AdminRoleAdmin, user.Rank > 0 && user.Rank == maxRank)
+		add(AdminRoleUserAdmin, user.Rank > 0)
+		add(AdminRoleClientAdmin, user.Rank >= cfg.MinClientRank)
+		add(AdminRoleLockoutAdmin, user.Rank >= cfg.MinLockoutRank)
+		add(AdminRoleAuditor, user.Rank >= cfg.MinAuditRank)
+
+		if !added {
+			continue
+		}
+
+		err = CRUD.SetUserAdminRoles(user.Username, names)
+		if err != nil {
+			log.Println(common.ChainError("error setting user admin roles", err))
+			return common.InternalError()
+		}
+	}
+
+	return common.NoError()
+}
+
+func (CoreAdminRoleController) validateAdminRole(role *models.AdminRole) common.CustomError {
+	verr := role.Validate()
+
+	if verr&models.ValidateAdminRoleEmptyName != 0 {
+		return common.ClientError("admin role name cannot be empty")
+	}
+	if verr&models.ValidateAdminRoleNameTooLong != 0 {
+		return common.ClientError(fmt.Sprint("admin role name cannot be longer than ", models.AdminRoleNameMaxLength, " characters"))
+	}
+	if verr&models.ValidateAdminRoleDescriptionTooLong != 0 {
+		return common.ClientError(fmt.Sprint("admin role description cannot be longer than ", models.AdminRoleDescriptionMaxLength, " characters"))
+	}
+	if verr&models.ValidateAdminRoleInvalidPermission != 0 {
+		return common.ClientError("admin role permissions can only contain known permissions, or client permissions scoped to a client id")
+	}
+	if verr&models.ValidateAdminRoleDuplicatePermission != 0 {
+		return common.ClientError("admin role permissions cannot contain duplicates")
+	}
+
+	return common.NoError()
+}
+
+func (CoreAdminRoleController) verifyUserExists(CRUD AdminRoleControllerCRUD, username string) common.CustomError {
+	user, err := CRUD.GetUserByUsername(username)
+	if err != nil {
+		log.Println(common.ChainError("error getting user by username", err))
+		return common.InternalError()
+	}
+	if user == nil {
+		return common.ClientError(fmt.Sprintf("user with username %s not found", username))
+	}
+
+	return common.NoError()
+}
@@ -0,0 +1,640 @@
+package controllers_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
+	"github.com/mhogar/amber/controllers"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AdminRoleControllerTestSuite struct {
+	ControllerTestSuite
+	AdminRoleController controllers.CoreAdminRoleController
+}
+
+func (suite *AdminRoleControllerTestSuite) SetupTest() {
+	suite.ControllerTestSuite.SetupTest()
+	suite.AdminRoleController = controllers.CoreAdminRoleController{}
+
+	viper.Set("permission", config.PermissionConfig{
+		MinClientRank:  5,
+		MinLockoutRank: 7,
+		MinAuditRank:   6,
+	})
+}
+
+func (suite *AdminRoleControllerTestSuite) runValidateAdminRoleTestCases(validateFunc func(role *models.AdminRole) common.CustomError) {
+	suite.Run("EmptyName_ReturnsClientError", func() {
+		//arrange
+		role := models.CreateAdminRole("", "")
+
+		//act
+		cerr := validateFunc(role)
+
+		//assert
+		suite.CustomClientError(cerr, "admin role name", "cannot be empty")
+	})
+
+	suite.Run("NameTooLong_ReturnsClientError", func() {
+		//arrange
+		role := models.CreateAdminRole(helpers.CreateStringOfLength(models.AdminRoleNameMaxLength+1), "")
+
+		//act
+		cerr := validateFunc(role)
+
+		//assert
+		suite.CustomClientError(cerr, "admin role name", "cannot be longer", fmt.Sprint(models.AdminRoleNameMaxLength))
+	})
+
+	suite.Run("DescriptionTooLong_ReturnsClientError", func() {
+		//arrange
+		role := models.CreateAdminRole("role", helpers.CreateStringOfLength(models.AdminRoleDescriptionMaxLength+1))
+
+		//act
+		cerr := validateFunc(role)
+
+		//assert
+		suite.CustomClientError(cerr, "admin role description", "cannot be longer", fmt.Sprint(models.AdminRoleDescriptionMaxLength))
+	})
+
+	suite.Run("InvalidPermission_ReturnsClientError", func() {
+		//arrange
+		role := models.CreateAdminRole("role", "", "DNE")
+
+		//act
+		cerr := validateFunc(role)
+
+		//assert
+		suite.CustomClientError(cerr, "admin role permissions", "known permissions")
+	})
+
+	suite.Run("DuplicatePermission_ReturnsClientError", func() {
+		//arrange
+		role := models.CreateAdminRole("role", "", models.PermissionUsersRead, models.PermissionUsersRead)
+
+		//act
+		cerr := validateFunc(role)
+
+		//assert
+		suite.CustomClientError(cerr, "admin role permissions", "cannot contain duplicates")
+	})
+}
+
+func (suite *AdminRoleControllerTestSuite) TestCreateAdminRole_ValidateAdminRoleTestCases() {
+	suite.runValidateAdminRoleTestCases(func(role *models.AdminRole) common.CustomError {
+		return suite.AdminRoleController.CreateAdminRole(&suite.CRUDMock, role)
+	})
+}
+
+func (suite *AdminRoleControllerTestSuite) TestCreateAdminRole_WithErrorGettingAdminRole_ReturnsInternalError() {
+	//arrange
+	role := models.CreateAdminRole("role", "")
+	suite.CRUDMock.On("GetAdminRoleByName", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.AdminRoleController.CreateAdminRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestCreateAdminRole_WhereAdminRoleAlreadyExists_ReturnsClientError() {
+	//arrange
+	role := models.CreateAdminRole("role", "")
+	suite.CRUDMock.On("GetAdminRoleByName", mock.Anything).Return(models.CreateAdminRole("role", "", models.Permissions...), nil)
+
+	//act
+	cerr := suite.AdminRoleController.CreateAdminRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomClientError(cerr, "admin role", role.Name, "already exists")
+	suite.CRUDMock.AssertCalled(suite.T(), "GetAdminRoleByName", role.Name)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestCreateAdminRole_WithErrorCreatingAdminRole_ReturnsInternalError() {
+	//arrange
+	role := models.CreateAdminRole("role", "")
+
+	suite.CRUDMock.On("GetAdminRoleByName", mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("CreateAdminRole", mock.Anything).Return(errors.New(""))
+
+	//act
+	cerr := suite.AdminRoleController.CreateAdminRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestCreateAdminRole_WithNoErrors_ReturnsNoError() {
+	//arrange
+	role := models.CreateAdminRole("role", "", models.PermissionUsersRead)
+
+	suite.CRUDMock.On("GetAdminRoleByName", mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("CreateAdminRole", mock.Anything).Return(nil)
+
+	//act
+	cerr := suite.AdminRoleController.CreateAdminRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "CreateAdminRole", role)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestGetAdminRoles_WithErrorGettingAdminRoles_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetAdminRoles").Return(nil, errors.New(""))
+
+	//act
+	roles, cerr := suite.AdminRoleController.GetAdminRoles(&suite.CRUDMock)
+
+	//assert
+	suite.Nil(roles)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestGetAdminRoles_WithNoErrors_ReturnsAdminRoles() {
+	//arrange
+	roles := []*models.AdminRole{
+		models.CreateAdminRole("role1", ""),
+		models.CreateAdminRole("role2", ""),
+	}
+	suite.CRUDMock.On("GetAdminRoles").Return(roles, nil)
+
+	//act
+	resultRoles, cerr := suite.AdminRoleController.GetAdminRoles(&suite.CRUDMock)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Equal(roles, resultRoles)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestUpdateAdminRole_ValidateAdminRoleTestCases() {
+	suite.runValidateAdminRoleTestCases(func(role *models.AdminRole) common.CustomError {
+		return suite.AdminRoleController.UpdateAdminRole(&suite.CRUDMock, role)
+	})
+}
+
+func (suite *AdminRoleControllerTestSuite) TestUpdateAdminRole_WithBuiltInAdminRole_ReturnsClientError() {
+	//arrange
+	role := models.CreateAdminRole(controllers.AdminRoleAuditor, "")
+
+	//act
+	cerr := suite.AdminRoleController.UpdateAdminRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomClientError(cerr, "built-in admin role", role.Name, "cannot be updated")
+	suite.CRUDMock.AssertNotCalled(suite.T(), "UpdateAdminRole", mock.Anything)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestUpdateAdminRole_WithErrorUpdatingAdminRole_ReturnsInternalError() {
+	//arrange
+	role := models.CreateAdminRole("role", "")
+	suite.CRUDMock.On("UpdateAdminRole", mock.Anything).Return(false, errors.New(""))
+
+	//act
+	cerr := suite.AdminRoleController.UpdateAdminRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestUpdateAdminRole_WhereAdminRoleNotFound_ReturnsClientError() {
+	//arrange
+	role := models.CreateAdminRole("role", "")
+	suite.CRUDMock.On("UpdateAdminRole", mock.Anything).Return(false, nil)
+
+	//act
+	cerr := suite.AdminRoleController.UpdateAdminRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomClientError(cerr, "admin role", role.Name, "not found")
+}
+
+func (suite *AdminRoleControllerTestSuite) TestUpdateAdminRole_WithNoErrors_ReturnsNoError() {
+	//arrange
+	role := models.CreateAdminRole("role", "description", models.PermissionAuditRead)
+	suite.CRUDMock.On("UpdateAdminRole", mock.Anything).Return(true, nil)
+
+	//act
+	cerr := suite.AdminRoleController.UpdateAdminRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "UpdateAdminRole", role)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestDeleteAdminRole_WithBuiltInAdminRole_ReturnsClientError() {
+	//act
+	cerr := suite.AdminRoleController.DeleteAdminRole(&suite.CRUDMock, controllers.AdminRoleAdmin)
+
+	//assert
+	suite.CustomClientError(cerr, "built-in admin role", controllers.AdminRoleAdmin, "cannot be deleted")
+	suite.CRUDMock.AssertNotCalled(suite.T(), "DeleteAdminRole", mock.Anything)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestDeleteAdminRole_WithErrorDeletingAdminRole_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("DeleteAdminRole", mock.Anything).Return(false, errors.New(""))
+
+	//act
+	cerr := suite.AdminRoleController.DeleteAdminRole(&suite.CRUDMock, "role")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestDeleteAdminRole_WhereAdminRoleNotFound_ReturnsClientError() {
+	//arrange
+	name := "role"
+	suite.CRUDMock.On("DeleteAdminRole", mock.Anything).Return(false, nil)
+
+	//act
+	cerr := suite.AdminRoleController.DeleteAdminRole(&suite.CRUDMock, name)
+
+	//assert
+	suite.CustomClientError(cerr, "admin role", name, "not found")
+}
+
+func (suite *AdminRoleControllerTestSuite) TestDeleteAdminRole_WithNoErrors_ReturnsNoError() {
+	//arrange
+	name := "role"
+	suite.CRUDMock.On("DeleteAdminRole", mock.Anything).Return(true, nil)
+
+	//act
+	cerr := suite.AdminRoleController.DeleteAdminRole(&suite.CRUDMock, name)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteAdminRole", name)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestVerifyAdminRoleRank_WithErrorGettingAdminRoleUsernames_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetAdminRoleUsernames", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	_, cerr := suite.AdminRoleController.VerifyAdminRoleRank(&suite.CRUDMock, "role", "session_user", 0)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestVerifyAdminRoleRank_WithErrorGettingUserByUsername_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetAdminRoleUsernames", mock.Anything).Return([]string{"username"}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	_, cerr := suite.AdminRoleController.VerifyAdminRoleRank(&suite.CRUDMock, "role", "session_user", 0)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestVerifyAdminRoleRank_WithNoErrors_TestCases() {
+	//arrange
+	name := "role"
+	users := []*models.User{
+		models.CreateUser("user1", 2, nil),
+		models.CreateUser("user2", 5, nil),
+	}
+
+	suite.CRUDMock.On("GetAdminRoleUsernames", mock.Anything).Return([]string{users[0].Username, users[1].Username}, nil)
+	suite.CRUDMock.On("GetUserByUsername", users[0].Username).Return(users[0], nil)
+	suite.CRUDMock.On("GetUserByUsername", users[1].Username).Return(users[1], nil)
+
+	var username string
+	var rank int
+	expectedResult := false
+
+	testCase := func() {
+		//act
+		res, cerr := suite.AdminRoleController.VerifyAdminRoleRank(&suite.CRUDMock, name, username, rank)
+
+		//assert
+		suite.CustomNoError(cerr)
+		suite.Equal(expectedResult, res)
+	}
+
+	username = "session_user"
+
+	rank = 4
+	suite.Run("RankLessThanUser_ReturnsFalseResult", testCase)
+
+	rank = 5
+	suite.Run("RankEqualToUser_ReturnsFalseResult", testCase)
+
+	rank = 6
+	expectedResult = true
+	suite.Run("RankGreaterThanAllUsers_ReturnsTrueResult", testCase)
+
+	username = users[1].Username
+	rank = 5
+	suite.Run("RankEqualToSessionUser_ReturnsTrueResult", testCase)
+
+	suite.CRUDMock.AssertCalled(suite.T(), "GetAdminRoleUsernames", name)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestGetUserAdminRoles_WithErrorGettingUser_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	roles, cerr := suite.AdminRoleController.GetUserAdminRoles(&suite.CRUDMock, "username")
+
+	//assert
+	suite.Nil(roles)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestGetUserAdminRoles_WhereUserNotFound_ReturnsClientError() {
+	//arrange
+	username := "username"
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
+
+	//act
+	roles, cerr := suite.AdminRoleController.GetUserAdminRoles(&suite.CRUDMock, username)
+
+	//assert
+	suite.Nil(roles)
+	suite.CustomClientError(cerr, "user with username", username, "not found")
+}
+
+func (suite *AdminRoleControllerTestSuite) TestGetUserAdminRoles_WithErrorGettingAdminRoles_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetAdminRolesByUsername", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	roles, cerr := suite.AdminRoleController.GetUserAdminRoles(&suite.CRUDMock, "username")
+
+	//assert
+	suite.Nil(roles)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestGetUserAdminRoles_WithNoErrors_ReturnsAdminRoles() {
+	//arrange
+	username := "username"
+	roles := []*models.AdminRole{models.CreateAdminRole("role", "")}
+
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetAdminRolesByUsername", mock.Anything).Return(roles, nil)
+
+	//act
+	resultRoles, cerr := suite.AdminRoleController.GetUserAdminRoles(&suite.CRUDMock, username)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Equal(roles, resultRoles)
+
+	suite.CRUDMock.AssertCalled(suite.T(), "GetUserByUsername", username)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetAdminRolesByUsername", username)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestUpdateUserAdminRoles_WhereUserNotFound_ReturnsClientError() {
+	//arrange
+	username := "username"
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
+
+	//act
+	roles, cerr := suite.AdminRoleController.UpdateUserAdminRoles(&suite.CRUDMock, username, []string{"role"})
+
+	//assert
+	suite.Nil(roles)
+	suite.CustomClientError(cerr, "user with username", username, "not found")
+}
+
+func (suite *AdminRoleControllerTestSuite) TestUpdateUserAdminRoles_WithDuplicateNames_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetAdminRoleByName", mock.Anything).Return(models.CreateAdminRole("role", "", models.Permissions...), nil)
+
+	//act
+	roles, cerr := suite.AdminRoleController.UpdateUserAdminRoles(&suite.CRUDMock, "username", []string{"role", "role"})
+
+	//assert
+	suite.Nil(roles)
+	suite.CustomClientError(cerr, "admin roles", "cannot contain duplicates")
+}
+
+func (suite *AdminRoleControllerTestSuite) TestUpdateUserAdminRoles_WithErrorGettingAdminRole_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetAdminRoleByName", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	roles, cerr := suite.AdminRoleController.UpdateUserAdminRoles(&suite.CRUDMock, "username", []string{"role"})
+
+	//assert
+	suite.Nil(roles)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestUpdateUserAdminRoles_WhereAdminRoleNotFound_ReturnsClientError() {
+	//arrange
+	name := "role"
+
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetAdminRoleByName", mock.Anything).Return(nil, nil)
+
+	//act
+	roles, cerr := suite.AdminRoleController.UpdateUserAdminRoles(&suite.CRUDMock, "username", []string{name})
+
+	//assert
+	suite.Nil(roles)
+	suite.CustomClientError(cerr, "admin role", name, "not found")
+	suite.CRUDMock.AssertCalled(suite.T(), "GetAdminRoleByName", name)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestUpdateUserAdminRoles_WithErrorSettingUserAdminRoles_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetAdminRoleByName", mock.Anything).Return(models.CreateAdminRole("role", "", models.Permissions...), nil)
+	suite.CRUDMock.On("SetUserAdminRoles", mock.Anything, mock.Anything).Return(errors.New(""))
+
+	//act
+	roles, cerr := suite.AdminRoleController.UpdateUserAdminRoles(&suite.CRUDMock, "username", []string{"role"})
+
+	//assert
+	suite.Nil(roles)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestUpdateUserAdminRoles_WithNoErrors_ReturnsNewAdminRoles() {
+	//arrange
+	username := "username"
+	names := []string{"role1", "role2"}
+	roles := []*models.AdminRole{models.CreateAdminRole("role1", ""), models.CreateAdminRole("role2", "")}
+
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetAdminRoleByName", mock.Anything).Return(models.CreateAdminRole("role", "", models.Permissions...), nil)
+	suite.CRUDMock.On("SetUserAdminRoles", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("GetAdminRolesByUsername", mock.Anything).Return(roles, nil)
+
+	//act
+	resultRoles, cerr := suite.AdminRoleController.UpdateUserAdminRoles(&suite.CRUDMock, username, names)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Equal(roles, resultRoles)
+	suite.CRUDMock.AssertCalled(suite.T(), "SetUserAdminRoles", username, names)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestCreateBuiltInAdminRoles_WithErrorGettingAdminRole_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetAdminRoleByName", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.AdminRoleController.CreateBuiltInAdminRoles(&suite.CRUDMock)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestCreateBuiltInAdminRoles_WithErrorCreatingAdminRole_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetAdminRoleByName", mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("CreateAdminRole", mock.Anything).Return(errors.New(""))
+
+	//act
+	cerr := suite.AdminRoleController.CreateBuiltInAdminRoles(&suite.CRUDMock)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestCreateBuiltInAdminRoles_CreatesOnlyTheMissingAdminRoles() {
+	//arrange
+	suite.CRUDMock.On("GetAdminRoleByName", controllers.AdminRoleAdmin).Return(models.CreateAdminRole("role", "", models.Permissions...), nil)
+	suite.CRUDMock.On("GetAdminRoleByName", mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("CreateAdminRole", mock.Anything).Return(nil)
+
+	//act
+	cerr := suite.AdminRoleController.CreateBuiltInAdminRoles(&suite.CRUDMock)
+
+	//assert
+	suite.CustomNoError(cerr)
+
+	suite.CRUDMock.AssertNumberOfCalls(suite.T(), "CreateAdminRole", 4)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "CreateAdminRole", mock.MatchedBy(func(role *models.AdminRole) bool {
+		return role.Name == controllers.AdminRoleAdmin
+	}))
+	suite.CRUDMock.AssertCalled(suite.T(), "CreateAdminRole", mock.MatchedBy(func(role *models.AdminRole) bool {
+		return role.Name == controllers.AdminRoleAuditor && len(role.Permissions) == 1 && role.Permissions[0] == models.PermissionAuditRead
+	}))
+}
+
+func (suite *AdminRoleControllerTestSuite) TestCreateBuiltInAdminRoles_WithErrorUpdatingAdminRole_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetAdminRoleByName", mock.Anything).Return(&models.AdminRole{}, nil)
+	suite.CRUDMock.On("UpdateAdminRole", mock.Anything).Return(false, errors.New(""))
+
+	//act
+	cerr := suite.AdminRoleController.CreateBuiltInAdminRoles(&suite.CRUDMock)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestCreateBuiltInAdminRoles_AddsMissingPermissionsToExistingAdminRoles() {
+	//arrange
+	role := models.CreateAdminRole(controllers.AdminRoleClientAdmin, "", models.PermissionClientsRead, models.PermissionClientsWrite)
+
+	suite.CRUDMock.On("GetAdminRoleByName", controllers.AdminRoleClientAdmin).Return(role, nil)
+	suite.CRUDMock.On("GetAdminRoleByName", mock.Anything).Return(models.CreateAdminRole("role", "", models.Permissions...), nil)
+	suite.CRUDMock.On("UpdateAdminRole", mock.Anything).Return(true, nil)
+
+	//act
+	cerr := suite.AdminRoleController.CreateBuiltInAdminRoles(&suite.CRUDMock)
+
+	//assert
+	suite.CustomNoError(cerr)
+
+	suite.CRUDMock.AssertNumberOfCalls(suite.T(), "UpdateAdminRole", 1)
+	suite.CRUDMock.AssertCalled(suite.T(), "UpdateAdminRole", role)
+	suite.Contains(role.Permissions, models.PermissionClientAdminsWrite)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "CreateAdminRole", mock.Anything)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestAssignAdminRolesByRank_WithErrorGettingUsers_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetAdminRoleByName", mock.Anything).Return(models.CreateAdminRole("role", "", models.Permissions...), nil)
+	suite.CRUDMock.On("GetUsersWithLesserRank", mock.Anything, mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.AdminRoleController.AssignAdminRolesByRank(&suite.CRUDMock)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestAssignAdminRolesByRank_WithErrorSettingUserAdminRoles_ReturnsInternalError() {
+	//arrange
+	users := []*models.User{models.CreateUser("user", 10, nil)}
+
+	suite.CRUDMock.On("GetAdminRoleByName", mock.Anything).Return(models.CreateAdminRole("role", "", models.Permissions...), nil)
+	suite.CRUDMock.On("GetUsersWithLesserRank", mock.Anything, mock.Anything).Return(users, nil)
+	suite.CRUDMock.On("GetAdminRolesByUsername", mock.Anything).Return([]*models.AdminRole{}, nil)
+	suite.CRUDMock.On("SetUserAdminRoles", mock.Anything, mock.Anything).Return(errors.New(""))
+
+	//act
+	cerr := suite.AdminRoleController.AssignAdminRolesByRank(&suite.CRUDMock)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AdminRoleControllerTestSuite) TestAssignAdminRolesByRank_GivesUsersTheAdminRolesForTheirRank() {
+	//arrange
+	users := []*models.User{
+		models.CreateUser("max", 10, nil),
+		models.CreateUser("auditor", 6, nil),
+		models.CreateUser("client", 5, nil),
+		models.CreateUser("user", 1, nil),
+		models.CreateUser("none", 0, nil),
+		models.CreateUser("existing", 1, nil),
+	}
+
+	suite.CRUDMock.On("GetAdminRoleByName", mock.Anything).Return(models.CreateAdminRole("role", "", models.Permissions...), nil)
+	suite.CRUDMock.On("GetUsersWithLesserRank", mock.Anything, mock.Anything).Return(users, nil)
+	suite.CRUDMock.On("GetAdminRolesByUsername", "existing").Return([]*models.AdminRole{models.CreateAdminRole("custom", ""), models.CreateAdminRole(controllers.AdminRoleUserAdmin, "")}, nil)
+	suite.CRUDMock.On("GetAdminRolesByUsername", mock.Anything).Return([]*models.AdminRole{}, nil)
+	suite.CRUDMock.On("SetUserAdminRoles", mock.Anything, mock.Anything).Return(nil)
+
+	//act
+	cerr := suite.AdminRoleController.AssignAdminRolesByRank(&suite.CRUDMock)
+
+	//assert
+	suite.CustomNoError(cerr)
+
+	suite.CRUDMock.AssertCalled(suite.T(), "GetUsersWithLesserRank", math.MaxInt16, models.PageQuery{})
+	suite.CRUDMock.AssertCalled(suite.T(), "SetUserAdminRoles", "max", []string{
+		controllers.AdminRoleAdmin, controllers.AdminRoleUserAdmin, controllers.AdminRoleClientAdmin, controllers.AdminRoleLockoutAdmin, controllers.AdminRoleAuditor,
+	})
+	suite.CRUDMock.AssertCalled(suite.T(), "SetUserAdminRoles", "auditor", []string{
+		controllers.AdminRoleUserAdmin, controllers.AdminRoleClientAdmin, controllers.AdminRoleAuditor,
+	})
+	suite.CRUDMock.AssertCalled(suite.T(), "SetUserAdminRoles", "client", []string{
+		controllers.AdminRoleUserAdmin, controllers.AdminRoleClientAdmin,
+	})
+	suite.CRUDMock.AssertCalled(suite.T(), "SetUserAdminRoles", "user", []string{controllers.AdminRoleUserAdmin})
+
+	//users with nothing new to add are left alone
+	suite.CRUDMock.AssertNotCalled(suite.T(), "SetUserAdminRoles", "none", mock.Anything)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "SetUserAdminRoles", "existing", mock.Anything)
+}
+
+func TestAdminRoleControllerTestSuite(t *testing.T) {
+	suite.Run(t, &AdminRoleControllerTestSuite{})
+}
@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+)
+
+const (
+	// DefaultAuditEventsLimit is the number of audit events returned when no limit is provided.
+	DefaultAuditEventsLimit = 50
+
+	// MaxAuditEventsLimit is the max number of audit events that can be returned at once.
+	MaxAuditEventsLimit = 200
+)
+
+type CoreAuditController struct{}
+
+func (CoreAuditController) CreateAuditEvent(CRUD AuditControllerCRUD, event *models.AuditEvent) common.CustomError {
+	//save the event
+	err := CRUD.SaveAuditEvent(event)
+	if err != nil {
+		log.Println(common.ChainError("error saving audit event", err))
+		return common.InternalError()
+	}
+
+	return common.NoError()
+}
+
+func (CoreAuditController) GetAuditEvents(CRUD AuditControllerCRUD, filter models.AuditEventFilter) ([]*models.AuditEvent, common.CustomError) {
+	//validate the page
+	if filter.Limit < 0 || filter.Limit > MaxAuditEventsLimit {
+		return nil, common.ClientError(fmt.Sprintf("limit must be between 0 and %d", MaxAuditEventsLimit))
+	}
+	if filter.Offset < 0 {
+		return nil, common.ClientError("offset cannot be negative")
+	}
+	if filter.Limit == 0 {
+		filter.Limit = DefaultAuditEventsLimit
+	}
+
+	//validate the time range
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && !filter.Since.Before(filter.Until) {
+		return nil, common.ClientError("since must be before until")
+	}
+
+	//get the events
+	events, err := CRUD.GetAuditEvents(filter)
+	if err != nil {
+		log.Println(common.ChainError("error getting audit events", err))
+		return nil, common.InternalError()
+	}
+
+	return events, common.NoError()
+}
@@ -0,0 +1,129 @@
+package controllers_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/controllers"
+	"github.com/mhogar/amber/models"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditControllerTestSuite struct {
+	ControllerTestSuite
+	AuditController controllers.CoreAuditController
+}
+
+func (suite *AuditControllerTestSuite) TestCreateAuditEvent_WithErrorSavingAuditEvent_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything).Return(errors.New(""))
+
+	//act
+	cerr := suite.AuditController.CreateAuditEvent(&suite.CRUDMock, models.CreateNewAuditEvent("username", models.AuditActionCreateUser, "target", "127.0.0.1"))
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AuditControllerTestSuite) TestCreateAuditEvent_WithNoErrors_SavesAuditEvent() {
+	//arrange
+	event := models.CreateNewAuditEvent("username", models.AuditActionCreateUser, "target", "127.0.0.1")
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything).Return(nil)
+
+	//act
+	cerr := suite.AuditController.CreateAuditEvent(&suite.CRUDMock, event)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "SaveAuditEvent", event)
+}
+
+func (suite *AuditControllerTestSuite) TestGetAuditEvents_InvalidFilterTestCases() {
+	var filter models.AuditEventFilter
+	var expectedErrorSubStrings []string
+
+	testCase := func() {
+		//act
+		events, cerr := suite.AuditController.GetAuditEvents(&suite.CRUDMock, filter)
+
+		//assert
+		suite.Nil(events)
+		suite.CustomClientError(cerr, expectedErrorSubStrings...)
+		suite.CRUDMock.AssertNotCalled(suite.T(), "GetAuditEvents", mock.Anything)
+	}
+
+	filter = models.AuditEventFilter{Limit: -1}
+	expectedErrorSubStrings = []string{"limit", "between"}
+	suite.Run("NegativeLimit", testCase)
+
+	filter = models.AuditEventFilter{Limit: controllers.MaxAuditEventsLimit + 1}
+	expectedErrorSubStrings = []string{"limit", "between"}
+	suite.Run("LimitGreaterThanMax", testCase)
+
+	filter = models.AuditEventFilter{Offset: -1}
+	expectedErrorSubStrings = []string{"offset", "negative"}
+	suite.Run("NegativeOffset", testCase)
+
+	now := time.Now()
+	filter = models.AuditEventFilter{Since: now, Until: now}
+	expectedErrorSubStrings = []string{"since", "before", "until"}
+	suite.Run("EmptyTimeRange", testCase)
+}
+
+func (suite *AuditControllerTestSuite) TestGetAuditEvents_WithErrorGettingAuditEvents_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetAuditEvents", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	events, cerr := suite.AuditController.GetAuditEvents(&suite.CRUDMock, models.AuditEventFilter{})
+
+	//assert
+	suite.Nil(events)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AuditControllerTestSuite) TestGetAuditEvents_WithNoLimit_UsesDefaultLimit() {
+	//arrange
+	suite.CRUDMock.On("GetAuditEvents", mock.Anything).Return([]*models.AuditEvent{}, nil)
+
+	//act
+	_, cerr := suite.AuditController.GetAuditEvents(&suite.CRUDMock, models.AuditEventFilter{Actor: "username"})
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetAuditEvents", models.AuditEventFilter{
+		Actor: "username",
+		Limit: controllers.DefaultAuditEventsLimit,
+	})
+}
+
+func (suite *AuditControllerTestSuite) TestGetAuditEvents_WithNoErrors_ReturnsAuditEvents() {
+	//arrange
+	filter := models.AuditEventFilter{
+		Action: models.AuditActionLogin,
+		Since:  time.Now().Add(-time.Hour),
+		Until:  time.Now(),
+		Limit:  10,
+		Offset: 20,
+	}
+
+	events := []*models.AuditEvent{
+		models.CreateNewAuditEvent("username", models.AuditActionLogin, "username", "127.0.0.1"),
+	}
+	suite.CRUDMock.On("GetAuditEvents", mock.Anything).Return(events, nil)
+
+	//act
+	results, cerr := suite.AuditController.GetAuditEvents(&suite.CRUDMock, filter)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Equal(events, results)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetAuditEvents", filter)
+}
+
+func TestAuditControllerTestSuite(t *testing.T) {
+	suite.Run(t, &AuditControllerTestSuite{})
+}
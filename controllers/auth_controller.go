@@ -1,15 +1,61 @@
 package controllers
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	"log"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
 	passwordhelpers "github.com/mhogar/amber/controllers/password_helpers"
+	totphelpers "github.com/mhogar/amber/controllers/totp_helpers"
 	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+// invalidUserCredentialsMessage is the error message returned when a user's username or password is incorrect.
+const invalidUserCredentialsMessage = "invalid username and/or password"
+
+// disabledUserMessage is the error message returned when a disabled user's credentials are correct.
+const disabledUserMessage = "user is disabled"
+
+const (
+	// ChallengeStepTwoFactor is the step for challenges completed with a TOTP or recovery code.
+	ChallengeStepTwoFactor = "two_factor"
+
+	// ChallengeStepChangePassword is the step for challenges completed with a new password, after the user's password has expired.
+	ChallengeStepChangePassword = "change_password"
 )
 
 type CoreAuthController struct {
-	PasswordHasher passwordhelpers.PasswordHasher
+	PasswordHasher            passwordhelpers.PasswordHasher
+	PasswordCriteriaValidator passwordhelpers.PasswordCriteriaValidator
+	TOTPGenerator             totphelpers.TOTPGenerator
+	Encryptor                 totphelpers.Encryptor
+}
+
+// challengePayload is the payload of a challenge.
+// It is encrypted before being sent to the user so it can't be forged or extended.
+type challengePayload struct {
+	Step      string `json:"step"`
+	Username  string `json:"username"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+// GetChallengeStep gets the step the user needs to complete the challenge with, or an empty string if the challenge is malformed.
+// The step is not verified until the challenge is used.
+func GetChallengeStep(challenge string) string {
+	parts := strings.SplitN(challenge, ".", 2)
+	if len(parts) != 2 {
+		return ""
+	}
+
+	return parts[0]
 }
 
 func (c CoreAuthController) AuthenticateUserWithPassword(CRUD AuthControllerCRUD, username string, password string) (*models.User, common.CustomError) {
@@ -22,15 +68,434 @@ func (c CoreAuthController) AuthenticateUserWithPassword(CRUD AuthControllerCRUD
 
 	//check if user was found
 	if user == nil {
-		return nil, common.ClientError("invalid username and/or password")
+		return nil, common.ClientError(invalidUserCredentialsMessage)
 	}
 
 	//validate the password
 	err = c.PasswordHasher.ComparePasswords(user.PasswordHash, password)
 	if err != nil {
 		log.Println(common.ChainError("error comparing password hashes", err))
-		return nil, common.ClientError("invalid username and/or password")
+		return nil, common.ClientError(invalidUserCredentialsMessage)
+	}
+
+	//check the user is enabled (only once the password is known to be correct, so it doesn't reveal the user exists)
+	if !user.Enabled {
+		return nil, common.ClientError(disabledUserMessage)
+	}
+
+	return user, common.NoError()
+}
+
+func (c CoreAuthController) AuthenticateClientWithSecret(CRUD ClientAuthControllerCRUD, clientUID uuid.UUID, secret string) (*models.Client, common.CustomError) {
+	//get the client
+	client, err := CRUD.GetClientByUID(clientUID)
+	if err != nil {
+		log.Println(common.ChainError("error getting client by uid", err))
+		return nil, common.InternalError()
+	}
+
+	//check if client was found and has a secret
+	if client == nil || !client.HasSecret() {
+		return nil, common.ClientError("invalid client id and/or secret")
+	}
+
+	//validate the secret
+	cerr := c.compareClientSecret(client, secret)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
+	}
+
+	return client, common.NoError()
+}
+
+func (c CoreAuthController) AuthenticateClient(CRUD ClientAuthControllerCRUD, clientUID uuid.UUID, secret string) common.CustomError {
+	//get the client
+	client, err := CRUD.GetClientByUID(clientUID)
+	if err != nil {
+		log.Println(common.ChainError("error getting client by uid", err))
+		return common.InternalError()
+	}
+
+	//check if client was found
+	if client == nil {
+		return common.ClientError("invalid client id and/or secret")
+	}
+
+	//clients without a secret are public, so there is nothing to authenticate them with
+	if !client.HasSecret() {
+		return common.NoError()
+	}
+
+	return c.compareClientSecret(client, secret)
+}
+
+func (c CoreAuthController) compareClientSecret(client *models.Client, secret string) common.CustomError {
+	err := c.PasswordHasher.ComparePasswords(client.SecretHash, secret)
+	if err != nil {
+		log.Println(common.ChainError("error comparing secret hashes", err))
+		return common.ClientError("invalid client id and/or secret")
+	}
+
+	return common.NoError()
+}
+
+func (c CoreAuthController) AuthenticateUser(CRUD UserAuthControllerCRUD, creds UserCredentials) (*models.User, string, common.CustomError) {
+	username := creds.Username
+	step := ""
+
+	//the challenge determines the username and step for the following steps
+	if creds.Challenge != "" {
+		payload, cerr := c.parseChallenge(creds.Challenge)
+		if cerr.Type != common.ErrorTypeNone {
+			return nil, "", cerr
+		}
+		username = payload.Username
+		step = payload.Step
+	}
+
+	//changing an expired password is not a login attempt, so it isn't throttled and only the completed change is audited
+	if step == ChallengeStepChangePassword {
+		user, cerr := c.changeExpiredPassword(CRUD, username, creds.NewPassword)
+		if cerr.Type != common.ErrorTypeNone {
+			return nil, "", cerr
+		}
+
+		for _, action := range []string{models.AuditActionUpdateUserPassword, models.AuditActionLogin} {
+			cerr = c.saveLoginAuditEvent(CRUD, username, action, creds.IPAddress)
+			if cerr.Type != common.ErrorTypeNone {
+				return nil, "", cerr
+			}
+		}
+
+		return user, "", common.NoError()
+	}
+
+	user, challenge, cerr := c.authenticateThrottledUser(CRUD, username, creds)
+
+	//audit the completed or failed login
+	action := ""
+	if cerr.Type == common.ErrorTypeClient {
+		action = models.AuditActionLoginFailed
+	} else if user != nil {
+		action = models.AuditActionLogin
+	}
+
+	if action != "" {
+		serr := c.saveLoginAuditEvent(CRUD, username, action, creds.IPAddress)
+		if serr.Type != common.ErrorTypeNone {
+			return nil, "", serr
+		}
+	}
+
+	return user, challenge, cerr
+}
+
+func (CoreAuthController) saveLoginAuditEvent(CRUD UserAuthControllerCRUD, username string, action string, ipAddress string) common.CustomError {
+	err := CRUD.SaveAuditEvent(models.CreateNewAuditEvent(username, action, username, ipAddress))
+	if err != nil {
+		log.Println(common.ChainError("error saving audit event", err))
+		return common.InternalError()
+	}
+
+	return common.NoError()
+}
+
+func (c CoreAuthController) authenticateThrottledUser(CRUD UserAuthControllerCRUD, username string, creds UserCredentials) (*models.User, string, common.CustomError) {
+	//get the throttles for the username and ip address
+	throttles, cerr := c.getLoginThrottles(CRUD, username, creds.IPAddress)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, "", cerr
+	}
+
+	//refuse the attempt without checking the credentials if either is locked
+	now := time.Now()
+	for _, throttle := range throttles {
+		if throttle.IsLocked(now) {
+			seconds := int64(math.Ceil(throttle.LockedUntil.Sub(now).Seconds()))
+			return nil, "", common.ClientError(fmt.Sprintf("too many failed login attempts, try again in %d seconds", seconds))
+		}
+	}
+
+	//authenticate the user
+	user, challenge, cerr := c.authenticateUser(CRUD, username, creds)
+	if cerr.Type == common.ErrorTypeClient {
+		rerr := c.recordFailedLogin(CRUD, throttles, now)
+		if rerr.Type != common.ErrorTypeNone {
+			return nil, "", rerr
+		}
+		return nil, "", cerr
+	}
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, "", cerr
+	}
+
+	//a completed login clears the failures for the username, but not the ip address
+	if user != nil && config.GetLockoutConfig().MaxUserAttempts > 0 {
+		_, err := CRUD.DeleteLoginThrottle(models.LoginThrottleTypeUsername, user.Username)
+		if err != nil {
+			log.Println(common.ChainError("error deleting login throttle", err))
+			return nil, "", common.InternalError()
+		}
+	}
+
+	return user, challenge, common.NoError()
+}
+
+func (c CoreAuthController) VerifyTwoFactorCode(CRUD TwoFactorAuthControllerCRUD, user *models.User, code string) common.CustomError {
+	//decrypt the user's secret
+	secret, err := c.Encryptor.Decrypt(user.TOTPSecret)
+	if err != nil {
+		log.Println(common.ChainError("error decrypting totp secret", err))
+		return common.InternalError()
+	}
+
+	//check the code against the secret
+	step, valid := c.TOTPGenerator.ValidateCode(secret, code)
+	if valid {
+		//only accept steps after the last accepted one, so each code can only be used once
+		res, err := CRUD.UpdateUserTOTPLastTimeStep(user.Username, step)
+		if err != nil {
+			log.Println(common.ChainError("error updating user totp last time step", err))
+			return common.InternalError()
+		}
+
+		if !res {
+			return common.ClientError("two-factor code has already been used")
+		}
+		return common.NoError()
+	}
+
+	//otherwise try to use it as a recovery code
+	res, err := CRUD.DeleteRecoveryCode(user.Username, totphelpers.HashRecoveryCode(code))
+	if err != nil {
+		log.Println(common.ChainError("error deleting recovery code", err))
+		return common.InternalError()
+	}
+
+	if !res {
+		return common.ClientError("invalid two-factor code")
+	}
+
+	return common.NoError()
+}
+
+func (c CoreAuthController) authenticateUser(CRUD UserAuthControllerCRUD, username string, creds UserCredentials) (*models.User, string, common.CustomError) {
+	var user *models.User
+	var cerr common.CustomError
+
+	if creds.Challenge != "" {
+		//complete the second step if a challenge was provided
+		user, cerr = c.authenticateUserWithChallenge(CRUD, username, creds.Code)
+		if cerr.Type != common.ErrorTypeNone {
+			return nil, "", cerr
+		}
+	} else {
+		//authenticate the user
+		user, cerr = c.AuthenticateUserWithPassword(CRUD, username, creds.Password)
+		if cerr.Type != common.ErrorTypeNone {
+			return nil, "", cerr
+		}
+
+		//users with two-factor authentication need to complete the second step
+		if user.TOTPEnabled {
+			challenge, cerr := c.createChallenge(ChallengeStepTwoFactor, user.Username)
+			if cerr.Type != common.ErrorTypeNone {
+				return nil, "", cerr
+			}
+			return nil, challenge, common.NoError()
+		}
+	}
+
+	//users with an expired password need to change it before they are logged in
+	maxAge := time.Duration(config.GetPasswordCriteriaConfig().MaxAge) * time.Second
+	if user.IsPasswordExpired(time.Now(), maxAge) {
+		challenge, cerr := c.createChallenge(ChallengeStepChangePassword, user.Username)
+		if cerr.Type != common.ErrorTypeNone {
+			return nil, "", cerr
+		}
+		return nil, challenge, common.NoError()
+	}
+
+	return user, "", common.NoError()
+}
+
+func (c CoreAuthController) authenticateUserWithChallenge(CRUD UserAuthControllerCRUD, username string, code string) (*models.User, common.CustomError) {
+	//get the user
+	user, err := CRUD.GetUserByUsername(username)
+	if err != nil {
+		log.Println(common.ChainError("error getting user by username", err))
+		return nil, common.InternalError()
+	}
+
+	//check if user was found and still has two-factor authentication enabled
+	if user == nil || !user.TOTPEnabled {
+		return nil, common.ClientError("two-factor challenge invalid or expired")
+	}
+
+	//check the user was not disabled since the first step
+	if !user.Enabled {
+		return nil, common.ClientError(disabledUserMessage)
+	}
+
+	//verify the code
+	cerr := c.VerifyTwoFactorCode(CRUD, user, code)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
 	}
 
 	return user, common.NoError()
 }
+
+func (c CoreAuthController) changeExpiredPassword(CRUD UserAuthControllerCRUD, username string, password string) (*models.User, common.CustomError) {
+	//get the user
+	user, err := CRUD.GetUserByUsername(username)
+	if err != nil {
+		log.Println(common.ChainError("error getting user by username", err))
+		return nil, common.InternalError()
+	}
+
+	//check if user was found and their password is still expired (i.e. it wasn't reset since the challenge was created)
+	maxAge := time.Duration(config.GetPasswordCriteriaConfig().MaxAge) * time.Second
+	if user == nil || !user.IsPasswordExpired(time.Now(), maxAge) {
+		return nil, common.ClientError("change password challenge invalid or expired")
+	}
+
+	//check the user was not disabled since the first step
+	if !user.Enabled {
+		return nil, common.ClientError(disabledUserMessage)
+	}
+
+	//update the user's password
+	cerr := changeUserPassword(CRUD, c.PasswordHasher, c.PasswordCriteriaValidator, user, password)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
+	}
+
+	return user, common.NoError()
+}
+
+func (CoreAuthController) getLoginThrottles(CRUD UserAuthControllerCRUD, username string, ipAddress string) ([]*models.LoginThrottle, common.CustomError) {
+	cfg := config.GetLockoutConfig()
+
+	//only throttle the types that are enabled
+	candidates := []*models.LoginThrottle{}
+	if cfg.MaxIPAttempts > 0 {
+		candidates = append(candidates, models.CreateNewLoginThrottle(models.LoginThrottleTypeIP, ipAddress))
+	}
+	if cfg.MaxUserAttempts > 0 {
+		candidates = append(candidates, models.CreateNewLoginThrottle(models.LoginThrottleTypeUsername, username))
+	}
+
+	throttles := []*models.LoginThrottle{}
+	for _, throttle := range candidates {
+		//keys that can't be saved (e.g. an empty ip address) are not throttled
+		if throttle.Validate() != models.ValidateLoginThrottleValid {
+			continue
+		}
+
+		//use the existing throttle if there is one
+		existing, err := CRUD.GetLoginThrottle(throttle.Type, throttle.Key)
+		if err != nil {
+			log.Println(common.ChainError("error getting login throttle", err))
+			return nil, common.InternalError()
+		}
+
+		if existing != nil {
+			throttle = existing
+		}
+		throttles = append(throttles, throttle)
+	}
+
+	return throttles, common.NoError()
+}
+
+func (CoreAuthController) recordFailedLogin(CRUD UserAuthControllerCRUD, throttles []*models.LoginThrottle, now time.Time) common.CustomError {
+	cfg := config.GetLockoutConfig()
+
+	lockout := time.Duration(cfg.Duration) * time.Second
+	maxLockout := time.Duration(cfg.MaxDuration) * time.Second
+	resetAfter := time.Duration(cfg.ResetAfter) * time.Second
+
+	for _, throttle := range throttles {
+		maxAttempts := cfg.MaxUserAttempts
+		if throttle.Type == models.LoginThrottleTypeIP {
+			maxAttempts = cfg.MaxIPAttempts
+		}
+
+		throttle.RecordFailedAttempt(now, maxAttempts, lockout, maxLockout, resetAfter)
+
+		err := CRUD.SaveLoginThrottle(throttle)
+		if err != nil {
+			log.Println(common.ChainError("error saving login throttle", err))
+			return common.InternalError()
+		}
+	}
+
+	return common.NoError()
+}
+
+func (c CoreAuthController) createChallenge(step string, username string) (string, common.CustomError) {
+	lifetime := time.Duration(config.GetTwoFactorConfig().ChallengeLifetime) * time.Second
+
+	bytes, err := json.Marshal(challengePayload{
+		Step:      step,
+		Username:  username,
+		ExpiresAt: time.Now().Add(lifetime).Unix(),
+	})
+	if err != nil {
+		log.Println(common.ChainError("error marshaling challenge", err))
+		return "", common.InternalError()
+	}
+
+	//encrypt the challenge
+	ciphertext, err := c.Encryptor.Encrypt(bytes)
+	if err != nil {
+		log.Println(common.ChainError("error encrypting challenge", err))
+		return "", common.InternalError()
+	}
+
+	//prefix the step so it can be read without decrypting the challenge
+	return step + "." + base64.RawURLEncoding.EncodeToString(ciphertext), common.NoError()
+}
+
+func (c CoreAuthController) parseChallenge(challenge string) (*challengePayload, common.CustomError) {
+	step := GetChallengeStep(challenge)
+
+	invalidErr := common.ClientError("two-factor challenge invalid or expired")
+	if step == ChallengeStepChangePassword {
+		invalidErr = common.ClientError("change password challenge invalid or expired")
+	}
+
+	//decode the challenge
+	ciphertext, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(challenge, step+"."))
+	if err != nil {
+		log.Println(common.ChainError("error decoding challenge", err))
+		return nil, invalidErr
+	}
+
+	//decrypt the challenge (fails if it was tampered with)
+	bytes, err := c.Encryptor.Decrypt(ciphertext)
+	if err != nil {
+		log.Println(common.ChainError("error decrypting challenge", err))
+		return nil, invalidErr
+	}
+
+	var payload challengePayload
+	err = json.Unmarshal(bytes, &payload)
+	if err != nil {
+		log.Println(common.ChainError("error unmarshaling challenge", err))
+		return nil, invalidErr
+	}
+
+	//check the prefixed step wasn't changed
+	if step == "" || payload.Step != step {
+		return nil, invalidErr
+	}
+
+	//check the challenge hasn't expired
+	if time.Now().Unix() > payload.ExpiresAt {
+		return nil, invalidErr
+	}
+
+	return &payload, common.NoError()
+}
@@ -1,32 +1,108 @@
 package controllers_test
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/mhogar/amber/config"
 	"github.com/mhogar/amber/controllers"
+	passwordhelpers "github.com/mhogar/amber/controllers/password_helpers"
 	"github.com/mhogar/amber/controllers/password_helpers/mocks"
+	totphelpers "github.com/mhogar/amber/controllers/totp_helpers"
+	totpmocks "github.com/mhogar/amber/controllers/totp_helpers/mocks"
+	datamocks "github.com/mhogar/amber/data/mocks"
 	"github.com/mhogar/amber/models"
 
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
 type AuthControllerTestSuite struct {
 	ControllerTestSuite
-	PasswordHasherMock mocks.PasswordHasher
-	AuthController     controllers.CoreAuthController
+	PasswordHasherMock            mocks.PasswordHasher
+	PasswordCriteriaValidatorMock mocks.PasswordCriteriaValidator
+	TOTPGeneratorMock             totpmocks.TOTPGenerator
+	EncryptorMock                 totpmocks.Encryptor
+	AuthController                controllers.CoreAuthController
 }
 
 func (suite *AuthControllerTestSuite) SetupTest() {
 	suite.ControllerTestSuite.SetupTest()
 
+	viper.Set("two_factor", config.TwoFactorConfig{
+		ChallengeLifetime: 60,
+	})
+	viper.Set("lockout", config.LockoutConfig{})
+	viper.Set("password_criteria", config.PasswordCriteriaConfig{})
+
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything).Return(nil)
+
 	suite.PasswordHasherMock = mocks.PasswordHasher{}
+	suite.PasswordCriteriaValidatorMock = mocks.PasswordCriteriaValidator{}
+	suite.TOTPGeneratorMock = totpmocks.TOTPGenerator{}
+	suite.EncryptorMock = totpmocks.Encryptor{}
 	suite.AuthController = controllers.CoreAuthController{
-		PasswordHasher: &suite.PasswordHasherMock,
+		PasswordHasher:            &suite.PasswordHasherMock,
+		PasswordCriteriaValidator: &suite.PasswordCriteriaValidatorMock,
+		TOTPGenerator:             &suite.TOTPGeneratorMock,
+		Encryptor:                 &suite.EncryptorMock,
 	}
 }
 
+// identity makes the encryptor mock pass data through unchanged, so challenges can round trip.
+func identity(b []byte) []byte {
+	return b
+}
+
+func (suite *AuthControllerTestSuite) createTwoFactorUser() *models.User {
+	user := models.CreateUser("username", 0, []byte("password"))
+	user.TOTPSecret = []byte("secret")
+	user.TOTPEnabled = true
+
+	return user
+}
+
+// createChallenge creates a challenge the way the controller does, minus the encryption since the encryptor mock passes data through unchanged.
+func (suite *AuthControllerTestSuite) createChallenge(step string, username string, expiresAt time.Time) string {
+	bytes, err := json.Marshal(map[string]interface{}{
+		"step":       step,
+		"username":   username,
+		"expires_at": expiresAt.Unix(),
+	})
+	suite.Require().NoError(err)
+
+	return step + "." + base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+// createExpiredPasswordUser creates a user whose password has expired under the given max age.
+func (suite *AuthControllerTestSuite) createExpiredPasswordUser(maxAge int64) *models.User {
+	viper.Set("password_criteria", config.PasswordCriteriaConfig{
+		MaxAge: maxAge,
+	})
+
+	user := models.CreateUser("username", 0, []byte("password"))
+	user.PasswordUpdatedAt = time.Now().Add(-time.Duration(maxAge+60) * time.Second)
+
+	return user
+}
+
+// enableLockouts turns on lockouts for both usernames and ip addresses.
+func (suite *AuthControllerTestSuite) enableLockouts() {
+	viper.Set("lockout", config.LockoutConfig{
+		MaxUserAttempts: 3,
+		MaxIPAttempts:   10,
+		Duration:        60,
+		MaxDuration:     3600,
+		ResetAfter:      3600,
+	})
+}
+
 func (suite *AuthControllerTestSuite) TestAuthenticateUserWithPassword_WithErrorGettingUserByUsername_ReturnsInternalError() {
 	//arrange
 	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, errors.New(""))
@@ -64,6 +140,22 @@ func (suite *AuthControllerTestSuite) TestAuthenticateUserWithPassword_WherePass
 	suite.CustomClientError(cerr, "invalid", "username", "password")
 }
 
+func (suite *AuthControllerTestSuite) TestAuthenticateUserWithPassword_WhereUserIsDisabled_ReturnsClientError() {
+	//arrange
+	existingUser := models.CreateUser("username", 0, nil)
+	existingUser.Enabled = false
+
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(existingUser, nil)
+	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
+
+	//act
+	user, cerr := suite.AuthController.AuthenticateUserWithPassword(&suite.CRUDMock, existingUser.Username, "password")
+
+	//assert
+	suite.Nil(user)
+	suite.CustomClientError(cerr, "user is disabled")
+}
+
 func (suite *AuthControllerTestSuite) TestAuthenticateUserWithPassword_WithNoErrors_ReturnsNoError() {
 	//arrange
 	password := "password"
@@ -83,6 +175,886 @@ func (suite *AuthControllerTestSuite) TestAuthenticateUserWithPassword_WithNoErr
 	suite.PasswordHasherMock.AssertCalled(suite.T(), "ComparePasswords", existingUser.PasswordHash, password)
 }
 
+func (suite *AuthControllerTestSuite) TestAuthenticateClientWithSecret_WithErrorGettingClientByUID_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	client, cerr := suite.AuthController.AuthenticateClientWithSecret(&suite.CRUDMock, uuid.New(), "secret")
+
+	//assert
+	suite.Nil(client)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateClientWithSecret_WhereClientIsNotFound_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(nil, nil)
+
+	//act
+	client, cerr := suite.AuthController.AuthenticateClientWithSecret(&suite.CRUDMock, uuid.New(), "secret")
+
+	//assert
+	suite.Nil(client)
+	suite.CustomClientError(cerr, "invalid", "client id", "secret")
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateClientWithSecret_WhereClientHasNoSecret_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+
+	//act
+	client, cerr := suite.AuthController.AuthenticateClientWithSecret(&suite.CRUDMock, uuid.New(), "secret")
+
+	//assert
+	suite.Nil(client)
+	suite.CustomClientError(cerr, "invalid", "client id", "secret")
+	suite.PasswordHasherMock.AssertNotCalled(suite.T(), "ComparePasswords", mock.Anything, mock.Anything)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateClientWithSecret_WhereSecretDoesNotMatch_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{SecretHash: []byte("hash")}, nil)
+	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(errors.New(""))
+
+	//act
+	client, cerr := suite.AuthController.AuthenticateClientWithSecret(&suite.CRUDMock, uuid.New(), "secret")
+
+	//assert
+	suite.Nil(client)
+	suite.CustomClientError(cerr, "invalid", "client id", "secret")
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateClientWithSecret_WithNoErrors_ReturnsNoError() {
+	//arrange
+	secret := "secret"
+	existingClient := models.CreateNewClient("name", "redirect.com", 0, "key.pem")
+	existingClient.SecretHash = []byte("hash")
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(existingClient, nil)
+	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
+
+	//act
+	client, cerr := suite.AuthController.AuthenticateClientWithSecret(&suite.CRUDMock, existingClient.UID, secret)
+
+	//assert
+	suite.Equal(existingClient, client)
+	suite.CustomNoError(cerr)
+
+	suite.CRUDMock.AssertCalled(suite.T(), "GetClientByUID", existingClient.UID)
+	suite.PasswordHasherMock.AssertCalled(suite.T(), "ComparePasswords", existingClient.SecretHash, secret)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateClient_WithErrorGettingClientByUID_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.AuthController.AuthenticateClient(&suite.CRUDMock, uuid.New(), "secret")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateClient_WhereClientIsNotFound_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(nil, nil)
+
+	//act
+	cerr := suite.AuthController.AuthenticateClient(&suite.CRUDMock, uuid.New(), "secret")
+
+	//assert
+	suite.CustomClientError(cerr, "invalid", "client id", "secret")
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateClient_WhereClientHasNoSecret_ReturnsNoError() {
+	//arrange
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+
+	//act
+	cerr := suite.AuthController.AuthenticateClient(&suite.CRUDMock, uuid.New(), "")
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.PasswordHasherMock.AssertNotCalled(suite.T(), "ComparePasswords", mock.Anything, mock.Anything)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateClient_WhereSecretDoesNotMatch_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{SecretHash: []byte("hash")}, nil)
+	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(errors.New(""))
+
+	//act
+	cerr := suite.AuthController.AuthenticateClient(&suite.CRUDMock, uuid.New(), "")
+
+	//assert
+	suite.CustomClientError(cerr, "invalid", "client id", "secret")
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateClient_WithNoErrors_ReturnsNoError() {
+	//arrange
+	secret := "secret"
+	existingClient := models.CreateNewClient("name", "redirect.com", 0, "key.pem")
+	existingClient.SecretHash = []byte("hash")
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(existingClient, nil)
+	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
+
+	//act
+	cerr := suite.AuthController.AuthenticateClient(&suite.CRUDMock, existingClient.UID, secret)
+
+	//assert
+	suite.CustomNoError(cerr)
+
+	suite.CRUDMock.AssertCalled(suite.T(), "GetClientByUID", existingClient.UID)
+	suite.PasswordHasherMock.AssertCalled(suite.T(), "ComparePasswords", existingClient.SecretHash, secret)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithErrorAuthenticatingUserWithPassword_ReturnsError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
+
+	//act
+	user, challenge, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Username: "username", Password: "password"})
+
+	//assert
+	suite.Nil(user)
+	suite.Empty(challenge)
+	suite.CustomClientError(cerr, "invalid", "username", "password")
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WhereUserDoesNotHaveTwoFactorEnabled_ReturnsUser() {
+	//arrange
+	existingUser := models.CreateUser("username", 0, []byte("password"))
+
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(existingUser, nil)
+	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
+
+	//act
+	user, challenge, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Username: existingUser.Username, Password: "password"})
+
+	//assert
+	suite.Equal(existingUser, user)
+	suite.Empty(challenge)
+	suite.CustomNoError(cerr)
+
+	suite.EncryptorMock.AssertNotCalled(suite.T(), "Encrypt", mock.Anything)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithErrorEncryptingChallenge_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createTwoFactorUser(), nil)
+	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
+	suite.EncryptorMock.On("Encrypt", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	user, challenge, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Username: "username", Password: "password"})
+
+	//assert
+	suite.Nil(user)
+	suite.Empty(challenge)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WhereUserHasTwoFactorEnabled_ReturnsChallenge() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createTwoFactorUser(), nil)
+	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
+	suite.EncryptorMock.On("Encrypt", mock.Anything).Return([]byte("ciphertext"), nil)
+
+	//act
+	user, challenge, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Username: "username", Password: "password"})
+
+	//assert
+	suite.Nil(user)
+	suite.Equal(controllers.ChallengeStepTwoFactor+"."+base64.RawURLEncoding.EncodeToString([]byte("ciphertext")), challenge)
+	suite.CustomNoError(cerr)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithInvalidChallenge_ReturnsClientError() {
+	var challenge string
+
+	testCase := func() {
+		//act
+		user, resultChallenge, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Challenge: challenge, Code: "code"})
+
+		//assert
+		suite.Nil(user)
+		suite.Empty(resultChallenge)
+		suite.CustomClientError(cerr, "two-factor challenge invalid or expired")
+	}
+
+	suite.EncryptorMock.On("Decrypt", []byte("tampered")).Return(nil, errors.New(""))
+	suite.EncryptorMock.On("Decrypt", []byte("not json")).Return([]byte("not json"), nil)
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return(identity, nil)
+
+	challenge = "not base64!"
+	suite.Run("NotBase64", testCase)
+
+	challenge = controllers.ChallengeStepTwoFactor + "." + base64.RawURLEncoding.EncodeToString([]byte("tampered"))
+	suite.Run("CannotBeDecrypted", testCase)
+
+	challenge = controllers.ChallengeStepTwoFactor + "." + base64.RawURLEncoding.EncodeToString([]byte("not json"))
+	suite.Run("NotJSON", testCase)
+
+	challenge = "unknown" + strings.TrimPrefix(suite.createChallenge(controllers.ChallengeStepTwoFactor, "username", time.Now().Add(time.Minute)), controllers.ChallengeStepTwoFactor)
+	suite.Run("StepChanged", testCase)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithExpiredChallenge_ReturnsClientError() {
+	//arrange
+	challenge := suite.createChallenge(controllers.ChallengeStepTwoFactor, "username", time.Now().Add(-time.Minute))
+
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return(identity, nil)
+
+	//act
+	user, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Challenge: challenge, Code: "code"})
+
+	//assert
+	suite.Nil(user)
+	suite.CustomClientError(cerr, "two-factor challenge invalid or expired")
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithChallengeAndErrorGettingUser_ReturnsInternalError() {
+	//arrange
+	challenge := suite.createChallenge(controllers.ChallengeStepTwoFactor, "username", time.Now().Add(time.Minute))
+
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return(identity, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	user, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Challenge: challenge, Code: "code"})
+
+	//assert
+	suite.Nil(user)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithChallengeWhereUserNoLongerHasTwoFactorEnabled_ReturnsClientError() {
+	var existingUser *models.User
+
+	testCase := func() {
+		//arrange
+		suite.CRUDMock = datamocks.DataCRUD{}
+		suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(existingUser, nil)
+		suite.CRUDMock.On("SaveAuditEvent", mock.Anything).Return(nil)
+
+		challenge := suite.createChallenge(controllers.ChallengeStepTwoFactor, "username", time.Now().Add(time.Minute))
+
+		//act
+		user, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Challenge: challenge, Code: "code"})
+
+		//assert
+		suite.Nil(user)
+		suite.CustomClientError(cerr, "two-factor challenge invalid or expired")
+	}
+
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return(identity, nil)
+
+	existingUser = nil
+	suite.Run("UserNotFound", testCase)
+
+	existingUser = models.CreateUser("username", 0, []byte("password"))
+	suite.Run("TwoFactorDisabled", testCase)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithChallengeWhereUserIsDisabled_ReturnsClientError() {
+	//arrange
+	existingUser := models.CreateUser("username", 0, []byte("password"))
+	existingUser.TOTPEnabled = true
+	existingUser.Enabled = false
+
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return(identity, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(existingUser, nil)
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything).Return(nil)
+
+	challenge := suite.createChallenge(controllers.ChallengeStepTwoFactor, existingUser.Username, time.Now().Add(time.Minute))
+
+	//act
+	user, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Challenge: challenge, Code: "code"})
+
+	//assert
+	suite.Nil(user)
+	suite.CustomClientError(cerr, "user is disabled")
+	suite.TOTPGeneratorMock.AssertNotCalled(suite.T(), "ValidateCode", mock.Anything, mock.Anything)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithChallengeAndInvalidCode_ReturnsClientError() {
+	//arrange
+	existingUser := suite.createTwoFactorUser()
+	challenge := suite.createChallenge(controllers.ChallengeStepTwoFactor, existingUser.Username, time.Now().Add(time.Minute))
+
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return(identity, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(existingUser, nil)
+	suite.TOTPGeneratorMock.On("ValidateCode", mock.Anything, mock.Anything).Return(int64(0), false)
+	suite.CRUDMock.On("DeleteRecoveryCode", mock.Anything, mock.Anything).Return(false, nil)
+
+	//act
+	user, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Challenge: challenge, Code: "code"})
+
+	//assert
+	suite.Nil(user)
+	suite.CustomClientError(cerr, "invalid two-factor code")
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithChallengeAndValidCode_ReturnsUser() {
+	//arrange
+	existingUser := suite.createTwoFactorUser()
+	challenge := suite.createChallenge(controllers.ChallengeStepTwoFactor, existingUser.Username, time.Now().Add(time.Minute))
+	code := "123456"
+
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return(identity, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(existingUser, nil)
+	suite.TOTPGeneratorMock.On("ValidateCode", mock.Anything, mock.Anything).Return(int64(1), true)
+	suite.CRUDMock.On("UpdateUserTOTPLastTimeStep", mock.Anything, mock.Anything).Return(true, nil)
+
+	//act
+	user, challenge, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Challenge: challenge, Code: code})
+
+	//assert
+	suite.Equal(existingUser, user)
+	suite.Empty(challenge)
+	suite.CustomNoError(cerr)
+
+	suite.CRUDMock.AssertCalled(suite.T(), "GetUserByUsername", existingUser.Username)
+	suite.TOTPGeneratorMock.AssertCalled(suite.T(), "ValidateCode", existingUser.TOTPSecret, code)
+	suite.PasswordHasherMock.AssertNotCalled(suite.T(), "ComparePasswords", mock.Anything, mock.Anything)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithLockoutsDisabled_DoesNotUseLoginThrottles() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
+
+	//act
+	_, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Username: "username", Password: "password", IPAddress: "127.0.0.1"})
+
+	//assert
+	suite.CustomClientError(cerr, "invalid", "username", "password")
+
+	suite.CRUDMock.AssertNotCalled(suite.T(), "GetLoginThrottle", mock.Anything, mock.Anything)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "SaveLoginThrottle", mock.Anything)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithErrorGettingLoginThrottle_ReturnsInternalError() {
+	//arrange
+	suite.enableLockouts()
+	suite.CRUDMock.On("GetLoginThrottle", mock.Anything, mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	user, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Username: "username", Password: "password", IPAddress: "127.0.0.1"})
+
+	//assert
+	suite.Nil(user)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WhereLoginThrottleIsLocked_ReturnsClientErrorWithoutCheckingPassword() {
+	var lockedType string
+
+	testCase := func() {
+		//arrange
+		suite.CRUDMock = datamocks.DataCRUD{}
+		suite.PasswordHasherMock = mocks.PasswordHasher{}
+
+		suite.CRUDMock.On("GetLoginThrottle", lockedType, mock.Anything).Return(
+			models.CreateLoginThrottle(lockedType, "key", 3, time.Now(), time.Now().Add(time.Minute)), nil,
+		)
+		suite.CRUDMock.On("GetLoginThrottle", mock.Anything, mock.Anything).Return(nil, nil)
+		suite.CRUDMock.On("SaveAuditEvent", mock.Anything).Return(nil)
+
+		//act
+		user, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Username: "username", Password: "password", IPAddress: "127.0.0.1"})
+
+		//assert
+		suite.Nil(user)
+		suite.CustomClientError(cerr, "too many failed login attempts", "60 seconds")
+
+		suite.CRUDMock.AssertNotCalled(suite.T(), "GetUserByUsername", mock.Anything)
+		suite.PasswordHasherMock.AssertNotCalled(suite.T(), "ComparePasswords", mock.Anything, mock.Anything)
+
+		//refused attempts are still audited
+		suite.CRUDMock.AssertCalled(suite.T(), "SaveAuditEvent", mock.MatchedBy(func(event *models.AuditEvent) bool {
+			return event.Action == models.AuditActionLoginFailed
+		}))
+	}
+
+	suite.enableLockouts()
+
+	lockedType = models.LoginThrottleTypeUsername
+	suite.Run("UsernameLocked", testCase)
+
+	lockedType = models.LoginThrottleTypeIP
+	suite.Run("IPAddressLocked", testCase)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithInvalidPassword_RecordsFailedAttemptForUsernameAndIPAddress() {
+	//arrange
+	suite.enableLockouts()
+	creds := controllers.UserCredentials{Username: "username", Password: "password", IPAddress: "127.0.0.1"}
+
+	ipThrottle := models.CreateLoginThrottle(models.LoginThrottleTypeIP, creds.IPAddress, 9, time.Now(), time.Time{})
+
+	suite.CRUDMock.On("GetLoginThrottle", models.LoginThrottleTypeIP, mock.Anything).Return(ipThrottle, nil)
+	suite.CRUDMock.On("GetLoginThrottle", models.LoginThrottleTypeUsername, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("SaveLoginThrottle", mock.Anything).Return(nil)
+
+	//act
+	user, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, creds)
+
+	//assert
+	suite.Nil(user)
+	suite.CustomClientError(cerr, "invalid", "username", "password")
+
+	suite.CRUDMock.AssertCalled(suite.T(), "GetLoginThrottle", models.LoginThrottleTypeIP, creds.IPAddress)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetLoginThrottle", models.LoginThrottleTypeUsername, creds.Username)
+
+	//the ip address reached its max attempts so is now locked
+	suite.Equal(10, ipThrottle.FailedAttempts)
+	suite.True(ipThrottle.IsLocked(time.Now()))
+	suite.CRUDMock.AssertCalled(suite.T(), "SaveLoginThrottle", ipThrottle)
+
+	//the username is on its first failure
+	suite.CRUDMock.AssertCalled(suite.T(), "SaveLoginThrottle", mock.MatchedBy(func(throttle *models.LoginThrottle) bool {
+		return throttle.Type == models.LoginThrottleTypeUsername && throttle.Key == creds.Username && throttle.FailedAttempts == 1 && !throttle.IsLocked(time.Now())
+	}))
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithEmptyIPAddress_OnlyThrottlesUsername() {
+	//arrange
+	suite.enableLockouts()
+
+	suite.CRUDMock.On("GetLoginThrottle", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("SaveLoginThrottle", mock.Anything).Return(nil)
+
+	//act
+	_, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Username: "username", Password: "password"})
+
+	//assert
+	suite.CustomClientError(cerr, "invalid", "username", "password")
+
+	suite.CRUDMock.AssertNotCalled(suite.T(), "GetLoginThrottle", models.LoginThrottleTypeIP, mock.Anything)
+	suite.CRUDMock.AssertNumberOfCalls(suite.T(), "SaveLoginThrottle", 1)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithErrorSavingLoginThrottle_ReturnsInternalError() {
+	//arrange
+	suite.enableLockouts()
+
+	suite.CRUDMock.On("GetLoginThrottle", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("SaveLoginThrottle", mock.Anything).Return(errors.New(""))
+
+	//act
+	user, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Username: "username", Password: "password", IPAddress: "127.0.0.1"})
+
+	//assert
+	suite.Nil(user)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithChallengeAndInvalidCode_RecordsFailedAttemptForUsername() {
+	//arrange
+	suite.enableLockouts()
+
+	existingUser := suite.createTwoFactorUser()
+	challenge := suite.createChallenge(controllers.ChallengeStepTwoFactor, existingUser.Username, time.Now().Add(time.Minute))
+
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return(identity, nil)
+	suite.CRUDMock.On("GetLoginThrottle", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(existingUser, nil)
+	suite.TOTPGeneratorMock.On("ValidateCode", mock.Anything, mock.Anything).Return(int64(0), false)
+	suite.CRUDMock.On("DeleteRecoveryCode", mock.Anything, mock.Anything).Return(false, nil)
+	suite.CRUDMock.On("SaveLoginThrottle", mock.Anything).Return(nil)
+
+	//act
+	_, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Challenge: challenge, Code: "code", IPAddress: "127.0.0.1"})
+
+	//assert
+	suite.CustomClientError(cerr, "invalid two-factor code")
+
+	suite.CRUDMock.AssertCalled(suite.T(), "GetLoginThrottle", models.LoginThrottleTypeUsername, existingUser.Username)
+	suite.CRUDMock.AssertCalled(suite.T(), "SaveLoginThrottle", mock.MatchedBy(func(throttle *models.LoginThrottle) bool {
+		return throttle.Type == models.LoginThrottleTypeUsername && throttle.Key == existingUser.Username
+	}))
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithErrorDeletingLoginThrottle_ReturnsInternalError() {
+	//arrange
+	suite.enableLockouts()
+
+	suite.CRUDMock.On("GetLoginThrottle", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(models.CreateUser("username", 0, []byte("password")), nil)
+	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("DeleteLoginThrottle", mock.Anything, mock.Anything).Return(false, errors.New(""))
+
+	//act
+	user, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Username: "username", Password: "password", IPAddress: "127.0.0.1"})
+
+	//assert
+	suite.Nil(user)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithSuccessfulLogin_ClearsUsernameLoginThrottle() {
+	//arrange
+	suite.enableLockouts()
+	existingUser := models.CreateUser("username", 0, []byte("password"))
+
+	suite.CRUDMock.On("GetLoginThrottle", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(existingUser, nil)
+	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("DeleteLoginThrottle", mock.Anything, mock.Anything).Return(true, nil)
+
+	//act
+	user, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Username: existingUser.Username, Password: "password", IPAddress: "127.0.0.1"})
+
+	//assert
+	suite.Equal(existingUser, user)
+	suite.CustomNoError(cerr)
+
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteLoginThrottle", models.LoginThrottleTypeUsername, existingUser.Username)
+	suite.CRUDMock.AssertNumberOfCalls(suite.T(), "DeleteLoginThrottle", 1)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "SaveLoginThrottle", mock.Anything)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WherePasswordIsCorrectButTwoFactorIsPending_DoesNotClearLoginThrottle() {
+	//arrange
+	suite.enableLockouts()
+
+	suite.CRUDMock.On("GetLoginThrottle", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createTwoFactorUser(), nil)
+	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
+	suite.EncryptorMock.On("Encrypt", mock.Anything).Return([]byte("ciphertext"), nil)
+
+	//act
+	user, challenge, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Username: "username", Password: "password", IPAddress: "127.0.0.1"})
+
+	//assert
+	suite.Nil(user)
+	suite.NotEmpty(challenge)
+	suite.CustomNoError(cerr)
+
+	suite.CRUDMock.AssertNotCalled(suite.T(), "DeleteLoginThrottle", mock.Anything, mock.Anything)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithSuccessfulLogin_SavesLoginAuditEvent() {
+	//arrange
+	existingUser := models.CreateUser("username", 0, []byte("password"))
+
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(existingUser, nil)
+	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
+
+	//act
+	_, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Username: existingUser.Username, Password: "password", IPAddress: "127.0.0.1"})
+
+	//assert
+	suite.CustomNoError(cerr)
+
+	suite.CRUDMock.AssertCalled(suite.T(), "SaveAuditEvent", mock.MatchedBy(func(event *models.AuditEvent) bool {
+		return event.Actor == existingUser.Username && event.Action == models.AuditActionLogin && event.IPAddress == "127.0.0.1"
+	}))
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithInvalidPassword_SavesLoginFailedAuditEvent() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
+
+	//act
+	_, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Username: "username", Password: "password", IPAddress: "127.0.0.1"})
+
+	//assert
+	suite.CustomClientError(cerr, "invalid", "username", "password")
+
+	suite.CRUDMock.AssertCalled(suite.T(), "SaveAuditEvent", mock.MatchedBy(func(event *models.AuditEvent) bool {
+		return event.Actor == "username" && event.Action == models.AuditActionLoginFailed && event.IPAddress == "127.0.0.1"
+	}))
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WherePasswordIsCorrectButTwoFactorIsPending_DoesNotSaveAuditEvent() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createTwoFactorUser(), nil)
+	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
+	suite.EncryptorMock.On("Encrypt", mock.Anything).Return([]byte("ciphertext"), nil)
+
+	//act
+	_, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Username: "username", Password: "password"})
+
+	//assert
+	suite.CustomNoError(cerr)
+
+	suite.CRUDMock.AssertNotCalled(suite.T(), "SaveAuditEvent", mock.Anything)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithErrorSavingAuditEvent_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock = datamocks.DataCRUD{}
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(models.CreateUser("username", 0, []byte("password")), nil)
+	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything).Return(errors.New(""))
+
+	//act
+	user, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Username: "username", Password: "password"})
+
+	//assert
+	suite.Nil(user)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WherePasswordHasExpired_ReturnsChangePasswordChallenge() {
+	//arrange
+	existingUser := suite.createExpiredPasswordUser(3600)
+
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(existingUser, nil)
+	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
+	suite.EncryptorMock.On("Encrypt", mock.Anything).Return([]byte("ciphertext"), nil)
+
+	//act
+	user, challenge, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Username: existingUser.Username, Password: "password"})
+
+	//assert
+	suite.Nil(user)
+	suite.Equal(controllers.ChallengeStepChangePassword+"."+base64.RawURLEncoding.EncodeToString([]byte("ciphertext")), challenge)
+	suite.Equal(controllers.ChallengeStepChangePassword, controllers.GetChallengeStep(challenge))
+	suite.CustomNoError(cerr)
+
+	suite.CRUDMock.AssertNotCalled(suite.T(), "SaveAuditEvent", mock.Anything)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithTwoFactorChallengeWherePasswordHasExpired_ReturnsChangePasswordChallenge() {
+	//arrange
+	existingUser := suite.createExpiredPasswordUser(3600)
+	existingUser.TOTPSecret = []byte("secret")
+	existingUser.TOTPEnabled = true
+
+	challenge := suite.createChallenge(controllers.ChallengeStepTwoFactor, existingUser.Username, time.Now().Add(time.Minute))
+
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return(identity, nil)
+	suite.EncryptorMock.On("Encrypt", mock.Anything).Return([]byte("ciphertext"), nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(existingUser, nil)
+	suite.TOTPGeneratorMock.On("ValidateCode", mock.Anything, mock.Anything).Return(int64(1), true)
+	suite.CRUDMock.On("UpdateUserTOTPLastTimeStep", mock.Anything, mock.Anything).Return(true, nil)
+
+	//act
+	user, resultChallenge, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Challenge: challenge, Code: "123456"})
+
+	//assert
+	suite.Nil(user)
+	suite.Equal(controllers.ChallengeStepChangePassword, controllers.GetChallengeStep(resultChallenge))
+	suite.CustomNoError(cerr)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithExpiredChangePasswordChallenge_ReturnsClientError() {
+	//arrange
+	challenge := suite.createChallenge(controllers.ChallengeStepChangePassword, "username", time.Now().Add(-time.Minute))
+
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return(identity, nil)
+
+	//act
+	user, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Challenge: challenge, NewPassword: "new password"})
+
+	//assert
+	suite.Nil(user)
+	suite.CustomClientError(cerr, "change password challenge invalid or expired")
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithChangePasswordChallengeWherePasswordIsNoLongerExpired_ReturnsClientError() {
+	//arrange
+	existingUser := suite.createExpiredPasswordUser(3600)
+	existingUser.PasswordUpdatedAt = time.Now()
+
+	challenge := suite.createChallenge(controllers.ChallengeStepChangePassword, existingUser.Username, time.Now().Add(time.Minute))
+
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return(identity, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(existingUser, nil)
+
+	//act
+	user, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Challenge: challenge, NewPassword: "new password"})
+
+	//assert
+	suite.Nil(user)
+	suite.CustomClientError(cerr, "change password challenge invalid or expired")
+	suite.CRUDMock.AssertNotCalled(suite.T(), "UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithChangePasswordChallengeWhereUserIsDisabled_ReturnsClientError() {
+	//arrange
+	existingUser := suite.createExpiredPasswordUser(3600)
+	existingUser.Enabled = false
+
+	challenge := suite.createChallenge(controllers.ChallengeStepChangePassword, existingUser.Username, time.Now().Add(time.Minute))
+
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return(identity, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(existingUser, nil)
+
+	//act
+	user, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Challenge: challenge, NewPassword: "new password"})
+
+	//assert
+	suite.Nil(user)
+	suite.CustomClientError(cerr, "user is disabled")
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithChangePasswordChallengeWhereNewPasswordDoesNotMeetCriteria_ReturnsClientErrorWithoutRecordingFailedAttempt() {
+	//arrange
+	suite.enableLockouts()
+	existingUser := suite.createExpiredPasswordUser(3600)
+
+	challenge := suite.createChallenge(controllers.ChallengeStepChangePassword, existingUser.Username, time.Now().Add(time.Minute))
+
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return(identity, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(existingUser, nil)
+	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaError(passwordhelpers.ValidatePasswordCriteriaTooShort, ""))
+
+	//act
+	user, _, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Challenge: challenge, NewPassword: "new password", IPAddress: "127.0.0.1"})
+
+	//assert
+	suite.Nil(user)
+	suite.CustomClientError(cerr, "password does not meet minimum criteria")
+
+	suite.CRUDMock.AssertNotCalled(suite.T(), "SaveLoginThrottle", mock.Anything)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "SaveAuditEvent", mock.Anything)
+}
+
+func (suite *AuthControllerTestSuite) TestAuthenticateUser_WithChangePasswordChallengeAndValidNewPassword_UpdatesPasswordAndReturnsUser() {
+	//arrange
+	existingUser := suite.createExpiredPasswordUser(3600)
+	newHash := []byte("new hash")
+
+	challenge := suite.createChallenge(controllers.ChallengeStepChangePassword, existingUser.Username, time.Now().Add(time.Minute))
+
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return(identity, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(existingUser, nil)
+	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
+	suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return(newHash, nil)
+	suite.CRUDMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+
+	//act
+	user, resultChallenge, cerr := suite.AuthController.AuthenticateUser(&suite.CRUDMock, controllers.UserCredentials{Challenge: challenge, NewPassword: "new password", IPAddress: "127.0.0.1"})
+
+	//assert
+	suite.Equal(existingUser, user)
+	suite.Empty(resultChallenge)
+	suite.CustomNoError(cerr)
+
+	suite.PasswordHasherMock.AssertCalled(suite.T(), "HashPassword", "new password")
+	suite.CRUDMock.AssertCalled(suite.T(), "UpdateUserPassword", existingUser.Username, newHash, mock.Anything)
+
+	for _, action := range []string{models.AuditActionUpdateUserPassword, models.AuditActionLogin} {
+		action := action
+		suite.CRUDMock.AssertCalled(suite.T(), "SaveAuditEvent", mock.MatchedBy(func(event *models.AuditEvent) bool {
+			return event.Actor == existingUser.Username && event.Action == action && event.IPAddress == "127.0.0.1"
+		}))
+	}
+}
+
+func (suite *AuthControllerTestSuite) TestVerifyTwoFactorCode_WithErrorDecryptingSecret_ReturnsInternalError() {
+	//arrange
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.AuthController.VerifyTwoFactorCode(&suite.CRUDMock, suite.createTwoFactorUser(), "code")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AuthControllerTestSuite) TestVerifyTwoFactorCode_WithValidTOTPCode_ReturnsNoError() {
+	//arrange
+	user := suite.createTwoFactorUser()
+	secret := []byte("decrypted")
+	code := "123456"
+
+	step := int64(12345)
+
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return(secret, nil)
+	suite.TOTPGeneratorMock.On("ValidateCode", mock.Anything, mock.Anything).Return(step, true)
+	suite.CRUDMock.On("UpdateUserTOTPLastTimeStep", mock.Anything, mock.Anything).Return(true, nil)
+
+	//act
+	cerr := suite.AuthController.VerifyTwoFactorCode(&suite.CRUDMock, user, code)
+
+	//assert
+	suite.CustomNoError(cerr)
+
+	suite.EncryptorMock.AssertCalled(suite.T(), "Decrypt", user.TOTPSecret)
+	suite.TOTPGeneratorMock.AssertCalled(suite.T(), "ValidateCode", secret, code)
+	suite.CRUDMock.AssertCalled(suite.T(), "UpdateUserTOTPLastTimeStep", user.Username, step)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "DeleteRecoveryCode", mock.Anything, mock.Anything)
+}
+
+func (suite *AuthControllerTestSuite) TestVerifyTwoFactorCode_WithErrorUpdatingUserTOTPLastTimeStep_ReturnsInternalError() {
+	//arrange
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return([]byte("decrypted"), nil)
+	suite.TOTPGeneratorMock.On("ValidateCode", mock.Anything, mock.Anything).Return(int64(1), true)
+	suite.CRUDMock.On("UpdateUserTOTPLastTimeStep", mock.Anything, mock.Anything).Return(false, errors.New(""))
+
+	//act
+	cerr := suite.AuthController.VerifyTwoFactorCode(&suite.CRUDMock, suite.createTwoFactorUser(), "code")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AuthControllerTestSuite) TestVerifyTwoFactorCode_WhereTOTPCodeWasAlreadyUsed_ReturnsClientError() {
+	//arrange
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return([]byte("decrypted"), nil)
+	suite.TOTPGeneratorMock.On("ValidateCode", mock.Anything, mock.Anything).Return(int64(1), true)
+	suite.CRUDMock.On("UpdateUserTOTPLastTimeStep", mock.Anything, mock.Anything).Return(false, nil)
+
+	//act
+	cerr := suite.AuthController.VerifyTwoFactorCode(&suite.CRUDMock, suite.createTwoFactorUser(), "code")
+
+	//assert
+	suite.CustomClientError(cerr, "two-factor code", "already been used")
+	suite.CRUDMock.AssertNotCalled(suite.T(), "DeleteRecoveryCode", mock.Anything, mock.Anything)
+}
+
+func (suite *AuthControllerTestSuite) TestVerifyTwoFactorCode_WithErrorDeletingRecoveryCode_ReturnsInternalError() {
+	//arrange
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return([]byte("decrypted"), nil)
+	suite.TOTPGeneratorMock.On("ValidateCode", mock.Anything, mock.Anything).Return(int64(0), false)
+	suite.CRUDMock.On("DeleteRecoveryCode", mock.Anything, mock.Anything).Return(false, errors.New(""))
+
+	//act
+	cerr := suite.AuthController.VerifyTwoFactorCode(&suite.CRUDMock, suite.createTwoFactorUser(), "code")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *AuthControllerTestSuite) TestVerifyTwoFactorCode_WhereCodeIsNotARecoveryCode_ReturnsClientError() {
+	//arrange
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return([]byte("decrypted"), nil)
+	suite.TOTPGeneratorMock.On("ValidateCode", mock.Anything, mock.Anything).Return(int64(0), false)
+	suite.CRUDMock.On("DeleteRecoveryCode", mock.Anything, mock.Anything).Return(false, nil)
+
+	//act
+	cerr := suite.AuthController.VerifyTwoFactorCode(&suite.CRUDMock, suite.createTwoFactorUser(), "code")
+
+	//assert
+	suite.CustomClientError(cerr, "invalid two-factor code")
+}
+
+func (suite *AuthControllerTestSuite) TestVerifyTwoFactorCode_WithRecoveryCode_UsesRecoveryCodeAndReturnsNoError() {
+	//arrange
+	user := suite.createTwoFactorUser()
+	code := "ABCD-EFGH"
+
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return([]byte("decrypted"), nil)
+	suite.TOTPGeneratorMock.On("ValidateCode", mock.Anything, mock.Anything).Return(int64(0), false)
+	suite.CRUDMock.On("DeleteRecoveryCode", mock.Anything, mock.Anything).Return(true, nil)
+
+	//act
+	cerr := suite.AuthController.VerifyTwoFactorCode(&suite.CRUDMock, user, code)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteRecoveryCode", user.Username, totphelpers.HashRecoveryCode(code))
+}
+
 func TestAuthControllerTestSuite(t *testing.T) {
 	suite.Run(t, &AuthControllerTestSuite{})
 }
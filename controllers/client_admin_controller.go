@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+type CoreClientAdminController struct{}
+
+func (c CoreClientAdminController) CreateClientAdmin(CRUD ClientAdminControllerCRUD, admin *models.ClientAdmin) common.CustomError {
+	//validate the model
+	verr := admin.Validate()
+	if verr&models.ValidateClientAdminEmptyUsername != 0 {
+		return common.ClientError("username cannot be empty")
+	}
+
+	//verify the client exists
+	cerr := c.verifyClientExists(CRUD, admin.ClientUID)
+	if cerr.Type != common.ErrorTypeNone {
+		return cerr
+	}
+
+	//verify the user exists
+	user, err := CRUD.GetUserByUsername(admin.Username)
+	if err != nil {
+		log.Println(common.ChainError("error getting user by username", err))
+		return common.InternalError()
+	}
+	if user == nil {
+		return common.ClientError(fmt.Sprintf("user with username %s not found", admin.Username))
+	}
+
+	//verify the user is not already a client admin
+	existingAdmin, err := CRUD.GetClientAdminByClientUIDAndUsername(admin.ClientUID, admin.Username)
+	if err != nil {
+		log.Println(common.ChainError("error getting client admin by client uid and username", err))
+		return common.InternalError()
+	}
+	if existingAdmin != nil {
+		return common.ClientError(fmt.Sprintf("user %s is already a client admin of the client", admin.Username))
+	}
+
+	//create the client admin
+	err = CRUD.CreateClientAdmin(admin)
+	if err != nil {
+		log.Println(common.ChainError("error creating client admin", err))
+		return common.InternalError()
+	}
+
+	return common.NoError()
+}
+
+func (c CoreClientAdminController) GetClientAdminsByClientUID(CRUD ClientAdminControllerCRUD, clientUID uuid.UUID) ([]*models.ClientAdmin, common.CustomError) {
+	//verify the client exists
+	cerr := c.verifyClientExists(CRUD, clientUID)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
+	}
+
+	//get the client admins
+	admins, err := CRUD.GetClientAdminsByClientUID(clientUID)
+	if err != nil {
+		log.Println(common.ChainError("error getting client admins by client uid", err))
+		return nil, common.InternalError()
+	}
+
+	return admins, common.NoError()
+}
+
+func (CoreClientAdminController) DeleteClientAdmin(CRUD ClientAdminControllerCRUD, clientUID uuid.UUID, username string) common.CustomError {
+	//delete the client admin
+	res, err := CRUD.DeleteClientAdmin(clientUID, username)
+	if err != nil {
+		log.Println(common.ChainError("error deleting client admin", err))
+		return common.InternalError()
+	}
+
+	//verify the client admin was found
+	if !res {
+		return common.ClientError(fmt.Sprintf("user %s is not a client admin of client %s", username, clientUID.String()))
+	}
+
+	return common.NoError()
+}
+
+func (CoreClientAdminController) verifyClientExists(CRUD ClientAdminControllerCRUD, clientUID uuid.UUID) common.CustomError {
+	client, err := CRUD.GetClientByUID(clientUID)
+	if err != nil {
+		log.Println(common.ChainError("error getting client by uid", err))
+		return common.InternalError()
+	}
+	if client == nil {
+		return common.ClientError(fmt.Sprintf("client with id %s not found", clientUID.String()))
+	}
+
+	return common.NoError()
+}
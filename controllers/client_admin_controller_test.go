@@ -0,0 +1,237 @@
+package controllers_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mhogar/amber/controllers"
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ClientAdminControllerTestSuite struct {
+	ControllerTestSuite
+	ClientAdminController controllers.CoreClientAdminController
+}
+
+func (suite *ClientAdminControllerTestSuite) SetupTest() {
+	suite.ControllerTestSuite.SetupTest()
+	suite.ClientAdminController = controllers.CoreClientAdminController{}
+}
+
+func (suite *ClientAdminControllerTestSuite) TestCreateClientAdmin_WithEmptyUsername_ReturnsClientError() {
+	//arrange
+	admin := models.CreateClientAdmin(uuid.New(), "", false)
+
+	//act
+	cerr := suite.ClientAdminController.CreateClientAdmin(&suite.CRUDMock, admin)
+
+	//assert
+	suite.CustomClientError(cerr, "username", "cannot be empty")
+}
+
+func (suite *ClientAdminControllerTestSuite) TestCreateClientAdmin_WithErrorGettingClientByUID_ReturnsInternalError() {
+	//arrange
+	admin := models.CreateClientAdmin(uuid.New(), "username", false)
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.ClientAdminController.CreateClientAdmin(&suite.CRUDMock, admin)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *ClientAdminControllerTestSuite) TestCreateClientAdmin_WhereClientIsNotFound_ReturnsClientError() {
+	//arrange
+	admin := models.CreateClientAdmin(uuid.New(), "username", false)
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(nil, nil)
+
+	//act
+	cerr := suite.ClientAdminController.CreateClientAdmin(&suite.CRUDMock, admin)
+
+	//assert
+	suite.CustomClientError(cerr, "client", admin.ClientUID.String(), "not found")
+}
+
+func (suite *ClientAdminControllerTestSuite) TestCreateClientAdmin_WithErrorGettingUserByUsername_ReturnsInternalError() {
+	//arrange
+	admin := models.CreateClientAdmin(uuid.New(), "username", false)
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.ClientAdminController.CreateClientAdmin(&suite.CRUDMock, admin)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *ClientAdminControllerTestSuite) TestCreateClientAdmin_WhereUserIsNotFound_ReturnsClientError() {
+	//arrange
+	admin := models.CreateClientAdmin(uuid.New(), "username", false)
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
+
+	//act
+	cerr := suite.ClientAdminController.CreateClientAdmin(&suite.CRUDMock, admin)
+
+	//assert
+	suite.CustomClientError(cerr, "user", admin.Username, "not found")
+}
+
+func (suite *ClientAdminControllerTestSuite) TestCreateClientAdmin_WithErrorGettingClientAdmin_ReturnsInternalError() {
+	//arrange
+	admin := models.CreateClientAdmin(uuid.New(), "username", false)
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetClientAdminByClientUIDAndUsername", mock.Anything, mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.ClientAdminController.CreateClientAdmin(&suite.CRUDMock, admin)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *ClientAdminControllerTestSuite) TestCreateClientAdmin_WhereUserIsAlreadyClientAdmin_ReturnsClientError() {
+	//arrange
+	admin := models.CreateClientAdmin(uuid.New(), "username", false)
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetClientAdminByClientUIDAndUsername", mock.Anything, mock.Anything).Return(&models.ClientAdmin{}, nil)
+
+	//act
+	cerr := suite.ClientAdminController.CreateClientAdmin(&suite.CRUDMock, admin)
+
+	//assert
+	suite.CustomClientError(cerr, admin.Username, "already a client admin")
+}
+
+func (suite *ClientAdminControllerTestSuite) TestCreateClientAdmin_WithErrorCreatingClientAdmin_ReturnsInternalError() {
+	//arrange
+	admin := models.CreateClientAdmin(uuid.New(), "username", false)
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetClientAdminByClientUIDAndUsername", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("CreateClientAdmin", mock.Anything).Return(errors.New(""))
+
+	//act
+	cerr := suite.ClientAdminController.CreateClientAdmin(&suite.CRUDMock, admin)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *ClientAdminControllerTestSuite) TestCreateClientAdmin_WithNoErrors_ReturnsNoError() {
+	//arrange
+	admin := models.CreateClientAdmin(uuid.New(), "username", true)
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetClientAdminByClientUIDAndUsername", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("CreateClientAdmin", mock.Anything).Return(nil)
+
+	//act
+	cerr := suite.ClientAdminController.CreateClientAdmin(&suite.CRUDMock, admin)
+
+	//assert
+	suite.CustomNoError(cerr)
+
+	suite.CRUDMock.AssertCalled(suite.T(), "GetClientByUID", admin.ClientUID)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetUserByUsername", admin.Username)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetClientAdminByClientUIDAndUsername", admin.ClientUID, admin.Username)
+	suite.CRUDMock.AssertCalled(suite.T(), "CreateClientAdmin", admin)
+}
+
+func (suite *ClientAdminControllerTestSuite) TestGetClientAdminsByClientUID_WhereClientIsNotFound_ReturnsClientError() {
+	//arrange
+	uid := uuid.New()
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(nil, nil)
+
+	//act
+	admins, cerr := suite.ClientAdminController.GetClientAdminsByClientUID(&suite.CRUDMock, uid)
+
+	//assert
+	suite.Nil(admins)
+	suite.CustomClientError(cerr, "client", uid.String(), "not found")
+}
+
+func (suite *ClientAdminControllerTestSuite) TestGetClientAdminsByClientUID_WithErrorGettingClientAdmins_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetClientAdminsByClientUID", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	admins, cerr := suite.ClientAdminController.GetClientAdminsByClientUID(&suite.CRUDMock, uuid.New())
+
+	//assert
+	suite.Nil(admins)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *ClientAdminControllerTestSuite) TestGetClientAdminsByClientUID_WithNoErrors_ReturnsClientAdmins() {
+	//arrange
+	uid := uuid.New()
+	admins := []*models.ClientAdmin{
+		models.CreateClientAdmin(uid, "username", true),
+	}
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetClientAdminsByClientUID", mock.Anything).Return(admins, nil)
+
+	//act
+	resultAdmins, cerr := suite.ClientAdminController.GetClientAdminsByClientUID(&suite.CRUDMock, uid)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Equal(admins, resultAdmins)
+
+	suite.CRUDMock.AssertCalled(suite.T(), "GetClientAdminsByClientUID", uid)
+}
+
+func (suite *ClientAdminControllerTestSuite) TestDeleteClientAdmin_WithErrorDeletingClientAdmin_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("DeleteClientAdmin", mock.Anything, mock.Anything).Return(false, errors.New(""))
+
+	//act
+	cerr := suite.ClientAdminController.DeleteClientAdmin(&suite.CRUDMock, uuid.New(), "username")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *ClientAdminControllerTestSuite) TestDeleteClientAdmin_WhereClientAdminIsNotFound_ReturnsClientError() {
+	//arrange
+	uid := uuid.New()
+	username := "username"
+
+	suite.CRUDMock.On("DeleteClientAdmin", mock.Anything, mock.Anything).Return(false, nil)
+
+	//act
+	cerr := suite.ClientAdminController.DeleteClientAdmin(&suite.CRUDMock, uid, username)
+
+	//assert
+	suite.CustomClientError(cerr, username, "not a client admin", uid.String())
+}
+
+func (suite *ClientAdminControllerTestSuite) TestDeleteClientAdmin_WithNoErrors_ReturnsNoError() {
+	//arrange
+	uid := uuid.New()
+	username := "username"
+
+	suite.CRUDMock.On("DeleteClientAdmin", mock.Anything, mock.Anything).Return(true, nil)
+
+	//act
+	cerr := suite.ClientAdminController.DeleteClientAdmin(&suite.CRUDMock, uid, username)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteClientAdmin", uid, username)
+}
+
+func TestClientAdminControllerTestSuite(t *testing.T) {
+	suite.Run(t, &ClientAdminControllerTestSuite{})
+}
@@ -1,16 +1,24 @@
 package controllers
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"fmt"
 	"log"
 
 	"github.com/mhogar/amber/common"
+	passwordhelpers "github.com/mhogar/amber/controllers/password_helpers"
 	"github.com/mhogar/amber/models"
 
 	"github.com/google/uuid"
 )
 
-type CoreClientController struct{}
+// ClientSecretNumBytes is the number of random bytes used to generate a client secret.
+const ClientSecretNumBytes = 32
+
+type CoreClientController struct {
+	PasswordHasher passwordhelpers.PasswordHasher
+}
 
 func (c CoreClientController) CreateClient(CRUD ClientControllerCRUD, client *models.Client) common.CustomError {
 	//validate the client
@@ -29,15 +37,46 @@ func (c CoreClientController) CreateClient(CRUD ClientControllerCRUD, client *mo
 	return common.NoError()
 }
 
-func (CoreClientController) GetClients(CRUD ClientControllerCRUD) ([]*models.Client, common.CustomError) {
-	//get the clients
-	clients, err := CRUD.GetClients()
+func (CoreClientController) GetClients(CRUD ClientControllerCRUD, query models.PageQuery) ([]*models.Client, models.PageInfo, common.CustomError) {
+	//validate the page query
+	cerr := validatePageQuery(&query, models.ClientSortName)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, models.PageInfo{}, cerr
+	}
+
+	if query.After != nil {
+		_, err := uuid.Parse(query.After.Key)
+		if err != nil {
+			return nil, models.PageInfo{}, common.ClientError("cursor is invalid")
+		}
+	}
+
+	//get the clients, fetching an extra one to check if there is a next page
+	limit := query.Limit
+	query.Limit++
+
+	clients, err := CRUD.GetClients(query)
 	if err != nil {
 		log.Println(common.ChainError("error getting clients", err))
-		return nil, common.InternalError()
+		return nil, models.PageInfo{}, common.InternalError()
 	}
 
-	return clients, common.NoError()
+	//count the clients
+	total, err := CRUD.CountClients(query.Search)
+	if err != nil {
+		log.Println(common.ChainError("error counting clients", err))
+		return nil, models.PageInfo{}, common.InternalError()
+	}
+
+	page := models.PageInfo{Total: total}
+	if len(clients) > limit {
+		clients = clients[:limit]
+
+		cursor := clients[limit-1].GetCursor()
+		page.NextCursor = &cursor
+	}
+
+	return clients, page, common.NoError()
 }
 
 func (c CoreClientController) UpdateClient(CRUD ClientControllerCRUD, client *models.Client) common.CustomError {
@@ -78,6 +117,38 @@ func (CoreClientController) DeleteClient(CRUD ClientControllerCRUD, uid uuid.UUI
 	return common.NoError()
 }
 
+func (c CoreClientController) RotateClientSecret(CRUD ClientControllerCRUD, uid uuid.UUID) (string, common.CustomError) {
+	//generate the secret
+	bytes := make([]byte, ClientSecretNumBytes)
+	_, err := rand.Read(bytes)
+	if err != nil {
+		log.Println(common.ChainError("error generating client secret", err))
+		return "", common.InternalError()
+	}
+	secret := base64.RawURLEncoding.EncodeToString(bytes)
+
+	//hash the secret
+	hash, err := c.PasswordHasher.HashPassword(secret)
+	if err != nil {
+		log.Println(common.ChainError("error generating client secret hash", err))
+		return "", common.InternalError()
+	}
+
+	//update the secret hash
+	res, err := CRUD.UpdateClientSecretHash(uid, hash)
+	if err != nil {
+		log.Println(common.ChainError("error updating client secret hash", err))
+		return "", common.InternalError()
+	}
+
+	//verify client was actually found
+	if !res {
+		return "", common.ClientError(fmt.Sprintf("client with id %s not found", uid.String()))
+	}
+
+	return secret, common.NoError()
+}
+
 func (CoreClientController) validateClient(client *models.Client) common.CustomError {
 	verr := client.Validate()
 
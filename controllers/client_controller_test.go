@@ -7,6 +7,7 @@ import (
 
 	"github.com/mhogar/amber/common"
 	"github.com/mhogar/amber/controllers"
+	passwordhelpermocks "github.com/mhogar/amber/controllers/password_helpers/mocks"
 	"github.com/mhogar/amber/models"
 	"github.com/mhogar/amber/testing/helpers"
 
@@ -17,7 +18,17 @@ import (
 
 type ClientControllerTestSuite struct {
 	ControllerTestSuite
-	ClientController controllers.CoreClientController
+	PasswordHasherMock passwordhelpermocks.PasswordHasher
+	ClientController   controllers.CoreClientController
+}
+
+func (suite *ClientControllerTestSuite) SetupTest() {
+	suite.ControllerTestSuite.SetupTest()
+
+	suite.PasswordHasherMock = passwordhelpermocks.PasswordHasher{}
+	suite.ClientController = controllers.CoreClientController{
+		PasswordHasher: &suite.PasswordHasherMock,
+	}
 }
 
 func (suite *ClientControllerTestSuite) runValidateClientTestCases(validateFunc func(client *models.Client) common.CustomError) {
@@ -143,31 +154,134 @@ func (suite *ClientControllerTestSuite) TestCreateClient_WithNoErrors_ReturnsNoE
 	suite.CRUDMock.AssertCalled(suite.T(), "CreateClient", client)
 }
 
+func (suite *ClientControllerTestSuite) TestGetClients_InvalidPageQueryTestCases() {
+	var query models.PageQuery
+	var expectedErrorSubStrings []string
+
+	testCase := func() {
+		//act
+		clients, page, cerr := suite.ClientController.GetClients(&suite.CRUDMock, query)
+
+		//assert
+		suite.Nil(clients)
+		suite.Zero(page)
+		suite.CustomClientError(cerr, expectedErrorSubStrings...)
+		suite.CRUDMock.AssertNotCalled(suite.T(), "GetClients", mock.Anything)
+	}
+
+	query = models.PageQuery{Limit: -1}
+	expectedErrorSubStrings = []string{"limit", "between"}
+	suite.Run("NegativeLimit", testCase)
+
+	query = models.PageQuery{Limit: controllers.MaxPageLimit + 1}
+	expectedErrorSubStrings = []string{"limit", "between"}
+	suite.Run("LimitGreaterThanMax", testCase)
+
+	query = models.PageQuery{SortBy: "redirect_url"}
+	expectedErrorSubStrings = []string{"sort", "one of", models.ClientSortName}
+	suite.Run("InvalidSortField", testCase)
+
+	query = models.PageQuery{After: &models.Cursor{Value: "name", Key: "not a uuid"}}
+	expectedErrorSubStrings = []string{"cursor", "invalid"}
+	suite.Run("InvalidCursor", testCase)
+}
+
 func (suite *ClientControllerTestSuite) TestGetClients_WithErrorGettingClients_ReturnsInternalError() {
 	//arrange
-	suite.CRUDMock.On("GetClients").Return(nil, errors.New(""))
+	suite.CRUDMock.On("GetClients", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	clients, page, cerr := suite.ClientController.GetClients(&suite.CRUDMock, models.PageQuery{})
+
+	//assert
+	suite.Nil(clients)
+	suite.Zero(page)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *ClientControllerTestSuite) TestGetClients_WithErrorCountingClients_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetClients", mock.Anything).Return([]*models.Client{}, nil)
+	suite.CRUDMock.On("CountClients", mock.Anything).Return(0, errors.New(""))
 
 	//act
-	clients, cerr := suite.ClientController.GetClients(&suite.CRUDMock)
+	clients, page, cerr := suite.ClientController.GetClients(&suite.CRUDMock, models.PageQuery{})
 
 	//assert
 	suite.Nil(clients)
+	suite.Zero(page)
 	suite.CustomInternalError(cerr)
 }
 
+func (suite *ClientControllerTestSuite) TestGetClients_WithNoLimitOrSortField_UsesDefaults() {
+	//arrange
+	query := models.PageQuery{Search: "search"}
+
+	suite.CRUDMock.On("GetClients", mock.Anything).Return([]*models.Client{}, nil)
+	suite.CRUDMock.On("CountClients", mock.Anything).Return(0, nil)
+
+	//act
+	_, _, cerr := suite.ClientController.GetClients(&suite.CRUDMock, query)
+
+	//assert
+	suite.CustomNoError(cerr)
+
+	suite.CRUDMock.AssertCalled(suite.T(), "GetClients", models.PageQuery{
+		Search: query.Search,
+		SortBy: models.ClientSortName,
+		Limit:  controllers.DefaultPageLimit + 1,
+	})
+	suite.CRUDMock.AssertCalled(suite.T(), "CountClients", query.Search)
+}
+
+func (suite *ClientControllerTestSuite) TestGetClients_WithMoreClientsThanLimit_ReturnsPageWithNextCursor() {
+	//arrange
+	clients := []*models.Client{
+		models.CreateNewClient("name1", "redirect.com", 0, "key.pem"),
+		models.CreateNewClient("name2", "redirect.com", 0, "key.pem"),
+		models.CreateNewClient("name3", "redirect.com", 0, "key.pem"),
+	}
+	total := 10
+
+	suite.CRUDMock.On("GetClients", mock.Anything).Return(clients, nil)
+	suite.CRUDMock.On("CountClients", mock.Anything).Return(total, nil)
+
+	//act
+	resultClients, page, cerr := suite.ClientController.GetClients(&suite.CRUDMock, models.PageQuery{Limit: 2})
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Equal(clients[:2], resultClients)
+
+	suite.Equal(total, page.Total)
+	suite.Require().NotNil(page.NextCursor)
+	suite.Equal(clients[1].GetCursor(), *page.NextCursor)
+}
+
 func (suite *ClientControllerTestSuite) TestGetClients_WithNoErrors_ReturnsClients() {
 	//arrange
+	query := models.PageQuery{
+		Search:         "name",
+		SortDescending: true,
+		After:          &models.Cursor{Value: "name", Key: uuid.New().String()},
+		Limit:          5,
+	}
+
 	clients := []*models.Client{models.CreateNewClient("name", "redirect.com", 0, "key.pem")}
-	suite.CRUDMock.On("GetClients").Return(clients, nil)
+	suite.CRUDMock.On("GetClients", mock.Anything).Return(clients, nil)
+	suite.CRUDMock.On("CountClients", mock.Anything).Return(len(clients), nil)
 
 	//act
-	resultClients, cerr := suite.ClientController.GetClients(&suite.CRUDMock)
+	resultClients, page, cerr := suite.ClientController.GetClients(&suite.CRUDMock, query)
 
 	//assert
-	suite.Equal(clients, resultClients)
 	suite.CustomNoError(cerr)
+	suite.Equal(clients, resultClients)
+	suite.Equal(models.PageInfo{Total: len(clients)}, page)
 
-	suite.CRUDMock.AssertCalled(suite.T(), "GetClients")
+	query.SortBy = models.ClientSortName
+	query.Limit++
+	suite.CRUDMock.AssertCalled(suite.T(), "GetClients", query)
 }
 
 func (suite *ClientControllerTestSuite) TestUpdateClient_ValidateClientTestCases() {
@@ -249,6 +363,67 @@ func (suite *ClientControllerTestSuite) TestDeleteClient_WithNoErrors_ReturnsNoE
 	suite.CRUDMock.AssertCalled(suite.T(), "DeleteClient", uid)
 }
 
+func (suite *ClientControllerTestSuite) TestRotateClientSecret_WithErrorHashingSecret_ReturnsInternalError() {
+	//arrange
+	suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	secret, cerr := suite.ClientController.RotateClientSecret(&suite.CRUDMock, uuid.New())
+
+	//assert
+	suite.Empty(secret)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *ClientControllerTestSuite) TestRotateClientSecret_WithErrorUpdatingClientSecretHash_ReturnsInternalError() {
+	//arrange
+	suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return([]byte("hash"), nil)
+	suite.CRUDMock.On("UpdateClientSecretHash", mock.Anything, mock.Anything).Return(false, errors.New(""))
+
+	//act
+	secret, cerr := suite.ClientController.RotateClientSecret(&suite.CRUDMock, uuid.New())
+
+	//assert
+	suite.Empty(secret)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *ClientControllerTestSuite) TestRotateClientSecret_WithFalseResultUpdatingClientSecretHash_ReturnsClientError() {
+	//arrange
+	uid := uuid.New()
+	suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return([]byte("hash"), nil)
+	suite.CRUDMock.On("UpdateClientSecretHash", mock.Anything, mock.Anything).Return(false, nil)
+
+	//act
+	secret, cerr := suite.ClientController.RotateClientSecret(&suite.CRUDMock, uid)
+
+	//assert
+	suite.Empty(secret)
+	suite.CustomClientError(cerr, "client with id", uid.String(), "not found")
+}
+
+func (suite *ClientControllerTestSuite) TestRotateClientSecret_WithNoErrors_ReturnsNewSecret() {
+	//arrange
+	uid := uuid.New()
+	hash := []byte("hash")
+	suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return(hash, nil)
+	suite.CRUDMock.On("UpdateClientSecretHash", mock.Anything, mock.Anything).Return(true, nil)
+
+	//act
+	secret, cerr := suite.ClientController.RotateClientSecret(&suite.CRUDMock, uid)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.NotEmpty(secret)
+
+	suite.PasswordHasherMock.AssertCalled(suite.T(), "HashPassword", secret)
+	suite.CRUDMock.AssertCalled(suite.T(), "UpdateClientSecretHash", uid, hash)
+
+	//a new secret is generated each time
+	otherSecret, _ := suite.ClientController.RotateClientSecret(&suite.CRUDMock, uid)
+	suite.NotEqual(secret, otherSecret)
+}
+
 func TestClientControllerTestSuite(t *testing.T) {
 	suite.Run(t, &ClientControllerTestSuite{})
 }
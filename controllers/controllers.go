@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"github.com/mhogar/amber/common"
+	jwthelpers "github.com/mhogar/amber/controllers/jwt_helpers"
 	"github.com/mhogar/amber/models"
 
 	"github.com/google/uuid"
@@ -12,44 +13,68 @@ type Controllers interface {
 	UserController
 	ClientController
 	UserRoleController
+	RoleDefinitionController
+	AdminRoleController
+	ClientAdminController
+	GroupController
+	GroupRoleController
 	AuthController
 	SessionController
 	TokenController
+	TwoFactorController
+	LockoutController
+	AuditController
+	PasswordResetController
+	InvitationController
 }
 
 type CoreControllers struct {
 	UserController
 	ClientController
 	UserRoleController
+	RoleDefinitionController
+	AdminRoleController
+	ClientAdminController
+	GroupController
+	GroupRoleController
 	AuthController
 	SessionController
 	TokenController
+	TwoFactorController
+	LockoutController
+	AuditController
+	PasswordResetController
+	InvitationController
 }
 
 // UserControllerCRUD encapsulates the CRUD operations required by the UserController.
 type UserControllerCRUD interface {
 	models.UserCRUD
 	models.SessionCRUD
+	models.PreviousPasswordCRUD
 }
 
 type UserController interface {
-	// CreateUser creates a new user with the given username, password, and rank.
+	// CreateUser creates a new user with the given username, password, rank, and profile.
 	// Returns the user model and any errors.
-	CreateUser(CRUD UserControllerCRUD, username string, password string, rank int) (*models.User, common.CustomError)
+	CreateUser(CRUD UserControllerCRUD, username string, password string, rank int, profile models.UserProfile) (*models.User, common.CustomError)
 
-	// GetUsersWithLesserRank gets all users with a rank less than the provided one.
-	// Returns the user models and any errors.
-	GetUsersWithLesserRank(CRUD UserControllerCRUD, rank int) ([]*models.User, common.CustomError)
+	// GetUsersWithLesserRank gets the page of users with a rank less than the provided one.
+	// Returns the user models, where the page is in the list, and any errors.
+	GetUsersWithLesserRank(CRUD UserControllerCRUD, rank int, query models.PageQuery) ([]*models.User, models.PageInfo, common.CustomError)
 
-	// UpdateUser updates the fields of the user for the given username.
+	// UpdateUser updates the rank and the profile fields set in the update for the user with the given username.
+	// Profile fields not set in the update keep their current values. Disabling the user also deletes all of their sessions.
 	// Returns the user model and any errors.
-	UpdateUser(CRUD UserControllerCRUD, username string, rank int) (*models.User, common.CustomError)
+	UpdateUser(CRUD UserControllerCRUD, username string, rank int, update models.UserProfileUpdate) (*models.User, common.CustomError)
 
 	// UpdateUserPassword updates the password for the user with the given username.
+	// The password cannot match any of the user's recent passwords, up to the configured history size.
 	// Returns any errors.
 	UpdateUserPassword(CRUD UserControllerCRUD, username string, password string) common.CustomError
 
 	// UpdateUserPasswordWithAuth authenticates the user and updates their password.
+	// Users can't change their password until it is older than the configured minimum age.
 	// Returns any errors.
 	UpdateUserPasswordWithAuth(CRUD UserControllerCRUD, username string, oldPassword string, newPassword string) common.CustomError
 
@@ -73,9 +98,9 @@ type ClientController interface {
 	// Returns any errors.
 	CreateClient(CRUD ClientControllerCRUD, client *models.Client) common.CustomError
 
-	// GetClients gets all clients.
-	// Returns the client models any errors.
-	GetClients(CRUD ClientControllerCRUD) ([]*models.Client, common.CustomError)
+	// GetClients gets the page of clients.
+	// Returns the client models, where the page is in the list, and any errors.
+	GetClients(CRUD ClientControllerCRUD, query models.PageQuery) ([]*models.Client, models.PageInfo, common.CustomError)
 
 	// UpdateClient updates the given client.
 	// Returns any errors.
@@ -84,23 +109,31 @@ type ClientController interface {
 	// DeleteClient deletes the client with the given uid.
 	// Returns any errors.
 	DeleteClient(CRUD ClientControllerCRUD, uid uuid.UUID) common.CustomError
+
+	// RotateClientSecret generates a new secret for the client with the given uid, replacing any existing secret.
+	// Only the hash of the secret is stored, so the plain text secret is only returned here.
+	// Returns the secret and any errors.
+	RotateClientSecret(CRUD ClientControllerCRUD, uid uuid.UUID) (string, common.CustomError)
 }
 
 // UserRoleControllerCRUD encapsulates the CRUD operations required by the UserRoleController.
 type UserRoleControllerCRUD interface {
 	models.UserRoleCRUD
+	models.RoleDefinitionCRUD
 }
 
 type UserRoleController interface {
 	// CreateUserRole creates a new user-role using the provided model.
+	// The role must be defined by the client. If no role is given, the user is given the client's default role.
 	// Returns any errors.
 	CreateUserRole(CRUD UserRoleControllerCRUD, role *models.UserRole) common.CustomError
 
-	// GetUserRolesByClientUID gets the user-roles with the provided client uid and with a rank less than the provided rank.
-	// Returns the user-role models and any errors.
-	GetUserRolesWithLesserRankByClientUID(CRUD UserRoleControllerCRUD, clientUID uuid.UUID, rank int) ([]*models.UserRole, common.CustomError)
+	// GetUserRolesWithLesserRankByClientUID gets the page of user-roles with the provided client uid and with a rank less than the provided rank.
+	// Returns the user-role models, where the page is in the list, and any errors.
+	GetUserRolesWithLesserRankByClientUID(CRUD UserRoleControllerCRUD, clientUID uuid.UUID, rank int, query models.PageQuery) ([]*models.UserRole, models.PageInfo, common.CustomError)
 
 	// UpdateUserRole updates the given user-role.
+	// The role must be defined by the client.
 	// Returns any errors.
 	UpdateUserRole(CRUD UserRoleControllerCRUD, role *models.UserRole) common.CustomError
 
@@ -109,28 +142,265 @@ type UserRoleController interface {
 	DeleteUserRole(CRUD UserRoleControllerCRUD, clientUID uuid.UUID, username string) common.CustomError
 }
 
+// RoleDefinitionControllerCRUD encapsulates the CRUD operations required by the RoleDefinitionController.
+type RoleDefinitionControllerCRUD interface {
+	models.ClientCRUD
+	models.RoleDefinitionCRUD
+	models.UserRoleCRUD
+	models.GroupRoleCRUD
+	models.InvitationCRUD
+}
+
+type RoleDefinitionController interface {
+	// CreateRoleDefinition creates a new role definition using the provided model.
+	// If the role definition is the default, the client's previous default role definition no longer is.
+	// Returns any errors.
+	CreateRoleDefinition(CRUD RoleDefinitionControllerCRUD, definition *models.RoleDefinition) common.CustomError
+
+	// GetRoleDefinitionsByClientUID gets all the role definitions for the client with the given uid.
+	// Returns the role definition models and any errors.
+	GetRoleDefinitionsByClientUID(CRUD RoleDefinitionControllerCRUD, clientUID uuid.UUID) ([]*models.RoleDefinition, common.CustomError)
+
+	// UpdateRoleDefinition updates the role definition with the given name using the provided model.
+	// If the model has a different name, the user-roles, group-roles, and invitation roles with the old name are renamed as well.
+	// Returns any errors.
+	UpdateRoleDefinition(CRUD RoleDefinitionControllerCRUD, name string, definition *models.RoleDefinition) common.CustomError
+
+	// DeleteRoleDefinition deletes the role definition with the given client uid and name.
+	// Roles that are still given to users or groups cannot be deleted.
+	// Returns any errors.
+	DeleteRoleDefinition(CRUD RoleDefinitionControllerCRUD, clientUID uuid.UUID, name string) common.CustomError
+}
+
+// AdminRoleControllerCRUD encapsulates the CRUD operations required by the AdminRoleController.
+type AdminRoleControllerCRUD interface {
+	models.UserCRUD
+	models.AdminRoleCRUD
+}
+
+type AdminRoleController interface {
+	// CreateAdminRole creates a new admin role using the provided model.
+	// Returns any errors.
+	CreateAdminRole(CRUD AdminRoleControllerCRUD, role *models.AdminRole) common.CustomError
+
+	// GetAdminRoles gets all the admin roles.
+	// Returns the admin role models and any errors.
+	GetAdminRoles(CRUD AdminRoleControllerCRUD) ([]*models.AdminRole, common.CustomError)
+
+	// UpdateAdminRole updates the description and permissions of the admin role with the model's name. The built-in admin roles cannot be updated.
+	// Returns any errors.
+	UpdateAdminRole(CRUD AdminRoleControllerCRUD, role *models.AdminRole) common.CustomError
+
+	// DeleteAdminRole deletes the admin role with the given name, taking it away from the users it was given to. The built-in admin roles cannot be deleted.
+	// Returns any errors.
+	DeleteAdminRole(CRUD AdminRoleControllerCRUD, name string) common.CustomError
+
+	// VerifyAdminRoleRank verifies every user the admin role with the given name was given to, other than the user with the given username,
+	// has a rank less than the provided rank.
+	// Returns result and any errors.
+	VerifyAdminRoleRank(CRUD AdminRoleControllerCRUD, name string, username string, rank int) (bool, common.CustomError)
+
+	// GetUserAdminRoles gets the admin roles given to the user with the given username.
+	// Returns the admin role models and any errors.
+	GetUserAdminRoles(CRUD AdminRoleControllerCRUD, username string) ([]*models.AdminRole, common.CustomError)
+
+	// UpdateUserAdminRoles replaces the admin roles given to the user with the given username with the admin roles with the given names.
+	// Returns the user's new admin role models and any errors.
+	UpdateUserAdminRoles(CRUD AdminRoleControllerCRUD, username string, names []string) ([]*models.AdminRole, common.CustomError)
+
+	// CreateBuiltInAdminRoles creates any of the built-in admin roles that do not exist yet,
+	// and adds any missing built-in permissions to the ones that do.
+	// Returns any errors.
+	CreateBuiltInAdminRoles(CRUD AdminRoleControllerCRUD) common.CustomError
+
+	// AssignAdminRolesByRank creates the built-in admin roles and gives them to every user according to their rank and the configured min ranks,
+	// keeping any admin roles the users already have. It is used to migrate from rank-based to permission-based authorization.
+	// Returns any errors.
+	AssignAdminRolesByRank(CRUD AdminRoleControllerCRUD) common.CustomError
+}
+
+// ClientAdminControllerCRUD encapsulates the CRUD operations required by the ClientAdminController.
+type ClientAdminControllerCRUD interface {
+	models.ClientCRUD
+	models.UserCRUD
+	models.ClientAdminCRUD
+}
+
+type ClientAdminController interface {
+	// CreateClientAdmin creates a new client admin using the provided model.
+	// Returns any errors.
+	CreateClientAdmin(CRUD ClientAdminControllerCRUD, admin *models.ClientAdmin) common.CustomError
+
+	// GetClientAdminsByClientUID gets all the client admins for the client with the given uid.
+	// Returns the client admin models and any errors.
+	GetClientAdminsByClientUID(CRUD ClientAdminControllerCRUD, clientUID uuid.UUID) ([]*models.ClientAdmin, common.CustomError)
+
+	// DeleteClientAdmin deletes the client admin with the given client uid and username.
+	// Returns any errors.
+	DeleteClientAdmin(CRUD ClientAdminControllerCRUD, clientUID uuid.UUID, username string) common.CustomError
+}
+
+// GroupControllerCRUD encapsulates the CRUD operations required by the GroupController.
+type GroupControllerCRUD interface {
+	models.UserCRUD
+	models.GroupCRUD
+}
+
+type GroupController interface {
+	// CreateGroup creates a new group using the provided model.
+	// Returns any errors.
+	CreateGroup(CRUD GroupControllerCRUD, group *models.Group) common.CustomError
+
+	// GetGroups gets all the groups.
+	// Returns the group models and any errors.
+	GetGroups(CRUD GroupControllerCRUD) ([]*models.Group, common.CustomError)
+
+	// UpdateGroup updates the description and priority of the group with the model's name.
+	// Returns any errors.
+	UpdateGroup(CRUD GroupControllerCRUD, group *models.Group) common.CustomError
+
+	// DeleteGroup deletes the group with the given name, along with its members and the roles clients gave it.
+	// Returns any errors.
+	DeleteGroup(CRUD GroupControllerCRUD, name string) common.CustomError
+
+	// GetGroupMembers gets the usernames of the members of the group with the given name.
+	// Returns the usernames and any errors.
+	GetGroupMembers(CRUD GroupControllerCRUD, name string) ([]string, common.CustomError)
+
+	// CreateGroupMember adds the user with the given username to the group with the given name.
+	// Returns any errors.
+	CreateGroupMember(CRUD GroupControllerCRUD, name string, username string) common.CustomError
+
+	// DeleteGroupMember removes the user with the given username from the group with the given name.
+	// Returns any errors.
+	DeleteGroupMember(CRUD GroupControllerCRUD, name string, username string) common.CustomError
+
+	// VerifyGroupRank verifies every member of the group with the given name has a rank less than the provided rank.
+	// Returns result and any errors.
+	VerifyGroupRank(CRUD GroupControllerCRUD, name string, rank int) (bool, common.CustomError)
+}
+
+// GroupRoleControllerCRUD encapsulates the CRUD operations required by the GroupRoleController.
+type GroupRoleControllerCRUD interface {
+	models.ClientCRUD
+	models.UserCRUD
+	models.UserRoleCRUD
+	models.RoleDefinitionCRUD
+	models.GroupCRUD
+	models.GroupRoleCRUD
+}
+
+type GroupRoleController interface {
+	// CreateGroupRole creates a new group-role using the provided model.
+	// The roles must be defined by the client.
+	// Returns any errors.
+	CreateGroupRole(CRUD GroupRoleControllerCRUD, role *models.GroupRole) common.CustomError
+
+	// GetGroupRolesByClientUID gets all the group-roles for the client with the given uid.
+	// Returns the group-role models and any errors.
+	GetGroupRolesByClientUID(CRUD GroupRoleControllerCRUD, clientUID uuid.UUID) ([]*models.GroupRole, common.CustomError)
+
+	// UpdateGroupRole updates the given group-role.
+	// The roles must be defined by the client.
+	// Returns any errors.
+	UpdateGroupRole(CRUD GroupRoleControllerCRUD, role *models.GroupRole) common.CustomError
+
+	// DeleteGroupRole deletes the group-role with the given client uid and group name.
+	// Returns any errors.
+	DeleteGroupRole(CRUD GroupRoleControllerCRUD, clientUID uuid.UUID, groupName string) common.CustomError
+
+	// GetUserRoleAssignments gets every role assignment the user with the given username has for the client with the given uid, in order of precedence.
+	// The first assignment decides the roles in the user's tokens for the client.
+	// Returns the role assignments and any errors.
+	GetUserRoleAssignments(CRUD GroupRoleControllerCRUD, clientUID uuid.UUID, username string) ([]*models.RoleAssignment, common.CustomError)
+}
+
 // AuthControllerCRUD encapsulates the CRUD operations required by the AuthController.
 type AuthControllerCRUD interface {
 	models.UserCRUD
 }
 
+// TwoFactorAuthControllerCRUD encapsulates the CRUD operations required by the AuthController to authenticate users with two-factor authentication.
+type TwoFactorAuthControllerCRUD interface {
+	models.UserCRUD
+	models.RecoveryCodeCRUD
+}
+
+// UserAuthControllerCRUD encapsulates the CRUD operations required by the AuthController to authenticate users, change expired passwords, throttle failed logins, and audit logins.
+type UserAuthControllerCRUD interface {
+	models.UserCRUD
+	models.RecoveryCodeCRUD
+	models.PreviousPasswordCRUD
+	models.LoginThrottleCRUD
+	models.AuditCRUD
+}
+
+// ClientAuthControllerCRUD encapsulates the CRUD operations required by the AuthController to authenticate clients.
+type ClientAuthControllerCRUD interface {
+	models.ClientCRUD
+}
+
+// UserCredentials contains the credentials a user signs in with.
+// Users with two-factor authentication enabled first sign in with their username and password,
+// then with the challenge returned from that step and either a TOTP or recovery code.
+// Users with an expired password then complete a final challenge with their new password.
+type UserCredentials struct {
+	Username    string
+	Password    string
+	Challenge   string
+	Code        string
+	NewPassword string
+
+	// IPAddress is the address the user is signing in from, used to throttle failed logins and recorded in the audit log.
+	IPAddress string
+}
+
 type AuthController interface {
-	// AuthenticateUserWithPassword authenticates a user with their username and password.
+	// AuthenticateUserWithPassword authenticates a user with their username and password. Disabled users cannot authenticate.
 	// Returns the user if authentication was successful, or nil if not.
 	// Also returns any errors.
 	AuthenticateUserWithPassword(CRUD AuthControllerCRUD, username string, password string) (*models.User, common.CustomError)
+
+	// AuthenticateUser authenticates a user with either their username and password, a two-factor challenge and code, or a change password challenge and new password.
+	// If the password is correct but the user has two-factor authentication enabled, returns a nil user and a challenge to complete with their code.
+	// If the user is authenticated but their password has expired, returns a nil user and a challenge to complete with a new password.
+	// Otherwise returns the user if authentication was successful, or nil if not.
+	// Failed attempts are counted against the username and ip address, and either is locked out once it has too many failures.
+	// Completed and failed logins are both recorded in the audit log.
+	// Also returns any errors.
+	AuthenticateUser(CRUD UserAuthControllerCRUD, creds UserCredentials) (*models.User, string, common.CustomError)
+
+	// VerifyTwoFactorCode verifies the code is either a valid TOTP code for the user, or one of their unused recovery codes.
+	// Recovery codes are deleted once used.
+	// Returns any errors.
+	VerifyTwoFactorCode(CRUD TwoFactorAuthControllerCRUD, user *models.User, code string) common.CustomError
+
+	// AuthenticateClientWithSecret authenticates a client with its uid and secret.
+	// Returns the client if authentication was successful, or nil if not.
+	// Also returns any errors.
+	AuthenticateClientWithSecret(CRUD ClientAuthControllerCRUD, clientUID uuid.UUID, secret string) (*models.Client, common.CustomError)
+
+	// AuthenticateClient authenticates a client with its uid and secret if a secret has been generated for it.
+	// Clients without a secret are public clients and only need to exist.
+	// Returns any errors.
+	AuthenticateClient(CRUD ClientAuthControllerCRUD, clientUID uuid.UUID, secret string) common.CustomError
 }
 
 // SessionControllerCRUD encapsulates the CRUD operations required by the SessionController.
 type SessionControllerCRUD interface {
 	models.UserCRUD
 	models.SessionCRUD
+	models.RecoveryCodeCRUD
+	models.PreviousPasswordCRUD
+	models.LoginThrottleCRUD
+	models.AuditCRUD
 }
 
 type SessionController interface {
-	// CreateSession creates a new session by authorizing the user with a password.
-	// Returns the session model and any errors.
-	CreateSession(CRUD SessionControllerCRUD, username string, password string) (*models.Session, common.CustomError)
+	// CreateSession creates a new session by authenticating the user with their credentials.
+	// If the user still needs to complete two-factor authentication or change their expired password, returns a nil session and the challenge instead.
+	// Returns the session model, the challenge, and any errors.
+	CreateSession(CRUD SessionControllerCRUD, creds UserCredentials) (*models.Session, string, common.CustomError)
 
 	// DeleteSession deletes the session with the given id.
 	// Returns any errors.
@@ -143,6 +413,10 @@ type SessionController interface {
 	// DeleteAllOtherUserSessions deletes all of the sessions for the given username expect the one with the given id.
 	// Returns any errors.
 	DeleteAllOtherUserSessions(CRUD SessionControllerCRUD, username string, id uuid.UUID) common.CustomError
+
+	// DeleteExpiredSessions deletes all sessions that have exceeded the configured lifetime or idle timeout.
+	// Returns any errors.
+	DeleteExpiredSessions(CRUD SessionControllerCRUD) common.CustomError
 }
 
 // TokenControllerCRUD encapsulates the CRUD operations required by the TokenController.
@@ -150,11 +424,171 @@ type TokenControllerCRUD interface {
 	models.UserCRUD
 	models.ClientCRUD
 	models.UserRoleCRUD
+	models.GroupCRUD
+	models.GroupRoleCRUD
+	models.AuthorizationCodeCRUD
+	models.RefreshTokenCRUD
+	models.RecoveryCodeCRUD
+	models.PreviousPasswordCRUD
+	models.LoginThrottleCRUD
+	models.AuditCRUD
+}
+
+// AuthorizationRequest contains the parameters of an OpenID Connect authorization request.
+type AuthorizationRequest struct {
+	ClientUID           uuid.UUID
+	RedirectURI         string
+	Scope               string
+	State               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+// OAuthTokens contains the tokens issued by the oauth token endpoint.
+type OAuthTokens struct {
+	AccessToken  string
+	IDToken      string
+	RefreshToken string
 }
 
 type TokenController interface {
-	// CreateTokenRedirectURL first authenticates using the username and password, then creates a signed JWT for the specified client.
+	// CreateTokenRedirectURL first authenticates using the user's credentials, then creates a signed JWT for the specified client.
+	// The token has the user's effective roles for the client, which are the roles given directly to the user if any, otherwise the roles given to their highest priority group.
 	// The base-64 encoded token string is then appended to the client's redirect url.
-	// Returns the url and any errors.
-	CreateTokenRedirectURL(CRUD TokenControllerCRUD, clientId uuid.UUID, username string, password string) (string, common.CustomError)
+	// If the user still needs to complete two-factor authentication or change their expired password, returns an empty url and the challenge instead.
+	// Returns the url, the challenge, and any errors.
+	CreateTokenRedirectURL(CRUD TokenControllerCRUD, clientId uuid.UUID, creds UserCredentials) (string, string, common.CustomError)
+
+	// GetJSONWebKeySet creates a JWKS containing the public half of every key used to sign default tokens.
+	// Returns the key set and any errors.
+	GetJSONWebKeySet(CRUD TokenControllerCRUD) (*jwthelpers.JWKS, common.CustomError)
+
+	// CreateAuthorizationCodeRedirectURL first authenticates using the user's credentials, then creates a short-lived authorization code for the requested client.
+	// The code and state are then appended to the client's redirect url.
+	// If the user still needs to complete two-factor authentication or change their expired password, returns an empty url and the challenge instead.
+	// Returns the url, the challenge, and any errors.
+	CreateAuthorizationCodeRedirectURL(CRUD TokenControllerCRUD, authReq AuthorizationRequest, creds UserCredentials) (string, string, common.CustomError)
+
+	// ExchangeAuthorizationCode verifies the authorization code and PKCE code verifier, then creates an access token, ID token, and refresh token for the client.
+	// The code can only be exchanged once, and the redirect uri must match the one sent with the authorization request, if any.
+	// Returns the tokens and any errors.
+	ExchangeAuthorizationCode(CRUD TokenControllerCRUD, clientUID uuid.UUID, code uuid.UUID, redirectURI string, codeVerifier string) (*OAuthTokens, common.CustomError)
+
+	// RedeemRefreshToken creates a new access token for the user the refresh token was issued to, and rotates the refresh token.
+	// Reusing a refresh token that has already been rotated revokes every refresh token in its family.
+	// Returns the tokens and any errors.
+	RedeemRefreshToken(CRUD TokenControllerCRUD, clientUID uuid.UUID, refreshToken uuid.UUID) (*OAuthTokens, common.CustomError)
+
+	// CreateClientCredentialsToken authenticates the client using its secret, then creates a signed JWT with the client as the subject.
+	// Returns the token and any errors.
+	CreateClientCredentialsToken(CRUD TokenControllerCRUD, clientUID uuid.UUID, secret string) (string, common.CustomError)
+}
+
+// TwoFactorControllerCRUD encapsulates the CRUD operations required by the TwoFactorController.
+type TwoFactorControllerCRUD interface {
+	models.UserCRUD
+	models.RecoveryCodeCRUD
+}
+
+type TwoFactorController interface {
+	// BeginTOTPEnrollment generates a new TOTP secret for the user with the given username, replacing any unconfirmed one.
+	// The secret is not used to authenticate until the enrollment is confirmed.
+	// Returns the provisioning uri for authenticator apps and any errors.
+	BeginTOTPEnrollment(CRUD TwoFactorControllerCRUD, username string) (string, common.CustomError)
+
+	// ConfirmTOTPEnrollment verifies the code against the user's unconfirmed secret, then enables two-factor authentication.
+	// Returns a new set of one-time recovery codes and any errors.
+	ConfirmTOTPEnrollment(CRUD TwoFactorControllerCRUD, username string, code string) ([]string, common.CustomError)
+
+	// DisableTOTP verifies the TOTP or recovery code, then disables two-factor authentication and deletes the user's recovery codes.
+	// Users whose rank requires two-factor authentication cannot disable it.
+	// Returns any errors.
+	DisableTOTP(CRUD TwoFactorControllerCRUD, username string, code string) common.CustomError
+}
+
+// LockoutControllerCRUD encapsulates the CRUD operations required by the LockoutController.
+type LockoutControllerCRUD interface {
+	models.LoginThrottleCRUD
+}
+
+type LockoutController interface {
+	// GetLockouts gets the login throttles for all usernames and ip addresses that are currently locked out.
+	// Returns the login throttles and any errors.
+	GetLockouts(CRUD LockoutControllerCRUD) ([]*models.LoginThrottle, common.CustomError)
+
+	// ClearLockout deletes the login throttle with the given type and key, which unlocks it and resets its failed attempts.
+	// Returns any errors.
+	ClearLockout(CRUD LockoutControllerCRUD, throttleType string, key string) common.CustomError
+}
+
+// AuditControllerCRUD encapsulates the CRUD operations required by the AuditController.
+type AuditControllerCRUD interface {
+	models.AuditCRUD
+}
+
+type AuditController interface {
+	// CreateAuditEvent saves the audit event.
+	// It should be called with the same CRUD as the action being audited, so the event is only saved if the action is.
+	// Returns any errors.
+	CreateAuditEvent(CRUD AuditControllerCRUD, event *models.AuditEvent) common.CustomError
+
+	// GetAuditEvents gets the audit events that match the filter, ordered from newest to oldest.
+	// A zero limit uses the default page size, and the limit can't exceed the max page size.
+	// Returns the audit events and any errors.
+	GetAuditEvents(CRUD AuditControllerCRUD, filter models.AuditEventFilter) ([]*models.AuditEvent, common.CustomError)
+}
+
+// PasswordResetControllerCRUD encapsulates the CRUD operations required by the PasswordResetController.
+type PasswordResetControllerCRUD interface {
+	models.UserCRUD
+	models.SessionCRUD
+	models.PreviousPasswordCRUD
+	models.PasswordResetTokenCRUD
+}
+
+type PasswordResetController interface {
+	// RequestPasswordReset creates a single-use password reset token for the user with the given email, then emails them a link to reset their password with it.
+	// The link points to the configured public url. If no enabled user has the email, nothing is sent but no error is returned either, so the result doesn't reveal which emails are in use.
+	// Returns any errors.
+	RequestPasswordReset(CRUD PasswordResetControllerCRUD, email string) common.CustomError
+
+	// ResetPassword verifies the password reset token, then updates the password of the user it was created for and deletes all of their sessions.
+	// Once the password is updated, all of the user's password reset tokens are deleted so none of them can be used again.
+	// Returns the user's username and any errors.
+	ResetPassword(CRUD PasswordResetControllerCRUD, token string, password string) (string, common.CustomError)
+}
+
+// InvitationControllerCRUD encapsulates the CRUD operations required by the InvitationController.
+type InvitationControllerCRUD interface {
+	models.UserCRUD
+	models.SessionCRUD
+	models.PreviousPasswordCRUD
+	models.ClientCRUD
+	models.UserRoleCRUD
+	models.RoleDefinitionCRUD
+	models.InvitationCRUD
+}
+
+type InvitationController interface {
+	// CreateInvitation creates a single-use invitation for a new user with the given rank and client roles, which expires after the configured lifetime.
+	// Each role must be defined by its client.
+	// If an email is given, the new user is given it and a link to accept the invitation is emailed to it. The link points to the configured public url.
+	// Only the hash of the invitation's token is stored, so the link is only returned here.
+	// Returns the invitation model, the link, and any errors.
+	CreateInvitation(CRUD InvitationControllerCRUD, email string, rank int, roles []*models.InvitationRole) (*models.Invitation, string, common.CustomError)
+
+	// DeleteInvitation deletes the invitation with the given id so it can no longer be accepted.
+	// Returns any errors.
+	DeleteInvitation(CRUD InvitationControllerCRUD, id uuid.UUID) common.CustomError
+
+	// VerifyInvitationRank verifies the invitation with the given id has a rank less than or equal to the provided rank.
+	// Returns result and any errors.
+	VerifyInvitationRank(CRUD InvitationControllerCRUD, id uuid.UUID, rank int) (bool, common.CustomError)
+
+	// AcceptInvitation verifies the invitation token, then creates a user with the chosen username and password, and the invitation's email, rank, and client roles.
+	// Roles for clients or role definitions that have since been deleted are skipped. The invitation is deleted before the user is created so it can only be accepted once,
+	// so any errors returned after that need to roll back the changes for the invitation to be kept.
+	// Returns the user model and any errors.
+	AcceptInvitation(CRUD InvitationControllerCRUD, token string, username string, password string) (*models.User, common.CustomError)
 }
@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+)
+
+type CoreGroupController struct{}
+
+func (c CoreGroupController) CreateGroup(CRUD GroupControllerCRUD, group *models.Group) common.CustomError {
+	//validate the model
+	cerr := c.validateGroup(group)
+	if cerr.Type != common.ErrorTypeNone {
+		return cerr
+	}
+
+	//verify the group does not already exist
+	existingGroup, err := CRUD.GetGroupByName(group.Name)
+	if err != nil {
+		log.Println(common.ChainError("error getting group by name", err))
+		return common.InternalError()
+	}
+	if existingGroup != nil {
+		return common.ClientError(fmt.Sprintf("group %s already exists", group.Name))
+	}
+
+	//create the group
+	err = CRUD.CreateGroup(group)
+	if err != nil {
+		log.Println(common.ChainError("error creating group", err))
+		return common.InternalError()
+	}
+
+	return common.NoError()
+}
+
+func (CoreGroupController) GetGroups(CRUD GroupControllerCRUD) ([]*models.Group, common.CustomError) {
+	groups, err := CRUD.GetGroups()
+	if err != nil {
+		log.Println(common.ChainError("error getting groups", err))
+		return nil, common.InternalError()
+	}
+
+	return groups, common.NoError()
+}
+
+func (c CoreGroupController) UpdateGroup(CRUD GroupControllerCRUD, group *models.Group) common.CustomError {
+	//validate the model
+	cerr := c.validateGroup(group)
+	if cerr.Type != common.ErrorTypeNone {
+		return cerr
+	}
+
+	//update the group
+	res, err := CRUD.UpdateGroup(group)
+	if err != nil {
+		log.Println(common.ChainError("error updating group", err))
+		return common.InternalError()
+	}
+
+	//verify the group was found
+	if !res {
+		return common.ClientError(fmt.Sprintf("group %s not found", group.Name))
+	}
+
+	return common.NoError()
+}
+
+func (CoreGroupController) DeleteGroup(CRUD GroupControllerCRUD, name string) common.CustomError {
+	res, err := CRUD.DeleteGroup(name)
+	if err != nil {
+		log.Println(common.ChainError("error deleting group", err))
+		return common.InternalError()
+	}
+
+	//verify the group was found
+	if !res {
+		return common.ClientError(fmt.Sprintf("group %s not found", name))
+	}
+
+	return common.NoError()
+}
+
+func (c CoreGroupController) GetGroupMembers(CRUD GroupControllerCRUD, name string) ([]string, common.CustomError) {
+	//verify the group exists
+	cerr := c.verifyGroupExists(CRUD, name)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
+	}
+
+	//get the group's members
+	usernames, err := CRUD.GetGroupMemberUsernames(name)
+	if err != nil {
+		log.Println(common.ChainError("error getting group member usernames", err))
+		return nil, common.InternalError()
+	}
+
+	return usernames, common.NoError()
+}
+
+func (c CoreGroupController) CreateGroupMember(CRUD GroupControllerCRUD, name string, username string) common.CustomError {
+	//verify the group exists
+	cerr := c.verifyGroupExists(CRUD, name)
+	if cerr.Type != common.ErrorTypeNone {
+		return cerr
+	}
+
+	//verify the user exists
+	user, err := CRUD.GetUserByUsername(username)
+	if err != nil {
+		log.Println(common.ChainError("error getting user by username", err))
+		return common.InternalError()
+	}
+	if user == nil {
+		return common.ClientError(fmt.Sprintf("user with username %s not found", username))
+	}
+
+	//verify the user is not already a member of the group
+	groups, err := CRUD.GetGroupsByUsername(username)
+	if err != nil {
+		log.Println(common.ChainError("error getting groups by username", err))
+		return common.InternalError()
+	}
+	if models.ContainsGroup(groups, name) {
+		return common.ClientError(fmt.Sprintf("user %s is already a member of group %s", username, name))
+	}
+
+	//add the user to the group
+	err = CRUD.CreateGroupMember(name, username)
+	if err != nil {
+		log.Println(common.ChainError("error creating group member", err))
+		return common.InternalError()
+	}
+
+	return common.NoError()
+}
+
+func (CoreGroupController) DeleteGroupMember(CRUD GroupControllerCRUD, name string, username string) common.CustomError {
+	res, err := CRUD.DeleteGroupMember(name, username)
+	if err != nil {
+		log.Println(common.ChainError("error deleting group member", err))
+		return common.InternalError()
+	}
+
+	//verify the user was a member of the group
+	if !res {
+		return common.ClientError(fmt.Sprintf("user %s is not a member of group %s", username, name))
+	}
+
+	return common.NoError()
+}
+
+func (c CoreGroupController) VerifyGroupRank(CRUD GroupControllerCRUD, name string, rank int) (bool, common.CustomError) {
+	//get the group's members
+	usernames, cerr := c.GetGroupMembers(CRUD, name)
+	if cerr.Type != common.ErrorTypeNone {
+		return false, cerr
+	}
+
+	//verify the rank of each member
+	for _, username := range usernames {
+		user, err := CRUD.GetUserByUsername(username)
+		if err != nil {
+			log.Println(common.ChainError("error getting user by username", err))
+			return false, common.InternalError()
+		}
+
+		//members are removed along with their user, so in practice the user should always be found
+		if user != nil && user.Rank >= rank {
+			return false, common.NoError()
+		}
+	}
+
+	return true, common.NoError()
+}
+
+func (CoreGroupController) validateGroup(group *models.Group) common.CustomError {
+	verr := group.Validate()
+
+	if verr&models.ValidateGroupEmptyName != 0 {
+		return common.ClientError("group name cannot be empty")
+	}
+	if verr&models.ValidateGroupNameTooLong != 0 {
+		return common.ClientError(fmt.Sprint("group name cannot be longer than ", models.GroupNameMaxLength, " characters"))
+	}
+	if verr&models.ValidateGroupDescriptionTooLong != 0 {
+		return common.ClientError(fmt.Sprint("group description cannot be longer than ", models.GroupDescriptionMaxLength, " characters"))
+	}
+
+	return common.NoError()
+}
+
+func (CoreGroupController) verifyGroupExists(CRUD GroupControllerCRUD, name string) common.CustomError {
+	group, err := CRUD.GetGroupByName(name)
+	if err != nil {
+		log.Println(common.ChainError("error getting group by name", err))
+		return common.InternalError()
+	}
+	if group == nil {
+		return common.ClientError(fmt.Sprintf("group %s not found", name))
+	}
+
+	return common.NoError()
+}
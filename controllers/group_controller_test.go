@@ -0,0 +1,487 @@
+package controllers_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/controllers"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type GroupControllerTestSuite struct {
+	ControllerTestSuite
+	GroupController controllers.CoreGroupController
+}
+
+func (suite *GroupControllerTestSuite) SetupTest() {
+	suite.ControllerTestSuite.SetupTest()
+	suite.GroupController = controllers.CoreGroupController{}
+}
+
+func (suite *GroupControllerTestSuite) runValidateGroupTestCases(validateFunc func(group *models.Group) common.CustomError) {
+	suite.Run("EmptyName_ReturnsClientError", func() {
+		//arrange
+		group := models.CreateGroup("", "", 0)
+
+		//act
+		cerr := validateFunc(group)
+
+		//assert
+		suite.CustomClientError(cerr, "group name", "cannot be empty")
+	})
+
+	suite.Run("NameTooLong_ReturnsClientError", func() {
+		//arrange
+		group := models.CreateGroup(helpers.CreateStringOfLength(models.GroupNameMaxLength+1), "", 0)
+
+		//act
+		cerr := validateFunc(group)
+
+		//assert
+		suite.CustomClientError(cerr, "group name", "cannot be longer", fmt.Sprint(models.GroupNameMaxLength))
+	})
+
+	suite.Run("DescriptionTooLong_ReturnsClientError", func() {
+		//arrange
+		group := models.CreateGroup("group", helpers.CreateStringOfLength(models.GroupDescriptionMaxLength+1), 0)
+
+		//act
+		cerr := validateFunc(group)
+
+		//assert
+		suite.CustomClientError(cerr, "group description", "cannot be longer", fmt.Sprint(models.GroupDescriptionMaxLength))
+	})
+}
+
+func (suite *GroupControllerTestSuite) TestCreateGroup_ValidateGroupTestCases() {
+	suite.runValidateGroupTestCases(func(group *models.Group) common.CustomError {
+		return suite.GroupController.CreateGroup(&suite.CRUDMock, group)
+	})
+}
+
+func (suite *GroupControllerTestSuite) TestCreateGroup_WithErrorGettingGroupByName_ReturnsInternalError() {
+	//arrange
+	group := models.CreateGroup("group", "", 0)
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.GroupController.CreateGroup(&suite.CRUDMock, group)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupControllerTestSuite) TestCreateGroup_WhereGroupAlreadyExists_ReturnsClientError() {
+	//arrange
+	group := models.CreateGroup("group", "", 0)
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(&models.Group{}, nil)
+
+	//act
+	cerr := suite.GroupController.CreateGroup(&suite.CRUDMock, group)
+
+	//assert
+	suite.CustomClientError(cerr, "group", group.Name, "already exists")
+	suite.CRUDMock.AssertNotCalled(suite.T(), "CreateGroup", mock.Anything)
+}
+
+func (suite *GroupControllerTestSuite) TestCreateGroup_WithErrorCreatingGroup_ReturnsInternalError() {
+	//arrange
+	group := models.CreateGroup("group", "", 0)
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("CreateGroup", mock.Anything).Return(errors.New(""))
+
+	//act
+	cerr := suite.GroupController.CreateGroup(&suite.CRUDMock, group)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupControllerTestSuite) TestCreateGroup_WithNoErrors_ReturnsNoError() {
+	//arrange
+	group := models.CreateGroup("group", "description", 5)
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("CreateGroup", mock.Anything).Return(nil)
+
+	//act
+	cerr := suite.GroupController.CreateGroup(&suite.CRUDMock, group)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetGroupByName", group.Name)
+	suite.CRUDMock.AssertCalled(suite.T(), "CreateGroup", group)
+}
+
+func (suite *GroupControllerTestSuite) TestGetGroups_WithErrorGettingGroups_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetGroups").Return(nil, errors.New(""))
+
+	//act
+	groups, cerr := suite.GroupController.GetGroups(&suite.CRUDMock)
+
+	//assert
+	suite.Nil(groups)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupControllerTestSuite) TestGetGroups_WithNoErrors_ReturnsGroups() {
+	//arrange
+	groups := []*models.Group{
+		models.CreateGroup("group1", "", 0),
+		models.CreateGroup("group2", "", 1),
+	}
+	suite.CRUDMock.On("GetGroups").Return(groups, nil)
+
+	//act
+	resultGroups, cerr := suite.GroupController.GetGroups(&suite.CRUDMock)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Equal(groups, resultGroups)
+}
+
+func (suite *GroupControllerTestSuite) TestUpdateGroup_ValidateGroupTestCases() {
+	suite.runValidateGroupTestCases(func(group *models.Group) common.CustomError {
+		return suite.GroupController.UpdateGroup(&suite.CRUDMock, group)
+	})
+}
+
+func (suite *GroupControllerTestSuite) TestUpdateGroup_WithErrorUpdatingGroup_ReturnsInternalError() {
+	//arrange
+	group := models.CreateGroup("group", "", 0)
+	suite.CRUDMock.On("UpdateGroup", mock.Anything).Return(false, errors.New(""))
+
+	//act
+	cerr := suite.GroupController.UpdateGroup(&suite.CRUDMock, group)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupControllerTestSuite) TestUpdateGroup_WhereGroupIsNotFound_ReturnsClientError() {
+	//arrange
+	group := models.CreateGroup("group", "", 0)
+	suite.CRUDMock.On("UpdateGroup", mock.Anything).Return(false, nil)
+
+	//act
+	cerr := suite.GroupController.UpdateGroup(&suite.CRUDMock, group)
+
+	//assert
+	suite.CustomClientError(cerr, "group", group.Name, "not found")
+}
+
+func (suite *GroupControllerTestSuite) TestUpdateGroup_WithNoErrors_ReturnsNoError() {
+	//arrange
+	group := models.CreateGroup("group", "description", 3)
+	suite.CRUDMock.On("UpdateGroup", mock.Anything).Return(true, nil)
+
+	//act
+	cerr := suite.GroupController.UpdateGroup(&suite.CRUDMock, group)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "UpdateGroup", group)
+}
+
+func (suite *GroupControllerTestSuite) TestDeleteGroup_WithErrorDeletingGroup_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("DeleteGroup", mock.Anything).Return(false, errors.New(""))
+
+	//act
+	cerr := suite.GroupController.DeleteGroup(&suite.CRUDMock, "group")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupControllerTestSuite) TestDeleteGroup_WhereGroupIsNotFound_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("DeleteGroup", mock.Anything).Return(false, nil)
+
+	//act
+	cerr := suite.GroupController.DeleteGroup(&suite.CRUDMock, "group")
+
+	//assert
+	suite.CustomClientError(cerr, "group", "not found")
+}
+
+func (suite *GroupControllerTestSuite) TestDeleteGroup_WithNoErrors_ReturnsNoError() {
+	//arrange
+	name := "group"
+	suite.CRUDMock.On("DeleteGroup", mock.Anything).Return(true, nil)
+
+	//act
+	cerr := suite.GroupController.DeleteGroup(&suite.CRUDMock, name)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteGroup", name)
+}
+
+func (suite *GroupControllerTestSuite) TestGetGroupMembers_WithErrorGettingGroupByName_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	usernames, cerr := suite.GroupController.GetGroupMembers(&suite.CRUDMock, "group")
+
+	//assert
+	suite.Nil(usernames)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupControllerTestSuite) TestGetGroupMembers_WhereGroupIsNotFound_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(nil, nil)
+
+	//act
+	usernames, cerr := suite.GroupController.GetGroupMembers(&suite.CRUDMock, "group")
+
+	//assert
+	suite.Nil(usernames)
+	suite.CustomClientError(cerr, "group", "not found")
+}
+
+func (suite *GroupControllerTestSuite) TestGetGroupMembers_WithErrorGettingGroupMemberUsernames_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(&models.Group{}, nil)
+	suite.CRUDMock.On("GetGroupMemberUsernames", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	usernames, cerr := suite.GroupController.GetGroupMembers(&suite.CRUDMock, "group")
+
+	//assert
+	suite.Nil(usernames)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupControllerTestSuite) TestGetGroupMembers_WithNoErrors_ReturnsUsernames() {
+	//arrange
+	name := "group"
+	usernames := []string{"user1", "user2"}
+
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(&models.Group{}, nil)
+	suite.CRUDMock.On("GetGroupMemberUsernames", mock.Anything).Return(usernames, nil)
+
+	//act
+	resultUsernames, cerr := suite.GroupController.GetGroupMembers(&suite.CRUDMock, name)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Equal(usernames, resultUsernames)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetGroupMemberUsernames", name)
+}
+
+func (suite *GroupControllerTestSuite) TestCreateGroupMember_WhereGroupIsNotFound_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(nil, nil)
+
+	//act
+	cerr := suite.GroupController.CreateGroupMember(&suite.CRUDMock, "group", "username")
+
+	//assert
+	suite.CustomClientError(cerr, "group", "not found")
+}
+
+func (suite *GroupControllerTestSuite) TestCreateGroupMember_WithErrorGettingUserByUsername_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(&models.Group{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.GroupController.CreateGroupMember(&suite.CRUDMock, "group", "username")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupControllerTestSuite) TestCreateGroupMember_WhereUserIsNotFound_ReturnsClientError() {
+	//arrange
+	username := "username"
+
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(&models.Group{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
+
+	//act
+	cerr := suite.GroupController.CreateGroupMember(&suite.CRUDMock, "group", username)
+
+	//assert
+	suite.CustomClientError(cerr, "user", username, "not found")
+}
+
+func (suite *GroupControllerTestSuite) TestCreateGroupMember_WithErrorGettingGroupsByUsername_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(&models.Group{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetGroupsByUsername", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.GroupController.CreateGroupMember(&suite.CRUDMock, "group", "username")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupControllerTestSuite) TestCreateGroupMember_WhereUserIsAlreadyMember_ReturnsClientError() {
+	//arrange
+	name := "group"
+	username := "username"
+
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(&models.Group{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetGroupsByUsername", mock.Anything).Return([]*models.Group{models.CreateGroup(name, "", 0)}, nil)
+
+	//act
+	cerr := suite.GroupController.CreateGroupMember(&suite.CRUDMock, name, username)
+
+	//assert
+	suite.CustomClientError(cerr, username, "already a member", name)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "CreateGroupMember", mock.Anything, mock.Anything)
+}
+
+func (suite *GroupControllerTestSuite) TestCreateGroupMember_WithErrorCreatingGroupMember_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(&models.Group{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetGroupsByUsername", mock.Anything).Return([]*models.Group{}, nil)
+	suite.CRUDMock.On("CreateGroupMember", mock.Anything, mock.Anything).Return(errors.New(""))
+
+	//act
+	cerr := suite.GroupController.CreateGroupMember(&suite.CRUDMock, "group", "username")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupControllerTestSuite) TestCreateGroupMember_WithNoErrors_ReturnsNoError() {
+	//arrange
+	name := "group"
+	username := "username"
+
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(&models.Group{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetGroupsByUsername", mock.Anything).Return([]*models.Group{models.CreateGroup("other", "", 0)}, nil)
+	suite.CRUDMock.On("CreateGroupMember", mock.Anything, mock.Anything).Return(nil)
+
+	//act
+	cerr := suite.GroupController.CreateGroupMember(&suite.CRUDMock, name, username)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetGroupByName", name)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetUserByUsername", username)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetGroupsByUsername", username)
+	suite.CRUDMock.AssertCalled(suite.T(), "CreateGroupMember", name, username)
+}
+
+func (suite *GroupControllerTestSuite) TestDeleteGroupMember_WithErrorDeletingGroupMember_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("DeleteGroupMember", mock.Anything, mock.Anything).Return(false, errors.New(""))
+
+	//act
+	cerr := suite.GroupController.DeleteGroupMember(&suite.CRUDMock, "group", "username")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupControllerTestSuite) TestDeleteGroupMember_WhereUserIsNotMember_ReturnsClientError() {
+	//arrange
+	name := "group"
+	username := "username"
+
+	suite.CRUDMock.On("DeleteGroupMember", mock.Anything, mock.Anything).Return(false, nil)
+
+	//act
+	cerr := suite.GroupController.DeleteGroupMember(&suite.CRUDMock, name, username)
+
+	//assert
+	suite.CustomClientError(cerr, username, "not a member", name)
+}
+
+func (suite *GroupControllerTestSuite) TestDeleteGroupMember_WithNoErrors_ReturnsNoError() {
+	//arrange
+	name := "group"
+	username := "username"
+
+	suite.CRUDMock.On("DeleteGroupMember", mock.Anything, mock.Anything).Return(true, nil)
+
+	//act
+	cerr := suite.GroupController.DeleteGroupMember(&suite.CRUDMock, name, username)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteGroupMember", name, username)
+}
+
+func (suite *GroupControllerTestSuite) TestVerifyGroupRank_WhereGroupIsNotFound_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(nil, nil)
+
+	//act
+	_, cerr := suite.GroupController.VerifyGroupRank(&suite.CRUDMock, "group", 0)
+
+	//assert
+	suite.CustomClientError(cerr, "group", "not found")
+}
+
+func (suite *GroupControllerTestSuite) TestVerifyGroupRank_WithErrorGettingUserByUsername_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(&models.Group{}, nil)
+	suite.CRUDMock.On("GetGroupMemberUsernames", mock.Anything).Return([]string{"username"}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	_, cerr := suite.GroupController.VerifyGroupRank(&suite.CRUDMock, "group", 0)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupControllerTestSuite) TestVerifyGroupRank_WithNoErrors_TestCases() {
+	//arrange
+	name := "group"
+	users := []*models.User{
+		models.CreateUser("user1", 2, nil),
+		models.CreateUser("user2", 5, nil),
+	}
+
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(&models.Group{}, nil)
+	suite.CRUDMock.On("GetGroupMemberUsernames", mock.Anything).Return([]string{users[0].Username, users[1].Username}, nil)
+	suite.CRUDMock.On("GetUserByUsername", users[0].Username).Return(users[0], nil)
+	suite.CRUDMock.On("GetUserByUsername", users[1].Username).Return(users[1], nil)
+
+	var rank int
+	expectedResult := false
+
+	testCase := func() {
+		//act
+		res, cerr := suite.GroupController.VerifyGroupRank(&suite.CRUDMock, name, rank)
+
+		//assert
+		suite.CustomNoError(cerr)
+		suite.Equal(expectedResult, res)
+	}
+
+	rank = 4
+	suite.Run("RankLessThanMember_ReturnsFalseResult", testCase)
+
+	rank = 5
+	suite.Run("RankEqualToMember_ReturnsFalseResult", testCase)
+
+	rank = 6
+	expectedResult = true
+	suite.Run("RankGreaterThanAllMembers_ReturnsTrueResult", testCase)
+
+	suite.CRUDMock.AssertCalled(suite.T(), "GetGroupMemberUsernames", name)
+}
+
+func TestGroupControllerTestSuite(t *testing.T) {
+	suite.Run(t, &GroupControllerTestSuite{})
+}
@@ -0,0 +1,201 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+// roleAssignmentCRUD encapsulates the CRUD operations required to resolve the roles a user has for a client.
+type roleAssignmentCRUD interface {
+	models.UserRoleCRUD
+	models.GroupCRUD
+	models.GroupRoleCRUD
+}
+
+type CoreGroupRoleController struct{}
+
+func (c CoreGroupRoleController) CreateGroupRole(CRUD GroupRoleControllerCRUD, role *models.GroupRole) common.CustomError {
+	//validate the model
+	cerr := validateRoles(CRUD, role.ClientUID, role.Roles, role.Validate())
+	if cerr.Type != common.ErrorTypeNone {
+		return cerr
+	}
+
+	//verify the group exists
+	group, err := CRUD.GetGroupByName(role.GroupName)
+	if err != nil {
+		log.Println(common.ChainError("error getting group by name", err))
+		return common.InternalError()
+	}
+	if group == nil {
+		return common.ClientError(fmt.Sprintf("group %s not found", role.GroupName))
+	}
+
+	//verify the group does not already have roles for the client
+	existingRole, err := CRUD.GetGroupRoleByClientUIDAndGroupName(role.ClientUID, role.GroupName)
+	if err != nil {
+		log.Println(common.ChainError("error getting group-role by client uid and group name", err))
+		return common.InternalError()
+	}
+	if existingRole != nil {
+		return common.ClientError(fmt.Sprintf("group %s already has a role for the client", role.GroupName))
+	}
+
+	//create the group-role
+	err = CRUD.CreateGroupRole(role)
+	if err != nil {
+		log.Println(common.ChainError("error creating group-role", err))
+		return common.InternalError()
+	}
+
+	return common.NoError()
+}
+
+func (c CoreGroupRoleController) GetGroupRolesByClientUID(CRUD GroupRoleControllerCRUD, clientUID uuid.UUID) ([]*models.GroupRole, common.CustomError) {
+	//verify the client exists
+	cerr := c.verifyClientExists(CRUD, clientUID)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
+	}
+
+	//get the group-roles
+	roles, err := CRUD.GetGroupRolesByClientUID(clientUID)
+	if err != nil {
+		log.Println(common.ChainError("error getting group-roles by client uid", err))
+		return nil, common.InternalError()
+	}
+
+	return roles, common.NoError()
+}
+
+func (CoreGroupRoleController) UpdateGroupRole(CRUD GroupRoleControllerCRUD, role *models.GroupRole) common.CustomError {
+	//validate the model
+	cerr := validateRoles(CRUD, role.ClientUID, role.Roles, role.Validate())
+	if cerr.Type != common.ErrorTypeNone {
+		return cerr
+	}
+
+	//update the group-role
+	res, err := CRUD.UpdateGroupRole(role)
+	if err != nil {
+		log.Println(common.ChainError("error updating group-role", err))
+		return common.InternalError()
+	}
+
+	//verify the group-role was found
+	if !res {
+		return common.ClientError(fmt.Sprintf("no role found for group %s and client %s", role.GroupName, role.ClientUID.String()))
+	}
+
+	return common.NoError()
+}
+
+func (CoreGroupRoleController) DeleteGroupRole(CRUD GroupRoleControllerCRUD, clientUID uuid.UUID, groupName string) common.CustomError {
+	res, err := CRUD.DeleteGroupRole(clientUID, groupName)
+	if err != nil {
+		log.Println(common.ChainError("error deleting group-role", err))
+		return common.InternalError()
+	}
+
+	//verify the group-role was found
+	if !res {
+		return common.ClientError(fmt.Sprintf("no role found for group %s and client %s", groupName, clientUID.String()))
+	}
+
+	return common.NoError()
+}
+
+func (c CoreGroupRoleController) GetUserRoleAssignments(CRUD GroupRoleControllerCRUD, clientUID uuid.UUID, username string) ([]*models.RoleAssignment, common.CustomError) {
+	//verify the client exists
+	cerr := c.verifyClientExists(CRUD, clientUID)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
+	}
+
+	//verify the user exists
+	user, err := CRUD.GetUserByUsername(username)
+	if err != nil {
+		log.Println(common.ChainError("error getting user by username", err))
+		return nil, common.InternalError()
+	}
+	if user == nil {
+		return nil, common.ClientError(fmt.Sprintf("user with username %s not found", username))
+	}
+
+	//get the roles given directly to the user
+	userRole, err := CRUD.GetUserRoleByClientUIDAndUsername(clientUID, username)
+	if err != nil {
+		log.Println(common.ChainError("error getting user-role by client uid and username", err))
+		return nil, common.InternalError()
+	}
+
+	return getRoleAssignments(CRUD, clientUID, username, userRole)
+}
+
+func (CoreGroupRoleController) verifyClientExists(CRUD GroupRoleControllerCRUD, clientUID uuid.UUID) common.CustomError {
+	client, err := CRUD.GetClientByUID(clientUID)
+	if err != nil {
+		log.Println(common.ChainError("error getting client by uid", err))
+		return common.InternalError()
+	}
+	if client == nil {
+		return common.ClientError(fmt.Sprintf("client with id %s not found", clientUID.String()))
+	}
+
+	return common.NoError()
+}
+
+// getEffectiveRoles gets the roles the user with the given username has for the client with the given uid.
+// These are the roles given directly to the user if there are any, otherwise the roles given to their highest priority group.
+// Returns the roles, which are empty if the user has none for the client, and any errors.
+func getEffectiveRoles(CRUD roleAssignmentCRUD, clientUID uuid.UUID, username string) ([]string, common.CustomError) {
+	userRole, err := CRUD.GetUserRoleByClientUIDAndUsername(clientUID, username)
+	if err != nil {
+		log.Println(common.ChainError("error getting user-role by client uid and username", err))
+		return nil, common.InternalError()
+	}
+
+	//roles given directly to the user take precedence, so there is no need to check their groups
+	if userRole != nil {
+		return userRole.Roles, common.NoError()
+	}
+
+	assignments, cerr := getRoleAssignments(CRUD, clientUID, username, nil)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
+	}
+
+	if len(assignments) == 0 {
+		return []string{}, common.NoError()
+	}
+	return assignments[0].Roles, common.NoError()
+}
+
+// getRoleAssignments gets every role assignment the user with the given username has for the client with the given uid, in order of precedence.
+// The user-role is the roles given directly to the user, and can be nil.
+// Returns the role assignments and any errors.
+func getRoleAssignments(CRUD roleAssignmentCRUD, clientUID uuid.UUID, username string, userRole *models.UserRole) ([]*models.RoleAssignment, common.CustomError) {
+	//get the user's groups
+	groups, err := CRUD.GetGroupsByUsername(username)
+	if err != nil {
+		log.Println(common.ChainError("error getting groups by username", err))
+		return nil, common.InternalError()
+	}
+
+	//only fetch the client's group-roles if the user is in any groups
+	groupRoles := []*models.GroupRole{}
+	if len(groups) > 0 {
+		groupRoles, err = CRUD.GetGroupRolesByClientUID(clientUID)
+		if err != nil {
+			log.Println(common.ChainError("error getting group-roles by client uid", err))
+			return nil, common.InternalError()
+		}
+	}
+
+	return models.ResolveRoleAssignments(userRole, groups, groupRoles), common.NoError()
+}
@@ -0,0 +1,413 @@
+package controllers_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mhogar/amber/controllers"
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type GroupRoleControllerTestSuite struct {
+	ControllerTestSuite
+	GroupRoleController controllers.CoreGroupRoleController
+}
+
+func (suite *GroupRoleControllerTestSuite) SetupTest() {
+	suite.ControllerTestSuite.SetupTest()
+	suite.GroupRoleController = controllers.CoreGroupRoleController{}
+}
+
+func (suite *GroupRoleControllerTestSuite) TestCreateGroupRole_WithNoRoles_ReturnsClientError() {
+	//arrange
+	role := models.CreateGroupRole(uuid.New(), "group")
+
+	//act
+	cerr := suite.GroupRoleController.CreateGroupRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomClientError(cerr, "at least one role")
+}
+
+func (suite *GroupRoleControllerTestSuite) TestCreateGroupRole_WhereRoleIsNotDefined_ReturnsClientError() {
+	//arrange
+	role := models.CreateGroupRole(uuid.New(), "group", "role")
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(nil, nil)
+
+	//act
+	cerr := suite.GroupRoleController.CreateGroupRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomClientError(cerr, "role", "not defined", role.ClientUID.String())
+	suite.CRUDMock.AssertNotCalled(suite.T(), "CreateGroupRole", mock.Anything)
+}
+
+func (suite *GroupRoleControllerTestSuite) TestCreateGroupRole_WithErrorGettingGroupByName_ReturnsInternalError() {
+	//arrange
+	role := models.CreateGroupRole(uuid.New(), "group", "role")
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.GroupRoleController.CreateGroupRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupRoleControllerTestSuite) TestCreateGroupRole_WhereGroupIsNotFound_ReturnsClientError() {
+	//arrange
+	role := models.CreateGroupRole(uuid.New(), "group", "role")
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(nil, nil)
+
+	//act
+	cerr := suite.GroupRoleController.CreateGroupRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomClientError(cerr, "group", role.GroupName, "not found")
+}
+
+func (suite *GroupRoleControllerTestSuite) TestCreateGroupRole_WithErrorGettingGroupRole_ReturnsInternalError() {
+	//arrange
+	role := models.CreateGroupRole(uuid.New(), "group", "role")
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(&models.Group{}, nil)
+	suite.CRUDMock.On("GetGroupRoleByClientUIDAndGroupName", mock.Anything, mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.GroupRoleController.CreateGroupRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupRoleControllerTestSuite) TestCreateGroupRole_WhereGroupAlreadyHasRoleForClient_ReturnsClientError() {
+	//arrange
+	role := models.CreateGroupRole(uuid.New(), "group", "role")
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(&models.Group{}, nil)
+	suite.CRUDMock.On("GetGroupRoleByClientUIDAndGroupName", mock.Anything, mock.Anything).Return(&models.GroupRole{}, nil)
+
+	//act
+	cerr := suite.GroupRoleController.CreateGroupRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomClientError(cerr, "group", role.GroupName, "already has a role")
+	suite.CRUDMock.AssertNotCalled(suite.T(), "CreateGroupRole", mock.Anything)
+}
+
+func (suite *GroupRoleControllerTestSuite) TestCreateGroupRole_WithErrorCreatingGroupRole_ReturnsInternalError() {
+	//arrange
+	role := models.CreateGroupRole(uuid.New(), "group", "role")
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(&models.Group{}, nil)
+	suite.CRUDMock.On("GetGroupRoleByClientUIDAndGroupName", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("CreateGroupRole", mock.Anything).Return(errors.New(""))
+
+	//act
+	cerr := suite.GroupRoleController.CreateGroupRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupRoleControllerTestSuite) TestCreateGroupRole_WithNoErrors_ReturnsNoError() {
+	//arrange
+	role := models.CreateGroupRole(uuid.New(), "group", "role")
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("GetGroupByName", mock.Anything).Return(&models.Group{}, nil)
+	suite.CRUDMock.On("GetGroupRoleByClientUIDAndGroupName", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("CreateGroupRole", mock.Anything).Return(nil)
+
+	//act
+	cerr := suite.GroupRoleController.CreateGroupRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetRoleDefinitionByClientUIDAndName", role.ClientUID, "role")
+	suite.CRUDMock.AssertCalled(suite.T(), "GetGroupByName", role.GroupName)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetGroupRoleByClientUIDAndGroupName", role.ClientUID, role.GroupName)
+	suite.CRUDMock.AssertCalled(suite.T(), "CreateGroupRole", role)
+}
+
+func (suite *GroupRoleControllerTestSuite) TestGetGroupRolesByClientUID_WhereClientIsNotFound_ReturnsClientError() {
+	//arrange
+	uid := uuid.New()
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(nil, nil)
+
+	//act
+	roles, cerr := suite.GroupRoleController.GetGroupRolesByClientUID(&suite.CRUDMock, uid)
+
+	//assert
+	suite.Nil(roles)
+	suite.CustomClientError(cerr, "client", uid.String(), "not found")
+}
+
+func (suite *GroupRoleControllerTestSuite) TestGetGroupRolesByClientUID_WithErrorGettingGroupRoles_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetGroupRolesByClientUID", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	roles, cerr := suite.GroupRoleController.GetGroupRolesByClientUID(&suite.CRUDMock, uuid.New())
+
+	//assert
+	suite.Nil(roles)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupRoleControllerTestSuite) TestGetGroupRolesByClientUID_WithNoErrors_ReturnsGroupRoles() {
+	//arrange
+	uid := uuid.New()
+	roles := []*models.GroupRole{
+		models.CreateGroupRole(uid, "group", "role"),
+	}
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetGroupRolesByClientUID", mock.Anything).Return(roles, nil)
+
+	//act
+	resultRoles, cerr := suite.GroupRoleController.GetGroupRolesByClientUID(&suite.CRUDMock, uid)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Equal(roles, resultRoles)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetGroupRolesByClientUID", uid)
+}
+
+func (suite *GroupRoleControllerTestSuite) TestUpdateGroupRole_WithNoRoles_ReturnsClientError() {
+	//arrange
+	role := models.CreateGroupRole(uuid.New(), "group")
+
+	//act
+	cerr := suite.GroupRoleController.UpdateGroupRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomClientError(cerr, "at least one role")
+}
+
+func (suite *GroupRoleControllerTestSuite) TestUpdateGroupRole_WithErrorUpdatingGroupRole_ReturnsInternalError() {
+	//arrange
+	role := models.CreateGroupRole(uuid.New(), "group", "role")
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("UpdateGroupRole", mock.Anything).Return(false, errors.New(""))
+
+	//act
+	cerr := suite.GroupRoleController.UpdateGroupRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupRoleControllerTestSuite) TestUpdateGroupRole_WithFalseResultUpdatingGroupRole_ReturnsClientError() {
+	//arrange
+	role := models.CreateGroupRole(uuid.New(), "group", "role")
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("UpdateGroupRole", mock.Anything).Return(false, nil)
+
+	//act
+	cerr := suite.GroupRoleController.UpdateGroupRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomClientError(cerr, "no role found", role.GroupName, role.ClientUID.String())
+}
+
+func (suite *GroupRoleControllerTestSuite) TestUpdateGroupRole_WithNoErrors_ReturnsNoError() {
+	//arrange
+	role := models.CreateGroupRole(uuid.New(), "group", "role")
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("UpdateGroupRole", mock.Anything).Return(true, nil)
+
+	//act
+	cerr := suite.GroupRoleController.UpdateGroupRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "UpdateGroupRole", role)
+}
+
+func (suite *GroupRoleControllerTestSuite) TestDeleteGroupRole_WithErrorDeletingGroupRole_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("DeleteGroupRole", mock.Anything, mock.Anything).Return(false, errors.New(""))
+
+	//act
+	cerr := suite.GroupRoleController.DeleteGroupRole(&suite.CRUDMock, uuid.New(), "group")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupRoleControllerTestSuite) TestDeleteGroupRole_WithFalseResultDeletingGroupRole_ReturnsClientError() {
+	//arrange
+	uid := uuid.New()
+	suite.CRUDMock.On("DeleteGroupRole", mock.Anything, mock.Anything).Return(false, nil)
+
+	//act
+	cerr := suite.GroupRoleController.DeleteGroupRole(&suite.CRUDMock, uid, "group")
+
+	//assert
+	suite.CustomClientError(cerr, "no role found", "group", uid.String())
+}
+
+func (suite *GroupRoleControllerTestSuite) TestDeleteGroupRole_WithNoErrors_ReturnsNoError() {
+	//arrange
+	uid := uuid.New()
+	suite.CRUDMock.On("DeleteGroupRole", mock.Anything, mock.Anything).Return(true, nil)
+
+	//act
+	cerr := suite.GroupRoleController.DeleteGroupRole(&suite.CRUDMock, uid, "group")
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteGroupRole", uid, "group")
+}
+
+func (suite *GroupRoleControllerTestSuite) TestGetUserRoleAssignments_WhereClientIsNotFound_ReturnsClientError() {
+	//arrange
+	uid := uuid.New()
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(nil, nil)
+
+	//act
+	assignments, cerr := suite.GroupRoleController.GetUserRoleAssignments(&suite.CRUDMock, uid, "username")
+
+	//assert
+	suite.Nil(assignments)
+	suite.CustomClientError(cerr, "client", uid.String(), "not found")
+}
+
+func (suite *GroupRoleControllerTestSuite) TestGetUserRoleAssignments_WithErrorGettingUserByUsername_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	assignments, cerr := suite.GroupRoleController.GetUserRoleAssignments(&suite.CRUDMock, uuid.New(), "username")
+
+	//assert
+	suite.Nil(assignments)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupRoleControllerTestSuite) TestGetUserRoleAssignments_WhereUserIsNotFound_ReturnsClientError() {
+	//arrange
+	username := "username"
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
+
+	//act
+	assignments, cerr := suite.GroupRoleController.GetUserRoleAssignments(&suite.CRUDMock, uuid.New(), username)
+
+	//assert
+	suite.Nil(assignments)
+	suite.CustomClientError(cerr, "user", username, "not found")
+}
+
+func (suite *GroupRoleControllerTestSuite) TestGetUserRoleAssignments_WithErrorGettingUserRole_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	assignments, cerr := suite.GroupRoleController.GetUserRoleAssignments(&suite.CRUDMock, uuid.New(), "username")
+
+	//assert
+	suite.Nil(assignments)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupRoleControllerTestSuite) TestGetUserRoleAssignments_WithErrorGettingGroupsByUsername_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetGroupsByUsername", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	assignments, cerr := suite.GroupRoleController.GetUserRoleAssignments(&suite.CRUDMock, uuid.New(), "username")
+
+	//assert
+	suite.Nil(assignments)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupRoleControllerTestSuite) TestGetUserRoleAssignments_WithErrorGettingGroupRoles_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetGroupsByUsername", mock.Anything).Return([]*models.Group{models.CreateGroup("group", "", 0)}, nil)
+	suite.CRUDMock.On("GetGroupRolesByClientUID", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	assignments, cerr := suite.GroupRoleController.GetUserRoleAssignments(&suite.CRUDMock, uuid.New(), "username")
+
+	//assert
+	suite.Nil(assignments)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *GroupRoleControllerTestSuite) TestGetUserRoleAssignments_WhereUserHasNoGroups_DoesNotGetGroupRoles() {
+	//arrange
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetGroupsByUsername", mock.Anything).Return([]*models.Group{}, nil)
+
+	//act
+	assignments, cerr := suite.GroupRoleController.GetUserRoleAssignments(&suite.CRUDMock, uuid.New(), "username")
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Empty(assignments)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "GetGroupRolesByClientUID", mock.Anything)
+}
+
+func (suite *GroupRoleControllerTestSuite) TestGetUserRoleAssignments_WithNoErrors_ReturnsRoleAssignmentsInOrderOfPrecedence() {
+	//arrange
+	uid := uuid.New()
+	username := "username"
+
+	userRole := models.CreateUserRole(uid, username, "role1")
+	groups := []*models.Group{
+		models.CreateGroup("group1", "", 1),
+		models.CreateGroup("group2", "", 2),
+	}
+	groupRoles := []*models.GroupRole{
+		models.CreateGroupRole(uid, "group1", "role2"),
+		models.CreateGroupRole(uid, "group2", "role3"),
+	}
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(userRole, nil)
+	suite.CRUDMock.On("GetGroupsByUsername", mock.Anything).Return(groups, nil)
+	suite.CRUDMock.On("GetGroupRolesByClientUID", mock.Anything).Return(groupRoles, nil)
+
+	//act
+	assignments, cerr := suite.GroupRoleController.GetUserRoleAssignments(&suite.CRUDMock, uid, username)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Equal([]*models.RoleAssignment{
+		{Source: models.RoleAssignmentSourceUser, Roles: []string{"role1"}},
+		{Source: models.RoleAssignmentSourceGroup, GroupName: "group2", Priority: 2, Roles: []string{"role3"}},
+		{Source: models.RoleAssignmentSourceGroup, GroupName: "group1", Priority: 1, Roles: []string{"role2"}},
+	}, assignments)
+
+	suite.CRUDMock.AssertCalled(suite.T(), "GetClientByUID", uid)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetUserByUsername", username)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetUserRoleByClientUIDAndUsername", uid, username)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetGroupsByUsername", username)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetGroupRolesByClientUID", uid)
+}
+
+func TestGroupRoleControllerTestSuite(t *testing.T) {
+	suite.Run(t, &GroupRoleControllerTestSuite{})
+}
@@ -0,0 +1,227 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
+	mailhelpers "github.com/mhogar/amber/controllers/mail_helpers"
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+// invalidInvitationTokenMessage is returned for every token that can't be used, so clients can't tell why it was rejected.
+const invalidInvitationTokenMessage = "invitation link is invalid or has expired"
+
+type CoreInvitationController struct {
+	UserController     UserController
+	UserRoleController UserRoleController
+	Mailer             mailhelpers.Mailer
+}
+
+func (c CoreInvitationController) CreateInvitation(CRUD InvitationControllerCRUD, email string, rank int, roles []*models.InvitationRole) (*models.Invitation, string, common.CustomError) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	//generate the token
+	token, err := generateLinkToken()
+	if err != nil {
+		log.Println(common.ChainError("error generating invitation token", err))
+		return nil, "", common.InternalError()
+	}
+
+	//create the invitation model, storing only the hash of the token
+	lifetime := time.Duration(config.GetInvitationConfig().Lifetime) * time.Second
+	invitation := models.CreateNewInvitation(hashLinkToken(token), email, rank, roles, lifetime)
+
+	//validate the invitation
+	cerr := c.validateInvitation(invitation)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, "", cerr
+	}
+
+	//validate email is not already in use
+	if email != "" {
+		user, err := CRUD.GetUserByEmail(email)
+		if err != nil {
+			log.Println(common.ChainError("error getting user by email", err))
+			return nil, "", common.InternalError()
+		}
+		if user != nil {
+			return nil, "", common.ClientError("email is already in use")
+		}
+	}
+
+	//validate the clients exist and define the roles
+	for _, role := range roles {
+		client, err := CRUD.GetClientByUID(role.ClientUID)
+		if err != nil {
+			log.Println(common.ChainError("error getting client by uid", err))
+			return nil, "", common.InternalError()
+		}
+		if client == nil {
+			return nil, "", common.ClientError(fmt.Sprintf("client with id %s not found", role.ClientUID.String()))
+		}
+
+		definition, err := CRUD.GetRoleDefinitionByClientUIDAndName(role.ClientUID, role.Role)
+		if err != nil {
+			log.Println(common.ChainError("error getting role definition by client uid and name", err))
+			return nil, "", common.InternalError()
+		}
+		if definition == nil {
+			return nil, "", common.ClientError(fmt.Sprintf("role %s is not defined for client %s", role.Role, role.ClientUID.String()))
+		}
+	}
+
+	//create the link from the configured url, since the request's host can be set by the client
+	link, err := createLinkURL("/invitation/accept", token)
+	if err != nil {
+		log.Println(common.ChainError("error creating invitation link", err))
+		return nil, "", common.InternalError()
+	}
+
+	//save the invitation
+	err = CRUD.SaveInvitation(invitation)
+	if err != nil {
+		log.Println(common.ChainError("error saving invitation", err))
+		return nil, "", common.InternalError()
+	}
+
+	//send the link to the invitee
+	if email != "" {
+		err = c.Mailer.SendMail(email, fmt.Sprintf("You're invited to %s", config.GetAppName()), createInvitationMailBody(link, lifetime))
+		if err != nil {
+			log.Println(common.ChainError("error sending invitation mail", err))
+			return nil, "", common.InternalError()
+		}
+	}
+
+	return invitation, link, common.NoError()
+}
+
+func (CoreInvitationController) DeleteInvitation(CRUD InvitationControllerCRUD, id uuid.UUID) common.CustomError {
+	//delete the invitation
+	res, err := CRUD.DeleteInvitation(id)
+	if err != nil {
+		log.Println(common.ChainError("error deleting invitation", err))
+		return common.InternalError()
+	}
+
+	//verify invitation was actually found
+	if !res {
+		return common.ClientError(fmt.Sprintf("invitation with id %s not found", id.String()))
+	}
+
+	return common.NoError()
+}
+
+func (CoreInvitationController) VerifyInvitationRank(CRUD InvitationControllerCRUD, id uuid.UUID, rank int) (bool, common.CustomError) {
+	//get the invitation
+	invitation, err := CRUD.GetInvitationByID(id)
+	if err != nil {
+		log.Println(common.ChainError("error getting invitation by id", err))
+		return false, common.InternalError()
+	}
+
+	//verify invitation exists
+	if invitation == nil {
+		return false, common.ClientError(fmt.Sprintf("invitation with id %s not found", id.String()))
+	}
+
+	//verify the rank
+	return invitation.Rank <= rank, common.NoError()
+}
+
+func (c CoreInvitationController) AcceptInvitation(CRUD InvitationControllerCRUD, token string, username string, password string) (*models.User, common.CustomError) {
+	//get the invitation
+	invitation, err := CRUD.GetInvitationByTokenHash(hashLinkToken(token))
+	if err != nil {
+		log.Println(common.ChainError("error getting invitation by token hash", err))
+		return nil, common.InternalError()
+	}
+	if invitation == nil || invitation.IsExpired(time.Now().UTC()) {
+		return nil, common.ClientError(invalidInvitationTokenMessage)
+	}
+
+	//delete the invitation before creating the user so it can't be accepted again
+	res, err := CRUD.DeleteInvitation(invitation.ID)
+	if err != nil {
+		log.Println(common.ChainError("error deleting invitation", err))
+		return nil, common.InternalError()
+	}
+
+	//the invitation was accepted by another request in the meantime
+	if !res {
+		return nil, common.ClientError(invalidInvitationTokenMessage)
+	}
+
+	//create the user
+	profile := models.UserProfile{
+		Email:   invitation.Email,
+		Enabled: true,
+	}
+	user, cerr := c.UserController.CreateUser(CRUD, username, password, invitation.Rank, profile)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
+	}
+
+	//give the user their roles
+	for _, role := range invitation.Roles {
+		definition, err := CRUD.GetRoleDefinitionByClientUIDAndName(role.ClientUID, role.Role)
+		if err != nil {
+			log.Println(common.ChainError("error getting role definition by client uid and name", err))
+			return nil, common.InternalError()
+		}
+
+		//the client or role definition was deleted after the invitation was created
+		if definition == nil {
+			continue
+		}
+
+		cerr = c.UserRoleController.CreateUserRole(CRUD, models.CreateUserRole(role.ClientUID, user.Username, role.Role))
+		if cerr.Type != common.ErrorTypeNone {
+			return nil, cerr
+		}
+	}
+
+	return user, common.NoError()
+}
+
+func (CoreInvitationController) validateInvitation(invitation *models.Invitation) common.CustomError {
+	verr := invitation.Validate()
+
+	if verr&models.ValidateInvitationInvalidRank != 0 {
+		return common.ClientError("rank is invalid")
+	}
+	if verr&models.ValidateInvitationEmailTooLong != 0 {
+		return common.ClientError(fmt.Sprint("email cannot be longer than ", models.UserEmailMaxLength, " characters"))
+	}
+	if verr&models.ValidateInvitationInvalidEmail != 0 {
+		return common.ClientError("email is invalid")
+	}
+	if verr&models.ValidateInvitationEmptyRole != 0 {
+		return common.ClientError("role cannot be empty")
+	}
+	if verr&models.ValidateInvitationRoleTooLong != 0 {
+		return common.ClientError(fmt.Sprint("role cannot be longer than ", models.UserRoleRoleMaxLength, " characters"))
+	}
+	if verr&models.ValidateInvitationDuplicateRoleClient != 0 {
+		return common.ClientError("roles cannot include the same client more than once")
+	}
+
+	return common.NoError()
+}
+
+func createInvitationMailBody(link string, lifetime time.Duration) string {
+	return fmt.Sprintf(
+		"Hi,\n\n"+
+			"You've been invited to create an account for %s. "+
+			"Use the link below to choose your username and password. It can only be used once and expires in %s.\n\n"+
+			"%s\n\n"+
+			"If you weren't expecting this invitation, you can ignore this email.\n",
+		config.GetAppName(), lifetime, link,
+	)
+}
@@ -0,0 +1,524 @@
+package controllers_test
+
+import (
+	"crypto/sha256"
+	"errors"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
+	"github.com/mhogar/amber/controllers"
+	mailmocks "github.com/mhogar/amber/controllers/mail_helpers/mocks"
+	"github.com/mhogar/amber/controllers/mocks"
+	datamocks "github.com/mhogar/amber/data/mocks"
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type InvitationControllerTestSuite struct {
+	ControllerTestSuite
+	ControllersMock      mocks.Controllers
+	MailerMock           mailmocks.Mailer
+	InvitationController controllers.CoreInvitationController
+}
+
+func (suite *InvitationControllerTestSuite) SetupTest() {
+	suite.ControllerTestSuite.SetupTest()
+
+	viper.Set("app_name", "app")
+	viper.Set("server", config.ServerConfig{
+		PublicURL: "http://base",
+	})
+	viper.Set("invitation", config.InvitationConfig{
+		Lifetime: 3600,
+	})
+
+	suite.ControllersMock = mocks.Controllers{}
+	suite.MailerMock = mailmocks.Mailer{}
+	suite.InvitationController = controllers.CoreInvitationController{
+		UserController:     &suite.ControllersMock,
+		UserRoleController: &suite.ControllersMock,
+		Mailer:             &suite.MailerMock,
+	}
+}
+
+func (suite *InvitationControllerTestSuite) createRoles() []*models.InvitationRole {
+	return []*models.InvitationRole{
+		models.CreateInvitationRole(uuid.New(), "role"),
+	}
+}
+
+func (suite *InvitationControllerTestSuite) createInvitation(token string, lifetime time.Duration) *models.Invitation {
+	hash := sha256.Sum256([]byte(token))
+	return models.CreateNewInvitation(hash[:], "user@example.com", 1, suite.createRoles(), lifetime)
+}
+
+func (suite *InvitationControllerTestSuite) TestCreateInvitation_WithInvalidFields_ReturnsClientError() {
+	var email string
+	var rank int
+	var roles []*models.InvitationRole
+	var expectedErrorMessage string
+
+	testCase := func() {
+		//act
+		invitation, link, cerr := suite.InvitationController.CreateInvitation(&suite.CRUDMock, email, rank, roles)
+
+		//assert
+		suite.Nil(invitation)
+		suite.Empty(link)
+		suite.CustomClientError(cerr, expectedErrorMessage)
+	}
+
+	email = ""
+	rank = -1
+	roles = nil
+	expectedErrorMessage = "rank is invalid"
+	suite.Run("NegativeRank", testCase)
+
+	email = "not an email"
+	rank = 0
+	expectedErrorMessage = "email is invalid"
+	suite.Run("InvalidEmail", testCase)
+
+	email = ""
+	roles = []*models.InvitationRole{models.CreateInvitationRole(uuid.New(), "")}
+	expectedErrorMessage = "role cannot be empty"
+	suite.Run("EmptyRole", testCase)
+
+	clientUID := uuid.New()
+	roles = []*models.InvitationRole{
+		models.CreateInvitationRole(clientUID, "role"),
+		models.CreateInvitationRole(clientUID, "other"),
+	}
+	expectedErrorMessage = "same client more than once"
+	suite.Run("DuplicateClient", testCase)
+}
+
+func (suite *InvitationControllerTestSuite) TestCreateInvitation_WithErrorGettingUserByEmail_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByEmail", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	invitation, link, cerr := suite.InvitationController.CreateInvitation(&suite.CRUDMock, "user@example.com", 0, nil)
+
+	//assert
+	suite.Nil(invitation)
+	suite.Empty(link)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *InvitationControllerTestSuite) TestCreateInvitation_WhereEmailIsAlreadyInUse_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByEmail", mock.Anything).Return(models.CreateUser("username", 0, nil), nil)
+
+	//act
+	invitation, link, cerr := suite.InvitationController.CreateInvitation(&suite.CRUDMock, "user@example.com", 0, nil)
+
+	//assert
+	suite.Nil(invitation)
+	suite.Empty(link)
+	suite.CustomClientError(cerr, "email is already in use")
+}
+
+func (suite *InvitationControllerTestSuite) TestCreateInvitation_WithErrorGettingClient_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	invitation, link, cerr := suite.InvitationController.CreateInvitation(&suite.CRUDMock, "", 0, suite.createRoles())
+
+	//assert
+	suite.Nil(invitation)
+	suite.Empty(link)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *InvitationControllerTestSuite) TestCreateInvitation_WhereClientNotFound_ReturnsClientError() {
+	//arrange
+	roles := suite.createRoles()
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(nil, nil)
+
+	//act
+	invitation, link, cerr := suite.InvitationController.CreateInvitation(&suite.CRUDMock, "", 0, roles)
+
+	//assert
+	suite.Nil(invitation)
+	suite.Empty(link)
+	suite.CustomClientError(cerr, "client with id", roles[0].ClientUID.String(), "not found")
+}
+
+func (suite *InvitationControllerTestSuite) TestCreateInvitation_WithErrorGettingRoleDefinition_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	invitation, link, cerr := suite.InvitationController.CreateInvitation(&suite.CRUDMock, "", 0, suite.createRoles())
+
+	//assert
+	suite.Nil(invitation)
+	suite.Empty(link)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *InvitationControllerTestSuite) TestCreateInvitation_WhereRoleIsNotDefined_ReturnsClientError() {
+	//arrange
+	roles := suite.createRoles()
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(nil, nil)
+
+	//act
+	invitation, link, cerr := suite.InvitationController.CreateInvitation(&suite.CRUDMock, "", 0, roles)
+
+	//assert
+	suite.Nil(invitation)
+	suite.Empty(link)
+	suite.CustomClientError(cerr, "role", roles[0].Role, "not defined", roles[0].ClientUID.String())
+	suite.CRUDMock.AssertNotCalled(suite.T(), "SaveInvitation", mock.Anything)
+}
+
+func (suite *InvitationControllerTestSuite) TestCreateInvitation_WithErrorSavingInvitation_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("SaveInvitation", mock.Anything).Return(errors.New(""))
+
+	//act
+	invitation, link, cerr := suite.InvitationController.CreateInvitation(&suite.CRUDMock, "", 0, nil)
+
+	//assert
+	suite.Nil(invitation)
+	suite.Empty(link)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *InvitationControllerTestSuite) TestCreateInvitation_WithErrorSendingMail_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByEmail", mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("SaveInvitation", mock.Anything).Return(nil)
+	suite.MailerMock.On("SendMail", mock.Anything, mock.Anything, mock.Anything).Return(errors.New(""))
+
+	//act
+	invitation, link, cerr := suite.InvitationController.CreateInvitation(&suite.CRUDMock, "user@example.com", 0, nil)
+
+	//assert
+	suite.Nil(invitation)
+	suite.Empty(link)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *InvitationControllerTestSuite) TestCreateInvitation_WithoutEmail_SavesTokenHashAndReturnsLinkWithoutSendingMail() {
+	//arrange
+	roles := suite.createRoles()
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("SaveInvitation", mock.Anything).Return(nil)
+
+	//act
+	invitation, link, cerr := suite.InvitationController.CreateInvitation(&suite.CRUDMock, "", 2, roles)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetClientByUID", roles[0].ClientUID)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetRoleDefinitionByClientUIDAndName", roles[0].ClientUID, roles[0].Role)
+	suite.CRUDMock.AssertCalled(suite.T(), "SaveInvitation", invitation)
+	suite.MailerMock.AssertNotCalled(suite.T(), "SendMail", mock.Anything, mock.Anything, mock.Anything)
+
+	suite.Equal(2, invitation.Rank)
+	suite.Equal(roles, invitation.Roles)
+	suite.WithinDuration(time.Now().Add(time.Hour), invitation.ExpiresAt, time.Second)
+
+	//only the hash of the token should be saved
+	matches := regexp.MustCompile(`^http://base/invitation/accept\?token=(\S+)$`).FindStringSubmatch(link)
+	suite.Require().Len(matches, 2)
+	token, err := url.QueryUnescape(matches[1])
+	suite.Require().NoError(err)
+
+	hash := sha256.Sum256([]byte(token))
+	suite.Equal(hash[:], invitation.TokenHash)
+}
+
+func (suite *InvitationControllerTestSuite) TestCreateInvitation_WithEmail_MailsLink() {
+	//arrange
+	suite.CRUDMock.On("GetUserByEmail", mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("SaveInvitation", mock.Anything).Return(nil)
+	suite.MailerMock.On("SendMail", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	//act
+	invitation, link, cerr := suite.InvitationController.CreateInvitation(&suite.CRUDMock, " User@Example.com ", 0, nil)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Equal("user@example.com", invitation.Email)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetUserByEmail", "user@example.com")
+
+	body := suite.MailerMock.Calls[0].Arguments.String(2)
+	suite.MailerMock.AssertCalled(suite.T(), "SendMail", "user@example.com", "You're invited to app", body)
+	suite.Contains(body, link)
+}
+
+func (suite *InvitationControllerTestSuite) TestDeleteInvitation_WithErrorDeletingInvitation_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("DeleteInvitation", mock.Anything).Return(false, errors.New(""))
+
+	//act
+	cerr := suite.InvitationController.DeleteInvitation(&suite.CRUDMock, uuid.New())
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *InvitationControllerTestSuite) TestDeleteInvitation_WhereInvitationNotFound_ReturnsClientError() {
+	//arrange
+	id := uuid.New()
+	suite.CRUDMock.On("DeleteInvitation", mock.Anything).Return(false, nil)
+
+	//act
+	cerr := suite.InvitationController.DeleteInvitation(&suite.CRUDMock, id)
+
+	//assert
+	suite.CustomClientError(cerr, "invitation with id", id.String(), "not found")
+}
+
+func (suite *InvitationControllerTestSuite) TestDeleteInvitation_DeletesInvitation() {
+	//arrange
+	id := uuid.New()
+	suite.CRUDMock.On("DeleteInvitation", mock.Anything).Return(true, nil)
+
+	//act
+	cerr := suite.InvitationController.DeleteInvitation(&suite.CRUDMock, id)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteInvitation", id)
+}
+
+func (suite *InvitationControllerTestSuite) TestVerifyInvitationRank_WithErrorGettingInvitation_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetInvitationByID", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	res, cerr := suite.InvitationController.VerifyInvitationRank(&suite.CRUDMock, uuid.New(), 0)
+
+	//assert
+	suite.False(res)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *InvitationControllerTestSuite) TestVerifyInvitationRank_WhereInvitationNotFound_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetInvitationByID", mock.Anything).Return(nil, nil)
+
+	//act
+	res, cerr := suite.InvitationController.VerifyInvitationRank(&suite.CRUDMock, uuid.New(), 0)
+
+	//assert
+	suite.False(res)
+	suite.CustomClientError(cerr, "invitation with id", "not found")
+}
+
+func (suite *InvitationControllerTestSuite) TestVerifyInvitationRank_RankTestCases() {
+	var rank int
+	var expected bool
+
+	testCase := func() {
+		//arrange
+		invitation := suite.createInvitation("token", time.Hour)
+
+		suite.CRUDMock = datamocks.DataCRUD{}
+		suite.CRUDMock.On("GetInvitationByID", mock.Anything).Return(invitation, nil)
+
+		//act
+		res, cerr := suite.InvitationController.VerifyInvitationRank(&suite.CRUDMock, invitation.ID, rank)
+
+		//assert
+		suite.CustomNoError(cerr)
+		suite.Equal(expected, res)
+		suite.CRUDMock.AssertCalled(suite.T(), "GetInvitationByID", invitation.ID)
+	}
+
+	rank = 0
+	expected = false
+	suite.Run("LesserRank", testCase)
+
+	rank = 1
+	expected = true
+	suite.Run("EqualRank", testCase)
+
+	rank = 2
+	expected = true
+	suite.Run("GreaterRank", testCase)
+}
+
+func (suite *InvitationControllerTestSuite) TestAcceptInvitation_WithErrorGettingInvitation_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetInvitationByTokenHash", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	user, cerr := suite.InvitationController.AcceptInvitation(&suite.CRUDMock, "token", "username", "password")
+
+	//assert
+	suite.Nil(user)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *InvitationControllerTestSuite) TestAcceptInvitation_WhereInvitationNotFound_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetInvitationByTokenHash", mock.Anything).Return(nil, nil)
+
+	//act
+	user, cerr := suite.InvitationController.AcceptInvitation(&suite.CRUDMock, "token", "username", "password")
+
+	//assert
+	suite.Nil(user)
+	suite.CustomClientError(cerr, "invalid or has expired")
+
+	hash := sha256.Sum256([]byte("token"))
+	suite.CRUDMock.AssertCalled(suite.T(), "GetInvitationByTokenHash", hash[:])
+}
+
+func (suite *InvitationControllerTestSuite) TestAcceptInvitation_WhereInvitationIsExpired_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetInvitationByTokenHash", mock.Anything).Return(suite.createInvitation("token", -time.Second), nil)
+
+	//act
+	user, cerr := suite.InvitationController.AcceptInvitation(&suite.CRUDMock, "token", "username", "password")
+
+	//assert
+	suite.Nil(user)
+	suite.CustomClientError(cerr, "invalid or has expired")
+	suite.CRUDMock.AssertNotCalled(suite.T(), "DeleteInvitation", mock.Anything)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *InvitationControllerTestSuite) TestAcceptInvitation_WithErrorDeletingInvitation_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetInvitationByTokenHash", mock.Anything).Return(suite.createInvitation("token", time.Hour), nil)
+	suite.CRUDMock.On("DeleteInvitation", mock.Anything).Return(false, errors.New(""))
+
+	//act
+	user, cerr := suite.InvitationController.AcceptInvitation(&suite.CRUDMock, "token", "username", "password")
+
+	//assert
+	suite.Nil(user)
+	suite.CustomInternalError(cerr)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *InvitationControllerTestSuite) TestAcceptInvitation_WhereInvitationWasAlreadyDeleted_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetInvitationByTokenHash", mock.Anything).Return(suite.createInvitation("token", time.Hour), nil)
+	suite.CRUDMock.On("DeleteInvitation", mock.Anything).Return(false, nil)
+
+	//act
+	user, cerr := suite.InvitationController.AcceptInvitation(&suite.CRUDMock, "token", "username", "password")
+
+	//assert
+	suite.Nil(user)
+	suite.CustomClientError(cerr, "invalid or has expired")
+	suite.ControllersMock.AssertNotCalled(suite.T(), "CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *InvitationControllerTestSuite) TestAcceptInvitation_WithErrorCreatingUser_ReturnsError() {
+	var expectedErr common.CustomError
+
+	testCase := func() {
+		//arrange
+		suite.CRUDMock = datamocks.DataCRUD{}
+		suite.ControllersMock = mocks.Controllers{}
+		suite.InvitationController.UserController = &suite.ControllersMock
+
+		suite.CRUDMock.On("GetInvitationByTokenHash", mock.Anything).Return(suite.createInvitation("token", time.Hour), nil)
+		suite.CRUDMock.On("DeleteInvitation", mock.Anything).Return(true, nil)
+		suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, expectedErr)
+
+		//act
+		user, cerr := suite.InvitationController.AcceptInvitation(&suite.CRUDMock, "token", "username", "password")
+
+		//assert
+		suite.Nil(user)
+		suite.Equal(expectedErr, cerr)
+	}
+
+	expectedErr = common.ClientError("password does not meet minimum criteria")
+	suite.Run("ClientError", testCase)
+
+	expectedErr = common.InternalError()
+	suite.Run("InternalError", testCase)
+}
+
+func (suite *InvitationControllerTestSuite) TestAcceptInvitation_WithErrorGettingRoleDefinition_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetInvitationByTokenHash", mock.Anything).Return(suite.createInvitation("token", time.Hour), nil)
+	suite.CRUDMock.On("DeleteInvitation", mock.Anything).Return(true, nil)
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(models.CreateUser("username", 1, nil), common.NoError())
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	user, cerr := suite.InvitationController.AcceptInvitation(&suite.CRUDMock, "token", "username", "password")
+
+	//assert
+	suite.Nil(user)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *InvitationControllerTestSuite) TestAcceptInvitation_WithErrorCreatingUserRole_ReturnsError() {
+	//arrange
+	suite.CRUDMock.On("GetInvitationByTokenHash", mock.Anything).Return(suite.createInvitation("token", time.Hour), nil)
+	suite.CRUDMock.On("DeleteInvitation", mock.Anything).Return(true, nil)
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(models.CreateUser("username", 1, nil), common.NoError())
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
+	suite.ControllersMock.On("CreateUserRole", mock.Anything, mock.Anything).Return(common.InternalError())
+
+	//act
+	user, cerr := suite.InvitationController.AcceptInvitation(&suite.CRUDMock, "token", "username", "password")
+
+	//assert
+	suite.Nil(user)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *InvitationControllerTestSuite) TestAcceptInvitation_DeletesInvitationAndCreatesUserWithRoles() {
+	//arrange
+	invitation := suite.createInvitation("token", time.Hour)
+	deletedClientUID := uuid.New()
+	invitation.Roles = append(invitation.Roles, models.CreateInvitationRole(deletedClientUID, "other"))
+
+	newUser := models.CreateUser("username", invitation.Rank, nil)
+
+	suite.CRUDMock.On("GetInvitationByTokenHash", mock.Anything).Return(invitation, nil)
+	suite.CRUDMock.On("DeleteInvitation", mock.Anything).Return(true, nil)
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(newUser, common.NoError())
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", invitation.Roles[0].ClientUID, mock.Anything).Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", deletedClientUID, mock.Anything).Return(nil, nil)
+	suite.ControllersMock.On("CreateUserRole", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	user, cerr := suite.InvitationController.AcceptInvitation(&suite.CRUDMock, "token", "username", "password")
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Equal(newUser, user)
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteInvitation", invitation.ID)
+
+	profile := models.UserProfile{
+		Email:   invitation.Email,
+		Enabled: true,
+	}
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateUser", &suite.CRUDMock, "username", "password", invitation.Rank, profile)
+
+	//the role that is no longer defined is skipped
+	suite.ControllersMock.AssertNumberOfCalls(suite.T(), "CreateUserRole", 1)
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateUserRole", &suite.CRUDMock, models.CreateUserRole(invitation.Roles[0].ClientUID, "username", "role"))
+}
+
+func TestInvitationControllerTestSuite(t *testing.T) {
+	suite.Run(t, &InvitationControllerTestSuite{})
+}
@@ -6,15 +6,27 @@ import (
 	"github.com/mhogar/amber/common"
 	"github.com/mhogar/amber/config"
 	"github.com/mhogar/amber/loaders"
+	"github.com/mhogar/amber/models"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/google/uuid"
 )
 
+// DefaultClaims are the claims of default tokens.
+// Email and Name are only included if profile claims are enabled in the token config and the user has them set.
+// Role is only included if the single role claim is enabled in the token config.
 type DefaultClaims struct {
 	jwt.StandardClaims
-	Username string `json:"username"`
-	Role     string `json:"role"`
+	Username string   `json:"username,omitempty"`
+	Roles    []string `json:"roles,omitempty"`
+	Role     string   `json:"role,omitempty"`
+	Email    string   `json:"email,omitempty"`
+	Name     string   `json:"name,omitempty"`
+}
+
+type IDTokenClaims struct {
+	DefaultClaims
+	Nonce string `json:"nonce,omitempty"`
 }
 
 type DefaultTokenFactory struct {
@@ -22,30 +34,96 @@ type DefaultTokenFactory struct {
 	TokenSigner TokenSigner
 }
 
-func (tf DefaultTokenFactory) CreateToken(keyUri string, clientUID uuid.UUID, username string, role string) (string, error) {
+func (tf DefaultTokenFactory) CreateToken(keyUri string, clientUID uuid.UUID, user *models.User, roles []string) (string, error) {
+	return tf.createSignedToken(keyUri, func(claims DefaultClaims) jwt.Claims {
+		claims.Audience = clientUID.String()
+		claims.Username = user.Username
+		setRoleClaims(&claims, roles)
+		setProfileClaims(&claims, user)
+
+		return claims
+	})
+}
+
+func (tf DefaultTokenFactory) CreateClientToken(keyUri string, clientUID uuid.UUID) (string, error) {
+	return tf.createSignedToken(keyUri, func(claims DefaultClaims) jwt.Claims {
+		claims.Audience = clientUID.String()
+		claims.Subject = clientUID.String()
+
+		return claims
+	})
+}
+
+func (tf DefaultTokenFactory) CreateIDToken(keyUri string, clientUID uuid.UUID, user *models.User, roles []string, nonce string) (string, error) {
+	return tf.createSignedToken(keyUri, func(claims DefaultClaims) jwt.Claims {
+		claims.Audience = clientUID.String()
+		claims.Subject = user.Username
+		claims.Username = user.Username
+		setRoleClaims(&claims, roles)
+		setProfileClaims(&claims, user)
+
+		return IDTokenClaims{
+			DefaultClaims: claims,
+			Nonce:         nonce,
+		}
+	})
+}
+
+// setRoleClaims sets the user's roles claim, and the single role claim if it is enabled.
+func setRoleClaims(claims *DefaultClaims, roles []string) {
+	claims.Roles = roles
+
+	if config.GetTokenConfig().IncludeSingleRoleClaim {
+		claims.Role = firstRole(roles)
+	}
+}
+
+// firstRole returns the first of the roles, or an empty string if there are none.
+func firstRole(roles []string) string {
+	if len(roles) == 0 {
+		return ""
+	}
+	return roles[0]
+}
+
+// setProfileClaims sets the user's email and display name claims if profile claims are enabled.
+func setProfileClaims(claims *DefaultClaims, user *models.User) {
+	if !config.GetTokenConfig().IncludeProfileClaims {
+		return
+	}
+
+	claims.Email = user.Email
+	claims.Name = user.DisplayName
+}
+
+func (tf DefaultTokenFactory) createSignedToken(keyUri string, createClaims func(DefaultClaims) jwt.Claims) (string, error) {
 	//load the private key
 	privateKey, err := tf.DataLoader.Load(keyUri)
 	if err != nil {
 		return "", common.ChainError("error loading private key", err)
 	}
 
+	//parse the private key so the key id can be derived from its public half
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(privateKey)
+	if err != nil {
+		return "", common.ChainError("error parsing private key", err)
+	}
+
 	now := time.Now().Unix()
 	cfg := config.GetTokenConfig()
 
 	//fill out the claims
-	claims := DefaultClaims{
+	claims := createClaims(DefaultClaims{
 		StandardClaims: jwt.StandardClaims{
 			Issuer:    cfg.DefaultIssuer,
-			Audience:  clientUID.String(),
 			IssuedAt:  now,
 			ExpiresAt: now + cfg.Lifetime,
 		},
-		Username: username,
-		Role:     role,
-	}
+	})
 
 	//create the token
 	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = CreateKeyID(&key.PublicKey)
 
 	//sign the token
 	signedToken, err := tf.TokenSigner.SignToken(token, []byte(privateKey))
@@ -2,12 +2,14 @@ package jwthelpers_test
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/mhogar/amber/config"
 	jwthelpers "github.com/mhogar/amber/controllers/jwt_helpers"
 	"github.com/mhogar/amber/controllers/jwt_helpers/mocks"
 	loadermocks "github.com/mhogar/amber/loaders/mocks"
+	"github.com/mhogar/amber/models"
 	"github.com/mhogar/amber/testing/helpers"
 
 	"github.com/golang-jwt/jwt"
@@ -40,7 +42,7 @@ func (suite *DefaultTokenFactoryTestSuite) TestCreateToken_WithErrorLoadingPriva
 	suite.DataLoaderMock.On("Load", mock.Anything).Return(nil, errors.New(message))
 
 	//act
-	token, err := suite.TokenFactory.CreateToken("key.json", uuid.New(), "username", "role")
+	token, err := suite.TokenFactory.CreateToken("key.json", uuid.New(), models.CreateUser("username", 0, nil), []string{"role"})
 
 	//assert
 	suite.Empty(token)
@@ -48,17 +50,31 @@ func (suite *DefaultTokenFactoryTestSuite) TestCreateToken_WithErrorLoadingPriva
 	suite.Contains(err.Error(), message)
 }
 
+func (suite *DefaultTokenFactoryTestSuite) TestCreateToken_WithErrorParsingPrivateKey_ReturnsError() {
+	//arrange
+	suite.DataLoaderMock.On("Load", mock.Anything).Return([]byte("invalid"), nil)
+
+	//act
+	token, err := suite.TokenFactory.CreateToken("key.json", uuid.New(), models.CreateUser("username", 0, nil), []string{"role"})
+
+	//assert
+	suite.Empty(token)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "error parsing private key")
+}
+
 func (suite *DefaultTokenFactoryTestSuite) TestCreateToken_WithErrorSigningToken_ReturnsError() {
 	//arrange
 	viper.Set("token", config.TokenConfig{})
 
-	suite.DataLoaderMock.On("Load", mock.Anything).Return(nil, nil)
+	_, privateKey := helpers.CreateRSAPrivateKey()
+	suite.DataLoaderMock.On("Load", mock.Anything).Return(privateKey, nil)
 
 	message := "sign token error"
 	suite.TokenSignerMock.On("SignToken", mock.Anything, mock.Anything).Return("", errors.New(message))
 
 	//act
-	token, err := suite.TokenFactory.CreateToken("key.json", uuid.New(), "username", "role")
+	token, err := suite.TokenFactory.CreateToken("key.json", uuid.New(), models.CreateUser("username", 0, nil), []string{"role"})
 
 	//assert
 	suite.Empty(token)
@@ -76,17 +92,195 @@ func (suite *DefaultTokenFactoryTestSuite) TestCreateToken_WithNoErrors_ReturnsT
 
 	uri := "key.json"
 	clientUID := uuid.New()
-	username := "username"
-	role := "role"
+	user := models.CreateUser("username", 0, nil)
+	roles := []string{"editor", "billing"}
+
+	key, privateKey := helpers.CreateRSAPrivateKey()
+	token := "this_is_a_signed_token"
+
+	suite.DataLoaderMock.On("Load", mock.Anything).Return(privateKey, nil)
+	suite.TokenSignerMock.On("SignToken", mock.Anything, mock.Anything).Return(token, nil)
+
+	//act
+	resultToken, err := suite.TokenFactory.CreateToken(uri, clientUID, user, roles)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(token, resultToken)
+
+	suite.DataLoaderMock.AssertCalled(suite.T(), "Load", uri)
+	suite.TokenSignerMock.AssertCalled(suite.T(), "SignToken", mock.MatchedBy(func(tk *jwt.Token) bool {
+		claims := tk.Claims.(jwthelpers.DefaultClaims)
+		return tk.Header["kid"] == jwthelpers.CreateKeyID(&key.PublicKey) &&
+			claims.Username == user.Username &&
+			reflect.DeepEqual(roles, claims.Roles) &&
+			claims.Role == "" &&
+			claims.Audience == clientUID.String() &&
+			claims.Issuer == cfg.DefaultIssuer &&
+			claims.ExpiresAt-claims.IssuedAt == cfg.Lifetime
+	}), privateKey)
+}
+
+func (suite *DefaultTokenFactoryTestSuite) TestCreateToken_ProfileClaimsTestCases() {
+	var includeProfileClaims bool
+	var expectedEmail string
+	var expectedName string
+
+	user := models.CreateUser("username", 0, nil)
+	user.Email = "user@example.com"
+	user.DisplayName = "Display Name"
+
+	testCase := func() {
+		//arrange
+		suite.SetupTest()
+		viper.Set("token", config.TokenConfig{
+			IncludeProfileClaims: includeProfileClaims,
+		})
+
+		_, privateKey := helpers.CreateRSAPrivateKey()
+
+		suite.DataLoaderMock.On("Load", mock.Anything).Return(privateKey, nil)
+		suite.TokenSignerMock.On("SignToken", mock.Anything, mock.Anything).Return("token", nil)
+
+		//act
+		_, err := suite.TokenFactory.CreateToken("key.json", uuid.New(), user, []string{"role"})
+
+		//assert
+		suite.NoError(err)
+		suite.TokenSignerMock.AssertCalled(suite.T(), "SignToken", mock.MatchedBy(func(tk *jwt.Token) bool {
+			claims := tk.Claims.(jwthelpers.DefaultClaims)
+			return claims.Email == expectedEmail && claims.Name == expectedName
+		}), privateKey)
+	}
+
+	includeProfileClaims = false
+	expectedEmail = ""
+	expectedName = ""
+	suite.Run("ProfileClaimsDisabled_OmitsProfileClaims", testCase)
+
+	includeProfileClaims = true
+	expectedEmail = user.Email
+	expectedName = user.DisplayName
+	suite.Run("ProfileClaimsEnabled_IncludesProfileClaims", testCase)
+}
+
+func (suite *DefaultTokenFactoryTestSuite) TestCreateToken_SingleRoleClaimTestCases() {
+	var includeSingleRoleClaim bool
+	var roles []string
+	var expectedRole string
+
+	testCase := func() {
+		//arrange
+		suite.SetupTest()
+		viper.Set("token", config.TokenConfig{
+			IncludeSingleRoleClaim: includeSingleRoleClaim,
+		})
+
+		_, privateKey := helpers.CreateRSAPrivateKey()
+
+		suite.DataLoaderMock.On("Load", mock.Anything).Return(privateKey, nil)
+		suite.TokenSignerMock.On("SignToken", mock.Anything, mock.Anything).Return("token", nil)
+
+		//act
+		_, err := suite.TokenFactory.CreateToken("key.json", uuid.New(), models.CreateUser("username", 0, nil), roles)
+
+		//assert
+		suite.NoError(err)
+		suite.TokenSignerMock.AssertCalled(suite.T(), "SignToken", mock.MatchedBy(func(tk *jwt.Token) bool {
+			claims := tk.Claims.(jwthelpers.DefaultClaims)
+			return reflect.DeepEqual(roles, claims.Roles) && claims.Role == expectedRole
+		}), privateKey)
+	}
+
+	includeSingleRoleClaim = false
+	roles = []string{"billing", "editor"}
+	expectedRole = ""
+	suite.Run("SingleRoleClaimDisabled_OmitsRoleClaim", testCase)
+
+	includeSingleRoleClaim = true
+	expectedRole = "billing"
+	suite.Run("SingleRoleClaimEnabled_IncludesFirstRole", testCase)
+
+	roles = nil
+	expectedRole = ""
+	suite.Run("SingleRoleClaimEnabledWithNoRoles_OmitsRoleClaim", testCase)
+}
+
+func (suite *DefaultTokenFactoryTestSuite) TestCreateIDToken_WithErrorLoadingPrivateKey_ReturnsError() {
+	//arrange
+	message := "load private key error"
+	suite.DataLoaderMock.On("Load", mock.Anything).Return(nil, errors.New(message))
+
+	//act
+	token, err := suite.TokenFactory.CreateIDToken("key.pem", uuid.New(), models.CreateUser("username", 0, nil), []string{"role"}, "nonce")
+
+	//assert
+	suite.Empty(token)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), message)
+}
+
+func (suite *DefaultTokenFactoryTestSuite) TestCreateIDToken_WithNoErrors_ReturnsToken() {
+	//arrange
+	cfg := config.TokenConfig{
+		DefaultIssuer: "issuer",
+		Lifetime:      60,
+	}
+	viper.Set("token", cfg)
+
+	uri := "key.pem"
+	clientUID := uuid.New()
+	user := models.CreateUser("username", 0, nil)
+	roles := []string{"editor", "billing"}
+	nonce := "nonce"
+
+	key, privateKey := helpers.CreateRSAPrivateKey()
+	token := "this_is_a_signed_token"
+
+	suite.DataLoaderMock.On("Load", mock.Anything).Return(privateKey, nil)
+	suite.TokenSignerMock.On("SignToken", mock.Anything, mock.Anything).Return(token, nil)
+
+	//act
+	resultToken, err := suite.TokenFactory.CreateIDToken(uri, clientUID, user, roles, nonce)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(token, resultToken)
+
+	suite.DataLoaderMock.AssertCalled(suite.T(), "Load", uri)
+	suite.TokenSignerMock.AssertCalled(suite.T(), "SignToken", mock.MatchedBy(func(tk *jwt.Token) bool {
+		claims := tk.Claims.(jwthelpers.IDTokenClaims)
+		return tk.Header["kid"] == jwthelpers.CreateKeyID(&key.PublicKey) &&
+			claims.Subject == user.Username &&
+			claims.Username == user.Username &&
+			reflect.DeepEqual(roles, claims.Roles) &&
+			claims.Role == "" &&
+			claims.Nonce == nonce &&
+			claims.Audience == clientUID.String() &&
+			claims.Issuer == cfg.DefaultIssuer &&
+			claims.ExpiresAt-claims.IssuedAt == cfg.Lifetime
+	}), privateKey)
+}
+
+func (suite *DefaultTokenFactoryTestSuite) TestCreateClientToken_WithNoErrors_ReturnsToken() {
+	//arrange
+	cfg := config.TokenConfig{
+		DefaultIssuer: "issuer",
+		Lifetime:      60,
+	}
+	viper.Set("token", cfg)
+
+	uri := "key.pem"
+	clientUID := uuid.New()
 
-	privateKey := []byte("private key")
+	_, privateKey := helpers.CreateRSAPrivateKey()
 	token := "this_is_a_signed_token"
 
 	suite.DataLoaderMock.On("Load", mock.Anything).Return(privateKey, nil)
 	suite.TokenSignerMock.On("SignToken", mock.Anything, mock.Anything).Return(token, nil)
 
 	//act
-	resultToken, err := suite.TokenFactory.CreateToken(uri, clientUID, username, role)
+	resultToken, err := suite.TokenFactory.CreateClientToken(uri, clientUID)
 
 	//assert
 	suite.NoError(err)
@@ -95,9 +289,10 @@ func (suite *DefaultTokenFactoryTestSuite) TestCreateToken_WithNoErrors_ReturnsT
 	suite.DataLoaderMock.AssertCalled(suite.T(), "Load", uri)
 	suite.TokenSignerMock.AssertCalled(suite.T(), "SignToken", mock.MatchedBy(func(tk *jwt.Token) bool {
 		claims := tk.Claims.(jwthelpers.DefaultClaims)
-		return claims.Username == username &&
-			claims.Role == role &&
+		return claims.Subject == clientUID.String() &&
 			claims.Audience == clientUID.String() &&
+			claims.Username == "" &&
+			claims.Role == "" &&
 			claims.Issuer == cfg.DefaultIssuer &&
 			claims.ExpiresAt-claims.IssuedAt == cfg.Lifetime
 	}), privateKey)
@@ -6,6 +6,7 @@ import (
 	"github.com/mhogar/amber/common"
 	"github.com/mhogar/amber/config"
 	"github.com/mhogar/amber/loaders"
+	"github.com/mhogar/amber/models"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/google/uuid"
@@ -18,9 +19,9 @@ type FirebaseServiceJSON struct {
 
 type FirebaseClaims struct {
 	jwt.StandardClaims
-	Algorithm string            `json:"alg"`
-	UID       string            `json:"uid"`
-	Claims    map[string]string `json:"claims"`
+	Algorithm string                 `json:"alg"`
+	UID       string                 `json:"uid"`
+	Claims    map[string]interface{} `json:"claims"`
 }
 
 type FirebaseTokenFactory struct {
@@ -28,7 +29,24 @@ type FirebaseTokenFactory struct {
 	TokenSigner TokenSigner
 }
 
-func (tf FirebaseTokenFactory) CreateToken(keyUri string, _ uuid.UUID, username string, role string) (string, error) {
+func (tf FirebaseTokenFactory) CreateToken(keyUri string, _ uuid.UUID, user *models.User, roles []string) (string, error) {
+	customClaims := map[string]interface{}{
+		"roles": roles,
+	}
+
+	//include the single role claim if enabled
+	if config.GetTokenConfig().IncludeSingleRoleClaim {
+		customClaims["role"] = firstRole(roles)
+	}
+
+	return tf.createSignedToken(keyUri, user.Username, customClaims)
+}
+
+func (tf FirebaseTokenFactory) CreateClientToken(keyUri string, clientUID uuid.UUID) (string, error) {
+	return tf.createSignedToken(keyUri, clientUID.String(), map[string]interface{}{})
+}
+
+func (tf FirebaseTokenFactory) createSignedToken(keyUri string, uid string, customClaims map[string]interface{}) (string, error) {
 	var serviceJSON FirebaseServiceJSON
 
 	//load the service json
@@ -50,10 +68,9 @@ func (tf FirebaseTokenFactory) CreateToken(keyUri string, _ uuid.UUID, username
 			ExpiresAt: now + config.GetTokenConfig().Lifetime,
 		},
 		Algorithm: "RS256",
-		UID:       username,
-		Claims:    make(map[string]string),
+		UID:       uid,
+		Claims:    customClaims,
 	}
-	claims.Claims["role"] = role
 
 	//create the token
 	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
@@ -2,12 +2,14 @@ package jwthelpers_test
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/mhogar/amber/config"
 	jwthelpers "github.com/mhogar/amber/controllers/jwt_helpers"
 	"github.com/mhogar/amber/controllers/jwt_helpers/mocks"
 	loadermocks "github.com/mhogar/amber/loaders/mocks"
+	"github.com/mhogar/amber/models"
 	"github.com/mhogar/amber/testing/helpers"
 
 	"github.com/golang-jwt/jwt"
@@ -40,7 +42,7 @@ func (suite *FirebaseTokenFactoryTestSuite) TestCreateToken_WithErrorLoadingJSON
 	suite.JSONLoaderMock.On("Load", mock.Anything, mock.Anything).Return(errors.New(message))
 
 	//act
-	token, err := suite.TokenFactory.CreateToken("key.json", uuid.New(), "username", "role")
+	token, err := suite.TokenFactory.CreateToken("key.json", uuid.New(), models.CreateUser("username", 0, nil), []string{"role"})
 
 	//assert
 	suite.Empty(token)
@@ -58,7 +60,7 @@ func (suite *FirebaseTokenFactoryTestSuite) TestCreateToken_WithErrorSigningToke
 	suite.TokenSignerMock.On("SignToken", mock.Anything, mock.Anything).Return("", errors.New(message))
 
 	//act
-	token, err := suite.TokenFactory.CreateToken("key.json", uuid.New(), "username", "role")
+	token, err := suite.TokenFactory.CreateToken("key.json", uuid.New(), models.CreateUser("username", 0, nil), []string{"role"})
 
 	//assert
 	suite.Empty(token)
@@ -74,8 +76,8 @@ func (suite *FirebaseTokenFactoryTestSuite) TestCreateToken_WithNoErrors_Returns
 	viper.Set("token", cfg)
 
 	uri := "key.json"
-	username := "username"
-	role := "role"
+	user := models.CreateUser("username", 0, nil)
+	roles := []string{"editor", "billing"}
 	token := "this_is_a_signed_token"
 
 	serviceJSON := jwthelpers.FirebaseServiceJSON{
@@ -89,7 +91,7 @@ func (suite *FirebaseTokenFactoryTestSuite) TestCreateToken_WithNoErrors_Returns
 	suite.TokenSignerMock.On("SignToken", mock.Anything, mock.Anything).Return(token, nil)
 
 	//act
-	resultToken, err := suite.TokenFactory.CreateToken(uri, uuid.Nil, username, role)
+	resultToken, err := suite.TokenFactory.CreateToken(uri, uuid.Nil, user, roles)
 
 	//assert
 	suite.NoError(err)
@@ -98,11 +100,71 @@ func (suite *FirebaseTokenFactoryTestSuite) TestCreateToken_WithNoErrors_Returns
 	suite.JSONLoaderMock.AssertCalled(suite.T(), "Load", uri, mock.Anything)
 	suite.TokenSignerMock.AssertCalled(suite.T(), "SignToken", mock.MatchedBy(func(tk *jwt.Token) bool {
 		claims := tk.Claims.(jwthelpers.FirebaseClaims)
-		return claims.UID == username &&
+		return claims.UID == user.Username &&
 			claims.Issuer == serviceJSON.ClientEmail &&
 			claims.Subject == serviceJSON.ClientEmail &&
 			claims.ExpiresAt-claims.IssuedAt == cfg.Lifetime &&
-			claims.Claims["role"] == role
+			reflect.DeepEqual(roles, claims.Claims["roles"]) &&
+			claims.Claims["role"] == nil
+	}), []byte(serviceJSON.PrivateKey))
+}
+
+func (suite *FirebaseTokenFactoryTestSuite) TestCreateToken_WithSingleRoleClaimEnabled_IncludesFirstRole() {
+	//arrange
+	viper.Set("token", config.TokenConfig{
+		IncludeSingleRoleClaim: true,
+	})
+
+	roles := []string{"billing", "editor"}
+
+	suite.JSONLoaderMock.On("Load", mock.Anything, mock.Anything).Return(nil)
+	suite.TokenSignerMock.On("SignToken", mock.Anything, mock.Anything).Return("token", nil)
+
+	//act
+	_, err := suite.TokenFactory.CreateToken("key.json", uuid.Nil, models.CreateUser("username", 0, nil), roles)
+
+	//assert
+	suite.NoError(err)
+	suite.TokenSignerMock.AssertCalled(suite.T(), "SignToken", mock.MatchedBy(func(tk *jwt.Token) bool {
+		claims := tk.Claims.(jwthelpers.FirebaseClaims)
+		return reflect.DeepEqual(roles, claims.Claims["roles"]) && claims.Claims["role"] == "billing"
+	}), mock.Anything)
+}
+
+func (suite *FirebaseTokenFactoryTestSuite) TestCreateClientToken_WithNoErrors_ReturnsToken() {
+	//arrange
+	cfg := config.TokenConfig{
+		Lifetime: 60,
+	}
+	viper.Set("token", cfg)
+
+	uri := "key.json"
+	clientUID := uuid.New()
+	token := "this_is_a_signed_token"
+
+	serviceJSON := jwthelpers.FirebaseServiceJSON{
+		ClientEmail: "email",
+		PrivateKey:  "private_key",
+	}
+
+	suite.JSONLoaderMock.On("Load", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*jwthelpers.FirebaseServiceJSON) = serviceJSON
+	})
+	suite.TokenSignerMock.On("SignToken", mock.Anything, mock.Anything).Return(token, nil)
+
+	//act
+	resultToken, err := suite.TokenFactory.CreateClientToken(uri, clientUID)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(token, resultToken)
+
+	suite.JSONLoaderMock.AssertCalled(suite.T(), "Load", uri, mock.Anything)
+	suite.TokenSignerMock.AssertCalled(suite.T(), "SignToken", mock.MatchedBy(func(tk *jwt.Token) bool {
+		claims := tk.Claims.(jwthelpers.FirebaseClaims)
+		return claims.UID == clientUID.String() &&
+			claims.Subject == serviceJSON.ClientEmail &&
+			len(claims.Claims) == 0
 	}), []byte(serviceJSON.PrivateKey))
 }
 
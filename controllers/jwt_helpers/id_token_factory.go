@@ -0,0 +1,14 @@
+package jwthelpers
+
+import (
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+type IDTokenFactory interface {
+	// CreateIDToken creates a signed OpenID Connect ID token using the key loaded from the key uri.
+	// The user's username is used as the subject and the nonce is included if it is not empty.
+	// Returns the token string and any errors.
+	CreateIDToken(keyUri string, clientUID uuid.UUID, user *models.User, roles []string, nonce string) (string, error)
+}
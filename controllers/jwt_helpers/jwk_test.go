@@ -0,0 +1,52 @@
+package jwthelpers_test
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"testing"
+
+	jwthelpers "github.com/mhogar/amber/controllers/jwt_helpers"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type JWKTestSuite struct {
+	helpers.CustomSuite
+}
+
+func (suite *JWKTestSuite) TestCreateKeyID_MatchesRFC7638Example() {
+	//arrange
+	n, _ := base64.RawURLEncoding.DecodeString("0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw")
+	key := &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: 65537,
+	}
+
+	//act
+	kid := jwthelpers.CreateKeyID(key)
+
+	//assert
+	suite.Equal("NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", kid)
+}
+
+func (suite *JWKTestSuite) TestCreateJWK_CreatesJWKForPublicKey() {
+	//arrange
+	key, _ := helpers.CreateRSAPrivateKey()
+
+	//act
+	jwk := jwthelpers.CreateJWK(&key.PublicKey)
+
+	//assert
+	suite.Equal("RSA", jwk.Kty)
+	suite.Equal("sig", jwk.Use)
+	suite.Equal("RS256", jwk.Alg)
+	suite.Equal(jwthelpers.CreateKeyID(&key.PublicKey), jwk.Kid)
+	suite.Equal(base64.RawURLEncoding.EncodeToString(key.N.Bytes()), jwk.N)
+	suite.Equal("AQAB", jwk.E)
+}
+
+func TestJWKTestSuite(t *testing.T) {
+	suite.Run(t, &JWKTestSuite{})
+}
@@ -5,6 +5,8 @@ package mocks
 import (
 	uuid "github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/mhogar/amber/models"
 )
 
 // TokenFactory is an autogenerated mock type for the TokenFactory type
@@ -12,20 +14,41 @@ type TokenFactory struct {
 	mock.Mock
 }
 
-// CreateToken provides a mock function with given fields: keyUri, clientUID, username, role
-func (_m *TokenFactory) CreateToken(keyUri string, clientUID uuid.UUID, username string, role string) (string, error) {
-	ret := _m.Called(keyUri, clientUID, username, role)
+// CreateClientToken provides a mock function with given fields: keyUri, clientUID
+func (_m *TokenFactory) CreateClientToken(keyUri string, clientUID uuid.UUID) (string, error) {
+	ret := _m.Called(keyUri, clientUID)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, uuid.UUID) string); ok {
+		r0 = rf(keyUri, clientUID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, uuid.UUID) error); ok {
+		r1 = rf(keyUri, clientUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateToken provides a mock function with given fields: keyUri, clientUID, user, roles
+func (_m *TokenFactory) CreateToken(keyUri string, clientUID uuid.UUID, user *models.User, roles []string) (string, error) {
+	ret := _m.Called(keyUri, clientUID, user, roles)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string, uuid.UUID, string, string) string); ok {
-		r0 = rf(keyUri, clientUID, username, role)
+	if rf, ok := ret.Get(0).(func(string, uuid.UUID, *models.User, []string) string); ok {
+		r0 = rf(keyUri, clientUID, user, roles)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, uuid.UUID, string, string) error); ok {
-		r1 = rf(keyUri, clientUID, username, role)
+	if rf, ok := ret.Get(1).(func(string, uuid.UUID, *models.User, []string) error); ok {
+		r1 = rf(keyUri, clientUID, user, roles)
 	} else {
 		r1 = ret.Error(1)
 	}
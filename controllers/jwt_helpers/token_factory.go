@@ -1,10 +1,19 @@
 package jwthelpers
 
-import "github.com/google/uuid"
+import (
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
 
 type TokenFactory interface {
 	// CreateToken creates a signed JWT using the key loaded from the key uri.
-	// Should also include the username and role in its claims and optionally the client uid.
+	// Should also include the user's username and roles in its claims and optionally the client uid and the user's profile.
+	// Returns the token string any errors.
+	CreateToken(keyUri string, clientUID uuid.UUID, user *models.User, roles []string) (string, error)
+
+	// CreateClientToken creates a signed JWT using the key loaded from the key uri.
+	// The client uid is the subject of the token, and no user or role claims are included.
 	// Returns the token string any errors.
-	CreateToken(keyUri string, clientUID uuid.UUID, username string, role string) (string, error)
+	CreateClientToken(keyUri string, clientUID uuid.UUID) (string, error)
 }
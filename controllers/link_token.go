@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/mhogar/amber/config"
+)
+
+// LinkTokenNumBytes is the number of random bytes used to generate the tokens in emailed links, such as password reset and invitation links.
+// Tokens are high entropy, so a fast hash is enough to protect them at rest.
+const LinkTokenNumBytes = 32
+
+// generateLinkToken generates a new random url-safe token. Returns the token and any errors.
+func generateLinkToken() (string, error) {
+	bytes := make([]byte, LinkTokenNumBytes)
+	_, err := rand.Read(bytes)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// hashLinkToken hashes the token so it can be stored.
+func hashLinkToken(token string) []byte {
+	hash := sha256.Sum256([]byte(token))
+	return hash[:]
+}
+
+// createLinkURL creates a link to the path on the app's public url, with the token as its query param.
+// Returns the link and any errors.
+func createLinkURL(path string, token string) (string, error) {
+	publicURL := strings.TrimSuffix(config.GetServerConfig().PublicURL, "/")
+	if publicURL == "" {
+		return "", errors.New("server public url is not configured")
+	}
+
+	return fmt.Sprintf("%s%s?token=%s", publicURL, path, url.QueryEscape(token)), nil
+}
@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+)
+
+type CoreLockoutController struct{}
+
+func (CoreLockoutController) GetLockouts(CRUD LockoutControllerCRUD) ([]*models.LoginThrottle, common.CustomError) {
+	//get the locked throttles
+	throttles, err := CRUD.GetLockedLoginThrottles(time.Now())
+	if err != nil {
+		log.Println(common.ChainError("error getting locked login throttles", err))
+		return nil, common.InternalError()
+	}
+
+	return throttles, common.NoError()
+}
+
+func (CoreLockoutController) ClearLockout(CRUD LockoutControllerCRUD, throttleType string, key string) common.CustomError {
+	//validate the type
+	if throttleType != models.LoginThrottleTypeUsername && throttleType != models.LoginThrottleTypeIP {
+		return common.ClientError(fmt.Sprintf("lockout type must be %s or %s", models.LoginThrottleTypeUsername, models.LoginThrottleTypeIP))
+	}
+
+	//delete the throttle
+	res, err := CRUD.DeleteLoginThrottle(throttleType, key)
+	if err != nil {
+		log.Println(common.ChainError("error deleting login throttle", err))
+		return common.InternalError()
+	}
+
+	//verify the throttle was actually found
+	if !res {
+		return common.ClientError(fmt.Sprintf("no lockout found for %s %s", throttleType, key))
+	}
+
+	return common.NoError()
+}
@@ -0,0 +1,99 @@
+package controllers_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/controllers"
+	"github.com/mhogar/amber/models"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LockoutControllerTestSuite struct {
+	ControllerTestSuite
+	LockoutController controllers.CoreLockoutController
+}
+
+func (suite *LockoutControllerTestSuite) TestGetLockouts_WithErrorGettingLockedLoginThrottles_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetLockedLoginThrottles", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	throttles, cerr := suite.LockoutController.GetLockouts(&suite.CRUDMock)
+
+	//assert
+	suite.Nil(throttles)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *LockoutControllerTestSuite) TestGetLockouts_WithNoErrors_ReturnsLockedLoginThrottles() {
+	//arrange
+	throttles := []*models.LoginThrottle{
+		models.CreateLoginThrottle(models.LoginThrottleTypeIP, "127.0.0.1", 10, time.Now(), time.Now().Add(time.Minute)),
+		models.CreateLoginThrottle(models.LoginThrottleTypeUsername, "username", 5, time.Now(), time.Now().Add(time.Minute)),
+	}
+	suite.CRUDMock.On("GetLockedLoginThrottles", mock.Anything).Return(throttles, nil)
+
+	//act
+	results, cerr := suite.LockoutController.GetLockouts(&suite.CRUDMock)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Equal(throttles, results)
+
+	suite.CRUDMock.AssertCalled(suite.T(), "GetLockedLoginThrottles", mock.MatchedBy(func(now time.Time) bool {
+		return time.Since(now) < time.Second
+	}))
+}
+
+func (suite *LockoutControllerTestSuite) TestClearLockout_WithInvalidType_ReturnsClientError() {
+	//act
+	cerr := suite.LockoutController.ClearLockout(&suite.CRUDMock, "invalid", "key")
+
+	//assert
+	suite.CustomClientError(cerr, "lockout type must be", models.LoginThrottleTypeUsername, models.LoginThrottleTypeIP)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "DeleteLoginThrottle", mock.Anything, mock.Anything)
+}
+
+func (suite *LockoutControllerTestSuite) TestClearLockout_WithErrorDeletingLoginThrottle_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("DeleteLoginThrottle", mock.Anything, mock.Anything).Return(false, errors.New(""))
+
+	//act
+	cerr := suite.LockoutController.ClearLockout(&suite.CRUDMock, models.LoginThrottleTypeUsername, "username")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *LockoutControllerTestSuite) TestClearLockout_WithFalseResultDeletingLoginThrottle_ReturnsClientError() {
+	//arrange
+	key := "username"
+	suite.CRUDMock.On("DeleteLoginThrottle", mock.Anything, mock.Anything).Return(false, nil)
+
+	//act
+	cerr := suite.LockoutController.ClearLockout(&suite.CRUDMock, models.LoginThrottleTypeUsername, key)
+
+	//assert
+	suite.CustomClientError(cerr, "no lockout found", models.LoginThrottleTypeUsername, key)
+}
+
+func (suite *LockoutControllerTestSuite) TestClearLockout_WithNoErrors_ReturnsNoError() {
+	//arrange
+	key := "127.0.0.1"
+	suite.CRUDMock.On("DeleteLoginThrottle", mock.Anything, mock.Anything).Return(true, nil)
+
+	//act
+	cerr := suite.LockoutController.ClearLockout(&suite.CRUDMock, models.LoginThrottleTypeIP, key)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteLoginThrottle", models.LoginThrottleTypeIP, key)
+}
+
+func TestLockoutControllerTestSuite(t *testing.T) {
+	suite.Run(t, &LockoutControllerTestSuite{})
+}
@@ -0,0 +1,46 @@
+package mailhelpers
+
+import (
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mhogar/amber/common"
+)
+
+// FileMailer writes emails to a file instead of sending them, so it is only meant for development and tests.
+// If Filename is empty, the emails are written to the log instead.
+type FileMailer struct {
+	Filename string
+	From     string
+
+	mutex sync.Mutex
+}
+
+func (m *FileMailer) SendMail(to string, subject string, body string) error {
+	msg := BuildMessage(m.From, to, subject, body, time.Now())
+
+	if m.Filename == "" {
+		log.Printf("sent mail:\n%s", msg)
+		return nil
+	}
+
+	//only one email can be written at once so they don't interleave
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	f, err := os.OpenFile(m.Filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return common.ChainError("error opening mail file", err)
+	}
+	defer f.Close()
+
+	//separate each email with a blank line
+	_, err = f.Write(append(msg, "\r\n\r\n"...))
+	if err != nil {
+		return common.ChainError("error writing mail file", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package mailhelpers_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	mailhelpers "github.com/mhogar/amber/controllers/mail_helpers"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FileMailerTestSuite struct {
+	helpers.CustomSuite
+	Dir        string
+	FileMailer *mailhelpers.FileMailer
+}
+
+func (suite *FileMailerTestSuite) SetupTest() {
+	var err error
+	suite.Dir, err = ioutil.TempDir("", "mail")
+	suite.Require().NoError(err)
+
+	suite.FileMailer = &mailhelpers.FileMailer{
+		Filename: path.Join(suite.Dir, "mail.log"),
+		From:     "from@example.com",
+	}
+}
+
+func (suite *FileMailerTestSuite) TearDownTest() {
+	os.RemoveAll(suite.Dir)
+}
+
+func (suite *FileMailerTestSuite) TestSendMail_AppendsEachMailToFile() {
+	//act
+	err1 := suite.FileMailer.SendMail("to1@example.com", "Subject 1", "body 1")
+	err2 := suite.FileMailer.SendMail("to2@example.com", "Subject 2", "body 2")
+
+	//assert
+	suite.Require().NoError(err1)
+	suite.Require().NoError(err2)
+
+	data, err := ioutil.ReadFile(suite.FileMailer.Filename)
+	suite.Require().NoError(err)
+
+	//each mail starts with its from header
+	mails := strings.Split(string(data), "\r\n\r\nFrom: ")
+	suite.Require().Len(mails, 2)
+	suite.ContainsSubstrings(mails[0], "To: to1@example.com", "Subject: Subject 1", "\r\n\r\nbody 1")
+	suite.ContainsSubstrings(mails[1], "To: to2@example.com", "Subject: Subject 2", "\r\n\r\nbody 2")
+}
+
+func (suite *FileMailerTestSuite) TestSendMail_WhereFileCannotBeOpened_ReturnsError() {
+	//arrange
+	suite.FileMailer.Filename = path.Join(suite.Dir, "dne", "mail.log")
+
+	//act
+	err := suite.FileMailer.SendMail("to@example.com", "Subject", "body")
+
+	//assert
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "error opening mail file")
+}
+
+func (suite *FileMailerTestSuite) TestSendMail_WithEmptyFilename_ReturnsNoError() {
+	//arrange
+	suite.FileMailer.Filename = ""
+
+	//act
+	err := suite.FileMailer.SendMail("to@example.com", "Subject", "body")
+
+	//assert
+	suite.NoError(err)
+}
+
+func TestFileMailerTestSuite(t *testing.T) {
+	suite.Run(t, &FileMailerTestSuite{})
+}
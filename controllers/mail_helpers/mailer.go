@@ -0,0 +1,40 @@
+package mailhelpers
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+)
+
+type Mailer interface {
+	// SendMail sends an email with the subject and plain text body to the address.
+	// Returns any errors.
+	SendMail(to string, subject string, body string) error
+}
+
+// BuildMessage builds the raw message for a plain text email, with its headers, from the provided fields.
+// Line breaks are stripped from the header values so they cannot add headers of their own.
+func BuildMessage(from string, to string, subject string, body string, date time.Time) []byte {
+	var msg bytes.Buffer
+
+	writeHeader := func(name string, value string) {
+		value = strings.NewReplacer("\r", "", "\n", "").Replace(value)
+		fmt.Fprintf(&msg, "%s: %s\r\n", name, value)
+	}
+
+	writeHeader("From", from)
+	writeHeader("To", to)
+	writeHeader("Subject", mime.QEncoding.Encode("utf-8", subject))
+	writeHeader("Date", date.Format(time.RFC1123Z))
+	writeHeader("MIME-Version", "1.0")
+	writeHeader("Content-Type", "text/plain; charset=utf-8")
+	msg.WriteString("\r\n")
+
+	//smtp requires every line to end with crlf
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	return msg.Bytes()
+}
@@ -0,0 +1,57 @@
+package mailhelpers_test
+
+import (
+	"testing"
+	"time"
+
+	mailhelpers "github.com/mhogar/amber/controllers/mail_helpers"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MailerTestSuite struct {
+	helpers.CustomSuite
+}
+
+func (suite *MailerTestSuite) TestBuildMessage_BuildsMessageWithHeadersAndBody() {
+	//arrange
+	date := time.Date(2021, time.October, 1, 12, 0, 0, 0, time.UTC)
+
+	//act
+	msg := mailhelpers.BuildMessage("from@example.com", "to@example.com", "Subject", "line 1\nline 2", date)
+
+	//assert
+	suite.Equal(
+		"From: from@example.com\r\n"+
+			"To: to@example.com\r\n"+
+			"Subject: Subject\r\n"+
+			"Date: Fri, 01 Oct 2021 12:00:00 +0000\r\n"+
+			"MIME-Version: 1.0\r\n"+
+			"Content-Type: text/plain; charset=utf-8\r\n"+
+			"\r\n"+
+			"line 1\r\nline 2",
+		string(msg),
+	)
+}
+
+func (suite *MailerTestSuite) TestBuildMessage_StripsLineBreaksFromHeaders() {
+	//act
+	msg := mailhelpers.BuildMessage("from@example.com", "to@example.com\r\nBcc: other@example.com", "Subject", "body", time.Now())
+
+	//assert
+	suite.Contains(string(msg), "To: to@example.comBcc: other@example.com\r\n")
+	suite.NotContains(string(msg), "\r\nBcc:")
+}
+
+func (suite *MailerTestSuite) TestBuildMessage_EncodesNonASCIISubject() {
+	//act
+	msg := mailhelpers.BuildMessage("from@example.com", "to@example.com", "Réinitialiser", "body", time.Now())
+
+	//assert
+	suite.Contains(string(msg), "Subject: =?utf-8?q?R=C3=A9initialiser?=\r\n")
+}
+
+func TestMailerTestSuite(t *testing.T) {
+	suite.Run(t, &MailerTestSuite{})
+}
@@ -0,0 +1,35 @@
+package mailhelpers
+
+import (
+	"net"
+	"net/smtp"
+	"time"
+
+	"github.com/mhogar/amber/common"
+)
+
+// SMTPMailer sends emails through an smtp server.
+// If Username is empty, emails are sent without authenticating.
+type SMTPMailer struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func (m SMTPMailer) SendMail(to string, subject string, body string) error {
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+
+	msg := BuildMessage(m.From, to, subject, body, time.Now())
+
+	err := smtp.SendMail(net.JoinHostPort(m.Host, m.Port), auth, m.From, []string{to}, msg)
+	if err != nil {
+		return common.ChainError("error sending mail", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package mailhelpers_test
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+
+	mailhelpers "github.com/mhogar/amber/controllers/mail_helpers"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SMTPMailerTestSuite struct {
+	helpers.CustomSuite
+	Listener   net.Listener
+	SMTPMailer mailhelpers.SMTPMailer
+}
+
+func (suite *SMTPMailerTestSuite) SetupTest() {
+	var err error
+	suite.Listener, err = net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+
+	host, port, err := net.SplitHostPort(suite.Listener.Addr().String())
+	suite.Require().NoError(err)
+
+	suite.SMTPMailer = mailhelpers.SMTPMailer{
+		Host: host,
+		Port: port,
+		From: "from@example.com",
+	}
+}
+
+func (suite *SMTPMailerTestSuite) TearDownTest() {
+	suite.Listener.Close()
+}
+
+// serveSMTP accepts one connection and answers it like a minimal smtp server.
+// The commands and message data it receives are sent to the returned channel once the connection closes.
+func (suite *SMTPMailerTestSuite) serveSMTP() <-chan []string {
+	received := make(chan []string, 1)
+
+	go func() {
+		lines := []string{}
+		defer func() { received <- lines }()
+
+		conn, err := suite.Listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+
+		reply("220 localhost ready")
+		inData := false
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			lines = append(lines, line)
+
+			if inData {
+				if line == "." {
+					inData = false
+					reply("250 ok")
+				}
+				continue
+			}
+
+			switch strings.ToUpper(strings.SplitN(line, " ", 2)[0]) {
+			case "DATA":
+				inData = true
+				reply("354 send data")
+			case "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+
+	return received
+}
+
+func (suite *SMTPMailerTestSuite) TestSendMail_SendsMailToServer() {
+	//arrange
+	received := suite.serveSMTP()
+
+	//act
+	err := suite.SMTPMailer.SendMail("to@example.com", "Subject", "body")
+
+	//assert
+	suite.Require().NoError(err)
+
+	lines := strings.Join(<-received, "\n")
+	suite.ContainsSubstrings(lines,
+		"MAIL FROM:<from@example.com>",
+		"RCPT TO:<to@example.com>",
+		"To: to@example.com",
+		"Subject: Subject",
+		"\n\nbody\n.",
+	)
+}
+
+func (suite *SMTPMailerTestSuite) TestSendMail_WhereServerIsUnavailable_ReturnsError() {
+	//arrange
+	suite.Listener.Close()
+
+	//act
+	err := suite.SMTPMailer.SendMail("to@example.com", "Subject", "body")
+
+	//assert
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "error sending mail")
+}
+
+func TestSMTPMailerTestSuite(t *testing.T) {
+	suite.Run(t, &SMTPMailerTestSuite{})
+}
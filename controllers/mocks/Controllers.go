@@ -11,6 +11,8 @@ import (
 	models "github.com/mhogar/amber/models"
 
 	uuid "github.com/google/uuid"
+
+	jwthelpers "github.com/mhogar/amber/controllers/jwt_helpers"
 )
 
 // Controllers is an autogenerated mock type for the Controllers type
@@ -18,13 +20,13 @@ type Controllers struct {
 	mock.Mock
 }
 
-// AuthenticateUserWithPassword provides a mock function with given fields: CRUD, username, password
-func (_m *Controllers) AuthenticateUserWithPassword(CRUD controllers.AuthControllerCRUD, username string, password string) (*models.User, common.CustomError) {
-	ret := _m.Called(CRUD, username, password)
+// AcceptInvitation provides a mock function with given fields: CRUD, token, username, password
+func (_m *Controllers) AcceptInvitation(CRUD controllers.InvitationControllerCRUD, token string, username string, password string) (*models.User, common.CustomError) {
+	ret := _m.Called(CRUD, token, username, password)
 
 	var r0 *models.User
-	if rf, ok := ret.Get(0).(func(controllers.AuthControllerCRUD, string, string) *models.User); ok {
-		r0 = rf(CRUD, username, password)
+	if rf, ok := ret.Get(0).(func(controllers.InvitationControllerCRUD, string, string, string) *models.User); ok {
+		r0 = rf(CRUD, token, username, password)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.User)
@@ -32,8 +34,8 @@ func (_m *Controllers) AuthenticateUserWithPassword(CRUD controllers.AuthControl
 	}
 
 	var r1 common.CustomError
-	if rf, ok := ret.Get(1).(func(controllers.AuthControllerCRUD, string, string) common.CustomError); ok {
-		r1 = rf(CRUD, username, password)
+	if rf, ok := ret.Get(1).(func(controllers.InvitationControllerCRUD, string, string, string) common.CustomError); ok {
+		r1 = rf(CRUD, token, username, password)
 	} else {
 		r1 = ret.Get(1).(common.CustomError)
 	}
@@ -41,13 +43,27 @@ func (_m *Controllers) AuthenticateUserWithPassword(CRUD controllers.AuthControl
 	return r0, r1
 }
 
-// CreateClient provides a mock function with given fields: CRUD, client
-func (_m *Controllers) CreateClient(CRUD controllers.ClientControllerCRUD, client *models.Client) common.CustomError {
-	ret := _m.Called(CRUD, client)
+// AssignAdminRolesByRank provides a mock function with given fields: CRUD
+func (_m *Controllers) AssignAdminRolesByRank(CRUD controllers.AdminRoleControllerCRUD) common.CustomError {
+	ret := _m.Called(CRUD)
 
 	var r0 common.CustomError
-	if rf, ok := ret.Get(0).(func(controllers.ClientControllerCRUD, *models.Client) common.CustomError); ok {
-		r0 = rf(CRUD, client)
+	if rf, ok := ret.Get(0).(func(controllers.AdminRoleControllerCRUD) common.CustomError); ok {
+		r0 = rf(CRUD)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// AuthenticateClient provides a mock function with given fields: CRUD, clientUID, secret
+func (_m *Controllers) AuthenticateClient(CRUD controllers.ClientAuthControllerCRUD, clientUID uuid.UUID, secret string) common.CustomError {
+	ret := _m.Called(CRUD, clientUID, secret)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.ClientAuthControllerCRUD, uuid.UUID, string) common.CustomError); ok {
+		r0 = rf(CRUD, clientUID, secret)
 	} else {
 		r0 = ret.Get(0).(common.CustomError)
 	}
@@ -55,21 +71,74 @@ func (_m *Controllers) CreateClient(CRUD controllers.ClientControllerCRUD, clien
 	return r0
 }
 
-// CreateSession provides a mock function with given fields: CRUD, username, password
-func (_m *Controllers) CreateSession(CRUD controllers.SessionControllerCRUD, username string, password string) (*models.Session, common.CustomError) {
+// AuthenticateClientWithSecret provides a mock function with given fields: CRUD, clientUID, secret
+func (_m *Controllers) AuthenticateClientWithSecret(CRUD controllers.ClientAuthControllerCRUD, clientUID uuid.UUID, secret string) (*models.Client, common.CustomError) {
+	ret := _m.Called(CRUD, clientUID, secret)
+
+	var r0 *models.Client
+	if rf, ok := ret.Get(0).(func(controllers.ClientAuthControllerCRUD, uuid.UUID, string) *models.Client); ok {
+		r0 = rf(CRUD, clientUID, secret)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Client)
+		}
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.ClientAuthControllerCRUD, uuid.UUID, string) common.CustomError); ok {
+		r1 = rf(CRUD, clientUID, secret)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// AuthenticateUser provides a mock function with given fields: CRUD, creds
+func (_m *Controllers) AuthenticateUser(CRUD controllers.UserAuthControllerCRUD, creds controllers.UserCredentials) (*models.User, string, common.CustomError) {
+	ret := _m.Called(CRUD, creds)
+
+	var r0 *models.User
+	if rf, ok := ret.Get(0).(func(controllers.UserAuthControllerCRUD, controllers.UserCredentials) *models.User); ok {
+		r0 = rf(CRUD, creds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(controllers.UserAuthControllerCRUD, controllers.UserCredentials) string); ok {
+		r1 = rf(CRUD, creds)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 common.CustomError
+	if rf, ok := ret.Get(2).(func(controllers.UserAuthControllerCRUD, controllers.UserCredentials) common.CustomError); ok {
+		r2 = rf(CRUD, creds)
+	} else {
+		r2 = ret.Get(2).(common.CustomError)
+	}
+
+	return r0, r1, r2
+}
+
+// AuthenticateUserWithPassword provides a mock function with given fields: CRUD, username, password
+func (_m *Controllers) AuthenticateUserWithPassword(CRUD controllers.AuthControllerCRUD, username string, password string) (*models.User, common.CustomError) {
 	ret := _m.Called(CRUD, username, password)
 
-	var r0 *models.Session
-	if rf, ok := ret.Get(0).(func(controllers.SessionControllerCRUD, string, string) *models.Session); ok {
+	var r0 *models.User
+	if rf, ok := ret.Get(0).(func(controllers.AuthControllerCRUD, string, string) *models.User); ok {
 		r0 = rf(CRUD, username, password)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Session)
+			r0 = ret.Get(0).(*models.User)
 		}
 	}
 
 	var r1 common.CustomError
-	if rf, ok := ret.Get(1).(func(controllers.SessionControllerCRUD, string, string) common.CustomError); ok {
+	if rf, ok := ret.Get(1).(func(controllers.AuthControllerCRUD, string, string) common.CustomError); ok {
 		r1 = rf(CRUD, username, password)
 	} else {
 		r1 = ret.Get(1).(common.CustomError)
@@ -78,20 +147,20 @@ func (_m *Controllers) CreateSession(CRUD controllers.SessionControllerCRUD, use
 	return r0, r1
 }
 
-// CreateTokenRedirectURL provides a mock function with given fields: CRUD, clientId, username, password
-func (_m *Controllers) CreateTokenRedirectURL(CRUD controllers.TokenControllerCRUD, clientId uuid.UUID, username string, password string) (string, common.CustomError) {
-	ret := _m.Called(CRUD, clientId, username, password)
+// BeginTOTPEnrollment provides a mock function with given fields: CRUD, username
+func (_m *Controllers) BeginTOTPEnrollment(CRUD controllers.TwoFactorControllerCRUD, username string) (string, common.CustomError) {
+	ret := _m.Called(CRUD, username)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(controllers.TokenControllerCRUD, uuid.UUID, string, string) string); ok {
-		r0 = rf(CRUD, clientId, username, password)
+	if rf, ok := ret.Get(0).(func(controllers.TwoFactorControllerCRUD, string) string); ok {
+		r0 = rf(CRUD, username)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 common.CustomError
-	if rf, ok := ret.Get(1).(func(controllers.TokenControllerCRUD, uuid.UUID, string, string) common.CustomError); ok {
-		r1 = rf(CRUD, clientId, username, password)
+	if rf, ok := ret.Get(1).(func(controllers.TwoFactorControllerCRUD, string) common.CustomError); ok {
+		r1 = rf(CRUD, username)
 	} else {
 		r1 = ret.Get(1).(common.CustomError)
 	}
@@ -99,22 +168,36 @@ func (_m *Controllers) CreateTokenRedirectURL(CRUD controllers.TokenControllerCR
 	return r0, r1
 }
 
-// CreateUser provides a mock function with given fields: CRUD, username, password, rank
-func (_m *Controllers) CreateUser(CRUD controllers.UserControllerCRUD, username string, password string, rank int) (*models.User, common.CustomError) {
-	ret := _m.Called(CRUD, username, password, rank)
+// ClearLockout provides a mock function with given fields: CRUD, throttleType, key
+func (_m *Controllers) ClearLockout(CRUD controllers.LockoutControllerCRUD, throttleType string, key string) common.CustomError {
+	ret := _m.Called(CRUD, throttleType, key)
 
-	var r0 *models.User
-	if rf, ok := ret.Get(0).(func(controllers.UserControllerCRUD, string, string, int) *models.User); ok {
-		r0 = rf(CRUD, username, password, rank)
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.LockoutControllerCRUD, string, string) common.CustomError); ok {
+		r0 = rf(CRUD, throttleType, key)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// ConfirmTOTPEnrollment provides a mock function with given fields: CRUD, username, code
+func (_m *Controllers) ConfirmTOTPEnrollment(CRUD controllers.TwoFactorControllerCRUD, username string, code string) ([]string, common.CustomError) {
+	ret := _m.Called(CRUD, username, code)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(controllers.TwoFactorControllerCRUD, string, string) []string); ok {
+		r0 = rf(CRUD, username, code)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.User)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
 	var r1 common.CustomError
-	if rf, ok := ret.Get(1).(func(controllers.UserControllerCRUD, string, string, int) common.CustomError); ok {
-		r1 = rf(CRUD, username, password, rank)
+	if rf, ok := ret.Get(1).(func(controllers.TwoFactorControllerCRUD, string, string) common.CustomError); ok {
+		r1 = rf(CRUD, username, code)
 	} else {
 		r1 = ret.Get(1).(common.CustomError)
 	}
@@ -122,12 +205,12 @@ func (_m *Controllers) CreateUser(CRUD controllers.UserControllerCRUD, username
 	return r0, r1
 }
 
-// CreateUserRole provides a mock function with given fields: CRUD, role
-func (_m *Controllers) CreateUserRole(CRUD controllers.UserRoleControllerCRUD, role *models.UserRole) common.CustomError {
+// CreateAdminRole provides a mock function with given fields: CRUD, role
+func (_m *Controllers) CreateAdminRole(CRUD controllers.AdminRoleControllerCRUD, role *models.AdminRole) common.CustomError {
 	ret := _m.Called(CRUD, role)
 
 	var r0 common.CustomError
-	if rf, ok := ret.Get(0).(func(controllers.UserRoleControllerCRUD, *models.UserRole) common.CustomError); ok {
+	if rf, ok := ret.Get(0).(func(controllers.AdminRoleControllerCRUD, *models.AdminRole) common.CustomError); ok {
 		r0 = rf(CRUD, role)
 	} else {
 		r0 = ret.Get(0).(common.CustomError)
@@ -136,13 +219,13 @@ func (_m *Controllers) CreateUserRole(CRUD controllers.UserRoleControllerCRUD, r
 	return r0
 }
 
-// DeleteAllOtherUserSessions provides a mock function with given fields: CRUD, username, id
-func (_m *Controllers) DeleteAllOtherUserSessions(CRUD controllers.SessionControllerCRUD, username string, id uuid.UUID) common.CustomError {
-	ret := _m.Called(CRUD, username, id)
+// CreateAuditEvent provides a mock function with given fields: CRUD, event
+func (_m *Controllers) CreateAuditEvent(CRUD controllers.AuditControllerCRUD, event *models.AuditEvent) common.CustomError {
+	ret := _m.Called(CRUD, event)
 
 	var r0 common.CustomError
-	if rf, ok := ret.Get(0).(func(controllers.SessionControllerCRUD, string, uuid.UUID) common.CustomError); ok {
-		r0 = rf(CRUD, username, id)
+	if rf, ok := ret.Get(0).(func(controllers.AuditControllerCRUD, *models.AuditEvent) common.CustomError); ok {
+		r0 = rf(CRUD, event)
 	} else {
 		r0 = ret.Get(0).(common.CustomError)
 	}
@@ -150,13 +233,41 @@ func (_m *Controllers) DeleteAllOtherUserSessions(CRUD controllers.SessionContro
 	return r0
 }
 
-// DeleteAllUserSessions provides a mock function with given fields: CRUD, username
-func (_m *Controllers) DeleteAllUserSessions(CRUD controllers.SessionControllerCRUD, username string) common.CustomError {
-	ret := _m.Called(CRUD, username)
+// CreateAuthorizationCodeRedirectURL provides a mock function with given fields: CRUD, authReq, creds
+func (_m *Controllers) CreateAuthorizationCodeRedirectURL(CRUD controllers.TokenControllerCRUD, authReq controllers.AuthorizationRequest, creds controllers.UserCredentials) (string, string, common.CustomError) {
+	ret := _m.Called(CRUD, authReq, creds)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(controllers.TokenControllerCRUD, controllers.AuthorizationRequest, controllers.UserCredentials) string); ok {
+		r0 = rf(CRUD, authReq, creds)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(controllers.TokenControllerCRUD, controllers.AuthorizationRequest, controllers.UserCredentials) string); ok {
+		r1 = rf(CRUD, authReq, creds)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 common.CustomError
+	if rf, ok := ret.Get(2).(func(controllers.TokenControllerCRUD, controllers.AuthorizationRequest, controllers.UserCredentials) common.CustomError); ok {
+		r2 = rf(CRUD, authReq, creds)
+	} else {
+		r2 = ret.Get(2).(common.CustomError)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateBuiltInAdminRoles provides a mock function with given fields: CRUD
+func (_m *Controllers) CreateBuiltInAdminRoles(CRUD controllers.AdminRoleControllerCRUD) common.CustomError {
+	ret := _m.Called(CRUD)
 
 	var r0 common.CustomError
-	if rf, ok := ret.Get(0).(func(controllers.SessionControllerCRUD, string) common.CustomError); ok {
-		r0 = rf(CRUD, username)
+	if rf, ok := ret.Get(0).(func(controllers.AdminRoleControllerCRUD) common.CustomError); ok {
+		r0 = rf(CRUD)
 	} else {
 		r0 = ret.Get(0).(common.CustomError)
 	}
@@ -164,13 +275,13 @@ func (_m *Controllers) DeleteAllUserSessions(CRUD controllers.SessionControllerC
 	return r0
 }
 
-// DeleteClient provides a mock function with given fields: CRUD, uid
-func (_m *Controllers) DeleteClient(CRUD controllers.ClientControllerCRUD, uid uuid.UUID) common.CustomError {
-	ret := _m.Called(CRUD, uid)
+// CreateClient provides a mock function with given fields: CRUD, client
+func (_m *Controllers) CreateClient(CRUD controllers.ClientControllerCRUD, client *models.Client) common.CustomError {
+	ret := _m.Called(CRUD, client)
 
 	var r0 common.CustomError
-	if rf, ok := ret.Get(0).(func(controllers.ClientControllerCRUD, uuid.UUID) common.CustomError); ok {
-		r0 = rf(CRUD, uid)
+	if rf, ok := ret.Get(0).(func(controllers.ClientControllerCRUD, *models.Client) common.CustomError); ok {
+		r0 = rf(CRUD, client)
 	} else {
 		r0 = ret.Get(0).(common.CustomError)
 	}
@@ -178,13 +289,13 @@ func (_m *Controllers) DeleteClient(CRUD controllers.ClientControllerCRUD, uid u
 	return r0
 }
 
-// DeleteSession provides a mock function with given fields: CRUD, id
-func (_m *Controllers) DeleteSession(CRUD controllers.SessionControllerCRUD, id uuid.UUID) common.CustomError {
-	ret := _m.Called(CRUD, id)
+// CreateClientAdmin provides a mock function with given fields: CRUD, admin
+func (_m *Controllers) CreateClientAdmin(CRUD controllers.ClientAdminControllerCRUD, admin *models.ClientAdmin) common.CustomError {
+	ret := _m.Called(CRUD, admin)
 
 	var r0 common.CustomError
-	if rf, ok := ret.Get(0).(func(controllers.SessionControllerCRUD, uuid.UUID) common.CustomError); ok {
-		r0 = rf(CRUD, id)
+	if rf, ok := ret.Get(0).(func(controllers.ClientAdminControllerCRUD, *models.ClientAdmin) common.CustomError); ok {
+		r0 = rf(CRUD, admin)
 	} else {
 		r0 = ret.Get(0).(common.CustomError)
 	}
@@ -192,13 +303,34 @@ func (_m *Controllers) DeleteSession(CRUD controllers.SessionControllerCRUD, id
 	return r0
 }
 
-// DeleteUser provides a mock function with given fields: CRUD, username
-func (_m *Controllers) DeleteUser(CRUD controllers.UserControllerCRUD, username string) common.CustomError {
-	ret := _m.Called(CRUD, username)
+// CreateClientCredentialsToken provides a mock function with given fields: CRUD, clientUID, secret
+func (_m *Controllers) CreateClientCredentialsToken(CRUD controllers.TokenControllerCRUD, clientUID uuid.UUID, secret string) (string, common.CustomError) {
+	ret := _m.Called(CRUD, clientUID, secret)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(controllers.TokenControllerCRUD, uuid.UUID, string) string); ok {
+		r0 = rf(CRUD, clientUID, secret)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.TokenControllerCRUD, uuid.UUID, string) common.CustomError); ok {
+		r1 = rf(CRUD, clientUID, secret)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// CreateGroup provides a mock function with given fields: CRUD, group
+func (_m *Controllers) CreateGroup(CRUD controllers.GroupControllerCRUD, group *models.Group) common.CustomError {
+	ret := _m.Called(CRUD, group)
 
 	var r0 common.CustomError
-	if rf, ok := ret.Get(0).(func(controllers.UserControllerCRUD, string) common.CustomError); ok {
-		r0 = rf(CRUD, username)
+	if rf, ok := ret.Get(0).(func(controllers.GroupControllerCRUD, *models.Group) common.CustomError); ok {
+		r0 = rf(CRUD, group)
 	} else {
 		r0 = ret.Get(0).(common.CustomError)
 	}
@@ -206,13 +338,13 @@ func (_m *Controllers) DeleteUser(CRUD controllers.UserControllerCRUD, username
 	return r0
 }
 
-// DeleteUserRole provides a mock function with given fields: CRUD, clientUID, username
-func (_m *Controllers) DeleteUserRole(CRUD controllers.UserRoleControllerCRUD, clientUID uuid.UUID, username string) common.CustomError {
-	ret := _m.Called(CRUD, clientUID, username)
+// CreateGroupMember provides a mock function with given fields: CRUD, name, username
+func (_m *Controllers) CreateGroupMember(CRUD controllers.GroupControllerCRUD, name string, username string) common.CustomError {
+	ret := _m.Called(CRUD, name, username)
 
 	var r0 common.CustomError
-	if rf, ok := ret.Get(0).(func(controllers.UserRoleControllerCRUD, uuid.UUID, string) common.CustomError); ok {
-		r0 = rf(CRUD, clientUID, username)
+	if rf, ok := ret.Get(0).(func(controllers.GroupControllerCRUD, string, string) common.CustomError); ok {
+		r0 = rf(CRUD, name, username)
 	} else {
 		r0 = ret.Get(0).(common.CustomError)
 	}
@@ -220,68 +352,138 @@ func (_m *Controllers) DeleteUserRole(CRUD controllers.UserRoleControllerCRUD, c
 	return r0
 }
 
-// GetClients provides a mock function with given fields: CRUD
-func (_m *Controllers) GetClients(CRUD controllers.ClientControllerCRUD) ([]*models.Client, common.CustomError) {
-	ret := _m.Called(CRUD)
+// CreateGroupRole provides a mock function with given fields: CRUD, role
+func (_m *Controllers) CreateGroupRole(CRUD controllers.GroupRoleControllerCRUD, role *models.GroupRole) common.CustomError {
+	ret := _m.Called(CRUD, role)
 
-	var r0 []*models.Client
-	if rf, ok := ret.Get(0).(func(controllers.ClientControllerCRUD) []*models.Client); ok {
-		r0 = rf(CRUD)
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.GroupRoleControllerCRUD, *models.GroupRole) common.CustomError); ok {
+		r0 = rf(CRUD, role)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// CreateInvitation provides a mock function with given fields: CRUD, email, rank, roles
+func (_m *Controllers) CreateInvitation(CRUD controllers.InvitationControllerCRUD, email string, rank int, roles []*models.InvitationRole) (*models.Invitation, string, common.CustomError) {
+	ret := _m.Called(CRUD, email, rank, roles)
+
+	var r0 *models.Invitation
+	if rf, ok := ret.Get(0).(func(controllers.InvitationControllerCRUD, string, int, []*models.InvitationRole) *models.Invitation); ok {
+		r0 = rf(CRUD, email, rank, roles)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Client)
+			r0 = ret.Get(0).(*models.Invitation)
 		}
 	}
 
-	var r1 common.CustomError
-	if rf, ok := ret.Get(1).(func(controllers.ClientControllerCRUD) common.CustomError); ok {
-		r1 = rf(CRUD)
+	var r1 string
+	if rf, ok := ret.Get(1).(func(controllers.InvitationControllerCRUD, string, int, []*models.InvitationRole) string); ok {
+		r1 = rf(CRUD, email, rank, roles)
 	} else {
-		r1 = ret.Get(1).(common.CustomError)
+		r1 = ret.Get(1).(string)
 	}
 
-	return r0, r1
+	var r2 common.CustomError
+	if rf, ok := ret.Get(2).(func(controllers.InvitationControllerCRUD, string, int, []*models.InvitationRole) common.CustomError); ok {
+		r2 = rf(CRUD, email, rank, roles)
+	} else {
+		r2 = ret.Get(2).(common.CustomError)
+	}
+
+	return r0, r1, r2
 }
 
-// GetUserRolesWithLesserRankByClientUID provides a mock function with given fields: CRUD, clientUID, rank
-func (_m *Controllers) GetUserRolesWithLesserRankByClientUID(CRUD controllers.UserRoleControllerCRUD, clientUID uuid.UUID, rank int) ([]*models.UserRole, common.CustomError) {
-	ret := _m.Called(CRUD, clientUID, rank)
+// CreateRoleDefinition provides a mock function with given fields: CRUD, definition
+func (_m *Controllers) CreateRoleDefinition(CRUD controllers.RoleDefinitionControllerCRUD, definition *models.RoleDefinition) common.CustomError {
+	ret := _m.Called(CRUD, definition)
 
-	var r0 []*models.UserRole
-	if rf, ok := ret.Get(0).(func(controllers.UserRoleControllerCRUD, uuid.UUID, int) []*models.UserRole); ok {
-		r0 = rf(CRUD, clientUID, rank)
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.RoleDefinitionControllerCRUD, *models.RoleDefinition) common.CustomError); ok {
+		r0 = rf(CRUD, definition)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// CreateSession provides a mock function with given fields: CRUD, creds
+func (_m *Controllers) CreateSession(CRUD controllers.SessionControllerCRUD, creds controllers.UserCredentials) (*models.Session, string, common.CustomError) {
+	ret := _m.Called(CRUD, creds)
+
+	var r0 *models.Session
+	if rf, ok := ret.Get(0).(func(controllers.SessionControllerCRUD, controllers.UserCredentials) *models.Session); ok {
+		r0 = rf(CRUD, creds)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.UserRole)
+			r0 = ret.Get(0).(*models.Session)
 		}
 	}
 
-	var r1 common.CustomError
-	if rf, ok := ret.Get(1).(func(controllers.UserRoleControllerCRUD, uuid.UUID, int) common.CustomError); ok {
-		r1 = rf(CRUD, clientUID, rank)
+	var r1 string
+	if rf, ok := ret.Get(1).(func(controllers.SessionControllerCRUD, controllers.UserCredentials) string); ok {
+		r1 = rf(CRUD, creds)
 	} else {
-		r1 = ret.Get(1).(common.CustomError)
+		r1 = ret.Get(1).(string)
 	}
 
-	return r0, r1
+	var r2 common.CustomError
+	if rf, ok := ret.Get(2).(func(controllers.SessionControllerCRUD, controllers.UserCredentials) common.CustomError); ok {
+		r2 = rf(CRUD, creds)
+	} else {
+		r2 = ret.Get(2).(common.CustomError)
+	}
+
+	return r0, r1, r2
 }
 
-// GetUsersWithLesserRank provides a mock function with given fields: CRUD, rank
-func (_m *Controllers) GetUsersWithLesserRank(CRUD controllers.UserControllerCRUD, rank int) ([]*models.User, common.CustomError) {
-	ret := _m.Called(CRUD, rank)
+// CreateTokenRedirectURL provides a mock function with given fields: CRUD, clientId, creds
+func (_m *Controllers) CreateTokenRedirectURL(CRUD controllers.TokenControllerCRUD, clientId uuid.UUID, creds controllers.UserCredentials) (string, string, common.CustomError) {
+	ret := _m.Called(CRUD, clientId, creds)
 
-	var r0 []*models.User
-	if rf, ok := ret.Get(0).(func(controllers.UserControllerCRUD, int) []*models.User); ok {
-		r0 = rf(CRUD, rank)
+	var r0 string
+	if rf, ok := ret.Get(0).(func(controllers.TokenControllerCRUD, uuid.UUID, controllers.UserCredentials) string); ok {
+		r0 = rf(CRUD, clientId, creds)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(controllers.TokenControllerCRUD, uuid.UUID, controllers.UserCredentials) string); ok {
+		r1 = rf(CRUD, clientId, creds)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 common.CustomError
+	if rf, ok := ret.Get(2).(func(controllers.TokenControllerCRUD, uuid.UUID, controllers.UserCredentials) common.CustomError); ok {
+		r2 = rf(CRUD, clientId, creds)
+	} else {
+		r2 = ret.Get(2).(common.CustomError)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateUser provides a mock function with given fields: CRUD, username, password, rank, profile
+func (_m *Controllers) CreateUser(CRUD controllers.UserControllerCRUD, username string, password string, rank int, profile models.UserProfile) (*models.User, common.CustomError) {
+	ret := _m.Called(CRUD, username, password, rank, profile)
+
+	var r0 *models.User
+	if rf, ok := ret.Get(0).(func(controllers.UserControllerCRUD, string, string, int, models.UserProfile) *models.User); ok {
+		r0 = rf(CRUD, username, password, rank, profile)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.User)
+			r0 = ret.Get(0).(*models.User)
 		}
 	}
 
 	var r1 common.CustomError
-	if rf, ok := ret.Get(1).(func(controllers.UserControllerCRUD, int) common.CustomError); ok {
-		r1 = rf(CRUD, rank)
+	if rf, ok := ret.Get(1).(func(controllers.UserControllerCRUD, string, string, int, models.UserProfile) common.CustomError); ok {
+		r1 = rf(CRUD, username, password, rank, profile)
 	} else {
 		r1 = ret.Get(1).(common.CustomError)
 	}
@@ -289,13 +491,13 @@ func (_m *Controllers) GetUsersWithLesserRank(CRUD controllers.UserControllerCRU
 	return r0, r1
 }
 
-// UpdateClient provides a mock function with given fields: CRUD, client
-func (_m *Controllers) UpdateClient(CRUD controllers.ClientControllerCRUD, client *models.Client) common.CustomError {
-	ret := _m.Called(CRUD, client)
+// CreateUserRole provides a mock function with given fields: CRUD, role
+func (_m *Controllers) CreateUserRole(CRUD controllers.UserRoleControllerCRUD, role *models.UserRole) common.CustomError {
+	ret := _m.Called(CRUD, role)
 
 	var r0 common.CustomError
-	if rf, ok := ret.Get(0).(func(controllers.ClientControllerCRUD, *models.Client) common.CustomError); ok {
-		r0 = rf(CRUD, client)
+	if rf, ok := ret.Get(0).(func(controllers.UserRoleControllerCRUD, *models.UserRole) common.CustomError); ok {
+		r0 = rf(CRUD, role)
 	} else {
 		r0 = ret.Get(0).(common.CustomError)
 	}
@@ -303,36 +505,27 @@ func (_m *Controllers) UpdateClient(CRUD controllers.ClientControllerCRUD, clien
 	return r0
 }
 
-// UpdateUser provides a mock function with given fields: CRUD, username, rank
-func (_m *Controllers) UpdateUser(CRUD controllers.UserControllerCRUD, username string, rank int) (*models.User, common.CustomError) {
-	ret := _m.Called(CRUD, username, rank)
-
-	var r0 *models.User
-	if rf, ok := ret.Get(0).(func(controllers.UserControllerCRUD, string, int) *models.User); ok {
-		r0 = rf(CRUD, username, rank)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.User)
-		}
-	}
+// DeleteAdminRole provides a mock function with given fields: CRUD, name
+func (_m *Controllers) DeleteAdminRole(CRUD controllers.AdminRoleControllerCRUD, name string) common.CustomError {
+	ret := _m.Called(CRUD, name)
 
-	var r1 common.CustomError
-	if rf, ok := ret.Get(1).(func(controllers.UserControllerCRUD, string, int) common.CustomError); ok {
-		r1 = rf(CRUD, username, rank)
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.AdminRoleControllerCRUD, string) common.CustomError); ok {
+		r0 = rf(CRUD, name)
 	} else {
-		r1 = ret.Get(1).(common.CustomError)
+		r0 = ret.Get(0).(common.CustomError)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// UpdateUserPassword provides a mock function with given fields: CRUD, username, password
-func (_m *Controllers) UpdateUserPassword(CRUD controllers.UserControllerCRUD, username string, password string) common.CustomError {
-	ret := _m.Called(CRUD, username, password)
+// DeleteAllOtherUserSessions provides a mock function with given fields: CRUD, username, id
+func (_m *Controllers) DeleteAllOtherUserSessions(CRUD controllers.SessionControllerCRUD, username string, id uuid.UUID) common.CustomError {
+	ret := _m.Called(CRUD, username, id)
 
 	var r0 common.CustomError
-	if rf, ok := ret.Get(0).(func(controllers.UserControllerCRUD, string, string) common.CustomError); ok {
-		r0 = rf(CRUD, username, password)
+	if rf, ok := ret.Get(0).(func(controllers.SessionControllerCRUD, string, uuid.UUID) common.CustomError); ok {
+		r0 = rf(CRUD, username, id)
 	} else {
 		r0 = ret.Get(0).(common.CustomError)
 	}
@@ -340,13 +533,13 @@ func (_m *Controllers) UpdateUserPassword(CRUD controllers.UserControllerCRUD, u
 	return r0
 }
 
-// UpdateUserPasswordWithAuth provides a mock function with given fields: CRUD, username, oldPassword, newPassword
-func (_m *Controllers) UpdateUserPasswordWithAuth(CRUD controllers.UserControllerCRUD, username string, oldPassword string, newPassword string) common.CustomError {
-	ret := _m.Called(CRUD, username, oldPassword, newPassword)
+// DeleteAllUserSessions provides a mock function with given fields: CRUD, username
+func (_m *Controllers) DeleteAllUserSessions(CRUD controllers.SessionControllerCRUD, username string) common.CustomError {
+	ret := _m.Called(CRUD, username)
 
 	var r0 common.CustomError
-	if rf, ok := ret.Get(0).(func(controllers.UserControllerCRUD, string, string, string) common.CustomError); ok {
-		r0 = rf(CRUD, username, oldPassword, newPassword)
+	if rf, ok := ret.Get(0).(func(controllers.SessionControllerCRUD, string) common.CustomError); ok {
+		r0 = rf(CRUD, username)
 	} else {
 		r0 = ret.Get(0).(common.CustomError)
 	}
@@ -354,12 +547,769 @@ func (_m *Controllers) UpdateUserPasswordWithAuth(CRUD controllers.UserControlle
 	return r0
 }
 
-// UpdateUserRole provides a mock function with given fields: CRUD, role
-func (_m *Controllers) UpdateUserRole(CRUD controllers.UserRoleControllerCRUD, role *models.UserRole) common.CustomError {
-	ret := _m.Called(CRUD, role)
+// DeleteClient provides a mock function with given fields: CRUD, uid
+func (_m *Controllers) DeleteClient(CRUD controllers.ClientControllerCRUD, uid uuid.UUID) common.CustomError {
+	ret := _m.Called(CRUD, uid)
 
 	var r0 common.CustomError
-	if rf, ok := ret.Get(0).(func(controllers.UserRoleControllerCRUD, *models.UserRole) common.CustomError); ok {
+	if rf, ok := ret.Get(0).(func(controllers.ClientControllerCRUD, uuid.UUID) common.CustomError); ok {
+		r0 = rf(CRUD, uid)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// DeleteClientAdmin provides a mock function with given fields: CRUD, clientUID, username
+func (_m *Controllers) DeleteClientAdmin(CRUD controllers.ClientAdminControllerCRUD, clientUID uuid.UUID, username string) common.CustomError {
+	ret := _m.Called(CRUD, clientUID, username)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.ClientAdminControllerCRUD, uuid.UUID, string) common.CustomError); ok {
+		r0 = rf(CRUD, clientUID, username)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// DeleteExpiredSessions provides a mock function with given fields: CRUD
+func (_m *Controllers) DeleteExpiredSessions(CRUD controllers.SessionControllerCRUD) common.CustomError {
+	ret := _m.Called(CRUD)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.SessionControllerCRUD) common.CustomError); ok {
+		r0 = rf(CRUD)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// DeleteGroup provides a mock function with given fields: CRUD, name
+func (_m *Controllers) DeleteGroup(CRUD controllers.GroupControllerCRUD, name string) common.CustomError {
+	ret := _m.Called(CRUD, name)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.GroupControllerCRUD, string) common.CustomError); ok {
+		r0 = rf(CRUD, name)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// DeleteGroupMember provides a mock function with given fields: CRUD, name, username
+func (_m *Controllers) DeleteGroupMember(CRUD controllers.GroupControllerCRUD, name string, username string) common.CustomError {
+	ret := _m.Called(CRUD, name, username)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.GroupControllerCRUD, string, string) common.CustomError); ok {
+		r0 = rf(CRUD, name, username)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// DeleteGroupRole provides a mock function with given fields: CRUD, clientUID, groupName
+func (_m *Controllers) DeleteGroupRole(CRUD controllers.GroupRoleControllerCRUD, clientUID uuid.UUID, groupName string) common.CustomError {
+	ret := _m.Called(CRUD, clientUID, groupName)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.GroupRoleControllerCRUD, uuid.UUID, string) common.CustomError); ok {
+		r0 = rf(CRUD, clientUID, groupName)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// DeleteInvitation provides a mock function with given fields: CRUD, id
+func (_m *Controllers) DeleteInvitation(CRUD controllers.InvitationControllerCRUD, id uuid.UUID) common.CustomError {
+	ret := _m.Called(CRUD, id)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.InvitationControllerCRUD, uuid.UUID) common.CustomError); ok {
+		r0 = rf(CRUD, id)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// DeleteRoleDefinition provides a mock function with given fields: CRUD, clientUID, name
+func (_m *Controllers) DeleteRoleDefinition(CRUD controllers.RoleDefinitionControllerCRUD, clientUID uuid.UUID, name string) common.CustomError {
+	ret := _m.Called(CRUD, clientUID, name)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.RoleDefinitionControllerCRUD, uuid.UUID, string) common.CustomError); ok {
+		r0 = rf(CRUD, clientUID, name)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// DeleteSession provides a mock function with given fields: CRUD, id
+func (_m *Controllers) DeleteSession(CRUD controllers.SessionControllerCRUD, id uuid.UUID) common.CustomError {
+	ret := _m.Called(CRUD, id)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.SessionControllerCRUD, uuid.UUID) common.CustomError); ok {
+		r0 = rf(CRUD, id)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// DeleteUser provides a mock function with given fields: CRUD, username
+func (_m *Controllers) DeleteUser(CRUD controllers.UserControllerCRUD, username string) common.CustomError {
+	ret := _m.Called(CRUD, username)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.UserControllerCRUD, string) common.CustomError); ok {
+		r0 = rf(CRUD, username)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// DeleteUserRole provides a mock function with given fields: CRUD, clientUID, username
+func (_m *Controllers) DeleteUserRole(CRUD controllers.UserRoleControllerCRUD, clientUID uuid.UUID, username string) common.CustomError {
+	ret := _m.Called(CRUD, clientUID, username)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.UserRoleControllerCRUD, uuid.UUID, string) common.CustomError); ok {
+		r0 = rf(CRUD, clientUID, username)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// DisableTOTP provides a mock function with given fields: CRUD, username, code
+func (_m *Controllers) DisableTOTP(CRUD controllers.TwoFactorControllerCRUD, username string, code string) common.CustomError {
+	ret := _m.Called(CRUD, username, code)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.TwoFactorControllerCRUD, string, string) common.CustomError); ok {
+		r0 = rf(CRUD, username, code)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// ExchangeAuthorizationCode provides a mock function with given fields: CRUD, clientUID, code, redirectURI, codeVerifier
+func (_m *Controllers) ExchangeAuthorizationCode(CRUD controllers.TokenControllerCRUD, clientUID uuid.UUID, code uuid.UUID, redirectURI string, codeVerifier string) (*controllers.OAuthTokens, common.CustomError) {
+	ret := _m.Called(CRUD, clientUID, code, redirectURI, codeVerifier)
+
+	var r0 *controllers.OAuthTokens
+	if rf, ok := ret.Get(0).(func(controllers.TokenControllerCRUD, uuid.UUID, uuid.UUID, string, string) *controllers.OAuthTokens); ok {
+		r0 = rf(CRUD, clientUID, code, redirectURI, codeVerifier)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*controllers.OAuthTokens)
+		}
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.TokenControllerCRUD, uuid.UUID, uuid.UUID, string, string) common.CustomError); ok {
+		r1 = rf(CRUD, clientUID, code, redirectURI, codeVerifier)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// GetAdminRoles provides a mock function with given fields: CRUD
+func (_m *Controllers) GetAdminRoles(CRUD controllers.AdminRoleControllerCRUD) ([]*models.AdminRole, common.CustomError) {
+	ret := _m.Called(CRUD)
+
+	var r0 []*models.AdminRole
+	if rf, ok := ret.Get(0).(func(controllers.AdminRoleControllerCRUD) []*models.AdminRole); ok {
+		r0 = rf(CRUD)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AdminRole)
+		}
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.AdminRoleControllerCRUD) common.CustomError); ok {
+		r1 = rf(CRUD)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// GetAuditEvents provides a mock function with given fields: CRUD, filter
+func (_m *Controllers) GetAuditEvents(CRUD controllers.AuditControllerCRUD, filter models.AuditEventFilter) ([]*models.AuditEvent, common.CustomError) {
+	ret := _m.Called(CRUD, filter)
+
+	var r0 []*models.AuditEvent
+	if rf, ok := ret.Get(0).(func(controllers.AuditControllerCRUD, models.AuditEventFilter) []*models.AuditEvent); ok {
+		r0 = rf(CRUD, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AuditEvent)
+		}
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.AuditControllerCRUD, models.AuditEventFilter) common.CustomError); ok {
+		r1 = rf(CRUD, filter)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// GetClientAdminsByClientUID provides a mock function with given fields: CRUD, clientUID
+func (_m *Controllers) GetClientAdminsByClientUID(CRUD controllers.ClientAdminControllerCRUD, clientUID uuid.UUID) ([]*models.ClientAdmin, common.CustomError) {
+	ret := _m.Called(CRUD, clientUID)
+
+	var r0 []*models.ClientAdmin
+	if rf, ok := ret.Get(0).(func(controllers.ClientAdminControllerCRUD, uuid.UUID) []*models.ClientAdmin); ok {
+		r0 = rf(CRUD, clientUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ClientAdmin)
+		}
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.ClientAdminControllerCRUD, uuid.UUID) common.CustomError); ok {
+		r1 = rf(CRUD, clientUID)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// GetClients provides a mock function with given fields: CRUD, query
+func (_m *Controllers) GetClients(CRUD controllers.ClientControllerCRUD, query models.PageQuery) ([]*models.Client, models.PageInfo, common.CustomError) {
+	ret := _m.Called(CRUD, query)
+
+	var r0 []*models.Client
+	if rf, ok := ret.Get(0).(func(controllers.ClientControllerCRUD, models.PageQuery) []*models.Client); ok {
+		r0 = rf(CRUD, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Client)
+		}
+	}
+
+	var r1 models.PageInfo
+	if rf, ok := ret.Get(1).(func(controllers.ClientControllerCRUD, models.PageQuery) models.PageInfo); ok {
+		r1 = rf(CRUD, query)
+	} else {
+		r1 = ret.Get(1).(models.PageInfo)
+	}
+
+	var r2 common.CustomError
+	if rf, ok := ret.Get(2).(func(controllers.ClientControllerCRUD, models.PageQuery) common.CustomError); ok {
+		r2 = rf(CRUD, query)
+	} else {
+		r2 = ret.Get(2).(common.CustomError)
+	}
+
+	return r0, r1, r2
+}
+
+// GetGroupMembers provides a mock function with given fields: CRUD, name
+func (_m *Controllers) GetGroupMembers(CRUD controllers.GroupControllerCRUD, name string) ([]string, common.CustomError) {
+	ret := _m.Called(CRUD, name)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(controllers.GroupControllerCRUD, string) []string); ok {
+		r0 = rf(CRUD, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.GroupControllerCRUD, string) common.CustomError); ok {
+		r1 = rf(CRUD, name)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// GetGroupRolesByClientUID provides a mock function with given fields: CRUD, clientUID
+func (_m *Controllers) GetGroupRolesByClientUID(CRUD controllers.GroupRoleControllerCRUD, clientUID uuid.UUID) ([]*models.GroupRole, common.CustomError) {
+	ret := _m.Called(CRUD, clientUID)
+
+	var r0 []*models.GroupRole
+	if rf, ok := ret.Get(0).(func(controllers.GroupRoleControllerCRUD, uuid.UUID) []*models.GroupRole); ok {
+		r0 = rf(CRUD, clientUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.GroupRole)
+		}
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.GroupRoleControllerCRUD, uuid.UUID) common.CustomError); ok {
+		r1 = rf(CRUD, clientUID)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// GetGroups provides a mock function with given fields: CRUD
+func (_m *Controllers) GetGroups(CRUD controllers.GroupControllerCRUD) ([]*models.Group, common.CustomError) {
+	ret := _m.Called(CRUD)
+
+	var r0 []*models.Group
+	if rf, ok := ret.Get(0).(func(controllers.GroupControllerCRUD) []*models.Group); ok {
+		r0 = rf(CRUD)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Group)
+		}
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.GroupControllerCRUD) common.CustomError); ok {
+		r1 = rf(CRUD)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// GetJSONWebKeySet provides a mock function with given fields: CRUD
+func (_m *Controllers) GetJSONWebKeySet(CRUD controllers.TokenControllerCRUD) (*jwthelpers.JWKS, common.CustomError) {
+	ret := _m.Called(CRUD)
+
+	var r0 *jwthelpers.JWKS
+	if rf, ok := ret.Get(0).(func(controllers.TokenControllerCRUD) *jwthelpers.JWKS); ok {
+		r0 = rf(CRUD)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*jwthelpers.JWKS)
+		}
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.TokenControllerCRUD) common.CustomError); ok {
+		r1 = rf(CRUD)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// GetLockouts provides a mock function with given fields: CRUD
+func (_m *Controllers) GetLockouts(CRUD controllers.LockoutControllerCRUD) ([]*models.LoginThrottle, common.CustomError) {
+	ret := _m.Called(CRUD)
+
+	var r0 []*models.LoginThrottle
+	if rf, ok := ret.Get(0).(func(controllers.LockoutControllerCRUD) []*models.LoginThrottle); ok {
+		r0 = rf(CRUD)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.LoginThrottle)
+		}
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.LockoutControllerCRUD) common.CustomError); ok {
+		r1 = rf(CRUD)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// GetRoleDefinitionsByClientUID provides a mock function with given fields: CRUD, clientUID
+func (_m *Controllers) GetRoleDefinitionsByClientUID(CRUD controllers.RoleDefinitionControllerCRUD, clientUID uuid.UUID) ([]*models.RoleDefinition, common.CustomError) {
+	ret := _m.Called(CRUD, clientUID)
+
+	var r0 []*models.RoleDefinition
+	if rf, ok := ret.Get(0).(func(controllers.RoleDefinitionControllerCRUD, uuid.UUID) []*models.RoleDefinition); ok {
+		r0 = rf(CRUD, clientUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.RoleDefinition)
+		}
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.RoleDefinitionControllerCRUD, uuid.UUID) common.CustomError); ok {
+		r1 = rf(CRUD, clientUID)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// GetUserAdminRoles provides a mock function with given fields: CRUD, username
+func (_m *Controllers) GetUserAdminRoles(CRUD controllers.AdminRoleControllerCRUD, username string) ([]*models.AdminRole, common.CustomError) {
+	ret := _m.Called(CRUD, username)
+
+	var r0 []*models.AdminRole
+	if rf, ok := ret.Get(0).(func(controllers.AdminRoleControllerCRUD, string) []*models.AdminRole); ok {
+		r0 = rf(CRUD, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AdminRole)
+		}
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.AdminRoleControllerCRUD, string) common.CustomError); ok {
+		r1 = rf(CRUD, username)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// GetUserRoleAssignments provides a mock function with given fields: CRUD, clientUID, username
+func (_m *Controllers) GetUserRoleAssignments(CRUD controllers.GroupRoleControllerCRUD, clientUID uuid.UUID, username string) ([]*models.RoleAssignment, common.CustomError) {
+	ret := _m.Called(CRUD, clientUID, username)
+
+	var r0 []*models.RoleAssignment
+	if rf, ok := ret.Get(0).(func(controllers.GroupRoleControllerCRUD, uuid.UUID, string) []*models.RoleAssignment); ok {
+		r0 = rf(CRUD, clientUID, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.RoleAssignment)
+		}
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.GroupRoleControllerCRUD, uuid.UUID, string) common.CustomError); ok {
+		r1 = rf(CRUD, clientUID, username)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// GetUserRolesWithLesserRankByClientUID provides a mock function with given fields: CRUD, clientUID, rank, query
+func (_m *Controllers) GetUserRolesWithLesserRankByClientUID(CRUD controllers.UserRoleControllerCRUD, clientUID uuid.UUID, rank int, query models.PageQuery) ([]*models.UserRole, models.PageInfo, common.CustomError) {
+	ret := _m.Called(CRUD, clientUID, rank, query)
+
+	var r0 []*models.UserRole
+	if rf, ok := ret.Get(0).(func(controllers.UserRoleControllerCRUD, uuid.UUID, int, models.PageQuery) []*models.UserRole); ok {
+		r0 = rf(CRUD, clientUID, rank, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.UserRole)
+		}
+	}
+
+	var r1 models.PageInfo
+	if rf, ok := ret.Get(1).(func(controllers.UserRoleControllerCRUD, uuid.UUID, int, models.PageQuery) models.PageInfo); ok {
+		r1 = rf(CRUD, clientUID, rank, query)
+	} else {
+		r1 = ret.Get(1).(models.PageInfo)
+	}
+
+	var r2 common.CustomError
+	if rf, ok := ret.Get(2).(func(controllers.UserRoleControllerCRUD, uuid.UUID, int, models.PageQuery) common.CustomError); ok {
+		r2 = rf(CRUD, clientUID, rank, query)
+	} else {
+		r2 = ret.Get(2).(common.CustomError)
+	}
+
+	return r0, r1, r2
+}
+
+// GetUsersWithLesserRank provides a mock function with given fields: CRUD, rank, query
+func (_m *Controllers) GetUsersWithLesserRank(CRUD controllers.UserControllerCRUD, rank int, query models.PageQuery) ([]*models.User, models.PageInfo, common.CustomError) {
+	ret := _m.Called(CRUD, rank, query)
+
+	var r0 []*models.User
+	if rf, ok := ret.Get(0).(func(controllers.UserControllerCRUD, int, models.PageQuery) []*models.User); ok {
+		r0 = rf(CRUD, rank, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.User)
+		}
+	}
+
+	var r1 models.PageInfo
+	if rf, ok := ret.Get(1).(func(controllers.UserControllerCRUD, int, models.PageQuery) models.PageInfo); ok {
+		r1 = rf(CRUD, rank, query)
+	} else {
+		r1 = ret.Get(1).(models.PageInfo)
+	}
+
+	var r2 common.CustomError
+	if rf, ok := ret.Get(2).(func(controllers.UserControllerCRUD, int, models.PageQuery) common.CustomError); ok {
+		r2 = rf(CRUD, rank, query)
+	} else {
+		r2 = ret.Get(2).(common.CustomError)
+	}
+
+	return r0, r1, r2
+}
+
+// RedeemRefreshToken provides a mock function with given fields: CRUD, clientUID, refreshToken
+func (_m *Controllers) RedeemRefreshToken(CRUD controllers.TokenControllerCRUD, clientUID uuid.UUID, refreshToken uuid.UUID) (*controllers.OAuthTokens, common.CustomError) {
+	ret := _m.Called(CRUD, clientUID, refreshToken)
+
+	var r0 *controllers.OAuthTokens
+	if rf, ok := ret.Get(0).(func(controllers.TokenControllerCRUD, uuid.UUID, uuid.UUID) *controllers.OAuthTokens); ok {
+		r0 = rf(CRUD, clientUID, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*controllers.OAuthTokens)
+		}
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.TokenControllerCRUD, uuid.UUID, uuid.UUID) common.CustomError); ok {
+		r1 = rf(CRUD, clientUID, refreshToken)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// RequestPasswordReset provides a mock function with given fields: CRUD, email
+func (_m *Controllers) RequestPasswordReset(CRUD controllers.PasswordResetControllerCRUD, email string) common.CustomError {
+	ret := _m.Called(CRUD, email)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.PasswordResetControllerCRUD, string) common.CustomError); ok {
+		r0 = rf(CRUD, email)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// ResetPassword provides a mock function with given fields: CRUD, token, password
+func (_m *Controllers) ResetPassword(CRUD controllers.PasswordResetControllerCRUD, token string, password string) (string, common.CustomError) {
+	ret := _m.Called(CRUD, token, password)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(controllers.PasswordResetControllerCRUD, string, string) string); ok {
+		r0 = rf(CRUD, token, password)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.PasswordResetControllerCRUD, string, string) common.CustomError); ok {
+		r1 = rf(CRUD, token, password)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// RotateClientSecret provides a mock function with given fields: CRUD, uid
+func (_m *Controllers) RotateClientSecret(CRUD controllers.ClientControllerCRUD, uid uuid.UUID) (string, common.CustomError) {
+	ret := _m.Called(CRUD, uid)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(controllers.ClientControllerCRUD, uuid.UUID) string); ok {
+		r0 = rf(CRUD, uid)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.ClientControllerCRUD, uuid.UUID) common.CustomError); ok {
+		r1 = rf(CRUD, uid)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// UpdateAdminRole provides a mock function with given fields: CRUD, role
+func (_m *Controllers) UpdateAdminRole(CRUD controllers.AdminRoleControllerCRUD, role *models.AdminRole) common.CustomError {
+	ret := _m.Called(CRUD, role)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.AdminRoleControllerCRUD, *models.AdminRole) common.CustomError); ok {
+		r0 = rf(CRUD, role)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// UpdateClient provides a mock function with given fields: CRUD, client
+func (_m *Controllers) UpdateClient(CRUD controllers.ClientControllerCRUD, client *models.Client) common.CustomError {
+	ret := _m.Called(CRUD, client)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.ClientControllerCRUD, *models.Client) common.CustomError); ok {
+		r0 = rf(CRUD, client)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// UpdateGroup provides a mock function with given fields: CRUD, group
+func (_m *Controllers) UpdateGroup(CRUD controllers.GroupControllerCRUD, group *models.Group) common.CustomError {
+	ret := _m.Called(CRUD, group)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.GroupControllerCRUD, *models.Group) common.CustomError); ok {
+		r0 = rf(CRUD, group)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// UpdateGroupRole provides a mock function with given fields: CRUD, role
+func (_m *Controllers) UpdateGroupRole(CRUD controllers.GroupRoleControllerCRUD, role *models.GroupRole) common.CustomError {
+	ret := _m.Called(CRUD, role)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.GroupRoleControllerCRUD, *models.GroupRole) common.CustomError); ok {
+		r0 = rf(CRUD, role)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// UpdateRoleDefinition provides a mock function with given fields: CRUD, name, definition
+func (_m *Controllers) UpdateRoleDefinition(CRUD controllers.RoleDefinitionControllerCRUD, name string, definition *models.RoleDefinition) common.CustomError {
+	ret := _m.Called(CRUD, name, definition)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.RoleDefinitionControllerCRUD, string, *models.RoleDefinition) common.CustomError); ok {
+		r0 = rf(CRUD, name, definition)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// UpdateUser provides a mock function with given fields: CRUD, username, rank, update
+func (_m *Controllers) UpdateUser(CRUD controllers.UserControllerCRUD, username string, rank int, update models.UserProfileUpdate) (*models.User, common.CustomError) {
+	ret := _m.Called(CRUD, username, rank, update)
+
+	var r0 *models.User
+	if rf, ok := ret.Get(0).(func(controllers.UserControllerCRUD, string, int, models.UserProfileUpdate) *models.User); ok {
+		r0 = rf(CRUD, username, rank, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.UserControllerCRUD, string, int, models.UserProfileUpdate) common.CustomError); ok {
+		r1 = rf(CRUD, username, rank, update)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// UpdateUserAdminRoles provides a mock function with given fields: CRUD, username, names
+func (_m *Controllers) UpdateUserAdminRoles(CRUD controllers.AdminRoleControllerCRUD, username string, names []string) ([]*models.AdminRole, common.CustomError) {
+	ret := _m.Called(CRUD, username, names)
+
+	var r0 []*models.AdminRole
+	if rf, ok := ret.Get(0).(func(controllers.AdminRoleControllerCRUD, string, []string) []*models.AdminRole); ok {
+		r0 = rf(CRUD, username, names)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AdminRole)
+		}
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.AdminRoleControllerCRUD, string, []string) common.CustomError); ok {
+		r1 = rf(CRUD, username, names)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// UpdateUserPassword provides a mock function with given fields: CRUD, username, password
+func (_m *Controllers) UpdateUserPassword(CRUD controllers.UserControllerCRUD, username string, password string) common.CustomError {
+	ret := _m.Called(CRUD, username, password)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.UserControllerCRUD, string, string) common.CustomError); ok {
+		r0 = rf(CRUD, username, password)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// UpdateUserPasswordWithAuth provides a mock function with given fields: CRUD, username, oldPassword, newPassword
+func (_m *Controllers) UpdateUserPasswordWithAuth(CRUD controllers.UserControllerCRUD, username string, oldPassword string, newPassword string) common.CustomError {
+	ret := _m.Called(CRUD, username, oldPassword, newPassword)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.UserControllerCRUD, string, string, string) common.CustomError); ok {
+		r0 = rf(CRUD, username, oldPassword, newPassword)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
+// UpdateUserRole provides a mock function with given fields: CRUD, role
+func (_m *Controllers) UpdateUserRole(CRUD controllers.UserRoleControllerCRUD, role *models.UserRole) common.CustomError {
+	ret := _m.Called(CRUD, role)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.UserRoleControllerCRUD, *models.UserRole) common.CustomError); ok {
 		r0 = rf(CRUD, role)
 	} else {
 		r0 = ret.Get(0).(common.CustomError)
@@ -368,6 +1318,83 @@ func (_m *Controllers) UpdateUserRole(CRUD controllers.UserRoleControllerCRUD, r
 	return r0
 }
 
+// VerifyAdminRoleRank provides a mock function with given fields: CRUD, name, username, rank
+func (_m *Controllers) VerifyAdminRoleRank(CRUD controllers.AdminRoleControllerCRUD, name string, username string, rank int) (bool, common.CustomError) {
+	ret := _m.Called(CRUD, name, username, rank)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(controllers.AdminRoleControllerCRUD, string, string, int) bool); ok {
+		r0 = rf(CRUD, name, username, rank)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.AdminRoleControllerCRUD, string, string, int) common.CustomError); ok {
+		r1 = rf(CRUD, name, username, rank)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// VerifyGroupRank provides a mock function with given fields: CRUD, name, rank
+func (_m *Controllers) VerifyGroupRank(CRUD controllers.GroupControllerCRUD, name string, rank int) (bool, common.CustomError) {
+	ret := _m.Called(CRUD, name, rank)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(controllers.GroupControllerCRUD, string, int) bool); ok {
+		r0 = rf(CRUD, name, rank)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.GroupControllerCRUD, string, int) common.CustomError); ok {
+		r1 = rf(CRUD, name, rank)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// VerifyInvitationRank provides a mock function with given fields: CRUD, id, rank
+func (_m *Controllers) VerifyInvitationRank(CRUD controllers.InvitationControllerCRUD, id uuid.UUID, rank int) (bool, common.CustomError) {
+	ret := _m.Called(CRUD, id, rank)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(controllers.InvitationControllerCRUD, uuid.UUID, int) bool); ok {
+		r0 = rf(CRUD, id, rank)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 common.CustomError
+	if rf, ok := ret.Get(1).(func(controllers.InvitationControllerCRUD, uuid.UUID, int) common.CustomError); ok {
+		r1 = rf(CRUD, id, rank)
+	} else {
+		r1 = ret.Get(1).(common.CustomError)
+	}
+
+	return r0, r1
+}
+
+// VerifyTwoFactorCode provides a mock function with given fields: CRUD, user, code
+func (_m *Controllers) VerifyTwoFactorCode(CRUD controllers.TwoFactorAuthControllerCRUD, user *models.User, code string) common.CustomError {
+	ret := _m.Called(CRUD, user, code)
+
+	var r0 common.CustomError
+	if rf, ok := ret.Get(0).(func(controllers.TwoFactorAuthControllerCRUD, *models.User, string) common.CustomError); ok {
+		r0 = rf(CRUD, user, code)
+	} else {
+		r0 = ret.Get(0).(common.CustomError)
+	}
+
+	return r0
+}
+
 // VerifyUserRank provides a mock function with given fields: CRUD, username, rank
 func (_m *Controllers) VerifyUserRank(CRUD controllers.UserControllerCRUD, username string, rank int) (bool, common.CustomError) {
 	ret := _m.Called(CRUD, username, rank)
@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+)
+
+const (
+	// DefaultPageLimit is the number of items returned in a page when no limit is provided.
+	DefaultPageLimit = 50
+
+	// MaxPageLimit is the max number of items that can be returned in a page.
+	MaxPageLimit = 200
+)
+
+// validatePageQuery validates the page query's limit and sort field, filling in the defaults for any that are not provided.
+// The first of the sort fields is the default. Returns any errors.
+func validatePageQuery(query *models.PageQuery, sortFields ...string) common.CustomError {
+	//validate the limit
+	if query.Limit < 0 || query.Limit > MaxPageLimit {
+		return common.ClientError(fmt.Sprintf("limit must be between 0 and %d", MaxPageLimit))
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultPageLimit
+	}
+
+	//validate the sort field
+	if query.SortBy == "" {
+		query.SortBy = sortFields[0]
+		return common.NoError()
+	}
+
+	for _, field := range sortFields {
+		if query.SortBy == field {
+			return common.NoError()
+		}
+	}
+	return common.ClientError(fmt.Sprintf("sort must be one of: %s", strings.Join(sortFields, ", ")))
+}
@@ -0,0 +1,282 @@
+package passwordhelpers
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mhogar/amber/common"
+)
+
+const (
+	// CorpusFormatPlainText is the format of a corpus with one plain text password per line.
+	CorpusFormatPlainText = "plain"
+
+	// CorpusFormatSHA1 is the format of a corpus with one hex encoded SHA-1 hash per line, optionally followed by a colon and a count.
+	CorpusFormatSHA1 = "sha1"
+)
+
+const (
+	sha1HexLength        = 40
+	rangePrefixHexLength = 5
+)
+
+// BreachedPasswordCriteriaValidator rejects passwords found in a local corpus of breached or common passwords.
+// The password is only checked against the corpus once it meets the criteria of the wrapped validator.
+type BreachedPasswordCriteriaValidator struct {
+	// Validator is the validator that is run before the corpus is checked.
+	Validator PasswordCriteriaValidator
+
+	// prefixes is the sorted set of the first 8 bytes of the SHA-1 hash of each password in the corpus.
+	prefixes []uint64
+}
+
+// LoadBreachedPasswordCriteriaValidator creates a new BreachedPasswordCriteriaValidator from the corpus at the provided path.
+// The path can be a single file in the provided format or a directory of range files, such as the ones downloaded from the Have I Been Pwned k-anonymity api.
+// Range files only hold SHA-1 hashes, so a directory requires the SHA-1 format.
+// Returns any errors.
+func LoadBreachedPasswordCriteriaValidator(validator PasswordCriteriaValidator, path string, format string) (*BreachedPasswordCriteriaValidator, error) {
+	err := validateCorpusFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &BreachedPasswordCriteriaValidator{
+		Validator: validator,
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, common.ChainError("error reading corpus info", err)
+	}
+
+	if info.IsDir() {
+		if format != CorpusFormatSHA1 {
+			return nil, fmt.Errorf("corpus directory requires the %s format", CorpusFormatSHA1)
+		}
+		err = v.loadRangeDir(path)
+	} else {
+		err = v.loadFile(path, format, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return v.finish()
+}
+
+// ReadBreachedPasswordCriteriaValidator creates a new BreachedPasswordCriteriaValidator from the corpus in the provided reader.
+// Each line is either a plain text password or an upper or lower case hex encoded SHA-1 hash, optionally followed by a colon and a count, depending on the provided format.
+// Returns any errors.
+func ReadBreachedPasswordCriteriaValidator(validator PasswordCriteriaValidator, r io.Reader, format string) (*BreachedPasswordCriteriaValidator, error) {
+	err := validateCorpusFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &BreachedPasswordCriteriaValidator{
+		Validator: validator,
+	}
+
+	err = v.read(r, format, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return v.finish()
+}
+
+// Size returns the number of unique passwords in the corpus.
+func (v *BreachedPasswordCriteriaValidator) Size() int {
+	return len(v.prefixes)
+}
+
+func (v *BreachedPasswordCriteriaValidator) ValidatePasswordCriteria(password string) ValidatePasswordCriteriaError {
+	//validate the other criteria first
+	verr := v.Validator.ValidatePasswordCriteria(password)
+	if verr.Status != ValidatePasswordCriteriaValid {
+		return verr
+	}
+
+	//validate password is not in the corpus
+	prefix := hashPrefix(sha1.Sum([]byte(password)))
+	index := sort.Search(len(v.prefixes), func(i int) bool {
+		return v.prefixes[i] >= prefix
+	})
+	if index < len(v.prefixes) && v.prefixes[index] == prefix {
+		return CreateValidatePasswordCriteriaError(ValidatePasswordCriteriaBreached, "password was found in the breached password corpus")
+	}
+
+	return CreateValidatePasswordCriteriaValid()
+}
+
+func (v *BreachedPasswordCriteriaValidator) loadRangeDir(dir string) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return common.ChainError("error reading corpus directory", err)
+	}
+
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		//range files are named after the hash prefix their lines complete, e.g. 21BD1.txt
+		name := strings.TrimSuffix(file.Name(), filepath.Ext(file.Name()))
+		if len(name) != rangePrefixHexLength || !isHex(name) {
+			continue
+		}
+
+		err = v.loadFile(filepath.Join(dir, file.Name()), CorpusFormatSHA1, name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *BreachedPasswordCriteriaValidator) loadFile(filename string, format string, rangePrefix string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return common.ChainError("error opening corpus file", err)
+	}
+	defer f.Close()
+
+	err = v.read(f, format, rangePrefix)
+	if err != nil {
+		return common.ChainError(fmt.Sprintf("error reading corpus file %s", filename), err)
+	}
+
+	return nil
+}
+
+func (v *BreachedPasswordCriteriaValidator) read(r io.Reader, format string, rangePrefix string) error {
+	scanner := bufio.NewScanner(r)
+
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+
+		//lines in range files only hold the rest of the hash
+		if rangePrefix != "" {
+			hash, ok := parseHashLine(rangePrefix + line)
+			if !ok {
+				return fmt.Errorf("line %d is not a hash suffix", lineNumber)
+			}
+
+			v.prefixes = append(v.prefixes, hashPrefix(hash))
+			continue
+		}
+
+		if format == CorpusFormatPlainText {
+			v.prefixes = append(v.prefixes, hashPrefix(sha1.Sum([]byte(line))))
+			continue
+		}
+
+		hash, ok := parseHashLine(line)
+		if !ok {
+			return fmt.Errorf("line %d is not a hash", lineNumber)
+		}
+		v.prefixes = append(v.prefixes, hashPrefix(hash))
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return common.ChainError("error scanning corpus", err)
+	}
+
+	return nil
+}
+
+// finish sorts the loaded corpus and verifies it is not empty.
+func (v *BreachedPasswordCriteriaValidator) finish() (*BreachedPasswordCriteriaValidator, error) {
+	//an empty corpus is most likely a misconfigured path
+	if len(v.prefixes) == 0 {
+		return nil, errors.New("corpus does not contain any passwords")
+	}
+
+	v.sortPrefixes()
+	return v, nil
+}
+
+func (v *BreachedPasswordCriteriaValidator) sortPrefixes() {
+	sort.Slice(v.prefixes, func(i, j int) bool {
+		return v.prefixes[i] < v.prefixes[j]
+	})
+
+	//remove duplicates
+	unique := v.prefixes[:0]
+	for i, prefix := range v.prefixes {
+		if i == 0 || prefix != v.prefixes[i-1] {
+			unique = append(unique, prefix)
+		}
+	}
+	v.prefixes = unique
+}
+
+func validateCorpusFormat(format string) error {
+	switch format {
+	case CorpusFormatPlainText, CorpusFormatSHA1:
+		return nil
+	}
+
+	return fmt.Errorf("unsupported corpus format %q, must be %s or %s", format, CorpusFormatPlainText, CorpusFormatSHA1)
+}
+
+// parseHashLine parses a hex encoded SHA-1 hash that is optionally followed by a colon and a count.
+// Returns the hash and if the line was in that format.
+func parseHashLine(line string) ([sha1.Size]byte, bool) {
+	var hash [sha1.Size]byte
+
+	parts := strings.SplitN(line, ":", 2)
+	if len(parts[0]) != sha1HexLength {
+		return hash, false
+	}
+	if len(parts) == 2 && !isDigits(parts[1]) {
+		return hash, false
+	}
+
+	_, err := hex.Decode(hash[:], []byte(parts[0]))
+	if err != nil {
+		return hash, false
+	}
+
+	return hash, true
+}
+
+func hashPrefix(hash [sha1.Size]byte) uint64 {
+	return binary.BigEndian.Uint64(hash[:8])
+}
+
+func isHex(s string) bool {
+	_, err := hex.DecodeString(s + strings.Repeat("0", len(s)%2))
+	return err == nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
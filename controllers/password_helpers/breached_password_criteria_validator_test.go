@@ -0,0 +1,237 @@
+package passwordhelpers_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	passwordhelpers "github.com/mhogar/amber/controllers/password_helpers"
+	"github.com/mhogar/amber/controllers/password_helpers/mocks"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const plainTextCorpus = "Password1!\r\n" +
+	"\r\n" +
+	"98E3002450246538ADCFB1E5FF3C89071BC45C29\r\n"
+
+const sha1Corpus = "32CA9FC1A0F5B6330E3F4C8C1BBECDE9BEDB9573\r\n" +
+	"98E3002450246538ADCFB1E5FF3C89071BC45C29:27\r\n" +
+	"\r\n" +
+	"5f80211ccb43cd491c4e2ffbbda4c7f6ba0ff604\r\n"
+
+type BreachedPasswordCriteriaValidatorTestSuite struct {
+	helpers.CustomSuite
+	PasswordCriteriaValidatorMock mocks.PasswordCriteriaValidator
+	Dir                           string
+}
+
+func (suite *BreachedPasswordCriteriaValidatorTestSuite) SetupTest() {
+	suite.PasswordCriteriaValidatorMock = mocks.PasswordCriteriaValidator{}
+
+	dir, err := ioutil.TempDir("", "corpus")
+	suite.Require().NoError(err)
+	suite.Dir = dir
+}
+
+func (suite *BreachedPasswordCriteriaValidatorTestSuite) TearDownTest() {
+	os.RemoveAll(suite.Dir)
+}
+
+func (suite *BreachedPasswordCriteriaValidatorTestSuite) writeFile(name string, content string) string {
+	filename := filepath.Join(suite.Dir, name)
+	suite.Require().NoError(ioutil.WriteFile(filename, []byte(content), 0644))
+	return filename
+}
+
+func (suite *BreachedPasswordCriteriaValidatorTestSuite) TestValidatePasswordCriteria_WherePasswordDoesNotMeetOtherCriteria_ReturnsOtherStatus() {
+	//arrange
+	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaError(passwordhelpers.ValidatePasswordCriteriaTooShort, ""))
+
+	validator, err := passwordhelpers.ReadBreachedPasswordCriteriaValidator(&suite.PasswordCriteriaValidatorMock, strings.NewReader(plainTextCorpus), passwordhelpers.CorpusFormatPlainText)
+	suite.Require().NoError(err)
+
+	//act
+	verr := validator.ValidatePasswordCriteria("Password1!")
+
+	//assert
+	suite.Equal(passwordhelpers.ValidatePasswordCriteriaTooShort, verr.Status)
+}
+
+func (suite *BreachedPasswordCriteriaValidatorTestSuite) TestValidatePasswordCriteria_CorpusTests() {
+	var corpus string
+	var format string
+	var password string
+	var expectedStatus int
+
+	testCase := func() {
+		//arrange
+		suite.PasswordCriteriaValidatorMock = mocks.PasswordCriteriaValidator{}
+		suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
+
+		validator, err := passwordhelpers.ReadBreachedPasswordCriteriaValidator(&suite.PasswordCriteriaValidatorMock, strings.NewReader(corpus), format)
+		suite.Require().NoError(err)
+
+		//act
+		verr := validator.ValidatePasswordCriteria(password)
+
+		//assert
+		suite.Equal(expectedStatus, verr.Status)
+		suite.PasswordCriteriaValidatorMock.AssertCalled(suite.T(), "ValidatePasswordCriteria", password)
+	}
+
+	corpus = plainTextCorpus
+	format = passwordhelpers.CorpusFormatPlainText
+
+	password = "Password1!"
+	expectedStatus = passwordhelpers.ValidatePasswordCriteriaBreached
+	suite.Run("PlainText_PasswordInCorpus_ReturnsValidatePasswordCriteriaBreached", testCase)
+
+	password = "98E3002450246538ADCFB1E5FF3C89071BC45C29"
+	expectedStatus = passwordhelpers.ValidatePasswordCriteriaBreached
+	suite.Run("PlainText_PasswordInCorpusThatLooksLikeHash_ReturnsValidatePasswordCriteriaBreached", testCase)
+
+	password = "Summer2020!"
+	expectedStatus = passwordhelpers.ValidatePasswordCriteriaValid
+	suite.Run("PlainText_PasswordWhoseHashIsInCorpus_ReturnsValidatePasswordCriteriaValid", testCase)
+
+	corpus = sha1Corpus
+	format = passwordhelpers.CorpusFormatSHA1
+
+	password = "Password1!"
+	expectedStatus = passwordhelpers.ValidatePasswordCriteriaBreached
+	suite.Run("SHA1_PasswordInCorpusAsUpperCaseHash_ReturnsValidatePasswordCriteriaBreached", testCase)
+
+	password = "Summer2020!"
+	expectedStatus = passwordhelpers.ValidatePasswordCriteriaBreached
+	suite.Run("SHA1_PasswordInCorpusAsUpperCaseHashWithCount_ReturnsValidatePasswordCriteriaBreached", testCase)
+
+	password = "Welcome1!"
+	expectedStatus = passwordhelpers.ValidatePasswordCriteriaBreached
+	suite.Run("SHA1_PasswordInCorpusAsLowerCaseHash_ReturnsValidatePasswordCriteriaBreached", testCase)
+
+	password = "password1!"
+	expectedStatus = passwordhelpers.ValidatePasswordCriteriaValid
+	suite.Run("SHA1_PasswordNotInCorpus_ReturnsValidatePasswordCriteriaValid", testCase)
+}
+
+func (suite *BreachedPasswordCriteriaValidatorTestSuite) TestReadBreachedPasswordCriteriaValidator_WithUnsupportedFormat_ReturnsError() {
+	//act
+	validator, err := passwordhelpers.ReadBreachedPasswordCriteriaValidator(&suite.PasswordCriteriaValidatorMock, strings.NewReader(plainTextCorpus), "")
+
+	//assert
+	suite.Nil(validator)
+	suite.ContainsSubstrings(err.Error(), "unsupported corpus format")
+}
+
+func (suite *BreachedPasswordCriteriaValidatorTestSuite) TestReadBreachedPasswordCriteriaValidator_WhereCorpusIsEmpty_ReturnsError() {
+	//act
+	validator, err := passwordhelpers.ReadBreachedPasswordCriteriaValidator(&suite.PasswordCriteriaValidatorMock, strings.NewReader("\r\n"), passwordhelpers.CorpusFormatPlainText)
+
+	//assert
+	suite.Nil(validator)
+	suite.ContainsSubstrings(err.Error(), "corpus", "not contain any passwords")
+}
+
+func (suite *BreachedPasswordCriteriaValidatorTestSuite) TestReadBreachedPasswordCriteriaValidator_WhereSHA1CorpusHasInvalidLine_ReturnsError() {
+	//act
+	validator, err := passwordhelpers.ReadBreachedPasswordCriteriaValidator(&suite.PasswordCriteriaValidatorMock, strings.NewReader(sha1Corpus+"Password1!\n"), passwordhelpers.CorpusFormatSHA1)
+
+	//assert
+	suite.Nil(validator)
+	suite.ContainsSubstrings(err.Error(), "line 5", "not a hash")
+}
+
+func (suite *BreachedPasswordCriteriaValidatorTestSuite) TestReadBreachedPasswordCriteriaValidator_RemovesDuplicatePasswords() {
+	//act
+	validator, err := passwordhelpers.ReadBreachedPasswordCriteriaValidator(&suite.PasswordCriteriaValidatorMock, strings.NewReader(plainTextCorpus+"Summer2020!\nPassword1!\n"), passwordhelpers.CorpusFormatPlainText)
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Equal(3, validator.Size())
+}
+
+func (suite *BreachedPasswordCriteriaValidatorTestSuite) TestLoadBreachedPasswordCriteriaValidator_WherePathDoesNotExist_ReturnsError() {
+	//act
+	validator, err := passwordhelpers.LoadBreachedPasswordCriteriaValidator(&suite.PasswordCriteriaValidatorMock, filepath.Join(suite.Dir, "missing.txt"), passwordhelpers.CorpusFormatPlainText)
+
+	//assert
+	suite.Nil(validator)
+	suite.ContainsSubstrings(err.Error(), "error reading corpus info")
+}
+
+func (suite *BreachedPasswordCriteriaValidatorTestSuite) TestLoadBreachedPasswordCriteriaValidator_WhereCorpusIsEmpty_ReturnsError() {
+	//arrange
+	suite.writeFile("readme.md", "not a range file")
+
+	//act
+	validator, err := passwordhelpers.LoadBreachedPasswordCriteriaValidator(&suite.PasswordCriteriaValidatorMock, suite.Dir, passwordhelpers.CorpusFormatSHA1)
+
+	//assert
+	suite.Nil(validator)
+	suite.ContainsSubstrings(err.Error(), "corpus", "not contain any passwords")
+}
+
+func (suite *BreachedPasswordCriteriaValidatorTestSuite) TestLoadBreachedPasswordCriteriaValidator_WithDirectoryAndPlainTextFormat_ReturnsError() {
+	//arrange
+	suite.writeFile("32CA9.txt", "FC1A0F5B6330E3F4C8C1BBECDE9BEDB9573:3\n")
+
+	//act
+	validator, err := passwordhelpers.LoadBreachedPasswordCriteriaValidator(&suite.PasswordCriteriaValidatorMock, suite.Dir, passwordhelpers.CorpusFormatPlainText)
+
+	//assert
+	suite.Nil(validator)
+	suite.ContainsSubstrings(err.Error(), "directory", "sha1")
+}
+
+func (suite *BreachedPasswordCriteriaValidatorTestSuite) TestLoadBreachedPasswordCriteriaValidator_WhereRangeFileHasInvalidLine_ReturnsError() {
+	//arrange
+	suite.writeFile("32CA9.txt", "FC1A0F5B6330E3F4C8C1BBECDE9BEDB9573:3\nPassword1!\n")
+
+	//act
+	validator, err := passwordhelpers.LoadBreachedPasswordCriteriaValidator(&suite.PasswordCriteriaValidatorMock, suite.Dir, passwordhelpers.CorpusFormatSHA1)
+
+	//assert
+	suite.Nil(validator)
+	suite.ContainsSubstrings(err.Error(), "32CA9.txt", "line 2", "not a hash suffix")
+}
+
+func (suite *BreachedPasswordCriteriaValidatorTestSuite) TestLoadBreachedPasswordCriteriaValidator_WithFile_LoadsCorpus() {
+	//arrange
+	filename := suite.writeFile("corpus.txt", sha1Corpus)
+	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
+
+	//act
+	validator, err := passwordhelpers.LoadBreachedPasswordCriteriaValidator(&suite.PasswordCriteriaValidatorMock, filename, passwordhelpers.CorpusFormatSHA1)
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Equal(3, validator.Size())
+	suite.Equal(passwordhelpers.ValidatePasswordCriteriaBreached, validator.ValidatePasswordCriteria("Password1!").Status)
+}
+
+func (suite *BreachedPasswordCriteriaValidatorTestSuite) TestLoadBreachedPasswordCriteriaValidator_WithRangeDirectory_LoadsCorpus() {
+	//arrange
+	suite.writeFile("32CA9.txt", "FC1A0F5B6330E3F4C8C1BBECDE9BEDB9573:3\r\n")
+	suite.writeFile("98e30", "02450246538ADCFB1E5FF3C89071BC45C29:27\r\n")
+	suite.writeFile("readme.md", "Welcome1!\n")
+	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
+
+	//act
+	validator, err := passwordhelpers.LoadBreachedPasswordCriteriaValidator(&suite.PasswordCriteriaValidatorMock, suite.Dir, passwordhelpers.CorpusFormatSHA1)
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Equal(2, validator.Size())
+	suite.Equal(passwordhelpers.ValidatePasswordCriteriaBreached, validator.ValidatePasswordCriteria("Password1!").Status)
+	suite.Equal(passwordhelpers.ValidatePasswordCriteriaBreached, validator.ValidatePasswordCriteria("Summer2020!").Status)
+	suite.Equal(passwordhelpers.ValidatePasswordCriteriaValid, validator.ValidatePasswordCriteria("Welcome1!").Status)
+}
+
+func TestBreachedPasswordCriteriaValidatorTestSuite(t *testing.T) {
+	suite.Run(t, &BreachedPasswordCriteriaValidatorTestSuite{})
+}
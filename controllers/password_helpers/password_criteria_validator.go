@@ -9,6 +9,7 @@ const (
 	ValidatePasswordCriteriaMissingUpperCaseLetter = iota
 	ValidatePasswordCriteriaMissingDigit           = iota
 	ValidatePasswordCriteriaMissingSymbol          = iota
+	ValidatePasswordCriteriaBreached               = iota
 )
 
 type ValidatePasswordCriteriaError struct {
@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
+	passwordhelpers "github.com/mhogar/amber/controllers/password_helpers"
+	"github.com/mhogar/amber/models"
+)
+
+// passwordChangeCRUD encapsulates the CRUD operations required to change a user's password.
+type passwordChangeCRUD interface {
+	models.UserCRUD
+	models.PreviousPasswordCRUD
+}
+
+// changeUserPassword validates the new password against the configured criteria and the user's password history, then updates it.
+// The replaced password is added to the history and any passwords older than the history size are removed.
+// Returns any errors.
+func changeUserPassword(CRUD passwordChangeCRUD, hasher passwordhelpers.PasswordHasher, validator passwordhelpers.PasswordCriteriaValidator, user *models.User, password string) common.CustomError {
+	historySize := config.GetPasswordCriteriaConfig().HistorySize
+
+	//validate password meets critera
+	cerr := validatePasswordCriteria(validator, password)
+	if cerr.Type != common.ErrorTypeNone {
+		return cerr
+	}
+
+	//get the previous passwords still in the history (the current password takes up one of the spots)
+	previous := []*models.PreviousPassword{}
+	if historySize > 1 {
+		var err error
+		previous, err = CRUD.GetLatestUserPreviousPasswords(user.Username, historySize-1)
+		if err != nil {
+			log.Println(common.ChainError("error getting latest user previous passwords", err))
+			return common.InternalError()
+		}
+	}
+
+	//validate the password doesn't match any in the history
+	if historySize > 0 {
+		hashes := [][]byte{user.PasswordHash}
+		for _, pp := range previous {
+			hashes = append(hashes, pp.PasswordHash)
+		}
+
+		for _, hash := range hashes {
+			if hasher.ComparePasswords(hash, password) == nil {
+				return common.ClientError(fmt.Sprintf("password cannot match any of the last %d passwords", historySize))
+			}
+		}
+	}
+
+	//hash the password
+	hash, err := hasher.HashPassword(password)
+	if err != nil {
+		log.Println(common.ChainError("error generating password hash", err))
+		return common.InternalError()
+	}
+
+	//update the user's password
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	_, err = CRUD.UpdateUserPassword(user.Username, hash, now)
+	if err != nil {
+		log.Println(common.ChainError("error updating user password", err))
+		return common.InternalError()
+	}
+
+	//nothing else needs to be remembered if only the current password is checked
+	if historySize <= 1 {
+		return common.NoError()
+	}
+
+	//add the replaced password to the history
+	retired := models.CreatePreviousPassword(user.Username, user.PasswordHash, now)
+	err = CRUD.SavePreviousPassword(retired)
+	if err != nil {
+		log.Println(common.ChainError("error saving previous password", err))
+		return common.InternalError()
+	}
+
+	//remove the passwords that no longer fit in the history
+	kept := append([]*models.PreviousPassword{retired}, previous...)
+	if len(kept) > historySize-1 {
+		err = CRUD.DeleteUserPreviousPasswordsReplacedBefore(user.Username, kept[historySize-2].ReplacedAt)
+		if err != nil {
+			log.Println(common.ChainError("error deleting user previous passwords replaced before", err))
+			return common.InternalError()
+		}
+	}
+
+	return common.NoError()
+}
+
+// validatePasswordCriteria validates the password meets the minimum criteria and is not a known breached password.
+// Returns any errors.
+func validatePasswordCriteria(validator passwordhelpers.PasswordCriteriaValidator, password string) common.CustomError {
+	verr := validator.ValidatePasswordCriteria(password)
+	if verr.Status == passwordhelpers.ValidatePasswordCriteriaValid {
+		return common.NoError()
+	}
+
+	log.Println(common.ChainError("error validating password criteria", verr))
+
+	if verr.Status == passwordhelpers.ValidatePasswordCriteriaBreached {
+		return common.ClientError("password is too common or has appeared in a data breach")
+	}
+	return common.ClientError("password does not meet minimum criteria")
+}
+
+// validatePasswordMinAge validates enough time has passed since the user's password was last updated for them to change it again.
+// Returns any errors.
+func validatePasswordMinAge(user *models.User, now time.Time) common.CustomError {
+	minAge := time.Duration(config.GetPasswordCriteriaConfig().MinAge) * time.Second
+
+	allowedAt := user.PasswordUpdatedAt.Add(minAge)
+	if now.Before(allowedAt) {
+		seconds := int64(math.Ceil(allowedAt.Sub(now).Seconds()))
+		return common.ClientError(fmt.Sprintf("password was changed too recently, try again in %d seconds", seconds))
+	}
+
+	return common.NoError()
+}
@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
+	mailhelpers "github.com/mhogar/amber/controllers/mail_helpers"
+	"github.com/mhogar/amber/models"
+)
+
+// invalidPasswordResetTokenMessage is returned for every token that can't be used, so clients can't tell why it was rejected.
+const invalidPasswordResetTokenMessage = "password reset link is invalid or has expired"
+
+type CorePasswordResetController struct {
+	UserController UserController
+	Mailer         mailhelpers.Mailer
+}
+
+func (c CorePasswordResetController) RequestPasswordReset(CRUD PasswordResetControllerCRUD, email string) common.CustomError {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return common.ClientError("email cannot be empty")
+	}
+
+	//get the user
+	user, err := CRUD.GetUserByEmail(email)
+	if err != nil {
+		log.Println(common.ChainError("error getting user by email", err))
+		return common.InternalError()
+	}
+
+	//don't reveal whether the email belongs to a user
+	if user == nil || !user.Enabled {
+		return common.NoError()
+	}
+
+	//generate the token
+	token, err := generateLinkToken()
+	if err != nil {
+		log.Println(common.ChainError("error generating password reset token", err))
+		return common.InternalError()
+	}
+
+	//create the link from the configured url, since the request's host can be set by the client
+	link, err := createLinkURL("/password/reset", token)
+	if err != nil {
+		log.Println(common.ChainError("error creating password reset link", err))
+		return common.InternalError()
+	}
+
+	//save only the hash of the token
+	lifetime := time.Duration(config.GetPasswordResetConfig().TokenLifetime) * time.Second
+	err = CRUD.SavePasswordResetToken(models.CreateNewPasswordResetToken(hashLinkToken(token), user.Username, lifetime))
+	if err != nil {
+		log.Println(common.ChainError("error saving password reset token", err))
+		return common.InternalError()
+	}
+
+	//send the link to the user
+	err = c.Mailer.SendMail(user.Email, "Reset your password", createPasswordResetMailBody(user, link, lifetime))
+	if err != nil {
+		log.Println(common.ChainError("error sending password reset mail", err))
+		return common.InternalError()
+	}
+
+	return common.NoError()
+}
+
+func (c CorePasswordResetController) ResetPassword(CRUD PasswordResetControllerCRUD, token string, password string) (string, common.CustomError) {
+	hash := hashLinkToken(token)
+
+	//get the token
+	resetToken, err := CRUD.GetPasswordResetToken(hash)
+	if err != nil {
+		log.Println(common.ChainError("error getting password reset token", err))
+		return "", common.InternalError()
+	}
+	if resetToken == nil {
+		return "", common.ClientError(invalidPasswordResetTokenMessage)
+	}
+
+	//delete the token if it has expired
+	if resetToken.IsExpired(time.Now().UTC()) {
+		_, err = CRUD.DeletePasswordResetToken(hash)
+		if err != nil {
+			log.Println(common.ChainError("error deleting expired password reset token", err))
+			return "", common.InternalError()
+		}
+
+		return "", common.ClientError(invalidPasswordResetTokenMessage)
+	}
+
+	//verify the user can still sign in
+	user, err := CRUD.GetUserByUsername(resetToken.Username)
+	if err != nil {
+		log.Println(common.ChainError("error getting user by username", err))
+		return "", common.InternalError()
+	}
+	if user == nil || !user.Enabled {
+		return "", common.ClientError(invalidPasswordResetTokenMessage)
+	}
+
+	//update the password (the token is kept if the password is rejected so the user can try again)
+	cerr := c.UserController.UpdateUserPassword(CRUD, user.Username, password)
+	if cerr.Type != common.ErrorTypeNone {
+		return "", cerr
+	}
+
+	//delete the user's reset tokens so none of them can be used again
+	err = CRUD.DeleteAllUserPasswordResetTokens(user.Username)
+	if err != nil {
+		log.Println(common.ChainError("error deleting all user password reset tokens", err))
+		return "", common.InternalError()
+	}
+
+	//sign the user out everywhere
+	err = CRUD.DeleteAllUserSessions(user.Username)
+	if err != nil {
+		log.Println(common.ChainError("error deleting all user sessions", err))
+		return "", common.InternalError()
+	}
+
+	return user.Username, common.NoError()
+}
+
+func createPasswordResetMailBody(user *models.User, link string, lifetime time.Duration) string {
+	name := user.DisplayName
+	if name == "" {
+		name = user.Username
+	}
+
+	return fmt.Sprintf(
+		"Hi %s,\n\n"+
+			"We received a request to reset the password for your %s account. "+
+			"Use the link below to choose a new password. It can only be used once and expires in %s.\n\n"+
+			"%s\n\n"+
+			"If you didn't request a password reset, you can ignore this email.\n",
+		name, config.GetAppName(), lifetime, link,
+	)
+}
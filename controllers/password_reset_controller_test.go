@@ -0,0 +1,354 @@
+package controllers_test
+
+import (
+	"crypto/sha256"
+	"errors"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
+	"github.com/mhogar/amber/controllers"
+	mailmocks "github.com/mhogar/amber/controllers/mail_helpers/mocks"
+	"github.com/mhogar/amber/controllers/mocks"
+	datamocks "github.com/mhogar/amber/data/mocks"
+	"github.com/mhogar/amber/models"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type PasswordResetControllerTestSuite struct {
+	ControllerTestSuite
+	ControllersMock         mocks.Controllers
+	MailerMock              mailmocks.Mailer
+	PasswordResetController controllers.CorePasswordResetController
+}
+
+func (suite *PasswordResetControllerTestSuite) SetupTest() {
+	suite.ControllerTestSuite.SetupTest()
+
+	viper.Set("app_name", "app")
+	viper.Set("server", config.ServerConfig{
+		PublicURL: "http://base/",
+	})
+	viper.Set("password_reset", config.PasswordResetConfig{
+		TokenLifetime: 3600,
+	})
+
+	suite.ControllersMock = mocks.Controllers{}
+	suite.MailerMock = mailmocks.Mailer{}
+	suite.PasswordResetController = controllers.CorePasswordResetController{
+		UserController: &suite.ControllersMock,
+		Mailer:         &suite.MailerMock,
+	}
+}
+
+func (suite *PasswordResetControllerTestSuite) createUser(enabled bool) *models.User {
+	user := models.CreateUser("username", 0, []byte("password"))
+	user.Email = "user@example.com"
+	user.Enabled = enabled
+
+	return user
+}
+
+func (suite *PasswordResetControllerTestSuite) createToken(token string, lifetime time.Duration) *models.PasswordResetToken {
+	hash := sha256.Sum256([]byte(token))
+	return models.CreateNewPasswordResetToken(hash[:], "username", lifetime)
+}
+
+func (suite *PasswordResetControllerTestSuite) TestRequestPasswordReset_WithEmptyEmail_ReturnsClientError() {
+	//act
+	cerr := suite.PasswordResetController.RequestPasswordReset(&suite.CRUDMock, "  ")
+
+	//assert
+	suite.CustomClientError(cerr, "email cannot be empty")
+}
+
+func (suite *PasswordResetControllerTestSuite) TestRequestPasswordReset_WithErrorGettingUserByEmail_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByEmail", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.PasswordResetController.RequestPasswordReset(&suite.CRUDMock, "user@example.com")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *PasswordResetControllerTestSuite) TestRequestPasswordReset_WhereUserCannotReset_ReturnsNoErrorWithoutSendingMail() {
+	var user *models.User
+
+	testCase := func() {
+		//arrange
+		suite.CRUDMock = datamocks.DataCRUD{}
+		suite.MailerMock = mailmocks.Mailer{}
+		suite.CRUDMock.On("GetUserByEmail", mock.Anything).Return(user, nil)
+
+		//act
+		cerr := suite.PasswordResetController.RequestPasswordReset(&suite.CRUDMock, "user@example.com")
+
+		//assert
+		suite.CustomNoError(cerr)
+		suite.CRUDMock.AssertNotCalled(suite.T(), "SavePasswordResetToken", mock.Anything)
+		suite.MailerMock.AssertNotCalled(suite.T(), "SendMail", mock.Anything, mock.Anything, mock.Anything)
+	}
+
+	user = nil
+	suite.Run("UserNotFound", testCase)
+
+	user = suite.createUser(false)
+	suite.Run("UserDisabled", testCase)
+}
+
+func (suite *PasswordResetControllerTestSuite) TestRequestPasswordReset_WherePublicURLIsNotConfigured_ReturnsInternalError() {
+	//arrange
+	viper.Set("server", config.ServerConfig{})
+	suite.CRUDMock.On("GetUserByEmail", mock.Anything).Return(suite.createUser(true), nil)
+
+	//act
+	cerr := suite.PasswordResetController.RequestPasswordReset(&suite.CRUDMock, "user@example.com")
+
+	//assert
+	suite.CustomInternalError(cerr)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "SavePasswordResetToken", mock.Anything)
+}
+
+func (suite *PasswordResetControllerTestSuite) TestRequestPasswordReset_WithErrorSavingPasswordResetToken_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByEmail", mock.Anything).Return(suite.createUser(true), nil)
+	suite.CRUDMock.On("SavePasswordResetToken", mock.Anything).Return(errors.New(""))
+
+	//act
+	cerr := suite.PasswordResetController.RequestPasswordReset(&suite.CRUDMock, "user@example.com")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *PasswordResetControllerTestSuite) TestRequestPasswordReset_WithErrorSendingMail_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByEmail", mock.Anything).Return(suite.createUser(true), nil)
+	suite.CRUDMock.On("SavePasswordResetToken", mock.Anything).Return(nil)
+	suite.MailerMock.On("SendMail", mock.Anything, mock.Anything, mock.Anything).Return(errors.New(""))
+
+	//act
+	cerr := suite.PasswordResetController.RequestPasswordReset(&suite.CRUDMock, "user@example.com")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *PasswordResetControllerTestSuite) TestRequestPasswordReset_SavesTokenHashAndMailsLink() {
+	//arrange
+	user := suite.createUser(true)
+
+	suite.CRUDMock.On("GetUserByEmail", mock.Anything).Return(user, nil)
+	suite.CRUDMock.On("SavePasswordResetToken", mock.Anything).Return(nil)
+	suite.MailerMock.On("SendMail", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	//act
+	cerr := suite.PasswordResetController.RequestPasswordReset(&suite.CRUDMock, " User@Example.com ")
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetUserByEmail", "user@example.com")
+
+	body := suite.MailerMock.Calls[0].Arguments.String(2)
+	suite.MailerMock.AssertCalled(suite.T(), "SendMail", user.Email, "Reset your password", body)
+
+	//extract the token from the link
+	matches := regexp.MustCompile(`http://base/password/reset\?token=(\S+)`).FindStringSubmatch(body)
+	suite.Require().Len(matches, 2)
+	token, err := url.QueryUnescape(matches[1])
+	suite.Require().NoError(err)
+
+	//only the hash of the token should be saved
+	resetToken := suite.CRUDMock.Calls[1].Arguments.Get(0).(*models.PasswordResetToken)
+	hash := sha256.Sum256([]byte(token))
+	suite.Equal(hash[:], resetToken.TokenHash)
+	suite.Equal(user.Username, resetToken.Username)
+	suite.WithinDuration(time.Now().Add(time.Hour), resetToken.ExpiresAt, time.Second)
+}
+
+func (suite *PasswordResetControllerTestSuite) TestResetPassword_WithErrorGettingPasswordResetToken_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetPasswordResetToken", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	username, cerr := suite.PasswordResetController.ResetPassword(&suite.CRUDMock, "token", "password")
+
+	//assert
+	suite.Empty(username)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *PasswordResetControllerTestSuite) TestResetPassword_WherePasswordResetTokenNotFound_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetPasswordResetToken", mock.Anything).Return(nil, nil)
+
+	//act
+	username, cerr := suite.PasswordResetController.ResetPassword(&suite.CRUDMock, "token", "password")
+
+	//assert
+	suite.Empty(username)
+	suite.CustomClientError(cerr, "invalid or has expired")
+
+	hash := sha256.Sum256([]byte("token"))
+	suite.CRUDMock.AssertCalled(suite.T(), "GetPasswordResetToken", hash[:])
+}
+
+func (suite *PasswordResetControllerTestSuite) TestResetPassword_WithErrorDeletingExpiredPasswordResetToken_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetPasswordResetToken", mock.Anything).Return(suite.createToken("token", -time.Second), nil)
+	suite.CRUDMock.On("DeletePasswordResetToken", mock.Anything).Return(false, errors.New(""))
+
+	//act
+	username, cerr := suite.PasswordResetController.ResetPassword(&suite.CRUDMock, "token", "password")
+
+	//assert
+	suite.Empty(username)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *PasswordResetControllerTestSuite) TestResetPassword_WherePasswordResetTokenIsExpired_DeletesTokenAndReturnsClientError() {
+	//arrange
+	token := suite.createToken("token", -time.Second)
+
+	suite.CRUDMock.On("GetPasswordResetToken", mock.Anything).Return(token, nil)
+	suite.CRUDMock.On("DeletePasswordResetToken", mock.Anything).Return(true, nil)
+
+	//act
+	username, cerr := suite.PasswordResetController.ResetPassword(&suite.CRUDMock, "token", "password")
+
+	//assert
+	suite.Empty(username)
+	suite.CustomClientError(cerr, "invalid or has expired")
+	suite.CRUDMock.AssertCalled(suite.T(), "DeletePasswordResetToken", token.TokenHash)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *PasswordResetControllerTestSuite) TestResetPassword_WithErrorGettingUser_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetPasswordResetToken", mock.Anything).Return(suite.createToken("token", time.Hour), nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	username, cerr := suite.PasswordResetController.ResetPassword(&suite.CRUDMock, "token", "password")
+
+	//assert
+	suite.Empty(username)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *PasswordResetControllerTestSuite) TestResetPassword_WhereUserCannotReset_ReturnsClientError() {
+	var user *models.User
+
+	testCase := func() {
+		//arrange
+		suite.CRUDMock = datamocks.DataCRUD{}
+		suite.CRUDMock.On("GetPasswordResetToken", mock.Anything).Return(suite.createToken("token", time.Hour), nil)
+		suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(user, nil)
+
+		//act
+		username, cerr := suite.PasswordResetController.ResetPassword(&suite.CRUDMock, "token", "password")
+
+		//assert
+		suite.Empty(username)
+		suite.CustomClientError(cerr, "invalid or has expired")
+	}
+
+	user = nil
+	suite.Run("UserNotFound", testCase)
+
+	user = suite.createUser(false)
+	suite.Run("UserDisabled", testCase)
+}
+
+func (suite *PasswordResetControllerTestSuite) TestResetPassword_WithErrorUpdatingUserPassword_ReturnsErrorAndKeepsToken() {
+	var expectedErr common.CustomError
+
+	testCase := func() {
+		//arrange
+		suite.CRUDMock = datamocks.DataCRUD{}
+		suite.ControllersMock = mocks.Controllers{}
+		suite.CRUDMock.On("GetPasswordResetToken", mock.Anything).Return(suite.createToken("token", time.Hour), nil)
+		suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser(true), nil)
+		suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything).Return(expectedErr)
+
+		//act
+		username, cerr := suite.PasswordResetController.ResetPassword(&suite.CRUDMock, "token", "password")
+
+		//assert
+		suite.Empty(username)
+		suite.Equal(expectedErr, cerr)
+		suite.CRUDMock.AssertNotCalled(suite.T(), "DeleteAllUserPasswordResetTokens", mock.Anything)
+		suite.CRUDMock.AssertNotCalled(suite.T(), "DeleteAllUserSessions", mock.Anything)
+	}
+
+	expectedErr = common.ClientError("password does not meet minimum criteria")
+	suite.Run("ClientError", testCase)
+
+	expectedErr = common.InternalError()
+	suite.Run("InternalError", testCase)
+}
+
+func (suite *PasswordResetControllerTestSuite) TestResetPassword_WithErrorDeletingAllUserPasswordResetTokens_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetPasswordResetToken", mock.Anything).Return(suite.createToken("token", time.Hour), nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser(true), nil)
+	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
+	suite.CRUDMock.On("DeleteAllUserPasswordResetTokens", mock.Anything).Return(errors.New(""))
+
+	//act
+	username, cerr := suite.PasswordResetController.ResetPassword(&suite.CRUDMock, "token", "password")
+
+	//assert
+	suite.Empty(username)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *PasswordResetControllerTestSuite) TestResetPassword_WithErrorDeletingAllUserSessions_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetPasswordResetToken", mock.Anything).Return(suite.createToken("token", time.Hour), nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser(true), nil)
+	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
+	suite.CRUDMock.On("DeleteAllUserPasswordResetTokens", mock.Anything).Return(nil)
+	suite.CRUDMock.On("DeleteAllUserSessions", mock.Anything).Return(errors.New(""))
+
+	//act
+	username, cerr := suite.PasswordResetController.ResetPassword(&suite.CRUDMock, "token", "password")
+
+	//assert
+	suite.Empty(username)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *PasswordResetControllerTestSuite) TestResetPassword_UpdatesPasswordAndRevokesTokensAndSessions() {
+	//arrange
+	user := suite.createUser(true)
+	password := "new password"
+
+	suite.CRUDMock.On("GetPasswordResetToken", mock.Anything).Return(suite.createToken("token", time.Hour), nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(user, nil)
+	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
+	suite.CRUDMock.On("DeleteAllUserPasswordResetTokens", mock.Anything).Return(nil)
+	suite.CRUDMock.On("DeleteAllUserSessions", mock.Anything).Return(nil)
+
+	//act
+	username, cerr := suite.PasswordResetController.ResetPassword(&suite.CRUDMock, "token", password)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Equal(user.Username, username)
+	suite.ControllersMock.AssertCalled(suite.T(), "UpdateUserPassword", &suite.CRUDMock, user.Username, password)
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteAllUserPasswordResetTokens", user.Username)
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteAllUserSessions", user.Username)
+}
+
+func TestPasswordResetControllerTestSuite(t *testing.T) {
+	suite.Run(t, &PasswordResetControllerTestSuite{})
+}
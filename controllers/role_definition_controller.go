@@ -0,0 +1,257 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+type CoreRoleDefinitionController struct{}
+
+func (c CoreRoleDefinitionController) CreateRoleDefinition(CRUD RoleDefinitionControllerCRUD, definition *models.RoleDefinition) common.CustomError {
+	//validate the model
+	cerr := c.validateRoleDefinition(definition)
+	if cerr.Type != common.ErrorTypeNone {
+		return cerr
+	}
+
+	//verify the client exists
+	cerr = c.verifyClientExists(CRUD, definition.ClientUID)
+	if cerr.Type != common.ErrorTypeNone {
+		return cerr
+	}
+
+	//verify the role is not already defined
+	existingDefinition, err := CRUD.GetRoleDefinitionByClientUIDAndName(definition.ClientUID, definition.Name)
+	if err != nil {
+		log.Println(common.ChainError("error getting role definition by client uid and name", err))
+		return common.InternalError()
+	}
+	if existingDefinition != nil {
+		return common.ClientError(fmt.Sprintf("role %s is already defined for the client", definition.Name))
+	}
+
+	//only one role can be the default
+	if definition.IsDefault {
+		cerr = c.unsetDefaultRoleDefinition(CRUD, definition.ClientUID)
+		if cerr.Type != common.ErrorTypeNone {
+			return cerr
+		}
+	}
+
+	//create the role definition
+	err = CRUD.CreateRoleDefinition(definition)
+	if err != nil {
+		log.Println(common.ChainError("error creating role definition", err))
+		return common.InternalError()
+	}
+
+	return common.NoError()
+}
+
+func (c CoreRoleDefinitionController) GetRoleDefinitionsByClientUID(CRUD RoleDefinitionControllerCRUD, clientUID uuid.UUID) ([]*models.RoleDefinition, common.CustomError) {
+	//verify the client exists
+	cerr := c.verifyClientExists(CRUD, clientUID)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
+	}
+
+	//get the role definitions
+	definitions, err := CRUD.GetRoleDefinitionsByClientUID(clientUID)
+	if err != nil {
+		log.Println(common.ChainError("error getting role definitions by client uid", err))
+		return nil, common.InternalError()
+	}
+
+	return definitions, common.NoError()
+}
+
+func (c CoreRoleDefinitionController) UpdateRoleDefinition(CRUD RoleDefinitionControllerCRUD, name string, definition *models.RoleDefinition) common.CustomError {
+	//validate the model
+	cerr := c.validateRoleDefinition(definition)
+	if cerr.Type != common.ErrorTypeNone {
+		return cerr
+	}
+
+	//verify the role is defined
+	cerr = c.verifyRoleDefined(CRUD, definition.ClientUID, name)
+	if cerr.Type != common.ErrorTypeNone {
+		return cerr
+	}
+
+	//verify the new name is not already defined
+	renamed := definition.Name != name
+	if renamed {
+		existingDefinition, err := CRUD.GetRoleDefinitionByClientUIDAndName(definition.ClientUID, definition.Name)
+		if err != nil {
+			log.Println(common.ChainError("error getting role definition by client uid and name", err))
+			return common.InternalError()
+		}
+		if existingDefinition != nil {
+			return common.ClientError(fmt.Sprintf("role %s is already defined for the client", definition.Name))
+		}
+	}
+
+	//only one role can be the default
+	if definition.IsDefault {
+		cerr = c.unsetDefaultRoleDefinition(CRUD, definition.ClientUID)
+		if cerr.Type != common.ErrorTypeNone {
+			return cerr
+		}
+	}
+
+	//update the role definition
+	_, err := CRUD.UpdateRoleDefinition(name, definition)
+	if err != nil {
+		log.Println(common.ChainError("error updating role definition", err))
+		return common.InternalError()
+	}
+
+	if !renamed {
+		return common.NoError()
+	}
+
+	//give the users that had the old role the new one
+	err = CRUD.RenameUserRoles(definition.ClientUID, name, definition.Name)
+	if err != nil {
+		log.Println(common.ChainError("error renaming user roles", err))
+		return common.InternalError()
+	}
+
+	//and the groups
+	err = CRUD.RenameGroupRoles(definition.ClientUID, name, definition.Name)
+	if err != nil {
+		log.Println(common.ChainError("error renaming group roles", err))
+		return common.InternalError()
+	}
+
+	//do the same for pending invitations
+	err = CRUD.RenameInvitationRoles(definition.ClientUID, name, definition.Name)
+	if err != nil {
+		log.Println(common.ChainError("error renaming invitation roles", err))
+		return common.InternalError()
+	}
+
+	return common.NoError()
+}
+
+func (c CoreRoleDefinitionController) DeleteRoleDefinition(CRUD RoleDefinitionControllerCRUD, clientUID uuid.UUID, name string) common.CustomError {
+	//verify the role is defined
+	cerr := c.verifyRoleDefined(CRUD, clientUID, name)
+	if cerr.Type != common.ErrorTypeNone {
+		return cerr
+	}
+
+	//verify the role is no longer given to any users
+	count, err := CRUD.CountUserRolesByClientUIDAndRole(clientUID, name)
+	if err != nil {
+		log.Println(common.ChainError("error counting user roles by client uid and role", err))
+		return common.InternalError()
+	}
+	if count > 0 {
+		return common.ClientError(fmt.Sprintf("role %s is still given to %d users", name, count))
+	}
+
+	//or any groups
+	count, err = CRUD.CountGroupRolesByClientUIDAndRole(clientUID, name)
+	if err != nil {
+		log.Println(common.ChainError("error counting group roles by client uid and role", err))
+		return common.InternalError()
+	}
+	if count > 0 {
+		return common.ClientError(fmt.Sprintf("role %s is still given to %d groups", name, count))
+	}
+
+	//delete the role definition
+	_, err = CRUD.DeleteRoleDefinition(clientUID, name)
+	if err != nil {
+		log.Println(common.ChainError("error deleting role definition", err))
+		return common.InternalError()
+	}
+
+	return common.NoError()
+}
+
+func (CoreRoleDefinitionController) validateRoleDefinition(definition *models.RoleDefinition) common.CustomError {
+	verr := definition.Validate()
+
+	if verr&models.ValidateRoleDefinitionEmptyName != 0 {
+		return common.ClientError("role name cannot be empty")
+	}
+	if verr&models.ValidateRoleDefinitionNameTooLong != 0 {
+		return common.ClientError(fmt.Sprint("role name cannot be longer than ", models.UserRoleRoleMaxLength, " characters"))
+	}
+	if verr&models.ValidateRoleDefinitionDescriptionTooLong != 0 {
+		return common.ClientError(fmt.Sprint("role description cannot be longer than ", models.RoleDefinitionDescriptionMaxLength, " characters"))
+	}
+
+	return common.NoError()
+}
+
+func (CoreRoleDefinitionController) verifyClientExists(CRUD RoleDefinitionControllerCRUD, clientUID uuid.UUID) common.CustomError {
+	client, err := CRUD.GetClientByUID(clientUID)
+	if err != nil {
+		log.Println(common.ChainError("error getting client by uid", err))
+		return common.InternalError()
+	}
+	if client == nil {
+		return common.ClientError(fmt.Sprintf("client with id %s not found", clientUID.String()))
+	}
+
+	return common.NoError()
+}
+
+func (CoreRoleDefinitionController) verifyRoleDefined(CRUD RoleDefinitionControllerCRUD, clientUID uuid.UUID, name string) common.CustomError {
+	definition, err := CRUD.GetRoleDefinitionByClientUIDAndName(clientUID, name)
+	if err != nil {
+		log.Println(common.ChainError("error getting role definition by client uid and name", err))
+		return common.InternalError()
+	}
+	if definition == nil {
+		return common.ClientError(fmt.Sprintf("role %s is not defined for client %s", name, clientUID.String()))
+	}
+
+	return common.NoError()
+}
+
+// unsetDefaultRoleDefinition makes the client's current default role definition, if it has one, no longer the default.
+// Returns any errors.
+func (CoreRoleDefinitionController) unsetDefaultRoleDefinition(CRUD RoleDefinitionControllerCRUD, clientUID uuid.UUID) common.CustomError {
+	definition, cerr := getDefaultRoleDefinition(CRUD, clientUID)
+	if cerr.Type != common.ErrorTypeNone {
+		return cerr
+	}
+	if definition == nil {
+		return common.NoError()
+	}
+
+	definition.IsDefault = false
+	_, err := CRUD.UpdateRoleDefinition(definition.Name, definition)
+	if err != nil {
+		log.Println(common.ChainError("error updating default role definition", err))
+		return common.InternalError()
+	}
+
+	return common.NoError()
+}
+
+// getDefaultRoleDefinition gets the client's default role definition.
+// Returns the role definition, nil if the client has no default, and any errors.
+func getDefaultRoleDefinition(CRUD models.RoleDefinitionCRUD, clientUID uuid.UUID) (*models.RoleDefinition, common.CustomError) {
+	definitions, err := CRUD.GetRoleDefinitionsByClientUID(clientUID)
+	if err != nil {
+		log.Println(common.ChainError("error getting role definitions by client uid", err))
+		return nil, common.InternalError()
+	}
+
+	for _, definition := range definitions {
+		if definition.IsDefault {
+			return definition, common.NoError()
+		}
+	}
+	return nil, common.NoError()
+}
@@ -0,0 +1,509 @@
+package controllers_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/controllers"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RoleDefinitionControllerTestSuite struct {
+	ControllerTestSuite
+	RoleDefinitionController controllers.CoreRoleDefinitionController
+}
+
+func (suite *RoleDefinitionControllerTestSuite) SetupTest() {
+	suite.ControllerTestSuite.SetupTest()
+	suite.RoleDefinitionController = controllers.CoreRoleDefinitionController{}
+}
+
+func (suite *RoleDefinitionControllerTestSuite) runValidateRoleDefinitionTestCases(validateFunc func(definition *models.RoleDefinition) common.CustomError) {
+	suite.Run("EmptyName_ReturnsClientError", func() {
+		//arrange
+		definition := models.CreateRoleDefinition(uuid.New(), "", "", false)
+
+		//act
+		cerr := validateFunc(definition)
+
+		//assert
+		suite.CustomClientError(cerr, "role name", "cannot be empty")
+	})
+
+	suite.Run("NameTooLong_ReturnsClientError", func() {
+		//arrange
+		definition := models.CreateRoleDefinition(uuid.New(), helpers.CreateStringOfLength(models.UserRoleRoleMaxLength+1), "", false)
+
+		//act
+		cerr := validateFunc(definition)
+
+		//assert
+		suite.CustomClientError(cerr, "role name", "cannot be longer", fmt.Sprint(models.UserRoleRoleMaxLength))
+	})
+
+	suite.Run("DescriptionTooLong_ReturnsClientError", func() {
+		//arrange
+		definition := models.CreateRoleDefinition(uuid.New(), "role", helpers.CreateStringOfLength(models.RoleDefinitionDescriptionMaxLength+1), false)
+
+		//act
+		cerr := validateFunc(definition)
+
+		//assert
+		suite.CustomClientError(cerr, "role description", "cannot be longer", fmt.Sprint(models.RoleDefinitionDescriptionMaxLength))
+	})
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestCreateRoleDefinition_ValidateRoleDefinitionTestCases() {
+	suite.runValidateRoleDefinitionTestCases(func(definition *models.RoleDefinition) common.CustomError {
+		return suite.RoleDefinitionController.CreateRoleDefinition(&suite.CRUDMock, definition)
+	})
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestCreateRoleDefinition_WithErrorGettingClient_ReturnsInternalError() {
+	//arrange
+	definition := models.CreateRoleDefinition(uuid.New(), "role", "", false)
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.RoleDefinitionController.CreateRoleDefinition(&suite.CRUDMock, definition)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestCreateRoleDefinition_WhereClientNotFound_ReturnsClientError() {
+	//arrange
+	definition := models.CreateRoleDefinition(uuid.New(), "role", "", false)
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(nil, nil)
+
+	//act
+	cerr := suite.RoleDefinitionController.CreateRoleDefinition(&suite.CRUDMock, definition)
+
+	//assert
+	suite.CustomClientError(cerr, "client with id", definition.ClientUID.String(), "not found")
+	suite.CRUDMock.AssertCalled(suite.T(), "GetClientByUID", definition.ClientUID)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestCreateRoleDefinition_WithErrorGettingRoleDefinition_ReturnsInternalError() {
+	//arrange
+	definition := models.CreateRoleDefinition(uuid.New(), "role", "", false)
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.RoleDefinitionController.CreateRoleDefinition(&suite.CRUDMock, definition)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestCreateRoleDefinition_WhereRoleIsAlreadyDefined_ReturnsClientError() {
+	//arrange
+	definition := models.CreateRoleDefinition(uuid.New(), "role", "", false)
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
+
+	//act
+	cerr := suite.RoleDefinitionController.CreateRoleDefinition(&suite.CRUDMock, definition)
+
+	//assert
+	suite.CustomClientError(cerr, "role", definition.Name, "already defined")
+	suite.CRUDMock.AssertCalled(suite.T(), "GetRoleDefinitionByClientUIDAndName", definition.ClientUID, definition.Name)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "CreateRoleDefinition", mock.Anything)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestCreateRoleDefinition_WithErrorGettingDefaultRoleDefinition_ReturnsInternalError() {
+	//arrange
+	definition := models.CreateRoleDefinition(uuid.New(), "role", "", true)
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetRoleDefinitionsByClientUID", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.RoleDefinitionController.CreateRoleDefinition(&suite.CRUDMock, definition)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestCreateRoleDefinition_WithErrorUnsettingDefaultRoleDefinition_ReturnsInternalError() {
+	//arrange
+	definition := models.CreateRoleDefinition(uuid.New(), "role", "", true)
+	currentDefault := models.CreateRoleDefinition(definition.ClientUID, "other", "", true)
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetRoleDefinitionsByClientUID", mock.Anything).Return([]*models.RoleDefinition{currentDefault}, nil)
+	suite.CRUDMock.On("UpdateRoleDefinition", mock.Anything, mock.Anything).Return(false, errors.New(""))
+
+	//act
+	cerr := suite.RoleDefinitionController.CreateRoleDefinition(&suite.CRUDMock, definition)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestCreateRoleDefinition_WithErrorCreatingRoleDefinition_ReturnsInternalError() {
+	//arrange
+	definition := models.CreateRoleDefinition(uuid.New(), "role", "", false)
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("CreateRoleDefinition", mock.Anything).Return(errors.New(""))
+
+	//act
+	cerr := suite.RoleDefinitionController.CreateRoleDefinition(&suite.CRUDMock, definition)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestCreateRoleDefinition_WithNoErrors_ReturnsNoError() {
+	//arrange
+	definition := models.CreateRoleDefinition(uuid.New(), "role", "description", false)
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("CreateRoleDefinition", mock.Anything).Return(nil)
+
+	//act
+	cerr := suite.RoleDefinitionController.CreateRoleDefinition(&suite.CRUDMock, definition)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "GetRoleDefinitionsByClientUID", mock.Anything)
+	suite.CRUDMock.AssertCalled(suite.T(), "CreateRoleDefinition", definition)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestCreateRoleDefinition_AsDefault_UnsetsCurrentDefault() {
+	//arrange
+	definition := models.CreateRoleDefinition(uuid.New(), "role", "", true)
+	currentDefault := models.CreateRoleDefinition(definition.ClientUID, "other", "", true)
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetRoleDefinitionsByClientUID", mock.Anything).Return([]*models.RoleDefinition{currentDefault}, nil)
+	suite.CRUDMock.On("UpdateRoleDefinition", mock.Anything, mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("CreateRoleDefinition", mock.Anything).Return(nil)
+
+	//act
+	cerr := suite.RoleDefinitionController.CreateRoleDefinition(&suite.CRUDMock, definition)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.False(currentDefault.IsDefault)
+
+	suite.CRUDMock.AssertCalled(suite.T(), "GetRoleDefinitionsByClientUID", definition.ClientUID)
+	suite.CRUDMock.AssertCalled(suite.T(), "UpdateRoleDefinition", "other", currentDefault)
+	suite.CRUDMock.AssertCalled(suite.T(), "CreateRoleDefinition", definition)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestGetRoleDefinitionsByClientUID_WhereClientNotFound_ReturnsClientError() {
+	//arrange
+	uid := uuid.New()
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(nil, nil)
+
+	//act
+	definitions, cerr := suite.RoleDefinitionController.GetRoleDefinitionsByClientUID(&suite.CRUDMock, uid)
+
+	//assert
+	suite.Nil(definitions)
+	suite.CustomClientError(cerr, "client with id", uid.String(), "not found")
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestGetRoleDefinitionsByClientUID_WithErrorGettingRoleDefinitions_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetRoleDefinitionsByClientUID", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	definitions, cerr := suite.RoleDefinitionController.GetRoleDefinitionsByClientUID(&suite.CRUDMock, uuid.New())
+
+	//assert
+	suite.Nil(definitions)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestGetRoleDefinitionsByClientUID_WithNoErrors_ReturnsRoleDefinitions() {
+	//arrange
+	uid := uuid.New()
+	expectedDefinitions := []*models.RoleDefinition{
+		models.CreateRoleDefinition(uid, "role", "", false),
+	}
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetRoleDefinitionsByClientUID", mock.Anything).Return(expectedDefinitions, nil)
+
+	//act
+	definitions, cerr := suite.RoleDefinitionController.GetRoleDefinitionsByClientUID(&suite.CRUDMock, uid)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Equal(expectedDefinitions, definitions)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetRoleDefinitionsByClientUID", uid)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestUpdateRoleDefinition_ValidateRoleDefinitionTestCases() {
+	suite.runValidateRoleDefinitionTestCases(func(definition *models.RoleDefinition) common.CustomError {
+		return suite.RoleDefinitionController.UpdateRoleDefinition(&suite.CRUDMock, "role", definition)
+	})
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestUpdateRoleDefinition_WhereRoleIsNotDefined_ReturnsClientError() {
+	//arrange
+	definition := models.CreateRoleDefinition(uuid.New(), "role", "", false)
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(nil, nil)
+
+	//act
+	cerr := suite.RoleDefinitionController.UpdateRoleDefinition(&suite.CRUDMock, "old", definition)
+
+	//assert
+	suite.CustomClientError(cerr, "role", "old", "not defined", definition.ClientUID.String())
+	suite.CRUDMock.AssertCalled(suite.T(), "GetRoleDefinitionByClientUIDAndName", definition.ClientUID, "old")
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestUpdateRoleDefinition_WhereNewNameIsAlreadyDefined_ReturnsClientError() {
+	//arrange
+	definition := models.CreateRoleDefinition(uuid.New(), "role", "", false)
+
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, "old").Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, "role").Return(&models.RoleDefinition{}, nil)
+
+	//act
+	cerr := suite.RoleDefinitionController.UpdateRoleDefinition(&suite.CRUDMock, "old", definition)
+
+	//assert
+	suite.CustomClientError(cerr, "role", definition.Name, "already defined")
+	suite.CRUDMock.AssertNotCalled(suite.T(), "UpdateRoleDefinition", mock.Anything, mock.Anything)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestUpdateRoleDefinition_WithErrorUpdatingRoleDefinition_ReturnsInternalError() {
+	//arrange
+	definition := models.CreateRoleDefinition(uuid.New(), "role", "", false)
+
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("UpdateRoleDefinition", mock.Anything, mock.Anything).Return(false, errors.New(""))
+
+	//act
+	cerr := suite.RoleDefinitionController.UpdateRoleDefinition(&suite.CRUDMock, "role", definition)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestUpdateRoleDefinition_WithSameName_DoesNotRenameRoles() {
+	//arrange
+	definition := models.CreateRoleDefinition(uuid.New(), "role", "description", false)
+
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("UpdateRoleDefinition", mock.Anything, mock.Anything).Return(true, nil)
+
+	//act
+	cerr := suite.RoleDefinitionController.UpdateRoleDefinition(&suite.CRUDMock, "role", definition)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "UpdateRoleDefinition", "role", definition)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "RenameUserRoles", mock.Anything, mock.Anything, mock.Anything)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "RenameGroupRoles", mock.Anything, mock.Anything, mock.Anything)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "RenameInvitationRoles", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestUpdateRoleDefinition_WithErrorRenamingUserRoles_ReturnsInternalError() {
+	//arrange
+	definition := models.CreateRoleDefinition(uuid.New(), "role", "", false)
+
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, "old").Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, "role").Return(nil, nil)
+	suite.CRUDMock.On("UpdateRoleDefinition", mock.Anything, mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("RenameUserRoles", mock.Anything, mock.Anything, mock.Anything).Return(errors.New(""))
+
+	//act
+	cerr := suite.RoleDefinitionController.UpdateRoleDefinition(&suite.CRUDMock, "old", definition)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestUpdateRoleDefinition_WithErrorRenamingGroupRoles_ReturnsInternalError() {
+	//arrange
+	definition := models.CreateRoleDefinition(uuid.New(), "role", "", false)
+
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, "old").Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, "role").Return(nil, nil)
+	suite.CRUDMock.On("UpdateRoleDefinition", mock.Anything, mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("RenameUserRoles", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("RenameGroupRoles", mock.Anything, mock.Anything, mock.Anything).Return(errors.New(""))
+
+	//act
+	cerr := suite.RoleDefinitionController.UpdateRoleDefinition(&suite.CRUDMock, "old", definition)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestUpdateRoleDefinition_WithErrorRenamingInvitationRoles_ReturnsInternalError() {
+	//arrange
+	definition := models.CreateRoleDefinition(uuid.New(), "role", "", false)
+
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, "old").Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, "role").Return(nil, nil)
+	suite.CRUDMock.On("UpdateRoleDefinition", mock.Anything, mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("RenameUserRoles", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("RenameGroupRoles", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("RenameInvitationRoles", mock.Anything, mock.Anything, mock.Anything).Return(errors.New(""))
+
+	//act
+	cerr := suite.RoleDefinitionController.UpdateRoleDefinition(&suite.CRUDMock, "old", definition)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestUpdateRoleDefinition_WithNewName_RenamesRoles() {
+	//arrange
+	definition := models.CreateRoleDefinition(uuid.New(), "role", "", false)
+
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, "old").Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, "role").Return(nil, nil)
+	suite.CRUDMock.On("UpdateRoleDefinition", mock.Anything, mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("RenameUserRoles", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("RenameGroupRoles", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("RenameInvitationRoles", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	//act
+	cerr := suite.RoleDefinitionController.UpdateRoleDefinition(&suite.CRUDMock, "old", definition)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "UpdateRoleDefinition", "old", definition)
+	suite.CRUDMock.AssertCalled(suite.T(), "RenameUserRoles", definition.ClientUID, "old", "role")
+	suite.CRUDMock.AssertCalled(suite.T(), "RenameGroupRoles", definition.ClientUID, "old", "role")
+	suite.CRUDMock.AssertCalled(suite.T(), "RenameInvitationRoles", definition.ClientUID, "old", "role")
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestDeleteRoleDefinition_WithErrorGettingRoleDefinition_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.RoleDefinitionController.DeleteRoleDefinition(&suite.CRUDMock, uuid.New(), "role")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestDeleteRoleDefinition_WhereRoleIsNotDefined_ReturnsClientError() {
+	//arrange
+	uid := uuid.New()
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(nil, nil)
+
+	//act
+	cerr := suite.RoleDefinitionController.DeleteRoleDefinition(&suite.CRUDMock, uid, "role")
+
+	//assert
+	suite.CustomClientError(cerr, "role", "not defined", uid.String())
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestDeleteRoleDefinition_WithErrorCountingUserRoles_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("CountUserRolesByClientUIDAndRole", mock.Anything, mock.Anything).Return(0, errors.New(""))
+
+	//act
+	cerr := suite.RoleDefinitionController.DeleteRoleDefinition(&suite.CRUDMock, uuid.New(), "role")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestDeleteRoleDefinition_WhereRoleIsStillGiven_ReturnsClientError() {
+	//arrange
+	uid := uuid.New()
+
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("CountUserRolesByClientUIDAndRole", mock.Anything, mock.Anything).Return(2, nil)
+
+	//act
+	cerr := suite.RoleDefinitionController.DeleteRoleDefinition(&suite.CRUDMock, uid, "role")
+
+	//assert
+	suite.CustomClientError(cerr, "role", "still given to 2 users")
+	suite.CRUDMock.AssertCalled(suite.T(), "CountUserRolesByClientUIDAndRole", uid, "role")
+	suite.CRUDMock.AssertNotCalled(suite.T(), "DeleteRoleDefinition", mock.Anything, mock.Anything)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestDeleteRoleDefinition_WithErrorCountingGroupRoles_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("CountUserRolesByClientUIDAndRole", mock.Anything, mock.Anything).Return(0, nil)
+	suite.CRUDMock.On("CountGroupRolesByClientUIDAndRole", mock.Anything, mock.Anything).Return(0, errors.New(""))
+
+	//act
+	cerr := suite.RoleDefinitionController.DeleteRoleDefinition(&suite.CRUDMock, uuid.New(), "role")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestDeleteRoleDefinition_WhereRoleIsStillGivenToGroups_ReturnsClientError() {
+	//arrange
+	uid := uuid.New()
+
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("CountUserRolesByClientUIDAndRole", mock.Anything, mock.Anything).Return(0, nil)
+	suite.CRUDMock.On("CountGroupRolesByClientUIDAndRole", mock.Anything, mock.Anything).Return(3, nil)
+
+	//act
+	cerr := suite.RoleDefinitionController.DeleteRoleDefinition(&suite.CRUDMock, uid, "role")
+
+	//assert
+	suite.CustomClientError(cerr, "role", "still given to 3 groups")
+	suite.CRUDMock.AssertCalled(suite.T(), "CountGroupRolesByClientUIDAndRole", uid, "role")
+	suite.CRUDMock.AssertNotCalled(suite.T(), "DeleteRoleDefinition", mock.Anything, mock.Anything)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestDeleteRoleDefinition_WithErrorDeletingRoleDefinition_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("CountUserRolesByClientUIDAndRole", mock.Anything, mock.Anything).Return(0, nil)
+	suite.CRUDMock.On("CountGroupRolesByClientUIDAndRole", mock.Anything, mock.Anything).Return(0, nil)
+	suite.CRUDMock.On("DeleteRoleDefinition", mock.Anything, mock.Anything).Return(false, errors.New(""))
+
+	//act
+	cerr := suite.RoleDefinitionController.DeleteRoleDefinition(&suite.CRUDMock, uuid.New(), "role")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *RoleDefinitionControllerTestSuite) TestDeleteRoleDefinition_WithNoErrors_ReturnsNoError() {
+	//arrange
+	uid := uuid.New()
+
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("CountUserRolesByClientUIDAndRole", mock.Anything, mock.Anything).Return(0, nil)
+	suite.CRUDMock.On("CountGroupRolesByClientUIDAndRole", mock.Anything, mock.Anything).Return(0, nil)
+	suite.CRUDMock.On("DeleteRoleDefinition", mock.Anything, mock.Anything).Return(true, nil)
+
+	//act
+	cerr := suite.RoleDefinitionController.DeleteRoleDefinition(&suite.CRUDMock, uid, "role")
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteRoleDefinition", uid, "role")
+}
+
+func TestRoleDefinitionControllerTestSuite(t *testing.T) {
+	suite.Run(t, &RoleDefinitionControllerTestSuite{})
+}
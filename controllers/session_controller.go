@@ -3,8 +3,10 @@ package controllers
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
 	"github.com/mhogar/amber/models"
 
 	"github.com/google/uuid"
@@ -14,24 +16,29 @@ type CoreSessionController struct {
 	AuthController AuthController
 }
 
-func (c CoreSessionController) CreateSession(CRUD SessionControllerCRUD, username string, password string) (*models.Session, common.CustomError) {
+func (c CoreSessionController) CreateSession(CRUD SessionControllerCRUD, creds UserCredentials) (*models.Session, string, common.CustomError) {
 	//authenticate the user
-	user, cerr := c.AuthController.AuthenticateUserWithPassword(CRUD, username, password)
+	user, challenge, cerr := c.AuthController.AuthenticateUser(CRUD, creds)
 	if cerr.Type != common.ErrorTypeNone {
-		return nil, cerr
+		return nil, "", cerr
+	}
+
+	//the user still needs to complete another step (e.g. two-factor authentication)
+	if challenge != "" {
+		return nil, challenge, common.NoError()
 	}
 
 	//create a new session
-	session := models.CreateNewSession(username, user.Rank)
+	session := models.CreateNewSession(user.Username, user.Rank)
 
 	//save the session
 	err := CRUD.SaveSession(session)
 	if err != nil {
 		log.Println(common.ChainError("error saving session", err))
-		return nil, common.InternalError()
+		return nil, "", common.InternalError()
 	}
 
-	return session, common.NoError()
+	return session, "", common.NoError()
 }
 
 func (c CoreSessionController) DeleteSession(CRUD SessionControllerCRUD, id uuid.UUID) common.CustomError {
@@ -71,3 +78,28 @@ func (c CoreSessionController) DeleteAllOtherUserSessions(CRUD SessionController
 
 	return common.NoError()
 }
+
+func (c CoreSessionController) DeleteExpiredSessions(CRUD SessionControllerCRUD) common.CustomError {
+	cfg := config.GetSessionConfig()
+	now := time.Now().UTC()
+
+	//calculate the cutoffs, leaving them zero if the check is disabled
+	createdBefore := time.Time{}
+	if cfg.Lifetime > 0 {
+		createdBefore = now.Add(-time.Duration(cfg.Lifetime) * time.Second)
+	}
+
+	lastUsedBefore := time.Time{}
+	if cfg.IdleTimeout > 0 {
+		lastUsedBefore = now.Add(-time.Duration(cfg.IdleTimeout) * time.Second)
+	}
+
+	//delete the sessions
+	err := CRUD.DeleteExpiredSessions(createdBefore, lastUsedBefore)
+	if err != nil {
+		log.Println(common.ChainError("error deleting expired sessions", err))
+		return common.InternalError()
+	}
+
+	return common.NoError()
+}
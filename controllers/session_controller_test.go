@@ -3,13 +3,16 @@ package controllers_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
 	"github.com/mhogar/amber/controllers"
 	"github.com/mhogar/amber/controllers/mocks"
 	"github.com/mhogar/amber/models"
 
 	"github.com/google/uuid"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/stretchr/testify/suite"
@@ -33,49 +36,71 @@ func (suite *SessionControllerTestSuite) SetupTest() {
 func (suite *SessionControllerTestSuite) TestCreateSession_WithErrorAuthenticatingUser_ReturnsError() {
 	//arrange
 	authErr := common.ClientError("authenticate user error")
-	suite.ControllersMock.On("AuthenticateUserWithPassword", mock.Anything, mock.Anything, mock.Anything).Return(nil, authErr)
+	suite.ControllersMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(nil, "", authErr)
 
 	//act
-	session, cerr := suite.SessionController.CreateSession(&suite.CRUDMock, "username", "password")
+	session, challenge, cerr := suite.SessionController.CreateSession(&suite.CRUDMock, controllers.UserCredentials{})
 
 	//assert
 	suite.Nil(session)
+	suite.Empty(challenge)
 	suite.Equal(cerr, authErr)
 }
 
+func (suite *SessionControllerTestSuite) TestCreateSession_WhereTwoFactorChallengeIsReturned_ReturnsChallengeWithoutCreatingSession() {
+	//arrange
+	challenge := "challenge"
+	suite.ControllersMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(nil, challenge, common.NoError())
+
+	//act
+	session, resultChallenge, cerr := suite.SessionController.CreateSession(&suite.CRUDMock, controllers.UserCredentials{})
+
+	//assert
+	suite.Nil(session)
+	suite.Equal(challenge, resultChallenge)
+	suite.CustomNoError(cerr)
+
+	suite.CRUDMock.AssertNotCalled(suite.T(), "SaveSession", mock.Anything)
+}
+
 func (suite *SessionControllerTestSuite) TestCreateSession_WithErrorSavingSession_ReturnsInternalError() {
 	//arrange
 	user := models.CreateUser("username", 0, nil)
 
-	suite.ControllersMock.On("AuthenticateUserWithPassword", mock.Anything, mock.Anything, mock.Anything).Return(user, common.NoError())
+	suite.ControllersMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(user, "", common.NoError())
 	suite.CRUDMock.On("SaveSession", mock.Anything).Return(errors.New(""))
 
 	//act
-	session, cerr := suite.SessionController.CreateSession(&suite.CRUDMock, user.Username, "password")
+	session, challenge, cerr := suite.SessionController.CreateSession(&suite.CRUDMock, controllers.UserCredentials{})
 
 	//assert
 	suite.Nil(session)
+	suite.Empty(challenge)
 	suite.CustomInternalError(cerr)
 }
 
 func (suite *SessionControllerTestSuite) TestCreateSession_WithNoErrors_ReturnsNoError() {
 	//arrange
 	user := models.CreateUser("username", 0, nil)
-	password := "password"
+	creds := controllers.UserCredentials{
+		Username: user.Username,
+		Password: "password",
+	}
 
-	suite.ControllersMock.On("AuthenticateUserWithPassword", mock.Anything, mock.Anything, mock.Anything).Return(user, common.NoError())
+	suite.ControllersMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(user, "", common.NoError())
 	suite.CRUDMock.On("SaveSession", mock.Anything).Return(nil)
 
 	//act
-	session, cerr := suite.SessionController.CreateSession(&suite.CRUDMock, user.Username, password)
+	session, challenge, cerr := suite.SessionController.CreateSession(&suite.CRUDMock, creds)
 
 	//assert
 	suite.Require().NotNil(session)
 	suite.Equal(user.Username, session.Username)
 	suite.Equal(user.Rank, session.Rank)
+	suite.Empty(challenge)
 	suite.CustomNoError(cerr)
 
-	suite.ControllersMock.AssertCalled(suite.T(), "AuthenticateUserWithPassword", &suite.CRUDMock, user.Username, password)
+	suite.ControllersMock.AssertCalled(suite.T(), "AuthenticateUser", &suite.CRUDMock, creds)
 	suite.CRUDMock.AssertCalled(suite.T(), "SaveSession", session)
 }
 
@@ -172,6 +197,56 @@ func (suite *SessionControllerTestSuite) TestDeleteAllOtherUserSessions_WithNoEr
 	suite.CRUDMock.AssertCalled(suite.T(), "DeleteAllOtherUserSessions", username, id)
 }
 
+func (suite *SessionControllerTestSuite) TestDeleteExpiredSessions_WithErrorDeletingSessions_ReturnsInternalError() {
+	//arrange
+	viper.Set("session", config.SessionConfig{})
+	suite.CRUDMock.On("DeleteExpiredSessions", mock.Anything, mock.Anything).Return(errors.New(""))
+
+	//act
+	cerr := suite.SessionController.DeleteExpiredSessions(&suite.CRUDMock)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *SessionControllerTestSuite) TestDeleteExpiredSessions_WithDisabledChecks_UsesZeroCutoffs() {
+	//arrange
+	viper.Set("session", config.SessionConfig{})
+	suite.CRUDMock.On("DeleteExpiredSessions", mock.Anything, mock.Anything).Return(nil)
+
+	//act
+	cerr := suite.SessionController.DeleteExpiredSessions(&suite.CRUDMock)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteExpiredSessions", time.Time{}, time.Time{})
+}
+
+func (suite *SessionControllerTestSuite) TestDeleteExpiredSessions_WithNoErrors_ReturnsNoError() {
+	//arrange
+	cfg := config.SessionConfig{
+		Lifetime:    100,
+		IdleTimeout: 10,
+	}
+	viper.Set("session", cfg)
+
+	var createdBefore, lastUsedBefore time.Time
+	suite.CRUDMock.On("DeleteExpiredSessions", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		createdBefore = args.Get(0).(time.Time)
+		lastUsedBefore = args.Get(1).(time.Time)
+	})
+
+	//act
+	cerr := suite.SessionController.DeleteExpiredSessions(&suite.CRUDMock)
+
+	//assert
+	suite.CustomNoError(cerr)
+
+	now := time.Now()
+	suite.WithinDuration(now.Add(-100*time.Second), createdBefore, time.Second)
+	suite.WithinDuration(now.Add(-10*time.Second), lastUsedBefore, time.Second)
+}
+
 func TestSessionControllerTestSuite(t *testing.T) {
 	suite.Run(t, &SessionControllerTestSuite{})
 }
@@ -4,77 +4,506 @@ import (
 	"fmt"
 	"log"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
 	jwthelpers "github.com/mhogar/amber/controllers/jwt_helpers"
+	"github.com/mhogar/amber/loaders"
+	"github.com/mhogar/amber/models"
 
+	"github.com/golang-jwt/jwt"
 	"github.com/google/uuid"
 )
 
 type CoreTokenController struct {
 	AuthController       AuthController
 	TokenFactorySelector jwthelpers.TokenFactorySelector
+	IDTokenFactory       jwthelpers.IDTokenFactory
+	DataLoader           loaders.RawDataLoader
 }
 
-func (c CoreTokenController) CreateTokenRedirectURL(CRUD TokenControllerCRUD, clientUID uuid.UUID, username string, password string) (string, common.CustomError) {
+func (c CoreTokenController) CreateTokenRedirectURL(CRUD TokenControllerCRUD, clientUID uuid.UUID, creds UserCredentials) (string, string, common.CustomError) {
 	//get the requested client
 	client, err := CRUD.GetClientByUID(clientUID)
 	if err != nil {
 		log.Println(common.ChainError("error getting client by uid", err))
-		return "", common.InternalError()
+		return "", "", common.InternalError()
 	}
 
 	//verify client exists
 	if client == nil {
-		return "", common.ClientError(fmt.Sprintf("client with id %s not found", clientUID.String()))
+		return "", "", common.ClientError(fmt.Sprintf("client with id %s not found", clientUID.String()))
 	}
 
-	//authenticate the user
-	_, cerr := c.AuthController.AuthenticateUserWithPassword(CRUD, username, password)
-	if cerr.Type == common.ErrorTypeClient {
-		return "", common.ClientError("invalid username and/or password, or user is not assigned to the client")
-	}
+	//authenticate the user and get their roles
+	user, roles, challenge, cerr := c.authenticateClientUser(CRUD, clientUID, creds)
 	if cerr.Type != common.ErrorTypeNone {
-		return "", cerr
-	}
-
-	//get the user's role
-	role, err := CRUD.GetUserRoleByClientUIDAndUsername(clientUID, username)
-	if err != nil {
-		log.Println(common.ChainError("error getting user role", err))
-		return "", common.InternalError()
+		return "", "", cerr
 	}
 
-	//verify role exists
-	if role == nil {
-		return "", common.ClientError("invalid username and/or password, or user is not assigned to the client")
+	//the user still needs to complete another step (e.g. two-factor authentication)
+	if challenge != "" {
+		return "", challenge, common.NoError()
 	}
 
 	//choose the token factory (in practice a factory should always be found since the client model validates the token type when saving)
 	tf := c.TokenFactorySelector.Select(client.TokenType)
 	if tf == nil {
 		log.Println(fmt.Sprintf("token factory for token type %d not found", client.TokenType))
-		return "", common.InternalError()
+		return "", "", common.InternalError()
 	}
 
 	//create the token
-	token, err := tf.CreateToken(client.KeyUri, clientUID, username, role.Role)
+	token, err := tf.CreateToken(client.KeyUri, clientUID, user, roles)
 	if err != nil {
 		log.Println(common.ChainError("error creating token", err))
-		return "", common.InternalError()
+		return "", "", common.InternalError()
 	}
 
 	//parse the redirect url (in practice this should always succeed since the client model validates the url when saving)
 	url, err := url.Parse(client.RedirectUrl)
 	if err != nil {
 		log.Println(common.ChainError("error parsing redirect url", err))
-		return "", common.InternalError()
+		return "", "", common.InternalError()
 	}
 
 	//set the token as a query parameter
+	//refresh tokens are long lived, so they are only issued by the token endpoint and never put in a url
 	q := url.Query()
 	q.Set("token", token)
 	url.RawQuery = q.Encode()
 
-	return url.String(), common.NoError()
+	return url.String(), "", common.NoError()
+}
+
+func (c CoreTokenController) CreateClientCredentialsToken(CRUD TokenControllerCRUD, clientUID uuid.UUID, secret string) (string, common.CustomError) {
+	//authenticate the client
+	client, cerr := c.AuthController.AuthenticateClientWithSecret(CRUD, clientUID, secret)
+	if cerr.Type != common.ErrorTypeNone {
+		return "", cerr
+	}
+
+	//choose the token factory
+	tf := c.TokenFactorySelector.Select(client.TokenType)
+	if tf == nil {
+		log.Println(fmt.Sprintf("token factory for token type %d not found", client.TokenType))
+		return "", common.InternalError()
+	}
+
+	//create the token with the client as the subject
+	token, err := tf.CreateClientToken(client.KeyUri, clientUID)
+	if err != nil {
+		log.Println(common.ChainError("error creating client token", err))
+		return "", common.InternalError()
+	}
+
+	return token, common.NoError()
+}
+
+func (c CoreTokenController) GetJSONWebKeySet(CRUD TokenControllerCRUD) (*jwthelpers.JWKS, common.CustomError) {
+	//get every client
+	clients, err := CRUD.GetClients(models.PageQuery{})
+	if err != nil {
+		log.Println(common.ChainError("error getting clients", err))
+		return nil, common.InternalError()
+	}
+
+	jwks := &jwthelpers.JWKS{
+		Keys: []jwthelpers.JWK{},
+	}
+	kids := map[string]bool{}
+
+	for _, client := range clients {
+		//only default tokens are verified using amber's keys
+		if client.TokenType != models.ClientTokenTypeDefault {
+			continue
+		}
+
+		//load the private key (skip misconfigured clients so they don't break the key set for everyone else)
+		bytes, err := c.DataLoader.Load(client.KeyUri)
+		if err != nil {
+			log.Println(common.ChainError(fmt.Sprintf("error loading private key for client %s", client.UID.String()), err))
+			continue
+		}
+
+		//parse the private key
+		key, err := jwt.ParseRSAPrivateKeyFromPEM(bytes)
+		if err != nil {
+			log.Println(common.ChainError(fmt.Sprintf("error parsing private key for client %s", client.UID.String()), err))
+			continue
+		}
+
+		//add the public key if it hasn't been already
+		jwk := jwthelpers.CreateJWK(&key.PublicKey)
+		if !kids[jwk.Kid] {
+			kids[jwk.Kid] = true
+			jwks.Keys = append(jwks.Keys, jwk)
+		}
+	}
+
+	return jwks, common.NoError()
+}
+
+func (c CoreTokenController) CreateAuthorizationCodeRedirectURL(CRUD TokenControllerCRUD, authReq AuthorizationRequest, creds UserCredentials) (string, string, common.CustomError) {
+	//verify the request is for openid
+	if !containsScope(authReq.Scope, "openid") {
+		return "", "", common.ClientError("scope must include openid")
+	}
+
+	//verify the PKCE params
+	if authReq.CodeChallenge == "" {
+		return "", "", common.ClientError("code_challenge is required")
+	}
+	if authReq.CodeChallengeMethod != "S256" {
+		return "", "", common.ClientError("code_challenge_method must be S256")
+	}
+
+	//get the requested client
+	client, cerr := c.getAuthorizationCodeClient(CRUD, authReq.ClientUID, authReq.RedirectURI)
+	if cerr.Type != common.ErrorTypeNone {
+		return "", "", cerr
+	}
+
+	//authenticate the user and verify they are assigned to the client
+	user, _, challenge, cerr := c.authenticateClientUser(CRUD, client.UID, creds)
+	if cerr.Type != common.ErrorTypeNone {
+		return "", "", cerr
+	}
+
+	//the user still needs to complete another step (e.g. two-factor authentication)
+	if challenge != "" {
+		return "", challenge, common.NoError()
+	}
+
+	//create the authorization code
+	lifetime := time.Duration(config.GetTokenConfig().AuthorizationCodeLifetime) * time.Second
+	code := models.CreateNewAuthorizationCode(client.UID, user.Username, authReq.CodeChallenge, authReq.Nonce, authReq.RedirectURI, lifetime)
+
+	verr := code.Validate()
+	if verr&models.ValidateAuthorizationCodeCodeChallengeTooLong != 0 {
+		return "", "", common.ClientError(fmt.Sprint("code_challenge cannot be longer than ", models.AuthorizationCodeCodeChallengeMaxLength, " characters"))
+	}
+	if verr&models.ValidateAuthorizationCodeNonceTooLong != 0 {
+		return "", "", common.ClientError(fmt.Sprint("nonce cannot be longer than ", models.AuthorizationCodeNonceMaxLength, " characters"))
+	}
+
+	//save the authorization code
+	err := CRUD.SaveAuthorizationCode(code)
+	if err != nil {
+		log.Println(common.ChainError("error saving authorization code", err))
+		return "", "", common.InternalError()
+	}
+
+	//parse the redirect url (in practice this should always succeed since the client model validates the url when saving)
+	url, err := url.Parse(client.RedirectUrl)
+	if err != nil {
+		log.Println(common.ChainError("error parsing redirect url", err))
+		return "", "", common.InternalError()
+	}
+
+	//set the code and state as query parameters
+	q := url.Query()
+	q.Set("code", code.Code.String())
+	if authReq.State != "" {
+		q.Set("state", authReq.State)
+	}
+	url.RawQuery = q.Encode()
+
+	return url.String(), "", common.NoError()
+}
+
+func (c CoreTokenController) ExchangeAuthorizationCode(CRUD TokenControllerCRUD, clientUID uuid.UUID, code uuid.UUID, redirectURI string, codeVerifier string) (*OAuthTokens, common.CustomError) {
+	//get the authorization code
+	authCode, err := CRUD.GetAuthorizationCode(code)
+	if err != nil {
+		log.Println(common.ChainError("error getting authorization code", err))
+		return nil, common.InternalError()
+	}
+
+	//verify the authorization code exists
+	if authCode == nil {
+		return nil, common.ClientError("authorization code invalid or expired")
+	}
+
+	//delete the authorization code so it can only be used once
+	res, err := CRUD.DeleteAuthorizationCode(code)
+	if err != nil {
+		log.Println(common.ChainError("error deleting authorization code", err))
+		return nil, common.InternalError()
+	}
+
+	//verify the authorization code was not already used by another request
+	if !res {
+		return nil, common.ClientError("authorization code invalid or expired")
+	}
+
+	//verify the authorization code is still valid for the client
+	if authCode.IsExpired(time.Now().UTC()) || authCode.ClientUID != clientUID {
+		return nil, common.ClientError("authorization code invalid or expired")
+	}
+
+	//verify the PKCE code verifier
+	if !authCode.VerifyCodeVerifier(codeVerifier) {
+		return nil, common.ClientError("code_verifier does not match the code_challenge")
+	}
+
+	//verify the redirect uri is the same as the one sent with the authorization request, if any (RFC 6749 section 4.1.3)
+	if authCode.RedirectURI != "" && redirectURI != authCode.RedirectURI {
+		return nil, common.ClientError("redirect_uri does not match the one used to request the authorization code")
+	}
+
+	//get the client
+	client, cerr := c.getAuthorizationCodeClient(CRUD, clientUID, redirectURI)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
+	}
+
+	//get the user
+	user, cerr := c.getEnabledUser(CRUD, authCode.Username)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
+	}
+
+	//get the user's current roles
+	roles, cerr := getEffectiveRoles(CRUD, clientUID, authCode.Username)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
+	}
+
+	//verify the user still has roles for the client
+	if len(roles) == 0 {
+		return nil, common.ClientError("user is no longer assigned to the client")
+	}
+
+	//create the access token (in practice a factory should always be found since the client model validates the token type when saving)
+	tf := c.TokenFactorySelector.Select(client.TokenType)
+	if tf == nil {
+		log.Println(fmt.Sprintf("token factory for token type %d not found", client.TokenType))
+		return nil, common.InternalError()
+	}
+
+	accessToken, err := tf.CreateToken(client.KeyUri, client.UID, user, roles)
+	if err != nil {
+		log.Println(common.ChainError("error creating access token", err))
+		return nil, common.InternalError()
+	}
+
+	//create the id token
+	idToken, err := c.IDTokenFactory.CreateIDToken(client.KeyUri, client.UID, user, roles, authCode.Nonce)
+	if err != nil {
+		log.Println(common.ChainError("error creating id token", err))
+		return nil, common.InternalError()
+	}
+
+	//create the refresh token as the start of a new family
+	refreshToken, cerr := c.createRefreshToken(CRUD, uuid.New(), client.UID, authCode.Username)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
+	}
+
+	return &OAuthTokens{
+		AccessToken:  accessToken,
+		IDToken:      idToken,
+		RefreshToken: refreshToken.Token.String(),
+	}, common.NoError()
+}
+
+func (c CoreTokenController) RedeemRefreshToken(CRUD TokenControllerCRUD, clientUID uuid.UUID, token uuid.UUID) (*OAuthTokens, common.CustomError) {
+	//get the refresh token
+	refreshToken, err := CRUD.GetRefreshToken(token)
+	if err != nil {
+		log.Println(common.ChainError("error getting refresh token", err))
+		return nil, common.InternalError()
+	}
+
+	//verify the refresh token exists and belongs to the client
+	if refreshToken == nil || refreshToken.ClientUID != clientUID {
+		return nil, common.ClientError("refresh token invalid or expired")
+	}
+
+	//a rotated token being used again means it has leaked, so revoke the whole family
+	if refreshToken.Rotated {
+		return nil, c.revokeRefreshTokenFamily(CRUD, refreshToken.FamilyID)
+	}
+
+	//verify the refresh token has not expired
+	if refreshToken.IsExpired(time.Now().UTC()) {
+		return nil, common.ClientError("refresh token invalid or expired")
+	}
+
+	//rotate the refresh token
+	res, err := CRUD.RotateRefreshToken(token)
+	if err != nil {
+		log.Println(common.ChainError("error rotating refresh token", err))
+		return nil, common.InternalError()
+	}
+
+	//the token was rotated by another request in the meantime, so it was reused
+	if !res {
+		return nil, c.revokeRefreshTokenFamily(CRUD, refreshToken.FamilyID)
+	}
+
+	//get the client
+	client, err := CRUD.GetClientByUID(clientUID)
+	if err != nil {
+		log.Println(common.ChainError("error getting client by uid", err))
+		return nil, common.InternalError()
+	}
+
+	//verify client exists
+	if client == nil {
+		return nil, common.ClientError(fmt.Sprintf("client with id %s not found", clientUID.String()))
+	}
+
+	//get the user
+	user, cerr := c.getEnabledUser(CRUD, refreshToken.Username)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
+	}
+
+	//get the user's current roles
+	roles, cerr := getEffectiveRoles(CRUD, clientUID, refreshToken.Username)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
+	}
+
+	//verify the user still has roles for the client
+	if len(roles) == 0 {
+		return nil, common.ClientError("user is no longer assigned to the client")
+	}
+
+	//create the access token (in practice a factory should always be found since the client model validates the token type when saving)
+	tf := c.TokenFactorySelector.Select(client.TokenType)
+	if tf == nil {
+		log.Println(fmt.Sprintf("token factory for token type %d not found", client.TokenType))
+		return nil, common.InternalError()
+	}
+
+	accessToken, err := tf.CreateToken(client.KeyUri, client.UID, user, roles)
+	if err != nil {
+		log.Println(common.ChainError("error creating access token", err))
+		return nil, common.InternalError()
+	}
+
+	//create the next refresh token in the family
+	newRefreshToken, cerr := c.createRefreshToken(CRUD, refreshToken.FamilyID, client.UID, refreshToken.Username)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
+	}
+
+	return &OAuthTokens{
+		AccessToken:  accessToken,
+		RefreshToken: newRefreshToken.Token.String(),
+	}, common.NoError()
+}
+
+func (CoreTokenController) createRefreshToken(CRUD TokenControllerCRUD, familyID uuid.UUID, clientUID uuid.UUID, username string) (*models.RefreshToken, common.CustomError) {
+	lifetime := time.Duration(config.GetTokenConfig().RefreshTokenLifetime) * time.Second
+	refreshToken := models.CreateNewRefreshToken(familyID, clientUID, username, lifetime)
+
+	//save the refresh token
+	err := CRUD.SaveRefreshToken(refreshToken)
+	if err != nil {
+		log.Println(common.ChainError("error saving refresh token", err))
+		return nil, common.InternalError()
+	}
+
+	return refreshToken, common.NoError()
+}
+
+func (CoreTokenController) revokeRefreshTokenFamily(CRUD TokenControllerCRUD, familyID uuid.UUID) common.CustomError {
+	err := CRUD.DeleteRefreshTokenFamily(familyID)
+	if err != nil {
+		log.Println(common.ChainError("error deleting refresh token family", err))
+		return common.InternalError()
+	}
+
+	return common.ClientError("refresh token has already been used, all tokens issued from it have been revoked")
+}
+
+func (c CoreTokenController) authenticateClientUser(CRUD TokenControllerCRUD, clientUID uuid.UUID, creds UserCredentials) (*models.User, []string, string, common.CustomError) {
+	//authenticate the user
+	user, challenge, cerr := c.AuthController.AuthenticateUser(CRUD, creds)
+
+	//don't reveal why password authentication failed, but let other errors (e.g. two-factor or lockout) through so the user knows what to do
+	if cerr.Type == common.ErrorTypeClient && cerr.Error() == invalidUserCredentialsMessage {
+		return nil, nil, "", common.ClientError("invalid username and/or password, or user is not assigned to the client")
+	}
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, nil, "", cerr
+	}
+
+	//the user still needs to complete another step (e.g. two-factor authentication)
+	if challenge != "" {
+		return nil, nil, challenge, common.NoError()
+	}
+
+	//get the user's roles, given either directly or through their groups
+	roles, cerr := getEffectiveRoles(CRUD, clientUID, user.Username)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, nil, "", cerr
+	}
+
+	//verify the user has roles for the client
+	if len(roles) == 0 {
+		return nil, nil, "", common.ClientError("invalid username and/or password, or user is not assigned to the client")
+	}
+
+	return user, roles, "", common.NoError()
+}
+
+func (CoreTokenController) getEnabledUser(CRUD TokenControllerCRUD, username string) (*models.User, common.CustomError) {
+	//get the user
+	user, err := CRUD.GetUserByUsername(username)
+	if err != nil {
+		log.Println(common.ChainError("error getting user by username", err))
+		return nil, common.InternalError()
+	}
+
+	//verify the user still exists and was not disabled since logging in
+	if user == nil {
+		return nil, common.ClientError("user no longer exists")
+	}
+	if !user.Enabled {
+		return nil, common.ClientError(disabledUserMessage)
+	}
+
+	return user, common.NoError()
+}
+
+func (c CoreTokenController) getAuthorizationCodeClient(CRUD TokenControllerCRUD, clientUID uuid.UUID, redirectURI string) (*models.Client, common.CustomError) {
+	//get the client
+	client, err := CRUD.GetClientByUID(clientUID)
+	if err != nil {
+		log.Println(common.ChainError("error getting client by uid", err))
+		return nil, common.InternalError()
+	}
+
+	//verify client exists
+	if client == nil {
+		return nil, common.ClientError(fmt.Sprintf("client with id %s not found", clientUID.String()))
+	}
+
+	//id tokens are signed with the client's key, so only default token clients are supported
+	if client.TokenType != models.ClientTokenTypeDefault {
+		return nil, common.ClientError("client does not support the authorization code flow")
+	}
+
+	//verify the redirect uri matches the one registered to the client
+	if redirectURI != "" && redirectURI != client.RedirectUrl {
+		return nil, common.ClientError("redirect_uri does not match the client's redirect url")
+	}
+
+	return client, common.NoError()
+}
+
+func containsScope(scope string, target string) bool {
+	for _, s := range strings.Fields(scope) {
+		if s == target {
+			return true
+		}
+	}
+	return false
 }
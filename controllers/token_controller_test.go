@@ -4,14 +4,21 @@ import (
 	"errors"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
 	"github.com/mhogar/amber/controllers"
+	jwthelpers "github.com/mhogar/amber/controllers/jwt_helpers"
 	jwtmocks "github.com/mhogar/amber/controllers/jwt_helpers/mocks"
 	"github.com/mhogar/amber/controllers/mocks"
+	datamocks "github.com/mhogar/amber/data/mocks"
+	loadermocks "github.com/mhogar/amber/loaders/mocks"
 	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
 
 	"github.com/google/uuid"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -21,6 +28,8 @@ type TokenControllerTestSuite struct {
 	ControllerMock           mocks.Controllers
 	TokenFactorySelectorMock jwtmocks.TokenFactorySelector
 	TokenFactoryMock         jwtmocks.TokenFactory
+	IDTokenFactoryMock       jwtmocks.IDTokenFactory
+	DataLoaderMock           loadermocks.RawDataLoader
 	TokenController          controllers.CoreTokenController
 }
 
@@ -30,10 +39,14 @@ func (suite *TokenControllerTestSuite) SetupTest() {
 	suite.ControllerMock = mocks.Controllers{}
 	suite.TokenFactorySelectorMock = jwtmocks.TokenFactorySelector{}
 	suite.TokenFactoryMock = jwtmocks.TokenFactory{}
+	suite.IDTokenFactoryMock = jwtmocks.IDTokenFactory{}
+	suite.DataLoaderMock = loadermocks.RawDataLoader{}
 
 	suite.TokenController = controllers.CoreTokenController{
 		AuthController:       &suite.ControllerMock,
 		TokenFactorySelector: &suite.TokenFactorySelectorMock,
+		IDTokenFactory:       &suite.IDTokenFactoryMock,
+		DataLoader:           &suite.DataLoaderMock,
 	}
 }
 
@@ -42,7 +55,7 @@ func (suite *TokenControllerTestSuite) TestCreateTokenRedirectURL_WithErrorGetti
 	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(nil, errors.New(""))
 
 	//act
-	tokenURL, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, uuid.New(), "username", "password")
+	tokenURL, _, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, uuid.New(), controllers.UserCredentials{Username: "username", Password: "password"})
 
 	//assert
 	suite.Empty(tokenURL)
@@ -55,53 +68,104 @@ func (suite *TokenControllerTestSuite) TestCreateTokenRedirectURL_WhereClientNot
 	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(nil, nil)
 
 	//act
-	tokenURL, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, clientUID, "username", "password")
+	tokenURL, _, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, clientUID, controllers.UserCredentials{Username: "username", Password: "password"})
 
 	//assert
 	suite.Empty(tokenURL)
 	suite.CustomClientError(cerr, "client with id", clientUID.String(), "not found")
 }
 
-func (suite *TokenControllerTestSuite) TestCreateTokenRedirectURL_WithClientErrorAuthenticatingUserWithPassword_ReturnsClientError() {
+func (suite *TokenControllerTestSuite) TestCreateTokenRedirectURL_WithClientErrorAuthenticatingUser_ReturnsClientError() {
 	//arrange
 	client := models.CreateNewClient("name", "redirect.com", 0, "key.pem")
 
 	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
-	suite.ControllerMock.On("AuthenticateUserWithPassword", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.ClientError(""))
+	suite.ControllerMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(nil, "", common.ClientError("invalid username and/or password"))
 
 	//act
-	tokenURL, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, "username", "password")
+	tokenURL, _, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, controllers.UserCredentials{Username: "username", Password: "password"})
 
 	//assert
 	suite.Empty(tokenURL)
 	suite.CustomClientError(cerr, "invalid", "username", "password", "not assigned", "client")
 }
 
-func (suite *TokenControllerTestSuite) TestCreateTokenRedirectURL_WithNonClientErrorAuthenticatingUserWithPassword_ReturnsError() {
+func (suite *TokenControllerTestSuite) TestCreateTokenRedirectURL_WithNonClientErrorAuthenticatingUser_ReturnsError() {
 	//arrange
 	client := models.CreateNewClient("name", "redirect.com", 0, "key.pem")
 
 	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
-	suite.ControllerMock.On("AuthenticateUserWithPassword", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.InternalError())
+	suite.ControllerMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(nil, "", common.InternalError())
 
 	//act
-	tokenURL, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, "username", "password")
+	tokenURL, _, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, controllers.UserCredentials{Username: "username", Password: "password"})
 
 	//assert
 	suite.Empty(tokenURL)
 	suite.CustomInternalError(cerr)
 }
 
+func (suite *TokenControllerTestSuite) TestCreateTokenRedirectURL_WhereUserIsLockedOut_ReturnsError() {
+	//arrange
+	client := models.CreateNewClient("name", "redirect.com", 0, "key.pem")
+	authErr := common.ClientError("too many failed login attempts, try again in 60 seconds")
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+	suite.ControllerMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(nil, "", authErr)
+
+	//act
+	tokenURL, _, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, controllers.UserCredentials{Username: "username", Password: "password"})
+
+	//assert
+	suite.Empty(tokenURL)
+	suite.Equal(authErr, cerr)
+}
+
+func (suite *TokenControllerTestSuite) TestCreateTokenRedirectURL_WithClientErrorCompletingTwoFactorChallenge_ReturnsError() {
+	//arrange
+	client := models.CreateNewClient("name", "redirect.com", 0, "key.pem")
+	authErr := common.ClientError("invalid two-factor code")
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+	suite.ControllerMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(nil, "", authErr)
+
+	//act
+	tokenURL, _, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, controllers.UserCredentials{Challenge: "challenge", Code: "code"})
+
+	//assert
+	suite.Empty(tokenURL)
+	suite.Equal(authErr, cerr)
+}
+
+func (suite *TokenControllerTestSuite) TestCreateTokenRedirectURL_WhereTwoFactorChallengeIsReturned_ReturnsChallenge() {
+	//arrange
+	client := models.CreateNewClient("name", "redirect.com", 0, "key.pem")
+	challenge := "challenge"
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+	suite.ControllerMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(nil, challenge, common.NoError())
+
+	//act
+	tokenURL, resultChallenge, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, controllers.UserCredentials{Username: "username", Password: "password"})
+
+	//assert
+	suite.Empty(tokenURL)
+	suite.Equal(challenge, resultChallenge)
+	suite.CustomNoError(cerr)
+
+	suite.CRUDMock.AssertNotCalled(suite.T(), "GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything)
+}
+
 func (suite *TokenControllerTestSuite) TestCreateTokenRedirectURL_WithErrorGettingUserRoleByUsernameAndClientUID_ReturnsInternalError() {
 	//arrange
 	client := models.CreateNewClient("name", "redirect.com", 0, "key.pem")
 
 	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
-	suite.ControllerMock.On("AuthenticateUserWithPassword", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.NoError())
+	suite.ControllerMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(models.CreateUser("username", 0, nil), "", common.NoError())
 	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(nil, errors.New(""))
 
 	//act
-	tokenURL, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, "username", "password")
+	tokenURL, _, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, controllers.UserCredentials{Username: "username", Password: "password"})
 
 	//assert
 	suite.Empty(tokenURL)
@@ -113,29 +177,100 @@ func (suite *TokenControllerTestSuite) TestCreateTokenRedirectURL_WhereUserRoleF
 	client := models.CreateNewClient("name", "redirect.com", 0, "key.pem")
 
 	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
-	suite.ControllerMock.On("AuthenticateUserWithPassword", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.NoError())
+	suite.ControllerMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(models.CreateUser("username", 0, nil), "", common.NoError())
 	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetGroupsByUsername", mock.Anything).Return([]*models.Group{}, nil)
 
 	//act
-	tokenURL, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, "username", "password")
+	tokenURL, _, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, controllers.UserCredentials{Username: "username", Password: "password"})
 
 	//assert
 	suite.Empty(tokenURL)
 	suite.CustomClientError(cerr, "invalid", "username", "password", "not assigned", "client")
 }
 
+func (suite *TokenControllerTestSuite) TestCreateTokenRedirectURL_WithErrorGettingGroupsByUsername_ReturnsInternalError() {
+	//arrange
+	client := models.CreateNewClient("name", "redirect.com", 0, "key.pem")
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+	suite.ControllerMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(models.CreateUser("username", 0, nil), "", common.NoError())
+	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetGroupsByUsername", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	tokenURL, _, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, controllers.UserCredentials{Username: "username", Password: "password"})
+
+	//assert
+	suite.Empty(tokenURL)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TokenControllerTestSuite) TestCreateTokenRedirectURL_WithErrorGettingGroupRolesByClientUID_ReturnsInternalError() {
+	//arrange
+	client := models.CreateNewClient("name", "redirect.com", 0, "key.pem")
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+	suite.ControllerMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(models.CreateUser("username", 0, nil), "", common.NoError())
+	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetGroupsByUsername", mock.Anything).Return([]*models.Group{models.CreateGroup("group", "", 0)}, nil)
+	suite.CRUDMock.On("GetGroupRolesByClientUID", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	tokenURL, _, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, controllers.UserCredentials{Username: "username", Password: "password"})
+
+	//assert
+	suite.Empty(tokenURL)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TokenControllerTestSuite) TestCreateTokenRedirectURL_WhereUserOnlyHasGroupRoles_UsesRolesOfHighestPriorityGroup() {
+	//arrange
+	client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeFirebase, "key.json")
+	user := models.CreateUser("username", 0, nil)
+
+	groups := []*models.Group{
+		models.CreateGroup("group1", "", 1),
+		models.CreateGroup("group2", "", 5),
+	}
+	groupRoles := []*models.GroupRole{
+		models.CreateGroupRole(client.UID, "group1", "role1"),
+		models.CreateGroupRole(client.UID, "group2", "role2"),
+		models.CreateGroupRole(client.UID, "group3", "role3"),
+	}
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+	suite.ControllerMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(user, "", common.NoError())
+	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetGroupsByUsername", mock.Anything).Return(groups, nil)
+	suite.CRUDMock.On("GetGroupRolesByClientUID", mock.Anything).Return(groupRoles, nil)
+	suite.TokenFactorySelectorMock.On("Select", mock.Anything).Return(&suite.TokenFactoryMock)
+	suite.TokenFactoryMock.On("CreateToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("token", nil)
+
+	//act
+	tokenURL, _, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, controllers.UserCredentials{Username: user.Username, Password: "password"})
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.NotEmpty(tokenURL)
+
+	suite.CRUDMock.AssertCalled(suite.T(), "GetGroupsByUsername", user.Username)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetGroupRolesByClientUID", client.UID)
+	suite.TokenFactoryMock.AssertCalled(suite.T(), "CreateToken", client.KeyUri, client.UID, user, []string{"role2"})
+}
+
 func (suite *TokenControllerTestSuite) TestCreateTokenRedirectURL_WhereTokenFactoryForTokenTypeNotFound_ReturnsInternalError() {
 	//arrange
 	client := models.CreateNewClient("name", "redirect.com", 0, "key.pem")
 	userRole := models.CreateUserRole(uuid.Nil, "username", "role")
 
 	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
-	suite.ControllerMock.On("AuthenticateUserWithPassword", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.NoError())
+	suite.ControllerMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(models.CreateUser("username", 0, nil), "", common.NoError())
 	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(userRole, nil)
 	suite.TokenFactorySelectorMock.On("Select", mock.Anything).Return(nil)
 
 	//act
-	tokenURL, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, userRole.Username, "password")
+	tokenURL, _, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, controllers.UserCredentials{Username: userRole.Username, Password: "password"})
 
 	//assert
 	suite.Empty(tokenURL)
@@ -148,13 +283,13 @@ func (suite *TokenControllerTestSuite) TestCreateTokenRedirectURL_WithErrorCreat
 	userRole := models.CreateUserRole(uuid.Nil, "username", "role")
 
 	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
-	suite.ControllerMock.On("AuthenticateUserWithPassword", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.NoError())
+	suite.ControllerMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(models.CreateUser("username", 0, nil), "", common.NoError())
 	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(userRole, nil)
 	suite.TokenFactorySelectorMock.On("Select", mock.Anything).Return(&suite.TokenFactoryMock)
 	suite.TokenFactoryMock.On("CreateToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", errors.New(""))
 
 	//act
-	tokenURL, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, userRole.Username, "password")
+	tokenURL, _, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, controllers.UserCredentials{Username: userRole.Username, Password: "password"})
 
 	//assert
 	suite.Empty(tokenURL)
@@ -167,13 +302,13 @@ func (suite *TokenControllerTestSuite) TestCreateTokenRedirectURL_WithErrorParsi
 	userRole := models.CreateUserRole(uuid.Nil, "username", "role")
 
 	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
-	suite.ControllerMock.On("AuthenticateUserWithPassword", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.NoError())
+	suite.ControllerMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(models.CreateUser("username", 0, nil), "", common.NoError())
 	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(userRole, nil)
 	suite.TokenFactorySelectorMock.On("Select", mock.Anything).Return(&suite.TokenFactoryMock)
 	suite.TokenFactoryMock.On("CreateToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", nil)
 
 	//act
-	tokenURL, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, userRole.Username, "password")
+	tokenURL, _, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, controllers.UserCredentials{Username: userRole.Username, Password: "password"})
 
 	//assert
 	suite.Empty(tokenURL)
@@ -184,17 +319,18 @@ func (suite *TokenControllerTestSuite) TestCreateTokenRedirectURL_WithNoErrors_R
 	//arrange
 	client := models.CreateNewClient("name", "redirect.com", 0, "key.pem")
 	userRole := models.CreateUserRole(uuid.Nil, "username", "role")
+	user := models.CreateUser("username", 0, nil)
 	password := "password"
 	token := "this_is_the_token_value"
 
 	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
-	suite.ControllerMock.On("AuthenticateUserWithPassword", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.NoError())
+	suite.ControllerMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(user, "", common.NoError())
 	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(userRole, nil)
 	suite.TokenFactorySelectorMock.On("Select", mock.Anything).Return(&suite.TokenFactoryMock)
 	suite.TokenFactoryMock.On("CreateToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(token, nil)
 
 	//act
-	tokenURL, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, userRole.Username, password)
+	tokenURL, _, cerr := suite.TokenController.CreateTokenRedirectURL(&suite.CRUDMock, client.UID, controllers.UserCredentials{Username: userRole.Username, Password: password})
 
 	//assert
 	suite.CustomNoError(cerr)
@@ -203,12 +339,953 @@ func (suite *TokenControllerTestSuite) TestCreateTokenRedirectURL_WithNoErrors_R
 	url, err := url.Parse(tokenURL)
 	suite.Require().NoError(err)
 	suite.Equal(token, url.Query().Get("token"))
+	suite.Empty(url.Query().Get("refresh_token"))
+	suite.CRUDMock.AssertNotCalled(suite.T(), "SaveRefreshToken", mock.Anything)
+
+	suite.CRUDMock.AssertCalled(suite.T(), "GetClientByUID", client.UID)
+	suite.ControllerMock.AssertCalled(suite.T(), "AuthenticateUser", &suite.CRUDMock, controllers.UserCredentials{Username: userRole.Username, Password: password})
+	suite.CRUDMock.AssertCalled(suite.T(), "GetUserRoleByClientUIDAndUsername", client.UID, userRole.Username)
+	suite.TokenFactorySelectorMock.AssertCalled(suite.T(), "Select", client.TokenType)
+	suite.TokenFactoryMock.AssertCalled(suite.T(), "CreateToken", client.KeyUri, client.UID, user, userRole.Roles)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "GetGroupsByUsername", mock.Anything)
+}
+
+func (suite *TokenControllerTestSuite) TestGetJSONWebKeySet_WithErrorGettingClients_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetClients", models.PageQuery{}).Return(nil, errors.New(""))
+
+	//act
+	jwks, cerr := suite.TokenController.GetJSONWebKeySet(&suite.CRUDMock)
+
+	//assert
+	suite.Nil(jwks)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TokenControllerTestSuite) TestGetJSONWebKeySet_SkipsKeysThatCannotBeLoaded() {
+	//arrange
+	clients := []*models.Client{
+		models.CreateNewClient("name1", "redirect1.com", models.ClientTokenTypeDefault, "missing.pem"),
+		models.CreateNewClient("name2", "redirect2.com", models.ClientTokenTypeDefault, "invalid.pem"),
+	}
+
+	suite.CRUDMock.On("GetClients", models.PageQuery{}).Return(clients, nil)
+	suite.DataLoaderMock.On("Load", "missing.pem").Return(nil, errors.New(""))
+	suite.DataLoaderMock.On("Load", "invalid.pem").Return([]byte("invalid"), nil)
+
+	//act
+	jwks, cerr := suite.TokenController.GetJSONWebKeySet(&suite.CRUDMock)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Require().NotNil(jwks)
+	suite.Empty(jwks.Keys)
+}
+
+func (suite *TokenControllerTestSuite) TestGetJSONWebKeySet_WithNoErrors_ReturnsUniquePublicKeysOfDefaultTokenClients() {
+	//arrange
+	key1, privateKey1 := helpers.CreateRSAPrivateKey()
+	key2, privateKey2 := helpers.CreateRSAPrivateKey()
+
+	clients := []*models.Client{
+		models.CreateNewClient("name1", "redirect1.com", models.ClientTokenTypeDefault, "key1.pem"),
+		models.CreateNewClient("name2", "redirect2.com", models.ClientTokenTypeDefault, "key2.pem"),
+		models.CreateNewClient("name3", "redirect3.com", models.ClientTokenTypeDefault, "key1.pem"),
+		models.CreateNewClient("name4", "redirect4.com", models.ClientTokenTypeFirebase, "firebase.json"),
+	}
+
+	suite.CRUDMock.On("GetClients", models.PageQuery{}).Return(clients, nil)
+	suite.DataLoaderMock.On("Load", "key1.pem").Return(privateKey1, nil)
+	suite.DataLoaderMock.On("Load", "key2.pem").Return(privateKey2, nil)
+
+	//act
+	jwks, cerr := suite.TokenController.GetJSONWebKeySet(&suite.CRUDMock)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Require().NotNil(jwks)
+	suite.Equal([]jwthelpers.JWK{
+		jwthelpers.CreateJWK(&key1.PublicKey),
+		jwthelpers.CreateJWK(&key2.PublicKey),
+	}, jwks.Keys)
+
+	suite.DataLoaderMock.AssertNotCalled(suite.T(), "Load", "firebase.json")
+}
+
+func (suite *TokenControllerTestSuite) createAuthorizationRequest(clientUID uuid.UUID) controllers.AuthorizationRequest {
+	return controllers.AuthorizationRequest{
+		ClientUID:           clientUID,
+		RedirectURI:         "redirect.com",
+		Scope:               "openid profile",
+		State:               "state",
+		Nonce:               "nonce",
+		CodeChallenge:       "challenge",
+		CodeChallengeMethod: "S256",
+	}
+}
+
+func (suite *TokenControllerTestSuite) TestCreateAuthorizationCodeRedirectURL_WithInvalidRequest_ReturnsClientError() {
+	var authReq controllers.AuthorizationRequest
+	var expectedErrorMessage string
+
+	testCase := func() {
+		//act
+		codeURL, _, cerr := suite.TokenController.CreateAuthorizationCodeRedirectURL(&suite.CRUDMock, authReq, controllers.UserCredentials{Username: "username", Password: "password"})
+
+		//assert
+		suite.Empty(codeURL)
+		suite.CustomClientError(cerr, expectedErrorMessage)
+	}
+
+	authReq = suite.createAuthorizationRequest(uuid.New())
+	authReq.Scope = "profile"
+	expectedErrorMessage = "scope must include openid"
+	suite.Run("ScopeMissingOpenID", testCase)
+
+	authReq = suite.createAuthorizationRequest(uuid.New())
+	authReq.CodeChallenge = ""
+	expectedErrorMessage = "code_challenge is required"
+	suite.Run("EmptyCodeChallenge", testCase)
+
+	authReq = suite.createAuthorizationRequest(uuid.New())
+	authReq.CodeChallengeMethod = "plain"
+	expectedErrorMessage = "code_challenge_method must be S256"
+	suite.Run("UnsupportedCodeChallengeMethod", testCase)
+}
+
+func (suite *TokenControllerTestSuite) TestCreateAuthorizationCodeRedirectURL_WithErrorGettingClientByUID_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	codeURL, _, cerr := suite.TokenController.CreateAuthorizationCodeRedirectURL(&suite.CRUDMock, suite.createAuthorizationRequest(uuid.New()), controllers.UserCredentials{Username: "username", Password: "password"})
+
+	//assert
+	suite.Empty(codeURL)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TokenControllerTestSuite) TestCreateAuthorizationCodeRedirectURL_WhereClientNotFound_ReturnsClientError() {
+	//arrange
+	clientUID := uuid.New()
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(nil, nil)
+
+	//act
+	codeURL, _, cerr := suite.TokenController.CreateAuthorizationCodeRedirectURL(&suite.CRUDMock, suite.createAuthorizationRequest(clientUID), controllers.UserCredentials{Username: "username", Password: "password"})
+
+	//assert
+	suite.Empty(codeURL)
+	suite.CustomClientError(cerr, "client with id", clientUID.String(), "not found")
+}
+
+func (suite *TokenControllerTestSuite) TestCreateAuthorizationCodeRedirectURL_WhereClientDoesNotUseDefaultTokenType_ReturnsClientError() {
+	//arrange
+	client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeFirebase, "key.json")
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+
+	//act
+	codeURL, _, cerr := suite.TokenController.CreateAuthorizationCodeRedirectURL(&suite.CRUDMock, suite.createAuthorizationRequest(client.UID), controllers.UserCredentials{Username: "username", Password: "password"})
+
+	//assert
+	suite.Empty(codeURL)
+	suite.CustomClientError(cerr, "client does not support the authorization code flow")
+}
+
+func (suite *TokenControllerTestSuite) TestCreateAuthorizationCodeRedirectURL_WhereRedirectURIDoesNotMatchClient_ReturnsClientError() {
+	//arrange
+	client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeDefault, "key.pem")
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+
+	authReq := suite.createAuthorizationRequest(client.UID)
+	authReq.RedirectURI = "other.com"
+
+	//act
+	codeURL, _, cerr := suite.TokenController.CreateAuthorizationCodeRedirectURL(&suite.CRUDMock, authReq, controllers.UserCredentials{Username: "username", Password: "password"})
+
+	//assert
+	suite.Empty(codeURL)
+	suite.CustomClientError(cerr, "redirect_uri does not match")
+}
+
+func (suite *TokenControllerTestSuite) TestCreateAuthorizationCodeRedirectURL_WithClientErrorAuthenticatingUser_ReturnsClientError() {
+	//arrange
+	client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeDefault, "key.pem")
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+	suite.ControllerMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(nil, "", common.ClientError("invalid username and/or password"))
+
+	//act
+	codeURL, _, cerr := suite.TokenController.CreateAuthorizationCodeRedirectURL(&suite.CRUDMock, suite.createAuthorizationRequest(client.UID), controllers.UserCredentials{Username: "username", Password: "password"})
+
+	//assert
+	suite.Empty(codeURL)
+	suite.CustomClientError(cerr, "invalid username and/or password")
+}
+
+func (suite *TokenControllerTestSuite) TestCreateAuthorizationCodeRedirectURL_WhereTwoFactorChallengeIsReturned_ReturnsChallenge() {
+	//arrange
+	client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeDefault, "key.pem")
+	challenge := "challenge"
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+	suite.ControllerMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(nil, challenge, common.NoError())
+
+	//act
+	codeURL, resultChallenge, cerr := suite.TokenController.CreateAuthorizationCodeRedirectURL(&suite.CRUDMock, suite.createAuthorizationRequest(client.UID), controllers.UserCredentials{Username: "username", Password: "password"})
+
+	//assert
+	suite.Empty(codeURL)
+	suite.Equal(challenge, resultChallenge)
+	suite.CustomNoError(cerr)
+
+	suite.CRUDMock.AssertNotCalled(suite.T(), "SaveAuthorizationCode", mock.Anything)
+}
+
+func (suite *TokenControllerTestSuite) TestCreateAuthorizationCodeRedirectURL_WhereUserRoleForClientNotFound_ReturnsClientError() {
+	//arrange
+	client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeDefault, "key.pem")
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+	suite.ControllerMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(models.CreateUser("username", 0, nil), "", common.NoError())
+	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetGroupsByUsername", mock.Anything).Return([]*models.Group{}, nil)
+
+	//act
+	codeURL, _, cerr := suite.TokenController.CreateAuthorizationCodeRedirectURL(&suite.CRUDMock, suite.createAuthorizationRequest(client.UID), controllers.UserCredentials{Username: "username", Password: "password"})
+
+	//assert
+	suite.Empty(codeURL)
+	suite.CustomClientError(cerr, "user is not assigned to the client")
+}
+
+func (suite *TokenControllerTestSuite) TestCreateAuthorizationCodeRedirectURL_WithNonceTooLong_ReturnsClientError() {
+	//arrange
+	viper.Set("token", config.TokenConfig{})
+
+	client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeDefault, "key.pem")
+	userRole := models.CreateUserRole(client.UID, "username", "role")
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+	suite.ControllerMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(models.CreateUser("username", 0, nil), "", common.NoError())
+	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(userRole, nil)
+
+	authReq := suite.createAuthorizationRequest(client.UID)
+	authReq.Nonce = helpers.CreateStringOfLength(models.AuthorizationCodeNonceMaxLength + 1)
+
+	//act
+	codeURL, _, cerr := suite.TokenController.CreateAuthorizationCodeRedirectURL(&suite.CRUDMock, authReq, controllers.UserCredentials{Username: userRole.Username, Password: "password"})
+
+	//assert
+	suite.Empty(codeURL)
+	suite.CustomClientError(cerr, "nonce cannot be longer than")
+}
+
+func (suite *TokenControllerTestSuite) TestCreateAuthorizationCodeRedirectURL_WithErrorSavingAuthorizationCode_ReturnsInternalError() {
+	//arrange
+	viper.Set("token", config.TokenConfig{})
+
+	client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeDefault, "key.pem")
+	userRole := models.CreateUserRole(client.UID, "username", "role")
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+	suite.ControllerMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(models.CreateUser("username", 0, nil), "", common.NoError())
+	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(userRole, nil)
+	suite.CRUDMock.On("SaveAuthorizationCode", mock.Anything).Return(errors.New(""))
+
+	//act
+	codeURL, _, cerr := suite.TokenController.CreateAuthorizationCodeRedirectURL(&suite.CRUDMock, suite.createAuthorizationRequest(client.UID), controllers.UserCredentials{Username: userRole.Username, Password: "password"})
+
+	//assert
+	suite.Empty(codeURL)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TokenControllerTestSuite) TestCreateAuthorizationCodeRedirectURL_WithNoErrors_ReturnsCodeRedirectURL() {
+	//arrange
+	viper.Set("token", config.TokenConfig{
+		AuthorizationCodeLifetime: 60,
+	})
+
+	client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeDefault, "key.pem")
+	userRole := models.CreateUserRole(client.UID, "username", "role")
+	password := "password"
+	authReq := suite.createAuthorizationRequest(client.UID)
+
+	var code *models.AuthorizationCode
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+	suite.ControllerMock.On("AuthenticateUser", mock.Anything, mock.Anything).Return(models.CreateUser("username", 0, nil), "", common.NoError())
+	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(userRole, nil)
+	suite.CRUDMock.On("SaveAuthorizationCode", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		code = args.Get(0).(*models.AuthorizationCode)
+	})
+
+	//act
+	codeURL, _, cerr := suite.TokenController.CreateAuthorizationCodeRedirectURL(&suite.CRUDMock, authReq, controllers.UserCredentials{Username: userRole.Username, Password: password})
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Require().NotNil(code)
+	suite.Equal(client.UID, code.ClientUID)
+	suite.Equal(userRole.Username, code.Username)
+	suite.Equal(authReq.CodeChallenge, code.CodeChallenge)
+	suite.Equal(authReq.Nonce, code.Nonce)
+	suite.Equal(authReq.RedirectURI, code.RedirectURI)
+	suite.WithinDuration(time.Now().Add(time.Minute), code.ExpiresAt, time.Second)
+
+	url, err := url.Parse(codeURL)
+	suite.Require().NoError(err)
+	suite.Equal(code.Code.String(), url.Query().Get("code"))
+	suite.Equal(authReq.State, url.Query().Get("state"))
 
 	suite.CRUDMock.AssertCalled(suite.T(), "GetClientByUID", client.UID)
-	suite.ControllerMock.AssertCalled(suite.T(), "AuthenticateUserWithPassword", &suite.CRUDMock, userRole.Username, password)
+	suite.ControllerMock.AssertCalled(suite.T(), "AuthenticateUser", &suite.CRUDMock, controllers.UserCredentials{Username: userRole.Username, Password: password})
 	suite.CRUDMock.AssertCalled(suite.T(), "GetUserRoleByClientUIDAndUsername", client.UID, userRole.Username)
+}
+
+func (suite *TokenControllerTestSuite) TestExchangeAuthorizationCode_WithErrorGettingAuthorizationCode_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetAuthorizationCode", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	tokens, cerr := suite.TokenController.ExchangeAuthorizationCode(&suite.CRUDMock, uuid.New(), uuid.New(), "", "verifier")
+
+	//assert
+	suite.Nil(tokens)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TokenControllerTestSuite) TestExchangeAuthorizationCode_WhereAuthorizationCodeNotFound_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetAuthorizationCode", mock.Anything).Return(nil, nil)
+
+	//act
+	tokens, cerr := suite.TokenController.ExchangeAuthorizationCode(&suite.CRUDMock, uuid.New(), uuid.New(), "", "verifier")
+
+	//assert
+	suite.Nil(tokens)
+	suite.CustomClientError(cerr, "authorization code invalid or expired")
+}
+
+func (suite *TokenControllerTestSuite) TestExchangeAuthorizationCode_WithErrorDeletingAuthorizationCode_ReturnsInternalError() {
+	//arrange
+	code := models.CreateNewAuthorizationCode(uuid.New(), "username", "challenge", "", "", time.Minute)
+
+	suite.CRUDMock.On("GetAuthorizationCode", mock.Anything).Return(code, nil)
+	suite.CRUDMock.On("DeleteAuthorizationCode", mock.Anything).Return(false, errors.New(""))
+
+	//act
+	tokens, cerr := suite.TokenController.ExchangeAuthorizationCode(&suite.CRUDMock, code.ClientUID, code.Code, "", "verifier")
+
+	//assert
+	suite.Nil(tokens)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TokenControllerTestSuite) TestExchangeAuthorizationCode_WhereAuthorizationCodeWasAlreadyDeleted_ReturnsClientError() {
+	//arrange
+	code := models.CreateNewAuthorizationCode(uuid.New(), "username", "challenge", "", "", time.Minute)
+
+	suite.CRUDMock.On("GetAuthorizationCode", mock.Anything).Return(code, nil)
+	suite.CRUDMock.On("DeleteAuthorizationCode", mock.Anything).Return(false, nil)
+
+	//act
+	tokens, cerr := suite.TokenController.ExchangeAuthorizationCode(&suite.CRUDMock, code.ClientUID, code.Code, "", "verifier")
+
+	//assert
+	suite.Nil(tokens)
+	suite.CustomClientError(cerr, "authorization code invalid or expired")
+	suite.CRUDMock.AssertNotCalled(suite.T(), "GetClientByUID", mock.Anything)
+}
+
+func (suite *TokenControllerTestSuite) TestExchangeAuthorizationCode_WithInvalidAuthorizationCode_ReturnsClientError() {
+	var code *models.AuthorizationCode
+	var clientUID uuid.UUID
+	var verifier string
+	var expectedErrorMessage string
+
+	testCase := func() {
+		//arrange
+		suite.CRUDMock = datamocks.DataCRUD{}
+		suite.CRUDMock.On("GetAuthorizationCode", mock.Anything).Return(code, nil)
+		suite.CRUDMock.On("DeleteAuthorizationCode", mock.Anything).Return(true, nil)
+
+		//act
+		tokens, cerr := suite.TokenController.ExchangeAuthorizationCode(&suite.CRUDMock, clientUID, code.Code, "", verifier)
+
+		//assert
+		suite.Nil(tokens)
+		suite.CustomClientError(cerr, expectedErrorMessage)
+		suite.CRUDMock.AssertCalled(suite.T(), "DeleteAuthorizationCode", code.Code)
+	}
+
+	code = models.CreateNewAuthorizationCode(uuid.New(), "username", "", "", "", -time.Minute)
+	clientUID = code.ClientUID
+	expectedErrorMessage = "authorization code invalid or expired"
+	suite.Run("ExpiredCode", testCase)
+
+	code = models.CreateNewAuthorizationCode(uuid.New(), "username", "", "", "", time.Minute)
+	clientUID = uuid.New()
+	expectedErrorMessage = "authorization code invalid or expired"
+	suite.Run("DifferentClient", testCase)
+
+	code = models.CreateNewAuthorizationCode(uuid.New(), "username", "challenge", "", "", time.Minute)
+	clientUID = code.ClientUID
+	verifier = "verifier"
+	expectedErrorMessage = "code_verifier does not match the code_challenge"
+	suite.Run("InvalidCodeVerifier", testCase)
+}
+
+func (suite *TokenControllerTestSuite) createExchangeableAuthorizationCode(clientUID uuid.UUID) (*models.AuthorizationCode, string) {
+	verifier := "abcdefghijklmnopqrstuvwxyz-0123456789-ABCDEFGHIJ"
+	code := models.CreateNewAuthorizationCode(clientUID, "username", "f4GlO33Knmhaikoyah1U7W_9AIdhM7QWrRd_ACqg6Yo", "nonce", "", time.Minute)
+
+	suite.CRUDMock.On("GetAuthorizationCode", mock.Anything).Return(code, nil)
+	suite.CRUDMock.On("DeleteAuthorizationCode", mock.Anything).Return(true, nil)
+
+	return code, verifier
+}
+
+func (suite *TokenControllerTestSuite) TestExchangeAuthorizationCode_WhereRedirectURIDoesNotMatchClient_ReturnsClientError() {
+	//arrange
+	client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeDefault, "key.pem")
+	code, verifier := suite.createExchangeableAuthorizationCode(client.UID)
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+
+	//act
+	tokens, cerr := suite.TokenController.ExchangeAuthorizationCode(&suite.CRUDMock, client.UID, code.Code, "other.com", verifier)
+
+	//assert
+	suite.Nil(tokens)
+	suite.CustomClientError(cerr, "redirect_uri does not match")
+}
+
+func (suite *TokenControllerTestSuite) TestExchangeAuthorizationCode_WhereRedirectURIDoesNotMatchAuthorizationRequest_ReturnsClientError() {
+	var redirectURI string
+
+	testCase := func() {
+		//arrange
+		suite.CRUDMock = datamocks.DataCRUD{}
+
+		client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeDefault, "key.pem")
+		code, verifier := suite.createExchangeableAuthorizationCode(client.UID)
+		code.RedirectURI = client.RedirectUrl
+
+		//act
+		tokens, cerr := suite.TokenController.ExchangeAuthorizationCode(&suite.CRUDMock, client.UID, code.Code, redirectURI, verifier)
+
+		//assert
+		suite.Nil(tokens)
+		suite.CustomClientError(cerr, "redirect_uri does not match the one used to request the authorization code")
+	}
+
+	redirectURI = ""
+	suite.Run("Missing", testCase)
+
+	redirectURI = "other.com"
+	suite.Run("Different", testCase)
+}
+
+func (suite *TokenControllerTestSuite) TestExchangeAuthorizationCode_WithErrorGettingUser_ReturnsInternalError() {
+	//arrange
+	client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeDefault, "key.pem")
+	code, verifier := suite.createExchangeableAuthorizationCode(client.UID)
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	tokens, cerr := suite.TokenController.ExchangeAuthorizationCode(&suite.CRUDMock, client.UID, code.Code, "", verifier)
+
+	//assert
+	suite.Nil(tokens)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TokenControllerTestSuite) TestExchangeAuthorizationCode_WithUnavailableUser_ReturnsClientError() {
+	var user *models.User
+	var expectedMessage string
+
+	testCase := func() {
+		//arrange
+		suite.SetupTest()
+
+		client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeDefault, "key.pem")
+		code, verifier := suite.createExchangeableAuthorizationCode(client.UID)
+		suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+		suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(user, nil)
+
+		//act
+		tokens, cerr := suite.TokenController.ExchangeAuthorizationCode(&suite.CRUDMock, client.UID, code.Code, "", verifier)
+
+		//assert
+		suite.Nil(tokens)
+		suite.CustomClientError(cerr, expectedMessage)
+		suite.TokenFactorySelectorMock.AssertNotCalled(suite.T(), "Select", mock.Anything)
+	}
+
+	user = nil
+	expectedMessage = "user no longer exists"
+	suite.Run("UserNotFound", testCase)
+
+	user = models.CreateUser("username", 0, nil)
+	user.Enabled = false
+	expectedMessage = "user is disabled"
+	suite.Run("UserDisabled", testCase)
+}
+
+func (suite *TokenControllerTestSuite) TestExchangeAuthorizationCode_WhereUserRoleNoLongerExists_ReturnsClientError() {
+	//arrange
+	client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeDefault, "key.pem")
+	code, verifier := suite.createExchangeableAuthorizationCode(client.UID)
+	user := models.CreateUser(code.Username, 0, nil)
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(user, nil)
+	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetGroupsByUsername", mock.Anything).Return([]*models.Group{}, nil)
+
+	//act
+	tokens, cerr := suite.TokenController.ExchangeAuthorizationCode(&suite.CRUDMock, client.UID, code.Code, "", verifier)
+
+	//assert
+	suite.Nil(tokens)
+	suite.CustomClientError(cerr, "user is no longer assigned to the client")
+}
+
+func (suite *TokenControllerTestSuite) TestExchangeAuthorizationCode_WithErrorCreatingIDToken_ReturnsInternalError() {
+	//arrange
+	client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeDefault, "key.pem")
+	code, verifier := suite.createExchangeableAuthorizationCode(client.UID)
+	user := models.CreateUser(code.Username, 0, nil)
+	userRole := models.CreateUserRole(client.UID, code.Username, "role")
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(user, nil)
+	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(userRole, nil)
+	suite.TokenFactorySelectorMock.On("Select", mock.Anything).Return(&suite.TokenFactoryMock)
+	suite.TokenFactoryMock.On("CreateToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("access_token", nil)
+	suite.IDTokenFactoryMock.On("CreateIDToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", errors.New(""))
+
+	//act
+	tokens, cerr := suite.TokenController.ExchangeAuthorizationCode(&suite.CRUDMock, client.UID, code.Code, "", verifier)
+
+	//assert
+	suite.Nil(tokens)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TokenControllerTestSuite) TestExchangeAuthorizationCode_WithNoErrors_ReturnsTokens() {
+	//arrange
+	client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeDefault, "key.pem")
+	code, verifier := suite.createExchangeableAuthorizationCode(client.UID)
+	code.RedirectURI = client.RedirectUrl
+	user := models.CreateUser(code.Username, 0, nil)
+	userRole := models.CreateUserRole(client.UID, code.Username, "role")
+	accessToken := "this_is_the_access_token"
+	idToken := "this_is_the_id_token"
+
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(user, nil)
+	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(userRole, nil)
+	suite.TokenFactorySelectorMock.On("Select", mock.Anything).Return(&suite.TokenFactoryMock)
+	suite.TokenFactoryMock.On("CreateToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(accessToken, nil)
+	suite.IDTokenFactoryMock.On("CreateIDToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(idToken, nil)
+
+	var refreshToken *models.RefreshToken
+	suite.CRUDMock.On("SaveRefreshToken", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		refreshToken = args.Get(0).(*models.RefreshToken)
+	})
+
+	//act
+	tokens, cerr := suite.TokenController.ExchangeAuthorizationCode(&suite.CRUDMock, client.UID, code.Code, client.RedirectUrl, verifier)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Require().NotNil(tokens)
+	suite.Equal(accessToken, tokens.AccessToken)
+	suite.Equal(idToken, tokens.IDToken)
+
+	suite.Require().NotNil(refreshToken)
+	suite.Equal(refreshToken.Token.String(), tokens.RefreshToken)
+	suite.Equal(client.UID, refreshToken.ClientUID)
+	suite.Equal(code.Username, refreshToken.Username)
+
+	suite.CRUDMock.AssertCalled(suite.T(), "GetAuthorizationCode", code.Code)
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteAuthorizationCode", code.Code)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetUserByUsername", code.Username)
+	suite.TokenFactoryMock.AssertCalled(suite.T(), "CreateToken", client.KeyUri, client.UID, user, userRole.Roles)
+	suite.IDTokenFactoryMock.AssertCalled(suite.T(), "CreateIDToken", client.KeyUri, client.UID, user, userRole.Roles, code.Nonce)
+}
+
+func (suite *TokenControllerTestSuite) TestRedeemRefreshToken_WithErrorGettingRefreshToken_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetRefreshToken", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	tokens, cerr := suite.TokenController.RedeemRefreshToken(&suite.CRUDMock, uuid.New(), uuid.New())
+
+	//assert
+	suite.Nil(tokens)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TokenControllerTestSuite) TestRedeemRefreshToken_WithInvalidRefreshToken_ReturnsClientError() {
+	var refreshToken *models.RefreshToken
+	var clientUID uuid.UUID
+
+	testCase := func() {
+		//arrange
+		suite.SetupTest()
+		suite.CRUDMock.On("GetRefreshToken", mock.Anything).Return(refreshToken, nil)
+
+		//act
+		tokens, cerr := suite.TokenController.RedeemRefreshToken(&suite.CRUDMock, clientUID, uuid.New())
+
+		//assert
+		suite.Nil(tokens)
+		suite.CustomClientError(cerr, "refresh token invalid or expired")
+		suite.CRUDMock.AssertNotCalled(suite.T(), "RotateRefreshToken", mock.Anything)
+	}
+
+	clientUID = uuid.New()
+
+	refreshToken = nil
+	suite.Run("RefreshTokenNotFound", testCase)
+
+	refreshToken = models.CreateNewRefreshToken(uuid.New(), uuid.New(), "username", time.Hour)
+	suite.Run("ClientMismatch", testCase)
+
+	refreshToken = models.CreateNewRefreshToken(uuid.New(), clientUID, "username", -time.Second)
+	suite.Run("Expired", testCase)
+}
+
+func (suite *TokenControllerTestSuite) TestRedeemRefreshToken_WhereRefreshTokenWasAlreadyRotated_RevokesFamilyAndReturnsClientError() {
+	//arrange
+	refreshToken := models.CreateNewRefreshToken(uuid.New(), uuid.New(), "username", time.Hour)
+	refreshToken.Rotated = true
+
+	suite.CRUDMock.On("GetRefreshToken", mock.Anything).Return(refreshToken, nil)
+	suite.CRUDMock.On("DeleteRefreshTokenFamily", mock.Anything).Return(nil)
+
+	//act
+	tokens, cerr := suite.TokenController.RedeemRefreshToken(&suite.CRUDMock, refreshToken.ClientUID, refreshToken.Token)
+
+	//assert
+	suite.Nil(tokens)
+	suite.CustomClientError(cerr, "refresh token has already been used")
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteRefreshTokenFamily", refreshToken.FamilyID)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "RotateRefreshToken", mock.Anything)
+}
+
+func (suite *TokenControllerTestSuite) TestRedeemRefreshToken_WithErrorRevokingRefreshTokenFamily_ReturnsInternalError() {
+	//arrange
+	refreshToken := models.CreateNewRefreshToken(uuid.New(), uuid.New(), "username", time.Hour)
+	refreshToken.Rotated = true
+
+	suite.CRUDMock.On("GetRefreshToken", mock.Anything).Return(refreshToken, nil)
+	suite.CRUDMock.On("DeleteRefreshTokenFamily", mock.Anything).Return(errors.New(""))
+
+	//act
+	tokens, cerr := suite.TokenController.RedeemRefreshToken(&suite.CRUDMock, refreshToken.ClientUID, refreshToken.Token)
+
+	//assert
+	suite.Nil(tokens)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TokenControllerTestSuite) TestRedeemRefreshToken_WithErrorRotatingRefreshToken_ReturnsInternalError() {
+	//arrange
+	refreshToken := models.CreateNewRefreshToken(uuid.New(), uuid.New(), "username", time.Hour)
+
+	suite.CRUDMock.On("GetRefreshToken", mock.Anything).Return(refreshToken, nil)
+	suite.CRUDMock.On("RotateRefreshToken", mock.Anything).Return(false, errors.New(""))
+
+	//act
+	tokens, cerr := suite.TokenController.RedeemRefreshToken(&suite.CRUDMock, refreshToken.ClientUID, refreshToken.Token)
+
+	//assert
+	suite.Nil(tokens)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TokenControllerTestSuite) TestRedeemRefreshToken_WhereRefreshTokenIsRotatedConcurrently_RevokesFamilyAndReturnsClientError() {
+	//arrange
+	refreshToken := models.CreateNewRefreshToken(uuid.New(), uuid.New(), "username", time.Hour)
+
+	suite.CRUDMock.On("GetRefreshToken", mock.Anything).Return(refreshToken, nil)
+	suite.CRUDMock.On("RotateRefreshToken", mock.Anything).Return(false, nil)
+	suite.CRUDMock.On("DeleteRefreshTokenFamily", mock.Anything).Return(nil)
+
+	//act
+	tokens, cerr := suite.TokenController.RedeemRefreshToken(&suite.CRUDMock, refreshToken.ClientUID, refreshToken.Token)
+
+	//assert
+	suite.Nil(tokens)
+	suite.CustomClientError(cerr, "refresh token has already been used")
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteRefreshTokenFamily", refreshToken.FamilyID)
+}
+
+func (suite *TokenControllerTestSuite) TestRedeemRefreshToken_WithErrorGettingClient_ReturnsInternalError() {
+	//arrange
+	refreshToken := models.CreateNewRefreshToken(uuid.New(), uuid.New(), "username", time.Hour)
+
+	suite.CRUDMock.On("GetRefreshToken", mock.Anything).Return(refreshToken, nil)
+	suite.CRUDMock.On("RotateRefreshToken", mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	tokens, cerr := suite.TokenController.RedeemRefreshToken(&suite.CRUDMock, refreshToken.ClientUID, refreshToken.Token)
+
+	//assert
+	suite.Nil(tokens)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TokenControllerTestSuite) TestRedeemRefreshToken_WhereClientNotFound_ReturnsClientError() {
+	//arrange
+	refreshToken := models.CreateNewRefreshToken(uuid.New(), uuid.New(), "username", time.Hour)
+
+	suite.CRUDMock.On("GetRefreshToken", mock.Anything).Return(refreshToken, nil)
+	suite.CRUDMock.On("RotateRefreshToken", mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(nil, nil)
+
+	//act
+	tokens, cerr := suite.TokenController.RedeemRefreshToken(&suite.CRUDMock, refreshToken.ClientUID, refreshToken.Token)
+
+	//assert
+	suite.Nil(tokens)
+	suite.CustomClientError(cerr, "client with id", refreshToken.ClientUID.String(), "not found")
+}
+
+func (suite *TokenControllerTestSuite) TestRedeemRefreshToken_WithErrorGettingUser_ReturnsInternalError() {
+	//arrange
+	client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeDefault, "key.pem")
+	refreshToken := models.CreateNewRefreshToken(uuid.New(), client.UID, "username", time.Hour)
+
+	suite.CRUDMock.On("GetRefreshToken", mock.Anything).Return(refreshToken, nil)
+	suite.CRUDMock.On("RotateRefreshToken", mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	tokens, cerr := suite.TokenController.RedeemRefreshToken(&suite.CRUDMock, client.UID, refreshToken.Token)
+
+	//assert
+	suite.Nil(tokens)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TokenControllerTestSuite) TestRedeemRefreshToken_WithUnavailableUser_ReturnsClientError() {
+	var user *models.User
+	var expectedMessage string
+
+	testCase := func() {
+		//arrange
+		suite.SetupTest()
+
+		client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeDefault, "key.pem")
+		refreshToken := models.CreateNewRefreshToken(uuid.New(), client.UID, "username", time.Hour)
+
+		suite.CRUDMock.On("GetRefreshToken", mock.Anything).Return(refreshToken, nil)
+		suite.CRUDMock.On("RotateRefreshToken", mock.Anything).Return(true, nil)
+		suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+		suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(user, nil)
+
+		//act
+		tokens, cerr := suite.TokenController.RedeemRefreshToken(&suite.CRUDMock, client.UID, refreshToken.Token)
+
+		//assert
+		suite.Nil(tokens)
+		suite.CustomClientError(cerr, expectedMessage)
+		suite.CRUDMock.AssertNotCalled(suite.T(), "SaveRefreshToken", mock.Anything)
+	}
+
+	user = nil
+	expectedMessage = "user no longer exists"
+	suite.Run("UserNotFound", testCase)
+
+	user = models.CreateUser("username", 0, nil)
+	user.Enabled = false
+	expectedMessage = "user is disabled"
+	suite.Run("UserDisabled", testCase)
+}
+
+func (suite *TokenControllerTestSuite) TestRedeemRefreshToken_WhereUserRoleNotFound_ReturnsClientError() {
+	//arrange
+	client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeDefault, "key.pem")
+	refreshToken := models.CreateNewRefreshToken(uuid.New(), client.UID, "username", time.Hour)
+	user := models.CreateUser(refreshToken.Username, 0, nil)
+
+	suite.CRUDMock.On("GetRefreshToken", mock.Anything).Return(refreshToken, nil)
+	suite.CRUDMock.On("RotateRefreshToken", mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(user, nil)
+	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetGroupsByUsername", mock.Anything).Return([]*models.Group{}, nil)
+
+	//act
+	tokens, cerr := suite.TokenController.RedeemRefreshToken(&suite.CRUDMock, client.UID, refreshToken.Token)
+
+	//assert
+	suite.Nil(tokens)
+	suite.CustomClientError(cerr, "user is no longer assigned to the client")
+}
+
+func (suite *TokenControllerTestSuite) TestRedeemRefreshToken_WithErrorCreatingAccessToken_ReturnsInternalError() {
+	//arrange
+	client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeDefault, "key.pem")
+	refreshToken := models.CreateNewRefreshToken(uuid.New(), client.UID, "username", time.Hour)
+	user := models.CreateUser(refreshToken.Username, 0, nil)
+	userRole := models.CreateUserRole(client.UID, refreshToken.Username, "role")
+
+	suite.CRUDMock.On("GetRefreshToken", mock.Anything).Return(refreshToken, nil)
+	suite.CRUDMock.On("RotateRefreshToken", mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(user, nil)
+	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(userRole, nil)
+	suite.TokenFactorySelectorMock.On("Select", mock.Anything).Return(&suite.TokenFactoryMock)
+	suite.TokenFactoryMock.On("CreateToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", errors.New(""))
+
+	//act
+	tokens, cerr := suite.TokenController.RedeemRefreshToken(&suite.CRUDMock, client.UID, refreshToken.Token)
+
+	//assert
+	suite.Nil(tokens)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TokenControllerTestSuite) TestRedeemRefreshToken_WithErrorSavingRefreshToken_ReturnsInternalError() {
+	//arrange
+	client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeDefault, "key.pem")
+	refreshToken := models.CreateNewRefreshToken(uuid.New(), client.UID, "username", time.Hour)
+	user := models.CreateUser(refreshToken.Username, 0, nil)
+	userRole := models.CreateUserRole(client.UID, refreshToken.Username, "role")
+
+	suite.CRUDMock.On("GetRefreshToken", mock.Anything).Return(refreshToken, nil)
+	suite.CRUDMock.On("RotateRefreshToken", mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(user, nil)
+	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(userRole, nil)
+	suite.TokenFactorySelectorMock.On("Select", mock.Anything).Return(&suite.TokenFactoryMock)
+	suite.TokenFactoryMock.On("CreateToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("token", nil)
+	suite.CRUDMock.On("SaveRefreshToken", mock.Anything).Return(errors.New(""))
+
+	//act
+	tokens, cerr := suite.TokenController.RedeemRefreshToken(&suite.CRUDMock, client.UID, refreshToken.Token)
+
+	//assert
+	suite.Nil(tokens)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TokenControllerTestSuite) TestRedeemRefreshToken_WithNoErrors_ReturnsTokensAndRotatesRefreshToken() {
+	//arrange
+	client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeDefault, "key.pem")
+	refreshToken := models.CreateNewRefreshToken(uuid.New(), client.UID, "username", time.Hour)
+	user := models.CreateUser(refreshToken.Username, 0, nil)
+	userRole := models.CreateUserRole(client.UID, refreshToken.Username, "role")
+	accessToken := "this_is_the_access_token"
+
+	suite.CRUDMock.On("GetRefreshToken", mock.Anything).Return(refreshToken, nil)
+	suite.CRUDMock.On("RotateRefreshToken", mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("GetClientByUID", mock.Anything).Return(client, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(user, nil)
+	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(userRole, nil)
+	suite.TokenFactorySelectorMock.On("Select", mock.Anything).Return(&suite.TokenFactoryMock)
+	suite.TokenFactoryMock.On("CreateToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(accessToken, nil)
+
+	var newRefreshToken *models.RefreshToken
+	suite.CRUDMock.On("SaveRefreshToken", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		newRefreshToken = args.Get(0).(*models.RefreshToken)
+	})
+
+	//act
+	tokens, cerr := suite.TokenController.RedeemRefreshToken(&suite.CRUDMock, client.UID, refreshToken.Token)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Require().NotNil(tokens)
+	suite.Equal(accessToken, tokens.AccessToken)
+
+	suite.Require().NotNil(newRefreshToken)
+	suite.Equal(newRefreshToken.Token.String(), tokens.RefreshToken)
+	suite.NotEqual(refreshToken.Token, newRefreshToken.Token)
+	suite.Equal(refreshToken.FamilyID, newRefreshToken.FamilyID)
+
+	suite.CRUDMock.AssertCalled(suite.T(), "RotateRefreshToken", refreshToken.Token)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetUserByUsername", refreshToken.Username)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetUserRoleByClientUIDAndUsername", client.UID, refreshToken.Username)
+	suite.TokenFactoryMock.AssertCalled(suite.T(), "CreateToken", client.KeyUri, client.UID, user, userRole.Roles)
+}
+
+func (suite *TokenControllerTestSuite) TestCreateClientCredentialsToken_WithClientErrorAuthenticatingClient_ReturnsClientError() {
+	//arrange
+	message := "authenticate client error"
+	suite.ControllerMock.On("AuthenticateClientWithSecret", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
+
+	//act
+	token, cerr := suite.TokenController.CreateClientCredentialsToken(&suite.CRUDMock, uuid.New(), "secret")
+
+	//assert
+	suite.Empty(token)
+	suite.CustomClientError(cerr, message)
+}
+
+func (suite *TokenControllerTestSuite) TestCreateClientCredentialsToken_WithInternalErrorAuthenticatingClient_ReturnsInternalError() {
+	//arrange
+	suite.ControllerMock.On("AuthenticateClientWithSecret", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.InternalError())
+
+	//act
+	token, cerr := suite.TokenController.CreateClientCredentialsToken(&suite.CRUDMock, uuid.New(), "secret")
+
+	//assert
+	suite.Empty(token)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TokenControllerTestSuite) TestCreateClientCredentialsToken_WhereTokenFactoryIsNotFound_ReturnsInternalError() {
+	//arrange
+	suite.ControllerMock.On("AuthenticateClientWithSecret", mock.Anything, mock.Anything, mock.Anything).Return(&models.Client{}, common.NoError())
+	suite.TokenFactorySelectorMock.On("Select", mock.Anything).Return(nil)
+
+	//act
+	token, cerr := suite.TokenController.CreateClientCredentialsToken(&suite.CRUDMock, uuid.New(), "secret")
+
+	//assert
+	suite.Empty(token)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TokenControllerTestSuite) TestCreateClientCredentialsToken_WithErrorCreatingClientToken_ReturnsInternalError() {
+	//arrange
+	suite.ControllerMock.On("AuthenticateClientWithSecret", mock.Anything, mock.Anything, mock.Anything).Return(&models.Client{}, common.NoError())
+	suite.TokenFactorySelectorMock.On("Select", mock.Anything).Return(&suite.TokenFactoryMock)
+	suite.TokenFactoryMock.On("CreateClientToken", mock.Anything, mock.Anything).Return("", errors.New(""))
+
+	//act
+	token, cerr := suite.TokenController.CreateClientCredentialsToken(&suite.CRUDMock, uuid.New(), "secret")
+
+	//assert
+	suite.Empty(token)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TokenControllerTestSuite) TestCreateClientCredentialsToken_WithNoErrors_ReturnsToken() {
+	//arrange
+	secret := "secret"
+	client := models.CreateNewClient("name", "redirect.com", models.ClientTokenTypeFirebase, "key.json")
+	token := "token"
+
+	suite.ControllerMock.On("AuthenticateClientWithSecret", mock.Anything, mock.Anything, mock.Anything).Return(client, common.NoError())
+	suite.TokenFactorySelectorMock.On("Select", mock.Anything).Return(&suite.TokenFactoryMock)
+	suite.TokenFactoryMock.On("CreateClientToken", mock.Anything, mock.Anything).Return(token, nil)
+
+	//act
+	resultToken, cerr := suite.TokenController.CreateClientCredentialsToken(&suite.CRUDMock, client.UID, secret)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Equal(token, resultToken)
+
+	suite.ControllerMock.AssertCalled(suite.T(), "AuthenticateClientWithSecret", &suite.CRUDMock, client.UID, secret)
 	suite.TokenFactorySelectorMock.AssertCalled(suite.T(), "Select", client.TokenType)
-	suite.TokenFactoryMock.AssertCalled(suite.T(), "CreateToken", client.KeyUri, client.UID, userRole.Username, userRole.Role)
+	suite.TokenFactoryMock.AssertCalled(suite.T(), "CreateClientToken", client.KeyUri, client.UID)
 }
 
 func TestTokenControllerTestSuite(t *testing.T) {
@@ -0,0 +1,72 @@
+package totphelpers
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
+)
+
+// AESEncryptor encrypts data with AES-GCM using the two-factor encryption key from the config.
+// The random nonce is prepended to the cipher text.
+type AESEncryptor struct{}
+
+func (e AESEncryptor) Encrypt(plaintext []byte) ([]byte, error) {
+	gcm, err := e.createGCM()
+	if err != nil {
+		return nil, err
+	}
+
+	//generate the nonce
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, common.ChainError("error generating nonce", err)
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (e AESEncryptor) Decrypt(ciphertext []byte) ([]byte, error) {
+	gcm, err := e.createGCM()
+	if err != nil {
+		return nil, err
+	}
+
+	//split off the nonce
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("cipher text is too short")
+	}
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, common.ChainError("error opening cipher text", err)
+	}
+
+	return plaintext, nil
+}
+
+func (AESEncryptor) createGCM() (cipher.AEAD, error) {
+	//decode the key
+	key, err := base64.StdEncoding.DecodeString(config.GetTwoFactorConfig().EncryptionKey)
+	if err != nil {
+		return nil, common.ChainError("error decoding encryption key", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, common.ChainError("error creating aes cipher", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, common.ChainError("error creating gcm", err)
+	}
+
+	return gcm, nil
+}
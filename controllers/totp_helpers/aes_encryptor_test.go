@@ -0,0 +1,98 @@
+package totphelpers_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/mhogar/amber/config"
+	totphelpers "github.com/mhogar/amber/controllers/totp_helpers"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+)
+
+type AESEncryptorTestSuite struct {
+	helpers.CustomSuite
+	Encryptor totphelpers.AESEncryptor
+}
+
+func (suite *AESEncryptorTestSuite) SetupTest() {
+	suite.setEncryptionKey(base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	suite.Encryptor = totphelpers.AESEncryptor{}
+}
+
+func (AESEncryptorTestSuite) setEncryptionKey(key string) {
+	viper.Set("two_factor", config.TwoFactorConfig{
+		EncryptionKey: key,
+	})
+}
+
+func (suite *AESEncryptorTestSuite) TestEncrypt_WithInvalidKey_ReturnsError() {
+	var key string
+	var expectedErrorMessage string
+
+	testCase := func() {
+		//arrange
+		suite.setEncryptionKey(key)
+
+		//act
+		ciphertext, err := suite.Encryptor.Encrypt([]byte("plaintext"))
+
+		//assert
+		suite.Nil(ciphertext)
+		suite.ContainsSubstrings(err.Error(), expectedErrorMessage)
+	}
+
+	key = "not base64"
+	expectedErrorMessage = "error decoding encryption key"
+	suite.Run("KeyIsNotBase64", testCase)
+
+	key = base64.StdEncoding.EncodeToString([]byte("short"))
+	expectedErrorMessage = "error creating aes cipher"
+	suite.Run("KeyIsWrongLength", testCase)
+}
+
+func (suite *AESEncryptorTestSuite) TestDecrypt_WithTooShortCipherText_ReturnsError() {
+	//act
+	plaintext, err := suite.Encryptor.Decrypt([]byte("short"))
+
+	//assert
+	suite.Nil(plaintext)
+	suite.ContainsSubstrings(err.Error(), "too short")
+}
+
+func (suite *AESEncryptorTestSuite) TestDecrypt_WithTamperedCipherText_ReturnsError() {
+	//arrange
+	ciphertext, err := suite.Encryptor.Encrypt([]byte("plaintext"))
+	suite.Require().NoError(err)
+
+	ciphertext[len(ciphertext)-1] ^= 0xff
+
+	//act
+	plaintext, err := suite.Encryptor.Decrypt(ciphertext)
+
+	//assert
+	suite.Nil(plaintext)
+	suite.ContainsSubstrings(err.Error(), "error opening cipher text")
+}
+
+func (suite *AESEncryptorTestSuite) TestEncrypt_CanBeDecrypted() {
+	//arrange
+	plaintext := []byte("plaintext")
+
+	//act
+	ciphertext, err := suite.Encryptor.Encrypt(plaintext)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.NotEqual(plaintext, ciphertext)
+
+	result, err := suite.Encryptor.Decrypt(ciphertext)
+	suite.Require().NoError(err)
+	suite.Equal(plaintext, result)
+}
+
+func TestAESEncryptorTestSuite(t *testing.T) {
+	suite.Run(t, &AESEncryptorTestSuite{})
+}
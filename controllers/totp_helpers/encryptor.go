@@ -0,0 +1,11 @@
+package totphelpers
+
+type Encryptor interface {
+	// Encrypt encrypts and authenticates the plain text.
+	// Returns the cipher text and any errors.
+	Encrypt(plaintext []byte) ([]byte, error)
+
+	// Decrypt decrypts the cipher text, verifying it has not been tampered with.
+	// Returns the plain text and any errors.
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
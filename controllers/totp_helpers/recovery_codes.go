@@ -0,0 +1,43 @@
+package totphelpers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"strings"
+
+	"github.com/mhogar/amber/common"
+)
+
+// RecoveryCodeNumBytes is the number of random bytes in a recovery code.
+// Codes are high entropy, so a fast hash is enough to protect them at rest.
+const RecoveryCodeNumBytes = 10
+
+// GenerateRecoveryCode generates a new random recovery code, grouped into blocks of four characters for readability.
+// Returns the code and any errors.
+func GenerateRecoveryCode() (string, error) {
+	bytes := make([]byte, RecoveryCodeNumBytes)
+
+	_, err := rand.Read(bytes)
+	if err != nil {
+		return "", common.ChainError("error generating random bytes", err)
+	}
+	code := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(bytes)
+
+	//split the code into groups
+	groups := []string{}
+	for i := 0; i < len(code); i += 4 {
+		groups = append(groups, code[i:i+4])
+	}
+
+	return strings.Join(groups, "-"), nil
+}
+
+// HashRecoveryCode hashes the recovery code, ignoring case and any dashes or spaces separating the groups.
+func HashRecoveryCode(code string) []byte {
+	code = strings.ToUpper(code)
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+
+	hash := sha256.Sum256([]byte(code))
+	return hash[:]
+}
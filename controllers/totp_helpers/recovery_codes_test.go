@@ -0,0 +1,50 @@
+package totphelpers_test
+
+import (
+	"strings"
+	"testing"
+
+	totphelpers "github.com/mhogar/amber/controllers/totp_helpers"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RecoveryCodesTestSuite struct {
+	helpers.CustomSuite
+}
+
+func (suite *RecoveryCodesTestSuite) TestGenerateRecoveryCode_GeneratesRandomGroupedCode() {
+	//act
+	code1, err1 := totphelpers.GenerateRecoveryCode()
+	code2, err2 := totphelpers.GenerateRecoveryCode()
+
+	//assert
+	suite.Require().NoError(err1)
+	suite.Require().NoError(err2)
+	suite.NotEqual(code1, code2)
+	suite.Regexp("^[A-Z2-7]{4}(-[A-Z2-7]{4})+$", code1)
+}
+
+func (suite *RecoveryCodesTestSuite) TestHashRecoveryCode_IgnoresCaseAndSeparators() {
+	//arrange
+	code, err := totphelpers.GenerateRecoveryCode()
+	suite.Require().NoError(err)
+
+	expectedHash := totphelpers.HashRecoveryCode(code)
+
+	//act
+	hash1 := totphelpers.HashRecoveryCode(strings.ToLower(code))
+	hash2 := totphelpers.HashRecoveryCode(strings.ReplaceAll(code, "-", " "))
+	hash3 := totphelpers.HashRecoveryCode(strings.ReplaceAll(code, "-", ""))
+
+	//assert
+	suite.Equal(expectedHash, hash1)
+	suite.Equal(expectedHash, hash2)
+	suite.Equal(expectedHash, hash3)
+	suite.NotEqual(expectedHash, totphelpers.HashRecoveryCode("other"))
+}
+
+func TestRecoveryCodesTestSuite(t *testing.T) {
+	suite.Run(t, &RecoveryCodesTestSuite{})
+}
@@ -0,0 +1,101 @@
+package totphelpers
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/mhogar/amber/common"
+)
+
+const (
+	// TOTPSecretNumBytes is the number of random bytes in a generated secret (the size recommended for HMAC-SHA1).
+	TOTPSecretNumBytes = 20
+
+	// TOTPDigits is the number of digits in a code.
+	TOTPDigits = 6
+
+	// TOTPPeriod is the length of time in seconds each code is valid for.
+	TOTPPeriod = 30
+
+	// TOTPSkew is the number of periods before and after the current one that are also accepted, to allow for clock drift.
+	TOTPSkew = 1
+)
+
+// RFC6238TOTPGenerator generates HMAC-SHA1 codes as described in RFC 6238, the format supported by most authenticator apps.
+type RFC6238TOTPGenerator struct{}
+
+func (RFC6238TOTPGenerator) GenerateSecret() ([]byte, error) {
+	secret := make([]byte, TOTPSecretNumBytes)
+
+	_, err := rand.Read(secret)
+	if err != nil {
+		return nil, common.ChainError("error generating random bytes", err)
+	}
+
+	return secret, nil
+}
+
+func (RFC6238TOTPGenerator) ValidateCode(secret []byte, code string) (int64, bool) {
+	counter := time.Now().Unix() / TOTPPeriod
+
+	//check the latest step first, so a code that happens to match more than one returns the latest
+	for i := TOTPSkew; i >= -TOTPSkew; i-- {
+		step := counter + int64(i)
+		expected := GenerateCode(secret, step)
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+func (RFC6238TOTPGenerator) CreateProvisioningURI(issuer string, account string, secret []byte) string {
+	q := url.Values{}
+	q.Set("secret", EncodeSecret(secret))
+	q.Set("issuer", issuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", fmt.Sprint(TOTPDigits))
+	q.Set("period", fmt.Sprint(TOTPPeriod))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: q.Encode(),
+	}
+	return u.String()
+}
+
+// EncodeSecret encodes the secret in the unpadded base32 format authenticator apps expect.
+func EncodeSecret(secret []byte) string {
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secret)
+}
+
+// GenerateCode generates the code for the secret at the given counter, where the counter is the number of periods since the unix epoch.
+func GenerateCode(secret []byte, counter int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	//dynamic truncation (RFC 4226 section 5.3)
+	offset := sum[len(sum)-1] & 0xf
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < TOTPDigits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", TOTPDigits, value%mod)
+}
@@ -0,0 +1,131 @@
+package totphelpers_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	totphelpers "github.com/mhogar/amber/controllers/totp_helpers"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RFC6238TOTPGeneratorTestSuite struct {
+	helpers.CustomSuite
+	TOTPGenerator totphelpers.RFC6238TOTPGenerator
+}
+
+func (suite *RFC6238TOTPGeneratorTestSuite) SetupTest() {
+	suite.TOTPGenerator = totphelpers.RFC6238TOTPGenerator{}
+}
+
+func (suite *RFC6238TOTPGeneratorTestSuite) TestGenerateSecret_GeneratesRandomSecretOfCorrectLength() {
+	//act
+	secret1, err1 := suite.TOTPGenerator.GenerateSecret()
+	secret2, err2 := suite.TOTPGenerator.GenerateSecret()
+
+	//assert
+	suite.Require().NoError(err1)
+	suite.Require().NoError(err2)
+	suite.Len(secret1, totphelpers.TOTPSecretNumBytes)
+	suite.NotEqual(secret1, secret2)
+}
+
+func (suite *RFC6238TOTPGeneratorTestSuite) TestGenerateCode_MatchesRFC6238TestVectors() {
+	//the sha1 test vectors from RFC 6238 appendix B, truncated to 6 digits
+	secret := []byte("12345678901234567890")
+	var unix int64
+	var expectedCode string
+
+	testCase := func() {
+		//act
+		code := totphelpers.GenerateCode(secret, unix/totphelpers.TOTPPeriod)
+
+		//assert
+		suite.Equal(expectedCode, code)
+	}
+
+	unix = 59
+	expectedCode = "287082"
+	suite.Run("59", testCase)
+
+	unix = 1111111109
+	expectedCode = "081804"
+	suite.Run("1111111109", testCase)
+
+	unix = 1234567890
+	expectedCode = "005924"
+	suite.Run("1234567890", testCase)
+
+	unix = 2000000000
+	expectedCode = "279037"
+	suite.Run("2000000000", testCase)
+}
+
+func (suite *RFC6238TOTPGeneratorTestSuite) TestValidateCode_CodeTestCases() {
+	secret := []byte("12345678901234567890")
+	counter := time.Now().Unix() / totphelpers.TOTPPeriod
+
+	var code string
+	var expected bool
+	var expectedStep int64
+
+	testCase := func() {
+		//act
+		step, result := suite.TOTPGenerator.ValidateCode(secret, code)
+
+		//assert
+		suite.Equal(expected, result)
+		suite.Equal(expectedStep, step)
+	}
+
+	code = totphelpers.GenerateCode(secret, counter)
+	expected = true
+	expectedStep = counter
+	suite.Run("CurrentPeriodIsValid", testCase)
+
+	code = totphelpers.GenerateCode(secret, counter-1)
+	expected = true
+	expectedStep = counter - 1
+	suite.Run("PreviousPeriodIsValid", testCase)
+
+	code = totphelpers.GenerateCode(secret, counter+1)
+	expected = true
+	expectedStep = counter + 1
+	suite.Run("NextPeriodIsValid", testCase)
+
+	code = totphelpers.GenerateCode(secret, counter-3)
+	expected = false
+	expectedStep = 0
+	suite.Run("OldPeriodIsInvalid", testCase)
+
+	code = ""
+	expected = false
+	expectedStep = 0
+	suite.Run("EmptyCodeIsInvalid", testCase)
+}
+
+func (suite *RFC6238TOTPGeneratorTestSuite) TestCreateProvisioningURI_CreatesOTPAuthURI() {
+	//arrange
+	secret := []byte("12345678901234567890")
+
+	//act
+	uri := suite.TOTPGenerator.CreateProvisioningURI("Test App", "username", secret)
+
+	//assert
+	u, err := url.Parse(uri)
+	suite.Require().NoError(err)
+
+	suite.Equal("otpauth", u.Scheme)
+	suite.Equal("totp", u.Host)
+	suite.Equal("/Test App:username", u.Path)
+	suite.Equal(totphelpers.EncodeSecret(secret), u.Query().Get("secret"))
+	suite.Equal("Test App", u.Query().Get("issuer"))
+	suite.Equal("6", u.Query().Get("digits"))
+	suite.Equal("30", u.Query().Get("period"))
+}
+
+func TestRFC6238TOTPGeneratorTestSuite(t *testing.T) {
+	suite.Run(t, &RFC6238TOTPGeneratorTestSuite{})
+}
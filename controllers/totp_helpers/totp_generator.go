@@ -0,0 +1,14 @@
+package totphelpers
+
+type TOTPGenerator interface {
+	// GenerateSecret generates a new random TOTP secret.
+	// Returns the secret and any errors.
+	GenerateSecret() ([]byte, error)
+
+	// ValidateCode checks if the code is valid for the secret at the current time.
+	// Returns the time step the code is for and whether it is valid.
+	ValidateCode(secret []byte, code string) (int64, bool)
+
+	// CreateProvisioningURI creates the otpauth uri authenticator apps use to enroll the secret.
+	CreateProvisioningURI(issuer string, account string, secret []byte) string
+}
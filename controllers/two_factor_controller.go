@@ -0,0 +1,184 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
+	totphelpers "github.com/mhogar/amber/controllers/totp_helpers"
+	"github.com/mhogar/amber/models"
+)
+
+// RecoveryCodeCount is the number of recovery codes generated when a user enables two-factor authentication.
+const RecoveryCodeCount = 10
+
+type CoreTwoFactorController struct {
+	AuthController AuthController
+	TOTPGenerator  totphelpers.TOTPGenerator
+	Encryptor      totphelpers.Encryptor
+}
+
+func (c CoreTwoFactorController) BeginTOTPEnrollment(CRUD TwoFactorControllerCRUD, username string) (string, common.CustomError) {
+	//get the user
+	user, cerr := c.getUser(CRUD, username)
+	if cerr.Type != common.ErrorTypeNone {
+		return "", cerr
+	}
+
+	//verify two-factor authentication isn't already enabled
+	if user.TOTPEnabled {
+		return "", common.ClientError("two-factor authentication is already enabled")
+	}
+
+	//generate the secret
+	secret, err := c.TOTPGenerator.GenerateSecret()
+	if err != nil {
+		log.Println(common.ChainError("error generating totp secret", err))
+		return "", common.InternalError()
+	}
+
+	//encrypt the secret
+	encrypted, err := c.Encryptor.Encrypt(secret)
+	if err != nil {
+		log.Println(common.ChainError("error encrypting totp secret", err))
+		return "", common.InternalError()
+	}
+
+	//save the secret without enabling it
+	_, err = CRUD.UpdateUserTOTP(username, encrypted, false)
+	if err != nil {
+		log.Println(common.ChainError("error updating user totp", err))
+		return "", common.InternalError()
+	}
+
+	return c.TOTPGenerator.CreateProvisioningURI(config.GetAppName(), username, secret), common.NoError()
+}
+
+func (c CoreTwoFactorController) ConfirmTOTPEnrollment(CRUD TwoFactorControllerCRUD, username string, code string) ([]string, common.CustomError) {
+	//get the user
+	user, cerr := c.getUser(CRUD, username)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
+	}
+
+	//verify there is an enrollment to confirm
+	if user.TOTPEnabled {
+		return nil, common.ClientError("two-factor authentication is already enabled")
+	}
+	if user.TOTPSecret == nil {
+		return nil, common.ClientError("two-factor enrollment has not been started")
+	}
+
+	//decrypt the secret
+	secret, err := c.Encryptor.Decrypt(user.TOTPSecret)
+	if err != nil {
+		log.Println(common.ChainError("error decrypting totp secret", err))
+		return nil, common.InternalError()
+	}
+
+	//verify the code (recovery codes aren't accepted since the user has none yet)
+	step, valid := c.TOTPGenerator.ValidateCode(secret, code)
+	if !valid {
+		return nil, common.ClientError("invalid two-factor code")
+	}
+
+	//only accept steps after the last accepted one, so the code can't be used again to log in
+	res, err := CRUD.UpdateUserTOTPLastTimeStep(username, step)
+	if err != nil {
+		log.Println(common.ChainError("error updating user totp last time step", err))
+		return nil, common.InternalError()
+	}
+	if !res {
+		return nil, common.ClientError("two-factor code has already been used")
+	}
+
+	//enable two-factor authentication
+	_, err = CRUD.UpdateUserTOTP(username, user.TOTPSecret, true)
+	if err != nil {
+		log.Println(common.ChainError("error updating user totp", err))
+		return nil, common.InternalError()
+	}
+
+	//replace any old recovery codes
+	err = CRUD.DeleteAllUserRecoveryCodes(username)
+	if err != nil {
+		log.Println(common.ChainError("error deleting all user recovery codes", err))
+		return nil, common.InternalError()
+	}
+
+	codes := make([]string, RecoveryCodeCount)
+	for i := range codes {
+		codes[i], err = totphelpers.GenerateRecoveryCode()
+		if err != nil {
+			log.Println(common.ChainError("error generating recovery code", err))
+			return nil, common.InternalError()
+		}
+
+		//save only the hash of the code
+		err = CRUD.SaveRecoveryCode(models.CreateRecoveryCode(username, totphelpers.HashRecoveryCode(codes[i])))
+		if err != nil {
+			log.Println(common.ChainError("error saving recovery code", err))
+			return nil, common.InternalError()
+		}
+	}
+
+	return codes, common.NoError()
+}
+
+func (c CoreTwoFactorController) DisableTOTP(CRUD TwoFactorControllerCRUD, username string, code string) common.CustomError {
+	//get the user
+	user, cerr := c.getUser(CRUD, username)
+	if cerr.Type != common.ErrorTypeNone {
+		return cerr
+	}
+
+	//verify two-factor authentication is enabled
+	if !user.TOTPEnabled {
+		return common.ClientError("two-factor authentication is not enabled")
+	}
+
+	//verify two-factor authentication isn't required for the user's rank
+	requiredRank := config.GetTwoFactorConfig().RequiredRank
+	if requiredRank > 0 && user.Rank >= requiredRank {
+		return common.ClientError("two-factor authentication is required for users of your rank")
+	}
+
+	//verify the code
+	cerr = c.AuthController.VerifyTwoFactorCode(CRUD, user, code)
+	if cerr.Type != common.ErrorTypeNone {
+		return cerr
+	}
+
+	//disable two-factor authentication and clear the secret
+	_, err := CRUD.UpdateUserTOTP(username, nil, false)
+	if err != nil {
+		log.Println(common.ChainError("error updating user totp", err))
+		return common.InternalError()
+	}
+
+	//delete the recovery codes
+	err = CRUD.DeleteAllUserRecoveryCodes(username)
+	if err != nil {
+		log.Println(common.ChainError("error deleting all user recovery codes", err))
+		return common.InternalError()
+	}
+
+	return common.NoError()
+}
+
+func (CoreTwoFactorController) getUser(CRUD TwoFactorControllerCRUD, username string) (*models.User, common.CustomError) {
+	//get the user
+	user, err := CRUD.GetUserByUsername(username)
+	if err != nil {
+		log.Println(common.ChainError("error getting user by username", err))
+		return nil, common.InternalError()
+	}
+
+	//verify user exists
+	if user == nil {
+		return nil, common.ClientError(fmt.Sprintf("user with username %s not found", username))
+	}
+
+	return user, common.NoError()
+}
@@ -0,0 +1,443 @@
+package controllers_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
+	"github.com/mhogar/amber/controllers"
+	"github.com/mhogar/amber/controllers/mocks"
+	totphelpers "github.com/mhogar/amber/controllers/totp_helpers"
+	totpmocks "github.com/mhogar/amber/controllers/totp_helpers/mocks"
+	datamocks "github.com/mhogar/amber/data/mocks"
+	"github.com/mhogar/amber/models"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TwoFactorControllerTestSuite struct {
+	ControllerTestSuite
+	ControllersMock     mocks.Controllers
+	TOTPGeneratorMock   totpmocks.TOTPGenerator
+	EncryptorMock       totpmocks.Encryptor
+	TwoFactorController controllers.CoreTwoFactorController
+}
+
+func (suite *TwoFactorControllerTestSuite) SetupTest() {
+	suite.ControllerTestSuite.SetupTest()
+
+	viper.Set("app_name", "app")
+	viper.Set("two_factor", config.TwoFactorConfig{})
+
+	suite.ControllersMock = mocks.Controllers{}
+	suite.TOTPGeneratorMock = totpmocks.TOTPGenerator{}
+	suite.EncryptorMock = totpmocks.Encryptor{}
+	suite.TwoFactorController = controllers.CoreTwoFactorController{
+		AuthController: &suite.ControllersMock,
+		TOTPGenerator:  &suite.TOTPGeneratorMock,
+		Encryptor:      &suite.EncryptorMock,
+	}
+}
+
+func (suite *TwoFactorControllerTestSuite) createUser(secret []byte, enabled bool) *models.User {
+	user := models.CreateUser("username", 0, []byte("password"))
+	user.TOTPSecret = secret
+	user.TOTPEnabled = enabled
+
+	return user
+}
+
+func (suite *TwoFactorControllerTestSuite) runGetUserTestCases(getUserFunc func() common.CustomError) {
+	suite.Run("ErrorGettingUser_ReturnsInternalError", func() {
+		//arrange
+		suite.CRUDMock = datamocks.DataCRUD{}
+		suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, errors.New(""))
+
+		//act
+		cerr := getUserFunc()
+
+		//assert
+		suite.CustomInternalError(cerr)
+	})
+
+	suite.Run("UserNotFound_ReturnsClientError", func() {
+		//arrange
+		suite.CRUDMock = datamocks.DataCRUD{}
+		suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
+
+		//act
+		cerr := getUserFunc()
+
+		//assert
+		suite.CustomClientError(cerr, "user", "not found")
+	})
+}
+
+func (suite *TwoFactorControllerTestSuite) TestBeginTOTPEnrollment_WithErrorGettingUser_ReturnsError() {
+	suite.runGetUserTestCases(func() common.CustomError {
+		uri, cerr := suite.TwoFactorController.BeginTOTPEnrollment(&suite.CRUDMock, "username")
+		suite.Empty(uri)
+
+		return cerr
+	})
+}
+
+func (suite *TwoFactorControllerTestSuite) TestBeginTOTPEnrollment_WhereTwoFactorIsAlreadyEnabled_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser([]byte("secret"), true), nil)
+
+	//act
+	uri, cerr := suite.TwoFactorController.BeginTOTPEnrollment(&suite.CRUDMock, "username")
+
+	//assert
+	suite.Empty(uri)
+	suite.CustomClientError(cerr, "already enabled")
+}
+
+func (suite *TwoFactorControllerTestSuite) TestBeginTOTPEnrollment_WithErrorGeneratingSecret_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser(nil, false), nil)
+	suite.TOTPGeneratorMock.On("GenerateSecret").Return(nil, errors.New(""))
+
+	//act
+	uri, cerr := suite.TwoFactorController.BeginTOTPEnrollment(&suite.CRUDMock, "username")
+
+	//assert
+	suite.Empty(uri)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TwoFactorControllerTestSuite) TestBeginTOTPEnrollment_WithErrorEncryptingSecret_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser(nil, false), nil)
+	suite.TOTPGeneratorMock.On("GenerateSecret").Return([]byte("secret"), nil)
+	suite.EncryptorMock.On("Encrypt", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	uri, cerr := suite.TwoFactorController.BeginTOTPEnrollment(&suite.CRUDMock, "username")
+
+	//assert
+	suite.Empty(uri)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TwoFactorControllerTestSuite) TestBeginTOTPEnrollment_WithErrorUpdatingUserTOTP_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser(nil, false), nil)
+	suite.TOTPGeneratorMock.On("GenerateSecret").Return([]byte("secret"), nil)
+	suite.EncryptorMock.On("Encrypt", mock.Anything).Return([]byte("encrypted"), nil)
+	suite.CRUDMock.On("UpdateUserTOTP", mock.Anything, mock.Anything, mock.Anything).Return(false, errors.New(""))
+
+	//act
+	uri, cerr := suite.TwoFactorController.BeginTOTPEnrollment(&suite.CRUDMock, "username")
+
+	//assert
+	suite.Empty(uri)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TwoFactorControllerTestSuite) TestBeginTOTPEnrollment_WithNoErrors_ReturnsProvisioningURI() {
+	//arrange
+	username := "username"
+	secret := []byte("secret")
+	encrypted := []byte("encrypted")
+	uri := "otpauth://totp/app:username"
+
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser(nil, false), nil)
+	suite.TOTPGeneratorMock.On("GenerateSecret").Return(secret, nil)
+	suite.EncryptorMock.On("Encrypt", mock.Anything).Return(encrypted, nil)
+	suite.CRUDMock.On("UpdateUserTOTP", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	suite.TOTPGeneratorMock.On("CreateProvisioningURI", mock.Anything, mock.Anything, mock.Anything).Return(uri)
+
+	//act
+	resultURI, cerr := suite.TwoFactorController.BeginTOTPEnrollment(&suite.CRUDMock, username)
+
+	//assert
+	suite.Equal(uri, resultURI)
+	suite.CustomNoError(cerr)
+
+	suite.CRUDMock.AssertCalled(suite.T(), "GetUserByUsername", username)
+	suite.EncryptorMock.AssertCalled(suite.T(), "Encrypt", secret)
+	suite.CRUDMock.AssertCalled(suite.T(), "UpdateUserTOTP", username, encrypted, false)
+	suite.TOTPGeneratorMock.AssertCalled(suite.T(), "CreateProvisioningURI", "app", username, secret)
+}
+
+func (suite *TwoFactorControllerTestSuite) TestConfirmTOTPEnrollment_WithErrorGettingUser_ReturnsError() {
+	suite.runGetUserTestCases(func() common.CustomError {
+		codes, cerr := suite.TwoFactorController.ConfirmTOTPEnrollment(&suite.CRUDMock, "username", "code")
+		suite.Nil(codes)
+
+		return cerr
+	})
+}
+
+func (suite *TwoFactorControllerTestSuite) TestConfirmTOTPEnrollment_WhereTwoFactorIsAlreadyEnabled_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser([]byte("secret"), true), nil)
+
+	//act
+	codes, cerr := suite.TwoFactorController.ConfirmTOTPEnrollment(&suite.CRUDMock, "username", "code")
+
+	//assert
+	suite.Nil(codes)
+	suite.CustomClientError(cerr, "already enabled")
+}
+
+func (suite *TwoFactorControllerTestSuite) TestConfirmTOTPEnrollment_WhereEnrollmentHasNotStarted_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser(nil, false), nil)
+
+	//act
+	codes, cerr := suite.TwoFactorController.ConfirmTOTPEnrollment(&suite.CRUDMock, "username", "code")
+
+	//assert
+	suite.Nil(codes)
+	suite.CustomClientError(cerr, "enrollment", "not been started")
+}
+
+func (suite *TwoFactorControllerTestSuite) TestConfirmTOTPEnrollment_WithErrorDecryptingSecret_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser([]byte("encrypted"), false), nil)
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	codes, cerr := suite.TwoFactorController.ConfirmTOTPEnrollment(&suite.CRUDMock, "username", "code")
+
+	//assert
+	suite.Nil(codes)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TwoFactorControllerTestSuite) TestConfirmTOTPEnrollment_WithInvalidCode_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser([]byte("encrypted"), false), nil)
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return([]byte("secret"), nil)
+	suite.TOTPGeneratorMock.On("ValidateCode", mock.Anything, mock.Anything).Return(int64(0), false)
+
+	//act
+	codes, cerr := suite.TwoFactorController.ConfirmTOTPEnrollment(&suite.CRUDMock, "username", "code")
+
+	//assert
+	suite.Nil(codes)
+	suite.CustomClientError(cerr, "invalid two-factor code")
+}
+
+func (suite *TwoFactorControllerTestSuite) TestConfirmTOTPEnrollment_WithErrorUpdatingUserTOTPLastTimeStep_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser([]byte("encrypted"), false), nil)
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return([]byte("secret"), nil)
+	suite.TOTPGeneratorMock.On("ValidateCode", mock.Anything, mock.Anything).Return(int64(1), true)
+	suite.CRUDMock.On("UpdateUserTOTPLastTimeStep", mock.Anything, mock.Anything).Return(false, errors.New(""))
+
+	//act
+	codes, cerr := suite.TwoFactorController.ConfirmTOTPEnrollment(&suite.CRUDMock, "username", "code")
+
+	//assert
+	suite.Nil(codes)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TwoFactorControllerTestSuite) TestConfirmTOTPEnrollment_WhereCodeWasAlreadyUsed_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser([]byte("encrypted"), false), nil)
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return([]byte("secret"), nil)
+	suite.TOTPGeneratorMock.On("ValidateCode", mock.Anything, mock.Anything).Return(int64(1), true)
+	suite.CRUDMock.On("UpdateUserTOTPLastTimeStep", mock.Anything, mock.Anything).Return(false, nil)
+
+	//act
+	codes, cerr := suite.TwoFactorController.ConfirmTOTPEnrollment(&suite.CRUDMock, "username", "code")
+
+	//assert
+	suite.Nil(codes)
+	suite.CustomClientError(cerr, "two-factor code", "already been used")
+	suite.CRUDMock.AssertNotCalled(suite.T(), "UpdateUserTOTP", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TwoFactorControllerTestSuite) TestConfirmTOTPEnrollment_WithErrorUpdatingUserTOTP_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser([]byte("encrypted"), false), nil)
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return([]byte("secret"), nil)
+	suite.TOTPGeneratorMock.On("ValidateCode", mock.Anything, mock.Anything).Return(int64(1), true)
+	suite.CRUDMock.On("UpdateUserTOTPLastTimeStep", mock.Anything, mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("UpdateUserTOTP", mock.Anything, mock.Anything, mock.Anything).Return(false, errors.New(""))
+
+	//act
+	codes, cerr := suite.TwoFactorController.ConfirmTOTPEnrollment(&suite.CRUDMock, "username", "code")
+
+	//assert
+	suite.Nil(codes)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TwoFactorControllerTestSuite) TestConfirmTOTPEnrollment_WithErrorDeletingAllUserRecoveryCodes_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser([]byte("encrypted"), false), nil)
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return([]byte("secret"), nil)
+	suite.TOTPGeneratorMock.On("ValidateCode", mock.Anything, mock.Anything).Return(int64(1), true)
+	suite.CRUDMock.On("UpdateUserTOTPLastTimeStep", mock.Anything, mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("UpdateUserTOTP", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("DeleteAllUserRecoveryCodes", mock.Anything).Return(errors.New(""))
+
+	//act
+	codes, cerr := suite.TwoFactorController.ConfirmTOTPEnrollment(&suite.CRUDMock, "username", "code")
+
+	//assert
+	suite.Nil(codes)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TwoFactorControllerTestSuite) TestConfirmTOTPEnrollment_WithErrorSavingRecoveryCode_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser([]byte("encrypted"), false), nil)
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return([]byte("secret"), nil)
+	suite.TOTPGeneratorMock.On("ValidateCode", mock.Anything, mock.Anything).Return(int64(1), true)
+	suite.CRUDMock.On("UpdateUserTOTPLastTimeStep", mock.Anything, mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("UpdateUserTOTP", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("DeleteAllUserRecoveryCodes", mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveRecoveryCode", mock.Anything).Return(errors.New(""))
+
+	//act
+	codes, cerr := suite.TwoFactorController.ConfirmTOTPEnrollment(&suite.CRUDMock, "username", "code")
+
+	//assert
+	suite.Nil(codes)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TwoFactorControllerTestSuite) TestConfirmTOTPEnrollment_WithNoErrors_ReturnsRecoveryCodes() {
+	//arrange
+	username := "username"
+	code := "123456"
+	secret := []byte("secret")
+	encrypted := []byte("encrypted")
+	step := int64(12345)
+
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser(encrypted, false), nil)
+	suite.EncryptorMock.On("Decrypt", mock.Anything).Return(secret, nil)
+	suite.TOTPGeneratorMock.On("ValidateCode", mock.Anything, mock.Anything).Return(step, true)
+	suite.CRUDMock.On("UpdateUserTOTPLastTimeStep", mock.Anything, mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("UpdateUserTOTP", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("DeleteAllUserRecoveryCodes", mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveRecoveryCode", mock.Anything).Return(nil)
+
+	//act
+	codes, cerr := suite.TwoFactorController.ConfirmTOTPEnrollment(&suite.CRUDMock, username, code)
+
+	//assert
+	suite.Len(codes, controllers.RecoveryCodeCount)
+	suite.CustomNoError(cerr)
+
+	suite.EncryptorMock.AssertCalled(suite.T(), "Decrypt", encrypted)
+	suite.TOTPGeneratorMock.AssertCalled(suite.T(), "ValidateCode", secret, code)
+	suite.CRUDMock.AssertCalled(suite.T(), "UpdateUserTOTPLastTimeStep", username, step)
+	suite.CRUDMock.AssertCalled(suite.T(), "UpdateUserTOTP", username, encrypted, true)
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteAllUserRecoveryCodes", username)
+
+	for _, recoveryCode := range codes {
+		suite.CRUDMock.AssertCalled(suite.T(), "SaveRecoveryCode", models.CreateRecoveryCode(username, totphelpers.HashRecoveryCode(recoveryCode)))
+	}
+}
+
+func (suite *TwoFactorControllerTestSuite) TestDisableTOTP_WithErrorGettingUser_ReturnsError() {
+	suite.runGetUserTestCases(func() common.CustomError {
+		return suite.TwoFactorController.DisableTOTP(&suite.CRUDMock, "username", "code")
+	})
+}
+
+func (suite *TwoFactorControllerTestSuite) TestDisableTOTP_WhereTwoFactorIsNotEnabled_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser(nil, false), nil)
+
+	//act
+	cerr := suite.TwoFactorController.DisableTOTP(&suite.CRUDMock, "username", "code")
+
+	//assert
+	suite.CustomClientError(cerr, "not enabled")
+}
+
+func (suite *TwoFactorControllerTestSuite) TestDisableTOTP_WhereTwoFactorIsRequiredForUserRank_ReturnsClientError() {
+	//arrange
+	user := suite.createUser([]byte("secret"), true)
+	user.Rank = 5
+
+	viper.Set("two_factor", config.TwoFactorConfig{
+		RequiredRank: user.Rank,
+	})
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(user, nil)
+
+	//act
+	cerr := suite.TwoFactorController.DisableTOTP(&suite.CRUDMock, "username", "code")
+
+	//assert
+	suite.CustomClientError(cerr, "required", "rank")
+}
+
+func (suite *TwoFactorControllerTestSuite) TestDisableTOTP_WithErrorVerifyingTwoFactorCode_ReturnsError() {
+	//arrange
+	verifyErr := common.ClientError("verify two-factor code error")
+
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser([]byte("secret"), true), nil)
+	suite.ControllersMock.On("VerifyTwoFactorCode", mock.Anything, mock.Anything, mock.Anything).Return(verifyErr)
+
+	//act
+	cerr := suite.TwoFactorController.DisableTOTP(&suite.CRUDMock, "username", "code")
+
+	//assert
+	suite.Equal(verifyErr, cerr)
+}
+
+func (suite *TwoFactorControllerTestSuite) TestDisableTOTP_WithErrorUpdatingUserTOTP_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser([]byte("secret"), true), nil)
+	suite.ControllersMock.On("VerifyTwoFactorCode", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
+	suite.CRUDMock.On("UpdateUserTOTP", mock.Anything, mock.Anything, mock.Anything).Return(false, errors.New(""))
+
+	//act
+	cerr := suite.TwoFactorController.DisableTOTP(&suite.CRUDMock, "username", "code")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TwoFactorControllerTestSuite) TestDisableTOTP_WithErrorDeletingAllUserRecoveryCodes_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(suite.createUser([]byte("secret"), true), nil)
+	suite.ControllersMock.On("VerifyTwoFactorCode", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
+	suite.CRUDMock.On("UpdateUserTOTP", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("DeleteAllUserRecoveryCodes", mock.Anything).Return(errors.New(""))
+
+	//act
+	cerr := suite.TwoFactorController.DisableTOTP(&suite.CRUDMock, "username", "code")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *TwoFactorControllerTestSuite) TestDisableTOTP_WithNoErrors_ReturnsNoError() {
+	//arrange
+	username := "username"
+	code := "123456"
+	user := suite.createUser([]byte("secret"), true)
+
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(user, nil)
+	suite.ControllersMock.On("VerifyTwoFactorCode", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
+	suite.CRUDMock.On("UpdateUserTOTP", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("DeleteAllUserRecoveryCodes", mock.Anything).Return(nil)
+
+	//act
+	cerr := suite.TwoFactorController.DisableTOTP(&suite.CRUDMock, username, code)
+
+	//assert
+	suite.CustomNoError(cerr)
+
+	suite.ControllersMock.AssertCalled(suite.T(), "VerifyTwoFactorCode", &suite.CRUDMock, user, code)
+	suite.CRUDMock.AssertCalled(suite.T(), "UpdateUserTOTP", username, []byte(nil), false)
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteAllUserRecoveryCodes", username)
+}
+
+func TestTwoFactorControllerTestSuite(t *testing.T) {
+	suite.Run(t, &TwoFactorControllerTestSuite{})
+}
@@ -3,6 +3,9 @@ package controllers
 import (
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/mhogar/amber/common"
 	passwordhelpers "github.com/mhogar/amber/controllers/password_helpers"
@@ -16,9 +19,10 @@ type CoreUserController struct {
 	UserController            UserController
 }
 
-func (c CoreUserController) CreateUser(CRUD UserControllerCRUD, username string, password string, rank int) (*models.User, common.CustomError) {
+func (c CoreUserController) CreateUser(CRUD UserControllerCRUD, username string, password string, rank int, profile models.UserProfile) (*models.User, common.CustomError) {
 	//create the user model
 	user := models.CreateUser(username, rank, nil)
+	user.UserProfile = normalizeUserProfile(profile)
 
 	//validate the user
 	cerr := c.validateUser(user)
@@ -36,11 +40,16 @@ func (c CoreUserController) CreateUser(CRUD UserControllerCRUD, username string,
 		return nil, common.ClientError("username is already in use")
 	}
 
+	//validate email is unique
+	cerr = c.validateUniqueEmail(CRUD, user)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
+	}
+
 	//validate password meets criteria
-	vperr := c.PasswordCriteriaValidator.ValidatePasswordCriteria(password)
-	if vperr.Status != passwordhelpers.ValidatePasswordCriteriaValid {
-		log.Println(common.ChainError("error validating password criteria", vperr))
-		return nil, common.ClientError("password does not meet minimum criteria")
+	cerr = validatePasswordCriteria(c.PasswordCriteriaValidator, password)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
 	}
 
 	//hash the password
@@ -60,20 +69,64 @@ func (c CoreUserController) CreateUser(CRUD UserControllerCRUD, username string,
 	return user, common.NoError()
 }
 
-func (CoreUserController) GetUsersWithLesserRank(CRUD UserControllerCRUD, rank int) ([]*models.User, common.CustomError) {
-	//get the users
-	users, err := CRUD.GetUsersWithLesserRank(rank)
+func (CoreUserController) GetUsersWithLesserRank(CRUD UserControllerCRUD, rank int, query models.PageQuery) ([]*models.User, models.PageInfo, common.CustomError) {
+	//validate the page query
+	cerr := validatePageQuery(&query, models.UserSortUsername, models.UserSortRank)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, models.PageInfo{}, cerr
+	}
+
+	if query.SortBy == models.UserSortRank && query.After != nil {
+		_, err := strconv.Atoi(query.After.Value)
+		if err != nil {
+			return nil, models.PageInfo{}, common.ClientError("cursor is invalid")
+		}
+	}
+
+	//get the users, fetching an extra one to check if there is a next page
+	limit := query.Limit
+	query.Limit++
+
+	users, err := CRUD.GetUsersWithLesserRank(rank, query)
 	if err != nil {
 		log.Println(common.ChainError("error getting users with lesser rank", err))
-		return nil, common.InternalError()
+		return nil, models.PageInfo{}, common.InternalError()
+	}
+
+	//count the users
+	total, err := CRUD.CountUsersWithLesserRank(rank, query.Search)
+	if err != nil {
+		log.Println(common.ChainError("error counting users with lesser rank", err))
+		return nil, models.PageInfo{}, common.InternalError()
+	}
+
+	page := models.PageInfo{Total: total}
+	if len(users) > limit {
+		users = users[:limit]
+
+		cursor := users[limit-1].GetCursor(query.SortBy)
+		page.NextCursor = &cursor
 	}
 
-	return users, common.NoError()
+	return users, page, common.NoError()
 }
 
-func (c CoreUserController) UpdateUser(CRUD UserControllerCRUD, username string, rank int) (*models.User, common.CustomError) {
-	//create the user model
+func (c CoreUserController) UpdateUser(CRUD UserControllerCRUD, username string, rank int, update models.UserProfileUpdate) (*models.User, common.CustomError) {
+	//get the user
+	existingUser, err := CRUD.GetUserByUsername(username)
+	if err != nil {
+		log.Println(common.ChainError("error getting user by username", err))
+		return nil, common.InternalError()
+	}
+
+	//verify user was actually found
+	if existingUser == nil {
+		return nil, common.ClientError(fmt.Sprintf("user with username %s not found", username))
+	}
+
+	//create the user model, keeping the current values of the profile fields not being updated
 	user := models.CreateUser(username, rank, nil)
+	user.UserProfile = normalizeUserProfile(update.Apply(existingUser.UserProfile))
 
 	//validate the user
 	cerr := c.validateUser(user)
@@ -81,6 +134,12 @@ func (c CoreUserController) UpdateUser(CRUD UserControllerCRUD, username string,
 		return nil, cerr
 	}
 
+	//validate email is unique
+	cerr = c.validateUniqueEmail(CRUD, user)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, cerr
+	}
+
 	//update the user
 	res, err := CRUD.UpdateUser(user)
 	if err != nil {
@@ -93,38 +152,38 @@ func (c CoreUserController) UpdateUser(CRUD UserControllerCRUD, username string,
 		return nil, common.ClientError(fmt.Sprintf("user with username %s not found", username))
 	}
 
+	//a disabled user should be logged out everywhere
+	if !user.Enabled {
+		err = CRUD.DeleteAllUserSessions(username)
+		if err != nil {
+			log.Println(common.ChainError("error deleting all user sessions", err))
+			return nil, common.InternalError()
+		}
+	}
+
 	return user, common.NoError()
 }
 
 func (c CoreUserController) UpdateUserPassword(CRUD UserControllerCRUD, username string, password string) common.CustomError {
-	//validate password meets critera
-	verr := c.PasswordCriteriaValidator.ValidatePasswordCriteria(password)
-	if verr.Status != passwordhelpers.ValidatePasswordCriteriaValid {
-		log.Println(common.ChainError("error validating password criteria", verr))
-		return common.ClientError("password does not meet minimum criteria")
-	}
-
-	//hash the password
-	hash, err := c.PasswordHasher.HashPassword(password)
+	//get the user
+	user, err := CRUD.GetUserByUsername(username)
 	if err != nil {
-		log.Println(common.ChainError("error generating password hash", err))
+		log.Println(common.ChainError("error getting user by username", err))
 		return common.InternalError()
 	}
 
-	//update the user's password (don't check result because we know the user already exists)
-	_, err = CRUD.UpdateUserPassword(username, hash)
-	if err != nil {
-		log.Println(common.ChainError("error updating user password", err))
-		return common.InternalError()
+	//verify user exists
+	if user == nil {
+		return common.ClientError(fmt.Sprintf("user with username %s not found", username))
 	}
 
-	//return success
-	return common.NoError()
+	//update the user's password
+	return changeUserPassword(CRUD, c.PasswordHasher, c.PasswordCriteriaValidator, user, password)
 }
 
 func (c CoreUserController) UpdateUserPasswordWithAuth(CRUD UserControllerCRUD, username string, oldPassword string, newPassword string) common.CustomError {
 	//authenticate user with their old password
-	_, cerr := c.AuthController.AuthenticateUserWithPassword(CRUD, username, oldPassword)
+	user, cerr := c.AuthController.AuthenticateUserWithPassword(CRUD, username, oldPassword)
 	if cerr.Type == common.ErrorTypeClient {
 		return common.ClientError("old password is incorrect")
 	}
@@ -132,6 +191,12 @@ func (c CoreUserController) UpdateUserPasswordWithAuth(CRUD UserControllerCRUD,
 		return cerr
 	}
 
+	//users can't change their own password again until it is old enough
+	cerr = validatePasswordMinAge(user, time.Now())
+	if cerr.Type != common.ErrorTypeNone {
+		return cerr
+	}
+
 	//update the user's password
 	return c.UserController.UpdateUserPassword(CRUD, username, newPassword)
 }
@@ -182,6 +247,40 @@ func (CoreUserController) validateUser(user *models.User) common.CustomError {
 	if verr&models.ValidateUserInvalidRank != 0 {
 		return common.ClientError("rank is invalid")
 	}
+	if verr&models.ValidateUserEmailTooLong != 0 {
+		return common.ClientError(fmt.Sprint("email cannot be longer than ", models.UserEmailMaxLength, " characters"))
+	}
+	if verr&models.ValidateUserInvalidEmail != 0 {
+		return common.ClientError("email is invalid")
+	}
+	if verr&models.ValidateUserDisplayNameTooLong != 0 {
+		return common.ClientError(fmt.Sprint("display name cannot be longer than ", models.UserDisplayNameMaxLength, " characters"))
+	}
 
 	return common.NoError()
 }
+
+func (CoreUserController) validateUniqueEmail(CRUD UserControllerCRUD, user *models.User) common.CustomError {
+	//users without an email don't conflict
+	if user.Email == "" {
+		return common.NoError()
+	}
+
+	otherUser, err := CRUD.GetUserByEmail(user.Email)
+	if err != nil {
+		log.Println(common.ChainError("error getting user by email", err))
+		return common.InternalError()
+	}
+	if otherUser != nil && otherUser.Username != user.Username {
+		return common.ClientError("email is already in use")
+	}
+
+	return common.NoError()
+}
+
+// normalizeUserProfile trims the profile fields and lower-cases the email so it is unique regardless of case.
+func normalizeUserProfile(profile models.UserProfile) models.UserProfile {
+	profile.Email = strings.ToLower(strings.TrimSpace(profile.Email))
+	profile.DisplayName = strings.TrimSpace(profile.DisplayName)
+	return profile
+}
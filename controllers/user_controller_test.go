@@ -4,15 +4,19 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
 	"github.com/mhogar/amber/controllers"
 	"github.com/mhogar/amber/controllers/mocks"
 	passwordhelpers "github.com/mhogar/amber/controllers/password_helpers"
 	passwordhelpermocks "github.com/mhogar/amber/controllers/password_helpers/mocks"
+	datamocks "github.com/mhogar/amber/data/mocks"
 	"github.com/mhogar/amber/models"
 	"github.com/mhogar/amber/testing/helpers"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -28,6 +32,8 @@ type UserControllerTestSuite struct {
 func (suite *UserControllerTestSuite) SetupTest() {
 	suite.ControllerTestSuite.SetupTest()
 
+	viper.Set("password_criteria", config.PasswordCriteriaConfig{})
+
 	suite.PasswordHasherMock = passwordhelpermocks.PasswordHasher{}
 	suite.PasswordCriteriaValidatorMock = passwordhelpermocks.PasswordCriteriaValidator{}
 	suite.ControllersMock = mocks.Controllers{}
@@ -39,6 +45,13 @@ func (suite *UserControllerTestSuite) SetupTest() {
 	}
 }
 
+// setHistorySize sets the number of recent passwords a new password cannot match.
+func (suite *UserControllerTestSuite) setHistorySize(size int) {
+	viper.Set("password_criteria", config.PasswordCriteriaConfig{
+		HistorySize: size,
+	})
+}
+
 func (suite *UserControllerTestSuite) runValidateUserTestCases(validateFunc func(user *models.User) common.CustomError) {
 	suite.Run("EmptyUsername_ReturnsClientError", func() {
 		//arrange
@@ -72,11 +85,47 @@ func (suite *UserControllerTestSuite) runValidateUserTestCases(validateFunc func
 		//assert
 		suite.CustomClientError(cerr, "rank", "invalid")
 	})
+
+	suite.Run("InvalidEmail_ReturnsClientError", func() {
+		//arrange
+		user := models.CreateUser("username", 0, nil)
+		user.Email = "not an email"
+
+		//act
+		cerr := validateFunc(user)
+
+		//assert
+		suite.CustomClientError(cerr, "email", "invalid")
+	})
+
+	suite.Run("EmailTooLong_ReturnsClientError", func() {
+		//arrange
+		user := models.CreateUser("username", 0, nil)
+		user.Email = helpers.CreateStringOfLength(models.UserEmailMaxLength) + "@example.com"
+
+		//act
+		cerr := validateFunc(user)
+
+		//assert
+		suite.CustomClientError(cerr, "email", "cannot be longer", fmt.Sprint(models.UserEmailMaxLength))
+	})
+
+	suite.Run("DisplayNameTooLong_ReturnsClientError", func() {
+		//arrange
+		user := models.CreateUser("username", 0, nil)
+		user.DisplayName = helpers.CreateStringOfLength(models.UserDisplayNameMaxLength + 1)
+
+		//act
+		cerr := validateFunc(user)
+
+		//assert
+		suite.CustomClientError(cerr, "display name", "cannot be longer", fmt.Sprint(models.UserDisplayNameMaxLength))
+	})
 }
 
 func (suite *UserControllerTestSuite) TestCreateUser_ValidateUserTestCases() {
 	suite.runValidateUserTestCases(func(user *models.User) common.CustomError {
-		resUser, cerr := suite.UserController.CreateUser(&suite.CRUDMock, user.Username, "password", user.Rank)
+		resUser, cerr := suite.UserController.CreateUser(&suite.CRUDMock, user.Username, "password", user.Rank, user.UserProfile)
 		suite.Nil(resUser)
 
 		return cerr
@@ -88,7 +137,7 @@ func (suite *UserControllerTestSuite) TestCreateUser_WithErrorGettingUserByUsern
 	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, errors.New(""))
 
 	//act
-	user, cerr := suite.UserController.CreateUser(&suite.CRUDMock, "username", "password", 0)
+	user, cerr := suite.UserController.CreateUser(&suite.CRUDMock, "username", "password", 0, models.UserProfile{})
 
 	//assert
 	suite.Nil(user)
@@ -100,26 +149,65 @@ func (suite *UserControllerTestSuite) TestCreateUser_WithNonUniqueUsername_Retur
 	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
 
 	//act
-	user, cerr := suite.UserController.CreateUser(&suite.CRUDMock, "username", "password", 0)
+	user, cerr := suite.UserController.CreateUser(&suite.CRUDMock, "username", "password", 0, models.UserProfile{})
 
 	//assert
 	suite.Nil(user)
 	suite.CustomClientError(cerr, "username", "already in use")
 }
 
+func (suite *UserControllerTestSuite) TestCreateUser_WithErrorGettingUserByEmail_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetUserByEmail", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	user, cerr := suite.UserController.CreateUser(&suite.CRUDMock, "username", "password", 0, models.UserProfile{Email: "user@example.com"})
+
+	//assert
+	suite.Nil(user)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *UserControllerTestSuite) TestCreateUser_WithNonUniqueEmail_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetUserByEmail", mock.Anything).Return(models.CreateUser("other", 0, nil), nil)
+
+	//act
+	user, cerr := suite.UserController.CreateUser(&suite.CRUDMock, "username", "password", 0, models.UserProfile{Email: "user@example.com"})
+
+	//assert
+	suite.Nil(user)
+	suite.CustomClientError(cerr, "email", "already in use")
+}
+
 func (suite *UserControllerTestSuite) TestCreateUser_WherePasswordDoesNotMeetCriteria_ReturnsClientError() {
 	//arrange
 	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
 	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaError(passwordhelpers.ValidatePasswordCriteriaTooShort, ""))
 
 	//act
-	user, cerr := suite.UserController.CreateUser(&suite.CRUDMock, "username", "password", 0)
+	user, cerr := suite.UserController.CreateUser(&suite.CRUDMock, "username", "password", 0, models.UserProfile{})
 
 	//assert
 	suite.Nil(user)
 	suite.CustomClientError(cerr, "password", "not", "minimum criteria")
 }
 
+func (suite *UserControllerTestSuite) TestCreateUser_WherePasswordIsBreached_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
+	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaError(passwordhelpers.ValidatePasswordCriteriaBreached, ""))
+
+	//act
+	user, cerr := suite.UserController.CreateUser(&suite.CRUDMock, "username", "password", 0, models.UserProfile{})
+
+	//assert
+	suite.Nil(user)
+	suite.CustomClientError(cerr, "password", "too common", "data breach")
+}
+
 func (suite *UserControllerTestSuite) TestCreateUser_WithErrorHashingNewPassword_ReturnsInternalError() {
 	//arrange
 	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
@@ -127,7 +215,7 @@ func (suite *UserControllerTestSuite) TestCreateUser_WithErrorHashingNewPassword
 	suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return(nil, errors.New(""))
 
 	//act
-	user, cerr := suite.UserController.CreateUser(&suite.CRUDMock, "username", "password", 0)
+	user, cerr := suite.UserController.CreateUser(&suite.CRUDMock, "username", "password", 0, models.UserProfile{})
 
 	//assert
 	suite.Nil(user)
@@ -142,7 +230,7 @@ func (suite *UserControllerTestSuite) TestCreateUser_WithErrorCreatingUser_Retur
 	suite.CRUDMock.On("CreateUser", mock.Anything).Return(errors.New(""))
 
 	//act
-	user, cerr := suite.UserController.CreateUser(&suite.CRUDMock, "username", "password", 0)
+	user, cerr := suite.UserController.CreateUser(&suite.CRUDMock, "username", "password", 0, models.UserProfile{})
 
 	//assert
 	suite.Nil(user)
@@ -154,73 +242,224 @@ func (suite *UserControllerTestSuite) TestCreateUser_WithNoErrors_ReturnsNoError
 	username := "username"
 	password := "password"
 	rank := 0
+	profile := models.UserProfile{
+		Email:       " User@Example.com ",
+		DisplayName: " Display Name ",
+		Enabled:     true,
+	}
 
 	hash := []byte("password hash")
 
 	suite.CRUDMock.On("GetUserByUsername", username).Return(nil, nil)
+	suite.CRUDMock.On("GetUserByEmail", mock.Anything).Return(nil, nil)
 	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
 	suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return(hash, nil)
 	suite.CRUDMock.On("CreateUser", mock.Anything).Return(nil)
 
 	//act
-	user, cerr := suite.UserController.CreateUser(&suite.CRUDMock, username, password, rank)
+	user, cerr := suite.UserController.CreateUser(&suite.CRUDMock, username, password, rank, profile)
 
 	//assert
 	suite.Require().NotNil(user)
 	suite.Equal(username, user.Username)
 	suite.Equal(hash, user.PasswordHash)
 	suite.Equal(rank, user.Rank)
+	suite.Equal("user@example.com", user.Email)
+	suite.Equal("Display Name", user.DisplayName)
+	suite.True(user.Enabled)
 	suite.CustomNoError(cerr)
 
 	suite.CRUDMock.AssertCalled(suite.T(), "GetUserByUsername", username)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetUserByEmail", "user@example.com")
 	suite.PasswordCriteriaValidatorMock.AssertCalled(suite.T(), "ValidatePasswordCriteria", password)
 	suite.PasswordHasherMock.AssertCalled(suite.T(), "HashPassword", password)
 	suite.CRUDMock.AssertCalled(suite.T(), "CreateUser", user)
 }
 
+func (suite *UserControllerTestSuite) TestGetUsersWithLesserRank_InvalidPageQueryTestCases() {
+	var query models.PageQuery
+	var expectedErrorSubStrings []string
+
+	testCase := func() {
+		//act
+		users, page, cerr := suite.UserController.GetUsersWithLesserRank(&suite.CRUDMock, 0, query)
+
+		//assert
+		suite.Nil(users)
+		suite.Zero(page)
+		suite.CustomClientError(cerr, expectedErrorSubStrings...)
+		suite.CRUDMock.AssertNotCalled(suite.T(), "GetUsersWithLesserRank", mock.Anything, mock.Anything)
+	}
+
+	query = models.PageQuery{Limit: -1}
+	expectedErrorSubStrings = []string{"limit", "between"}
+	suite.Run("NegativeLimit", testCase)
+
+	query = models.PageQuery{Limit: controllers.MaxPageLimit + 1}
+	expectedErrorSubStrings = []string{"limit", "between"}
+	suite.Run("LimitGreaterThanMax", testCase)
+
+	query = models.PageQuery{SortBy: "password"}
+	expectedErrorSubStrings = []string{"sort", "one of", models.UserSortUsername, models.UserSortRank}
+	suite.Run("InvalidSortField", testCase)
+
+	query = models.PageQuery{SortBy: models.UserSortRank, After: &models.Cursor{Value: "not a rank", Key: "username"}}
+	expectedErrorSubStrings = []string{"cursor", "invalid"}
+	suite.Run("InvalidRankCursor", testCase)
+}
+
 func (suite *UserControllerTestSuite) TestGetUsersWithLesserRank_WithErrorGettingUsersWithLesserRank_ReturnsInternalError() {
 	//arrange
-	suite.CRUDMock.On("GetUsersWithLesserRank", mock.Anything).Return(nil, errors.New(""))
+	suite.CRUDMock.On("GetUsersWithLesserRank", mock.Anything, mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	users, page, cerr := suite.UserController.GetUsersWithLesserRank(&suite.CRUDMock, 0, models.PageQuery{})
+
+	//assert
+	suite.Nil(users)
+	suite.Zero(page)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *UserControllerTestSuite) TestGetUsersWithLesserRank_WithErrorCountingUsersWithLesserRank_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUsersWithLesserRank", mock.Anything, mock.Anything).Return([]*models.User{}, nil)
+	suite.CRUDMock.On("CountUsersWithLesserRank", mock.Anything, mock.Anything).Return(0, errors.New(""))
 
 	//act
-	users, cerr := suite.UserController.GetUsersWithLesserRank(&suite.CRUDMock, 0)
+	users, page, cerr := suite.UserController.GetUsersWithLesserRank(&suite.CRUDMock, 0, models.PageQuery{})
 
 	//assert
 	suite.Nil(users)
+	suite.Zero(page)
 	suite.CustomInternalError(cerr)
 }
 
+func (suite *UserControllerTestSuite) TestGetUsersWithLesserRank_WithMoreUsersThanLimit_ReturnsPageWithNextCursor() {
+	//arrange
+	users := []*models.User{
+		models.CreateUser("username1", 0, nil),
+		models.CreateUser("username2", 1, nil),
+		models.CreateUser("username3", 2, nil),
+	}
+	total := 10
+
+	suite.CRUDMock.On("GetUsersWithLesserRank", mock.Anything, mock.Anything).Return(users, nil)
+	suite.CRUDMock.On("CountUsersWithLesserRank", mock.Anything, mock.Anything).Return(total, nil)
+
+	//act
+	resultUsers, page, cerr := suite.UserController.GetUsersWithLesserRank(&suite.CRUDMock, 5, models.PageQuery{SortBy: models.UserSortRank, Limit: 2})
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Equal(users[:2], resultUsers)
+
+	suite.Equal(total, page.Total)
+	suite.Require().NotNil(page.NextCursor)
+	suite.Equal(models.Cursor{Value: "1", Key: "username2"}, *page.NextCursor)
+}
+
 func (suite *UserControllerTestSuite) TestGetUsersWithLesserRank_WithNoErrors_ReturnsUsers() {
 	//arrange
 	rank := 5
+	query := models.PageQuery{Search: "user"}
+
 	users := []*models.User{models.CreateUser("username", 0, nil)}
-	suite.CRUDMock.On("GetUsersWithLesserRank", mock.Anything).Return(users, nil)
+	suite.CRUDMock.On("GetUsersWithLesserRank", mock.Anything, mock.Anything).Return(users, nil)
+	suite.CRUDMock.On("CountUsersWithLesserRank", mock.Anything, mock.Anything).Return(len(users), nil)
 
 	//act
-	resultUsers, cerr := suite.UserController.GetUsersWithLesserRank(&suite.CRUDMock, rank)
+	resultUsers, page, cerr := suite.UserController.GetUsersWithLesserRank(&suite.CRUDMock, rank, query)
 
 	//assert
 	suite.Equal(users, resultUsers)
+	suite.Equal(models.PageInfo{Total: len(users)}, page)
 	suite.CustomNoError(cerr)
 
-	suite.CRUDMock.AssertCalled(suite.T(), "GetUsersWithLesserRank", rank)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetUsersWithLesserRank", rank, models.PageQuery{
+		Search: query.Search,
+		SortBy: models.UserSortUsername,
+		Limit:  controllers.DefaultPageLimit + 1,
+	})
+	suite.CRUDMock.AssertCalled(suite.T(), "CountUsersWithLesserRank", rank, query.Search)
 }
 
 func (suite *UserControllerTestSuite) TestUpdateUser_ValidateUserTestCases() {
 	suite.runValidateUserTestCases(func(user *models.User) common.CustomError {
-		resUser, cerr := suite.UserController.UpdateUser(&suite.CRUDMock, user.Username, user.Rank)
+		suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(models.CreateUser(user.Username, 0, nil), nil)
+
+		resUser, cerr := suite.UserController.UpdateUser(&suite.CRUDMock, user.Username, user.Rank, models.UserProfileUpdate{
+			Email:       &user.Email,
+			DisplayName: &user.DisplayName,
+		})
 		suite.Nil(resUser)
 
 		return cerr
 	})
 }
 
+func (suite *UserControllerTestSuite) TestUpdateUser_WithErrorGettingUserByUsername_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	user, cerr := suite.UserController.UpdateUser(&suite.CRUDMock, "username", 0, models.UserProfileUpdate{})
+
+	//assert
+	suite.Nil(user)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *UserControllerTestSuite) TestUpdateUser_WhereUserIsNotFound_ReturnsClientError() {
+	//arrange
+	username := "username"
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
+
+	//act
+	user, cerr := suite.UserController.UpdateUser(&suite.CRUDMock, username, 0, models.UserProfileUpdate{})
+
+	//assert
+	suite.Nil(user)
+	suite.CustomClientError(cerr, "user with username", username, "not found")
+	suite.CRUDMock.AssertNotCalled(suite.T(), "UpdateUser", mock.Anything)
+}
+
+func (suite *UserControllerTestSuite) TestUpdateUser_WithErrorGettingUserByEmail_ReturnsInternalError() {
+	//arrange
+	email := "user@example.com"
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(models.CreateUser("username", 0, nil), nil)
+	suite.CRUDMock.On("GetUserByEmail", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	user, cerr := suite.UserController.UpdateUser(&suite.CRUDMock, "username", 0, models.UserProfileUpdate{Email: &email})
+
+	//assert
+	suite.Nil(user)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *UserControllerTestSuite) TestUpdateUser_WithEmailInUseByAnotherUser_ReturnsClientError() {
+	//arrange
+	email := "user@example.com"
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(models.CreateUser("username", 0, nil), nil)
+	suite.CRUDMock.On("GetUserByEmail", mock.Anything).Return(models.CreateUser("other", 0, nil), nil)
+
+	//act
+	user, cerr := suite.UserController.UpdateUser(&suite.CRUDMock, "username", 0, models.UserProfileUpdate{Email: &email})
+
+	//assert
+	suite.Nil(user)
+	suite.CustomClientError(cerr, "email", "already in use")
+}
+
 func (suite *UserControllerTestSuite) TestUpdateUser_WithErrorUpdatingUser_ReturnsInternalError() {
 	//arrange
+	enabled := true
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(models.CreateUser("username", 0, nil), nil)
 	suite.CRUDMock.On("UpdateUser", mock.Anything, mock.Anything).Return(false, errors.New(""))
 
 	//act
-	user, cerr := suite.UserController.UpdateUser(&suite.CRUDMock, "username", 0)
+	user, cerr := suite.UserController.UpdateUser(&suite.CRUDMock, "username", 0, models.UserProfileUpdate{Enabled: &enabled})
 
 	//assert
 	suite.Nil(user)
@@ -230,38 +469,134 @@ func (suite *UserControllerTestSuite) TestUpdateUser_WithErrorUpdatingUser_Retur
 func (suite *UserControllerTestSuite) TestUpdateUser_WithFalseResultUpdatingUser_ReturnsClientError() {
 	//arrange
 	username := "username"
+	enabled := true
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(models.CreateUser(username, 0, nil), nil)
 	suite.CRUDMock.On("UpdateUser", mock.Anything, mock.Anything).Return(false, nil)
 
 	//act
-	user, cerr := suite.UserController.UpdateUser(&suite.CRUDMock, username, 0)
+	user, cerr := suite.UserController.UpdateUser(&suite.CRUDMock, username, 0, models.UserProfileUpdate{Enabled: &enabled})
 
 	//assert
 	suite.Nil(user)
 	suite.CustomClientError(cerr, "user with username", username, "not found")
 }
 
+func (suite *UserControllerTestSuite) TestUpdateUser_WithErrorDeletingSessionsOfDisabledUser_ReturnsInternalError() {
+	//arrange
+	enabled := false
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(models.CreateUser("username", 0, nil), nil)
+	suite.CRUDMock.On("UpdateUser", mock.Anything, mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("DeleteAllUserSessions", mock.Anything).Return(errors.New(""))
+
+	//act
+	user, cerr := suite.UserController.UpdateUser(&suite.CRUDMock, "username", 0, models.UserProfileUpdate{Enabled: &enabled})
+
+	//assert
+	suite.Nil(user)
+	suite.CustomInternalError(cerr)
+}
+
 func (suite *UserControllerTestSuite) TestUpdateUser_WithNoErrors_ReturnsNoError() {
+	var enabled bool
+
+	testCase := func() {
+		//arrange
+		username := "username"
+		rank := 0
+		profile := models.UserProfile{
+			Email:       "user@example.com",
+			DisplayName: "Display Name",
+			Enabled:     enabled,
+		}
+
+		suite.CRUDMock = datamocks.DataCRUD{}
+		suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(models.CreateUser(username, 0, nil), nil)
+		suite.CRUDMock.On("GetUserByEmail", mock.Anything).Return(models.CreateUser(username, 0, nil), nil)
+		suite.CRUDMock.On("UpdateUser", mock.Anything, mock.Anything).Return(true, nil)
+		suite.CRUDMock.On("DeleteAllUserSessions", mock.Anything).Return(nil)
+
+		//act
+		user, cerr := suite.UserController.UpdateUser(&suite.CRUDMock, username, rank, models.UserProfileUpdate{
+			Email:       &profile.Email,
+			DisplayName: &profile.DisplayName,
+			Enabled:     &profile.Enabled,
+		})
+
+		//assert
+		suite.Require().NotNil(user)
+		suite.Equal(username, user.Username)
+		suite.Equal(rank, user.Rank)
+		suite.Equal(profile, user.UserProfile)
+		suite.CustomNoError(cerr)
+
+		suite.CRUDMock.AssertCalled(suite.T(), "GetUserByUsername", username)
+		suite.CRUDMock.AssertCalled(suite.T(), "UpdateUser", user)
+		if enabled {
+			suite.CRUDMock.AssertNotCalled(suite.T(), "DeleteAllUserSessions", mock.Anything)
+		} else {
+			suite.CRUDMock.AssertCalled(suite.T(), "DeleteAllUserSessions", username)
+		}
+	}
+
+	enabled = true
+	suite.Run("EnabledUser_KeepsSessions", testCase)
+
+	enabled = false
+	suite.Run("DisabledUser_DeletesSessions", testCase)
+}
+
+func (suite *UserControllerTestSuite) TestUpdateUser_WithOmittedProfileFields_KeepsCurrentValues() {
 	//arrange
-	username := "username"
-	rank := 0
+	existingUser := models.CreateUser("username", 0, nil)
+	existingUser.UserProfile = models.UserProfile{
+		Email:       "user@example.com",
+		DisplayName: "Display Name",
+		Enabled:     false,
+	}
 
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(existingUser, nil)
+	suite.CRUDMock.On("GetUserByEmail", mock.Anything).Return(existingUser, nil)
 	suite.CRUDMock.On("UpdateUser", mock.Anything, mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("DeleteAllUserSessions", mock.Anything).Return(nil)
 
 	//act
-	user, cerr := suite.UserController.UpdateUser(&suite.CRUDMock, username, rank)
+	user, cerr := suite.UserController.UpdateUser(&suite.CRUDMock, existingUser.Username, 1, models.UserProfileUpdate{})
 
 	//assert
-	suite.Require().NotNil(user)
-	suite.Equal(username, user.Username)
-	suite.Equal(rank, user.Rank)
 	suite.CustomNoError(cerr)
+	suite.Require().NotNil(user)
+	suite.Equal(1, user.Rank)
+	suite.Equal(existingUser.UserProfile, user.UserProfile)
 
 	suite.CRUDMock.AssertCalled(suite.T(), "UpdateUser", user)
 }
 
+func (suite *UserControllerTestSuite) TestUpdateUserPassword_WithErrorGettingUserByUsername_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.UserController.UpdateUserPassword(&suite.CRUDMock, "username", "password")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *UserControllerTestSuite) TestUpdateUserPassword_WhereUserIsNotFound_ReturnsClientError() {
+	//arrange
+	username := "username"
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
+
+	//act
+	cerr := suite.UserController.UpdateUserPassword(&suite.CRUDMock, username, "password")
+
+	//assert
+	suite.CustomClientError(cerr, "user", username, "not found")
+}
+
 func (suite *UserControllerTestSuite) TestUpdateUserPassword_WhereNewPasswordDoesNotMeetCriteria_ReturnsClientError() {
 	//arrange
-	suite.ControllersMock.On("AuthenticateUserWithPassword", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.NoError())
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(models.CreateUser("username", 0, []byte("hash")), nil)
 	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaError(passwordhelpers.ValidatePasswordCriteriaTooShort, ""))
 
 	//act
@@ -271,9 +606,21 @@ func (suite *UserControllerTestSuite) TestUpdateUserPassword_WhereNewPasswordDoe
 	suite.CustomClientError(cerr, "password", "not", "minimum criteria")
 }
 
+func (suite *UserControllerTestSuite) TestUpdateUserPassword_WhereNewPasswordIsBreached_ReturnsClientError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(models.CreateUser("username", 0, []byte("hash")), nil)
+	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaError(passwordhelpers.ValidatePasswordCriteriaBreached, ""))
+
+	//act
+	cerr := suite.UserController.UpdateUserPassword(&suite.CRUDMock, "username", "password")
+
+	//assert
+	suite.CustomClientError(cerr, "password", "too common", "data breach")
+}
+
 func (suite *UserControllerTestSuite) TestUpdateUserPassword_WithErrorHashingNewPassword_ReturnsInternalError() {
 	//arrange
-	suite.ControllersMock.On("AuthenticateUserWithPassword", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.NoError())
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(models.CreateUser("username", 0, []byte("hash")), nil)
 	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
 	suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return(nil, errors.New(""))
 
@@ -286,10 +633,10 @@ func (suite *UserControllerTestSuite) TestUpdateUserPassword_WithErrorHashingNew
 
 func (suite *UserControllerTestSuite) TestUpdateUserPassword_WithErrorUpdatingUserPassword_ReturnsInternalError() {
 	//arrange
-	suite.ControllersMock.On("AuthenticateUserWithPassword", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.NoError())
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(models.CreateUser("username", 0, []byte("hash")), nil)
 	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
 	suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return(nil, nil)
-	suite.CRUDMock.On("UpdateUserPassword", mock.Anything, mock.Anything).Return(false, errors.New(""))
+	suite.CRUDMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything).Return(false, errors.New(""))
 
 	//act
 	cerr := suite.UserController.UpdateUserPassword(&suite.CRUDMock, "username", "password")
@@ -304,10 +651,10 @@ func (suite *UserControllerTestSuite) TestUpdateUserPassword_WithNoErrors_Return
 	password := "password"
 	passwordHash := []byte("hashed password")
 
-	suite.ControllersMock.On("AuthenticateUserWithPassword", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.NoError())
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(models.CreateUser(username, 0, []byte("hash")), nil)
 	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
 	suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return(passwordHash, nil)
-	suite.CRUDMock.On("UpdateUserPassword", mock.Anything, mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
 
 	//act
 	cerr := suite.UserController.UpdateUserPassword(&suite.CRUDMock, username, password)
@@ -317,7 +664,137 @@ func (suite *UserControllerTestSuite) TestUpdateUserPassword_WithNoErrors_Return
 
 	suite.PasswordCriteriaValidatorMock.AssertCalled(suite.T(), "ValidatePasswordCriteria", password)
 	suite.PasswordHasherMock.AssertCalled(suite.T(), "HashPassword", password)
-	suite.CRUDMock.AssertCalled(suite.T(), "UpdateUserPassword", username, passwordHash)
+	suite.CRUDMock.AssertCalled(suite.T(), "UpdateUserPassword", username, passwordHash, mock.MatchedBy(func(updatedAt time.Time) bool {
+		return time.Since(updatedAt) < time.Minute
+	}))
+
+	//the history is disabled, so nothing is remembered
+	suite.PasswordHasherMock.AssertNotCalled(suite.T(), "ComparePasswords", mock.Anything, mock.Anything)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "SavePreviousPassword", mock.Anything)
+}
+
+func (suite *UserControllerTestSuite) TestUpdateUserPassword_WithErrorGettingPreviousPasswords_ReturnsInternalError() {
+	//arrange
+	suite.setHistorySize(3)
+
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(models.CreateUser("username", 0, []byte("hash")), nil)
+	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
+	suite.CRUDMock.On("GetLatestUserPreviousPasswords", mock.Anything, mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.UserController.UpdateUserPassword(&suite.CRUDMock, "username", "password")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *UserControllerTestSuite) TestUpdateUserPassword_WhereNewPasswordIsInHistory_ReturnsClientError() {
+	var matchingHash []byte
+
+	testCase := func() {
+		//arrange
+		suite.SetupTest()
+		suite.setHistorySize(3)
+
+		user := models.CreateUser("username", 0, []byte("current hash"))
+		previous := []*models.PreviousPassword{
+			models.CreateNewPreviousPassword(user.Username, []byte("previous hash 1")),
+			models.CreateNewPreviousPassword(user.Username, []byte("previous hash 2")),
+		}
+
+		suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(user, nil)
+		suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
+		suite.CRUDMock.On("GetLatestUserPreviousPasswords", mock.Anything, mock.Anything).Return(previous, nil)
+		suite.PasswordHasherMock.On("ComparePasswords", matchingHash, mock.Anything).Return(nil)
+		suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(errors.New(""))
+
+		//act
+		cerr := suite.UserController.UpdateUserPassword(&suite.CRUDMock, user.Username, "password")
+
+		//assert
+		suite.CustomClientError(cerr, "password cannot match any of the last 3 passwords")
+
+		suite.CRUDMock.AssertCalled(suite.T(), "GetLatestUserPreviousPasswords", user.Username, 2)
+		suite.CRUDMock.AssertNotCalled(suite.T(), "UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything)
+	}
+
+	matchingHash = []byte("current hash")
+	suite.Run("MatchesCurrentPassword", testCase)
+
+	matchingHash = []byte("previous hash 2")
+	suite.Run("MatchesPreviousPassword", testCase)
+}
+
+func (suite *UserControllerTestSuite) TestUpdateUserPassword_WithErrorSavingPreviousPassword_ReturnsInternalError() {
+	//arrange
+	suite.setHistorySize(3)
+
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(models.CreateUser("username", 0, []byte("hash")), nil)
+	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
+	suite.CRUDMock.On("GetLatestUserPreviousPasswords", mock.Anything, mock.Anything).Return([]*models.PreviousPassword{}, nil)
+	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(errors.New(""))
+	suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return([]byte("new hash"), nil)
+	suite.CRUDMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	suite.CRUDMock.On("SavePreviousPassword", mock.Anything).Return(errors.New(""))
+
+	//act
+	cerr := suite.UserController.UpdateUserPassword(&suite.CRUDMock, "username", "password")
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *UserControllerTestSuite) TestUpdateUserPassword_WithHistory_SavesReplacedPasswordAndDeletesPasswordsNoLongerInHistory() {
+	var previous []*models.PreviousPassword
+	var expectedCutoff *time.Time
+
+	testCase := func() {
+		//arrange
+		suite.SetupTest()
+		suite.setHistorySize(3)
+
+		user := models.CreateUser("username", 0, []byte("current hash"))
+
+		suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(user, nil)
+		suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
+		suite.CRUDMock.On("GetLatestUserPreviousPasswords", mock.Anything, mock.Anything).Return(previous, nil)
+		suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(errors.New(""))
+		suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return([]byte("new hash"), nil)
+		suite.CRUDMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+		suite.CRUDMock.On("SavePreviousPassword", mock.Anything).Return(nil)
+		suite.CRUDMock.On("DeleteUserPreviousPasswordsReplacedBefore", mock.Anything, mock.Anything).Return(nil)
+
+		//act
+		cerr := suite.UserController.UpdateUserPassword(&suite.CRUDMock, user.Username, "password")
+
+		//assert
+		suite.CustomNoError(cerr)
+
+		suite.CRUDMock.AssertCalled(suite.T(), "SavePreviousPassword", mock.MatchedBy(func(pp *models.PreviousPassword) bool {
+			return pp.Username == user.Username && string(pp.PasswordHash) == "current hash"
+		}))
+
+		if expectedCutoff == nil {
+			suite.CRUDMock.AssertNotCalled(suite.T(), "DeleteUserPreviousPasswordsReplacedBefore", mock.Anything, mock.Anything)
+		} else {
+			suite.CRUDMock.AssertCalled(suite.T(), "DeleteUserPreviousPasswordsReplacedBefore", user.Username, *expectedCutoff)
+		}
+	}
+
+	now := time.Now().UTC()
+
+	previous = []*models.PreviousPassword{
+		models.CreatePreviousPassword("username", []byte("previous hash 1"), now.Add(-time.Hour)),
+	}
+	expectedCutoff = nil
+	suite.Run("HistoryNotFull_KeepsAllPasswords", testCase)
+
+	previous = []*models.PreviousPassword{
+		models.CreatePreviousPassword("username", []byte("previous hash 1"), now.Add(-time.Hour)),
+		models.CreatePreviousPassword("username", []byte("previous hash 2"), now.Add(-2*time.Hour)),
+	}
+	expectedCutoff = &previous[0].ReplacedAt
+	suite.Run("HistoryFull_DeletesOldestPassword", testCase)
 }
 
 func (suite *UserControllerTestSuite) TestUpdateUserPasswordWithAuth_WithClientErrorAuthenticatingUser_ReturnsClientError() {
@@ -342,13 +819,36 @@ func (suite *UserControllerTestSuite) TestUpdateUserPasswordWithAuth_WithNonClie
 	suite.CustomInternalError(cerr)
 }
 
+func (suite *UserControllerTestSuite) TestUpdateUserPasswordWithAuth_WherePasswordWasChangedTooRecently_ReturnsClientError() {
+	//arrange
+	viper.Set("password_criteria", config.PasswordCriteriaConfig{
+		MinAge: 3600,
+	})
+
+	user := models.CreateUser("username", 0, []byte("hash"))
+	suite.ControllersMock.On("AuthenticateUserWithPassword", mock.Anything, mock.Anything, mock.Anything).Return(user, common.NoError())
+
+	//act
+	cerr := suite.UserController.UpdateUserPasswordWithAuth(&suite.CRUDMock, user.Username, "old password", "new password")
+
+	//assert
+	suite.CustomClientError(cerr, "password was changed too recently", "try again in")
+	suite.ControllersMock.AssertNotCalled(suite.T(), "UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func (suite *UserControllerTestSuite) TestUpdateUserPasswordWithAuth_WithNoErrors_ReturnsResultFromUpdateUserPassword() {
 	//arrange
 	username := "username"
 	oldPassword := "old password"
 	newPassword := "new password"
 
-	suite.ControllersMock.On("AuthenticateUserWithPassword", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.NoError())
+	viper.Set("password_criteria", config.PasswordCriteriaConfig{
+		MinAge: 3600,
+	})
+
+	user := models.CreateUser(username, 0, []byte("hash"))
+	user.PasswordUpdatedAt = time.Now().Add(-2 * time.Hour)
+	suite.ControllersMock.On("AuthenticateUserWithPassword", mock.Anything, mock.Anything, mock.Anything).Return(user, common.NoError())
 
 	cerr := common.ClientError("update user password error")
 	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything).Return(cerr)
@@ -13,8 +13,19 @@ import (
 type CoreUserRoleController struct{}
 
 func (c CoreUserRoleController) CreateUserRole(CRUD UserRoleControllerCRUD, role *models.UserRole) common.CustomError {
+	//give the user the client's default role if none were chosen
+	if len(role.Roles) == 0 {
+		definition, cerr := getDefaultRoleDefinition(CRUD, role.ClientUID)
+		if cerr.Type != common.ErrorTypeNone {
+			return cerr
+		}
+		if definition != nil {
+			role.Roles = []string{definition.Name}
+		}
+	}
+
 	//validate the model
-	cerr := c.validateUserRole(role)
+	cerr := c.validateUserRole(CRUD, role)
 	if cerr.Type != common.ErrorTypeNone {
 		return cerr
 	}
@@ -39,20 +50,44 @@ func (c CoreUserRoleController) CreateUserRole(CRUD UserRoleControllerCRUD, role
 	return common.NoError()
 }
 
-func (c CoreUserRoleController) GetUserRolesWithLesserRankByClientUID(CRUD UserRoleControllerCRUD, clientUID uuid.UUID, rank int) ([]*models.UserRole, common.CustomError) {
-	//get the roles
-	roles, err := CRUD.GetUserRolesWithLesserRankByClientUID(clientUID, rank)
+func (c CoreUserRoleController) GetUserRolesWithLesserRankByClientUID(CRUD UserRoleControllerCRUD, clientUID uuid.UUID, rank int, query models.PageQuery) ([]*models.UserRole, models.PageInfo, common.CustomError) {
+	//validate the page query
+	cerr := validatePageQuery(&query, models.UserRoleSortUsername, models.UserRoleSortRole)
+	if cerr.Type != common.ErrorTypeNone {
+		return nil, models.PageInfo{}, cerr
+	}
+
+	//get the roles, fetching an extra one to check if there is a next page
+	limit := query.Limit
+	query.Limit++
+
+	roles, err := CRUD.GetUserRolesWithLesserRankByClientUID(clientUID, rank, query)
 	if err != nil {
 		log.Println("error getting user roles with lesser rank by client uid", err)
-		return nil, common.InternalError()
+		return nil, models.PageInfo{}, common.InternalError()
+	}
+
+	//count the roles
+	total, err := CRUD.CountUserRolesWithLesserRankByClientUID(clientUID, rank, query.Search)
+	if err != nil {
+		log.Println("error counting user roles with lesser rank by client uid", err)
+		return nil, models.PageInfo{}, common.InternalError()
 	}
 
-	return roles, common.NoError()
+	page := models.PageInfo{Total: total}
+	if len(roles) > limit {
+		roles = roles[:limit]
+
+		cursor := roles[limit-1].GetCursor(query.SortBy)
+		page.NextCursor = &cursor
+	}
+
+	return roles, page, common.NoError()
 }
 
 func (c CoreUserRoleController) UpdateUserRole(CRUD UserRoleControllerCRUD, role *models.UserRole) common.CustomError {
 	//validate the model
-	cerr := c.validateUserRole(role)
+	cerr := c.validateUserRole(CRUD, role)
 	if cerr.Type != common.ErrorTypeNone {
 		return cerr
 	}
@@ -88,15 +123,37 @@ func (c CoreUserRoleController) DeleteUserRole(CRUD UserRoleControllerCRUD, clie
 	return common.NoError()
 }
 
-func (CoreUserRoleController) validateUserRole(role *models.UserRole) common.CustomError {
-	verr := role.Validate()
+func (CoreUserRoleController) validateUserRole(CRUD UserRoleControllerCRUD, role *models.UserRole) common.CustomError {
+	return validateRoles(CRUD, role.ClientUID, role.Roles, role.Validate())
+}
 
+// validateRoles converts the validate code of a user-role or group-role into a client error,
+// then validates each of the roles is one the client defines.
+func validateRoles(CRUD models.RoleDefinitionCRUD, clientUID uuid.UUID, roles []string, verr int) common.CustomError {
+	if verr&models.ValidateUserRoleNoRoles != 0 {
+		return common.ClientError("at least one role must be given")
+	}
 	if verr&models.ValidateUserRoleEmptyRole != 0 {
 		return common.ClientError("role cannot be empty")
 	}
 	if verr&models.ValidateUserRoleRoleTooLong != 0 {
 		return common.ClientError(fmt.Sprint("role cannot be longer than ", models.UserRoleRoleMaxLength, " characters"))
 	}
+	if verr&models.ValidateUserRoleDuplicateRole != 0 {
+		return common.ClientError("roles cannot contain duplicates")
+	}
+
+	//validate each role is one the client defines
+	for _, name := range roles {
+		definition, err := CRUD.GetRoleDefinitionByClientUIDAndName(clientUID, name)
+		if err != nil {
+			log.Println(common.ChainError("error getting role definition by client uid and name", err))
+			return common.InternalError()
+		}
+		if definition == nil {
+			return common.ClientError(fmt.Sprintf("role %s is not defined for client %s", name, clientUID.String()))
+		}
+	}
 
 	return common.NoError()
 }
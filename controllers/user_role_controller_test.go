@@ -26,6 +26,17 @@ func (suite *UserRoleControllerTestSuite) SetupTest() {
 }
 
 func (suite *UserRoleControllerTestSuite) runValidateUserRoleTestCases(validateFunc func(role *models.UserRole) common.CustomError) {
+	suite.Run("NoRoles_ReturnsClientError", func() {
+		//arrange
+		role := models.CreateUserRole(uuid.New(), "username")
+
+		//act
+		cerr := validateFunc(role)
+
+		//assert
+		suite.CustomClientError(cerr, "at least one role")
+	})
+
 	suite.Run("EmptyRole_ReturnsClientError", func() {
 		//arrange
 		role := models.CreateUserRole(uuid.New(), "username", "")
@@ -47,17 +58,94 @@ func (suite *UserRoleControllerTestSuite) runValidateUserRoleTestCases(validateF
 		//assert
 		suite.CustomClientError(cerr, "role", "cannot be longer", fmt.Sprint(models.UserRoleRoleMaxLength))
 	})
+
+	suite.Run("DuplicateRole_ReturnsClientError", func() {
+		//arrange
+		role := models.CreateUserRole(uuid.New(), "username", "role", "role")
+
+		//act
+		cerr := validateFunc(role)
+
+		//assert
+		suite.CustomClientError(cerr, "roles", "duplicates")
+	})
 }
 
 func (suite *UserRoleControllerTestSuite) TestCreateUserRole_ValidateUserRoleTestCases() {
+	suite.CRUDMock.On("GetRoleDefinitionsByClientUID", mock.Anything).Return([]*models.RoleDefinition{}, nil)
+
 	suite.runValidateUserRoleTestCases(func(role *models.UserRole) common.CustomError {
 		return suite.UserRoleController.CreateUserRole(&suite.CRUDMock, role)
 	})
 }
 
+func (suite *UserRoleControllerTestSuite) TestCreateUserRole_WithErrorGettingDefaultRoleDefinition_ReturnsInternalError() {
+	//arrange
+	role := models.CreateUserRole(uuid.New(), "username")
+	suite.CRUDMock.On("GetRoleDefinitionsByClientUID", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.UserRoleController.CreateUserRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *UserRoleControllerTestSuite) TestCreateUserRole_WithNoRoles_GivesUserDefaultRole() {
+	//arrange
+	role := models.CreateUserRole(uuid.New(), "username")
+	definitions := []*models.RoleDefinition{
+		models.CreateRoleDefinition(role.ClientUID, "admin", "", false),
+		models.CreateRoleDefinition(role.ClientUID, "member", "", true),
+	}
+
+	suite.CRUDMock.On("GetRoleDefinitionsByClientUID", mock.Anything).Return(definitions, nil)
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(definitions[1], nil)
+	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("CreateUserRole", mock.Anything).Return(nil)
+
+	//act
+	cerr := suite.UserRoleController.CreateUserRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Equal([]string{"member"}, role.Roles)
+
+	suite.CRUDMock.AssertCalled(suite.T(), "GetRoleDefinitionsByClientUID", role.ClientUID)
+	suite.CRUDMock.AssertCalled(suite.T(), "CreateUserRole", role)
+}
+
+func (suite *UserRoleControllerTestSuite) TestCreateUserRole_WithErrorGettingRoleDefinition_ReturnsInternalError() {
+	//arrange
+	role := models.CreateUserRole(uuid.New(), "username", "role")
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	cerr := suite.UserRoleController.CreateUserRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *UserRoleControllerTestSuite) TestCreateUserRole_WhereAnyRoleIsNotDefined_ReturnsClientError() {
+	//arrange
+	role := models.CreateUserRole(uuid.New(), "username", "editor", "admn")
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, "editor").Return(&models.RoleDefinition{}, nil)
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, "admn").Return(nil, nil)
+
+	//act
+	cerr := suite.UserRoleController.CreateUserRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomClientError(cerr, "role", "admn", "not defined", role.ClientUID.String())
+	suite.CRUDMock.AssertCalled(suite.T(), "GetRoleDefinitionByClientUIDAndName", role.ClientUID, "admn")
+	suite.CRUDMock.AssertNotCalled(suite.T(), "CreateUserRole", mock.Anything)
+}
+
 func (suite *UserRoleControllerTestSuite) TestCreateUserRole_WithErrorGettingUserRoleByUsernameAndClientUID_ReturnsInternalError() {
 	//arrange
 	role := models.CreateUserRole(uuid.New(), "username", "role")
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
 	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(nil, errors.New(""))
 
 	//act
@@ -70,6 +158,7 @@ func (suite *UserRoleControllerTestSuite) TestCreateUserRole_WithErrorGettingUse
 func (suite *UserRoleControllerTestSuite) TestCreateUser_WhereUserAlreadyHasRoleForClient_ReturnsClientError() {
 	//arrange
 	role := models.CreateUserRole(uuid.New(), "username", "role")
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
 	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(&models.UserRole{}, nil)
 
 	//act
@@ -82,6 +171,7 @@ func (suite *UserRoleControllerTestSuite) TestCreateUser_WhereUserAlreadyHasRole
 func (suite *UserRoleControllerTestSuite) TestCreateUserRole_WithErrorCreatingUserRoleByUsernameAndClientUID_ReturnsInternalError() {
 	//arrange
 	role := models.CreateUserRole(uuid.New(), "username", "role")
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
 
 	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(nil, nil)
 	suite.CRUDMock.On("CreateUserRole", mock.Anything).Return(errors.New(""))
@@ -96,6 +186,7 @@ func (suite *UserRoleControllerTestSuite) TestCreateUserRole_WithErrorCreatingUs
 func (suite *UserRoleControllerTestSuite) TestCreateUserRole_WithNoErrors_ReturnsNoError() {
 	//arrange
 	role := models.CreateUserRole(uuid.New(), "username", "role")
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
 
 	suite.CRUDMock.On("GetUserRoleByClientUIDAndUsername", mock.Anything, mock.Anything).Return(nil, nil)
 	suite.CRUDMock.On("CreateUserRole", mock.Anything).Return(nil)
@@ -110,34 +201,109 @@ func (suite *UserRoleControllerTestSuite) TestCreateUserRole_WithNoErrors_Return
 	suite.CRUDMock.AssertCalled(suite.T(), "CreateUserRole", role)
 }
 
+func (suite *UserRoleControllerTestSuite) TestGetUserRolesWithLesserRankByClientUID_InvalidPageQueryTestCases() {
+	var query models.PageQuery
+	var expectedErrorSubStrings []string
+
+	testCase := func() {
+		//act
+		roles, page, cerr := suite.UserRoleController.GetUserRolesWithLesserRankByClientUID(&suite.CRUDMock, uuid.New(), 0, query)
+
+		//assert
+		suite.Nil(roles)
+		suite.Zero(page)
+		suite.CustomClientError(cerr, expectedErrorSubStrings...)
+		suite.CRUDMock.AssertNotCalled(suite.T(), "GetUserRolesWithLesserRankByClientUID", mock.Anything, mock.Anything, mock.Anything)
+	}
+
+	query = models.PageQuery{Limit: -1}
+	expectedErrorSubStrings = []string{"limit", "between"}
+	suite.Run("NegativeLimit", testCase)
+
+	query = models.PageQuery{Limit: controllers.MaxPageLimit + 1}
+	expectedErrorSubStrings = []string{"limit", "between"}
+	suite.Run("LimitGreaterThanMax", testCase)
+
+	query = models.PageQuery{SortBy: "client"}
+	expectedErrorSubStrings = []string{"sort", "one of", models.UserRoleSortUsername, models.UserRoleSortRole}
+	suite.Run("InvalidSortField", testCase)
+}
+
 func (suite *UserRoleControllerTestSuite) TestGetUserRolesWithLesserRankByClientUID_WithErrorGettingUserRolesByClientUID_ReturnsInternalError() {
 	//arrange
-	suite.CRUDMock.On("GetUserRolesWithLesserRankByClientUID", mock.Anything, mock.Anything).Return(nil, errors.New(""))
+	suite.CRUDMock.On("GetUserRolesWithLesserRankByClientUID", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New(""))
 
 	//act
-	clients, cerr := suite.UserRoleController.GetUserRolesWithLesserRankByClientUID(&suite.CRUDMock, uuid.New(), 0)
+	roles, page, cerr := suite.UserRoleController.GetUserRolesWithLesserRankByClientUID(&suite.CRUDMock, uuid.New(), 0, models.PageQuery{})
 
 	//assert
-	suite.Nil(clients)
+	suite.Nil(roles)
+	suite.Zero(page)
 	suite.CustomInternalError(cerr)
 }
 
+func (suite *UserRoleControllerTestSuite) TestGetUserRolesWithLesserRankByClientUID_WithErrorCountingUserRolesByClientUID_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserRolesWithLesserRankByClientUID", mock.Anything, mock.Anything, mock.Anything).Return([]*models.UserRole{}, nil)
+	suite.CRUDMock.On("CountUserRolesWithLesserRankByClientUID", mock.Anything, mock.Anything, mock.Anything).Return(0, errors.New(""))
+
+	//act
+	roles, page, cerr := suite.UserRoleController.GetUserRolesWithLesserRankByClientUID(&suite.CRUDMock, uuid.New(), 0, models.PageQuery{})
+
+	//assert
+	suite.Nil(roles)
+	suite.Zero(page)
+	suite.CustomInternalError(cerr)
+}
+
+func (suite *UserRoleControllerTestSuite) TestGetUserRolesWithLesserRankByClientUID_WithMoreUserRolesThanLimit_ReturnsPageWithNextCursor() {
+	//arrange
+	clientUID := uuid.New()
+	roles := []*models.UserRole{
+		models.CreateUserRole(clientUID, "username1", "role1"),
+		models.CreateUserRole(clientUID, "username2", "role2"),
+	}
+	total := 10
+
+	suite.CRUDMock.On("GetUserRolesWithLesserRankByClientUID", mock.Anything, mock.Anything, mock.Anything).Return(roles, nil)
+	suite.CRUDMock.On("CountUserRolesWithLesserRankByClientUID", mock.Anything, mock.Anything, mock.Anything).Return(total, nil)
+
+	//act
+	resultRoles, page, cerr := suite.UserRoleController.GetUserRolesWithLesserRankByClientUID(&suite.CRUDMock, clientUID, 5, models.PageQuery{SortBy: models.UserRoleSortRole, Limit: 1})
+
+	//assert
+	suite.CustomNoError(cerr)
+	suite.Equal(roles[:1], resultRoles)
+
+	suite.Equal(total, page.Total)
+	suite.Require().NotNil(page.NextCursor)
+	suite.Equal(models.Cursor{Value: "role1", Key: "username1"}, *page.NextCursor)
+}
+
 func (suite *UserRoleControllerTestSuite) TestGetUserRolesWithLesserRankByClientUID_WithNoErrors_ReturnsUserRoles() {
 	//arrange
 	clientUID := uuid.New()
 	rank := 5
+	query := models.PageQuery{Search: "user"}
 
 	roles := []*models.UserRole{models.CreateUserRole(clientUID, "username", "role")}
-	suite.CRUDMock.On("GetUserRolesWithLesserRankByClientUID", mock.Anything, mock.Anything).Return(roles, nil)
+	suite.CRUDMock.On("GetUserRolesWithLesserRankByClientUID", mock.Anything, mock.Anything, mock.Anything).Return(roles, nil)
+	suite.CRUDMock.On("CountUserRolesWithLesserRankByClientUID", mock.Anything, mock.Anything, mock.Anything).Return(len(roles), nil)
 
 	//act
-	resultRoles, cerr := suite.UserRoleController.GetUserRolesWithLesserRankByClientUID(&suite.CRUDMock, clientUID, rank)
+	resultRoles, page, cerr := suite.UserRoleController.GetUserRolesWithLesserRankByClientUID(&suite.CRUDMock, clientUID, rank, query)
 
 	//assert
 	suite.Equal(roles, resultRoles)
+	suite.Equal(models.PageInfo{Total: len(roles)}, page)
 	suite.CustomNoError(cerr)
 
-	suite.CRUDMock.AssertCalled(suite.T(), "GetUserRolesWithLesserRankByClientUID", clientUID, rank)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetUserRolesWithLesserRankByClientUID", clientUID, rank, models.PageQuery{
+		Search: query.Search,
+		SortBy: models.UserRoleSortUsername,
+		Limit:  controllers.DefaultPageLimit + 1,
+	})
+	suite.CRUDMock.AssertCalled(suite.T(), "CountUserRolesWithLesserRankByClientUID", clientUID, rank, query.Search)
 }
 
 func (suite *UserRoleControllerTestSuite) TestUpdateUserRole_ValidateUserRoleTestCases() {
@@ -146,9 +312,23 @@ func (suite *UserRoleControllerTestSuite) TestUpdateUserRole_ValidateUserRoleTes
 	})
 }
 
+func (suite *UserRoleControllerTestSuite) TestUpdateUserRole_WhereRoleIsNotDefined_ReturnsClientError() {
+	//arrange
+	role := models.CreateUserRole(uuid.New(), "username", "admn")
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(nil, nil)
+
+	//act
+	cerr := suite.UserRoleController.UpdateUserRole(&suite.CRUDMock, role)
+
+	//assert
+	suite.CustomClientError(cerr, "role", "admn", "not defined", role.ClientUID.String())
+	suite.CRUDMock.AssertNotCalled(suite.T(), "UpdateUserRole", mock.Anything)
+}
+
 func (suite *UserRoleControllerTestSuite) TestUpdateUserRole_WithErrorUpdatingUserRole_ReturnsInternalError() {
 	//arrange
 	role := models.CreateUserRole(uuid.New(), "username", "role")
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
 	suite.CRUDMock.On("UpdateUserRole", mock.Anything).Return(false, errors.New(""))
 
 	//act
@@ -161,18 +341,20 @@ func (suite *UserRoleControllerTestSuite) TestUpdateUserRole_WithErrorUpdatingUs
 func (suite *UserRoleControllerTestSuite) TestUpdateUserRole_WithFalseResultUpdatingUserRole_ReturnsClientError() {
 	//arrange
 	role := models.CreateUserRole(uuid.New(), "username", "role")
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
 	suite.CRUDMock.On("UpdateUserRole", mock.Anything).Return(false, nil)
 
 	//act
 	cerr := suite.UserRoleController.UpdateUserRole(&suite.CRUDMock, role)
 
 	//assert
-	suite.CustomClientError(cerr, "no role found", role.Username, role.Role)
+	suite.CustomClientError(cerr, "no role found", role.Username, role.ClientUID.String())
 }
 
 func (suite *UserRoleControllerTestSuite) TestUpdateUserRole_WithNoErrors_ReturnsNoError() {
 	//arrange
 	role := models.CreateUserRole(uuid.New(), "username", "role")
+	suite.CRUDMock.On("GetRoleDefinitionByClientUIDAndName", mock.Anything, mock.Anything).Return(&models.RoleDefinition{}, nil)
 	suite.CRUDMock.On("UpdateUserRole", mock.Anything).Return(true, nil)
 
 	//act
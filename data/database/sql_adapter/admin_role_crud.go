@@ -0,0 +1,263 @@
+package sqladapter
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+)
+
+// CreateAdminRoleTable creates the admin role table in the database.
+// Returns any errors.
+func (crud *SQLCRUD) CreateAdminRoleTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateAdminRoleTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create admin role table script", err)
+	}
+
+	return err
+}
+
+// DropAdminRoleTable drops the admin role table from the database.
+// Returns any errors.
+func (crud *SQLCRUD) DropAdminRoleTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropAdminRoleTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop admin role table script", err)
+	}
+
+	return err
+}
+
+// CreateUserAdminRoleTable creates the table of admin roles given to users in the database.
+// Returns any errors.
+func (crud *SQLCRUD) CreateUserAdminRoleTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateUserAdminRoleTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create user admin role table script", err)
+	}
+
+	return err
+}
+
+// DropUserAdminRoleTable drops the table of admin roles given to users from the database.
+// Returns any errors.
+func (crud *SQLCRUD) DropUserAdminRoleTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropUserAdminRoleTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop user admin role table script", err)
+	}
+
+	return err
+}
+
+func (crud *SQLCRUD) CreateAdminRole(role *models.AdminRole) error {
+	//validate the admin role model
+	verr := role.Validate()
+	if verr != models.ValidateAdminRoleValid {
+		return errors.New(fmt.Sprint("error validating admin role model:", verr))
+	}
+
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateAdminRoleScript(),
+		role.Name, role.Description, strings.Join(role.Permissions, " "),
+	)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create admin role statement", err)
+	}
+
+	return nil
+}
+
+func (crud *SQLCRUD) GetAdminRoles() ([]*models.AdminRole, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetAdminRolesScript())
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get admin roles query", err)
+	}
+	defer rows.Close()
+
+	return readAdminRolesData(rows)
+}
+
+func (crud *SQLCRUD) GetAdminRoleByName(name string) (*models.AdminRole, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetAdminRoleByNameScript(), name)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get admin role by name query", err)
+	}
+	defer rows.Close()
+
+	return readAdminRoleData(rows)
+}
+
+func (crud *SQLCRUD) UpdateAdminRole(role *models.AdminRole) (bool, error) {
+	//validate the admin role model
+	verr := role.Validate()
+	if verr != models.ValidateAdminRoleValid {
+		return false, errors.New(fmt.Sprint("error validating admin role model:", verr))
+	}
+
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.UpdateAdminRoleScript(),
+		role.Name, role.Description, strings.Join(role.Permissions, " "),
+	)
+	cancel()
+
+	if err != nil {
+		return false, common.ChainError("error executing update admin role statement", err)
+	}
+
+	count, _ := res.RowsAffected()
+	return count > 0, nil
+}
+
+func (crud *SQLCRUD) DeleteAdminRole(name string) (bool, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DeleteAdminRoleScript(), name)
+	cancel()
+
+	if err != nil {
+		return false, common.ChainError("error executing delete admin role statement", err)
+	}
+
+	count, _ := res.RowsAffected()
+	return count > 0, nil
+}
+
+func (crud *SQLCRUD) GetAdminRolesByUsername(username string) ([]*models.AdminRole, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetAdminRolesByUsernameScript(), username)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get admin roles by username query", err)
+	}
+	defer rows.Close()
+
+	return readAdminRolesData(rows)
+}
+
+func (crud *SQLCRUD) GetAdminRoleUsernames(name string) ([]string, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetAdminRoleUsernamesScript(), name)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get admin role usernames query", err)
+	}
+	defer rows.Close()
+
+	usernames := []string{}
+	for rows.Next() {
+		var username string
+		err := rows.Scan(&username)
+		if err != nil {
+			return nil, common.ChainError("error reading row", err)
+		}
+		usernames = append(usernames, username)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, common.ChainError("error preparing next row", err)
+	}
+
+	return usernames, nil
+}
+
+func (crud *SQLCRUD) SetUserAdminRoles(username string, names []string) error {
+	//remove the user's current admin roles
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DeleteUserAdminRolesScript(), username)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing delete user admin roles statement", err)
+	}
+
+	//admin roles can only be given to existing users
+	user, err := crud.GetUserByUsername(username)
+	if err != nil {
+		return common.ChainError("error getting user by username", err)
+	}
+	if user == nil {
+		return errors.New("admin role user does not exist")
+	}
+
+	//give the user each of the new admin roles (nothing is inserted for admin roles that don't exist)
+	for _, name := range names {
+		ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+		_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateUserAdminRoleScript(), username, name)
+		cancel()
+
+		if err != nil {
+			return common.ChainError("error executing create user admin role statement", err)
+		}
+	}
+
+	return nil
+}
+
+func readAdminRolesData(rows *sql.Rows) ([]*models.AdminRole, error) {
+	roles := []*models.AdminRole{}
+	for {
+		role, err := readAdminRoleData(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		if role == nil {
+			break
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+func readAdminRoleData(rows *sql.Rows) (*models.AdminRole, error) {
+	//check if there was a result
+	if !rows.Next() {
+		err := rows.Err()
+		if err != nil {
+			return nil, common.ChainError("error preparing next row", err)
+		}
+
+		//return no results
+		return nil, nil
+	}
+
+	//get the result
+	var permissions string
+	role := &models.AdminRole{}
+	err := rows.Scan(&role.Name, &role.Description, &permissions)
+	if err != nil {
+		return nil, common.ChainError("error reading row", err)
+	}
+
+	//the permissions are stored space separated
+	role.Permissions = strings.Fields(permissions)
+
+	return role, nil
+}
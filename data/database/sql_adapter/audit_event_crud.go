@@ -0,0 +1,123 @@
+package sqladapter
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+)
+
+// CreateAuditEventTable creates the audit event table in the database.
+// Returns any errors.
+func (crud *SQLCRUD) CreateAuditEventTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateAuditEventTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create audit event table script", err)
+	}
+
+	return err
+}
+
+// DropAuditEventTable drops the audit event table from the database.
+// Returns any errors.
+func (crud *SQLCRUD) DropAuditEventTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropAuditEventTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop audit event table script", err)
+	}
+
+	return err
+}
+
+func (crud *SQLCRUD) SaveAuditEvent(event *models.AuditEvent) error {
+	//validate the audit event model
+	verr := event.Validate()
+	if verr != models.ValidateAuditEventValid {
+		return errors.New(fmt.Sprint("error validating audit event model:", verr))
+	}
+
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.SaveAuditEventScript(),
+		event.ID, event.Actor, event.Action, event.Target, event.IPAddress, event.Timestamp,
+	)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing save audit event statement", err)
+	}
+
+	return nil
+}
+
+func (crud *SQLCRUD) GetAuditEvents(filter models.AuditEventFilter) ([]*models.AuditEvent, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetAuditEventsScript(),
+		filter.Actor, filter.Action, filter.Target, nullTime(filter.Since), nullTime(filter.Until), filter.Limit, filter.Offset,
+	)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get audit events query", err)
+	}
+	defer rows.Close()
+
+	//read the data
+	events := []*models.AuditEvent{}
+	for {
+		event, err := readAuditEventData(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		if event == nil {
+			break
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// nullTime converts a zero time to a null value so optional time filters can be skipped.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{
+		Time:  t,
+		Valid: !t.IsZero(),
+	}
+}
+
+func readAuditEventData(rows *sql.Rows) (*models.AuditEvent, error) {
+	//check if there was a result
+	if !rows.Next() {
+		err := rows.Err()
+		if err != nil {
+			return nil, common.ChainError("error preparing next row", err)
+		}
+
+		//return no results
+		return nil, nil
+	}
+
+	event := &models.AuditEvent{}
+
+	//get the result
+	err := rows.Scan(
+		&event.ID, &event.Actor, &event.Action, &event.Target, &event.IPAddress, &event.Timestamp,
+	)
+	if err != nil {
+		return nil, common.ChainError("error reading row", err)
+	}
+
+	//normalize the timestamp to UTC
+	event.Timestamp = event.Timestamp.UTC()
+
+	return event, nil
+}
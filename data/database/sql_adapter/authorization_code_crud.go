@@ -0,0 +1,148 @@
+package sqladapter
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+// CreateAuthorizationCodeTable creates the authorization code table in the database.
+// Returns any errors.
+func (crud *SQLCRUD) CreateAuthorizationCodeTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateAuthorizationCodeTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create authorization code table script", err)
+	}
+
+	return err
+}
+
+// DropAuthorizationCodeTable drops the authorization code table from the database.
+// Returns any errors.
+func (crud *SQLCRUD) DropAuthorizationCodeTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropAuthorizationCodeTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop authorization code table script", err)
+	}
+
+	return err
+}
+
+// AddAuthorizationCodeRedirectURIColumn adds the redirect uri column to the authorization code table.
+// Returns any errors.
+func (crud *SQLCRUD) AddAuthorizationCodeRedirectURIColumn() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.AddAuthorizationCodeRedirectURIColumnScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing add authorization code redirect uri column script", err)
+	}
+
+	return err
+}
+
+// DropAuthorizationCodeRedirectURIColumn drops the redirect uri column from the authorization code table.
+// Returns any errors.
+func (crud *SQLCRUD) DropAuthorizationCodeRedirectURIColumn() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropAuthorizationCodeRedirectURIColumnScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop authorization code redirect uri column script", err)
+	}
+
+	return err
+}
+
+func (crud *SQLCRUD) SaveAuthorizationCode(code *models.AuthorizationCode) error {
+	//validate the authorization code model
+	verr := code.Validate()
+	if verr != models.ValidateAuthorizationCodeValid {
+		return errors.New(fmt.Sprint("error validating authorization code model:", verr))
+	}
+
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.SaveAuthorizationCodeScript(),
+		code.Code, code.ClientUID, code.Username, code.CodeChallenge, code.Nonce, code.RedirectURI, code.ExpiresAt,
+	)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing save authorization code statement", err)
+	}
+
+	//nothing is inserted if the client or user does not exist
+	count, _ := res.RowsAffected()
+	if count == 0 {
+		return errors.New("authorization code client or user does not exist")
+	}
+
+	return nil
+}
+
+func (crud *SQLCRUD) GetAuthorizationCode(code uuid.UUID) (*models.AuthorizationCode, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetAuthorizationCodeScript(), code)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get authorization code query", err)
+	}
+	defer rows.Close()
+
+	return readAuthorizationCodeData(rows)
+}
+
+func (crud *SQLCRUD) DeleteAuthorizationCode(code uuid.UUID) (bool, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DeleteAuthorizationCodeScript(), code)
+	cancel()
+
+	if err != nil {
+		return false, common.ChainError("error executing delete authorization code statement", err)
+	}
+
+	count, _ := res.RowsAffected()
+	return count > 0, nil
+}
+
+func readAuthorizationCodeData(rows *sql.Rows) (*models.AuthorizationCode, error) {
+	//check if there was a result
+	if !rows.Next() {
+		err := rows.Err()
+		if err != nil {
+			return nil, common.ChainError("error preparing next row", err)
+		}
+
+		//return no results
+		return nil, nil
+	}
+
+	code := &models.AuthorizationCode{}
+
+	//get the result
+	err := rows.Scan(
+		&code.Code, &code.ClientUID, &code.Username, &code.CodeChallenge, &code.Nonce, &code.RedirectURI, &code.ExpiresAt,
+	)
+	if err != nil {
+		return nil, common.ChainError("error reading row", err)
+	}
+
+	//normalize the timestamp to UTC
+	code.ExpiresAt = code.ExpiresAt.UTC()
+
+	return code, nil
+}
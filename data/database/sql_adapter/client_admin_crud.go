@@ -0,0 +1,162 @@
+package sqladapter
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+// CreateClientAdminTable creates the client admin table in the database.
+// Returns any errors.
+func (crud *SQLCRUD) CreateClientAdminTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateClientAdminTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create client admin table script", err)
+	}
+
+	return err
+}
+
+// DropClientAdminTable drops the client admin table from the database.
+// Returns any errors.
+func (crud *SQLCRUD) DropClientAdminTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropClientAdminTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop client admin table script", err)
+	}
+
+	return err
+}
+
+func (crud *SQLCRUD) CreateClientAdmin(admin *models.ClientAdmin) error {
+	//validate the client admin model
+	verr := admin.Validate()
+	if verr != models.ValidateClientAdminValid {
+		return errors.New(fmt.Sprint("error validating client admin model:", verr))
+	}
+
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateClientAdminScript(),
+		admin.ClientUID, admin.Username, admin.IsOwner,
+	)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create client admin statement", err)
+	}
+
+	//nothing is inserted if the client or user does not exist
+	count, _ := res.RowsAffected()
+	if count == 0 {
+		return errors.New("client admin client or user does not exist")
+	}
+
+	return nil
+}
+
+func (crud *SQLCRUD) GetClientAdminsByClientUID(clientUID uuid.UUID) ([]*models.ClientAdmin, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetClientAdminsByClientUIDScript(), clientUID)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get client admins by client uid query", err)
+	}
+	defer rows.Close()
+
+	return readClientAdminsData(rows)
+}
+
+func (crud *SQLCRUD) GetClientAdminsByUsername(username string) ([]*models.ClientAdmin, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetClientAdminsByUsernameScript(), username)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get client admins by username query", err)
+	}
+	defer rows.Close()
+
+	return readClientAdminsData(rows)
+}
+
+func (crud *SQLCRUD) GetClientAdminByClientUIDAndUsername(clientUID uuid.UUID, username string) (*models.ClientAdmin, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetClientAdminByClientUIDAndUsernameScript(),
+		clientUID, username,
+	)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get client admin by client uid and username query", err)
+	}
+	defer rows.Close()
+
+	return readClientAdminData(rows)
+}
+
+func (crud *SQLCRUD) DeleteClientAdmin(clientUID uuid.UUID, username string) (bool, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DeleteClientAdminScript(),
+		clientUID, username,
+	)
+	cancel()
+
+	if err != nil {
+		return false, common.ChainError("error executing delete client admin statement", err)
+	}
+
+	count, _ := res.RowsAffected()
+	return count > 0, nil
+}
+
+func readClientAdminsData(rows *sql.Rows) ([]*models.ClientAdmin, error) {
+	admins := []*models.ClientAdmin{}
+	for {
+		admin, err := readClientAdminData(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		if admin == nil {
+			break
+		}
+		admins = append(admins, admin)
+	}
+	return admins, nil
+}
+
+func readClientAdminData(rows *sql.Rows) (*models.ClientAdmin, error) {
+	//check if there was a result
+	if !rows.Next() {
+		err := rows.Err()
+		if err != nil {
+			return nil, common.ChainError("error preparing next row", err)
+		}
+
+		//return no results
+		return nil, nil
+	}
+
+	//get the result
+	admin := &models.ClientAdmin{}
+	err := rows.Scan(
+		&admin.ClientUID, &admin.Username, &admin.IsOwner,
+	)
+	if err != nil {
+		return nil, common.ChainError("error reading row", err)
+	}
+
+	return admin, nil
+}
@@ -39,6 +39,34 @@ func (crud *SQLCRUD) DropClientTable() error {
 	return err
 }
 
+// AddClientSecretHashColumn adds the secret hash column to the client table.
+// Returns any errors.
+func (crud *SQLCRUD) AddClientSecretHashColumn() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.AddClientSecretHashColumnScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing add client secret hash column script", err)
+	}
+
+	return err
+}
+
+// DropClientSecretHashColumn drops the secret hash column from the client table.
+// Returns any errors.
+func (crud *SQLCRUD) DropClientSecretHashColumn() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropClientSecretHashColumnScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop client secret hash column script", err)
+	}
+
+	return err
+}
+
 func (crud *SQLCRUD) CreateClient(client *models.Client) error {
 	//validate the client model
 	verr := client.Validate()
@@ -58,9 +86,22 @@ func (crud *SQLCRUD) CreateClient(client *models.Client) error {
 	return nil
 }
 
-func (crud *SQLCRUD) GetClients() ([]*models.Client, error) {
+func (crud *SQLCRUD) GetClients(query models.PageQuery) ([]*models.Client, error) {
+	hasCursor, cursorName, cursorKey := pageCursor(query)
+
+	cursorUID := uuid.Nil
+	if hasCursor {
+		var err error
+		cursorUID, err = uuid.Parse(cursorKey)
+		if err != nil {
+			return nil, common.ChainError("error parsing cursor uid", err)
+		}
+	}
+
 	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
-	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetClientsScript())
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetClientsScript(),
+		query.Search, query.SortDescending, hasCursor, cursorName, cursorUID, pageLimit(query),
+	)
 	defer cancel()
 
 	if err != nil {
@@ -84,6 +125,15 @@ func (crud *SQLCRUD) GetClients() ([]*models.Client, error) {
 	return clients, nil
 }
 
+func (crud *SQLCRUD) CountClients(search string) (int, error) {
+	count, err := crud.queryCount(crud.SQLDriver.CountClientsScript(), search)
+	if err != nil {
+		return 0, common.ChainError("error counting clients", err)
+	}
+
+	return count, nil
+}
+
 func (crud *SQLCRUD) GetClientByUID(uid uuid.UUID) (*models.Client, error) {
 	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
 	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetClientByUIDScript(), uid)
@@ -117,6 +167,19 @@ func (crud *SQLCRUD) UpdateClient(client *models.Client) (bool, error) {
 	return count > 0, nil
 }
 
+func (crud *SQLCRUD) UpdateClientSecretHash(uid uuid.UUID, secretHash []byte) (bool, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.UpdateClientSecretHashScript(), uid, secretHash)
+	cancel()
+
+	if err != nil {
+		return false, common.ChainError("error executing update client secret hash statement", err)
+	}
+
+	count, _ := res.RowsAffected()
+	return count > 0, nil
+}
+
 func (crud *SQLCRUD) DeleteClient(uid uuid.UUID) (bool, error) {
 	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
 	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DeleteClientScript(), uid)
@@ -144,7 +207,7 @@ func readClientData(rows *sql.Rows) (*models.Client, error) {
 
 	//get the result
 	client := &models.Client{}
-	err := rows.Scan(&client.UID, &client.Name, &client.RedirectUrl, &client.TokenType, &client.KeyUri)
+	err := rows.Scan(&client.UID, &client.Name, &client.RedirectUrl, &client.TokenType, &client.KeyUri, &client.SecretHash)
 	if err != nil {
 		return nil, common.ChainError("error reading row", err)
 	}
@@ -4,19 +4,24 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/mhogar/amber/common"
 	"github.com/mhogar/amber/config"
 )
 
-// OpenConnection opens the connection to SQL database server using the fields from the database config.
+// OpenConnection opens the connection pool to SQL database server using the fields from the database config.
 // Initializes the adapter's context and cancel function, as well as its db instance.
+// The pool is shared by every executor created from the adapter, so it should only be opened once.
 // Returns any errors.
 func (a *SQLAdapter) OpenConnection() error {
 	dbConfig := config.GetDatabaseConfig()
 
-	//get connection string
-	connectionStr, ok := dbConfig.ConnectionStrings[a.DbKey]
+	//get connection string, preferring one specific to the driver
+	connectionStr, ok := dbConfig.ConnectionStrings[dbConfig.Driver+":"+a.DbKey]
+	if !ok {
+		connectionStr, ok = dbConfig.ConnectionStrings[a.DbKey]
+	}
 	if !ok {
 		return errors.New("no connection string found for database key " + a.DbKey)
 	}
@@ -30,13 +35,21 @@ func (a *SQLAdapter) OpenConnection() error {
 		return common.ChainError("error opening database connection", err)
 	}
 
+	//configure the pool
+	db.SetMaxOpenConns(dbConfig.MaxOpenConnections)
+	if dbConfig.MaxIdleConnections > 0 {
+		db.SetMaxIdleConns(dbConfig.MaxIdleConnections)
+	}
+	db.SetConnMaxLifetime(time.Duration(dbConfig.ConnectionMaxLifetime) * time.Second)
+
 	a.DB = db
 
 	return nil
 }
 
-// CloseConnection closes the connection to the SQL database server and resets its db instance.
-// The adapter also calls its cancel function to cancel any child requests that may still be running.
+// CloseConnection closes the connection pool to the SQL database server and resets its db instance.
+// Queries that have already started are waited on before the connections are closed.
+// The adapter then calls its cancel function to cancel any child requests that may still be running.
 // Neither the adapter's db instance or context should be used after calling this function.
 // Returns any errors.
 func (a *SQLAdapter) CloseConnection() error {
@@ -0,0 +1,256 @@
+package sqladapter
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+)
+
+// CreateGroupTable creates the group table in the database.
+// Returns any errors.
+func (crud *SQLCRUD) CreateGroupTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateGroupTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create group table script", err)
+	}
+
+	return err
+}
+
+// DropGroupTable drops the group table from the database.
+// Returns any errors.
+func (crud *SQLCRUD) DropGroupTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropGroupTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop group table script", err)
+	}
+
+	return err
+}
+
+// CreateGroupMemberTable creates the table of group members in the database.
+// Returns any errors.
+func (crud *SQLCRUD) CreateGroupMemberTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateGroupMemberTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create group member table script", err)
+	}
+
+	return err
+}
+
+// DropGroupMemberTable drops the table of group members from the database.
+// Returns any errors.
+func (crud *SQLCRUD) DropGroupMemberTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropGroupMemberTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop group member table script", err)
+	}
+
+	return err
+}
+
+func (crud *SQLCRUD) CreateGroup(group *models.Group) error {
+	//validate the group model
+	verr := group.Validate()
+	if verr != models.ValidateGroupValid {
+		return errors.New(fmt.Sprint("error validating group model:", verr))
+	}
+
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateGroupScript(),
+		group.Name, group.Description, group.Priority,
+	)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create group statement", err)
+	}
+
+	return nil
+}
+
+func (crud *SQLCRUD) GetGroups() ([]*models.Group, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetGroupsScript())
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get groups query", err)
+	}
+	defer rows.Close()
+
+	return readGroupsData(rows)
+}
+
+func (crud *SQLCRUD) GetGroupByName(name string) (*models.Group, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetGroupByNameScript(), name)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get group by name query", err)
+	}
+	defer rows.Close()
+
+	return readGroupData(rows)
+}
+
+func (crud *SQLCRUD) UpdateGroup(group *models.Group) (bool, error) {
+	//validate the group model
+	verr := group.Validate()
+	if verr != models.ValidateGroupValid {
+		return false, errors.New(fmt.Sprint("error validating group model:", verr))
+	}
+
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.UpdateGroupScript(),
+		group.Name, group.Description, group.Priority,
+	)
+	cancel()
+
+	if err != nil {
+		return false, common.ChainError("error executing update group statement", err)
+	}
+
+	count, _ := res.RowsAffected()
+	return count > 0, nil
+}
+
+func (crud *SQLCRUD) DeleteGroup(name string) (bool, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DeleteGroupScript(), name)
+	cancel()
+
+	if err != nil {
+		return false, common.ChainError("error executing delete group statement", err)
+	}
+
+	count, _ := res.RowsAffected()
+	return count > 0, nil
+}
+
+func (crud *SQLCRUD) GetGroupsByUsername(username string) ([]*models.Group, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetGroupsByUsernameScript(), username)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get groups by username query", err)
+	}
+	defer rows.Close()
+
+	return readGroupsData(rows)
+}
+
+func (crud *SQLCRUD) GetGroupMemberUsernames(name string) ([]string, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetGroupMemberUsernamesScript(), name)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get group member usernames query", err)
+	}
+	defer rows.Close()
+
+	usernames := []string{}
+	for rows.Next() {
+		var username string
+		err := rows.Scan(&username)
+		if err != nil {
+			return nil, common.ChainError("error reading row", err)
+		}
+		usernames = append(usernames, username)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, common.ChainError("error preparing next row", err)
+	}
+
+	return usernames, nil
+}
+
+func (crud *SQLCRUD) CreateGroupMember(name string, username string) error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateGroupMemberScript(), name, username)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create group member statement", err)
+	}
+
+	//nothing is inserted if the group or user does not exist
+	count, _ := res.RowsAffected()
+	if count == 0 {
+		return errors.New("group or user does not exist")
+	}
+
+	return nil
+}
+
+func (crud *SQLCRUD) DeleteGroupMember(name string, username string) (bool, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DeleteGroupMemberScript(), name, username)
+	cancel()
+
+	if err != nil {
+		return false, common.ChainError("error executing delete group member statement", err)
+	}
+
+	count, _ := res.RowsAffected()
+	return count > 0, nil
+}
+
+func readGroupsData(rows *sql.Rows) ([]*models.Group, error) {
+	groups := []*models.Group{}
+	for {
+		group, err := readGroupData(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		if group == nil {
+			break
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+func readGroupData(rows *sql.Rows) (*models.Group, error) {
+	//check if there was a result
+	if !rows.Next() {
+		err := rows.Err()
+		if err != nil {
+			return nil, common.ChainError("error preparing next row", err)
+		}
+
+		//return no results
+		return nil, nil
+	}
+
+	//get the result
+	group := &models.Group{}
+	err := rows.Scan(&group.Name, &group.Description, &group.Priority)
+	if err != nil {
+		return nil, common.ChainError("error reading row", err)
+	}
+
+	return group, nil
+}
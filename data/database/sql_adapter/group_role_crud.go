@@ -0,0 +1,200 @@
+package sqladapter
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+// CreateGroupRoleTable creates the group-role table in the database.
+// Returns any errors.
+func (crud *SQLCRUD) CreateGroupRoleTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateGroupRoleTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create group-role table script", err)
+	}
+
+	return err
+}
+
+// DropGroupRoleTable drops the group-role table from the database.
+// Returns any errors.
+func (crud *SQLCRUD) DropGroupRoleTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropGroupRoleTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop group-role table script", err)
+	}
+
+	return err
+}
+
+func (crud *SQLCRUD) CreateGroupRole(role *models.GroupRole) error {
+	//validate the group-role model
+	verr := role.Validate()
+	if verr != models.ValidateGroupRoleValid {
+		return errors.New(fmt.Sprint("error validating group-role model:", verr))
+	}
+
+	return crud.createGroupRoleRoles(role)
+}
+
+func (crud *SQLCRUD) createGroupRoleRoles(role *models.GroupRole) error {
+	//each role is its own row
+	for _, r := range role.Roles {
+		ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+		res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateGroupRoleScript(),
+			role.ClientUID, role.GroupName, r,
+		)
+		cancel()
+
+		if err != nil {
+			return common.ChainError("error executing create group role statement", err)
+		}
+
+		//nothing is inserted if the client or group does not exist
+		count, _ := res.RowsAffected()
+		if count == 0 {
+			return errors.New("group-role client or group does not exist")
+		}
+	}
+
+	return nil
+}
+
+func (crud *SQLCRUD) GetGroupRolesByClientUID(clientUID uuid.UUID) ([]*models.GroupRole, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetGroupRolesByClientUIDScript(), clientUID)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get group roles by client uid query", err)
+	}
+	defer rows.Close()
+
+	return readGroupRolesData(rows)
+}
+
+func (crud *SQLCRUD) GetGroupRoleByClientUIDAndGroupName(clientUID uuid.UUID, groupName string) (*models.GroupRole, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetGroupRoleByClientUIDAndGroupNameScript(),
+		clientUID, groupName,
+	)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get group role by client uid and group name query", err)
+	}
+	defer rows.Close()
+
+	roles, err := readGroupRolesData(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(roles) == 0 {
+		return nil, nil
+	}
+	return roles[0], nil
+}
+
+func (crud *SQLCRUD) UpdateGroupRole(role *models.GroupRole) (bool, error) {
+	//validate the group-role model
+	verr := role.Validate()
+	if verr != models.ValidateGroupRoleValid {
+		return false, errors.New(fmt.Sprint("error validating group-role model:", verr))
+	}
+
+	//replace the old roles with the new ones
+	res, err := crud.DeleteGroupRole(role.ClientUID, role.GroupName)
+	if err != nil {
+		return false, common.ChainError("error deleting old roles", err)
+	}
+	if !res {
+		return false, nil
+	}
+
+	err = crud.createGroupRoleRoles(role)
+	if err != nil {
+		return false, common.ChainError("error creating new roles", err)
+	}
+
+	return true, nil
+}
+
+func (crud *SQLCRUD) CountGroupRolesByClientUIDAndRole(clientUID uuid.UUID, role string) (int, error) {
+	count, err := crud.queryCount(crud.SQLDriver.CountGroupRolesByClientUIDAndRoleScript(), clientUID, role)
+	if err != nil {
+		return 0, common.ChainError("error counting group roles by client uid and role", err)
+	}
+
+	return count, nil
+}
+
+func (crud *SQLCRUD) RenameGroupRoles(clientUID uuid.UUID, role string, newRole string) error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.RenameGroupRolesScript(),
+		clientUID, role, newRole,
+	)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing rename group roles statement", err)
+	}
+
+	return nil
+}
+
+func (crud *SQLCRUD) DeleteGroupRole(clientUID uuid.UUID, groupName string) (bool, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DeleteGroupRoleScript(),
+		clientUID, groupName,
+	)
+	cancel()
+
+	if err != nil {
+		return false, common.ChainError("error executing delete group role statement", err)
+	}
+
+	count, _ := res.RowsAffected()
+	return count > 0, nil
+}
+
+func readGroupRolesData(rows *sql.Rows) ([]*models.GroupRole, error) {
+	roles := []*models.GroupRole{}
+
+	//each row is one role, rows of the same group-role are adjacent
+	for rows.Next() {
+		var clientUID uuid.UUID
+		var groupName, role string
+
+		err := rows.Scan(&clientUID, &groupName, &role)
+		if err != nil {
+			return nil, common.ChainError("error reading row", err)
+		}
+
+		if len(roles) == 0 || roles[len(roles)-1].GroupName != groupName {
+			roles = append(roles, models.CreateGroupRole(clientUID, groupName))
+		}
+
+		groupRole := roles[len(roles)-1]
+		groupRole.Roles = append(groupRole.Roles, role)
+	}
+
+	err := rows.Err()
+	if err != nil {
+		return nil, common.ChainError("error preparing next row", err)
+	}
+
+	return roles, nil
+}
@@ -0,0 +1,243 @@
+package sqladapter
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+// CreateInvitationTable creates the invitation table in the database.
+// Returns any errors.
+func (crud *SQLCRUD) CreateInvitationTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateInvitationTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create invitation table script", err)
+	}
+
+	return err
+}
+
+// DropInvitationTable drops the invitation table from the database.
+// Returns any errors.
+func (crud *SQLCRUD) DropInvitationTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropInvitationTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop invitation table script", err)
+	}
+
+	return err
+}
+
+// CreateInvitationRoleTable creates the invitation role table in the database.
+// Returns any errors.
+func (crud *SQLCRUD) CreateInvitationRoleTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateInvitationRoleTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create invitation role table script", err)
+	}
+
+	return err
+}
+
+// DropInvitationRoleTable drops the invitation role table from the database.
+// Returns any errors.
+func (crud *SQLCRUD) DropInvitationRoleTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropInvitationRoleTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop invitation role table script", err)
+	}
+
+	return err
+}
+
+func (crud *SQLCRUD) SaveInvitation(invitation *models.Invitation) error {
+	//validate the invitation model
+	verr := invitation.Validate()
+	if verr != models.ValidateInvitationValid {
+		return errors.New(fmt.Sprint("error validating invitation model:", verr))
+	}
+
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.SaveInvitationScript(),
+		invitation.ID, invitation.TokenHash, invitation.Email, invitation.Rank, invitation.ExpiresAt,
+	)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing save invitation statement", err)
+	}
+
+	//save the roles
+	for _, role := range invitation.Roles {
+		ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+		res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.SaveInvitationRoleScript(),
+			invitation.ID, role.ClientUID, role.Role,
+		)
+		cancel()
+
+		if err != nil {
+			return common.ChainError("error executing save invitation role statement", err)
+		}
+
+		//nothing is inserted if the client does not exist
+		count, _ := res.RowsAffected()
+		if count == 0 {
+			return errors.New("invitation role client does not exist")
+		}
+	}
+
+	return nil
+}
+
+func (crud *SQLCRUD) GetInvitationByID(id uuid.UUID) (*models.Invitation, error) {
+	return crud.getInvitation("get invitation by id", crud.SQLDriver.GetInvitationByIDScript(), id)
+}
+
+func (crud *SQLCRUD) GetInvitationByTokenHash(hash []byte) (*models.Invitation, error) {
+	return crud.getInvitation("get invitation by token hash", crud.SQLDriver.GetInvitationByTokenHashScript(), hash)
+}
+
+func (crud *SQLCRUD) DeleteInvitation(id uuid.UUID) (bool, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DeleteInvitationScript(), id)
+	cancel()
+
+	if err != nil {
+		return false, common.ChainError("error executing delete invitation statement", err)
+	}
+
+	count, _ := res.RowsAffected()
+	return count > 0, nil
+}
+
+// getInvitation fetches the invitation using the script and arg, then fetches its roles.
+// The name is used to describe the query in errors.
+func (crud *SQLCRUD) RenameInvitationRoles(clientUID uuid.UUID, role string, newRole string) error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.RenameInvitationRolesScript(),
+		clientUID, role, newRole,
+	)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing rename invitation roles statement", err)
+	}
+
+	return nil
+}
+
+func (crud *SQLCRUD) getInvitation(name string, script string, arg interface{}) (*models.Invitation, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, script, arg)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError(fmt.Sprintf("error executing %s query", name), err)
+	}
+
+	invitation, err := readInvitationData(rows)
+	rows.Close()
+
+	if err != nil || invitation == nil {
+		return nil, err
+	}
+
+	//get the roles
+	invitation.Roles, err = crud.getInvitationRoles(invitation.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+func (crud *SQLCRUD) getInvitationRoles(id uuid.UUID) ([]*models.InvitationRole, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetInvitationRolesScript(), id)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get invitation roles query", err)
+	}
+	defer rows.Close()
+
+	//read the data
+	roles := []*models.InvitationRole{}
+	for {
+		role, err := readInvitationRoleData(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		if role == nil {
+			break
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+func readInvitationData(rows *sql.Rows) (*models.Invitation, error) {
+	//check if there was a result
+	if !rows.Next() {
+		err := rows.Err()
+		if err != nil {
+			return nil, common.ChainError("error preparing next row", err)
+		}
+
+		//return no results
+		return nil, nil
+	}
+
+	invitation := &models.Invitation{}
+
+	//get the result
+	err := rows.Scan(&invitation.ID, &invitation.TokenHash, &invitation.Email, &invitation.Rank, &invitation.ExpiresAt)
+	if err != nil {
+		return nil, common.ChainError("error reading row", err)
+	}
+
+	//normalize the timestamp to UTC
+	invitation.ExpiresAt = invitation.ExpiresAt.UTC()
+
+	return invitation, nil
+}
+
+func readInvitationRoleData(rows *sql.Rows) (*models.InvitationRole, error) {
+	//check if there was a result
+	if !rows.Next() {
+		err := rows.Err()
+		if err != nil {
+			return nil, common.ChainError("error preparing next row", err)
+		}
+
+		//return no results
+		return nil, nil
+	}
+
+	//get the result
+	role := &models.InvitationRole{}
+	err := rows.Scan(&role.ClientUID, &role.Role)
+	if err != nil {
+		return nil, common.ChainError("error reading row", err)
+	}
+
+	return role, nil
+}
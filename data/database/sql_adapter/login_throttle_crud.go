@@ -0,0 +1,140 @@
+package sqladapter
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+)
+
+// CreateLoginThrottleTable creates the login throttle table in the database.
+// Returns any errors.
+func (crud *SQLCRUD) CreateLoginThrottleTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateLoginThrottleTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create login throttle table script", err)
+	}
+
+	return err
+}
+
+// DropLoginThrottleTable drops the login throttle table from the database.
+// Returns any errors.
+func (crud *SQLCRUD) DropLoginThrottleTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropLoginThrottleTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop login throttle table script", err)
+	}
+
+	return err
+}
+
+func (crud *SQLCRUD) SaveLoginThrottle(throttle *models.LoginThrottle) error {
+	//validate the login throttle model
+	verr := throttle.Validate()
+	if verr != models.ValidateLoginThrottleValid {
+		return errors.New(fmt.Sprint("error validating login throttle model:", verr))
+	}
+
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.SaveLoginThrottleScript(),
+		throttle.Type, throttle.Key, throttle.FailedAttempts, throttle.LastFailedAt, throttle.LockedUntil,
+	)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing save login throttle statement", err)
+	}
+
+	return nil
+}
+
+func (crud *SQLCRUD) GetLoginThrottle(throttleType string, key string) (*models.LoginThrottle, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetLoginThrottleScript(), throttleType, key)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get login throttle query", err)
+	}
+	defer rows.Close()
+
+	return readLoginThrottleData(rows)
+}
+
+func (crud *SQLCRUD) GetLockedLoginThrottles(now time.Time) ([]*models.LoginThrottle, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetLockedLoginThrottlesScript(), now)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get locked login throttles query", err)
+	}
+	defer rows.Close()
+
+	//read the data
+	throttles := []*models.LoginThrottle{}
+	for {
+		throttle, err := readLoginThrottleData(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		if throttle == nil {
+			break
+		}
+		throttles = append(throttles, throttle)
+	}
+	return throttles, nil
+}
+
+func (crud *SQLCRUD) DeleteLoginThrottle(throttleType string, key string) (bool, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DeleteLoginThrottleScript(), throttleType, key)
+	cancel()
+
+	if err != nil {
+		return false, common.ChainError("error executing delete login throttle statement", err)
+	}
+
+	count, _ := res.RowsAffected()
+	return count > 0, nil
+}
+
+func readLoginThrottleData(rows *sql.Rows) (*models.LoginThrottle, error) {
+	//check if there was a result
+	if !rows.Next() {
+		err := rows.Err()
+		if err != nil {
+			return nil, common.ChainError("error preparing next row", err)
+		}
+
+		//return no results
+		return nil, nil
+	}
+
+	throttle := &models.LoginThrottle{}
+
+	//get the result
+	err := rows.Scan(
+		&throttle.Type, &throttle.Key, &throttle.FailedAttempts, &throttle.LastFailedAt, &throttle.LockedUntil,
+	)
+	if err != nil {
+		return nil, common.ChainError("error reading row", err)
+	}
+
+	//normalize the timestamps to UTC
+	throttle.LastFailedAt = throttle.LastFailedAt.UTC()
+	throttle.LockedUntil = throttle.LockedUntil.UTC()
+
+	return throttle, nil
+}
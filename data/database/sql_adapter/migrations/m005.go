@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	sqladapter "github.com/mhogar/amber/data/database/sql_adapter"
+
+	"github.com/mhogar/migrationrunner"
+)
+
+func m005(exec data.DataExecutor, sf data.ScopeFactory) migrationrunner.Migration {
+	return migrationrunner.Migration{
+		Timestamp:   "005",
+		Description: "add timestamps to sessions table",
+		Migrator: &migrator005{
+			Executor:     exec,
+			ScopeFactory: sf,
+		},
+	}
+}
+
+type migrator005 struct {
+	Executor     data.DataExecutor
+	ScopeFactory data.ScopeFactory
+}
+
+func (m migrator005) Up() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//add the session timestamp columns
+		err := sqlTx.AddSessionTimestampColumns()
+		if err != nil {
+			return false, common.ChainError("error adding session timestamp columns", err)
+		}
+
+		return true, nil
+	})
+}
+
+func (m migrator005) Down() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//drop the session timestamp columns
+		err := sqlTx.DropSessionTimestampColumns()
+		if err != nil {
+			return false, common.ChainError("error dropping session timestamp columns", err)
+		}
+
+		return true, nil
+	})
+}
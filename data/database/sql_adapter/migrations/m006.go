@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	sqladapter "github.com/mhogar/amber/data/database/sql_adapter"
+
+	"github.com/mhogar/migrationrunner"
+)
+
+func m006(exec data.DataExecutor, sf data.ScopeFactory) migrationrunner.Migration {
+	return migrationrunner.Migration{
+		Timestamp:   "006",
+		Description: "create authorization codes table",
+		Migrator: &migrator006{
+			Executor:     exec,
+			ScopeFactory: sf,
+		},
+	}
+}
+
+type migrator006 struct {
+	Executor     data.DataExecutor
+	ScopeFactory data.ScopeFactory
+}
+
+func (m migrator006) Up() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//create the authorization code table
+		err := sqlTx.CreateAuthorizationCodeTable()
+		if err != nil {
+			return false, common.ChainError("error creating authorization code table", err)
+		}
+
+		return true, nil
+	})
+}
+
+func (m migrator006) Down() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//drop the authorization code table
+		err := sqlTx.DropAuthorizationCodeTable()
+		if err != nil {
+			return false, common.ChainError("error dropping authorization code table", err)
+		}
+
+		return true, nil
+	})
+}
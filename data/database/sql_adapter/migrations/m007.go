@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	sqladapter "github.com/mhogar/amber/data/database/sql_adapter"
+
+	"github.com/mhogar/migrationrunner"
+)
+
+func m007(exec data.DataExecutor, sf data.ScopeFactory) migrationrunner.Migration {
+	return migrationrunner.Migration{
+		Timestamp:   "007",
+		Description: "add secret hash to client table",
+		Migrator: &migrator007{
+			Executor:     exec,
+			ScopeFactory: sf,
+		},
+	}
+}
+
+type migrator007 struct {
+	Executor     data.DataExecutor
+	ScopeFactory data.ScopeFactory
+}
+
+func (m migrator007) Up() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//add the client secret hash column
+		err := sqlTx.AddClientSecretHashColumn()
+		if err != nil {
+			return false, common.ChainError("error adding client secret hash column", err)
+		}
+
+		return true, nil
+	})
+}
+
+func (m migrator007) Down() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//drop the client secret hash column
+		err := sqlTx.DropClientSecretHashColumn()
+		if err != nil {
+			return false, common.ChainError("error dropping client secret hash column", err)
+		}
+
+		return true, nil
+	})
+}
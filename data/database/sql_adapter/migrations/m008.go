@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	sqladapter "github.com/mhogar/amber/data/database/sql_adapter"
+
+	"github.com/mhogar/migrationrunner"
+)
+
+func m008(exec data.DataExecutor, sf data.ScopeFactory) migrationrunner.Migration {
+	return migrationrunner.Migration{
+		Timestamp:   "008",
+		Description: "create refresh tokens table",
+		Migrator: &migrator008{
+			Executor:     exec,
+			ScopeFactory: sf,
+		},
+	}
+}
+
+type migrator008 struct {
+	Executor     data.DataExecutor
+	ScopeFactory data.ScopeFactory
+}
+
+func (m migrator008) Up() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//create the refresh token table
+		err := sqlTx.CreateRefreshTokenTable()
+		if err != nil {
+			return false, common.ChainError("error creating refresh token table", err)
+		}
+
+		return true, nil
+	})
+}
+
+func (m migrator008) Down() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//drop the refresh token table
+		err := sqlTx.DropRefreshTokenTable()
+		if err != nil {
+			return false, common.ChainError("error dropping refresh token table", err)
+		}
+
+		return true, nil
+	})
+}
@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	sqladapter "github.com/mhogar/amber/data/database/sql_adapter"
+
+	"github.com/mhogar/migrationrunner"
+)
+
+func m009(exec data.DataExecutor, sf data.ScopeFactory) migrationrunner.Migration {
+	return migrationrunner.Migration{
+		Timestamp:   "009",
+		Description: "add totp columns to user table",
+		Migrator: &migrator009{
+			Executor:     exec,
+			ScopeFactory: sf,
+		},
+	}
+}
+
+type migrator009 struct {
+	Executor     data.DataExecutor
+	ScopeFactory data.ScopeFactory
+}
+
+func (m migrator009) Up() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//add the user totp columns
+		err := sqlTx.AddUserTOTPColumns()
+		if err != nil {
+			return false, common.ChainError("error adding user totp columns", err)
+		}
+
+		return true, nil
+	})
+}
+
+func (m migrator009) Down() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//drop the user totp columns
+		err := sqlTx.DropUserTOTPColumns()
+		if err != nil {
+			return false, common.ChainError("error dropping user totp columns", err)
+		}
+
+		return true, nil
+	})
+}
@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	sqladapter "github.com/mhogar/amber/data/database/sql_adapter"
+
+	"github.com/mhogar/migrationrunner"
+)
+
+func m010(exec data.DataExecutor, sf data.ScopeFactory) migrationrunner.Migration {
+	return migrationrunner.Migration{
+		Timestamp:   "010",
+		Description: "create recovery codes table",
+		Migrator: &migrator010{
+			Executor:     exec,
+			ScopeFactory: sf,
+		},
+	}
+}
+
+type migrator010 struct {
+	Executor     data.DataExecutor
+	ScopeFactory data.ScopeFactory
+}
+
+func (m migrator010) Up() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//create the recovery code table
+		err := sqlTx.CreateRecoveryCodeTable()
+		if err != nil {
+			return false, common.ChainError("error creating recovery code table", err)
+		}
+
+		return true, nil
+	})
+}
+
+func (m migrator010) Down() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//drop the recovery code table
+		err := sqlTx.DropRecoveryCodeTable()
+		if err != nil {
+			return false, common.ChainError("error dropping recovery code table", err)
+		}
+
+		return true, nil
+	})
+}
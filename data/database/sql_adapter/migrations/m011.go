@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	sqladapter "github.com/mhogar/amber/data/database/sql_adapter"
+
+	"github.com/mhogar/migrationrunner"
+)
+
+func m011(exec data.DataExecutor, sf data.ScopeFactory) migrationrunner.Migration {
+	return migrationrunner.Migration{
+		Timestamp:   "011",
+		Description: "create login throttle table",
+		Migrator: &migrator011{
+			Executor:     exec,
+			ScopeFactory: sf,
+		},
+	}
+}
+
+type migrator011 struct {
+	Executor     data.DataExecutor
+	ScopeFactory data.ScopeFactory
+}
+
+func (m migrator011) Up() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//create the login throttle table
+		err := sqlTx.CreateLoginThrottleTable()
+		if err != nil {
+			return false, common.ChainError("error creating login throttle table", err)
+		}
+
+		return true, nil
+	})
+}
+
+func (m migrator011) Down() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//drop the login throttle table
+		err := sqlTx.DropLoginThrottleTable()
+		if err != nil {
+			return false, common.ChainError("error dropping login throttle table", err)
+		}
+
+		return true, nil
+	})
+}
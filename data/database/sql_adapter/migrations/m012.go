@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	sqladapter "github.com/mhogar/amber/data/database/sql_adapter"
+
+	"github.com/mhogar/migrationrunner"
+)
+
+func m012(exec data.DataExecutor, sf data.ScopeFactory) migrationrunner.Migration {
+	return migrationrunner.Migration{
+		Timestamp:   "012",
+		Description: "create audit event table",
+		Migrator: &migrator012{
+			Executor:     exec,
+			ScopeFactory: sf,
+		},
+	}
+}
+
+type migrator012 struct {
+	Executor     data.DataExecutor
+	ScopeFactory data.ScopeFactory
+}
+
+func (m migrator012) Up() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//create the audit event table
+		err := sqlTx.CreateAuditEventTable()
+		if err != nil {
+			return false, common.ChainError("error creating audit event table", err)
+		}
+
+		return true, nil
+	})
+}
+
+func (m migrator012) Down() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//drop the audit event table
+		err := sqlTx.DropAuditEventTable()
+		if err != nil {
+			return false, common.ChainError("error dropping audit event table", err)
+		}
+
+		return true, nil
+	})
+}
@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	sqladapter "github.com/mhogar/amber/data/database/sql_adapter"
+
+	"github.com/mhogar/migrationrunner"
+)
+
+func m013(exec data.DataExecutor, sf data.ScopeFactory) migrationrunner.Migration {
+	return migrationrunner.Migration{
+		Timestamp:   "013",
+		Description: "add profile columns to user table",
+		Migrator: &migrator013{
+			Executor:     exec,
+			ScopeFactory: sf,
+		},
+	}
+}
+
+type migrator013 struct {
+	Executor     data.DataExecutor
+	ScopeFactory data.ScopeFactory
+}
+
+func (m migrator013) Up() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//add the user profile columns
+		err := sqlTx.AddUserProfileColumns()
+		if err != nil {
+			return false, common.ChainError("error adding user profile columns", err)
+		}
+
+		return true, nil
+	})
+}
+
+func (m migrator013) Down() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//drop the user profile columns
+		err := sqlTx.DropUserProfileColumns()
+		if err != nil {
+			return false, common.ChainError("error dropping user profile columns", err)
+		}
+
+		return true, nil
+	})
+}
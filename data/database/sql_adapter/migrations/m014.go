@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	sqladapter "github.com/mhogar/amber/data/database/sql_adapter"
+
+	"github.com/mhogar/migrationrunner"
+)
+
+func m014(exec data.DataExecutor, sf data.ScopeFactory) migrationrunner.Migration {
+	return migrationrunner.Migration{
+		Timestamp:   "014",
+		Description: "create password reset token table",
+		Migrator: &migrator014{
+			Executor:     exec,
+			ScopeFactory: sf,
+		},
+	}
+}
+
+type migrator014 struct {
+	Executor     data.DataExecutor
+	ScopeFactory data.ScopeFactory
+}
+
+func (m migrator014) Up() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//create the password reset token table
+		err := sqlTx.CreatePasswordResetTokenTable()
+		if err != nil {
+			return false, common.ChainError("error creating password reset token table", err)
+		}
+
+		return true, nil
+	})
+}
+
+func (m migrator014) Down() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//drop the password reset token table
+		err := sqlTx.DropPasswordResetTokenTable()
+		if err != nil {
+			return false, common.ChainError("error dropping password reset token table", err)
+		}
+
+		return true, nil
+	})
+}
@@ -0,0 +1,65 @@
+package migrations
+
+import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	sqladapter "github.com/mhogar/amber/data/database/sql_adapter"
+
+	"github.com/mhogar/migrationrunner"
+)
+
+func m015(exec data.DataExecutor, sf data.ScopeFactory) migrationrunner.Migration {
+	return migrationrunner.Migration{
+		Timestamp:   "015",
+		Description: "create invitation and invitation role tables",
+		Migrator: &migrator015{
+			Executor:     exec,
+			ScopeFactory: sf,
+		},
+	}
+}
+
+type migrator015 struct {
+	Executor     data.DataExecutor
+	ScopeFactory data.ScopeFactory
+}
+
+func (m migrator015) Up() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//create the invitation table
+		err := sqlTx.CreateInvitationTable()
+		if err != nil {
+			return false, common.ChainError("error creating invitation table", err)
+		}
+
+		//create the invitation role table
+		err = sqlTx.CreateInvitationRoleTable()
+		if err != nil {
+			return false, common.ChainError("error creating invitation role table", err)
+		}
+
+		return true, nil
+	})
+}
+
+func (m migrator015) Down() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//drop the invitation role table
+		err := sqlTx.DropInvitationRoleTable()
+		if err != nil {
+			return false, common.ChainError("error dropping invitation role table", err)
+		}
+
+		//drop the invitation table
+		err = sqlTx.DropInvitationTable()
+		if err != nil {
+			return false, common.ChainError("error dropping invitation table", err)
+		}
+
+		return true, nil
+	})
+}
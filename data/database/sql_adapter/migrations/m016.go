@@ -0,0 +1,65 @@
+package migrations
+
+import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	sqladapter "github.com/mhogar/amber/data/database/sql_adapter"
+
+	"github.com/mhogar/migrationrunner"
+)
+
+func m016(exec data.DataExecutor, sf data.ScopeFactory) migrationrunner.Migration {
+	return migrationrunner.Migration{
+		Timestamp:   "016",
+		Description: "add user password updated at column and create previous password table",
+		Migrator: &migrator016{
+			Executor:     exec,
+			ScopeFactory: sf,
+		},
+	}
+}
+
+type migrator016 struct {
+	Executor     data.DataExecutor
+	ScopeFactory data.ScopeFactory
+}
+
+func (m migrator016) Up() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//add the user password updated at column
+		err := sqlTx.AddUserPasswordUpdatedAtColumn()
+		if err != nil {
+			return false, common.ChainError("error adding user password updated at column", err)
+		}
+
+		//create the previous password table
+		err = sqlTx.CreatePreviousPasswordTable()
+		if err != nil {
+			return false, common.ChainError("error creating previous password table", err)
+		}
+
+		return true, nil
+	})
+}
+
+func (m migrator016) Down() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//drop the previous password table
+		err := sqlTx.DropPreviousPasswordTable()
+		if err != nil {
+			return false, common.ChainError("error dropping previous password table", err)
+		}
+
+		//drop the user password updated at column
+		err = sqlTx.DropUserPasswordUpdatedAtColumn()
+		if err != nil {
+			return false, common.ChainError("error dropping user password updated at column", err)
+		}
+
+		return true, nil
+	})
+}
@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	sqladapter "github.com/mhogar/amber/data/database/sql_adapter"
+
+	"github.com/mhogar/migrationrunner"
+)
+
+func m017(exec data.DataExecutor, sf data.ScopeFactory) migrationrunner.Migration {
+	return migrationrunner.Migration{
+		Timestamp:   "017",
+		Description: "create role definition table",
+		Migrator: &migrator017{
+			Executor:     exec,
+			ScopeFactory: sf,
+		},
+	}
+}
+
+type migrator017 struct {
+	Executor     data.DataExecutor
+	ScopeFactory data.ScopeFactory
+}
+
+func (m migrator017) Up() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//create the role definition table
+		err := sqlTx.CreateRoleDefinitionTable()
+		if err != nil {
+			return false, common.ChainError("error creating role definition table", err)
+		}
+
+		//define the roles that are already assigned so they stay valid
+		err = sqlTx.CreateRoleDefinitionsFromAssignedRoles()
+		if err != nil {
+			return false, common.ChainError("error creating role definitions from assigned roles", err)
+		}
+
+		return true, nil
+	})
+}
+
+func (m migrator017) Down() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//drop the role definition table
+		err := sqlTx.DropRoleDefinitionTable()
+		if err != nil {
+			return false, common.ChainError("error dropping role definition table", err)
+		}
+
+		return true, nil
+	})
+}
@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	sqladapter "github.com/mhogar/amber/data/database/sql_adapter"
+
+	"github.com/mhogar/migrationrunner"
+)
+
+func m018(exec data.DataExecutor, sf data.ScopeFactory) migrationrunner.Migration {
+	return migrationrunner.Migration{
+		Timestamp:   "018",
+		Description: "allow multiple roles per user per client",
+		Migrator: &migrator018{
+			Executor:     exec,
+			ScopeFactory: sf,
+		},
+	}
+}
+
+type migrator018 struct {
+	Executor     data.DataExecutor
+	ScopeFactory data.ScopeFactory
+}
+
+func (m migrator018) Up() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//add the role to the user-role primary key
+		err := sqlTx.AddRoleToUserRolePrimaryKey()
+		if err != nil {
+			return false, common.ChainError("error adding role to user-role primary key", err)
+		}
+
+		return true, nil
+	})
+}
+
+func (m migrator018) Down() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//only the first role of each user can be kept
+		err := sqlTx.DeleteAllButFirstUserRoles()
+		if err != nil {
+			return false, common.ChainError("error deleting all but first user-roles", err)
+		}
+
+		//remove the role from the user-role primary key
+		err = sqlTx.RemoveRoleFromUserRolePrimaryKey()
+		if err != nil {
+			return false, common.ChainError("error removing role from user-role primary key", err)
+		}
+
+		return true, nil
+	})
+}
@@ -0,0 +1,65 @@
+package migrations
+
+import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	sqladapter "github.com/mhogar/amber/data/database/sql_adapter"
+
+	"github.com/mhogar/migrationrunner"
+)
+
+func m019(exec data.DataExecutor, sf data.ScopeFactory) migrationrunner.Migration {
+	return migrationrunner.Migration{
+		Timestamp:   "019",
+		Description: "create admin role tables",
+		Migrator: &migrator019{
+			Executor:     exec,
+			ScopeFactory: sf,
+		},
+	}
+}
+
+type migrator019 struct {
+	Executor     data.DataExecutor
+	ScopeFactory data.ScopeFactory
+}
+
+func (m migrator019) Up() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//create the admin role table
+		err := sqlTx.CreateAdminRoleTable()
+		if err != nil {
+			return false, common.ChainError("error creating admin role table", err)
+		}
+
+		//create the user admin role table
+		err = sqlTx.CreateUserAdminRoleTable()
+		if err != nil {
+			return false, common.ChainError("error creating user admin role table", err)
+		}
+
+		return true, nil
+	})
+}
+
+func (m migrator019) Down() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//drop the user admin role table
+		err := sqlTx.DropUserAdminRoleTable()
+		if err != nil {
+			return false, common.ChainError("error dropping user admin role table", err)
+		}
+
+		//drop the admin role table
+		err = sqlTx.DropAdminRoleTable()
+		if err != nil {
+			return false, common.ChainError("error dropping admin role table", err)
+		}
+
+		return true, nil
+	})
+}
@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	sqladapter "github.com/mhogar/amber/data/database/sql_adapter"
+
+	"github.com/mhogar/migrationrunner"
+)
+
+func m020(exec data.DataExecutor, sf data.ScopeFactory) migrationrunner.Migration {
+	return migrationrunner.Migration{
+		Timestamp:   "020",
+		Description: "create client admin table",
+		Migrator: &migrator020{
+			Executor:     exec,
+			ScopeFactory: sf,
+		},
+	}
+}
+
+type migrator020 struct {
+	Executor     data.DataExecutor
+	ScopeFactory data.ScopeFactory
+}
+
+func (m migrator020) Up() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//create the client admin table
+		err := sqlTx.CreateClientAdminTable()
+		if err != nil {
+			return false, common.ChainError("error creating client admin table", err)
+		}
+
+		return true, nil
+	})
+}
+
+func (m migrator020) Down() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//drop the client admin table
+		err := sqlTx.DropClientAdminTable()
+		if err != nil {
+			return false, common.ChainError("error dropping client admin table", err)
+		}
+
+		return true, nil
+	})
+}
@@ -0,0 +1,77 @@
+package migrations
+
+import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	sqladapter "github.com/mhogar/amber/data/database/sql_adapter"
+
+	"github.com/mhogar/migrationrunner"
+)
+
+func m021(exec data.DataExecutor, sf data.ScopeFactory) migrationrunner.Migration {
+	return migrationrunner.Migration{
+		Timestamp:   "021",
+		Description: "create group tables",
+		Migrator: &migrator021{
+			Executor:     exec,
+			ScopeFactory: sf,
+		},
+	}
+}
+
+type migrator021 struct {
+	Executor     data.DataExecutor
+	ScopeFactory data.ScopeFactory
+}
+
+func (m migrator021) Up() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//create the group table
+		err := sqlTx.CreateGroupTable()
+		if err != nil {
+			return false, common.ChainError("error creating group table", err)
+		}
+
+		//create the group member table
+		err = sqlTx.CreateGroupMemberTable()
+		if err != nil {
+			return false, common.ChainError("error creating group member table", err)
+		}
+
+		//create the group-role table
+		err = sqlTx.CreateGroupRoleTable()
+		if err != nil {
+			return false, common.ChainError("error creating group-role table", err)
+		}
+
+		return true, nil
+	})
+}
+
+func (m migrator021) Down() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//drop the group-role table
+		err := sqlTx.DropGroupRoleTable()
+		if err != nil {
+			return false, common.ChainError("error dropping group-role table", err)
+		}
+
+		//drop the group member table
+		err = sqlTx.DropGroupMemberTable()
+		if err != nil {
+			return false, common.ChainError("error dropping group member table", err)
+		}
+
+		//drop the group table
+		err = sqlTx.DropGroupTable()
+		if err != nil {
+			return false, common.ChainError("error dropping group table", err)
+		}
+
+		return true, nil
+	})
+}
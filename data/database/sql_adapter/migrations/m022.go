@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	sqladapter "github.com/mhogar/amber/data/database/sql_adapter"
+
+	"github.com/mhogar/migrationrunner"
+)
+
+func m022(exec data.DataExecutor, sf data.ScopeFactory) migrationrunner.Migration {
+	return migrationrunner.Migration{
+		Timestamp:   "022",
+		Description: "add redirect uri column to authorization code table",
+		Migrator: &migrator022{
+			Executor:     exec,
+			ScopeFactory: sf,
+		},
+	}
+}
+
+type migrator022 struct {
+	Executor     data.DataExecutor
+	ScopeFactory data.ScopeFactory
+}
+
+func (m migrator022) Up() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//add the authorization code redirect uri column
+		err := sqlTx.AddAuthorizationCodeRedirectURIColumn()
+		if err != nil {
+			return false, common.ChainError("error adding authorization code redirect uri column", err)
+		}
+
+		return true, nil
+	})
+}
+
+func (m migrator022) Down() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//drop the authorization code redirect uri column
+		err := sqlTx.DropAuthorizationCodeRedirectURIColumn()
+		if err != nil {
+			return false, common.ChainError("error dropping authorization code redirect uri column", err)
+		}
+
+		return true, nil
+	})
+}
@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	sqladapter "github.com/mhogar/amber/data/database/sql_adapter"
+
+	"github.com/mhogar/migrationrunner"
+)
+
+func m023(exec data.DataExecutor, sf data.ScopeFactory) migrationrunner.Migration {
+	return migrationrunner.Migration{
+		Timestamp:   "023",
+		Description: "add totp last time step column to user table",
+		Migrator: &migrator023{
+			Executor:     exec,
+			ScopeFactory: sf,
+		},
+	}
+}
+
+type migrator023 struct {
+	Executor     data.DataExecutor
+	ScopeFactory data.ScopeFactory
+}
+
+func (m migrator023) Up() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//add the user totp last time step column
+		err := sqlTx.AddUserTOTPLastTimeStepColumn()
+		if err != nil {
+			return false, common.ChainError("error adding user totp last time step column", err)
+		}
+
+		return true, nil
+	})
+}
+
+func (m migrator023) Down() error {
+	return m.ScopeFactory.CreateTransactionScope(m.Executor, func(tx data.Transaction) (bool, error) {
+		sqlTx := tx.(*sqladapter.SQLTransaction)
+
+		//drop the user totp last time step column
+		err := sqlTx.DropUserTOTPLastTimeStepColumn()
+		if err != nil {
+			return false, common.ChainError("error dropping user totp last time step column", err)
+		}
+
+		return true, nil
+	})
+}
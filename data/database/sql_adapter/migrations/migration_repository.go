@@ -17,6 +17,25 @@ func (repo MigrationRepository) GetMigrations() []migrationrunner.Migration {
 		m002(repo.Executor, repo.ScopeFactory),
 		m003(repo.Executor, repo.ScopeFactory),
 		m004(repo.Executor, repo.ScopeFactory),
+		m005(repo.Executor, repo.ScopeFactory),
+		m006(repo.Executor, repo.ScopeFactory),
+		m007(repo.Executor, repo.ScopeFactory),
+		m008(repo.Executor, repo.ScopeFactory),
+		m009(repo.Executor, repo.ScopeFactory),
+		m010(repo.Executor, repo.ScopeFactory),
+		m011(repo.Executor, repo.ScopeFactory),
+		m012(repo.Executor, repo.ScopeFactory),
+		m013(repo.Executor, repo.ScopeFactory),
+		m014(repo.Executor, repo.ScopeFactory),
+		m015(repo.Executor, repo.ScopeFactory),
+		m016(repo.Executor, repo.ScopeFactory),
+		m017(repo.Executor, repo.ScopeFactory),
+		m018(repo.Executor, repo.ScopeFactory),
+		m019(repo.Executor, repo.ScopeFactory),
+		m020(repo.Executor, repo.ScopeFactory),
+		m021(repo.Executor, repo.ScopeFactory),
+		m022(repo.Executor, repo.ScopeFactory),
+		m023(repo.Executor, repo.ScopeFactory),
 	}
 }
 
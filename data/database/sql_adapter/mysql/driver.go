@@ -0,0 +1,59 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"time"
+
+	"github.com/mhogar/amber/data/database/sql_adapter/mysql/scripts"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// driverName is the name the mysql driver is registered under.
+// It is separate from the default "mysql" name so every connection is set up the way the scripts expect.
+const driverName = "amber_mysql"
+
+func init() {
+	sql.Register(driverName, connectorDriver{})
+}
+
+// connectorDriver wraps the mysql driver, forcing the connection options the sql adapter depends on.
+type connectorDriver struct{}
+
+func (d connectorDriver) Open(dsn string) (driver.Conn, error) {
+	connector, err := d.OpenConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return connector.Connect(context.Background())
+}
+
+func (connectorDriver) OpenConnector(dsn string) (driver.Connector, error) {
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	//scan DATETIME columns into time.Time, stored and read as utc
+	cfg.ParseTime = true
+	cfg.Loc = time.UTC
+	if cfg.Params == nil {
+		cfg.Params = map[string]string{}
+	}
+	cfg.Params["time_zone"] = "'+00:00'"
+
+	//the adapter treats rows affected as rows found, which mysql only reports for unchanged rows with this flag
+	cfg.ClientFoundRows = true
+
+	return mysql.NewConnector(cfg)
+}
+
+type Driver struct {
+	scripts.ScriptRepository
+}
+
+func (Driver) GetDriverName() string {
+	return driverName
+}
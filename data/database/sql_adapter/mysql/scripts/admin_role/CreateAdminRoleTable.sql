@@ -0,0 +1,8 @@
+CREATE TABLE `admin_role` (
+	`key` INT NOT NULL AUTO_INCREMENT,
+	`name` VARCHAR(30) NOT NULL,
+	`description` VARCHAR(255) NOT NULL,
+	`permissions` TEXT NOT NULL,
+	CONSTRAINT `admin_role_pk` PRIMARY KEY (`key`),
+	CONSTRAINT `admin_role_name_un` UNIQUE (`name`)
+)
\ No newline at end of file
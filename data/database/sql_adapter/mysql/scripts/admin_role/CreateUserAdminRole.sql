@@ -0,0 +1,5 @@
+INSERT INTO `user_admin_role` (`user_key`, `admin_role_key`)
+	SELECT u.`key`, ar.`key`
+		FROM (SELECT ? AS `username`, ? AS `name`) p
+			INNER JOIN `user` u ON u.`username` = p.`username`
+			INNER JOIN `admin_role` ar ON ar.`name` = p.`name`
\ No newline at end of file
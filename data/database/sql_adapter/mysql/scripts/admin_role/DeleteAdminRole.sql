@@ -0,0 +1,2 @@
+DELETE FROM `admin_role`
+    WHERE `name` = ?
\ No newline at end of file
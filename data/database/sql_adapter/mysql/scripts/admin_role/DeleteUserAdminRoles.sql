@@ -0,0 +1,2 @@
+DELETE FROM `user_admin_role`
+    WHERE `user_key` IN (SELECT u.`key` FROM `user` u WHERE u.`username` = ?)
\ No newline at end of file
@@ -0,0 +1,3 @@
+SELECT ar.`name`, ar.`description`, ar.`permissions`
+    FROM `admin_role` ar
+    ORDER BY ar.`name`
\ No newline at end of file
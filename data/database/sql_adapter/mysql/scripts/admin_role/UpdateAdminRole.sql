@@ -0,0 +1,5 @@
+UPDATE `admin_role` ar
+    INNER JOIN (SELECT ? AS `name`, ? AS `description`, ? AS `permissions`) p ON ar.`name` = p.`name`
+SET
+    ar.`description` = p.`description`,
+    ar.`permissions` = p.`permissions`
\ No newline at end of file
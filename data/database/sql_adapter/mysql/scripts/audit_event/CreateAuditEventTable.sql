@@ -0,0 +1,10 @@
+CREATE TABLE `audit_event` (
+	`id` CHAR(36) NOT NULL,
+	`actor` VARCHAR(64) NOT NULL,
+	`action` VARCHAR(32) NOT NULL,
+	`target` VARCHAR(128) NOT NULL,
+	`ip_address` VARCHAR(64) NOT NULL,
+	`timestamp` DATETIME(6) NOT NULL,
+	CONSTRAINT `audit_event_pk` PRIMARY KEY (`id`),
+	INDEX `audit_event_timestamp_idx` (`timestamp`)
+)
\ No newline at end of file
@@ -0,0 +1,10 @@
+SELECT ae.`id`, ae.`actor`, ae.`action`, ae.`target`, ae.`ip_address`, ae.`timestamp`
+    FROM `audit_event` ae,
+        (SELECT ? AS `actor`, ? AS `action`, ? AS `target`, ? AS `since`, ? AS `until`) f
+    WHERE (f.`actor` = '' OR ae.`actor` = f.`actor`)
+        AND (f.`action` = '' OR ae.`action` = f.`action`)
+        AND (f.`target` = '' OR ae.`target` = f.`target`)
+        AND (f.`since` IS NULL OR ae.`timestamp` >= f.`since`)
+        AND (f.`until` IS NULL OR ae.`timestamp` < f.`until`)
+    ORDER BY ae.`timestamp` DESC, ae.`id`
+    LIMIT ? OFFSET ?
\ No newline at end of file
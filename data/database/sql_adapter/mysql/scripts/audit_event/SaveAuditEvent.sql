@@ -0,0 +1,2 @@
+INSERT INTO `audit_event` (`id`, `actor`, `action`, `target`, `ip_address`, `timestamp`)
+    VALUES (?, ?, ?, ?, ?, ?)
\ No newline at end of file
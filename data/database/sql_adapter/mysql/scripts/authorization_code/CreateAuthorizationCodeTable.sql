@@ -0,0 +1,11 @@
+CREATE TABLE `authorization_code` (
+	`code` CHAR(36) NOT NULL,
+	`client_key` SMALLINT NOT NULL,
+	`user_key` INTEGER NOT NULL,
+	`code_challenge` VARCHAR(128) NOT NULL,
+	`nonce` VARCHAR(255) NOT NULL,
+	`expires_at` DATETIME(6) NOT NULL,
+	CONSTRAINT `authorization_code_pk` PRIMARY KEY (`code`),
+	CONSTRAINT `authorization_code_client_fk` FOREIGN KEY (`client_key`) REFERENCES `client`(`key`) ON DELETE CASCADE,
+	CONSTRAINT `authorization_code_user_fk` FOREIGN KEY (`user_key`) REFERENCES `user`(`key`) ON DELETE CASCADE
+)
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE FROM `authorization_code`
+    WHERE `code` = ?
\ No newline at end of file
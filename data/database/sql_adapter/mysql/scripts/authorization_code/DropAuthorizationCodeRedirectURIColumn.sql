@@ -0,0 +1,2 @@
+ALTER TABLE `authorization_code`
+	DROP COLUMN `redirect_uri`
\ No newline at end of file
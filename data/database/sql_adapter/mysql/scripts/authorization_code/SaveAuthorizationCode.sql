@@ -0,0 +1,5 @@
+INSERT INTO `authorization_code` (`code`, `client_key`, `user_key`, `code_challenge`, `nonce`, `redirect_uri`, `expires_at`)
+    SELECT p.`code`, c.`key`, u.`key`, p.`code_challenge`, p.`nonce`, p.`redirect_uri`, p.`expires_at`
+        FROM (SELECT ? AS `code`, ? AS `client_uid`, ? AS `username`, ? AS `code_challenge`, ? AS `nonce`, ? AS `redirect_uri`, ? AS `expires_at`) p
+            INNER JOIN `client` c ON c.`uid` = p.`client_uid`
+            INNER JOIN `user` u ON u.`username` = p.`username`
\ No newline at end of file
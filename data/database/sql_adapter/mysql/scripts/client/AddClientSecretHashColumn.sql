@@ -0,0 +1,2 @@
+ALTER TABLE `client`
+	ADD COLUMN `secret_hash` BLOB
\ No newline at end of file
@@ -0,0 +1,4 @@
+SELECT COUNT(*)
+	FROM `client` c,
+		(SELECT ? AS `search`) p
+	WHERE LOWER(SUBSTR(c.`name`, 1, CHAR_LENGTH(p.`search`))) = LOWER(p.`search`)
\ No newline at end of file
@@ -0,0 +1,2 @@
+INSERT INTO `client` (`uid`, `name`, `redirect_url`, `token_type`, `key_uri`)
+	VALUES (?, ?, ?, ?, ?)
\ No newline at end of file
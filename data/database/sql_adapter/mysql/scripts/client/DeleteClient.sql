@@ -0,0 +1,2 @@
+DELETE FROM `client`
+    WHERE `uid` = ?
\ No newline at end of file
@@ -0,0 +1,13 @@
+SELECT c.`uid`, c.`name`, c.`redirect_url`, c.`token_type`, c.`key_uri`, c.`secret_hash`
+	FROM `client` c,
+		(SELECT ? AS `search`, ? AS `descending`, ? AS `has_cursor`, ? AS `cursor_name`, ? AS `cursor_uid`) p
+	WHERE LOWER(SUBSTR(c.`name`, 1, CHAR_LENGTH(p.`search`))) = LOWER(p.`search`)
+		AND (NOT p.`has_cursor`
+			OR (p.`descending` AND (c.`name` < p.`cursor_name` OR (c.`name` = p.`cursor_name` AND c.`uid` < p.`cursor_uid`)))
+			OR (NOT p.`descending` AND (c.`name` > p.`cursor_name` OR (c.`name` = p.`cursor_name` AND c.`uid` > p.`cursor_uid`))))
+	ORDER BY
+		CASE WHEN p.`descending` THEN c.`name` END DESC,
+		CASE WHEN p.`descending` THEN c.`uid` END DESC,
+		c.`name`,
+		c.`uid`
+	LIMIT ?
\ No newline at end of file
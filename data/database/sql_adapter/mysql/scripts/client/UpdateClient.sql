@@ -0,0 +1,7 @@
+UPDATE `client` c
+    INNER JOIN (SELECT ? AS `uid`, ? AS `name`, ? AS `redirect_url`, ? AS `token_type`, ? AS `key_uri`) p ON c.`uid` = p.`uid`
+SET
+    c.`name` = p.`name`,
+    c.`redirect_url` = p.`redirect_url`,
+    c.`token_type` = p.`token_type`,
+    c.`key_uri` = p.`key_uri`
\ No newline at end of file
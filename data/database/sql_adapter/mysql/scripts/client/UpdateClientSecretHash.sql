@@ -0,0 +1,4 @@
+UPDATE `client` c
+    INNER JOIN (SELECT ? AS `uid`, ? AS `secret_hash`) p ON c.`uid` = p.`uid`
+SET
+    c.`secret_hash` = p.`secret_hash`
\ No newline at end of file
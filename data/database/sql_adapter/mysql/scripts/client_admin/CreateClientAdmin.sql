@@ -0,0 +1,5 @@
+INSERT INTO `client_admin` (`client_key`, `user_key`, `is_owner`)
+	SELECT c.`key`, u.`key`, p.`is_owner`
+		FROM (SELECT ? AS `client_uid`, ? AS `username`, ? AS `is_owner`) p
+			INNER JOIN `client` c ON c.`uid` = p.`client_uid`
+			INNER JOIN `user` u ON u.`username` = p.`username`
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE `client_admin` (
+	`client_key` SMALLINT NOT NULL,
+	`user_key` INTEGER NOT NULL,
+	`is_owner` BOOLEAN NOT NULL,
+	CONSTRAINT `client_admin_pk` PRIMARY KEY (`client_key`, `user_key`),
+	CONSTRAINT `client_admin_client_fk` FOREIGN KEY (`client_key`) REFERENCES `client`(`key`) ON DELETE CASCADE,
+	CONSTRAINT `client_admin_user_fk` FOREIGN KEY (`user_key`) REFERENCES `user`(`key`) ON DELETE CASCADE
+)
\ No newline at end of file
@@ -0,0 +1,3 @@
+DELETE FROM `client_admin`
+    WHERE `client_key` IN (SELECT c.`key` FROM `client` c WHERE c.`uid` = ?) AND
+          `user_key` IN (SELECT u.`key` FROM `user` u WHERE u.`username` = ?)
\ No newline at end of file
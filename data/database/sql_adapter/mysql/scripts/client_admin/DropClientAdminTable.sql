@@ -0,0 +1 @@
+DROP TABLE `client_admin`
\ No newline at end of file
@@ -0,0 +1,5 @@
+SELECT c.`uid`, u.`username`, ca.`is_owner`
+    FROM `client_admin` ca
+        INNER JOIN `client` c ON c.`uid` = ? AND c.`key` = ca.`client_key`
+        INNER JOIN `user` u ON u.`key` = ca.`user_key`
+    ORDER BY u.`username`
\ No newline at end of file
@@ -0,0 +1,2 @@
+INSERT INTO `group` (`name`, `description`, `priority`)
+    VALUES (?, ?, ?)
\ No newline at end of file
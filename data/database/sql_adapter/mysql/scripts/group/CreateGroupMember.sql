@@ -0,0 +1,5 @@
+INSERT INTO `group_member` (`group_key`, `user_key`)
+	SELECT g.`key`, u.`key`
+		FROM (SELECT ? AS `name`, ? AS `username`) p
+			INNER JOIN `group` g ON g.`name` = p.`name`
+			INNER JOIN `user` u ON u.`username` = p.`username`
\ No newline at end of file
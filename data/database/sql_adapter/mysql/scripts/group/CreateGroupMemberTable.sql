@@ -0,0 +1,7 @@
+CREATE TABLE `group_member` (
+	`group_key` INT NOT NULL,
+	`user_key` INT NOT NULL,
+	CONSTRAINT `group_member_pk` PRIMARY KEY (`group_key`, `user_key`),
+	CONSTRAINT `group_member_group_fk` FOREIGN KEY (`group_key`) REFERENCES `group`(`key`) ON DELETE CASCADE,
+	CONSTRAINT `group_member_user_fk` FOREIGN KEY (`user_key`) REFERENCES `user`(`key`) ON DELETE CASCADE
+)
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE FROM `group`
+    WHERE `name` = ?
\ No newline at end of file
@@ -0,0 +1,3 @@
+DELETE FROM `group_member`
+    WHERE `group_key` IN (SELECT g.`key` FROM `group` g WHERE g.`name` = ?) AND
+          `user_key` IN (SELECT u.`key` FROM `user` u WHERE u.`username` = ?)
\ No newline at end of file
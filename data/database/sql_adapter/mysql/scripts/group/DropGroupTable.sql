@@ -0,0 +1 @@
+DROP TABLE `group`
\ No newline at end of file
@@ -0,0 +1,5 @@
+UPDATE `group` g
+    INNER JOIN (SELECT ? AS `name`, ? AS `description`, ? AS `priority`) p ON g.`name` = p.`name`
+SET
+    g.`description` = p.`description`,
+    g.`priority` = p.`priority`
\ No newline at end of file
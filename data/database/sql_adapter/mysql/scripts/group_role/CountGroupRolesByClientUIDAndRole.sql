@@ -0,0 +1,4 @@
+SELECT COUNT(*)
+    FROM `group_role` gr
+        INNER JOIN `client` c ON c.`uid` = ? AND c.`key` = gr.`client_key`
+    WHERE gr.`role` = ?
\ No newline at end of file
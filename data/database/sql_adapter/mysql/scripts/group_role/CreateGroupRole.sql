@@ -0,0 +1,5 @@
+INSERT INTO `group_role` (`client_key`, `group_key`, `role`)
+	SELECT c.`key`, g.`key`, p.`role`
+		FROM (SELECT ? AS `client_uid`, ? AS `name`, ? AS `role`) p
+			INNER JOIN `client` c ON c.`uid` = p.`client_uid`
+			INNER JOIN `group` g ON g.`name` = p.`name`
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE `group_role` (
+	`client_key` SMALLINT NOT NULL,
+	`group_key` INT NOT NULL,
+	`role` VARCHAR(15) NOT NULL,
+	CONSTRAINT `group_role_pk` PRIMARY KEY (`client_key`, `group_key`, `role`),
+	CONSTRAINT `group_role_client_fk` FOREIGN KEY (`client_key`) REFERENCES `client`(`key`) ON DELETE CASCADE,
+	CONSTRAINT `group_role_group_fk` FOREIGN KEY (`group_key`) REFERENCES `group`(`key`) ON DELETE CASCADE
+)
\ No newline at end of file
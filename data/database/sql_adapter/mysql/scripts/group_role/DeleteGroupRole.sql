@@ -0,0 +1,3 @@
+DELETE FROM `group_role`
+    WHERE `client_key` IN (SELECT c.`key` FROM `client` c WHERE c.`uid` = ?) AND
+          `group_key` IN (SELECT g.`key` FROM `group` g WHERE g.`name` = ?)
\ No newline at end of file
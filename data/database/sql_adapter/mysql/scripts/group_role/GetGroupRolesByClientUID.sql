@@ -0,0 +1,5 @@
+SELECT c.`uid`, g.`name`, gr.`role`
+    FROM `group_role` gr
+        INNER JOIN `client` c ON c.`uid` = ? AND c.`key` = gr.`client_key`
+        INNER JOIN `group` g ON g.`key` = gr.`group_key`
+    ORDER BY g.`name`, gr.`role`
\ No newline at end of file
@@ -0,0 +1,5 @@
+UPDATE `group_role` gr
+    INNER JOIN `client` c ON c.`key` = gr.`client_key`
+    INNER JOIN (SELECT ? AS `client_uid`, ? AS `role`, ? AS `new_role`) p ON c.`uid` = p.`client_uid` AND gr.`role` = p.`role`
+SET
+    gr.`role` = p.`new_role`
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE `invitation` (
+	`key` INT NOT NULL AUTO_INCREMENT,
+	`id` CHAR(36) NOT NULL,
+	`token_hash` VARBINARY(64) NOT NULL,
+	`email` VARCHAR(254) NOT NULL,
+	`rank` SMALLINT NOT NULL,
+	`expires_at` DATETIME(6) NOT NULL,
+	CONSTRAINT `invitation_pk` PRIMARY KEY (`key`),
+	CONSTRAINT `invitation_id_un` UNIQUE (`id`),
+	CONSTRAINT `invitation_token_hash_un` UNIQUE (`token_hash`)
+)
\ No newline at end of file
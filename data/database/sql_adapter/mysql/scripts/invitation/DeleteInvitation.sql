@@ -0,0 +1,2 @@
+DELETE FROM `invitation`
+    WHERE `id` = ?
\ No newline at end of file
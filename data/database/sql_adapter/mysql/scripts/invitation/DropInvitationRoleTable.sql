@@ -0,0 +1 @@
+DROP TABLE `invitation_role`
\ No newline at end of file
@@ -0,0 +1,5 @@
+UPDATE `invitation_role` ir
+    INNER JOIN `client` c ON c.`key` = ir.`client_key`
+    INNER JOIN (SELECT ? AS `client_uid`, ? AS `role`, ? AS `new_role`) p ON c.`uid` = p.`client_uid` AND ir.`role` = p.`role`
+SET
+    ir.`role` = p.`new_role`
\ No newline at end of file
@@ -0,0 +1,5 @@
+INSERT INTO `invitation_role` (`invitation_key`, `client_key`, `role`)
+	SELECT i.`key`, c.`key`, p.`role`
+		FROM (SELECT ? AS `invitation_id`, ? AS `client_uid`, ? AS `role`) p
+			INNER JOIN `invitation` i ON i.`id` = p.`invitation_id`
+			INNER JOIN `client` c ON c.`uid` = p.`client_uid`
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE FROM `login_throttle`
+    WHERE `type` = ? AND `key` = ?
\ No newline at end of file
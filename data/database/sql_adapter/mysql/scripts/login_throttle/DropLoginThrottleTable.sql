@@ -0,0 +1 @@
+DROP TABLE `login_throttle`
\ No newline at end of file
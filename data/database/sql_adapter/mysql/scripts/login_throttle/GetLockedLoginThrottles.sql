@@ -0,0 +1,4 @@
+SELECT lt.`type`, lt.`key`, lt.`failed_attempts`, lt.`last_failed_at`, lt.`locked_until`
+    FROM `login_throttle` lt
+    WHERE lt.`locked_until` > ?
+    ORDER BY lt.`type`, lt.`key`
\ No newline at end of file
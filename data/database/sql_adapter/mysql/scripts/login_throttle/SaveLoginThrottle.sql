@@ -0,0 +1,6 @@
+INSERT INTO `login_throttle` (`type`, `key`, `failed_attempts`, `last_failed_at`, `locked_until`)
+    VALUES (?, ?, ?, ?, ?)
+    ON DUPLICATE KEY UPDATE
+        `failed_attempts` = VALUES(`failed_attempts`),
+        `last_failed_at` = VALUES(`last_failed_at`),
+        `locked_until` = VALUES(`locked_until`)
\ No newline at end of file
@@ -0,0 +1,4 @@
+CREATE TABLE IF NOT EXISTS `migration` (
+    `timestamp` CHAR(3) NOT NULL,
+    CONSTRAINT `migration_pk` PRIMARY KEY (`timestamp`)
+)
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE FROM `migration`
+   WHERE `timestamp` = ?
\ No newline at end of file
@@ -0,0 +1,3 @@
+SELECT m.`timestamp` FROM `migration` m
+    ORDER BY m.`timestamp` DESC
+    LIMIT 1
\ No newline at end of file
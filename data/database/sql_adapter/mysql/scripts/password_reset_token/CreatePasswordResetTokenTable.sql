@@ -0,0 +1,7 @@
+CREATE TABLE `password_reset_token` (
+	`token_hash` VARBINARY(64) NOT NULL,
+	`user_key` INTEGER NOT NULL,
+	`expires_at` DATETIME(6) NOT NULL,
+	CONSTRAINT `password_reset_token_pk` PRIMARY KEY (`token_hash`),
+	CONSTRAINT `password_reset_token_user_fk` FOREIGN KEY (`user_key`) REFERENCES `user`(`key`) ON DELETE CASCADE
+)
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE FROM `password_reset_token`
+    WHERE `token_hash` = ?
\ No newline at end of file
@@ -0,0 +1,4 @@
+SELECT prt.`token_hash`, u.`username`, prt.`expires_at`
+    FROM `password_reset_token` prt
+        INNER JOIN `user` u ON u.`key` = prt.`user_key`
+    WHERE prt.`token_hash` = ?
\ No newline at end of file
@@ -0,0 +1,4 @@
+INSERT INTO `password_reset_token` (`token_hash`, `user_key`, `expires_at`)
+    SELECT p.`token_hash`, u.`key`, p.`expires_at`
+        FROM (SELECT ? AS `token_hash`, ? AS `username`, ? AS `expires_at`) p
+            INNER JOIN `user` u ON u.`username` = p.`username`
\ No newline at end of file
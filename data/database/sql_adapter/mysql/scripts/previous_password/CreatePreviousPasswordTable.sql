@@ -0,0 +1,8 @@
+CREATE TABLE `previous_password` (
+	`key` INT NOT NULL AUTO_INCREMENT,
+	`user_key` INTEGER NOT NULL,
+	`password_hash` BLOB NOT NULL,
+	`replaced_at` DATETIME(6) NOT NULL,
+	CONSTRAINT `previous_password_pk` PRIMARY KEY (`key`),
+	CONSTRAINT `previous_password_user_fk` FOREIGN KEY (`user_key`) REFERENCES `user`(`key`) ON DELETE CASCADE
+)
\ No newline at end of file
@@ -0,0 +1,5 @@
+DELETE FROM `previous_password`
+    WHERE `user_key` IN (
+        SELECT u.`key` FROM `user` u WHERE u.`username` = ?
+    )
+    AND `replaced_at` < ?
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE `previous_password`
\ No newline at end of file
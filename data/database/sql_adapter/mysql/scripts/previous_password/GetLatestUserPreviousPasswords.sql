@@ -0,0 +1,6 @@
+SELECT u.`username`, pp.`password_hash`, pp.`replaced_at`
+    FROM `previous_password` pp
+        INNER JOIN `user` u ON u.`key` = pp.`user_key`
+    WHERE u.`username` = ?
+    ORDER BY pp.`replaced_at` DESC, pp.`key` DESC
+    LIMIT ?
\ No newline at end of file
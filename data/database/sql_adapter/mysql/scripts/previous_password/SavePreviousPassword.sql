@@ -0,0 +1,4 @@
+INSERT INTO `previous_password` (`user_key`, `password_hash`, `replaced_at`)
+	SELECT u.`key`, p.`password_hash`, p.`replaced_at`
+		FROM (SELECT ? AS `username`, ? AS `password_hash`, ? AS `replaced_at`) p
+			INNER JOIN `user` u ON u.`username` = p.`username`
\ No newline at end of file
@@ -0,0 +1,3 @@
+DELETE rc FROM `recovery_code` rc
+    INNER JOIN `user` u ON u.`key` = rc.`user_key`
+    WHERE u.`username` = ? AND rc.`code_hash` = ?
\ No newline at end of file
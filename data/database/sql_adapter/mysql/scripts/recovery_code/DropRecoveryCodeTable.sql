@@ -0,0 +1 @@
+DROP TABLE `recovery_code`
\ No newline at end of file
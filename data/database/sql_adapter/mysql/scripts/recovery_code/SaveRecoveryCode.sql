@@ -0,0 +1,4 @@
+INSERT INTO `recovery_code` (`user_key`, `code_hash`)
+	SELECT u.`key`, p.`code_hash`
+		FROM (SELECT ? AS `username`, ? AS `code_hash`) p
+			INNER JOIN `user` u ON u.`username` = p.`username`
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE `refresh_token` (
+	`token` CHAR(36) NOT NULL,
+	`family_id` CHAR(36) NOT NULL,
+	`client_key` SMALLINT NOT NULL,
+	`user_key` INTEGER NOT NULL,
+	`rotated` BOOLEAN NOT NULL DEFAULT FALSE,
+	`expires_at` DATETIME(6) NOT NULL,
+	CONSTRAINT `refresh_token_pk` PRIMARY KEY (`token`),
+	CONSTRAINT `refresh_token_client_fk` FOREIGN KEY (`client_key`) REFERENCES `client`(`key`) ON DELETE CASCADE,
+	CONSTRAINT `refresh_token_user_fk` FOREIGN KEY (`user_key`) REFERENCES `user`(`key`) ON DELETE CASCADE
+)
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE FROM `refresh_token`
+    WHERE `family_id` = ?
\ No newline at end of file
@@ -0,0 +1,3 @@
+UPDATE `refresh_token` SET
+    `rotated` = TRUE
+WHERE `token` = ? AND `rotated` = FALSE
\ No newline at end of file
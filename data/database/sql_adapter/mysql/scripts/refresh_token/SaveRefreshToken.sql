@@ -0,0 +1,5 @@
+INSERT INTO `refresh_token` (`token`, `family_id`, `client_key`, `user_key`, `rotated`, `expires_at`)
+    SELECT p.`token`, p.`family_id`, c.`key`, u.`key`, p.`rotated`, p.`expires_at`
+        FROM (SELECT ? AS `token`, ? AS `family_id`, ? AS `client_uid`, ? AS `username`, ? AS `rotated`, ? AS `expires_at`) p
+            INNER JOIN `client` c ON c.`uid` = p.`client_uid`
+            INNER JOIN `user` u ON u.`username` = p.`username`
\ No newline at end of file
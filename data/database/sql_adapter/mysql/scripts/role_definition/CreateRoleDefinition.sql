@@ -0,0 +1,4 @@
+INSERT INTO `role_definition` (`client_key`, `name`, `description`, `is_default`)
+	SELECT c.`key`, p.`name`, p.`description`, p.`is_default`
+		FROM (SELECT ? AS `client_uid`, ? AS `name`, ? AS `description`, ? AS `is_default`) p
+			INNER JOIN `client` c ON c.`uid` = p.`client_uid`
\ No newline at end of file
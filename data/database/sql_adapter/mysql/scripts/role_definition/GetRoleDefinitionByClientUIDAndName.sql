@@ -0,0 +1,4 @@
+SELECT c.`uid`, rd.`name`, rd.`description`, rd.`is_default`
+    FROM `role_definition` rd
+        INNER JOIN `client` c ON c.`uid` = ? AND c.`key` = rd.`client_key`
+    WHERE rd.`name` = ?
\ No newline at end of file
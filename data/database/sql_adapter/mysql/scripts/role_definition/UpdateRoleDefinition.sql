@@ -0,0 +1,7 @@
+UPDATE `role_definition` rd
+    INNER JOIN `client` c ON c.`key` = rd.`client_key`
+    INNER JOIN (SELECT ? AS `client_uid`, ? AS `old_name`, ? AS `name`, ? AS `description`, ? AS `is_default`) p ON c.`uid` = p.`client_uid` AND rd.`name` = p.`old_name`
+SET
+    rd.`name` = p.`name`,
+    rd.`description` = p.`description`,
+    rd.`is_default` = p.`is_default`
\ No newline at end of file
@@ -0,0 +1,1680 @@
+// Auto generated. DO NOT EDIT.
+
+package scripts
+
+// ScriptRepository is an implementation of the sql script repository interface that fetches scripts laoded from sql files.
+type ScriptRepository struct {}
+
+// CreateAdminRoleScript gets the CreateAdminRole script.
+func (ScriptRepository) CreateAdminRoleScript() string {
+	return `
+INSERT INTO ` + "`" + `admin_role` + "`" + ` (` + "`" + `name` + "`" + `, ` + "`" + `description` + "`" + `, ` + "`" + `permissions` + "`" + `)
+    VALUES (?, ?, ?)
+`
+}
+
+// CreateAdminRoleTableScript gets the CreateAdminRoleTable script.
+func (ScriptRepository) CreateAdminRoleTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `admin_role` + "`" + ` (
+	` + "`" + `key` + "`" + ` INT NOT NULL AUTO_INCREMENT,
+	` + "`" + `name` + "`" + ` VARCHAR(30) NOT NULL,
+	` + "`" + `description` + "`" + ` VARCHAR(255) NOT NULL,
+	` + "`" + `permissions` + "`" + ` TEXT NOT NULL,
+	CONSTRAINT ` + "`" + `admin_role_pk` + "`" + ` PRIMARY KEY (` + "`" + `key` + "`" + `),
+	CONSTRAINT ` + "`" + `admin_role_name_un` + "`" + ` UNIQUE (` + "`" + `name` + "`" + `)
+)
+`
+}
+
+// CreateUserAdminRoleScript gets the CreateUserAdminRole script.
+func (ScriptRepository) CreateUserAdminRoleScript() string {
+	return `
+INSERT INTO ` + "`" + `user_admin_role` + "`" + ` (` + "`" + `user_key` + "`" + `, ` + "`" + `admin_role_key` + "`" + `)
+	SELECT u.` + "`" + `key` + "`" + `, ar.` + "`" + `key` + "`" + `
+		FROM (SELECT ? AS ` + "`" + `username` + "`" + `, ? AS ` + "`" + `name` + "`" + `) p
+			INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `username` + "`" + ` = p.` + "`" + `username` + "`" + `
+			INNER JOIN ` + "`" + `admin_role` + "`" + ` ar ON ar.` + "`" + `name` + "`" + ` = p.` + "`" + `name` + "`" + `
+`
+}
+
+// CreateUserAdminRoleTableScript gets the CreateUserAdminRoleTable script.
+func (ScriptRepository) CreateUserAdminRoleTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `user_admin_role` + "`" + ` (
+	` + "`" + `user_key` + "`" + ` INT NOT NULL,
+	` + "`" + `admin_role_key` + "`" + ` INT NOT NULL,
+	CONSTRAINT ` + "`" + `user_admin_role_pk` + "`" + ` PRIMARY KEY (` + "`" + `user_key` + "`" + `, ` + "`" + `admin_role_key` + "`" + `),
+	CONSTRAINT ` + "`" + `user_admin_role_user_fk` + "`" + ` FOREIGN KEY (` + "`" + `user_key` + "`" + `) REFERENCES ` + "`" + `user` + "`" + `(` + "`" + `key` + "`" + `) ON DELETE CASCADE,
+	CONSTRAINT ` + "`" + `user_admin_role_admin_role_fk` + "`" + ` FOREIGN KEY (` + "`" + `admin_role_key` + "`" + `) REFERENCES ` + "`" + `admin_role` + "`" + `(` + "`" + `key` + "`" + `) ON DELETE CASCADE
+)
+`
+}
+
+// DeleteAdminRoleScript gets the DeleteAdminRole script.
+func (ScriptRepository) DeleteAdminRoleScript() string {
+	return `
+DELETE FROM ` + "`" + `admin_role` + "`" + `
+    WHERE ` + "`" + `name` + "`" + ` = ?
+`
+}
+
+// DeleteUserAdminRolesScript gets the DeleteUserAdminRoles script.
+func (ScriptRepository) DeleteUserAdminRolesScript() string {
+	return `
+DELETE FROM ` + "`" + `user_admin_role` + "`" + `
+    WHERE ` + "`" + `user_key` + "`" + ` IN (SELECT u.` + "`" + `key` + "`" + ` FROM ` + "`" + `user` + "`" + ` u WHERE u.` + "`" + `username` + "`" + ` = ?)
+`
+}
+
+// DropAdminRoleTableScript gets the DropAdminRoleTable script.
+func (ScriptRepository) DropAdminRoleTableScript() string {
+	return `
+DROP TABLE ` + "`" + `admin_role` + "`" + `
+`
+}
+
+// DropUserAdminRoleTableScript gets the DropUserAdminRoleTable script.
+func (ScriptRepository) DropUserAdminRoleTableScript() string {
+	return `
+DROP TABLE ` + "`" + `user_admin_role` + "`" + `
+`
+}
+
+// GetAdminRoleByNameScript gets the GetAdminRoleByName script.
+func (ScriptRepository) GetAdminRoleByNameScript() string {
+	return `
+SELECT ar.` + "`" + `name` + "`" + `, ar.` + "`" + `description` + "`" + `, ar.` + "`" + `permissions` + "`" + `
+    FROM ` + "`" + `admin_role` + "`" + ` ar
+    WHERE ar.` + "`" + `name` + "`" + ` = ?
+`
+}
+
+// GetAdminRoleUsernamesScript gets the GetAdminRoleUsernames script.
+func (ScriptRepository) GetAdminRoleUsernamesScript() string {
+	return `
+SELECT u.` + "`" + `username` + "`" + `
+    FROM ` + "`" + `user_admin_role` + "`" + ` uar
+        INNER JOIN ` + "`" + `admin_role` + "`" + ` ar ON ar.` + "`" + `name` + "`" + ` = ? AND ar.` + "`" + `key` + "`" + ` = uar.` + "`" + `admin_role_key` + "`" + `
+        INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `key` + "`" + ` = uar.` + "`" + `user_key` + "`" + `
+    ORDER BY u.` + "`" + `username` + "`" + `
+`
+}
+
+// GetAdminRolesScript gets the GetAdminRoles script.
+func (ScriptRepository) GetAdminRolesScript() string {
+	return `
+SELECT ar.` + "`" + `name` + "`" + `, ar.` + "`" + `description` + "`" + `, ar.` + "`" + `permissions` + "`" + `
+    FROM ` + "`" + `admin_role` + "`" + ` ar
+    ORDER BY ar.` + "`" + `name` + "`" + `
+`
+}
+
+// GetAdminRolesByUsernameScript gets the GetAdminRolesByUsername script.
+func (ScriptRepository) GetAdminRolesByUsernameScript() string {
+	return `
+SELECT ar.` + "`" + `name` + "`" + `, ar.` + "`" + `description` + "`" + `, ar.` + "`" + `permissions` + "`" + `
+    FROM ` + "`" + `admin_role` + "`" + ` ar
+        INNER JOIN ` + "`" + `user_admin_role` + "`" + ` uar ON uar.` + "`" + `admin_role_key` + "`" + ` = ar.` + "`" + `key` + "`" + `
+        INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `username` + "`" + ` = ? AND u.` + "`" + `key` + "`" + ` = uar.` + "`" + `user_key` + "`" + `
+    ORDER BY ar.` + "`" + `name` + "`" + `
+`
+}
+
+// UpdateAdminRoleScript gets the UpdateAdminRole script.
+func (ScriptRepository) UpdateAdminRoleScript() string {
+	return `
+UPDATE ` + "`" + `admin_role` + "`" + ` ar
+    INNER JOIN (SELECT ? AS ` + "`" + `name` + "`" + `, ? AS ` + "`" + `description` + "`" + `, ? AS ` + "`" + `permissions` + "`" + `) p ON ar.` + "`" + `name` + "`" + ` = p.` + "`" + `name` + "`" + `
+SET
+    ar.` + "`" + `description` + "`" + ` = p.` + "`" + `description` + "`" + `,
+    ar.` + "`" + `permissions` + "`" + ` = p.` + "`" + `permissions` + "`" + `
+`
+}
+
+// CreateAuditEventTableScript gets the CreateAuditEventTable script.
+func (ScriptRepository) CreateAuditEventTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `audit_event` + "`" + ` (
+	` + "`" + `id` + "`" + ` CHAR(36) NOT NULL,
+	` + "`" + `actor` + "`" + ` VARCHAR(64) NOT NULL,
+	` + "`" + `action` + "`" + ` VARCHAR(32) NOT NULL,
+	` + "`" + `target` + "`" + ` VARCHAR(128) NOT NULL,
+	` + "`" + `ip_address` + "`" + ` VARCHAR(64) NOT NULL,
+	` + "`" + `timestamp` + "`" + ` DATETIME(6) NOT NULL,
+	CONSTRAINT ` + "`" + `audit_event_pk` + "`" + ` PRIMARY KEY (` + "`" + `id` + "`" + `),
+	INDEX ` + "`" + `audit_event_timestamp_idx` + "`" + ` (` + "`" + `timestamp` + "`" + `)
+)
+`
+}
+
+// DropAuditEventTableScript gets the DropAuditEventTable script.
+func (ScriptRepository) DropAuditEventTableScript() string {
+	return `
+DROP TABLE ` + "`" + `audit_event` + "`" + `
+`
+}
+
+// GetAuditEventsScript gets the GetAuditEvents script.
+func (ScriptRepository) GetAuditEventsScript() string {
+	return `
+SELECT ae.` + "`" + `id` + "`" + `, ae.` + "`" + `actor` + "`" + `, ae.` + "`" + `action` + "`" + `, ae.` + "`" + `target` + "`" + `, ae.` + "`" + `ip_address` + "`" + `, ae.` + "`" + `timestamp` + "`" + `
+    FROM ` + "`" + `audit_event` + "`" + ` ae,
+        (SELECT ? AS ` + "`" + `actor` + "`" + `, ? AS ` + "`" + `action` + "`" + `, ? AS ` + "`" + `target` + "`" + `, ? AS ` + "`" + `since` + "`" + `, ? AS ` + "`" + `until` + "`" + `) f
+    WHERE (f.` + "`" + `actor` + "`" + ` = '' OR ae.` + "`" + `actor` + "`" + ` = f.` + "`" + `actor` + "`" + `)
+        AND (f.` + "`" + `action` + "`" + ` = '' OR ae.` + "`" + `action` + "`" + ` = f.` + "`" + `action` + "`" + `)
+        AND (f.` + "`" + `target` + "`" + ` = '' OR ae.` + "`" + `target` + "`" + ` = f.` + "`" + `target` + "`" + `)
+        AND (f.` + "`" + `since` + "`" + ` IS NULL OR ae.` + "`" + `timestamp` + "`" + ` >= f.` + "`" + `since` + "`" + `)
+        AND (f.` + "`" + `until` + "`" + ` IS NULL OR ae.` + "`" + `timestamp` + "`" + ` < f.` + "`" + `until` + "`" + `)
+    ORDER BY ae.` + "`" + `timestamp` + "`" + ` DESC, ae.` + "`" + `id` + "`" + `
+    LIMIT ? OFFSET ?
+`
+}
+
+// SaveAuditEventScript gets the SaveAuditEvent script.
+func (ScriptRepository) SaveAuditEventScript() string {
+	return `
+INSERT INTO ` + "`" + `audit_event` + "`" + ` (` + "`" + `id` + "`" + `, ` + "`" + `actor` + "`" + `, ` + "`" + `action` + "`" + `, ` + "`" + `target` + "`" + `, ` + "`" + `ip_address` + "`" + `, ` + "`" + `timestamp` + "`" + `)
+    VALUES (?, ?, ?, ?, ?, ?)
+`
+}
+
+// AddAuthorizationCodeRedirectURIColumnScript gets the AddAuthorizationCodeRedirectURIColumn script.
+func (ScriptRepository) AddAuthorizationCodeRedirectURIColumnScript() string {
+	return `
+ALTER TABLE ` + "`" + `authorization_code` + "`" + `
+	ADD COLUMN ` + "`" + `redirect_uri` + "`" + ` VARCHAR(100) NOT NULL DEFAULT ''
+`
+}
+
+// CreateAuthorizationCodeTableScript gets the CreateAuthorizationCodeTable script.
+func (ScriptRepository) CreateAuthorizationCodeTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `authorization_code` + "`" + ` (
+	` + "`" + `code` + "`" + ` CHAR(36) NOT NULL,
+	` + "`" + `client_key` + "`" + ` SMALLINT NOT NULL,
+	` + "`" + `user_key` + "`" + ` INTEGER NOT NULL,
+	` + "`" + `code_challenge` + "`" + ` VARCHAR(128) NOT NULL,
+	` + "`" + `nonce` + "`" + ` VARCHAR(255) NOT NULL,
+	` + "`" + `expires_at` + "`" + ` DATETIME(6) NOT NULL,
+	CONSTRAINT ` + "`" + `authorization_code_pk` + "`" + ` PRIMARY KEY (` + "`" + `code` + "`" + `),
+	CONSTRAINT ` + "`" + `authorization_code_client_fk` + "`" + ` FOREIGN KEY (` + "`" + `client_key` + "`" + `) REFERENCES ` + "`" + `client` + "`" + `(` + "`" + `key` + "`" + `) ON DELETE CASCADE,
+	CONSTRAINT ` + "`" + `authorization_code_user_fk` + "`" + ` FOREIGN KEY (` + "`" + `user_key` + "`" + `) REFERENCES ` + "`" + `user` + "`" + `(` + "`" + `key` + "`" + `) ON DELETE CASCADE
+)
+`
+}
+
+// DeleteAuthorizationCodeScript gets the DeleteAuthorizationCode script.
+func (ScriptRepository) DeleteAuthorizationCodeScript() string {
+	return `
+DELETE FROM ` + "`" + `authorization_code` + "`" + `
+    WHERE ` + "`" + `code` + "`" + ` = ?
+`
+}
+
+// DropAuthorizationCodeRedirectURIColumnScript gets the DropAuthorizationCodeRedirectURIColumn script.
+func (ScriptRepository) DropAuthorizationCodeRedirectURIColumnScript() string {
+	return `
+ALTER TABLE ` + "`" + `authorization_code` + "`" + `
+	DROP COLUMN ` + "`" + `redirect_uri` + "`" + `
+`
+}
+
+// DropAuthorizationCodeTableScript gets the DropAuthorizationCodeTable script.
+func (ScriptRepository) DropAuthorizationCodeTableScript() string {
+	return `
+DROP TABLE ` + "`" + `authorization_code` + "`" + `
+`
+}
+
+// GetAuthorizationCodeScript gets the GetAuthorizationCode script.
+func (ScriptRepository) GetAuthorizationCodeScript() string {
+	return `
+SELECT ac.` + "`" + `code` + "`" + `, c.` + "`" + `uid` + "`" + `, u.` + "`" + `username` + "`" + `, ac.` + "`" + `code_challenge` + "`" + `, ac.` + "`" + `nonce` + "`" + `, ac.` + "`" + `redirect_uri` + "`" + `, ac.` + "`" + `expires_at` + "`" + `
+    FROM ` + "`" + `authorization_code` + "`" + ` ac
+        INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `key` + "`" + ` = ac.` + "`" + `client_key` + "`" + `
+        INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `key` + "`" + ` = ac.` + "`" + `user_key` + "`" + `
+    WHERE ac.` + "`" + `code` + "`" + ` = ?
+`
+}
+
+// SaveAuthorizationCodeScript gets the SaveAuthorizationCode script.
+func (ScriptRepository) SaveAuthorizationCodeScript() string {
+	return `
+INSERT INTO ` + "`" + `authorization_code` + "`" + ` (` + "`" + `code` + "`" + `, ` + "`" + `client_key` + "`" + `, ` + "`" + `user_key` + "`" + `, ` + "`" + `code_challenge` + "`" + `, ` + "`" + `nonce` + "`" + `, ` + "`" + `redirect_uri` + "`" + `, ` + "`" + `expires_at` + "`" + `)
+    SELECT p.` + "`" + `code` + "`" + `, c.` + "`" + `key` + "`" + `, u.` + "`" + `key` + "`" + `, p.` + "`" + `code_challenge` + "`" + `, p.` + "`" + `nonce` + "`" + `, p.` + "`" + `redirect_uri` + "`" + `, p.` + "`" + `expires_at` + "`" + `
+        FROM (SELECT ? AS ` + "`" + `code` + "`" + `, ? AS ` + "`" + `client_uid` + "`" + `, ? AS ` + "`" + `username` + "`" + `, ? AS ` + "`" + `code_challenge` + "`" + `, ? AS ` + "`" + `nonce` + "`" + `, ? AS ` + "`" + `redirect_uri` + "`" + `, ? AS ` + "`" + `expires_at` + "`" + `) p
+            INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `uid` + "`" + ` = p.` + "`" + `client_uid` + "`" + `
+            INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `username` + "`" + ` = p.` + "`" + `username` + "`" + `
+`
+}
+
+// AddClientSecretHashColumnScript gets the AddClientSecretHashColumn script.
+func (ScriptRepository) AddClientSecretHashColumnScript() string {
+	return `
+ALTER TABLE ` + "`" + `client` + "`" + `
+	ADD COLUMN ` + "`" + `secret_hash` + "`" + ` BLOB
+`
+}
+
+// CountClientsScript gets the CountClients script.
+func (ScriptRepository) CountClientsScript() string {
+	return `
+SELECT COUNT(*)
+	FROM ` + "`" + `client` + "`" + ` c,
+		(SELECT ? AS ` + "`" + `search` + "`" + `) p
+	WHERE LOWER(SUBSTR(c.` + "`" + `name` + "`" + `, 1, CHAR_LENGTH(p.` + "`" + `search` + "`" + `))) = LOWER(p.` + "`" + `search` + "`" + `)
+`
+}
+
+// CreateClientScript gets the CreateClient script.
+func (ScriptRepository) CreateClientScript() string {
+	return `
+INSERT INTO ` + "`" + `client` + "`" + ` (` + "`" + `uid` + "`" + `, ` + "`" + `name` + "`" + `, ` + "`" + `redirect_url` + "`" + `, ` + "`" + `token_type` + "`" + `, ` + "`" + `key_uri` + "`" + `)
+	VALUES (?, ?, ?, ?, ?)
+`
+}
+
+// CreateClientTableScript gets the CreateClientTable script.
+func (ScriptRepository) CreateClientTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `client` + "`" + ` (
+	` + "`" + `key` + "`" + ` SMALLINT NOT NULL AUTO_INCREMENT,
+	` + "`" + `uid` + "`" + ` CHAR(36) NOT NULL,
+	` + "`" + `name` + "`" + ` VARCHAR(30) NOT NULL,
+	` + "`" + `redirect_url` + "`" + ` VARCHAR(100) NOT NULL,
+	` + "`" + `token_type` + "`" + ` SMALLINT NOT NULL,
+	` + "`" + `key_uri` + "`" + ` VARCHAR(100) NOT NULL,
+	CONSTRAINT ` + "`" + `client_pk` + "`" + ` PRIMARY KEY (` + "`" + `key` + "`" + `),
+	CONSTRAINT ` + "`" + `client_uid_un` + "`" + ` UNIQUE (` + "`" + `uid` + "`" + `)
+)
+`
+}
+
+// DeleteClientScript gets the DeleteClient script.
+func (ScriptRepository) DeleteClientScript() string {
+	return `
+DELETE FROM ` + "`" + `client` + "`" + `
+    WHERE ` + "`" + `uid` + "`" + ` = ?
+`
+}
+
+// DropClientSecretHashColumnScript gets the DropClientSecretHashColumn script.
+func (ScriptRepository) DropClientSecretHashColumnScript() string {
+	return `
+ALTER TABLE ` + "`" + `client` + "`" + `
+	DROP COLUMN ` + "`" + `secret_hash` + "`" + `
+`
+}
+
+// DropClientTableScript gets the DropClientTable script.
+func (ScriptRepository) DropClientTableScript() string {
+	return `
+DROP TABLE ` + "`" + `client` + "`" + `
+`
+}
+
+// GetClientByUIDScript gets the GetClientByUID script.
+func (ScriptRepository) GetClientByUIDScript() string {
+	return `
+SELECT c.` + "`" + `uid` + "`" + `, c.` + "`" + `name` + "`" + `, c.` + "`" + `redirect_url` + "`" + `, c.` + "`" + `token_type` + "`" + `, c.` + "`" + `key_uri` + "`" + `, c.` + "`" + `secret_hash` + "`" + `
+	FROM ` + "`" + `client` + "`" + ` c
+WHERE c.` + "`" + `uid` + "`" + ` = ?
+`
+}
+
+// GetClientsScript gets the GetClients script.
+func (ScriptRepository) GetClientsScript() string {
+	return `
+SELECT c.` + "`" + `uid` + "`" + `, c.` + "`" + `name` + "`" + `, c.` + "`" + `redirect_url` + "`" + `, c.` + "`" + `token_type` + "`" + `, c.` + "`" + `key_uri` + "`" + `, c.` + "`" + `secret_hash` + "`" + `
+	FROM ` + "`" + `client` + "`" + ` c,
+		(SELECT ? AS ` + "`" + `search` + "`" + `, ? AS ` + "`" + `descending` + "`" + `, ? AS ` + "`" + `has_cursor` + "`" + `, ? AS ` + "`" + `cursor_name` + "`" + `, ? AS ` + "`" + `cursor_uid` + "`" + `) p
+	WHERE LOWER(SUBSTR(c.` + "`" + `name` + "`" + `, 1, CHAR_LENGTH(p.` + "`" + `search` + "`" + `))) = LOWER(p.` + "`" + `search` + "`" + `)
+		AND (NOT p.` + "`" + `has_cursor` + "`" + `
+			OR (p.` + "`" + `descending` + "`" + ` AND (c.` + "`" + `name` + "`" + ` < p.` + "`" + `cursor_name` + "`" + ` OR (c.` + "`" + `name` + "`" + ` = p.` + "`" + `cursor_name` + "`" + ` AND c.` + "`" + `uid` + "`" + ` < p.` + "`" + `cursor_uid` + "`" + `)))
+			OR (NOT p.` + "`" + `descending` + "`" + ` AND (c.` + "`" + `name` + "`" + ` > p.` + "`" + `cursor_name` + "`" + ` OR (c.` + "`" + `name` + "`" + ` = p.` + "`" + `cursor_name` + "`" + ` AND c.` + "`" + `uid` + "`" + ` > p.` + "`" + `cursor_uid` + "`" + `))))
+	ORDER BY
+		CASE WHEN p.` + "`" + `descending` + "`" + ` THEN c.` + "`" + `name` + "`" + ` END DESC,
+		CASE WHEN p.` + "`" + `descending` + "`" + ` THEN c.` + "`" + `uid` + "`" + ` END DESC,
+		c.` + "`" + `name` + "`" + `,
+		c.` + "`" + `uid` + "`" + `
+	LIMIT ?
+`
+}
+
+// UpdateClientScript gets the UpdateClient script.
+func (ScriptRepository) UpdateClientScript() string {
+	return `
+UPDATE ` + "`" + `client` + "`" + ` c
+    INNER JOIN (SELECT ? AS ` + "`" + `uid` + "`" + `, ? AS ` + "`" + `name` + "`" + `, ? AS ` + "`" + `redirect_url` + "`" + `, ? AS ` + "`" + `token_type` + "`" + `, ? AS ` + "`" + `key_uri` + "`" + `) p ON c.` + "`" + `uid` + "`" + ` = p.` + "`" + `uid` + "`" + `
+SET
+    c.` + "`" + `name` + "`" + ` = p.` + "`" + `name` + "`" + `,
+    c.` + "`" + `redirect_url` + "`" + ` = p.` + "`" + `redirect_url` + "`" + `,
+    c.` + "`" + `token_type` + "`" + ` = p.` + "`" + `token_type` + "`" + `,
+    c.` + "`" + `key_uri` + "`" + ` = p.` + "`" + `key_uri` + "`" + `
+`
+}
+
+// UpdateClientSecretHashScript gets the UpdateClientSecretHash script.
+func (ScriptRepository) UpdateClientSecretHashScript() string {
+	return `
+UPDATE ` + "`" + `client` + "`" + ` c
+    INNER JOIN (SELECT ? AS ` + "`" + `uid` + "`" + `, ? AS ` + "`" + `secret_hash` + "`" + `) p ON c.` + "`" + `uid` + "`" + ` = p.` + "`" + `uid` + "`" + `
+SET
+    c.` + "`" + `secret_hash` + "`" + ` = p.` + "`" + `secret_hash` + "`" + `
+`
+}
+
+// CreateClientAdminScript gets the CreateClientAdmin script.
+func (ScriptRepository) CreateClientAdminScript() string {
+	return `
+INSERT INTO ` + "`" + `client_admin` + "`" + ` (` + "`" + `client_key` + "`" + `, ` + "`" + `user_key` + "`" + `, ` + "`" + `is_owner` + "`" + `)
+	SELECT c.` + "`" + `key` + "`" + `, u.` + "`" + `key` + "`" + `, p.` + "`" + `is_owner` + "`" + `
+		FROM (SELECT ? AS ` + "`" + `client_uid` + "`" + `, ? AS ` + "`" + `username` + "`" + `, ? AS ` + "`" + `is_owner` + "`" + `) p
+			INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `uid` + "`" + ` = p.` + "`" + `client_uid` + "`" + `
+			INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `username` + "`" + ` = p.` + "`" + `username` + "`" + `
+`
+}
+
+// CreateClientAdminTableScript gets the CreateClientAdminTable script.
+func (ScriptRepository) CreateClientAdminTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `client_admin` + "`" + ` (
+	` + "`" + `client_key` + "`" + ` SMALLINT NOT NULL,
+	` + "`" + `user_key` + "`" + ` INTEGER NOT NULL,
+	` + "`" + `is_owner` + "`" + ` BOOLEAN NOT NULL,
+	CONSTRAINT ` + "`" + `client_admin_pk` + "`" + ` PRIMARY KEY (` + "`" + `client_key` + "`" + `, ` + "`" + `user_key` + "`" + `),
+	CONSTRAINT ` + "`" + `client_admin_client_fk` + "`" + ` FOREIGN KEY (` + "`" + `client_key` + "`" + `) REFERENCES ` + "`" + `client` + "`" + `(` + "`" + `key` + "`" + `) ON DELETE CASCADE,
+	CONSTRAINT ` + "`" + `client_admin_user_fk` + "`" + ` FOREIGN KEY (` + "`" + `user_key` + "`" + `) REFERENCES ` + "`" + `user` + "`" + `(` + "`" + `key` + "`" + `) ON DELETE CASCADE
+)
+`
+}
+
+// DeleteClientAdminScript gets the DeleteClientAdmin script.
+func (ScriptRepository) DeleteClientAdminScript() string {
+	return `
+DELETE FROM ` + "`" + `client_admin` + "`" + `
+    WHERE ` + "`" + `client_key` + "`" + ` IN (SELECT c.` + "`" + `key` + "`" + ` FROM ` + "`" + `client` + "`" + ` c WHERE c.` + "`" + `uid` + "`" + ` = ?) AND
+          ` + "`" + `user_key` + "`" + ` IN (SELECT u.` + "`" + `key` + "`" + ` FROM ` + "`" + `user` + "`" + ` u WHERE u.` + "`" + `username` + "`" + ` = ?)
+`
+}
+
+// DropClientAdminTableScript gets the DropClientAdminTable script.
+func (ScriptRepository) DropClientAdminTableScript() string {
+	return `
+DROP TABLE ` + "`" + `client_admin` + "`" + `
+`
+}
+
+// GetClientAdminByClientUIDAndUsernameScript gets the GetClientAdminByClientUIDAndUsername script.
+func (ScriptRepository) GetClientAdminByClientUIDAndUsernameScript() string {
+	return `
+SELECT c.` + "`" + `uid` + "`" + `, u.` + "`" + `username` + "`" + `, ca.` + "`" + `is_owner` + "`" + `
+    FROM ` + "`" + `client_admin` + "`" + ` ca
+        INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `uid` + "`" + ` = ? AND c.` + "`" + `key` + "`" + ` = ca.` + "`" + `client_key` + "`" + `
+        INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `username` + "`" + ` = ? AND u.` + "`" + `key` + "`" + ` = ca.` + "`" + `user_key` + "`" + `
+`
+}
+
+// GetClientAdminsByClientUIDScript gets the GetClientAdminsByClientUID script.
+func (ScriptRepository) GetClientAdminsByClientUIDScript() string {
+	return `
+SELECT c.` + "`" + `uid` + "`" + `, u.` + "`" + `username` + "`" + `, ca.` + "`" + `is_owner` + "`" + `
+    FROM ` + "`" + `client_admin` + "`" + ` ca
+        INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `uid` + "`" + ` = ? AND c.` + "`" + `key` + "`" + ` = ca.` + "`" + `client_key` + "`" + `
+        INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `key` + "`" + ` = ca.` + "`" + `user_key` + "`" + `
+    ORDER BY u.` + "`" + `username` + "`" + `
+`
+}
+
+// GetClientAdminsByUsernameScript gets the GetClientAdminsByUsername script.
+func (ScriptRepository) GetClientAdminsByUsernameScript() string {
+	return `
+SELECT c.` + "`" + `uid` + "`" + `, u.` + "`" + `username` + "`" + `, ca.` + "`" + `is_owner` + "`" + `
+    FROM ` + "`" + `client_admin` + "`" + ` ca
+        INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `key` + "`" + ` = ca.` + "`" + `client_key` + "`" + `
+        INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `username` + "`" + ` = ? AND u.` + "`" + `key` + "`" + ` = ca.` + "`" + `user_key` + "`" + `
+`
+}
+
+// CreateGroupScript gets the CreateGroup script.
+func (ScriptRepository) CreateGroupScript() string {
+	return `
+INSERT INTO ` + "`" + `group` + "`" + ` (` + "`" + `name` + "`" + `, ` + "`" + `description` + "`" + `, ` + "`" + `priority` + "`" + `)
+    VALUES (?, ?, ?)
+`
+}
+
+// CreateGroupMemberScript gets the CreateGroupMember script.
+func (ScriptRepository) CreateGroupMemberScript() string {
+	return `
+INSERT INTO ` + "`" + `group_member` + "`" + ` (` + "`" + `group_key` + "`" + `, ` + "`" + `user_key` + "`" + `)
+	SELECT g.` + "`" + `key` + "`" + `, u.` + "`" + `key` + "`" + `
+		FROM (SELECT ? AS ` + "`" + `name` + "`" + `, ? AS ` + "`" + `username` + "`" + `) p
+			INNER JOIN ` + "`" + `group` + "`" + ` g ON g.` + "`" + `name` + "`" + ` = p.` + "`" + `name` + "`" + `
+			INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `username` + "`" + ` = p.` + "`" + `username` + "`" + `
+`
+}
+
+// CreateGroupMemberTableScript gets the CreateGroupMemberTable script.
+func (ScriptRepository) CreateGroupMemberTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `group_member` + "`" + ` (
+	` + "`" + `group_key` + "`" + ` INT NOT NULL,
+	` + "`" + `user_key` + "`" + ` INT NOT NULL,
+	CONSTRAINT ` + "`" + `group_member_pk` + "`" + ` PRIMARY KEY (` + "`" + `group_key` + "`" + `, ` + "`" + `user_key` + "`" + `),
+	CONSTRAINT ` + "`" + `group_member_group_fk` + "`" + ` FOREIGN KEY (` + "`" + `group_key` + "`" + `) REFERENCES ` + "`" + `group` + "`" + `(` + "`" + `key` + "`" + `) ON DELETE CASCADE,
+	CONSTRAINT ` + "`" + `group_member_user_fk` + "`" + ` FOREIGN KEY (` + "`" + `user_key` + "`" + `) REFERENCES ` + "`" + `user` + "`" + `(` + "`" + `key` + "`" + `) ON DELETE CASCADE
+)
+`
+}
+
+// CreateGroupTableScript gets the CreateGroupTable script.
+func (ScriptRepository) CreateGroupTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `group` + "`" + ` (
+	` + "`" + `key` + "`" + ` INT NOT NULL AUTO_INCREMENT,
+	` + "`" + `name` + "`" + ` VARCHAR(30) NOT NULL,
+	` + "`" + `description` + "`" + ` VARCHAR(255) NOT NULL,
+	` + "`" + `priority` + "`" + ` INT NOT NULL,
+	CONSTRAINT ` + "`" + `group_pk` + "`" + ` PRIMARY KEY (` + "`" + `key` + "`" + `),
+	CONSTRAINT ` + "`" + `group_name_un` + "`" + ` UNIQUE (` + "`" + `name` + "`" + `)
+)
+`
+}
+
+// DeleteGroupScript gets the DeleteGroup script.
+func (ScriptRepository) DeleteGroupScript() string {
+	return `
+DELETE FROM ` + "`" + `group` + "`" + `
+    WHERE ` + "`" + `name` + "`" + ` = ?
+`
+}
+
+// DeleteGroupMemberScript gets the DeleteGroupMember script.
+func (ScriptRepository) DeleteGroupMemberScript() string {
+	return `
+DELETE FROM ` + "`" + `group_member` + "`" + `
+    WHERE ` + "`" + `group_key` + "`" + ` IN (SELECT g.` + "`" + `key` + "`" + ` FROM ` + "`" + `group` + "`" + ` g WHERE g.` + "`" + `name` + "`" + ` = ?) AND
+          ` + "`" + `user_key` + "`" + ` IN (SELECT u.` + "`" + `key` + "`" + ` FROM ` + "`" + `user` + "`" + ` u WHERE u.` + "`" + `username` + "`" + ` = ?)
+`
+}
+
+// DropGroupMemberTableScript gets the DropGroupMemberTable script.
+func (ScriptRepository) DropGroupMemberTableScript() string {
+	return `
+DROP TABLE ` + "`" + `group_member` + "`" + `
+`
+}
+
+// DropGroupTableScript gets the DropGroupTable script.
+func (ScriptRepository) DropGroupTableScript() string {
+	return `
+DROP TABLE ` + "`" + `group` + "`" + `
+`
+}
+
+// GetGroupByNameScript gets the GetGroupByName script.
+func (ScriptRepository) GetGroupByNameScript() string {
+	return `
+SELECT g.` + "`" + `name` + "`" + `, g.` + "`" + `description` + "`" + `, g.` + "`" + `priority` + "`" + `
+    FROM ` + "`" + `group` + "`" + ` g
+    WHERE g.` + "`" + `name` + "`" + ` = ?
+`
+}
+
+// GetGroupMemberUsernamesScript gets the GetGroupMemberUsernames script.
+func (ScriptRepository) GetGroupMemberUsernamesScript() string {
+	return `
+SELECT u.` + "`" + `username` + "`" + `
+    FROM ` + "`" + `group_member` + "`" + ` gm
+        INNER JOIN ` + "`" + `group` + "`" + ` g ON g.` + "`" + `name` + "`" + ` = ? AND g.` + "`" + `key` + "`" + ` = gm.` + "`" + `group_key` + "`" + `
+        INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `key` + "`" + ` = gm.` + "`" + `user_key` + "`" + `
+    ORDER BY u.` + "`" + `username` + "`" + `
+`
+}
+
+// GetGroupsScript gets the GetGroups script.
+func (ScriptRepository) GetGroupsScript() string {
+	return `
+SELECT g.` + "`" + `name` + "`" + `, g.` + "`" + `description` + "`" + `, g.` + "`" + `priority` + "`" + `
+    FROM ` + "`" + `group` + "`" + ` g
+    ORDER BY g.` + "`" + `name` + "`" + `
+`
+}
+
+// GetGroupsByUsernameScript gets the GetGroupsByUsername script.
+func (ScriptRepository) GetGroupsByUsernameScript() string {
+	return `
+SELECT g.` + "`" + `name` + "`" + `, g.` + "`" + `description` + "`" + `, g.` + "`" + `priority` + "`" + `
+    FROM ` + "`" + `group` + "`" + ` g
+        INNER JOIN ` + "`" + `group_member` + "`" + ` gm ON gm.` + "`" + `group_key` + "`" + ` = g.` + "`" + `key` + "`" + `
+        INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `username` + "`" + ` = ? AND u.` + "`" + `key` + "`" + ` = gm.` + "`" + `user_key` + "`" + `
+    ORDER BY g.` + "`" + `name` + "`" + `
+`
+}
+
+// UpdateGroupScript gets the UpdateGroup script.
+func (ScriptRepository) UpdateGroupScript() string {
+	return `
+UPDATE ` + "`" + `group` + "`" + ` g
+    INNER JOIN (SELECT ? AS ` + "`" + `name` + "`" + `, ? AS ` + "`" + `description` + "`" + `, ? AS ` + "`" + `priority` + "`" + `) p ON g.` + "`" + `name` + "`" + ` = p.` + "`" + `name` + "`" + `
+SET
+    g.` + "`" + `description` + "`" + ` = p.` + "`" + `description` + "`" + `,
+    g.` + "`" + `priority` + "`" + ` = p.` + "`" + `priority` + "`" + `
+`
+}
+
+// CountGroupRolesByClientUIDAndRoleScript gets the CountGroupRolesByClientUIDAndRole script.
+func (ScriptRepository) CountGroupRolesByClientUIDAndRoleScript() string {
+	return `
+SELECT COUNT(*)
+    FROM ` + "`" + `group_role` + "`" + ` gr
+        INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `uid` + "`" + ` = ? AND c.` + "`" + `key` + "`" + ` = gr.` + "`" + `client_key` + "`" + `
+    WHERE gr.` + "`" + `role` + "`" + ` = ?
+`
+}
+
+// CreateGroupRoleScript gets the CreateGroupRole script.
+func (ScriptRepository) CreateGroupRoleScript() string {
+	return `
+INSERT INTO ` + "`" + `group_role` + "`" + ` (` + "`" + `client_key` + "`" + `, ` + "`" + `group_key` + "`" + `, ` + "`" + `role` + "`" + `)
+	SELECT c.` + "`" + `key` + "`" + `, g.` + "`" + `key` + "`" + `, p.` + "`" + `role` + "`" + `
+		FROM (SELECT ? AS ` + "`" + `client_uid` + "`" + `, ? AS ` + "`" + `name` + "`" + `, ? AS ` + "`" + `role` + "`" + `) p
+			INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `uid` + "`" + ` = p.` + "`" + `client_uid` + "`" + `
+			INNER JOIN ` + "`" + `group` + "`" + ` g ON g.` + "`" + `name` + "`" + ` = p.` + "`" + `name` + "`" + `
+`
+}
+
+// CreateGroupRoleTableScript gets the CreateGroupRoleTable script.
+func (ScriptRepository) CreateGroupRoleTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `group_role` + "`" + ` (
+	` + "`" + `client_key` + "`" + ` SMALLINT NOT NULL,
+	` + "`" + `group_key` + "`" + ` INT NOT NULL,
+	` + "`" + `role` + "`" + ` VARCHAR(15) NOT NULL,
+	CONSTRAINT ` + "`" + `group_role_pk` + "`" + ` PRIMARY KEY (` + "`" + `client_key` + "`" + `, ` + "`" + `group_key` + "`" + `, ` + "`" + `role` + "`" + `),
+	CONSTRAINT ` + "`" + `group_role_client_fk` + "`" + ` FOREIGN KEY (` + "`" + `client_key` + "`" + `) REFERENCES ` + "`" + `client` + "`" + `(` + "`" + `key` + "`" + `) ON DELETE CASCADE,
+	CONSTRAINT ` + "`" + `group_role_group_fk` + "`" + ` FOREIGN KEY (` + "`" + `group_key` + "`" + `) REFERENCES ` + "`" + `group` + "`" + `(` + "`" + `key` + "`" + `) ON DELETE CASCADE
+)
+`
+}
+
+// DeleteGroupRoleScript gets the DeleteGroupRole script.
+func (ScriptRepository) DeleteGroupRoleScript() string {
+	return `
+DELETE FROM ` + "`" + `group_role` + "`" + `
+    WHERE ` + "`" + `client_key` + "`" + ` IN (SELECT c.` + "`" + `key` + "`" + ` FROM ` + "`" + `client` + "`" + ` c WHERE c.` + "`" + `uid` + "`" + ` = ?) AND
+          ` + "`" + `group_key` + "`" + ` IN (SELECT g.` + "`" + `key` + "`" + ` FROM ` + "`" + `group` + "`" + ` g WHERE g.` + "`" + `name` + "`" + ` = ?)
+`
+}
+
+// DropGroupRoleTableScript gets the DropGroupRoleTable script.
+func (ScriptRepository) DropGroupRoleTableScript() string {
+	return `
+DROP TABLE ` + "`" + `group_role` + "`" + `
+`
+}
+
+// GetGroupRoleByClientUIDAndGroupNameScript gets the GetGroupRoleByClientUIDAndGroupName script.
+func (ScriptRepository) GetGroupRoleByClientUIDAndGroupNameScript() string {
+	return `
+SELECT c.` + "`" + `uid` + "`" + `, g.` + "`" + `name` + "`" + `, gr.` + "`" + `role` + "`" + `
+    FROM ` + "`" + `group_role` + "`" + ` gr
+        INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `uid` + "`" + ` = ? AND c.` + "`" + `key` + "`" + ` = gr.` + "`" + `client_key` + "`" + `
+        INNER JOIN ` + "`" + `group` + "`" + ` g ON g.` + "`" + `name` + "`" + ` = ? AND g.` + "`" + `key` + "`" + ` = gr.` + "`" + `group_key` + "`" + `
+    ORDER BY gr.` + "`" + `role` + "`" + `
+`
+}
+
+// GetGroupRolesByClientUIDScript gets the GetGroupRolesByClientUID script.
+func (ScriptRepository) GetGroupRolesByClientUIDScript() string {
+	return `
+SELECT c.` + "`" + `uid` + "`" + `, g.` + "`" + `name` + "`" + `, gr.` + "`" + `role` + "`" + `
+    FROM ` + "`" + `group_role` + "`" + ` gr
+        INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `uid` + "`" + ` = ? AND c.` + "`" + `key` + "`" + ` = gr.` + "`" + `client_key` + "`" + `
+        INNER JOIN ` + "`" + `group` + "`" + ` g ON g.` + "`" + `key` + "`" + ` = gr.` + "`" + `group_key` + "`" + `
+    ORDER BY g.` + "`" + `name` + "`" + `, gr.` + "`" + `role` + "`" + `
+`
+}
+
+// RenameGroupRolesScript gets the RenameGroupRoles script.
+func (ScriptRepository) RenameGroupRolesScript() string {
+	return `
+UPDATE ` + "`" + `group_role` + "`" + ` gr
+    INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `key` + "`" + ` = gr.` + "`" + `client_key` + "`" + `
+    INNER JOIN (SELECT ? AS ` + "`" + `client_uid` + "`" + `, ? AS ` + "`" + `role` + "`" + `, ? AS ` + "`" + `new_role` + "`" + `) p ON c.` + "`" + `uid` + "`" + ` = p.` + "`" + `client_uid` + "`" + ` AND gr.` + "`" + `role` + "`" + ` = p.` + "`" + `role` + "`" + `
+SET
+    gr.` + "`" + `role` + "`" + ` = p.` + "`" + `new_role` + "`" + `
+`
+}
+
+// CreateInvitationRoleTableScript gets the CreateInvitationRoleTable script.
+func (ScriptRepository) CreateInvitationRoleTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `invitation_role` + "`" + ` (
+	` + "`" + `invitation_key` + "`" + ` INT NOT NULL,
+	` + "`" + `client_key` + "`" + ` SMALLINT NOT NULL,
+	` + "`" + `role` + "`" + ` VARCHAR(15) NOT NULL,
+	CONSTRAINT ` + "`" + `invitation_role_pk` + "`" + ` PRIMARY KEY (` + "`" + `invitation_key` + "`" + `, ` + "`" + `client_key` + "`" + `),
+	CONSTRAINT ` + "`" + `invitation_role_invitation_fk` + "`" + ` FOREIGN KEY (` + "`" + `invitation_key` + "`" + `) REFERENCES ` + "`" + `invitation` + "`" + `(` + "`" + `key` + "`" + `) ON DELETE CASCADE,
+	CONSTRAINT ` + "`" + `invitation_role_client_fk` + "`" + ` FOREIGN KEY (` + "`" + `client_key` + "`" + `) REFERENCES ` + "`" + `client` + "`" + `(` + "`" + `key` + "`" + `) ON DELETE CASCADE
+)
+`
+}
+
+// CreateInvitationTableScript gets the CreateInvitationTable script.
+func (ScriptRepository) CreateInvitationTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `invitation` + "`" + ` (
+	` + "`" + `key` + "`" + ` INT NOT NULL AUTO_INCREMENT,
+	` + "`" + `id` + "`" + ` CHAR(36) NOT NULL,
+	` + "`" + `token_hash` + "`" + ` VARBINARY(64) NOT NULL,
+	` + "`" + `email` + "`" + ` VARCHAR(254) NOT NULL,
+	` + "`" + `rank` + "`" + ` SMALLINT NOT NULL,
+	` + "`" + `expires_at` + "`" + ` DATETIME(6) NOT NULL,
+	CONSTRAINT ` + "`" + `invitation_pk` + "`" + ` PRIMARY KEY (` + "`" + `key` + "`" + `),
+	CONSTRAINT ` + "`" + `invitation_id_un` + "`" + ` UNIQUE (` + "`" + `id` + "`" + `),
+	CONSTRAINT ` + "`" + `invitation_token_hash_un` + "`" + ` UNIQUE (` + "`" + `token_hash` + "`" + `)
+)
+`
+}
+
+// DeleteInvitationScript gets the DeleteInvitation script.
+func (ScriptRepository) DeleteInvitationScript() string {
+	return `
+DELETE FROM ` + "`" + `invitation` + "`" + `
+    WHERE ` + "`" + `id` + "`" + ` = ?
+`
+}
+
+// DropInvitationRoleTableScript gets the DropInvitationRoleTable script.
+func (ScriptRepository) DropInvitationRoleTableScript() string {
+	return `
+DROP TABLE ` + "`" + `invitation_role` + "`" + `
+`
+}
+
+// DropInvitationTableScript gets the DropInvitationTable script.
+func (ScriptRepository) DropInvitationTableScript() string {
+	return `
+DROP TABLE ` + "`" + `invitation` + "`" + `
+`
+}
+
+// GetInvitationByIDScript gets the GetInvitationByID script.
+func (ScriptRepository) GetInvitationByIDScript() string {
+	return `
+SELECT i.` + "`" + `id` + "`" + `, i.` + "`" + `token_hash` + "`" + `, i.` + "`" + `email` + "`" + `, i.` + "`" + `rank` + "`" + `, i.` + "`" + `expires_at` + "`" + `
+    FROM ` + "`" + `invitation` + "`" + ` i
+    WHERE i.` + "`" + `id` + "`" + ` = ?
+`
+}
+
+// GetInvitationByTokenHashScript gets the GetInvitationByTokenHash script.
+func (ScriptRepository) GetInvitationByTokenHashScript() string {
+	return `
+SELECT i.` + "`" + `id` + "`" + `, i.` + "`" + `token_hash` + "`" + `, i.` + "`" + `email` + "`" + `, i.` + "`" + `rank` + "`" + `, i.` + "`" + `expires_at` + "`" + `
+    FROM ` + "`" + `invitation` + "`" + ` i
+    WHERE i.` + "`" + `token_hash` + "`" + ` = ?
+`
+}
+
+// GetInvitationRolesScript gets the GetInvitationRoles script.
+func (ScriptRepository) GetInvitationRolesScript() string {
+	return `
+SELECT c.` + "`" + `uid` + "`" + `, ir.` + "`" + `role` + "`" + `
+    FROM ` + "`" + `invitation_role` + "`" + ` ir
+        INNER JOIN ` + "`" + `invitation` + "`" + ` i ON i.` + "`" + `key` + "`" + ` = ir.` + "`" + `invitation_key` + "`" + `
+        INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `key` + "`" + ` = ir.` + "`" + `client_key` + "`" + `
+    WHERE i.` + "`" + `id` + "`" + ` = ?
+    ORDER BY c.` + "`" + `uid` + "`" + `
+`
+}
+
+// RenameInvitationRolesScript gets the RenameInvitationRoles script.
+func (ScriptRepository) RenameInvitationRolesScript() string {
+	return `
+UPDATE ` + "`" + `invitation_role` + "`" + ` ir
+    INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `key` + "`" + ` = ir.` + "`" + `client_key` + "`" + `
+    INNER JOIN (SELECT ? AS ` + "`" + `client_uid` + "`" + `, ? AS ` + "`" + `role` + "`" + `, ? AS ` + "`" + `new_role` + "`" + `) p ON c.` + "`" + `uid` + "`" + ` = p.` + "`" + `client_uid` + "`" + ` AND ir.` + "`" + `role` + "`" + ` = p.` + "`" + `role` + "`" + `
+SET
+    ir.` + "`" + `role` + "`" + ` = p.` + "`" + `new_role` + "`" + `
+`
+}
+
+// SaveInvitationScript gets the SaveInvitation script.
+func (ScriptRepository) SaveInvitationScript() string {
+	return `
+INSERT INTO ` + "`" + `invitation` + "`" + ` (` + "`" + `id` + "`" + `, ` + "`" + `token_hash` + "`" + `, ` + "`" + `email` + "`" + `, ` + "`" + `rank` + "`" + `, ` + "`" + `expires_at` + "`" + `)
+    VALUES (?, ?, ?, ?, ?)
+`
+}
+
+// SaveInvitationRoleScript gets the SaveInvitationRole script.
+func (ScriptRepository) SaveInvitationRoleScript() string {
+	return `
+INSERT INTO ` + "`" + `invitation_role` + "`" + ` (` + "`" + `invitation_key` + "`" + `, ` + "`" + `client_key` + "`" + `, ` + "`" + `role` + "`" + `)
+	SELECT i.` + "`" + `key` + "`" + `, c.` + "`" + `key` + "`" + `, p.` + "`" + `role` + "`" + `
+		FROM (SELECT ? AS ` + "`" + `invitation_id` + "`" + `, ? AS ` + "`" + `client_uid` + "`" + `, ? AS ` + "`" + `role` + "`" + `) p
+			INNER JOIN ` + "`" + `invitation` + "`" + ` i ON i.` + "`" + `id` + "`" + ` = p.` + "`" + `invitation_id` + "`" + `
+			INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `uid` + "`" + ` = p.` + "`" + `client_uid` + "`" + `
+`
+}
+
+// CreateLoginThrottleTableScript gets the CreateLoginThrottleTable script.
+func (ScriptRepository) CreateLoginThrottleTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `login_throttle` + "`" + ` (
+	` + "`" + `type` + "`" + ` VARCHAR(10) NOT NULL,
+	` + "`" + `key` + "`" + ` VARCHAR(64) NOT NULL,
+	` + "`" + `failed_attempts` + "`" + ` INTEGER NOT NULL,
+	` + "`" + `last_failed_at` + "`" + ` DATETIME(6) NOT NULL,
+	` + "`" + `locked_until` + "`" + ` DATETIME(6) NOT NULL,
+	CONSTRAINT ` + "`" + `login_throttle_pk` + "`" + ` PRIMARY KEY (` + "`" + `type` + "`" + `, ` + "`" + `key` + "`" + `)
+)
+`
+}
+
+// DeleteLoginThrottleScript gets the DeleteLoginThrottle script.
+func (ScriptRepository) DeleteLoginThrottleScript() string {
+	return `
+DELETE FROM ` + "`" + `login_throttle` + "`" + `
+    WHERE ` + "`" + `type` + "`" + ` = ? AND ` + "`" + `key` + "`" + ` = ?
+`
+}
+
+// DropLoginThrottleTableScript gets the DropLoginThrottleTable script.
+func (ScriptRepository) DropLoginThrottleTableScript() string {
+	return `
+DROP TABLE ` + "`" + `login_throttle` + "`" + `
+`
+}
+
+// GetLockedLoginThrottlesScript gets the GetLockedLoginThrottles script.
+func (ScriptRepository) GetLockedLoginThrottlesScript() string {
+	return `
+SELECT lt.` + "`" + `type` + "`" + `, lt.` + "`" + `key` + "`" + `, lt.` + "`" + `failed_attempts` + "`" + `, lt.` + "`" + `last_failed_at` + "`" + `, lt.` + "`" + `locked_until` + "`" + `
+    FROM ` + "`" + `login_throttle` + "`" + ` lt
+    WHERE lt.` + "`" + `locked_until` + "`" + ` > ?
+    ORDER BY lt.` + "`" + `type` + "`" + `, lt.` + "`" + `key` + "`" + `
+`
+}
+
+// GetLoginThrottleScript gets the GetLoginThrottle script.
+func (ScriptRepository) GetLoginThrottleScript() string {
+	return `
+SELECT lt.` + "`" + `type` + "`" + `, lt.` + "`" + `key` + "`" + `, lt.` + "`" + `failed_attempts` + "`" + `, lt.` + "`" + `last_failed_at` + "`" + `, lt.` + "`" + `locked_until` + "`" + `
+    FROM ` + "`" + `login_throttle` + "`" + ` lt
+    WHERE lt.` + "`" + `type` + "`" + ` = ? AND lt.` + "`" + `key` + "`" + ` = ?
+`
+}
+
+// SaveLoginThrottleScript gets the SaveLoginThrottle script.
+func (ScriptRepository) SaveLoginThrottleScript() string {
+	return `
+INSERT INTO ` + "`" + `login_throttle` + "`" + ` (` + "`" + `type` + "`" + `, ` + "`" + `key` + "`" + `, ` + "`" + `failed_attempts` + "`" + `, ` + "`" + `last_failed_at` + "`" + `, ` + "`" + `locked_until` + "`" + `)
+    VALUES (?, ?, ?, ?, ?)
+    ON DUPLICATE KEY UPDATE
+        ` + "`" + `failed_attempts` + "`" + ` = VALUES(` + "`" + `failed_attempts` + "`" + `),
+        ` + "`" + `last_failed_at` + "`" + ` = VALUES(` + "`" + `last_failed_at` + "`" + `),
+        ` + "`" + `locked_until` + "`" + ` = VALUES(` + "`" + `locked_until` + "`" + `)
+`
+}
+
+// CreateMigrationTableScript gets the CreateMigrationTable script.
+func (ScriptRepository) CreateMigrationTableScript() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + "`" + `migration` + "`" + ` (
+    ` + "`" + `timestamp` + "`" + ` CHAR(3) NOT NULL,
+    CONSTRAINT ` + "`" + `migration_pk` + "`" + ` PRIMARY KEY (` + "`" + `timestamp` + "`" + `)
+)
+`
+}
+
+// DeleteMigrationByTimestampScript gets the DeleteMigrationByTimestamp script.
+func (ScriptRepository) DeleteMigrationByTimestampScript() string {
+	return `
+DELETE FROM ` + "`" + `migration` + "`" + `
+   WHERE ` + "`" + `timestamp` + "`" + ` = ?
+`
+}
+
+// GetLatestTimestampScript gets the GetLatestTimestamp script.
+func (ScriptRepository) GetLatestTimestampScript() string {
+	return `
+SELECT m.` + "`" + `timestamp` + "`" + ` FROM ` + "`" + `migration` + "`" + ` m
+    ORDER BY m.` + "`" + `timestamp` + "`" + ` DESC
+    LIMIT 1
+`
+}
+
+// GetMigrationByTimestampScript gets the GetMigrationByTimestamp script.
+func (ScriptRepository) GetMigrationByTimestampScript() string {
+	return `
+SELECT m.` + "`" + `timestamp` + "`" + ` 
+    FROM ` + "`" + `migration` + "`" + ` m 
+    WHERE m.` + "`" + `timestamp` + "`" + ` = ?
+`
+}
+
+// SaveMigrationScript gets the SaveMigration script.
+func (ScriptRepository) SaveMigrationScript() string {
+	return `
+INSERT INTO ` + "`" + `migration` + "`" + ` (` + "`" + `timestamp` + "`" + `) 
+    VALUES (?)
+`
+}
+
+// CreatePasswordResetTokenTableScript gets the CreatePasswordResetTokenTable script.
+func (ScriptRepository) CreatePasswordResetTokenTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `password_reset_token` + "`" + ` (
+	` + "`" + `token_hash` + "`" + ` VARBINARY(64) NOT NULL,
+	` + "`" + `user_key` + "`" + ` INTEGER NOT NULL,
+	` + "`" + `expires_at` + "`" + ` DATETIME(6) NOT NULL,
+	CONSTRAINT ` + "`" + `password_reset_token_pk` + "`" + ` PRIMARY KEY (` + "`" + `token_hash` + "`" + `),
+	CONSTRAINT ` + "`" + `password_reset_token_user_fk` + "`" + ` FOREIGN KEY (` + "`" + `user_key` + "`" + `) REFERENCES ` + "`" + `user` + "`" + `(` + "`" + `key` + "`" + `) ON DELETE CASCADE
+)
+`
+}
+
+// DeleteAllUserPasswordResetTokensScript gets the DeleteAllUserPasswordResetTokens script.
+func (ScriptRepository) DeleteAllUserPasswordResetTokensScript() string {
+	return `
+DELETE FROM ` + "`" + `password_reset_token` + "`" + `
+    WHERE ` + "`" + `user_key` + "`" + ` IN (
+        SELECT u.` + "`" + `key` + "`" + ` FROM ` + "`" + `user` + "`" + ` u WHERE u.` + "`" + `username` + "`" + ` = ?
+    )
+`
+}
+
+// DeletePasswordResetTokenScript gets the DeletePasswordResetToken script.
+func (ScriptRepository) DeletePasswordResetTokenScript() string {
+	return `
+DELETE FROM ` + "`" + `password_reset_token` + "`" + `
+    WHERE ` + "`" + `token_hash` + "`" + ` = ?
+`
+}
+
+// DropPasswordResetTokenTableScript gets the DropPasswordResetTokenTable script.
+func (ScriptRepository) DropPasswordResetTokenTableScript() string {
+	return `
+DROP TABLE ` + "`" + `password_reset_token` + "`" + `
+`
+}
+
+// GetPasswordResetTokenScript gets the GetPasswordResetToken script.
+func (ScriptRepository) GetPasswordResetTokenScript() string {
+	return `
+SELECT prt.` + "`" + `token_hash` + "`" + `, u.` + "`" + `username` + "`" + `, prt.` + "`" + `expires_at` + "`" + `
+    FROM ` + "`" + `password_reset_token` + "`" + ` prt
+        INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `key` + "`" + ` = prt.` + "`" + `user_key` + "`" + `
+    WHERE prt.` + "`" + `token_hash` + "`" + ` = ?
+`
+}
+
+// SavePasswordResetTokenScript gets the SavePasswordResetToken script.
+func (ScriptRepository) SavePasswordResetTokenScript() string {
+	return `
+INSERT INTO ` + "`" + `password_reset_token` + "`" + ` (` + "`" + `token_hash` + "`" + `, ` + "`" + `user_key` + "`" + `, ` + "`" + `expires_at` + "`" + `)
+    SELECT p.` + "`" + `token_hash` + "`" + `, u.` + "`" + `key` + "`" + `, p.` + "`" + `expires_at` + "`" + `
+        FROM (SELECT ? AS ` + "`" + `token_hash` + "`" + `, ? AS ` + "`" + `username` + "`" + `, ? AS ` + "`" + `expires_at` + "`" + `) p
+            INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `username` + "`" + ` = p.` + "`" + `username` + "`" + `
+`
+}
+
+// CreatePreviousPasswordTableScript gets the CreatePreviousPasswordTable script.
+func (ScriptRepository) CreatePreviousPasswordTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `previous_password` + "`" + ` (
+	` + "`" + `key` + "`" + ` INT NOT NULL AUTO_INCREMENT,
+	` + "`" + `user_key` + "`" + ` INTEGER NOT NULL,
+	` + "`" + `password_hash` + "`" + ` BLOB NOT NULL,
+	` + "`" + `replaced_at` + "`" + ` DATETIME(6) NOT NULL,
+	CONSTRAINT ` + "`" + `previous_password_pk` + "`" + ` PRIMARY KEY (` + "`" + `key` + "`" + `),
+	CONSTRAINT ` + "`" + `previous_password_user_fk` + "`" + ` FOREIGN KEY (` + "`" + `user_key` + "`" + `) REFERENCES ` + "`" + `user` + "`" + `(` + "`" + `key` + "`" + `) ON DELETE CASCADE
+)
+`
+}
+
+// DeleteUserPreviousPasswordsReplacedBeforeScript gets the DeleteUserPreviousPasswordsReplacedBefore script.
+func (ScriptRepository) DeleteUserPreviousPasswordsReplacedBeforeScript() string {
+	return `
+DELETE FROM ` + "`" + `previous_password` + "`" + `
+    WHERE ` + "`" + `user_key` + "`" + ` IN (
+        SELECT u.` + "`" + `key` + "`" + ` FROM ` + "`" + `user` + "`" + ` u WHERE u.` + "`" + `username` + "`" + ` = ?
+    )
+    AND ` + "`" + `replaced_at` + "`" + ` < ?
+`
+}
+
+// DropPreviousPasswordTableScript gets the DropPreviousPasswordTable script.
+func (ScriptRepository) DropPreviousPasswordTableScript() string {
+	return `
+DROP TABLE ` + "`" + `previous_password` + "`" + `
+`
+}
+
+// GetLatestUserPreviousPasswordsScript gets the GetLatestUserPreviousPasswords script.
+func (ScriptRepository) GetLatestUserPreviousPasswordsScript() string {
+	return `
+SELECT u.` + "`" + `username` + "`" + `, pp.` + "`" + `password_hash` + "`" + `, pp.` + "`" + `replaced_at` + "`" + `
+    FROM ` + "`" + `previous_password` + "`" + ` pp
+        INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `key` + "`" + ` = pp.` + "`" + `user_key` + "`" + `
+    WHERE u.` + "`" + `username` + "`" + ` = ?
+    ORDER BY pp.` + "`" + `replaced_at` + "`" + ` DESC, pp.` + "`" + `key` + "`" + ` DESC
+    LIMIT ?
+`
+}
+
+// SavePreviousPasswordScript gets the SavePreviousPassword script.
+func (ScriptRepository) SavePreviousPasswordScript() string {
+	return `
+INSERT INTO ` + "`" + `previous_password` + "`" + ` (` + "`" + `user_key` + "`" + `, ` + "`" + `password_hash` + "`" + `, ` + "`" + `replaced_at` + "`" + `)
+	SELECT u.` + "`" + `key` + "`" + `, p.` + "`" + `password_hash` + "`" + `, p.` + "`" + `replaced_at` + "`" + `
+		FROM (SELECT ? AS ` + "`" + `username` + "`" + `, ? AS ` + "`" + `password_hash` + "`" + `, ? AS ` + "`" + `replaced_at` + "`" + `) p
+			INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `username` + "`" + ` = p.` + "`" + `username` + "`" + `
+`
+}
+
+// CreateRecoveryCodeTableScript gets the CreateRecoveryCodeTable script.
+func (ScriptRepository) CreateRecoveryCodeTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `recovery_code` + "`" + ` (
+	` + "`" + `user_key` + "`" + ` INTEGER NOT NULL,
+	` + "`" + `code_hash` + "`" + ` VARBINARY(64) NOT NULL,
+	CONSTRAINT ` + "`" + `recovery_code_pk` + "`" + ` PRIMARY KEY (` + "`" + `user_key` + "`" + `, ` + "`" + `code_hash` + "`" + `),
+	CONSTRAINT ` + "`" + `recovery_code_user_fk` + "`" + ` FOREIGN KEY (` + "`" + `user_key` + "`" + `) REFERENCES ` + "`" + `user` + "`" + `(` + "`" + `key` + "`" + `) ON DELETE CASCADE
+)
+`
+}
+
+// DeleteAllUserRecoveryCodesScript gets the DeleteAllUserRecoveryCodes script.
+func (ScriptRepository) DeleteAllUserRecoveryCodesScript() string {
+	return `
+DELETE FROM ` + "`" + `recovery_code` + "`" + `
+    WHERE ` + "`" + `user_key` + "`" + ` IN (
+        SELECT u.` + "`" + `key` + "`" + ` FROM ` + "`" + `user` + "`" + ` u WHERE u.` + "`" + `username` + "`" + ` = ?
+    )
+`
+}
+
+// DeleteRecoveryCodeScript gets the DeleteRecoveryCode script.
+func (ScriptRepository) DeleteRecoveryCodeScript() string {
+	return `
+DELETE rc FROM ` + "`" + `recovery_code` + "`" + ` rc
+    INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `key` + "`" + ` = rc.` + "`" + `user_key` + "`" + `
+    WHERE u.` + "`" + `username` + "`" + ` = ? AND rc.` + "`" + `code_hash` + "`" + ` = ?
+`
+}
+
+// DropRecoveryCodeTableScript gets the DropRecoveryCodeTable script.
+func (ScriptRepository) DropRecoveryCodeTableScript() string {
+	return `
+DROP TABLE ` + "`" + `recovery_code` + "`" + `
+`
+}
+
+// SaveRecoveryCodeScript gets the SaveRecoveryCode script.
+func (ScriptRepository) SaveRecoveryCodeScript() string {
+	return `
+INSERT INTO ` + "`" + `recovery_code` + "`" + ` (` + "`" + `user_key` + "`" + `, ` + "`" + `code_hash` + "`" + `)
+	SELECT u.` + "`" + `key` + "`" + `, p.` + "`" + `code_hash` + "`" + `
+		FROM (SELECT ? AS ` + "`" + `username` + "`" + `, ? AS ` + "`" + `code_hash` + "`" + `) p
+			INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `username` + "`" + ` = p.` + "`" + `username` + "`" + `
+`
+}
+
+// CreateRefreshTokenTableScript gets the CreateRefreshTokenTable script.
+func (ScriptRepository) CreateRefreshTokenTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `refresh_token` + "`" + ` (
+	` + "`" + `token` + "`" + ` CHAR(36) NOT NULL,
+	` + "`" + `family_id` + "`" + ` CHAR(36) NOT NULL,
+	` + "`" + `client_key` + "`" + ` SMALLINT NOT NULL,
+	` + "`" + `user_key` + "`" + ` INTEGER NOT NULL,
+	` + "`" + `rotated` + "`" + ` BOOLEAN NOT NULL DEFAULT FALSE,
+	` + "`" + `expires_at` + "`" + ` DATETIME(6) NOT NULL,
+	CONSTRAINT ` + "`" + `refresh_token_pk` + "`" + ` PRIMARY KEY (` + "`" + `token` + "`" + `),
+	CONSTRAINT ` + "`" + `refresh_token_client_fk` + "`" + ` FOREIGN KEY (` + "`" + `client_key` + "`" + `) REFERENCES ` + "`" + `client` + "`" + `(` + "`" + `key` + "`" + `) ON DELETE CASCADE,
+	CONSTRAINT ` + "`" + `refresh_token_user_fk` + "`" + ` FOREIGN KEY (` + "`" + `user_key` + "`" + `) REFERENCES ` + "`" + `user` + "`" + `(` + "`" + `key` + "`" + `) ON DELETE CASCADE
+)
+`
+}
+
+// DeleteRefreshTokenFamilyScript gets the DeleteRefreshTokenFamily script.
+func (ScriptRepository) DeleteRefreshTokenFamilyScript() string {
+	return `
+DELETE FROM ` + "`" + `refresh_token` + "`" + `
+    WHERE ` + "`" + `family_id` + "`" + ` = ?
+`
+}
+
+// DropRefreshTokenTableScript gets the DropRefreshTokenTable script.
+func (ScriptRepository) DropRefreshTokenTableScript() string {
+	return `
+DROP TABLE ` + "`" + `refresh_token` + "`" + `
+`
+}
+
+// GetRefreshTokenScript gets the GetRefreshToken script.
+func (ScriptRepository) GetRefreshTokenScript() string {
+	return `
+SELECT rt.` + "`" + `token` + "`" + `, rt.` + "`" + `family_id` + "`" + `, c.` + "`" + `uid` + "`" + `, u.` + "`" + `username` + "`" + `, rt.` + "`" + `rotated` + "`" + `, rt.` + "`" + `expires_at` + "`" + `
+    FROM ` + "`" + `refresh_token` + "`" + ` rt
+        INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `key` + "`" + ` = rt.` + "`" + `client_key` + "`" + `
+        INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `key` + "`" + ` = rt.` + "`" + `user_key` + "`" + `
+    WHERE rt.` + "`" + `token` + "`" + ` = ?
+`
+}
+
+// RotateRefreshTokenScript gets the RotateRefreshToken script.
+func (ScriptRepository) RotateRefreshTokenScript() string {
+	return `
+UPDATE ` + "`" + `refresh_token` + "`" + ` SET
+    ` + "`" + `rotated` + "`" + ` = TRUE
+WHERE ` + "`" + `token` + "`" + ` = ? AND ` + "`" + `rotated` + "`" + ` = FALSE
+`
+}
+
+// SaveRefreshTokenScript gets the SaveRefreshToken script.
+func (ScriptRepository) SaveRefreshTokenScript() string {
+	return `
+INSERT INTO ` + "`" + `refresh_token` + "`" + ` (` + "`" + `token` + "`" + `, ` + "`" + `family_id` + "`" + `, ` + "`" + `client_key` + "`" + `, ` + "`" + `user_key` + "`" + `, ` + "`" + `rotated` + "`" + `, ` + "`" + `expires_at` + "`" + `)
+    SELECT p.` + "`" + `token` + "`" + `, p.` + "`" + `family_id` + "`" + `, c.` + "`" + `key` + "`" + `, u.` + "`" + `key` + "`" + `, p.` + "`" + `rotated` + "`" + `, p.` + "`" + `expires_at` + "`" + `
+        FROM (SELECT ? AS ` + "`" + `token` + "`" + `, ? AS ` + "`" + `family_id` + "`" + `, ? AS ` + "`" + `client_uid` + "`" + `, ? AS ` + "`" + `username` + "`" + `, ? AS ` + "`" + `rotated` + "`" + `, ? AS ` + "`" + `expires_at` + "`" + `) p
+            INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `uid` + "`" + ` = p.` + "`" + `client_uid` + "`" + `
+            INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `username` + "`" + ` = p.` + "`" + `username` + "`" + `
+`
+}
+
+// CreateRoleDefinitionScript gets the CreateRoleDefinition script.
+func (ScriptRepository) CreateRoleDefinitionScript() string {
+	return `
+INSERT INTO ` + "`" + `role_definition` + "`" + ` (` + "`" + `client_key` + "`" + `, ` + "`" + `name` + "`" + `, ` + "`" + `description` + "`" + `, ` + "`" + `is_default` + "`" + `)
+	SELECT c.` + "`" + `key` + "`" + `, p.` + "`" + `name` + "`" + `, p.` + "`" + `description` + "`" + `, p.` + "`" + `is_default` + "`" + `
+		FROM (SELECT ? AS ` + "`" + `client_uid` + "`" + `, ? AS ` + "`" + `name` + "`" + `, ? AS ` + "`" + `description` + "`" + `, ? AS ` + "`" + `is_default` + "`" + `) p
+			INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `uid` + "`" + ` = p.` + "`" + `client_uid` + "`" + `
+`
+}
+
+// CreateRoleDefinitionTableScript gets the CreateRoleDefinitionTable script.
+func (ScriptRepository) CreateRoleDefinitionTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `role_definition` + "`" + ` (
+	` + "`" + `client_key` + "`" + ` SMALLINT NOT NULL,
+	` + "`" + `name` + "`" + ` VARCHAR(15) NOT NULL,
+	` + "`" + `description` + "`" + ` VARCHAR(255) NOT NULL,
+	` + "`" + `is_default` + "`" + ` BOOLEAN NOT NULL,
+	CONSTRAINT ` + "`" + `role_definition_pk` + "`" + ` PRIMARY KEY (` + "`" + `client_key` + "`" + `, ` + "`" + `name` + "`" + `),
+	CONSTRAINT ` + "`" + `role_definition_client_fk` + "`" + ` FOREIGN KEY (` + "`" + `client_key` + "`" + `) REFERENCES ` + "`" + `client` + "`" + `(` + "`" + `key` + "`" + `) ON DELETE CASCADE
+)
+`
+}
+
+// CreateRoleDefinitionsFromAssignedRolesScript gets the CreateRoleDefinitionsFromAssignedRoles script.
+func (ScriptRepository) CreateRoleDefinitionsFromAssignedRolesScript() string {
+	return `
+INSERT INTO ` + "`" + `role_definition` + "`" + ` (` + "`" + `client_key` + "`" + `, ` + "`" + `name` + "`" + `, ` + "`" + `description` + "`" + `, ` + "`" + `is_default` + "`" + `)
+    SELECT r.` + "`" + `client_key` + "`" + `, r.` + "`" + `role` + "`" + `, '', FALSE
+        FROM (
+            SELECT ur.` + "`" + `client_key` + "`" + `, ur.` + "`" + `role` + "`" + ` FROM ` + "`" + `user_role` + "`" + ` ur
+            UNION
+            SELECT ir.` + "`" + `client_key` + "`" + `, ir.` + "`" + `role` + "`" + ` FROM ` + "`" + `invitation_role` + "`" + ` ir
+        ) r
+`
+}
+
+// DeleteRoleDefinitionScript gets the DeleteRoleDefinition script.
+func (ScriptRepository) DeleteRoleDefinitionScript() string {
+	return `
+DELETE FROM ` + "`" + `role_definition` + "`" + `
+    WHERE ` + "`" + `client_key` + "`" + ` IN (SELECT c.` + "`" + `key` + "`" + ` FROM ` + "`" + `client` + "`" + ` c WHERE c.` + "`" + `uid` + "`" + ` = ?) AND
+          ` + "`" + `name` + "`" + ` = ?
+`
+}
+
+// DropRoleDefinitionTableScript gets the DropRoleDefinitionTable script.
+func (ScriptRepository) DropRoleDefinitionTableScript() string {
+	return `
+DROP TABLE ` + "`" + `role_definition` + "`" + `
+`
+}
+
+// GetRoleDefinitionByClientUIDAndNameScript gets the GetRoleDefinitionByClientUIDAndName script.
+func (ScriptRepository) GetRoleDefinitionByClientUIDAndNameScript() string {
+	return `
+SELECT c.` + "`" + `uid` + "`" + `, rd.` + "`" + `name` + "`" + `, rd.` + "`" + `description` + "`" + `, rd.` + "`" + `is_default` + "`" + `
+    FROM ` + "`" + `role_definition` + "`" + ` rd
+        INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `uid` + "`" + ` = ? AND c.` + "`" + `key` + "`" + ` = rd.` + "`" + `client_key` + "`" + `
+    WHERE rd.` + "`" + `name` + "`" + ` = ?
+`
+}
+
+// GetRoleDefinitionsByClientUIDScript gets the GetRoleDefinitionsByClientUID script.
+func (ScriptRepository) GetRoleDefinitionsByClientUIDScript() string {
+	return `
+SELECT c.` + "`" + `uid` + "`" + `, rd.` + "`" + `name` + "`" + `, rd.` + "`" + `description` + "`" + `, rd.` + "`" + `is_default` + "`" + `
+    FROM ` + "`" + `role_definition` + "`" + ` rd
+        INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `uid` + "`" + ` = ? AND c.` + "`" + `key` + "`" + ` = rd.` + "`" + `client_key` + "`" + `
+    ORDER BY rd.` + "`" + `name` + "`" + `
+`
+}
+
+// UpdateRoleDefinitionScript gets the UpdateRoleDefinition script.
+func (ScriptRepository) UpdateRoleDefinitionScript() string {
+	return `
+UPDATE ` + "`" + `role_definition` + "`" + ` rd
+    INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `key` + "`" + ` = rd.` + "`" + `client_key` + "`" + `
+    INNER JOIN (SELECT ? AS ` + "`" + `client_uid` + "`" + `, ? AS ` + "`" + `old_name` + "`" + `, ? AS ` + "`" + `name` + "`" + `, ? AS ` + "`" + `description` + "`" + `, ? AS ` + "`" + `is_default` + "`" + `) p ON c.` + "`" + `uid` + "`" + ` = p.` + "`" + `client_uid` + "`" + ` AND rd.` + "`" + `name` + "`" + ` = p.` + "`" + `old_name` + "`" + `
+SET
+    rd.` + "`" + `name` + "`" + ` = p.` + "`" + `name` + "`" + `,
+    rd.` + "`" + `description` + "`" + ` = p.` + "`" + `description` + "`" + `,
+    rd.` + "`" + `is_default` + "`" + ` = p.` + "`" + `is_default` + "`" + `
+`
+}
+
+// AddSessionTimestampColumnsScript gets the AddSessionTimestampColumns script.
+func (ScriptRepository) AddSessionTimestampColumnsScript() string {
+	return `
+ALTER TABLE ` + "`" + `session` + "`" + `
+	ADD COLUMN ` + "`" + `created_at` + "`" + ` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+	ADD COLUMN ` + "`" + `last_used_at` + "`" + ` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+`
+}
+
+// CreateSessionTableScript gets the CreateSessionTable script.
+func (ScriptRepository) CreateSessionTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `session` + "`" + ` (
+	` + "`" + `token` + "`" + ` CHAR(36) NOT NULL,
+	` + "`" + `user_key` + "`" + ` INTEGER NOT NULL,
+	CONSTRAINT ` + "`" + `session_pk` + "`" + ` PRIMARY KEY (` + "`" + `token` + "`" + `),
+	CONSTRAINT ` + "`" + `session_user_fk` + "`" + ` FOREIGN KEY (` + "`" + `user_key` + "`" + `) REFERENCES ` + "`" + `user` + "`" + `(` + "`" + `key` + "`" + `) ON DELETE CASCADE
+)
+`
+}
+
+// DeleteAllOtherUserSessionsScript gets the DeleteAllOtherUserSessions script.
+func (ScriptRepository) DeleteAllOtherUserSessionsScript() string {
+	return `
+DELETE FROM ` + "`" + `session` + "`" + `
+    WHERE ` + "`" + `token` + "`" + ` != ? AND
+        ` + "`" + `user_key` + "`" + ` IN (
+            SELECT u.` + "`" + `key` + "`" + ` FROM ` + "`" + `user` + "`" + ` u WHERE u.` + "`" + `username` + "`" + ` = ?
+        )
+`
+}
+
+// DeleteAllUserSessionsScript gets the DeleteAllUserSessions script.
+func (ScriptRepository) DeleteAllUserSessionsScript() string {
+	return `
+DELETE FROM ` + "`" + `session` + "`" + `
+    WHERE ` + "`" + `user_key` + "`" + ` IN (
+        SELECT u.` + "`" + `key` + "`" + ` FROM ` + "`" + `user` + "`" + ` u WHERE u.` + "`" + `username` + "`" + ` = ?
+    )
+`
+}
+
+// DeleteExpiredSessionsScript gets the DeleteExpiredSessions script.
+func (ScriptRepository) DeleteExpiredSessionsScript() string {
+	return `
+DELETE FROM ` + "`" + `session` + "`" + `
+    WHERE ` + "`" + `created_at` + "`" + ` < ? OR
+        ` + "`" + `last_used_at` + "`" + ` < ?
+`
+}
+
+// DeleteSessionScript gets the DeleteSession script.
+func (ScriptRepository) DeleteSessionScript() string {
+	return `
+DELETE FROM ` + "`" + `session` + "`" + `
+    WHERE ` + "`" + `token` + "`" + ` = ?
+`
+}
+
+// DropSessionTableScript gets the DropSessionTable script.
+func (ScriptRepository) DropSessionTableScript() string {
+	return `
+DROP TABLE ` + "`" + `session` + "`" + `
+`
+}
+
+// DropSessionTimestampColumnsScript gets the DropSessionTimestampColumns script.
+func (ScriptRepository) DropSessionTimestampColumnsScript() string {
+	return `
+ALTER TABLE ` + "`" + `session` + "`" + `
+	DROP COLUMN ` + "`" + `created_at` + "`" + `,
+	DROP COLUMN ` + "`" + `last_used_at` + "`" + `
+`
+}
+
+// GetSessionByTokenScript gets the GetSessionByToken script.
+func (ScriptRepository) GetSessionByTokenScript() string {
+	return `
+SELECT s.` + "`" + `token` + "`" + `, u.` + "`" + `username` + "`" + `, u.` + "`" + `rank` + "`" + `, s.` + "`" + `created_at` + "`" + `, s.` + "`" + `last_used_at` + "`" + `
+    FROM ` + "`" + `session` + "`" + ` s
+        INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `key` + "`" + ` = s.` + "`" + `user_key` + "`" + `
+    WHERE s.` + "`" + `token` + "`" + ` = ?
+`
+}
+
+// SaveSessionScript gets the SaveSession script.
+func (ScriptRepository) SaveSessionScript() string {
+	return `
+INSERT INTO ` + "`" + `session` + "`" + ` (` + "`" + `token` + "`" + `, ` + "`" + `user_key` + "`" + `, ` + "`" + `created_at` + "`" + `, ` + "`" + `last_used_at` + "`" + `)
+	SELECT p.` + "`" + `token` + "`" + `, u.` + "`" + `key` + "`" + `, p.` + "`" + `created_at` + "`" + `, p.` + "`" + `last_used_at` + "`" + `
+		FROM (SELECT ? AS ` + "`" + `token` + "`" + `, ? AS ` + "`" + `username` + "`" + `, ? AS ` + "`" + `created_at` + "`" + `, ? AS ` + "`" + `last_used_at` + "`" + `) p
+			INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `username` + "`" + ` = p.` + "`" + `username` + "`" + `
+`
+}
+
+// UpdateSessionLastUsedAtScript gets the UpdateSessionLastUsedAt script.
+func (ScriptRepository) UpdateSessionLastUsedAtScript() string {
+	return `
+UPDATE ` + "`" + `session` + "`" + ` s
+    INNER JOIN (SELECT ? AS ` + "`" + `token` + "`" + `, ? AS ` + "`" + `last_used_at` + "`" + `) p ON s.` + "`" + `token` + "`" + ` = p.` + "`" + `token` + "`" + `
+SET
+    s.` + "`" + `last_used_at` + "`" + ` = p.` + "`" + `last_used_at` + "`" + `
+`
+}
+
+// AddUserPasswordUpdatedAtColumnScript gets the AddUserPasswordUpdatedAtColumn script.
+func (ScriptRepository) AddUserPasswordUpdatedAtColumnScript() string {
+	return `
+ALTER TABLE ` + "`" + `user` + "`" + `
+	ADD COLUMN ` + "`" + `password_updated_at` + "`" + ` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+`
+}
+
+// AddUserProfileColumnsScript gets the AddUserProfileColumns script.
+func (ScriptRepository) AddUserProfileColumnsScript() string {
+	return `
+ALTER TABLE ` + "`" + `user` + "`" + `
+	ADD COLUMN ` + "`" + `email` + "`" + ` VARCHAR(254),
+	ADD COLUMN ` + "`" + `display_name` + "`" + ` VARCHAR(64) NOT NULL DEFAULT '',
+	ADD COLUMN ` + "`" + `enabled` + "`" + ` BOOLEAN NOT NULL DEFAULT TRUE,
+	ADD CONSTRAINT ` + "`" + `user_email_un` + "`" + ` UNIQUE (` + "`" + `email` + "`" + `)
+`
+}
+
+// AddUserTOTPColumnsScript gets the AddUserTOTPColumns script.
+func (ScriptRepository) AddUserTOTPColumnsScript() string {
+	return `
+ALTER TABLE ` + "`" + `user` + "`" + `
+	ADD COLUMN ` + "`" + `totp_secret` + "`" + ` BLOB,
+	ADD COLUMN ` + "`" + `totp_enabled` + "`" + ` BOOLEAN NOT NULL DEFAULT FALSE
+`
+}
+
+// AddUserTOTPLastTimeStepColumnScript gets the AddUserTOTPLastTimeStepColumn script.
+func (ScriptRepository) AddUserTOTPLastTimeStepColumnScript() string {
+	return `
+ALTER TABLE ` + "`" + `user` + "`" + `
+	ADD COLUMN ` + "`" + `totp_last_time_step` + "`" + ` BIGINT NOT NULL DEFAULT 0
+`
+}
+
+// CountUsersWithLesserRankScript gets the CountUsersWithLesserRank script.
+func (ScriptRepository) CountUsersWithLesserRankScript() string {
+	return `
+SELECT COUNT(*)
+	FROM ` + "`" + `user` + "`" + ` u,
+		(SELECT ? AS ` + "`" + `rank` + "`" + `, ? AS ` + "`" + `search` + "`" + `) p
+	WHERE u.` + "`" + `rank` + "`" + ` < p.` + "`" + `rank` + "`" + `
+		AND LOWER(SUBSTR(u.` + "`" + `username` + "`" + `, 1, CHAR_LENGTH(p.` + "`" + `search` + "`" + `))) = LOWER(p.` + "`" + `search` + "`" + `)
+`
+}
+
+// CreateUserScript gets the CreateUser script.
+func (ScriptRepository) CreateUserScript() string {
+	return `
+INSERT INTO ` + "`" + `user` + "`" + ` (` + "`" + `username` + "`" + `, ` + "`" + `rank` + "`" + `, ` + "`" + `password_hash` + "`" + `, ` + "`" + `password_updated_at` + "`" + `, ` + "`" + `email` + "`" + `, ` + "`" + `display_name` + "`" + `, ` + "`" + `enabled` + "`" + `)
+	VALUES (?, ?, ?, ?, ?, ?, ?)
+`
+}
+
+// CreateUserTableScript gets the CreateUserTable script.
+func (ScriptRepository) CreateUserTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `user` + "`" + ` (
+	` + "`" + `key` + "`" + ` INT NOT NULL AUTO_INCREMENT,
+	` + "`" + `username` + "`" + ` VARCHAR(30) NOT NULL,
+	` + "`" + `rank` + "`" + ` SMALLINT NOT NULL,
+	` + "`" + `password_hash` + "`" + ` BLOB NOT NULL,
+	CONSTRAINT ` + "`" + `user_pk` + "`" + ` PRIMARY KEY (` + "`" + `key` + "`" + `),
+	CONSTRAINT ` + "`" + `user_username_un` + "`" + ` UNIQUE (` + "`" + `username` + "`" + `)
+)
+`
+}
+
+// DeleteUserScript gets the DeleteUser script.
+func (ScriptRepository) DeleteUserScript() string {
+	return `
+DELETE FROM ` + "`" + `user` + "`" + `
+    WHERE ` + "`" + `username` + "`" + ` = ?
+`
+}
+
+// DropUserPasswordUpdatedAtColumnScript gets the DropUserPasswordUpdatedAtColumn script.
+func (ScriptRepository) DropUserPasswordUpdatedAtColumnScript() string {
+	return `
+ALTER TABLE ` + "`" + `user` + "`" + `
+	DROP COLUMN ` + "`" + `password_updated_at` + "`" + `
+`
+}
+
+// DropUserProfileColumnsScript gets the DropUserProfileColumns script.
+func (ScriptRepository) DropUserProfileColumnsScript() string {
+	return `
+ALTER TABLE ` + "`" + `user` + "`" + `
+	DROP INDEX ` + "`" + `user_email_un` + "`" + `,
+	DROP COLUMN ` + "`" + `email` + "`" + `,
+	DROP COLUMN ` + "`" + `display_name` + "`" + `,
+	DROP COLUMN ` + "`" + `enabled` + "`" + `
+`
+}
+
+// DropUserTOTPColumnsScript gets the DropUserTOTPColumns script.
+func (ScriptRepository) DropUserTOTPColumnsScript() string {
+	return `
+ALTER TABLE ` + "`" + `user` + "`" + `
+	DROP COLUMN ` + "`" + `totp_secret` + "`" + `,
+	DROP COLUMN ` + "`" + `totp_enabled` + "`" + `
+`
+}
+
+// DropUserTOTPLastTimeStepColumnScript gets the DropUserTOTPLastTimeStepColumn script.
+func (ScriptRepository) DropUserTOTPLastTimeStepColumnScript() string {
+	return `
+ALTER TABLE ` + "`" + `user` + "`" + `
+	DROP COLUMN ` + "`" + `totp_last_time_step` + "`" + `
+`
+}
+
+// DropUserTableScript gets the DropUserTable script.
+func (ScriptRepository) DropUserTableScript() string {
+	return `
+DROP TABLE ` + "`" + `user` + "`" + `
+`
+}
+
+// GetUserByEmailScript gets the GetUserByEmail script.
+func (ScriptRepository) GetUserByEmailScript() string {
+	return `
+SELECT u.` + "`" + `username` + "`" + `, u.` + "`" + `rank` + "`" + `, u.` + "`" + `password_hash` + "`" + `, u.` + "`" + `password_updated_at` + "`" + `, u.` + "`" + `totp_secret` + "`" + `, u.` + "`" + `totp_enabled` + "`" + `, u.` + "`" + `totp_last_time_step` + "`" + `,
+		u.` + "`" + `email` + "`" + `, u.` + "`" + `display_name` + "`" + `, u.` + "`" + `enabled` + "`" + `
+	FROM ` + "`" + `user` + "`" + ` u
+	WHERE u.` + "`" + `email` + "`" + ` = ?
+`
+}
+
+// GetUserByUsernameScript gets the GetUserByUsername script.
+func (ScriptRepository) GetUserByUsernameScript() string {
+	return `
+SELECT u.` + "`" + `username` + "`" + `, u.` + "`" + `rank` + "`" + `, u.` + "`" + `password_hash` + "`" + `, u.` + "`" + `password_updated_at` + "`" + `, u.` + "`" + `totp_secret` + "`" + `, u.` + "`" + `totp_enabled` + "`" + `, u.` + "`" + `totp_last_time_step` + "`" + `,
+		u.` + "`" + `email` + "`" + `, u.` + "`" + `display_name` + "`" + `, u.` + "`" + `enabled` + "`" + `
+	FROM ` + "`" + `user` + "`" + ` u
+	WHERE u.` + "`" + `username` + "`" + ` = ?
+`
+}
+
+// GetUsersWithLesserRankSortedByRankScript gets the GetUsersWithLesserRankSortedByRank script.
+func (ScriptRepository) GetUsersWithLesserRankSortedByRankScript() string {
+	return `
+SELECT u.` + "`" + `username` + "`" + `, u.` + "`" + `rank` + "`" + `, u.` + "`" + `password_hash` + "`" + `, u.` + "`" + `password_updated_at` + "`" + `, u.` + "`" + `totp_secret` + "`" + `, u.` + "`" + `totp_enabled` + "`" + `, u.` + "`" + `totp_last_time_step` + "`" + `,
+		u.` + "`" + `email` + "`" + `, u.` + "`" + `display_name` + "`" + `, u.` + "`" + `enabled` + "`" + `
+	FROM ` + "`" + `user` + "`" + ` u,
+		(SELECT ? AS ` + "`" + `rank` + "`" + `, ? AS ` + "`" + `search` + "`" + `, ? AS ` + "`" + `descending` + "`" + `, ? AS ` + "`" + `has_cursor` + "`" + `, ? AS ` + "`" + `cursor_rank` + "`" + `, ? AS ` + "`" + `cursor_username` + "`" + `) p
+	WHERE u.` + "`" + `rank` + "`" + ` < p.` + "`" + `rank` + "`" + `
+		AND LOWER(SUBSTR(u.` + "`" + `username` + "`" + `, 1, CHAR_LENGTH(p.` + "`" + `search` + "`" + `))) = LOWER(p.` + "`" + `search` + "`" + `)
+		AND (NOT p.` + "`" + `has_cursor` + "`" + `
+			OR (p.` + "`" + `descending` + "`" + ` AND (u.` + "`" + `rank` + "`" + ` < p.` + "`" + `cursor_rank` + "`" + ` OR (u.` + "`" + `rank` + "`" + ` = p.` + "`" + `cursor_rank` + "`" + ` AND u.` + "`" + `username` + "`" + ` < p.` + "`" + `cursor_username` + "`" + `)))
+			OR (NOT p.` + "`" + `descending` + "`" + ` AND (u.` + "`" + `rank` + "`" + ` > p.` + "`" + `cursor_rank` + "`" + ` OR (u.` + "`" + `rank` + "`" + ` = p.` + "`" + `cursor_rank` + "`" + ` AND u.` + "`" + `username` + "`" + ` > p.` + "`" + `cursor_username` + "`" + `))))
+	ORDER BY
+		CASE WHEN p.` + "`" + `descending` + "`" + ` THEN u.` + "`" + `rank` + "`" + ` END DESC,
+		CASE WHEN p.` + "`" + `descending` + "`" + ` THEN u.` + "`" + `username` + "`" + ` END DESC,
+		u.` + "`" + `rank` + "`" + `,
+		u.` + "`" + `username` + "`" + `
+	LIMIT ?
+`
+}
+
+// GetUsersWithLesserRankSortedByUsernameScript gets the GetUsersWithLesserRankSortedByUsername script.
+func (ScriptRepository) GetUsersWithLesserRankSortedByUsernameScript() string {
+	return `
+SELECT u.` + "`" + `username` + "`" + `, u.` + "`" + `rank` + "`" + `, u.` + "`" + `password_hash` + "`" + `, u.` + "`" + `password_updated_at` + "`" + `, u.` + "`" + `totp_secret` + "`" + `, u.` + "`" + `totp_enabled` + "`" + `, u.` + "`" + `totp_last_time_step` + "`" + `,
+		u.` + "`" + `email` + "`" + `, u.` + "`" + `display_name` + "`" + `, u.` + "`" + `enabled` + "`" + `
+	FROM ` + "`" + `user` + "`" + ` u,
+		(SELECT ? AS ` + "`" + `rank` + "`" + `, ? AS ` + "`" + `search` + "`" + `, ? AS ` + "`" + `descending` + "`" + `, ? AS ` + "`" + `has_cursor` + "`" + `, ? AS ` + "`" + `cursor_username` + "`" + `) p
+	WHERE u.` + "`" + `rank` + "`" + ` < p.` + "`" + `rank` + "`" + `
+		AND LOWER(SUBSTR(u.` + "`" + `username` + "`" + `, 1, CHAR_LENGTH(p.` + "`" + `search` + "`" + `))) = LOWER(p.` + "`" + `search` + "`" + `)
+		AND (NOT p.` + "`" + `has_cursor` + "`" + `
+			OR (p.` + "`" + `descending` + "`" + ` AND u.` + "`" + `username` + "`" + ` < p.` + "`" + `cursor_username` + "`" + `)
+			OR (NOT p.` + "`" + `descending` + "`" + ` AND u.` + "`" + `username` + "`" + ` > p.` + "`" + `cursor_username` + "`" + `))
+	ORDER BY
+		CASE WHEN p.` + "`" + `descending` + "`" + ` THEN u.` + "`" + `username` + "`" + ` END DESC,
+		u.` + "`" + `username` + "`" + `
+	LIMIT ?
+`
+}
+
+// UpdateUserScript gets the UpdateUser script.
+func (ScriptRepository) UpdateUserScript() string {
+	return `
+UPDATE ` + "`" + `user` + "`" + ` u
+    INNER JOIN (SELECT ? AS ` + "`" + `username` + "`" + `, ? AS ` + "`" + `rank` + "`" + `, ? AS ` + "`" + `email` + "`" + `, ? AS ` + "`" + `display_name` + "`" + `, ? AS ` + "`" + `enabled` + "`" + `) p ON u.` + "`" + `username` + "`" + ` = p.` + "`" + `username` + "`" + `
+SET
+    u.` + "`" + `rank` + "`" + ` = p.` + "`" + `rank` + "`" + `,
+    u.` + "`" + `email` + "`" + ` = p.` + "`" + `email` + "`" + `,
+    u.` + "`" + `display_name` + "`" + ` = p.` + "`" + `display_name` + "`" + `,
+    u.` + "`" + `enabled` + "`" + ` = p.` + "`" + `enabled` + "`" + `
+`
+}
+
+// UpdateUserPasswordScript gets the UpdateUserPassword script.
+func (ScriptRepository) UpdateUserPasswordScript() string {
+	return `
+UPDATE ` + "`" + `user` + "`" + ` u
+    INNER JOIN (SELECT ? AS ` + "`" + `username` + "`" + `, ? AS ` + "`" + `password_hash` + "`" + `, ? AS ` + "`" + `password_updated_at` + "`" + `) p ON u.` + "`" + `username` + "`" + ` = p.` + "`" + `username` + "`" + `
+SET
+    u.` + "`" + `password_hash` + "`" + ` = p.` + "`" + `password_hash` + "`" + `,
+    u.` + "`" + `password_updated_at` + "`" + ` = p.` + "`" + `password_updated_at` + "`" + `
+`
+}
+
+// UpdateUserTOTPScript gets the UpdateUserTOTP script.
+func (ScriptRepository) UpdateUserTOTPScript() string {
+	return `
+UPDATE ` + "`" + `user` + "`" + ` u
+    INNER JOIN (SELECT ? AS ` + "`" + `username` + "`" + `, ? AS ` + "`" + `totp_secret` + "`" + `, ? AS ` + "`" + `totp_enabled` + "`" + `) p ON u.` + "`" + `username` + "`" + ` = p.` + "`" + `username` + "`" + `
+SET
+    u.` + "`" + `totp_secret` + "`" + ` = p.` + "`" + `totp_secret` + "`" + `,
+    u.` + "`" + `totp_enabled` + "`" + ` = p.` + "`" + `totp_enabled` + "`" + `
+`
+}
+
+// UpdateUserTOTPLastTimeStepScript gets the UpdateUserTOTPLastTimeStep script.
+func (ScriptRepository) UpdateUserTOTPLastTimeStepScript() string {
+	return `
+UPDATE ` + "`" + `user` + "`" + ` u
+    INNER JOIN (SELECT ? AS ` + "`" + `username` + "`" + `, ? AS ` + "`" + `totp_last_time_step` + "`" + `) p ON u.` + "`" + `username` + "`" + ` = p.` + "`" + `username` + "`" + `
+SET
+    u.` + "`" + `totp_last_time_step` + "`" + ` = p.` + "`" + `totp_last_time_step` + "`" + `
+WHERE u.` + "`" + `totp_last_time_step` + "`" + ` < p.` + "`" + `totp_last_time_step` + "`" + `
+`
+}
+
+// AddRoleToUserRolePrimaryKeyScript gets the AddRoleToUserRolePrimaryKey script.
+func (ScriptRepository) AddRoleToUserRolePrimaryKeyScript() string {
+	return `
+ALTER TABLE ` + "`" + `user_role` + "`" + `
+    DROP PRIMARY KEY,
+    ADD CONSTRAINT ` + "`" + `user_role_pk` + "`" + ` PRIMARY KEY (` + "`" + `client_key` + "`" + `, ` + "`" + `user_key` + "`" + `, ` + "`" + `role` + "`" + `)
+`
+}
+
+// CountUserRolesByClientUIDAndRoleScript gets the CountUserRolesByClientUIDAndRole script.
+func (ScriptRepository) CountUserRolesByClientUIDAndRoleScript() string {
+	return `
+SELECT COUNT(*)
+    FROM ` + "`" + `user_role` + "`" + ` ur
+        INNER JOIN ` + "`" + `client` + "`" + ` c on c.` + "`" + `uid` + "`" + ` = ? AND c.` + "`" + `key` + "`" + ` = ur.` + "`" + `client_key` + "`" + `
+    WHERE ur.` + "`" + `role` + "`" + ` = ?
+`
+}
+
+// CountUserRolesWithLesserRankByClientUIDScript gets the CountUserRolesWithLesserRankByClientUID script.
+func (ScriptRepository) CountUserRolesWithLesserRankByClientUIDScript() string {
+	return `
+SELECT COUNT(DISTINCT ur.` + "`" + `user_key` + "`" + `)
+    FROM ` + "`" + `user_role` + "`" + ` ur
+        INNER JOIN ` + "`" + `client` + "`" + ` c on c.` + "`" + `uid` + "`" + ` = ? AND c.` + "`" + `key` + "`" + ` = ur.` + "`" + `client_key` + "`" + `
+        INNER JOIN ` + "`" + `user` + "`" + ` u on u.` + "`" + `rank` + "`" + ` < ? AND u.` + "`" + `key` + "`" + ` = ur.` + "`" + `user_key` + "`" + `,
+        (SELECT ? AS ` + "`" + `search` + "`" + `) p
+    WHERE LOWER(SUBSTR(u.` + "`" + `username` + "`" + `, 1, CHAR_LENGTH(p.` + "`" + `search` + "`" + `))) = LOWER(p.` + "`" + `search` + "`" + `)
+`
+}
+
+// CreateUserRoleScript gets the CreateUserRole script.
+func (ScriptRepository) CreateUserRoleScript() string {
+	return `
+INSERT INTO ` + "`" + `user_role` + "`" + ` (` + "`" + `client_key` + "`" + `, ` + "`" + `user_key` + "`" + `, ` + "`" + `role` + "`" + `)
+	SELECT c.` + "`" + `key` + "`" + `, u.` + "`" + `key` + "`" + `, p.` + "`" + `role` + "`" + `
+		FROM (SELECT ? AS ` + "`" + `client_uid` + "`" + `, ? AS ` + "`" + `username` + "`" + `, ? AS ` + "`" + `role` + "`" + `) p
+			INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `uid` + "`" + ` = p.` + "`" + `client_uid` + "`" + `
+			INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `username` + "`" + ` = p.` + "`" + `username` + "`" + `
+`
+}
+
+// CreateUserRoleTableScript gets the CreateUserRoleTable script.
+func (ScriptRepository) CreateUserRoleTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `user_role` + "`" + ` (
+	` + "`" + `client_key` + "`" + ` SMALLINT,
+    ` + "`" + `user_key` + "`" + ` INTEGER,
+	` + "`" + `role` + "`" + ` VARCHAR(15) NOT NULL,
+	CONSTRAINT ` + "`" + `user_role_pk` + "`" + ` PRIMARY KEY (` + "`" + `client_key` + "`" + `, ` + "`" + `user_key` + "`" + `),
+	CONSTRAINT ` + "`" + `user_role_client_fk` + "`" + ` FOREIGN KEY (` + "`" + `client_key` + "`" + `) REFERENCES ` + "`" + `client` + "`" + `(` + "`" + `key` + "`" + `) ON DELETE CASCADE,
+	CONSTRAINT ` + "`" + `user_role_user_fk` + "`" + ` FOREIGN KEY (` + "`" + `user_key` + "`" + `) REFERENCES ` + "`" + `user` + "`" + `(` + "`" + `key` + "`" + `) ON DELETE CASCADE
+)
+`
+}
+
+// DeleteAllButFirstUserRolesScript gets the DeleteAllButFirstUserRoles script.
+func (ScriptRepository) DeleteAllButFirstUserRolesScript() string {
+	return `
+DELETE ur FROM ` + "`" + `user_role` + "`" + ` ur
+    INNER JOIN ` + "`" + `user_role` + "`" + ` ur2 ON ur2.` + "`" + `client_key` + "`" + ` = ur.` + "`" + `client_key` + "`" + ` AND ur2.` + "`" + `user_key` + "`" + ` = ur.` + "`" + `user_key` + "`" + ` AND ur2.` + "`" + `role` + "`" + ` < ur.` + "`" + `role` + "`" + `
+`
+}
+
+// DeleteUserRoleScript gets the DeleteUserRole script.
+func (ScriptRepository) DeleteUserRoleScript() string {
+	return `
+DELETE FROM ` + "`" + `user_role` + "`" + `
+    WHERE ` + "`" + `client_key` + "`" + ` IN (SELECT c.` + "`" + `key` + "`" + ` FROM ` + "`" + `client` + "`" + ` c WHERE c.` + "`" + `uid` + "`" + ` = ?) AND
+          ` + "`" + `user_key` + "`" + ` IN (SELECT u.` + "`" + `key` + "`" + ` FROM ` + "`" + `user` + "`" + ` u WHERE u.` + "`" + `username` + "`" + ` = ?)
+`
+}
+
+// DropUserRoleTableScript gets the DropUserRoleTable script.
+func (ScriptRepository) DropUserRoleTableScript() string {
+	return `
+DROP TABLE ` + "`" + `user_role` + "`" + `
+`
+}
+
+// GetUserRoleByClientUIDAndUsernameScript gets the GetUserRoleByClientUIDAndUsername script.
+func (ScriptRepository) GetUserRoleByClientUIDAndUsernameScript() string {
+	return `
+SELECT c.` + "`" + `uid` + "`" + `, u.` + "`" + `username` + "`" + `, ur.` + "`" + `role` + "`" + `
+    FROM ` + "`" + `user_role` + "`" + ` ur
+        INNER JOIN ` + "`" + `client` + "`" + ` c on c.` + "`" + `uid` + "`" + ` = ? AND c.` + "`" + `key` + "`" + ` = ur.` + "`" + `client_key` + "`" + `
+        INNER JOIN ` + "`" + `user` + "`" + ` u on u.` + "`" + `username` + "`" + ` = ? AND u.` + "`" + `key` + "`" + ` = ur.` + "`" + `user_key` + "`" + `
+    ORDER BY ur.` + "`" + `role` + "`" + `
+`
+}
+
+// GetUserRolesWithLesserRankByClientUIDSortedByRoleScript gets the GetUserRolesWithLesserRankByClientUIDSortedByRole script.
+func (ScriptRepository) GetUserRolesWithLesserRankByClientUIDSortedByRoleScript() string {
+	return `
+SELECT c.` + "`" + `uid` + "`" + `, pg.` + "`" + `username` + "`" + `, ur.` + "`" + `role` + "`" + `
+    FROM (
+        SELECT ur.` + "`" + `client_key` + "`" + `, ur.` + "`" + `user_key` + "`" + `, u.` + "`" + `username` + "`" + `, MIN(ur.` + "`" + `role` + "`" + `) AS ` + "`" + `first_role` + "`" + `, p.` + "`" + `descending` + "`" + `
+            FROM ` + "`" + `user_role` + "`" + ` ur
+                INNER JOIN ` + "`" + `client` + "`" + ` c on c.` + "`" + `uid` + "`" + ` = ? AND c.` + "`" + `key` + "`" + ` = ur.` + "`" + `client_key` + "`" + `
+                INNER JOIN ` + "`" + `user` + "`" + ` u on u.` + "`" + `rank` + "`" + ` < ? AND u.` + "`" + `key` + "`" + ` = ur.` + "`" + `user_key` + "`" + `,
+                (SELECT ? AS ` + "`" + `search` + "`" + `, ? AS ` + "`" + `descending` + "`" + `, ? AS ` + "`" + `has_cursor` + "`" + `, ? AS ` + "`" + `cursor_role` + "`" + `, ? AS ` + "`" + `cursor_username` + "`" + `) p
+            WHERE LOWER(SUBSTR(u.` + "`" + `username` + "`" + `, 1, CHAR_LENGTH(p.` + "`" + `search` + "`" + `))) = LOWER(p.` + "`" + `search` + "`" + `)
+            GROUP BY ur.` + "`" + `client_key` + "`" + `, ur.` + "`" + `user_key` + "`" + `, u.` + "`" + `username` + "`" + `, p.` + "`" + `descending` + "`" + `, p.` + "`" + `has_cursor` + "`" + `, p.` + "`" + `cursor_role` + "`" + `, p.` + "`" + `cursor_username` + "`" + `
+            HAVING NOT p.` + "`" + `has_cursor` + "`" + `
+                OR (p.` + "`" + `descending` + "`" + ` AND (MIN(ur.` + "`" + `role` + "`" + `) < p.` + "`" + `cursor_role` + "`" + ` OR (MIN(ur.` + "`" + `role` + "`" + `) = p.` + "`" + `cursor_role` + "`" + ` AND u.` + "`" + `username` + "`" + ` < p.` + "`" + `cursor_username` + "`" + `)))
+                OR (NOT p.` + "`" + `descending` + "`" + ` AND (MIN(ur.` + "`" + `role` + "`" + `) > p.` + "`" + `cursor_role` + "`" + ` OR (MIN(ur.` + "`" + `role` + "`" + `) = p.` + "`" + `cursor_role` + "`" + ` AND u.` + "`" + `username` + "`" + ` > p.` + "`" + `cursor_username` + "`" + `)))
+            ORDER BY
+                CASE WHEN p.` + "`" + `descending` + "`" + ` THEN MIN(ur.` + "`" + `role` + "`" + `) END DESC,
+                CASE WHEN p.` + "`" + `descending` + "`" + ` THEN u.` + "`" + `username` + "`" + ` END DESC,
+                MIN(ur.` + "`" + `role` + "`" + `),
+                u.` + "`" + `username` + "`" + `
+            LIMIT ?
+    ) pg
+        INNER JOIN ` + "`" + `client` + "`" + ` c on c.` + "`" + `key` + "`" + ` = pg.` + "`" + `client_key` + "`" + `
+        INNER JOIN ` + "`" + `user_role` + "`" + ` ur on ur.` + "`" + `client_key` + "`" + ` = pg.` + "`" + `client_key` + "`" + ` AND ur.` + "`" + `user_key` + "`" + ` = pg.` + "`" + `user_key` + "`" + `
+    ORDER BY
+        CASE WHEN pg.` + "`" + `descending` + "`" + ` THEN pg.` + "`" + `first_role` + "`" + ` END DESC,
+        CASE WHEN pg.` + "`" + `descending` + "`" + ` THEN pg.` + "`" + `username` + "`" + ` END DESC,
+        pg.` + "`" + `first_role` + "`" + `,
+        pg.` + "`" + `username` + "`" + `,
+        ur.` + "`" + `role` + "`" + `
+`
+}
+
+// GetUserRolesWithLesserRankByClientUIDSortedByUsernameScript gets the GetUserRolesWithLesserRankByClientUIDSortedByUsername script.
+func (ScriptRepository) GetUserRolesWithLesserRankByClientUIDSortedByUsernameScript() string {
+	return `
+SELECT c.` + "`" + `uid` + "`" + `, pg.` + "`" + `username` + "`" + `, ur.` + "`" + `role` + "`" + `
+    FROM (
+        SELECT ur.` + "`" + `client_key` + "`" + `, ur.` + "`" + `user_key` + "`" + `, u.` + "`" + `username` + "`" + `, p.` + "`" + `descending` + "`" + `
+            FROM ` + "`" + `user_role` + "`" + ` ur
+                INNER JOIN ` + "`" + `client` + "`" + ` c on c.` + "`" + `uid` + "`" + ` = ? AND c.` + "`" + `key` + "`" + ` = ur.` + "`" + `client_key` + "`" + `
+                INNER JOIN ` + "`" + `user` + "`" + ` u on u.` + "`" + `rank` + "`" + ` < ? AND u.` + "`" + `key` + "`" + ` = ur.` + "`" + `user_key` + "`" + `,
+                (SELECT ? AS ` + "`" + `search` + "`" + `, ? AS ` + "`" + `descending` + "`" + `, ? AS ` + "`" + `has_cursor` + "`" + `, ? AS ` + "`" + `cursor_username` + "`" + `) p
+            WHERE LOWER(SUBSTR(u.` + "`" + `username` + "`" + `, 1, CHAR_LENGTH(p.` + "`" + `search` + "`" + `))) = LOWER(p.` + "`" + `search` + "`" + `)
+                AND (NOT p.` + "`" + `has_cursor` + "`" + `
+                    OR (p.` + "`" + `descending` + "`" + ` AND u.` + "`" + `username` + "`" + ` < p.` + "`" + `cursor_username` + "`" + `)
+                    OR (NOT p.` + "`" + `descending` + "`" + ` AND u.` + "`" + `username` + "`" + ` > p.` + "`" + `cursor_username` + "`" + `))
+            GROUP BY ur.` + "`" + `client_key` + "`" + `, ur.` + "`" + `user_key` + "`" + `, u.` + "`" + `username` + "`" + `, p.` + "`" + `descending` + "`" + `
+            ORDER BY
+                CASE WHEN p.` + "`" + `descending` + "`" + ` THEN u.` + "`" + `username` + "`" + ` END DESC,
+                u.` + "`" + `username` + "`" + `
+            LIMIT ?
+    ) pg
+        INNER JOIN ` + "`" + `client` + "`" + ` c on c.` + "`" + `key` + "`" + ` = pg.` + "`" + `client_key` + "`" + `
+        INNER JOIN ` + "`" + `user_role` + "`" + ` ur on ur.` + "`" + `client_key` + "`" + ` = pg.` + "`" + `client_key` + "`" + ` AND ur.` + "`" + `user_key` + "`" + ` = pg.` + "`" + `user_key` + "`" + `
+    ORDER BY
+        CASE WHEN pg.` + "`" + `descending` + "`" + ` THEN pg.` + "`" + `username` + "`" + ` END DESC,
+        pg.` + "`" + `username` + "`" + `,
+        ur.` + "`" + `role` + "`" + `
+`
+}
+
+// RemoveRoleFromUserRolePrimaryKeyScript gets the RemoveRoleFromUserRolePrimaryKey script.
+func (ScriptRepository) RemoveRoleFromUserRolePrimaryKeyScript() string {
+	return `
+ALTER TABLE ` + "`" + `user_role` + "`" + `
+    DROP PRIMARY KEY,
+    ADD CONSTRAINT ` + "`" + `user_role_pk` + "`" + ` PRIMARY KEY (` + "`" + `client_key` + "`" + `, ` + "`" + `user_key` + "`" + `)
+`
+}
+
+// RenameUserRolesScript gets the RenameUserRoles script.
+func (ScriptRepository) RenameUserRolesScript() string {
+	return `
+UPDATE ` + "`" + `user_role` + "`" + ` ur
+    INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `key` + "`" + ` = ur.` + "`" + `client_key` + "`" + `
+    INNER JOIN (SELECT ? AS ` + "`" + `client_uid` + "`" + `, ? AS ` + "`" + `role` + "`" + `, ? AS ` + "`" + `new_role` + "`" + `) p ON c.` + "`" + `uid` + "`" + ` = p.` + "`" + `client_uid` + "`" + ` AND ur.` + "`" + `role` + "`" + ` = p.` + "`" + `role` + "`" + `
+SET
+    ur.` + "`" + `role` + "`" + ` = p.` + "`" + `new_role` + "`" + `
+`
+}
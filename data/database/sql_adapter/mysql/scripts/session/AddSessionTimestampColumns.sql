@@ -0,0 +1,3 @@
+ALTER TABLE `session`
+	ADD COLUMN `created_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+	ADD COLUMN `last_used_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
\ No newline at end of file
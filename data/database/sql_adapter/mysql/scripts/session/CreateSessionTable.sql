@@ -0,0 +1,6 @@
+CREATE TABLE `session` (
+	`token` CHAR(36) NOT NULL,
+	`user_key` INTEGER NOT NULL,
+	CONSTRAINT `session_pk` PRIMARY KEY (`token`),
+	CONSTRAINT `session_user_fk` FOREIGN KEY (`user_key`) REFERENCES `user`(`key`) ON DELETE CASCADE
+)
\ No newline at end of file
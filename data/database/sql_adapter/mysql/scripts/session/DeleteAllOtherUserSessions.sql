@@ -0,0 +1,5 @@
+DELETE FROM `session`
+    WHERE `token` != ? AND
+        `user_key` IN (
+            SELECT u.`key` FROM `user` u WHERE u.`username` = ?
+        )
\ No newline at end of file
@@ -0,0 +1,3 @@
+DELETE FROM `session`
+    WHERE `created_at` < ? OR
+        `last_used_at` < ?
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE FROM `session`
+    WHERE `token` = ?
\ No newline at end of file
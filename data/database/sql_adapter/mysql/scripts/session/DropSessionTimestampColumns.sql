@@ -0,0 +1,3 @@
+ALTER TABLE `session`
+	DROP COLUMN `created_at`,
+	DROP COLUMN `last_used_at`
\ No newline at end of file
@@ -0,0 +1,4 @@
+INSERT INTO `session` (`token`, `user_key`, `created_at`, `last_used_at`)
+	SELECT p.`token`, u.`key`, p.`created_at`, p.`last_used_at`
+		FROM (SELECT ? AS `token`, ? AS `username`, ? AS `created_at`, ? AS `last_used_at`) p
+			INNER JOIN `user` u ON u.`username` = p.`username`
\ No newline at end of file
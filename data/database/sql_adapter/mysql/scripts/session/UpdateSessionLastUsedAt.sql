@@ -0,0 +1,4 @@
+UPDATE `session` s
+    INNER JOIN (SELECT ? AS `token`, ? AS `last_used_at`) p ON s.`token` = p.`token`
+SET
+    s.`last_used_at` = p.`last_used_at`
\ No newline at end of file
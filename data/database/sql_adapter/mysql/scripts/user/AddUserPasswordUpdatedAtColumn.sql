@@ -0,0 +1,2 @@
+ALTER TABLE `user`
+	ADD COLUMN `password_updated_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
\ No newline at end of file
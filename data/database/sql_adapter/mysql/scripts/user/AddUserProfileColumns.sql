@@ -0,0 +1,5 @@
+ALTER TABLE `user`
+	ADD COLUMN `email` VARCHAR(254),
+	ADD COLUMN `display_name` VARCHAR(64) NOT NULL DEFAULT '',
+	ADD COLUMN `enabled` BOOLEAN NOT NULL DEFAULT TRUE,
+	ADD CONSTRAINT `user_email_un` UNIQUE (`email`)
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE `user`
+	ADD COLUMN `totp_secret` BLOB,
+	ADD COLUMN `totp_enabled` BOOLEAN NOT NULL DEFAULT FALSE
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE `user`
+	ADD COLUMN `totp_last_time_step` BIGINT NOT NULL DEFAULT 0
\ No newline at end of file
@@ -0,0 +1,5 @@
+SELECT COUNT(*)
+	FROM `user` u,
+		(SELECT ? AS `rank`, ? AS `search`) p
+	WHERE u.`rank` < p.`rank`
+		AND LOWER(SUBSTR(u.`username`, 1, CHAR_LENGTH(p.`search`))) = LOWER(p.`search`)
\ No newline at end of file
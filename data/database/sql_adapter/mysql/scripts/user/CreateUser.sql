@@ -0,0 +1,2 @@
+INSERT INTO `user` (`username`, `rank`, `password_hash`, `password_updated_at`, `email`, `display_name`, `enabled`)
+	VALUES (?, ?, ?, ?, ?, ?, ?)
\ No newline at end of file
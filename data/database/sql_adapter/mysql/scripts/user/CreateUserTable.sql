@@ -0,0 +1,8 @@
+CREATE TABLE `user` (
+	`key` INT NOT NULL AUTO_INCREMENT,
+	`username` VARCHAR(30) NOT NULL,
+	`rank` SMALLINT NOT NULL,
+	`password_hash` BLOB NOT NULL,
+	CONSTRAINT `user_pk` PRIMARY KEY (`key`),
+	CONSTRAINT `user_username_un` UNIQUE (`username`)
+)
\ No newline at end of file
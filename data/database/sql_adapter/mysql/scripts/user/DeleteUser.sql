@@ -0,0 +1,2 @@
+DELETE FROM `user`
+    WHERE `username` = ?
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE `user`
+	DROP INDEX `user_email_un`,
+	DROP COLUMN `email`,
+	DROP COLUMN `display_name`,
+	DROP COLUMN `enabled`
\ No newline at end of file
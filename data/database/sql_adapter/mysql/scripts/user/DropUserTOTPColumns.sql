@@ -0,0 +1,3 @@
+ALTER TABLE `user`
+	DROP COLUMN `totp_secret`,
+	DROP COLUMN `totp_enabled`
\ No newline at end of file
@@ -0,0 +1,13 @@
+SELECT u.`username`, u.`rank`, u.`password_hash`, u.`password_updated_at`, u.`totp_secret`, u.`totp_enabled`, u.`totp_last_time_step`,
+		u.`email`, u.`display_name`, u.`enabled`
+	FROM `user` u,
+		(SELECT ? AS `rank`, ? AS `search`, ? AS `descending`, ? AS `has_cursor`, ? AS `cursor_username`) p
+	WHERE u.`rank` < p.`rank`
+		AND LOWER(SUBSTR(u.`username`, 1, CHAR_LENGTH(p.`search`))) = LOWER(p.`search`)
+		AND (NOT p.`has_cursor`
+			OR (p.`descending` AND u.`username` < p.`cursor_username`)
+			OR (NOT p.`descending` AND u.`username` > p.`cursor_username`))
+	ORDER BY
+		CASE WHEN p.`descending` THEN u.`username` END DESC,
+		u.`username`
+	LIMIT ?
\ No newline at end of file
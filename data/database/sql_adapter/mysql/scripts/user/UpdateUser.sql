@@ -0,0 +1,7 @@
+UPDATE `user` u
+    INNER JOIN (SELECT ? AS `username`, ? AS `rank`, ? AS `email`, ? AS `display_name`, ? AS `enabled`) p ON u.`username` = p.`username`
+SET
+    u.`rank` = p.`rank`,
+    u.`email` = p.`email`,
+    u.`display_name` = p.`display_name`,
+    u.`enabled` = p.`enabled`
\ No newline at end of file
@@ -0,0 +1,5 @@
+UPDATE `user` u
+    INNER JOIN (SELECT ? AS `username`, ? AS `password_hash`, ? AS `password_updated_at`) p ON u.`username` = p.`username`
+SET
+    u.`password_hash` = p.`password_hash`,
+    u.`password_updated_at` = p.`password_updated_at`
\ No newline at end of file
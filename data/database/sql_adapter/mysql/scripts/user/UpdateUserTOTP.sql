@@ -0,0 +1,5 @@
+UPDATE `user` u
+    INNER JOIN (SELECT ? AS `username`, ? AS `totp_secret`, ? AS `totp_enabled`) p ON u.`username` = p.`username`
+SET
+    u.`totp_secret` = p.`totp_secret`,
+    u.`totp_enabled` = p.`totp_enabled`
\ No newline at end of file
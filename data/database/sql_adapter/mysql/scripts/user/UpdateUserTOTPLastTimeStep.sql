@@ -0,0 +1,5 @@
+UPDATE `user` u
+    INNER JOIN (SELECT ? AS `username`, ? AS `totp_last_time_step`) p ON u.`username` = p.`username`
+SET
+    u.`totp_last_time_step` = p.`totp_last_time_step`
+WHERE u.`totp_last_time_step` < p.`totp_last_time_step`
\ No newline at end of file
@@ -0,0 +1,4 @@
+SELECT COUNT(*)
+    FROM `user_role` ur
+        INNER JOIN `client` c on c.`uid` = ? AND c.`key` = ur.`client_key`
+    WHERE ur.`role` = ?
\ No newline at end of file
@@ -0,0 +1,6 @@
+SELECT COUNT(DISTINCT ur.`user_key`)
+    FROM `user_role` ur
+        INNER JOIN `client` c on c.`uid` = ? AND c.`key` = ur.`client_key`
+        INNER JOIN `user` u on u.`rank` < ? AND u.`key` = ur.`user_key`,
+        (SELECT ? AS `search`) p
+    WHERE LOWER(SUBSTR(u.`username`, 1, CHAR_LENGTH(p.`search`))) = LOWER(p.`search`)
\ No newline at end of file
@@ -0,0 +1,5 @@
+INSERT INTO `user_role` (`client_key`, `user_key`, `role`)
+	SELECT c.`key`, u.`key`, p.`role`
+		FROM (SELECT ? AS `client_uid`, ? AS `username`, ? AS `role`) p
+			INNER JOIN `client` c ON c.`uid` = p.`client_uid`
+			INNER JOIN `user` u ON u.`username` = p.`username`
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE ur FROM `user_role` ur
+    INNER JOIN `user_role` ur2 ON ur2.`client_key` = ur.`client_key` AND ur2.`user_key` = ur.`user_key` AND ur2.`role` < ur.`role`
\ No newline at end of file
@@ -0,0 +1,27 @@
+SELECT c.`uid`, pg.`username`, ur.`role`
+    FROM (
+        SELECT ur.`client_key`, ur.`user_key`, u.`username`, MIN(ur.`role`) AS `first_role`, p.`descending`
+            FROM `user_role` ur
+                INNER JOIN `client` c on c.`uid` = ? AND c.`key` = ur.`client_key`
+                INNER JOIN `user` u on u.`rank` < ? AND u.`key` = ur.`user_key`,
+                (SELECT ? AS `search`, ? AS `descending`, ? AS `has_cursor`, ? AS `cursor_role`, ? AS `cursor_username`) p
+            WHERE LOWER(SUBSTR(u.`username`, 1, CHAR_LENGTH(p.`search`))) = LOWER(p.`search`)
+            GROUP BY ur.`client_key`, ur.`user_key`, u.`username`, p.`descending`, p.`has_cursor`, p.`cursor_role`, p.`cursor_username`
+            HAVING NOT p.`has_cursor`
+                OR (p.`descending` AND (MIN(ur.`role`) < p.`cursor_role` OR (MIN(ur.`role`) = p.`cursor_role` AND u.`username` < p.`cursor_username`)))
+                OR (NOT p.`descending` AND (MIN(ur.`role`) > p.`cursor_role` OR (MIN(ur.`role`) = p.`cursor_role` AND u.`username` > p.`cursor_username`)))
+            ORDER BY
+                CASE WHEN p.`descending` THEN MIN(ur.`role`) END DESC,
+                CASE WHEN p.`descending` THEN u.`username` END DESC,
+                MIN(ur.`role`),
+                u.`username`
+            LIMIT ?
+    ) pg
+        INNER JOIN `client` c on c.`key` = pg.`client_key`
+        INNER JOIN `user_role` ur on ur.`client_key` = pg.`client_key` AND ur.`user_key` = pg.`user_key`
+    ORDER BY
+        CASE WHEN pg.`descending` THEN pg.`first_role` END DESC,
+        CASE WHEN pg.`descending` THEN pg.`username` END DESC,
+        pg.`first_role`,
+        pg.`username`,
+        ur.`role`
\ No newline at end of file
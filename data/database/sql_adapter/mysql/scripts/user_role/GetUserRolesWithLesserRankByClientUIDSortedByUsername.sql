@@ -0,0 +1,23 @@
+SELECT c.`uid`, pg.`username`, ur.`role`
+    FROM (
+        SELECT ur.`client_key`, ur.`user_key`, u.`username`, p.`descending`
+            FROM `user_role` ur
+                INNER JOIN `client` c on c.`uid` = ? AND c.`key` = ur.`client_key`
+                INNER JOIN `user` u on u.`rank` < ? AND u.`key` = ur.`user_key`,
+                (SELECT ? AS `search`, ? AS `descending`, ? AS `has_cursor`, ? AS `cursor_username`) p
+            WHERE LOWER(SUBSTR(u.`username`, 1, CHAR_LENGTH(p.`search`))) = LOWER(p.`search`)
+                AND (NOT p.`has_cursor`
+                    OR (p.`descending` AND u.`username` < p.`cursor_username`)
+                    OR (NOT p.`descending` AND u.`username` > p.`cursor_username`))
+            GROUP BY ur.`client_key`, ur.`user_key`, u.`username`, p.`descending`
+            ORDER BY
+                CASE WHEN p.`descending` THEN u.`username` END DESC,
+                u.`username`
+            LIMIT ?
+    ) pg
+        INNER JOIN `client` c on c.`key` = pg.`client_key`
+        INNER JOIN `user_role` ur on ur.`client_key` = pg.`client_key` AND ur.`user_key` = pg.`user_key`
+    ORDER BY
+        CASE WHEN pg.`descending` THEN pg.`username` END DESC,
+        pg.`username`,
+        ur.`role`
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE `user_role`
+    DROP PRIMARY KEY,
+    ADD CONSTRAINT `user_role_pk` PRIMARY KEY (`client_key`, `user_key`)
\ No newline at end of file
@@ -0,0 +1,5 @@
+UPDATE `user_role` ur
+    INNER JOIN `client` c ON c.`key` = ur.`client_key`
+    INNER JOIN (SELECT ? AS `client_uid`, ? AS `role`, ? AS `new_role`) p ON c.`uid` = p.`client_uid` AND ur.`role` = p.`role`
+SET
+    ur.`role` = p.`new_role`
\ No newline at end of file
@@ -0,0 +1,58 @@
+package sqladapter
+
+import (
+	"math"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+)
+
+// pageLimit returns the limit to pass to the page scripts. A limit of zero fetches every item, so it is converted to the max limit.
+func pageLimit(query models.PageQuery) int {
+	if query.Limit == 0 {
+		return math.MaxInt32
+	}
+	return query.Limit
+}
+
+// pageCursor returns whether the page query has a cursor, along with the cursor's value and key.
+// The page scripts expect the values even if there is no cursor, so empty ones are returned in that case.
+func pageCursor(query models.PageQuery) (bool, string, string) {
+	if query.After == nil {
+		return false, "", ""
+	}
+	return true, query.After.Value, query.After.Key
+}
+
+// queryCount executes the count script with the provided args.
+// Returns the count and any errors.
+func (crud *SQLCRUD) queryCount(script string, args ...interface{}) (int, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, script, args...)
+	defer cancel()
+
+	if err != nil {
+		return 0, common.ChainError("error executing count query", err)
+	}
+	defer rows.Close()
+
+	//check if there was a result
+	if !rows.Next() {
+		err := rows.Err()
+		if err != nil {
+			return 0, common.ChainError("error preparing next row", err)
+		}
+
+		//return no count
+		return 0, nil
+	}
+
+	//get the result
+	count := 0
+	err = rows.Scan(&count)
+	if err != nil {
+		return 0, common.ChainError("error reading row", err)
+	}
+
+	return count, nil
+}
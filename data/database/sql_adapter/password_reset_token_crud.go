@@ -0,0 +1,128 @@
+package sqladapter
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+)
+
+// CreatePasswordResetTokenTable creates the password reset token table in the database.
+// Returns any errors.
+func (crud *SQLCRUD) CreatePasswordResetTokenTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreatePasswordResetTokenTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create password reset token table script", err)
+	}
+
+	return err
+}
+
+// DropPasswordResetTokenTable drops the password reset token table from the database.
+// Returns any errors.
+func (crud *SQLCRUD) DropPasswordResetTokenTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropPasswordResetTokenTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop password reset token table script", err)
+	}
+
+	return err
+}
+
+func (crud *SQLCRUD) SavePasswordResetToken(token *models.PasswordResetToken) error {
+	//validate the password reset token model
+	verr := token.Validate()
+	if verr != models.ValidatePasswordResetTokenValid {
+		return errors.New(fmt.Sprint("error validating password reset token model:", verr))
+	}
+
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.SavePasswordResetTokenScript(),
+		token.TokenHash, token.Username, token.ExpiresAt,
+	)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing save password reset token statement", err)
+	}
+
+	//nothing is inserted if the user does not exist
+	count, _ := res.RowsAffected()
+	if count == 0 {
+		return errors.New("password reset token user does not exist")
+	}
+
+	return nil
+}
+
+func (crud *SQLCRUD) GetPasswordResetToken(hash []byte) (*models.PasswordResetToken, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetPasswordResetTokenScript(), hash)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get password reset token query", err)
+	}
+	defer rows.Close()
+
+	return readPasswordResetTokenData(rows)
+}
+
+func (crud *SQLCRUD) DeletePasswordResetToken(hash []byte) (bool, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DeletePasswordResetTokenScript(), hash)
+	cancel()
+
+	if err != nil {
+		return false, common.ChainError("error executing delete password reset token statement", err)
+	}
+
+	count, _ := res.RowsAffected()
+	return count > 0, nil
+}
+
+func (crud *SQLCRUD) DeleteAllUserPasswordResetTokens(username string) error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DeleteAllUserPasswordResetTokensScript(), username)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing delete all user password reset tokens statement", err)
+	}
+
+	return nil
+}
+
+func readPasswordResetTokenData(rows *sql.Rows) (*models.PasswordResetToken, error) {
+	//check if there was a result
+	if !rows.Next() {
+		err := rows.Err()
+		if err != nil {
+			return nil, common.ChainError("error preparing next row", err)
+		}
+
+		//return no results
+		return nil, nil
+	}
+
+	token := &models.PasswordResetToken{}
+
+	//get the result
+	err := rows.Scan(&token.TokenHash, &token.Username, &token.ExpiresAt)
+	if err != nil {
+		return nil, common.ChainError("error reading row", err)
+	}
+
+	//normalize the timestamp to UTC
+	token.ExpiresAt = token.ExpiresAt.UTC()
+
+	return token, nil
+}
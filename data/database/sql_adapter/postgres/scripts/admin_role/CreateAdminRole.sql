@@ -0,0 +1,2 @@
+INSERT INTO "admin_role" ("name", "description", "permissions")
+    VALUES ($1, $2, $3)
\ No newline at end of file
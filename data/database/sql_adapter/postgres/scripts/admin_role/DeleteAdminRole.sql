@@ -0,0 +1,2 @@
+DELETE FROM "admin_role" ar
+    WHERE ar."name" = $1
\ No newline at end of file
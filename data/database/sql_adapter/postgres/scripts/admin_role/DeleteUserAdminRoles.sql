@@ -0,0 +1,2 @@
+DELETE FROM "user_admin_role" uar
+    WHERE uar."user_key" IN (SELECT u."key" FROM "user" u WHERE u."username" = $1)
\ No newline at end of file
@@ -0,0 +1,3 @@
+SELECT ar."name", ar."description", ar."permissions"
+    FROM "admin_role" ar
+    WHERE ar."name" = $1
\ No newline at end of file
@@ -0,0 +1,5 @@
+SELECT u."username"
+    FROM "user_admin_role" uar
+        INNER JOIN "admin_role" ar ON ar."name" = $1 AND ar."key" = uar."admin_role_key"
+        INNER JOIN "user" u ON u."key" = uar."user_key"
+    ORDER BY u."username"
\ No newline at end of file
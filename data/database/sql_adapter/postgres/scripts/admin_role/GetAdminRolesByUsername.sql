@@ -0,0 +1,5 @@
+SELECT ar."name", ar."description", ar."permissions"
+    FROM "admin_role" ar
+        INNER JOIN "user_admin_role" uar ON uar."admin_role_key" = ar."key"
+        INNER JOIN "user" u ON u."username" = $1 AND u."key" = uar."user_key"
+    ORDER BY ar."name"
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE "public"."audit_event" (
+	"id" UUID NOT NULL,
+	"actor" VARCHAR(64) NOT NULL,
+	"action" VARCHAR(32) NOT NULL,
+	"target" VARCHAR(128) NOT NULL,
+	"ip_address" VARCHAR(64) NOT NULL,
+	"timestamp" TIMESTAMP WITH TIME ZONE NOT NULL,
+	CONSTRAINT "audit_event_pk" PRIMARY KEY ("id")
+);
+
+CREATE INDEX "audit_event_timestamp_idx" ON "public"."audit_event" ("timestamp");
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE "public"."audit_event"
\ No newline at end of file
@@ -0,0 +1,9 @@
+SELECT ae."id", ae."actor", ae."action", ae."target", ae."ip_address", ae."timestamp"
+    FROM "audit_event" ae
+    WHERE ($1 = '' OR ae."actor" = $1)
+        AND ($2 = '' OR ae."action" = $2)
+        AND ($3 = '' OR ae."target" = $3)
+        AND ($4::TIMESTAMP WITH TIME ZONE IS NULL OR ae."timestamp" >= $4)
+        AND ($5::TIMESTAMP WITH TIME ZONE IS NULL OR ae."timestamp" < $5)
+    ORDER BY ae."timestamp" DESC, ae."id"
+    LIMIT $6 OFFSET $7
\ No newline at end of file
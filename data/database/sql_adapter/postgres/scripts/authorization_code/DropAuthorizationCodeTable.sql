@@ -0,0 +1 @@
+DROP TABLE "public"."authorization_code"
\ No newline at end of file
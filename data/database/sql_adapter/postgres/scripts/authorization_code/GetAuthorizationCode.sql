@@ -0,0 +1,5 @@
+SELECT ac."code", c."uid", u."username", ac."code_challenge", ac."nonce", ac."redirect_uri", ac."expires_at"
+    FROM "authorization_code" ac
+        INNER JOIN "client" c ON c."key" = ac."client_key"
+        INNER JOIN "user" u ON u."key" = ac."user_key"
+    WHERE ac."code" = $1
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE "public"."client"
+	ADD COLUMN "secret_hash" BYTEA;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE "public"."client_admin"
\ No newline at end of file
@@ -0,0 +1,4 @@
+SELECT c."uid", u."username", ca."is_owner"
+    FROM "client_admin" ca
+        INNER JOIN "client" c ON c."key" = ca."client_key"
+        INNER JOIN "user" u ON u."username" = $1 AND u."key" = ca."user_key"
\ No newline at end of file
@@ -0,0 +1,6 @@
+INSERT INTO "group_member" ("group_key", "user_key")
+    WITH
+        t1 AS (SELECT g."key" FROM "group" g WHERE g."name" = $1),
+        t2 AS (SELECT u."key" FROM "user" u WHERE u."username" = $2)
+    SELECT t1."key", t2."key"
+        FROM t1, t2
\ No newline at end of file
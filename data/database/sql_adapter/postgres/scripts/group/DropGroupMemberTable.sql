@@ -0,0 +1 @@
+DROP TABLE "public"."group_member"
\ No newline at end of file
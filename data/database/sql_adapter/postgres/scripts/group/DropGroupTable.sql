@@ -0,0 +1 @@
+DROP TABLE "public"."group"
\ No newline at end of file
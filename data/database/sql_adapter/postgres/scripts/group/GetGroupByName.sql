@@ -0,0 +1,3 @@
+SELECT g."name", g."description", g."priority"
+    FROM "group" g
+    WHERE g."name" = $1
\ No newline at end of file
@@ -0,0 +1,3 @@
+SELECT g."name", g."description", g."priority"
+    FROM "group" g
+    ORDER BY g."name"
\ No newline at end of file
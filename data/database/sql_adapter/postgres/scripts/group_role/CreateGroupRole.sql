@@ -0,0 +1,6 @@
+INSERT INTO "group_role" ("client_key", "group_key", "role")
+    WITH
+        t1 AS (SELECT c."key" FROM "client" c WHERE c."uid" = $1),
+        t2 AS (SELECT g."key" FROM "group" g WHERE g."name" = $2)
+    SELECT t1."key", t2."key", $3
+        FROM t1, t2
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE "public"."group_role"
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE "public"."invitation_role" (
+	"invitation_key" INTEGER NOT NULL,
+	"client_key" SMALLINT NOT NULL,
+	"role" VARCHAR(15) NOT NULL,
+	CONSTRAINT "invitation_role_pk" PRIMARY KEY ("invitation_key", "client_key"),
+	CONSTRAINT "invitation_role_invitation_fk" FOREIGN KEY ("invitation_key") REFERENCES "invitation"("key") ON DELETE CASCADE,
+	CONSTRAINT "invitation_role_client_fk" FOREIGN KEY ("client_key") REFERENCES "client"("key") ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE "public"."invitation" (
+	"key" SERIAL,
+	"id" UUID NOT NULL,
+	"token_hash" BYTEA NOT NULL,
+	"email" VARCHAR(254) NOT NULL,
+	"rank" SMALLINT NOT NULL,
+	"expires_at" TIMESTAMP WITH TIME ZONE NOT NULL,
+	CONSTRAINT "invitation_pk" PRIMARY KEY ("key"),
+	CONSTRAINT "invitation_id_un" UNIQUE ("id"),
+	CONSTRAINT "invitation_token_hash_un" UNIQUE ("token_hash")
+);
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE FROM "invitation" i
+    WHERE i."id" = $1
\ No newline at end of file
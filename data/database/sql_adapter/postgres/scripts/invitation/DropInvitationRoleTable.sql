@@ -0,0 +1 @@
+DROP TABLE "public"."invitation_role"
\ No newline at end of file
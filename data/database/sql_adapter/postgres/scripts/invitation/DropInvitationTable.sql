@@ -0,0 +1 @@
+DROP TABLE "public"."invitation"
\ No newline at end of file
@@ -0,0 +1,3 @@
+SELECT i."id", i."token_hash", i."email", i."rank", i."expires_at"
+    FROM "invitation" i
+    WHERE i."id" = $1
\ No newline at end of file
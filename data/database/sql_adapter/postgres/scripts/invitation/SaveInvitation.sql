@@ -0,0 +1,2 @@
+INSERT INTO "invitation" ("id", "token_hash", "email", "rank", "expires_at")
+    VALUES ($1, $2, $3, $4, $5)
\ No newline at end of file
@@ -0,0 +1,6 @@
+INSERT INTO "invitation_role" ("invitation_key", "client_key", "role")
+    WITH
+        t1 AS (SELECT i."key" FROM "invitation" i WHERE i."id" = $1),
+        t2 AS (SELECT c."key" FROM "client" c WHERE c."uid" = $2)
+    SELECT t1."key", t2."key", $3
+        FROM t1, t2
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE "public"."login_throttle" (
+	"type" VARCHAR(10) NOT NULL,
+	"key" VARCHAR(64) NOT NULL,
+	"failed_attempts" INTEGER NOT NULL,
+	"last_failed_at" TIMESTAMP WITH TIME ZONE NOT NULL,
+	"locked_until" TIMESTAMP WITH TIME ZONE NOT NULL,
+	CONSTRAINT "login_throttle_pk" PRIMARY KEY ("type", "key")
+);
\ No newline at end of file
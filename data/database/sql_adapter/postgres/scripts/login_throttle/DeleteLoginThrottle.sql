@@ -0,0 +1,2 @@
+DELETE FROM "login_throttle" lt
+    WHERE lt."type" = $1 AND lt."key" = $2
\ No newline at end of file
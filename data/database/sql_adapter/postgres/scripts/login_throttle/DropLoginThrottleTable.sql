@@ -0,0 +1 @@
+DROP TABLE "public"."login_throttle"
\ No newline at end of file
@@ -0,0 +1,3 @@
+SELECT lt."type", lt."key", lt."failed_attempts", lt."last_failed_at", lt."locked_until"
+    FROM "login_throttle" lt
+    WHERE lt."type" = $1 AND lt."key" = $2
\ No newline at end of file
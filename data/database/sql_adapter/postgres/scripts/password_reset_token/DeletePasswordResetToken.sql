@@ -0,0 +1,2 @@
+DELETE FROM "password_reset_token" prt
+    WHERE prt."token_hash" = $1
\ No newline at end of file
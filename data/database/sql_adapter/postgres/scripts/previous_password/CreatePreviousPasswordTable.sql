@@ -0,0 +1,8 @@
+CREATE TABLE "public"."previous_password" (
+	"key" SERIAL,
+	"user_key" INTEGER NOT NULL,
+	"password_hash" BYTEA NOT NULL,
+	"replaced_at" TIMESTAMP WITH TIME ZONE NOT NULL,
+	CONSTRAINT "previous_password_pk" PRIMARY KEY ("key"),
+	CONSTRAINT "previous_password_user_fk" FOREIGN KEY ("user_key") REFERENCES "user"("key") ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,5 @@
+DELETE FROM "previous_password" pp
+    WHERE pp."user_key" IN (
+        SELECT u."key" FROM "user" u WHERE u."username" = $1
+    )
+    AND pp."replaced_at" < $2
\ No newline at end of file
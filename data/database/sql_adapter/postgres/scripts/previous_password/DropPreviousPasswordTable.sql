@@ -0,0 +1 @@
+DROP TABLE "public"."previous_password"
\ No newline at end of file
@@ -0,0 +1,5 @@
+INSERT INTO "previous_password" ("user_key", "password_hash", "replaced_at")
+	WITH
+		t1 AS (SELECT u."key" FROM "user" u WHERE u."username" = $1)
+	SELECT t1."key", $2, $3
+		FROM t1
\ No newline at end of file
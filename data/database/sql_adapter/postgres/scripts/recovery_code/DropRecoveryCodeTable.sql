@@ -0,0 +1 @@
+DROP TABLE "public"."recovery_code"
\ No newline at end of file
@@ -0,0 +1,5 @@
+INSERT INTO "recovery_code" ("user_key", "code_hash")
+	WITH
+		t1 AS (SELECT u."key" FROM "user" u WHERE u."username" = $1)
+	SELECT t1."key", $2
+		FROM t1
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE FROM "refresh_token" rt
+    WHERE rt."family_id" = $1
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE "public"."refresh_token"
\ No newline at end of file
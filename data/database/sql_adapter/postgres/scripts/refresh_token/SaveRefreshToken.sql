@@ -0,0 +1,6 @@
+INSERT INTO "refresh_token" ("token", "family_id", "client_key", "user_key", "rotated", "expires_at")
+    WITH
+        t1 AS (SELECT c."key" FROM "client" c WHERE c."uid" = $3),
+        t2 AS (SELECT u."key" FROM "user" u WHERE u."username" = $4)
+    SELECT $1, $2, t1."key", t2."key", $5, $6
+        FROM t1, t2
\ No newline at end of file
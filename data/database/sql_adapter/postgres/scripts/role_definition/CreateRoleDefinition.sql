@@ -0,0 +1,4 @@
+INSERT INTO "role_definition" ("client_key", "name", "description", "is_default")
+    SELECT c."key", $2, $3, $4
+        FROM "client" c
+    WHERE c."uid" = $1
\ No newline at end of file
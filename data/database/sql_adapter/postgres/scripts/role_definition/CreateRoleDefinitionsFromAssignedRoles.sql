@@ -0,0 +1,7 @@
+INSERT INTO "role_definition" ("client_key", "name", "description", "is_default")
+    SELECT r."client_key", r."role", '', FALSE
+        FROM (
+            SELECT ur."client_key", ur."role" FROM "user_role" ur
+            UNION
+            SELECT ir."client_key", ir."role" FROM "invitation_role" ir
+        ) r
\ No newline at end of file
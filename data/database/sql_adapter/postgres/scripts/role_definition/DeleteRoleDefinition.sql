@@ -0,0 +1,3 @@
+DELETE FROM "role_definition" rd
+    WHERE rd."client_key" IN (SELECT c."key" FROM "client" c WHERE c."uid" = $1) AND
+          rd."name" = $2
\ No newline at end of file
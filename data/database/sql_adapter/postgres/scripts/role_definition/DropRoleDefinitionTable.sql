@@ -0,0 +1 @@
+DROP TABLE "public"."role_definition"
\ No newline at end of file
@@ -0,0 +1,6 @@
+UPDATE "role_definition" SET
+    "name" = $3,
+    "description" = $4,
+    "is_default" = $5
+WHERE "client_key" IN (SELECT c."key" FROM "client" c WHERE c."uid" = $1) AND
+      "name" = $2
\ No newline at end of file
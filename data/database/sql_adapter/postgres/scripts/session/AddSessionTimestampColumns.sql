@@ -0,0 +1,3 @@
+ALTER TABLE "public"."session"
+	ADD COLUMN "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+	ADD COLUMN "last_used_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
\ No newline at end of file
@@ -0,0 +1,3 @@
+DELETE FROM "session" s
+    WHERE s."created_at" < $1 OR
+        s."last_used_at" < $2
\ No newline at end of file
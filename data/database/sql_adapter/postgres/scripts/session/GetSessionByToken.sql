@@ -1,4 +1,4 @@
-SELECT s."token", u."username", u."rank"
+SELECT s."token", u."username", u."rank", s."created_at", s."last_used_at"
     FROM "session" s
         INNER JOIN "user" u ON u."key" = s."user_key"
     WHERE s."token" = $1
\ No newline at end of file
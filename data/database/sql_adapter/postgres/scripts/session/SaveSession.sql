@@ -1,5 +1,5 @@
-INSERT INTO "session" ("token", "user_key")
+INSERT INTO "session" ("token", "user_key", "created_at", "last_used_at")
 	WITH
 		t1 AS (SELECT u."key" FROM "user" u WHERE u."username" = $2)
-	SELECT $1, t1."key"
+	SELECT $1, t1."key", $3, $4
 		FROM t1
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE "public"."user"
+	ADD COLUMN "password_updated_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
\ No newline at end of file
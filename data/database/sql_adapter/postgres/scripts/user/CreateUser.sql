@@ -1,2 +1,2 @@
-INSERT INTO "user" ("username", "rank", "password_hash")
-	VALUES ($1, $2, $3)
\ No newline at end of file
+INSERT INTO "user" ("username", "rank", "password_hash", "password_updated_at", "email", "display_name", "enabled")
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
\ No newline at end of file
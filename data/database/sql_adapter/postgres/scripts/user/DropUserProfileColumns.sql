@@ -0,0 +1,4 @@
+ALTER TABLE "public"."user"
+	DROP COLUMN "email",
+	DROP COLUMN "display_name",
+	DROP COLUMN "enabled";
\ No newline at end of file
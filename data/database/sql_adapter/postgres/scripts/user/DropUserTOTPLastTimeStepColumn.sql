@@ -0,0 +1,2 @@
+ALTER TABLE "public"."user"
+	DROP COLUMN "totp_last_time_step";
\ No newline at end of file
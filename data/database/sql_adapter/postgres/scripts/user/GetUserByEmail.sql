@@ -0,0 +1,4 @@
+SELECT u."username", u."rank", u."password_hash", u."password_updated_at", u."totp_secret", u."totp_enabled", u."totp_last_time_step",
+		u."email", u."display_name", u."enabled"
+	FROM "user" u
+	WHERE u."email" = $1
\ No newline at end of file
@@ -1,4 +0,0 @@
-SELECT u."username", u."rank", u."password_hash"
-	FROM "user" u
-	WHERE u."rank" < $1
-	ORDER BY u."username"
\ No newline at end of file
@@ -0,0 +1,14 @@
+SELECT u."username", u."rank", u."password_hash", u."password_updated_at", u."totp_secret", u."totp_enabled", u."totp_last_time_step",
+		u."email", u."display_name", u."enabled"
+	FROM "user" u
+	WHERE u."rank" < $1
+		AND LOWER(SUBSTR(u."username", 1, LENGTH($2::TEXT))) = LOWER($2::TEXT)
+		AND (NOT $4::BOOLEAN
+			OR ($3::BOOLEAN AND (u."rank" < $5 OR (u."rank" = $5 AND u."username" < $6)))
+			OR (NOT $3::BOOLEAN AND (u."rank" > $5 OR (u."rank" = $5 AND u."username" > $6))))
+	ORDER BY
+		CASE WHEN $3::BOOLEAN THEN u."rank" END DESC,
+		CASE WHEN $3::BOOLEAN THEN u."username" END DESC,
+		u."rank",
+		u."username"
+	LIMIT $7
\ No newline at end of file
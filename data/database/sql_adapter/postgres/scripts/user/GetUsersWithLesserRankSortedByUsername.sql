@@ -0,0 +1,12 @@
+SELECT u."username", u."rank", u."password_hash", u."password_updated_at", u."totp_secret", u."totp_enabled", u."totp_last_time_step",
+		u."email", u."display_name", u."enabled"
+	FROM "user" u
+	WHERE u."rank" < $1
+		AND LOWER(SUBSTR(u."username", 1, LENGTH($2::TEXT))) = LOWER($2::TEXT)
+		AND (NOT $4::BOOLEAN
+			OR ($3::BOOLEAN AND u."username" < $5)
+			OR (NOT $3::BOOLEAN AND u."username" > $5))
+	ORDER BY
+		CASE WHEN $3::BOOLEAN THEN u."username" END DESC,
+		u."username"
+	LIMIT $6
\ No newline at end of file
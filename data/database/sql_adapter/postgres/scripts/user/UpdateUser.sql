@@ -1,3 +1,6 @@
 UPDATE "user" SET
-    "rank" = $2
+    "rank" = $2,
+    "email" = $3,
+    "display_name" = $4,
+    "enabled" = $5
 WHERE "username" = $1
\ No newline at end of file
@@ -0,0 +1,4 @@
+UPDATE "user" SET
+    "totp_secret" = $2,
+    "totp_enabled" = $3
+WHERE "username" = $1
\ No newline at end of file
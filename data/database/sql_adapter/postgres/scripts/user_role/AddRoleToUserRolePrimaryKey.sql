@@ -0,0 +1,3 @@
+ALTER TABLE "public"."user_role"
+    DROP CONSTRAINT "user_role_pk",
+    ADD CONSTRAINT "user_role_pk" PRIMARY KEY ("client_key", "user_key", "role")
\ No newline at end of file
@@ -0,0 +1,4 @@
+SELECT COUNT(*)
+    FROM "user_role" ur
+        INNER JOIN "client" c on c."uid" = $1 AND c."key" = ur."client_key"
+    WHERE ur."role" = $2
\ No newline at end of file
@@ -1,5 +1,5 @@
-SELECT c."uid", u."username", ur."role"
+SELECT COUNT(DISTINCT ur."user_key")
     FROM "user_role" ur
         INNER JOIN "client" c on c."uid" = $1 AND c."key" = ur."client_key"
         INNER JOIN "user" u on u."rank" < $2 AND u."key" = ur."user_key"
-    ORDER BY u."username"
\ No newline at end of file
+    WHERE LOWER(SUBSTR(u."username", 1, LENGTH($3::TEXT))) = LOWER($3::TEXT)
\ No newline at end of file
@@ -0,0 +1,5 @@
+DELETE FROM "user_role" ur
+    WHERE EXISTS (
+        SELECT 1 FROM "user_role" ur2
+            WHERE ur2."client_key" = ur."client_key" AND ur2."user_key" = ur."user_key" AND ur2."role" < ur."role"
+    )
\ No newline at end of file
@@ -0,0 +1,22 @@
+SELECT c."uid", p."username", ur."role"
+    FROM (
+        SELECT ur."client_key", ur."user_key", u."username"
+            FROM "user_role" ur
+                INNER JOIN "client" c on c."uid" = $1 AND c."key" = ur."client_key"
+                INNER JOIN "user" u on u."rank" < $2 AND u."key" = ur."user_key"
+            WHERE LOWER(SUBSTR(u."username", 1, LENGTH($3::TEXT))) = LOWER($3::TEXT)
+                AND (NOT $5::BOOLEAN
+                    OR ($4::BOOLEAN AND u."username" < $6)
+                    OR (NOT $4::BOOLEAN AND u."username" > $6))
+            GROUP BY ur."client_key", ur."user_key", u."username"
+            ORDER BY
+                CASE WHEN $4::BOOLEAN THEN u."username" END DESC,
+                u."username"
+            LIMIT $7
+    ) p
+        INNER JOIN "client" c on c."key" = p."client_key"
+        INNER JOIN "user_role" ur on ur."client_key" = p."client_key" AND ur."user_key" = p."user_key"
+    ORDER BY
+        CASE WHEN $4::BOOLEAN THEN p."username" END DESC,
+        p."username",
+        ur."role"
\ No newline at end of file
@@ -0,0 +1,129 @@
+package sqladapter
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+)
+
+// CreatePreviousPasswordTable creates the previous password table in the database.
+// Returns any errors.
+func (crud *SQLCRUD) CreatePreviousPasswordTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreatePreviousPasswordTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create previous password table script", err)
+	}
+
+	return err
+}
+
+// DropPreviousPasswordTable drops the previous password table from the database.
+// Returns any errors.
+func (crud *SQLCRUD) DropPreviousPasswordTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropPreviousPasswordTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop previous password table script", err)
+	}
+
+	return err
+}
+
+func (crud *SQLCRUD) SavePreviousPassword(password *models.PreviousPassword) error {
+	//validate the previous password model
+	verr := password.Validate()
+	if verr != models.ValidatePreviousPasswordValid {
+		return errors.New(fmt.Sprint("error validating previous password model:", verr))
+	}
+
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.SavePreviousPasswordScript(),
+		password.Username, password.PasswordHash, password.ReplacedAt,
+	)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing save previous password statement", err)
+	}
+
+	//nothing is inserted if the user does not exist
+	count, _ := res.RowsAffected()
+	if count == 0 {
+		return errors.New("previous password user does not exist")
+	}
+
+	return nil
+}
+
+func (crud *SQLCRUD) GetLatestUserPreviousPasswords(username string, limit int) ([]*models.PreviousPassword, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetLatestUserPreviousPasswordsScript(), username, limit)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get latest user previous passwords query", err)
+	}
+	defer rows.Close()
+
+	//read the data
+	passwords := []*models.PreviousPassword{}
+	for {
+		password, err := readPreviousPasswordData(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		if password == nil {
+			break
+		}
+		passwords = append(passwords, password)
+	}
+	return passwords, nil
+}
+
+func (crud *SQLCRUD) DeleteUserPreviousPasswordsReplacedBefore(username string, before time.Time) error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DeleteUserPreviousPasswordsReplacedBeforeScript(), username, before)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing delete user previous passwords replaced before statement", err)
+	}
+
+	return nil
+}
+
+func readPreviousPasswordData(rows *sql.Rows) (*models.PreviousPassword, error) {
+	//check if there was a result
+	if !rows.Next() {
+		err := rows.Err()
+		if err != nil {
+			return nil, common.ChainError("error preparing next row", err)
+		}
+
+		//return no results
+		return nil, nil
+	}
+
+	password := &models.PreviousPassword{}
+
+	//get the result
+	err := rows.Scan(&password.Username, &password.PasswordHash, &password.ReplacedAt)
+	if err != nil {
+		return nil, common.ChainError("error reading row", err)
+	}
+
+	//normalize the timestamp to UTC
+	password.ReplacedAt = password.ReplacedAt.UTC()
+
+	return password, nil
+}
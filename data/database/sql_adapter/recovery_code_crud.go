@@ -0,0 +1,88 @@
+package sqladapter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+)
+
+// CreateRecoveryCodeTable creates the recovery code table in the database.
+// Returns any errors.
+func (crud *SQLCRUD) CreateRecoveryCodeTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateRecoveryCodeTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create recovery code table script", err)
+	}
+
+	return err
+}
+
+// DropRecoveryCodeTable drops the recovery code table from the database.
+// Returns any errors.
+func (crud *SQLCRUD) DropRecoveryCodeTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropRecoveryCodeTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop recovery code table script", err)
+	}
+
+	return err
+}
+
+func (crud *SQLCRUD) SaveRecoveryCode(code *models.RecoveryCode) error {
+	//validate the recovery code model
+	verr := code.Validate()
+	if verr != models.ValidateRecoveryCodeValid {
+		return errors.New(fmt.Sprint("error validating recovery code model:", verr))
+	}
+
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.SaveRecoveryCodeScript(),
+		code.Username, code.CodeHash,
+	)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing save recovery code statement", err)
+	}
+
+	//nothing is inserted if the user does not exist
+	count, _ := res.RowsAffected()
+	if count == 0 {
+		return errors.New("recovery code user does not exist")
+	}
+
+	return nil
+}
+
+func (crud *SQLCRUD) DeleteRecoveryCode(username string, hash []byte) (bool, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DeleteRecoveryCodeScript(), username, hash)
+	cancel()
+
+	if err != nil {
+		return false, common.ChainError("error executing delete recovery code statement", err)
+	}
+
+	count, _ := res.RowsAffected()
+	return count > 0, nil
+}
+
+func (crud *SQLCRUD) DeleteAllUserRecoveryCodes(username string) error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DeleteAllUserRecoveryCodesScript(), username)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing delete all user recovery codes statement", err)
+	}
+
+	return nil
+}
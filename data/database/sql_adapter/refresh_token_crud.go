@@ -0,0 +1,132 @@
+package sqladapter
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+// CreateRefreshTokenTable creates the refresh token table in the database.
+// Returns any errors.
+func (crud *SQLCRUD) CreateRefreshTokenTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateRefreshTokenTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create refresh token table script", err)
+	}
+
+	return err
+}
+
+// DropRefreshTokenTable drops the refresh token table from the database.
+// Returns any errors.
+func (crud *SQLCRUD) DropRefreshTokenTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropRefreshTokenTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop refresh token table script", err)
+	}
+
+	return err
+}
+
+func (crud *SQLCRUD) SaveRefreshToken(token *models.RefreshToken) error {
+	//validate the refresh token model
+	verr := token.Validate()
+	if verr != models.ValidateRefreshTokenValid {
+		return errors.New(fmt.Sprint("error validating refresh token model:", verr))
+	}
+
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.SaveRefreshTokenScript(),
+		token.Token, token.FamilyID, token.ClientUID, token.Username, token.Rotated, token.ExpiresAt,
+	)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing save refresh token statement", err)
+	}
+
+	//nothing is inserted if the client or user does not exist
+	count, _ := res.RowsAffected()
+	if count == 0 {
+		return errors.New("refresh token client or user does not exist")
+	}
+
+	return nil
+}
+
+func (crud *SQLCRUD) GetRefreshToken(token uuid.UUID) (*models.RefreshToken, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetRefreshTokenScript(), token)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get refresh token query", err)
+	}
+	defer rows.Close()
+
+	return readRefreshTokenData(rows)
+}
+
+func (crud *SQLCRUD) RotateRefreshToken(token uuid.UUID) (bool, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.RotateRefreshTokenScript(), token)
+	cancel()
+
+	if err != nil {
+		return false, common.ChainError("error executing rotate refresh token statement", err)
+	}
+
+	count, _ := res.RowsAffected()
+	return count > 0, nil
+}
+
+func (crud *SQLCRUD) DeleteRefreshTokenFamily(familyID uuid.UUID) error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DeleteRefreshTokenFamilyScript(), familyID)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing delete refresh token family statement", err)
+	}
+
+	return nil
+}
+
+func readRefreshTokenData(rows *sql.Rows) (*models.RefreshToken, error) {
+	//check if there was a result
+	if !rows.Next() {
+		err := rows.Err()
+		if err != nil {
+			return nil, common.ChainError("error preparing next row", err)
+		}
+
+		//return no results
+		return nil, nil
+	}
+
+	token := &models.RefreshToken{}
+
+	//get the result
+	err := rows.Scan(
+		&token.Token, &token.FamilyID, &token.ClientUID, &token.Username, &token.Rotated, &token.ExpiresAt,
+	)
+	if err != nil {
+		return nil, common.ChainError("error reading row", err)
+	}
+
+	//normalize the timestamp to UTC
+	token.ExpiresAt = token.ExpiresAt.UTC()
+
+	return token, nil
+}
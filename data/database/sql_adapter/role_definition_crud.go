@@ -0,0 +1,181 @@
+package sqladapter
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+// CreateRoleDefinitionTable creates the role definition table in the database.
+// Returns any errors.
+func (crud *SQLCRUD) CreateRoleDefinitionTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateRoleDefinitionTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create role definition table script", err)
+	}
+
+	return err
+}
+
+// DropRoleDefinitionTable drops the role definition table from the database.
+// Returns any errors.
+func (crud *SQLCRUD) DropRoleDefinitionTable() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropRoleDefinitionTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop role definition table script", err)
+	}
+
+	return err
+}
+
+// CreateRoleDefinitionsFromAssignedRoles creates a role definition for every role already given to a user or invitation, so existing assignments stay valid.
+// Returns any errors.
+func (crud *SQLCRUD) CreateRoleDefinitionsFromAssignedRoles() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateRoleDefinitionsFromAssignedRolesScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create role definitions from assigned roles script", err)
+	}
+
+	return err
+}
+
+func (crud *SQLCRUD) CreateRoleDefinition(definition *models.RoleDefinition) error {
+	//validate the role definition model
+	verr := definition.Validate()
+	if verr != models.ValidateRoleDefinitionValid {
+		return errors.New(fmt.Sprint("error validating role definition model:", verr))
+	}
+
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateRoleDefinitionScript(),
+		definition.ClientUID, definition.Name, definition.Description, definition.IsDefault,
+	)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create role definition statement", err)
+	}
+
+	//nothing is inserted if the client does not exist
+	count, _ := res.RowsAffected()
+	if count == 0 {
+		return errors.New("role definition client does not exist")
+	}
+
+	return nil
+}
+
+func (crud *SQLCRUD) GetRoleDefinitionsByClientUID(clientUID uuid.UUID) ([]*models.RoleDefinition, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetRoleDefinitionsByClientUIDScript(), clientUID)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get role definitions by client uid query", err)
+	}
+	defer rows.Close()
+
+	//read the data
+	definitions := []*models.RoleDefinition{}
+	for {
+		definition, err := readRoleDefinitionData(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		if definition == nil {
+			break
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, nil
+}
+
+func (crud *SQLCRUD) GetRoleDefinitionByClientUIDAndName(clientUID uuid.UUID, name string) (*models.RoleDefinition, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetRoleDefinitionByClientUIDAndNameScript(),
+		clientUID, name,
+	)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get role definition by client uid and name query", err)
+	}
+	defer rows.Close()
+
+	return readRoleDefinitionData(rows)
+}
+
+func (crud *SQLCRUD) UpdateRoleDefinition(name string, definition *models.RoleDefinition) (bool, error) {
+	//validate the role definition model
+	verr := definition.Validate()
+	if verr != models.ValidateRoleDefinitionValid {
+		return false, errors.New(fmt.Sprint("error validating role definition model:", verr))
+	}
+
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.UpdateRoleDefinitionScript(),
+		definition.ClientUID, name, definition.Name, definition.Description, definition.IsDefault,
+	)
+	cancel()
+
+	if err != nil {
+		return false, common.ChainError("error executing update role definition statement", err)
+	}
+
+	count, _ := res.RowsAffected()
+	return count > 0, nil
+}
+
+func (crud *SQLCRUD) DeleteRoleDefinition(clientUID uuid.UUID, name string) (bool, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DeleteRoleDefinitionScript(),
+		clientUID, name,
+	)
+	cancel()
+
+	if err != nil {
+		return false, common.ChainError("error executing delete role definition statement", err)
+	}
+
+	count, _ := res.RowsAffected()
+	return count > 0, nil
+}
+
+func readRoleDefinitionData(rows *sql.Rows) (*models.RoleDefinition, error) {
+	//check if there was a result
+	if !rows.Next() {
+		err := rows.Err()
+		if err != nil {
+			return nil, common.ChainError("error preparing next row", err)
+		}
+
+		//return no results
+		return nil, nil
+	}
+
+	//get the result
+	definition := &models.RoleDefinition{}
+	err := rows.Scan(
+		&definition.ClientUID, &definition.Name, &definition.Description, &definition.IsDefault,
+	)
+	if err != nil {
+		return nil, common.ChainError("error reading row", err)
+	}
+
+	return definition, nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"io/ioutil"
 	"log"
 	"os"
@@ -17,12 +18,16 @@ type tmplData struct {
 }
 
 func main() {
+	//parse flags
+	driver := flag.String("driver", "postgres", "The name of the driver whose scripts will be compiled")
+	flag.Parse()
+
 	err := config.InitConfig(".")
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	inDir := config.GetAppRoot("data/database/sql_adapter/postgres/scripts")
+	inDir := config.GetAppRoot("data/database/sql_adapter", *driver, "scripts")
 
 	//load the template
 	tmpl := template.Must(template.ParseFiles(config.GetAppRoot("data/database/sql_adapter/script_compiler/script_repository.go.tmpl")))
@@ -65,8 +70,14 @@ func createDataObjects(dir string, data *[]tmplData) {
 
 			*data = append(*data, tmplData{
 				Name:   strings.SplitN(path.Base(file.Name()), ".", 2)[0],
-				Script: string(script),
+				Script: escapeBackticks(string(script)),
 			})
 		}
 	}
 }
+
+// escapeBackticks splices any backticks (such as mysql's quoted identifiers) into the script as interpreted string literals,
+// since they cannot appear inside the raw string literal the template wraps the script in.
+func escapeBackticks(script string) string {
+	return strings.ReplaceAll(script, "`", "` + \"`\" + `")
+}
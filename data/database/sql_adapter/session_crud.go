@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/mhogar/amber/common"
 	"github.com/mhogar/amber/models"
@@ -39,6 +40,34 @@ func (crud *SQLCRUD) DropSessionTable() error {
 	return err
 }
 
+// AddSessionTimestampColumns adds the created and last used timestamp columns to the session table.
+// Returns any errors.
+func (crud *SQLCRUD) AddSessionTimestampColumns() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.AddSessionTimestampColumnsScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing add session timestamp columns script", err)
+	}
+
+	return err
+}
+
+// DropSessionTimestampColumns drops the created and last used timestamp columns from the session table.
+// Returns any errors.
+func (crud *SQLCRUD) DropSessionTimestampColumns() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropSessionTimestampColumnsScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop session timestamp columns script", err)
+	}
+
+	return err
+}
+
 func (crud *SQLCRUD) SaveSession(session *models.Session) error {
 	//validate the session model
 	verr := session.Validate()
@@ -47,13 +76,21 @@ func (crud *SQLCRUD) SaveSession(session *models.Session) error {
 	}
 
 	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
-	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.SaveSessionScript(), session.Token, session.Username)
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.SaveSessionScript(),
+		session.Token, session.Username, session.CreatedAt, session.LastUsedAt,
+	)
 	cancel()
 
 	if err != nil {
 		return common.ChainError("error executing save session statement", err)
 	}
 
+	//nothing is inserted if the user does not exist
+	count, _ := res.RowsAffected()
+	if count == 0 {
+		return errors.New("session user does not exist")
+	}
+
 	return nil
 }
 
@@ -70,6 +107,19 @@ func (crud *SQLCRUD) GetSessionByToken(token uuid.UUID) (*models.Session, error)
 	return readSessionData(rows)
 }
 
+func (crud *SQLCRUD) UpdateSessionLastUsedAt(token uuid.UUID, lastUsedAt time.Time) (bool, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.UpdateSessionLastUsedAtScript(), token, lastUsedAt)
+	cancel()
+
+	if err != nil {
+		return false, common.ChainError("error executing update session last used at statement", err)
+	}
+
+	count, _ := res.RowsAffected()
+	return count > 0, nil
+}
+
 func (crud *SQLCRUD) DeleteSession(token uuid.UUID) (bool, error) {
 	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
 	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DeleteSessionScript(), token)
@@ -107,6 +157,18 @@ func (crud *SQLCRUD) DeleteAllOtherUserSessions(username string, token uuid.UUID
 	return nil
 }
 
+func (crud *SQLCRUD) DeleteExpiredSessions(createdBefore time.Time, lastUsedBefore time.Time) error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DeleteExpiredSessionsScript(), createdBefore, lastUsedBefore)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing delete expired sessions statement", err)
+	}
+
+	return nil
+}
+
 func readSessionData(rows *sql.Rows) (*models.Session, error) {
 	//check if there was a result
 	if !rows.Next() {
@@ -123,11 +185,15 @@ func readSessionData(rows *sql.Rows) (*models.Session, error) {
 
 	//get the result
 	err := rows.Scan(
-		&session.Token, &session.Username, &session.Rank,
+		&session.Token, &session.Username, &session.Rank, &session.CreatedAt, &session.LastUsedAt,
 	)
 	if err != nil {
 		return nil, common.ChainError("error reading row", err)
 	}
 
+	//normalize the timestamps to UTC
+	session.CreatedAt = session.CreatedAt.UTC()
+	session.LastUsedAt = session.LastUsedAt.UTC()
+
 	return session, nil
 }
@@ -7,27 +7,48 @@ type SQLScriptRepository interface {
 	MigrationScriptRepository
 	UserScriptRepository
 	UserRoleScriptRepository
+	RoleDefinitionScriptRepository
+	AdminRoleScriptRepository
+	ClientAdminScriptRepository
+	GroupScriptRepository
+	GroupRoleScriptRepository
+	AuthorizationCodeScriptRepository
+	RefreshTokenScriptRepository
+	RecoveryCodeScriptRepository
+	PreviousPasswordScriptRepository
+	PasswordResetTokenScriptRepository
+	InvitationScriptRepository
+	LoginThrottleScriptRepository
+	AuditEventScriptRepository
 }
 
 // SessionScriptRepository is an interface for fetching session sql scripts.
 type SessionScriptRepository interface {
 	CreateSessionTableScript() string
 	DropSessionTableScript() string
+	AddSessionTimestampColumnsScript() string
+	DropSessionTimestampColumnsScript() string
 	SaveSessionScript() string
 	GetSessionByTokenScript() string
+	UpdateSessionLastUsedAtScript() string
 	DeleteSessionScript() string
 	DeleteAllUserSessionsScript() string
 	DeleteAllOtherUserSessionsScript() string
+	DeleteExpiredSessionsScript() string
 }
 
 // ClientScriptRepository is an interface for fetching client sql scripts.
 type ClientScriptRepository interface {
 	CreateClientTableScript() string
 	DropClientTableScript() string
+	AddClientSecretHashColumnScript() string
+	DropClientSecretHashColumnScript() string
 	CreateClientScript() string
 	GetClientsScript() string
+	CountClientsScript() string
 	GetClientByUIDScript() string
 	UpdateClientScript() string
+	UpdateClientSecretHashScript() string
 	DeleteClientScript() string
 }
 
@@ -44,11 +65,24 @@ type MigrationScriptRepository interface {
 type UserScriptRepository interface {
 	CreateUserTableScript() string
 	DropUserTableScript() string
+	AddUserTOTPColumnsScript() string
+	DropUserTOTPColumnsScript() string
+	AddUserProfileColumnsScript() string
+	DropUserProfileColumnsScript() string
+	AddUserPasswordUpdatedAtColumnScript() string
+	DropUserPasswordUpdatedAtColumnScript() string
+	AddUserTOTPLastTimeStepColumnScript() string
+	DropUserTOTPLastTimeStepColumnScript() string
 	CreateUserScript() string
-	GetUsersWithLesserRankScript() string
+	GetUsersWithLesserRankSortedByUsernameScript() string
+	GetUsersWithLesserRankSortedByRankScript() string
+	CountUsersWithLesserRankScript() string
 	GetUserByUsernameScript() string
+	GetUserByEmailScript() string
 	UpdateUserScript() string
 	UpdateUserPasswordScript() string
+	UpdateUserTOTPScript() string
+	UpdateUserTOTPLastTimeStepScript() string
 	DeleteUserScript() string
 }
 
@@ -56,9 +90,166 @@ type UserScriptRepository interface {
 type UserRoleScriptRepository interface {
 	CreateUserRoleTableScript() string
 	DropUserRoleTableScript() string
+	AddRoleToUserRolePrimaryKeyScript() string
+	DeleteAllButFirstUserRolesScript() string
+	RemoveRoleFromUserRolePrimaryKeyScript() string
 	CreateUserRoleScript() string
-	GetUserRolesWithLesserRankByClientUIDScript() string
+	GetUserRolesWithLesserRankByClientUIDSortedByUsernameScript() string
+	GetUserRolesWithLesserRankByClientUIDSortedByRoleScript() string
+	CountUserRolesWithLesserRankByClientUIDScript() string
 	GetUserRoleByClientUIDAndUsernameScript() string
-	UpdateUserRoleScript() string
+	CountUserRolesByClientUIDAndRoleScript() string
+	RenameUserRolesScript() string
 	DeleteUserRoleScript() string
 }
+
+// RoleDefinitionScriptRepository is an interface for fetching role definition sql scripts.
+type RoleDefinitionScriptRepository interface {
+	CreateRoleDefinitionTableScript() string
+	DropRoleDefinitionTableScript() string
+	CreateRoleDefinitionsFromAssignedRolesScript() string
+	CreateRoleDefinitionScript() string
+	GetRoleDefinitionsByClientUIDScript() string
+	GetRoleDefinitionByClientUIDAndNameScript() string
+	UpdateRoleDefinitionScript() string
+	DeleteRoleDefinitionScript() string
+}
+
+// AdminRoleScriptRepository is an interface for fetching admin role sql scripts.
+type AdminRoleScriptRepository interface {
+	CreateAdminRoleTableScript() string
+	DropAdminRoleTableScript() string
+	CreateUserAdminRoleTableScript() string
+	DropUserAdminRoleTableScript() string
+	CreateAdminRoleScript() string
+	GetAdminRolesScript() string
+	GetAdminRoleByNameScript() string
+	UpdateAdminRoleScript() string
+	DeleteAdminRoleScript() string
+	GetAdminRolesByUsernameScript() string
+	GetAdminRoleUsernamesScript() string
+	CreateUserAdminRoleScript() string
+	DeleteUserAdminRolesScript() string
+}
+
+// ClientAdminScriptRepository is an interface for fetching client admin sql scripts.
+type ClientAdminScriptRepository interface {
+	CreateClientAdminTableScript() string
+	DropClientAdminTableScript() string
+	CreateClientAdminScript() string
+	GetClientAdminsByClientUIDScript() string
+	GetClientAdminsByUsernameScript() string
+	GetClientAdminByClientUIDAndUsernameScript() string
+	DeleteClientAdminScript() string
+}
+
+// GroupScriptRepository is an interface for fetching group sql scripts.
+type GroupScriptRepository interface {
+	CreateGroupTableScript() string
+	DropGroupTableScript() string
+	CreateGroupMemberTableScript() string
+	DropGroupMemberTableScript() string
+	CreateGroupScript() string
+	GetGroupsScript() string
+	GetGroupByNameScript() string
+	UpdateGroupScript() string
+	DeleteGroupScript() string
+	GetGroupsByUsernameScript() string
+	GetGroupMemberUsernamesScript() string
+	CreateGroupMemberScript() string
+	DeleteGroupMemberScript() string
+}
+
+// GroupRoleScriptRepository is an interface for fetching group-role sql scripts.
+type GroupRoleScriptRepository interface {
+	CreateGroupRoleTableScript() string
+	DropGroupRoleTableScript() string
+	CreateGroupRoleScript() string
+	GetGroupRolesByClientUIDScript() string
+	GetGroupRoleByClientUIDAndGroupNameScript() string
+	CountGroupRolesByClientUIDAndRoleScript() string
+	RenameGroupRolesScript() string
+	DeleteGroupRoleScript() string
+}
+
+// AuthorizationCodeScriptRepository is an interface for fetching authorization code sql scripts.
+type AuthorizationCodeScriptRepository interface {
+	CreateAuthorizationCodeTableScript() string
+	DropAuthorizationCodeTableScript() string
+	AddAuthorizationCodeRedirectURIColumnScript() string
+	DropAuthorizationCodeRedirectURIColumnScript() string
+	SaveAuthorizationCodeScript() string
+	GetAuthorizationCodeScript() string
+	DeleteAuthorizationCodeScript() string
+}
+
+// RefreshTokenScriptRepository is an interface for fetching refresh token sql scripts.
+type RefreshTokenScriptRepository interface {
+	CreateRefreshTokenTableScript() string
+	DropRefreshTokenTableScript() string
+	SaveRefreshTokenScript() string
+	GetRefreshTokenScript() string
+	RotateRefreshTokenScript() string
+	DeleteRefreshTokenFamilyScript() string
+}
+
+// RecoveryCodeScriptRepository is an interface for fetching recovery code sql scripts.
+type RecoveryCodeScriptRepository interface {
+	CreateRecoveryCodeTableScript() string
+	DropRecoveryCodeTableScript() string
+	SaveRecoveryCodeScript() string
+	DeleteRecoveryCodeScript() string
+	DeleteAllUserRecoveryCodesScript() string
+}
+
+// PreviousPasswordScriptRepository is an interface for fetching previous password sql scripts.
+type PreviousPasswordScriptRepository interface {
+	CreatePreviousPasswordTableScript() string
+	DropPreviousPasswordTableScript() string
+	SavePreviousPasswordScript() string
+	GetLatestUserPreviousPasswordsScript() string
+	DeleteUserPreviousPasswordsReplacedBeforeScript() string
+}
+
+// PasswordResetTokenScriptRepository is an interface for fetching password reset token sql scripts.
+type PasswordResetTokenScriptRepository interface {
+	CreatePasswordResetTokenTableScript() string
+	DropPasswordResetTokenTableScript() string
+	SavePasswordResetTokenScript() string
+	GetPasswordResetTokenScript() string
+	DeletePasswordResetTokenScript() string
+	DeleteAllUserPasswordResetTokensScript() string
+}
+
+// InvitationScriptRepository is an interface for fetching invitation sql scripts.
+type InvitationScriptRepository interface {
+	CreateInvitationTableScript() string
+	DropInvitationTableScript() string
+	CreateInvitationRoleTableScript() string
+	DropInvitationRoleTableScript() string
+	SaveInvitationScript() string
+	SaveInvitationRoleScript() string
+	GetInvitationByIDScript() string
+	GetInvitationByTokenHashScript() string
+	GetInvitationRolesScript() string
+	RenameInvitationRolesScript() string
+	DeleteInvitationScript() string
+}
+
+// LoginThrottleScriptRepository is an interface for fetching login throttle sql scripts.
+type LoginThrottleScriptRepository interface {
+	CreateLoginThrottleTableScript() string
+	DropLoginThrottleTableScript() string
+	SaveLoginThrottleScript() string
+	GetLoginThrottleScript() string
+	GetLockedLoginThrottlesScript() string
+	DeleteLoginThrottleScript() string
+}
+
+// AuditEventScriptRepository is an interface for fetching audit event sql scripts.
+type AuditEventScriptRepository interface {
+	CreateAuditEventTableScript() string
+	DropAuditEventTableScript() string
+	SaveAuditEventScript() string
+	GetAuditEventsScript() string
+}
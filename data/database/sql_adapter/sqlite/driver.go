@@ -0,0 +1,31 @@
+package sqlite
+
+import (
+	"database/sql"
+
+	"github.com/mhogar/amber/data/database/sql_adapter/sqlite/scripts"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// driverName is the name the sqlite driver is registered under.
+// It is separate from the default "sqlite3" name so every connection can be set up with foreign keys enabled.
+const driverName = "amber_sqlite3"
+
+func init() {
+	sql.Register(driverName, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			//sqlite does not enforce foreign keys (or their cascades) unless enabled for each connection
+			_, err := conn.Exec("PRAGMA foreign_keys = ON", nil)
+			return err
+		},
+	})
+}
+
+type Driver struct {
+	scripts.ScriptRepository
+}
+
+func (Driver) GetDriverName() string {
+	return driverName
+}
@@ -0,0 +1,6 @@
+WITH
+    t1 AS (SELECT u."key" FROM "user" u WHERE u."username" = ?1),
+    t2 AS (SELECT ar."key" FROM "admin_role" ar WHERE ar."name" = ?2)
+INSERT INTO "user_admin_role" ("user_key", "admin_role_key")
+    SELECT t1."key", t2."key"
+        FROM t1, t2
\ No newline at end of file
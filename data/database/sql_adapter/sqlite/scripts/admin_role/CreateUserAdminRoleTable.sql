@@ -0,0 +1,7 @@
+CREATE TABLE "user_admin_role" (
+	"user_key" INTEGER NOT NULL,
+	"admin_role_key" INTEGER NOT NULL,
+	CONSTRAINT "user_admin_role_pk" PRIMARY KEY ("user_key", "admin_role_key"),
+	CONSTRAINT "user_admin_role_user_fk" FOREIGN KEY ("user_key") REFERENCES "user"("key") ON DELETE CASCADE,
+	CONSTRAINT "user_admin_role_admin_role_fk" FOREIGN KEY ("admin_role_key") REFERENCES "admin_role"("key") ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE "admin_role"
\ No newline at end of file
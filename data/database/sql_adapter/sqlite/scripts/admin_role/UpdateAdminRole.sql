@@ -0,0 +1,4 @@
+UPDATE "admin_role" SET
+    "description" = ?2,
+    "permissions" = ?3
+WHERE "name" = ?1
\ No newline at end of file
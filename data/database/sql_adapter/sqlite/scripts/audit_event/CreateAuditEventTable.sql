@@ -0,0 +1,11 @@
+CREATE TABLE "audit_event" (
+	"id" TEXT NOT NULL,
+	"actor" VARCHAR(64) NOT NULL,
+	"action" VARCHAR(32) NOT NULL,
+	"target" VARCHAR(128) NOT NULL,
+	"ip_address" VARCHAR(64) NOT NULL,
+	"timestamp" TIMESTAMP NOT NULL,
+	CONSTRAINT "audit_event_pk" PRIMARY KEY ("id")
+);
+
+CREATE INDEX "audit_event_timestamp_idx" ON "audit_event" ("timestamp");
\ No newline at end of file
@@ -0,0 +1,2 @@
+INSERT INTO "audit_event" ("id", "actor", "action", "target", "ip_address", "timestamp")
+    VALUES (?1, ?2, ?3, ?4, ?5, ?6)
\ No newline at end of file
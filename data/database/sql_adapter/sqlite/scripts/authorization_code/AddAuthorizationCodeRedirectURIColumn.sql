@@ -0,0 +1,2 @@
+ALTER TABLE "authorization_code"
+	ADD COLUMN "redirect_uri" VARCHAR(100) NOT NULL DEFAULT '';
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE FROM "authorization_code"
+    WHERE "code" = ?1
\ No newline at end of file
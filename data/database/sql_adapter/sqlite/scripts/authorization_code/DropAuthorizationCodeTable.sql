@@ -0,0 +1 @@
+DROP TABLE "authorization_code"
\ No newline at end of file
@@ -0,0 +1,6 @@
+WITH
+    t1 AS (SELECT c."key" FROM "client" c WHERE c."uid" = ?2),
+    t2 AS (SELECT u."key" FROM "user" u WHERE u."username" = ?3)
+INSERT INTO "authorization_code" ("code", "client_key", "user_key", "code_challenge", "nonce", "redirect_uri", "expires_at")
+    SELECT ?1, t1."key", t2."key", ?4, ?5, ?6, ?7
+        FROM t1, t2
\ No newline at end of file
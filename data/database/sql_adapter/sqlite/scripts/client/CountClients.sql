@@ -0,0 +1,3 @@
+SELECT COUNT(*)
+	FROM "client" c
+	WHERE LOWER(SUBSTR(c."name", 1, LENGTH(?1))) = LOWER(?1)
\ No newline at end of file
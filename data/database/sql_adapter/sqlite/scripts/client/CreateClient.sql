@@ -0,0 +1,2 @@
+INSERT INTO "client" ("uid", "name", "redirect_url", "token_type", "key_uri")
+	VALUES (?1, ?2, ?3, ?4, ?5)
\ No newline at end of file
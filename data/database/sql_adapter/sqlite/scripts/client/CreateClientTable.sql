@@ -0,0 +1,10 @@
+CREATE TABLE "client" (
+	"key" INTEGER NOT NULL,
+	"uid" TEXT NOT NULL,
+	"name" VARCHAR(30) NOT NULL,
+	"redirect_url" VARCHAR(100) NOT NULL,
+	"token_type" INTEGER NOT NULL,
+	"key_uri" VARCHAR(100) NOT NULL,
+	CONSTRAINT "client_pk" PRIMARY KEY ("key"),
+	CONSTRAINT "client_uid_un" UNIQUE ("uid")
+);
\ No newline at end of file
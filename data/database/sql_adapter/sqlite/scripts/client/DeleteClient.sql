@@ -0,0 +1,2 @@
+DELETE FROM "client"
+    WHERE "uid" = ?1
\ No newline at end of file
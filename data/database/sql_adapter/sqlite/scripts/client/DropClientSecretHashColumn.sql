@@ -0,0 +1,2 @@
+ALTER TABLE "client"
+	DROP COLUMN "secret_hash";
\ No newline at end of file
@@ -0,0 +1,3 @@
+SELECT c."uid", c."name", c."redirect_url", c."token_type", c."key_uri", c."secret_hash"
+	FROM "client" c
+WHERE c."uid" = ?1
\ No newline at end of file
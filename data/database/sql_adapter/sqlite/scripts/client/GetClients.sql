@@ -0,0 +1,12 @@
+SELECT c."uid", c."name", c."redirect_url", c."token_type", c."key_uri", c."secret_hash"
+	FROM "client" c
+	WHERE LOWER(SUBSTR(c."name", 1, LENGTH(?1))) = LOWER(?1)
+		AND (NOT ?3
+			OR (?2 AND (c."name" < ?4 OR (c."name" = ?4 AND c."uid" < ?5)))
+			OR (NOT ?2 AND (c."name" > ?4 OR (c."name" = ?4 AND c."uid" > ?5))))
+	ORDER BY
+		CASE WHEN ?2 THEN c."name" END DESC,
+		CASE WHEN ?2 THEN c."uid" END DESC,
+		c."name",
+		c."uid"
+	LIMIT ?6
\ No newline at end of file
@@ -0,0 +1,3 @@
+UPDATE "client" SET
+    "secret_hash" = ?2
+WHERE "uid" = ?1
\ No newline at end of file
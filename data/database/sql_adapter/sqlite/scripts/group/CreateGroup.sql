@@ -0,0 +1,2 @@
+INSERT INTO "group" ("name", "description", "priority")
+    VALUES (?1, ?2, ?3)
\ No newline at end of file
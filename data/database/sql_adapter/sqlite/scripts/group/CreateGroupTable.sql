@@ -0,0 +1,8 @@
+CREATE TABLE "group" (
+	"key" INTEGER NOT NULL,
+	"name" VARCHAR(30) NOT NULL,
+	"description" VARCHAR(255) NOT NULL,
+	"priority" INTEGER NOT NULL,
+	CONSTRAINT "group_pk" PRIMARY KEY ("key"),
+	CONSTRAINT "group_name_un" UNIQUE ("name")
+);
\ No newline at end of file
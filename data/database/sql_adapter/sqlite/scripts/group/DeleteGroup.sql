@@ -0,0 +1,2 @@
+DELETE FROM "group"
+    WHERE "name" = ?1
\ No newline at end of file
@@ -0,0 +1,3 @@
+DELETE FROM "group_member"
+    WHERE "group_key" IN (SELECT g."key" FROM "group" g WHERE g."name" = ?1) AND
+          "user_key" IN (SELECT u."key" FROM "user" u WHERE u."username" = ?2)
\ No newline at end of file
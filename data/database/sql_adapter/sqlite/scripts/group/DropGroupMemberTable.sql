@@ -0,0 +1 @@
+DROP TABLE "group_member"
\ No newline at end of file
@@ -0,0 +1,5 @@
+SELECT u."username"
+    FROM "group_member" gm
+        INNER JOIN "group" g ON g."name" = ?1 AND g."key" = gm."group_key"
+        INNER JOIN "user" u ON u."key" = gm."user_key"
+    ORDER BY u."username"
\ No newline at end of file
@@ -0,0 +1,5 @@
+SELECT g."name", g."description", g."priority"
+    FROM "group" g
+        INNER JOIN "group_member" gm ON gm."group_key" = g."key"
+        INNER JOIN "user" u ON u."username" = ?1 AND u."key" = gm."user_key"
+    ORDER BY g."name"
\ No newline at end of file
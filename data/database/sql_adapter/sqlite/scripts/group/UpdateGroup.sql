@@ -0,0 +1,4 @@
+UPDATE "group" SET
+    "description" = ?2,
+    "priority" = ?3
+WHERE "name" = ?1
\ No newline at end of file
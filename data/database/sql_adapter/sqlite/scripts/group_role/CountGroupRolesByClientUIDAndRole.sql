@@ -0,0 +1,4 @@
+SELECT COUNT(*)
+    FROM "group_role" gr
+        INNER JOIN "client" c ON c."uid" = ?1 AND c."key" = gr."client_key"
+    WHERE gr."role" = ?2
\ No newline at end of file
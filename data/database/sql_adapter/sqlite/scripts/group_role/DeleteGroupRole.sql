@@ -0,0 +1,3 @@
+DELETE FROM "group_role"
+    WHERE "client_key" IN (SELECT c."key" FROM "client" c WHERE c."uid" = ?1) AND
+          "group_key" IN (SELECT g."key" FROM "group" g WHERE g."name" = ?2)
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE "group_role"
\ No newline at end of file
@@ -0,0 +1,4 @@
+UPDATE "group_role" SET
+    "role" = ?3
+WHERE "client_key" IN (SELECT c."key" FROM "client" c WHERE c."uid" = ?1) AND
+      "role" = ?2
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE "invitation" (
+	"key" INTEGER NOT NULL,
+	"id" TEXT NOT NULL,
+	"token_hash" BLOB NOT NULL,
+	"email" VARCHAR(254) NOT NULL,
+	"rank" INTEGER NOT NULL,
+	"expires_at" TIMESTAMP NOT NULL,
+	CONSTRAINT "invitation_pk" PRIMARY KEY ("key"),
+	CONSTRAINT "invitation_id_un" UNIQUE ("id"),
+	CONSTRAINT "invitation_token_hash_un" UNIQUE ("token_hash")
+);
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE FROM "invitation"
+    WHERE "id" = ?1
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE "invitation"
\ No newline at end of file
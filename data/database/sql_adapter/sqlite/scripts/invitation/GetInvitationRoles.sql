@@ -0,0 +1,6 @@
+SELECT c."uid", ir."role"
+    FROM "invitation_role" ir
+        INNER JOIN "invitation" i ON i."key" = ir."invitation_key"
+        INNER JOIN "client" c ON c."key" = ir."client_key"
+    WHERE i."id" = ?1
+    ORDER BY c."uid"
\ No newline at end of file
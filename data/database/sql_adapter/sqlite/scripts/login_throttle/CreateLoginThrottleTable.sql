@@ -0,0 +1,8 @@
+CREATE TABLE "login_throttle" (
+	"type" VARCHAR(10) NOT NULL,
+	"key" VARCHAR(64) NOT NULL,
+	"failed_attempts" INTEGER NOT NULL,
+	"last_failed_at" TIMESTAMP NOT NULL,
+	"locked_until" TIMESTAMP NOT NULL,
+	CONSTRAINT "login_throttle_pk" PRIMARY KEY ("type", "key")
+);
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE FROM "login_throttle"
+    WHERE "type" = ?1 AND "key" = ?2
\ No newline at end of file
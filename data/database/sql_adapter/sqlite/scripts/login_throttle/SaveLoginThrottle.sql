@@ -0,0 +1,6 @@
+INSERT INTO "login_throttle" ("type", "key", "failed_attempts", "last_failed_at", "locked_until")
+    VALUES (?1, ?2, ?3, ?4, ?5)
+    ON CONFLICT ("type", "key") DO UPDATE SET
+        "failed_attempts" = EXCLUDED."failed_attempts",
+        "last_failed_at" = EXCLUDED."last_failed_at",
+        "locked_until" = EXCLUDED."locked_until"
\ No newline at end of file
@@ -0,0 +1,2 @@
+INSERT INTO "migration" ("timestamp") 
+    VALUES (?1)
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE "password_reset_token"
\ No newline at end of file
@@ -0,0 +1,5 @@
+WITH
+    t1 AS (SELECT u."key" FROM "user" u WHERE u."username" = ?2)
+INSERT INTO "password_reset_token" ("token_hash", "user_key", "expires_at")
+    SELECT ?1, t1."key", ?3
+        FROM t1
\ No newline at end of file
@@ -0,0 +1,5 @@
+DELETE FROM "previous_password"
+    WHERE "user_key" IN (
+        SELECT u."key" FROM "user" u WHERE u."username" = ?1
+    )
+    AND JULIANDAY("replaced_at") < JULIANDAY(?2)
\ No newline at end of file
@@ -0,0 +1,6 @@
+CREATE TABLE "recovery_code" (
+	"user_key" INTEGER NOT NULL,
+	"code_hash" BLOB NOT NULL,
+	CONSTRAINT "recovery_code_pk" PRIMARY KEY ("user_key", "code_hash"),
+	CONSTRAINT "recovery_code_user_fk" FOREIGN KEY ("user_key") REFERENCES "user"("key") ON DELETE CASCADE
+);
\ No newline at end of file
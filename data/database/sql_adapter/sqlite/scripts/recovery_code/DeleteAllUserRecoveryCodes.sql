@@ -0,0 +1,4 @@
+DELETE FROM "recovery_code"
+    WHERE "user_key" IN (
+        SELECT u."key" FROM "user" u WHERE u."username" = ?1
+    )
\ No newline at end of file
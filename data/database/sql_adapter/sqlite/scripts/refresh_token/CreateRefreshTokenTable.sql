@@ -0,0 +1,11 @@
+CREATE TABLE "refresh_token" (
+	"token" TEXT NOT NULL,
+	"family_id" TEXT NOT NULL,
+	"client_key" INTEGER NOT NULL,
+	"user_key" INTEGER NOT NULL,
+	"rotated" BOOLEAN NOT NULL DEFAULT FALSE,
+	"expires_at" TIMESTAMP NOT NULL,
+	CONSTRAINT "refresh_token_pk" PRIMARY KEY ("token"),
+	CONSTRAINT "refresh_token_client_fk" FOREIGN KEY ("client_key") REFERENCES "client"("key") ON DELETE CASCADE,
+	CONSTRAINT "refresh_token_user_fk" FOREIGN KEY ("user_key") REFERENCES "user"("key") ON DELETE CASCADE
+);
\ No newline at end of file
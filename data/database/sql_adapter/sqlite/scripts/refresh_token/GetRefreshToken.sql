@@ -0,0 +1,5 @@
+SELECT rt."token", rt."family_id", c."uid", u."username", rt."rotated", rt."expires_at"
+    FROM "refresh_token" rt
+        INNER JOIN "client" c ON c."key" = rt."client_key"
+        INNER JOIN "user" u ON u."key" = rt."user_key"
+    WHERE rt."token" = ?1
\ No newline at end of file
@@ -0,0 +1,3 @@
+UPDATE "refresh_token" SET
+    "rotated" = TRUE
+WHERE "token" = ?1 AND "rotated" = FALSE
\ No newline at end of file
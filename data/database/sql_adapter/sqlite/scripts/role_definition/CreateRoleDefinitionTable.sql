@@ -0,0 +1,8 @@
+CREATE TABLE "role_definition" (
+	"client_key" INTEGER NOT NULL,
+	"name" VARCHAR(15) NOT NULL,
+	"description" VARCHAR(255) NOT NULL,
+	"is_default" BOOLEAN NOT NULL,
+	CONSTRAINT "role_definition_pk" PRIMARY KEY ("client_key", "name"),
+	CONSTRAINT "role_definition_client_fk" FOREIGN KEY ("client_key") REFERENCES "client"("key") ON DELETE CASCADE
+);
\ No newline at end of file
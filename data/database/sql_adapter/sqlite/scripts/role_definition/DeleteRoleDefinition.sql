@@ -0,0 +1,3 @@
+DELETE FROM "role_definition"
+    WHERE "client_key" IN (SELECT c."key" FROM "client" c WHERE c."uid" = ?1) AND
+          "name" = ?2
\ No newline at end of file
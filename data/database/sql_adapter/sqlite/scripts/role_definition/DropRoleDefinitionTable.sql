@@ -0,0 +1 @@
+DROP TABLE "role_definition"
\ No newline at end of file
@@ -0,0 +1,4 @@
+SELECT c."uid", rd."name", rd."description", rd."is_default"
+    FROM "role_definition" rd
+        INNER JOIN "client" c ON c."uid" = ?1 AND c."key" = rd."client_key"
+    ORDER BY rd."name"
\ No newline at end of file
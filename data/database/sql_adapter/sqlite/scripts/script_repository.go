@@ -0,0 +1,1726 @@
+// Auto generated. DO NOT EDIT.
+
+package scripts
+
+// ScriptRepository is an implementation of the sql script repository interface that fetches scripts laoded from sql files.
+type ScriptRepository struct {}
+
+// CreateAdminRoleScript gets the CreateAdminRole script.
+func (ScriptRepository) CreateAdminRoleScript() string {
+	return `
+INSERT INTO "admin_role" ("name", "description", "permissions")
+    VALUES (?1, ?2, ?3)
+`
+}
+
+// CreateAdminRoleTableScript gets the CreateAdminRoleTable script.
+func (ScriptRepository) CreateAdminRoleTableScript() string {
+	return `
+CREATE TABLE "admin_role" (
+	"key" INTEGER NOT NULL,
+	"name" VARCHAR(30) NOT NULL,
+	"description" VARCHAR(255) NOT NULL,
+	"permissions" TEXT NOT NULL,
+	CONSTRAINT "admin_role_pk" PRIMARY KEY ("key"),
+	CONSTRAINT "admin_role_name_un" UNIQUE ("name")
+);
+`
+}
+
+// CreateUserAdminRoleScript gets the CreateUserAdminRole script.
+func (ScriptRepository) CreateUserAdminRoleScript() string {
+	return `
+WITH
+    t1 AS (SELECT u."key" FROM "user" u WHERE u."username" = ?1),
+    t2 AS (SELECT ar."key" FROM "admin_role" ar WHERE ar."name" = ?2)
+INSERT INTO "user_admin_role" ("user_key", "admin_role_key")
+    SELECT t1."key", t2."key"
+        FROM t1, t2
+`
+}
+
+// CreateUserAdminRoleTableScript gets the CreateUserAdminRoleTable script.
+func (ScriptRepository) CreateUserAdminRoleTableScript() string {
+	return `
+CREATE TABLE "user_admin_role" (
+	"user_key" INTEGER NOT NULL,
+	"admin_role_key" INTEGER NOT NULL,
+	CONSTRAINT "user_admin_role_pk" PRIMARY KEY ("user_key", "admin_role_key"),
+	CONSTRAINT "user_admin_role_user_fk" FOREIGN KEY ("user_key") REFERENCES "user"("key") ON DELETE CASCADE,
+	CONSTRAINT "user_admin_role_admin_role_fk" FOREIGN KEY ("admin_role_key") REFERENCES "admin_role"("key") ON DELETE CASCADE
+);
+`
+}
+
+// DeleteAdminRoleScript gets the DeleteAdminRole script.
+func (ScriptRepository) DeleteAdminRoleScript() string {
+	return `
+DELETE FROM "admin_role"
+    WHERE "name" = ?1
+`
+}
+
+// DeleteUserAdminRolesScript gets the DeleteUserAdminRoles script.
+func (ScriptRepository) DeleteUserAdminRolesScript() string {
+	return `
+DELETE FROM "user_admin_role"
+    WHERE "user_key" IN (SELECT u."key" FROM "user" u WHERE u."username" = ?1)
+`
+}
+
+// DropAdminRoleTableScript gets the DropAdminRoleTable script.
+func (ScriptRepository) DropAdminRoleTableScript() string {
+	return `
+DROP TABLE "admin_role"
+`
+}
+
+// DropUserAdminRoleTableScript gets the DropUserAdminRoleTable script.
+func (ScriptRepository) DropUserAdminRoleTableScript() string {
+	return `
+DROP TABLE "user_admin_role"
+`
+}
+
+// GetAdminRoleByNameScript gets the GetAdminRoleByName script.
+func (ScriptRepository) GetAdminRoleByNameScript() string {
+	return `
+SELECT ar."name", ar."description", ar."permissions"
+    FROM "admin_role" ar
+    WHERE ar."name" = ?1
+`
+}
+
+// GetAdminRoleUsernamesScript gets the GetAdminRoleUsernames script.
+func (ScriptRepository) GetAdminRoleUsernamesScript() string {
+	return `
+SELECT u."username"
+    FROM "user_admin_role" uar
+        INNER JOIN "admin_role" ar ON ar."name" = ?1 AND ar."key" = uar."admin_role_key"
+        INNER JOIN "user" u ON u."key" = uar."user_key"
+    ORDER BY u."username"
+`
+}
+
+// GetAdminRolesScript gets the GetAdminRoles script.
+func (ScriptRepository) GetAdminRolesScript() string {
+	return `
+SELECT ar."name", ar."description", ar."permissions"
+    FROM "admin_role" ar
+    ORDER BY ar."name"
+`
+}
+
+// GetAdminRolesByUsernameScript gets the GetAdminRolesByUsername script.
+func (ScriptRepository) GetAdminRolesByUsernameScript() string {
+	return `
+SELECT ar."name", ar."description", ar."permissions"
+    FROM "admin_role" ar
+        INNER JOIN "user_admin_role" uar ON uar."admin_role_key" = ar."key"
+        INNER JOIN "user" u ON u."username" = ?1 AND u."key" = uar."user_key"
+    ORDER BY ar."name"
+`
+}
+
+// UpdateAdminRoleScript gets the UpdateAdminRole script.
+func (ScriptRepository) UpdateAdminRoleScript() string {
+	return `
+UPDATE "admin_role" SET
+    "description" = ?2,
+    "permissions" = ?3
+WHERE "name" = ?1
+`
+}
+
+// CreateAuditEventTableScript gets the CreateAuditEventTable script.
+func (ScriptRepository) CreateAuditEventTableScript() string {
+	return `
+CREATE TABLE "audit_event" (
+	"id" TEXT NOT NULL,
+	"actor" VARCHAR(64) NOT NULL,
+	"action" VARCHAR(32) NOT NULL,
+	"target" VARCHAR(128) NOT NULL,
+	"ip_address" VARCHAR(64) NOT NULL,
+	"timestamp" TIMESTAMP NOT NULL,
+	CONSTRAINT "audit_event_pk" PRIMARY KEY ("id")
+);
+
+CREATE INDEX "audit_event_timestamp_idx" ON "audit_event" ("timestamp");
+`
+}
+
+// DropAuditEventTableScript gets the DropAuditEventTable script.
+func (ScriptRepository) DropAuditEventTableScript() string {
+	return `
+DROP TABLE "audit_event"
+`
+}
+
+// GetAuditEventsScript gets the GetAuditEvents script.
+func (ScriptRepository) GetAuditEventsScript() string {
+	return `
+SELECT ae."id", ae."actor", ae."action", ae."target", ae."ip_address", ae."timestamp"
+    FROM "audit_event" ae
+    WHERE (?1 = '' OR ae."actor" = ?1)
+        AND (?2 = '' OR ae."action" = ?2)
+        AND (?3 = '' OR ae."target" = ?3)
+        AND (?4 IS NULL OR JULIANDAY(ae."timestamp") >= JULIANDAY(?4))
+        AND (?5 IS NULL OR JULIANDAY(ae."timestamp") < JULIANDAY(?5))
+    ORDER BY JULIANDAY(ae."timestamp") DESC, ae."id"
+    LIMIT ?6 OFFSET ?7
+`
+}
+
+// SaveAuditEventScript gets the SaveAuditEvent script.
+func (ScriptRepository) SaveAuditEventScript() string {
+	return `
+INSERT INTO "audit_event" ("id", "actor", "action", "target", "ip_address", "timestamp")
+    VALUES (?1, ?2, ?3, ?4, ?5, ?6)
+`
+}
+
+// AddAuthorizationCodeRedirectURIColumnScript gets the AddAuthorizationCodeRedirectURIColumn script.
+func (ScriptRepository) AddAuthorizationCodeRedirectURIColumnScript() string {
+	return `
+ALTER TABLE "authorization_code"
+	ADD COLUMN "redirect_uri" VARCHAR(100) NOT NULL DEFAULT '';
+`
+}
+
+// CreateAuthorizationCodeTableScript gets the CreateAuthorizationCodeTable script.
+func (ScriptRepository) CreateAuthorizationCodeTableScript() string {
+	return `
+CREATE TABLE "authorization_code" (
+	"code" TEXT NOT NULL,
+	"client_key" INTEGER NOT NULL,
+	"user_key" INTEGER NOT NULL,
+	"code_challenge" VARCHAR(128) NOT NULL,
+	"nonce" VARCHAR(255) NOT NULL,
+	"expires_at" TIMESTAMP NOT NULL,
+	CONSTRAINT "authorization_code_pk" PRIMARY KEY ("code"),
+	CONSTRAINT "authorization_code_client_fk" FOREIGN KEY ("client_key") REFERENCES "client"("key") ON DELETE CASCADE,
+	CONSTRAINT "authorization_code_user_fk" FOREIGN KEY ("user_key") REFERENCES "user"("key") ON DELETE CASCADE
+);
+`
+}
+
+// DeleteAuthorizationCodeScript gets the DeleteAuthorizationCode script.
+func (ScriptRepository) DeleteAuthorizationCodeScript() string {
+	return `
+DELETE FROM "authorization_code"
+    WHERE "code" = ?1
+`
+}
+
+// DropAuthorizationCodeRedirectURIColumnScript gets the DropAuthorizationCodeRedirectURIColumn script.
+func (ScriptRepository) DropAuthorizationCodeRedirectURIColumnScript() string {
+	return `
+ALTER TABLE "authorization_code"
+	DROP COLUMN "redirect_uri";
+`
+}
+
+// DropAuthorizationCodeTableScript gets the DropAuthorizationCodeTable script.
+func (ScriptRepository) DropAuthorizationCodeTableScript() string {
+	return `
+DROP TABLE "authorization_code"
+`
+}
+
+// GetAuthorizationCodeScript gets the GetAuthorizationCode script.
+func (ScriptRepository) GetAuthorizationCodeScript() string {
+	return `
+SELECT ac."code", c."uid", u."username", ac."code_challenge", ac."nonce", ac."redirect_uri", ac."expires_at"
+    FROM "authorization_code" ac
+        INNER JOIN "client" c ON c."key" = ac."client_key"
+        INNER JOIN "user" u ON u."key" = ac."user_key"
+    WHERE ac."code" = ?1
+`
+}
+
+// SaveAuthorizationCodeScript gets the SaveAuthorizationCode script.
+func (ScriptRepository) SaveAuthorizationCodeScript() string {
+	return `
+WITH
+    t1 AS (SELECT c."key" FROM "client" c WHERE c."uid" = ?2),
+    t2 AS (SELECT u."key" FROM "user" u WHERE u."username" = ?3)
+INSERT INTO "authorization_code" ("code", "client_key", "user_key", "code_challenge", "nonce", "redirect_uri", "expires_at")
+    SELECT ?1, t1."key", t2."key", ?4, ?5, ?6, ?7
+        FROM t1, t2
+`
+}
+
+// AddClientSecretHashColumnScript gets the AddClientSecretHashColumn script.
+func (ScriptRepository) AddClientSecretHashColumnScript() string {
+	return `
+ALTER TABLE "client"
+	ADD COLUMN "secret_hash" BLOB;
+`
+}
+
+// CountClientsScript gets the CountClients script.
+func (ScriptRepository) CountClientsScript() string {
+	return `
+SELECT COUNT(*)
+	FROM "client" c
+	WHERE LOWER(SUBSTR(c."name", 1, LENGTH(?1))) = LOWER(?1)
+`
+}
+
+// CreateClientScript gets the CreateClient script.
+func (ScriptRepository) CreateClientScript() string {
+	return `
+INSERT INTO "client" ("uid", "name", "redirect_url", "token_type", "key_uri")
+	VALUES (?1, ?2, ?3, ?4, ?5)
+`
+}
+
+// CreateClientTableScript gets the CreateClientTable script.
+func (ScriptRepository) CreateClientTableScript() string {
+	return `
+CREATE TABLE "client" (
+	"key" INTEGER NOT NULL,
+	"uid" TEXT NOT NULL,
+	"name" VARCHAR(30) NOT NULL,
+	"redirect_url" VARCHAR(100) NOT NULL,
+	"token_type" INTEGER NOT NULL,
+	"key_uri" VARCHAR(100) NOT NULL,
+	CONSTRAINT "client_pk" PRIMARY KEY ("key"),
+	CONSTRAINT "client_uid_un" UNIQUE ("uid")
+);
+`
+}
+
+// DeleteClientScript gets the DeleteClient script.
+func (ScriptRepository) DeleteClientScript() string {
+	return `
+DELETE FROM "client"
+    WHERE "uid" = ?1
+`
+}
+
+// DropClientSecretHashColumnScript gets the DropClientSecretHashColumn script.
+func (ScriptRepository) DropClientSecretHashColumnScript() string {
+	return `
+ALTER TABLE "client"
+	DROP COLUMN "secret_hash";
+`
+}
+
+// DropClientTableScript gets the DropClientTable script.
+func (ScriptRepository) DropClientTableScript() string {
+	return `
+DROP TABLE "client"
+`
+}
+
+// GetClientByUIDScript gets the GetClientByUID script.
+func (ScriptRepository) GetClientByUIDScript() string {
+	return `
+SELECT c."uid", c."name", c."redirect_url", c."token_type", c."key_uri", c."secret_hash"
+	FROM "client" c
+WHERE c."uid" = ?1
+`
+}
+
+// GetClientsScript gets the GetClients script.
+func (ScriptRepository) GetClientsScript() string {
+	return `
+SELECT c."uid", c."name", c."redirect_url", c."token_type", c."key_uri", c."secret_hash"
+	FROM "client" c
+	WHERE LOWER(SUBSTR(c."name", 1, LENGTH(?1))) = LOWER(?1)
+		AND (NOT ?3
+			OR (?2 AND (c."name" < ?4 OR (c."name" = ?4 AND c."uid" < ?5)))
+			OR (NOT ?2 AND (c."name" > ?4 OR (c."name" = ?4 AND c."uid" > ?5))))
+	ORDER BY
+		CASE WHEN ?2 THEN c."name" END DESC,
+		CASE WHEN ?2 THEN c."uid" END DESC,
+		c."name",
+		c."uid"
+	LIMIT ?6
+`
+}
+
+// UpdateClientScript gets the UpdateClient script.
+func (ScriptRepository) UpdateClientScript() string {
+	return `
+UPDATE "client" SET
+    "name" = ?2,
+    "redirect_url" = ?3,
+    "token_type" = ?4,
+    "key_uri" = ?5
+WHERE "uid" = ?1
+`
+}
+
+// UpdateClientSecretHashScript gets the UpdateClientSecretHash script.
+func (ScriptRepository) UpdateClientSecretHashScript() string {
+	return `
+UPDATE "client" SET
+    "secret_hash" = ?2
+WHERE "uid" = ?1
+`
+}
+
+// CreateClientAdminScript gets the CreateClientAdmin script.
+func (ScriptRepository) CreateClientAdminScript() string {
+	return `
+WITH
+    t1 AS (SELECT c."key" FROM "client" c WHERE c."uid" = ?1),
+    t2 AS (SELECT u."key" FROM "user" u WHERE u."username" = ?2)
+INSERT INTO "client_admin" ("client_key", "user_key", "is_owner")
+    SELECT t1."key", t2."key", ?3
+        FROM t1, t2
+`
+}
+
+// CreateClientAdminTableScript gets the CreateClientAdminTable script.
+func (ScriptRepository) CreateClientAdminTableScript() string {
+	return `
+CREATE TABLE "client_admin" (
+	"client_key" INTEGER NOT NULL,
+	"user_key" INTEGER NOT NULL,
+	"is_owner" BOOLEAN NOT NULL,
+	CONSTRAINT "client_admin_pk" PRIMARY KEY ("client_key", "user_key"),
+	CONSTRAINT "client_admin_client_fk" FOREIGN KEY ("client_key") REFERENCES "client"("key") ON DELETE CASCADE,
+	CONSTRAINT "client_admin_user_fk" FOREIGN KEY ("user_key") REFERENCES "user"("key") ON DELETE CASCADE
+);
+`
+}
+
+// DeleteClientAdminScript gets the DeleteClientAdmin script.
+func (ScriptRepository) DeleteClientAdminScript() string {
+	return `
+DELETE FROM "client_admin"
+    WHERE "client_key" IN (SELECT c."key" FROM "client" c WHERE c."uid" = ?1) AND
+          "user_key" IN (SELECT u."key" FROM "user" u WHERE u."username" = ?2)
+`
+}
+
+// DropClientAdminTableScript gets the DropClientAdminTable script.
+func (ScriptRepository) DropClientAdminTableScript() string {
+	return `
+DROP TABLE "client_admin"
+`
+}
+
+// GetClientAdminByClientUIDAndUsernameScript gets the GetClientAdminByClientUIDAndUsername script.
+func (ScriptRepository) GetClientAdminByClientUIDAndUsernameScript() string {
+	return `
+SELECT c."uid", u."username", ca."is_owner"
+    FROM "client_admin" ca
+        INNER JOIN "client" c ON c."uid" = ?1 AND c."key" = ca."client_key"
+        INNER JOIN "user" u ON u."username" = ?2 AND u."key" = ca."user_key"
+`
+}
+
+// GetClientAdminsByClientUIDScript gets the GetClientAdminsByClientUID script.
+func (ScriptRepository) GetClientAdminsByClientUIDScript() string {
+	return `
+SELECT c."uid", u."username", ca."is_owner"
+    FROM "client_admin" ca
+        INNER JOIN "client" c ON c."uid" = ?1 AND c."key" = ca."client_key"
+        INNER JOIN "user" u ON u."key" = ca."user_key"
+    ORDER BY u."username"
+`
+}
+
+// GetClientAdminsByUsernameScript gets the GetClientAdminsByUsername script.
+func (ScriptRepository) GetClientAdminsByUsernameScript() string {
+	return `
+SELECT c."uid", u."username", ca."is_owner"
+    FROM "client_admin" ca
+        INNER JOIN "client" c ON c."key" = ca."client_key"
+        INNER JOIN "user" u ON u."username" = ?1 AND u."key" = ca."user_key"
+`
+}
+
+// CreateGroupScript gets the CreateGroup script.
+func (ScriptRepository) CreateGroupScript() string {
+	return `
+INSERT INTO "group" ("name", "description", "priority")
+    VALUES (?1, ?2, ?3)
+`
+}
+
+// CreateGroupMemberScript gets the CreateGroupMember script.
+func (ScriptRepository) CreateGroupMemberScript() string {
+	return `
+WITH
+    t1 AS (SELECT g."key" FROM "group" g WHERE g."name" = ?1),
+    t2 AS (SELECT u."key" FROM "user" u WHERE u."username" = ?2)
+INSERT INTO "group_member" ("group_key", "user_key")
+    SELECT t1."key", t2."key"
+        FROM t1, t2
+`
+}
+
+// CreateGroupMemberTableScript gets the CreateGroupMemberTable script.
+func (ScriptRepository) CreateGroupMemberTableScript() string {
+	return `
+CREATE TABLE "group_member" (
+	"group_key" INTEGER NOT NULL,
+	"user_key" INTEGER NOT NULL,
+	CONSTRAINT "group_member_pk" PRIMARY KEY ("group_key", "user_key"),
+	CONSTRAINT "group_member_group_fk" FOREIGN KEY ("group_key") REFERENCES "group"("key") ON DELETE CASCADE,
+	CONSTRAINT "group_member_user_fk" FOREIGN KEY ("user_key") REFERENCES "user"("key") ON DELETE CASCADE
+);
+`
+}
+
+// CreateGroupTableScript gets the CreateGroupTable script.
+func (ScriptRepository) CreateGroupTableScript() string {
+	return `
+CREATE TABLE "group" (
+	"key" INTEGER NOT NULL,
+	"name" VARCHAR(30) NOT NULL,
+	"description" VARCHAR(255) NOT NULL,
+	"priority" INTEGER NOT NULL,
+	CONSTRAINT "group_pk" PRIMARY KEY ("key"),
+	CONSTRAINT "group_name_un" UNIQUE ("name")
+);
+`
+}
+
+// DeleteGroupScript gets the DeleteGroup script.
+func (ScriptRepository) DeleteGroupScript() string {
+	return `
+DELETE FROM "group"
+    WHERE "name" = ?1
+`
+}
+
+// DeleteGroupMemberScript gets the DeleteGroupMember script.
+func (ScriptRepository) DeleteGroupMemberScript() string {
+	return `
+DELETE FROM "group_member"
+    WHERE "group_key" IN (SELECT g."key" FROM "group" g WHERE g."name" = ?1) AND
+          "user_key" IN (SELECT u."key" FROM "user" u WHERE u."username" = ?2)
+`
+}
+
+// DropGroupMemberTableScript gets the DropGroupMemberTable script.
+func (ScriptRepository) DropGroupMemberTableScript() string {
+	return `
+DROP TABLE "group_member"
+`
+}
+
+// DropGroupTableScript gets the DropGroupTable script.
+func (ScriptRepository) DropGroupTableScript() string {
+	return `
+DROP TABLE "group"
+`
+}
+
+// GetGroupByNameScript gets the GetGroupByName script.
+func (ScriptRepository) GetGroupByNameScript() string {
+	return `
+SELECT g."name", g."description", g."priority"
+    FROM "group" g
+    WHERE g."name" = ?1
+`
+}
+
+// GetGroupMemberUsernamesScript gets the GetGroupMemberUsernames script.
+func (ScriptRepository) GetGroupMemberUsernamesScript() string {
+	return `
+SELECT u."username"
+    FROM "group_member" gm
+        INNER JOIN "group" g ON g."name" = ?1 AND g."key" = gm."group_key"
+        INNER JOIN "user" u ON u."key" = gm."user_key"
+    ORDER BY u."username"
+`
+}
+
+// GetGroupsScript gets the GetGroups script.
+func (ScriptRepository) GetGroupsScript() string {
+	return `
+SELECT g."name", g."description", g."priority"
+    FROM "group" g
+    ORDER BY g."name"
+`
+}
+
+// GetGroupsByUsernameScript gets the GetGroupsByUsername script.
+func (ScriptRepository) GetGroupsByUsernameScript() string {
+	return `
+SELECT g."name", g."description", g."priority"
+    FROM "group" g
+        INNER JOIN "group_member" gm ON gm."group_key" = g."key"
+        INNER JOIN "user" u ON u."username" = ?1 AND u."key" = gm."user_key"
+    ORDER BY g."name"
+`
+}
+
+// UpdateGroupScript gets the UpdateGroup script.
+func (ScriptRepository) UpdateGroupScript() string {
+	return `
+UPDATE "group" SET
+    "description" = ?2,
+    "priority" = ?3
+WHERE "name" = ?1
+`
+}
+
+// CountGroupRolesByClientUIDAndRoleScript gets the CountGroupRolesByClientUIDAndRole script.
+func (ScriptRepository) CountGroupRolesByClientUIDAndRoleScript() string {
+	return `
+SELECT COUNT(*)
+    FROM "group_role" gr
+        INNER JOIN "client" c ON c."uid" = ?1 AND c."key" = gr."client_key"
+    WHERE gr."role" = ?2
+`
+}
+
+// CreateGroupRoleScript gets the CreateGroupRole script.
+func (ScriptRepository) CreateGroupRoleScript() string {
+	return `
+WITH
+    t1 AS (SELECT c."key" FROM "client" c WHERE c."uid" = ?1),
+    t2 AS (SELECT g."key" FROM "group" g WHERE g."name" = ?2)
+INSERT INTO "group_role" ("client_key", "group_key", "role")
+    SELECT t1."key", t2."key", ?3
+        FROM t1, t2
+`
+}
+
+// CreateGroupRoleTableScript gets the CreateGroupRoleTable script.
+func (ScriptRepository) CreateGroupRoleTableScript() string {
+	return `
+CREATE TABLE "group_role" (
+	"client_key" INTEGER NOT NULL,
+	"group_key" INTEGER NOT NULL,
+	"role" VARCHAR(15) NOT NULL,
+	CONSTRAINT "group_role_pk" PRIMARY KEY ("client_key", "group_key", "role"),
+	CONSTRAINT "group_role_client_fk" FOREIGN KEY ("client_key") REFERENCES "client"("key") ON DELETE CASCADE,
+	CONSTRAINT "group_role_group_fk" FOREIGN KEY ("group_key") REFERENCES "group"("key") ON DELETE CASCADE
+);
+`
+}
+
+// DeleteGroupRoleScript gets the DeleteGroupRole script.
+func (ScriptRepository) DeleteGroupRoleScript() string {
+	return `
+DELETE FROM "group_role"
+    WHERE "client_key" IN (SELECT c."key" FROM "client" c WHERE c."uid" = ?1) AND
+          "group_key" IN (SELECT g."key" FROM "group" g WHERE g."name" = ?2)
+`
+}
+
+// DropGroupRoleTableScript gets the DropGroupRoleTable script.
+func (ScriptRepository) DropGroupRoleTableScript() string {
+	return `
+DROP TABLE "group_role"
+`
+}
+
+// GetGroupRoleByClientUIDAndGroupNameScript gets the GetGroupRoleByClientUIDAndGroupName script.
+func (ScriptRepository) GetGroupRoleByClientUIDAndGroupNameScript() string {
+	return `
+SELECT c."uid", g."name", gr."role"
+    FROM "group_role" gr
+        INNER JOIN "client" c ON c."uid" = ?1 AND c."key" = gr."client_key"
+        INNER JOIN "group" g ON g."name" = ?2 AND g."key" = gr."group_key"
+    ORDER BY gr."role"
+`
+}
+
+// GetGroupRolesByClientUIDScript gets the GetGroupRolesByClientUID script.
+func (ScriptRepository) GetGroupRolesByClientUIDScript() string {
+	return `
+SELECT c."uid", g."name", gr."role"
+    FROM "group_role" gr
+        INNER JOIN "client" c ON c."uid" = ?1 AND c."key" = gr."client_key"
+        INNER JOIN "group" g ON g."key" = gr."group_key"
+    ORDER BY g."name", gr."role"
+`
+}
+
+// RenameGroupRolesScript gets the RenameGroupRoles script.
+func (ScriptRepository) RenameGroupRolesScript() string {
+	return `
+UPDATE "group_role" SET
+    "role" = ?3
+WHERE "client_key" IN (SELECT c."key" FROM "client" c WHERE c."uid" = ?1) AND
+      "role" = ?2
+`
+}
+
+// CreateInvitationRoleTableScript gets the CreateInvitationRoleTable script.
+func (ScriptRepository) CreateInvitationRoleTableScript() string {
+	return `
+CREATE TABLE "invitation_role" (
+	"invitation_key" INTEGER NOT NULL,
+	"client_key" INTEGER NOT NULL,
+	"role" VARCHAR(15) NOT NULL,
+	CONSTRAINT "invitation_role_pk" PRIMARY KEY ("invitation_key", "client_key"),
+	CONSTRAINT "invitation_role_invitation_fk" FOREIGN KEY ("invitation_key") REFERENCES "invitation"("key") ON DELETE CASCADE,
+	CONSTRAINT "invitation_role_client_fk" FOREIGN KEY ("client_key") REFERENCES "client"("key") ON DELETE CASCADE
+);
+`
+}
+
+// CreateInvitationTableScript gets the CreateInvitationTable script.
+func (ScriptRepository) CreateInvitationTableScript() string {
+	return `
+CREATE TABLE "invitation" (
+	"key" INTEGER NOT NULL,
+	"id" TEXT NOT NULL,
+	"token_hash" BLOB NOT NULL,
+	"email" VARCHAR(254) NOT NULL,
+	"rank" INTEGER NOT NULL,
+	"expires_at" TIMESTAMP NOT NULL,
+	CONSTRAINT "invitation_pk" PRIMARY KEY ("key"),
+	CONSTRAINT "invitation_id_un" UNIQUE ("id"),
+	CONSTRAINT "invitation_token_hash_un" UNIQUE ("token_hash")
+);
+`
+}
+
+// DeleteInvitationScript gets the DeleteInvitation script.
+func (ScriptRepository) DeleteInvitationScript() string {
+	return `
+DELETE FROM "invitation"
+    WHERE "id" = ?1
+`
+}
+
+// DropInvitationRoleTableScript gets the DropInvitationRoleTable script.
+func (ScriptRepository) DropInvitationRoleTableScript() string {
+	return `
+DROP TABLE "invitation_role"
+`
+}
+
+// DropInvitationTableScript gets the DropInvitationTable script.
+func (ScriptRepository) DropInvitationTableScript() string {
+	return `
+DROP TABLE "invitation"
+`
+}
+
+// GetInvitationByIDScript gets the GetInvitationByID script.
+func (ScriptRepository) GetInvitationByIDScript() string {
+	return `
+SELECT i."id", i."token_hash", i."email", i."rank", i."expires_at"
+    FROM "invitation" i
+    WHERE i."id" = ?1
+`
+}
+
+// GetInvitationByTokenHashScript gets the GetInvitationByTokenHash script.
+func (ScriptRepository) GetInvitationByTokenHashScript() string {
+	return `
+SELECT i."id", i."token_hash", i."email", i."rank", i."expires_at"
+    FROM "invitation" i
+    WHERE i."token_hash" = ?1
+`
+}
+
+// GetInvitationRolesScript gets the GetInvitationRoles script.
+func (ScriptRepository) GetInvitationRolesScript() string {
+	return `
+SELECT c."uid", ir."role"
+    FROM "invitation_role" ir
+        INNER JOIN "invitation" i ON i."key" = ir."invitation_key"
+        INNER JOIN "client" c ON c."key" = ir."client_key"
+    WHERE i."id" = ?1
+    ORDER BY c."uid"
+`
+}
+
+// RenameInvitationRolesScript gets the RenameInvitationRoles script.
+func (ScriptRepository) RenameInvitationRolesScript() string {
+	return `
+UPDATE "invitation_role" SET
+    "role" = ?3
+WHERE "client_key" IN (SELECT c."key" FROM "client" c WHERE c."uid" = ?1) AND
+      "role" = ?2
+`
+}
+
+// SaveInvitationScript gets the SaveInvitation script.
+func (ScriptRepository) SaveInvitationScript() string {
+	return `
+INSERT INTO "invitation" ("id", "token_hash", "email", "rank", "expires_at")
+    VALUES (?1, ?2, ?3, ?4, ?5)
+`
+}
+
+// SaveInvitationRoleScript gets the SaveInvitationRole script.
+func (ScriptRepository) SaveInvitationRoleScript() string {
+	return `
+WITH
+    t1 AS (SELECT i."key" FROM "invitation" i WHERE i."id" = ?1),
+    t2 AS (SELECT c."key" FROM "client" c WHERE c."uid" = ?2)
+INSERT INTO "invitation_role" ("invitation_key", "client_key", "role")
+    SELECT t1."key", t2."key", ?3
+        FROM t1, t2
+`
+}
+
+// CreateLoginThrottleTableScript gets the CreateLoginThrottleTable script.
+func (ScriptRepository) CreateLoginThrottleTableScript() string {
+	return `
+CREATE TABLE "login_throttle" (
+	"type" VARCHAR(10) NOT NULL,
+	"key" VARCHAR(64) NOT NULL,
+	"failed_attempts" INTEGER NOT NULL,
+	"last_failed_at" TIMESTAMP NOT NULL,
+	"locked_until" TIMESTAMP NOT NULL,
+	CONSTRAINT "login_throttle_pk" PRIMARY KEY ("type", "key")
+);
+`
+}
+
+// DeleteLoginThrottleScript gets the DeleteLoginThrottle script.
+func (ScriptRepository) DeleteLoginThrottleScript() string {
+	return `
+DELETE FROM "login_throttle"
+    WHERE "type" = ?1 AND "key" = ?2
+`
+}
+
+// DropLoginThrottleTableScript gets the DropLoginThrottleTable script.
+func (ScriptRepository) DropLoginThrottleTableScript() string {
+	return `
+DROP TABLE "login_throttle"
+`
+}
+
+// GetLockedLoginThrottlesScript gets the GetLockedLoginThrottles script.
+func (ScriptRepository) GetLockedLoginThrottlesScript() string {
+	return `
+SELECT lt."type", lt."key", lt."failed_attempts", lt."last_failed_at", lt."locked_until"
+    FROM "login_throttle" lt
+    WHERE JULIANDAY(lt."locked_until") > JULIANDAY(?1)
+    ORDER BY lt."type", lt."key"
+`
+}
+
+// GetLoginThrottleScript gets the GetLoginThrottle script.
+func (ScriptRepository) GetLoginThrottleScript() string {
+	return `
+SELECT lt."type", lt."key", lt."failed_attempts", lt."last_failed_at", lt."locked_until"
+    FROM "login_throttle" lt
+    WHERE lt."type" = ?1 AND lt."key" = ?2
+`
+}
+
+// SaveLoginThrottleScript gets the SaveLoginThrottle script.
+func (ScriptRepository) SaveLoginThrottleScript() string {
+	return `
+INSERT INTO "login_throttle" ("type", "key", "failed_attempts", "last_failed_at", "locked_until")
+    VALUES (?1, ?2, ?3, ?4, ?5)
+    ON CONFLICT ("type", "key") DO UPDATE SET
+        "failed_attempts" = EXCLUDED."failed_attempts",
+        "last_failed_at" = EXCLUDED."last_failed_at",
+        "locked_until" = EXCLUDED."locked_until"
+`
+}
+
+// CreateMigrationTableScript gets the CreateMigrationTable script.
+func (ScriptRepository) CreateMigrationTableScript() string {
+	return `
+CREATE TABLE IF NOT EXISTS "migration" (
+    "timestamp" CHAR(3) NOT NULL,
+    CONSTRAINT "migration_pk" PRIMARY KEY ("timestamp")
+);
+`
+}
+
+// DeleteMigrationByTimestampScript gets the DeleteMigrationByTimestamp script.
+func (ScriptRepository) DeleteMigrationByTimestampScript() string {
+	return `
+DELETE FROM "migration"
+   WHERE "timestamp" = ?1
+`
+}
+
+// GetLatestTimestampScript gets the GetLatestTimestamp script.
+func (ScriptRepository) GetLatestTimestampScript() string {
+	return `
+SELECT m."timestamp" FROM "migration" m
+    ORDER BY m."timestamp" DESC
+    LIMIT 1
+`
+}
+
+// GetMigrationByTimestampScript gets the GetMigrationByTimestamp script.
+func (ScriptRepository) GetMigrationByTimestampScript() string {
+	return `
+SELECT m."timestamp" 
+    FROM "migration" m 
+    WHERE m."timestamp" = ?1
+`
+}
+
+// SaveMigrationScript gets the SaveMigration script.
+func (ScriptRepository) SaveMigrationScript() string {
+	return `
+INSERT INTO "migration" ("timestamp") 
+    VALUES (?1)
+`
+}
+
+// CreatePasswordResetTokenTableScript gets the CreatePasswordResetTokenTable script.
+func (ScriptRepository) CreatePasswordResetTokenTableScript() string {
+	return `
+CREATE TABLE "password_reset_token" (
+	"token_hash" BLOB NOT NULL,
+	"user_key" INTEGER NOT NULL,
+	"expires_at" TIMESTAMP NOT NULL,
+	CONSTRAINT "password_reset_token_pk" PRIMARY KEY ("token_hash"),
+	CONSTRAINT "password_reset_token_user_fk" FOREIGN KEY ("user_key") REFERENCES "user"("key") ON DELETE CASCADE
+);
+`
+}
+
+// DeleteAllUserPasswordResetTokensScript gets the DeleteAllUserPasswordResetTokens script.
+func (ScriptRepository) DeleteAllUserPasswordResetTokensScript() string {
+	return `
+DELETE FROM "password_reset_token"
+    WHERE "user_key" IN (
+        SELECT u."key" FROM "user" u WHERE u."username" = ?1
+    )
+`
+}
+
+// DeletePasswordResetTokenScript gets the DeletePasswordResetToken script.
+func (ScriptRepository) DeletePasswordResetTokenScript() string {
+	return `
+DELETE FROM "password_reset_token"
+    WHERE "token_hash" = ?1
+`
+}
+
+// DropPasswordResetTokenTableScript gets the DropPasswordResetTokenTable script.
+func (ScriptRepository) DropPasswordResetTokenTableScript() string {
+	return `
+DROP TABLE "password_reset_token"
+`
+}
+
+// GetPasswordResetTokenScript gets the GetPasswordResetToken script.
+func (ScriptRepository) GetPasswordResetTokenScript() string {
+	return `
+SELECT prt."token_hash", u."username", prt."expires_at"
+    FROM "password_reset_token" prt
+        INNER JOIN "user" u ON u."key" = prt."user_key"
+    WHERE prt."token_hash" = ?1
+`
+}
+
+// SavePasswordResetTokenScript gets the SavePasswordResetToken script.
+func (ScriptRepository) SavePasswordResetTokenScript() string {
+	return `
+WITH
+    t1 AS (SELECT u."key" FROM "user" u WHERE u."username" = ?2)
+INSERT INTO "password_reset_token" ("token_hash", "user_key", "expires_at")
+    SELECT ?1, t1."key", ?3
+        FROM t1
+`
+}
+
+// CreatePreviousPasswordTableScript gets the CreatePreviousPasswordTable script.
+func (ScriptRepository) CreatePreviousPasswordTableScript() string {
+	return `
+CREATE TABLE "previous_password" (
+	"key" INTEGER NOT NULL,
+	"user_key" INTEGER NOT NULL,
+	"password_hash" BLOB NOT NULL,
+	"replaced_at" TIMESTAMP NOT NULL,
+	CONSTRAINT "previous_password_pk" PRIMARY KEY ("key"),
+	CONSTRAINT "previous_password_user_fk" FOREIGN KEY ("user_key") REFERENCES "user"("key") ON DELETE CASCADE
+);
+`
+}
+
+// DeleteUserPreviousPasswordsReplacedBeforeScript gets the DeleteUserPreviousPasswordsReplacedBefore script.
+func (ScriptRepository) DeleteUserPreviousPasswordsReplacedBeforeScript() string {
+	return `
+DELETE FROM "previous_password"
+    WHERE "user_key" IN (
+        SELECT u."key" FROM "user" u WHERE u."username" = ?1
+    )
+    AND JULIANDAY("replaced_at") < JULIANDAY(?2)
+`
+}
+
+// DropPreviousPasswordTableScript gets the DropPreviousPasswordTable script.
+func (ScriptRepository) DropPreviousPasswordTableScript() string {
+	return `
+DROP TABLE "previous_password"
+`
+}
+
+// GetLatestUserPreviousPasswordsScript gets the GetLatestUserPreviousPasswords script.
+func (ScriptRepository) GetLatestUserPreviousPasswordsScript() string {
+	return `
+SELECT u."username", pp."password_hash", pp."replaced_at"
+    FROM "previous_password" pp
+        INNER JOIN "user" u ON u."key" = pp."user_key"
+    WHERE u."username" = ?1
+    ORDER BY pp."replaced_at" DESC, pp."key" DESC
+    LIMIT ?2
+`
+}
+
+// SavePreviousPasswordScript gets the SavePreviousPassword script.
+func (ScriptRepository) SavePreviousPasswordScript() string {
+	return `
+WITH
+    t1 AS (SELECT u."key" FROM "user" u WHERE u."username" = ?1)
+INSERT INTO "previous_password" ("user_key", "password_hash", "replaced_at")
+    SELECT t1."key", ?2, ?3
+        FROM t1
+`
+}
+
+// CreateRecoveryCodeTableScript gets the CreateRecoveryCodeTable script.
+func (ScriptRepository) CreateRecoveryCodeTableScript() string {
+	return `
+CREATE TABLE "recovery_code" (
+	"user_key" INTEGER NOT NULL,
+	"code_hash" BLOB NOT NULL,
+	CONSTRAINT "recovery_code_pk" PRIMARY KEY ("user_key", "code_hash"),
+	CONSTRAINT "recovery_code_user_fk" FOREIGN KEY ("user_key") REFERENCES "user"("key") ON DELETE CASCADE
+);
+`
+}
+
+// DeleteAllUserRecoveryCodesScript gets the DeleteAllUserRecoveryCodes script.
+func (ScriptRepository) DeleteAllUserRecoveryCodesScript() string {
+	return `
+DELETE FROM "recovery_code"
+    WHERE "user_key" IN (
+        SELECT u."key" FROM "user" u WHERE u."username" = ?1
+    )
+`
+}
+
+// DeleteRecoveryCodeScript gets the DeleteRecoveryCode script.
+func (ScriptRepository) DeleteRecoveryCodeScript() string {
+	return `
+DELETE FROM "recovery_code"
+    WHERE "code_hash" = ?2 AND "user_key" IN (
+        SELECT u."key" FROM "user" u WHERE u."username" = ?1
+    )
+`
+}
+
+// DropRecoveryCodeTableScript gets the DropRecoveryCodeTable script.
+func (ScriptRepository) DropRecoveryCodeTableScript() string {
+	return `
+DROP TABLE "recovery_code"
+`
+}
+
+// SaveRecoveryCodeScript gets the SaveRecoveryCode script.
+func (ScriptRepository) SaveRecoveryCodeScript() string {
+	return `
+WITH
+    t1 AS (SELECT u."key" FROM "user" u WHERE u."username" = ?1)
+INSERT INTO "recovery_code" ("user_key", "code_hash")
+    SELECT t1."key", ?2
+        FROM t1
+`
+}
+
+// CreateRefreshTokenTableScript gets the CreateRefreshTokenTable script.
+func (ScriptRepository) CreateRefreshTokenTableScript() string {
+	return `
+CREATE TABLE "refresh_token" (
+	"token" TEXT NOT NULL,
+	"family_id" TEXT NOT NULL,
+	"client_key" INTEGER NOT NULL,
+	"user_key" INTEGER NOT NULL,
+	"rotated" BOOLEAN NOT NULL DEFAULT FALSE,
+	"expires_at" TIMESTAMP NOT NULL,
+	CONSTRAINT "refresh_token_pk" PRIMARY KEY ("token"),
+	CONSTRAINT "refresh_token_client_fk" FOREIGN KEY ("client_key") REFERENCES "client"("key") ON DELETE CASCADE,
+	CONSTRAINT "refresh_token_user_fk" FOREIGN KEY ("user_key") REFERENCES "user"("key") ON DELETE CASCADE
+);
+`
+}
+
+// DeleteRefreshTokenFamilyScript gets the DeleteRefreshTokenFamily script.
+func (ScriptRepository) DeleteRefreshTokenFamilyScript() string {
+	return `
+DELETE FROM "refresh_token"
+    WHERE "family_id" = ?1
+`
+}
+
+// DropRefreshTokenTableScript gets the DropRefreshTokenTable script.
+func (ScriptRepository) DropRefreshTokenTableScript() string {
+	return `
+DROP TABLE "refresh_token"
+`
+}
+
+// GetRefreshTokenScript gets the GetRefreshToken script.
+func (ScriptRepository) GetRefreshTokenScript() string {
+	return `
+SELECT rt."token", rt."family_id", c."uid", u."username", rt."rotated", rt."expires_at"
+    FROM "refresh_token" rt
+        INNER JOIN "client" c ON c."key" = rt."client_key"
+        INNER JOIN "user" u ON u."key" = rt."user_key"
+    WHERE rt."token" = ?1
+`
+}
+
+// RotateRefreshTokenScript gets the RotateRefreshToken script.
+func (ScriptRepository) RotateRefreshTokenScript() string {
+	return `
+UPDATE "refresh_token" SET
+    "rotated" = TRUE
+WHERE "token" = ?1 AND "rotated" = FALSE
+`
+}
+
+// SaveRefreshTokenScript gets the SaveRefreshToken script.
+func (ScriptRepository) SaveRefreshTokenScript() string {
+	return `
+WITH
+    t1 AS (SELECT c."key" FROM "client" c WHERE c."uid" = ?3),
+    t2 AS (SELECT u."key" FROM "user" u WHERE u."username" = ?4)
+INSERT INTO "refresh_token" ("token", "family_id", "client_key", "user_key", "rotated", "expires_at")
+    SELECT ?1, ?2, t1."key", t2."key", ?5, ?6
+        FROM t1, t2
+`
+}
+
+// CreateRoleDefinitionScript gets the CreateRoleDefinition script.
+func (ScriptRepository) CreateRoleDefinitionScript() string {
+	return `
+INSERT INTO "role_definition" ("client_key", "name", "description", "is_default")
+    SELECT c."key", ?2, ?3, ?4
+        FROM "client" c
+    WHERE c."uid" = ?1
+`
+}
+
+// CreateRoleDefinitionTableScript gets the CreateRoleDefinitionTable script.
+func (ScriptRepository) CreateRoleDefinitionTableScript() string {
+	return `
+CREATE TABLE "role_definition" (
+	"client_key" INTEGER NOT NULL,
+	"name" VARCHAR(15) NOT NULL,
+	"description" VARCHAR(255) NOT NULL,
+	"is_default" BOOLEAN NOT NULL,
+	CONSTRAINT "role_definition_pk" PRIMARY KEY ("client_key", "name"),
+	CONSTRAINT "role_definition_client_fk" FOREIGN KEY ("client_key") REFERENCES "client"("key") ON DELETE CASCADE
+);
+`
+}
+
+// CreateRoleDefinitionsFromAssignedRolesScript gets the CreateRoleDefinitionsFromAssignedRoles script.
+func (ScriptRepository) CreateRoleDefinitionsFromAssignedRolesScript() string {
+	return `
+INSERT INTO "role_definition" ("client_key", "name", "description", "is_default")
+    SELECT r."client_key", r."role", '', FALSE
+        FROM (
+            SELECT ur."client_key", ur."role" FROM "user_role" ur
+            UNION
+            SELECT ir."client_key", ir."role" FROM "invitation_role" ir
+        ) r
+`
+}
+
+// DeleteRoleDefinitionScript gets the DeleteRoleDefinition script.
+func (ScriptRepository) DeleteRoleDefinitionScript() string {
+	return `
+DELETE FROM "role_definition"
+    WHERE "client_key" IN (SELECT c."key" FROM "client" c WHERE c."uid" = ?1) AND
+          "name" = ?2
+`
+}
+
+// DropRoleDefinitionTableScript gets the DropRoleDefinitionTable script.
+func (ScriptRepository) DropRoleDefinitionTableScript() string {
+	return `
+DROP TABLE "role_definition"
+`
+}
+
+// GetRoleDefinitionByClientUIDAndNameScript gets the GetRoleDefinitionByClientUIDAndName script.
+func (ScriptRepository) GetRoleDefinitionByClientUIDAndNameScript() string {
+	return `
+SELECT c."uid", rd."name", rd."description", rd."is_default"
+    FROM "role_definition" rd
+        INNER JOIN "client" c ON c."uid" = ?1 AND c."key" = rd."client_key"
+    WHERE rd."name" = ?2
+`
+}
+
+// GetRoleDefinitionsByClientUIDScript gets the GetRoleDefinitionsByClientUID script.
+func (ScriptRepository) GetRoleDefinitionsByClientUIDScript() string {
+	return `
+SELECT c."uid", rd."name", rd."description", rd."is_default"
+    FROM "role_definition" rd
+        INNER JOIN "client" c ON c."uid" = ?1 AND c."key" = rd."client_key"
+    ORDER BY rd."name"
+`
+}
+
+// UpdateRoleDefinitionScript gets the UpdateRoleDefinition script.
+func (ScriptRepository) UpdateRoleDefinitionScript() string {
+	return `
+UPDATE "role_definition" SET
+    "name" = ?3,
+    "description" = ?4,
+    "is_default" = ?5
+WHERE "client_key" IN (SELECT c."key" FROM "client" c WHERE c."uid" = ?1) AND
+      "name" = ?2
+`
+}
+
+// AddSessionTimestampColumnsScript gets the AddSessionTimestampColumns script.
+func (ScriptRepository) AddSessionTimestampColumnsScript() string {
+	return `
+ALTER TABLE "session"
+	ADD COLUMN "created_at" TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00';
+
+ALTER TABLE "session"
+	ADD COLUMN "last_used_at" TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00';
+
+--sqlite cannot default new columns to the current time, so existing sessions are set to it afterwards
+UPDATE "session" SET
+	"created_at" = DATETIME('now'),
+	"last_used_at" = DATETIME('now');
+`
+}
+
+// CreateSessionTableScript gets the CreateSessionTable script.
+func (ScriptRepository) CreateSessionTableScript() string {
+	return `
+CREATE TABLE "session" (
+	"token" TEXT NOT NULL,
+	"user_key" INTEGER NOT NULL,
+	CONSTRAINT "session_pk" PRIMARY KEY ("token"),
+	CONSTRAINT "session_user_fk" FOREIGN KEY ("user_key") REFERENCES "user"("key") ON DELETE CASCADE
+);
+`
+}
+
+// DeleteAllOtherUserSessionsScript gets the DeleteAllOtherUserSessions script.
+func (ScriptRepository) DeleteAllOtherUserSessionsScript() string {
+	return `
+DELETE FROM "session"
+    WHERE "token" != ?1 AND
+        "user_key" IN (
+            SELECT u."key" FROM "user" u WHERE u."username" = ?2
+        )
+`
+}
+
+// DeleteAllUserSessionsScript gets the DeleteAllUserSessions script.
+func (ScriptRepository) DeleteAllUserSessionsScript() string {
+	return `
+DELETE FROM "session"
+    WHERE "user_key" IN (
+        SELECT u."key" FROM "user" u WHERE u."username" = ?1
+    )
+`
+}
+
+// DeleteExpiredSessionsScript gets the DeleteExpiredSessions script.
+func (ScriptRepository) DeleteExpiredSessionsScript() string {
+	return `
+DELETE FROM "session"
+    WHERE JULIANDAY("created_at") < JULIANDAY(?1) OR
+        JULIANDAY("last_used_at") < JULIANDAY(?2)
+`
+}
+
+// DeleteSessionScript gets the DeleteSession script.
+func (ScriptRepository) DeleteSessionScript() string {
+	return `
+DELETE FROM "session"
+    WHERE "token" = ?1
+`
+}
+
+// DropSessionTableScript gets the DropSessionTable script.
+func (ScriptRepository) DropSessionTableScript() string {
+	return `
+DROP TABLE "session"
+`
+}
+
+// DropSessionTimestampColumnsScript gets the DropSessionTimestampColumns script.
+func (ScriptRepository) DropSessionTimestampColumnsScript() string {
+	return `
+ALTER TABLE "session"
+	DROP COLUMN "created_at";
+
+ALTER TABLE "session"
+	DROP COLUMN "last_used_at";
+`
+}
+
+// GetSessionByTokenScript gets the GetSessionByToken script.
+func (ScriptRepository) GetSessionByTokenScript() string {
+	return `
+SELECT s."token", u."username", u."rank", s."created_at", s."last_used_at"
+    FROM "session" s
+        INNER JOIN "user" u ON u."key" = s."user_key"
+    WHERE s."token" = ?1
+`
+}
+
+// SaveSessionScript gets the SaveSession script.
+func (ScriptRepository) SaveSessionScript() string {
+	return `
+WITH
+    t1 AS (SELECT u."key" FROM "user" u WHERE u."username" = ?2)
+INSERT INTO "session" ("token", "user_key", "created_at", "last_used_at")
+    SELECT ?1, t1."key", ?3, ?4
+        FROM t1
+`
+}
+
+// UpdateSessionLastUsedAtScript gets the UpdateSessionLastUsedAt script.
+func (ScriptRepository) UpdateSessionLastUsedAtScript() string {
+	return `
+UPDATE "session" SET
+    "last_used_at" = ?2
+WHERE "token" = ?1
+`
+}
+
+// AddUserPasswordUpdatedAtColumnScript gets the AddUserPasswordUpdatedAtColumn script.
+func (ScriptRepository) AddUserPasswordUpdatedAtColumnScript() string {
+	return `
+ALTER TABLE "user"
+	ADD COLUMN "password_updated_at" TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00';
+
+--sqlite cannot default new columns to the current time, so existing users are set to it afterwards
+UPDATE "user" SET
+	"password_updated_at" = DATETIME('now');
+`
+}
+
+// AddUserProfileColumnsScript gets the AddUserProfileColumns script.
+func (ScriptRepository) AddUserProfileColumnsScript() string {
+	return `
+ALTER TABLE "user"
+	ADD COLUMN "email" VARCHAR(254);
+
+ALTER TABLE "user"
+	ADD COLUMN "display_name" VARCHAR(64) NOT NULL DEFAULT '';
+
+ALTER TABLE "user"
+	ADD COLUMN "enabled" BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE UNIQUE INDEX "user_email_un" ON "user" ("email");
+`
+}
+
+// AddUserTOTPColumnsScript gets the AddUserTOTPColumns script.
+func (ScriptRepository) AddUserTOTPColumnsScript() string {
+	return `
+ALTER TABLE "user"
+	ADD COLUMN "totp_secret" BLOB;
+
+ALTER TABLE "user"
+	ADD COLUMN "totp_enabled" BOOLEAN NOT NULL DEFAULT FALSE;
+`
+}
+
+// AddUserTOTPLastTimeStepColumnScript gets the AddUserTOTPLastTimeStepColumn script.
+func (ScriptRepository) AddUserTOTPLastTimeStepColumnScript() string {
+	return `
+ALTER TABLE "user"
+	ADD COLUMN "totp_last_time_step" INTEGER NOT NULL DEFAULT 0;
+`
+}
+
+// CountUsersWithLesserRankScript gets the CountUsersWithLesserRank script.
+func (ScriptRepository) CountUsersWithLesserRankScript() string {
+	return `
+SELECT COUNT(*)
+	FROM "user" u
+	WHERE u."rank" < ?1
+		AND LOWER(SUBSTR(u."username", 1, LENGTH(?2))) = LOWER(?2)
+`
+}
+
+// CreateUserScript gets the CreateUser script.
+func (ScriptRepository) CreateUserScript() string {
+	return `
+INSERT INTO "user" ("username", "rank", "password_hash", "password_updated_at", "email", "display_name", "enabled")
+	VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)
+`
+}
+
+// CreateUserTableScript gets the CreateUserTable script.
+func (ScriptRepository) CreateUserTableScript() string {
+	return `
+CREATE TABLE "user" (
+	"key" INTEGER NOT NULL,
+	"username" VARCHAR(30) NOT NULL,
+	"rank" INTEGER NOT NULL,
+	"password_hash" BLOB NOT NULL,
+	CONSTRAINT "user_pk" PRIMARY KEY ("key"),
+	CONSTRAINT "user_username_un" UNIQUE ("username")
+);
+`
+}
+
+// DeleteUserScript gets the DeleteUser script.
+func (ScriptRepository) DeleteUserScript() string {
+	return `
+DELETE FROM "user"
+    WHERE "username" = ?1
+`
+}
+
+// DropUserPasswordUpdatedAtColumnScript gets the DropUserPasswordUpdatedAtColumn script.
+func (ScriptRepository) DropUserPasswordUpdatedAtColumnScript() string {
+	return `
+ALTER TABLE "user"
+	DROP COLUMN "password_updated_at";
+`
+}
+
+// DropUserProfileColumnsScript gets the DropUserProfileColumns script.
+func (ScriptRepository) DropUserProfileColumnsScript() string {
+	return `
+DROP INDEX "user_email_un";
+
+ALTER TABLE "user"
+	DROP COLUMN "email";
+
+ALTER TABLE "user"
+	DROP COLUMN "display_name";
+
+ALTER TABLE "user"
+	DROP COLUMN "enabled";
+`
+}
+
+// DropUserTOTPColumnsScript gets the DropUserTOTPColumns script.
+func (ScriptRepository) DropUserTOTPColumnsScript() string {
+	return `
+ALTER TABLE "user"
+	DROP COLUMN "totp_secret";
+
+ALTER TABLE "user"
+	DROP COLUMN "totp_enabled";
+`
+}
+
+// DropUserTOTPLastTimeStepColumnScript gets the DropUserTOTPLastTimeStepColumn script.
+func (ScriptRepository) DropUserTOTPLastTimeStepColumnScript() string {
+	return `
+ALTER TABLE "user"
+	DROP COLUMN "totp_last_time_step";
+`
+}
+
+// DropUserTableScript gets the DropUserTable script.
+func (ScriptRepository) DropUserTableScript() string {
+	return `
+DROP TABLE "user"
+`
+}
+
+// GetUserByEmailScript gets the GetUserByEmail script.
+func (ScriptRepository) GetUserByEmailScript() string {
+	return `
+SELECT u."username", u."rank", u."password_hash", u."password_updated_at", u."totp_secret", u."totp_enabled", u."totp_last_time_step",
+		u."email", u."display_name", u."enabled"
+	FROM "user" u
+	WHERE u."email" = ?1
+`
+}
+
+// GetUserByUsernameScript gets the GetUserByUsername script.
+func (ScriptRepository) GetUserByUsernameScript() string {
+	return `
+SELECT u."username", u."rank", u."password_hash", u."password_updated_at", u."totp_secret", u."totp_enabled", u."totp_last_time_step",
+		u."email", u."display_name", u."enabled"
+	FROM "user" u
+	WHERE u."username" = ?1
+`
+}
+
+// GetUsersWithLesserRankSortedByRankScript gets the GetUsersWithLesserRankSortedByRank script.
+func (ScriptRepository) GetUsersWithLesserRankSortedByRankScript() string {
+	return `
+SELECT u."username", u."rank", u."password_hash", u."password_updated_at", u."totp_secret", u."totp_enabled", u."totp_last_time_step",
+		u."email", u."display_name", u."enabled"
+	FROM "user" u
+	WHERE u."rank" < ?1
+		AND LOWER(SUBSTR(u."username", 1, LENGTH(?2))) = LOWER(?2)
+		AND (NOT ?4
+			OR (?3 AND (u."rank" < ?5 OR (u."rank" = ?5 AND u."username" < ?6)))
+			OR (NOT ?3 AND (u."rank" > ?5 OR (u."rank" = ?5 AND u."username" > ?6))))
+	ORDER BY
+		CASE WHEN ?3 THEN u."rank" END DESC,
+		CASE WHEN ?3 THEN u."username" END DESC,
+		u."rank",
+		u."username"
+	LIMIT ?7
+`
+}
+
+// GetUsersWithLesserRankSortedByUsernameScript gets the GetUsersWithLesserRankSortedByUsername script.
+func (ScriptRepository) GetUsersWithLesserRankSortedByUsernameScript() string {
+	return `
+SELECT u."username", u."rank", u."password_hash", u."password_updated_at", u."totp_secret", u."totp_enabled", u."totp_last_time_step",
+		u."email", u."display_name", u."enabled"
+	FROM "user" u
+	WHERE u."rank" < ?1
+		AND LOWER(SUBSTR(u."username", 1, LENGTH(?2))) = LOWER(?2)
+		AND (NOT ?4
+			OR (?3 AND u."username" < ?5)
+			OR (NOT ?3 AND u."username" > ?5))
+	ORDER BY
+		CASE WHEN ?3 THEN u."username" END DESC,
+		u."username"
+	LIMIT ?6
+`
+}
+
+// UpdateUserScript gets the UpdateUser script.
+func (ScriptRepository) UpdateUserScript() string {
+	return `
+UPDATE "user" SET
+    "rank" = ?2,
+    "email" = ?3,
+    "display_name" = ?4,
+    "enabled" = ?5
+WHERE "username" = ?1
+`
+}
+
+// UpdateUserPasswordScript gets the UpdateUserPassword script.
+func (ScriptRepository) UpdateUserPasswordScript() string {
+	return `
+UPDATE "user" SET
+    "password_hash" = ?2,
+    "password_updated_at" = ?3
+WHERE "username" = ?1
+`
+}
+
+// UpdateUserTOTPScript gets the UpdateUserTOTP script.
+func (ScriptRepository) UpdateUserTOTPScript() string {
+	return `
+UPDATE "user" SET
+    "totp_secret" = ?2,
+    "totp_enabled" = ?3
+WHERE "username" = ?1
+`
+}
+
+// UpdateUserTOTPLastTimeStepScript gets the UpdateUserTOTPLastTimeStep script.
+func (ScriptRepository) UpdateUserTOTPLastTimeStepScript() string {
+	return `
+UPDATE "user" SET
+    "totp_last_time_step" = ?2
+WHERE "username" = ?1
+    AND "totp_last_time_step" < ?2
+`
+}
+
+// AddRoleToUserRolePrimaryKeyScript gets the AddRoleToUserRolePrimaryKey script.
+func (ScriptRepository) AddRoleToUserRolePrimaryKeyScript() string {
+	return `
+CREATE TABLE "user_role_new" (
+	"client_key" INTEGER NOT NULL,
+	"user_key" INTEGER NOT NULL,
+	"role" VARCHAR(15) NOT NULL,
+	CONSTRAINT "user_role_pk" PRIMARY KEY ("client_key", "user_key", "role"),
+	CONSTRAINT "user_role_client_fk" FOREIGN KEY ("client_key") REFERENCES "client"("key") ON DELETE CASCADE,
+	CONSTRAINT "user_role_user_fk" FOREIGN KEY ("user_key") REFERENCES "user"("key") ON DELETE CASCADE
+);
+
+INSERT INTO "user_role_new" ("client_key", "user_key", "role")
+	SELECT "client_key", "user_key", "role" FROM "user_role";
+
+DROP TABLE "user_role";
+
+ALTER TABLE "user_role_new" RENAME TO "user_role";
+`
+}
+
+// CountUserRolesByClientUIDAndRoleScript gets the CountUserRolesByClientUIDAndRole script.
+func (ScriptRepository) CountUserRolesByClientUIDAndRoleScript() string {
+	return `
+SELECT COUNT(*)
+    FROM "user_role" ur
+        INNER JOIN "client" c on c."uid" = ?1 AND c."key" = ur."client_key"
+    WHERE ur."role" = ?2
+`
+}
+
+// CountUserRolesWithLesserRankByClientUIDScript gets the CountUserRolesWithLesserRankByClientUID script.
+func (ScriptRepository) CountUserRolesWithLesserRankByClientUIDScript() string {
+	return `
+SELECT COUNT(DISTINCT ur."user_key")
+    FROM "user_role" ur
+        INNER JOIN "client" c on c."uid" = ?1 AND c."key" = ur."client_key"
+        INNER JOIN "user" u on u."rank" < ?2 AND u."key" = ur."user_key"
+    WHERE LOWER(SUBSTR(u."username", 1, LENGTH(?3))) = LOWER(?3)
+`
+}
+
+// CreateUserRoleScript gets the CreateUserRole script.
+func (ScriptRepository) CreateUserRoleScript() string {
+	return `
+WITH
+    t1 AS (SELECT c."key" FROM "client" c WHERE c."uid" = ?1),
+    t2 AS (SELECT u."key" FROM "user" u WHERE u."username" = ?2)
+INSERT INTO "user_role" ("client_key", "user_key", "role")
+    SELECT t1."key", t2."key", ?3
+        FROM t1, t2
+`
+}
+
+// CreateUserRoleTableScript gets the CreateUserRoleTable script.
+func (ScriptRepository) CreateUserRoleTableScript() string {
+	return `
+CREATE TABLE "user_role" (
+	"client_key" INTEGER NOT NULL,
+	"user_key" INTEGER NOT NULL,
+	"role" VARCHAR(15) NOT NULL,
+	CONSTRAINT "user_role_pk" PRIMARY KEY ("client_key", "user_key"),
+	CONSTRAINT "user_role_client_fk" FOREIGN KEY ("client_key") REFERENCES "client"("key") ON DELETE CASCADE,
+	CONSTRAINT "user_role_user_fk" FOREIGN KEY ("user_key") REFERENCES "user"("key") ON DELETE CASCADE
+);
+`
+}
+
+// DeleteAllButFirstUserRolesScript gets the DeleteAllButFirstUserRoles script.
+func (ScriptRepository) DeleteAllButFirstUserRolesScript() string {
+	return `
+DELETE FROM "user_role"
+    WHERE EXISTS (
+        SELECT 1 FROM "user_role" ur2
+            WHERE ur2."client_key" = "user_role"."client_key" AND ur2."user_key" = "user_role"."user_key" AND ur2."role" < "user_role"."role"
+    )
+`
+}
+
+// DeleteUserRoleScript gets the DeleteUserRole script.
+func (ScriptRepository) DeleteUserRoleScript() string {
+	return `
+DELETE FROM "user_role"
+    WHERE "client_key" IN (SELECT c."key" FROM "client" c WHERE c."uid" = ?1) AND
+          "user_key" IN (SELECT u."key" FROM "user" u WHERE u."username" = ?2)
+`
+}
+
+// DropUserRoleTableScript gets the DropUserRoleTable script.
+func (ScriptRepository) DropUserRoleTableScript() string {
+	return `
+DROP TABLE "user_role"
+`
+}
+
+// GetUserRoleByClientUIDAndUsernameScript gets the GetUserRoleByClientUIDAndUsername script.
+func (ScriptRepository) GetUserRoleByClientUIDAndUsernameScript() string {
+	return `
+SELECT c."uid", u."username", ur."role"
+    FROM "user_role" ur
+        INNER JOIN "client" c on c."uid" = ?1 AND c."key" = ur."client_key"
+        INNER JOIN "user" u on u."username" = ?2 AND u."key" = ur."user_key"
+    ORDER BY ur."role"
+`
+}
+
+// GetUserRolesWithLesserRankByClientUIDSortedByRoleScript gets the GetUserRolesWithLesserRankByClientUIDSortedByRole script.
+func (ScriptRepository) GetUserRolesWithLesserRankByClientUIDSortedByRoleScript() string {
+	return `
+SELECT c."uid", p."username", ur."role"
+    FROM (
+        SELECT ur."client_key", ur."user_key", u."username", MIN(ur."role") AS "first_role"
+            FROM "user_role" ur
+                INNER JOIN "client" c on c."uid" = ?1 AND c."key" = ur."client_key"
+                INNER JOIN "user" u on u."rank" < ?2 AND u."key" = ur."user_key"
+            WHERE LOWER(SUBSTR(u."username", 1, LENGTH(?3))) = LOWER(?3)
+            GROUP BY ur."client_key", ur."user_key", u."username"
+            HAVING NOT ?5
+                OR (?4 AND (MIN(ur."role") < ?6 OR (MIN(ur."role") = ?6 AND u."username" < ?7)))
+                OR (NOT ?4 AND (MIN(ur."role") > ?6 OR (MIN(ur."role") = ?6 AND u."username" > ?7)))
+            ORDER BY
+                CASE WHEN ?4 THEN MIN(ur."role") END DESC,
+                CASE WHEN ?4 THEN u."username" END DESC,
+                MIN(ur."role"),
+                u."username"
+            LIMIT ?8
+    ) p
+        INNER JOIN "client" c on c."key" = p."client_key"
+        INNER JOIN "user_role" ur on ur."client_key" = p."client_key" AND ur."user_key" = p."user_key"
+    ORDER BY
+        CASE WHEN ?4 THEN p."first_role" END DESC,
+        CASE WHEN ?4 THEN p."username" END DESC,
+        p."first_role",
+        p."username",
+        ur."role"
+`
+}
+
+// GetUserRolesWithLesserRankByClientUIDSortedByUsernameScript gets the GetUserRolesWithLesserRankByClientUIDSortedByUsername script.
+func (ScriptRepository) GetUserRolesWithLesserRankByClientUIDSortedByUsernameScript() string {
+	return `
+SELECT c."uid", p."username", ur."role"
+    FROM (
+        SELECT ur."client_key", ur."user_key", u."username"
+            FROM "user_role" ur
+                INNER JOIN "client" c on c."uid" = ?1 AND c."key" = ur."client_key"
+                INNER JOIN "user" u on u."rank" < ?2 AND u."key" = ur."user_key"
+            WHERE LOWER(SUBSTR(u."username", 1, LENGTH(?3))) = LOWER(?3)
+                AND (NOT ?5
+                    OR (?4 AND u."username" < ?6)
+                    OR (NOT ?4 AND u."username" > ?6))
+            GROUP BY ur."client_key", ur."user_key", u."username"
+            ORDER BY
+                CASE WHEN ?4 THEN u."username" END DESC,
+                u."username"
+            LIMIT ?7
+    ) p
+        INNER JOIN "client" c on c."key" = p."client_key"
+        INNER JOIN "user_role" ur on ur."client_key" = p."client_key" AND ur."user_key" = p."user_key"
+    ORDER BY
+        CASE WHEN ?4 THEN p."username" END DESC,
+        p."username",
+        ur."role"
+`
+}
+
+// RemoveRoleFromUserRolePrimaryKeyScript gets the RemoveRoleFromUserRolePrimaryKey script.
+func (ScriptRepository) RemoveRoleFromUserRolePrimaryKeyScript() string {
+	return `
+CREATE TABLE "user_role_new" (
+	"client_key" INTEGER NOT NULL,
+	"user_key" INTEGER NOT NULL,
+	"role" VARCHAR(15) NOT NULL,
+	CONSTRAINT "user_role_pk" PRIMARY KEY ("client_key", "user_key"),
+	CONSTRAINT "user_role_client_fk" FOREIGN KEY ("client_key") REFERENCES "client"("key") ON DELETE CASCADE,
+	CONSTRAINT "user_role_user_fk" FOREIGN KEY ("user_key") REFERENCES "user"("key") ON DELETE CASCADE
+);
+
+INSERT INTO "user_role_new" ("client_key", "user_key", "role")
+	SELECT "client_key", "user_key", "role" FROM "user_role";
+
+DROP TABLE "user_role";
+
+ALTER TABLE "user_role_new" RENAME TO "user_role";
+`
+}
+
+// RenameUserRolesScript gets the RenameUserRoles script.
+func (ScriptRepository) RenameUserRolesScript() string {
+	return `
+UPDATE "user_role" SET
+    "role" = ?3
+WHERE "client_key" IN (SELECT c."key" FROM "client" c WHERE c."uid" = ?1) AND
+      "role" = ?2
+`
+}
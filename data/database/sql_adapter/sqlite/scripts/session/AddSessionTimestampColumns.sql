@@ -0,0 +1,10 @@
+ALTER TABLE "session"
+	ADD COLUMN "created_at" TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00';
+
+ALTER TABLE "session"
+	ADD COLUMN "last_used_at" TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00';
+
+--sqlite cannot default new columns to the current time, so existing sessions are set to it afterwards
+UPDATE "session" SET
+	"created_at" = DATETIME('now'),
+	"last_used_at" = DATETIME('now');
\ No newline at end of file
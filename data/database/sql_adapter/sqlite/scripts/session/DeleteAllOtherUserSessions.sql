@@ -0,0 +1,5 @@
+DELETE FROM "session"
+    WHERE "token" != ?1 AND
+        "user_key" IN (
+            SELECT u."key" FROM "user" u WHERE u."username" = ?2
+        )
\ No newline at end of file
@@ -0,0 +1,4 @@
+DELETE FROM "session"
+    WHERE "user_key" IN (
+        SELECT u."key" FROM "user" u WHERE u."username" = ?1
+    )
\ No newline at end of file
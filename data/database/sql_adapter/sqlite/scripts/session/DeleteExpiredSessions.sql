@@ -0,0 +1,3 @@
+DELETE FROM "session"
+    WHERE JULIANDAY("created_at") < JULIANDAY(?1) OR
+        JULIANDAY("last_used_at") < JULIANDAY(?2)
\ No newline at end of file
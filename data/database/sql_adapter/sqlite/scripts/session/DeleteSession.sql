@@ -0,0 +1,2 @@
+DELETE FROM "session"
+    WHERE "token" = ?1
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE "session"
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE "session"
+	DROP COLUMN "created_at";
+
+ALTER TABLE "session"
+	DROP COLUMN "last_used_at";
\ No newline at end of file
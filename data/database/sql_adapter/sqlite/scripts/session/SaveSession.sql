@@ -0,0 +1,5 @@
+WITH
+    t1 AS (SELECT u."key" FROM "user" u WHERE u."username" = ?2)
+INSERT INTO "session" ("token", "user_key", "created_at", "last_used_at")
+    SELECT ?1, t1."key", ?3, ?4
+        FROM t1
\ No newline at end of file
@@ -0,0 +1,3 @@
+UPDATE "session" SET
+    "last_used_at" = ?2
+WHERE "token" = ?1
\ No newline at end of file
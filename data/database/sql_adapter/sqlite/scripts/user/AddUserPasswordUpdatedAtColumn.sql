@@ -0,0 +1,6 @@
+ALTER TABLE "user"
+	ADD COLUMN "password_updated_at" TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00';
+
+--sqlite cannot default new columns to the current time, so existing users are set to it afterwards
+UPDATE "user" SET
+	"password_updated_at" = DATETIME('now');
\ No newline at end of file
@@ -0,0 +1,10 @@
+ALTER TABLE "user"
+	ADD COLUMN "email" VARCHAR(254);
+
+ALTER TABLE "user"
+	ADD COLUMN "display_name" VARCHAR(64) NOT NULL DEFAULT '';
+
+ALTER TABLE "user"
+	ADD COLUMN "enabled" BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE UNIQUE INDEX "user_email_un" ON "user" ("email");
\ No newline at end of file
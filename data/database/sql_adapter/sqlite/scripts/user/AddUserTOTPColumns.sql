@@ -0,0 +1,5 @@
+ALTER TABLE "user"
+	ADD COLUMN "totp_secret" BLOB;
+
+ALTER TABLE "user"
+	ADD COLUMN "totp_enabled" BOOLEAN NOT NULL DEFAULT FALSE;
\ No newline at end of file
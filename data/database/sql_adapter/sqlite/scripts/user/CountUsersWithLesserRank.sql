@@ -0,0 +1,4 @@
+SELECT COUNT(*)
+	FROM "user" u
+	WHERE u."rank" < ?1
+		AND LOWER(SUBSTR(u."username", 1, LENGTH(?2))) = LOWER(?2)
\ No newline at end of file
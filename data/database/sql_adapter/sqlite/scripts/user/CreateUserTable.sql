@@ -0,0 +1,8 @@
+CREATE TABLE "user" (
+	"key" INTEGER NOT NULL,
+	"username" VARCHAR(30) NOT NULL,
+	"rank" INTEGER NOT NULL,
+	"password_hash" BLOB NOT NULL,
+	CONSTRAINT "user_pk" PRIMARY KEY ("key"),
+	CONSTRAINT "user_username_un" UNIQUE ("username")
+);
\ No newline at end of file
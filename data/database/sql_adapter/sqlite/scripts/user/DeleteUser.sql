@@ -0,0 +1,2 @@
+DELETE FROM "user"
+    WHERE "username" = ?1
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE "user"
+	DROP COLUMN "password_updated_at";
\ No newline at end of file
@@ -0,0 +1,10 @@
+DROP INDEX "user_email_un";
+
+ALTER TABLE "user"
+	DROP COLUMN "email";
+
+ALTER TABLE "user"
+	DROP COLUMN "display_name";
+
+ALTER TABLE "user"
+	DROP COLUMN "enabled";
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE "user"
+	DROP COLUMN "totp_secret";
+
+ALTER TABLE "user"
+	DROP COLUMN "totp_enabled";
\ No newline at end of file
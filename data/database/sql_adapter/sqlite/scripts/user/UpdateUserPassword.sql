@@ -0,0 +1,4 @@
+UPDATE "user" SET
+    "password_hash" = ?2,
+    "password_updated_at" = ?3
+WHERE "username" = ?1
\ No newline at end of file
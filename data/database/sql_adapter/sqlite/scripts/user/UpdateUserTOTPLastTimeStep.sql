@@ -0,0 +1,4 @@
+UPDATE "user" SET
+    "totp_last_time_step" = ?2
+WHERE "username" = ?1
+    AND "totp_last_time_step" < ?2
\ No newline at end of file
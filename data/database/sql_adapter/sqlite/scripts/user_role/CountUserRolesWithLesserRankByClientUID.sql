@@ -0,0 +1,5 @@
+SELECT COUNT(DISTINCT ur."user_key")
+    FROM "user_role" ur
+        INNER JOIN "client" c on c."uid" = ?1 AND c."key" = ur."client_key"
+        INNER JOIN "user" u on u."rank" < ?2 AND u."key" = ur."user_key"
+    WHERE LOWER(SUBSTR(u."username", 1, LENGTH(?3))) = LOWER(?3)
\ No newline at end of file
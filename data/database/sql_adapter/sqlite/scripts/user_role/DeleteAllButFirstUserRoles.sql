@@ -0,0 +1,5 @@
+DELETE FROM "user_role"
+    WHERE EXISTS (
+        SELECT 1 FROM "user_role" ur2
+            WHERE ur2."client_key" = "user_role"."client_key" AND ur2."user_key" = "user_role"."user_key" AND ur2."role" < "user_role"."role"
+    )
\ No newline at end of file
@@ -0,0 +1,3 @@
+DELETE FROM "user_role"
+    WHERE "client_key" IN (SELECT c."key" FROM "client" c WHERE c."uid" = ?1) AND
+          "user_key" IN (SELECT u."key" FROM "user" u WHERE u."username" = ?2)
\ No newline at end of file
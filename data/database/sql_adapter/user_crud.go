@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/mhogar/amber/common"
 	"github.com/mhogar/amber/models"
@@ -37,6 +39,118 @@ func (crud *SQLCRUD) DropUserTable() error {
 	return err
 }
 
+// AddUserTOTPColumns adds the TOTP secret and enabled columns to the user table.
+// Returns any errors.
+func (crud *SQLCRUD) AddUserTOTPColumns() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.AddUserTOTPColumnsScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing add user totp columns script", err)
+	}
+
+	return err
+}
+
+// DropUserTOTPColumns drops the TOTP secret and enabled columns from the user table.
+// Returns any errors.
+func (crud *SQLCRUD) DropUserTOTPColumns() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropUserTOTPColumnsScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop user totp columns script", err)
+	}
+
+	return err
+}
+
+// AddUserProfileColumns adds the email, display name, and enabled columns to the user table.
+// Returns any errors.
+func (crud *SQLCRUD) AddUserProfileColumns() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.AddUserProfileColumnsScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing add user profile columns script", err)
+	}
+
+	return err
+}
+
+// DropUserProfileColumns drops the email, display name, and enabled columns from the user table.
+// Returns any errors.
+func (crud *SQLCRUD) DropUserProfileColumns() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropUserProfileColumnsScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop user profile columns script", err)
+	}
+
+	return err
+}
+
+// AddUserPasswordUpdatedAtColumn adds the password updated at column to the user table.
+// Returns any errors.
+func (crud *SQLCRUD) AddUserPasswordUpdatedAtColumn() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.AddUserPasswordUpdatedAtColumnScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing add user password updated at column script", err)
+	}
+
+	return err
+}
+
+// DropUserPasswordUpdatedAtColumn drops the password updated at column from the user table.
+// Returns any errors.
+func (crud *SQLCRUD) DropUserPasswordUpdatedAtColumn() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropUserPasswordUpdatedAtColumnScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop user password updated at column script", err)
+	}
+
+	return err
+}
+
+// AddUserTOTPLastTimeStepColumn adds the TOTP last time step column to the user table.
+// Returns any errors.
+func (crud *SQLCRUD) AddUserTOTPLastTimeStepColumn() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.AddUserTOTPLastTimeStepColumnScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing add user totp last time step column script", err)
+	}
+
+	return err
+}
+
+// DropUserTOTPLastTimeStepColumn drops the TOTP last time step column from the user table.
+// Returns any errors.
+func (crud *SQLCRUD) DropUserTOTPLastTimeStepColumn() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DropUserTOTPLastTimeStepColumnScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop user totp last time step column script", err)
+	}
+
+	return err
+}
+
 func (crud *SQLCRUD) CreateUser(user *models.User) error {
 	//validate the user model
 	verr := user.Validate()
@@ -50,7 +164,7 @@ func (crud *SQLCRUD) CreateUser(user *models.User) error {
 
 	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
 	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateUserScript(),
-		user.Username, user.Rank, user.PasswordHash,
+		user.Username, user.Rank, user.PasswordHash, user.PasswordUpdatedAt, nullString(user.Email), user.DisplayName, user.Enabled,
 	)
 	cancel()
 
@@ -61,9 +175,29 @@ func (crud *SQLCRUD) CreateUser(user *models.User) error {
 	return nil
 }
 
-func (crud *SQLCRUD) GetUsersWithLesserRank(rank int) ([]*models.User, error) {
+func (crud *SQLCRUD) GetUsersWithLesserRank(rank int, query models.PageQuery) ([]*models.User, error) {
+	hasCursor, cursorValue, cursorKey := pageCursor(query)
+
+	//pick the script for the sort field
+	script := crud.SQLDriver.GetUsersWithLesserRankSortedByUsernameScript()
+	args := []interface{}{rank, query.Search, query.SortDescending, hasCursor, cursorKey, pageLimit(query)}
+
+	if query.SortBy == models.UserSortRank {
+		cursorRank := 0
+		if hasCursor {
+			var err error
+			cursorRank, err = strconv.Atoi(cursorValue)
+			if err != nil {
+				return nil, common.ChainError("error parsing cursor rank", err)
+			}
+		}
+
+		script = crud.SQLDriver.GetUsersWithLesserRankSortedByRankScript()
+		args = []interface{}{rank, query.Search, query.SortDescending, hasCursor, cursorRank, cursorKey, pageLimit(query)}
+	}
+
 	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
-	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetUsersWithLesserRankScript(), rank)
+	rows, err := crud.Executor.QueryContext(ctx, script, args...)
 	defer cancel()
 
 	if err != nil {
@@ -87,6 +221,15 @@ func (crud *SQLCRUD) GetUsersWithLesserRank(rank int) ([]*models.User, error) {
 	return users, nil
 }
 
+func (crud *SQLCRUD) CountUsersWithLesserRank(rank int, search string) (int, error) {
+	count, err := crud.queryCount(crud.SQLDriver.CountUsersWithLesserRankScript(), rank, search)
+	if err != nil {
+		return 0, common.ChainError("error counting users with lesser rank", err)
+	}
+
+	return count, nil
+}
+
 func (crud *SQLCRUD) GetUserByUsername(username string) (*models.User, error) {
 	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
 	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetUserByUsernameScript(), username)
@@ -100,6 +243,19 @@ func (crud *SQLCRUD) GetUserByUsername(username string) (*models.User, error) {
 	return readUserData(rows)
 }
 
+func (crud *SQLCRUD) GetUserByEmail(email string) (*models.User, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetUserByEmailScript(), email)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get user by email query", err)
+	}
+	defer rows.Close()
+
+	return readUserData(rows)
+}
+
 func (crud *SQLCRUD) UpdateUser(user *models.User) (bool, error) {
 	//validate the user model
 	verr := user.Validate()
@@ -109,7 +265,7 @@ func (crud *SQLCRUD) UpdateUser(user *models.User) (bool, error) {
 
 	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
 	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.UpdateUserScript(),
-		user.Username, user.Rank,
+		user.Username, user.Rank, nullString(user.Email), user.DisplayName, user.Enabled,
 	)
 	cancel()
 
@@ -121,14 +277,14 @@ func (crud *SQLCRUD) UpdateUser(user *models.User) (bool, error) {
 	return count > 0, nil
 }
 
-func (crud *SQLCRUD) UpdateUserPassword(username string, hash []byte) (bool, error) {
+func (crud *SQLCRUD) UpdateUserPassword(username string, hash []byte, updatedAt time.Time) (bool, error) {
 	if hash == nil {
 		return false, errors.New("password hash cannot be nil")
 	}
 
 	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
 	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.UpdateUserPasswordScript(),
-		username, hash,
+		username, hash, updatedAt,
 	)
 	cancel()
 
@@ -140,6 +296,37 @@ func (crud *SQLCRUD) UpdateUserPassword(username string, hash []byte) (bool, err
 	return count > 0, nil
 }
 
+func (crud *SQLCRUD) UpdateUserTOTP(username string, secret []byte, enabled bool) (bool, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.UpdateUserTOTPScript(),
+		username, secret, enabled,
+	)
+	cancel()
+
+	if err != nil {
+		return false, common.ChainError("error executing update user totp statement", err)
+	}
+
+	count, _ := res.RowsAffected()
+	return count > 0, nil
+}
+
+func (crud *SQLCRUD) UpdateUserTOTPLastTimeStep(username string, step int64) (bool, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.UpdateUserTOTPLastTimeStepScript(),
+		username, step,
+	)
+	cancel()
+
+	if err != nil {
+		return false, common.ChainError("error executing update user totp last time step statement", err)
+	}
+
+	//nothing is updated if the step is not after the last one
+	count, _ := res.RowsAffected()
+	return count > 0, nil
+}
+
 func (crud *SQLCRUD) DeleteUser(username string) (bool, error) {
 	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
 	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DeleteUserScript(), username)
@@ -153,6 +340,14 @@ func (crud *SQLCRUD) DeleteUser(username string) (bool, error) {
 	return count > 0, nil
 }
 
+// nullString converts an empty string to a null value so optional unique columns, such as the user's email, don't conflict.
+func nullString(s string) sql.NullString {
+	return sql.NullString{
+		String: s,
+		Valid:  s != "",
+	}
+}
+
 func readUserData(rows *sql.Rows) (*models.User, error) {
 	//check if there was a result
 	if !rows.Next() {
@@ -167,12 +362,19 @@ func readUserData(rows *sql.Rows) (*models.User, error) {
 
 	//get the result
 	user := &models.User{}
+	email := sql.NullString{}
+
 	err := rows.Scan(
-		&user.Username, &user.Rank, &user.PasswordHash,
+		&user.Username, &user.Rank, &user.PasswordHash, &user.PasswordUpdatedAt, &user.TOTPSecret, &user.TOTPEnabled, &user.TOTPLastTimeStep,
+		&email, &user.DisplayName, &user.Enabled,
 	)
 	if err != nil {
 		return nil, common.ChainError("error reading row", err)
 	}
+	user.Email = email.String
+
+	//normalize the timestamp to UTC
+	user.PasswordUpdatedAt = user.PasswordUpdatedAt.UTC()
 
 	return user, nil
 }
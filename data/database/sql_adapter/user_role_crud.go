@@ -39,6 +39,48 @@ func (crud *SQLCRUD) DropUserRoleTable() error {
 	return err
 }
 
+// AddRoleToUserRolePrimaryKey adds the role to the user-role table's primary key so a user can have multiple roles for a client.
+// Returns any errors.
+func (crud *SQLCRUD) AddRoleToUserRolePrimaryKey() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.AddRoleToUserRolePrimaryKeyScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing add role to user-role primary key script", err)
+	}
+
+	return err
+}
+
+// DeleteAllButFirstUserRoles deletes every role of each user-role except its first.
+// Returns any errors.
+func (crud *SQLCRUD) DeleteAllButFirstUserRoles() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.DeleteAllButFirstUserRolesScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing delete all but first user-roles script", err)
+	}
+
+	return err
+}
+
+// RemoveRoleFromUserRolePrimaryKey removes the role from the user-role table's primary key.
+// Returns any errors.
+func (crud *SQLCRUD) RemoveRoleFromUserRolePrimaryKey() error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.RemoveRoleFromUserRolePrimaryKeyScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing remove role from user-role primary key script", err)
+	}
+
+	return err
+}
+
 func (crud *SQLCRUD) CreateUserRole(role *models.UserRole) error {
 	//validate the user-role model
 	verr := role.Validate()
@@ -46,22 +88,46 @@ func (crud *SQLCRUD) CreateUserRole(role *models.UserRole) error {
 		return errors.New(fmt.Sprint("error validating user-role model:", verr))
 	}
 
-	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
-	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateUserRoleScript(),
-		role.ClientUID, role.Username, role.Role,
-	)
-	defer cancel()
+	return crud.createUserRoleRoles(role)
+}
 
-	if err != nil {
-		return common.ChainError("error executing create user role statement", err)
+func (crud *SQLCRUD) createUserRoleRoles(role *models.UserRole) error {
+	//each role is its own row
+	for _, r := range role.Roles {
+		ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+		res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.CreateUserRoleScript(),
+			role.ClientUID, role.Username, r,
+		)
+		cancel()
+
+		if err != nil {
+			return common.ChainError("error executing create user role statement", err)
+		}
+
+		//nothing is inserted if the client or user does not exist
+		count, _ := res.RowsAffected()
+		if count == 0 {
+			return errors.New("user-role client or user does not exist")
+		}
 	}
 
 	return nil
 }
 
-func (crud *SQLCRUD) GetUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank int) ([]*models.UserRole, error) {
+func (crud *SQLCRUD) GetUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank int, query models.PageQuery) ([]*models.UserRole, error) {
+	hasCursor, cursorValue, cursorKey := pageCursor(query)
+
+	//pick the script for the sort field
+	script := crud.SQLDriver.GetUserRolesWithLesserRankByClientUIDSortedByUsernameScript()
+	args := []interface{}{uid, rank, query.Search, query.SortDescending, hasCursor, cursorKey, pageLimit(query)}
+
+	if query.SortBy == models.UserRoleSortRole {
+		script = crud.SQLDriver.GetUserRolesWithLesserRankByClientUIDSortedByRoleScript()
+		args = []interface{}{uid, rank, query.Search, query.SortDescending, hasCursor, cursorValue, cursorKey, pageLimit(query)}
+	}
+
 	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
-	rows, err := crud.Executor.QueryContext(ctx, crud.SQLDriver.GetUserRolesWithLesserRankByClientUIDScript(), uid, rank)
+	rows, err := crud.Executor.QueryContext(ctx, script, args...)
 	defer cancel()
 
 	if err != nil {
@@ -69,20 +135,16 @@ func (crud *SQLCRUD) GetUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank i
 	}
 	defer rows.Close()
 
-	//read the data
-	roles := []*models.UserRole{}
-	for {
-		role, err := readUserRoleData(rows)
-		if err != nil {
-			return nil, err
-		}
+	return readUserRolesData(rows)
+}
 
-		if role == nil {
-			break
-		}
-		roles = append(roles, role)
+func (crud *SQLCRUD) CountUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank int, search string) (int, error) {
+	count, err := crud.queryCount(crud.SQLDriver.CountUserRolesWithLesserRankByClientUIDScript(), uid, rank, search)
+	if err != nil {
+		return 0, common.ChainError("error counting user roles with lesser rank by client uid", err)
 	}
-	return roles, nil
+
+	return count, nil
 }
 
 func (crud *SQLCRUD) GetUserRoleByClientUIDAndUsername(clientUID uuid.UUID, username string) (*models.UserRole, error) {
@@ -97,7 +159,15 @@ func (crud *SQLCRUD) GetUserRoleByClientUIDAndUsername(clientUID uuid.UUID, user
 	}
 	defer rows.Close()
 
-	return readUserRoleData(rows)
+	roles, err := readUserRolesData(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(roles) == 0 {
+		return nil, nil
+	}
+	return roles[0], nil
 }
 
 func (crud *SQLCRUD) UpdateUserRole(role *models.UserRole) (bool, error) {
@@ -107,18 +177,45 @@ func (crud *SQLCRUD) UpdateUserRole(role *models.UserRole) (bool, error) {
 		return false, errors.New(fmt.Sprint("error validating user-role model:", verr))
 	}
 
+	//replace the old roles with the new ones
+	res, err := crud.DeleteUserRole(role.ClientUID, role.Username)
+	if err != nil {
+		return false, common.ChainError("error deleting old roles", err)
+	}
+
+	if !res {
+		return false, nil
+	}
+
+	err = crud.createUserRoleRoles(role)
+	if err != nil {
+		return false, common.ChainError("error creating new roles", err)
+	}
+
+	return true, nil
+}
+
+func (crud *SQLCRUD) CountUserRolesByClientUIDAndRole(clientUID uuid.UUID, role string) (int, error) {
+	count, err := crud.queryCount(crud.SQLDriver.CountUserRolesByClientUIDAndRoleScript(), clientUID, role)
+	if err != nil {
+		return 0, common.ChainError("error counting user roles by client uid and role", err)
+	}
+
+	return count, nil
+}
+
+func (crud *SQLCRUD) RenameUserRoles(clientUID uuid.UUID, role string, newRole string) error {
 	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
-	res, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.UpdateUserRoleScript(),
-		role.ClientUID, role.Username, role.Role,
+	_, err := crud.Executor.ExecContext(ctx, crud.SQLDriver.RenameUserRolesScript(),
+		clientUID, role, newRole,
 	)
 	cancel()
 
 	if err != nil {
-		return false, common.ChainError("error executing update user role statement", err)
+		return common.ChainError("error executing rename user roles statement", err)
 	}
 
-	count, _ := res.RowsAffected()
-	return count > 0, nil
+	return nil
 }
 
 func (crud *SQLCRUD) DeleteUserRole(clientUID uuid.UUID, username string) (bool, error) {
@@ -136,26 +233,31 @@ func (crud *SQLCRUD) DeleteUserRole(clientUID uuid.UUID, username string) (bool,
 	return count > 0, nil
 }
 
-func readUserRoleData(rows *sql.Rows) (*models.UserRole, error) {
-	//check if there was a result
-	if !rows.Next() {
-		err := rows.Err()
+func readUserRolesData(rows *sql.Rows) ([]*models.UserRole, error) {
+	roles := []*models.UserRole{}
+
+	//each row is one role, rows of the same user-role are adjacent
+	for rows.Next() {
+		var clientUID uuid.UUID
+		var username, role string
+
+		err := rows.Scan(&clientUID, &username, &role)
 		if err != nil {
-			return nil, common.ChainError("error preparing next row", err)
+			return nil, common.ChainError("error reading row", err)
 		}
 
-		//return no results
-		return nil, nil
+		if len(roles) == 0 || roles[len(roles)-1].Username != username {
+			roles = append(roles, models.CreateUserRole(clientUID, username))
+		}
+
+		userRole := roles[len(roles)-1]
+		userRole.Roles = append(userRole.Roles, role)
 	}
 
-	//get the result
-	userRole := &models.UserRole{}
-	err := rows.Scan(
-		&userRole.ClientUID, &userRole.Username, &userRole.Role,
-	)
+	err := rows.Err()
 	if err != nil {
-		return nil, common.ChainError("error reading row", err)
+		return nil, common.ChainError("error preparing next row", err)
 	}
 
-	return userRole, nil
+	return roles, nil
 }
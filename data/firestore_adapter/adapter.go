@@ -20,6 +20,7 @@ type FirestoreAdapter struct {
 }
 
 // Setup creates a new firestore client using the firestore config.
+// The client manages its own pool of connections and is shared by every executor created from the adapter.
 // Also initializes the adapter's context and cancel function.
 // Returns any errors.
 func (a *FirestoreAdapter) Setup() error {
@@ -0,0 +1,304 @@
+package firestoreadapter
+
+import (
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"google.golang.org/api/iterator"
+)
+
+// userAdminRole is the doc that records an admin role was given to a user.
+type userAdminRole struct {
+	Username string `firestore:"username"`
+	Name     string `firestore:"name"`
+}
+
+func (crud *FirestoreCRUD) CreateAdminRole(role *models.AdminRole) error {
+	//validate the admin role model
+	verr := role.Validate()
+	if verr != models.ValidateAdminRoleValid {
+		return errors.New(fmt.Sprint("error validating admin role model:", verr))
+	}
+
+	//create admin role
+	err := crud.DocWriter.Create(crud.getAdminRoleDocRef(role.Name), role)
+	if err != nil {
+		return common.ChainError("error creating admin role", err)
+	}
+
+	return nil
+}
+
+func (crud *FirestoreCRUD) GetAdminRoles() ([]*models.AdminRole, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := crud.Client.Collection("admin-roles").
+		OrderBy("name", firestore.Asc).
+		Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	//read the results
+	roles := []*models.AdminRole{}
+	for {
+		doc, err := itr.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, common.ChainError("error getting next doc", err)
+		}
+
+		role, err := crud.readAdminRoleData(doc)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
+
+func (crud *FirestoreCRUD) GetAdminRoleByName(name string) (*models.AdminRole, error) {
+	doc, err := crud.getAdminRole(name)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, nil
+	}
+
+	return crud.readAdminRoleData(doc)
+}
+
+func (crud *FirestoreCRUD) UpdateAdminRole(role *models.AdminRole) (bool, error) {
+	//validate the admin role model
+	verr := role.Validate()
+	if verr != models.ValidateAdminRoleValid {
+		return false, errors.New(fmt.Sprint("error validating admin role model:", verr))
+	}
+
+	//check admin role already exists
+	doc, err := crud.getAdminRole(role.Name)
+	if err != nil {
+		return false, err
+	}
+	if doc == nil {
+		return false, nil
+	}
+
+	//update admin role
+	err = crud.DocWriter.Set(doc.Ref, role)
+	if err != nil {
+		return true, common.ChainError("error updating admin role", err)
+	}
+
+	return true, nil
+}
+
+func (crud *FirestoreCRUD) DeleteAdminRole(name string) (bool, error) {
+	//check admin role already exists
+	doc, err := crud.getAdminRole(name)
+	if err != nil {
+		return false, err
+	}
+	if doc == nil {
+		return false, nil
+	}
+
+	//delete admin role
+	err = crud.DocWriter.Delete(doc.Ref)
+	if err != nil {
+		return false, common.ChainError("error deleting admin role", err)
+	}
+
+	//take the admin role away from the users it was given to
+	err = crud.deleteUserAdminRoles(crud.Client.Collection("user-admin-roles").Where("name", "==", name))
+	if err != nil {
+		return false, common.ChainError("error deleting user admin roles", err)
+	}
+
+	return true, nil
+}
+
+func (crud *FirestoreCRUD) GetAdminRolesByUsername(username string) ([]*models.AdminRole, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := crud.Client.Collection("user-admin-roles").
+		Where("username", "==", username).
+		OrderBy("name", firestore.Asc).
+		Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	//read the results
+	roles := []*models.AdminRole{}
+	for {
+		doc, err := itr.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, common.ChainError("error getting next doc", err)
+		}
+
+		userRole := userAdminRole{}
+		err = doc.DataTo(&userRole)
+		if err != nil {
+			return nil, common.ChainError("error reading user admin role data", err)
+		}
+
+		//get the admin role the user was given
+		role, err := crud.GetAdminRoleByName(userRole.Name)
+		if err != nil {
+			return nil, err
+		}
+		if role != nil {
+			roles = append(roles, role)
+		}
+	}
+
+	return roles, nil
+}
+
+func (crud *FirestoreCRUD) GetAdminRoleUsernames(name string) ([]string, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := crud.Client.Collection("user-admin-roles").
+		Where("name", "==", name).
+		OrderBy("username", firestore.Asc).
+		Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	//read the results
+	usernames := []string{}
+	for {
+		doc, err := itr.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, common.ChainError("error getting next doc", err)
+		}
+
+		userRole := userAdminRole{}
+		err = doc.DataTo(&userRole)
+		if err != nil {
+			return nil, common.ChainError("error reading user admin role data", err)
+		}
+		usernames = append(usernames, userRole.Username)
+	}
+
+	return usernames, nil
+}
+
+func (crud *FirestoreCRUD) SetUserAdminRoles(username string, names []string) error {
+	//remove the user's current admin roles
+	err := crud.DeleteAllUserAdminRolesByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	//admin roles can only be given to existing users
+	doc, err := crud.getUser(username)
+	if err != nil {
+		return err
+	}
+	if doc == nil {
+		return nil
+	}
+
+	//give the user each of the new admin roles that exist
+	for _, name := range names {
+		role, err := crud.getAdminRole(name)
+		if err != nil {
+			return err
+		}
+		if role == nil {
+			continue
+		}
+
+		ref := crud.Client.Collection("user-admin-roles").Doc(username + "-" + name)
+		err = crud.DocWriter.Set(ref, userAdminRole{Username: username, Name: name})
+		if err != nil {
+			return common.ChainError("error creating user admin role", err)
+		}
+	}
+
+	return nil
+}
+
+func (crud *FirestoreCRUD) DeleteAllUserAdminRolesByUsername(username string) error {
+	err := crud.deleteUserAdminRoles(crud.Client.Collection("user-admin-roles").Where("username", "==", username))
+	if err != nil {
+		return common.ChainError("error deleting user admin roles", err)
+	}
+
+	return nil
+}
+
+func (crud *FirestoreCRUD) deleteUserAdminRoles(query firestore.Query) error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := query.Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	for {
+		doc, err := itr.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return common.ChainError("error getting next doc", err)
+		}
+
+		//delete user admin role
+		err = crud.DocWriter.Delete(doc.Ref)
+		if err != nil {
+			return common.ChainError("error deleting user admin role", err)
+		}
+	}
+}
+
+func (crud *FirestoreCRUD) getAdminRoleDocRef(name string) *firestore.DocumentRef {
+	return crud.Client.Collection("admin-roles").Doc(name)
+}
+
+func (crud *FirestoreCRUD) getAdminRole(name string) (*firestore.DocumentSnapshot, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	doc, err := crud.getAdminRoleDocRef(name).Get(ctx)
+	cancel()
+
+	//check admin role was found
+	if !doc.Exists() {
+		return nil, nil
+	}
+
+	//handle other errors
+	if err != nil {
+		return nil, common.ChainError("error getting admin role", err)
+	}
+
+	return doc, nil
+}
+
+func (*FirestoreCRUD) readAdminRoleData(doc *firestore.DocumentSnapshot) (*models.AdminRole, error) {
+	role := &models.AdminRole{}
+
+	err := doc.DataTo(&role)
+	if err != nil {
+		return nil, common.ChainError("error reading admin role data", err)
+	}
+
+	//roles stored without permissions read back as nil
+	if role.Permissions == nil {
+		role.Permissions = []string{}
+	}
+
+	return role, nil
+}
@@ -0,0 +1,93 @@
+package firestoreadapter
+
+import (
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"google.golang.org/api/iterator"
+)
+
+func (crud *FirestoreCRUD) SaveAuditEvent(event *models.AuditEvent) error {
+	//validate the audit event model
+	verr := event.Validate()
+	if verr != models.ValidateAuditEventValid {
+		return errors.New(fmt.Sprint("error validating audit event model:", verr))
+	}
+
+	//create audit event
+	err := crud.DocWriter.Create(crud.Client.Collection("audit-events").Doc(event.ID.String()), event)
+	if err != nil {
+		return common.ChainError("error creating audit event", err)
+	}
+
+	return nil
+}
+
+func (crud *FirestoreCRUD) GetAuditEvents(filter models.AuditEventFilter) ([]*models.AuditEvent, error) {
+	query := crud.Client.Collection("audit-events").Query
+
+	//only add the filters that were provided
+	if filter.Actor != "" {
+		query = query.Where("actor", "==", filter.Actor)
+	}
+	if filter.Action != "" {
+		query = query.Where("action", "==", filter.Action)
+	}
+	if filter.Target != "" {
+		query = query.Where("target", "==", filter.Target)
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("timestamp", ">=", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		query = query.Where("timestamp", "<", filter.Until)
+	}
+
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := query.
+		OrderBy("timestamp", firestore.Desc).
+		OrderBy("id", firestore.Asc).
+		Offset(filter.Offset).
+		Limit(filter.Limit).
+		Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	//read the results
+	events := []*models.AuditEvent{}
+	for {
+		doc, err := itr.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, common.ChainError("error getting next doc", err)
+		}
+
+		event, err := crud.readAuditEventData(doc)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (*FirestoreCRUD) readAuditEventData(doc *firestore.DocumentSnapshot) (*models.AuditEvent, error) {
+	event := &models.AuditEvent{}
+
+	err := doc.DataTo(&event)
+	if err != nil {
+		return nil, common.ChainError("error reading audit event data", err)
+	}
+
+	//normalize the timestamp to UTC
+	event.Timestamp = event.Timestamp.UTC()
+
+	return event, nil
+}
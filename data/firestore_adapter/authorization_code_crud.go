@@ -0,0 +1,95 @@
+package firestoreadapter
+
+import (
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+func (crud *FirestoreCRUD) SaveAuthorizationCode(code *models.AuthorizationCode) error {
+	//validate the authorization code model
+	verr := code.Validate()
+	if verr != models.ValidateAuthorizationCodeValid {
+		return errors.New(fmt.Sprint("error validating authorization code model:", verr))
+	}
+
+	//create authorization code
+	err := crud.DocWriter.Create(crud.getAuthorizationCodeDocRef(code.Code), code)
+	if err != nil {
+		return common.ChainError("error creating authorization code", err)
+	}
+
+	return nil
+}
+
+func (crud *FirestoreCRUD) GetAuthorizationCode(code uuid.UUID) (*models.AuthorizationCode, error) {
+	doc, err := crud.getAuthorizationCode(code)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, nil
+	}
+
+	return crud.readAuthorizationCodeData(doc)
+}
+
+func (crud *FirestoreCRUD) DeleteAuthorizationCode(code uuid.UUID) (bool, error) {
+	//check authorization code already exists
+	doc, err := crud.getAuthorizationCode(code)
+	if err != nil {
+		return false, err
+	}
+	if doc == nil {
+		return false, nil
+	}
+
+	//delete authorization code
+	err = crud.DocWriter.Delete(doc.Ref)
+	if err != nil {
+		return false, common.ChainError("error deleting authorization code", err)
+	}
+
+	return true, nil
+}
+
+func (crud *FirestoreCRUD) getAuthorizationCodeDocRef(code uuid.UUID) *firestore.DocumentRef {
+	return crud.Client.Collection("authorization-codes").Doc(code.String())
+}
+
+func (crud *FirestoreCRUD) getAuthorizationCode(code uuid.UUID) (*firestore.DocumentSnapshot, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	doc, err := crud.getAuthorizationCodeDocRef(code).Get(ctx)
+	cancel()
+
+	//check authorization code was found
+	if !doc.Exists() {
+		return nil, nil
+	}
+
+	//handle other errors
+	if err != nil {
+		return nil, common.ChainError("error getting authorization code", err)
+	}
+
+	return doc, nil
+}
+
+func (*FirestoreCRUD) readAuthorizationCodeData(doc *firestore.DocumentSnapshot) (*models.AuthorizationCode, error) {
+	code := &models.AuthorizationCode{}
+
+	err := doc.DataTo(&code)
+	if err != nil {
+		return nil, common.ChainError("error reading authorization code data", err)
+	}
+
+	//normalize the timestamp to UTC
+	code.ExpiresAt = code.ExpiresAt.UTC()
+
+	return code, nil
+}
@@ -0,0 +1,166 @@
+package firestoreadapter
+
+import (
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"google.golang.org/api/iterator"
+
+	"github.com/google/uuid"
+)
+
+func (crud *FirestoreCRUD) CreateClientAdmin(admin *models.ClientAdmin) error {
+	//validate the client admin model
+	verr := admin.Validate()
+	if verr != models.ValidateClientAdminValid {
+		return errors.New(fmt.Sprint("error validating client admin model:", verr))
+	}
+
+	//create client admin
+	err := crud.DocWriter.Create(crud.getClientAdminDocRef(admin.ClientUID, admin.Username), admin)
+	if err != nil {
+		return common.ChainError("error creating client admin", err)
+	}
+
+	return nil
+}
+
+func (crud *FirestoreCRUD) GetClientAdminsByClientUID(clientUID uuid.UUID) ([]*models.ClientAdmin, error) {
+	return crud.getClientAdmins(crud.Client.Collection("client-admins").
+		Where("client_uid", "==", clientUID).
+		OrderBy("username", firestore.Asc),
+	)
+}
+
+func (crud *FirestoreCRUD) GetClientAdminsByUsername(username string) ([]*models.ClientAdmin, error) {
+	return crud.getClientAdmins(crud.Client.Collection("client-admins").
+		Where("username", "==", username),
+	)
+}
+
+func (crud *FirestoreCRUD) GetClientAdminByClientUIDAndUsername(clientUID uuid.UUID, username string) (*models.ClientAdmin, error) {
+	doc, err := crud.getClientAdmin(clientUID, username)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, nil
+	}
+
+	return crud.readClientAdminData(doc)
+}
+
+func (crud *FirestoreCRUD) DeleteClientAdmin(clientUID uuid.UUID, username string) (bool, error) {
+	//check client admin already exists
+	doc, err := crud.getClientAdmin(clientUID, username)
+	if err != nil {
+		return false, err
+	}
+	if doc == nil {
+		return false, nil
+	}
+
+	//delete client admin
+	err = crud.DocWriter.Delete(doc.Ref)
+	if err != nil {
+		return false, common.ChainError("error deleting client admin", err)
+	}
+
+	return true, nil
+}
+
+func (crud *FirestoreCRUD) DeleteAllClientAdminsByClientUID(uid uuid.UUID) error {
+	return crud.deleteClientAdmins(crud.Client.Collection("client-admins").Where("client_uid", "==", uid))
+}
+
+func (crud *FirestoreCRUD) DeleteAllClientAdminsByUsername(username string) error {
+	return crud.deleteClientAdmins(crud.Client.Collection("client-admins").Where("username", "==", username))
+}
+
+func (crud *FirestoreCRUD) getClientAdmins(query firestore.Query) ([]*models.ClientAdmin, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := query.Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	//read the results
+	admins := []*models.ClientAdmin{}
+	for {
+		doc, err := itr.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, common.ChainError("error getting next doc", err)
+		}
+
+		admin, err := crud.readClientAdminData(doc)
+		if err != nil {
+			return nil, err
+		}
+		admins = append(admins, admin)
+	}
+
+	return admins, nil
+}
+
+func (crud *FirestoreCRUD) deleteClientAdmins(query firestore.Query) error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := query.Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	for {
+		doc, err := itr.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return common.ChainError("error getting next doc", err)
+		}
+
+		//delete client admin
+		err = crud.DocWriter.Delete(doc.Ref)
+		if err != nil {
+			return common.ChainError("error deleting client admin", err)
+		}
+	}
+}
+
+func (crud *FirestoreCRUD) getClientAdminDocRef(clientUID uuid.UUID, username string) *firestore.DocumentRef {
+	return crud.Client.Collection("client-admins").Doc(clientUID.String() + "-" + username)
+}
+
+func (crud *FirestoreCRUD) getClientAdmin(clientUID uuid.UUID, username string) (*firestore.DocumentSnapshot, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	doc, err := crud.getClientAdminDocRef(clientUID, username).Get(ctx)
+	cancel()
+
+	//check client admin was found
+	if !doc.Exists() {
+		return nil, nil
+	}
+
+	//handle other errors
+	if err != nil {
+		return nil, common.ChainError("error getting client admin", err)
+	}
+
+	return doc, nil
+}
+
+func (*FirestoreCRUD) readClientAdminData(doc *firestore.DocumentSnapshot) (*models.ClientAdmin, error) {
+	admin := &models.ClientAdmin{}
+
+	err := doc.DataTo(&admin)
+	if err != nil {
+		return nil, common.ChainError("error reading client admin data", err)
+	}
+
+	return admin, nil
+}
@@ -27,7 +27,39 @@ func (crud *FirestoreCRUD) CreateClient(client *models.Client) error {
 	return nil
 }
 
-func (crud *FirestoreCRUD) GetClients() ([]*models.Client, error) {
+func (crud *FirestoreCRUD) GetClients(query models.PageQuery) ([]*models.Client, error) {
+	clients, err := crud.searchClients(query.Search)
+	if err != nil {
+		return nil, err
+	}
+
+	//firestore cannot search ignoring case, so the clients are sorted and paged once read
+	cursors := make([]models.Cursor, len(clients))
+	for index, client := range clients {
+		cursors[index] = client.GetCursor()
+	}
+
+	indexes := models.SortPage(cursors, false, query)
+
+	page := make([]*models.Client, len(indexes))
+	for index, clientIndex := range indexes {
+		page[index] = clients[clientIndex]
+	}
+	return page, nil
+}
+
+func (crud *FirestoreCRUD) CountClients(search string) (int, error) {
+	clients, err := crud.searchClients(search)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(clients), nil
+}
+
+// searchClients fetches all the clients whose name starts with the search.
+// Returns the clients and any errors.
+func (crud *FirestoreCRUD) searchClients(search string) ([]*models.Client, error) {
 	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
 	itr := crud.Client.Collection("clients").
 		OrderBy("name", firestore.Asc).
@@ -51,7 +83,10 @@ func (crud *FirestoreCRUD) GetClients() ([]*models.Client, error) {
 		if err != nil {
 			return nil, err
 		}
-		clients = append(clients, client)
+
+		if models.MatchesSearch(client.Name, search) {
+			clients = append(clients, client)
+		}
 	}
 
 	return clients, nil
@@ -85,8 +120,13 @@ func (crud *FirestoreCRUD) UpdateClient(client *models.Client) (bool, error) {
 		return false, nil
 	}
 
-	//update client
-	err = crud.DocWriter.Set(doc.Ref, client)
+	//update client, leaving its secret hash untouched
+	err = crud.DocWriter.Update(doc.Ref, []firestore.Update{
+		{Path: "name", Value: client.Name},
+		{Path: "redirect_url", Value: client.RedirectUrl},
+		{Path: "token_type", Value: client.TokenType},
+		{Path: "key_uri", Value: client.KeyUri},
+	})
 	if err != nil {
 		return true, common.ChainError("error updating client", err)
 	}
@@ -94,6 +134,27 @@ func (crud *FirestoreCRUD) UpdateClient(client *models.Client) (bool, error) {
 	return true, nil
 }
 
+func (crud *FirestoreCRUD) UpdateClientSecretHash(uid uuid.UUID, secretHash []byte) (bool, error) {
+	//check client already exists
+	doc, err := crud.getClient(uid)
+	if err != nil {
+		return false, err
+	}
+	if doc == nil {
+		return false, nil
+	}
+
+	//update the secret hash
+	err = crud.DocWriter.Update(doc.Ref, []firestore.Update{
+		{Path: "secret_hash", Value: secretHash},
+	})
+	if err != nil {
+		return false, common.ChainError("error updating client secret hash", err)
+	}
+
+	return true, nil
+}
+
 func (crud *FirestoreCRUD) DeleteClient(uid uuid.UUID) (bool, error) {
 	//check client already exists
 	doc, err := crud.getClient(uid)
@@ -116,6 +177,24 @@ func (crud *FirestoreCRUD) DeleteClient(uid uuid.UUID) (bool, error) {
 		return false, common.ChainError("error deleting user-roles", err)
 	}
 
+	//delete all role definitions
+	err = crud.DeleteAllRoleDefinitionsByClientUID(uid)
+	if err != nil {
+		return false, common.ChainError("error deleting role definitions", err)
+	}
+
+	//delete all client admins
+	err = crud.DeleteAllClientAdminsByClientUID(uid)
+	if err != nil {
+		return false, common.ChainError("error deleting client admins", err)
+	}
+
+	//delete all group-roles
+	err = crud.DeleteAllGroupRolesByClientUID(uid)
+	if err != nil {
+		return false, common.ChainError("error deleting group-roles", err)
+	}
+
 	return true, nil
 }
 
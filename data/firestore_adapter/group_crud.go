@@ -0,0 +1,315 @@
+package firestoreadapter
+
+import (
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"google.golang.org/api/iterator"
+)
+
+// groupMember is the doc that records a user is a member of a group.
+type groupMember struct {
+	Name     string `firestore:"name"`
+	Username string `firestore:"username"`
+}
+
+func (crud *FirestoreCRUD) CreateGroup(group *models.Group) error {
+	//validate the group model
+	verr := group.Validate()
+	if verr != models.ValidateGroupValid {
+		return errors.New(fmt.Sprint("error validating group model:", verr))
+	}
+
+	//create group
+	err := crud.DocWriter.Create(crud.getGroupDocRef(group.Name), group)
+	if err != nil {
+		return common.ChainError("error creating group", err)
+	}
+
+	return nil
+}
+
+func (crud *FirestoreCRUD) GetGroups() ([]*models.Group, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := crud.Client.Collection("groups").
+		OrderBy("name", firestore.Asc).
+		Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	//read the results
+	groups := []*models.Group{}
+	for {
+		doc, err := itr.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, common.ChainError("error getting next doc", err)
+		}
+
+		group, err := crud.readGroupData(doc)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+func (crud *FirestoreCRUD) GetGroupByName(name string) (*models.Group, error) {
+	doc, err := crud.getGroup(name)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, nil
+	}
+
+	return crud.readGroupData(doc)
+}
+
+func (crud *FirestoreCRUD) UpdateGroup(group *models.Group) (bool, error) {
+	//validate the group model
+	verr := group.Validate()
+	if verr != models.ValidateGroupValid {
+		return false, errors.New(fmt.Sprint("error validating group model:", verr))
+	}
+
+	//check group already exists
+	doc, err := crud.getGroup(group.Name)
+	if err != nil {
+		return false, err
+	}
+	if doc == nil {
+		return false, nil
+	}
+
+	//update group
+	err = crud.DocWriter.Set(doc.Ref, group)
+	if err != nil {
+		return true, common.ChainError("error updating group", err)
+	}
+
+	return true, nil
+}
+
+func (crud *FirestoreCRUD) DeleteGroup(name string) (bool, error) {
+	//check group already exists
+	doc, err := crud.getGroup(name)
+	if err != nil {
+		return false, err
+	}
+	if doc == nil {
+		return false, nil
+	}
+
+	//delete group
+	err = crud.DocWriter.Delete(doc.Ref)
+	if err != nil {
+		return false, common.ChainError("error deleting group", err)
+	}
+
+	//delete the group's members
+	err = crud.deleteGroupMembers(crud.Client.Collection("group-members").Where("name", "==", name))
+	if err != nil {
+		return false, common.ChainError("error deleting group members", err)
+	}
+
+	//delete the roles clients gave the group
+	err = crud.deleteGroupRoles(crud.Client.Collection("group-roles").Where("group_name", "==", name))
+	if err != nil {
+		return false, common.ChainError("error deleting group-roles", err)
+	}
+
+	return true, nil
+}
+
+func (crud *FirestoreCRUD) GetGroupsByUsername(username string) ([]*models.Group, error) {
+	members, err := crud.getGroupMembers(crud.Client.Collection("group-members").
+		Where("username", "==", username).
+		OrderBy("name", firestore.Asc),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []*models.Group{}
+	for _, member := range members {
+		//get the group the user is a member of
+		group, err := crud.GetGroupByName(member.Name)
+		if err != nil {
+			return nil, err
+		}
+		if group != nil {
+			groups = append(groups, group)
+		}
+	}
+
+	return groups, nil
+}
+
+func (crud *FirestoreCRUD) GetGroupMemberUsernames(name string) ([]string, error) {
+	members, err := crud.getGroupMembers(crud.Client.Collection("group-members").
+		Where("name", "==", name).
+		OrderBy("username", firestore.Asc),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	usernames := make([]string, len(members))
+	for index, member := range members {
+		usernames[index] = member.Username
+	}
+
+	return usernames, nil
+}
+
+func (crud *FirestoreCRUD) CreateGroupMember(name string, username string) error {
+	//only existing users can be added to existing groups
+	doc, err := crud.getGroup(name)
+	if err != nil {
+		return err
+	}
+	if doc == nil {
+		return nil
+	}
+
+	doc, err = crud.getUser(username)
+	if err != nil {
+		return err
+	}
+	if doc == nil {
+		return nil
+	}
+
+	//create group member
+	err = crud.DocWriter.Create(crud.getGroupMemberDocRef(name, username), groupMember{Name: name, Username: username})
+	if err != nil {
+		return common.ChainError("error creating group member", err)
+	}
+
+	return nil
+}
+
+func (crud *FirestoreCRUD) DeleteGroupMember(name string, username string) (bool, error) {
+	//check group member already exists
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	doc, err := crud.getGroupMemberDocRef(name, username).Get(ctx)
+	cancel()
+
+	if !doc.Exists() {
+		return false, nil
+	}
+	if err != nil {
+		return false, common.ChainError("error getting group member", err)
+	}
+
+	//delete group member
+	err = crud.DocWriter.Delete(doc.Ref)
+	if err != nil {
+		return false, common.ChainError("error deleting group member", err)
+	}
+
+	return true, nil
+}
+
+func (crud *FirestoreCRUD) DeleteAllGroupMembersByUsername(username string) error {
+	return crud.deleteGroupMembers(crud.Client.Collection("group-members").Where("username", "==", username))
+}
+
+func (crud *FirestoreCRUD) getGroupMembers(query firestore.Query) ([]groupMember, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := query.Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	//read the results
+	members := []groupMember{}
+	for {
+		doc, err := itr.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, common.ChainError("error getting next doc", err)
+		}
+
+		member := groupMember{}
+		err = doc.DataTo(&member)
+		if err != nil {
+			return nil, common.ChainError("error reading group member data", err)
+		}
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+func (crud *FirestoreCRUD) deleteGroupMembers(query firestore.Query) error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := query.Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	for {
+		doc, err := itr.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return common.ChainError("error getting next doc", err)
+		}
+
+		//delete group member
+		err = crud.DocWriter.Delete(doc.Ref)
+		if err != nil {
+			return common.ChainError("error deleting group member", err)
+		}
+	}
+}
+
+func (crud *FirestoreCRUD) getGroupDocRef(name string) *firestore.DocumentRef {
+	return crud.Client.Collection("groups").Doc(name)
+}
+
+func (crud *FirestoreCRUD) getGroupMemberDocRef(name string, username string) *firestore.DocumentRef {
+	return crud.Client.Collection("group-members").Doc(name + "-" + username)
+}
+
+func (crud *FirestoreCRUD) getGroup(name string) (*firestore.DocumentSnapshot, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	doc, err := crud.getGroupDocRef(name).Get(ctx)
+	cancel()
+
+	//check group was found
+	if !doc.Exists() {
+		return nil, nil
+	}
+
+	//handle other errors
+	if err != nil {
+		return nil, common.ChainError("error getting group", err)
+	}
+
+	return doc, nil
+}
+
+func (*FirestoreCRUD) readGroupData(doc *firestore.DocumentSnapshot) (*models.Group, error) {
+	group := &models.Group{}
+
+	err := doc.DataTo(&group)
+	if err != nil {
+		return nil, common.ChainError("error reading group data", err)
+	}
+
+	return group, nil
+}
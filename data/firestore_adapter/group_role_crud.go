@@ -0,0 +1,229 @@
+package firestoreadapter
+
+import (
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"google.golang.org/api/iterator"
+
+	"github.com/google/uuid"
+)
+
+func (crud *FirestoreCRUD) CreateGroupRole(role *models.GroupRole) error {
+	//validate the group-role model
+	verr := role.Validate()
+	if verr != models.ValidateGroupRoleValid {
+		return errors.New(fmt.Sprint("error validating group-role model:", verr))
+	}
+
+	//create group-role
+	err := crud.DocWriter.Create(crud.getGroupRoleDocRef(role.ClientUID, role.GroupName), role)
+	if err != nil {
+		return common.ChainError("error creating group-role", err)
+	}
+
+	return nil
+}
+
+func (crud *FirestoreCRUD) GetGroupRolesByClientUID(clientUID uuid.UUID) ([]*models.GroupRole, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	docs, err := crud.Client.Collection("group-roles").
+		Where("client_uid", "==", clientUID).
+		OrderBy("group_name", firestore.Asc).
+		Documents(ctx).GetAll()
+	cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error getting group-role docs", err)
+	}
+
+	return crud.readGroupRolesData(docs)
+}
+
+func (crud *FirestoreCRUD) GetGroupRoleByClientUIDAndGroupName(clientUID uuid.UUID, groupName string) (*models.GroupRole, error) {
+	doc, err := crud.getGroupRole(clientUID, groupName)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, nil
+	}
+
+	return crud.readGroupRoleData(doc)
+}
+
+func (crud *FirestoreCRUD) UpdateGroupRole(role *models.GroupRole) (bool, error) {
+	//validate the group-role model
+	verr := role.Validate()
+	if verr != models.ValidateGroupRoleValid {
+		return false, errors.New(fmt.Sprint("error validating group-role model:", verr))
+	}
+
+	//check group-role already exists
+	doc, err := crud.getGroupRole(role.ClientUID, role.GroupName)
+	if err != nil {
+		return false, err
+	}
+	if doc == nil {
+		return false, nil
+	}
+
+	//update group-role
+	err = crud.DocWriter.Set(doc.Ref, role)
+	if err != nil {
+		return true, common.ChainError("error updating group-role", err)
+	}
+
+	return true, nil
+}
+
+func (crud *FirestoreCRUD) CountGroupRolesByClientUIDAndRole(clientUID uuid.UUID, role string) (int, error) {
+	docs, err := crud.getGroupRoleDocsByClientUIDAndRole(clientUID, role)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(docs), nil
+}
+
+func (crud *FirestoreCRUD) RenameGroupRoles(clientUID uuid.UUID, role string, newRole string) error {
+	docs, err := crud.getGroupRoleDocsByClientUIDAndRole(clientUID, role)
+	if err != nil {
+		return err
+	}
+
+	//update group-roles
+	for _, doc := range docs {
+		groupRole, err := crud.readGroupRoleData(doc)
+		if err != nil {
+			return err
+		}
+
+		roles := []string{newRole}
+		for _, r := range groupRole.Roles {
+			if r != role {
+				roles = append(roles, r)
+			}
+		}
+
+		err = crud.DocWriter.Set(doc.Ref, models.CreateGroupRole(groupRole.ClientUID, groupRole.GroupName, roles...))
+		if err != nil {
+			return common.ChainError("error updating group-role", err)
+		}
+	}
+
+	return nil
+}
+
+func (crud *FirestoreCRUD) DeleteGroupRole(clientUID uuid.UUID, groupName string) (bool, error) {
+	//check group-role already exists
+	doc, err := crud.getGroupRole(clientUID, groupName)
+	if err != nil {
+		return false, err
+	}
+	if doc == nil {
+		return false, nil
+	}
+
+	//delete group-role
+	err = crud.DocWriter.Delete(doc.Ref)
+	if err != nil {
+		return false, common.ChainError("error deleting group-role", err)
+	}
+
+	return true, nil
+}
+
+func (crud *FirestoreCRUD) DeleteAllGroupRolesByClientUID(uid uuid.UUID) error {
+	return crud.deleteGroupRoles(crud.Client.Collection("group-roles").Where("client_uid", "==", uid))
+}
+
+// getGroupRoleDocsByClientUIDAndRole fetches all the group-roles for the provided client uid that include the given role.
+// Returns the docs and any errors.
+func (crud *FirestoreCRUD) getGroupRoleDocsByClientUIDAndRole(clientUID uuid.UUID, role string) ([]*firestore.DocumentSnapshot, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	docs, err := crud.Client.Collection("group-roles").
+		Where("client_uid", "==", clientUID).
+		Where("roles", "array-contains", role).
+		Documents(ctx).GetAll()
+	cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error getting group-role docs", err)
+	}
+
+	return docs, nil
+}
+
+func (crud *FirestoreCRUD) deleteGroupRoles(query firestore.Query) error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := query.Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	for {
+		doc, err := itr.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return common.ChainError("error getting next doc", err)
+		}
+
+		//delete group-role
+		err = crud.DocWriter.Delete(doc.Ref)
+		if err != nil {
+			return common.ChainError("error deleting group-role", err)
+		}
+	}
+}
+
+func (crud *FirestoreCRUD) getGroupRoleDocRef(clientUID uuid.UUID, groupName string) *firestore.DocumentRef {
+	return crud.Client.Collection("group-roles").Doc(clientUID.String() + "-" + groupName)
+}
+
+func (crud *FirestoreCRUD) getGroupRole(clientUID uuid.UUID, groupName string) (*firestore.DocumentSnapshot, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	doc, err := crud.getGroupRoleDocRef(clientUID, groupName).Get(ctx)
+	cancel()
+
+	//check group-role was found
+	if !doc.Exists() {
+		return nil, nil
+	}
+
+	//handle other errors
+	if err != nil {
+		return nil, common.ChainError("error getting group-role", err)
+	}
+
+	return doc, nil
+}
+
+func (crud *FirestoreCRUD) readGroupRolesData(docs []*firestore.DocumentSnapshot) ([]*models.GroupRole, error) {
+	roles := make([]*models.GroupRole, len(docs))
+	for index, doc := range docs {
+		role, err := crud.readGroupRoleData(doc)
+		if err != nil {
+			return nil, err
+		}
+		roles[index] = role
+	}
+
+	return roles, nil
+}
+
+func (*FirestoreCRUD) readGroupRoleData(doc *firestore.DocumentSnapshot) (*models.GroupRole, error) {
+	role := &models.GroupRole{}
+
+	err := doc.DataTo(&role)
+	if err != nil {
+		return nil, common.ChainError("error reading group-role data", err)
+	}
+
+	return models.CreateGroupRole(role.ClientUID, role.GroupName, role.Roles...), nil
+}
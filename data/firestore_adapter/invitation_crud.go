@@ -0,0 +1,161 @@
+package firestoreadapter
+
+import (
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"github.com/google/uuid"
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"google.golang.org/api/iterator"
+)
+
+func (crud *FirestoreCRUD) SaveInvitation(invitation *models.Invitation) error {
+	//validate the invitation model
+	verr := invitation.Validate()
+	if verr != models.ValidateInvitationValid {
+		return errors.New(fmt.Sprint("error validating invitation model:", verr))
+	}
+
+	//create invitation
+	err := crud.DocWriter.Create(crud.getInvitationDocRef(invitation.ID), invitation)
+	if err != nil {
+		return common.ChainError("error creating invitation", err)
+	}
+
+	return nil
+}
+
+func (crud *FirestoreCRUD) GetInvitationByID(id uuid.UUID) (*models.Invitation, error) {
+	doc, err := crud.getInvitation(id)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, nil
+	}
+
+	return crud.readInvitationData(doc)
+}
+
+func (crud *FirestoreCRUD) GetInvitationByTokenHash(hash []byte) (*models.Invitation, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := crud.Client.Collection("invitations").
+		Where("token_hash", "==", hash).
+		Limit(1).
+		Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	//check invitation was found
+	doc, err := itr.Next()
+	if err == iterator.Done {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, common.ChainError("error getting next doc", err)
+	}
+
+	return crud.readInvitationData(doc)
+}
+
+func (crud *FirestoreCRUD) DeleteInvitation(id uuid.UUID) (bool, error) {
+	//check invitation already exists
+	doc, err := crud.getInvitation(id)
+	if err != nil {
+		return false, err
+	}
+	if doc == nil {
+		return false, nil
+	}
+
+	//delete invitation
+	err = crud.DocWriter.Delete(doc.Ref)
+	if err != nil {
+		return false, common.ChainError("error deleting invitation", err)
+	}
+
+	return true, nil
+}
+
+func (crud *FirestoreCRUD) RenameInvitationRoles(clientUID uuid.UUID, role string, newRole string) error {
+	//roles are stored in their invitation's doc, so every invitation is checked (there are only ever a few since they expire)
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := crud.Client.Collection("invitations").Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	for {
+		doc, err := itr.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return common.ChainError("error getting next doc", err)
+		}
+
+		invitation, err := crud.readInvitationData(doc)
+		if err != nil {
+			return err
+		}
+
+		//rename the matching roles
+		renamed := false
+		for _, r := range invitation.Roles {
+			if r.ClientUID == clientUID && r.Role == role {
+				r.Role = newRole
+				renamed = true
+			}
+		}
+		if !renamed {
+			continue
+		}
+
+		//update invitation
+		err = crud.DocWriter.Update(doc.Ref, []firestore.Update{
+			{Path: "roles", Value: invitation.Roles},
+		})
+		if err != nil {
+			return common.ChainError("error updating invitation", err)
+		}
+	}
+}
+
+func (crud *FirestoreCRUD) getInvitationDocRef(id uuid.UUID) *firestore.DocumentRef {
+	return crud.Client.Collection("invitations").Doc(id.String())
+}
+
+func (crud *FirestoreCRUD) getInvitation(id uuid.UUID) (*firestore.DocumentSnapshot, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	doc, err := crud.getInvitationDocRef(id).Get(ctx)
+	cancel()
+
+	//check invitation was found
+	if !doc.Exists() {
+		return nil, nil
+	}
+
+	//handle other errors
+	if err != nil {
+		return nil, common.ChainError("error getting invitation", err)
+	}
+
+	return doc, nil
+}
+
+func (*FirestoreCRUD) readInvitationData(doc *firestore.DocumentSnapshot) (*models.Invitation, error) {
+	invitation := &models.Invitation{}
+
+	err := doc.DataTo(&invitation)
+	if err != nil {
+		return nil, common.ChainError("error reading invitation data", err)
+	}
+
+	//normalize the timestamp to UTC
+	invitation.ExpiresAt = invitation.ExpiresAt.UTC()
+
+	return invitation, nil
+}
@@ -0,0 +1,131 @@
+package firestoreadapter
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"google.golang.org/api/iterator"
+)
+
+func (crud *FirestoreCRUD) SaveLoginThrottle(throttle *models.LoginThrottle) error {
+	//validate the login throttle model
+	verr := throttle.Validate()
+	if verr != models.ValidateLoginThrottleValid {
+		return errors.New(fmt.Sprint("error validating login throttle model:", verr))
+	}
+
+	//create or replace login throttle
+	err := crud.DocWriter.Set(crud.getLoginThrottleDocRef(throttle.Type, throttle.Key), throttle)
+	if err != nil {
+		return common.ChainError("error setting login throttle", err)
+	}
+
+	return nil
+}
+
+func (crud *FirestoreCRUD) GetLoginThrottle(throttleType string, key string) (*models.LoginThrottle, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	doc, err := crud.getLoginThrottleDocRef(throttleType, key).Get(ctx)
+	cancel()
+
+	//check login throttle was found
+	if !doc.Exists() {
+		return nil, nil
+	}
+
+	//handle other errors
+	if err != nil {
+		return nil, common.ChainError("error getting login throttle", err)
+	}
+
+	return crud.readLoginThrottleData(doc)
+}
+
+func (crud *FirestoreCRUD) GetLockedLoginThrottles(now time.Time) ([]*models.LoginThrottle, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := crud.Client.Collection("login-throttles").
+		Where("locked_until", ">", now).
+		OrderBy("locked_until", firestore.Asc).
+		Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	//read the results
+	throttles := []*models.LoginThrottle{}
+	for {
+		doc, err := itr.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, common.ChainError("error getting next doc", err)
+		}
+
+		throttle, err := crud.readLoginThrottleData(doc)
+		if err != nil {
+			return nil, err
+		}
+		throttles = append(throttles, throttle)
+	}
+
+	//firestore requires the first order by to be on the inequality field, so sort by type and key here
+	sort.Slice(throttles, func(i, j int) bool {
+		if throttles[i].Type != throttles[j].Type {
+			return throttles[i].Type < throttles[j].Type
+		}
+		return throttles[i].Key < throttles[j].Key
+	})
+
+	return throttles, nil
+}
+
+func (crud *FirestoreCRUD) DeleteLoginThrottle(throttleType string, key string) (bool, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	doc, err := crud.getLoginThrottleDocRef(throttleType, key).Get(ctx)
+	cancel()
+
+	//check login throttle was found
+	if !doc.Exists() {
+		return false, nil
+	}
+
+	//handle other errors
+	if err != nil {
+		return false, common.ChainError("error getting login throttle", err)
+	}
+
+	//delete login throttle
+	err = crud.DocWriter.Delete(doc.Ref)
+	if err != nil {
+		return false, common.ChainError("error deleting login throttle", err)
+	}
+
+	return true, nil
+}
+
+func (crud *FirestoreCRUD) getLoginThrottleDocRef(throttleType string, key string) *firestore.DocumentRef {
+	//keys can contain characters that aren't allowed in document ids, so encode them
+	return crud.Client.Collection("login-throttles").Doc(throttleType + "-" + base64.RawURLEncoding.EncodeToString([]byte(key)))
+}
+
+func (*FirestoreCRUD) readLoginThrottleData(doc *firestore.DocumentSnapshot) (*models.LoginThrottle, error) {
+	throttle := &models.LoginThrottle{}
+
+	err := doc.DataTo(&throttle)
+	if err != nil {
+		return nil, common.ChainError("error reading login throttle data", err)
+	}
+
+	//normalize the timestamps to UTC
+	throttle.LastFailedAt = throttle.LastFailedAt.UTC()
+	throttle.LockedUntil = throttle.LockedUntil.UTC()
+
+	return throttle, nil
+}
@@ -0,0 +1,121 @@
+package firestoreadapter
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"google.golang.org/api/iterator"
+)
+
+func (crud *FirestoreCRUD) SavePasswordResetToken(token *models.PasswordResetToken) error {
+	//validate the password reset token model
+	verr := token.Validate()
+	if verr != models.ValidatePasswordResetTokenValid {
+		return errors.New(fmt.Sprint("error validating password reset token model:", verr))
+	}
+
+	//create password reset token
+	err := crud.DocWriter.Create(crud.getPasswordResetTokenDocRef(token.TokenHash), token)
+	if err != nil {
+		return common.ChainError("error creating password reset token", err)
+	}
+
+	return nil
+}
+
+func (crud *FirestoreCRUD) GetPasswordResetToken(hash []byte) (*models.PasswordResetToken, error) {
+	doc, err := crud.getPasswordResetToken(hash)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, nil
+	}
+
+	return crud.readPasswordResetTokenData(doc)
+}
+
+func (crud *FirestoreCRUD) DeletePasswordResetToken(hash []byte) (bool, error) {
+	//check password reset token already exists
+	doc, err := crud.getPasswordResetToken(hash)
+	if err != nil {
+		return false, err
+	}
+	if doc == nil {
+		return false, nil
+	}
+
+	//delete password reset token
+	err = crud.DocWriter.Delete(doc.Ref)
+	if err != nil {
+		return false, common.ChainError("error deleting password reset token", err)
+	}
+
+	return true, nil
+}
+
+func (crud *FirestoreCRUD) DeleteAllUserPasswordResetTokens(username string) error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := crud.Client.Collection("password-reset-tokens").
+		Where("username", "==", username).
+		Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	for {
+		doc, err := itr.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return common.ChainError("error getting next doc", err)
+		}
+
+		//delete password reset token
+		err = crud.DocWriter.Delete(doc.Ref)
+		if err != nil {
+			return common.ChainError("error deleting password reset token", err)
+		}
+	}
+}
+
+func (crud *FirestoreCRUD) getPasswordResetTokenDocRef(hash []byte) *firestore.DocumentRef {
+	return crud.Client.Collection("password-reset-tokens").Doc(base64.RawURLEncoding.EncodeToString(hash))
+}
+
+func (crud *FirestoreCRUD) getPasswordResetToken(hash []byte) (*firestore.DocumentSnapshot, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	doc, err := crud.getPasswordResetTokenDocRef(hash).Get(ctx)
+	cancel()
+
+	//check password reset token was found
+	if !doc.Exists() {
+		return nil, nil
+	}
+
+	//handle other errors
+	if err != nil {
+		return nil, common.ChainError("error getting password reset token", err)
+	}
+
+	return doc, nil
+}
+
+func (*FirestoreCRUD) readPasswordResetTokenData(doc *firestore.DocumentSnapshot) (*models.PasswordResetToken, error) {
+	token := &models.PasswordResetToken{}
+
+	err := doc.DataTo(&token)
+	if err != nil {
+		return nil, common.ChainError("error reading password reset token data", err)
+	}
+
+	//normalize the timestamp to UTC
+	token.ExpiresAt = token.ExpiresAt.UTC()
+
+	return token, nil
+}
@@ -0,0 +1,95 @@
+package firestoreadapter
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"google.golang.org/api/iterator"
+)
+
+func (crud *FirestoreCRUD) SavePreviousPassword(password *models.PreviousPassword) error {
+	//validate the previous password model
+	verr := password.Validate()
+	if verr != models.ValidatePreviousPasswordValid {
+		return errors.New(fmt.Sprint("error validating previous password model:", verr))
+	}
+
+	//create previous password
+	err := crud.DocWriter.Create(crud.getPreviousPasswordDocRef(password.Username, password.PasswordHash), password)
+	if err != nil {
+		return common.ChainError("error creating previous password", err)
+	}
+
+	return nil
+}
+
+func (crud *FirestoreCRUD) GetLatestUserPreviousPasswords(username string, limit int) ([]*models.PreviousPassword, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := crud.Client.Collection("previous-passwords").
+		Where("username", "==", username).
+		OrderBy("replaced_at", firestore.Desc).
+		Limit(limit).
+		Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	passwords := []*models.PreviousPassword{}
+	for {
+		doc, err := itr.Next()
+		if err == iterator.Done {
+			return passwords, nil
+		}
+		if err != nil {
+			return nil, common.ChainError("error getting next doc", err)
+		}
+
+		password := &models.PreviousPassword{}
+		err = doc.DataTo(password)
+		if err != nil {
+			return nil, common.ChainError("error reading previous password data", err)
+		}
+
+		passwords = append(passwords, password)
+	}
+}
+
+func (crud *FirestoreCRUD) DeleteUserPreviousPasswordsReplacedBefore(username string, before time.Time) error {
+	return crud.deleteUserPreviousPasswords(crud.Client.Collection("previous-passwords").
+		Where("username", "==", username).
+		Where("replaced_at", "<", before),
+	)
+}
+
+func (crud *FirestoreCRUD) deleteUserPreviousPasswords(query firestore.Query) error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := query.Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	for {
+		doc, err := itr.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return common.ChainError("error getting next doc", err)
+		}
+
+		//delete previous password
+		err = crud.DocWriter.Delete(doc.Ref)
+		if err != nil {
+			return common.ChainError("error deleting previous password", err)
+		}
+	}
+}
+
+func (crud *FirestoreCRUD) getPreviousPasswordDocRef(username string, hash []byte) *firestore.DocumentRef {
+	return crud.Client.Collection("previous-passwords").Doc(username + "-" + base64.RawURLEncoding.EncodeToString(hash))
+}
@@ -0,0 +1,82 @@
+package firestoreadapter
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"google.golang.org/api/iterator"
+)
+
+func (crud *FirestoreCRUD) SaveRecoveryCode(code *models.RecoveryCode) error {
+	//validate the recovery code model
+	verr := code.Validate()
+	if verr != models.ValidateRecoveryCodeValid {
+		return errors.New(fmt.Sprint("error validating recovery code model:", verr))
+	}
+
+	//create recovery code
+	err := crud.DocWriter.Create(crud.getRecoveryCodeDocRef(code.Username, code.CodeHash), code)
+	if err != nil {
+		return common.ChainError("error creating recovery code", err)
+	}
+
+	return nil
+}
+
+func (crud *FirestoreCRUD) DeleteRecoveryCode(username string, hash []byte) (bool, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	doc, err := crud.getRecoveryCodeDocRef(username, hash).Get(ctx)
+	cancel()
+
+	//check recovery code was found
+	if !doc.Exists() {
+		return false, nil
+	}
+
+	//handle other errors
+	if err != nil {
+		return false, common.ChainError("error getting recovery code", err)
+	}
+
+	//delete recovery code
+	err = crud.DocWriter.Delete(doc.Ref)
+	if err != nil {
+		return false, common.ChainError("error deleting recovery code", err)
+	}
+
+	return true, nil
+}
+
+func (crud *FirestoreCRUD) DeleteAllUserRecoveryCodes(username string) error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := crud.Client.Collection("recovery-codes").
+		Where("username", "==", username).
+		Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	for {
+		doc, err := itr.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return common.ChainError("error getting next doc", err)
+		}
+
+		//delete recovery code
+		err = crud.DocWriter.Delete(doc.Ref)
+		if err != nil {
+			return common.ChainError("error deleting recovery code", err)
+		}
+	}
+}
+
+func (crud *FirestoreCRUD) getRecoveryCodeDocRef(username string, hash []byte) *firestore.DocumentRef {
+	return crud.Client.Collection("recovery-codes").Doc(username + "-" + base64.RawURLEncoding.EncodeToString(hash))
+}
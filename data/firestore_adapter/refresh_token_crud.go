@@ -0,0 +1,133 @@
+package firestoreadapter
+
+import (
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"google.golang.org/api/iterator"
+
+	"github.com/google/uuid"
+)
+
+func (crud *FirestoreCRUD) SaveRefreshToken(token *models.RefreshToken) error {
+	//validate the refresh token model
+	verr := token.Validate()
+	if verr != models.ValidateRefreshTokenValid {
+		return errors.New(fmt.Sprint("error validating refresh token model:", verr))
+	}
+
+	//create refresh token
+	err := crud.DocWriter.Create(crud.getRefreshTokenDocRef(token.Token), token)
+	if err != nil {
+		return common.ChainError("error creating refresh token", err)
+	}
+
+	return nil
+}
+
+func (crud *FirestoreCRUD) GetRefreshToken(token uuid.UUID) (*models.RefreshToken, error) {
+	doc, err := crud.getRefreshToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, nil
+	}
+
+	return crud.readRefreshTokenData(doc)
+}
+
+func (crud *FirestoreCRUD) RotateRefreshToken(token uuid.UUID) (bool, error) {
+	//check refresh token already exists
+	doc, err := crud.getRefreshToken(token)
+	if err != nil {
+		return false, err
+	}
+	if doc == nil {
+		return false, nil
+	}
+
+	//check refresh token has not already been rotated
+	refreshToken, err := crud.readRefreshTokenData(doc)
+	if err != nil {
+		return false, err
+	}
+	if refreshToken.Rotated {
+		return false, nil
+	}
+
+	//mark the refresh token as rotated
+	err = crud.DocWriter.Update(doc.Ref, []firestore.Update{
+		{Path: "rotated", Value: true},
+	})
+	if err != nil {
+		return false, common.ChainError("error rotating refresh token", err)
+	}
+
+	return true, nil
+}
+
+func (crud *FirestoreCRUD) DeleteRefreshTokenFamily(familyID uuid.UUID) error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := crud.Client.Collection("refresh-tokens").
+		Where("family_id", "==", familyID).
+		Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	for {
+		doc, err := itr.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return common.ChainError("error getting next doc", err)
+		}
+
+		//delete refresh token
+		err = crud.DocWriter.Delete(doc.Ref)
+		if err != nil {
+			return common.ChainError("error deleting refresh token", err)
+		}
+	}
+}
+
+func (crud *FirestoreCRUD) getRefreshTokenDocRef(token uuid.UUID) *firestore.DocumentRef {
+	return crud.Client.Collection("refresh-tokens").Doc(token.String())
+}
+
+func (crud *FirestoreCRUD) getRefreshToken(token uuid.UUID) (*firestore.DocumentSnapshot, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	doc, err := crud.getRefreshTokenDocRef(token).Get(ctx)
+	cancel()
+
+	//check refresh token was found
+	if !doc.Exists() {
+		return nil, nil
+	}
+
+	//handle other errors
+	if err != nil {
+		return nil, common.ChainError("error getting refresh token", err)
+	}
+
+	return doc, nil
+}
+
+func (*FirestoreCRUD) readRefreshTokenData(doc *firestore.DocumentSnapshot) (*models.RefreshToken, error) {
+	token := &models.RefreshToken{}
+
+	err := doc.DataTo(&token)
+	if err != nil {
+		return nil, common.ChainError("error reading refresh token data", err)
+	}
+
+	//normalize the timestamp to UTC
+	token.ExpiresAt = token.ExpiresAt.UTC()
+
+	return token, nil
+}
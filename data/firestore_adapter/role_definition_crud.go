@@ -0,0 +1,190 @@
+package firestoreadapter
+
+import (
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"google.golang.org/api/iterator"
+
+	"github.com/google/uuid"
+)
+
+func (crud *FirestoreCRUD) CreateRoleDefinition(definition *models.RoleDefinition) error {
+	//validate the role definition model
+	verr := definition.Validate()
+	if verr != models.ValidateRoleDefinitionValid {
+		return errors.New(fmt.Sprint("error validating role definition model:", verr))
+	}
+
+	//create role definition
+	err := crud.DocWriter.Create(crud.getRoleDefinitionDocRef(definition.ClientUID, definition.Name), definition)
+	if err != nil {
+		return common.ChainError("error creating role definition", err)
+	}
+
+	return nil
+}
+
+func (crud *FirestoreCRUD) GetRoleDefinitionsByClientUID(clientUID uuid.UUID) ([]*models.RoleDefinition, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := crud.Client.Collection("role-definitions").
+		Where("client_uid", "==", clientUID).
+		OrderBy("name", firestore.Asc).
+		Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	//read the results
+	definitions := []*models.RoleDefinition{}
+	for {
+		doc, err := itr.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, common.ChainError("error getting next doc", err)
+		}
+
+		definition, err := crud.readRoleDefinitionData(doc)
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, definition)
+	}
+
+	return definitions, nil
+}
+
+func (crud *FirestoreCRUD) GetRoleDefinitionByClientUIDAndName(clientUID uuid.UUID, name string) (*models.RoleDefinition, error) {
+	doc, err := crud.getRoleDefinition(clientUID, name)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, nil
+	}
+
+	return crud.readRoleDefinitionData(doc)
+}
+
+func (crud *FirestoreCRUD) UpdateRoleDefinition(name string, definition *models.RoleDefinition) (bool, error) {
+	//validate the role definition model
+	verr := definition.Validate()
+	if verr != models.ValidateRoleDefinitionValid {
+		return false, errors.New(fmt.Sprint("error validating role definition model:", verr))
+	}
+
+	//check role definition already exists
+	doc, err := crud.getRoleDefinition(definition.ClientUID, name)
+	if err != nil {
+		return false, err
+	}
+	if doc == nil {
+		return false, nil
+	}
+
+	//update role definition in place if it is not being renamed
+	if definition.Name == name {
+		err = crud.DocWriter.Set(doc.Ref, definition)
+		if err != nil {
+			return true, common.ChainError("error updating role definition", err)
+		}
+
+		return true, nil
+	}
+
+	//the name is part of the doc id, so renaming moves the role definition to a new doc
+	err = crud.DocWriter.Create(crud.getRoleDefinitionDocRef(definition.ClientUID, definition.Name), definition)
+	if err != nil {
+		return true, common.ChainError("error creating renamed role definition", err)
+	}
+
+	err = crud.DocWriter.Delete(doc.Ref)
+	if err != nil {
+		return true, common.ChainError("error deleting old role definition", err)
+	}
+
+	return true, nil
+}
+
+func (crud *FirestoreCRUD) DeleteRoleDefinition(clientUID uuid.UUID, name string) (bool, error) {
+	//check role definition already exists
+	doc, err := crud.getRoleDefinition(clientUID, name)
+	if err != nil {
+		return false, err
+	}
+	if doc == nil {
+		return false, nil
+	}
+
+	//delete role definition
+	err = crud.DocWriter.Delete(doc.Ref)
+	if err != nil {
+		return false, common.ChainError("error deleting role definition", err)
+	}
+
+	return true, nil
+}
+
+func (crud *FirestoreCRUD) DeleteAllRoleDefinitionsByClientUID(uid uuid.UUID) error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := crud.Client.Collection("role-definitions").
+		Where("client_uid", "==", uid).
+		Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	for {
+		doc, err := itr.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return common.ChainError("error getting next doc", err)
+		}
+
+		//delete role definition
+		err = crud.DocWriter.Delete(doc.Ref)
+		if err != nil {
+			return common.ChainError("error deleting role definition", err)
+		}
+	}
+}
+
+func (crud *FirestoreCRUD) getRoleDefinitionDocRef(clientUID uuid.UUID, name string) *firestore.DocumentRef {
+	return crud.Client.Collection("role-definitions").Doc(clientUID.String() + "-" + name)
+}
+
+func (crud *FirestoreCRUD) getRoleDefinition(clientUID uuid.UUID, name string) (*firestore.DocumentSnapshot, error) {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	doc, err := crud.getRoleDefinitionDocRef(clientUID, name).Get(ctx)
+	cancel()
+
+	//check role definition was found
+	if !doc.Exists() {
+		return nil, nil
+	}
+
+	//handle other errors
+	if err != nil {
+		return nil, common.ChainError("error getting role definition", err)
+	}
+
+	return doc, nil
+}
+
+func (*FirestoreCRUD) readRoleDefinitionData(doc *firestore.DocumentSnapshot) (*models.RoleDefinition, error) {
+	definition := &models.RoleDefinition{}
+
+	err := doc.DataTo(&definition)
+	if err != nil {
+		return nil, common.ChainError("error reading role definition data", err)
+	}
+
+	return definition, nil
+}
@@ -3,6 +3,7 @@ package firestoreadapter
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"cloud.google.com/go/firestore"
 	"github.com/mhogar/amber/common"
@@ -40,6 +41,27 @@ func (crud *FirestoreCRUD) GetSessionByToken(token uuid.UUID) (*models.Session,
 	return crud.readSessionData(doc)
 }
 
+func (crud *FirestoreCRUD) UpdateSessionLastUsedAt(token uuid.UUID, lastUsedAt time.Time) (bool, error) {
+	//check session already exists
+	doc, err := crud.getSession(token)
+	if err != nil {
+		return false, err
+	}
+	if doc == nil {
+		return false, nil
+	}
+
+	//update the session
+	err = crud.DocWriter.Update(doc.Ref, []firestore.Update{
+		{Path: "last_used_at", Value: lastUsedAt},
+	})
+	if err != nil {
+		return false, common.ChainError("error updating session last used at", err)
+	}
+
+	return true, nil
+}
+
 func (crud *FirestoreCRUD) DeleteSession(token uuid.UUID) (bool, error) {
 	//check session already exists
 	doc, err := crud.getSession(token)
@@ -92,6 +114,39 @@ func (crud *FirestoreCRUD) DeleteAllOtherUserSessions(username string, token uui
 	return nil
 }
 
+func (crud *FirestoreCRUD) DeleteExpiredSessions(createdBefore time.Time, lastUsedBefore time.Time) error {
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	defer cancel()
+
+	//firestore cannot OR across fields, so collect the results of both queries
+	refs := map[string]*firestore.DocumentRef{}
+	queries := []firestore.Query{
+		crud.Client.Collection("sessions").Where("created_at", "<", createdBefore),
+		crud.Client.Collection("sessions").Where("last_used_at", "<", lastUsedBefore),
+	}
+
+	for _, query := range queries {
+		docs, err := query.Documents(ctx).GetAll()
+		if err != nil {
+			return common.ChainError("error getting expired sessions", err)
+		}
+
+		for _, doc := range docs {
+			refs[doc.Ref.ID] = doc.Ref
+		}
+	}
+
+	//delete the sessions
+	for _, ref := range refs {
+		err := crud.DocWriter.Delete(ref)
+		if err != nil {
+			return common.ChainError("error deleting session", err)
+		}
+	}
+
+	return nil
+}
+
 func (crud *FirestoreCRUD) deleteSessions(itr *firestore.DocumentIterator) error {
 	defer itr.Stop()
 	for {
@@ -141,5 +196,9 @@ func (*FirestoreCRUD) readSessionData(doc *firestore.DocumentSnapshot) (*models.
 		return nil, common.ChainError("error reading session data", err)
 	}
 
+	//normalize the timestamps to UTC
+	session.CreatedAt = session.CreatedAt.UTC()
+	session.LastUsedAt = session.LastUsedAt.UTC()
+
 	return session, nil
 }
@@ -3,6 +3,7 @@ package firestoreadapter
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"cloud.google.com/go/firestore"
 	"github.com/mhogar/amber/common"
@@ -30,7 +31,39 @@ func (crud *FirestoreCRUD) CreateUser(user *models.User) error {
 	return nil
 }
 
-func (crud *FirestoreCRUD) GetUsersWithLesserRank(rank int) ([]*models.User, error) {
+func (crud *FirestoreCRUD) GetUsersWithLesserRank(rank int, query models.PageQuery) ([]*models.User, error) {
+	users, err := crud.searchUsersWithLesserRank(rank, query.Search)
+	if err != nil {
+		return nil, err
+	}
+
+	//firestore requires the rank filter to be the first sort field and cannot search ignoring case, so the users are sorted and paged once read
+	cursors := make([]models.Cursor, len(users))
+	for index, user := range users {
+		cursors[index] = user.GetCursor(query.SortBy)
+	}
+
+	indexes := models.SortPage(cursors, query.SortBy == models.UserSortRank, query)
+
+	page := make([]*models.User, len(indexes))
+	for index, userIndex := range indexes {
+		page[index] = users[userIndex]
+	}
+	return page, nil
+}
+
+func (crud *FirestoreCRUD) CountUsersWithLesserRank(rank int, search string) (int, error) {
+	users, err := crud.searchUsersWithLesserRank(rank, search)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(users), nil
+}
+
+// searchUsersWithLesserRank fetches all the users with a rank less than the provided one whose username starts with the search.
+// Returns the users and any errors.
+func (crud *FirestoreCRUD) searchUsersWithLesserRank(rank int, search string) ([]*models.User, error) {
 	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
 	itr := crud.Client.Collection("users").
 		Where("rank", "<", rank).
@@ -56,7 +89,10 @@ func (crud *FirestoreCRUD) GetUsersWithLesserRank(rank int) ([]*models.User, err
 		if err != nil {
 			return nil, err
 		}
-		users = append(users, user)
+
+		if models.MatchesSearch(user.Username, search) {
+			users = append(users, user)
+		}
 	}
 
 	return users, nil
@@ -74,6 +110,33 @@ func (crud *FirestoreCRUD) GetUserByUsername(username string) (*models.User, err
 	return crud.readUserData(doc)
 }
 
+func (crud *FirestoreCRUD) GetUserByEmail(email string) (*models.User, error) {
+	//users without an email are stored with an empty one, so it should never match
+	if email == "" {
+		return nil, nil
+	}
+
+	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+	itr := crud.Client.Collection("users").
+		Where("email", "==", email).
+		Limit(1).
+		Documents(ctx)
+
+	defer cancel()
+	defer itr.Stop()
+
+	//check if a user was found
+	doc, err := itr.Next()
+	if err == iterator.Done {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, common.ChainError("error getting next doc", err)
+	}
+
+	return crud.readUserData(doc)
+}
+
 func (crud *FirestoreCRUD) UpdateUser(user *models.User) (bool, error) {
 	//validate the user model
 	verr := user.Validate()
@@ -94,6 +157,9 @@ func (crud *FirestoreCRUD) UpdateUser(user *models.User) (bool, error) {
 	err = crud.DocWriter.Update(doc.Ref, []firestore.Update{
 		{Path: "username", Value: user.Username},
 		{Path: "rank", Value: user.Rank},
+		{Path: "email", Value: user.Email},
+		{Path: "display_name", Value: user.DisplayName},
+		{Path: "enabled", Value: user.Enabled},
 	})
 	if err != nil {
 		return true, common.ChainError("error updating user", err)
@@ -102,7 +168,7 @@ func (crud *FirestoreCRUD) UpdateUser(user *models.User) (bool, error) {
 	return true, nil
 }
 
-func (crud *FirestoreCRUD) UpdateUserPassword(username string, hash []byte) (bool, error) {
+func (crud *FirestoreCRUD) UpdateUserPassword(username string, hash []byte, updatedAt time.Time) (bool, error) {
 	if hash == nil {
 		return false, errors.New("password hash cannot be nil")
 	}
@@ -119,6 +185,7 @@ func (crud *FirestoreCRUD) UpdateUserPassword(username string, hash []byte) (boo
 	//update fields
 	err = crud.DocWriter.Update(doc.Ref, []firestore.Update{
 		{Path: "password_hash", Value: hash},
+		{Path: "password_updated_at", Value: updatedAt},
 	})
 	if err != nil {
 		return false, common.ChainError("error updating user password", err)
@@ -127,6 +194,59 @@ func (crud *FirestoreCRUD) UpdateUserPassword(username string, hash []byte) (boo
 	return true, nil
 }
 
+func (crud *FirestoreCRUD) UpdateUserTOTP(username string, secret []byte, enabled bool) (bool, error) {
+	//check user already exists
+	doc, err := crud.getUser(username)
+	if err != nil {
+		return false, err
+	}
+	if doc == nil {
+		return false, nil
+	}
+
+	//update fields
+	err = crud.DocWriter.Update(doc.Ref, []firestore.Update{
+		{Path: "totp_secret", Value: secret},
+		{Path: "totp_enabled", Value: enabled},
+	})
+	if err != nil {
+		return false, common.ChainError("error updating user totp", err)
+	}
+
+	return true, nil
+}
+
+func (crud *FirestoreCRUD) UpdateUserTOTPLastTimeStep(username string, step int64) (bool, error) {
+	//check user already exists
+	doc, err := crud.getUser(username)
+	if err != nil {
+		return false, err
+	}
+	if doc == nil {
+		return false, nil
+	}
+
+	user, err := crud.readUserData(doc)
+	if err != nil {
+		return false, err
+	}
+
+	//only move the step forward
+	if user.TOTPLastTimeStep >= step {
+		return false, nil
+	}
+
+	//update fields
+	err = crud.DocWriter.Update(doc.Ref, []firestore.Update{
+		{Path: "totp_last_time_step", Value: step},
+	})
+	if err != nil {
+		return false, common.ChainError("error updating user totp last time step", err)
+	}
+
+	return true, nil
+}
+
 func (crud *FirestoreCRUD) DeleteUser(username string) (bool, error) {
 	//check user already exists
 	doc, err := crud.getUser(username)
@@ -149,12 +269,48 @@ func (crud *FirestoreCRUD) DeleteUser(username string) (bool, error) {
 		return false, common.ChainError("error deleting user-roles", err)
 	}
 
+	//delete all user admin roles
+	err = crud.DeleteAllUserAdminRolesByUsername(username)
+	if err != nil {
+		return false, err
+	}
+
+	//delete all client admins
+	err = crud.DeleteAllClientAdminsByUsername(username)
+	if err != nil {
+		return false, common.ChainError("error deleting client admins", err)
+	}
+
+	//delete all group memberships
+	err = crud.DeleteAllGroupMembersByUsername(username)
+	if err != nil {
+		return false, common.ChainError("error deleting group members", err)
+	}
+
 	//delete all user sessions
 	err = crud.DeleteAllUserSessions(username)
 	if err != nil {
 		return false, common.ChainError("error deleting user sessions", err)
 	}
 
+	//delete all user recovery codes
+	err = crud.DeleteAllUserRecoveryCodes(username)
+	if err != nil {
+		return false, common.ChainError("error deleting user recovery codes", err)
+	}
+
+	//delete all user password reset tokens
+	err = crud.DeleteAllUserPasswordResetTokens(username)
+	if err != nil {
+		return false, common.ChainError("error deleting user password reset tokens", err)
+	}
+
+	//delete all user previous passwords
+	err = crud.deleteUserPreviousPasswords(crud.Client.Collection("previous-passwords").Where("username", "==", username))
+	if err != nil {
+		return false, common.ChainError("error deleting user previous passwords", err)
+	}
+
 	return true, nil
 }
 
@@ -181,9 +337,14 @@ func (crud *FirestoreCRUD) getUser(username string) (*firestore.DocumentSnapshot
 }
 
 func (*FirestoreCRUD) readUserData(doc *firestore.DocumentSnapshot) (*models.User, error) {
-	user := &models.User{}
+	//users created before the enabled field was added should still be enabled
+	user := &models.User{
+		UserProfile: models.UserProfile{
+			Enabled: true,
+		},
+	}
 
-	err := doc.DataTo(&user)
+	err := doc.DataTo(user)
 	if err != nil {
 		return nil, common.ChainError("error reading user data", err)
 	}
@@ -28,24 +28,55 @@ func (crud *FirestoreCRUD) CreateUserRole(role *models.UserRole) error {
 	return nil
 }
 
-func (crud *FirestoreCRUD) GetUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank int) ([]*models.UserRole, error) {
+func (crud *FirestoreCRUD) GetUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank int, query models.PageQuery) ([]*models.UserRole, error) {
+	roles, err := crud.searchUserRolesWithLesserRankByClientUID(uid, rank, query.Search)
+	if err != nil {
+		return nil, err
+	}
+
+	//firestore cannot search ignoring case, so the user-roles are sorted and paged once read
+	cursors := make([]models.Cursor, len(roles))
+	for index, role := range roles {
+		cursors[index] = role.GetCursor(query.SortBy)
+	}
+
+	indexes := models.SortPage(cursors, false, query)
+
+	page := make([]*models.UserRole, len(indexes))
+	for index, roleIndex := range indexes {
+		page[index] = roles[roleIndex]
+	}
+	return page, nil
+}
+
+func (crud *FirestoreCRUD) CountUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank int, search string) (int, error) {
+	roles, err := crud.searchUserRolesWithLesserRankByClientUID(uid, rank, search)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(roles), nil
+}
+
+// searchUserRolesWithLesserRankByClientUID fetches all the user-roles for the provided client uid and with a rank less than the provided rank whose username starts with the search.
+// Returns the user-roles and any errors.
+func (crud *FirestoreCRUD) searchUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank int, search string) ([]*models.UserRole, error) {
 	//get users
-	users, err := crud.GetUsersWithLesserRank(rank)
+	users, err := crud.searchUsersWithLesserRank(rank, search)
 	if err != nil {
 		return nil, common.ChainError("error getting user with lesser rank", err)
 	}
 
-	//extract usernames
-	usernames := make([]string, len(users))
-	for index, user := range users {
-		usernames[index] = user.Username
+	//index usernames
+	usernames := make(map[string]bool, len(users))
+	for _, user := range users {
+		usernames[user.Username] = true
 	}
 
-	//get user-roles
+	//get user-roles, filtering by username once read since "in" queries are limited to a handful of values
 	ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
 	itr := crud.Client.Collection("user-roles").
 		Where("client_uid", "==", uid).
-		Where("username", "in", usernames).
 		OrderBy("username", firestore.Asc).
 		Documents(ctx)
 
@@ -67,7 +98,10 @@ func (crud *FirestoreCRUD) GetUserRolesWithLesserRankByClientUID(uid uuid.UUID,
 		if err != nil {
 			return nil, err
 		}
-		roles = append(roles, role)
+
+		if usernames[role.Username] {
+			roles = append(roles, role)
+		}
 	}
 
 	return roles, nil
@@ -110,6 +144,81 @@ func (crud *FirestoreCRUD) UpdateUserRole(role *models.UserRole) (bool, error) {
 	return true, nil
 }
 
+func (crud *FirestoreCRUD) CountUserRolesByClientUIDAndRole(clientUID uuid.UUID, role string) (int, error) {
+	docs, err := crud.getUserRoleDocsByClientUIDAndRole(clientUID, role)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(docs), nil
+}
+
+func (crud *FirestoreCRUD) RenameUserRoles(clientUID uuid.UUID, role string, newRole string) error {
+	docs, err := crud.getUserRoleDocsByClientUIDAndRole(clientUID, role)
+	if err != nil {
+		return err
+	}
+
+	//update user-roles
+	for _, doc := range docs {
+		userRole, err := crud.readUserRoleData(doc)
+		if err != nil {
+			return err
+		}
+
+		roles := []string{newRole}
+		for _, r := range userRole.Roles {
+			if r != role {
+				roles = append(roles, r)
+			}
+		}
+
+		//set the whole doc so any legacy role field is dropped
+		err = crud.DocWriter.Set(doc.Ref, models.CreateUserRole(userRole.ClientUID, userRole.Username, roles...))
+		if err != nil {
+			return common.ChainError("error updating user-role", err)
+		}
+	}
+
+	return nil
+}
+
+// getUserRoleDocsByClientUIDAndRole fetches all the user-roles for the provided client uid that include the given role.
+// User-roles written before roles became a set are matched on their single role field.
+// Returns the docs and any errors.
+func (crud *FirestoreCRUD) getUserRoleDocsByClientUIDAndRole(clientUID uuid.UUID, role string) ([]*firestore.DocumentSnapshot, error) {
+	queries := []firestore.Query{
+		crud.Client.Collection("user-roles").
+			Where("client_uid", "==", clientUID).
+			Where("roles", "array-contains", role),
+		crud.Client.Collection("user-roles").
+			Where("client_uid", "==", clientUID).
+			Where("role", "==", role),
+	}
+
+	docs := []*firestore.DocumentSnapshot{}
+	seen := map[string]bool{}
+
+	for _, query := range queries {
+		ctx, cancel := crud.ContextFactory.CreateStandardTimeoutContext()
+		queryDocs, err := query.Documents(ctx).GetAll()
+		cancel()
+
+		if err != nil {
+			return nil, common.ChainError("error getting user-role docs", err)
+		}
+
+		for _, doc := range queryDocs {
+			if !seen[doc.Ref.ID] {
+				seen[doc.Ref.ID] = true
+				docs = append(docs, doc)
+			}
+		}
+	}
+
+	return docs, nil
+}
+
 func (crud *FirestoreCRUD) DeleteUserRole(clientUID uuid.UUID, username string) (bool, error) {
 	//check user-role already exists
 	doc, err := crud.getUserRole(clientUID, username)
@@ -210,5 +319,11 @@ func (*FirestoreCRUD) readUserRoleData(doc *firestore.DocumentSnapshot) (*models
 		return nil, common.ChainError("error reading user-role data", err)
 	}
 
-	return role, nil
+	//user-roles written before roles became a set have a single role field
+	legacyRole, ok := doc.Data()["role"].(string)
+	if ok && legacyRole != "" && !role.HasRole(legacyRole) {
+		role.Roles = append(role.Roles, legacyRole)
+	}
+
+	return models.CreateUserRole(role.ClientUID, role.Username, role.Roles...), nil
 }
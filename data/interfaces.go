@@ -13,6 +13,19 @@ type DataCRUD interface {
 	models.ClientCRUD
 	models.SessionCRUD
 	models.UserRoleCRUD
+	models.RoleDefinitionCRUD
+	models.AdminRoleCRUD
+	models.ClientAdminCRUD
+	models.GroupCRUD
+	models.GroupRoleCRUD
+	models.AuthorizationCodeCRUD
+	models.RefreshTokenCRUD
+	models.RecoveryCodeCRUD
+	models.PreviousPasswordCRUD
+	models.PasswordResetTokenCRUD
+	models.InvitationCRUD
+	models.LoginThrottleCRUD
+	models.AuditCRUD
 }
 
 type Transaction interface {
@@ -34,9 +47,11 @@ type DataExecutor interface {
 
 type DataAdapter interface {
 	// Setup sets up the adapter and returns any errors.
+	// It opens the connections shared by every executor, so it should be called once before the adapter is used.
 	Setup() error
 
 	// CleanUp cleans up the adapter and returns any errors.
+	// Any work already in progress is finished before the connections are closed.
 	CleanUp() error
 
 	// GetExecutor gets the DataExecutor for the adapter.
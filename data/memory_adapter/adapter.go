@@ -0,0 +1,77 @@
+package memoryadapter
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/mhogar/amber/data"
+)
+
+// MemoryAdapter is a data adapter that keeps all its data in memory, so it needs no external services.
+// Its data only lasts until it is cleaned up, which makes it suited to tests and ephemeral dev servers.
+type MemoryAdapter struct {
+	mutex sync.RWMutex
+	store *store
+
+	//txMutex is held by the open transaction, so only one transaction runs at a time
+	txMutex sync.Mutex
+}
+
+// Setup creates the adapter's empty store. Always returns a nil error.
+func (a *MemoryAdapter) Setup() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.store = newStore()
+	return nil
+}
+
+// CleanUp discards the adapter's store along with all of its data.
+// The adapter should not be used again until it is set up. Always returns a nil error.
+func (a *MemoryAdapter) CleanUp() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.store = nil
+	return nil
+}
+
+func (a *MemoryAdapter) GetExecutor() data.DataExecutor {
+	exec := &MemoryExecutor{
+		Adapter: a,
+	}
+	exec.MemoryCRUD.StoreAccessor = exec
+
+	return exec
+}
+
+// read runs the function against the adapter's store while holding its read lock.
+func (a *MemoryAdapter) read(f func(*store) error) error {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	if a.store == nil {
+		return errors.New("memory adapter is not set up")
+	}
+	return f(a.store)
+}
+
+// write runs the function against a clone of the adapter's store while holding its write lock.
+// The clone replaces the store only if the function succeeds, so a failed write leaves no partial changes.
+func (a *MemoryAdapter) write(f func(*store) error) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.store == nil {
+		return errors.New("memory adapter is not set up")
+	}
+
+	s := a.store.clone()
+	err := f(s)
+	if err != nil {
+		return err
+	}
+
+	a.store = s
+	return nil
+}
@@ -0,0 +1,157 @@
+package memoryadapter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/mhogar/amber/models"
+)
+
+func (crud *MemoryCRUD) CreateAdminRole(role *models.AdminRole) error {
+	//validate the admin role model
+	verr := role.Validate()
+	if verr != models.ValidateAdminRoleValid {
+		return errors.New(fmt.Sprint("error validating admin role model:", verr))
+	}
+
+	r := copyAdminRole(role)
+
+	return crud.StoreAccessor.write(func(s *store) error {
+		if _, ok := s.adminRoles[r.Name]; ok {
+			return errors.New("admin role already exists")
+		}
+
+		s.adminRoles[r.Name] = r
+		return nil
+	})
+}
+
+func (crud *MemoryCRUD) GetAdminRoles() ([]*models.AdminRole, error) {
+	roles := []*models.AdminRole{}
+	err := crud.StoreAccessor.read(func(s *store) error {
+		for _, role := range s.adminRoles {
+			roles = append(roles, copyAdminRole(role))
+		}
+		return nil
+	})
+
+	sortAdminRoles(roles)
+	return roles, err
+}
+
+func (crud *MemoryCRUD) GetAdminRoleByName(name string) (*models.AdminRole, error) {
+	var role *models.AdminRole
+	err := crud.StoreAccessor.read(func(s *store) error {
+		if r, ok := s.adminRoles[name]; ok {
+			role = copyAdminRole(r)
+		}
+		return nil
+	})
+
+	return role, err
+}
+
+func (crud *MemoryCRUD) UpdateAdminRole(role *models.AdminRole) (bool, error) {
+	//validate the admin role model
+	verr := role.Validate()
+	if verr != models.ValidateAdminRoleValid {
+		return false, errors.New(fmt.Sprint("error validating admin role model:", verr))
+	}
+
+	r := copyAdminRole(role)
+
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		_, found = s.adminRoles[r.Name]
+		if found {
+			s.adminRoles[r.Name] = r
+		}
+		return nil
+	})
+
+	return found, err
+}
+
+func (crud *MemoryCRUD) DeleteAdminRole(name string) (bool, error) {
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		_, found = s.adminRoles[name]
+		delete(s.adminRoles, name)
+
+		//take the admin role away from the users it was given to
+		for key := range s.userAdminRoles {
+			if key.Name == name {
+				delete(s.userAdminRoles, key)
+			}
+		}
+		return nil
+	})
+
+	return found, err
+}
+
+func (crud *MemoryCRUD) GetAdminRolesByUsername(username string) ([]*models.AdminRole, error) {
+	roles := []*models.AdminRole{}
+	err := crud.StoreAccessor.read(func(s *store) error {
+		for key := range s.userAdminRoles {
+			if key.Username == username {
+				roles = append(roles, copyAdminRole(s.adminRoles[key.Name]))
+			}
+		}
+		return nil
+	})
+
+	sortAdminRoles(roles)
+	return roles, err
+}
+
+func (crud *MemoryCRUD) GetAdminRoleUsernames(name string) ([]string, error) {
+	usernames := []string{}
+	err := crud.StoreAccessor.read(func(s *store) error {
+		for key := range s.userAdminRoles {
+			if key.Name == name {
+				usernames = append(usernames, key.Username)
+			}
+		}
+		return nil
+	})
+
+	sort.Strings(usernames)
+	return usernames, err
+}
+
+func (crud *MemoryCRUD) SetUserAdminRoles(username string, names []string) error {
+	return crud.StoreAccessor.write(func(s *store) error {
+		//remove the user's current admin roles
+		for key := range s.userAdminRoles {
+			if key.Username == username {
+				delete(s.userAdminRoles, key)
+			}
+		}
+
+		//admin roles can only be given to existing users
+		if _, ok := s.users[username]; !ok {
+			return errors.New("admin role user does not exist")
+		}
+
+		//give the user each of the new admin roles that exist
+		for _, name := range names {
+			if _, ok := s.adminRoles[name]; ok {
+				s.userAdminRoles[userAdminRoleKey{Username: username, Name: name}] = true
+			}
+		}
+		return nil
+	})
+}
+
+// copyAdminRole copies the admin role so its permissions are not shared with the store.
+func copyAdminRole(role *models.AdminRole) *models.AdminRole {
+	return models.CreateAdminRole(role.Name, role.Description, role.Permissions...)
+}
+
+func sortAdminRoles(roles []*models.AdminRole) {
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].Name < roles[j].Name
+	})
+}
@@ -0,0 +1,83 @@
+package memoryadapter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/mhogar/amber/models"
+)
+
+func (crud *MemoryCRUD) SaveAuditEvent(event *models.AuditEvent) error {
+	//validate the audit event model
+	verr := event.Validate()
+	if verr != models.ValidateAuditEventValid {
+		return errors.New(fmt.Sprint("error validating audit event model:", verr))
+	}
+
+	e := *event
+	e.Timestamp = event.Timestamp.UTC()
+
+	return crud.StoreAccessor.write(func(s *store) error {
+		if _, ok := s.auditEvents[e.ID]; ok {
+			return errors.New("audit event with id already exists")
+		}
+
+		s.auditEvents[e.ID] = &e
+		return nil
+	})
+}
+
+func (crud *MemoryCRUD) GetAuditEvents(filter models.AuditEventFilter) ([]*models.AuditEvent, error) {
+	events := []*models.AuditEvent{}
+	err := crud.StoreAccessor.read(func(s *store) error {
+		for _, event := range s.auditEvents {
+			if matchesAuditEventFilter(event, filter) {
+				e := *event
+				events = append(events, &e)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	//order from newest to oldest
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].Timestamp.Equal(events[j].Timestamp) {
+			return events[i].Timestamp.After(events[j].Timestamp)
+		}
+		return events[i].ID.String() < events[j].ID.String()
+	})
+
+	//apply the offset and limit
+	if filter.Offset >= len(events) {
+		return []*models.AuditEvent{}, nil
+	}
+	events = events[filter.Offset:]
+
+	if filter.Limit < len(events) {
+		events = events[:filter.Limit]
+	}
+	return events, nil
+}
+
+func matchesAuditEventFilter(event *models.AuditEvent, filter models.AuditEventFilter) bool {
+	if filter.Actor != "" && event.Actor != filter.Actor {
+		return false
+	}
+	if filter.Action != "" && event.Action != filter.Action {
+		return false
+	}
+	if filter.Target != "" && event.Target != filter.Target {
+		return false
+	}
+	if !filter.Since.IsZero() && event.Timestamp.Before(filter.Since) {
+		return false
+	}
+	if !filter.Until.IsZero() && !event.Timestamp.Before(filter.Until) {
+		return false
+	}
+	return true
+}
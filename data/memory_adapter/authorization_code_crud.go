@@ -0,0 +1,59 @@
+package memoryadapter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+func (crud *MemoryCRUD) SaveAuthorizationCode(code *models.AuthorizationCode) error {
+	//validate the authorization code model
+	verr := code.Validate()
+	if verr != models.ValidateAuthorizationCodeValid {
+		return errors.New(fmt.Sprint("error validating authorization code model:", verr))
+	}
+
+	c := *code
+	c.ExpiresAt = code.ExpiresAt.UTC()
+
+	return crud.StoreAccessor.write(func(s *store) error {
+		if _, ok := s.authorizationCodes[c.Code]; ok {
+			return errors.New("authorization code already exists")
+		}
+
+		//authorization codes can only belong to existing clients and users
+		if !s.hasClientAndUser(c.ClientUID, c.Username) {
+			return errors.New("authorization code client or user does not exist")
+		}
+
+		s.authorizationCodes[c.Code] = &c
+		return nil
+	})
+}
+
+func (crud *MemoryCRUD) GetAuthorizationCode(code uuid.UUID) (*models.AuthorizationCode, error) {
+	var authorizationCode *models.AuthorizationCode
+	err := crud.StoreAccessor.read(func(s *store) error {
+		if c, ok := s.authorizationCodes[code]; ok {
+			authorizationCode = &models.AuthorizationCode{}
+			*authorizationCode = *c
+		}
+		return nil
+	})
+
+	return authorizationCode, err
+}
+
+func (crud *MemoryCRUD) DeleteAuthorizationCode(code uuid.UUID) (bool, error) {
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		_, found = s.authorizationCodes[code]
+		delete(s.authorizationCodes, code)
+		return nil
+	})
+
+	return found, err
+}
@@ -0,0 +1,95 @@
+package memoryadapter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+func (crud *MemoryCRUD) CreateClientAdmin(admin *models.ClientAdmin) error {
+	//validate the client admin model
+	verr := admin.Validate()
+	if verr != models.ValidateClientAdminValid {
+		return errors.New(fmt.Sprint("error validating client admin model:", verr))
+	}
+
+	a := *admin
+	key := clientAdminKey{ClientUID: a.ClientUID, Username: a.Username}
+
+	return crud.StoreAccessor.write(func(s *store) error {
+		if _, ok := s.clientAdmins[key]; ok {
+			return errors.New("client admin already exists")
+		}
+
+		//client admins can only belong to existing clients and users
+		if !s.hasClientAndUser(a.ClientUID, a.Username) {
+			return errors.New("client admin client or user does not exist")
+		}
+
+		s.clientAdmins[key] = &a
+		return nil
+	})
+}
+
+func (crud *MemoryCRUD) GetClientAdminsByClientUID(clientUID uuid.UUID) ([]*models.ClientAdmin, error) {
+	admins := []*models.ClientAdmin{}
+	err := crud.StoreAccessor.read(func(s *store) error {
+		for key, admin := range s.clientAdmins {
+			if key.ClientUID == clientUID {
+				a := *admin
+				admins = append(admins, &a)
+			}
+		}
+		return nil
+	})
+
+	sort.Slice(admins, func(i, j int) bool {
+		return admins[i].Username < admins[j].Username
+	})
+	return admins, err
+}
+
+func (crud *MemoryCRUD) GetClientAdminsByUsername(username string) ([]*models.ClientAdmin, error) {
+	admins := []*models.ClientAdmin{}
+	err := crud.StoreAccessor.read(func(s *store) error {
+		for key, admin := range s.clientAdmins {
+			if key.Username == username {
+				a := *admin
+				admins = append(admins, &a)
+			}
+		}
+		return nil
+	})
+
+	return admins, err
+}
+
+func (crud *MemoryCRUD) GetClientAdminByClientUIDAndUsername(clientUID uuid.UUID, username string) (*models.ClientAdmin, error) {
+	var admin *models.ClientAdmin
+	err := crud.StoreAccessor.read(func(s *store) error {
+		if a, ok := s.clientAdmins[clientAdminKey{ClientUID: clientUID, Username: username}]; ok {
+			admin = &models.ClientAdmin{}
+			*admin = *a
+		}
+		return nil
+	})
+
+	return admin, err
+}
+
+func (crud *MemoryCRUD) DeleteClientAdmin(clientUID uuid.UUID, username string) (bool, error) {
+	key := clientAdminKey{ClientUID: clientUID, Username: username}
+
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		_, found = s.clientAdmins[key]
+		delete(s.clientAdmins, key)
+		return nil
+	})
+
+	return found, err
+}
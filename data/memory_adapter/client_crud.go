@@ -0,0 +1,181 @@
+package memoryadapter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+func (crud *MemoryCRUD) CreateClient(client *models.Client) error {
+	//validate the client model
+	verr := client.Validate()
+	if verr != models.ValidateClientValid {
+		return errors.New(fmt.Sprint("error validating client model: ", verr))
+	}
+
+	//a new client does not have a secret until one is generated
+	c := models.CreateClient(client.UID, client.Name, client.RedirectUrl, client.TokenType, client.KeyUri)
+
+	return crud.StoreAccessor.write(func(s *store) error {
+		if _, ok := s.clients[c.UID]; ok {
+			return errors.New("client with uid already exists")
+		}
+
+		s.clients[c.UID] = c
+		return nil
+	})
+}
+
+func (crud *MemoryCRUD) GetClients(query models.PageQuery) ([]*models.Client, error) {
+	clients, err := crud.searchClients(query.Search)
+	if err != nil {
+		return nil, err
+	}
+
+	cursors := make([]models.Cursor, len(clients))
+	for index, client := range clients {
+		cursors[index] = client.GetCursor()
+	}
+
+	indexes := models.SortPage(cursors, false, query)
+
+	page := make([]*models.Client, len(indexes))
+	for index, clientIndex := range indexes {
+		page[index] = clients[clientIndex]
+	}
+	return page, nil
+}
+
+func (crud *MemoryCRUD) CountClients(search string) (int, error) {
+	clients, err := crud.searchClients(search)
+	return len(clients), err
+}
+
+// searchClients copies the clients whose name starts with the search.
+func (crud *MemoryCRUD) searchClients(search string) ([]*models.Client, error) {
+	clients := []*models.Client{}
+	err := crud.StoreAccessor.read(func(s *store) error {
+		for _, client := range s.clients {
+			if models.MatchesSearch(client.Name, search) {
+				clients = append(clients, copyClient(client))
+			}
+		}
+		return nil
+	})
+
+	return clients, err
+}
+
+func (crud *MemoryCRUD) GetClientByUID(uid uuid.UUID) (*models.Client, error) {
+	var client *models.Client
+	err := crud.StoreAccessor.read(func(s *store) error {
+		if c, ok := s.clients[uid]; ok {
+			client = copyClient(c)
+		}
+		return nil
+	})
+
+	return client, err
+}
+
+func (crud *MemoryCRUD) UpdateClient(client *models.Client) (bool, error) {
+	//validate the client model
+	verr := client.Validate()
+	if verr != models.ValidateClientValid {
+		return false, errors.New(fmt.Sprint("error validating client model: ", verr))
+	}
+
+	//update client, leaving its secret hash untouched
+	return crud.updateClient(client.UID, func(c *models.Client) {
+		c.Name = client.Name
+		c.RedirectUrl = client.RedirectUrl
+		c.TokenType = client.TokenType
+		c.KeyUri = client.KeyUri
+	})
+}
+
+func (crud *MemoryCRUD) UpdateClientSecretHash(uid uuid.UUID, secretHash []byte) (bool, error) {
+	secretHash = copyBytes(secretHash)
+	return crud.updateClient(uid, func(c *models.Client) {
+		c.SecretHash = secretHash
+	})
+}
+
+func (crud *MemoryCRUD) DeleteClient(uid uuid.UUID) (bool, error) {
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		_, found = s.clients[uid]
+		if !found {
+			return nil
+		}
+		delete(s.clients, uid)
+
+		//delete everything that belongs to the client
+		for key := range s.userRoles {
+			if key.ClientUID == uid {
+				delete(s.userRoles, key)
+			}
+		}
+		for key := range s.roleDefinitions {
+			if key.ClientUID == uid {
+				delete(s.roleDefinitions, key)
+			}
+		}
+		for key := range s.clientAdmins {
+			if key.ClientUID == uid {
+				delete(s.clientAdmins, key)
+			}
+		}
+		for key := range s.groupRoles {
+			if key.ClientUID == uid {
+				delete(s.groupRoles, key)
+			}
+		}
+		for key, code := range s.authorizationCodes {
+			if code.ClientUID == uid {
+				delete(s.authorizationCodes, key)
+			}
+		}
+		for key, token := range s.refreshTokens {
+			if token.ClientUID == uid {
+				delete(s.refreshTokens, key)
+			}
+		}
+		for key, invitation := range s.invitations {
+			s.invitations[key] = removeInvitationClientRoles(invitation, uid)
+		}
+
+		return nil
+	})
+
+	return found, err
+}
+
+// updateClient replaces the client with an updated copy if it exists. Returns if the client was found and any errors.
+func (crud *MemoryCRUD) updateClient(uid uuid.UUID, update func(*models.Client)) (bool, error) {
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		var client *models.Client
+		client, found = s.clients[uid]
+		if !found {
+			return nil
+		}
+
+		c := *client
+		update(&c)
+		s.clients[uid] = &c
+
+		return nil
+	})
+
+	return found, err
+}
+
+func copyClient(client *models.Client) *models.Client {
+	c := *client
+	c.SecretHash = copyBytes(client.SecretHash)
+	return &c
+}
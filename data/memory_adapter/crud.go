@@ -0,0 +1,15 @@
+package memoryadapter
+
+// storeAccessor provides access to the store a MemoryCRUD operates on.
+type storeAccessor interface {
+	// read runs the function against the store without changing it. Returns any errors from the function.
+	read(func(*store) error) error
+
+	// write runs the function against the store, keeping its changes only if it does not return an error.
+	// Returns any errors from the function.
+	write(func(*store) error) error
+}
+
+type MemoryCRUD struct {
+	StoreAccessor storeAccessor
+}
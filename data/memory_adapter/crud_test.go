@@ -0,0 +1,120 @@
+package memoryadapter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/data"
+	memoryadapter "github.com/mhogar/amber/data/memory_adapter"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type MemoryCRUDTestSuite struct {
+	helpers.CustomSuite
+	Executor data.DataExecutor
+}
+
+func (suite *MemoryCRUDTestSuite) SetupTest() {
+	adapter := &memoryadapter.MemoryAdapter{}
+	suite.Require().NoError(adapter.Setup())
+
+	suite.Executor = adapter.GetExecutor()
+}
+
+func (suite *MemoryCRUDTestSuite) TestSave_WhereParentDoesNotExist_ReturnsError() {
+	var save func() error
+	var expectedErrorMessage string
+
+	testCase := func() {
+		//act
+		err := save()
+
+		//assert
+		suite.Require().Error(err)
+		suite.ContainsSubstrings(err.Error(), expectedErrorMessage, "does not exist")
+	}
+
+	//the user and client do not exist
+	username := "username"
+	clientUID := uuid.New()
+
+	save = func() error {
+		return suite.Executor.SaveSession(models.CreateNewSession(username, 0))
+	}
+	expectedErrorMessage = "session user"
+	suite.Run("Session", testCase)
+
+	save = func() error {
+		return suite.Executor.SaveRecoveryCode(models.CreateRecoveryCode(username, []byte("hash")))
+	}
+	expectedErrorMessage = "recovery code user"
+	suite.Run("RecoveryCode", testCase)
+
+	save = func() error {
+		return suite.Executor.SavePreviousPassword(models.CreateNewPreviousPassword(username, []byte("hash")))
+	}
+	expectedErrorMessage = "previous password user"
+	suite.Run("PreviousPassword", testCase)
+
+	save = func() error {
+		return suite.Executor.SavePasswordResetToken(models.CreateNewPasswordResetToken([]byte("hash"), username, time.Hour))
+	}
+	expectedErrorMessage = "password reset token user"
+	suite.Run("PasswordResetToken", testCase)
+
+	save = func() error {
+		return suite.Executor.CreateRoleDefinition(models.CreateRoleDefinition(clientUID, "role", "", false))
+	}
+	expectedErrorMessage = "role definition client"
+	suite.Run("RoleDefinition", testCase)
+
+	save = func() error {
+		return suite.Executor.CreateUserRole(models.CreateUserRole(clientUID, username, "role"))
+	}
+	expectedErrorMessage = "user-role client or user"
+	suite.Run("UserRole", testCase)
+
+	save = func() error {
+		return suite.Executor.CreateClientAdmin(models.CreateClientAdmin(clientUID, username, false))
+	}
+	expectedErrorMessage = "client admin client or user"
+	suite.Run("ClientAdmin", testCase)
+
+	save = func() error {
+		return suite.Executor.CreateGroupRole(models.CreateGroupRole(clientUID, "group", "role"))
+	}
+	expectedErrorMessage = "group-role client or group"
+	suite.Run("GroupRole", testCase)
+
+	save = func() error {
+		return suite.Executor.CreateGroupMember("group", username)
+	}
+	expectedErrorMessage = "group or user"
+	suite.Run("GroupMember", testCase)
+
+	save = func() error {
+		return suite.Executor.SaveAuthorizationCode(models.CreateNewAuthorizationCode(clientUID, username, "challenge", "", "", time.Minute))
+	}
+	expectedErrorMessage = "authorization code client or user"
+	suite.Run("AuthorizationCode", testCase)
+
+	save = func() error {
+		return suite.Executor.SaveRefreshToken(models.CreateNewRefreshToken(uuid.New(), clientUID, username, time.Hour))
+	}
+	expectedErrorMessage = "refresh token client or user"
+	suite.Run("RefreshToken", testCase)
+
+	save = func() error {
+		return suite.Executor.SetUserAdminRoles(username, []string{"role"})
+	}
+	expectedErrorMessage = "admin role user"
+	suite.Run("UserAdminRole", testCase)
+}
+
+func TestMemoryCRUDTestSuite(t *testing.T) {
+	suite.Run(t, &MemoryCRUDTestSuite{})
+}
@@ -0,0 +1,45 @@
+package memoryadapter
+
+import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+)
+
+type MemoryExecutor struct {
+	MemoryCRUD
+	Adapter *MemoryAdapter
+}
+
+// CreateTransaction creates a new transaction working on a snapshot of the adapter's current data.
+// Waits for any open transaction to be committed or rolled back first.
+// Returns the transaction and any errors.
+func (exec *MemoryExecutor) CreateTransaction() (data.Transaction, error) {
+	//wait for the open transaction to finish so transactions can't make conflicting changes (e.g. both rotating the same refresh token)
+	exec.Adapter.txMutex.Lock()
+
+	var snapshot *store
+	err := exec.Adapter.read(func(s *store) error {
+		snapshot = s.clone()
+		return nil
+	})
+	if err != nil {
+		exec.Adapter.txMutex.Unlock()
+		return nil, common.ChainError("error creating snapshot", err)
+	}
+
+	tx := &MemoryTransaction{
+		Adapter:  exec.Adapter,
+		snapshot: snapshot,
+	}
+	tx.MemoryCRUD.StoreAccessor = tx
+
+	return tx, nil
+}
+
+func (exec *MemoryExecutor) read(f func(*store) error) error {
+	return exec.Adapter.read(f)
+}
+
+func (exec *MemoryExecutor) write(f func(*store) error) error {
+	return exec.Adapter.write(f)
+}
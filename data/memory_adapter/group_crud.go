@@ -0,0 +1,169 @@
+package memoryadapter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/mhogar/amber/models"
+)
+
+func (crud *MemoryCRUD) CreateGroup(group *models.Group) error {
+	//validate the group model
+	verr := group.Validate()
+	if verr != models.ValidateGroupValid {
+		return errors.New(fmt.Sprint("error validating group model:", verr))
+	}
+
+	g := *group
+
+	return crud.StoreAccessor.write(func(s *store) error {
+		if _, ok := s.groups[g.Name]; ok {
+			return errors.New("group already exists")
+		}
+
+		s.groups[g.Name] = &g
+		return nil
+	})
+}
+
+func (crud *MemoryCRUD) GetGroups() ([]*models.Group, error) {
+	groups := []*models.Group{}
+	err := crud.StoreAccessor.read(func(s *store) error {
+		for _, group := range s.groups {
+			g := *group
+			groups = append(groups, &g)
+		}
+		return nil
+	})
+
+	sortGroups(groups)
+	return groups, err
+}
+
+func (crud *MemoryCRUD) GetGroupByName(name string) (*models.Group, error) {
+	var group *models.Group
+	err := crud.StoreAccessor.read(func(s *store) error {
+		if g, ok := s.groups[name]; ok {
+			group = &models.Group{}
+			*group = *g
+		}
+		return nil
+	})
+
+	return group, err
+}
+
+func (crud *MemoryCRUD) UpdateGroup(group *models.Group) (bool, error) {
+	//validate the group model
+	verr := group.Validate()
+	if verr != models.ValidateGroupValid {
+		return false, errors.New(fmt.Sprint("error validating group model:", verr))
+	}
+
+	g := *group
+
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		_, found = s.groups[g.Name]
+		if found {
+			s.groups[g.Name] = &g
+		}
+		return nil
+	})
+
+	return found, err
+}
+
+func (crud *MemoryCRUD) DeleteGroup(name string) (bool, error) {
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		_, found = s.groups[name]
+		delete(s.groups, name)
+
+		//delete the group's members and the roles clients gave it
+		for key := range s.groupMembers {
+			if key.Name == name {
+				delete(s.groupMembers, key)
+			}
+		}
+		for key := range s.groupRoles {
+			if key.GroupName == name {
+				delete(s.groupRoles, key)
+			}
+		}
+		return nil
+	})
+
+	return found, err
+}
+
+func (crud *MemoryCRUD) GetGroupsByUsername(username string) ([]*models.Group, error) {
+	groups := []*models.Group{}
+	err := crud.StoreAccessor.read(func(s *store) error {
+		for key := range s.groupMembers {
+			if key.Username == username {
+				g := *s.groups[key.Name]
+				groups = append(groups, &g)
+			}
+		}
+		return nil
+	})
+
+	sortGroups(groups)
+	return groups, err
+}
+
+func (crud *MemoryCRUD) GetGroupMemberUsernames(name string) ([]string, error) {
+	usernames := []string{}
+	err := crud.StoreAccessor.read(func(s *store) error {
+		for key := range s.groupMembers {
+			if key.Name == name {
+				usernames = append(usernames, key.Username)
+			}
+		}
+		return nil
+	})
+
+	sort.Strings(usernames)
+	return usernames, err
+}
+
+func (crud *MemoryCRUD) CreateGroupMember(name string, username string) error {
+	key := groupMemberKey{Name: name, Username: username}
+
+	return crud.StoreAccessor.write(func(s *store) error {
+		if s.groupMembers[key] {
+			return errors.New("group member already exists")
+		}
+
+		//only existing users can be added to existing groups
+		_, hasGroup := s.groups[name]
+		_, hasUser := s.users[username]
+		if !hasGroup || !hasUser {
+			return errors.New("group or user does not exist")
+		}
+
+		s.groupMembers[key] = true
+		return nil
+	})
+}
+
+func (crud *MemoryCRUD) DeleteGroupMember(name string, username string) (bool, error) {
+	key := groupMemberKey{Name: name, Username: username}
+
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		found = s.groupMembers[key]
+		delete(s.groupMembers, key)
+		return nil
+	})
+
+	return found, err
+}
+
+func sortGroups(groups []*models.Group) {
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+}
@@ -0,0 +1,138 @@
+package memoryadapter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+func (crud *MemoryCRUD) CreateGroupRole(role *models.GroupRole) error {
+	//validate the group-role model
+	verr := role.Validate()
+	if verr != models.ValidateGroupRoleValid {
+		return errors.New(fmt.Sprint("error validating group-role model:", verr))
+	}
+
+	r := copyGroupRole(role)
+	key := groupRoleKey{ClientUID: r.ClientUID, GroupName: r.GroupName}
+
+	return crud.StoreAccessor.write(func(s *store) error {
+		if _, ok := s.groupRoles[key]; ok {
+			return errors.New("group-role already exists")
+		}
+
+		//group-roles can only belong to existing clients and groups
+		_, hasClient := s.clients[r.ClientUID]
+		_, hasGroup := s.groups[r.GroupName]
+		if !hasClient || !hasGroup {
+			return errors.New("group-role client or group does not exist")
+		}
+
+		s.groupRoles[key] = r
+		return nil
+	})
+}
+
+func (crud *MemoryCRUD) GetGroupRolesByClientUID(clientUID uuid.UUID) ([]*models.GroupRole, error) {
+	roles := []*models.GroupRole{}
+	err := crud.StoreAccessor.read(func(s *store) error {
+		for key, role := range s.groupRoles {
+			if key.ClientUID == clientUID {
+				roles = append(roles, copyGroupRole(role))
+			}
+		}
+		return nil
+	})
+
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].GroupName < roles[j].GroupName
+	})
+	return roles, err
+}
+
+func (crud *MemoryCRUD) GetGroupRoleByClientUIDAndGroupName(clientUID uuid.UUID, groupName string) (*models.GroupRole, error) {
+	var role *models.GroupRole
+	err := crud.StoreAccessor.read(func(s *store) error {
+		if r, ok := s.groupRoles[groupRoleKey{ClientUID: clientUID, GroupName: groupName}]; ok {
+			role = copyGroupRole(r)
+		}
+		return nil
+	})
+
+	return role, err
+}
+
+func (crud *MemoryCRUD) UpdateGroupRole(role *models.GroupRole) (bool, error) {
+	//validate the group-role model
+	verr := role.Validate()
+	if verr != models.ValidateGroupRoleValid {
+		return false, errors.New(fmt.Sprint("error validating group-role model:", verr))
+	}
+
+	r := copyGroupRole(role)
+	key := groupRoleKey{ClientUID: r.ClientUID, GroupName: r.GroupName}
+
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		_, found = s.groupRoles[key]
+		if found {
+			s.groupRoles[key] = r
+		}
+		return nil
+	})
+
+	return found, err
+}
+
+func (crud *MemoryCRUD) CountGroupRolesByClientUIDAndRole(clientUID uuid.UUID, role string) (int, error) {
+	count := 0
+	err := crud.StoreAccessor.read(func(s *store) error {
+		for key, r := range s.groupRoles {
+			if key.ClientUID == clientUID && r.HasRole(role) {
+				count++
+			}
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (crud *MemoryCRUD) RenameGroupRoles(clientUID uuid.UUID, role string, newRole string) error {
+	return crud.StoreAccessor.write(func(s *store) error {
+		for key, r := range s.groupRoles {
+			if key.ClientUID == clientUID && r.HasRole(role) {
+				roles := []string{newRole}
+				for _, name := range r.Roles {
+					if name != role {
+						roles = append(roles, name)
+					}
+				}
+				s.groupRoles[key] = models.CreateGroupRole(r.ClientUID, r.GroupName, roles...)
+			}
+		}
+		return nil
+	})
+}
+
+func (crud *MemoryCRUD) DeleteGroupRole(clientUID uuid.UUID, groupName string) (bool, error) {
+	key := groupRoleKey{ClientUID: clientUID, GroupName: groupName}
+
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		_, found = s.groupRoles[key]
+		delete(s.groupRoles, key)
+		return nil
+	})
+
+	return found, err
+}
+
+// copyGroupRole copies the group-role so its roles are not shared with the store.
+func copyGroupRole(role *models.GroupRole) *models.GroupRole {
+	return models.CreateGroupRole(role.ClientUID, role.GroupName, role.Roles...)
+}
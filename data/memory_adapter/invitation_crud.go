@@ -0,0 +1,120 @@
+package memoryadapter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+func (crud *MemoryCRUD) SaveInvitation(invitation *models.Invitation) error {
+	//validate the invitation model
+	verr := invitation.Validate()
+	if verr != models.ValidateInvitationValid {
+		return errors.New(fmt.Sprint("error validating invitation model:", verr))
+	}
+
+	inv := copyInvitation(invitation)
+	inv.ExpiresAt = inv.ExpiresAt.UTC()
+
+	return crud.StoreAccessor.write(func(s *store) error {
+		if _, ok := s.invitations[inv.ID]; ok {
+			return errors.New("invitation with id already exists")
+		}
+		for _, other := range s.invitations {
+			if string(other.TokenHash) == string(inv.TokenHash) {
+				return errors.New("invitation with token hash already exists")
+			}
+		}
+
+		//invitation roles can only be for existing clients
+		for _, role := range inv.Roles {
+			if _, ok := s.clients[role.ClientUID]; !ok {
+				return errors.New("invitation role client does not exist")
+			}
+		}
+
+		s.invitations[inv.ID] = inv
+		return nil
+	})
+}
+
+func (crud *MemoryCRUD) GetInvitationByID(id uuid.UUID) (*models.Invitation, error) {
+	var invitation *models.Invitation
+	err := crud.StoreAccessor.read(func(s *store) error {
+		if inv, ok := s.invitations[id]; ok {
+			invitation = copyInvitation(inv)
+		}
+		return nil
+	})
+
+	return invitation, err
+}
+
+func (crud *MemoryCRUD) GetInvitationByTokenHash(hash []byte) (*models.Invitation, error) {
+	var invitation *models.Invitation
+	err := crud.StoreAccessor.read(func(s *store) error {
+		for _, inv := range s.invitations {
+			if string(inv.TokenHash) == string(hash) {
+				invitation = copyInvitation(inv)
+				break
+			}
+		}
+		return nil
+	})
+
+	return invitation, err
+}
+
+func (crud *MemoryCRUD) DeleteInvitation(id uuid.UUID) (bool, error) {
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		_, found = s.invitations[id]
+		delete(s.invitations, id)
+		return nil
+	})
+
+	return found, err
+}
+
+func (crud *MemoryCRUD) RenameInvitationRoles(clientUID uuid.UUID, role string, newRole string) error {
+	return crud.StoreAccessor.write(func(s *store) error {
+		for key, invitation := range s.invitations {
+			inv := copyInvitation(invitation)
+			for _, r := range inv.Roles {
+				if r.ClientUID == clientUID && r.Role == role {
+					r.Role = newRole
+				}
+			}
+			s.invitations[key] = inv
+		}
+		return nil
+	})
+}
+
+// copyInvitation copies the invitation and its roles so the caller cannot change the stored model.
+func copyInvitation(invitation *models.Invitation) *models.Invitation {
+	roles := make([]*models.InvitationRole, len(invitation.Roles))
+	for index, role := range invitation.Roles {
+		roles[index] = models.CreateInvitationRole(role.ClientUID, role.Role)
+	}
+
+	return models.CreateInvitation(invitation.ID, copyBytes(invitation.TokenHash), invitation.Email, invitation.Rank, roles, invitation.ExpiresAt)
+}
+
+// removeInvitationClientRoles returns a copy of the invitation without its roles for the client.
+func removeInvitationClientRoles(invitation *models.Invitation, clientUID uuid.UUID) *models.Invitation {
+	inv := copyInvitation(invitation)
+
+	roles := []*models.InvitationRole{}
+	for _, role := range inv.Roles {
+		if role.ClientUID != clientUID {
+			roles = append(roles, role)
+		}
+	}
+	inv.Roles = roles
+
+	return inv
+}
@@ -0,0 +1,78 @@
+package memoryadapter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mhogar/amber/models"
+)
+
+func (crud *MemoryCRUD) SaveLoginThrottle(throttle *models.LoginThrottle) error {
+	//validate the login throttle model
+	verr := throttle.Validate()
+	if verr != models.ValidateLoginThrottleValid {
+		return errors.New(fmt.Sprint("error validating login throttle model:", verr))
+	}
+
+	t := *throttle
+	t.LastFailedAt = throttle.LastFailedAt.UTC()
+	t.LockedUntil = throttle.LockedUntil.UTC()
+
+	//create or replace the throttle
+	return crud.StoreAccessor.write(func(s *store) error {
+		s.loginThrottles[loginThrottleKey{Type: t.Type, Key: t.Key}] = &t
+		return nil
+	})
+}
+
+func (crud *MemoryCRUD) GetLoginThrottle(throttleType string, key string) (*models.LoginThrottle, error) {
+	var throttle *models.LoginThrottle
+	err := crud.StoreAccessor.read(func(s *store) error {
+		if t, ok := s.loginThrottles[loginThrottleKey{Type: throttleType, Key: key}]; ok {
+			throttle = &models.LoginThrottle{}
+			*throttle = *t
+		}
+		return nil
+	})
+
+	return throttle, err
+}
+
+func (crud *MemoryCRUD) GetLockedLoginThrottles(now time.Time) ([]*models.LoginThrottle, error) {
+	throttles := []*models.LoginThrottle{}
+	err := crud.StoreAccessor.read(func(s *store) error {
+		for _, throttle := range s.loginThrottles {
+			if throttle.LockedUntil.After(now) {
+				t := *throttle
+				throttles = append(throttles, &t)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(throttles, func(i, j int) bool {
+		if throttles[i].Type != throttles[j].Type {
+			return throttles[i].Type < throttles[j].Type
+		}
+		return throttles[i].Key < throttles[j].Key
+	})
+	return throttles, nil
+}
+
+func (crud *MemoryCRUD) DeleteLoginThrottle(throttleType string, key string) (bool, error) {
+	k := loginThrottleKey{Type: throttleType, Key: key}
+
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		_, found = s.loginThrottles[k]
+		delete(s.loginThrottles, k)
+		return nil
+	})
+
+	return found, err
+}
@@ -0,0 +1,63 @@
+package memoryadapter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mhogar/amber/models"
+)
+
+func (crud *MemoryCRUD) Setup() error {
+	return nil
+}
+
+func (crud *MemoryCRUD) CreateMigration(timestamp string) error {
+	//create and validate migration model
+	migration := models.CreateMigration(timestamp)
+	verr := migration.Validate()
+	if verr != models.ValidateMigrationValid {
+		return errors.New(fmt.Sprint("error validating migration model:", verr))
+	}
+
+	return crud.StoreAccessor.write(func(s *store) error {
+		if _, ok := s.migrations[timestamp]; ok {
+			return errors.New("migration with timestamp already exists")
+		}
+
+		s.migrations[timestamp] = migration
+		return nil
+	})
+}
+
+func (crud *MemoryCRUD) GetMigrationByTimestamp(timestamp string) (*models.Migration, error) {
+	var migration *models.Migration
+	err := crud.StoreAccessor.read(func(s *store) error {
+		if m, ok := s.migrations[timestamp]; ok {
+			migration = models.CreateMigration(m.Timestamp)
+		}
+		return nil
+	})
+
+	return migration, err
+}
+
+func (crud *MemoryCRUD) GetLatestTimestamp() (string, bool, error) {
+	latest := ""
+	err := crud.StoreAccessor.read(func(s *store) error {
+		for timestamp := range s.migrations {
+			if timestamp > latest {
+				latest = timestamp
+			}
+		}
+		return nil
+	})
+
+	return latest, latest != "", err
+}
+
+func (crud *MemoryCRUD) DeleteMigrationByTimestamp(timestamp string) error {
+	return crud.StoreAccessor.write(func(s *store) error {
+		delete(s.migrations, timestamp)
+		return nil
+	})
+}
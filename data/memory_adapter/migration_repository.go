@@ -0,0 +1,19 @@
+package memoryadapter
+
+import (
+	"github.com/mhogar/amber/data"
+	"github.com/mhogar/migrationrunner"
+)
+
+// MigrationRepository has no migrations, since the memory adapter has no schema to migrate.
+type MigrationRepository struct{}
+
+func (MigrationRepository) GetMigrations() []migrationrunner.Migration {
+	return []migrationrunner.Migration{}
+}
+
+type MemoryMigrationRepositoryFactory struct{}
+
+func (MemoryMigrationRepositoryFactory) CreateMigrationRepository(_ data.DataExecutor) migrationrunner.MigrationRepository {
+	return &MigrationRepository{}
+}
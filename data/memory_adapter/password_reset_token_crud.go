@@ -0,0 +1,69 @@
+package memoryadapter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mhogar/amber/models"
+)
+
+func (crud *MemoryCRUD) SavePasswordResetToken(token *models.PasswordResetToken) error {
+	//validate the password reset token model
+	verr := token.Validate()
+	if verr != models.ValidatePasswordResetTokenValid {
+		return errors.New(fmt.Sprint("error validating password reset token model:", verr))
+	}
+
+	t := models.CreatePasswordResetToken(copyBytes(token.TokenHash), token.Username, token.ExpiresAt.UTC())
+	key := string(t.TokenHash)
+
+	return crud.StoreAccessor.write(func(s *store) error {
+		if _, ok := s.passwordResetTokens[key]; ok {
+			return errors.New("password reset token already exists")
+		}
+
+		//password reset tokens can only belong to existing users
+		if _, ok := s.users[t.Username]; !ok {
+			return errors.New("password reset token user does not exist")
+		}
+
+		s.passwordResetTokens[key] = t
+		return nil
+	})
+}
+
+func (crud *MemoryCRUD) GetPasswordResetToken(hash []byte) (*models.PasswordResetToken, error) {
+	var token *models.PasswordResetToken
+	err := crud.StoreAccessor.read(func(s *store) error {
+		if t, ok := s.passwordResetTokens[string(hash)]; ok {
+			token = models.CreatePasswordResetToken(copyBytes(t.TokenHash), t.Username, t.ExpiresAt)
+		}
+		return nil
+	})
+
+	return token, err
+}
+
+func (crud *MemoryCRUD) DeletePasswordResetToken(hash []byte) (bool, error) {
+	key := string(hash)
+
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		_, found = s.passwordResetTokens[key]
+		delete(s.passwordResetTokens, key)
+		return nil
+	})
+
+	return found, err
+}
+
+func (crud *MemoryCRUD) DeleteAllUserPasswordResetTokens(username string) error {
+	return crud.StoreAccessor.write(func(s *store) error {
+		for key, token := range s.passwordResetTokens {
+			if token.Username == username {
+				delete(s.passwordResetTokens, key)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,71 @@
+package memoryadapter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mhogar/amber/models"
+)
+
+func (crud *MemoryCRUD) SavePreviousPassword(password *models.PreviousPassword) error {
+	//validate the previous password model
+	verr := password.Validate()
+	if verr != models.ValidatePreviousPasswordValid {
+		return errors.New(fmt.Sprint("error validating previous password model:", verr))
+	}
+
+	p := models.CreatePreviousPassword(password.Username, copyBytes(password.PasswordHash), password.ReplacedAt.UTC())
+	key := previousPasswordKey{Username: p.Username, PasswordHash: string(p.PasswordHash)}
+
+	return crud.StoreAccessor.write(func(s *store) error {
+		if _, ok := s.previousPasswords[key]; ok {
+			return errors.New("previous password already exists")
+		}
+
+		//previous passwords can only belong to existing users
+		if _, ok := s.users[p.Username]; !ok {
+			return errors.New("previous password user does not exist")
+		}
+
+		s.previousPasswords[key] = p
+		return nil
+	})
+}
+
+func (crud *MemoryCRUD) GetLatestUserPreviousPasswords(username string, limit int) ([]*models.PreviousPassword, error) {
+	passwords := []*models.PreviousPassword{}
+	err := crud.StoreAccessor.read(func(s *store) error {
+		for key, p := range s.previousPasswords {
+			if key.Username == username {
+				passwords = append(passwords, models.CreatePreviousPassword(p.Username, copyBytes(p.PasswordHash), p.ReplacedAt))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	//newest first
+	sort.Slice(passwords, func(i, j int) bool {
+		return passwords[i].ReplacedAt.After(passwords[j].ReplacedAt)
+	})
+
+	if len(passwords) > limit {
+		passwords = passwords[:limit]
+	}
+	return passwords, nil
+}
+
+func (crud *MemoryCRUD) DeleteUserPreviousPasswordsReplacedBefore(username string, before time.Time) error {
+	return crud.StoreAccessor.write(func(s *store) error {
+		for key, p := range s.previousPasswords {
+			if key.Username == username && p.ReplacedAt.Before(before) {
+				delete(s.previousPasswords, key)
+			}
+		}
+		return nil
+	})
+}
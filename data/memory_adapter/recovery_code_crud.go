@@ -0,0 +1,57 @@
+package memoryadapter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mhogar/amber/models"
+)
+
+func (crud *MemoryCRUD) SaveRecoveryCode(code *models.RecoveryCode) error {
+	//validate the recovery code model
+	verr := code.Validate()
+	if verr != models.ValidateRecoveryCodeValid {
+		return errors.New(fmt.Sprint("error validating recovery code model:", verr))
+	}
+
+	c := models.CreateRecoveryCode(code.Username, copyBytes(code.CodeHash))
+	key := recoveryCodeKey{Username: c.Username, CodeHash: string(c.CodeHash)}
+
+	return crud.StoreAccessor.write(func(s *store) error {
+		if _, ok := s.recoveryCodes[key]; ok {
+			return errors.New("recovery code already exists")
+		}
+
+		//recovery codes can only belong to existing users
+		if _, ok := s.users[c.Username]; !ok {
+			return errors.New("recovery code user does not exist")
+		}
+
+		s.recoveryCodes[key] = c
+		return nil
+	})
+}
+
+func (crud *MemoryCRUD) DeleteRecoveryCode(username string, hash []byte) (bool, error) {
+	key := recoveryCodeKey{Username: username, CodeHash: string(hash)}
+
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		_, found = s.recoveryCodes[key]
+		delete(s.recoveryCodes, key)
+		return nil
+	})
+
+	return found, err
+}
+
+func (crud *MemoryCRUD) DeleteAllUserRecoveryCodes(username string) error {
+	return crud.StoreAccessor.write(func(s *store) error {
+		for key := range s.recoveryCodes {
+			if key.Username == username {
+				delete(s.recoveryCodes, key)
+			}
+		}
+		return nil
+	})
+}
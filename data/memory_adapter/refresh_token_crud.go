@@ -0,0 +1,79 @@
+package memoryadapter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+func (crud *MemoryCRUD) SaveRefreshToken(token *models.RefreshToken) error {
+	//validate the refresh token model
+	verr := token.Validate()
+	if verr != models.ValidateRefreshTokenValid {
+		return errors.New(fmt.Sprint("error validating refresh token model:", verr))
+	}
+
+	t := *token
+	t.ExpiresAt = token.ExpiresAt.UTC()
+
+	return crud.StoreAccessor.write(func(s *store) error {
+		if _, ok := s.refreshTokens[t.Token]; ok {
+			return errors.New("refresh token already exists")
+		}
+
+		//refresh tokens can only belong to existing clients and users
+		if !s.hasClientAndUser(t.ClientUID, t.Username) {
+			return errors.New("refresh token client or user does not exist")
+		}
+
+		s.refreshTokens[t.Token] = &t
+		return nil
+	})
+}
+
+func (crud *MemoryCRUD) GetRefreshToken(token uuid.UUID) (*models.RefreshToken, error) {
+	var refreshToken *models.RefreshToken
+	err := crud.StoreAccessor.read(func(s *store) error {
+		if t, ok := s.refreshTokens[token]; ok {
+			refreshToken = &models.RefreshToken{}
+			*refreshToken = *t
+		}
+		return nil
+	})
+
+	return refreshToken, err
+}
+
+func (crud *MemoryCRUD) RotateRefreshToken(token uuid.UUID) (bool, error) {
+	rotated := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		//only a token that has not been rotated yet can be rotated
+		refreshToken, ok := s.refreshTokens[token]
+		rotated = ok && !refreshToken.Rotated
+		if !rotated {
+			return nil
+		}
+
+		t := *refreshToken
+		t.Rotated = true
+		s.refreshTokens[token] = &t
+
+		return nil
+	})
+
+	return rotated, err
+}
+
+func (crud *MemoryCRUD) DeleteRefreshTokenFamily(familyID uuid.UUID) error {
+	return crud.StoreAccessor.write(func(s *store) error {
+		for key, token := range s.refreshTokens {
+			if token.FamilyID == familyID {
+				delete(s.refreshTokens, key)
+			}
+		}
+		return nil
+	})
+}
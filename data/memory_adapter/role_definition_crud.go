@@ -0,0 +1,111 @@
+package memoryadapter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+func (crud *MemoryCRUD) CreateRoleDefinition(definition *models.RoleDefinition) error {
+	//validate the role definition model
+	verr := definition.Validate()
+	if verr != models.ValidateRoleDefinitionValid {
+		return errors.New(fmt.Sprint("error validating role definition model:", verr))
+	}
+
+	d := *definition
+	key := roleDefinitionKey{ClientUID: d.ClientUID, Name: d.Name}
+
+	return crud.StoreAccessor.write(func(s *store) error {
+		if _, ok := s.roleDefinitions[key]; ok {
+			return errors.New("role definition already exists")
+		}
+
+		//role definitions can only belong to existing clients
+		if _, ok := s.clients[d.ClientUID]; !ok {
+			return errors.New("role definition client does not exist")
+		}
+
+		s.roleDefinitions[key] = &d
+		return nil
+	})
+}
+
+func (crud *MemoryCRUD) GetRoleDefinitionsByClientUID(clientUID uuid.UUID) ([]*models.RoleDefinition, error) {
+	definitions := []*models.RoleDefinition{}
+	err := crud.StoreAccessor.read(func(s *store) error {
+		for key, definition := range s.roleDefinitions {
+			if key.ClientUID == clientUID {
+				d := *definition
+				definitions = append(definitions, &d)
+			}
+		}
+		return nil
+	})
+
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].Name < definitions[j].Name
+	})
+	return definitions, err
+}
+
+func (crud *MemoryCRUD) GetRoleDefinitionByClientUIDAndName(clientUID uuid.UUID, name string) (*models.RoleDefinition, error) {
+	var definition *models.RoleDefinition
+	err := crud.StoreAccessor.read(func(s *store) error {
+		if d, ok := s.roleDefinitions[roleDefinitionKey{ClientUID: clientUID, Name: name}]; ok {
+			definition = &models.RoleDefinition{}
+			*definition = *d
+		}
+		return nil
+	})
+
+	return definition, err
+}
+
+func (crud *MemoryCRUD) UpdateRoleDefinition(name string, definition *models.RoleDefinition) (bool, error) {
+	//validate the role definition model
+	verr := definition.Validate()
+	if verr != models.ValidateRoleDefinitionValid {
+		return false, errors.New(fmt.Sprint("error validating role definition model:", verr))
+	}
+
+	d := *definition
+	key := roleDefinitionKey{ClientUID: d.ClientUID, Name: name}
+	newKey := roleDefinitionKey{ClientUID: d.ClientUID, Name: d.Name}
+
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		_, found = s.roleDefinitions[key]
+		if !found {
+			return nil
+		}
+
+		//the new name must not already be taken
+		if _, ok := s.roleDefinitions[newKey]; ok && newKey != key {
+			return errors.New("role definition already exists")
+		}
+
+		delete(s.roleDefinitions, key)
+		s.roleDefinitions[newKey] = &d
+		return nil
+	})
+
+	return found, err
+}
+
+func (crud *MemoryCRUD) DeleteRoleDefinition(clientUID uuid.UUID, name string) (bool, error) {
+	key := roleDefinitionKey{ClientUID: clientUID, Name: name}
+
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		_, found = s.roleDefinitions[key]
+		delete(s.roleDefinitions, key)
+		return nil
+	})
+
+	return found, err
+}
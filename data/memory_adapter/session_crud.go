@@ -0,0 +1,116 @@
+package memoryadapter
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+func (crud *MemoryCRUD) SaveSession(session *models.Session) error {
+	//validate the session model
+	verr := session.Validate()
+	if verr != models.ValidateSessionValid {
+		return errors.New(fmt.Sprint("error validating session model:", verr))
+	}
+
+	sess := *session
+	sess.CreatedAt = session.CreatedAt.UTC()
+	sess.LastUsedAt = session.LastUsedAt.UTC()
+
+	return crud.StoreAccessor.write(func(s *store) error {
+		if _, ok := s.sessions[sess.Token]; ok {
+			return errors.New("session with token already exists")
+		}
+
+		//sessions can only belong to existing users
+		if _, ok := s.users[sess.Username]; !ok {
+			return errors.New("session user does not exist")
+		}
+
+		s.sessions[sess.Token] = &sess
+		return nil
+	})
+}
+
+func (crud *MemoryCRUD) GetSessionByToken(token uuid.UUID) (*models.Session, error) {
+	var session *models.Session
+	err := crud.StoreAccessor.read(func(s *store) error {
+		sess, ok := s.sessions[token]
+		if !ok {
+			return nil
+		}
+
+		//the rank is always the user's current rank
+		session = &models.Session{}
+		*session = *sess
+		session.Rank = s.users[sess.Username].Rank
+
+		return nil
+	})
+
+	return session, err
+}
+
+func (crud *MemoryCRUD) UpdateSessionLastUsedAt(token uuid.UUID, lastUsedAt time.Time) (bool, error) {
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		var session *models.Session
+		session, found = s.sessions[token]
+		if !found {
+			return nil
+		}
+
+		sess := *session
+		sess.LastUsedAt = lastUsedAt.UTC()
+		s.sessions[token] = &sess
+
+		return nil
+	})
+
+	return found, err
+}
+
+func (crud *MemoryCRUD) DeleteSession(token uuid.UUID) (bool, error) {
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		_, found = s.sessions[token]
+		delete(s.sessions, token)
+		return nil
+	})
+
+	return found, err
+}
+
+func (crud *MemoryCRUD) DeleteAllUserSessions(username string) error {
+	return crud.deleteSessions(func(session *models.Session) bool {
+		return session.Username == username
+	})
+}
+
+func (crud *MemoryCRUD) DeleteAllOtherUserSessions(username string, token uuid.UUID) error {
+	return crud.deleteSessions(func(session *models.Session) bool {
+		return session.Username == username && session.Token != token
+	})
+}
+
+func (crud *MemoryCRUD) DeleteExpiredSessions(createdBefore time.Time, lastUsedBefore time.Time) error {
+	return crud.deleteSessions(func(session *models.Session) bool {
+		return session.CreatedAt.Before(createdBefore) || session.LastUsedAt.Before(lastUsedBefore)
+	})
+}
+
+// deleteSessions deletes every session that matches. Returns any errors.
+func (crud *MemoryCRUD) deleteSessions(match func(*models.Session) bool) error {
+	return crud.StoreAccessor.write(func(s *store) error {
+		for token, session := range s.sessions {
+			if match(session) {
+				delete(s.sessions, token)
+			}
+		}
+		return nil
+	})
+}
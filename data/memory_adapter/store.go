@@ -0,0 +1,176 @@
+package memoryadapter
+
+import (
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+type userRoleKey struct {
+	ClientUID uuid.UUID
+	Username  string
+}
+
+type roleDefinitionKey struct {
+	ClientUID uuid.UUID
+	Name      string
+}
+
+type userAdminRoleKey struct {
+	Username string
+	Name     string
+}
+
+type clientAdminKey struct {
+	ClientUID uuid.UUID
+	Username  string
+}
+
+type groupMemberKey struct {
+	Name     string
+	Username string
+}
+
+type groupRoleKey struct {
+	ClientUID uuid.UUID
+	GroupName string
+}
+
+type recoveryCodeKey struct {
+	Username string
+	CodeHash string
+}
+
+type previousPasswordKey struct {
+	Username     string
+	PasswordHash string
+}
+
+type loginThrottleKey struct {
+	Type string
+	Key  string
+}
+
+// store holds the adapter's data, with a map for each model.
+// The models in a store are never modified once added, only replaced, so clones can share them.
+type store struct {
+	migrations          map[string]*models.Migration
+	users               map[string]*models.User
+	clients             map[uuid.UUID]*models.Client
+	sessions            map[uuid.UUID]*models.Session
+	userRoles           map[userRoleKey]*models.UserRole
+	roleDefinitions     map[roleDefinitionKey]*models.RoleDefinition
+	adminRoles          map[string]*models.AdminRole
+	userAdminRoles      map[userAdminRoleKey]bool
+	clientAdmins        map[clientAdminKey]*models.ClientAdmin
+	groups              map[string]*models.Group
+	groupMembers        map[groupMemberKey]bool
+	groupRoles          map[groupRoleKey]*models.GroupRole
+	authorizationCodes  map[uuid.UUID]*models.AuthorizationCode
+	refreshTokens       map[uuid.UUID]*models.RefreshToken
+	recoveryCodes       map[recoveryCodeKey]*models.RecoveryCode
+	previousPasswords   map[previousPasswordKey]*models.PreviousPassword
+	passwordResetTokens map[string]*models.PasswordResetToken
+	invitations         map[uuid.UUID]*models.Invitation
+	loginThrottles      map[loginThrottleKey]*models.LoginThrottle
+	auditEvents         map[uuid.UUID]*models.AuditEvent
+}
+
+func newStore() *store {
+	return &store{
+		migrations:          map[string]*models.Migration{},
+		users:               map[string]*models.User{},
+		clients:             map[uuid.UUID]*models.Client{},
+		sessions:            map[uuid.UUID]*models.Session{},
+		userRoles:           map[userRoleKey]*models.UserRole{},
+		roleDefinitions:     map[roleDefinitionKey]*models.RoleDefinition{},
+		adminRoles:          map[string]*models.AdminRole{},
+		userAdminRoles:      map[userAdminRoleKey]bool{},
+		clientAdmins:        map[clientAdminKey]*models.ClientAdmin{},
+		groups:              map[string]*models.Group{},
+		groupMembers:        map[groupMemberKey]bool{},
+		groupRoles:          map[groupRoleKey]*models.GroupRole{},
+		authorizationCodes:  map[uuid.UUID]*models.AuthorizationCode{},
+		refreshTokens:       map[uuid.UUID]*models.RefreshToken{},
+		recoveryCodes:       map[recoveryCodeKey]*models.RecoveryCode{},
+		previousPasswords:   map[previousPasswordKey]*models.PreviousPassword{},
+		passwordResetTokens: map[string]*models.PasswordResetToken{},
+		invitations:         map[uuid.UUID]*models.Invitation{},
+		loginThrottles:      map[loginThrottleKey]*models.LoginThrottle{},
+		auditEvents:         map[uuid.UUID]*models.AuditEvent{},
+	}
+}
+
+// clone creates a copy of the store whose maps can be changed without affecting the original.
+func (s *store) clone() *store {
+	c := newStore()
+	for k, v := range s.migrations {
+		c.migrations[k] = v
+	}
+	for k, v := range s.users {
+		c.users[k] = v
+	}
+	for k, v := range s.clients {
+		c.clients[k] = v
+	}
+	for k, v := range s.sessions {
+		c.sessions[k] = v
+	}
+	for k, v := range s.userRoles {
+		c.userRoles[k] = v
+	}
+	for k, v := range s.roleDefinitions {
+		c.roleDefinitions[k] = v
+	}
+	for k, v := range s.adminRoles {
+		c.adminRoles[k] = v
+	}
+	for k, v := range s.userAdminRoles {
+		c.userAdminRoles[k] = v
+	}
+	for k, v := range s.clientAdmins {
+		c.clientAdmins[k] = v
+	}
+	for k, v := range s.groups {
+		c.groups[k] = v
+	}
+	for k, v := range s.groupMembers {
+		c.groupMembers[k] = v
+	}
+	for k, v := range s.groupRoles {
+		c.groupRoles[k] = v
+	}
+	for k, v := range s.authorizationCodes {
+		c.authorizationCodes[k] = v
+	}
+	for k, v := range s.refreshTokens {
+		c.refreshTokens[k] = v
+	}
+	for k, v := range s.recoveryCodes {
+		c.recoveryCodes[k] = v
+	}
+	for k, v := range s.previousPasswords {
+		c.previousPasswords[k] = v
+	}
+	for k, v := range s.passwordResetTokens {
+		c.passwordResetTokens[k] = v
+	}
+	for k, v := range s.invitations {
+		c.invitations[k] = v
+	}
+	for k, v := range s.loginThrottles {
+		c.loginThrottles[k] = v
+	}
+	for k, v := range s.auditEvents {
+		c.auditEvents[k] = v
+	}
+	return c
+}
+
+// copyBytes copies the byte slice so the caller's slice cannot change the stored model.
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append([]byte{}, b...)
+}
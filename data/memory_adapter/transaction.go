@@ -0,0 +1,101 @@
+package memoryadapter
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/mhogar/amber/common"
+)
+
+// MemoryTransaction stages its changes on its own snapshot of the adapter's data, so they are only seen inside the transaction.
+// Only one transaction is open at a time, so no other transaction can commit changes the snapshot doesn't have.
+// Committing replays the changes against the adapter's latest data, so changes made outside of transactions in the meantime are kept.
+type MemoryTransaction struct {
+	MemoryCRUD
+	Adapter *MemoryAdapter
+
+	mutex    sync.Mutex
+	snapshot *store
+	writes   []func(*store) error
+	done     bool
+}
+
+func (tx *MemoryTransaction) read(f func(*store) error) error {
+	tx.mutex.Lock()
+	defer tx.mutex.Unlock()
+
+	if tx.done {
+		return errors.New("transaction has already been committed or rolled back")
+	}
+	return f(tx.snapshot)
+}
+
+func (tx *MemoryTransaction) write(f func(*store) error) error {
+	tx.mutex.Lock()
+	defer tx.mutex.Unlock()
+
+	if tx.done {
+		return errors.New("transaction has already been committed or rolled back")
+	}
+
+	//apply the write to a clone of the snapshot so a failed write leaves no partial changes
+	s := tx.snapshot.clone()
+	err := f(s)
+	if err != nil {
+		return err
+	}
+
+	tx.snapshot = s
+	tx.writes = append(tx.writes, f)
+	return nil
+}
+
+// Commit applies the transaction's changes to the adapter's data.
+// If any of the changes now fail (e.g. the same user was created outside of a transaction), none of them are applied.
+// Returns any errors.
+func (tx *MemoryTransaction) Commit() error {
+	tx.mutex.Lock()
+	defer tx.mutex.Unlock()
+
+	if tx.done {
+		return errors.New("transaction has already been committed or rolled back")
+	}
+	tx.done = true
+	defer tx.Adapter.txMutex.Unlock()
+
+	if len(tx.writes) == 0 {
+		return nil
+	}
+
+	err := tx.Adapter.write(func(s *store) error {
+		for _, f := range tx.writes {
+			err := f(s)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return common.ChainError("error applying transaction changes", err)
+	}
+
+	return nil
+}
+
+// Rollback discards the transaction's changes. Does nothing if the transaction was already committed.
+// Always returns a nil error.
+func (tx *MemoryTransaction) Rollback() error {
+	tx.mutex.Lock()
+	defer tx.mutex.Unlock()
+
+	if !tx.done {
+		tx.Adapter.txMutex.Unlock()
+	}
+
+	tx.done = true
+	tx.snapshot = nil
+	tx.writes = nil
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package memoryadapter_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/data"
+	memoryadapter "github.com/mhogar/amber/data/memory_adapter"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TransactionTestSuite struct {
+	helpers.CustomSuite
+	Adapter  *memoryadapter.MemoryAdapter
+	Executor data.DataExecutor
+}
+
+func (suite *TransactionTestSuite) SetupTest() {
+	suite.Adapter = &memoryadapter.MemoryAdapter{}
+	suite.Require().NoError(suite.Adapter.Setup())
+
+	suite.Executor = suite.Adapter.GetExecutor()
+}
+
+func (suite *TransactionTestSuite) CreateTransaction() data.Transaction {
+	tx, err := suite.Executor.CreateTransaction()
+	suite.Require().NoError(err)
+
+	return tx
+}
+
+func (suite *TransactionTestSuite) CreateUser(CRUD models.UserCRUD, username string) {
+	err := CRUD.CreateUser(models.CreateUser(username, 0, []byte("password")))
+	suite.Require().NoError(err)
+}
+
+func (suite *TransactionTestSuite) GetUser(CRUD models.UserCRUD, username string) *models.User {
+	user, err := CRUD.GetUserByUsername(username)
+	suite.Require().NoError(err)
+
+	return user
+}
+
+func (suite *TransactionTestSuite) TestTransaction_ChangesAreOnlyVisibleInsideTransactionUntilCommitted() {
+	//arrange
+	tx := suite.CreateTransaction()
+
+	//act
+	suite.CreateUser(tx, "username")
+
+	//assert
+	suite.NotNil(suite.GetUser(tx, "username"))
+	suite.Nil(suite.GetUser(suite.Executor, "username"))
+
+	suite.Require().NoError(tx.Commit())
+	suite.NotNil(suite.GetUser(suite.Executor, "username"))
+}
+
+func (suite *TransactionTestSuite) TestRollback_DiscardsChanges() {
+	//arrange
+	suite.CreateUser(suite.Executor, "username")
+	tx := suite.CreateTransaction()
+
+	_, err := tx.DeleteUser("username")
+	suite.Require().NoError(err)
+
+	//act
+	err = tx.Rollback()
+
+	//assert
+	suite.Require().NoError(err)
+	suite.NotNil(suite.GetUser(suite.Executor, "username"))
+}
+
+func (suite *TransactionTestSuite) TestRollback_AfterCommit_DoesNotDiscardChanges() {
+	//arrange
+	tx := suite.CreateTransaction()
+	suite.CreateUser(tx, "username")
+	suite.Require().NoError(tx.Commit())
+
+	//act
+	err := tx.Rollback()
+
+	//assert
+	suite.Require().NoError(err)
+	suite.NotNil(suite.GetUser(suite.Executor, "username"))
+}
+
+func (suite *TransactionTestSuite) TestCommit_KeepsChangesMadeOutsideTransaction() {
+	//arrange
+	tx := suite.CreateTransaction()
+
+	suite.CreateUser(tx, "username1")
+	suite.CreateUser(suite.Executor, "username2")
+
+	//act
+	err := tx.Commit()
+
+	//assert
+	suite.Require().NoError(err)
+	suite.NotNil(suite.GetUser(suite.Executor, "username1"))
+	suite.NotNil(suite.GetUser(suite.Executor, "username2"))
+}
+
+func (suite *TransactionTestSuite) TestCommit_WhereChangeNoLongerApplies_ReturnsErrorAndAppliesNoChanges() {
+	//arrange
+	tx := suite.CreateTransaction()
+
+	suite.CreateUser(tx, "other")
+	suite.CreateUser(tx, "username")
+	suite.CreateUser(suite.Executor, "username")
+
+	//act
+	err := tx.Commit()
+
+	//assert
+	suite.ContainsSubstrings(err.Error(), "error applying transaction changes", "already exists")
+	suite.Nil(suite.GetUser(suite.Executor, "other"))
+}
+
+func (suite *TransactionTestSuite) TestCreateTransaction_WaitsForOpenTransactionToFinish() {
+	//arrange
+	suite.CreateUser(suite.Executor, "username")
+
+	tx1 := suite.CreateTransaction()
+	res, err := tx1.DeleteUser("username")
+	suite.Require().NoError(err)
+	suite.Require().True(res)
+
+	//act
+	results := make(chan bool)
+	go func() {
+		tx2, err := suite.Executor.CreateTransaction()
+		if err != nil {
+			close(results)
+			return
+		}
+		defer tx2.Rollback()
+
+		res, _ := tx2.DeleteUser("username")
+		results <- res
+	}()
+
+	//assert
+	select {
+	case <-results:
+		suite.Fail("transaction was created while another was still open")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	suite.Require().NoError(tx1.Commit())
+
+	//the second transaction sees the user was already deleted
+	res, ok := <-results
+	suite.Require().True(ok)
+	suite.False(res)
+}
+
+func (suite *TransactionTestSuite) TestTransaction_AfterCommit_ReturnsError() {
+	//arrange
+	tx := suite.CreateTransaction()
+	suite.Require().NoError(tx.Commit())
+
+	//act
+	_, err := tx.GetUserByUsername("username")
+
+	//assert
+	suite.ContainsSubstrings(err.Error(), "already been committed or rolled back")
+}
+
+func (suite *TransactionTestSuite) TestTransaction_WithConcurrentTransactions_AppliesEveryChange() {
+	//arrange
+	count := 50
+	wg := sync.WaitGroup{}
+
+	//act
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			tx, err := suite.Executor.CreateTransaction()
+			if err != nil {
+				return
+			}
+			defer tx.Rollback()
+
+			tx.CreateUser(models.CreateUser(fmt.Sprint("username", i), 0, []byte("password")))
+			suite.Executor.GetUsersWithLesserRank(1, models.PageQuery{})
+			tx.Commit()
+		}(i)
+	}
+	wg.Wait()
+
+	//assert
+	users, err := suite.Executor.GetUsersWithLesserRank(1, models.PageQuery{})
+	suite.Require().NoError(err)
+	suite.Len(users, count)
+}
+
+func (suite *TransactionTestSuite) TestCleanUp_DiscardsData() {
+	//arrange
+	suite.CreateUser(suite.Executor, "username")
+
+	//act
+	err := suite.Adapter.CleanUp()
+
+	//assert
+	suite.Require().NoError(err)
+
+	_, err = suite.Executor.GetUserByUsername("username")
+	suite.ContainsSubstrings(err.Error(), "not set up")
+
+	suite.Require().NoError(suite.Adapter.Setup())
+	suite.Nil(suite.GetUser(suite.Executor, "username"))
+}
+
+func TestTransactionTestSuite(t *testing.T) {
+	suite.Run(t, &TransactionTestSuite{})
+}
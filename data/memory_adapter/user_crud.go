@@ -0,0 +1,280 @@
+package memoryadapter
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mhogar/amber/models"
+)
+
+func (crud *MemoryCRUD) CreateUser(user *models.User) error {
+	//validate the user model
+	verr := user.Validate()
+	if verr != models.ValidateUserValid {
+		return errors.New(fmt.Sprint("error validating user model:", verr))
+	}
+
+	if user.PasswordHash == nil {
+		return errors.New("password hash cannot be nil")
+	}
+
+	//only the username, rank, password hash, and profile are set when creating a user
+	u := models.CreateUser(user.Username, user.Rank, copyBytes(user.PasswordHash))
+	u.PasswordUpdatedAt = user.PasswordUpdatedAt.UTC()
+	u.UserProfile = user.UserProfile
+
+	return crud.StoreAccessor.write(func(s *store) error {
+		if _, ok := s.users[u.Username]; ok {
+			return errors.New("user with username already exists")
+		}
+		if findUserByEmail(s, u.Email) != nil {
+			return errors.New("user with email already exists")
+		}
+
+		s.users[u.Username] = u
+		return nil
+	})
+}
+
+func (crud *MemoryCRUD) GetUsersWithLesserRank(rank int, query models.PageQuery) ([]*models.User, error) {
+	users, err := crud.searchUsersWithLesserRank(rank, query.Search)
+	if err != nil {
+		return nil, err
+	}
+
+	cursors := make([]models.Cursor, len(users))
+	for index, user := range users {
+		cursors[index] = user.GetCursor(query.SortBy)
+	}
+
+	indexes := models.SortPage(cursors, query.SortBy == models.UserSortRank, query)
+
+	page := make([]*models.User, len(indexes))
+	for index, userIndex := range indexes {
+		page[index] = users[userIndex]
+	}
+	return page, nil
+}
+
+func (crud *MemoryCRUD) CountUsersWithLesserRank(rank int, search string) (int, error) {
+	users, err := crud.searchUsersWithLesserRank(rank, search)
+	return len(users), err
+}
+
+// searchUsersWithLesserRank copies the users with a rank less than the provided one whose username starts with the search.
+func (crud *MemoryCRUD) searchUsersWithLesserRank(rank int, search string) ([]*models.User, error) {
+	users := []*models.User{}
+	err := crud.StoreAccessor.read(func(s *store) error {
+		for _, user := range s.users {
+			if user.Rank < rank && models.MatchesSearch(user.Username, search) {
+				users = append(users, copyUser(user))
+			}
+		}
+		return nil
+	})
+
+	return users, err
+}
+
+func (crud *MemoryCRUD) GetUserByUsername(username string) (*models.User, error) {
+	var user *models.User
+	err := crud.StoreAccessor.read(func(s *store) error {
+		if u, ok := s.users[username]; ok {
+			user = copyUser(u)
+		}
+		return nil
+	})
+
+	return user, err
+}
+
+func (crud *MemoryCRUD) GetUserByEmail(email string) (*models.User, error) {
+	var user *models.User
+	err := crud.StoreAccessor.read(func(s *store) error {
+		if u := findUserByEmail(s, email); u != nil {
+			user = copyUser(u)
+		}
+		return nil
+	})
+
+	return user, err
+}
+
+func (crud *MemoryCRUD) UpdateUser(user *models.User) (bool, error) {
+	//validate the user model
+	verr := user.Validate()
+	if verr != models.ValidateUserValid {
+		return false, errors.New(fmt.Sprint("error validating user model:", verr))
+	}
+
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		u, ok := s.users[user.Username]
+		if !ok {
+			return nil
+		}
+		found = true
+
+		other := findUserByEmail(s, user.Email)
+		if other != nil && other.Username != user.Username {
+			return errors.New("user with email already exists")
+		}
+
+		updated := *u
+		updated.Rank = user.Rank
+		updated.UserProfile = user.UserProfile
+		s.users[user.Username] = &updated
+
+		return nil
+	})
+
+	return found, err
+}
+
+func (crud *MemoryCRUD) UpdateUserPassword(username string, hash []byte, updatedAt time.Time) (bool, error) {
+	if hash == nil {
+		return false, errors.New("password hash cannot be nil")
+	}
+
+	hash = copyBytes(hash)
+	return crud.updateUser(username, func(u *models.User) {
+		u.PasswordHash = hash
+		u.PasswordUpdatedAt = updatedAt.UTC()
+	})
+}
+
+func (crud *MemoryCRUD) UpdateUserTOTP(username string, secret []byte, enabled bool) (bool, error) {
+	secret = copyBytes(secret)
+	return crud.updateUser(username, func(u *models.User) {
+		u.TOTPSecret = secret
+		u.TOTPEnabled = enabled
+	})
+}
+
+func (crud *MemoryCRUD) UpdateUserTOTPLastTimeStep(username string, step int64) (bool, error) {
+	updated := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		u, ok := s.users[username]
+		if !ok || u.TOTPLastTimeStep >= step {
+			return nil
+		}
+		updated = true
+
+		user := *u
+		user.TOTPLastTimeStep = step
+		s.users[username] = &user
+
+		return nil
+	})
+
+	return updated, err
+}
+
+func (crud *MemoryCRUD) DeleteUser(username string) (bool, error) {
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		_, found = s.users[username]
+		if !found {
+			return nil
+		}
+		delete(s.users, username)
+
+		//delete everything that belongs to the user
+		for key, role := range s.userRoles {
+			if role.Username == username {
+				delete(s.userRoles, key)
+			}
+		}
+		for key := range s.userAdminRoles {
+			if key.Username == username {
+				delete(s.userAdminRoles, key)
+			}
+		}
+		for key := range s.clientAdmins {
+			if key.Username == username {
+				delete(s.clientAdmins, key)
+			}
+		}
+		for key := range s.groupMembers {
+			if key.Username == username {
+				delete(s.groupMembers, key)
+			}
+		}
+		for key, session := range s.sessions {
+			if session.Username == username {
+				delete(s.sessions, key)
+			}
+		}
+		for key, code := range s.authorizationCodes {
+			if code.Username == username {
+				delete(s.authorizationCodes, key)
+			}
+		}
+		for key, token := range s.refreshTokens {
+			if token.Username == username {
+				delete(s.refreshTokens, key)
+			}
+		}
+		for key := range s.recoveryCodes {
+			if key.Username == username {
+				delete(s.recoveryCodes, key)
+			}
+		}
+		for key, token := range s.passwordResetTokens {
+			if token.Username == username {
+				delete(s.passwordResetTokens, key)
+			}
+		}
+		for key := range s.previousPasswords {
+			if key.Username == username {
+				delete(s.previousPasswords, key)
+			}
+		}
+
+		return nil
+	})
+
+	return found, err
+}
+
+// updateUser replaces the user with an updated copy if it exists. Returns if the user was found and any errors.
+func (crud *MemoryCRUD) updateUser(username string, update func(*models.User)) (bool, error) {
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		var user *models.User
+		user, found = s.users[username]
+		if !found {
+			return nil
+		}
+
+		u := *user
+		update(&u)
+		s.users[username] = &u
+
+		return nil
+	})
+
+	return found, err
+}
+
+// findUserByEmail finds the user with the email in the store. Users without an email never match.
+func findUserByEmail(s *store, email string) *models.User {
+	if email == "" {
+		return nil
+	}
+
+	for _, user := range s.users {
+		if user.Email == email {
+			return user
+		}
+	}
+	return nil
+}
+
+func copyUser(user *models.User) *models.User {
+	u := *user
+	u.PasswordHash = copyBytes(user.PasswordHash)
+	u.TOTPSecret = copyBytes(user.TOTPSecret)
+	return &u
+}
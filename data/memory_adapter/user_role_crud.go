@@ -0,0 +1,165 @@
+package memoryadapter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+)
+
+func (crud *MemoryCRUD) CreateUserRole(role *models.UserRole) error {
+	//validate the user-role model
+	verr := role.Validate()
+	if verr != models.ValidateUserRoleValid {
+		return errors.New(fmt.Sprint("error validating user-role model:", verr))
+	}
+
+	r := copyUserRole(role)
+	key := userRoleKey{ClientUID: r.ClientUID, Username: r.Username}
+
+	return crud.StoreAccessor.write(func(s *store) error {
+		if _, ok := s.userRoles[key]; ok {
+			return errors.New("user-role already exists")
+		}
+
+		//user-roles can only belong to existing clients and users
+		if !s.hasClientAndUser(r.ClientUID, r.Username) {
+			return errors.New("user-role client or user does not exist")
+		}
+
+		s.userRoles[key] = r
+		return nil
+	})
+}
+
+func (crud *MemoryCRUD) GetUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank int, query models.PageQuery) ([]*models.UserRole, error) {
+	roles, err := crud.searchUserRolesWithLesserRankByClientUID(uid, rank, query.Search)
+	if err != nil {
+		return nil, err
+	}
+
+	cursors := make([]models.Cursor, len(roles))
+	for index, role := range roles {
+		cursors[index] = role.GetCursor(query.SortBy)
+	}
+
+	indexes := models.SortPage(cursors, false, query)
+
+	page := make([]*models.UserRole, len(indexes))
+	for index, roleIndex := range indexes {
+		page[index] = roles[roleIndex]
+	}
+	return page, nil
+}
+
+func (crud *MemoryCRUD) CountUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank int, search string) (int, error) {
+	roles, err := crud.searchUserRolesWithLesserRankByClientUID(uid, rank, search)
+	return len(roles), err
+}
+
+// searchUserRolesWithLesserRankByClientUID copies the user-roles for the provided client uid and with a rank less than the provided rank whose username starts with the search.
+func (crud *MemoryCRUD) searchUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank int, search string) ([]*models.UserRole, error) {
+	roles := []*models.UserRole{}
+	err := crud.StoreAccessor.read(func(s *store) error {
+		for key, role := range s.userRoles {
+			if key.ClientUID == uid && s.users[key.Username].Rank < rank && models.MatchesSearch(key.Username, search) {
+				roles = append(roles, copyUserRole(role))
+			}
+		}
+		return nil
+	})
+
+	return roles, err
+}
+
+func (crud *MemoryCRUD) GetUserRoleByClientUIDAndUsername(clientUID uuid.UUID, username string) (*models.UserRole, error) {
+	var role *models.UserRole
+	err := crud.StoreAccessor.read(func(s *store) error {
+		if r, ok := s.userRoles[userRoleKey{ClientUID: clientUID, Username: username}]; ok {
+			role = copyUserRole(r)
+		}
+		return nil
+	})
+
+	return role, err
+}
+
+func (crud *MemoryCRUD) UpdateUserRole(role *models.UserRole) (bool, error) {
+	//validate the user-role model
+	verr := role.Validate()
+	if verr != models.ValidateUserRoleValid {
+		return false, errors.New(fmt.Sprint("error validating user-role model:", verr))
+	}
+
+	r := copyUserRole(role)
+	key := userRoleKey{ClientUID: r.ClientUID, Username: r.Username}
+
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		_, found = s.userRoles[key]
+		if found {
+			s.userRoles[key] = r
+		}
+		return nil
+	})
+
+	return found, err
+}
+
+func (crud *MemoryCRUD) CountUserRolesByClientUIDAndRole(clientUID uuid.UUID, role string) (int, error) {
+	count := 0
+	err := crud.StoreAccessor.read(func(s *store) error {
+		for key, r := range s.userRoles {
+			if key.ClientUID == clientUID && r.HasRole(role) {
+				count++
+			}
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (crud *MemoryCRUD) RenameUserRoles(clientUID uuid.UUID, role string, newRole string) error {
+	return crud.StoreAccessor.write(func(s *store) error {
+		for key, r := range s.userRoles {
+			if key.ClientUID == clientUID && r.HasRole(role) {
+				roles := []string{newRole}
+				for _, name := range r.Roles {
+					if name != role {
+						roles = append(roles, name)
+					}
+				}
+				s.userRoles[key] = models.CreateUserRole(r.ClientUID, r.Username, roles...)
+			}
+		}
+		return nil
+	})
+}
+
+func (crud *MemoryCRUD) DeleteUserRole(clientUID uuid.UUID, username string) (bool, error) {
+	key := userRoleKey{ClientUID: clientUID, Username: username}
+
+	found := false
+	err := crud.StoreAccessor.write(func(s *store) error {
+		_, found = s.userRoles[key]
+		delete(s.userRoles, key)
+		return nil
+	})
+
+	return found, err
+}
+
+// copyUserRole copies the user-role so its roles are not shared with the store.
+func copyUserRole(role *models.UserRole) *models.UserRole {
+	return models.CreateUserRole(role.ClientUID, role.Username, role.Roles...)
+}
+
+// hasClientAndUser checks if both the client and user exist.
+func (s *store) hasClientAndUser(clientUID uuid.UUID, username string) bool {
+	_, hasClient := s.clients[clientUID]
+	_, hasUser := s.users[username]
+	return hasClient && hasUser
+}
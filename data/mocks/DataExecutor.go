@@ -9,6 +9,8 @@ import (
 	models "github.com/mhogar/amber/models"
 
 	uuid "github.com/google/uuid"
+
+	time "time"
 )
 
 // DataExecutor is an autogenerated mock type for the DataExecutor type
@@ -16,6 +18,125 @@ type DataExecutor struct {
 	mock.Mock
 }
 
+// CountClients provides a mock function with given fields: search
+func (_m *DataExecutor) CountClients(search string) (int, error) {
+	ret := _m.Called(search)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(search)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountGroupRolesByClientUIDAndRole provides a mock function with given fields: clientUID, role
+func (_m *DataExecutor) CountGroupRolesByClientUIDAndRole(clientUID uuid.UUID, role string) (int, error) {
+	ret := _m.Called(clientUID, role)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) int); ok {
+		r0 = rf(clientUID, role)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
+		r1 = rf(clientUID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountUserRolesByClientUIDAndRole provides a mock function with given fields: clientUID, role
+func (_m *DataExecutor) CountUserRolesByClientUIDAndRole(clientUID uuid.UUID, role string) (int, error) {
+	ret := _m.Called(clientUID, role)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) int); ok {
+		r0 = rf(clientUID, role)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
+		r1 = rf(clientUID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountUserRolesWithLesserRankByClientUID provides a mock function with given fields: uid, rank, search
+func (_m *DataExecutor) CountUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank int, search string) (int, error) {
+	ret := _m.Called(uid, rank, search)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(uuid.UUID, int, string) int); ok {
+		r0 = rf(uid, rank, search)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, int, string) error); ok {
+		r1 = rf(uid, rank, search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountUsersWithLesserRank provides a mock function with given fields: rank, search
+func (_m *DataExecutor) CountUsersWithLesserRank(rank int, search string) (int, error) {
+	ret := _m.Called(rank, search)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int, string) int); ok {
+		r0 = rf(rank, search)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(rank, search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAdminRole provides a mock function with given fields: role
+func (_m *DataExecutor) CreateAdminRole(role *models.AdminRole) error {
+	ret := _m.Called(role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.AdminRole) error); ok {
+		r0 = rf(role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateClient provides a mock function with given fields: client
 func (_m *DataExecutor) CreateClient(client *models.Client) error {
 	ret := _m.Called(client)
@@ -30,6 +151,62 @@ func (_m *DataExecutor) CreateClient(client *models.Client) error {
 	return r0
 }
 
+// CreateClientAdmin provides a mock function with given fields: admin
+func (_m *DataExecutor) CreateClientAdmin(admin *models.ClientAdmin) error {
+	ret := _m.Called(admin)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ClientAdmin) error); ok {
+		r0 = rf(admin)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateGroup provides a mock function with given fields: group
+func (_m *DataExecutor) CreateGroup(group *models.Group) error {
+	ret := _m.Called(group)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Group) error); ok {
+		r0 = rf(group)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateGroupMember provides a mock function with given fields: name, username
+func (_m *DataExecutor) CreateGroupMember(name string, username string) error {
+	ret := _m.Called(name, username)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(name, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateGroupRole provides a mock function with given fields: role
+func (_m *DataExecutor) CreateGroupRole(role *models.GroupRole) error {
+	ret := _m.Called(role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.GroupRole) error); ok {
+		r0 = rf(role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateMigration provides a mock function with given fields: timestamp
 func (_m *DataExecutor) CreateMigration(timestamp string) error {
 	ret := _m.Called(timestamp)
@@ -44,6 +221,20 @@ func (_m *DataExecutor) CreateMigration(timestamp string) error {
 	return r0
 }
 
+// CreateRoleDefinition provides a mock function with given fields: definition
+func (_m *DataExecutor) CreateRoleDefinition(definition *models.RoleDefinition) error {
+	ret := _m.Called(definition)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.RoleDefinition) error); ok {
+		r0 = rf(definition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateTransaction provides a mock function with given fields:
 func (_m *DataExecutor) CreateTransaction() (data.Transaction, error) {
 	ret := _m.Called()
@@ -95,6 +286,27 @@ func (_m *DataExecutor) CreateUserRole(role *models.UserRole) error {
 	return r0
 }
 
+// DeleteAdminRole provides a mock function with given fields: name
+func (_m *DataExecutor) DeleteAdminRole(name string) (bool, error) {
+	ret := _m.Called(name)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteAllOtherUserSessions provides a mock function with given fields: username, tokem
 func (_m *DataExecutor) DeleteAllOtherUserSessions(username string, tokem uuid.UUID) error {
 	ret := _m.Called(username, tokem)
@@ -109,6 +321,34 @@ func (_m *DataExecutor) DeleteAllOtherUserSessions(username string, tokem uuid.U
 	return r0
 }
 
+// DeleteAllUserPasswordResetTokens provides a mock function with given fields: username
+func (_m *DataExecutor) DeleteAllUserPasswordResetTokens(username string) error {
+	ret := _m.Called(username)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAllUserRecoveryCodes provides a mock function with given fields: username
+func (_m *DataExecutor) DeleteAllUserRecoveryCodes(username string) error {
+	ret := _m.Called(username)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteAllUserSessions provides a mock function with given fields: username
 func (_m *DataExecutor) DeleteAllUserSessions(username string) error {
 	ret := _m.Called(username)
@@ -123,6 +363,27 @@ func (_m *DataExecutor) DeleteAllUserSessions(username string) error {
 	return r0
 }
 
+// DeleteAuthorizationCode provides a mock function with given fields: code
+func (_m *DataExecutor) DeleteAuthorizationCode(code uuid.UUID) (bool, error) {
+	ret := _m.Called(code)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID) bool); ok {
+		r0 = rf(code)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteClient provides a mock function with given fields: uid
 func (_m *DataExecutor) DeleteClient(uid uuid.UUID) (bool, error) {
 	ret := _m.Called(uid)
@@ -144,13 +405,34 @@ func (_m *DataExecutor) DeleteClient(uid uuid.UUID) (bool, error) {
 	return r0, r1
 }
 
-// DeleteMigrationByTimestamp provides a mock function with given fields: timestamp
-func (_m *DataExecutor) DeleteMigrationByTimestamp(timestamp string) error {
-	ret := _m.Called(timestamp)
+// DeleteClientAdmin provides a mock function with given fields: clientUID, username
+func (_m *DataExecutor) DeleteClientAdmin(clientUID uuid.UUID, username string) (bool, error) {
+	ret := _m.Called(clientUID, username)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) bool); ok {
+		r0 = rf(clientUID, username)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
+		r1 = rf(clientUID, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteExpiredSessions provides a mock function with given fields: createdBefore, lastUsedBefore
+func (_m *DataExecutor) DeleteExpiredSessions(createdBefore time.Time, lastUsedBefore time.Time) error {
+	ret := _m.Called(createdBefore, lastUsedBefore)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(timestamp)
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) error); ok {
+		r0 = rf(createdBefore, lastUsedBefore)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -158,20 +440,20 @@ func (_m *DataExecutor) DeleteMigrationByTimestamp(timestamp string) error {
 	return r0
 }
 
-// DeleteSession provides a mock function with given fields: token
-func (_m *DataExecutor) DeleteSession(token uuid.UUID) (bool, error) {
-	ret := _m.Called(token)
+// DeleteGroup provides a mock function with given fields: name
+func (_m *DataExecutor) DeleteGroup(name string) (bool, error) {
+	ret := _m.Called(name)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(uuid.UUID) bool); ok {
-		r0 = rf(token)
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(name)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(token)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -179,20 +461,20 @@ func (_m *DataExecutor) DeleteSession(token uuid.UUID) (bool, error) {
 	return r0, r1
 }
 
-// DeleteUser provides a mock function with given fields: username
-func (_m *DataExecutor) DeleteUser(username string) (bool, error) {
-	ret := _m.Called(username)
+// DeleteGroupMember provides a mock function with given fields: name, username
+func (_m *DataExecutor) DeleteGroupMember(name string, username string) (bool, error) {
+	ret := _m.Called(name, username)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(username)
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(name, username)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(username)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(name, username)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -200,20 +482,20 @@ func (_m *DataExecutor) DeleteUser(username string) (bool, error) {
 	return r0, r1
 }
 
-// DeleteUserRole provides a mock function with given fields: clientUID, username
-func (_m *DataExecutor) DeleteUserRole(clientUID uuid.UUID, username string) (bool, error) {
-	ret := _m.Called(clientUID, username)
+// DeleteGroupRole provides a mock function with given fields: clientUID, groupName
+func (_m *DataExecutor) DeleteGroupRole(clientUID uuid.UUID, groupName string) (bool, error) {
+	ret := _m.Called(clientUID, groupName)
 
 	var r0 bool
 	if rf, ok := ret.Get(0).(func(uuid.UUID, string) bool); ok {
-		r0 = rf(clientUID, username)
+		r0 = rf(clientUID, groupName)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
-		r1 = rf(clientUID, username)
+		r1 = rf(clientUID, groupName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -221,22 +503,20 @@ func (_m *DataExecutor) DeleteUserRole(clientUID uuid.UUID, username string) (bo
 	return r0, r1
 }
 
-// GetClientByUID provides a mock function with given fields: uid
-func (_m *DataExecutor) GetClientByUID(uid uuid.UUID) (*models.Client, error) {
-	ret := _m.Called(uid)
+// DeleteInvitation provides a mock function with given fields: id
+func (_m *DataExecutor) DeleteInvitation(id uuid.UUID) (bool, error) {
+	ret := _m.Called(id)
 
-	var r0 *models.Client
-	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.Client); ok {
-		r0 = rf(uid)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID) bool); ok {
+		r0 = rf(id)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Client)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(uid)
+		r1 = rf(id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -244,22 +524,20 @@ func (_m *DataExecutor) GetClientByUID(uid uuid.UUID) (*models.Client, error) {
 	return r0, r1
 }
 
-// GetClients provides a mock function with given fields:
-func (_m *DataExecutor) GetClients() ([]*models.Client, error) {
-	ret := _m.Called()
+// DeleteLoginThrottle provides a mock function with given fields: throttleType, key
+func (_m *DataExecutor) DeleteLoginThrottle(throttleType string, key string) (bool, error) {
+	ret := _m.Called(throttleType, key)
 
-	var r0 []*models.Client
-	if rf, ok := ret.Get(0).(func() []*models.Client); ok {
-		r0 = rf()
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(throttleType, key)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Client)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(throttleType, key)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -267,14 +545,619 @@ func (_m *DataExecutor) GetClients() ([]*models.Client, error) {
 	return r0, r1
 }
 
-// GetLatestTimestamp provides a mock function with given fields:
-func (_m *DataExecutor) GetLatestTimestamp() (string, bool, error) {
-	ret := _m.Called()
+// DeleteMigrationByTimestamp provides a mock function with given fields: timestamp
+func (_m *DataExecutor) DeleteMigrationByTimestamp(timestamp string) error {
+	ret := _m.Called(timestamp)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func() string); ok {
-		r0 = rf()
-	} else {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(timestamp)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePasswordResetToken provides a mock function with given fields: hash
+func (_m *DataExecutor) DeletePasswordResetToken(hash []byte) (bool, error) {
+	ret := _m.Called(hash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func([]byte) bool); ok {
+		r0 = rf(hash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteRecoveryCode provides a mock function with given fields: username, hash
+func (_m *DataExecutor) DeleteRecoveryCode(username string, hash []byte) (bool, error) {
+	ret := _m.Called(username, hash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, []byte) bool); ok {
+		r0 = rf(username, hash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []byte) error); ok {
+		r1 = rf(username, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteRefreshTokenFamily provides a mock function with given fields: familyID
+func (_m *DataExecutor) DeleteRefreshTokenFamily(familyID uuid.UUID) error {
+	ret := _m.Called(familyID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(familyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRoleDefinition provides a mock function with given fields: clientUID, name
+func (_m *DataExecutor) DeleteRoleDefinition(clientUID uuid.UUID, name string) (bool, error) {
+	ret := _m.Called(clientUID, name)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) bool); ok {
+		r0 = rf(clientUID, name)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
+		r1 = rf(clientUID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteSession provides a mock function with given fields: token
+func (_m *DataExecutor) DeleteSession(token uuid.UUID) (bool, error) {
+	ret := _m.Called(token)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID) bool); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUser provides a mock function with given fields: username
+func (_m *DataExecutor) DeleteUser(username string) (bool, error) {
+	ret := _m.Called(username)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(username)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUserPreviousPasswordsReplacedBefore provides a mock function with given fields: username, before
+func (_m *DataExecutor) DeleteUserPreviousPasswordsReplacedBefore(username string, before time.Time) error {
+	ret := _m.Called(username, before)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) error); ok {
+		r0 = rf(username, before)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUserRole provides a mock function with given fields: clientUID, username
+func (_m *DataExecutor) DeleteUserRole(clientUID uuid.UUID, username string) (bool, error) {
+	ret := _m.Called(clientUID, username)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) bool); ok {
+		r0 = rf(clientUID, username)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
+		r1 = rf(clientUID, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAdminRoleByName provides a mock function with given fields: name
+func (_m *DataExecutor) GetAdminRoleByName(name string) (*models.AdminRole, error) {
+	ret := _m.Called(name)
+
+	var r0 *models.AdminRole
+	if rf, ok := ret.Get(0).(func(string) *models.AdminRole); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AdminRole)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAdminRoleUsernames provides a mock function with given fields: name
+func (_m *DataExecutor) GetAdminRoleUsernames(name string) ([]string, error) {
+	ret := _m.Called(name)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAdminRoles provides a mock function with given fields:
+func (_m *DataExecutor) GetAdminRoles() ([]*models.AdminRole, error) {
+	ret := _m.Called()
+
+	var r0 []*models.AdminRole
+	if rf, ok := ret.Get(0).(func() []*models.AdminRole); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AdminRole)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAdminRolesByUsername provides a mock function with given fields: username
+func (_m *DataExecutor) GetAdminRolesByUsername(username string) ([]*models.AdminRole, error) {
+	ret := _m.Called(username)
+
+	var r0 []*models.AdminRole
+	if rf, ok := ret.Get(0).(func(string) []*models.AdminRole); ok {
+		r0 = rf(username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AdminRole)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAuditEvents provides a mock function with given fields: filter
+func (_m *DataExecutor) GetAuditEvents(filter models.AuditEventFilter) ([]*models.AuditEvent, error) {
+	ret := _m.Called(filter)
+
+	var r0 []*models.AuditEvent
+	if rf, ok := ret.Get(0).(func(models.AuditEventFilter) []*models.AuditEvent); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AuditEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(models.AuditEventFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAuthorizationCode provides a mock function with given fields: code
+func (_m *DataExecutor) GetAuthorizationCode(code uuid.UUID) (*models.AuthorizationCode, error) {
+	ret := _m.Called(code)
+
+	var r0 *models.AuthorizationCode
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.AuthorizationCode); ok {
+		r0 = rf(code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthorizationCode)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClientAdminByClientUIDAndUsername provides a mock function with given fields: clientUID, username
+func (_m *DataExecutor) GetClientAdminByClientUIDAndUsername(clientUID uuid.UUID, username string) (*models.ClientAdmin, error) {
+	ret := _m.Called(clientUID, username)
+
+	var r0 *models.ClientAdmin
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) *models.ClientAdmin); ok {
+		r0 = rf(clientUID, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ClientAdmin)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
+		r1 = rf(clientUID, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClientAdminsByClientUID provides a mock function with given fields: clientUID
+func (_m *DataExecutor) GetClientAdminsByClientUID(clientUID uuid.UUID) ([]*models.ClientAdmin, error) {
+	ret := _m.Called(clientUID)
+
+	var r0 []*models.ClientAdmin
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []*models.ClientAdmin); ok {
+		r0 = rf(clientUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ClientAdmin)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(clientUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClientAdminsByUsername provides a mock function with given fields: username
+func (_m *DataExecutor) GetClientAdminsByUsername(username string) ([]*models.ClientAdmin, error) {
+	ret := _m.Called(username)
+
+	var r0 []*models.ClientAdmin
+	if rf, ok := ret.Get(0).(func(string) []*models.ClientAdmin); ok {
+		r0 = rf(username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ClientAdmin)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClientByUID provides a mock function with given fields: uid
+func (_m *DataExecutor) GetClientByUID(uid uuid.UUID) (*models.Client, error) {
+	ret := _m.Called(uid)
+
+	var r0 *models.Client
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.Client); ok {
+		r0 = rf(uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Client)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClients provides a mock function with given fields: query
+func (_m *DataExecutor) GetClients(query models.PageQuery) ([]*models.Client, error) {
+	ret := _m.Called(query)
+
+	var r0 []*models.Client
+	if rf, ok := ret.Get(0).(func(models.PageQuery) []*models.Client); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Client)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(models.PageQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupByName provides a mock function with given fields: name
+func (_m *DataExecutor) GetGroupByName(name string) (*models.Group, error) {
+	ret := _m.Called(name)
+
+	var r0 *models.Group
+	if rf, ok := ret.Get(0).(func(string) *models.Group); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Group)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupMemberUsernames provides a mock function with given fields: name
+func (_m *DataExecutor) GetGroupMemberUsernames(name string) ([]string, error) {
+	ret := _m.Called(name)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupRoleByClientUIDAndGroupName provides a mock function with given fields: clientUID, groupName
+func (_m *DataExecutor) GetGroupRoleByClientUIDAndGroupName(clientUID uuid.UUID, groupName string) (*models.GroupRole, error) {
+	ret := _m.Called(clientUID, groupName)
+
+	var r0 *models.GroupRole
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) *models.GroupRole); ok {
+		r0 = rf(clientUID, groupName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.GroupRole)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
+		r1 = rf(clientUID, groupName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupRolesByClientUID provides a mock function with given fields: clientUID
+func (_m *DataExecutor) GetGroupRolesByClientUID(clientUID uuid.UUID) ([]*models.GroupRole, error) {
+	ret := _m.Called(clientUID)
+
+	var r0 []*models.GroupRole
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []*models.GroupRole); ok {
+		r0 = rf(clientUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.GroupRole)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(clientUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroups provides a mock function with given fields:
+func (_m *DataExecutor) GetGroups() ([]*models.Group, error) {
+	ret := _m.Called()
+
+	var r0 []*models.Group
+	if rf, ok := ret.Get(0).(func() []*models.Group); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Group)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupsByUsername provides a mock function with given fields: username
+func (_m *DataExecutor) GetGroupsByUsername(username string) ([]*models.Group, error) {
+	ret := _m.Called(username)
+
+	var r0 []*models.Group
+	if rf, ok := ret.Get(0).(func(string) []*models.Group); ok {
+		r0 = rf(username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Group)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvitationByID provides a mock function with given fields: id
+func (_m *DataExecutor) GetInvitationByID(id uuid.UUID) (*models.Invitation, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.Invitation
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.Invitation); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Invitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvitationByTokenHash provides a mock function with given fields: hash
+func (_m *DataExecutor) GetInvitationByTokenHash(hash []byte) (*models.Invitation, error) {
+	ret := _m.Called(hash)
+
+	var r0 *models.Invitation
+	if rf, ok := ret.Get(0).(func([]byte) *models.Invitation); ok {
+		r0 = rf(hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Invitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestTimestamp provides a mock function with given fields:
+func (_m *DataExecutor) GetLatestTimestamp() (string, bool, error) {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
 		r0 = ret.Get(0).(string)
 	}
 
@@ -295,6 +1178,75 @@ func (_m *DataExecutor) GetLatestTimestamp() (string, bool, error) {
 	return r0, r1, r2
 }
 
+// GetLatestUserPreviousPasswords provides a mock function with given fields: username, limit
+func (_m *DataExecutor) GetLatestUserPreviousPasswords(username string, limit int) ([]*models.PreviousPassword, error) {
+	ret := _m.Called(username, limit)
+
+	var r0 []*models.PreviousPassword
+	if rf, ok := ret.Get(0).(func(string, int) []*models.PreviousPassword); ok {
+		r0 = rf(username, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PreviousPassword)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(username, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLockedLoginThrottles provides a mock function with given fields: now
+func (_m *DataExecutor) GetLockedLoginThrottles(now time.Time) ([]*models.LoginThrottle, error) {
+	ret := _m.Called(now)
+
+	var r0 []*models.LoginThrottle
+	if rf, ok := ret.Get(0).(func(time.Time) []*models.LoginThrottle); ok {
+		r0 = rf(now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.LoginThrottle)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoginThrottle provides a mock function with given fields: throttleType, key
+func (_m *DataExecutor) GetLoginThrottle(throttleType string, key string) (*models.LoginThrottle, error) {
+	ret := _m.Called(throttleType, key)
+
+	var r0 *models.LoginThrottle
+	if rf, ok := ret.Get(0).(func(string, string) *models.LoginThrottle); ok {
+		r0 = rf(throttleType, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LoginThrottle)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(throttleType, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMigrationByTimestamp provides a mock function with given fields: timestamp
 func (_m *DataExecutor) GetMigrationByTimestamp(timestamp string) (*models.Migration, error) {
 	ret := _m.Called(timestamp)
@@ -318,6 +1270,98 @@ func (_m *DataExecutor) GetMigrationByTimestamp(timestamp string) (*models.Migra
 	return r0, r1
 }
 
+// GetPasswordResetToken provides a mock function with given fields: hash
+func (_m *DataExecutor) GetPasswordResetToken(hash []byte) (*models.PasswordResetToken, error) {
+	ret := _m.Called(hash)
+
+	var r0 *models.PasswordResetToken
+	if rf, ok := ret.Get(0).(func([]byte) *models.PasswordResetToken); ok {
+		r0 = rf(hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PasswordResetToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRefreshToken provides a mock function with given fields: token
+func (_m *DataExecutor) GetRefreshToken(token uuid.UUID) (*models.RefreshToken, error) {
+	ret := _m.Called(token)
+
+	var r0 *models.RefreshToken
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.RefreshToken); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RefreshToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoleDefinitionByClientUIDAndName provides a mock function with given fields: clientUID, name
+func (_m *DataExecutor) GetRoleDefinitionByClientUIDAndName(clientUID uuid.UUID, name string) (*models.RoleDefinition, error) {
+	ret := _m.Called(clientUID, name)
+
+	var r0 *models.RoleDefinition
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) *models.RoleDefinition); ok {
+		r0 = rf(clientUID, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RoleDefinition)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
+		r1 = rf(clientUID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoleDefinitionsByClientUID provides a mock function with given fields: clientUID
+func (_m *DataExecutor) GetRoleDefinitionsByClientUID(clientUID uuid.UUID) ([]*models.RoleDefinition, error) {
+	ret := _m.Called(clientUID)
+
+	var r0 []*models.RoleDefinition
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []*models.RoleDefinition); ok {
+		r0 = rf(clientUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.RoleDefinition)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(clientUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSessionByToken provides a mock function with given fields: token
 func (_m *DataExecutor) GetSessionByToken(token uuid.UUID) (*models.Session, error) {
 	ret := _m.Called(token)
@@ -341,6 +1385,29 @@ func (_m *DataExecutor) GetSessionByToken(token uuid.UUID) (*models.Session, err
 	return r0, r1
 }
 
+// GetUserByEmail provides a mock function with given fields: email
+func (_m *DataExecutor) GetUserByEmail(email string) (*models.User, error) {
+	ret := _m.Called(email)
+
+	var r0 *models.User
+	if rf, ok := ret.Get(0).(func(string) *models.User); ok {
+		r0 = rf(email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserByUsername provides a mock function with given fields: username
 func (_m *DataExecutor) GetUserByUsername(username string) (*models.User, error) {
 	ret := _m.Called(username)
@@ -387,13 +1454,13 @@ func (_m *DataExecutor) GetUserRoleByClientUIDAndUsername(clientUID uuid.UUID, u
 	return r0, r1
 }
 
-// GetUserRolesWithLesserRankByClientUID provides a mock function with given fields: uid, rank
-func (_m *DataExecutor) GetUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank int) ([]*models.UserRole, error) {
-	ret := _m.Called(uid, rank)
+// GetUserRolesWithLesserRankByClientUID provides a mock function with given fields: uid, rank, query
+func (_m *DataExecutor) GetUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank int, query models.PageQuery) ([]*models.UserRole, error) {
+	ret := _m.Called(uid, rank, query)
 
 	var r0 []*models.UserRole
-	if rf, ok := ret.Get(0).(func(uuid.UUID, int) []*models.UserRole); ok {
-		r0 = rf(uid, rank)
+	if rf, ok := ret.Get(0).(func(uuid.UUID, int, models.PageQuery) []*models.UserRole); ok {
+		r0 = rf(uid, rank, query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.UserRole)
@@ -401,8 +1468,8 @@ func (_m *DataExecutor) GetUserRolesWithLesserRankByClientUID(uid uuid.UUID, ran
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uuid.UUID, int) error); ok {
-		r1 = rf(uid, rank)
+	if rf, ok := ret.Get(1).(func(uuid.UUID, int, models.PageQuery) error); ok {
+		r1 = rf(uid, rank, query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -410,13 +1477,13 @@ func (_m *DataExecutor) GetUserRolesWithLesserRankByClientUID(uid uuid.UUID, ran
 	return r0, r1
 }
 
-// GetUsersWithLesserRank provides a mock function with given fields: rank
-func (_m *DataExecutor) GetUsersWithLesserRank(rank int) ([]*models.User, error) {
-	ret := _m.Called(rank)
+// GetUsersWithLesserRank provides a mock function with given fields: rank, query
+func (_m *DataExecutor) GetUsersWithLesserRank(rank int, query models.PageQuery) ([]*models.User, error) {
+	ret := _m.Called(rank, query)
 
 	var r0 []*models.User
-	if rf, ok := ret.Get(0).(func(int) []*models.User); ok {
-		r0 = rf(rank)
+	if rf, ok := ret.Get(0).(func(int, models.PageQuery) []*models.User); ok {
+		r0 = rf(rank, query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.User)
@@ -424,8 +1491,71 @@ func (_m *DataExecutor) GetUsersWithLesserRank(rank int) ([]*models.User, error)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(rank)
+	if rf, ok := ret.Get(1).(func(int, models.PageQuery) error); ok {
+		r1 = rf(rank, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RenameGroupRoles provides a mock function with given fields: clientUID, role, newRole
+func (_m *DataExecutor) RenameGroupRoles(clientUID uuid.UUID, role string, newRole string) error {
+	ret := _m.Called(clientUID, role, newRole)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, string) error); ok {
+		r0 = rf(clientUID, role, newRole)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RenameInvitationRoles provides a mock function with given fields: clientUID, role, newRole
+func (_m *DataExecutor) RenameInvitationRoles(clientUID uuid.UUID, role string, newRole string) error {
+	ret := _m.Called(clientUID, role, newRole)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, string) error); ok {
+		r0 = rf(clientUID, role, newRole)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RenameUserRoles provides a mock function with given fields: clientUID, role, newRole
+func (_m *DataExecutor) RenameUserRoles(clientUID uuid.UUID, role string, newRole string) error {
+	ret := _m.Called(clientUID, role, newRole)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, string) error); ok {
+		r0 = rf(clientUID, role, newRole)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RotateRefreshToken provides a mock function with given fields: token
+func (_m *DataExecutor) RotateRefreshToken(token uuid.UUID) (bool, error) {
+	ret := _m.Called(token)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID) bool); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(token)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -433,6 +1563,118 @@ func (_m *DataExecutor) GetUsersWithLesserRank(rank int) ([]*models.User, error)
 	return r0, r1
 }
 
+// SaveAuditEvent provides a mock function with given fields: event
+func (_m *DataExecutor) SaveAuditEvent(event *models.AuditEvent) error {
+	ret := _m.Called(event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.AuditEvent) error); ok {
+		r0 = rf(event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveAuthorizationCode provides a mock function with given fields: code
+func (_m *DataExecutor) SaveAuthorizationCode(code *models.AuthorizationCode) error {
+	ret := _m.Called(code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.AuthorizationCode) error); ok {
+		r0 = rf(code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveInvitation provides a mock function with given fields: invitation
+func (_m *DataExecutor) SaveInvitation(invitation *models.Invitation) error {
+	ret := _m.Called(invitation)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Invitation) error); ok {
+		r0 = rf(invitation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveLoginThrottle provides a mock function with given fields: throttle
+func (_m *DataExecutor) SaveLoginThrottle(throttle *models.LoginThrottle) error {
+	ret := _m.Called(throttle)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.LoginThrottle) error); ok {
+		r0 = rf(throttle)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SavePasswordResetToken provides a mock function with given fields: token
+func (_m *DataExecutor) SavePasswordResetToken(token *models.PasswordResetToken) error {
+	ret := _m.Called(token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.PasswordResetToken) error); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SavePreviousPassword provides a mock function with given fields: password
+func (_m *DataExecutor) SavePreviousPassword(password *models.PreviousPassword) error {
+	ret := _m.Called(password)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.PreviousPassword) error); ok {
+		r0 = rf(password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveRecoveryCode provides a mock function with given fields: code
+func (_m *DataExecutor) SaveRecoveryCode(code *models.RecoveryCode) error {
+	ret := _m.Called(code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.RecoveryCode) error); ok {
+		r0 = rf(code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveRefreshToken provides a mock function with given fields: token
+func (_m *DataExecutor) SaveRefreshToken(token *models.RefreshToken) error {
+	ret := _m.Called(token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.RefreshToken) error); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveSession provides a mock function with given fields: session
 func (_m *DataExecutor) SaveSession(session *models.Session) error {
 	ret := _m.Called(session)
@@ -447,6 +1689,20 @@ func (_m *DataExecutor) SaveSession(session *models.Session) error {
 	return r0
 }
 
+// SetUserAdminRoles provides a mock function with given fields: username, names
+func (_m *DataExecutor) SetUserAdminRoles(username string, names []string) error {
+	ret := _m.Called(username, names)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(username, names)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Setup provides a mock function with given fields:
 func (_m *DataExecutor) Setup() error {
 	ret := _m.Called()
@@ -461,6 +1717,27 @@ func (_m *DataExecutor) Setup() error {
 	return r0
 }
 
+// UpdateAdminRole provides a mock function with given fields: role
+func (_m *DataExecutor) UpdateAdminRole(role *models.AdminRole) (bool, error) {
+	ret := _m.Called(role)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*models.AdminRole) bool); ok {
+		r0 = rf(role)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.AdminRole) error); ok {
+		r1 = rf(role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateClient provides a mock function with given fields: client
 func (_m *DataExecutor) UpdateClient(client *models.Client) (bool, error) {
 	ret := _m.Called(client)
@@ -482,6 +1759,111 @@ func (_m *DataExecutor) UpdateClient(client *models.Client) (bool, error) {
 	return r0, r1
 }
 
+// UpdateClientSecretHash provides a mock function with given fields: uid, secretHash
+func (_m *DataExecutor) UpdateClientSecretHash(uid uuid.UUID, secretHash []byte) (bool, error) {
+	ret := _m.Called(uid, secretHash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID, []byte) bool); ok {
+		r0 = rf(uid, secretHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, []byte) error); ok {
+		r1 = rf(uid, secretHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateGroup provides a mock function with given fields: group
+func (_m *DataExecutor) UpdateGroup(group *models.Group) (bool, error) {
+	ret := _m.Called(group)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*models.Group) bool); ok {
+		r0 = rf(group)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.Group) error); ok {
+		r1 = rf(group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateGroupRole provides a mock function with given fields: role
+func (_m *DataExecutor) UpdateGroupRole(role *models.GroupRole) (bool, error) {
+	ret := _m.Called(role)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*models.GroupRole) bool); ok {
+		r0 = rf(role)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.GroupRole) error); ok {
+		r1 = rf(role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRoleDefinition provides a mock function with given fields: name, definition
+func (_m *DataExecutor) UpdateRoleDefinition(name string, definition *models.RoleDefinition) (bool, error) {
+	ret := _m.Called(name, definition)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, *models.RoleDefinition) bool); ok {
+		r0 = rf(name, definition)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *models.RoleDefinition) error); ok {
+		r1 = rf(name, definition)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateSessionLastUsedAt provides a mock function with given fields: token, lastUsedAt
+func (_m *DataExecutor) UpdateSessionLastUsedAt(token uuid.UUID, lastUsedAt time.Time) (bool, error) {
+	ret := _m.Called(token, lastUsedAt)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time) bool); ok {
+		r0 = rf(token, lastUsedAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, time.Time) error); ok {
+		r1 = rf(token, lastUsedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateUser provides a mock function with given fields: user
 func (_m *DataExecutor) UpdateUser(user *models.User) (bool, error) {
 	ret := _m.Called(user)
@@ -503,20 +1885,20 @@ func (_m *DataExecutor) UpdateUser(user *models.User) (bool, error) {
 	return r0, r1
 }
 
-// UpdateUserPassword provides a mock function with given fields: username, hash
-func (_m *DataExecutor) UpdateUserPassword(username string, hash []byte) (bool, error) {
-	ret := _m.Called(username, hash)
+// UpdateUserPassword provides a mock function with given fields: username, hash, updatedAt
+func (_m *DataExecutor) UpdateUserPassword(username string, hash []byte, updatedAt time.Time) (bool, error) {
+	ret := _m.Called(username, hash, updatedAt)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(string, []byte) bool); ok {
-		r0 = rf(username, hash)
+	if rf, ok := ret.Get(0).(func(string, []byte, time.Time) bool); ok {
+		r0 = rf(username, hash, updatedAt)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, []byte) error); ok {
-		r1 = rf(username, hash)
+	if rf, ok := ret.Get(1).(func(string, []byte, time.Time) error); ok {
+		r1 = rf(username, hash, updatedAt)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -544,3 +1926,45 @@ func (_m *DataExecutor) UpdateUserRole(role *models.UserRole) (bool, error) {
 
 	return r0, r1
 }
+
+// UpdateUserTOTP provides a mock function with given fields: username, secret, enabled
+func (_m *DataExecutor) UpdateUserTOTP(username string, secret []byte, enabled bool) (bool, error) {
+	ret := _m.Called(username, secret, enabled)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, []byte, bool) bool); ok {
+		r0 = rf(username, secret, enabled)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []byte, bool) error); ok {
+		r1 = rf(username, secret, enabled)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUserTOTPLastTimeStep provides a mock function with given fields: username, step
+func (_m *DataExecutor) UpdateUserTOTPLastTimeStep(username string, step int64) (bool, error) {
+	ret := _m.Called(username, step)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, int64) bool); ok {
+		r0 = rf(username, step)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(username, step)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -7,6 +7,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	uuid "github.com/google/uuid"
+
+	time "time"
 )
 
 // Transaction is an autogenerated mock type for the Transaction type
@@ -28,6 +30,125 @@ func (_m *Transaction) Commit() error {
 	return r0
 }
 
+// CountClients provides a mock function with given fields: search
+func (_m *Transaction) CountClients(search string) (int, error) {
+	ret := _m.Called(search)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(search)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountGroupRolesByClientUIDAndRole provides a mock function with given fields: clientUID, role
+func (_m *Transaction) CountGroupRolesByClientUIDAndRole(clientUID uuid.UUID, role string) (int, error) {
+	ret := _m.Called(clientUID, role)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) int); ok {
+		r0 = rf(clientUID, role)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
+		r1 = rf(clientUID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountUserRolesByClientUIDAndRole provides a mock function with given fields: clientUID, role
+func (_m *Transaction) CountUserRolesByClientUIDAndRole(clientUID uuid.UUID, role string) (int, error) {
+	ret := _m.Called(clientUID, role)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) int); ok {
+		r0 = rf(clientUID, role)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
+		r1 = rf(clientUID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountUserRolesWithLesserRankByClientUID provides a mock function with given fields: uid, rank, search
+func (_m *Transaction) CountUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank int, search string) (int, error) {
+	ret := _m.Called(uid, rank, search)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(uuid.UUID, int, string) int); ok {
+		r0 = rf(uid, rank, search)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, int, string) error); ok {
+		r1 = rf(uid, rank, search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountUsersWithLesserRank provides a mock function with given fields: rank, search
+func (_m *Transaction) CountUsersWithLesserRank(rank int, search string) (int, error) {
+	ret := _m.Called(rank, search)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int, string) int); ok {
+		r0 = rf(rank, search)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(rank, search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAdminRole provides a mock function with given fields: role
+func (_m *Transaction) CreateAdminRole(role *models.AdminRole) error {
+	ret := _m.Called(role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.AdminRole) error); ok {
+		r0 = rf(role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateClient provides a mock function with given fields: client
 func (_m *Transaction) CreateClient(client *models.Client) error {
 	ret := _m.Called(client)
@@ -42,6 +163,62 @@ func (_m *Transaction) CreateClient(client *models.Client) error {
 	return r0
 }
 
+// CreateClientAdmin provides a mock function with given fields: admin
+func (_m *Transaction) CreateClientAdmin(admin *models.ClientAdmin) error {
+	ret := _m.Called(admin)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ClientAdmin) error); ok {
+		r0 = rf(admin)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateGroup provides a mock function with given fields: group
+func (_m *Transaction) CreateGroup(group *models.Group) error {
+	ret := _m.Called(group)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Group) error); ok {
+		r0 = rf(group)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateGroupMember provides a mock function with given fields: name, username
+func (_m *Transaction) CreateGroupMember(name string, username string) error {
+	ret := _m.Called(name, username)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(name, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateGroupRole provides a mock function with given fields: role
+func (_m *Transaction) CreateGroupRole(role *models.GroupRole) error {
+	ret := _m.Called(role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.GroupRole) error); ok {
+		r0 = rf(role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateMigration provides a mock function with given fields: timestamp
 func (_m *Transaction) CreateMigration(timestamp string) error {
 	ret := _m.Called(timestamp)
@@ -56,6 +233,20 @@ func (_m *Transaction) CreateMigration(timestamp string) error {
 	return r0
 }
 
+// CreateRoleDefinition provides a mock function with given fields: definition
+func (_m *Transaction) CreateRoleDefinition(definition *models.RoleDefinition) error {
+	ret := _m.Called(definition)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.RoleDefinition) error); ok {
+		r0 = rf(definition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateUser provides a mock function with given fields: user
 func (_m *Transaction) CreateUser(user *models.User) error {
 	ret := _m.Called(user)
@@ -84,6 +275,27 @@ func (_m *Transaction) CreateUserRole(role *models.UserRole) error {
 	return r0
 }
 
+// DeleteAdminRole provides a mock function with given fields: name
+func (_m *Transaction) DeleteAdminRole(name string) (bool, error) {
+	ret := _m.Called(name)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteAllOtherUserSessions provides a mock function with given fields: username, tokem
 func (_m *Transaction) DeleteAllOtherUserSessions(username string, tokem uuid.UUID) error {
 	ret := _m.Called(username, tokem)
@@ -98,6 +310,34 @@ func (_m *Transaction) DeleteAllOtherUserSessions(username string, tokem uuid.UU
 	return r0
 }
 
+// DeleteAllUserPasswordResetTokens provides a mock function with given fields: username
+func (_m *Transaction) DeleteAllUserPasswordResetTokens(username string) error {
+	ret := _m.Called(username)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAllUserRecoveryCodes provides a mock function with given fields: username
+func (_m *Transaction) DeleteAllUserRecoveryCodes(username string) error {
+	ret := _m.Called(username)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteAllUserSessions provides a mock function with given fields: username
 func (_m *Transaction) DeleteAllUserSessions(username string) error {
 	ret := _m.Called(username)
@@ -112,6 +352,27 @@ func (_m *Transaction) DeleteAllUserSessions(username string) error {
 	return r0
 }
 
+// DeleteAuthorizationCode provides a mock function with given fields: code
+func (_m *Transaction) DeleteAuthorizationCode(code uuid.UUID) (bool, error) {
+	ret := _m.Called(code)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID) bool); ok {
+		r0 = rf(code)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteClient provides a mock function with given fields: uid
 func (_m *Transaction) DeleteClient(uid uuid.UUID) (bool, error) {
 	ret := _m.Called(uid)
@@ -133,13 +394,34 @@ func (_m *Transaction) DeleteClient(uid uuid.UUID) (bool, error) {
 	return r0, r1
 }
 
-// DeleteMigrationByTimestamp provides a mock function with given fields: timestamp
-func (_m *Transaction) DeleteMigrationByTimestamp(timestamp string) error {
-	ret := _m.Called(timestamp)
+// DeleteClientAdmin provides a mock function with given fields: clientUID, username
+func (_m *Transaction) DeleteClientAdmin(clientUID uuid.UUID, username string) (bool, error) {
+	ret := _m.Called(clientUID, username)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) bool); ok {
+		r0 = rf(clientUID, username)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
+		r1 = rf(clientUID, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteExpiredSessions provides a mock function with given fields: createdBefore, lastUsedBefore
+func (_m *Transaction) DeleteExpiredSessions(createdBefore time.Time, lastUsedBefore time.Time) error {
+	ret := _m.Called(createdBefore, lastUsedBefore)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(timestamp)
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) error); ok {
+		r0 = rf(createdBefore, lastUsedBefore)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -147,20 +429,20 @@ func (_m *Transaction) DeleteMigrationByTimestamp(timestamp string) error {
 	return r0
 }
 
-// DeleteSession provides a mock function with given fields: token
-func (_m *Transaction) DeleteSession(token uuid.UUID) (bool, error) {
-	ret := _m.Called(token)
+// DeleteGroup provides a mock function with given fields: name
+func (_m *Transaction) DeleteGroup(name string) (bool, error) {
+	ret := _m.Called(name)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(uuid.UUID) bool); ok {
-		r0 = rf(token)
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(name)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(token)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -168,20 +450,20 @@ func (_m *Transaction) DeleteSession(token uuid.UUID) (bool, error) {
 	return r0, r1
 }
 
-// DeleteUser provides a mock function with given fields: username
-func (_m *Transaction) DeleteUser(username string) (bool, error) {
-	ret := _m.Called(username)
+// DeleteGroupMember provides a mock function with given fields: name, username
+func (_m *Transaction) DeleteGroupMember(name string, username string) (bool, error) {
+	ret := _m.Called(name, username)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(username)
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(name, username)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(username)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(name, username)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -189,20 +471,20 @@ func (_m *Transaction) DeleteUser(username string) (bool, error) {
 	return r0, r1
 }
 
-// DeleteUserRole provides a mock function with given fields: clientUID, username
-func (_m *Transaction) DeleteUserRole(clientUID uuid.UUID, username string) (bool, error) {
-	ret := _m.Called(clientUID, username)
+// DeleteGroupRole provides a mock function with given fields: clientUID, groupName
+func (_m *Transaction) DeleteGroupRole(clientUID uuid.UUID, groupName string) (bool, error) {
+	ret := _m.Called(clientUID, groupName)
 
 	var r0 bool
 	if rf, ok := ret.Get(0).(func(uuid.UUID, string) bool); ok {
-		r0 = rf(clientUID, username)
+		r0 = rf(clientUID, groupName)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
-		r1 = rf(clientUID, username)
+		r1 = rf(clientUID, groupName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -210,22 +492,20 @@ func (_m *Transaction) DeleteUserRole(clientUID uuid.UUID, username string) (boo
 	return r0, r1
 }
 
-// GetClientByUID provides a mock function with given fields: uid
-func (_m *Transaction) GetClientByUID(uid uuid.UUID) (*models.Client, error) {
-	ret := _m.Called(uid)
+// DeleteInvitation provides a mock function with given fields: id
+func (_m *Transaction) DeleteInvitation(id uuid.UUID) (bool, error) {
+	ret := _m.Called(id)
 
-	var r0 *models.Client
-	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.Client); ok {
-		r0 = rf(uid)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID) bool); ok {
+		r0 = rf(id)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Client)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(uid)
+		r1 = rf(id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -233,22 +513,20 @@ func (_m *Transaction) GetClientByUID(uid uuid.UUID) (*models.Client, error) {
 	return r0, r1
 }
 
-// GetClients provides a mock function with given fields:
-func (_m *Transaction) GetClients() ([]*models.Client, error) {
-	ret := _m.Called()
+// DeleteLoginThrottle provides a mock function with given fields: throttleType, key
+func (_m *Transaction) DeleteLoginThrottle(throttleType string, key string) (bool, error) {
+	ret := _m.Called(throttleType, key)
 
-	var r0 []*models.Client
-	if rf, ok := ret.Get(0).(func() []*models.Client); ok {
-		r0 = rf()
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(throttleType, key)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Client)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(throttleType, key)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -256,14 +534,619 @@ func (_m *Transaction) GetClients() ([]*models.Client, error) {
 	return r0, r1
 }
 
-// GetLatestTimestamp provides a mock function with given fields:
-func (_m *Transaction) GetLatestTimestamp() (string, bool, error) {
-	ret := _m.Called()
+// DeleteMigrationByTimestamp provides a mock function with given fields: timestamp
+func (_m *Transaction) DeleteMigrationByTimestamp(timestamp string) error {
+	ret := _m.Called(timestamp)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func() string); ok {
-		r0 = rf()
-	} else {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(timestamp)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePasswordResetToken provides a mock function with given fields: hash
+func (_m *Transaction) DeletePasswordResetToken(hash []byte) (bool, error) {
+	ret := _m.Called(hash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func([]byte) bool); ok {
+		r0 = rf(hash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteRecoveryCode provides a mock function with given fields: username, hash
+func (_m *Transaction) DeleteRecoveryCode(username string, hash []byte) (bool, error) {
+	ret := _m.Called(username, hash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, []byte) bool); ok {
+		r0 = rf(username, hash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []byte) error); ok {
+		r1 = rf(username, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteRefreshTokenFamily provides a mock function with given fields: familyID
+func (_m *Transaction) DeleteRefreshTokenFamily(familyID uuid.UUID) error {
+	ret := _m.Called(familyID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(familyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRoleDefinition provides a mock function with given fields: clientUID, name
+func (_m *Transaction) DeleteRoleDefinition(clientUID uuid.UUID, name string) (bool, error) {
+	ret := _m.Called(clientUID, name)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) bool); ok {
+		r0 = rf(clientUID, name)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
+		r1 = rf(clientUID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteSession provides a mock function with given fields: token
+func (_m *Transaction) DeleteSession(token uuid.UUID) (bool, error) {
+	ret := _m.Called(token)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID) bool); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUser provides a mock function with given fields: username
+func (_m *Transaction) DeleteUser(username string) (bool, error) {
+	ret := _m.Called(username)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(username)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUserPreviousPasswordsReplacedBefore provides a mock function with given fields: username, before
+func (_m *Transaction) DeleteUserPreviousPasswordsReplacedBefore(username string, before time.Time) error {
+	ret := _m.Called(username, before)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) error); ok {
+		r0 = rf(username, before)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUserRole provides a mock function with given fields: clientUID, username
+func (_m *Transaction) DeleteUserRole(clientUID uuid.UUID, username string) (bool, error) {
+	ret := _m.Called(clientUID, username)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) bool); ok {
+		r0 = rf(clientUID, username)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
+		r1 = rf(clientUID, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAdminRoleByName provides a mock function with given fields: name
+func (_m *Transaction) GetAdminRoleByName(name string) (*models.AdminRole, error) {
+	ret := _m.Called(name)
+
+	var r0 *models.AdminRole
+	if rf, ok := ret.Get(0).(func(string) *models.AdminRole); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AdminRole)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAdminRoleUsernames provides a mock function with given fields: name
+func (_m *Transaction) GetAdminRoleUsernames(name string) ([]string, error) {
+	ret := _m.Called(name)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAdminRoles provides a mock function with given fields:
+func (_m *Transaction) GetAdminRoles() ([]*models.AdminRole, error) {
+	ret := _m.Called()
+
+	var r0 []*models.AdminRole
+	if rf, ok := ret.Get(0).(func() []*models.AdminRole); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AdminRole)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAdminRolesByUsername provides a mock function with given fields: username
+func (_m *Transaction) GetAdminRolesByUsername(username string) ([]*models.AdminRole, error) {
+	ret := _m.Called(username)
+
+	var r0 []*models.AdminRole
+	if rf, ok := ret.Get(0).(func(string) []*models.AdminRole); ok {
+		r0 = rf(username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AdminRole)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAuditEvents provides a mock function with given fields: filter
+func (_m *Transaction) GetAuditEvents(filter models.AuditEventFilter) ([]*models.AuditEvent, error) {
+	ret := _m.Called(filter)
+
+	var r0 []*models.AuditEvent
+	if rf, ok := ret.Get(0).(func(models.AuditEventFilter) []*models.AuditEvent); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AuditEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(models.AuditEventFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAuthorizationCode provides a mock function with given fields: code
+func (_m *Transaction) GetAuthorizationCode(code uuid.UUID) (*models.AuthorizationCode, error) {
+	ret := _m.Called(code)
+
+	var r0 *models.AuthorizationCode
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.AuthorizationCode); ok {
+		r0 = rf(code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthorizationCode)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClientAdminByClientUIDAndUsername provides a mock function with given fields: clientUID, username
+func (_m *Transaction) GetClientAdminByClientUIDAndUsername(clientUID uuid.UUID, username string) (*models.ClientAdmin, error) {
+	ret := _m.Called(clientUID, username)
+
+	var r0 *models.ClientAdmin
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) *models.ClientAdmin); ok {
+		r0 = rf(clientUID, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ClientAdmin)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
+		r1 = rf(clientUID, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClientAdminsByClientUID provides a mock function with given fields: clientUID
+func (_m *Transaction) GetClientAdminsByClientUID(clientUID uuid.UUID) ([]*models.ClientAdmin, error) {
+	ret := _m.Called(clientUID)
+
+	var r0 []*models.ClientAdmin
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []*models.ClientAdmin); ok {
+		r0 = rf(clientUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ClientAdmin)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(clientUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClientAdminsByUsername provides a mock function with given fields: username
+func (_m *Transaction) GetClientAdminsByUsername(username string) ([]*models.ClientAdmin, error) {
+	ret := _m.Called(username)
+
+	var r0 []*models.ClientAdmin
+	if rf, ok := ret.Get(0).(func(string) []*models.ClientAdmin); ok {
+		r0 = rf(username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ClientAdmin)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClientByUID provides a mock function with given fields: uid
+func (_m *Transaction) GetClientByUID(uid uuid.UUID) (*models.Client, error) {
+	ret := _m.Called(uid)
+
+	var r0 *models.Client
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.Client); ok {
+		r0 = rf(uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Client)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClients provides a mock function with given fields: query
+func (_m *Transaction) GetClients(query models.PageQuery) ([]*models.Client, error) {
+	ret := _m.Called(query)
+
+	var r0 []*models.Client
+	if rf, ok := ret.Get(0).(func(models.PageQuery) []*models.Client); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Client)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(models.PageQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupByName provides a mock function with given fields: name
+func (_m *Transaction) GetGroupByName(name string) (*models.Group, error) {
+	ret := _m.Called(name)
+
+	var r0 *models.Group
+	if rf, ok := ret.Get(0).(func(string) *models.Group); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Group)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupMemberUsernames provides a mock function with given fields: name
+func (_m *Transaction) GetGroupMemberUsernames(name string) ([]string, error) {
+	ret := _m.Called(name)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupRoleByClientUIDAndGroupName provides a mock function with given fields: clientUID, groupName
+func (_m *Transaction) GetGroupRoleByClientUIDAndGroupName(clientUID uuid.UUID, groupName string) (*models.GroupRole, error) {
+	ret := _m.Called(clientUID, groupName)
+
+	var r0 *models.GroupRole
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) *models.GroupRole); ok {
+		r0 = rf(clientUID, groupName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.GroupRole)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
+		r1 = rf(clientUID, groupName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupRolesByClientUID provides a mock function with given fields: clientUID
+func (_m *Transaction) GetGroupRolesByClientUID(clientUID uuid.UUID) ([]*models.GroupRole, error) {
+	ret := _m.Called(clientUID)
+
+	var r0 []*models.GroupRole
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []*models.GroupRole); ok {
+		r0 = rf(clientUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.GroupRole)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(clientUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroups provides a mock function with given fields:
+func (_m *Transaction) GetGroups() ([]*models.Group, error) {
+	ret := _m.Called()
+
+	var r0 []*models.Group
+	if rf, ok := ret.Get(0).(func() []*models.Group); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Group)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupsByUsername provides a mock function with given fields: username
+func (_m *Transaction) GetGroupsByUsername(username string) ([]*models.Group, error) {
+	ret := _m.Called(username)
+
+	var r0 []*models.Group
+	if rf, ok := ret.Get(0).(func(string) []*models.Group); ok {
+		r0 = rf(username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Group)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvitationByID provides a mock function with given fields: id
+func (_m *Transaction) GetInvitationByID(id uuid.UUID) (*models.Invitation, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.Invitation
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.Invitation); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Invitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvitationByTokenHash provides a mock function with given fields: hash
+func (_m *Transaction) GetInvitationByTokenHash(hash []byte) (*models.Invitation, error) {
+	ret := _m.Called(hash)
+
+	var r0 *models.Invitation
+	if rf, ok := ret.Get(0).(func([]byte) *models.Invitation); ok {
+		r0 = rf(hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Invitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestTimestamp provides a mock function with given fields:
+func (_m *Transaction) GetLatestTimestamp() (string, bool, error) {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
 		r0 = ret.Get(0).(string)
 	}
 
@@ -284,6 +1167,75 @@ func (_m *Transaction) GetLatestTimestamp() (string, bool, error) {
 	return r0, r1, r2
 }
 
+// GetLatestUserPreviousPasswords provides a mock function with given fields: username, limit
+func (_m *Transaction) GetLatestUserPreviousPasswords(username string, limit int) ([]*models.PreviousPassword, error) {
+	ret := _m.Called(username, limit)
+
+	var r0 []*models.PreviousPassword
+	if rf, ok := ret.Get(0).(func(string, int) []*models.PreviousPassword); ok {
+		r0 = rf(username, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PreviousPassword)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(username, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLockedLoginThrottles provides a mock function with given fields: now
+func (_m *Transaction) GetLockedLoginThrottles(now time.Time) ([]*models.LoginThrottle, error) {
+	ret := _m.Called(now)
+
+	var r0 []*models.LoginThrottle
+	if rf, ok := ret.Get(0).(func(time.Time) []*models.LoginThrottle); ok {
+		r0 = rf(now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.LoginThrottle)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoginThrottle provides a mock function with given fields: throttleType, key
+func (_m *Transaction) GetLoginThrottle(throttleType string, key string) (*models.LoginThrottle, error) {
+	ret := _m.Called(throttleType, key)
+
+	var r0 *models.LoginThrottle
+	if rf, ok := ret.Get(0).(func(string, string) *models.LoginThrottle); ok {
+		r0 = rf(throttleType, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LoginThrottle)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(throttleType, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMigrationByTimestamp provides a mock function with given fields: timestamp
 func (_m *Transaction) GetMigrationByTimestamp(timestamp string) (*models.Migration, error) {
 	ret := _m.Called(timestamp)
@@ -307,6 +1259,98 @@ func (_m *Transaction) GetMigrationByTimestamp(timestamp string) (*models.Migrat
 	return r0, r1
 }
 
+// GetPasswordResetToken provides a mock function with given fields: hash
+func (_m *Transaction) GetPasswordResetToken(hash []byte) (*models.PasswordResetToken, error) {
+	ret := _m.Called(hash)
+
+	var r0 *models.PasswordResetToken
+	if rf, ok := ret.Get(0).(func([]byte) *models.PasswordResetToken); ok {
+		r0 = rf(hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PasswordResetToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRefreshToken provides a mock function with given fields: token
+func (_m *Transaction) GetRefreshToken(token uuid.UUID) (*models.RefreshToken, error) {
+	ret := _m.Called(token)
+
+	var r0 *models.RefreshToken
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.RefreshToken); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RefreshToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoleDefinitionByClientUIDAndName provides a mock function with given fields: clientUID, name
+func (_m *Transaction) GetRoleDefinitionByClientUIDAndName(clientUID uuid.UUID, name string) (*models.RoleDefinition, error) {
+	ret := _m.Called(clientUID, name)
+
+	var r0 *models.RoleDefinition
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) *models.RoleDefinition); ok {
+		r0 = rf(clientUID, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RoleDefinition)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
+		r1 = rf(clientUID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoleDefinitionsByClientUID provides a mock function with given fields: clientUID
+func (_m *Transaction) GetRoleDefinitionsByClientUID(clientUID uuid.UUID) ([]*models.RoleDefinition, error) {
+	ret := _m.Called(clientUID)
+
+	var r0 []*models.RoleDefinition
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []*models.RoleDefinition); ok {
+		r0 = rf(clientUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.RoleDefinition)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(clientUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSessionByToken provides a mock function with given fields: token
 func (_m *Transaction) GetSessionByToken(token uuid.UUID) (*models.Session, error) {
 	ret := _m.Called(token)
@@ -330,6 +1374,29 @@ func (_m *Transaction) GetSessionByToken(token uuid.UUID) (*models.Session, erro
 	return r0, r1
 }
 
+// GetUserByEmail provides a mock function with given fields: email
+func (_m *Transaction) GetUserByEmail(email string) (*models.User, error) {
+	ret := _m.Called(email)
+
+	var r0 *models.User
+	if rf, ok := ret.Get(0).(func(string) *models.User); ok {
+		r0 = rf(email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserByUsername provides a mock function with given fields: username
 func (_m *Transaction) GetUserByUsername(username string) (*models.User, error) {
 	ret := _m.Called(username)
@@ -376,13 +1443,13 @@ func (_m *Transaction) GetUserRoleByClientUIDAndUsername(clientUID uuid.UUID, us
 	return r0, r1
 }
 
-// GetUserRolesWithLesserRankByClientUID provides a mock function with given fields: uid, rank
-func (_m *Transaction) GetUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank int) ([]*models.UserRole, error) {
-	ret := _m.Called(uid, rank)
+// GetUserRolesWithLesserRankByClientUID provides a mock function with given fields: uid, rank, query
+func (_m *Transaction) GetUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank int, query models.PageQuery) ([]*models.UserRole, error) {
+	ret := _m.Called(uid, rank, query)
 
 	var r0 []*models.UserRole
-	if rf, ok := ret.Get(0).(func(uuid.UUID, int) []*models.UserRole); ok {
-		r0 = rf(uid, rank)
+	if rf, ok := ret.Get(0).(func(uuid.UUID, int, models.PageQuery) []*models.UserRole); ok {
+		r0 = rf(uid, rank, query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.UserRole)
@@ -390,8 +1457,8 @@ func (_m *Transaction) GetUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uuid.UUID, int) error); ok {
-		r1 = rf(uid, rank)
+	if rf, ok := ret.Get(1).(func(uuid.UUID, int, models.PageQuery) error); ok {
+		r1 = rf(uid, rank, query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -399,13 +1466,13 @@ func (_m *Transaction) GetUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank
 	return r0, r1
 }
 
-// GetUsersWithLesserRank provides a mock function with given fields: rank
-func (_m *Transaction) GetUsersWithLesserRank(rank int) ([]*models.User, error) {
-	ret := _m.Called(rank)
+// GetUsersWithLesserRank provides a mock function with given fields: rank, query
+func (_m *Transaction) GetUsersWithLesserRank(rank int, query models.PageQuery) ([]*models.User, error) {
+	ret := _m.Called(rank, query)
 
 	var r0 []*models.User
-	if rf, ok := ret.Get(0).(func(int) []*models.User); ok {
-		r0 = rf(rank)
+	if rf, ok := ret.Get(0).(func(int, models.PageQuery) []*models.User); ok {
+		r0 = rf(rank, query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.User)
@@ -413,8 +1480,8 @@ func (_m *Transaction) GetUsersWithLesserRank(rank int) ([]*models.User, error)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(rank)
+	if rf, ok := ret.Get(1).(func(int, models.PageQuery) error); ok {
+		r1 = rf(rank, query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -422,6 +1489,48 @@ func (_m *Transaction) GetUsersWithLesserRank(rank int) ([]*models.User, error)
 	return r0, r1
 }
 
+// RenameGroupRoles provides a mock function with given fields: clientUID, role, newRole
+func (_m *Transaction) RenameGroupRoles(clientUID uuid.UUID, role string, newRole string) error {
+	ret := _m.Called(clientUID, role, newRole)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, string) error); ok {
+		r0 = rf(clientUID, role, newRole)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RenameInvitationRoles provides a mock function with given fields: clientUID, role, newRole
+func (_m *Transaction) RenameInvitationRoles(clientUID uuid.UUID, role string, newRole string) error {
+	ret := _m.Called(clientUID, role, newRole)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, string) error); ok {
+		r0 = rf(clientUID, role, newRole)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RenameUserRoles provides a mock function with given fields: clientUID, role, newRole
+func (_m *Transaction) RenameUserRoles(clientUID uuid.UUID, role string, newRole string) error {
+	ret := _m.Called(clientUID, role, newRole)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, string) error); ok {
+		r0 = rf(clientUID, role, newRole)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Rollback provides a mock function with given fields:
 func (_m *Transaction) Rollback() error {
 	ret := _m.Called()
@@ -436,6 +1545,139 @@ func (_m *Transaction) Rollback() error {
 	return r0
 }
 
+// RotateRefreshToken provides a mock function with given fields: token
+func (_m *Transaction) RotateRefreshToken(token uuid.UUID) (bool, error) {
+	ret := _m.Called(token)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID) bool); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveAuditEvent provides a mock function with given fields: event
+func (_m *Transaction) SaveAuditEvent(event *models.AuditEvent) error {
+	ret := _m.Called(event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.AuditEvent) error); ok {
+		r0 = rf(event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveAuthorizationCode provides a mock function with given fields: code
+func (_m *Transaction) SaveAuthorizationCode(code *models.AuthorizationCode) error {
+	ret := _m.Called(code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.AuthorizationCode) error); ok {
+		r0 = rf(code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveInvitation provides a mock function with given fields: invitation
+func (_m *Transaction) SaveInvitation(invitation *models.Invitation) error {
+	ret := _m.Called(invitation)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Invitation) error); ok {
+		r0 = rf(invitation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveLoginThrottle provides a mock function with given fields: throttle
+func (_m *Transaction) SaveLoginThrottle(throttle *models.LoginThrottle) error {
+	ret := _m.Called(throttle)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.LoginThrottle) error); ok {
+		r0 = rf(throttle)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SavePasswordResetToken provides a mock function with given fields: token
+func (_m *Transaction) SavePasswordResetToken(token *models.PasswordResetToken) error {
+	ret := _m.Called(token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.PasswordResetToken) error); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SavePreviousPassword provides a mock function with given fields: password
+func (_m *Transaction) SavePreviousPassword(password *models.PreviousPassword) error {
+	ret := _m.Called(password)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.PreviousPassword) error); ok {
+		r0 = rf(password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveRecoveryCode provides a mock function with given fields: code
+func (_m *Transaction) SaveRecoveryCode(code *models.RecoveryCode) error {
+	ret := _m.Called(code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.RecoveryCode) error); ok {
+		r0 = rf(code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveRefreshToken provides a mock function with given fields: token
+func (_m *Transaction) SaveRefreshToken(token *models.RefreshToken) error {
+	ret := _m.Called(token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.RefreshToken) error); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveSession provides a mock function with given fields: session
 func (_m *Transaction) SaveSession(session *models.Session) error {
 	ret := _m.Called(session)
@@ -450,6 +1692,20 @@ func (_m *Transaction) SaveSession(session *models.Session) error {
 	return r0
 }
 
+// SetUserAdminRoles provides a mock function with given fields: username, names
+func (_m *Transaction) SetUserAdminRoles(username string, names []string) error {
+	ret := _m.Called(username, names)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(username, names)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Setup provides a mock function with given fields:
 func (_m *Transaction) Setup() error {
 	ret := _m.Called()
@@ -464,6 +1720,27 @@ func (_m *Transaction) Setup() error {
 	return r0
 }
 
+// UpdateAdminRole provides a mock function with given fields: role
+func (_m *Transaction) UpdateAdminRole(role *models.AdminRole) (bool, error) {
+	ret := _m.Called(role)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*models.AdminRole) bool); ok {
+		r0 = rf(role)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.AdminRole) error); ok {
+		r1 = rf(role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateClient provides a mock function with given fields: client
 func (_m *Transaction) UpdateClient(client *models.Client) (bool, error) {
 	ret := _m.Called(client)
@@ -485,6 +1762,111 @@ func (_m *Transaction) UpdateClient(client *models.Client) (bool, error) {
 	return r0, r1
 }
 
+// UpdateClientSecretHash provides a mock function with given fields: uid, secretHash
+func (_m *Transaction) UpdateClientSecretHash(uid uuid.UUID, secretHash []byte) (bool, error) {
+	ret := _m.Called(uid, secretHash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID, []byte) bool); ok {
+		r0 = rf(uid, secretHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, []byte) error); ok {
+		r1 = rf(uid, secretHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateGroup provides a mock function with given fields: group
+func (_m *Transaction) UpdateGroup(group *models.Group) (bool, error) {
+	ret := _m.Called(group)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*models.Group) bool); ok {
+		r0 = rf(group)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.Group) error); ok {
+		r1 = rf(group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateGroupRole provides a mock function with given fields: role
+func (_m *Transaction) UpdateGroupRole(role *models.GroupRole) (bool, error) {
+	ret := _m.Called(role)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*models.GroupRole) bool); ok {
+		r0 = rf(role)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.GroupRole) error); ok {
+		r1 = rf(role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRoleDefinition provides a mock function with given fields: name, definition
+func (_m *Transaction) UpdateRoleDefinition(name string, definition *models.RoleDefinition) (bool, error) {
+	ret := _m.Called(name, definition)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, *models.RoleDefinition) bool); ok {
+		r0 = rf(name, definition)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *models.RoleDefinition) error); ok {
+		r1 = rf(name, definition)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateSessionLastUsedAt provides a mock function with given fields: token, lastUsedAt
+func (_m *Transaction) UpdateSessionLastUsedAt(token uuid.UUID, lastUsedAt time.Time) (bool, error) {
+	ret := _m.Called(token, lastUsedAt)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time) bool); ok {
+		r0 = rf(token, lastUsedAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, time.Time) error); ok {
+		r1 = rf(token, lastUsedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateUser provides a mock function with given fields: user
 func (_m *Transaction) UpdateUser(user *models.User) (bool, error) {
 	ret := _m.Called(user)
@@ -506,20 +1888,20 @@ func (_m *Transaction) UpdateUser(user *models.User) (bool, error) {
 	return r0, r1
 }
 
-// UpdateUserPassword provides a mock function with given fields: username, hash
-func (_m *Transaction) UpdateUserPassword(username string, hash []byte) (bool, error) {
-	ret := _m.Called(username, hash)
+// UpdateUserPassword provides a mock function with given fields: username, hash, updatedAt
+func (_m *Transaction) UpdateUserPassword(username string, hash []byte, updatedAt time.Time) (bool, error) {
+	ret := _m.Called(username, hash, updatedAt)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(string, []byte) bool); ok {
-		r0 = rf(username, hash)
+	if rf, ok := ret.Get(0).(func(string, []byte, time.Time) bool); ok {
+		r0 = rf(username, hash, updatedAt)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, []byte) error); ok {
-		r1 = rf(username, hash)
+	if rf, ok := ret.Get(1).(func(string, []byte, time.Time) error); ok {
+		r1 = rf(username, hash, updatedAt)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -547,3 +1929,45 @@ func (_m *Transaction) UpdateUserRole(role *models.UserRole) (bool, error) {
 
 	return r0, r1
 }
+
+// UpdateUserTOTP provides a mock function with given fields: username, secret, enabled
+func (_m *Transaction) UpdateUserTOTP(username string, secret []byte, enabled bool) (bool, error) {
+	ret := _m.Called(username, secret, enabled)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, []byte, bool) bool); ok {
+		r0 = rf(username, secret, enabled)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []byte, bool) error); ok {
+		r1 = rf(username, secret, enabled)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUserTOTPLastTimeStep provides a mock function with given fields: username, step
+func (_m *Transaction) UpdateUserTOTPLastTimeStep(username string, step int64) (bool, error) {
+	ret := _m.Called(username, step)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, int64) bool); ok {
+		r0 = rf(username, step)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(username, step)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -5,8 +5,9 @@ import (
 )
 
 type ScopeFactory interface {
-	// CreateDataExecutorScope handles setup and clean up of the data executor, and passes it to the provided body function.
-	// Returns any errors from the executor or body.
+	// CreateDataExecutorScope gets a data executor from the data adapter, and passes it to the provided body function.
+	// The data adapter must already be set up, and its connections are shared between every scope.
+	// Returns any errors from the body.
 	CreateDataExecutorScope(func(DataExecutor) error) error
 
 	// CreateTransactionScope handles transaction creation and commiting, and passes it to the provided body function.
@@ -19,13 +20,6 @@ type CoreScopeFactory struct {
 }
 
 func (sf CoreScopeFactory) CreateDataExecutorScope(body func(DataExecutor) error) error {
-	//init the data adapter
-	err := sf.DataAdapter.Setup()
-	if err != nil {
-		return common.ChainError("error setting up data adapter", err)
-	}
-	defer sf.DataAdapter.CleanUp()
-
 	//execute the body
 	return body(sf.DataAdapter.GetExecutor())
 }
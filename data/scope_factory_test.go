@@ -29,42 +29,39 @@ func (suite *ScopeFactoryTestSuite) SetupTest() {
 	}
 }
 
-func (suite *ScopeFactoryTestSuite) TestCreateDataExecutorScope_WithErrorSettingUpDataAdapter_ReturnsError() {
+func (suite *ScopeFactoryTestSuite) TestCreateDataExecutorScope_ReturnsResultFromBody() {
 	//arrange
-	message := "setup error"
-	suite.DataAdapterMock.On("Setup").Return(errors.New(message))
+	message := "body error"
+	suite.DataAdapterMock.On("GetExecutor").Return(&suite.DataExecutorMock)
 
 	//act
-	err := suite.ScopeFactory.CreateDataExecutorScope(func(_ data.DataExecutor) error {
-		return nil
+	err := suite.ScopeFactory.CreateDataExecutorScope(func(exec data.DataExecutor) error {
+		suite.Equal(&suite.DataExecutorMock, exec)
+		return errors.New(message)
 	})
 
 	//assert
 	suite.Require().Error(err)
 	suite.Contains(err.Error(), message)
+
+	suite.DataAdapterMock.AssertCalled(suite.T(), "GetExecutor")
 }
 
-func (suite *ScopeFactoryTestSuite) TestCreateDataExecutorScope_ReturnsResultFromBody() {
+func (suite *ScopeFactoryTestSuite) TestCreateDataExecutorScope_DoesNotSetUpOrCleanUpDataAdapter() {
 	//arrange
-	message := "body error"
-
-	suite.DataAdapterMock.On("Setup").Return(nil)
-	suite.DataAdapterMock.On("CleanUp").Return(nil)
 	suite.DataAdapterMock.On("GetExecutor").Return(&suite.DataExecutorMock)
 
 	//act
-	err := suite.ScopeFactory.CreateDataExecutorScope(func(exec data.DataExecutor) error {
-		suite.Equal(&suite.DataExecutorMock, exec)
-		return errors.New(message)
+	err := suite.ScopeFactory.CreateDataExecutorScope(func(_ data.DataExecutor) error {
+		return nil
 	})
 
 	//assert
-	suite.Require().Error(err)
-	suite.Contains(err.Error(), message)
+	suite.NoError(err)
 
-	suite.DataAdapterMock.AssertCalled(suite.T(), "Setup")
-	suite.DataAdapterMock.AssertCalled(suite.T(), "GetExecutor")
-	suite.DataAdapterMock.AssertCalled(suite.T(), "CleanUp")
+	//the adapter's connections are shared between scopes, so they are only opened and closed by its owner
+	suite.DataAdapterMock.AssertNotCalled(suite.T(), "Setup")
+	suite.DataAdapterMock.AssertNotCalled(suite.T(), "CleanUp")
 }
 
 func (suite *ScopeFactoryTestSuite) TestCreateTransactionScope_WithErrorCreatingTransaction_ReturnsError() {
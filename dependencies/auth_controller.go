@@ -14,7 +14,10 @@ var authController controllerspkg.AuthController
 func ResolveAuthController() controllerspkg.AuthController {
 	createAuthControllerOnce.Do(func() {
 		authController = &controllerspkg.CoreAuthController{
-			PasswordHasher: ResolvePasswordHasher(),
+			PasswordHasher:            ResolvePasswordHasher(),
+			PasswordCriteriaValidator: ResolvePasswordCriteriaValidator(),
+			TOTPGenerator:             ResolveTOTPGenerator(),
+			Encryptor:                 ResolveEncryptor(),
 		}
 	})
 	return authController
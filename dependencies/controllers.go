@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	controllerspkg "github.com/mhogar/amber/controllers"
+	jwthelpers "github.com/mhogar/amber/controllers/jwt_helpers"
 )
 
 var createControllersOnce sync.Once
@@ -14,17 +15,45 @@ var controllers controllerspkg.Controllers
 func ResolveControllers() controllerspkg.Controllers {
 	createControllersOnce.Do(func() {
 		controllers = &controllerspkg.CoreControllers{
-			UserController:   ResolveUserController(),
-			ClientController: controllerspkg.CoreClientController{},
-			AuthController:   ResolveAuthController(),
+			UserController: ResolveUserController(),
+			ClientController: controllerspkg.CoreClientController{
+				PasswordHasher: ResolvePasswordHasher(),
+			},
+			AuthController: ResolveAuthController(),
 			SessionController: controllerspkg.CoreSessionController{
 				AuthController: ResolveAuthController(),
 			},
 			TokenController: controllerspkg.CoreTokenController{
 				AuthController:       ResolveAuthController(),
 				TokenFactorySelector: ResolveTokenFactorySelector(),
+				IDTokenFactory: jwthelpers.DefaultTokenFactory{
+					DataLoader:  ResolveRawDataLoader(),
+					TokenSigner: ResolveTokenSigner(),
+				},
+				DataLoader: ResolveRawDataLoader(),
+			},
+			UserRoleController:       controllerspkg.CoreUserRoleController{},
+			RoleDefinitionController: controllerspkg.CoreRoleDefinitionController{},
+			AdminRoleController:      controllerspkg.CoreAdminRoleController{},
+			ClientAdminController:    controllerspkg.CoreClientAdminController{},
+			GroupController:          controllerspkg.CoreGroupController{},
+			GroupRoleController:      controllerspkg.CoreGroupRoleController{},
+			TwoFactorController: controllerspkg.CoreTwoFactorController{
+				AuthController: ResolveAuthController(),
+				TOTPGenerator:  ResolveTOTPGenerator(),
+				Encryptor:      ResolveEncryptor(),
+			},
+			LockoutController: controllerspkg.CoreLockoutController{},
+			AuditController:   controllerspkg.CoreAuditController{},
+			PasswordResetController: controllerspkg.CorePasswordResetController{
+				UserController: ResolveUserController(),
+				Mailer:         ResolveMailer(),
+			},
+			InvitationController: controllerspkg.CoreInvitationController{
+				UserController:     ResolveUserController(),
+				UserRoleController: controllerspkg.CoreUserRoleController{},
+				Mailer:             ResolveMailer(),
 			},
-			UserRoleController: controllerspkg.CoreUserRoleController{},
 		}
 	})
 	return controllers
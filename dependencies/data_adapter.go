@@ -7,6 +7,7 @@ import (
 	"github.com/mhogar/amber/data"
 	sqladapter "github.com/mhogar/amber/data/database/sql_adapter"
 	firestoreadapter "github.com/mhogar/amber/data/firestore_adapter"
+	memoryadapter "github.com/mhogar/amber/data/memory_adapter"
 
 	"github.com/spf13/viper"
 )
@@ -23,6 +24,8 @@ func ResolveDataAdapter() data.DataAdapter {
 			dataAdapter = sqladapter.CreateSQLAdpater(viper.GetString("db_key"), ResolveSQLDriver())
 		case "firestore":
 			dataAdapter = &firestoreadapter.FirestoreAdapter{}
+		case "memory":
+			dataAdapter = &memoryadapter.MemoryAdapter{}
 		default:
 			panic("invalid data adapter key")
 		}
@@ -0,0 +1,19 @@
+package dependencies
+
+import (
+	"sync"
+
+	totphelpers "github.com/mhogar/amber/controllers/totp_helpers"
+)
+
+var createEncryptorOnce sync.Once
+var encryptor totphelpers.Encryptor
+
+// ResolveEncryptor resolves the Encryptor dependency.
+// Only the first call to this function will create a new Encryptor, after which it will be retrieved from memory.
+func ResolveEncryptor() totphelpers.Encryptor {
+	createEncryptorOnce.Do(func() {
+		encryptor = totphelpers.AESEncryptor{}
+	})
+	return encryptor
+}
@@ -0,0 +1,43 @@
+package dependencies
+
+import (
+	"sync"
+
+	"github.com/mhogar/amber/config"
+	mailhelpers "github.com/mhogar/amber/controllers/mail_helpers"
+)
+
+var createMailerOnce sync.Once
+var mailer mailhelpers.Mailer
+
+// ResolveMailer resolves the Mailer dependency.
+// Only the first call to this function will create a new Mailer, after which it will be retrieved from memory.
+func ResolveMailer() mailhelpers.Mailer {
+	createMailerOnce.Do(func() {
+		cfg := config.GetMailConfig()
+
+		switch cfg.Type {
+		case "smtp":
+			mailer = mailhelpers.SMTPMailer{
+				Host:     cfg.SMTPHost,
+				Port:     cfg.SMTPPort,
+				Username: cfg.SMTPUsername,
+				Password: cfg.SMTPPassword,
+				From:     cfg.From,
+			}
+		case "file", "":
+			filename := ""
+			if cfg.File != "" {
+				filename = config.GetAppRoot(cfg.File)
+			}
+
+			mailer = &mailhelpers.FileMailer{
+				Filename: filename,
+				From:     cfg.From,
+			}
+		default:
+			panic("invalid mail type")
+		}
+	})
+	return mailer
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/mhogar/amber/data"
 	"github.com/mhogar/amber/data/database/sql_adapter/migrations"
 	firestoreadapter "github.com/mhogar/amber/data/firestore_adapter"
+	memoryadapter "github.com/mhogar/amber/data/memory_adapter"
 )
 
 var createMigrationRepositoryFactoryOnce sync.Once
@@ -23,6 +24,8 @@ func ResolveMigrationRepositoryFactory() data.MigrationRepositoryFactory {
 			}
 		case "firestore":
 			migrationRepositoryFactory = &firestoreadapter.FirestoreMigrationRepositoryFactory{}
+		case "memory":
+			migrationRepositoryFactory = &memoryadapter.MemoryMigrationRepositoryFactory{}
 		default:
 			panic("invalid data adpater key")
 		}
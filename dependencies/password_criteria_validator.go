@@ -1,8 +1,11 @@
 package dependencies
 
 import (
+	"log"
 	"sync"
 
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
 	passwordhelpers "github.com/mhogar/amber/controllers/password_helpers"
 )
 
@@ -14,6 +17,20 @@ var passwordCriteriaValidator passwordhelpers.PasswordCriteriaValidator
 func ResolvePasswordCriteriaValidator() passwordhelpers.PasswordCriteriaValidator {
 	createPasswordCriteriaValidatorOnce.Do(func() {
 		passwordCriteriaValidator = passwordhelpers.ConfigPasswordCriteriaValidator{}
+
+		//also check the breached password corpus if one is configured
+		cfg := config.GetPasswordCriteriaConfig()
+		if cfg.BreachedPasswordsPath == "" {
+			return
+		}
+
+		validator, err := passwordhelpers.LoadBreachedPasswordCriteriaValidator(passwordCriteriaValidator, config.GetAppRoot(cfg.BreachedPasswordsPath), cfg.BreachedPasswordsFormat)
+		if err != nil {
+			panic(common.ChainError("error loading breached password corpus", err))
+		}
+
+		log.Printf("loaded %d breached passwords", validator.Size())
+		passwordCriteriaValidator = validator
 	})
 	return passwordCriteriaValidator
 }
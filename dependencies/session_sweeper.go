@@ -0,0 +1,25 @@
+package dependencies
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mhogar/amber/config"
+	"github.com/mhogar/amber/sweeper"
+)
+
+var createSessionSweeperOnce sync.Once
+var sessionSweeper sweeper.Sweeper
+
+// ResolveSessionSweeper resolves the session Sweeper dependency.
+// Only the first call to this function will create a new Sweeper, after which it will be retrieved from memory.
+func ResolveSessionSweeper() sweeper.Sweeper {
+	createSessionSweeperOnce.Do(func() {
+		sessionSweeper = &sweeper.SessionSweeper{
+			ScopeFactory:      ResolveScopeFactory(),
+			SessionController: ResolveControllers(),
+			Interval:          time.Duration(config.GetSessionConfig().SweepInterval) * time.Second,
+		}
+	})
+	return sessionSweeper
+}
@@ -5,7 +5,9 @@ import (
 
 	"github.com/mhogar/amber/config"
 	sqladapter "github.com/mhogar/amber/data/database/sql_adapter"
+	"github.com/mhogar/amber/data/database/sql_adapter/mysql"
 	"github.com/mhogar/amber/data/database/sql_adapter/postgres"
+	"github.com/mhogar/amber/data/database/sql_adapter/sqlite"
 )
 
 var createSQLDriverOnce sync.Once
@@ -16,8 +18,12 @@ var sqlDriver sqladapter.SQLDriver
 func ResolveSQLDriver() sqladapter.SQLDriver {
 	createSQLDriverOnce.Do(func() {
 		switch config.GetDatabaseConfig().Driver {
+		case "mysql":
+			sqlDriver = mysql.Driver{}
 		case "postgres":
 			sqlDriver = postgres.Driver{}
+		case "sqlite":
+			sqlDriver = sqlite.Driver{}
 		default:
 			panic("invalid database driver")
 		}
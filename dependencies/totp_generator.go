@@ -0,0 +1,19 @@
+package dependencies
+
+import (
+	"sync"
+
+	totphelpers "github.com/mhogar/amber/controllers/totp_helpers"
+)
+
+var createTOTPGeneratorOnce sync.Once
+var totpGenerator totphelpers.TOTPGenerator
+
+// ResolveTOTPGenerator resolves the TOTPGenerator dependency.
+// Only the first call to this function will create a new TOTPGenerator, after which it will be retrieved from memory.
+func ResolveTOTPGenerator() totphelpers.TOTPGenerator {
+	createTOTPGeneratorOnce.Do(func() {
+		totpGenerator = totphelpers.RFC6238TOTPGenerator{}
+	})
+	return totpGenerator
+}
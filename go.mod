@@ -5,10 +5,12 @@ go 1.14
 require (
 	cloud.google.com/go/firestore v1.6.0 // indirect
 	firebase.google.com/go/v4 v4.6.0 // indirect
+	github.com/go-sql-driver/mysql v1.6.0
 	github.com/golang-jwt/jwt v3.2.2+incompatible
 	github.com/google/uuid v1.3.0
 	github.com/julienschmidt/httprouter v1.3.0
 	github.com/lib/pq v1.10.3
+	github.com/mattn/go-sqlite3 v1.14.16
 	github.com/mhogar/migrationrunner v1.1.0
 	github.com/spf13/viper v1.8.1
 	github.com/stretchr/testify v1.7.0
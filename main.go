@@ -2,10 +2,13 @@ package main
 
 import (
 	"log"
+	"os"
+	"time"
 
 	"github.com/mhogar/amber/common"
 	"github.com/mhogar/amber/dependencies"
 	"github.com/mhogar/amber/server"
+	"github.com/mhogar/amber/tools/admin_creator/runner"
 
 	"github.com/mhogar/amber/config"
 )
@@ -16,6 +19,40 @@ func main() {
 		log.Fatal(common.ChainError("error initing config", err))
 	}
 
-	serverRunner := server.CreateHTTPServerRunner(dependencies.ResolveRouterFactory())
-	log.Fatal(serverRunner.Run())
+	serverRunner, err := server.CreateHTTPServerRunner(dependencies.ResolveRouterFactory())
+	if err != nil {
+		log.Fatal(common.ChainError("error creating server runner", err))
+	}
+
+	//open the data adapter once so its connections are shared by every request
+	dataAdapter := dependencies.ResolveDataAdapter()
+	err = dataAdapter.Setup()
+	if err != nil {
+		log.Fatal(common.ChainError("error setting up data adapter", err))
+	}
+
+	//the memory data adapter starts out empty, so create the max admin in it if one is provided
+	if config.GetDataAdapter() == "memory" && os.Getenv("ADMIN_USERNAME") != "" {
+		err = runner.Run(dependencies.ResolveScopeFactory(), dependencies.ResolveControllers(), os.Getenv("ADMIN_USERNAME"), os.Getenv("ADMIN_PASSWORD"), 10)
+		if err != nil {
+			log.Fatal(common.ChainError("error creating admin", err))
+		}
+	}
+
+	//the runner closes the data adapter once the server has shut down
+	serverRunner.DataAdapter = dataAdapter
+	serverRunner.ShutdownTimeout = time.Duration(config.GetServerConfig().ShutdownTimeout) * time.Second
+
+	//sweep expired sessions in the background if enabled
+	if config.GetSessionConfig().SweepInterval > 0 {
+		sessionSweeper := dependencies.ResolveSessionSweeper()
+		sessionSweeper.Start()
+		serverRunner.Sweepers = append(serverRunner.Sweepers, sessionSweeper)
+	}
+
+	//the runner has already released its resources if it returns an error, so exit with a failure status
+	err = serverRunner.Run()
+	if err != nil {
+		log.Fatal(common.ChainError("error running server", err))
+	}
 }
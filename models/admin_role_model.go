@@ -0,0 +1,122 @@
+package models
+
+import "sort"
+
+const (
+	ValidateAdminRoleValid               = 0x0
+	ValidateAdminRoleEmptyName           = 0x1
+	ValidateAdminRoleNameTooLong         = 0x2
+	ValidateAdminRoleDescriptionTooLong  = 0x4
+	ValidateAdminRoleInvalidPermission   = 0x8
+	ValidateAdminRoleDuplicatePermission = 0x10
+)
+
+// AdminRoleNameMaxLength is the max length an admin role's name can be.
+const AdminRoleNameMaxLength = 30
+
+// AdminRoleDescriptionMaxLength is the max length an admin role's description can be.
+const AdminRoleDescriptionMaxLength = 255
+
+// AdminRole represents the admin role model.
+// Admin roles grant a set of permissions to the users they are given to.
+type AdminRole struct {
+	Name        string   `firestore:"name"`
+	Description string   `firestore:"description"`
+	Permissions []string `firestore:"permissions"`
+}
+
+type AdminRoleCRUD interface {
+	// CreateAdminRole creates the admin role. Returns any errors.
+	CreateAdminRole(role *AdminRole) error
+
+	// GetAdminRoles fetches all the admin roles, sorted by name.
+	// Returns the admin roles and any errors.
+	GetAdminRoles() ([]*AdminRole, error)
+
+	// GetAdminRoleByName fetches the admin role with the given name.
+	// Returns the admin role if it exists, nil if not. Also returns any errors.
+	GetAdminRoleByName(name string) (*AdminRole, error)
+
+	// UpdateAdminRole updates the description and permissions of the admin role.
+	// Returns result of whether the admin role was found and any errors.
+	UpdateAdminRole(role *AdminRole) (bool, error)
+
+	// DeleteAdminRole deletes the admin role with the given name, also taking it away from the users it was given to.
+	// Returns result of whether the admin role was found, and any errors.
+	DeleteAdminRole(name string) (bool, error)
+
+	// GetAdminRolesByUsername fetches the admin roles given to the user with the given username, sorted by name.
+	// Returns the admin roles and any errors.
+	GetAdminRolesByUsername(username string) ([]*AdminRole, error)
+
+	// GetAdminRoleUsernames fetches the usernames of the users the admin role with the given name was given to, sorted by username.
+	// Returns the usernames and any errors.
+	GetAdminRoleUsernames(name string) ([]string, error)
+
+	// SetUserAdminRoles replaces the admin roles given to the user with the given username with the admin roles with the given names.
+	// Returns any errors.
+	SetUserAdminRoles(username string, names []string) error
+}
+
+// CreateAdminRole creates a new admin role model with the provided fields. The permissions are kept in sorted order.
+func CreateAdminRole(name string, description string, permissions ...string) *AdminRole {
+	role := &AdminRole{
+		Name:        name,
+		Description: description,
+		Permissions: append([]string{}, permissions...),
+	}
+	sort.Strings(role.Permissions)
+
+	return role
+}
+
+// Validate validates the admin role model has valid fields.
+// Returns an int indicating which fields are invalid.
+func (ar *AdminRole) Validate() int {
+	code := ValidateAdminRoleValid
+
+	//validate name
+	if ar.Name == "" {
+		code |= ValidateAdminRoleEmptyName
+	} else if len(ar.Name) > AdminRoleNameMaxLength {
+		code |= ValidateAdminRoleNameTooLong
+	}
+
+	//validate description
+	if len(ar.Description) > AdminRoleDescriptionMaxLength {
+		code |= ValidateAdminRoleDescriptionTooLong
+	}
+
+	//validate permissions
+	seen := map[string]bool{}
+	for _, permission := range ar.Permissions {
+		if !IsValidPermission(permission) {
+			code |= ValidateAdminRoleInvalidPermission
+		}
+
+		if seen[permission] {
+			code |= ValidateAdminRoleDuplicatePermission
+		}
+		seen[permission] = true
+	}
+
+	return code
+}
+
+// MergeAdminRolePermissions returns the sorted set of permissions granted by all of the admin roles.
+func MergeAdminRolePermissions(roles []*AdminRole) []string {
+	seen := map[string]bool{}
+	permissions := []string{}
+
+	for _, role := range roles {
+		for _, permission := range role.Permissions {
+			if !seen[permission] {
+				seen[permission] = true
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	sort.Strings(permissions)
+
+	return permissions
+}
@@ -0,0 +1,156 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AdminRoleTestSuite struct {
+	helpers.CustomSuite
+	AdminRole *models.AdminRole
+}
+
+func (suite *AdminRoleTestSuite) SetupTest() {
+	suite.AdminRole = models.CreateAdminRole("role", "description", models.PermissionUsersRead)
+}
+
+func (suite *AdminRoleTestSuite) TestCreateAdminRole_CreatesAdminRoleWithSuppliedFieldsAndSortedPermissions() {
+	//arrange
+	name := "name"
+	description := "this is a test description"
+
+	//act
+	role := models.CreateAdminRole(name, description, models.PermissionUsersWrite, models.PermissionAuditRead)
+
+	//assert
+	suite.Require().NotNil(role)
+	suite.Equal(name, role.Name)
+	suite.Equal(description, role.Description)
+	suite.Equal([]string{models.PermissionAuditRead, models.PermissionUsersWrite}, role.Permissions)
+}
+
+func (suite *AdminRoleTestSuite) TestValidate_WithValidAdminRole_ReturnsValid() {
+	//act
+	verr := suite.AdminRole.Validate()
+
+	//assert
+	suite.Equal(models.ValidateAdminRoleValid, verr)
+}
+
+func (suite *AdminRoleTestSuite) TestValidate_WithEmptyName_ReturnsAdminRoleEmptyName() {
+	//arrange
+	suite.AdminRole.Name = ""
+
+	//act
+	verr := suite.AdminRole.Validate()
+
+	//assert
+	suite.Equal(models.ValidateAdminRoleEmptyName, verr)
+}
+
+func (suite *AdminRoleTestSuite) TestValidate_NameMaxLengthTestCases() {
+	var name string
+	var expectedValidateError int
+
+	testCase := func() {
+		//arrange
+		suite.AdminRole.Name = name
+
+		//act
+		verr := suite.AdminRole.Validate()
+
+		//assert
+		suite.Equal(expectedValidateError, verr)
+	}
+
+	name = helpers.CreateStringOfLength(models.AdminRoleNameMaxLength)
+	expectedValidateError = models.ValidateAdminRoleValid
+	suite.Run("ExactlyMaxLengthIsValid", testCase)
+
+	name += "a"
+	expectedValidateError = models.ValidateAdminRoleNameTooLong
+	suite.Run("OneMoreThanMaxLengthIsInvalid", testCase)
+}
+
+func (suite *AdminRoleTestSuite) TestValidate_DescriptionMaxLengthTestCases() {
+	var description string
+	var expectedValidateError int
+
+	testCase := func() {
+		//arrange
+		suite.AdminRole.Description = description
+
+		//act
+		verr := suite.AdminRole.Validate()
+
+		//assert
+		suite.Equal(expectedValidateError, verr)
+	}
+
+	description = ""
+	expectedValidateError = models.ValidateAdminRoleValid
+	suite.Run("EmptyIsValid", testCase)
+
+	description = helpers.CreateStringOfLength(models.AdminRoleDescriptionMaxLength)
+	expectedValidateError = models.ValidateAdminRoleValid
+	suite.Run("ExactlyMaxLengthIsValid", testCase)
+
+	description += "a"
+	expectedValidateError = models.ValidateAdminRoleDescriptionTooLong
+	suite.Run("OneMoreThanMaxLengthIsInvalid", testCase)
+}
+
+func (suite *AdminRoleTestSuite) TestValidate_WithNoPermissions_ReturnsValid() {
+	//arrange
+	suite.AdminRole.Permissions = []string{}
+
+	//act
+	verr := suite.AdminRole.Validate()
+
+	//assert
+	suite.Equal(models.ValidateAdminRoleValid, verr)
+}
+
+func (suite *AdminRoleTestSuite) TestValidate_WithInvalidPermission_ReturnsAdminRoleInvalidPermission() {
+	//arrange
+	suite.AdminRole.Permissions = []string{"DNE"}
+
+	//act
+	verr := suite.AdminRole.Validate()
+
+	//assert
+	suite.Equal(models.ValidateAdminRoleInvalidPermission, verr)
+}
+
+func (suite *AdminRoleTestSuite) TestValidate_WithDuplicatePermission_ReturnsAdminRoleDuplicatePermission() {
+	//arrange
+	suite.AdminRole.Permissions = []string{models.PermissionUsersRead, models.PermissionUsersRead}
+
+	//act
+	verr := suite.AdminRole.Validate()
+
+	//assert
+	suite.Equal(models.ValidateAdminRoleDuplicatePermission, verr)
+}
+
+func (suite *AdminRoleTestSuite) TestMergeAdminRolePermissions_ReturnsSortedSetOfPermissions() {
+	//arrange
+	roles := []*models.AdminRole{
+		models.CreateAdminRole("role1", "", models.PermissionUsersWrite, models.PermissionUsersRead),
+		models.CreateAdminRole("role2", "", models.PermissionAuditRead, models.PermissionUsersRead),
+	}
+
+	//act
+	permissions := models.MergeAdminRolePermissions(roles)
+
+	//assert
+	suite.Equal([]string{models.PermissionAuditRead, models.PermissionUsersRead, models.PermissionUsersWrite}, permissions)
+}
+
+func TestAdminRoleTestSuite(t *testing.T) {
+	suite.Run(t, &AdminRoleTestSuite{})
+}
@@ -0,0 +1,180 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ValidateAuditEventValid            = 0x0
+	ValidateAuditEventNilID            = 0x1
+	ValidateAuditEventActorTooLong     = 0x2
+	ValidateAuditEventEmptyAction      = 0x4
+	ValidateAuditEventActionTooLong    = 0x8
+	ValidateAuditEventTargetTooLong    = 0x10
+	ValidateAuditEventIPAddressTooLong = 0x20
+)
+
+const (
+	AuditActionLogin             = "login"
+	AuditActionLoginFailed       = "login_failed"
+	AuditActionLogout            = "logout"
+	AuditActionClientLogin       = "client_login"
+	AuditActionClientLoginFailed = "client_login_failed"
+
+	AuditActionCreateUser         = "user.create"
+	AuditActionUpdateUser         = "user.update"
+	AuditActionUpdateUserPassword = "user.update_password"
+	AuditActionResetPassword      = "user.reset_password"
+	AuditActionDeleteUser         = "user.delete"
+	AuditActionEnableTOTP         = "user.enable_totp"
+	AuditActionDisableTOTP        = "user.disable_totp"
+
+	AuditActionCreateClient       = "client.create"
+	AuditActionUpdateClient       = "client.update"
+	AuditActionDeleteClient       = "client.delete"
+	AuditActionRotateClientSecret = "client.rotate_secret"
+
+	AuditActionCreateUserRole = "user_role.create"
+	AuditActionUpdateUserRole = "user_role.update"
+	AuditActionDeleteUserRole = "user_role.delete"
+
+	AuditActionCreateRoleDefinition = "role_definition.create"
+	AuditActionUpdateRoleDefinition = "role_definition.update"
+	AuditActionDeleteRoleDefinition = "role_definition.delete"
+
+	AuditActionCreateAdminRole      = "admin_role.create"
+	AuditActionUpdateAdminRole      = "admin_role.update"
+	AuditActionDeleteAdminRole      = "admin_role.delete"
+	AuditActionUpdateUserAdminRoles = "user.update_admin_roles"
+
+	AuditActionCreateClientAdmin = "client_admin.create"
+	AuditActionDeleteClientAdmin = "client_admin.delete"
+
+	AuditActionCreateGroup       = "group.create"
+	AuditActionUpdateGroup       = "group.update"
+	AuditActionDeleteGroup       = "group.delete"
+	AuditActionCreateGroupMember = "group.add_member"
+	AuditActionDeleteGroupMember = "group.remove_member"
+
+	AuditActionCreateGroupRole = "group_role.create"
+	AuditActionUpdateGroupRole = "group_role.update"
+	AuditActionDeleteGroupRole = "group_role.delete"
+
+	AuditActionCreateInvitation = "invitation.create"
+	AuditActionRevokeInvitation = "invitation.revoke"
+	AuditActionAcceptInvitation = "invitation.accept"
+
+	AuditActionClearLockout = "lockout.clear"
+)
+
+// AuditEventActorMaxLength is the max length an audit event's actor can be.
+const AuditEventActorMaxLength = 64
+
+// AuditEventActionMaxLength is the max length an audit event's action can be.
+const AuditEventActionMaxLength = 32
+
+// AuditEventTargetMaxLength is the max length an audit event's target can be.
+const AuditEventTargetMaxLength = 128
+
+// AuditEventIPAddressMaxLength is the max length an audit event's ip address can be.
+const AuditEventIPAddressMaxLength = 64
+
+// AuditEvent represents the audit event model.
+// The actor is the username (or client id) that performed the action, and the target is what it was performed on.
+type AuditEvent struct {
+	ID        uuid.UUID `firestore:"id"`
+	Actor     string    `firestore:"actor"`
+	Action    string    `firestore:"action"`
+	Target    string    `firestore:"target"`
+	IPAddress string    `firestore:"ip_address"`
+	Timestamp time.Time `firestore:"timestamp"`
+}
+
+// AuditEventFilter narrows down which audit events are fetched.
+// Empty fields match every event.
+type AuditEventFilter struct {
+	Actor  string
+	Action string
+	Target string
+
+	// Since and Until limit the events to the range [Since, Until).
+	Since time.Time
+	Until time.Time
+
+	Limit  int
+	Offset int
+}
+
+type AuditCRUD interface {
+	// SaveAuditEvent saves the audit event and returns any errors.
+	SaveAuditEvent(event *AuditEvent) error
+
+	// GetAuditEvents fetches the audit events that match the filter, ordered from newest to oldest.
+	// Returns the audit events and any errors.
+	GetAuditEvents(filter AuditEventFilter) ([]*AuditEvent, error)
+}
+
+// CreateAuditEvent creates a new audit event model with the provided fields.
+func CreateAuditEvent(id uuid.UUID, actor string, action string, target string, ipAddress string, timestamp time.Time) *AuditEvent {
+	return &AuditEvent{
+		ID:        id,
+		Actor:     actor,
+		Action:    action,
+		Target:    target,
+		IPAddress: ipAddress,
+		Timestamp: timestamp,
+	}
+}
+
+// CreateNewAuditEvent creates a new audit event model with a new id and the current time.
+// The actor, target, and ip address come from requests, so they are truncated to their max lengths to ensure the event can always be saved.
+func CreateNewAuditEvent(actor string, action string, target string, ipAddress string) *AuditEvent {
+	timestamp := time.Now().UTC().Truncate(time.Microsecond)
+	return CreateAuditEvent(
+		uuid.New(),
+		truncate(actor, AuditEventActorMaxLength),
+		action,
+		truncate(target, AuditEventTargetMaxLength),
+		truncate(ipAddress, AuditEventIPAddressMaxLength),
+		timestamp,
+	)
+}
+
+func truncate(s string, maxLength int) string {
+	if len(s) > maxLength {
+		return s[:maxLength]
+	}
+	return s
+}
+
+// Validate validates the audit event model has valid fields.
+// Returns an int indicating which fields are invalid.
+func (e *AuditEvent) Validate() int {
+	code := ValidateAuditEventValid
+
+	if e.ID == uuid.Nil {
+		code |= ValidateAuditEventNilID
+	}
+
+	if len(e.Actor) > AuditEventActorMaxLength {
+		code |= ValidateAuditEventActorTooLong
+	}
+
+	if e.Action == "" {
+		code |= ValidateAuditEventEmptyAction
+	} else if len(e.Action) > AuditEventActionMaxLength {
+		code |= ValidateAuditEventActionTooLong
+	}
+
+	if len(e.Target) > AuditEventTargetMaxLength {
+		code |= ValidateAuditEventTargetTooLong
+	}
+
+	if len(e.IPAddress) > AuditEventIPAddressMaxLength {
+		code |= ValidateAuditEventIPAddressTooLong
+	}
+
+	return code
+}
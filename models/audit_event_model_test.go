@@ -0,0 +1,149 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditEventTestSuite struct {
+	helpers.CustomSuite
+	AuditEvent *models.AuditEvent
+}
+
+func (suite *AuditEventTestSuite) SetupTest() {
+	suite.AuditEvent = models.CreateNewAuditEvent("username", models.AuditActionCreateUser, "target", "127.0.0.1")
+}
+
+func (suite *AuditEventTestSuite) TestCreateNewAuditEvent_CreatesAuditEventWithSuppliedFields() {
+	//arrange
+	actor := "username"
+	action := models.AuditActionDeleteClient
+	target := uuid.New().String()
+	ipAddress := "127.0.0.1"
+
+	//act
+	event := models.CreateNewAuditEvent(actor, action, target, ipAddress)
+
+	//assert
+	suite.Require().NotNil(event)
+	suite.NotEqual(uuid.Nil, event.ID)
+	suite.Equal(actor, event.Actor)
+	suite.Equal(action, event.Action)
+	suite.Equal(target, event.Target)
+	suite.Equal(ipAddress, event.IPAddress)
+	suite.WithinDuration(time.Now(), event.Timestamp, time.Second)
+}
+
+func (suite *AuditEventTestSuite) TestCreateNewAuditEvent_TruncatesFieldsLongerThanMaxLength() {
+	//act
+	event := models.CreateNewAuditEvent(
+		helpers.CreateStringOfLength(models.AuditEventActorMaxLength+1),
+		models.AuditActionLoginFailed,
+		helpers.CreateStringOfLength(models.AuditEventTargetMaxLength+1),
+		helpers.CreateStringOfLength(models.AuditEventIPAddressMaxLength+1),
+	)
+
+	//assert
+	suite.Require().NotNil(event)
+	suite.Len(event.Actor, models.AuditEventActorMaxLength)
+	suite.Len(event.Target, models.AuditEventTargetMaxLength)
+	suite.Len(event.IPAddress, models.AuditEventIPAddressMaxLength)
+	suite.Equal(models.ValidateAuditEventValid, event.Validate())
+}
+
+func (suite *AuditEventTestSuite) TestValidate_WithValidAuditEvent_ReturnsValid() {
+	//act
+	verr := suite.AuditEvent.Validate()
+
+	//assert
+	suite.Equal(models.ValidateAuditEventValid, verr)
+}
+
+func (suite *AuditEventTestSuite) TestValidate_WithEmptyActorAndTarget_ReturnsValid() {
+	//arrange
+	suite.AuditEvent.Actor = ""
+	suite.AuditEvent.Target = ""
+	suite.AuditEvent.IPAddress = ""
+
+	//act
+	verr := suite.AuditEvent.Validate()
+
+	//assert
+	suite.Equal(models.ValidateAuditEventValid, verr)
+}
+
+func (suite *AuditEventTestSuite) TestValidate_WithNilID_ReturnsAuditEventNilID() {
+	//arrange
+	suite.AuditEvent.ID = uuid.Nil
+
+	//act
+	verr := suite.AuditEvent.Validate()
+
+	//assert
+	suite.Equal(models.ValidateAuditEventNilID, verr)
+}
+
+func (suite *AuditEventTestSuite) TestValidate_WithEmptyAction_ReturnsAuditEventEmptyAction() {
+	//arrange
+	suite.AuditEvent.Action = ""
+
+	//act
+	verr := suite.AuditEvent.Validate()
+
+	//assert
+	suite.Equal(models.ValidateAuditEventEmptyAction, verr)
+}
+
+func (suite *AuditEventTestSuite) TestValidate_MaxLengthTestCases() {
+	var field *string
+	var maxLength int
+	var expectedTooLongError int
+
+	testCase := func() {
+		//arrange
+		original := *field
+
+		//act
+		*field = helpers.CreateStringOfLength(maxLength)
+		exactVerr := suite.AuditEvent.Validate()
+
+		*field = helpers.CreateStringOfLength(maxLength + 1)
+		overVerr := suite.AuditEvent.Validate()
+
+		//assert
+		suite.Equal(models.ValidateAuditEventValid, exactVerr)
+		suite.Equal(expectedTooLongError, overVerr)
+
+		*field = original
+	}
+
+	field = &suite.AuditEvent.Actor
+	maxLength = models.AuditEventActorMaxLength
+	expectedTooLongError = models.ValidateAuditEventActorTooLong
+	suite.Run("Actor", testCase)
+
+	field = &suite.AuditEvent.Action
+	maxLength = models.AuditEventActionMaxLength
+	expectedTooLongError = models.ValidateAuditEventActionTooLong
+	suite.Run("Action", testCase)
+
+	field = &suite.AuditEvent.Target
+	maxLength = models.AuditEventTargetMaxLength
+	expectedTooLongError = models.ValidateAuditEventTargetTooLong
+	suite.Run("Target", testCase)
+
+	field = &suite.AuditEvent.IPAddress
+	maxLength = models.AuditEventIPAddressMaxLength
+	expectedTooLongError = models.ValidateAuditEventIPAddressTooLong
+	suite.Run("IPAddress", testCase)
+}
+
+func TestAuditEventTestSuite(t *testing.T) {
+	suite.Run(t, &AuditEventTestSuite{})
+}
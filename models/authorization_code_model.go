@@ -0,0 +1,108 @@
+package models
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ValidateAuthorizationCodeValid                = 0x0
+	ValidateAuthorizationCodeNilCode              = 0x1
+	ValidateAuthorizationCodeEmptyCodeChallenge   = 0x2
+	ValidateAuthorizationCodeCodeChallengeTooLong = 0x4
+	ValidateAuthorizationCodeNonceTooLong         = 0x8
+)
+
+// AuthorizationCodeCodeChallengeMaxLength is the max length an authorization code's code challenge can be.
+const AuthorizationCodeCodeChallengeMaxLength = 128
+
+// AuthorizationCodeNonceMaxLength is the max length an authorization code's nonce can be.
+const AuthorizationCodeNonceMaxLength = 255
+
+// AuthorizationCode represents the authorization code model.
+type AuthorizationCode struct {
+	Code          uuid.UUID `firestore:"code"`
+	ClientUID     uuid.UUID `firestore:"client_uid"`
+	Username      string    `firestore:"username"`
+	CodeChallenge string    `firestore:"code_challenge"`
+	Nonce         string    `firestore:"nonce"`
+	RedirectURI   string    `firestore:"redirect_uri"`
+	ExpiresAt     time.Time `firestore:"expires_at"`
+}
+
+type AuthorizationCodeCRUD interface {
+	// SaveAuthorizationCode saves the authorization code and returns any errors.
+	SaveAuthorizationCode(code *AuthorizationCode) error
+
+	// GetAuthorizationCode fetches the authorization code with the given code.
+	// If no authorization codes are found, returns nil authorization code.
+	// Also returns any errors.
+	GetAuthorizationCode(code uuid.UUID) (*AuthorizationCode, error)
+
+	// DeleteAuthorizationCode deletes the authorization code with the given code.
+	// Returns result of whether the authorization code was found, and any errors.
+	DeleteAuthorizationCode(code uuid.UUID) (bool, error)
+}
+
+// CreateAuthorizationCode creates a new authorization code model with the provided fields.
+// The redirect uri is the one sent with the authorization request, or empty if none was sent.
+func CreateAuthorizationCode(code uuid.UUID, clientUID uuid.UUID, username string, codeChallenge string, nonce string, redirectURI string, expiresAt time.Time) *AuthorizationCode {
+	return &AuthorizationCode{
+		Code:          code,
+		ClientUID:     clientUID,
+		Username:      username,
+		CodeChallenge: codeChallenge,
+		Nonce:         nonce,
+		RedirectURI:   redirectURI,
+		ExpiresAt:     expiresAt,
+	}
+}
+
+// CreateNewAuthorizationCode generates a new code then creates a new authorization code model with the code and provided fields.
+// The code will expire after the provided lifetime.
+func CreateNewAuthorizationCode(clientUID uuid.UUID, username string, codeChallenge string, nonce string, redirectURI string, lifetime time.Duration) *AuthorizationCode {
+	expiresAt := time.Now().UTC().Truncate(time.Microsecond).Add(lifetime)
+	return CreateAuthorizationCode(uuid.New(), clientUID, username, codeChallenge, nonce, redirectURI, expiresAt)
+}
+
+// Validate validates the authorization code model has valid fields.
+// Returns an int indicating which fields are invalid.
+func (ac *AuthorizationCode) Validate() int {
+	code := ValidateAuthorizationCodeValid
+
+	//validate code
+	if ac.Code == uuid.Nil {
+		code |= ValidateAuthorizationCodeNilCode
+	}
+
+	//validate code challenge
+	if ac.CodeChallenge == "" {
+		code |= ValidateAuthorizationCodeEmptyCodeChallenge
+	} else if len(ac.CodeChallenge) > AuthorizationCodeCodeChallengeMaxLength {
+		code |= ValidateAuthorizationCodeCodeChallengeTooLong
+	}
+
+	//validate nonce
+	if len(ac.Nonce) > AuthorizationCodeNonceMaxLength {
+		code |= ValidateAuthorizationCodeNonceTooLong
+	}
+
+	return code
+}
+
+// IsExpired checks if the authorization code has expired relative to now.
+func (ac *AuthorizationCode) IsExpired(now time.Time) bool {
+	return now.After(ac.ExpiresAt)
+}
+
+// VerifyCodeVerifier checks the PKCE code verifier matches the code challenge using the S256 method.
+func (ac *AuthorizationCode) VerifyCodeVerifier(verifier string) bool {
+	hash := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(hash[:])
+
+	return subtle.ConstantTimeCompare([]byte(challenge), []byte(ac.CodeChallenge)) == 1
+}
@@ -0,0 +1,170 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthorizationCodeTestSuite struct {
+	helpers.CustomSuite
+	AuthorizationCode *models.AuthorizationCode
+}
+
+func (suite *AuthorizationCodeTestSuite) SetupTest() {
+	suite.AuthorizationCode = models.CreateNewAuthorizationCode(uuid.New(), "username", "challenge", "nonce", "https://mhogar.dev", time.Minute)
+}
+
+func (suite *AuthorizationCodeTestSuite) TestCreateNewAuthorizationCode_CreatesAuthorizationCodeWithSuppliedFields() {
+	//arrange
+	clientUID := uuid.New()
+	username := "username"
+	challenge := "challenge"
+	nonce := "nonce"
+	redirectURI := "https://mhogar.dev"
+
+	//act
+	code := models.CreateNewAuthorizationCode(clientUID, username, challenge, nonce, redirectURI, time.Minute)
+
+	//assert
+	suite.Require().NotNil(code)
+	suite.NotEqual(uuid.Nil, code.Code)
+	suite.Equal(clientUID, code.ClientUID)
+	suite.Equal(username, code.Username)
+	suite.Equal(challenge, code.CodeChallenge)
+	suite.Equal(nonce, code.Nonce)
+	suite.Equal(redirectURI, code.RedirectURI)
+	suite.WithinDuration(time.Now().Add(time.Minute), code.ExpiresAt, time.Second)
+}
+
+func (suite *AuthorizationCodeTestSuite) TestValidate_WithValidAuthorizationCode_ReturnsValid() {
+	//act
+	verr := suite.AuthorizationCode.Validate()
+
+	//assert
+	suite.Equal(models.ValidateAuthorizationCodeValid, verr)
+}
+
+func (suite *AuthorizationCodeTestSuite) TestValidate_WithNilCode_ReturnsAuthorizationCodeNilCode() {
+	//arrange
+	suite.AuthorizationCode.Code = uuid.Nil
+
+	//act
+	verr := suite.AuthorizationCode.Validate()
+
+	//assert
+	suite.Equal(models.ValidateAuthorizationCodeNilCode, verr)
+}
+
+func (suite *AuthorizationCodeTestSuite) TestValidate_WithEmptyCodeChallenge_ReturnsAuthorizationCodeEmptyCodeChallenge() {
+	//arrange
+	suite.AuthorizationCode.CodeChallenge = ""
+
+	//act
+	verr := suite.AuthorizationCode.Validate()
+
+	//assert
+	suite.Equal(models.ValidateAuthorizationCodeEmptyCodeChallenge, verr)
+}
+
+func (suite *AuthorizationCodeTestSuite) TestValidate_AuthorizationCodeCodeChallengeMaxLengthTestCases() {
+	var challenge string
+	var expectedValidateError int
+
+	testCase := func() {
+		//arrange
+		suite.AuthorizationCode.CodeChallenge = challenge
+
+		//act
+		verr := suite.AuthorizationCode.Validate()
+
+		//assert
+		suite.Equal(expectedValidateError, verr)
+	}
+
+	challenge = helpers.CreateStringOfLength(models.AuthorizationCodeCodeChallengeMaxLength)
+	expectedValidateError = models.ValidateAuthorizationCodeValid
+	suite.Run("ExactlyMaxLengthIsValid", testCase)
+
+	challenge += "a"
+	expectedValidateError = models.ValidateAuthorizationCodeCodeChallengeTooLong
+	suite.Run("OneMoreThanMaxLengthIsInvalid", testCase)
+}
+
+func (suite *AuthorizationCodeTestSuite) TestValidate_AuthorizationCodeNonceMaxLengthTestCases() {
+	var nonce string
+	var expectedValidateError int
+
+	testCase := func() {
+		//arrange
+		suite.AuthorizationCode.Nonce = nonce
+
+		//act
+		verr := suite.AuthorizationCode.Validate()
+
+		//assert
+		suite.Equal(expectedValidateError, verr)
+	}
+
+	nonce = helpers.CreateStringOfLength(models.AuthorizationCodeNonceMaxLength)
+	expectedValidateError = models.ValidateAuthorizationCodeValid
+	suite.Run("ExactlyMaxLengthIsValid", testCase)
+
+	nonce += "a"
+	expectedValidateError = models.ValidateAuthorizationCodeNonceTooLong
+	suite.Run("OneMoreThanMaxLengthIsInvalid", testCase)
+}
+
+func (suite *AuthorizationCodeTestSuite) TestIsExpired_ExpiryTestCases() {
+	var now time.Time
+	var expected bool
+
+	testCase := func() {
+		//act
+		result := suite.AuthorizationCode.IsExpired(now)
+
+		//assert
+		suite.Equal(expected, result)
+	}
+
+	now = suite.AuthorizationCode.ExpiresAt.Add(-time.Second)
+	expected = false
+	suite.Run("BeforeExpiryIsNotExpired", testCase)
+
+	now = suite.AuthorizationCode.ExpiresAt.Add(time.Second)
+	expected = true
+	suite.Run("AfterExpiryIsExpired", testCase)
+}
+
+func (suite *AuthorizationCodeTestSuite) TestVerifyCodeVerifier_VerifierTestCases() {
+	var verifier string
+	var expected bool
+
+	testCase := func() {
+		//act
+		result := suite.AuthorizationCode.VerifyCodeVerifier(verifier)
+
+		//assert
+		suite.Equal(expected, result)
+	}
+
+	//base64url encoded SHA256 hash of the verifier
+	suite.AuthorizationCode.CodeChallenge = "f4GlO33Knmhaikoyah1U7W_9AIdhM7QWrRd_ACqg6Yo"
+
+	verifier = "abcdefghijklmnopqrstuvwxyz-0123456789-ABCDEFGHIJ"
+	expected = true
+	suite.Run("MatchingVerifierIsValid", testCase)
+
+	verifier = "invalid"
+	expected = false
+	suite.Run("NonMatchingVerifierIsInvalid", testCase)
+}
+
+func TestAuthorizationCodeTestSuite(t *testing.T) {
+	suite.Run(t, &AuthorizationCodeTestSuite{})
+}
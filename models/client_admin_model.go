@@ -0,0 +1,75 @@
+package models
+
+import "github.com/google/uuid"
+
+const (
+	ValidateClientAdminValid         = 0x0
+	ValidateClientAdminEmptyUsername = 0x1
+)
+
+// ClientAdmin represents the client admin model.
+// Client admins are users delegated to administer a single client. Owners can also delegate the client to other users.
+type ClientAdmin struct {
+	ClientUID uuid.UUID `firestore:"client_uid"`
+	Username  string    `firestore:"username"`
+	IsOwner   bool      `firestore:"is_owner"`
+}
+
+type ClientAdminCRUD interface {
+	// CreateClientAdmin creates the client admin. Returns any errors.
+	CreateClientAdmin(admin *ClientAdmin) error
+
+	// GetClientAdminsByClientUID fetches all the client admins for the provided client uid, sorted by username.
+	// Returns the client admins and any errors.
+	GetClientAdminsByClientUID(clientUID uuid.UUID) ([]*ClientAdmin, error)
+
+	// GetClientAdminsByUsername fetches all the clients the user with the given username is an admin of.
+	// Returns the client admins and any errors.
+	GetClientAdminsByUsername(username string) ([]*ClientAdmin, error)
+
+	// GetClientAdminByClientUIDAndUsername fetches the client admin for the provided client uid and username.
+	// Returns the client admin if it exists, nil if not. Also returns any errors.
+	GetClientAdminByClientUIDAndUsername(clientUID uuid.UUID, username string) (*ClientAdmin, error)
+
+	// DeleteClientAdmin deletes the client admin with the given client uid and username.
+	// Returns result of whether the client admin was found, and any errors.
+	DeleteClientAdmin(clientUID uuid.UUID, username string) (bool, error)
+}
+
+// CreateClientAdmin creates a new client admin model with the provided fields.
+func CreateClientAdmin(clientUID uuid.UUID, username string, isOwner bool) *ClientAdmin {
+	return &ClientAdmin{
+		ClientUID: clientUID,
+		Username:  username,
+		IsOwner:   isOwner,
+	}
+}
+
+// Validate validates the client admin model has valid fields.
+// Returns an int indicating which fields are invalid.
+func (ca *ClientAdmin) Validate() int {
+	code := ValidateClientAdminValid
+
+	//validate username
+	if ca.Username == "" {
+		code |= ValidateClientAdminEmptyUsername
+	}
+
+	return code
+}
+
+// Permissions returns the permissions the client admin has, scoped to their client.
+// Client admins can manage the client and its user-roles, and owners can also manage its client admins.
+func (ca *ClientAdmin) Permissions() []string {
+	permissions := []string{
+		ScopePermission(PermissionClientsWrite, ca.ClientUID),
+		ScopePermission(PermissionRolesRead, ca.ClientUID),
+		ScopePermission(PermissionRolesWrite, ca.ClientUID),
+		ScopePermission(PermissionClientAdminsRead, ca.ClientUID),
+	}
+
+	if ca.IsOwner {
+		permissions = append(permissions, ScopePermission(PermissionClientAdminsWrite, ca.ClientUID))
+	}
+	return permissions
+}
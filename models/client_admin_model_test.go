@@ -0,0 +1,84 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type ClientAdminTestSuite struct {
+	helpers.CustomSuite
+	ClientAdmin *models.ClientAdmin
+}
+
+func (suite *ClientAdminTestSuite) SetupTest() {
+	suite.ClientAdmin = models.CreateClientAdmin(uuid.New(), "username", false)
+}
+
+func (suite *ClientAdminTestSuite) TestCreateClientAdmin_CreatesClientAdminWithSuppliedFields() {
+	//arrange
+	clientUID := uuid.New()
+	username := "username"
+
+	//act
+	admin := models.CreateClientAdmin(clientUID, username, true)
+
+	//assert
+	suite.Require().NotNil(admin)
+	suite.Equal(clientUID, admin.ClientUID)
+	suite.Equal(username, admin.Username)
+	suite.True(admin.IsOwner)
+}
+
+func (suite *ClientAdminTestSuite) TestValidate_WithValidClientAdmin_ReturnsValid() {
+	//act
+	verr := suite.ClientAdmin.Validate()
+
+	//assert
+	suite.Equal(models.ValidateClientAdminValid, verr)
+}
+
+func (suite *ClientAdminTestSuite) TestValidate_WithEmptyUsername_ReturnsClientAdminEmptyUsername() {
+	//arrange
+	suite.ClientAdmin.Username = ""
+
+	//act
+	verr := suite.ClientAdmin.Validate()
+
+	//assert
+	suite.Equal(models.ValidateClientAdminEmptyUsername, verr)
+}
+
+func (suite *ClientAdminTestSuite) TestPermissions_ReturnsPermissionsScopedToClient() {
+	//arrange
+	clientUID := suite.ClientAdmin.ClientUID
+
+	//act
+	permissions := suite.ClientAdmin.Permissions()
+
+	//assert
+	suite.Contains(permissions, models.ScopePermission(models.PermissionClientsWrite, clientUID))
+	suite.Contains(permissions, models.ScopePermission(models.PermissionRolesWrite, clientUID))
+	suite.Contains(permissions, models.ScopePermission(models.PermissionClientAdminsRead, clientUID))
+	suite.NotContains(permissions, models.ScopePermission(models.PermissionClientAdminsWrite, clientUID))
+	suite.NotContains(permissions, models.PermissionRolesWrite)
+}
+
+func (suite *ClientAdminTestSuite) TestPermissions_WithOwner_AlsoReturnsClientAdminsWritePermission() {
+	//arrange
+	suite.ClientAdmin.IsOwner = true
+
+	//act
+	permissions := suite.ClientAdmin.Permissions()
+
+	//assert
+	suite.Contains(permissions, models.ScopePermission(models.PermissionClientAdminsWrite, suite.ClientAdmin.ClientUID))
+}
+
+func TestClientAdminTestSuite(t *testing.T) {
+	suite.Run(t, &ClientAdminTestSuite{})
+}
@@ -40,15 +40,20 @@ type Client struct {
 	RedirectUrl string    `firestore:"redirect_url"`
 	TokenType   int       `firestore:"token_type"`
 	KeyUri      string    `firestore:"key_uri"`
+	SecretHash  []byte    `firestore:"secret_hash"`
 }
 
 type ClientCRUD interface {
 	// CreateClient creates a new client and returns any errors.
 	CreateClient(client *Client) error
 
-	// GetClients fetches all the clients.
+	// GetClients fetches the page of clients, searching and sorting by name.
 	// Returns the clients and any errors.
-	GetClients() ([]*Client, error)
+	GetClients(query PageQuery) ([]*Client, error)
+
+	// CountClients counts the clients whose name starts with the search.
+	// Returns the count and any errors.
+	CountClients(search string) (int, error)
 
 	// GetClientByUID fetches the client associated with the uid.
 	// If no clients are found, returns nil client. Also returns any errors.
@@ -58,6 +63,10 @@ type ClientCRUD interface {
 	// Returns result of whether the client was found, and any errors.
 	UpdateClient(client *Client) (bool, error)
 
+	// UpdateClientSecretHash updates the secret hash of the client with the given uid.
+	// Returns result of whether the client was found, and any errors.
+	UpdateClientSecretHash(uid uuid.UUID, secretHash []byte) (bool, error)
+
 	// DeleteClient deletes the client the with the given uid.
 	// Returns result of whether the client was found, and any errors.
 	DeleteClient(uid uuid.UUID) (bool, error)
@@ -122,3 +131,13 @@ func (c *Client) Validate() int {
 
 	return code
 }
+
+// HasSecret returns whether a secret has been generated for the client.
+func (c *Client) HasSecret() bool {
+	return len(c.SecretHash) > 0
+}
+
+// GetCursor returns the client's cursor in a list sorted by name.
+func (c *Client) GetCursor() Cursor {
+	return Cursor{Value: c.Name, Key: c.UID.String()}
+}
@@ -192,6 +192,21 @@ func (suite *ClientTestSuite) TestValidate_KeyUriMaxLengthTestCases() {
 	suite.Run("OneMoreThanMaxLengthIsInvalid", testCase)
 }
 
+func (suite *ClientTestSuite) TestHasSecret_ReturnsWhetherClientHasSecretHash() {
+	suite.False(suite.Client.HasSecret())
+
+	suite.Client.SecretHash = []byte("hash")
+	suite.True(suite.Client.HasSecret())
+}
+
+func (suite *ClientTestSuite) TestGetCursor_ReturnsNameAndUIDCursor() {
+	//act
+	cursor := suite.Client.GetCursor()
+
+	//assert
+	suite.Equal(models.Cursor{Value: suite.Client.Name, Key: suite.Client.UID.String()}, cursor)
+}
+
 func TestClientTestSuite(t *testing.T) {
 	suite.Run(t, &ClientTestSuite{})
 }
@@ -0,0 +1,99 @@
+package models
+
+const (
+	ValidateGroupValid              = 0x0
+	ValidateGroupEmptyName          = 0x1
+	ValidateGroupNameTooLong        = 0x2
+	ValidateGroupDescriptionTooLong = 0x4
+)
+
+// GroupNameMaxLength is the max length a group's name can be.
+const GroupNameMaxLength = 30
+
+// GroupDescriptionMaxLength is the max length a group's description can be.
+const GroupDescriptionMaxLength = 255
+
+// Group represents the group model.
+// Clients can give roles to a group, which every member of the group then has.
+// When a user's groups give them different roles for the same client, the roles of the group with the highest priority are used.
+type Group struct {
+	Name        string `firestore:"name"`
+	Description string `firestore:"description"`
+	Priority    int    `firestore:"priority"`
+}
+
+type GroupCRUD interface {
+	// CreateGroup creates the group. Returns any errors.
+	CreateGroup(group *Group) error
+
+	// GetGroups fetches all the groups, sorted by name.
+	// Returns the groups and any errors.
+	GetGroups() ([]*Group, error)
+
+	// GetGroupByName fetches the group with the given name.
+	// Returns the group if it exists, nil if not. Also returns any errors.
+	GetGroupByName(name string) (*Group, error)
+
+	// UpdateGroup updates the description and priority of the group.
+	// Returns result of whether the group was found and any errors.
+	UpdateGroup(group *Group) (bool, error)
+
+	// DeleteGroup deletes the group with the given name, along with its members and the roles clients gave it.
+	// Returns result of whether the group was found, and any errors.
+	DeleteGroup(name string) (bool, error)
+
+	// GetGroupsByUsername fetches the groups the user with the given username is a member of, sorted by name.
+	// Returns the groups and any errors.
+	GetGroupsByUsername(username string) ([]*Group, error)
+
+	// GetGroupMemberUsernames fetches the usernames of the members of the group with the given name, sorted by username.
+	// Returns the usernames and any errors.
+	GetGroupMemberUsernames(name string) ([]string, error)
+
+	// CreateGroupMember adds the user with the given username to the group with the given name.
+	// Returns any errors.
+	CreateGroupMember(name string, username string) error
+
+	// DeleteGroupMember removes the user with the given username from the group with the given name.
+	// Returns result of whether the user was a member of the group, and any errors.
+	DeleteGroupMember(name string, username string) (bool, error)
+}
+
+// CreateGroup creates a new group model with the provided fields.
+func CreateGroup(name string, description string, priority int) *Group {
+	return &Group{
+		Name:        name,
+		Description: description,
+		Priority:    priority,
+	}
+}
+
+// Validate validates the group model has valid fields.
+// Returns an int indicating which fields are invalid.
+func (g *Group) Validate() int {
+	code := ValidateGroupValid
+
+	//validate name
+	if g.Name == "" {
+		code |= ValidateGroupEmptyName
+	} else if len(g.Name) > GroupNameMaxLength {
+		code |= ValidateGroupNameTooLong
+	}
+
+	//validate description
+	if len(g.Description) > GroupDescriptionMaxLength {
+		code |= ValidateGroupDescriptionTooLong
+	}
+
+	return code
+}
+
+// ContainsGroup returns whether any of the groups have the given name.
+func ContainsGroup(groups []*Group, name string) bool {
+	for _, group := range groups {
+		if group.Name == name {
+			return true
+		}
+	}
+	return false
+}
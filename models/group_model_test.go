@@ -0,0 +1,123 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type GroupTestSuite struct {
+	helpers.CustomSuite
+	Group *models.Group
+}
+
+func (suite *GroupTestSuite) SetupTest() {
+	suite.Group = models.CreateGroup("group", "description", 1)
+}
+
+func (suite *GroupTestSuite) TestCreateGroup_CreatesGroupWithSuppliedFields() {
+	//arrange
+	name := "name"
+	description := "this is a test description"
+	priority := 5
+
+	//act
+	group := models.CreateGroup(name, description, priority)
+
+	//assert
+	suite.Require().NotNil(group)
+	suite.Equal(name, group.Name)
+	suite.Equal(description, group.Description)
+	suite.Equal(priority, group.Priority)
+}
+
+func (suite *GroupTestSuite) TestValidate_WithValidGroup_ReturnsValid() {
+	//act
+	verr := suite.Group.Validate()
+
+	//assert
+	suite.Equal(models.ValidateGroupValid, verr)
+}
+
+func (suite *GroupTestSuite) TestValidate_WithEmptyName_ReturnsGroupEmptyName() {
+	//arrange
+	suite.Group.Name = ""
+
+	//act
+	verr := suite.Group.Validate()
+
+	//assert
+	suite.Equal(models.ValidateGroupEmptyName, verr)
+}
+
+func (suite *GroupTestSuite) TestValidate_NameMaxLengthTestCases() {
+	var name string
+	var expectedValidateError int
+
+	testCase := func() {
+		//arrange
+		suite.Group.Name = name
+
+		//act
+		verr := suite.Group.Validate()
+
+		//assert
+		suite.Equal(expectedValidateError, verr)
+	}
+
+	name = helpers.CreateStringOfLength(models.GroupNameMaxLength)
+	expectedValidateError = models.ValidateGroupValid
+	suite.Run("ExactlyMaxLengthIsValid", testCase)
+
+	name += "a"
+	expectedValidateError = models.ValidateGroupNameTooLong
+	suite.Run("OneMoreThanMaxLengthIsInvalid", testCase)
+}
+
+func (suite *GroupTestSuite) TestValidate_DescriptionMaxLengthTestCases() {
+	var description string
+	var expectedValidateError int
+
+	testCase := func() {
+		//arrange
+		suite.Group.Description = description
+
+		//act
+		verr := suite.Group.Validate()
+
+		//assert
+		suite.Equal(expectedValidateError, verr)
+	}
+
+	description = ""
+	expectedValidateError = models.ValidateGroupValid
+	suite.Run("EmptyIsValid", testCase)
+
+	description = helpers.CreateStringOfLength(models.GroupDescriptionMaxLength)
+	expectedValidateError = models.ValidateGroupValid
+	suite.Run("ExactlyMaxLengthIsValid", testCase)
+
+	description += "a"
+	expectedValidateError = models.ValidateGroupDescriptionTooLong
+	suite.Run("OneMoreThanMaxLengthIsInvalid", testCase)
+}
+
+func (suite *GroupTestSuite) TestContainsGroup_ReturnsWhetherAnyGroupHasTheName() {
+	//arrange
+	groups := []*models.Group{
+		models.CreateGroup("group1", "", 0),
+		models.CreateGroup("group2", "", 0),
+	}
+
+	//assert
+	suite.True(models.ContainsGroup(groups, "group2"))
+	suite.False(models.ContainsGroup(groups, "DNE"))
+	suite.False(models.ContainsGroup(nil, "group1"))
+}
+
+func TestGroupTestSuite(t *testing.T) {
+	suite.Run(t, &GroupTestSuite{})
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ValidateGroupRoleValid         = ValidateUserRoleValid
+	ValidateGroupRoleEmptyRole     = ValidateUserRoleEmptyRole
+	ValidateGroupRoleRoleTooLong   = ValidateUserRoleRoleTooLong
+	ValidateGroupRoleNoRoles       = ValidateUserRoleNoRoles
+	ValidateGroupRoleDuplicateRole = ValidateUserRoleDuplicateRole
+)
+
+// GroupRole represents the group-role model. A client can give a set of roles to every member of a group.
+type GroupRole struct {
+	ClientUID uuid.UUID `firestore:"client_uid"`
+	GroupName string    `firestore:"group_name"`
+	Roles     []string  `firestore:"roles"`
+}
+
+type GroupRoleCRUD interface {
+	// CreateGroupRole creates the group-role with all of its roles. Returns any errors.
+	CreateGroupRole(role *GroupRole) error
+
+	// GetGroupRolesByClientUID fetches all the group-roles for the provided client uid, sorted by group name.
+	// Returns the group-roles and any errors.
+	GetGroupRolesByClientUID(clientUID uuid.UUID) ([]*GroupRole, error)
+
+	// GetGroupRoleByClientUIDAndGroupName fetches the group-role for the provided client uid and group name.
+	// Returns the group-role if it exists, nil if not. Also returns any errors.
+	GetGroupRoleByClientUIDAndGroupName(clientUID uuid.UUID, groupName string) (*GroupRole, error)
+
+	// UpdateGroupRole replaces the roles of the group-role.
+	// Returns result of whether the group-role was found and any errors.
+	UpdateGroupRole(role *GroupRole) (bool, error)
+
+	// CountGroupRolesByClientUIDAndRole counts the group-roles for the provided client uid that include the given role.
+	// Returns the count and any errors.
+	CountGroupRolesByClientUIDAndRole(clientUID uuid.UUID, role string) (int, error)
+
+	// RenameGroupRoles changes the role to the new role in every group-role for the provided client uid that includes it.
+	// Returns any errors.
+	RenameGroupRoles(clientUID uuid.UUID, role string, newRole string) error
+
+	// DeleteGroupRole deletes the group-role, and all of its roles, with the given client uid and group name.
+	// Returns result of whether the group-role was found, and any errors.
+	DeleteGroupRole(clientUID uuid.UUID, groupName string) (bool, error)
+}
+
+// CreateGroupRole creates a new group-role model with the provided fields. The roles are kept in sorted order.
+func CreateGroupRole(clientUID uuid.UUID, groupName string, roles ...string) *GroupRole {
+	role := &GroupRole{
+		ClientUID: clientUID,
+		GroupName: groupName,
+		Roles:     append([]string{}, roles...),
+	}
+	sort.Strings(role.Roles)
+
+	return role
+}
+
+// HasRole returns whether the group-role includes the given role.
+func (gr *GroupRole) HasRole(role string) bool {
+	for _, r := range gr.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate validates the group-role model has valid fields.
+// Returns an int indicating which fields are invalid.
+func (gr *GroupRole) Validate() int {
+	return validateRoles(gr.Roles)
+}
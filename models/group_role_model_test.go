@@ -0,0 +1,112 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type GroupRoleTestSuite struct {
+	helpers.CustomSuite
+	GroupRole *models.GroupRole
+}
+
+func (suite *GroupRoleTestSuite) SetupTest() {
+	suite.GroupRole = models.CreateGroupRole(uuid.Nil, "group", "role", "other")
+}
+
+func (suite *GroupRoleTestSuite) TestCreateGroupRole_CreatesGroupRoleWithSuppliedFields() {
+	//arrange
+	clientUID := uuid.New()
+	groupName := "this is a test group"
+	roles := []string{"role2", "role1"}
+
+	//act
+	groupRole := models.CreateGroupRole(clientUID, groupName, roles...)
+
+	//assert
+	suite.Require().NotNil(groupRole)
+	suite.Equal(clientUID, groupRole.ClientUID)
+	suite.Equal(groupName, groupRole.GroupName)
+	suite.Equal([]string{"role1", "role2"}, groupRole.Roles)
+	suite.Equal([]string{"role2", "role1"}, roles, "the provided roles should not be reordered")
+}
+
+func (suite *GroupRoleTestSuite) TestHasRole_ReturnsWhetherTheGroupRoleIncludesTheRole() {
+	suite.True(suite.GroupRole.HasRole("role"))
+	suite.True(suite.GroupRole.HasRole("other"))
+	suite.False(suite.GroupRole.HasRole("DNE"))
+}
+
+func (suite *GroupRoleTestSuite) TestValidate_WithValidGroupRole_ReturnsValid() {
+	//act
+	verr := suite.GroupRole.Validate()
+
+	//assert
+	suite.Equal(models.ValidateGroupRoleValid, verr)
+}
+
+func (suite *GroupRoleTestSuite) TestValidate_WithNoRoles_ReturnsGroupRoleNoRoles() {
+	//arrange
+	suite.GroupRole.Roles = nil
+
+	//act
+	verr := suite.GroupRole.Validate()
+
+	//assert
+	suite.Equal(models.ValidateGroupRoleNoRoles, verr)
+}
+
+func (suite *GroupRoleTestSuite) TestValidate_WithDuplicateRole_ReturnsGroupRoleDuplicateRole() {
+	//arrange
+	suite.GroupRole.Roles = []string{"role", "role"}
+
+	//act
+	verr := suite.GroupRole.Validate()
+
+	//assert
+	suite.Equal(models.ValidateGroupRoleDuplicateRole, verr)
+}
+
+func (suite *GroupRoleTestSuite) TestValidate_WithEmptyRole_ReturnsGroupRoleEmptyRole() {
+	//arrange
+	suite.GroupRole.Roles = []string{"role", ""}
+
+	//act
+	verr := suite.GroupRole.Validate()
+
+	//assert
+	suite.Equal(models.ValidateGroupRoleEmptyRole, verr)
+}
+
+func (suite *GroupRoleTestSuite) TestValidate_RoleMaxLengthTestCases() {
+	var role string
+	var expectedValidateError int
+
+	testCase := func() {
+		//arrange
+		suite.GroupRole.Roles = []string{"role", role}
+
+		//act
+		verr := suite.GroupRole.Validate()
+
+		//assert
+		suite.Equal(expectedValidateError, verr)
+	}
+
+	role = helpers.CreateStringOfLength(models.UserRoleRoleMaxLength)
+	expectedValidateError = models.ValidateGroupRoleValid
+	suite.Run("ExactlyMaxLengthIsValid", testCase)
+
+	role += "a"
+	expectedValidateError = models.ValidateGroupRoleRoleTooLong
+	suite.Run("OneMoreThanMaxLengthIsInvalid", testCase)
+}
+
+func TestGroupRoleTestSuite(t *testing.T) {
+	suite.Run(t, &GroupRoleTestSuite{})
+}
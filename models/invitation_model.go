@@ -0,0 +1,135 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ValidateInvitationValid               = 0x0
+	ValidateInvitationNilID               = 0x1
+	ValidateInvitationNilTokenHash        = 0x2
+	ValidateInvitationInvalidRank         = 0x4
+	ValidateInvitationEmailTooLong        = 0x8
+	ValidateInvitationInvalidEmail        = 0x10
+	ValidateInvitationEmptyRole           = 0x20
+	ValidateInvitationRoleTooLong         = 0x40
+	ValidateInvitationDuplicateRoleClient = 0x80
+)
+
+// Invitation represents the invitation model.
+// The invitee picks their own username and password when accepting it, and is given the rank and client roles.
+// Each invitation can be accepted once, so only the hash of its token is stored.
+type Invitation struct {
+	ID        uuid.UUID         `firestore:"id"`
+	TokenHash []byte            `firestore:"token_hash"`
+	Email     string            `firestore:"email"`
+	Rank      int               `firestore:"rank"`
+	Roles     []*InvitationRole `firestore:"roles"`
+	ExpiresAt time.Time         `firestore:"expires_at"`
+}
+
+// InvitationRole is a role the invitee is given for a client when they accept the invitation.
+type InvitationRole struct {
+	ClientUID uuid.UUID `firestore:"client_uid"`
+	Role      string    `firestore:"role"`
+}
+
+type InvitationCRUD interface {
+	// SaveInvitation saves the invitation and its roles, and returns any errors.
+	SaveInvitation(invitation *Invitation) error
+
+	// GetInvitationByID fetches the invitation with the given id.
+	// If no invitations are found, returns nil invitation. Also returns any errors.
+	GetInvitationByID(id uuid.UUID) (*Invitation, error)
+
+	// GetInvitationByTokenHash fetches the invitation with the given token hash.
+	// If no invitations are found, returns nil invitation. Also returns any errors.
+	GetInvitationByTokenHash(hash []byte) (*Invitation, error)
+
+	// RenameInvitationRoles changes the role of every invitation role for the provided client uid with the given role to the new role.
+	// Returns any errors.
+	RenameInvitationRoles(clientUID uuid.UUID, role string, newRole string) error
+
+	// DeleteInvitation deletes the invitation with the given id and its roles.
+	// Returns result of whether the invitation was found, and any errors.
+	DeleteInvitation(id uuid.UUID) (bool, error)
+}
+
+// CreateInvitation creates a new invitation model with the provided fields.
+func CreateInvitation(id uuid.UUID, hash []byte, email string, rank int, roles []*InvitationRole, expiresAt time.Time) *Invitation {
+	return &Invitation{
+		ID:        id,
+		TokenHash: hash,
+		Email:     email,
+		Rank:      rank,
+		Roles:     roles,
+		ExpiresAt: expiresAt,
+	}
+}
+
+// CreateNewInvitation creates a new invitation model with a new id and the provided fields.
+// The invitation will expire after the provided lifetime.
+func CreateNewInvitation(hash []byte, email string, rank int, roles []*InvitationRole, lifetime time.Duration) *Invitation {
+	expiresAt := time.Now().UTC().Truncate(time.Microsecond).Add(lifetime)
+	return CreateInvitation(uuid.New(), hash, email, rank, roles, expiresAt)
+}
+
+// CreateInvitationRole creates a new invitation role model with the provided fields.
+func CreateInvitationRole(clientUID uuid.UUID, role string) *InvitationRole {
+	return &InvitationRole{
+		ClientUID: clientUID,
+		Role:      role,
+	}
+}
+
+// Validate validates the invitation model has valid fields.
+// Returns an int indicating which fields are invalid.
+func (inv *Invitation) Validate() int {
+	code := ValidateInvitationValid
+
+	//validate id
+	if inv.ID == uuid.Nil {
+		code |= ValidateInvitationNilID
+	}
+
+	//validate token hash
+	if inv.TokenHash == nil {
+		code |= ValidateInvitationNilTokenHash
+	}
+
+	//validate rank
+	if inv.Rank < 0 {
+		code |= ValidateInvitationInvalidRank
+	}
+
+	//validate email (an empty email means the invitation is not sent to anyone)
+	if len(inv.Email) > UserEmailMaxLength {
+		code |= ValidateInvitationEmailTooLong
+	} else if inv.Email != "" && !isValidEmail(inv.Email) {
+		code |= ValidateInvitationInvalidEmail
+	}
+
+	//validate roles
+	clients := map[uuid.UUID]bool{}
+	for _, role := range inv.Roles {
+		if role.Role == "" {
+			code |= ValidateInvitationEmptyRole
+		} else if len(role.Role) > UserRoleRoleMaxLength {
+			code |= ValidateInvitationRoleTooLong
+		}
+
+		if clients[role.ClientUID] {
+			code |= ValidateInvitationDuplicateRoleClient
+		}
+		clients[role.ClientUID] = true
+	}
+
+	return code
+}
+
+// IsExpired checks if the invitation has expired relative to now.
+func (inv *Invitation) IsExpired(now time.Time) bool {
+	return now.After(inv.ExpiresAt)
+}
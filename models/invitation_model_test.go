@@ -0,0 +1,181 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type InvitationTestSuite struct {
+	helpers.CustomSuite
+	Invitation *models.Invitation
+}
+
+func (suite *InvitationTestSuite) SetupTest() {
+	roles := []*models.InvitationRole{
+		models.CreateInvitationRole(uuid.New(), "role"),
+	}
+	suite.Invitation = models.CreateNewInvitation([]byte("hash"), "user@example.com", 0, roles, time.Hour)
+}
+
+func (suite *InvitationTestSuite) TestCreateNewInvitation_CreatesInvitationWithSuppliedFields() {
+	//arrange
+	hash := []byte("hash")
+	email := "user@example.com"
+	rank := 1
+	roles := []*models.InvitationRole{
+		models.CreateInvitationRole(uuid.New(), "role"),
+	}
+
+	//act
+	invitation := models.CreateNewInvitation(hash, email, rank, roles, time.Hour)
+
+	//assert
+	suite.Require().NotNil(invitation)
+	suite.NotEqual(uuid.Nil, invitation.ID)
+	suite.Equal(hash, invitation.TokenHash)
+	suite.Equal(email, invitation.Email)
+	suite.Equal(rank, invitation.Rank)
+	suite.Equal(roles, invitation.Roles)
+	suite.WithinDuration(time.Now().Add(time.Hour), invitation.ExpiresAt, time.Second)
+}
+
+func (suite *InvitationTestSuite) TestValidate_WithValidInvitation_ReturnsValid() {
+	//act
+	verr := suite.Invitation.Validate()
+
+	//assert
+	suite.Equal(models.ValidateInvitationValid, verr)
+}
+
+func (suite *InvitationTestSuite) TestValidate_WithNilID_ReturnsInvitationNilID() {
+	//arrange
+	suite.Invitation.ID = uuid.Nil
+
+	//act
+	verr := suite.Invitation.Validate()
+
+	//assert
+	suite.Equal(models.ValidateInvitationNilID, verr)
+}
+
+func (suite *InvitationTestSuite) TestValidate_WithNilTokenHash_ReturnsInvitationNilTokenHash() {
+	//arrange
+	suite.Invitation.TokenHash = nil
+
+	//act
+	verr := suite.Invitation.Validate()
+
+	//assert
+	suite.Equal(models.ValidateInvitationNilTokenHash, verr)
+}
+
+func (suite *InvitationTestSuite) TestValidate_WithNegativeRank_ReturnsInvitationInvalidRank() {
+	//arrange
+	suite.Invitation.Rank = -1
+
+	//act
+	verr := suite.Invitation.Validate()
+
+	//assert
+	suite.Equal(models.ValidateInvitationInvalidRank, verr)
+}
+
+func (suite *InvitationTestSuite) TestValidate_EmailTestCases() {
+	var email string
+	var expected int
+
+	testCase := func() {
+		//arrange
+		suite.Invitation.Email = email
+
+		//act
+		verr := suite.Invitation.Validate()
+
+		//assert
+		suite.Equal(expected, verr)
+	}
+
+	email = ""
+	expected = models.ValidateInvitationValid
+	suite.Run("EmptyEmailIsValid", testCase)
+
+	email = "not an email"
+	expected = models.ValidateInvitationInvalidEmail
+	suite.Run("MalformedEmailIsInvalid", testCase)
+
+	email = strings.Repeat("a", models.UserEmailMaxLength) + "@example.com"
+	expected = models.ValidateInvitationEmailTooLong
+	suite.Run("EmailTooLongIsInvalid", testCase)
+}
+
+func (suite *InvitationTestSuite) TestValidate_RoleTestCases() {
+	var role string
+	var expected int
+
+	testCase := func() {
+		//arrange
+		suite.Invitation.Roles[0].Role = role
+
+		//act
+		verr := suite.Invitation.Validate()
+
+		//assert
+		suite.Equal(expected, verr)
+	}
+
+	role = ""
+	expected = models.ValidateInvitationEmptyRole
+	suite.Run("EmptyRoleIsInvalid", testCase)
+
+	role = strings.Repeat("a", models.UserRoleRoleMaxLength)
+	expected = models.ValidateInvitationValid
+	suite.Run("MaxLengthRoleIsValid", testCase)
+
+	role = strings.Repeat("a", models.UserRoleRoleMaxLength+1)
+	expected = models.ValidateInvitationRoleTooLong
+	suite.Run("RoleTooLongIsInvalid", testCase)
+}
+
+func (suite *InvitationTestSuite) TestValidate_WithDuplicateRoleClient_ReturnsInvitationDuplicateRoleClient() {
+	//arrange
+	role := suite.Invitation.Roles[0]
+	suite.Invitation.Roles = append(suite.Invitation.Roles, models.CreateInvitationRole(role.ClientUID, "other"))
+
+	//act
+	verr := suite.Invitation.Validate()
+
+	//assert
+	suite.Equal(models.ValidateInvitationDuplicateRoleClient, verr)
+}
+
+func (suite *InvitationTestSuite) TestIsExpired_ExpiryTestCases() {
+	var now time.Time
+	var expected bool
+
+	testCase := func() {
+		//act
+		result := suite.Invitation.IsExpired(now)
+
+		//assert
+		suite.Equal(expected, result)
+	}
+
+	now = suite.Invitation.ExpiresAt.Add(-time.Second)
+	expected = false
+	suite.Run("BeforeExpiryIsNotExpired", testCase)
+
+	now = suite.Invitation.ExpiresAt.Add(time.Second)
+	expected = true
+	suite.Run("AfterExpiryIsExpired", testCase)
+}
+
+func TestInvitationTestSuite(t *testing.T) {
+	suite.Run(t, &InvitationTestSuite{})
+}
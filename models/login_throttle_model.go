@@ -0,0 +1,120 @@
+package models
+
+import "time"
+
+const (
+	ValidateLoginThrottleValid       = 0x0
+	ValidateLoginThrottleInvalidType = 0x1
+	ValidateLoginThrottleEmptyKey    = 0x2
+	ValidateLoginThrottleKeyTooLong  = 0x4
+)
+
+const (
+	// LoginThrottleTypeUsername is the type of login throttles keyed by the username being logged in to.
+	LoginThrottleTypeUsername = "username"
+
+	// LoginThrottleTypeIP is the type of login throttles keyed by the ip address the logins come from.
+	LoginThrottleTypeIP = "ip"
+)
+
+// LoginThrottleKeyMaxLength is the max length a login throttle's key can be.
+const LoginThrottleKeyMaxLength = 64
+
+// LoginThrottle represents the login throttle model.
+// It counts the failed logins for a username or ip address, and is locked until LockedUntil once there are too many.
+type LoginThrottle struct {
+	Type           string    `firestore:"type"`
+	Key            string    `firestore:"key"`
+	FailedAttempts int       `firestore:"failed_attempts"`
+	LastFailedAt   time.Time `firestore:"last_failed_at"`
+	LockedUntil    time.Time `firestore:"locked_until"`
+}
+
+type LoginThrottleCRUD interface {
+	// SaveLoginThrottle saves the login throttle, replacing any existing one with the same type and key.
+	// Returns any errors.
+	SaveLoginThrottle(throttle *LoginThrottle) error
+
+	// GetLoginThrottle fetches the login throttle with the given type and key.
+	// If no login throttles are found, returns nil login throttle.
+	// Also returns any errors.
+	GetLoginThrottle(throttleType string, key string) (*LoginThrottle, error)
+
+	// GetLockedLoginThrottles fetches all the login throttles that are still locked at the given time, ordered by type then key.
+	// Returns the login throttles and any errors.
+	GetLockedLoginThrottles(now time.Time) ([]*LoginThrottle, error)
+
+	// DeleteLoginThrottle deletes the login throttle with the given type and key.
+	// Returns result of whether the login throttle was found, and any errors.
+	DeleteLoginThrottle(throttleType string, key string) (bool, error)
+}
+
+// CreateLoginThrottle creates a new login throttle model with the provided fields.
+func CreateLoginThrottle(throttleType string, key string, failedAttempts int, lastFailedAt time.Time, lockedUntil time.Time) *LoginThrottle {
+	return &LoginThrottle{
+		Type:           throttleType,
+		Key:            key,
+		FailedAttempts: failedAttempts,
+		LastFailedAt:   lastFailedAt,
+		LockedUntil:    lockedUntil,
+	}
+}
+
+// CreateNewLoginThrottle creates a new login throttle model with no failed attempts.
+func CreateNewLoginThrottle(throttleType string, key string) *LoginThrottle {
+	return CreateLoginThrottle(throttleType, key, 0, time.Time{}, time.Time{})
+}
+
+// Validate validates the login throttle model has valid fields.
+// Returns an int indicating which fields are invalid.
+func (lt *LoginThrottle) Validate() int {
+	code := ValidateLoginThrottleValid
+
+	//validate type
+	if lt.Type != LoginThrottleTypeUsername && lt.Type != LoginThrottleTypeIP {
+		code |= ValidateLoginThrottleInvalidType
+	}
+
+	//validate key
+	if lt.Key == "" {
+		code |= ValidateLoginThrottleEmptyKey
+	} else if len(lt.Key) > LoginThrottleKeyMaxLength {
+		code |= ValidateLoginThrottleKeyTooLong
+	}
+
+	return code
+}
+
+// IsLocked checks if the login throttle is locked relative to now.
+func (lt *LoginThrottle) IsLocked(now time.Time) bool {
+	return now.Before(lt.LockedUntil)
+}
+
+// RecordFailedAttempt adds a failed attempt at the given time.
+// The count starts over if there have been no failures within the resetAfter duration.
+// Once maxAttempts is reached the throttle is locked for the lockout duration, which doubles with each further failure up to maxLockout.
+// A maxLockout of zero means the lockout never grows.
+func (lt *LoginThrottle) RecordFailedAttempt(now time.Time, maxAttempts int, lockout time.Duration, maxLockout time.Duration, resetAfter time.Duration) {
+	//forget old failures
+	if now.Sub(lt.LastFailedAt) > resetAfter {
+		lt.FailedAttempts = 0
+	}
+
+	lt.FailedAttempts++
+	lt.LastFailedAt = now
+
+	if lt.FailedAttempts < maxAttempts {
+		return
+	}
+
+	//double the lockout for every attempt past the max, without going over the cap
+	duration := lockout
+	for i := maxAttempts; i < lt.FailedAttempts && duration > 0 && duration < maxLockout; i++ {
+		duration *= 2
+	}
+	if maxLockout > 0 && duration > maxLockout {
+		duration = maxLockout
+	}
+
+	lt.LockedUntil = now.Add(duration)
+}
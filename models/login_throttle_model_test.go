@@ -0,0 +1,185 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LoginThrottleTestSuite struct {
+	helpers.CustomSuite
+	LoginThrottle *models.LoginThrottle
+}
+
+func (suite *LoginThrottleTestSuite) SetupTest() {
+	suite.LoginThrottle = models.CreateNewLoginThrottle(models.LoginThrottleTypeUsername, "username")
+}
+
+func (suite *LoginThrottleTestSuite) TestCreateLoginThrottle_CreatesLoginThrottleWithSuppliedFields() {
+	//arrange
+	throttleType := models.LoginThrottleTypeIP
+	key := "127.0.0.1"
+	failedAttempts := 3
+	lastFailedAt := time.Now()
+	lockedUntil := lastFailedAt.Add(time.Minute)
+
+	//act
+	throttle := models.CreateLoginThrottle(throttleType, key, failedAttempts, lastFailedAt, lockedUntil)
+
+	//assert
+	suite.Require().NotNil(throttle)
+	suite.Equal(throttleType, throttle.Type)
+	suite.Equal(key, throttle.Key)
+	suite.Equal(failedAttempts, throttle.FailedAttempts)
+	suite.Equal(lastFailedAt, throttle.LastFailedAt)
+	suite.Equal(lockedUntil, throttle.LockedUntil)
+}
+
+func (suite *LoginThrottleTestSuite) TestCreateNewLoginThrottle_CreatesLoginThrottleWithNoFailedAttempts() {
+	//act
+	throttle := models.CreateNewLoginThrottle(models.LoginThrottleTypeIP, "127.0.0.1")
+
+	//assert
+	suite.Require().NotNil(throttle)
+	suite.Zero(throttle.FailedAttempts)
+	suite.False(throttle.IsLocked(time.Now()))
+}
+
+func (suite *LoginThrottleTestSuite) TestValidate_WithValidLoginThrottle_ReturnsValid() {
+	//act
+	verr := suite.LoginThrottle.Validate()
+
+	//assert
+	suite.Equal(models.ValidateLoginThrottleValid, verr)
+}
+
+func (suite *LoginThrottleTestSuite) TestValidate_WithInvalidType_ReturnsLoginThrottleInvalidType() {
+	//arrange
+	suite.LoginThrottle.Type = "invalid"
+
+	//act
+	verr := suite.LoginThrottle.Validate()
+
+	//assert
+	suite.Equal(models.ValidateLoginThrottleInvalidType, verr)
+}
+
+func (suite *LoginThrottleTestSuite) TestValidate_WithEmptyKey_ReturnsLoginThrottleEmptyKey() {
+	//arrange
+	suite.LoginThrottle.Key = ""
+
+	//act
+	verr := suite.LoginThrottle.Validate()
+
+	//assert
+	suite.Equal(models.ValidateLoginThrottleEmptyKey, verr)
+}
+
+func (suite *LoginThrottleTestSuite) TestValidate_KeyMaxLengthTestCases() {
+	var key string
+	var expectedValidateError int
+
+	testCase := func() {
+		//arrange
+		suite.LoginThrottle.Key = key
+
+		//act
+		verr := suite.LoginThrottle.Validate()
+
+		//assert
+		suite.Equal(expectedValidateError, verr)
+	}
+
+	key = helpers.CreateStringOfLength(models.LoginThrottleKeyMaxLength)
+	expectedValidateError = models.ValidateLoginThrottleValid
+	suite.Run("ExactlyMaxLengthIsValid", testCase)
+
+	key = helpers.CreateStringOfLength(models.LoginThrottleKeyMaxLength + 1)
+	expectedValidateError = models.ValidateLoginThrottleKeyTooLong
+	suite.Run("OneMoreThanMaxLengthIsInvalid", testCase)
+}
+
+func (suite *LoginThrottleTestSuite) TestIsLocked() {
+	now := time.Now()
+
+	suite.LoginThrottle.LockedUntil = now.Add(time.Second)
+	suite.True(suite.LoginThrottle.IsLocked(now))
+
+	suite.LoginThrottle.LockedUntil = now
+	suite.False(suite.LoginThrottle.IsLocked(now))
+}
+
+func (suite *LoginThrottleTestSuite) TestRecordFailedAttempt_BelowMaxAttempts_DoesNotLock() {
+	//arrange
+	now := time.Now()
+
+	//act
+	suite.LoginThrottle.RecordFailedAttempt(now, 3, time.Minute, time.Hour, time.Hour)
+	suite.LoginThrottle.RecordFailedAttempt(now, 3, time.Minute, time.Hour, time.Hour)
+
+	//assert
+	suite.Equal(2, suite.LoginThrottle.FailedAttempts)
+	suite.Equal(now, suite.LoginThrottle.LastFailedAt)
+	suite.False(suite.LoginThrottle.IsLocked(now))
+}
+
+func (suite *LoginThrottleTestSuite) TestRecordFailedAttempt_WhereLastFailureIsOlderThanResetAfter_StartsCountOver() {
+	//arrange
+	now := time.Now()
+	suite.LoginThrottle.FailedAttempts = 2
+	suite.LoginThrottle.LastFailedAt = now.Add(-time.Hour - time.Second)
+
+	//act
+	suite.LoginThrottle.RecordFailedAttempt(now, 3, time.Minute, time.Hour, time.Hour)
+
+	//assert
+	suite.Equal(1, suite.LoginThrottle.FailedAttempts)
+	suite.False(suite.LoginThrottle.IsLocked(now))
+}
+
+func (suite *LoginThrottleTestSuite) TestRecordFailedAttempt_LockoutDoublesWithEachFailurePastMaxUpToCap() {
+	var failedAttempts int
+	var expectedLockout time.Duration
+
+	testCase := func() {
+		//arrange
+		now := time.Now()
+		suite.LoginThrottle.FailedAttempts = failedAttempts - 1
+		suite.LoginThrottle.LastFailedAt = now
+
+		//act
+		suite.LoginThrottle.RecordFailedAttempt(now, 3, time.Minute, 5*time.Minute, time.Hour)
+
+		//assert
+		suite.Equal(failedAttempts, suite.LoginThrottle.FailedAttempts)
+		suite.Equal(now.Add(expectedLockout), suite.LoginThrottle.LockedUntil)
+	}
+
+	failedAttempts = 3
+	expectedLockout = time.Minute
+	suite.Run("ReachingMaxLocksForLockout", testCase)
+
+	failedAttempts = 4
+	expectedLockout = 2 * time.Minute
+	suite.Run("OnePastMaxDoublesLockout", testCase)
+
+	failedAttempts = 5
+	expectedLockout = 4 * time.Minute
+	suite.Run("TwoPastMaxQuadruplesLockout", testCase)
+
+	failedAttempts = 6
+	expectedLockout = 5 * time.Minute
+	suite.Run("LockoutIsCapped", testCase)
+
+	failedAttempts = 100
+	expectedLockout = 5 * time.Minute
+	suite.Run("LockoutStaysCapped", testCase)
+}
+
+func TestLoginThrottleTestSuite(t *testing.T) {
+	suite.Run(t, &LoginThrottleTestSuite{})
+}
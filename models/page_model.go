@@ -0,0 +1,130 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mhogar/amber/common"
+)
+
+const (
+	UserSortUsername = "username"
+	UserSortRank     = "rank"
+
+	ClientSortName = "name"
+
+	UserRoleSortUsername = "username"
+	UserRoleSortRole     = "role"
+)
+
+// PageQuery narrows down which page of a list is fetched.
+type PageQuery struct {
+	// Search limits the list to the items whose searched field (such as a user's username) starts with it, ignoring case.
+	// Empty matches every item.
+	Search string
+
+	// SortBy is the field the list is sorted by. The list's key is always used to break ties.
+	SortBy         string
+	SortDescending bool
+
+	// After is the cursor of the last item on the previous page. Nil fetches the first page.
+	After *Cursor
+
+	// Limit is the max number of items fetched. Zero fetches every item.
+	Limit int
+}
+
+// Cursor marks an item's position in a sorted list.
+// Value is the item's sorted field (empty when the list is sorted by its key) and Key is the item's unique key.
+type Cursor struct {
+	Value string `json:"v,omitempty"`
+	Key   string `json:"k"`
+}
+
+// Encode encodes the cursor into an opaque string that can be returned to clients.
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decodes a cursor previously encoded with Encode.
+// Returns the cursor and any errors.
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, common.ChainError("error decoding cursor string", err)
+	}
+
+	cursor := &Cursor{}
+	err = json.Unmarshal(data, cursor)
+	if err != nil {
+		return nil, common.ChainError("error unmarshaling cursor", err)
+	}
+
+	if cursor.Key == "" {
+		return nil, errors.New("cursor key cannot be empty")
+	}
+
+	return cursor, nil
+}
+
+// MatchesSearch returns whether the value starts with the search, ignoring case.
+func MatchesSearch(value string, search string) bool {
+	return strings.HasPrefix(strings.ToLower(value), strings.ToLower(search))
+}
+
+// SortPage sorts the items by their cursors according to the page query and returns the indexes of the items on the requested page.
+// It is used by data adapters that cannot sort and page lists in their queries, so the items should already match the query's search.
+// If numeric is true, the cursor values are compared as integers.
+func SortPage(cursors []Cursor, numeric bool, query PageQuery) []int {
+	compare := func(a Cursor, b Cursor) int {
+		if a.Value != b.Value {
+			if numeric {
+				x, _ := strconv.Atoi(a.Value)
+				y, _ := strconv.Atoi(b.Value)
+				return x - y
+			}
+			return strings.Compare(a.Value, b.Value)
+		}
+		return strings.Compare(a.Key, b.Key)
+	}
+
+	//order compares the cursors in the direction of the sort
+	order := func(a Cursor, b Cursor) int {
+		if query.SortDescending {
+			return compare(b, a)
+		}
+		return compare(a, b)
+	}
+
+	indexes := make([]int, 0, len(cursors))
+	for index, cursor := range cursors {
+		//skip items before or at the cursor
+		if query.After != nil && order(cursor, *query.After) <= 0 {
+			continue
+		}
+		indexes = append(indexes, index)
+	}
+
+	sort.Slice(indexes, func(i, j int) bool {
+		return order(cursors[indexes[i]], cursors[indexes[j]]) < 0
+	})
+
+	if query.Limit > 0 && len(indexes) > query.Limit {
+		indexes = indexes[:query.Limit]
+	}
+	return indexes
+}
+
+// PageInfo describes where a fetched page is in its list.
+type PageInfo struct {
+	// NextCursor is the cursor to fetch the next page with, or nil if there are no more pages.
+	NextCursor *Cursor
+
+	// Total is the number of items in the list across every page.
+	Total int
+}
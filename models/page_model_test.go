@@ -0,0 +1,114 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PageTestSuite struct {
+	helpers.CustomSuite
+}
+
+func (suite *PageTestSuite) TestDecodeCursor_WithEncodedCursor_ReturnsCursor() {
+	//arrange
+	cursor := models.Cursor{Value: "value", Key: "key"}
+
+	//act
+	result, err := models.DecodeCursor(cursor.Encode())
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Equal(cursor, *result)
+}
+
+func (suite *PageTestSuite) TestDecodeCursor_InvalidCursorTestCases() {
+	var s string
+	var expectedErrorSubStrings []string
+
+	testCase := func() {
+		//act
+		cursor, err := models.DecodeCursor(s)
+
+		//assert
+		suite.Nil(cursor)
+		suite.ContainsSubstrings(err.Error(), expectedErrorSubStrings...)
+	}
+
+	s = "not base64!"
+	expectedErrorSubStrings = []string{"error decoding"}
+	suite.Run("InvalidBase64", testCase)
+
+	s = "bm90IGpzb24"
+	expectedErrorSubStrings = []string{"error unmarshaling"}
+	suite.Run("InvalidJSON", testCase)
+
+	s = models.Cursor{Value: "value"}.Encode()
+	expectedErrorSubStrings = []string{"key", "empty"}
+	suite.Run("EmptyKey", testCase)
+}
+
+func (suite *PageTestSuite) TestMatchesSearch_ReturnsWhetherValueStartsWithSearchIgnoringCase() {
+	suite.True(models.MatchesSearch("Username", ""))
+	suite.True(models.MatchesSearch("Username", "user"))
+	suite.True(models.MatchesSearch("username", "USER"))
+	suite.False(models.MatchesSearch("username", "name"))
+}
+
+func (suite *PageTestSuite) TestSortPage_PageQueryTestCases() {
+	cursors := []models.Cursor{
+		{Value: "10", Key: "c"},
+		{Value: "9", Key: "a"},
+		{Value: "10", Key: "b"},
+		{Value: "2", Key: "d"},
+	}
+
+	var query models.PageQuery
+	var numeric bool
+	var expectedIndexes []int
+
+	testCase := func() {
+		//act
+		indexes := models.SortPage(cursors, numeric, query)
+
+		//assert
+		suite.Equal(expectedIndexes, indexes)
+	}
+
+	query = models.PageQuery{}
+	numeric = true
+	expectedIndexes = []int{3, 1, 2, 0}
+	suite.Run("Numeric", testCase)
+
+	query = models.PageQuery{}
+	numeric = false
+	expectedIndexes = []int{2, 0, 3, 1}
+	suite.Run("NotNumeric", testCase)
+
+	query = models.PageQuery{SortDescending: true}
+	numeric = true
+	expectedIndexes = []int{0, 2, 1, 3}
+	suite.Run("Descending", testCase)
+
+	query = models.PageQuery{Limit: 2}
+	numeric = true
+	expectedIndexes = []int{3, 1}
+	suite.Run("Limit", testCase)
+
+	query = models.PageQuery{After: &cursors[1], Limit: 2}
+	numeric = true
+	expectedIndexes = []int{2, 0}
+	suite.Run("After", testCase)
+
+	query = models.PageQuery{SortDescending: true, After: &cursors[0]}
+	numeric = true
+	expectedIndexes = []int{2, 1, 3}
+	suite.Run("DescendingAfter", testCase)
+}
+
+func TestPageTestSuite(t *testing.T) {
+	suite.Run(t, &PageTestSuite{})
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	ValidatePasswordResetTokenValid         = 0x0
+	ValidatePasswordResetTokenNilTokenHash  = 0x1
+	ValidatePasswordResetTokenEmptyUsername = 0x2
+)
+
+// PasswordResetToken represents the password reset token model.
+// Each token can be used once to reset the user's password, so only its hash is stored.
+type PasswordResetToken struct {
+	TokenHash []byte    `firestore:"token_hash"`
+	Username  string    `firestore:"username"`
+	ExpiresAt time.Time `firestore:"expires_at"`
+}
+
+type PasswordResetTokenCRUD interface {
+	// SavePasswordResetToken saves the password reset token and returns any errors.
+	SavePasswordResetToken(token *PasswordResetToken) error
+
+	// GetPasswordResetToken fetches the password reset token with the given hash.
+	// If no password reset tokens are found, returns nil password reset token.
+	// Also returns any errors.
+	GetPasswordResetToken(hash []byte) (*PasswordResetToken, error)
+
+	// DeletePasswordResetToken deletes the password reset token with the given hash.
+	// Returns result of whether the password reset token was found, and any errors.
+	DeletePasswordResetToken(hash []byte) (bool, error)
+
+	// DeleteAllUserPasswordResetTokens deletes all of the password reset tokens for the given username.
+	// Returns any errors.
+	DeleteAllUserPasswordResetTokens(username string) error
+}
+
+// CreatePasswordResetToken creates a new password reset token model with the provided fields.
+func CreatePasswordResetToken(hash []byte, username string, expiresAt time.Time) *PasswordResetToken {
+	return &PasswordResetToken{
+		TokenHash: hash,
+		Username:  username,
+		ExpiresAt: expiresAt,
+	}
+}
+
+// CreateNewPasswordResetToken creates a new password reset token model with the provided fields.
+// The token will expire after the provided lifetime.
+func CreateNewPasswordResetToken(hash []byte, username string, lifetime time.Duration) *PasswordResetToken {
+	expiresAt := time.Now().UTC().Truncate(time.Microsecond).Add(lifetime)
+	return CreatePasswordResetToken(hash, username, expiresAt)
+}
+
+// Validate validates the password reset token model has valid fields.
+// Returns an int indicating which fields are invalid.
+func (prt *PasswordResetToken) Validate() int {
+	code := ValidatePasswordResetTokenValid
+
+	//validate token hash
+	if prt.TokenHash == nil {
+		code |= ValidatePasswordResetTokenNilTokenHash
+	}
+
+	//validate username
+	if prt.Username == "" {
+		code |= ValidatePasswordResetTokenEmptyUsername
+	}
+
+	return code
+}
+
+// IsExpired checks if the password reset token has expired relative to now.
+func (prt *PasswordResetToken) IsExpired(now time.Time) bool {
+	return now.After(prt.ExpiresAt)
+}
@@ -0,0 +1,90 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PasswordResetTokenTestSuite struct {
+	helpers.CustomSuite
+	PasswordResetToken *models.PasswordResetToken
+}
+
+func (suite *PasswordResetTokenTestSuite) SetupTest() {
+	suite.PasswordResetToken = models.CreateNewPasswordResetToken([]byte("hash"), "username", time.Hour)
+}
+
+func (suite *PasswordResetTokenTestSuite) TestCreateNewPasswordResetToken_CreatesPasswordResetTokenWithSuppliedFields() {
+	//arrange
+	hash := []byte("hash")
+	username := "username"
+
+	//act
+	token := models.CreateNewPasswordResetToken(hash, username, time.Hour)
+
+	//assert
+	suite.Require().NotNil(token)
+	suite.Equal(hash, token.TokenHash)
+	suite.Equal(username, token.Username)
+	suite.WithinDuration(time.Now().Add(time.Hour), token.ExpiresAt, time.Second)
+}
+
+func (suite *PasswordResetTokenTestSuite) TestValidate_WithValidPasswordResetToken_ReturnsValid() {
+	//act
+	verr := suite.PasswordResetToken.Validate()
+
+	//assert
+	suite.Equal(models.ValidatePasswordResetTokenValid, verr)
+}
+
+func (suite *PasswordResetTokenTestSuite) TestValidate_WithNilTokenHash_ReturnsPasswordResetTokenNilTokenHash() {
+	//arrange
+	suite.PasswordResetToken.TokenHash = nil
+
+	//act
+	verr := suite.PasswordResetToken.Validate()
+
+	//assert
+	suite.Equal(models.ValidatePasswordResetTokenNilTokenHash, verr)
+}
+
+func (suite *PasswordResetTokenTestSuite) TestValidate_WithEmptyUsername_ReturnsPasswordResetTokenEmptyUsername() {
+	//arrange
+	suite.PasswordResetToken.Username = ""
+
+	//act
+	verr := suite.PasswordResetToken.Validate()
+
+	//assert
+	suite.Equal(models.ValidatePasswordResetTokenEmptyUsername, verr)
+}
+
+func (suite *PasswordResetTokenTestSuite) TestIsExpired_ExpiryTestCases() {
+	var now time.Time
+	var expected bool
+
+	testCase := func() {
+		//act
+		result := suite.PasswordResetToken.IsExpired(now)
+
+		//assert
+		suite.Equal(expected, result)
+	}
+
+	now = suite.PasswordResetToken.ExpiresAt.Add(-time.Second)
+	expected = false
+	suite.Run("BeforeExpiryIsNotExpired", testCase)
+
+	now = suite.PasswordResetToken.ExpiresAt.Add(time.Second)
+	expected = true
+	suite.Run("AfterExpiryIsExpired", testCase)
+}
+
+func TestPasswordResetTokenTestSuite(t *testing.T) {
+	suite.Run(t, &PasswordResetTokenTestSuite{})
+}
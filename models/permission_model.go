@@ -0,0 +1,120 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Permissions are named actions admin roles grant. The client permissions can also be scoped to a single client by appending its id, e.g. "roles:write:<client id>".
+const (
+	PermissionUsersRead         = "users:read"
+	PermissionUsersWrite        = "users:write"
+	PermissionClientsRead       = "clients:read"
+	PermissionClientsWrite      = "clients:write"
+	PermissionRolesRead         = "roles:read"
+	PermissionRolesWrite        = "roles:write"
+	PermissionInvitationsWrite  = "invitations:write"
+	PermissionLockoutsRead      = "lockouts:read"
+	PermissionLockoutsWrite     = "lockouts:write"
+	PermissionAuditRead         = "audit:read"
+	PermissionAdminRolesRead    = "admin_roles:read"
+	PermissionAdminRolesWrite   = "admin_roles:write"
+	PermissionClientAdminsRead  = "client_admins:read"
+	PermissionClientAdminsWrite = "client_admins:write"
+	PermissionGroupsRead        = "groups:read"
+	PermissionGroupsWrite       = "groups:write"
+)
+
+// Permissions is the list of every permission.
+var Permissions = []string{
+	PermissionUsersRead,
+	PermissionUsersWrite,
+	PermissionClientsRead,
+	PermissionClientsWrite,
+	PermissionRolesRead,
+	PermissionRolesWrite,
+	PermissionInvitationsWrite,
+	PermissionLockoutsRead,
+	PermissionLockoutsWrite,
+	PermissionAuditRead,
+	PermissionAdminRolesRead,
+	PermissionAdminRolesWrite,
+	PermissionClientAdminsRead,
+	PermissionClientAdminsWrite,
+	PermissionGroupsRead,
+	PermissionGroupsWrite,
+}
+
+// ScopablePermissions is the list of permissions that can be scoped to a single client.
+var ScopablePermissions = []string{
+	PermissionClientsWrite,
+	PermissionRolesRead,
+	PermissionRolesWrite,
+	PermissionClientAdminsRead,
+	PermissionClientAdminsWrite,
+}
+
+// ScopePermission returns the permission scoped to the client with the given uid.
+func ScopePermission(permission string, clientUID uuid.UUID) string {
+	return permission + ":" + clientUID.String()
+}
+
+// IsValidPermission returns whether the permission is known, or is a scopable permission scoped to a client.
+func IsValidPermission(permission string) bool {
+	for _, p := range Permissions {
+		if p == permission {
+			return true
+		}
+	}
+
+	for _, p := range ScopablePermissions {
+		if !strings.HasPrefix(permission, p+":") {
+			continue
+		}
+
+		uid, err := uuid.Parse(strings.TrimPrefix(permission, p+":"))
+		return err == nil && ScopePermission(p, uid) == permission
+	}
+
+	return false
+}
+
+// HasPermission returns whether the permissions include the given permission.
+// If a client uid is provided, the permission scoped to that client is also accepted.
+func HasPermission(permissions []string, permission string, clientUID uuid.UUID) bool {
+	scoped := ""
+	if clientUID != uuid.Nil {
+		scoped = ScopePermission(permission, clientUID)
+	}
+
+	for _, p := range permissions {
+		if p == permission || (scoped != "" && p == scoped) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPermissions returns whether the permissions include every one of the given permissions.
+// A permission scoped to a client is also included by the same permission unscoped.
+func HasPermissions(permissions []string, included []string) bool {
+	for _, permission := range included {
+		if HasPermission(permissions, permission, uuid.Nil) {
+			continue
+		}
+
+		//check for the unscoped permission
+		found := false
+		for _, p := range ScopablePermissions {
+			if strings.HasPrefix(permission, p+":") && HasPermission(permissions, p, uuid.Nil) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,159 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type PermissionTestSuite struct {
+	helpers.CustomSuite
+}
+
+func (suite *PermissionTestSuite) TestScopePermission_AppendsClientUID() {
+	//arrange
+	clientUID := uuid.New()
+
+	//act
+	permission := models.ScopePermission(models.PermissionRolesWrite, clientUID)
+
+	//assert
+	suite.Equal("roles:write:"+clientUID.String(), permission)
+}
+
+func (suite *PermissionTestSuite) TestIsValidPermission_TestCases() {
+	var permission string
+	var expectedResult bool
+
+	testCase := func() {
+		//act
+		result := models.IsValidPermission(permission)
+
+		//assert
+		suite.Equal(expectedResult, result)
+	}
+
+	permission = models.PermissionUsersWrite
+	expectedResult = true
+	suite.Run("KnownPermissionIsValid", testCase)
+
+	permission = "users:delete"
+	expectedResult = false
+	suite.Run("UnknownPermissionIsInvalid", testCase)
+
+	permission = models.ScopePermission(models.PermissionRolesWrite, uuid.New())
+	expectedResult = true
+	suite.Run("ScopedRolePermissionIsValid", testCase)
+
+	permission = models.ScopePermission(models.PermissionClientAdminsWrite, uuid.New())
+	expectedResult = true
+	suite.Run("ScopedClientAdminPermissionIsValid", testCase)
+
+	permission = models.ScopePermission(models.PermissionUsersWrite, uuid.New())
+	expectedResult = false
+	suite.Run("ScopedNonScopablePermissionIsInvalid", testCase)
+
+	permission = models.PermissionRolesWrite + ":not-a-uuid"
+	expectedResult = false
+	suite.Run("ScopedWithInvalidClientIDIsInvalid", testCase)
+}
+
+func (suite *PermissionTestSuite) TestHasPermission_TestCases() {
+	clientUID := uuid.New()
+
+	var permissions []string
+	var scope uuid.UUID
+	var expectedResult bool
+
+	testCase := func() {
+		//act
+		result := models.HasPermission(permissions, models.PermissionRolesWrite, scope)
+
+		//assert
+		suite.Equal(expectedResult, result)
+	}
+
+	permissions = []string{models.PermissionRolesWrite}
+	scope = uuid.Nil
+	expectedResult = true
+	suite.Run("WithPermission", testCase)
+
+	permissions = []string{models.PermissionRolesRead}
+	scope = clientUID
+	expectedResult = false
+	suite.Run("WithoutPermission", testCase)
+
+	permissions = []string{models.ScopePermission(models.PermissionRolesWrite, clientUID)}
+	scope = clientUID
+	expectedResult = true
+	suite.Run("WithPermissionScopedToClient", testCase)
+
+	permissions = []string{models.ScopePermission(models.PermissionRolesWrite, uuid.New())}
+	scope = clientUID
+	expectedResult = false
+	suite.Run("WithPermissionScopedToOtherClient", testCase)
+
+	permissions = []string{models.ScopePermission(models.PermissionRolesWrite, clientUID)}
+	scope = uuid.Nil
+	expectedResult = false
+	suite.Run("WithScopedPermissionAndNoScope", testCase)
+}
+
+func (suite *PermissionTestSuite) TestHasPermissions_TestCases() {
+	clientUID := uuid.New()
+
+	var permissions []string
+	var included []string
+	var expectedResult bool
+
+	testCase := func() {
+		//act
+		result := models.HasPermissions(permissions, included)
+
+		//assert
+		suite.Equal(expectedResult, result)
+	}
+
+	permissions = []string{models.PermissionUsersRead, models.PermissionRolesWrite}
+	included = []string{models.PermissionRolesWrite, models.PermissionUsersRead}
+	expectedResult = true
+	suite.Run("WithEveryPermission", testCase)
+
+	permissions = []string{models.PermissionUsersRead}
+	included = []string{}
+	expectedResult = true
+	suite.Run("WithNoIncludedPermissions", testCase)
+
+	permissions = []string{models.PermissionUsersRead}
+	included = []string{models.PermissionUsersRead, models.PermissionUsersWrite}
+	expectedResult = false
+	suite.Run("WithMissingPermission", testCase)
+
+	permissions = []string{models.PermissionRolesWrite}
+	included = []string{models.ScopePermission(models.PermissionRolesWrite, clientUID)}
+	expectedResult = true
+	suite.Run("WithUnscopedPermissionForScopedPermission", testCase)
+
+	permissions = []string{models.ScopePermission(models.PermissionRolesWrite, clientUID)}
+	included = []string{models.ScopePermission(models.PermissionRolesWrite, clientUID)}
+	expectedResult = true
+	suite.Run("WithSameScopedPermission", testCase)
+
+	permissions = []string{models.ScopePermission(models.PermissionRolesWrite, uuid.New())}
+	included = []string{models.ScopePermission(models.PermissionRolesWrite, clientUID)}
+	expectedResult = false
+	suite.Run("WithPermissionScopedToOtherClient", testCase)
+
+	permissions = []string{models.ScopePermission(models.PermissionRolesWrite, clientUID)}
+	included = []string{models.PermissionRolesWrite}
+	expectedResult = false
+	suite.Run("WithScopedPermissionForUnscopedPermission", testCase)
+}
+
+func TestPermissionTestSuite(t *testing.T) {
+	suite.Run(t, &PermissionTestSuite{})
+}
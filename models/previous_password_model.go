@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+const (
+	ValidatePreviousPasswordValid           = 0x0
+	ValidatePreviousPasswordEmptyUsername   = 0x1
+	ValidatePreviousPasswordNilPasswordHash = 0x2
+)
+
+// PreviousPassword represents the previous password model.
+// It is a password the user had before it was replaced, and is kept so the user can't reuse it.
+type PreviousPassword struct {
+	Username     string    `firestore:"username"`
+	PasswordHash []byte    `firestore:"password_hash"`
+	ReplacedAt   time.Time `firestore:"replaced_at"`
+}
+
+type PreviousPasswordCRUD interface {
+	// SavePreviousPassword saves the previous password and returns any errors.
+	SavePreviousPassword(password *PreviousPassword) error
+
+	// GetLatestUserPreviousPasswords fetches up to the limit of the user's most recently replaced passwords, newest first.
+	// Returns the previous passwords and any errors.
+	GetLatestUserPreviousPasswords(username string, limit int) ([]*PreviousPassword, error)
+
+	// DeleteUserPreviousPasswordsReplacedBefore deletes the user's previous passwords that were replaced before the given time.
+	// Returns any errors.
+	DeleteUserPreviousPasswordsReplacedBefore(username string, before time.Time) error
+}
+
+// CreatePreviousPassword creates a new previous password model with the provided fields.
+func CreatePreviousPassword(username string, hash []byte, replacedAt time.Time) *PreviousPassword {
+	return &PreviousPassword{
+		Username:     username,
+		PasswordHash: hash,
+		ReplacedAt:   replacedAt,
+	}
+}
+
+// CreateNewPreviousPassword creates a new previous password model with the provided fields that was replaced now.
+func CreateNewPreviousPassword(username string, hash []byte) *PreviousPassword {
+	return CreatePreviousPassword(username, hash, time.Now().UTC().Truncate(time.Microsecond))
+}
+
+// Validate validates the previous password model has valid fields.
+// Returns an int indicating which fields are invalid.
+func (pp *PreviousPassword) Validate() int {
+	code := ValidatePreviousPasswordValid
+
+	//validate username
+	if pp.Username == "" {
+		code |= ValidatePreviousPasswordEmptyUsername
+	}
+
+	//validate password hash
+	if pp.PasswordHash == nil {
+		code |= ValidatePreviousPasswordNilPasswordHash
+	}
+
+	return code
+}
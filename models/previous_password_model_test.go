@@ -0,0 +1,69 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PreviousPasswordTestSuite struct {
+	helpers.CustomSuite
+	PreviousPassword *models.PreviousPassword
+}
+
+func (suite *PreviousPasswordTestSuite) SetupTest() {
+	suite.PreviousPassword = models.CreateNewPreviousPassword("username", []byte("hash"))
+}
+
+func (suite *PreviousPasswordTestSuite) TestCreateNewPreviousPassword_CreatesPreviousPasswordWithSuppliedFields() {
+	//arrange
+	username := "username"
+	hash := []byte("hash")
+
+	//act
+	password := models.CreateNewPreviousPassword(username, hash)
+
+	//assert
+	suite.Require().NotNil(password)
+	suite.Equal(username, password.Username)
+	suite.Equal(hash, password.PasswordHash)
+	suite.WithinDuration(time.Now(), password.ReplacedAt, time.Second)
+}
+
+func (suite *PreviousPasswordTestSuite) TestValidate_WithValidPreviousPassword_ReturnsValid() {
+	//act
+	verr := suite.PreviousPassword.Validate()
+
+	//assert
+	suite.Equal(models.ValidatePreviousPasswordValid, verr)
+}
+
+func (suite *PreviousPasswordTestSuite) TestValidate_WithEmptyUsername_ReturnsPreviousPasswordEmptyUsername() {
+	//arrange
+	suite.PreviousPassword.Username = ""
+
+	//act
+	verr := suite.PreviousPassword.Validate()
+
+	//assert
+	suite.Equal(models.ValidatePreviousPasswordEmptyUsername, verr)
+}
+
+func (suite *PreviousPasswordTestSuite) TestValidate_WithNilPasswordHash_ReturnsPreviousPasswordNilPasswordHash() {
+	//arrange
+	suite.PreviousPassword.PasswordHash = nil
+
+	//act
+	verr := suite.PreviousPassword.Validate()
+
+	//assert
+	suite.Equal(models.ValidatePreviousPasswordNilPasswordHash, verr)
+}
+
+func TestPreviousPasswordTestSuite(t *testing.T) {
+	suite.Run(t, &PreviousPasswordTestSuite{})
+}
@@ -0,0 +1,53 @@
+package models
+
+const (
+	ValidateRecoveryCodeValid         = 0x0
+	ValidateRecoveryCodeEmptyUsername = 0x1
+	ValidateRecoveryCodeNilCodeHash   = 0x2
+)
+
+// RecoveryCode represents the recovery code model.
+// Each code can be used once in place of a TOTP code, so only its hash is stored.
+type RecoveryCode struct {
+	Username string `firestore:"username"`
+	CodeHash []byte `firestore:"code_hash"`
+}
+
+type RecoveryCodeCRUD interface {
+	// SaveRecoveryCode saves the recovery code and returns any errors.
+	SaveRecoveryCode(code *RecoveryCode) error
+
+	// DeleteRecoveryCode deletes the recovery code with the given username and hash.
+	// Returns result of whether the recovery code was found, and any errors.
+	DeleteRecoveryCode(username string, hash []byte) (bool, error)
+
+	// DeleteAllUserRecoveryCodes deletes all of the recovery codes for the given username.
+	// Returns any errors.
+	DeleteAllUserRecoveryCodes(username string) error
+}
+
+// CreateRecoveryCode creates a new recovery code model with the provided fields.
+func CreateRecoveryCode(username string, hash []byte) *RecoveryCode {
+	return &RecoveryCode{
+		Username: username,
+		CodeHash: hash,
+	}
+}
+
+// Validate validates the recovery code model has valid fields.
+// Returns an int indicating which fields are invalid.
+func (rc *RecoveryCode) Validate() int {
+	code := ValidateRecoveryCodeValid
+
+	//validate username
+	if rc.Username == "" {
+		code |= ValidateRecoveryCodeEmptyUsername
+	}
+
+	//validate code hash
+	if rc.CodeHash == nil {
+		code |= ValidateRecoveryCodeNilCodeHash
+	}
+
+	return code
+}
@@ -0,0 +1,67 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RecoveryCodeTestSuite struct {
+	helpers.CustomSuite
+	RecoveryCode *models.RecoveryCode
+}
+
+func (suite *RecoveryCodeTestSuite) SetupTest() {
+	suite.RecoveryCode = models.CreateRecoveryCode("username", []byte("hash"))
+}
+
+func (suite *RecoveryCodeTestSuite) TestCreateRecoveryCode_CreatesRecoveryCodeWithSuppliedFields() {
+	//arrange
+	username := "username"
+	hash := []byte("hash")
+
+	//act
+	code := models.CreateRecoveryCode(username, hash)
+
+	//assert
+	suite.Require().NotNil(code)
+	suite.Equal(username, code.Username)
+	suite.Equal(hash, code.CodeHash)
+}
+
+func (suite *RecoveryCodeTestSuite) TestValidate_WithValidRecoveryCode_ReturnsValid() {
+	//act
+	verr := suite.RecoveryCode.Validate()
+
+	//assert
+	suite.Equal(models.ValidateRecoveryCodeValid, verr)
+}
+
+func (suite *RecoveryCodeTestSuite) TestValidate_WithEmptyUsername_ReturnsRecoveryCodeEmptyUsername() {
+	//arrange
+	suite.RecoveryCode.Username = ""
+
+	//act
+	verr := suite.RecoveryCode.Validate()
+
+	//assert
+	suite.Equal(models.ValidateRecoveryCodeEmptyUsername, verr)
+}
+
+func (suite *RecoveryCodeTestSuite) TestValidate_WithNilCodeHash_ReturnsRecoveryCodeNilCodeHash() {
+	//arrange
+	suite.RecoveryCode.CodeHash = nil
+
+	//act
+	verr := suite.RecoveryCode.Validate()
+
+	//assert
+	suite.Equal(models.ValidateRecoveryCodeNilCodeHash, verr)
+}
+
+func TestRecoveryCodeTestSuite(t *testing.T) {
+	suite.Run(t, &RecoveryCodeTestSuite{})
+}
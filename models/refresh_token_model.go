@@ -0,0 +1,84 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ValidateRefreshTokenValid       = 0x0
+	ValidateRefreshTokenNilToken    = 0x1
+	ValidateRefreshTokenNilFamilyID = 0x2
+)
+
+// RefreshToken represents the refresh token model.
+// Every token created by rotating another shares its family id, so the whole chain can be revoked at once.
+type RefreshToken struct {
+	Token     uuid.UUID `firestore:"token"`
+	FamilyID  uuid.UUID `firestore:"family_id"`
+	ClientUID uuid.UUID `firestore:"client_uid"`
+	Username  string    `firestore:"username"`
+	Rotated   bool      `firestore:"rotated"`
+	ExpiresAt time.Time `firestore:"expires_at"`
+}
+
+type RefreshTokenCRUD interface {
+	// SaveRefreshToken saves the refresh token and returns any errors.
+	SaveRefreshToken(token *RefreshToken) error
+
+	// GetRefreshToken fetches the refresh token with the given token.
+	// If no refresh tokens are found, returns nil refresh token.
+	// Also returns any errors.
+	GetRefreshToken(token uuid.UUID) (*RefreshToken, error)
+
+	// RotateRefreshToken marks the refresh token with the given token as rotated.
+	// Returns result of whether an unrotated refresh token was found, and any errors.
+	RotateRefreshToken(token uuid.UUID) (bool, error)
+
+	// DeleteRefreshTokenFamily deletes all refresh tokens with the given family id.
+	// Returns any errors.
+	DeleteRefreshTokenFamily(familyID uuid.UUID) error
+}
+
+// CreateRefreshToken creates a new refresh token model with the provided fields.
+func CreateRefreshToken(token uuid.UUID, familyID uuid.UUID, clientUID uuid.UUID, username string, rotated bool, expiresAt time.Time) *RefreshToken {
+	return &RefreshToken{
+		Token:     token,
+		FamilyID:  familyID,
+		ClientUID: clientUID,
+		Username:  username,
+		Rotated:   rotated,
+		ExpiresAt: expiresAt,
+	}
+}
+
+// CreateNewRefreshToken generates a new token then creates a new unrotated refresh token model in the given family.
+// The token will expire after the provided lifetime.
+func CreateNewRefreshToken(familyID uuid.UUID, clientUID uuid.UUID, username string, lifetime time.Duration) *RefreshToken {
+	expiresAt := time.Now().UTC().Truncate(time.Microsecond).Add(lifetime)
+	return CreateRefreshToken(uuid.New(), familyID, clientUID, username, false, expiresAt)
+}
+
+// Validate validates the refresh token model has valid fields.
+// Returns an int indicating which fields are invalid.
+func (rt *RefreshToken) Validate() int {
+	code := ValidateRefreshTokenValid
+
+	//validate token
+	if rt.Token == uuid.Nil {
+		code |= ValidateRefreshTokenNilToken
+	}
+
+	//validate family id
+	if rt.FamilyID == uuid.Nil {
+		code |= ValidateRefreshTokenNilFamilyID
+	}
+
+	return code
+}
+
+// IsExpired checks if the refresh token has expired relative to now.
+func (rt *RefreshToken) IsExpired(now time.Time) bool {
+	return now.After(rt.ExpiresAt)
+}
@@ -0,0 +1,95 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type RefreshTokenTestSuite struct {
+	helpers.CustomSuite
+	RefreshToken *models.RefreshToken
+}
+
+func (suite *RefreshTokenTestSuite) SetupTest() {
+	suite.RefreshToken = models.CreateNewRefreshToken(uuid.New(), uuid.New(), "username", time.Hour)
+}
+
+func (suite *RefreshTokenTestSuite) TestCreateNewRefreshToken_CreatesRefreshTokenWithSuppliedFields() {
+	//arrange
+	familyID := uuid.New()
+	clientUID := uuid.New()
+	username := "username"
+
+	//act
+	token := models.CreateNewRefreshToken(familyID, clientUID, username, time.Hour)
+
+	//assert
+	suite.Require().NotNil(token)
+	suite.NotEqual(uuid.Nil, token.Token)
+	suite.Equal(familyID, token.FamilyID)
+	suite.Equal(clientUID, token.ClientUID)
+	suite.Equal(username, token.Username)
+	suite.False(token.Rotated)
+	suite.WithinDuration(time.Now().Add(time.Hour), token.ExpiresAt, time.Second)
+}
+
+func (suite *RefreshTokenTestSuite) TestValidate_WithValidRefreshToken_ReturnsValid() {
+	//act
+	verr := suite.RefreshToken.Validate()
+
+	//assert
+	suite.Equal(models.ValidateRefreshTokenValid, verr)
+}
+
+func (suite *RefreshTokenTestSuite) TestValidate_WithNilToken_ReturnsRefreshTokenNilToken() {
+	//arrange
+	suite.RefreshToken.Token = uuid.Nil
+
+	//act
+	verr := suite.RefreshToken.Validate()
+
+	//assert
+	suite.Equal(models.ValidateRefreshTokenNilToken, verr)
+}
+
+func (suite *RefreshTokenTestSuite) TestValidate_WithNilFamilyID_ReturnsRefreshTokenNilFamilyID() {
+	//arrange
+	suite.RefreshToken.FamilyID = uuid.Nil
+
+	//act
+	verr := suite.RefreshToken.Validate()
+
+	//assert
+	suite.Equal(models.ValidateRefreshTokenNilFamilyID, verr)
+}
+
+func (suite *RefreshTokenTestSuite) TestIsExpired_ExpiryTestCases() {
+	var now time.Time
+	var expected bool
+
+	testCase := func() {
+		//act
+		result := suite.RefreshToken.IsExpired(now)
+
+		//assert
+		suite.Equal(expected, result)
+	}
+
+	now = suite.RefreshToken.ExpiresAt.Add(-time.Second)
+	expected = false
+	suite.Run("BeforeExpiryIsNotExpired", testCase)
+
+	now = suite.RefreshToken.ExpiresAt.Add(time.Second)
+	expected = true
+	suite.Run("AfterExpiryIsExpired", testCase)
+}
+
+func TestRefreshTokenTestSuite(t *testing.T) {
+	suite.Run(t, &RefreshTokenTestSuite{})
+}
@@ -0,0 +1,58 @@
+package models
+
+import "sort"
+
+const (
+	RoleAssignmentSourceUser  = "user"
+	RoleAssignmentSourceGroup = "group"
+)
+
+// RoleAssignment represents a set of roles a user has for a client, given either directly to the user or to one of their groups.
+type RoleAssignment struct {
+	Source    string
+	GroupName string
+	Priority  int
+	Roles     []string
+}
+
+// ResolveRoleAssignments returns every role assignment the user has for a client, in order of precedence.
+// Roles given directly to the user come first, followed by the roles given to their groups from highest to lowest priority, with ties broken by group name.
+// The first assignment decides the user's roles for the client. The user-role can be nil, and group-roles of groups the user is not a member of are ignored.
+func ResolveRoleAssignments(userRole *UserRole, groups []*Group, groupRoles []*GroupRole) []*RoleAssignment {
+	assignments := []*RoleAssignment{}
+	if userRole != nil {
+		assignments = append(assignments, &RoleAssignment{
+			Source: RoleAssignmentSourceUser,
+			Roles:  userRole.Roles,
+		})
+	}
+
+	priorities := map[string]int{}
+	for _, group := range groups {
+		priorities[group.Name] = group.Priority
+	}
+
+	groupAssignments := []*RoleAssignment{}
+	for _, groupRole := range groupRoles {
+		priority, ok := priorities[groupRole.GroupName]
+		if !ok {
+			continue
+		}
+
+		groupAssignments = append(groupAssignments, &RoleAssignment{
+			Source:    RoleAssignmentSourceGroup,
+			GroupName: groupRole.GroupName,
+			Priority:  priority,
+			Roles:     groupRole.Roles,
+		})
+	}
+
+	sort.SliceStable(groupAssignments, func(i, j int) bool {
+		if groupAssignments[i].Priority != groupAssignments[j].Priority {
+			return groupAssignments[i].Priority > groupAssignments[j].Priority
+		}
+		return groupAssignments[i].GroupName < groupAssignments[j].GroupName
+	})
+
+	return append(assignments, groupAssignments...)
+}
@@ -0,0 +1,81 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type RoleAssignmentTestSuite struct {
+	helpers.CustomSuite
+}
+
+func (suite *RoleAssignmentTestSuite) TestResolveRoleAssignments_WithNoRoles_ReturnsEmptyList() {
+	//act
+	assignments := models.ResolveRoleAssignments(nil, nil, nil)
+
+	//assert
+	suite.Empty(assignments)
+}
+
+func (suite *RoleAssignmentTestSuite) TestResolveRoleAssignments_PutsUserRoleFirst() {
+	//arrange
+	userRole := models.CreateUserRole(uuid.Nil, "username", "user")
+	groups := []*models.Group{models.CreateGroup("group", "", 100)}
+	groupRoles := []*models.GroupRole{models.CreateGroupRole(uuid.Nil, "group", "group")}
+
+	//act
+	assignments := models.ResolveRoleAssignments(userRole, groups, groupRoles)
+
+	//assert
+	suite.Require().Len(assignments, 2)
+	suite.Equal(&models.RoleAssignment{Source: models.RoleAssignmentSourceUser, Roles: []string{"user"}}, assignments[0])
+	suite.Equal(&models.RoleAssignment{Source: models.RoleAssignmentSourceGroup, GroupName: "group", Priority: 100, Roles: []string{"group"}}, assignments[1])
+}
+
+func (suite *RoleAssignmentTestSuite) TestResolveRoleAssignments_OrdersGroupRolesByPriorityThenGroupName() {
+	//arrange
+	groups := []*models.Group{
+		models.CreateGroup("a", "", 1),
+		models.CreateGroup("b", "", 2),
+		models.CreateGroup("c", "", 1),
+	}
+	groupRoles := []*models.GroupRole{
+		models.CreateGroupRole(uuid.Nil, "c", "role"),
+		models.CreateGroupRole(uuid.Nil, "a", "role"),
+		models.CreateGroupRole(uuid.Nil, "b", "role"),
+	}
+
+	//act
+	assignments := models.ResolveRoleAssignments(nil, groups, groupRoles)
+
+	//assert
+	suite.Require().Len(assignments, 3)
+	suite.Equal("b", assignments[0].GroupName)
+	suite.Equal("a", assignments[1].GroupName)
+	suite.Equal("c", assignments[2].GroupName)
+}
+
+func (suite *RoleAssignmentTestSuite) TestResolveRoleAssignments_IgnoresGroupRolesOfOtherGroups() {
+	//arrange
+	groups := []*models.Group{models.CreateGroup("group", "", 0)}
+	groupRoles := []*models.GroupRole{
+		models.CreateGroupRole(uuid.Nil, "other", "role"),
+		models.CreateGroupRole(uuid.Nil, "group", "role"),
+	}
+
+	//act
+	assignments := models.ResolveRoleAssignments(nil, groups, groupRoles)
+
+	//assert
+	suite.Require().Len(assignments, 1)
+	suite.Equal("group", assignments[0].GroupName)
+}
+
+func TestRoleAssignmentTestSuite(t *testing.T) {
+	suite.Run(t, &RoleAssignmentTestSuite{})
+}
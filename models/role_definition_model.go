@@ -0,0 +1,73 @@
+package models
+
+import "github.com/google/uuid"
+
+const (
+	ValidateRoleDefinitionValid              = 0x0
+	ValidateRoleDefinitionEmptyName          = 0x1
+	ValidateRoleDefinitionNameTooLong        = 0x2
+	ValidateRoleDefinitionDescriptionTooLong = 0x4
+)
+
+// RoleDefinitionDescriptionMaxLength is the max length a role definition's description can be.
+const RoleDefinitionDescriptionMaxLength = 255
+
+// RoleDefinition represents the role definition model.
+// Each client defines the set of roles its users can be given, and can mark one of them as the default.
+type RoleDefinition struct {
+	ClientUID   uuid.UUID `firestore:"client_uid"`
+	Name        string    `firestore:"name"`
+	Description string    `firestore:"description"`
+	IsDefault   bool      `firestore:"is_default"`
+}
+
+type RoleDefinitionCRUD interface {
+	// CreateRoleDefinition creates the role definition. Returns any errors.
+	CreateRoleDefinition(definition *RoleDefinition) error
+
+	// GetRoleDefinitionsByClientUID fetches all the role definitions for the provided client uid, sorted by name.
+	// Returns the role definitions and any errors.
+	GetRoleDefinitionsByClientUID(clientUID uuid.UUID) ([]*RoleDefinition, error)
+
+	// GetRoleDefinitionByClientUIDAndName fetches the role definition for the provided client uid and name.
+	// Returns the role definition if it exists, nil if not. Also returns any errors.
+	GetRoleDefinitionByClientUIDAndName(clientUID uuid.UUID, name string) (*RoleDefinition, error)
+
+	// UpdateRoleDefinition updates the role definition with the given name, renaming it if the model's name is different.
+	// Returns result of whether the role definition was found and any errors.
+	UpdateRoleDefinition(name string, definition *RoleDefinition) (bool, error)
+
+	// DeleteRoleDefinition deletes the role definition with the given client uid and name.
+	// Returns result of whether the role definition was found, and any errors.
+	DeleteRoleDefinition(clientUID uuid.UUID, name string) (bool, error)
+}
+
+// CreateRoleDefinition creates a new role definition model with the provided fields.
+func CreateRoleDefinition(clientUID uuid.UUID, name string, description string, isDefault bool) *RoleDefinition {
+	return &RoleDefinition{
+		ClientUID:   clientUID,
+		Name:        name,
+		Description: description,
+		IsDefault:   isDefault,
+	}
+}
+
+// Validate validates the role definition model has valid fields.
+// Returns an int indicating which fields are invalid.
+func (rd *RoleDefinition) Validate() int {
+	code := ValidateRoleDefinitionValid
+
+	//validate name
+	if rd.Name == "" {
+		code |= ValidateRoleDefinitionEmptyName
+	} else if len(rd.Name) > UserRoleRoleMaxLength {
+		code |= ValidateRoleDefinitionNameTooLong
+	}
+
+	//validate description
+	if len(rd.Description) > RoleDefinitionDescriptionMaxLength {
+		code |= ValidateRoleDefinitionDescriptionTooLong
+	}
+
+	return code
+}
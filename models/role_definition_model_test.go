@@ -0,0 +1,112 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type RoleDefinitionTestSuite struct {
+	helpers.CustomSuite
+	RoleDefinition *models.RoleDefinition
+}
+
+func (suite *RoleDefinitionTestSuite) SetupTest() {
+	suite.RoleDefinition = models.CreateRoleDefinition(uuid.New(), "role", "description", false)
+}
+
+func (suite *RoleDefinitionTestSuite) TestCreateRoleDefinition_CreatesRoleDefinitionWithSuppliedFields() {
+	//arrange
+	clientUID := uuid.New()
+	name := "name"
+	description := "this is a test description"
+
+	//act
+	definition := models.CreateRoleDefinition(clientUID, name, description, true)
+
+	//assert
+	suite.Require().NotNil(definition)
+	suite.Equal(clientUID, definition.ClientUID)
+	suite.Equal(name, definition.Name)
+	suite.Equal(description, definition.Description)
+	suite.True(definition.IsDefault)
+}
+
+func (suite *RoleDefinitionTestSuite) TestValidate_WithValidRoleDefinition_ReturnsValid() {
+	//act
+	verr := suite.RoleDefinition.Validate()
+
+	//assert
+	suite.Equal(models.ValidateRoleDefinitionValid, verr)
+}
+
+func (suite *RoleDefinitionTestSuite) TestValidate_WithEmptyName_ReturnsRoleDefinitionEmptyName() {
+	//arrange
+	suite.RoleDefinition.Name = ""
+
+	//act
+	verr := suite.RoleDefinition.Validate()
+
+	//assert
+	suite.Equal(models.ValidateRoleDefinitionEmptyName, verr)
+}
+
+func (suite *RoleDefinitionTestSuite) TestValidate_NameMaxLengthTestCases() {
+	var name string
+	var expectedValidateError int
+
+	testCase := func() {
+		//arrange
+		suite.RoleDefinition.Name = name
+
+		//act
+		verr := suite.RoleDefinition.Validate()
+
+		//assert
+		suite.Equal(expectedValidateError, verr)
+	}
+
+	name = helpers.CreateStringOfLength(models.UserRoleRoleMaxLength)
+	expectedValidateError = models.ValidateRoleDefinitionValid
+	suite.Run("ExactlyMaxLengthIsValid", testCase)
+
+	name += "a"
+	expectedValidateError = models.ValidateRoleDefinitionNameTooLong
+	suite.Run("OneMoreThanMaxLengthIsInvalid", testCase)
+}
+
+func (suite *RoleDefinitionTestSuite) TestValidate_DescriptionMaxLengthTestCases() {
+	var description string
+	var expectedValidateError int
+
+	testCase := func() {
+		//arrange
+		suite.RoleDefinition.Description = description
+
+		//act
+		verr := suite.RoleDefinition.Validate()
+
+		//assert
+		suite.Equal(expectedValidateError, verr)
+	}
+
+	description = ""
+	expectedValidateError = models.ValidateRoleDefinitionValid
+	suite.Run("EmptyIsValid", testCase)
+
+	description = helpers.CreateStringOfLength(models.RoleDefinitionDescriptionMaxLength)
+	expectedValidateError = models.ValidateRoleDefinitionValid
+	suite.Run("ExactlyMaxLengthIsValid", testCase)
+
+	description += "a"
+	expectedValidateError = models.ValidateRoleDefinitionDescriptionTooLong
+	suite.Run("OneMoreThanMaxLengthIsInvalid", testCase)
+}
+
+func TestRoleDefinitionTestSuite(t *testing.T) {
+	suite.Run(t, &RoleDefinitionTestSuite{})
+}
@@ -1,6 +1,10 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 const (
 	ValidateSessionValid    = 0x0
@@ -8,10 +12,15 @@ const (
 )
 
 // Session represents the session model.
+// Permissions and client admin uids are not stored with the session, and are instead loaded from the user's admin roles and client admins each time the session is used.
 type Session struct {
-	Token    uuid.UUID `firestore:"token"`
-	Username string    `firestore:"username"`
-	Rank     int       `firestore:"rank"`
+	Token           uuid.UUID   `firestore:"token"`
+	Username        string      `firestore:"username"`
+	Rank            int         `firestore:"rank"`
+	CreatedAt       time.Time   `firestore:"created_at"`
+	LastUsedAt      time.Time   `firestore:"last_used_at"`
+	Permissions     []string    `firestore:"-"`
+	ClientAdminUIDs []uuid.UUID `firestore:"-"`
 }
 
 type SessionCRUD interface {
@@ -23,6 +32,10 @@ type SessionCRUD interface {
 	// Also returns any errors.
 	GetSessionByToken(token uuid.UUID) (*Session, error)
 
+	// UpdateSessionLastUsedAt updates the last used time of the session with the given token.
+	// Returns result of whether the session was found, and any errors.
+	UpdateSessionLastUsedAt(token uuid.UUID, lastUsedAt time.Time) (bool, error)
+
 	// DeleteSession deletes the session with the given token.
 	// Returns result of whether the session was found, and any errors.
 	DeleteSession(token uuid.UUID) (bool, error)
@@ -34,14 +47,23 @@ type SessionCRUD interface {
 	// DeleteAllOtherUserSessions deletes all of the sessions for the given username except the one with the given token.
 	// Returns any errors.
 	DeleteAllOtherUserSessions(username string, tokem uuid.UUID) error
+
+	// DeleteExpiredSessions deletes all sessions created before createdBefore or last used before lastUsedBefore.
+	// Returns any errors.
+	DeleteExpiredSessions(createdBefore time.Time, lastUsedBefore time.Time) error
 }
 
 // CreateSession creates a new session model with the provided field.
+// The created and last used times are set to the current time, truncated to the precision the databases support.
 func CreateSession(token uuid.UUID, username string, rank int) *Session {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
 	return &Session{
-		Token:    token,
-		Username: username,
-		Rank:     rank,
+		Token:      token,
+		Username:   username,
+		Rank:       rank,
+		CreatedAt:  now,
+		LastUsedAt: now,
 	}
 }
 
@@ -62,3 +84,40 @@ func (s *Session) Validate() int {
 
 	return code
 }
+
+// HasPermission returns whether the session's permissions include the given permission, or the permission scoped to the client with the given uid.
+// Use uuid.Nil when the permission is not for a single client.
+func (s *Session) HasPermission(permission string, clientUID uuid.UUID) bool {
+	return HasPermission(s.Permissions, permission, clientUID)
+}
+
+// HasPermissions returns whether the session's permissions include every one of the given permissions.
+func (s *Session) HasPermissions(permissions []string) bool {
+	return HasPermissions(s.Permissions, permissions)
+}
+
+// IsClientAdmin returns whether the session's user is a client admin of the client with the given uid.
+func (s *Session) IsClientAdmin(clientUID uuid.UUID) bool {
+	for _, uid := range s.ClientAdminUIDs {
+		if uid == clientUID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpired checks if the session has outlived its lifetime or has been idle longer than the idle timeout, relative to now.
+// A zero lifetime or idle timeout disables that check.
+func (s *Session) IsExpired(now time.Time, lifetime time.Duration, idleTimeout time.Duration) bool {
+	//check absolute lifetime
+	if lifetime > 0 && now.Sub(s.CreatedAt) > lifetime {
+		return true
+	}
+
+	//check idle timeout
+	if idleTimeout > 0 && now.Sub(s.LastUsedAt) > idleTimeout {
+		return true
+	}
+
+	return false
+}
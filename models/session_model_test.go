@@ -2,6 +2,7 @@ package models_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/mhogar/amber/models"
 	"github.com/mhogar/amber/testing/helpers"
@@ -32,6 +33,8 @@ func (suite *SessionTestSuite) TestCreateNewSession_CreatesSessionWithSuppliedFi
 	suite.NotEqual(session.Token, uuid.Nil)
 	suite.Equal(session.Username, username)
 	suite.Equal(session.Rank, rank)
+	suite.WithinDuration(time.Now(), session.CreatedAt, time.Second)
+	suite.Equal(session.CreatedAt, session.LastUsedAt)
 }
 
 func (suite *SessionTestSuite) TestValidate_WithValidSession_ReturnsValid() {
@@ -53,6 +56,81 @@ func (suite *SessionTestSuite) TestValidate_WithNilID_ReturnsSessionNilToken() {
 	suite.Equal(models.ValidateSessionNilToken, verr)
 }
 
+func (suite *SessionTestSuite) TestIsExpired_ExpiryTestCases() {
+	var lifetime time.Duration
+	var idleTimeout time.Duration
+	var expected bool
+
+	now := time.Now()
+	suite.Session.CreatedAt = now.Add(-time.Hour)
+	suite.Session.LastUsedAt = now.Add(-time.Minute)
+
+	testCase := func() {
+		//act
+		res := suite.Session.IsExpired(now, lifetime, idleTimeout)
+
+		//assert
+		suite.Equal(expected, res)
+	}
+
+	lifetime, idleTimeout, expected = 2*time.Hour, 2*time.Minute, false
+	suite.Run("WithinLifetimeAndIdleTimeoutIsNotExpired", testCase)
+
+	lifetime, idleTimeout, expected = 30*time.Minute, 2*time.Minute, true
+	suite.Run("PastLifetimeIsExpired", testCase)
+
+	lifetime, idleTimeout, expected = 2*time.Hour, 30*time.Second, true
+	suite.Run("PastIdleTimeoutIsExpired", testCase)
+
+	lifetime, idleTimeout, expected = 0, 0, false
+	suite.Run("ZeroLifetimeAndIdleTimeoutNeverExpires", testCase)
+}
+
+func (suite *SessionTestSuite) TestHasPermission_ChecksTheSessionPermissions() {
+	//arrange
+	clientUID := uuid.New()
+	suite.Session.Permissions = []string{models.PermissionUsersRead, models.ScopePermission(models.PermissionRolesWrite, clientUID)}
+
+	//act
+	hasUsersRead := suite.Session.HasPermission(models.PermissionUsersRead, uuid.Nil)
+	hasUsersWrite := suite.Session.HasPermission(models.PermissionUsersWrite, uuid.Nil)
+	hasClientRolesWrite := suite.Session.HasPermission(models.PermissionRolesWrite, clientUID)
+	hasRolesWrite := suite.Session.HasPermission(models.PermissionRolesWrite, uuid.Nil)
+
+	//assert
+	suite.True(hasUsersRead)
+	suite.False(hasUsersWrite)
+	suite.True(hasClientRolesWrite)
+	suite.False(hasRolesWrite)
+}
+
+func (suite *SessionTestSuite) TestHasPermissions_ChecksTheSessionPermissions() {
+	//arrange
+	suite.Session.Permissions = []string{models.PermissionUsersRead, models.PermissionRolesWrite}
+
+	//act
+	hasSubset := suite.Session.HasPermissions([]string{models.PermissionRolesWrite})
+	hasSuperset := suite.Session.HasPermissions([]string{models.PermissionRolesWrite, models.PermissionUsersWrite})
+
+	//assert
+	suite.True(hasSubset)
+	suite.False(hasSuperset)
+}
+
+func (suite *SessionTestSuite) TestIsClientAdmin_ChecksTheSessionClientAdminUIDs() {
+	//arrange
+	clientUID := uuid.New()
+	suite.Session.ClientAdminUIDs = []uuid.UUID{clientUID}
+
+	//act
+	isClientAdmin := suite.Session.IsClientAdmin(clientUID)
+	isOtherClientAdmin := suite.Session.IsClientAdmin(uuid.New())
+
+	//assert
+	suite.True(isClientAdmin)
+	suite.False(isOtherClientAdmin)
+}
+
 func TestSessionTestSuite(t *testing.T) {
 	suite.Run(t, &SessionTestSuite{})
 }
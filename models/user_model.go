@@ -1,53 +1,127 @@
 package models
 
+import (
+	"net/mail"
+	"strconv"
+	"time"
+)
+
 const (
-	ValidateUserValid           = 0x0
-	ValidateUserEmptyUsername   = 0x1
-	ValidateUserUsernameTooLong = 0x2
-	ValidateUserInvalidRank     = 0x8
+	ValidateUserValid              = 0x0
+	ValidateUserEmptyUsername      = 0x1
+	ValidateUserUsernameTooLong    = 0x2
+	ValidateUserInvalidRank        = 0x8
+	ValidateUserInvalidEmail       = 0x10
+	ValidateUserEmailTooLong       = 0x20
+	ValidateUserDisplayNameTooLong = 0x40
 )
 
 // UserUsernameMaxLength is the max length a user's username can be.
 const UserUsernameMaxLength = 30
 
+// UserEmailMaxLength is the max length a user's email can be.
+const UserEmailMaxLength = 254
+
+// UserDisplayNameMaxLength is the max length a user's display name can be.
+const UserDisplayNameMaxLength = 64
+
 // User represents the user model.
+// TOTPSecret is stored encrypted, and is set but not yet enabled while the user is confirming their enrollment.
+// TOTPLastTimeStep is the time step of the last TOTP code accepted for the user, so codes can't be used more than once.
+// PasswordUpdatedAt is when the user's password was last set, and is used to check its age.
 type User struct {
-	Username     string `firestore:"username"`
-	Rank         int    `firestore:"rank"`
-	PasswordHash []byte `firestore:"password_hash"`
+	Username          string    `firestore:"username"`
+	Rank              int       `firestore:"rank"`
+	PasswordHash      []byte    `firestore:"password_hash"`
+	PasswordUpdatedAt time.Time `firestore:"password_updated_at"`
+	TOTPSecret        []byte    `firestore:"totp_secret"`
+	TOTPEnabled       bool      `firestore:"totp_enabled"`
+	TOTPLastTimeStep  int64     `firestore:"totp_last_time_step"`
+	UserProfile
+}
+
+// UserProfile contains the user's profile fields.
+// Email is optional, but must be unique across all users when set. Disabled users cannot log in.
+type UserProfile struct {
+	Email       string `firestore:"email"`
+	DisplayName string `firestore:"display_name"`
+	Enabled     bool   `firestore:"enabled"`
+}
+
+// UserProfileUpdate contains the user's profile fields to update.
+// Nil fields keep their current values.
+type UserProfileUpdate struct {
+	Email       *string
+	DisplayName *string
+	Enabled     *bool
+}
+
+// Apply returns the profile with the fields set in the update replaced.
+func (u UserProfileUpdate) Apply(profile UserProfile) UserProfile {
+	if u.Email != nil {
+		profile.Email = *u.Email
+	}
+	if u.DisplayName != nil {
+		profile.DisplayName = *u.DisplayName
+	}
+	if u.Enabled != nil {
+		profile.Enabled = *u.Enabled
+	}
+	return profile
 }
 
 type UserCRUD interface {
 	// CreateUser creates a new user and returns any errors.
 	CreateUser(user *User) error
 
-	// GetUsersWithLesserRank fetches all the users with a rank less than the provided one.
-	// Returns the users and any errors.
-	GetUsersWithLesserRank(rank int) ([]*User, error)
+	// GetUsersWithLesserRank fetches the page of users with a rank less than the provided one, searching by username.
+	// Users can be sorted by username or rank. Returns the users and any errors.
+	GetUsersWithLesserRank(rank int, query PageQuery) ([]*User, error)
+
+	// CountUsersWithLesserRank counts the users with a rank less than the provided one whose username starts with the search.
+	// Returns the count and any errors.
+	CountUsersWithLesserRank(rank int, search string) (int, error)
 
 	// GetUserByUsername fetches the user with the matching username.
 	// If no users are found, returns nil user. Also returns any errors.
 	GetUserByUsername(username string) (*User, error)
 
-	// UpdateUser updates the user.
+	// GetUserByEmail fetches the user with the matching email.
+	// If no users are found, returns nil user. Also returns any errors.
+	GetUserByEmail(email string) (*User, error)
+
+	// UpdateUser updates the user's rank and profile.
 	// Returns result of whether the user was found, and any errors.
 	UpdateUser(user *User) (bool, error)
 
-	// UpdateUserPassword updates the user's password.
+	// UpdateUserPassword updates the user's password and when it was updated.
+	// Returns result of whether the user was found, and any errors.
+	UpdateUserPassword(username string, hash []byte, updatedAt time.Time) (bool, error)
+
+	// UpdateUserTOTP updates the user's encrypted TOTP secret and whether two-factor authentication is enabled.
 	// Returns result of whether the user was found, and any errors.
-	UpdateUserPassword(username string, hash []byte) (bool, error)
+	UpdateUserTOTP(username string, secret []byte, enabled bool) (bool, error)
+
+	// UpdateUserTOTPLastTimeStep updates the time step of the last TOTP code accepted for the user, but only if the step is after the current one.
+	// Returns result of whether the step was updated, which is false if the user was not found, and any errors.
+	UpdateUserTOTPLastTimeStep(username string, step int64) (bool, error)
 
 	// DeleteUser deletes the user with the given username.
 	// Returns result of whether the user was found, and any errors.
 	DeleteUser(username string) (bool, error)
 }
 
-// CreateUser creates a new user model with the provided fields.
+// CreateUser creates a new enabled user model with the provided fields and an empty profile.
+// The password is considered updated now.
 func CreateUser(username string, rank int, passwordHash []byte) *User {
 	return &User{
-		Username:     username,
-		Rank:         rank,
-		PasswordHash: passwordHash,
+		Username:          username,
+		Rank:              rank,
+		PasswordHash:      passwordHash,
+		PasswordUpdatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		UserProfile: UserProfile{
+			Enabled: true,
+		},
 	}
 }
 
@@ -68,5 +142,37 @@ func (u *User) Validate() int {
 		code |= ValidateUserInvalidRank
 	}
 
+	//validate email (an empty email means the user does not have one)
+	if len(u.Email) > UserEmailMaxLength {
+		code |= ValidateUserEmailTooLong
+	} else if u.Email != "" && !isValidEmail(u.Email) {
+		code |= ValidateUserInvalidEmail
+	}
+
+	//validate display name
+	if len(u.DisplayName) > UserDisplayNameMaxLength {
+		code |= ValidateUserDisplayNameTooLong
+	}
+
 	return code
 }
+
+// isValidEmail returns whether the email is a plain address, without a name or angle brackets.
+func isValidEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == email
+}
+
+// IsPasswordExpired checks if the user's password is older than the max age relative to now.
+// A max age of zero means the password never expires.
+func (u *User) IsPasswordExpired(now time.Time, maxAge time.Duration) bool {
+	return maxAge > 0 && now.After(u.PasswordUpdatedAt.Add(maxAge))
+}
+
+// GetCursor returns the user's cursor in a list sorted by the given field.
+func (u *User) GetCursor(sortBy string) Cursor {
+	if sortBy == UserSortRank {
+		return Cursor{Value: strconv.Itoa(u.Rank), Key: u.Username}
+	}
+	return Cursor{Key: u.Username}
+}
@@ -2,6 +2,7 @@ package models_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/mhogar/amber/models"
 	"github.com/mhogar/amber/testing/helpers"
@@ -32,6 +33,8 @@ func (suite *UserTestSuite) TestCreateNewUser_CreatesUserWithSuppliedFields() {
 	suite.Equal(username, user.Username)
 	suite.Equal(hash, user.PasswordHash)
 	suite.Equal(rank, user.Rank)
+	suite.WithinDuration(time.Now(), user.PasswordUpdatedAt, time.Second)
+	suite.True(user.Enabled)
 }
 
 func (suite *UserTestSuite) TestValidate_WithValidUser_ReturnsValid() {
@@ -88,6 +91,156 @@ func (suite *UserTestSuite) TestValidate_WithNegativeRank_ReturnsUserInvalidRank
 	suite.Equal(models.ValidateUserInvalidRank, verr)
 }
 
+func (suite *UserTestSuite) TestValidate_EmailTestCases() {
+	var email string
+	var expectedValidateError int
+
+	testCase := func() {
+		//arrange
+		suite.User.Email = email
+
+		//act
+		verr := suite.User.Validate()
+
+		//assert
+		suite.Equal(expectedValidateError, verr)
+	}
+
+	email = ""
+	expectedValidateError = models.ValidateUserValid
+	suite.Run("EmptyIsValid", testCase)
+
+	email = "user@example.com"
+	expectedValidateError = models.ValidateUserValid
+	suite.Run("AddressIsValid", testCase)
+
+	email = "example.com"
+	expectedValidateError = models.ValidateUserInvalidEmail
+	suite.Run("MissingAtIsInvalid", testCase)
+
+	email = "User <user@example.com>"
+	expectedValidateError = models.ValidateUserInvalidEmail
+	suite.Run("NamedAddressIsInvalid", testCase)
+
+	email = helpers.CreateStringOfLength(models.UserEmailMaxLength-len("@example.com")) + "@example.com"
+	expectedValidateError = models.ValidateUserValid
+	suite.Run("ExactlyMaxLengthIsValid", testCase)
+
+	email = "a" + email
+	expectedValidateError = models.ValidateUserEmailTooLong
+	suite.Run("OneMoreThanMaxLengthIsInvalid", testCase)
+}
+
+func (suite *UserTestSuite) TestValidate_DisplayNameMaxLengthTestCases() {
+	var displayName string
+	var expectedValidateError int
+
+	testCase := func() {
+		//arrange
+		suite.User.DisplayName = displayName
+
+		//act
+		verr := suite.User.Validate()
+
+		//assert
+		suite.Equal(expectedValidateError, verr)
+	}
+
+	displayName = helpers.CreateStringOfLength(models.UserDisplayNameMaxLength)
+	expectedValidateError = models.ValidateUserValid
+	suite.Run("ExactlyMaxLengthIsValid", testCase)
+
+	displayName += "a"
+	expectedValidateError = models.ValidateUserDisplayNameTooLong
+	suite.Run("OneMoreThanMaxLengthIsInvalid", testCase)
+}
+
+func (suite *UserTestSuite) TestGetCursor_SortFieldTestCases() {
+	var sortBy string
+	var expectedCursor models.Cursor
+
+	testCase := func() {
+		//act
+		cursor := suite.User.GetCursor(sortBy)
+
+		//assert
+		suite.Equal(expectedCursor, cursor)
+	}
+
+	suite.User.Rank = 7
+
+	sortBy = models.UserSortUsername
+	expectedCursor = models.Cursor{Key: suite.User.Username}
+	suite.Run("Username", testCase)
+
+	sortBy = models.UserSortRank
+	expectedCursor = models.Cursor{Value: "7", Key: suite.User.Username}
+	suite.Run("Rank", testCase)
+}
+
+func (suite *UserTestSuite) TestIsPasswordExpired_ExpiryTestCases() {
+	var maxAge time.Duration
+	var expected bool
+
+	now := time.Now()
+	suite.User.PasswordUpdatedAt = now.Add(-time.Hour)
+
+	testCase := func() {
+		//act
+		res := suite.User.IsPasswordExpired(now, maxAge)
+
+		//assert
+		suite.Equal(expected, res)
+	}
+
+	maxAge, expected = 2*time.Hour, false
+	suite.Run("WithinMaxAgeIsNotExpired", testCase)
+
+	maxAge, expected = 30*time.Minute, true
+	suite.Run("PastMaxAgeIsExpired", testCase)
+
+	maxAge, expected = 0, false
+	suite.Run("ZeroMaxAgeNeverExpires", testCase)
+}
+
+func (suite *UserTestSuite) TestUserProfileUpdateApply_FieldTestCases() {
+	var update models.UserProfileUpdate
+	var expected models.UserProfile
+
+	profile := models.UserProfile{
+		Email:       "user@example.com",
+		DisplayName: "Display Name",
+		Enabled:     false,
+	}
+
+	testCase := func() {
+		//act
+		res := update.Apply(profile)
+
+		//assert
+		suite.Equal(expected, res)
+	}
+
+	update = models.UserProfileUpdate{}
+	expected = profile
+	suite.Run("UnsetFieldsKeepCurrentValues", testCase)
+
+	email := ""
+	displayName := "New Name"
+	enabled := true
+	update = models.UserProfileUpdate{
+		Email:       &email,
+		DisplayName: &displayName,
+		Enabled:     &enabled,
+	}
+	expected = models.UserProfile{
+		Email:       email,
+		DisplayName: displayName,
+		Enabled:     enabled,
+	}
+	suite.Run("SetFieldsAreReplaced", testCase)
+}
+
 func TestUserTestSuite(t *testing.T) {
 	suite.Run(t, &UserTestSuite{})
 }
@@ -1,64 +1,133 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"sort"
+
+	"github.com/google/uuid"
+)
 
 const (
-	ValidateUserRoleValid       = 0x0
-	ValidateUserRoleEmptyRole   = 0x1
-	ValidateUserRoleRoleTooLong = 0x2
+	ValidateUserRoleValid         = 0x0
+	ValidateUserRoleEmptyRole     = 0x1
+	ValidateUserRoleRoleTooLong   = 0x2
+	ValidateUserRoleNoRoles       = 0x4
+	ValidateUserRoleDuplicateRole = 0x8
 )
 
 // UserRoleRoleMaxLength is the max length a user's username can be.
 const UserRoleRoleMaxLength = 15
 
-// UserRole represents the user-role model.
+// UserRole represents the user-role model. A user can have a set of roles for a client.
 type UserRole struct {
 	ClientUID uuid.UUID `firestore:"client_uid"`
 	Username  string    `firestore:"username"`
-	Role      string    `firestore:"role"`
+	Roles     []string  `firestore:"roles"`
 }
 
 type UserRoleCRUD interface {
-	// CreateUserRole creates the user-role. Returns any errors.
+	// CreateUserRole creates the user-role with all of its roles. Returns any errors.
 	CreateUserRole(role *UserRole) error
 
-	// GetUserRolesWithLesserRankByClientUID fetches the user-roles for the provided client uid and with a rank less than the provided rank.
-	// Returns the user-roles and returns any errors.
-	GetUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank int) ([]*UserRole, error)
+	// GetUserRolesWithLesserRankByClientUID fetches the page of user-roles for the provided client uid and with a rank less than the provided rank, searching by username.
+	// User-roles can be sorted by username or their first role. Returns the user-roles and returns any errors.
+	GetUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank int, query PageQuery) ([]*UserRole, error)
+
+	// CountUserRolesWithLesserRankByClientUID counts the user-roles for the provided client uid and with a rank less than the provided rank whose username starts with the search.
+	// Returns the count and any errors.
+	CountUserRolesWithLesserRankByClientUID(uid uuid.UUID, rank int, search string) (int, error)
 
 	// GetUserRoleByClientUIDAndUsername fetches the user-role for the provided client uid and username.
 	// Returns the user-role if it exists, nil if not. Also returns any errors.
 	GetUserRoleByClientUIDAndUsername(clientUID uuid.UUID, username string) (*UserRole, error)
 
-	// UpdateUserRole updates the user-role.
+	// UpdateUserRole replaces the roles of the user-role.
 	// Returns result of whether the user-role was found and any errors.
 	UpdateUserRole(role *UserRole) (bool, error)
 
-	// DeleteUserRole deletes the user-role with the given client uid and username.
+	// CountUserRolesByClientUIDAndRole counts the user-roles for the provided client uid that include the given role.
+	// Returns the count and any errors.
+	CountUserRolesByClientUIDAndRole(clientUID uuid.UUID, role string) (int, error)
+
+	// RenameUserRoles changes the role to the new role in every user-role for the provided client uid that includes it.
+	// Returns any errors.
+	RenameUserRoles(clientUID uuid.UUID, role string, newRole string) error
+
+	// DeleteUserRole deletes the user-role, and all of its roles, with the given client uid and username.
 	// Returns result of whether the user-role was found, and any errors.
 	DeleteUserRole(clientUID uuid.UUID, username string) (bool, error)
 }
 
-// CreateUserRole creates a new user-role model with the provided fields.
-func CreateUserRole(clientUID uuid.UUID, username string, role string) *UserRole {
-	return &UserRole{
+// CreateUserRole creates a new user-role model with the provided fields. The roles are kept in sorted order.
+func CreateUserRole(clientUID uuid.UUID, username string, roles ...string) *UserRole {
+	role := &UserRole{
 		ClientUID: clientUID,
 		Username:  username,
-		Role:      role,
+		Roles:     append([]string{}, roles...),
+	}
+	sort.Strings(role.Roles)
+
+	return role
+}
+
+// HasRole returns whether the user-role includes the given role.
+func (ur *UserRole) HasRole(role string) bool {
+	for _, r := range ur.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// FirstRole returns the lowest of the user-role's roles, or an empty string if it has none.
+func (ur *UserRole) FirstRole() string {
+	first := ""
+	for i, r := range ur.Roles {
+		if i == 0 || r < first {
+			first = r
+		}
 	}
+	return first
 }
 
 // Validate validates the user-role model has valid fields.
 // Returns an int indicating which fields are invalid.
 func (ur *UserRole) Validate() int {
+	return validateRoles(ur.Roles)
+}
+
+// validateRoles validates a set of roles given to a user or group.
+// Returns an int indicating why the roles are invalid, using the user-role validate codes.
+func validateRoles(roles []string) int {
 	code := ValidateUserRoleValid
 
-	//validate role
-	if ur.Role == "" {
-		code |= ValidateUserRoleEmptyRole
-	} else if len(ur.Role) > UserRoleRoleMaxLength {
-		code |= ValidateUserRoleRoleTooLong
+	//validate roles
+	if len(roles) == 0 {
+		code |= ValidateUserRoleNoRoles
+	}
+
+	seen := map[string]bool{}
+	for _, role := range roles {
+		if role == "" {
+			code |= ValidateUserRoleEmptyRole
+		} else if len(role) > UserRoleRoleMaxLength {
+			code |= ValidateUserRoleRoleTooLong
+		}
+
+		if seen[role] {
+			code |= ValidateUserRoleDuplicateRole
+		}
+		seen[role] = true
 	}
 
 	return code
 }
+
+// GetCursor returns the user-role's cursor in a list sorted by the given field.
+// User-roles are sorted by their first role when sorting by role.
+func (ur *UserRole) GetCursor(sortBy string) Cursor {
+	if sortBy == UserRoleSortRole {
+		return Cursor{Value: ur.FirstRole(), Key: ur.Username}
+	}
+	return Cursor{Key: ur.Username}
+}
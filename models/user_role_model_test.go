@@ -16,21 +16,33 @@ type UserRoleTestSuite struct {
 }
 
 func (suite *UserRoleTestSuite) SetupTest() {
-	suite.UserRole = models.CreateUserRole(uuid.Nil, "username", "role")
+	suite.UserRole = models.CreateUserRole(uuid.Nil, "username", "role", "other")
 }
 
 func (suite *UserRoleTestSuite) TestCreateNewUserRole_CreatesUserRoleWithSuppliedFields() {
 	//arrange
 	username := "this is a test username"
-	role := "this is a test role"
+	roles := []string{"role2", "role1"}
 
 	//act
-	userRole := models.CreateUserRole(uuid.Nil, username, role)
+	userRole := models.CreateUserRole(uuid.Nil, username, roles...)
 
 	//assert
 	suite.Require().NotNil(userRole)
 	suite.Equal(username, userRole.Username)
-	suite.Equal(role, userRole.Role)
+	suite.Equal([]string{"role1", "role2"}, userRole.Roles)
+	suite.Equal([]string{"role2", "role1"}, roles, "the provided roles should not be reordered")
+}
+
+func (suite *UserRoleTestSuite) TestHasRole_ReturnsWhetherTheUserRoleIncludesTheRole() {
+	suite.True(suite.UserRole.HasRole("role"))
+	suite.True(suite.UserRole.HasRole("other"))
+	suite.False(suite.UserRole.HasRole("DNE"))
+}
+
+func (suite *UserRoleTestSuite) TestFirstRole_ReturnsTheLowestRole() {
+	suite.Equal("other", suite.UserRole.FirstRole())
+	suite.Equal("", models.CreateUserRole(uuid.Nil, "username").FirstRole())
 }
 
 func (suite *UserRoleTestSuite) TestValidate_WithValidUserRole_ReturnsValid() {
@@ -41,9 +53,31 @@ func (suite *UserRoleTestSuite) TestValidate_WithValidUserRole_ReturnsValid() {
 	suite.Equal(models.ValidateUserValid, verr)
 }
 
+func (suite *UserRoleTestSuite) TestValidate_WithNoRoles_ReturnsUserRoleNoRoles() {
+	//arrange
+	suite.UserRole.Roles = nil
+
+	//act
+	verr := suite.UserRole.Validate()
+
+	//assert
+	suite.Equal(models.ValidateUserRoleNoRoles, verr)
+}
+
+func (suite *UserRoleTestSuite) TestValidate_WithDuplicateRole_ReturnsUserRoleDuplicateRole() {
+	//arrange
+	suite.UserRole.Roles = []string{"role", "role"}
+
+	//act
+	verr := suite.UserRole.Validate()
+
+	//assert
+	suite.Equal(models.ValidateUserRoleDuplicateRole, verr)
+}
+
 func (suite *UserRoleTestSuite) TestValidate_WithEmptyRole_ReturnsUserRoleEmptyRole() {
 	//arrange
-	suite.UserRole.Role = ""
+	suite.UserRole.Roles = []string{"role", ""}
 
 	//act
 	verr := suite.UserRole.Validate()
@@ -58,7 +92,7 @@ func (suite *UserRoleTestSuite) TestValidate_RoleMaxLengthTestCases() {
 
 	testCase := func() {
 		//arrange
-		suite.UserRole.Role = role
+		suite.UserRole.Roles = []string{"role", role}
 
 		//act
 		verr := suite.UserRole.Validate()
@@ -76,6 +110,27 @@ func (suite *UserRoleTestSuite) TestValidate_RoleMaxLengthTestCases() {
 	suite.Run("OneMoreThanMaxLengthIsInvalid", testCase)
 }
 
+func (suite *UserRoleTestSuite) TestGetCursor_SortFieldTestCases() {
+	var sortBy string
+	var expectedCursor models.Cursor
+
+	testCase := func() {
+		//act
+		cursor := suite.UserRole.GetCursor(sortBy)
+
+		//assert
+		suite.Equal(expectedCursor, cursor)
+	}
+
+	sortBy = models.UserRoleSortUsername
+	expectedCursor = models.Cursor{Key: suite.UserRole.Username}
+	suite.Run("Username", testCase)
+
+	sortBy = models.UserRoleSortRole
+	expectedCursor = models.Cursor{Value: "other", Key: suite.UserRole.Username}
+	suite.Run("Role", testCase)
+}
+
 func TestUserRoleTestSuite(t *testing.T) {
 	suite.Run(t, &UserRoleTestSuite{})
 }
@@ -0,0 +1,286 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	"github.com/mhogar/amber/models"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type AdminRoleDataResponse struct {
+	PostAdminRoleBody
+}
+
+func (h CoreHandlers) GetAdminRoles(req *http.Request, _ httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//get the admin roles
+	roles, cerr := h.Controllers.GetAdminRoles(CRUD)
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessDataResponse(h.newAdminRoleDataResponses(roles))
+}
+
+type PostAdminRoleBody struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+func (h CoreHandlers) PostAdminRole(req *http.Request, _ httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	var body PostAdminRoleBody
+
+	//parse the body
+	err := parseJSONBody(req.Body, &body)
+	if err != nil {
+		log.Println(common.ChainError("error parsing PostAdminRoleBody request body", err))
+		return common.NewBadRequestResponse("invalid json body")
+	}
+
+	//create the model
+	role := models.CreateAdminRole(body.Name, body.Description, body.Permissions...)
+
+	//verify the session has every permission the admin role grants, so admins cannot grant themselves more access
+	//invalid permissions are left for the controller to reject
+	if role.Validate()&models.ValidateAdminRoleInvalidPermission == 0 && !session.HasPermissions(role.Permissions) {
+		return common.NewInsufficientPermissionsErrorResponse()
+	}
+
+	//create the admin role
+	cerr := h.Controllers.CreateAdminRole(CRUD, role)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionCreateAdminRole, role.Name)
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessDataResponse(h.newAdminRoleDataResponse(role))
+}
+
+type PutAdminRoleBody struct {
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+func (h CoreHandlers) PutAdminRole(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	var body PutAdminRoleBody
+
+	//get the name
+	name := params.ByName("name")
+	if name == "" {
+		return common.NewBadRequestResponse("admin role name not provided")
+	}
+
+	//parse the body
+	err := parseJSONBody(req.Body, &body)
+	if err != nil {
+		log.Println(common.ChainError("error parsing PutAdminRoleBody request body", err))
+		return common.NewBadRequestResponse("invalid json body")
+	}
+
+	//create the model
+	role := models.CreateAdminRole(name, body.Description, body.Permissions...)
+
+	//verify the session has every permission the admin role grants, so admins cannot grant themselves more access
+	//invalid permissions are left for the controller to reject
+	if role.Validate()&models.ValidateAdminRoleInvalidPermission == 0 && !session.HasPermissions(role.Permissions) {
+		return common.NewInsufficientPermissionsErrorResponse()
+	}
+
+	//verify the session can change the admin role as it is now
+	res, cerr := h.verifyCanModifyAdminRole(CRUD, session, name)
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+	if !res {
+		return common.NewInsufficientPermissionsErrorResponse()
+	}
+
+	//update the admin role
+	cerr = h.Controllers.UpdateAdminRole(CRUD, role)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionUpdateAdminRole, role.Name)
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessDataResponse(h.newAdminRoleDataResponse(role))
+}
+
+func (h CoreHandlers) DeleteAdminRole(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//get the name
+	name := params.ByName("name")
+	if name == "" {
+		return common.NewBadRequestResponse("admin role name not provided")
+	}
+
+	//verify the session can change the admin role as it is now
+	res, cerr := h.verifyCanModifyAdminRole(CRUD, session, name)
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+	if !res {
+		return common.NewInsufficientPermissionsErrorResponse()
+	}
+
+	//delete the admin role
+	cerr = h.Controllers.DeleteAdminRole(CRUD, name)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionDeleteAdminRole, name)
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessResponse()
+}
+
+func (h CoreHandlers) GetUserAdminRoles(req *http.Request, params httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//get the username
+	username := params.ByName("username")
+	if username == "" {
+		return common.NewBadRequestResponse("username not provided")
+	}
+
+	//get the user's admin roles
+	roles, cerr := h.Controllers.GetUserAdminRoles(CRUD, username)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessDataResponse(h.newAdminRoleDataResponses(roles))
+}
+
+type PutUserAdminRolesBody struct {
+	AdminRoles []string `json:"admin_roles"`
+}
+
+func (h CoreHandlers) PutUserAdminRoles(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	var body PutUserAdminRolesBody
+
+	//get the username
+	username := params.ByName("username")
+	if username == "" {
+		return common.NewBadRequestResponse("username not provided")
+	}
+
+	//parse the body
+	err := parseJSONBody(req.Body, &body)
+	if err != nil {
+		log.Println(common.ChainError("error parsing PutUserAdminRolesBody request body", err))
+		return common.NewBadRequestResponse("invalid json body")
+	}
+
+	//verify the session has a greater rank than the user
+	res, cerr := h.Controllers.VerifyUserRank(CRUD, username, session.Rank)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+	if !res {
+		return common.NewInsufficientPermissionsErrorResponse()
+	}
+
+	//verify the session has every permission the admin roles grant
+	res, cerr = h.verifyCanGrantAdminRoles(CRUD, session, body.AdminRoles)
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+	if !res {
+		return common.NewInsufficientPermissionsErrorResponse()
+	}
+
+	//replace the user's admin roles
+	roles, cerr := h.Controllers.UpdateUserAdminRoles(CRUD, username, body.AdminRoles)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionUpdateUserAdminRoles, username)
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessDataResponse(h.newAdminRoleDataResponses(roles))
+}
+
+// verifyCanGrantAdminRoles verifies the session has every permission granted by the admin roles with the given names.
+// Names that are not admin roles are left for the controller to reject.
+func (h CoreHandlers) verifyCanGrantAdminRoles(CRUD data.DataCRUD, session *models.Session, names []string) (bool, common.CustomError) {
+	roles, cerr := h.Controllers.GetAdminRoles(CRUD)
+	if cerr.Type != common.ErrorTypeNone {
+		return false, cerr
+	}
+
+	for _, role := range roles {
+		for _, name := range names {
+			if role.Name == name && !session.HasPermissions(role.Permissions) {
+				return false, common.NoError()
+			}
+		}
+	}
+
+	return true, common.NoError()
+}
+
+// verifyCanModifyAdminRole verifies the session has every permission the admin role with the given name currently grants,
+// and has a greater rank than every other user the admin role was given to, so admins cannot take access away from those above them.
+func (h CoreHandlers) verifyCanModifyAdminRole(CRUD data.DataCRUD, session *models.Session, name string) (bool, common.CustomError) {
+	res, cerr := h.verifyCanGrantAdminRoles(CRUD, session, []string{name})
+	if cerr.Type != common.ErrorTypeNone || !res {
+		return false, cerr
+	}
+
+	return h.Controllers.VerifyAdminRoleRank(CRUD, name, session.Username, session.Rank)
+}
+
+func (h CoreHandlers) newAdminRoleDataResponses(roles []*models.AdminRole) []AdminRoleDataResponse {
+	data := make([]AdminRoleDataResponse, len(roles))
+	for index, role := range roles {
+		data[index] = h.newAdminRoleDataResponse(role)
+	}
+	return data
+}
+
+func (CoreHandlers) newAdminRoleDataResponse(role *models.AdminRole) AdminRoleDataResponse {
+	return AdminRoleDataResponse{
+		PostAdminRoleBody: PostAdminRoleBody{
+			Name:        role.Name,
+			Description: role.Description,
+			Permissions: role.Permissions,
+		},
+	}
+}
@@ -0,0 +1,839 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/router/handlers"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AdminRoleHandlerTestSuite struct {
+	HandlersTestSuite
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestGetAdminRoles_WithErrorGettingAdminRoles_ReturnsInternalServerError() {
+	//arrange
+	suite.ControllersMock.On("GetAdminRoles", mock.Anything).Return(nil, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.GetAdminRoles(nil, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestGetAdminRoles_WithNoErrors_ReturnsAdminRoleData() {
+	//arrange
+	roles := []*models.AdminRole{
+		models.CreateAdminRole("auditor", "Views the audit log", models.PermissionAuditRead),
+		models.CreateAdminRole("empty", ""),
+	}
+	suite.ControllersMock.On("GetAdminRoles", mock.Anything).Return(roles, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.GetAdminRoles(nil, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, []handlers.AdminRoleDataResponse{
+		{
+			PostAdminRoleBody: handlers.PostAdminRoleBody{
+				Name:        roles[0].Name,
+				Description: roles[0].Description,
+				Permissions: roles[0].Permissions,
+			},
+		},
+		{
+			PostAdminRoleBody: handlers.PostAdminRoleBody{
+				Name:        roles[1].Name,
+				Description: roles[1].Description,
+				Permissions: roles[1].Permissions,
+			},
+		},
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "GetAdminRoles", &suite.CRUDMock)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPostAdminRole_WithInvalidJSONBody_ReturnsBadRequest() {
+	//arrange
+	req := suite.CreateDummyJSONRequest("invalid")
+
+	//act
+	status, res := suite.CoreHandlers.PostAdminRole(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "invalid json body")
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPostAdminRole_WithPermissionSessionDoesNotHave_ReturnsForbidden() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	session.Permissions = []string{models.PermissionUsersRead}
+
+	req := suite.CreateDummyJSONRequest(handlers.PostAdminRoleBody{
+		Name:        "role",
+		Permissions: []string{models.PermissionUsersRead, models.PermissionAdminRolesWrite},
+	})
+
+	//act
+	status, res := suite.CoreHandlers.PostAdminRole(req, nil, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusForbidden, status)
+	suite.InsufficientPermissionsErrorResponse(res)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "CreateAdminRole", mock.Anything, mock.Anything)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPostAdminRole_WithClientErrorCreatingAdminRole_ReturnsBadRequest() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	req := suite.CreateDummyJSONRequest(handlers.PostAdminRoleBody{
+		Name: "role",
+	})
+
+	message := "create admin role error"
+	suite.ControllersMock.On("CreateAdminRole", mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostAdminRole(req, nil, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPostAdminRole_WithInternalErrorCreatingAdminRole_ReturnsInternalServerError() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	req := suite.CreateDummyJSONRequest(handlers.PostAdminRoleBody{
+		Name: "role",
+	})
+
+	suite.ControllersMock.On("CreateAdminRole", mock.Anything, mock.Anything).Return(common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostAdminRole(req, nil, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPostAdminRole_WithErrorCreatingAuditEvent_ReturnsInternalServerError() {
+	//arrange
+	suite.FailAuditEvents()
+
+	session := models.CreateNewSession("admin", 5)
+	req := suite.CreateDummyJSONRequest(handlers.PostAdminRoleBody{
+		Name: "role",
+	})
+
+	suite.ControllersMock.On("CreateAdminRole", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostAdminRole(req, nil, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPostAdminRole_WithNoErrors_ReturnsAdminRoleData() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	session.Permissions = []string{models.PermissionUsersRead, models.PermissionUsersWrite, models.PermissionAuditRead}
+
+	body := handlers.PostAdminRoleBody{
+		Name:        "role",
+		Description: "description",
+		Permissions: []string{models.PermissionUsersRead, models.PermissionUsersWrite},
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	suite.ControllersMock.On("CreateAdminRole", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostAdminRole(req, nil, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, handlers.AdminRoleDataResponse{
+		PostAdminRoleBody: body,
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateAdminRole", &suite.CRUDMock, models.CreateAdminRole(body.Name, body.Description, body.Permissions...))
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionCreateAdminRole, body.Name)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPutAdminRole_WithoutName_ReturnsBadRequest() {
+	//act
+	status, res := suite.CoreHandlers.PutAdminRole(nil, []httprouter.Param{}, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "admin role name", "not provided")
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPutAdminRole_WithInvalidJSONBody_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "role",
+		},
+	}
+	req := suite.CreateDummyJSONRequest("invalid")
+
+	//act
+	status, res := suite.CoreHandlers.PutAdminRole(req, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "invalid json body")
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPutAdminRole_WithPermissionSessionDoesNotHave_ReturnsForbidden() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	session.Permissions = []string{models.ScopePermission(models.PermissionRolesWrite, uuid.New())}
+
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "role",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PutAdminRoleBody{
+		Permissions: []string{models.PermissionRolesWrite},
+	})
+
+	//act
+	status, res := suite.CoreHandlers.PutAdminRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusForbidden, status)
+	suite.InsufficientPermissionsErrorResponse(res)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "UpdateAdminRole", mock.Anything, mock.Anything)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPutAdminRole_WithErrorGettingAdminRoles_ReturnsInternalServerError() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "role",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PutAdminRoleBody{})
+
+	suite.ControllersMock.On("GetAdminRoles", mock.Anything).Return(nil, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PutAdminRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPutAdminRole_WhereAdminRoleHasPermissionSessionDoesNotHave_ReturnsForbidden() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	session.Permissions = []string{models.PermissionAuditRead}
+
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "role",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PutAdminRoleBody{})
+
+	roles := []*models.AdminRole{
+		models.CreateAdminRole("role", "", models.PermissionAuditRead, models.PermissionUsersWrite),
+	}
+	suite.ControllersMock.On("GetAdminRoles", mock.Anything).Return(roles, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PutAdminRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusForbidden, status)
+	suite.InsufficientPermissionsErrorResponse(res)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "UpdateAdminRole", mock.Anything, mock.Anything)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPutAdminRole_WithErrorVerifyingAdminRoleRank_ReturnsInternalServerError() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "role",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PutAdminRoleBody{})
+
+	suite.ControllersMock.On("GetAdminRoles", mock.Anything).Return([]*models.AdminRole{}, common.NoError())
+	suite.ControllersMock.On("VerifyAdminRoleRank", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(false, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PutAdminRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPutAdminRole_WhereAdminRoleUserRankIsNotLess_ReturnsForbidden() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "role",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PutAdminRoleBody{})
+
+	suite.ControllersMock.On("GetAdminRoles", mock.Anything).Return([]*models.AdminRole{}, common.NoError())
+	suite.ControllersMock.On("VerifyAdminRoleRank", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(false, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PutAdminRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusForbidden, status)
+	suite.InsufficientPermissionsErrorResponse(res)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "UpdateAdminRole", mock.Anything, mock.Anything)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPutAdminRole_WithClientErrorUpdatingAdminRole_ReturnsBadRequest() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "role",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PutAdminRoleBody{})
+
+	message := "update admin role error"
+	suite.ControllersMock.On("GetAdminRoles", mock.Anything).Return([]*models.AdminRole{}, common.NoError())
+	suite.ControllersMock.On("VerifyAdminRoleRank", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("UpdateAdminRole", mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PutAdminRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPutAdminRole_WithInternalErrorUpdatingAdminRole_ReturnsInternalServerError() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "role",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PutAdminRoleBody{})
+
+	suite.ControllersMock.On("GetAdminRoles", mock.Anything).Return([]*models.AdminRole{}, common.NoError())
+	suite.ControllersMock.On("VerifyAdminRoleRank", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("UpdateAdminRole", mock.Anything, mock.Anything).Return(common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PutAdminRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPutAdminRole_WithNoErrors_ReturnsAdminRoleData() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	session.Permissions = []string{models.PermissionAuditRead}
+	name := "role"
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: name,
+		},
+	}
+
+	body := handlers.PutAdminRoleBody{
+		Description: "description",
+		Permissions: []string{models.PermissionAuditRead},
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	suite.ControllersMock.On("GetAdminRoles", mock.Anything).Return([]*models.AdminRole{}, common.NoError())
+	suite.ControllersMock.On("VerifyAdminRoleRank", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("UpdateAdminRole", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PutAdminRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, handlers.AdminRoleDataResponse{
+		PostAdminRoleBody: handlers.PostAdminRoleBody{
+			Name:        name,
+			Description: body.Description,
+			Permissions: body.Permissions,
+		},
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "VerifyAdminRoleRank", &suite.CRUDMock, name, session.Username, session.Rank)
+	suite.ControllersMock.AssertCalled(suite.T(), "UpdateAdminRole", &suite.CRUDMock, models.CreateAdminRole(name, body.Description, body.Permissions...))
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionUpdateAdminRole, name)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestDeleteAdminRole_WithoutName_ReturnsBadRequest() {
+	//act
+	status, res := suite.CoreHandlers.DeleteAdminRole(nil, []httprouter.Param{}, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "admin role name", "not provided")
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestDeleteAdminRole_WithErrorGettingAdminRoles_ReturnsInternalServerError() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "role",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(nil)
+
+	suite.ControllersMock.On("GetAdminRoles", mock.Anything).Return(nil, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteAdminRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestDeleteAdminRole_WhereAdminRoleHasPermissionSessionDoesNotHave_ReturnsForbidden() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	session.Permissions = []string{models.PermissionAuditRead}
+
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "role",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(nil)
+
+	roles := []*models.AdminRole{
+		models.CreateAdminRole("role", "", models.PermissionAuditRead, models.PermissionUsersWrite),
+	}
+	suite.ControllersMock.On("GetAdminRoles", mock.Anything).Return(roles, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteAdminRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusForbidden, status)
+	suite.InsufficientPermissionsErrorResponse(res)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "DeleteAdminRole", mock.Anything, mock.Anything)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestDeleteAdminRole_WithErrorVerifyingAdminRoleRank_ReturnsInternalServerError() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "role",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(nil)
+
+	suite.ControllersMock.On("GetAdminRoles", mock.Anything).Return([]*models.AdminRole{}, common.NoError())
+	suite.ControllersMock.On("VerifyAdminRoleRank", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(false, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteAdminRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestDeleteAdminRole_WhereAdminRoleUserRankIsNotLess_ReturnsForbidden() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "role",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(nil)
+
+	suite.ControllersMock.On("GetAdminRoles", mock.Anything).Return([]*models.AdminRole{}, common.NoError())
+	suite.ControllersMock.On("VerifyAdminRoleRank", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(false, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteAdminRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusForbidden, status)
+	suite.InsufficientPermissionsErrorResponse(res)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "DeleteAdminRole", mock.Anything, mock.Anything)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestDeleteAdminRole_WithClientErrorDeletingAdminRole_ReturnsBadRequest() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "role",
+		},
+	}
+
+	message := "delete admin role error"
+	suite.ControllersMock.On("GetAdminRoles", mock.Anything).Return([]*models.AdminRole{}, common.NoError())
+	suite.ControllersMock.On("VerifyAdminRoleRank", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("DeleteAdminRole", mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.DeleteAdminRole(nil, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestDeleteAdminRole_WithNoErrors_ReturnsSuccess() {
+	//arrange
+	req := suite.CreateDummyJSONRequest(nil)
+	session := models.CreateNewSession("admin", 5)
+	name := "role"
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: name,
+		},
+	}
+
+	suite.ControllersMock.On("GetAdminRoles", mock.Anything).Return([]*models.AdminRole{}, common.NoError())
+	suite.ControllersMock.On("VerifyAdminRoleRank", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("DeleteAdminRole", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteAdminRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessResponse(res)
+
+	suite.ControllersMock.AssertCalled(suite.T(), "VerifyAdminRoleRank", &suite.CRUDMock, name, session.Username, session.Rank)
+	suite.ControllersMock.AssertCalled(suite.T(), "DeleteAdminRole", &suite.CRUDMock, name)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionDeleteAdminRole, name)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestGetUserAdminRoles_WithoutUsername_ReturnsBadRequest() {
+	//act
+	status, res := suite.CoreHandlers.GetUserAdminRoles(nil, []httprouter.Param{}, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "username", "not provided")
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestGetUserAdminRoles_WithClientErrorGettingAdminRoles_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "username",
+			Value: "username",
+		},
+	}
+
+	message := "get user admin roles error"
+	suite.ControllersMock.On("GetUserAdminRoles", mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.GetUserAdminRoles(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestGetUserAdminRoles_WithNoErrors_ReturnsAdminRoleData() {
+	//arrange
+	username := "username"
+	params := []httprouter.Param{
+		{
+			Key:   "username",
+			Value: username,
+		},
+	}
+
+	roles := []*models.AdminRole{models.CreateAdminRole("auditor", "", models.PermissionAuditRead)}
+	suite.ControllersMock.On("GetUserAdminRoles", mock.Anything, mock.Anything).Return(roles, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.GetUserAdminRoles(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, []handlers.AdminRoleDataResponse{
+		{
+			PostAdminRoleBody: handlers.PostAdminRoleBody{
+				Name:        roles[0].Name,
+				Description: roles[0].Description,
+				Permissions: roles[0].Permissions,
+			},
+		},
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "GetUserAdminRoles", &suite.CRUDMock, username)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPutUserAdminRoles_WithoutUsername_ReturnsBadRequest() {
+	//act
+	status, res := suite.CoreHandlers.PutUserAdminRoles(nil, []httprouter.Param{}, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "username", "not provided")
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPutUserAdminRoles_WithInvalidJSONBody_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "username",
+			Value: "username",
+		},
+	}
+	req := suite.CreateDummyJSONRequest("invalid")
+
+	//act
+	status, res := suite.CoreHandlers.PutUserAdminRoles(req, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "invalid json body")
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPutUserAdminRoles_WithClientErrorVerifyingUserRank_ReturnsBadRequest() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "username",
+			Value: "username",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PutUserAdminRolesBody{})
+
+	message := "verify user rank error"
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(false, common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PutUserAdminRoles(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPutUserAdminRoles_WhereUserRankIsNotLess_ReturnsForbidden() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	username := "username"
+	params := []httprouter.Param{
+		{
+			Key:   "username",
+			Value: username,
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PutUserAdminRolesBody{})
+
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(false, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PutUserAdminRoles(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusForbidden, status)
+	suite.InsufficientPermissionsErrorResponse(res)
+
+	suite.ControllersMock.AssertCalled(suite.T(), "VerifyUserRank", &suite.CRUDMock, username, session.Rank)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPutUserAdminRoles_WithErrorGettingAdminRoles_ReturnsInternalServerError() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "username",
+			Value: "username",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PutUserAdminRolesBody{AdminRoles: []string{"auditor"}})
+
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("GetAdminRoles", mock.Anything).Return(nil, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PutUserAdminRoles(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPutUserAdminRoles_WhereAdminRoleHasPermissionSessionDoesNotHave_ReturnsForbidden() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	session.Permissions = []string{models.PermissionAuditRead}
+
+	params := []httprouter.Param{
+		{
+			Key:   "username",
+			Value: "username",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PutUserAdminRolesBody{AdminRoles: []string{"auditor", "lockout_admin"}})
+
+	roles := []*models.AdminRole{
+		models.CreateAdminRole("auditor", "", models.PermissionAuditRead),
+		models.CreateAdminRole("lockout_admin", "", models.PermissionLockoutsRead, models.PermissionLockoutsWrite),
+		models.CreateAdminRole("user_admin", "", models.PermissionUsersWrite),
+	}
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("GetAdminRoles", mock.Anything).Return(roles, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PutUserAdminRoles(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusForbidden, status)
+	suite.InsufficientPermissionsErrorResponse(res)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "UpdateUserAdminRoles", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPutUserAdminRoles_WithClientErrorUpdatingUserAdminRoles_ReturnsBadRequest() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "username",
+			Value: "username",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PutUserAdminRolesBody{})
+
+	message := "update user admin roles error"
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("GetAdminRoles", mock.Anything).Return([]*models.AdminRole{}, common.NoError())
+	suite.ControllersMock.On("UpdateUserAdminRoles", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PutUserAdminRoles(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPutUserAdminRoles_WithErrorCreatingAuditEvent_ReturnsInternalServerError() {
+	//arrange
+	suite.FailAuditEvents()
+
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "username",
+			Value: "username",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PutUserAdminRolesBody{})
+
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("GetAdminRoles", mock.Anything).Return([]*models.AdminRole{}, common.NoError())
+	suite.ControllersMock.On("UpdateUserAdminRoles", mock.Anything, mock.Anything, mock.Anything).Return([]*models.AdminRole{}, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PutUserAdminRoles(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *AdminRoleHandlerTestSuite) TestPutUserAdminRoles_WithNoErrors_ReturnsAdminRoleData() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	session.Permissions = []string{models.PermissionAuditRead}
+	username := "username"
+	params := []httprouter.Param{
+		{
+			Key:   "username",
+			Value: username,
+		},
+	}
+
+	body := handlers.PutUserAdminRolesBody{
+		AdminRoles: []string{"auditor"},
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	roles := []*models.AdminRole{models.CreateAdminRole("auditor", "", models.PermissionAuditRead)}
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("GetAdminRoles", mock.Anything).Return(roles, common.NoError())
+	suite.ControllersMock.On("UpdateUserAdminRoles", mock.Anything, mock.Anything, mock.Anything).Return(roles, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PutUserAdminRoles(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, []handlers.AdminRoleDataResponse{
+		{
+			PostAdminRoleBody: handlers.PostAdminRoleBody{
+				Name:        roles[0].Name,
+				Description: roles[0].Description,
+				Permissions: roles[0].Permissions,
+			},
+		},
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "UpdateUserAdminRoles", &suite.CRUDMock, username, body.AdminRoles)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionUpdateUserAdminRoles, username)
+}
+
+func TestAdminRoleHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &AdminRoleHandlerTestSuite{})
+}
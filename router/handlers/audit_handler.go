@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	"github.com/mhogar/amber/models"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type AuditEventDataResponse struct {
+	ID        string    `json:"id"`
+	Actor     string    `json:"actor"`
+	Action    string    `json:"action"`
+	Target    string    `json:"target"`
+	IPAddress string    `json:"ip_address"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (h CoreHandlers) GetAuditEvents(req *http.Request, _ httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	query := req.URL.Query()
+
+	filter := models.AuditEventFilter{
+		Actor:  query.Get("actor"),
+		Action: query.Get("action"),
+		Target: query.Get("target"),
+	}
+
+	//parse the time range
+	var err error
+	filter.Since, err = parseTimeQueryParam(query, "since")
+	if err != nil {
+		log.Println(common.ChainError("error parsing since", err))
+		return common.NewBadRequestResponse("since must be an RFC3339 timestamp")
+	}
+
+	filter.Until, err = parseTimeQueryParam(query, "until")
+	if err != nil {
+		log.Println(common.ChainError("error parsing until", err))
+		return common.NewBadRequestResponse("until must be an RFC3339 timestamp")
+	}
+
+	//parse the page
+	filter.Limit, err = parseIntQueryParam(query, "limit")
+	if err != nil {
+		log.Println(common.ChainError("error parsing limit", err))
+		return common.NewBadRequestResponse("limit must be an integer")
+	}
+
+	filter.Offset, err = parseIntQueryParam(query, "offset")
+	if err != nil {
+		log.Println(common.ChainError("error parsing offset", err))
+		return common.NewBadRequestResponse("offset must be an integer")
+	}
+
+	//get the events
+	events, cerr := h.Controllers.GetAuditEvents(CRUD, filter)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//return the data
+	data := make([]AuditEventDataResponse, len(events))
+	for index, event := range events {
+		data[index] = AuditEventDataResponse{
+			ID:        event.ID.String(),
+			Actor:     event.Actor,
+			Action:    event.Action,
+			Target:    event.Target,
+			IPAddress: event.IPAddress,
+			Timestamp: event.Timestamp,
+		}
+	}
+	return common.NewSuccessDataResponse(data)
+}
+
+// parseTimeQueryParam parses the RFC3339 timestamp in the query param with the given name.
+// Returns a zero time if the param is not provided.
+func parseTimeQueryParam(query url.Values, name string) (time.Time, error) {
+	value := query.Get(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// parseIntQueryParam parses the integer in the query param with the given name.
+// Returns zero if the param is not provided.
+func parseIntQueryParam(query url.Values, name string) (int, error) {
+	value := query.Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
@@ -0,0 +1,120 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/router/handlers"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditHandlerTestSuite struct {
+	HandlersTestSuite
+}
+
+func (suite *AuditHandlerTestSuite) TestGetAuditEvents_InvalidQueryTestCases() {
+	var query string
+	var expectedErrorSubStrings []string
+
+	testCase := func() {
+		//arrange
+		req := suite.CreateRequest(http.MethodGet, "/audit?"+query, "", nil)
+
+		//act
+		status, res := suite.CoreHandlers.GetAuditEvents(req, nil, nil, &suite.CRUDMock)
+
+		//assert
+		suite.Require().Equal(http.StatusBadRequest, status)
+		suite.ErrorResponse(res, expectedErrorSubStrings...)
+		suite.ControllersMock.AssertNotCalled(suite.T(), "GetAuditEvents", mock.Anything, mock.Anything)
+	}
+
+	query = "since=yesterday"
+	expectedErrorSubStrings = []string{"since", "RFC3339"}
+	suite.Run("InvalidSince", testCase)
+
+	query = "until=2021-01-01"
+	expectedErrorSubStrings = []string{"until", "RFC3339"}
+	suite.Run("InvalidUntil", testCase)
+
+	query = "limit=ten"
+	expectedErrorSubStrings = []string{"limit", "integer"}
+	suite.Run("InvalidLimit", testCase)
+
+	query = "offset=1.5"
+	expectedErrorSubStrings = []string{"offset", "integer"}
+	suite.Run("InvalidOffset", testCase)
+}
+
+func (suite *AuditHandlerTestSuite) TestGetAuditEvents_WithClientErrorGettingAuditEvents_ReturnsBadRequest() {
+	//arrange
+	req := suite.CreateRequest(http.MethodGet, "/audit", "", nil)
+
+	message := "get audit events error"
+	suite.ControllersMock.On("GetAuditEvents", mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.GetAuditEvents(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *AuditHandlerTestSuite) TestGetAuditEvents_WithInternalErrorGettingAuditEvents_ReturnsInternalServerError() {
+	//arrange
+	req := suite.CreateRequest(http.MethodGet, "/audit", "", nil)
+	suite.ControllersMock.On("GetAuditEvents", mock.Anything, mock.Anything).Return(nil, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.GetAuditEvents(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *AuditHandlerTestSuite) TestGetAuditEvents_WithNoErrors_ReturnsAuditEventData() {
+	//arrange
+	req := suite.CreateRequest(http.MethodGet, "/audit?actor=admin&action=user.delete&target=username&since=2021-01-01T00:00:00Z&until=2021-02-01T00:00:00Z&limit=10&offset=20", "", nil)
+
+	events := []*models.AuditEvent{
+		models.CreateNewAuditEvent("admin", models.AuditActionDeleteUser, "username", "127.0.0.1"),
+	}
+	suite.ControllersMock.On("GetAuditEvents", mock.Anything, mock.Anything).Return(events, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.GetAuditEvents(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, []handlers.AuditEventDataResponse{
+		{
+			ID:        events[0].ID.String(),
+			Actor:     events[0].Actor,
+			Action:    events[0].Action,
+			Target:    events[0].Target,
+			IPAddress: events[0].IPAddress,
+			Timestamp: events[0].Timestamp,
+		},
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "GetAuditEvents", &suite.CRUDMock, models.AuditEventFilter{
+		Actor:  "admin",
+		Action: models.AuditActionDeleteUser,
+		Target: "username",
+		Since:  time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		Until:  time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+		Limit:  10,
+		Offset: 20,
+	})
+}
+
+func TestAuditHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &AuditHandlerTestSuite{})
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+)
+
+type ClientAdminDataResponse struct {
+	PostClientAdminBody
+}
+
+func (h CoreHandlers) GetClientAdmins(req *http.Request, params httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//parse the client id
+	clientID, err := uuid.Parse(params.ByName("id"))
+	if err != nil {
+		log.Println(common.ChainError("error parsing client id", err))
+		return common.NewBadRequestResponse("client id is in an invalid format")
+	}
+
+	//get the client admins
+	admins, cerr := h.Controllers.GetClientAdminsByClientUID(CRUD, clientID)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//return the data
+	data := make([]ClientAdminDataResponse, len(admins))
+	for index, admin := range admins {
+		data[index] = h.newClientAdminDataResponse(admin)
+	}
+	return common.NewSuccessDataResponse(data)
+}
+
+type PostClientAdminBody struct {
+	Username string `json:"username"`
+	IsOwner  bool   `json:"is_owner"`
+}
+
+func (h CoreHandlers) PostClientAdmin(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	var body PostClientAdminBody
+
+	//parse the client id
+	clientID, err := uuid.Parse(params.ByName("id"))
+	if err != nil {
+		log.Println(common.ChainError("error parsing client id", err))
+		return common.NewBadRequestResponse("client id is in an invalid format")
+	}
+
+	//parse the body
+	err = parseJSONBody(req.Body, &body)
+	if err != nil {
+		log.Println(common.ChainError("error parsing PostClientAdminBody request body", err))
+		return common.NewBadRequestResponse("invalid json body")
+	}
+
+	//create the model
+	admin := models.CreateClientAdmin(clientID, body.Username, body.IsOwner)
+
+	//create the client admin
+	cerr := h.Controllers.CreateClientAdmin(CRUD, admin)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionCreateClientAdmin, userRoleAuditTarget(clientID, admin.Username))
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessDataResponse(h.newClientAdminDataResponse(admin))
+}
+
+func (h CoreHandlers) DeleteClientAdmin(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//parse the client id
+	clientID, err := uuid.Parse(params.ByName("id"))
+	if err != nil {
+		log.Println(common.ChainError("error parsing client id", err))
+		return common.NewBadRequestResponse("client id is in an invalid format")
+	}
+
+	//get the username
+	username := params.ByName("username")
+	if username == "" {
+		return common.NewBadRequestResponse("username not provided")
+	}
+
+	//delete the client admin
+	cerr := h.Controllers.DeleteClientAdmin(CRUD, clientID, username)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionDeleteClientAdmin, userRoleAuditTarget(clientID, username))
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessResponse()
+}
+
+func (CoreHandlers) newClientAdminDataResponse(admin *models.ClientAdmin) ClientAdminDataResponse {
+	return ClientAdminDataResponse{
+		PostClientAdminBody: PostClientAdminBody{
+			Username: admin.Username,
+			IsOwner:  admin.IsOwner,
+		},
+	}
+}
@@ -0,0 +1,371 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/router/handlers"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ClientAdminHandlerTestSuite struct {
+	HandlersTestSuite
+}
+
+func (suite *ClientAdminHandlerTestSuite) TestGetClientAdmins_WithInvalidClientID_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: "invalid",
+		},
+	}
+
+	//act
+	status, res := suite.CoreHandlers.GetClientAdmins(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "client id", "invalid format")
+}
+
+func (suite *ClientAdminHandlerTestSuite) TestGetClientAdmins_WithClientErrorGettingClientAdmins_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	message := "get client admins error"
+	suite.ControllersMock.On("GetClientAdminsByClientUID", mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.GetClientAdmins(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *ClientAdminHandlerTestSuite) TestGetClientAdmins_WithInternalErrorGettingClientAdmins_ReturnsInternalServerError() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	suite.ControllersMock.On("GetClientAdminsByClientUID", mock.Anything, mock.Anything).Return(nil, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.GetClientAdmins(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *ClientAdminHandlerTestSuite) TestGetClientAdmins_WithNoErrors_ReturnsClientAdminData() {
+	//arrange
+	clientUID := uuid.New()
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: clientUID.String(),
+		},
+	}
+
+	admins := []*models.ClientAdmin{
+		models.CreateClientAdmin(clientUID, "owner", true),
+		models.CreateClientAdmin(clientUID, "username", false),
+	}
+	suite.ControllersMock.On("GetClientAdminsByClientUID", mock.Anything, mock.Anything).Return(admins, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.GetClientAdmins(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, []handlers.ClientAdminDataResponse{
+		{
+			PostClientAdminBody: handlers.PostClientAdminBody{
+				Username: admins[0].Username,
+				IsOwner:  admins[0].IsOwner,
+			},
+		},
+		{
+			PostClientAdminBody: handlers.PostClientAdminBody{
+				Username: admins[1].Username,
+				IsOwner:  admins[1].IsOwner,
+			},
+		},
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "GetClientAdminsByClientUID", &suite.CRUDMock, clientUID)
+}
+
+func (suite *ClientAdminHandlerTestSuite) TestPostClientAdmin_WithInvalidClientID_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: "invalid",
+		},
+	}
+
+	//act
+	status, res := suite.CoreHandlers.PostClientAdmin(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "client id", "invalid format")
+}
+
+func (suite *ClientAdminHandlerTestSuite) TestPostClientAdmin_WithInvalidJSONBody_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+	req := suite.CreateDummyJSONRequest("invalid")
+
+	//act
+	status, res := suite.CoreHandlers.PostClientAdmin(req, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "invalid json body")
+}
+
+func (suite *ClientAdminHandlerTestSuite) TestPostClientAdmin_WithClientErrorCreatingClientAdmin_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	body := handlers.PostClientAdminBody{
+		Username: "username",
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	message := "create client admin error"
+	suite.ControllersMock.On("CreateClientAdmin", mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostClientAdmin(req, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *ClientAdminHandlerTestSuite) TestPostClientAdmin_WithInternalErrorCreatingClientAdmin_ReturnsInternalServerError() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	body := handlers.PostClientAdminBody{
+		Username: "username",
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	suite.ControllersMock.On("CreateClientAdmin", mock.Anything, mock.Anything).Return(common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostClientAdmin(req, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *ClientAdminHandlerTestSuite) TestPostClientAdmin_WithErrorCreatingAuditEvent_ReturnsInternalServerError() {
+	//arrange
+	suite.FailAuditEvents()
+
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	body := handlers.PostClientAdminBody{
+		Username: "username",
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	suite.ControllersMock.On("CreateClientAdmin", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostClientAdmin(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *ClientAdminHandlerTestSuite) TestPostClientAdmin_WithNoErrors_ReturnsClientAdminData() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	clientUID := uuid.New()
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: clientUID.String(),
+		},
+	}
+
+	body := handlers.PostClientAdminBody{
+		Username: "username",
+		IsOwner:  true,
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	suite.ControllersMock.On("CreateClientAdmin", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostClientAdmin(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, handlers.ClientAdminDataResponse{
+		PostClientAdminBody: body,
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateClientAdmin", &suite.CRUDMock, models.CreateClientAdmin(clientUID, body.Username, body.IsOwner))
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionCreateClientAdmin, clientUID.String()+"/"+body.Username)
+}
+
+func (suite *ClientAdminHandlerTestSuite) TestDeleteClientAdmin_WithInvalidClientID_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: "invalid",
+		},
+	}
+
+	//act
+	status, res := suite.CoreHandlers.DeleteClientAdmin(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "client id", "invalid format")
+}
+
+func (suite *ClientAdminHandlerTestSuite) TestDeleteClientAdmin_WithMissingUsername_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	//act
+	status, res := suite.CoreHandlers.DeleteClientAdmin(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "username not provided")
+}
+
+func (suite *ClientAdminHandlerTestSuite) TestDeleteClientAdmin_WithClientErrorDeletingClientAdmin_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+		{
+			Key:   "username",
+			Value: "username",
+		},
+	}
+
+	message := "delete client admin error"
+	suite.ControllersMock.On("DeleteClientAdmin", mock.Anything, mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.DeleteClientAdmin(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *ClientAdminHandlerTestSuite) TestDeleteClientAdmin_WithInternalErrorDeletingClientAdmin_ReturnsInternalServerError() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+		{
+			Key:   "username",
+			Value: "username",
+		},
+	}
+
+	suite.ControllersMock.On("DeleteClientAdmin", mock.Anything, mock.Anything, mock.Anything).Return(common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteClientAdmin(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *ClientAdminHandlerTestSuite) TestDeleteClientAdmin_WithNoErrors_ReturnsSuccess() {
+	//arrange
+	req := suite.CreateDummyJSONRequest(nil)
+	session := models.CreateNewSession("admin", 5)
+	clientUID := uuid.New()
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: clientUID.String(),
+		},
+		{
+			Key:   "username",
+			Value: "username",
+		},
+	}
+
+	suite.ControllersMock.On("DeleteClientAdmin", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteClientAdmin(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessResponse(res)
+
+	suite.ControllersMock.AssertCalled(suite.T(), "DeleteClientAdmin", &suite.CRUDMock, clientUID, params[1].Value)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionDeleteClientAdmin, clientUID.String()+"/"+params[1].Value)
+}
+
+func TestClientAdminHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &ClientAdminHandlerTestSuite{})
+}
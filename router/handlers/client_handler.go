@@ -17,9 +17,15 @@ type ClientDataResponse struct {
 	PostClientBody
 }
 
-func (h CoreHandlers) GetClients(_ *http.Request, _ httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+func (h CoreHandlers) GetClients(req *http.Request, _ httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//parse the page query
+	query, err := parsePageQuery(req.URL.Query())
+	if err != nil {
+		return common.NewBadRequestResponse(err.Error())
+	}
+
 	//get the clients
-	clients, cerr := h.Controllers.GetClients(CRUD)
+	clients, page, cerr := h.Controllers.GetClients(CRUD, query)
 	if cerr.Type == common.ErrorTypeClient {
 		return common.NewBadRequestResponse(cerr.Error())
 	}
@@ -32,7 +38,7 @@ func (h CoreHandlers) GetClients(_ *http.Request, _ httprouter.Params, _ *models
 	for index, client := range clients {
 		data[index] = h.newClientDataResponse(client)
 	}
-	return common.NewSuccessDataResponse(data)
+	return newPageResponse(data, page)
 }
 
 type PostClientBody struct {
@@ -42,7 +48,7 @@ type PostClientBody struct {
 	KeyUri      string `json:"key_uri"`
 }
 
-func (h CoreHandlers) PostClient(req *http.Request, _ httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+func (h CoreHandlers) PostClient(req *http.Request, _ httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
 	var body PostClientBody
 
 	//parse the body
@@ -64,10 +70,16 @@ func (h CoreHandlers) PostClient(req *http.Request, _ httprouter.Params, _ *mode
 		return common.NewInternalServerErrorResponse()
 	}
 
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionCreateClient, client.UID.String())
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
 	return common.NewSuccessDataResponse(h.newClientDataResponse(client))
 }
 
-func (h CoreHandlers) PutClient(req *http.Request, params httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+func (h CoreHandlers) PutClient(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
 	var body PostClientBody
 
 	//parse the id
@@ -96,10 +108,16 @@ func (h CoreHandlers) PutClient(req *http.Request, params httprouter.Params, _ *
 		return common.NewInternalServerErrorResponse()
 	}
 
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionUpdateClient, client.UID.String())
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
 	return common.NewSuccessDataResponse(h.newClientDataResponse(client))
 }
 
-func (h CoreHandlers) DeleteClient(_ *http.Request, params httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+func (h CoreHandlers) DeleteClient(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
 	//parse the id
 	id, err := uuid.Parse(params.ByName("id"))
 	if err != nil {
@@ -116,9 +134,47 @@ func (h CoreHandlers) DeleteClient(_ *http.Request, params httprouter.Params, _
 		return common.NewInternalServerErrorResponse()
 	}
 
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionDeleteClient, id.String())
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
 	return common.NewSuccessResponse()
 }
 
+type ClientSecretDataResponse struct {
+	Secret string `json:"secret"`
+}
+
+func (h CoreHandlers) PostClientSecret(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//parse the id
+	id, err := uuid.Parse(params.ByName("id"))
+	if err != nil {
+		log.Println(common.ChainError("error parsing id", err))
+		return common.NewBadRequestResponse("client id is in an invalid format")
+	}
+
+	//rotate the client secret
+	secret, cerr := h.Controllers.RotateClientSecret(CRUD, id)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionRotateClientSecret, id.String())
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessDataResponse(ClientSecretDataResponse{
+		Secret: secret,
+	})
+}
+
 func (CoreHandlers) newClientDataResponse(client *models.Client) ClientDataResponse {
 	return ClientDataResponse{
 		ID: client.UID.String(),
@@ -20,11 +20,13 @@ type ClientHandlerTestSuite struct {
 
 func (suite *ClientHandlerTestSuite) TestGetClients_WithClientErrorGettingClients_ReturnsBadRequest() {
 	//arrange
+	req := suite.CreateRequest(http.MethodGet, "/clients", "", nil)
+
 	message := "get clients error"
-	suite.ControllersMock.On("GetClients", mock.Anything).Return(nil, common.ClientError(message))
+	suite.ControllersMock.On("GetClients", mock.Anything, mock.Anything).Return(nil, models.PageInfo{}, common.ClientError(message))
 
 	//act
-	status, res := suite.CoreHandlers.GetClients(nil, nil, nil, &suite.CRUDMock)
+	status, res := suite.CoreHandlers.GetClients(req, nil, nil, &suite.CRUDMock)
 
 	//assert
 	suite.Require().Equal(http.StatusBadRequest, status)
@@ -33,30 +35,47 @@ func (suite *ClientHandlerTestSuite) TestGetClients_WithClientErrorGettingClient
 
 func (suite *ClientHandlerTestSuite) TestGetClients_WithInternalErrorGettingClients_ReturnsInternalServerError() {
 	//arrange
-	suite.ControllersMock.On("GetClients", mock.Anything).Return(nil, common.InternalError())
+	req := suite.CreateRequest(http.MethodGet, "/clients", "", nil)
+	suite.ControllersMock.On("GetClients", mock.Anything, mock.Anything).Return(nil, models.PageInfo{}, common.InternalError())
 
 	//act
-	status, res := suite.CoreHandlers.GetClients(nil, nil, nil, &suite.CRUDMock)
+	status, res := suite.CoreHandlers.GetClients(req, nil, nil, &suite.CRUDMock)
 
 	//assert
 	suite.Require().Equal(http.StatusInternalServerError, status)
 	suite.InternalServerErrorResponse(res)
 }
 
+func (suite *ClientHandlerTestSuite) TestGetClients_WithInvalidQuery_ReturnsBadRequest() {
+	//arrange
+	req := suite.CreateRequest(http.MethodGet, "/clients?order=sideways", "", nil)
+
+	//act
+	status, res := suite.CoreHandlers.GetClients(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "order", "asc", "desc")
+	suite.ControllersMock.AssertNotCalled(suite.T(), "GetClients", mock.Anything, mock.Anything)
+}
+
 func (suite *ClientHandlerTestSuite) TestGetClients_WithNoErrors_ReturnsClientData() {
 	//arrange
+	req := suite.CreateRequest(http.MethodGet, "/clients?search=name", "", nil)
+
 	clients := []*models.Client{
 		models.CreateNewClient("name1", "redirect1.com", 0, "key1.pem"),
 		models.CreateNewClient("name2", "redirect2.com", 1, "key2.pem"),
 	}
-	suite.ControllersMock.On("GetClients", mock.Anything).Return(clients, common.NoError())
+	page := models.PageInfo{Total: len(clients)}
+	suite.ControllersMock.On("GetClients", mock.Anything, mock.Anything).Return(clients, page, common.NoError())
 
 	//act
-	status, res := suite.CoreHandlers.GetClients(nil, nil, nil, &suite.CRUDMock)
+	status, res := suite.CoreHandlers.GetClients(req, nil, nil, &suite.CRUDMock)
 
 	//assert
 	suite.Require().Equal(http.StatusOK, status)
-	suite.SuccessDataResponse(res, []handlers.ClientDataResponse{
+	suite.SuccessPageResponse(res, []handlers.ClientDataResponse{
 		{
 			ID: clients[0].UID.String(),
 			PostClientBody: handlers.PostClientBody{
@@ -75,9 +94,9 @@ func (suite *ClientHandlerTestSuite) TestGetClients_WithNoErrors_ReturnsClientDa
 				KeyUri:      clients[1].KeyUri,
 			},
 		},
-	})
+	}, "", page.Total)
 
-	suite.ControllersMock.AssertCalled(suite.T(), "GetClients", &suite.CRUDMock)
+	suite.ControllersMock.AssertCalled(suite.T(), "GetClients", &suite.CRUDMock, models.PageQuery{Search: "name"})
 }
 
 func (suite *ClientHandlerTestSuite) TestPostClient_WithInvalidJSONBody_ReturnsBadRequest() {
@@ -135,6 +154,7 @@ func (suite *ClientHandlerTestSuite) TestPostClient_WithInternalErrorCreatingCli
 
 func (suite *ClientHandlerTestSuite) TestPostClient_WithNoErrors_ReturnsClientData() {
 	//arrange
+	session := models.CreateNewSession("admin", 5)
 	body := handlers.PostClientBody{
 		Name:        "name",
 		RedirectUrl: "redirect.com",
@@ -149,7 +169,7 @@ func (suite *ClientHandlerTestSuite) TestPostClient_WithNoErrors_ReturnsClientDa
 	})
 
 	//act
-	status, res := suite.CoreHandlers.PostClient(req, nil, nil, &suite.CRUDMock)
+	status, res := suite.CoreHandlers.PostClient(req, nil, session, &suite.CRUDMock)
 
 	//assert
 	suite.Require().Equal(http.StatusOK, status)
@@ -164,6 +184,7 @@ func (suite *ClientHandlerTestSuite) TestPostClient_WithNoErrors_ReturnsClientDa
 	})
 
 	suite.ControllersMock.AssertCalled(suite.T(), "CreateClient", &suite.CRUDMock, client)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionCreateClient, client.UID.String())
 }
 
 func (suite *ClientHandlerTestSuite) TestPutClient_WithErrorParsingId_ReturnsBadRequest() {
@@ -259,6 +280,7 @@ func (suite *ClientHandlerTestSuite) TestPutClient_WithInternalErrorUpdatingClie
 
 func (suite *ClientHandlerTestSuite) TestPutClient_WithNoErrors_ReturnsClientData() {
 	//arrange
+	session := models.CreateNewSession("admin", 5)
 	body := handlers.PostClientBody{
 		Name:        "name",
 		RedirectUrl: "redirect.com",
@@ -280,7 +302,7 @@ func (suite *ClientHandlerTestSuite) TestPutClient_WithNoErrors_ReturnsClientDat
 	})
 
 	//act
-	status, res := suite.CoreHandlers.PutClient(req, params, nil, &suite.CRUDMock)
+	status, res := suite.CoreHandlers.PutClient(req, params, session, &suite.CRUDMock)
 
 	//assert
 	suite.Require().Equal(http.StatusOK, status)
@@ -295,6 +317,7 @@ func (suite *ClientHandlerTestSuite) TestPutClient_WithNoErrors_ReturnsClientDat
 	})
 
 	suite.ControllersMock.AssertCalled(suite.T(), "UpdateClient", &suite.CRUDMock, client)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionUpdateClient, client.UID.String())
 }
 
 func (suite *ClientHandlerTestSuite) TestDeleteClient_WithErrorParsingId_ReturnsBadRequest() {
@@ -355,6 +378,8 @@ func (suite *ClientHandlerTestSuite) TestDeleteClient_WithInternalErrorDeletingU
 
 func (suite *ClientHandlerTestSuite) TestDeleteClient_WithNoErrors_ReturnsSuccess() {
 	//arrange
+	session := models.CreateNewSession("admin", 5)
+	req := suite.CreateDummyJSONRequest(nil)
 	uid := uuid.New()
 	params := []httprouter.Param{
 		{
@@ -366,13 +391,98 @@ func (suite *ClientHandlerTestSuite) TestDeleteClient_WithNoErrors_ReturnsSucces
 	suite.ControllersMock.On("DeleteClient", mock.Anything, mock.Anything).Return(common.NoError())
 
 	//act
-	status, res := suite.CoreHandlers.DeleteClient(nil, params, nil, &suite.CRUDMock)
+	status, res := suite.CoreHandlers.DeleteClient(req, params, session, &suite.CRUDMock)
 
 	//assert
 	suite.Require().Equal(http.StatusOK, status)
 	suite.SuccessResponse(res)
 
 	suite.ControllersMock.AssertCalled(suite.T(), "DeleteClient", &suite.CRUDMock, uid)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionDeleteClient, uid.String())
+}
+
+func (suite *ClientHandlerTestSuite) TestPostClientSecret_WithErrorParsingId_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: "invalid",
+		},
+	}
+
+	//act
+	status, res := suite.CoreHandlers.PostClientSecret(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "client id", "invalid format")
+}
+
+func (suite *ClientHandlerTestSuite) TestPostClientSecret_WithClientErrorRotatingClientSecret_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	message := "rotate client secret error"
+	suite.ControllersMock.On("RotateClientSecret", mock.Anything, mock.Anything).Return("", common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostClientSecret(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *ClientHandlerTestSuite) TestPostClientSecret_WithInternalErrorRotatingClientSecret_ReturnsInternalServerError() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	suite.ControllersMock.On("RotateClientSecret", mock.Anything, mock.Anything).Return("", common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostClientSecret(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *ClientHandlerTestSuite) TestPostClientSecret_WithNoErrors_ReturnsSecret() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	req := suite.CreateDummyJSONRequest(nil)
+	uid := uuid.New()
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uid.String(),
+		},
+	}
+
+	secret := "secret"
+	suite.ControllersMock.On("RotateClientSecret", mock.Anything, mock.Anything).Return(secret, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostClientSecret(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, handlers.ClientSecretDataResponse{
+		Secret: secret,
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "RotateClientSecret", &suite.CRUDMock, uid)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionRotateClientSecret, uid.String())
 }
 
 func TestClientHandlerTestSuite(t *testing.T) {
@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	"github.com/mhogar/amber/models"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type GroupDataResponse struct {
+	PostGroupBody
+}
+
+func (h CoreHandlers) GetGroups(req *http.Request, _ httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//get the groups
+	groups, cerr := h.Controllers.GetGroups(CRUD)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//return the data
+	data := make([]GroupDataResponse, len(groups))
+	for index, group := range groups {
+		data[index] = h.newGroupDataResponse(group)
+	}
+	return common.NewSuccessDataResponse(data)
+}
+
+type PostGroupBody struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Priority    int    `json:"priority"`
+}
+
+func (h CoreHandlers) PostGroup(req *http.Request, _ httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	var body PostGroupBody
+
+	//parse the body
+	err := parseJSONBody(req.Body, &body)
+	if err != nil {
+		log.Println(common.ChainError("error parsing PostGroupBody request body", err))
+		return common.NewBadRequestResponse("invalid json body")
+	}
+
+	//create the model
+	group := models.CreateGroup(body.Name, body.Description, body.Priority)
+
+	//create the group
+	cerr := h.Controllers.CreateGroup(CRUD, group)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionCreateGroup, group.Name)
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessDataResponse(h.newGroupDataResponse(group))
+}
+
+type PutGroupBody struct {
+	Description string `json:"description"`
+	Priority    int    `json:"priority"`
+}
+
+func (h CoreHandlers) PutGroup(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	var body PutGroupBody
+
+	//get the name
+	name := params.ByName("name")
+	if name == "" {
+		return common.NewBadRequestResponse("group name not provided")
+	}
+
+	//parse the body
+	err := parseJSONBody(req.Body, &body)
+	if err != nil {
+		log.Println(common.ChainError("error parsing PutGroupBody request body", err))
+		return common.NewBadRequestResponse("invalid json body")
+	}
+
+	//create the model
+	group := models.CreateGroup(name, body.Description, body.Priority)
+
+	//update the group
+	cerr := h.Controllers.UpdateGroup(CRUD, group)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionUpdateGroup, group.Name)
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessDataResponse(h.newGroupDataResponse(group))
+}
+
+func (h CoreHandlers) DeleteGroup(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//get the name
+	name := params.ByName("name")
+	if name == "" {
+		return common.NewBadRequestResponse("group name not provided")
+	}
+
+	//delete the group
+	cerr := h.Controllers.DeleteGroup(CRUD, name)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionDeleteGroup, name)
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessResponse()
+}
+
+func (h CoreHandlers) GetGroupMembers(req *http.Request, params httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//get the name
+	name := params.ByName("name")
+	if name == "" {
+		return common.NewBadRequestResponse("group name not provided")
+	}
+
+	//get the group's members
+	usernames, cerr := h.Controllers.GetGroupMembers(CRUD, name)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//return the data
+	data := make([]PostGroupMemberBody, len(usernames))
+	for index, username := range usernames {
+		data[index] = PostGroupMemberBody{
+			Username: username,
+		}
+	}
+	return common.NewSuccessDataResponse(data)
+}
+
+type PostGroupMemberBody struct {
+	Username string `json:"username"`
+}
+
+func (h CoreHandlers) PostGroupMember(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	var body PostGroupMemberBody
+
+	//get the name
+	name := params.ByName("name")
+	if name == "" {
+		return common.NewBadRequestResponse("group name not provided")
+	}
+
+	//parse the body
+	err := parseJSONBody(req.Body, &body)
+	if err != nil {
+		log.Println(common.ChainError("error parsing PostGroupMemberBody request body", err))
+		return common.NewBadRequestResponse("invalid json body")
+	}
+
+	//verify the session has a greater rank than the user
+	res, cerr := h.Controllers.VerifyUserRank(CRUD, body.Username, session.Rank)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+	if !res {
+		return common.NewInsufficientPermissionsErrorResponse()
+	}
+
+	//add the user to the group
+	cerr = h.Controllers.CreateGroupMember(CRUD, name, body.Username)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionCreateGroupMember, groupMemberAuditTarget(name, body.Username))
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessResponse()
+}
+
+func (h CoreHandlers) DeleteGroupMember(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//get the name
+	name := params.ByName("name")
+	if name == "" {
+		return common.NewBadRequestResponse("group name not provided")
+	}
+
+	//get the username
+	username := params.ByName("username")
+	if username == "" {
+		return common.NewBadRequestResponse("username not provided")
+	}
+
+	//verify the session has a greater rank than the user
+	res, cerr := h.Controllers.VerifyUserRank(CRUD, username, session.Rank)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+	if !res {
+		return common.NewInsufficientPermissionsErrorResponse()
+	}
+
+	//remove the user from the group
+	cerr = h.Controllers.DeleteGroupMember(CRUD, name, username)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionDeleteGroupMember, groupMemberAuditTarget(name, username))
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessResponse()
+}
+
+// groupMemberAuditTarget creates the audit event target for the member with the given username of the group with the given name.
+func groupMemberAuditTarget(name string, username string) string {
+	return name + "/" + username
+}
+
+func (CoreHandlers) newGroupDataResponse(group *models.Group) GroupDataResponse {
+	return GroupDataResponse{
+		PostGroupBody: PostGroupBody{
+			Name:        group.Name,
+			Description: group.Description,
+			Priority:    group.Priority,
+		},
+	}
+}
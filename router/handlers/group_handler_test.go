@@ -0,0 +1,671 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/router/handlers"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type GroupHandlerTestSuite struct {
+	HandlersTestSuite
+}
+
+func (suite *GroupHandlerTestSuite) TestGetGroups_WithInternalErrorGettingGroups_ReturnsInternalServerError() {
+	//arrange
+	suite.ControllersMock.On("GetGroups", mock.Anything).Return(nil, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.GetGroups(nil, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *GroupHandlerTestSuite) TestGetGroups_WithNoErrors_ReturnsGroupData() {
+	//arrange
+	groups := []*models.Group{
+		models.CreateGroup("group1", "description", 1),
+		models.CreateGroup("group2", "", 0),
+	}
+	suite.ControllersMock.On("GetGroups", mock.Anything).Return(groups, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.GetGroups(nil, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, []handlers.GroupDataResponse{
+		{
+			PostGroupBody: handlers.PostGroupBody{
+				Name:        groups[0].Name,
+				Description: groups[0].Description,
+				Priority:    groups[0].Priority,
+			},
+		},
+		{
+			PostGroupBody: handlers.PostGroupBody{
+				Name:        groups[1].Name,
+				Description: groups[1].Description,
+				Priority:    groups[1].Priority,
+			},
+		},
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "GetGroups", &suite.CRUDMock)
+}
+
+func (suite *GroupHandlerTestSuite) TestPostGroup_WithInvalidJSONBody_ReturnsBadRequest() {
+	//arrange
+	req := suite.CreateDummyJSONRequest("invalid")
+
+	//act
+	status, res := suite.CoreHandlers.PostGroup(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "invalid json body")
+}
+
+func (suite *GroupHandlerTestSuite) TestPostGroup_WithClientErrorCreatingGroup_ReturnsBadRequest() {
+	//arrange
+	req := suite.CreateDummyJSONRequest(handlers.PostGroupBody{Name: "group"})
+
+	message := "create group error"
+	suite.ControllersMock.On("CreateGroup", mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostGroup(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *GroupHandlerTestSuite) TestPostGroup_WithInternalErrorCreatingGroup_ReturnsInternalServerError() {
+	//arrange
+	req := suite.CreateDummyJSONRequest(handlers.PostGroupBody{Name: "group"})
+	suite.ControllersMock.On("CreateGroup", mock.Anything, mock.Anything).Return(common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostGroup(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *GroupHandlerTestSuite) TestPostGroup_WithErrorCreatingAuditEvent_ReturnsInternalServerError() {
+	//arrange
+	suite.FailAuditEvents()
+
+	session := models.CreateNewSession("admin", 5)
+	req := suite.CreateDummyJSONRequest(handlers.PostGroupBody{Name: "group"})
+
+	suite.ControllersMock.On("CreateGroup", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostGroup(req, nil, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *GroupHandlerTestSuite) TestPostGroup_WithNoErrors_ReturnsGroupData() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+
+	body := handlers.PostGroupBody{
+		Name:        "group",
+		Description: "description",
+		Priority:    3,
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	suite.ControllersMock.On("CreateGroup", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostGroup(req, nil, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, handlers.GroupDataResponse{
+		PostGroupBody: body,
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateGroup", &suite.CRUDMock, models.CreateGroup(body.Name, body.Description, body.Priority))
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionCreateGroup, body.Name)
+}
+
+func (suite *GroupHandlerTestSuite) TestPutGroup_WithMissingName_ReturnsBadRequest() {
+	//act
+	status, res := suite.CoreHandlers.PutGroup(nil, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "group name not provided")
+}
+
+func (suite *GroupHandlerTestSuite) TestPutGroup_WithInvalidJSONBody_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "group",
+		},
+	}
+	req := suite.CreateDummyJSONRequest("invalid")
+
+	//act
+	status, res := suite.CoreHandlers.PutGroup(req, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "invalid json body")
+}
+
+func (suite *GroupHandlerTestSuite) TestPutGroup_WithClientErrorUpdatingGroup_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "group",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PutGroupBody{})
+
+	message := "update group error"
+	suite.ControllersMock.On("UpdateGroup", mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PutGroup(req, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *GroupHandlerTestSuite) TestPutGroup_WithInternalErrorUpdatingGroup_ReturnsInternalServerError() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "group",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PutGroupBody{})
+
+	suite.ControllersMock.On("UpdateGroup", mock.Anything, mock.Anything).Return(common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PutGroup(req, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *GroupHandlerTestSuite) TestPutGroup_WithNoErrors_ReturnsGroupData() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "group",
+		},
+	}
+
+	body := handlers.PutGroupBody{
+		Description: "description",
+		Priority:    2,
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	suite.ControllersMock.On("UpdateGroup", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PutGroup(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, handlers.GroupDataResponse{
+		PostGroupBody: handlers.PostGroupBody{
+			Name:        params[0].Value,
+			Description: body.Description,
+			Priority:    body.Priority,
+		},
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "UpdateGroup", &suite.CRUDMock, models.CreateGroup(params[0].Value, body.Description, body.Priority))
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionUpdateGroup, params[0].Value)
+}
+
+func (suite *GroupHandlerTestSuite) TestDeleteGroup_WithMissingName_ReturnsBadRequest() {
+	//act
+	status, res := suite.CoreHandlers.DeleteGroup(nil, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "group name not provided")
+}
+
+func (suite *GroupHandlerTestSuite) TestDeleteGroup_WithClientErrorDeletingGroup_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "group",
+		},
+	}
+
+	message := "delete group error"
+	suite.ControllersMock.On("DeleteGroup", mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.DeleteGroup(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *GroupHandlerTestSuite) TestDeleteGroup_WithInternalErrorDeletingGroup_ReturnsInternalServerError() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "group",
+		},
+	}
+
+	suite.ControllersMock.On("DeleteGroup", mock.Anything, mock.Anything).Return(common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteGroup(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *GroupHandlerTestSuite) TestDeleteGroup_WithNoErrors_ReturnsSuccess() {
+	//arrange
+	req := suite.CreateDummyJSONRequest(nil)
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "group",
+		},
+	}
+
+	suite.ControllersMock.On("DeleteGroup", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteGroup(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessResponse(res)
+
+	suite.ControllersMock.AssertCalled(suite.T(), "DeleteGroup", &suite.CRUDMock, params[0].Value)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionDeleteGroup, params[0].Value)
+}
+
+func (suite *GroupHandlerTestSuite) TestGetGroupMembers_WithMissingName_ReturnsBadRequest() {
+	//act
+	status, res := suite.CoreHandlers.GetGroupMembers(nil, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "group name not provided")
+}
+
+func (suite *GroupHandlerTestSuite) TestGetGroupMembers_WithClientErrorGettingGroupMembers_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "group",
+		},
+	}
+
+	message := "get group members error"
+	suite.ControllersMock.On("GetGroupMembers", mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.GetGroupMembers(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *GroupHandlerTestSuite) TestGetGroupMembers_WithInternalErrorGettingGroupMembers_ReturnsInternalServerError() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "group",
+		},
+	}
+
+	suite.ControllersMock.On("GetGroupMembers", mock.Anything, mock.Anything).Return(nil, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.GetGroupMembers(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *GroupHandlerTestSuite) TestGetGroupMembers_WithNoErrors_ReturnsGroupMemberData() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "group",
+		},
+	}
+
+	suite.ControllersMock.On("GetGroupMembers", mock.Anything, mock.Anything).Return([]string{"user1", "user2"}, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.GetGroupMembers(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, []handlers.PostGroupMemberBody{
+		{Username: "user1"},
+		{Username: "user2"},
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "GetGroupMembers", &suite.CRUDMock, params[0].Value)
+}
+
+func (suite *GroupHandlerTestSuite) TestPostGroupMember_WithMissingName_ReturnsBadRequest() {
+	//act
+	status, res := suite.CoreHandlers.PostGroupMember(nil, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "group name not provided")
+}
+
+func (suite *GroupHandlerTestSuite) TestPostGroupMember_WithInvalidJSONBody_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "group",
+		},
+	}
+	req := suite.CreateDummyJSONRequest("invalid")
+
+	//act
+	status, res := suite.CoreHandlers.PostGroupMember(req, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "invalid json body")
+}
+
+func (suite *GroupHandlerTestSuite) TestPostGroupMember_WithClientErrorVerifyingUserRank_ReturnsBadRequest() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "group",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PostGroupMemberBody{Username: "username"})
+
+	message := "verify user rank error"
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(false, common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostGroupMember(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *GroupHandlerTestSuite) TestPostGroupMember_WithInternalErrorVerifyingUserRank_ReturnsInternalServerError() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "group",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PostGroupMemberBody{Username: "username"})
+
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(false, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostGroupMember(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *GroupHandlerTestSuite) TestPostGroupMember_WithFalseResultVerifyingUserRank_ReturnsForbidden() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "group",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PostGroupMemberBody{Username: "username"})
+
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(false, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostGroupMember(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusForbidden, status)
+	suite.InsufficientPermissionsErrorResponse(res)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "CreateGroupMember", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *GroupHandlerTestSuite) TestPostGroupMember_WithClientErrorCreatingGroupMember_ReturnsBadRequest() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "group",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PostGroupMemberBody{Username: "username"})
+
+	message := "create group member error"
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("CreateGroupMember", mock.Anything, mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostGroupMember(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *GroupHandlerTestSuite) TestPostGroupMember_WithInternalErrorCreatingGroupMember_ReturnsInternalServerError() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "group",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PostGroupMemberBody{Username: "username"})
+
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("CreateGroupMember", mock.Anything, mock.Anything, mock.Anything).Return(common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostGroupMember(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *GroupHandlerTestSuite) TestPostGroupMember_WithNoErrors_ReturnsSuccess() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "group",
+		},
+	}
+
+	body := handlers.PostGroupMemberBody{
+		Username: "username",
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("CreateGroupMember", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostGroupMember(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessResponse(res)
+
+	suite.ControllersMock.AssertCalled(suite.T(), "VerifyUserRank", &suite.CRUDMock, body.Username, session.Rank)
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateGroupMember", &suite.CRUDMock, params[0].Value, body.Username)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionCreateGroupMember, params[0].Value+"/"+body.Username)
+}
+
+func (suite *GroupHandlerTestSuite) TestDeleteGroupMember_WithMissingName_ReturnsBadRequest() {
+	//act
+	status, res := suite.CoreHandlers.DeleteGroupMember(nil, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "group name not provided")
+}
+
+func (suite *GroupHandlerTestSuite) TestDeleteGroupMember_WithMissingUsername_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "group",
+		},
+	}
+
+	//act
+	status, res := suite.CoreHandlers.DeleteGroupMember(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "username not provided")
+}
+
+func (suite *GroupHandlerTestSuite) TestDeleteGroupMember_WithFalseResultVerifyingUserRank_ReturnsForbidden() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "group",
+		},
+		{
+			Key:   "username",
+			Value: "username",
+		},
+	}
+
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(false, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteGroupMember(nil, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusForbidden, status)
+	suite.InsufficientPermissionsErrorResponse(res)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "DeleteGroupMember", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *GroupHandlerTestSuite) TestDeleteGroupMember_WithClientErrorDeletingGroupMember_ReturnsBadRequest() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "group",
+		},
+		{
+			Key:   "username",
+			Value: "username",
+		},
+	}
+
+	message := "delete group member error"
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("DeleteGroupMember", mock.Anything, mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.DeleteGroupMember(nil, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *GroupHandlerTestSuite) TestDeleteGroupMember_WithNoErrors_ReturnsSuccess() {
+	//arrange
+	req := suite.CreateDummyJSONRequest(nil)
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "name",
+			Value: "group",
+		},
+		{
+			Key:   "username",
+			Value: "username",
+		},
+	}
+
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("DeleteGroupMember", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteGroupMember(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessResponse(res)
+
+	suite.ControllersMock.AssertCalled(suite.T(), "VerifyUserRank", &suite.CRUDMock, params[1].Value, session.Rank)
+	suite.ControllersMock.AssertCalled(suite.T(), "DeleteGroupMember", &suite.CRUDMock, params[0].Value, params[1].Value)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionDeleteGroupMember, params[0].Value+"/"+params[1].Value)
+}
+
+func TestGroupHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &GroupHandlerTestSuite{})
+}
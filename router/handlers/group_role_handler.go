@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+)
+
+type GroupRoleDataResponse struct {
+	PostGroupRoleBody
+}
+
+func (h CoreHandlers) GetGroupRoles(req *http.Request, params httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//parse the client id
+	clientID, err := uuid.Parse(params.ByName("id"))
+	if err != nil {
+		log.Println(common.ChainError("error parsing client id", err))
+		return common.NewBadRequestResponse("client id is in an invalid format")
+	}
+
+	//get the group-roles
+	roles, cerr := h.Controllers.GetGroupRolesByClientUID(CRUD, clientID)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//return the data
+	data := make([]GroupRoleDataResponse, len(roles))
+	for index, role := range roles {
+		data[index] = h.newGroupRoleDataResponse(role)
+	}
+	return common.NewSuccessDataResponse(data)
+}
+
+type PostGroupRoleBody struct {
+	Group string   `json:"group"`
+	Roles []string `json:"roles"`
+}
+
+func (h CoreHandlers) PostGroupRole(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	var body PostGroupRoleBody
+
+	//parse the client id
+	clientID, err := uuid.Parse(params.ByName("id"))
+	if err != nil {
+		log.Println(common.ChainError("error parsing client id", err))
+		return common.NewBadRequestResponse("client id is in an invalid format")
+	}
+
+	//parse the body
+	err = parseJSONBody(req.Body, &body)
+	if err != nil {
+		log.Println(common.ChainError("error parsing PostGroupRoleBody request body", err))
+		return common.NewBadRequestResponse("invalid json body")
+	}
+
+	//verify the session can manage the group's group-role
+	res, cerr := h.verifyCanManageGroupRole(CRUD, session, clientID, body.Group)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+	if !res {
+		return common.NewInsufficientPermissionsErrorResponse()
+	}
+
+	//create the model
+	role := models.CreateGroupRole(clientID, body.Group, body.Roles...)
+
+	//create the group-role
+	cerr = h.Controllers.CreateGroupRole(CRUD, role)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionCreateGroupRole, groupRoleAuditTarget(role.ClientUID, role.GroupName))
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessDataResponse(h.newGroupRoleDataResponse(role))
+}
+
+type PutGroupRoleBody struct {
+	Roles []string `json:"roles"`
+}
+
+func (h CoreHandlers) PutGroupRole(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	var body PutGroupRoleBody
+
+	//parse the client id
+	clientID, err := uuid.Parse(params.ByName("id"))
+	if err != nil {
+		log.Println(common.ChainError("error parsing client id", err))
+		return common.NewBadRequestResponse("client id is in an invalid format")
+	}
+
+	//get the group name
+	groupName := params.ByName("group")
+	if groupName == "" {
+		return common.NewBadRequestResponse("group name not provided")
+	}
+
+	//parse the body
+	err = parseJSONBody(req.Body, &body)
+	if err != nil {
+		log.Println(common.ChainError("error parsing PutGroupRoleBody request body", err))
+		return common.NewBadRequestResponse("invalid json body")
+	}
+
+	//verify the session can manage the group's group-role
+	res, cerr := h.verifyCanManageGroupRole(CRUD, session, clientID, groupName)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+	if !res {
+		return common.NewInsufficientPermissionsErrorResponse()
+	}
+
+	//create the model
+	role := models.CreateGroupRole(clientID, groupName, body.Roles...)
+
+	//update the group-role
+	cerr = h.Controllers.UpdateGroupRole(CRUD, role)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionUpdateGroupRole, groupRoleAuditTarget(role.ClientUID, role.GroupName))
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessDataResponse(h.newGroupRoleDataResponse(role))
+}
+
+func (h CoreHandlers) DeleteGroupRole(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//parse the client id
+	clientID, err := uuid.Parse(params.ByName("id"))
+	if err != nil {
+		log.Println(common.ChainError("error parsing client id", err))
+		return common.NewBadRequestResponse("client id is in an invalid format")
+	}
+
+	//get the group name
+	groupName := params.ByName("group")
+	if groupName == "" {
+		return common.NewBadRequestResponse("group name not provided")
+	}
+
+	//delete the group-role
+	cerr := h.Controllers.DeleteGroupRole(CRUD, clientID, groupName)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionDeleteGroupRole, groupRoleAuditTarget(clientID, groupName))
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessResponse()
+}
+
+type RoleAssignmentDataResponse struct {
+	Source   string   `json:"source"`
+	Group    string   `json:"group,omitempty"`
+	Priority int      `json:"priority"`
+	Roles    []string `json:"roles"`
+}
+
+type UserRoleAssignmentsDataResponse struct {
+	Username       string                       `json:"username"`
+	EffectiveRoles []string                     `json:"effective_roles"`
+	Assignments    []RoleAssignmentDataResponse `json:"assignments"`
+}
+
+func (h CoreHandlers) GetUserRoleAssignments(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//parse the client id
+	clientID, err := uuid.Parse(params.ByName("id"))
+	if err != nil {
+		log.Println(common.ChainError("error parsing client id", err))
+		return common.NewBadRequestResponse("client id is in an invalid format")
+	}
+
+	//get the username
+	username := params.ByName("username")
+	if username == "" {
+		return common.NewBadRequestResponse("username not provided")
+	}
+
+	//verify the session can manage the user's user-role
+	res, cerr := h.verifyCanManageUserRole(CRUD, session, clientID, username)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+	if !res {
+		return common.NewInsufficientPermissionsErrorResponse()
+	}
+
+	//get the user's role assignments
+	assignments, cerr := h.Controllers.GetUserRoleAssignments(CRUD, clientID, username)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//return the data, the first assignment being the one that decides the user's roles
+	data := UserRoleAssignmentsDataResponse{
+		Username:       username,
+		EffectiveRoles: []string{},
+		Assignments:    make([]RoleAssignmentDataResponse, len(assignments)),
+	}
+	for index, assignment := range assignments {
+		data.Assignments[index] = RoleAssignmentDataResponse{
+			Source:   assignment.Source,
+			Group:    assignment.GroupName,
+			Priority: assignment.Priority,
+			Roles:    assignment.Roles,
+		}
+	}
+	if len(assignments) > 0 {
+		data.EffectiveRoles = assignments[0].Roles
+	}
+
+	return common.NewSuccessDataResponse(data)
+}
+
+// verifyCanManageGroupRole verifies the session is a client admin of the client, or has a greater rank than every member of the group,
+// since the group's members are given its roles.
+func (h CoreHandlers) verifyCanManageGroupRole(CRUD data.DataCRUD, session *models.Session, clientID uuid.UUID, groupName string) (bool, common.CustomError) {
+	if session.IsClientAdmin(clientID) {
+		return true, common.NoError()
+	}
+
+	return h.Controllers.VerifyGroupRank(CRUD, groupName, session.Rank)
+}
+
+// groupRoleAuditTarget creates the audit event target for the group-role with the given client id and group name.
+func groupRoleAuditTarget(clientID uuid.UUID, groupName string) string {
+	return clientID.String() + "/" + groupName
+}
+
+func (CoreHandlers) newGroupRoleDataResponse(role *models.GroupRole) GroupRoleDataResponse {
+	return GroupRoleDataResponse{
+		PostGroupRoleBody: PostGroupRoleBody{
+			Group: role.GroupName,
+			Roles: role.Roles,
+		},
+	}
+}
@@ -0,0 +1,896 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/router/handlers"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type GroupRoleHandlerTestSuite struct {
+	HandlersTestSuite
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestGetGroupRoles_WithInvalidClientID_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: "invalid",
+		},
+	}
+
+	//act
+	status, res := suite.CoreHandlers.GetGroupRoles(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "client id", "invalid format")
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestGetGroupRoles_WithClientErrorGettingGroupRoles_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	message := "get group roles error"
+	suite.ControllersMock.On("GetGroupRolesByClientUID", mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.GetGroupRoles(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestGetGroupRoles_WithInternalErrorGettingGroupRoles_ReturnsInternalServerError() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	suite.ControllersMock.On("GetGroupRolesByClientUID", mock.Anything, mock.Anything).Return(nil, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.GetGroupRoles(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestGetGroupRoles_WithNoErrors_ReturnsGroupRoleData() {
+	//arrange
+	clientUID := uuid.New()
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: clientUID.String(),
+		},
+	}
+
+	roles := []*models.GroupRole{
+		models.CreateGroupRole(clientUID, "group1", "role1"),
+		models.CreateGroupRole(clientUID, "group2", "role1", "role2"),
+	}
+	suite.ControllersMock.On("GetGroupRolesByClientUID", mock.Anything, mock.Anything).Return(roles, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.GetGroupRoles(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, []handlers.GroupRoleDataResponse{
+		{
+			PostGroupRoleBody: handlers.PostGroupRoleBody{
+				Group: roles[0].GroupName,
+				Roles: roles[0].Roles,
+			},
+		},
+		{
+			PostGroupRoleBody: handlers.PostGroupRoleBody{
+				Group: roles[1].GroupName,
+				Roles: roles[1].Roles,
+			},
+		},
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "GetGroupRolesByClientUID", &suite.CRUDMock, clientUID)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestPostGroupRole_WithInvalidClientID_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: "invalid",
+		},
+	}
+
+	//act
+	status, res := suite.CoreHandlers.PostGroupRole(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "client id", "invalid format")
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestPostGroupRole_WithInvalidJSONBody_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+	req := suite.CreateDummyJSONRequest("invalid")
+
+	//act
+	status, res := suite.CoreHandlers.PostGroupRole(req, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "invalid json body")
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestPostGroupRole_WithClientErrorVerifyingGroupRank_ReturnsBadRequest() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PostGroupRoleBody{Group: "group", Roles: []string{"role"}})
+
+	message := "verify group rank error"
+	suite.ControllersMock.On("VerifyGroupRank", mock.Anything, mock.Anything, mock.Anything).Return(false, common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostGroupRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestPostGroupRole_WithInternalErrorVerifyingGroupRank_ReturnsInternalServerError() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PostGroupRoleBody{Group: "group", Roles: []string{"role"}})
+
+	suite.ControllersMock.On("VerifyGroupRank", mock.Anything, mock.Anything, mock.Anything).Return(false, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostGroupRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestPostGroupRole_WithFalseResultVerifyingGroupRank_ReturnsForbidden() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PostGroupRoleBody{Group: "group", Roles: []string{"role"}})
+
+	suite.ControllersMock.On("VerifyGroupRank", mock.Anything, mock.Anything, mock.Anything).Return(false, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostGroupRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusForbidden, status)
+	suite.InsufficientPermissionsErrorResponse(res)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestPostGroupRole_WithClientErrorCreatingGroupRole_ReturnsBadRequest() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PostGroupRoleBody{Group: "group", Roles: []string{"role"}})
+
+	suite.ControllersMock.On("VerifyGroupRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+
+	message := "create group role error"
+	suite.ControllersMock.On("CreateGroupRole", mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostGroupRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestPostGroupRole_WithInternalErrorCreatingGroupRole_ReturnsInternalServerError() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PostGroupRoleBody{Group: "group", Roles: []string{"role"}})
+
+	suite.ControllersMock.On("VerifyGroupRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("CreateGroupRole", mock.Anything, mock.Anything).Return(common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostGroupRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestPostGroupRole_WithNoErrors_ReturnsGroupRoleData() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	clientUID := uuid.New()
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: clientUID.String(),
+		},
+	}
+
+	body := handlers.PostGroupRoleBody{
+		Group: "group",
+		Roles: []string{"role"},
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	suite.ControllersMock.On("VerifyGroupRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("CreateGroupRole", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostGroupRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, handlers.GroupRoleDataResponse{
+		PostGroupRoleBody: body,
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "VerifyGroupRank", &suite.CRUDMock, body.Group, session.Rank)
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateGroupRole", &suite.CRUDMock, models.CreateGroupRole(clientUID, body.Group, body.Roles...))
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionCreateGroupRole, clientUID.String()+"/"+body.Group)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestPostGroupRole_WhereSessionIsClientAdmin_DoesNotVerifyGroupRank() {
+	//arrange
+	clientUID := uuid.New()
+	session := models.CreateNewSession("admin", 0)
+	session.ClientAdminUIDs = []uuid.UUID{clientUID}
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: clientUID.String(),
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PostGroupRoleBody{Group: "group", Roles: []string{"role"}})
+
+	suite.ControllersMock.On("CreateGroupRole", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, _ := suite.CoreHandlers.PostGroupRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "VerifyGroupRank", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestPutGroupRole_WithInvalidClientID_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: "invalid",
+		},
+	}
+
+	//act
+	status, res := suite.CoreHandlers.PutGroupRole(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "client id", "invalid format")
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestPutGroupRole_WithMissingGroupName_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	//act
+	status, res := suite.CoreHandlers.PutGroupRole(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "group name not provided")
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestPutGroupRole_WithInvalidJSONBody_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+		{
+			Key:   "group",
+			Value: "group",
+		},
+	}
+	req := suite.CreateDummyJSONRequest("invalid")
+
+	//act
+	status, res := suite.CoreHandlers.PutGroupRole(req, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "invalid json body")
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestPutGroupRole_WithClientErrorVerifyingGroupRank_ReturnsBadRequest() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+		{
+			Key:   "group",
+			Value: "group",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PutGroupRoleBody{Roles: []string{"role"}})
+
+	message := "verify group rank error"
+	suite.ControllersMock.On("VerifyGroupRank", mock.Anything, mock.Anything, mock.Anything).Return(false, common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PutGroupRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestPutGroupRole_WithInternalErrorVerifyingGroupRank_ReturnsInternalServerError() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+		{
+			Key:   "group",
+			Value: "group",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PutGroupRoleBody{Roles: []string{"role"}})
+
+	suite.ControllersMock.On("VerifyGroupRank", mock.Anything, mock.Anything, mock.Anything).Return(false, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PutGroupRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestPutGroupRole_WithFalseResultVerifyingGroupRank_ReturnsForbidden() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+		{
+			Key:   "group",
+			Value: "group",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PutGroupRoleBody{Roles: []string{"role"}})
+
+	suite.ControllersMock.On("VerifyGroupRank", mock.Anything, mock.Anything, mock.Anything).Return(false, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PutGroupRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusForbidden, status)
+	suite.InsufficientPermissionsErrorResponse(res)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestPutGroupRole_WithClientErrorUpdatingGroupRole_ReturnsBadRequest() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+		{
+			Key:   "group",
+			Value: "group",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PutGroupRoleBody{Roles: []string{"role"}})
+
+	suite.ControllersMock.On("VerifyGroupRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+
+	message := "update group role error"
+	suite.ControllersMock.On("UpdateGroupRole", mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PutGroupRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestPutGroupRole_WithInternalErrorUpdatingGroupRole_ReturnsInternalServerError() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+		{
+			Key:   "group",
+			Value: "group",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PutGroupRoleBody{Roles: []string{"role"}})
+
+	suite.ControllersMock.On("VerifyGroupRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("UpdateGroupRole", mock.Anything, mock.Anything).Return(common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PutGroupRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestPutGroupRole_WithNoErrors_ReturnsGroupRoleData() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	clientUID := uuid.New()
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: clientUID.String(),
+		},
+		{
+			Key:   "group",
+			Value: "group",
+		},
+	}
+
+	body := handlers.PutGroupRoleBody{
+		Roles: []string{"role"},
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	suite.ControllersMock.On("VerifyGroupRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("UpdateGroupRole", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PutGroupRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, handlers.GroupRoleDataResponse{
+		PostGroupRoleBody: handlers.PostGroupRoleBody{
+			Group: params[1].Value,
+			Roles: body.Roles,
+		},
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "VerifyGroupRank", &suite.CRUDMock, params[1].Value, session.Rank)
+	suite.ControllersMock.AssertCalled(suite.T(), "UpdateGroupRole", &suite.CRUDMock, models.CreateGroupRole(clientUID, params[1].Value, body.Roles...))
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionUpdateGroupRole, clientUID.String()+"/"+params[1].Value)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestPutGroupRole_WhereSessionIsClientAdmin_DoesNotVerifyGroupRank() {
+	//arrange
+	clientUID := uuid.New()
+	session := models.CreateNewSession("admin", 0)
+	session.ClientAdminUIDs = []uuid.UUID{clientUID}
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: clientUID.String(),
+		},
+		{
+			Key:   "group",
+			Value: "group",
+		},
+	}
+	req := suite.CreateDummyJSONRequest(handlers.PutGroupRoleBody{Roles: []string{"role"}})
+
+	suite.ControllersMock.On("UpdateGroupRole", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, _ := suite.CoreHandlers.PutGroupRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "VerifyGroupRank", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestDeleteGroupRole_WithInvalidClientID_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: "invalid",
+		},
+	}
+
+	//act
+	status, res := suite.CoreHandlers.DeleteGroupRole(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "client id", "invalid format")
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestDeleteGroupRole_WithMissingGroupName_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	//act
+	status, res := suite.CoreHandlers.DeleteGroupRole(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "group name not provided")
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestDeleteGroupRole_WithClientErrorDeletingGroupRole_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+		{
+			Key:   "group",
+			Value: "group",
+		},
+	}
+
+	message := "delete group role error"
+	suite.ControllersMock.On("DeleteGroupRole", mock.Anything, mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.DeleteGroupRole(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestDeleteGroupRole_WithInternalErrorDeletingGroupRole_ReturnsInternalServerError() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+		{
+			Key:   "group",
+			Value: "group",
+		},
+	}
+
+	suite.ControllersMock.On("DeleteGroupRole", mock.Anything, mock.Anything, mock.Anything).Return(common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteGroupRole(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestDeleteGroupRole_WithNoErrors_ReturnsSuccess() {
+	//arrange
+	req := suite.CreateDummyJSONRequest(nil)
+	session := models.CreateNewSession("admin", 5)
+	clientUID := uuid.New()
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: clientUID.String(),
+		},
+		{
+			Key:   "group",
+			Value: "group",
+		},
+	}
+
+	suite.ControllersMock.On("DeleteGroupRole", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteGroupRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessResponse(res)
+
+	suite.ControllersMock.AssertCalled(suite.T(), "DeleteGroupRole", &suite.CRUDMock, clientUID, params[1].Value)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionDeleteGroupRole, clientUID.String()+"/"+params[1].Value)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestGetUserRoleAssignments_WithInvalidClientID_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: "invalid",
+		},
+	}
+
+	//act
+	status, res := suite.CoreHandlers.GetUserRoleAssignments(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "client id", "invalid format")
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestGetUserRoleAssignments_WithMissingUsername_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	//act
+	status, res := suite.CoreHandlers.GetUserRoleAssignments(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "username not provided")
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestGetUserRoleAssignments_WithFalseResultVerifyingUserRank_ReturnsForbidden() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+		{
+			Key:   "username",
+			Value: "username",
+		},
+	}
+
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(false, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.GetUserRoleAssignments(nil, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusForbidden, status)
+	suite.InsufficientPermissionsErrorResponse(res)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "GetUserRoleAssignments", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestGetUserRoleAssignments_WithClientErrorGettingUserRoleAssignments_ReturnsBadRequest() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+		{
+			Key:   "username",
+			Value: "username",
+		},
+	}
+
+	message := "get user role assignments error"
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("GetUserRoleAssignments", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.GetUserRoleAssignments(nil, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestGetUserRoleAssignments_WithInternalErrorGettingUserRoleAssignments_ReturnsInternalServerError() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+		{
+			Key:   "username",
+			Value: "username",
+		},
+	}
+
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("GetUserRoleAssignments", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.GetUserRoleAssignments(nil, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestGetUserRoleAssignments_WithNoAssignments_ReturnsEmptyEffectiveRoles() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+		{
+			Key:   "username",
+			Value: "username",
+		},
+	}
+
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("GetUserRoleAssignments", mock.Anything, mock.Anything, mock.Anything).Return([]*models.RoleAssignment{}, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.GetUserRoleAssignments(nil, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, handlers.UserRoleAssignmentsDataResponse{
+		Username:       params[1].Value,
+		EffectiveRoles: []string{},
+		Assignments:    []handlers.RoleAssignmentDataResponse{},
+	})
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestGetUserRoleAssignments_WithNoErrors_ReturnsUserRoleAssignmentsData() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	clientUID := uuid.New()
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: clientUID.String(),
+		},
+		{
+			Key:   "username",
+			Value: "username",
+		},
+	}
+
+	assignments := []*models.RoleAssignment{
+		{Source: models.RoleAssignmentSourceUser, Roles: []string{"role1"}},
+		{Source: models.RoleAssignmentSourceGroup, GroupName: "group", Priority: 2, Roles: []string{"role2"}},
+	}
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("GetUserRoleAssignments", mock.Anything, mock.Anything, mock.Anything).Return(assignments, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.GetUserRoleAssignments(nil, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, handlers.UserRoleAssignmentsDataResponse{
+		Username:       params[1].Value,
+		EffectiveRoles: assignments[0].Roles,
+		Assignments: []handlers.RoleAssignmentDataResponse{
+			{
+				Source: models.RoleAssignmentSourceUser,
+				Roles:  assignments[0].Roles,
+			},
+			{
+				Source:   models.RoleAssignmentSourceGroup,
+				Group:    assignments[1].GroupName,
+				Priority: assignments[1].Priority,
+				Roles:    assignments[1].Roles,
+			},
+		},
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "VerifyUserRank", &suite.CRUDMock, params[1].Value, session.Rank)
+	suite.ControllersMock.AssertCalled(suite.T(), "GetUserRoleAssignments", &suite.CRUDMock, clientUID, params[1].Value)
+}
+
+func (suite *GroupRoleHandlerTestSuite) TestGetUserRoleAssignments_WhereSessionIsClientAdmin_DoesNotVerifyUserRank() {
+	//arrange
+	clientUID := uuid.New()
+	session := models.CreateNewSession("admin", 0)
+	session.ClientAdminUIDs = []uuid.UUID{clientUID}
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: clientUID.String(),
+		},
+		{
+			Key:   "username",
+			Value: "username",
+		},
+	}
+
+	suite.ControllersMock.On("GetUserRoleAssignments", mock.Anything, mock.Anything, mock.Anything).Return([]*models.RoleAssignment{}, common.NoError())
+
+	//act
+	status, _ := suite.CoreHandlers.GetUserRoleAssignments(nil, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "VerifyUserRank", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGroupRoleHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &GroupRoleHandlerTestSuite{})
+}
@@ -33,6 +33,15 @@ type Handlers interface {
 	// DeleteUser handles DELETE requests to /user/:username.
 	DeleteUser(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
 
+	// PostUserTOTP handles POST requests to /user/totp.
+	PostUserTOTP(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// PostUserTOTPConfirm handles POST requests to /user/totp/confirm.
+	PostUserTOTPConfirm(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// PostUserTOTPDisable handles POST requests to /user/totp/disable.
+	PostUserTOTPDisable(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
 	// GetClients handles GET requests to /clients.
 	GetClients(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
 
@@ -45,6 +54,9 @@ type Handlers interface {
 	// DeleteClient handles DELETE requests to /client/:id.
 	DeleteClient(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
 
+	// PostClientSecret handles POST requests to /client/:id/secret.
+	PostClientSecret(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
 	// GetUserRoles handles GET requests to /client/:id/roles.
 	GetUserRoles(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
 
@@ -57,6 +69,102 @@ type Handlers interface {
 	// DeleteUserRole handles DELETE requests to /client/:id/role/:username.
 	DeleteUserRole(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
 
+	// GetRoleDefinitions handles GET requests to /client/:id/roles/definitions.
+	GetRoleDefinitions(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// PostRoleDefinition handles POST requests to /client/:id/roles/definitions.
+	PostRoleDefinition(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// PutRoleDefinition handles PUT requests to /client/:id/roles/definitions/:name.
+	PutRoleDefinition(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// DeleteRoleDefinition handles DELETE requests to /client/:id/roles/definitions/:name.
+	DeleteRoleDefinition(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// GetAdminRoles handles GET requests to /admin/roles.
+	GetAdminRoles(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// PostAdminRole handles POST requests to /admin/role.
+	PostAdminRole(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// PutAdminRole handles PUT requests to /admin/role/:name.
+	PutAdminRole(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// DeleteAdminRole handles DELETE requests to /admin/role/:name.
+	DeleteAdminRole(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// GetUserAdminRoles handles GET requests to /user/:username/admin-roles.
+	GetUserAdminRoles(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// PutUserAdminRoles handles PUT requests to /user/:username/admin-roles.
+	PutUserAdminRoles(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// GetClientAdmins handles GET requests to /client/:id/admins.
+	GetClientAdmins(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// PostClientAdmin handles POST requests to /client/:id/admin.
+	PostClientAdmin(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// DeleteClientAdmin handles DELETE requests to /client/:id/admin/:username.
+	DeleteClientAdmin(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// GetGroups handles GET requests to /groups.
+	GetGroups(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// PostGroup handles POST requests to /group.
+	PostGroup(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// PutGroup handles PUT requests to /group/:name.
+	PutGroup(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// DeleteGroup handles DELETE requests to /group/:name.
+	DeleteGroup(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// GetGroupMembers handles GET requests to /group/:name/members.
+	GetGroupMembers(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// PostGroupMember handles POST requests to /group/:name/member.
+	PostGroupMember(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// DeleteGroupMember handles DELETE requests to /group/:name/member/:username.
+	DeleteGroupMember(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// GetGroupRoles handles GET requests to /client/:id/group-roles.
+	GetGroupRoles(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// PostGroupRole handles POST requests to /client/:id/group-role.
+	PostGroupRole(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// PutGroupRole handles PUT requests to /client/:id/group-role/:group.
+	PutGroupRole(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// DeleteGroupRole handles DELETE requests to /client/:id/group-role/:group.
+	DeleteGroupRole(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// GetUserRoleAssignments handles GET requests to /client/:id/role/:username/effective.
+	GetUserRoleAssignments(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// PostInvitation handles POST requests to /invitation.
+	PostInvitation(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// DeleteInvitation handles DELETE requests to /invitation/:id.
+	DeleteInvitation(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// GetAcceptInvitation handles GET requests to /invitation/accept.
+	GetAcceptInvitation(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// PostAcceptInvitation handles POST requests to /invitation/accept.
+	PostAcceptInvitation(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// GetLockouts handles GET requests to /lockouts.
+	GetLockouts(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// DeleteLockout handles DELETE requests to /lockout/:type/:key.
+	DeleteLockout(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// GetAuditEvents handles GET requests to /audit.
+	GetAuditEvents(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
 	// PostSession handles POST requests to /session.
 	PostSession(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
 
@@ -68,6 +176,33 @@ type Handlers interface {
 
 	// PostToken handles POST requests to /token.
 	PostToken(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// GetJWKS handles GET requests to /.well-known/jwks.json.
+	GetJWKS(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// GetForgotPassword handles GET requests to /password/forgot.
+	GetForgotPassword(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// PostForgotPassword handles POST requests to /password/forgot.
+	PostForgotPassword(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// GetResetPassword handles GET requests to /password/reset.
+	GetResetPassword(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// PostResetPassword handles POST requests to /password/reset.
+	PostResetPassword(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// GetAuthorize handles GET requests to /authorize.
+	GetAuthorize(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// PostAuthorize handles POST requests to /authorize.
+	PostAuthorize(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// PostOAuthToken handles POST requests to /oauth/token.
+	PostOAuthToken(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
+
+	// GetOpenIDConfiguration handles GET requests to /.well-known/openid-configuration.
+	GetOpenIDConfiguration(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
 }
 
 type CoreHandlers struct {
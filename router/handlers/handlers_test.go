@@ -1,12 +1,16 @@
 package handlers_test
 
 import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
 	controllermocks "github.com/mhogar/amber/controllers/mocks"
 	datamocks "github.com/mhogar/amber/data/mocks"
+	"github.com/mhogar/amber/models"
 	"github.com/mhogar/amber/router/handlers"
 	renderermocks "github.com/mhogar/amber/router/renderer/mocks"
 	"github.com/mhogar/amber/testing/helpers"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -22,8 +26,12 @@ type HandlersTestSuite struct {
 }
 
 func (suite *HandlersTestSuite) SetupTest() {
+	viper.Set("lockout", config.LockoutConfig{})
+	viper.Set("server", config.ServerConfig{})
+
 	suite.CRUDMock = datamocks.DataCRUD{}
 	suite.ControllersMock = controllermocks.Controllers{}
+	suite.ControllersMock.On("CreateAuditEvent", mock.Anything, mock.Anything).Return(common.NoError())
 	suite.RendererMock = renderermocks.Renderer{}
 
 	suite.RenderViewResult = []byte("render view result")
@@ -40,3 +48,17 @@ func (suite *HandlersTestSuite) SetupTest() {
 func (suite *HandlersTestSuite) AssertRenderViewResult(expected interface{}) {
 	suite.Equal(expected, suite.RenderViewResult)
 }
+
+// FailAuditEvents replaces the default audit event expectation with one that returns an internal error.
+// It must be called before any other expectations are set.
+func (suite *HandlersTestSuite) FailAuditEvents() {
+	suite.ControllersMock = controllermocks.Controllers{}
+	suite.ControllersMock.On("CreateAuditEvent", mock.Anything, mock.Anything).Return(common.InternalError())
+}
+
+// AssertAuditEventCreated asserts an audit event was created with the given actor, action, and target.
+func (suite *HandlersTestSuite) AssertAuditEventCreated(actor string, action string, target string) {
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateAuditEvent", &suite.CRUDMock, mock.MatchedBy(func(event *models.AuditEvent) bool {
+		return event.Actor == actor && event.Action == action && event.Target == target
+	}))
+}
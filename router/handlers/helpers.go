@@ -5,6 +5,15 @@ import (
 	"errors"
 	"io"
 	"log"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/controllers"
+	"github.com/mhogar/amber/data"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/router/renderer"
 )
 
 func parseJSONBody(r io.Reader, v interface{}) error {
@@ -17,3 +26,84 @@ func parseJSONBody(r io.Reader, v interface{}) error {
 
 	return nil
 }
+
+// parseUserCredentialsForm reads the credentials submitted by any step of the login view.
+func parseUserCredentialsForm(req *http.Request) controllers.UserCredentials {
+	return controllers.UserCredentials{
+		Username:    req.PostFormValue("username"),
+		Password:    req.PostFormValue("password"),
+		Challenge:   req.PostFormValue("challenge"),
+		Code:        req.PostFormValue("code"),
+		NewPassword: req.PostFormValue("new_password"),
+		IPAddress:   getClientIP(req),
+	}
+}
+
+// auditEvent records the action performed by the session's user in the audit log.
+// The CRUD should be the same one the action was performed with, so the event is only saved if the action is.
+func (h CoreHandlers) auditEvent(req *http.Request, session *models.Session, CRUD data.DataCRUD, action string, target string) common.CustomError {
+	event := models.CreateNewAuditEvent(session.Username, action, target, getClientIP(req))
+	return h.Controllers.CreateAuditEvent(CRUD, event)
+}
+
+// getClientIP gets the ip address the request came from.
+// The X-Forwarded-For header is only used if the config trusts proxies, since clients can set it to anything.
+func getClientIP(req *http.Request) string {
+	//each proxy appends the address it received the request from, so the client's is the one added by the outermost trusted proxy
+	if ip := renderer.GetTrustedForwardedValue(req, "X-Forwarded-For"); ip != "" {
+		return ip
+	}
+
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+// parsePageQuery parses the search, sort, order, cursor, and limit query params of a list request.
+// Returns the page query and any errors, which are safe to show to the client.
+func parsePageQuery(query url.Values) (models.PageQuery, error) {
+	page := models.PageQuery{
+		Search: query.Get("search"),
+		SortBy: query.Get("sort"),
+	}
+
+	//parse the order
+	switch query.Get("order") {
+	case "", "asc":
+	case "desc":
+		page.SortDescending = true
+	default:
+		return page, errors.New("order must be either asc or desc")
+	}
+
+	//parse the cursor
+	if cursor := query.Get("cursor"); cursor != "" {
+		var err error
+		page.After, err = models.DecodeCursor(cursor)
+		if err != nil {
+			log.Println(common.ChainError("error decoding cursor", err))
+			return page, errors.New("cursor is invalid")
+		}
+	}
+
+	//parse the limit
+	var err error
+	page.Limit, err = parseIntQueryParam(query, "limit")
+	if err != nil {
+		log.Println(common.ChainError("error parsing limit", err))
+		return page, errors.New("limit must be an integer")
+	}
+
+	return page, nil
+}
+
+// newPageResponse returns an http OK status and a new page response with the provided data and page info.
+func newPageResponse(data interface{}, page models.PageInfo) (int, common.PageResponse) {
+	nextCursor := ""
+	if page.NextCursor != nil {
+		nextCursor = page.NextCursor.Encode()
+	}
+	return common.NewSuccessPageResponse(data, nextCursor, page.Total)
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+)
+
+type InvitationRoleBody struct {
+	ClientID string `json:"client_id"`
+	Role     string `json:"role"`
+}
+
+type PostInvitationBody struct {
+	Email string               `json:"email"`
+	Rank  int                  `json:"rank"`
+	Roles []InvitationRoleBody `json:"roles"`
+}
+
+type InvitationDataResponse struct {
+	ID        string    `json:"id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Link      string    `json:"link"`
+	PostInvitationBody
+}
+
+func (h CoreHandlers) PostInvitation(req *http.Request, _ httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//parse the body
+	var body PostInvitationBody
+	err := parseJSONBody(req.Body, &body)
+	if err != nil {
+		log.Println(common.ChainError("error parsing PostInvitation request body", err))
+		return common.NewBadRequestResponse("invalid json body")
+	}
+
+	//verify the session has at least the rank of the user being invited
+	if body.Rank > session.Rank {
+		return common.NewInsufficientPermissionsErrorResponse()
+	}
+
+	//verify the session has a greater rank than the user if giving them roles
+	if len(body.Roles) > 0 && body.Rank >= session.Rank {
+		return common.NewInsufficientPermissionsErrorResponse()
+	}
+
+	//parse the roles
+	roles := make([]*models.InvitationRole, len(body.Roles))
+	for index, role := range body.Roles {
+		clientID, err := uuid.Parse(role.ClientID)
+		if err != nil {
+			log.Println(common.ChainError("error parsing client id", err))
+			return common.NewBadRequestResponse("client id is in an invalid format")
+		}
+
+		roles[index] = models.CreateInvitationRole(clientID, role.Role)
+	}
+
+	//create the invitation
+	invitation, link, cerr := h.Controllers.CreateInvitation(CRUD, body.Email, body.Rank, roles)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionCreateInvitation, invitation.ID.String())
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessDataResponse(h.newInvitationDataResponse(invitation, link))
+}
+
+func (h CoreHandlers) DeleteInvitation(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//parse the invitation id
+	id, err := uuid.Parse(params.ByName("id"))
+	if err != nil {
+		log.Println(common.ChainError("error parsing invitation id", err))
+		return common.NewBadRequestResponse("invitation id is in an invalid format")
+	}
+
+	//verify the session has a rank at least as great as the invitation's
+	res, cerr := h.Controllers.VerifyInvitationRank(CRUD, id, session.Rank)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+	if !res {
+		return common.NewInsufficientPermissionsErrorResponse()
+	}
+
+	//delete the invitation
+	cerr = h.Controllers.DeleteInvitation(CRUD, id)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionRevokeInvitation, id.String())
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessResponse()
+}
+
+// AcceptInvitationViewData is the data for the accept invitation view.
+// Token is the invitation token from the link. If Message is set, the view shows it instead of the form.
+type AcceptInvitationViewData struct {
+	Token    string
+	Username string
+	Message  string
+	Error    string
+}
+
+func (h CoreHandlers) GetAcceptInvitation(req *http.Request, _ httprouter.Params, _ *models.Session, _ data.DataCRUD) (int, interface{}) {
+	return h.renderAcceptInvitationView(req, AcceptInvitationViewData{
+		Token: req.URL.Query().Get("token"),
+	}, common.NoError())
+}
+
+func (h CoreHandlers) PostAcceptInvitation(req *http.Request, _ httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	data := AcceptInvitationViewData{
+		Token:    req.PostFormValue("token"),
+		Username: req.PostFormValue("username"),
+	}
+
+	//verify the password was entered the same both times
+	password := req.PostFormValue("password")
+	if password != req.PostFormValue("confirm_password") {
+		return h.renderAcceptInvitationView(req, data, common.ClientError("passwords do not match"))
+	}
+
+	//create the user
+	user, cerr := h.Controllers.AcceptInvitation(CRUD, data.Token, data.Username, password)
+	if cerr.Type != common.ErrorTypeNone {
+		return h.renderAcceptInvitationView(req, data, cerr)
+	}
+
+	//audit the action as the new user
+	event := models.CreateNewAuditEvent(user.Username, models.AuditActionAcceptInvitation, user.Username, getClientIP(req))
+	cerr = h.Controllers.CreateAuditEvent(CRUD, event)
+	if cerr.Type != common.ErrorTypeNone {
+		return h.renderAcceptInvitationView(req, data, cerr)
+	}
+
+	return h.renderAcceptInvitationView(req, AcceptInvitationViewData{
+		Message: "Your account has been created. You can now sign in with your username and password.",
+	}, common.NoError())
+}
+
+// renderAcceptInvitationView renders the accept invitation view with the data and the error's message.
+// Errors are sent with an error status so any changes made by the request are rolled back, e.g. the invitation is kept if the username is rejected.
+func (h CoreHandlers) renderAcceptInvitationView(req *http.Request, data AcceptInvitationViewData, cerr common.CustomError) (int, interface{}) {
+	status := http.StatusOK
+	if cerr.Type == common.ErrorTypeClient {
+		status = http.StatusBadRequest
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		status = http.StatusInternalServerError
+	}
+	if cerr.Type != common.ErrorTypeNone {
+		data.Error = cerr.Error()
+	}
+
+	//render the view
+	return status, h.Renderer.RenderView(req, data, "invitation/accept")
+}
+
+func (CoreHandlers) newInvitationDataResponse(invitation *models.Invitation, link string) InvitationDataResponse {
+	roles := make([]InvitationRoleBody, len(invitation.Roles))
+	for index, role := range invitation.Roles {
+		roles[index] = InvitationRoleBody{
+			ClientID: role.ClientUID.String(),
+			Role:     role.Role,
+		}
+	}
+
+	return InvitationDataResponse{
+		ID:        invitation.ID.String(),
+		ExpiresAt: invitation.ExpiresAt,
+		Link:      link,
+		PostInvitationBody: PostInvitationBody{
+			Email: invitation.Email,
+			Rank:  invitation.Rank,
+			Roles: roles,
+		},
+	}
+}
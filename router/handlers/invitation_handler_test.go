@@ -0,0 +1,467 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/router/handlers"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type InvitationHandlerTestSuite struct {
+	HandlersTestSuite
+}
+
+func (suite *InvitationHandlerTestSuite) AcceptInvitationViewRenderedWithData(token string, username string, message string, errSubStrings ...string) {
+	data := suite.RenderViewData.(handlers.AcceptInvitationViewData)
+	suite.Equal(token, data.Token)
+	suite.Equal(username, data.Username)
+	suite.Equal(message, data.Message)
+	suite.ContainsSubstrings(data.Error, errSubStrings...)
+
+	suite.RendererMock.AssertCalled(suite.T(), "RenderView", mock.Anything, data, "invitation/accept")
+}
+
+func (suite *InvitationHandlerTestSuite) TestPostInvitation_WithInvalidJSONBody_ReturnsBadRequest() {
+	//arrange
+	req := suite.CreateDummyJSONRequest("invalid")
+
+	//act
+	status, res := suite.CoreHandlers.PostInvitation(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "invalid json body")
+}
+
+func (suite *InvitationHandlerTestSuite) TestPostInvitation_WithInsufficientRank_ReturnsForbidden() {
+	var body handlers.PostInvitationBody
+
+	testCase := func() {
+		//arrange
+		session := models.CreateNewSession("admin", 5)
+		req := suite.CreateDummyJSONRequest(body)
+
+		//act
+		status, res := suite.CoreHandlers.PostInvitation(req, nil, session, &suite.CRUDMock)
+
+		//assert
+		suite.Require().Equal(http.StatusForbidden, status)
+		suite.InsufficientPermissionsErrorResponse(res)
+		suite.ControllersMock.AssertNotCalled(suite.T(), "CreateInvitation", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	}
+
+	body = handlers.PostInvitationBody{
+		Rank: 6,
+	}
+	suite.Run("GreaterRank", testCase)
+
+	body = handlers.PostInvitationBody{
+		Rank: 5,
+		Roles: []handlers.InvitationRoleBody{
+			{
+				ClientID: uuid.New().String(),
+				Role:     "role",
+			},
+		},
+	}
+	suite.Run("EqualRankWithRoles", testCase)
+}
+
+func (suite *InvitationHandlerTestSuite) TestPostInvitation_WithInvalidClientID_ReturnsBadRequest() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	body := handlers.PostInvitationBody{
+		Roles: []handlers.InvitationRoleBody{
+			{
+				ClientID: "invalid",
+				Role:     "role",
+			},
+		},
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	//act
+	status, res := suite.CoreHandlers.PostInvitation(req, nil, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "client id", "invalid format")
+}
+
+func (suite *InvitationHandlerTestSuite) TestPostInvitation_WithClientErrorCreatingInvitation_ReturnsBadRequest() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	req := suite.CreateDummyJSONRequest(handlers.PostInvitationBody{})
+
+	message := "create invitation error"
+	suite.ControllersMock.On("CreateInvitation", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, "", common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostInvitation(req, nil, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *InvitationHandlerTestSuite) TestPostInvitation_WithInternalErrorCreatingInvitation_ReturnsInternalServerError() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	req := suite.CreateDummyJSONRequest(handlers.PostInvitationBody{})
+
+	suite.ControllersMock.On("CreateInvitation", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, "", common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostInvitation(req, nil, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *InvitationHandlerTestSuite) TestPostInvitation_WithErrorCreatingAuditEvent_ReturnsInternalServerError() {
+	//arrange
+	suite.FailAuditEvents()
+
+	session := models.CreateNewSession("admin", 5)
+	req := suite.CreateDummyJSONRequest(handlers.PostInvitationBody{})
+
+	invitation := models.CreateNewInvitation([]byte("hash"), "", 0, nil, time.Hour)
+	suite.ControllersMock.On("CreateInvitation", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(invitation, "link", common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostInvitation(req, nil, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *InvitationHandlerTestSuite) TestPostInvitation_WithNoErrors_ReturnsInvitationData() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	clientID := uuid.New()
+
+	body := handlers.PostInvitationBody{
+		Email: "user@example.com",
+		Rank:  4,
+		Roles: []handlers.InvitationRoleBody{
+			{
+				ClientID: clientID.String(),
+				Role:     "role",
+			},
+		},
+	}
+	req := suite.CreateJSONRequest("POST", "/invitation", "", body)
+
+	roles := []*models.InvitationRole{
+		models.CreateInvitationRole(clientID, "role"),
+	}
+	invitation := models.CreateNewInvitation([]byte("hash"), body.Email, body.Rank, roles, time.Hour)
+	link := "http://base/invitation/accept?token=token"
+	suite.ControllersMock.On("CreateInvitation", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(invitation, link, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostInvitation(req, nil, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, handlers.InvitationDataResponse{
+		ID:                 invitation.ID.String(),
+		ExpiresAt:          invitation.ExpiresAt,
+		Link:               link,
+		PostInvitationBody: body,
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateInvitation", &suite.CRUDMock, body.Email, body.Rank, roles)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionCreateInvitation, invitation.ID.String())
+}
+
+func (suite *InvitationHandlerTestSuite) TestDeleteInvitation_WithInvalidID_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: "invalid",
+		},
+	}
+
+	//act
+	status, res := suite.CoreHandlers.DeleteInvitation(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "invitation id", "invalid format")
+}
+
+func (suite *InvitationHandlerTestSuite) TestDeleteInvitation_WithClientErrorVerifyingInvitationRank_ReturnsBadRequest() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	message := "verify invitation rank error"
+	suite.ControllersMock.On("VerifyInvitationRank", mock.Anything, mock.Anything, mock.Anything).Return(false, common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.DeleteInvitation(nil, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *InvitationHandlerTestSuite) TestDeleteInvitation_WithInternalErrorVerifyingInvitationRank_ReturnsInternalServerError() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	suite.ControllersMock.On("VerifyInvitationRank", mock.Anything, mock.Anything, mock.Anything).Return(false, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteInvitation(nil, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *InvitationHandlerTestSuite) TestDeleteInvitation_WithFalseResultVerifyingInvitationRank_ReturnsForbidden() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	suite.ControllersMock.On("VerifyInvitationRank", mock.Anything, mock.Anything, mock.Anything).Return(false, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteInvitation(nil, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusForbidden, status)
+	suite.InsufficientPermissionsErrorResponse(res)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "DeleteInvitation", mock.Anything, mock.Anything)
+}
+
+func (suite *InvitationHandlerTestSuite) TestDeleteInvitation_WithClientErrorDeletingInvitation_ReturnsBadRequest() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	suite.ControllersMock.On("VerifyInvitationRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+
+	message := "delete invitation error"
+	suite.ControllersMock.On("DeleteInvitation", mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.DeleteInvitation(nil, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *InvitationHandlerTestSuite) TestDeleteInvitation_WithInternalErrorDeletingInvitation_ReturnsInternalServerError() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	suite.ControllersMock.On("VerifyInvitationRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("DeleteInvitation", mock.Anything, mock.Anything).Return(common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteInvitation(nil, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *InvitationHandlerTestSuite) TestDeleteInvitation_WithNoErrors_ReturnsSuccess() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	id := uuid.New()
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: id.String(),
+		},
+	}
+	req := suite.CreateDummyJSONRequest(nil)
+
+	suite.ControllersMock.On("VerifyInvitationRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("DeleteInvitation", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteInvitation(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessResponse(res)
+
+	suite.ControllersMock.AssertCalled(suite.T(), "VerifyInvitationRank", &suite.CRUDMock, id, session.Rank)
+	suite.ControllersMock.AssertCalled(suite.T(), "DeleteInvitation", &suite.CRUDMock, id)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionRevokeInvitation, id.String())
+}
+
+func (suite *InvitationHandlerTestSuite) TestGetAcceptInvitation_RendersAcceptInvitationViewWithToken() {
+	//arrange
+	token := "token"
+	req := suite.CreateRequest("", "/invitation/accept?token="+token, "", nil)
+
+	//act
+	status, res := suite.CoreHandlers.GetAcceptInvitation(req, nil, nil, nil)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.AssertRenderViewResult(res)
+	suite.AcceptInvitationViewRenderedWithData(token, "", "")
+}
+
+func (suite *InvitationHandlerTestSuite) TestPostAcceptInvitation_WherePasswordsDoNotMatch_RendersAcceptInvitationViewWithError() {
+	//arrange
+	values := url.Values{
+		"token":            []string{"token"},
+		"username":         []string{"username"},
+		"password":         []string{"password"},
+		"confirm_password": []string{"other password"},
+	}
+	req := suite.CreateDummyFormRequest(values)
+
+	//act
+	status, res := suite.CoreHandlers.PostAcceptInvitation(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.AssertRenderViewResult(res)
+	suite.AcceptInvitationViewRenderedWithData("token", "username", "", "passwords do not match")
+	suite.ControllersMock.AssertNotCalled(suite.T(), "AcceptInvitation", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *InvitationHandlerTestSuite) TestPostAcceptInvitation_WithClientErrorAcceptingInvitation_RendersAcceptInvitationViewWithError() {
+	//arrange
+	values := url.Values{
+		"token":            []string{"token"},
+		"username":         []string{"username"},
+		"password":         []string{"password"},
+		"confirm_password": []string{"password"},
+	}
+	req := suite.CreateDummyFormRequest(values)
+
+	message := "accept invitation error"
+	suite.ControllersMock.On("AcceptInvitation", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostAcceptInvitation(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.AssertRenderViewResult(res)
+	suite.AcceptInvitationViewRenderedWithData("token", "username", "", message)
+}
+
+func (suite *InvitationHandlerTestSuite) TestPostAcceptInvitation_WithInternalErrorAcceptingInvitation_RendersAcceptInvitationViewWithInternalServerError() {
+	//arrange
+	values := url.Values{
+		"token":            []string{"token"},
+		"username":         []string{"username"},
+		"password":         []string{"password"},
+		"confirm_password": []string{"password"},
+	}
+	req := suite.CreateDummyFormRequest(values)
+
+	suite.ControllersMock.On("AcceptInvitation", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostAcceptInvitation(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.AssertRenderViewResult(res)
+	suite.AcceptInvitationViewRenderedWithData("token", "username", "", "internal error")
+}
+
+func (suite *InvitationHandlerTestSuite) TestPostAcceptInvitation_WithErrorCreatingAuditEvent_RendersAcceptInvitationViewWithInternalServerError() {
+	//arrange
+	suite.FailAuditEvents()
+
+	values := url.Values{
+		"token":            []string{"token"},
+		"username":         []string{"username"},
+		"password":         []string{"password"},
+		"confirm_password": []string{"password"},
+	}
+	req := suite.CreateDummyFormRequest(values)
+
+	suite.ControllersMock.On("AcceptInvitation", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(models.CreateUser("username", 0, nil), common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostAcceptInvitation(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.AssertRenderViewResult(res)
+	suite.AcceptInvitationViewRenderedWithData("token", "username", "", "internal error")
+}
+
+func (suite *InvitationHandlerTestSuite) TestPostAcceptInvitation_AcceptsInvitationAndRendersMessage() {
+	//arrange
+	values := url.Values{
+		"token":            []string{"token"},
+		"username":         []string{"username"},
+		"password":         []string{"password"},
+		"confirm_password": []string{"password"},
+	}
+	req := suite.CreateDummyFormRequest(values)
+
+	suite.ControllersMock.On("AcceptInvitation", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(models.CreateUser("username", 0, nil), common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostAcceptInvitation(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.AssertRenderViewResult(res)
+	suite.ControllersMock.AssertCalled(suite.T(), "AcceptInvitation", &suite.CRUDMock, "token", "username", "password")
+	suite.AssertAuditEventCreated("username", models.AuditActionAcceptInvitation, "username")
+
+	data := suite.RenderViewData.(handlers.AcceptInvitationViewData)
+	suite.Contains(data.Message, "account has been created")
+	suite.Empty(data.Error)
+}
+
+func TestInvitationHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &InvitationHandlerTestSuite{})
+}
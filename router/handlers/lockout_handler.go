@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	"github.com/mhogar/amber/models"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type LockoutDataResponse struct {
+	Type           string    `json:"type"`
+	Key            string    `json:"key"`
+	FailedAttempts int       `json:"failed_attempts"`
+	LockedUntil    time.Time `json:"locked_until"`
+}
+
+func (h CoreHandlers) GetLockouts(_ *http.Request, _ httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//get the lockouts
+	throttles, cerr := h.Controllers.GetLockouts(CRUD)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//return the data
+	data := make([]LockoutDataResponse, len(throttles))
+	for index, throttle := range throttles {
+		data[index] = LockoutDataResponse{
+			Type:           throttle.Type,
+			Key:            throttle.Key,
+			FailedAttempts: throttle.FailedAttempts,
+			LockedUntil:    throttle.LockedUntil,
+		}
+	}
+	return common.NewSuccessDataResponse(data)
+}
+
+func (h CoreHandlers) DeleteLockout(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	throttleType := params.ByName("type")
+	key := params.ByName("key")
+
+	//clear the lockout
+	cerr := h.Controllers.ClearLockout(CRUD, throttleType, key)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionClearLockout, throttleType+"/"+key)
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessResponse()
+}
@@ -0,0 +1,141 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/router/handlers"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LockoutHandlerTestSuite struct {
+	HandlersTestSuite
+}
+
+func (suite *LockoutHandlerTestSuite) TestGetLockouts_WithClientErrorGettingLockouts_ReturnsBadRequest() {
+	//arrange
+	message := "get lockouts error"
+	suite.ControllersMock.On("GetLockouts", mock.Anything).Return(nil, common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.GetLockouts(nil, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *LockoutHandlerTestSuite) TestGetLockouts_WithInternalErrorGettingLockouts_ReturnsInternalServerError() {
+	//arrange
+	suite.ControllersMock.On("GetLockouts", mock.Anything).Return(nil, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.GetLockouts(nil, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *LockoutHandlerTestSuite) TestGetLockouts_WithNoErrors_ReturnsLockoutData() {
+	//arrange
+	throttles := []*models.LoginThrottle{
+		models.CreateLoginThrottle(models.LoginThrottleTypeIP, "127.0.0.1", 10, time.Now(), time.Now().Add(time.Minute)),
+		models.CreateLoginThrottle(models.LoginThrottleTypeUsername, "username", 5, time.Now(), time.Now().Add(time.Hour)),
+	}
+	suite.ControllersMock.On("GetLockouts", mock.Anything).Return(throttles, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.GetLockouts(nil, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, []handlers.LockoutDataResponse{
+		{
+			Type:           throttles[0].Type,
+			Key:            throttles[0].Key,
+			FailedAttempts: throttles[0].FailedAttempts,
+			LockedUntil:    throttles[0].LockedUntil,
+		},
+		{
+			Type:           throttles[1].Type,
+			Key:            throttles[1].Key,
+			FailedAttempts: throttles[1].FailedAttempts,
+			LockedUntil:    throttles[1].LockedUntil,
+		},
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "GetLockouts", &suite.CRUDMock)
+}
+
+func (suite *LockoutHandlerTestSuite) createLockoutParams(throttleType string, key string) httprouter.Params {
+	return []httprouter.Param{
+		{
+			Key:   "type",
+			Value: throttleType,
+		},
+		{
+			Key:   "key",
+			Value: key,
+		},
+	}
+}
+
+func (suite *LockoutHandlerTestSuite) TestDeleteLockout_WithClientErrorClearingLockout_ReturnsBadRequest() {
+	//arrange
+	params := suite.createLockoutParams(models.LoginThrottleTypeUsername, "username")
+
+	message := "clear lockout error"
+	suite.ControllersMock.On("ClearLockout", mock.Anything, mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.DeleteLockout(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *LockoutHandlerTestSuite) TestDeleteLockout_WithInternalErrorClearingLockout_ReturnsInternalServerError() {
+	//arrange
+	params := suite.createLockoutParams(models.LoginThrottleTypeUsername, "username")
+	suite.ControllersMock.On("ClearLockout", mock.Anything, mock.Anything, mock.Anything).Return(common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteLockout(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *LockoutHandlerTestSuite) TestDeleteLockout_WithNoErrors_ReturnsSuccess() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	req := suite.CreateDummyJSONRequest(nil)
+	throttleType := models.LoginThrottleTypeIP
+	key := "127.0.0.1"
+	params := suite.createLockoutParams(throttleType, key)
+
+	suite.ControllersMock.On("ClearLockout", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteLockout(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessResponse(res)
+
+	suite.ControllersMock.AssertCalled(suite.T(), "ClearLockout", &suite.CRUDMock, throttleType, key)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionClearLockout, throttleType+"/"+key)
+}
+
+func TestLockoutHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &LockoutHandlerTestSuite{})
+}
@@ -19,6 +19,29 @@ type Handlers struct {
 	mock.Mock
 }
 
+// DeleteAdminRole provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) DeleteAdminRole(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
 // DeleteClient provides a mock function with given fields: _a0, _a1, _a2, _a3
 func (_m *Handlers) DeleteClient(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
@@ -42,8 +65,767 @@ func (_m *Handlers) DeleteClient(_a0 *http.Request, _a1 httprouter.Params, _a2 *
 	return r0, r1
 }
 
-// DeleteSession provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *Handlers) DeleteSession(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+// DeleteClientAdmin provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) DeleteClientAdmin(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteGroup provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) DeleteGroup(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteGroupMember provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) DeleteGroupMember(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteGroupRole provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) DeleteGroupRole(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteInvitation provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) DeleteInvitation(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteLockout provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) DeleteLockout(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteRoleDefinition provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) DeleteRoleDefinition(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteSession provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) DeleteSession(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteUser provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) DeleteUser(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteUserRole provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) DeleteUserRole(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// GetAcceptInvitation provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) GetAcceptInvitation(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// GetAdminRoles provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) GetAdminRoles(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// GetAuditEvents provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) GetAuditEvents(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// GetAuthorize provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) GetAuthorize(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// GetClientAdmins provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) GetClientAdmins(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// GetClients provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) GetClients(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// GetForgotPassword provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) GetForgotPassword(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// GetGroupMembers provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) GetGroupMembers(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// GetGroupRoles provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) GetGroupRoles(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// GetGroups provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) GetGroups(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// GetHome provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) GetHome(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// GetJWKS provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) GetJWKS(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// GetLockouts provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) GetLockouts(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// GetOpenIDConfiguration provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) GetOpenIDConfiguration(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// GetResetPassword provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) GetResetPassword(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// GetRoleDefinitions provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) GetRoleDefinitions(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// GetToken provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) GetToken(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// GetUserAdminRoles provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) GetUserAdminRoles(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// GetUserRoleAssignments provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) GetUserRoleAssignments(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// GetUserRoles provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) GetUserRoles(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// GetUsers provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) GetUsers(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// PatchPassword provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PatchPassword(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// PatchUserPassword provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PatchUserPassword(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// PostAcceptInvitation provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PostAcceptInvitation(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
 
 	var r0 int
@@ -65,8 +847,8 @@ func (_m *Handlers) DeleteSession(_a0 *http.Request, _a1 httprouter.Params, _a2
 	return r0, r1
 }
 
-// DeleteUser provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *Handlers) DeleteUser(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+// PostAdminRole provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PostAdminRole(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
 
 	var r0 int
@@ -88,8 +870,8 @@ func (_m *Handlers) DeleteUser(_a0 *http.Request, _a1 httprouter.Params, _a2 *mo
 	return r0, r1
 }
 
-// DeleteUserRole provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *Handlers) DeleteUserRole(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+// PostAuthorize provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PostAuthorize(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
 
 	var r0 int
@@ -111,8 +893,8 @@ func (_m *Handlers) DeleteUserRole(_a0 *http.Request, _a1 httprouter.Params, _a2
 	return r0, r1
 }
 
-// GetClients provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *Handlers) GetClients(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+// PostClient provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PostClient(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
 
 	var r0 int
@@ -134,8 +916,8 @@ func (_m *Handlers) GetClients(_a0 *http.Request, _a1 httprouter.Params, _a2 *mo
 	return r0, r1
 }
 
-// GetHome provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *Handlers) GetHome(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+// PostClientAdmin provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PostClientAdmin(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
 
 	var r0 int
@@ -157,8 +939,8 @@ func (_m *Handlers) GetHome(_a0 *http.Request, _a1 httprouter.Params, _a2 *model
 	return r0, r1
 }
 
-// GetToken provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *Handlers) GetToken(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+// PostClientSecret provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PostClientSecret(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
 
 	var r0 int
@@ -180,8 +962,8 @@ func (_m *Handlers) GetToken(_a0 *http.Request, _a1 httprouter.Params, _a2 *mode
 	return r0, r1
 }
 
-// GetUserRoles provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *Handlers) GetUserRoles(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+// PostForgotPassword provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PostForgotPassword(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
 
 	var r0 int
@@ -203,8 +985,8 @@ func (_m *Handlers) GetUserRoles(_a0 *http.Request, _a1 httprouter.Params, _a2 *
 	return r0, r1
 }
 
-// GetUsers provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *Handlers) GetUsers(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+// PostGroup provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PostGroup(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
 
 	var r0 int
@@ -226,8 +1008,8 @@ func (_m *Handlers) GetUsers(_a0 *http.Request, _a1 httprouter.Params, _a2 *mode
 	return r0, r1
 }
 
-// PatchPassword provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *Handlers) PatchPassword(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+// PostGroupMember provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PostGroupMember(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
 
 	var r0 int
@@ -249,8 +1031,8 @@ func (_m *Handlers) PatchPassword(_a0 *http.Request, _a1 httprouter.Params, _a2
 	return r0, r1
 }
 
-// PatchUserPassword provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *Handlers) PatchUserPassword(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+// PostGroupRole provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PostGroupRole(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
 
 	var r0 int
@@ -272,8 +1054,77 @@ func (_m *Handlers) PatchUserPassword(_a0 *http.Request, _a1 httprouter.Params,
 	return r0, r1
 }
 
-// PostClient provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *Handlers) PostClient(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+// PostInvitation provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PostInvitation(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// PostOAuthToken provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PostOAuthToken(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// PostResetPassword provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PostResetPassword(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// PostRoleDefinition provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PostRoleDefinition(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
 
 	var r0 int
@@ -387,6 +1238,98 @@ func (_m *Handlers) PostUserRole(_a0 *http.Request, _a1 httprouter.Params, _a2 *
 	return r0, r1
 }
 
+// PostUserTOTP provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PostUserTOTP(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// PostUserTOTPConfirm provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PostUserTOTPConfirm(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// PostUserTOTPDisable provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PostUserTOTPDisable(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// PutAdminRole provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PutAdminRole(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
 // PutClient provides a mock function with given fields: _a0, _a1, _a2, _a3
 func (_m *Handlers) PutClient(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
@@ -410,6 +1353,75 @@ func (_m *Handlers) PutClient(_a0 *http.Request, _a1 httprouter.Params, _a2 *mod
 	return r0, r1
 }
 
+// PutGroup provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PutGroup(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// PutGroupRole provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PutGroupRole(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
+// PutRoleDefinition provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PutRoleDefinition(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
 // PutUser provides a mock function with given fields: _a0, _a1, _a2, _a3
 func (_m *Handlers) PutUser(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
@@ -433,6 +1445,29 @@ func (_m *Handlers) PutUser(_a0 *http.Request, _a1 httprouter.Params, _a2 *model
 	return r0, r1
 }
 
+// PutUserAdminRoles provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Handlers) PutUserAdminRoles(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) interface{}); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(interface{})
+		}
+	}
+
+	return r0, r1
+}
+
 // PutUserRole provides a mock function with given fields: _a0, _a1, _a2, _a3
 func (_m *Handlers) PutUserRole(_a0 *http.Request, _a1 httprouter.Params, _a2 *models.Session, _a3 data.DataCRUD) (int, interface{}) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
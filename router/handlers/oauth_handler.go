@@ -0,0 +1,300 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
+	"github.com/mhogar/amber/controllers"
+	"github.com/mhogar/amber/data"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/router/renderer"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+)
+
+// authorizeParamNames are the authorization request params carried through the login view.
+var authorizeParamNames = []string{
+	"response_type", "redirect_uri", "scope", "state", "nonce", "code_challenge", "code_challenge_method",
+}
+
+func (h CoreHandlers) GetAuthorize(req *http.Request, _ httprouter.Params, _ *models.Session, _ data.DataCRUD) (int, interface{}) {
+	return h.renderAuthorizeView(req, req.URL.Query(), "", "")
+}
+
+func (h CoreHandlers) PostAuthorize(req *http.Request, _ httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//get the form values
+	err := req.ParseForm()
+	if err != nil {
+		log.Println(common.ChainError("error parsing PostAuthorize form", err))
+	}
+	values := req.PostForm
+
+	//only the authorization code flow is supported
+	if values.Get("response_type") != "code" {
+		return h.renderAuthorizeView(req, values, "", "response_type must be code")
+	}
+
+	//parse the client id
+	clientID, err := uuid.Parse(values.Get("client_id"))
+	if err != nil {
+		log.Println(common.ChainError("error parsing client id", err))
+		return h.renderAuthorizeView(req, values, "", "client_id is not provided or in an invalid format")
+	}
+
+	authReq := controllers.AuthorizationRequest{
+		ClientUID:           clientID,
+		RedirectURI:         values.Get("redirect_uri"),
+		Scope:               values.Get("scope"),
+		State:               values.Get("state"),
+		Nonce:               values.Get("nonce"),
+		CodeChallenge:       values.Get("code_challenge"),
+		CodeChallengeMethod: values.Get("code_challenge_method"),
+	}
+
+	//verify the new password was confirmed
+	creds := parseUserCredentialsForm(req)
+	if creds.NewPassword != values.Get("confirm_password") {
+		return h.renderAuthorizeView(req, values, creds.Challenge, "passwords do not match")
+	}
+
+	//create the code redirect url
+	redirectUrl, challenge, cerr := h.Controllers.CreateAuthorizationCodeRedirectURL(CRUD, authReq, creds)
+	if cerr.Type != common.ErrorTypeNone {
+		return h.renderAuthorizeView(req, values, "", cerr.Error())
+	}
+
+	//ask for the user's two-factor code or new password
+	if challenge != "" {
+		return h.renderAuthorizeView(req, values, challenge, "")
+	}
+
+	//send redirect response
+	return http.StatusSeeOther, redirectUrl
+}
+
+func (h CoreHandlers) renderAuthorizeView(req *http.Request, values url.Values, challenge string, errMessage string) (int, interface{}) {
+	//fill in the data struct
+	data := TokenViewData{
+		Action:    "/authorize",
+		ClientID:  values.Get("client_id"),
+		Params:    map[string]string{},
+		Challenge: challenge,
+		Step:      controllers.GetChallengeStep(challenge),
+		Error:     errMessage,
+	}
+	for _, name := range authorizeParamNames {
+		data.Params[name] = values.Get(name)
+	}
+
+	//render the view
+	return http.StatusOK, h.Renderer.RenderView(req, data, "token/index")
+}
+
+// OAuthTokenResponse represents a successful response from the oauth token endpoint.
+type OAuthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	IDToken      string `json:"id_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// OAuthErrorResponse represents an error response from the oauth token endpoint.
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+func (h CoreHandlers) PostOAuthToken(req *http.Request, _ httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	switch req.PostFormValue("grant_type") {
+	case "authorization_code":
+		return h.exchangeAuthorizationCode(req, CRUD)
+	case "refresh_token":
+		return h.redeemRefreshToken(req, CRUD)
+	case "client_credentials":
+		return h.createClientCredentialsToken(req, CRUD)
+	}
+
+	return newOAuthErrorResponse(http.StatusBadRequest, "unsupported_grant_type", "")
+}
+
+func (h CoreHandlers) exchangeAuthorizationCode(req *http.Request, CRUD data.DataCRUD) (int, interface{}) {
+	id, secret := getClientCredentials(req)
+
+	//parse the client id
+	clientID, err := uuid.Parse(id)
+	if err != nil {
+		log.Println(common.ChainError("error parsing client id", err))
+		return newOAuthErrorResponse(http.StatusBadRequest, "invalid_request", "client_id is not provided or in an invalid format")
+	}
+
+	//parse the code
+	code, err := uuid.Parse(req.PostFormValue("code"))
+	if err != nil {
+		log.Println(common.ChainError("error parsing authorization code", err))
+		return newOAuthErrorResponse(http.StatusBadRequest, "invalid_request", "code is not provided or in an invalid format")
+	}
+
+	//clients with a secret must authenticate with it
+	cerr := h.Controllers.AuthenticateClient(CRUD, clientID, secret)
+	if cerr.Type == common.ErrorTypeClient {
+		return newOAuthErrorResponse(http.StatusUnauthorized, "invalid_client", cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return newOAuthErrorResponse(http.StatusInternalServerError, "server_error", "")
+	}
+
+	//exchange the code for the tokens
+	tokens, cerr := h.Controllers.ExchangeAuthorizationCode(CRUD, clientID, code, req.PostFormValue("redirect_uri"), req.PostFormValue("code_verifier"))
+	if cerr.Type == common.ErrorTypeClient {
+		return newOAuthErrorResponse(http.StatusBadRequest, "invalid_grant", cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return newOAuthErrorResponse(http.StatusInternalServerError, "server_error", "")
+	}
+
+	return newOAuthTokenResponse(tokens)
+}
+
+func (h CoreHandlers) redeemRefreshToken(req *http.Request, CRUD data.DataCRUD) (int, interface{}) {
+	id, secret := getClientCredentials(req)
+
+	//parse the client id
+	clientID, err := uuid.Parse(id)
+	if err != nil {
+		log.Println(common.ChainError("error parsing client id", err))
+		return newOAuthErrorResponse(http.StatusBadRequest, "invalid_request", "client_id is not provided or in an invalid format")
+	}
+
+	//parse the refresh token
+	refreshToken, err := uuid.Parse(req.PostFormValue("refresh_token"))
+	if err != nil {
+		log.Println(common.ChainError("error parsing refresh token", err))
+		return newOAuthErrorResponse(http.StatusBadRequest, "invalid_request", "refresh_token is not provided or in an invalid format")
+	}
+
+	//clients with a secret must authenticate with it
+	cerr := h.Controllers.AuthenticateClient(CRUD, clientID, secret)
+	if cerr.Type == common.ErrorTypeClient {
+		return newOAuthErrorResponse(http.StatusUnauthorized, "invalid_client", cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return newOAuthErrorResponse(http.StatusInternalServerError, "server_error", "")
+	}
+
+	//redeem the refresh token for new tokens
+	tokens, cerr := h.Controllers.RedeemRefreshToken(CRUD, clientID, refreshToken)
+	if cerr.Type == common.ErrorTypeClient {
+		//a reused refresh token revokes its family, which needs to be committed even though the request fails
+		return common.NewCommittedResponse(newOAuthErrorResponse(http.StatusBadRequest, "invalid_grant", cerr.Error()))
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return newOAuthErrorResponse(http.StatusInternalServerError, "server_error", "")
+	}
+
+	return newOAuthTokenResponse(tokens)
+}
+
+func (h CoreHandlers) createClientCredentialsToken(req *http.Request, CRUD data.DataCRUD) (int, interface{}) {
+	id, secret := getClientCredentials(req)
+
+	//parse the client id
+	clientID, err := uuid.Parse(id)
+	if err != nil {
+		log.Println(common.ChainError("error parsing client id", err))
+		return newOAuthErrorResponse(http.StatusBadRequest, "invalid_request", "client_id is not provided or in an invalid format")
+	}
+
+	//create the token
+	token, cerr := h.Controllers.CreateClientCredentialsToken(CRUD, clientID, secret)
+	if cerr.Type == common.ErrorTypeInternal {
+		return newOAuthErrorResponse(http.StatusInternalServerError, "server_error", "")
+	}
+
+	//audit the completed or failed login
+	action := models.AuditActionClientLogin
+	if cerr.Type == common.ErrorTypeClient {
+		action = models.AuditActionClientLoginFailed
+	}
+
+	aerr := h.Controllers.CreateAuditEvent(CRUD, models.CreateNewAuditEvent(clientID.String(), action, clientID.String(), getClientIP(req)))
+	if aerr.Type != common.ErrorTypeNone {
+		return newOAuthErrorResponse(http.StatusInternalServerError, "server_error", "")
+	}
+
+	if cerr.Type == common.ErrorTypeClient {
+		//commit the audit event of the failed login
+		return common.NewCommittedResponse(newOAuthErrorResponse(http.StatusUnauthorized, "invalid_client", cerr.Error()))
+	}
+
+	return http.StatusOK, OAuthTokenResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   config.GetTokenConfig().Lifetime,
+	}
+}
+
+// getClientCredentials gets the client id and secret, which can be sent with basic auth or in the form body.
+func getClientCredentials(req *http.Request) (string, string) {
+	id, secret, ok := req.BasicAuth()
+	if !ok {
+		id = req.PostFormValue("client_id")
+		secret = req.PostFormValue("client_secret")
+	}
+
+	return id, secret
+}
+
+func newOAuthTokenResponse(tokens *controllers.OAuthTokens) (int, OAuthTokenResponse) {
+	return http.StatusOK, OAuthTokenResponse{
+		AccessToken:  tokens.AccessToken,
+		IDToken:      tokens.IDToken,
+		RefreshToken: tokens.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    config.GetTokenConfig().Lifetime,
+	}
+}
+
+func newOAuthErrorResponse(status int, err string, description string) (int, OAuthErrorResponse) {
+	return status, OAuthErrorResponse{
+		Error:            err,
+		ErrorDescription: description,
+	}
+}
+
+// OpenIDConfiguration represents the OpenID Connect discovery document.
+type OpenIDConfiguration struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+}
+
+func (h CoreHandlers) GetOpenIDConfiguration(req *http.Request, _ httprouter.Params, _ *models.Session, _ data.DataCRUD) (int, interface{}) {
+	baseURL := renderer.GetBaseURL(req)
+
+	return http.StatusOK, OpenIDConfiguration{
+		Issuer:                            config.GetTokenConfig().DefaultIssuer,
+		AuthorizationEndpoint:             baseURL + "/authorize",
+		TokenEndpoint:                     baseURL + "/oauth/token",
+		JWKSURI:                           baseURL + "/.well-known/jwks.json",
+		ScopesSupported:                   []string{"openid"},
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{"authorization_code", "refresh_token", "client_credentials"},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{"RS256"},
+		TokenEndpointAuthMethodsSupported: []string{"none", "client_secret_basic", "client_secret_post"},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+	}
+}
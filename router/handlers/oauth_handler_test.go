@@ -0,0 +1,725 @@
+package handlers_test
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
+	"github.com/mhogar/amber/controllers"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/router/handlers"
+
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type OAuthHandlerTestSuite struct {
+	HandlersTestSuite
+	Values url.Values
+}
+
+func (suite *OAuthHandlerTestSuite) SetupTest() {
+	suite.HandlersTestSuite.SetupTest()
+
+	viper.Set("token", config.TokenConfig{
+		DefaultIssuer: "issuer",
+		Lifetime:      60,
+	})
+
+	suite.Values = url.Values{
+		"response_type":         []string{"code"},
+		"client_id":             []string{uuid.New().String()},
+		"redirect_uri":          []string{"https://redirect.com"},
+		"scope":                 []string{"openid"},
+		"state":                 []string{"state"},
+		"nonce":                 []string{"nonce"},
+		"code_challenge":        []string{"challenge"},
+		"code_challenge_method": []string{"S256"},
+		"username":              []string{"username"},
+		"password":              []string{"password"},
+	}
+}
+
+func (suite *OAuthHandlerTestSuite) AuthorizeViewRenderedWithData(challenge string, errSubStrings ...string) {
+	data := suite.RenderViewData.(handlers.TokenViewData)
+	suite.Equal("/authorize", data.Action)
+	suite.Equal(suite.Values.Get("client_id"), data.ClientID)
+	suite.Equal(suite.Values.Get("redirect_uri"), data.Params["redirect_uri"])
+	suite.Equal(suite.Values.Get("state"), data.Params["state"])
+	suite.Equal(suite.Values.Get("code_challenge"), data.Params["code_challenge"])
+	suite.NotContains(data.Params, "password")
+	suite.Equal(challenge, data.Challenge)
+	suite.Equal(controllers.GetChallengeStep(challenge), data.Step)
+	suite.ContainsSubstrings(data.Error, errSubStrings...)
+
+	suite.RendererMock.AssertCalled(suite.T(), "RenderView", mock.Anything, data, "token/index")
+}
+
+func (suite *OAuthHandlerTestSuite) TestGetAuthorize_RendersAuthorizeView() {
+	//arrange
+	req := suite.CreateRequest("", "/authorize?"+suite.Values.Encode(), "", nil)
+
+	//act
+	status, res := suite.CoreHandlers.GetAuthorize(req, nil, nil, nil)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.AssertRenderViewResult(res)
+	suite.AuthorizeViewRenderedWithData("")
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostAuthorize_WithUnsupportedResponseType_RendersAuthorizeViewWithError() {
+	//arrange
+	suite.Values.Set("response_type", "token")
+	req := suite.CreateDummyFormRequest(suite.Values)
+
+	//act
+	status, res := suite.CoreHandlers.PostAuthorize(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.AssertRenderViewResult(res)
+	suite.AuthorizeViewRenderedWithData("", "response_type", "code")
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostAuthorize_WithErrorParsingClientId_RendersAuthorizeViewWithError() {
+	//arrange
+	suite.Values.Set("client_id", "invalid")
+	req := suite.CreateDummyFormRequest(suite.Values)
+
+	//act
+	status, res := suite.CoreHandlers.PostAuthorize(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.AssertRenderViewResult(res)
+	suite.AuthorizeViewRenderedWithData("", "client_id", "not provided", "invalid format")
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostAuthorize_WithClientErrorCreatingAuthorizationCodeRedirectURL_RendersAuthorizeViewWithError() {
+	//arrange
+	req := suite.CreateDummyFormRequest(suite.Values)
+
+	message := "create authorization code error"
+	suite.ControllersMock.On("CreateAuthorizationCodeRedirectURL", mock.Anything, mock.Anything, mock.Anything).Return("", "", common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostAuthorize(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.AssertRenderViewResult(res)
+	suite.AuthorizeViewRenderedWithData("", message)
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostAuthorize_WithInternalErrorCreatingAuthorizationCodeRedirectURL_RendersAuthorizeViewWithError() {
+	//arrange
+	req := suite.CreateDummyFormRequest(suite.Values)
+
+	suite.ControllersMock.On("CreateAuthorizationCodeRedirectURL", mock.Anything, mock.Anything, mock.Anything).Return("", "", common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostAuthorize(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.AssertRenderViewResult(res)
+	suite.AuthorizeViewRenderedWithData("", "internal error")
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostAuthorize_WithNoErrors_ReturnsRedirect() {
+	//arrange
+	req := suite.CreateDummyFormRequest(suite.Values)
+
+	redirectUrl := "redirect.com"
+	suite.ControllersMock.On("CreateAuthorizationCodeRedirectURL", mock.Anything, mock.Anything, mock.Anything).Return(redirectUrl, "", common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostAuthorize(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusSeeOther, status)
+	suite.Equal(redirectUrl, res)
+
+	authReq := controllers.AuthorizationRequest{
+		ClientUID:           uuid.MustParse(suite.Values.Get("client_id")),
+		RedirectURI:         suite.Values.Get("redirect_uri"),
+		Scope:               suite.Values.Get("scope"),
+		State:               suite.Values.Get("state"),
+		Nonce:               suite.Values.Get("nonce"),
+		CodeChallenge:       suite.Values.Get("code_challenge"),
+		CodeChallengeMethod: suite.Values.Get("code_challenge_method"),
+	}
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateAuthorizationCodeRedirectURL", &suite.CRUDMock, authReq, controllers.UserCredentials{
+		Username: "username",
+		Password: "password",
+	})
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostAuthorize_WhereTwoFactorChallengeIsReturned_RendersAuthorizeViewWithChallenge() {
+	//arrange
+	req := suite.CreateDummyFormRequest(suite.Values)
+
+	challenge := controllers.ChallengeStepTwoFactor + ".challenge"
+	suite.ControllersMock.On("CreateAuthorizationCodeRedirectURL", mock.Anything, mock.Anything, mock.Anything).Return("", challenge, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostAuthorize(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.AssertRenderViewResult(res)
+	suite.AuthorizeViewRenderedWithData(challenge)
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostAuthorize_WhereNewPasswordIsNotConfirmed_RendersAuthorizeViewWithChallengeAndError() {
+	//arrange
+	challenge := controllers.ChallengeStepChangePassword + ".challenge"
+	suite.Values.Set("challenge", challenge)
+	suite.Values.Set("new_password", "new password")
+	suite.Values.Set("confirm_password", "other password")
+	req := suite.CreateDummyFormRequest(suite.Values)
+
+	//act
+	status, res := suite.CoreHandlers.PostAuthorize(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.AssertRenderViewResult(res)
+	suite.AuthorizeViewRenderedWithData(challenge, "passwords do not match")
+
+	suite.ControllersMock.AssertNotCalled(suite.T(), "CreateAuthorizationCodeRedirectURL", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostOAuthToken_WithInvalidRequest_ReturnsBadRequest() {
+	var values url.Values
+
+	testCase := func(expectedError string) {
+		//arrange
+		req := suite.CreateDummyFormRequest(values)
+
+		//act
+		status, res := suite.CoreHandlers.PostOAuthToken(req, nil, nil, &suite.CRUDMock)
+
+		//assert
+		suite.Require().Equal(http.StatusBadRequest, status)
+		suite.Equal(expectedError, res.(handlers.OAuthErrorResponse).Error)
+	}
+
+	values = url.Values{
+		"grant_type": []string{"password"},
+	}
+	suite.Run("UnsupportedGrantType", func() { testCase("unsupported_grant_type") })
+
+	values = url.Values{
+		"grant_type": []string{"authorization_code"},
+		"client_id":  []string{"invalid"},
+		"code":       []string{uuid.New().String()},
+	}
+	suite.Run("InvalidClientID", func() { testCase("invalid_request") })
+
+	values = url.Values{
+		"grant_type": []string{"authorization_code"},
+		"client_id":  []string{uuid.New().String()},
+		"code":       []string{"invalid"},
+	}
+	suite.Run("InvalidCode", func() { testCase("invalid_request") })
+}
+
+func (suite *OAuthHandlerTestSuite) createTokenRequest() *http.Request {
+	return suite.CreateDummyFormRequest(url.Values{
+		"grant_type":    []string{"authorization_code"},
+		"client_id":     []string{uuid.New().String()},
+		"code":          []string{uuid.New().String()},
+		"redirect_uri":  []string{"https://redirect.com"},
+		"code_verifier": []string{"verifier"},
+	})
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostOAuthToken_WithClientErrorExchangingAuthorizationCode_ReturnsInvalidGrant() {
+	//arrange
+	req := suite.createTokenRequest()
+
+	message := "exchange error"
+	suite.ControllersMock.On("AuthenticateClient", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
+	suite.ControllersMock.On("ExchangeAuthorizationCode", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostOAuthToken(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.Equal(handlers.OAuthErrorResponse{Error: "invalid_grant", ErrorDescription: message}, res)
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostOAuthToken_WithInternalErrorExchangingAuthorizationCode_ReturnsServerError() {
+	//arrange
+	req := suite.createTokenRequest()
+
+	suite.ControllersMock.On("AuthenticateClient", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
+	suite.ControllersMock.On("ExchangeAuthorizationCode", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostOAuthToken(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.Equal(handlers.OAuthErrorResponse{Error: "server_error"}, res)
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostOAuthToken_WithClientErrorAuthenticatingClient_ReturnsInvalidClient() {
+	//arrange
+	req := suite.createTokenRequest()
+
+	message := "authenticate client error"
+	suite.ControllersMock.On("AuthenticateClient", mock.Anything, mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostOAuthToken(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusUnauthorized, status)
+	suite.Equal(handlers.OAuthErrorResponse{Error: "invalid_client", ErrorDescription: message}, res)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "ExchangeAuthorizationCode", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostOAuthToken_WithInternalErrorAuthenticatingClient_ReturnsServerError() {
+	//arrange
+	req := suite.createTokenRequest()
+
+	suite.ControllersMock.On("AuthenticateClient", mock.Anything, mock.Anything, mock.Anything).Return(common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostOAuthToken(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.Equal(handlers.OAuthErrorResponse{Error: "server_error"}, res)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "ExchangeAuthorizationCode", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostOAuthToken_WithNoErrors_ReturnsTokens() {
+	var req *http.Request
+	var secret string
+	clientID := uuid.New()
+	code := uuid.New()
+
+	values := url.Values{
+		"grant_type":    []string{"authorization_code"},
+		"code":          []string{code.String()},
+		"redirect_uri":  []string{"https://redirect.com"},
+		"code_verifier": []string{"verifier"},
+	}
+
+	testCase := func() {
+		//arrange
+		suite.SetupTest()
+
+		tokens := &controllers.OAuthTokens{
+			AccessToken:  "access token",
+			IDToken:      "id token",
+			RefreshToken: "refresh token",
+		}
+		suite.ControllersMock.On("AuthenticateClient", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
+		suite.ControllersMock.On("ExchangeAuthorizationCode", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tokens, common.NoError())
+
+		//act
+		status, res := suite.CoreHandlers.PostOAuthToken(req, nil, nil, &suite.CRUDMock)
+
+		//assert
+		suite.Require().Equal(http.StatusOK, status)
+		suite.Equal(handlers.OAuthTokenResponse{
+			AccessToken:  tokens.AccessToken,
+			IDToken:      tokens.IDToken,
+			RefreshToken: tokens.RefreshToken,
+			TokenType:    "Bearer",
+			ExpiresIn:    60,
+		}, res)
+		suite.ControllersMock.AssertCalled(suite.T(), "AuthenticateClient", &suite.CRUDMock, clientID, secret)
+		suite.ControllersMock.AssertCalled(suite.T(), "ExchangeAuthorizationCode", &suite.CRUDMock, clientID, code, "https://redirect.com", "verifier")
+	}
+
+	secret = ""
+	values.Set("client_id", clientID.String())
+	req = suite.CreateDummyFormRequest(values)
+	suite.Run("PublicClient", testCase)
+
+	secret = "secret"
+	values.Set("client_secret", secret)
+	req = suite.CreateDummyFormRequest(values)
+	suite.Run("CredentialsInFormBody", testCase)
+
+	values.Del("client_id")
+	values.Del("client_secret")
+	req = suite.CreateDummyFormRequest(values)
+	req.SetBasicAuth(clientID.String(), secret)
+	suite.Run("CredentialsInBasicAuth", testCase)
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostOAuthToken_RefreshTokenWithInvalidRequest_ReturnsInvalidRequest() {
+	var values url.Values
+
+	testCase := func() {
+		//arrange
+		req := suite.CreateDummyFormRequest(values)
+
+		//act
+		status, res := suite.CoreHandlers.PostOAuthToken(req, nil, nil, &suite.CRUDMock)
+
+		//assert
+		suite.Require().Equal(http.StatusBadRequest, status)
+		suite.Equal("invalid_request", res.(handlers.OAuthErrorResponse).Error)
+	}
+
+	values = url.Values{
+		"grant_type":    []string{"refresh_token"},
+		"client_id":     []string{"invalid"},
+		"refresh_token": []string{uuid.New().String()},
+	}
+	suite.Run("InvalidClientID", testCase)
+
+	values = url.Values{
+		"grant_type":    []string{"refresh_token"},
+		"client_id":     []string{uuid.New().String()},
+		"refresh_token": []string{"invalid"},
+	}
+	suite.Run("InvalidRefreshToken", testCase)
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostOAuthToken_RefreshTokenWithClientErrorAuthenticatingClient_ReturnsInvalidClient() {
+	//arrange
+	req := suite.CreateDummyFormRequest(url.Values{
+		"grant_type":    []string{"refresh_token"},
+		"client_id":     []string{uuid.New().String()},
+		"refresh_token": []string{uuid.New().String()},
+	})
+
+	message := "authenticate client error"
+	suite.ControllersMock.On("AuthenticateClient", mock.Anything, mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostOAuthToken(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusUnauthorized, status)
+	suite.Equal(handlers.OAuthErrorResponse{Error: "invalid_client", ErrorDescription: message}, res)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "RedeemRefreshToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostOAuthToken_RefreshTokenWithInternalErrorAuthenticatingClient_ReturnsServerError() {
+	//arrange
+	req := suite.CreateDummyFormRequest(url.Values{
+		"grant_type":    []string{"refresh_token"},
+		"client_id":     []string{uuid.New().String()},
+		"refresh_token": []string{uuid.New().String()},
+	})
+
+	suite.ControllersMock.On("AuthenticateClient", mock.Anything, mock.Anything, mock.Anything).Return(common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostOAuthToken(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.Equal(handlers.OAuthErrorResponse{Error: "server_error"}, res)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "RedeemRefreshToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostOAuthToken_RefreshTokenWithClientErrorRedeemingRefreshToken_ReturnsCommittedInvalidGrant() {
+	//arrange
+	req := suite.CreateDummyFormRequest(url.Values{
+		"grant_type":    []string{"refresh_token"},
+		"client_id":     []string{uuid.New().String()},
+		"refresh_token": []string{uuid.New().String()},
+	})
+
+	message := "redeem refresh token error"
+	suite.ControllersMock.On("AuthenticateClient", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
+	suite.ControllersMock.On("RedeemRefreshToken", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostOAuthToken(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.Equal(common.CommittedResponse{
+		Data: handlers.OAuthErrorResponse{Error: "invalid_grant", ErrorDescription: message},
+	}, res)
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostOAuthToken_RefreshTokenWithInternalErrorRedeemingRefreshToken_ReturnsServerError() {
+	//arrange
+	req := suite.CreateDummyFormRequest(url.Values{
+		"grant_type":    []string{"refresh_token"},
+		"client_id":     []string{uuid.New().String()},
+		"refresh_token": []string{uuid.New().String()},
+	})
+
+	suite.ControllersMock.On("AuthenticateClient", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
+	suite.ControllersMock.On("RedeemRefreshToken", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostOAuthToken(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.Equal(handlers.OAuthErrorResponse{Error: "server_error"}, res)
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostOAuthToken_RefreshTokenWithNoErrors_ReturnsTokens() {
+	var req *http.Request
+	var secret string
+	clientID := uuid.New()
+	refreshToken := uuid.New()
+
+	values := url.Values{
+		"grant_type":    []string{"refresh_token"},
+		"refresh_token": []string{refreshToken.String()},
+	}
+
+	testCase := func() {
+		//arrange
+		suite.SetupTest()
+
+		tokens := &controllers.OAuthTokens{
+			AccessToken:  "access token",
+			RefreshToken: "new refresh token",
+		}
+		suite.ControllersMock.On("AuthenticateClient", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
+		suite.ControllersMock.On("RedeemRefreshToken", mock.Anything, mock.Anything, mock.Anything).Return(tokens, common.NoError())
+
+		//act
+		status, res := suite.CoreHandlers.PostOAuthToken(req, nil, nil, &suite.CRUDMock)
+
+		//assert
+		suite.Require().Equal(http.StatusOK, status)
+		suite.Equal(handlers.OAuthTokenResponse{
+			AccessToken:  tokens.AccessToken,
+			RefreshToken: tokens.RefreshToken,
+			TokenType:    "Bearer",
+			ExpiresIn:    60,
+		}, res)
+		suite.ControllersMock.AssertCalled(suite.T(), "AuthenticateClient", &suite.CRUDMock, clientID, secret)
+		suite.ControllersMock.AssertCalled(suite.T(), "RedeemRefreshToken", &suite.CRUDMock, clientID, refreshToken)
+	}
+
+	secret = ""
+	values.Set("client_id", clientID.String())
+	req = suite.CreateDummyFormRequest(values)
+	suite.Run("PublicClient", testCase)
+
+	secret = "secret"
+	values.Set("client_secret", secret)
+	req = suite.CreateDummyFormRequest(values)
+	suite.Run("CredentialsInFormBody", testCase)
+
+	values.Del("client_id")
+	values.Del("client_secret")
+	req = suite.CreateDummyFormRequest(values)
+	req.SetBasicAuth(clientID.String(), secret)
+	suite.Run("CredentialsInBasicAuth", testCase)
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostOAuthToken_ClientCredentialsWithErrorParsingClientId_ReturnsInvalidRequest() {
+	//arrange
+	req := suite.CreateDummyFormRequest(url.Values{
+		"grant_type":    []string{"client_credentials"},
+		"client_id":     []string{"invalid"},
+		"client_secret": []string{"secret"},
+	})
+
+	//act
+	status, res := suite.CoreHandlers.PostOAuthToken(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.Equal("invalid_request", res.(handlers.OAuthErrorResponse).Error)
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostOAuthToken_ClientCredentialsWithClientErrorCreatingToken_ReturnsCommittedInvalidClient() {
+	//arrange
+	clientID := uuid.New().String()
+	req := suite.CreateDummyFormRequest(url.Values{
+		"grant_type":    []string{"client_credentials"},
+		"client_id":     []string{clientID},
+		"client_secret": []string{"secret"},
+	})
+
+	message := "create client token error"
+	suite.ControllersMock.On("CreateClientCredentialsToken", mock.Anything, mock.Anything, mock.Anything).Return("", common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostOAuthToken(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusUnauthorized, status)
+	suite.Equal(common.CommittedResponse{
+		Data: handlers.OAuthErrorResponse{Error: "invalid_client", ErrorDescription: message},
+	}, res)
+
+	suite.AssertAuditEventCreated(clientID, models.AuditActionClientLoginFailed, clientID)
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostOAuthToken_ClientCredentialsWithErrorCreatingAuditEvent_ReturnsServerError() {
+	//arrange
+	suite.FailAuditEvents()
+
+	req := suite.CreateDummyFormRequest(url.Values{
+		"grant_type":    []string{"client_credentials"},
+		"client_id":     []string{uuid.New().String()},
+		"client_secret": []string{"secret"},
+	})
+
+	suite.ControllersMock.On("CreateClientCredentialsToken", mock.Anything, mock.Anything, mock.Anything).Return("token", common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostOAuthToken(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.Equal(handlers.OAuthErrorResponse{Error: "server_error"}, res)
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostOAuthToken_ClientCredentialsWithInternalErrorCreatingToken_ReturnsServerError() {
+	//arrange
+	req := suite.CreateDummyFormRequest(url.Values{
+		"grant_type":    []string{"client_credentials"},
+		"client_id":     []string{uuid.New().String()},
+		"client_secret": []string{"secret"},
+	})
+
+	suite.ControllersMock.On("CreateClientCredentialsToken", mock.Anything, mock.Anything, mock.Anything).Return("", common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostOAuthToken(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.Equal(handlers.OAuthErrorResponse{Error: "server_error"}, res)
+}
+
+func (suite *OAuthHandlerTestSuite) TestPostOAuthToken_ClientCredentialsWithNoErrors_ReturnsToken() {
+	var req *http.Request
+	clientID := uuid.New()
+	secret := "secret"
+
+	testCase := func() {
+		//arrange
+		suite.SetupTest()
+
+		token := "token"
+		suite.ControllersMock.On("CreateClientCredentialsToken", mock.Anything, mock.Anything, mock.Anything).Return(token, common.NoError())
+
+		//act
+		status, res := suite.CoreHandlers.PostOAuthToken(req, nil, nil, &suite.CRUDMock)
+
+		//assert
+		suite.Require().Equal(http.StatusOK, status)
+		suite.Equal(handlers.OAuthTokenResponse{
+			AccessToken: token,
+			TokenType:   "Bearer",
+			ExpiresIn:   60,
+		}, res)
+		suite.ControllersMock.AssertCalled(suite.T(), "CreateClientCredentialsToken", &suite.CRUDMock, clientID, secret)
+		suite.AssertAuditEventCreated(clientID.String(), models.AuditActionClientLogin, clientID.String())
+	}
+
+	req = suite.CreateDummyFormRequest(url.Values{
+		"grant_type":    []string{"client_credentials"},
+		"client_id":     []string{clientID.String()},
+		"client_secret": []string{secret},
+	})
+	suite.Run("CredentialsInFormBody", testCase)
+
+	req = suite.CreateDummyFormRequest(url.Values{
+		"grant_type": []string{"client_credentials"},
+	})
+	req.SetBasicAuth(clientID.String(), secret)
+	suite.Run("CredentialsInBasicAuth", testCase)
+}
+
+func (suite *OAuthHandlerTestSuite) TestGetOpenIDConfiguration_ReturnsDiscoveryDocument() {
+	//arrange
+	req := suite.CreateRequest("", "http://localhost:8080/.well-known/openid-configuration", "", nil)
+
+	//act
+	status, res := suite.CoreHandlers.GetOpenIDConfiguration(req, nil, nil, nil)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+
+	cfg := res.(handlers.OpenIDConfiguration)
+	suite.Equal("issuer", cfg.Issuer)
+	suite.Equal("http://localhost:8080/authorize", cfg.AuthorizationEndpoint)
+	suite.Equal("http://localhost:8080/oauth/token", cfg.TokenEndpoint)
+	suite.Equal("http://localhost:8080/.well-known/jwks.json", cfg.JWKSURI)
+	suite.Equal([]string{"S256"}, cfg.CodeChallengeMethodsSupported)
+}
+
+func (suite *OAuthHandlerTestSuite) TestGetOpenIDConfiguration_BaseURLTestCases() {
+	var trustedProxies int
+	var req *http.Request
+	var expectedBaseURL string
+
+	testCase := func() {
+		//arrange
+		viper.Set("server", config.ServerConfig{
+			TrustedProxies: trustedProxies,
+		})
+
+		//act
+		status, res := suite.CoreHandlers.GetOpenIDConfiguration(req, nil, nil, nil)
+
+		//assert
+		suite.Require().Equal(http.StatusOK, status)
+		suite.Equal(expectedBaseURL+"/authorize", res.(handlers.OpenIDConfiguration).AuthorizationEndpoint)
+	}
+
+	trustedProxies = 0
+	req = suite.CreateRequest("", "https://localhost:8443/.well-known/openid-configuration", "", nil)
+	req.TLS = &tls.ConnectionState{}
+	expectedBaseURL = "https://localhost:8443"
+	suite.Run("TLSRequestUsesHTTPS", testCase)
+
+	trustedProxies = 0
+	req = suite.CreateRequest("", "http://localhost:8080/.well-known/openid-configuration", "", nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+	req.Header.Set("X-Forwarded-Host", "amber.example.com")
+	expectedBaseURL = "http://localhost:8080"
+	suite.Run("UntrustedForwardedHeadersAreIgnored", testCase)
+
+	trustedProxies = 1
+	req = suite.CreateRequest("", "http://localhost:8080/.well-known/openid-configuration", "", nil)
+	req.Header.Set("X-Forwarded-Proto", "HTTPS")
+	req.Header.Set("X-Forwarded-Host", "amber.example.com")
+	expectedBaseURL = "https://amber.example.com"
+	suite.Run("TrustedForwardedHeadersAreUsed", testCase)
+
+	trustedProxies = 1
+	req = suite.CreateRequest("", "http://localhost:8080/.well-known/openid-configuration", "", nil)
+	req.Header.Set("X-Forwarded-Proto", "http, https")
+	req.Header.Set("X-Forwarded-Host", "evil.example.com, amber.example.com")
+	expectedBaseURL = "https://amber.example.com"
+	suite.Run("SpoofedValuesBeforeTrustedProxyAreIgnored", testCase)
+
+	trustedProxies = 2
+	req = suite.CreateRequest("", "http://localhost:8080/.well-known/openid-configuration", "", nil)
+	req.Header.Set("X-Forwarded-Proto", "https, http")
+	req.Header.Set("X-Forwarded-Host", "amber.example.com, proxy.local")
+	expectedBaseURL = "https://amber.example.com"
+	suite.Run("ValuesAddedByOutermostOfSeveralProxiesAreUsed", testCase)
+
+	trustedProxies = 1
+	req = suite.CreateRequest("", "http://localhost:8080/.well-known/openid-configuration", "", nil)
+	expectedBaseURL = "http://localhost:8080"
+	suite.Run("MissingForwardedHeadersUseRequest", testCase)
+}
+
+func TestOAuthHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &OAuthHandlerTestSuite{})
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	"github.com/mhogar/amber/models"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// PasswordResetViewData is the data for the forgot password and reset password views.
+// Token is the password reset token from the emailed link. If Message is set, the view shows it instead of the form.
+type PasswordResetViewData struct {
+	Token   string
+	Message string
+	Error   string
+}
+
+func (h CoreHandlers) GetForgotPassword(req *http.Request, _ httprouter.Params, _ *models.Session, _ data.DataCRUD) (int, interface{}) {
+	return h.renderPasswordResetView(req, "password/forgot", PasswordResetViewData{}, common.NoError())
+}
+
+func (h CoreHandlers) PostForgotPassword(req *http.Request, _ httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//send the reset link
+	cerr := h.Controllers.RequestPasswordReset(CRUD, req.PostFormValue("email"))
+	if cerr.Type != common.ErrorTypeNone {
+		return h.renderPasswordResetView(req, "password/forgot", PasswordResetViewData{}, cerr)
+	}
+
+	return h.renderPasswordResetView(req, "password/forgot", PasswordResetViewData{
+		Message: "If an account with that email exists, a link to reset its password has been sent to it.",
+	}, common.NoError())
+}
+
+func (h CoreHandlers) GetResetPassword(req *http.Request, _ httprouter.Params, _ *models.Session, _ data.DataCRUD) (int, interface{}) {
+	return h.renderPasswordResetView(req, "password/reset", PasswordResetViewData{
+		Token: req.URL.Query().Get("token"),
+	}, common.NoError())
+}
+
+func (h CoreHandlers) PostResetPassword(req *http.Request, _ httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	data := PasswordResetViewData{
+		Token: req.PostFormValue("token"),
+	}
+
+	//verify the password was entered the same both times
+	password := req.PostFormValue("password")
+	if password != req.PostFormValue("confirm_password") {
+		return h.renderPasswordResetView(req, "password/reset", data, common.ClientError("passwords do not match"))
+	}
+
+	//reset the password
+	username, cerr := h.Controllers.ResetPassword(CRUD, data.Token, password)
+	if cerr.Type != common.ErrorTypeNone {
+		return h.renderPasswordResetView(req, "password/reset", data, cerr)
+	}
+
+	//audit the reset as the user it was for
+	event := models.CreateNewAuditEvent(username, models.AuditActionResetPassword, username, getClientIP(req))
+	cerr = h.Controllers.CreateAuditEvent(CRUD, event)
+	if cerr.Type != common.ErrorTypeNone {
+		return h.renderPasswordResetView(req, "password/reset", data, cerr)
+	}
+
+	return h.renderPasswordResetView(req, "password/reset", PasswordResetViewData{
+		Message: "Your password has been reset. You can now sign in with your new password.",
+	}, common.NoError())
+}
+
+// renderPasswordResetView renders the view with the data and the error's message.
+// Internal errors are sent with an internal server error status so any changes made by the request are rolled back.
+func (h CoreHandlers) renderPasswordResetView(req *http.Request, view string, data PasswordResetViewData, cerr common.CustomError) (int, interface{}) {
+	status := http.StatusOK
+	if cerr.Type == common.ErrorTypeInternal {
+		status = http.StatusInternalServerError
+	}
+	if cerr.Type != common.ErrorTypeNone {
+		data.Error = cerr.Error()
+	}
+
+	//render the view
+	return status, h.Renderer.RenderView(req, data, view)
+}
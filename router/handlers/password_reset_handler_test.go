@@ -0,0 +1,222 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/router/handlers"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type PasswordResetHandlerTestSuite struct {
+	HandlersTestSuite
+}
+
+func (suite *PasswordResetHandlerTestSuite) PasswordResetViewRenderedWithData(view string, token string, message string, errSubStrings ...string) {
+	data := suite.RenderViewData.(handlers.PasswordResetViewData)
+	suite.Equal(token, data.Token)
+	suite.Equal(message, data.Message)
+	suite.ContainsSubstrings(data.Error, errSubStrings...)
+
+	suite.RendererMock.AssertCalled(suite.T(), "RenderView", mock.Anything, data, view)
+}
+
+func (suite *PasswordResetHandlerTestSuite) TestGetForgotPassword_RendersForgotPasswordView() {
+	//arrange
+	req := suite.CreateRequest("", "/password/forgot", "", nil)
+
+	//act
+	status, res := suite.CoreHandlers.GetForgotPassword(req, nil, nil, nil)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.AssertRenderViewResult(res)
+	suite.PasswordResetViewRenderedWithData("password/forgot", "", "")
+}
+
+func (suite *PasswordResetHandlerTestSuite) TestPostForgotPassword_WithClientErrorRequestingPasswordReset_RendersForgotPasswordViewWithError() {
+	//arrange
+	req := suite.CreateDummyFormRequest(url.Values{"email": []string{""}})
+
+	message := "request password reset error"
+	suite.ControllersMock.On("RequestPasswordReset", mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostForgotPassword(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.AssertRenderViewResult(res)
+	suite.PasswordResetViewRenderedWithData("password/forgot", "", "", message)
+}
+
+func (suite *PasswordResetHandlerTestSuite) TestPostForgotPassword_WithInternalErrorRequestingPasswordReset_RendersForgotPasswordViewWithInternalServerError() {
+	//arrange
+	req := suite.CreateDummyFormRequest(url.Values{"email": []string{"user@example.com"}})
+	suite.ControllersMock.On("RequestPasswordReset", mock.Anything, mock.Anything).Return(common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostForgotPassword(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.AssertRenderViewResult(res)
+	suite.PasswordResetViewRenderedWithData("password/forgot", "", "", "internal error")
+}
+
+func (suite *PasswordResetHandlerTestSuite) TestPostForgotPassword_RequestsPasswordResetAndRendersMessage() {
+	//arrange
+	email := "user@example.com"
+	req := suite.CreateFormRequest("POST", "http://host/password/forgot", "", url.Values{"email": []string{email}})
+
+	suite.ControllersMock.On("RequestPasswordReset", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostForgotPassword(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.AssertRenderViewResult(res)
+	suite.ControllersMock.AssertCalled(suite.T(), "RequestPasswordReset", &suite.CRUDMock, email)
+
+	data := suite.RenderViewData.(handlers.PasswordResetViewData)
+	suite.Contains(data.Message, "link to reset its password has been sent")
+	suite.Empty(data.Error)
+}
+
+func (suite *PasswordResetHandlerTestSuite) TestGetResetPassword_RendersResetPasswordViewWithToken() {
+	//arrange
+	token := "token"
+	req := suite.CreateRequest("", "/password/reset?token="+token, "", nil)
+
+	//act
+	status, res := suite.CoreHandlers.GetResetPassword(req, nil, nil, nil)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.AssertRenderViewResult(res)
+	suite.PasswordResetViewRenderedWithData("password/reset", token, "")
+}
+
+func (suite *PasswordResetHandlerTestSuite) TestPostResetPassword_WherePasswordsDoNotMatch_RendersResetPasswordViewWithError() {
+	//arrange
+	token := "token"
+	values := url.Values{
+		"token":            []string{token},
+		"password":         []string{"password"},
+		"confirm_password": []string{"other password"},
+	}
+	req := suite.CreateDummyFormRequest(values)
+
+	//act
+	status, res := suite.CoreHandlers.PostResetPassword(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.AssertRenderViewResult(res)
+	suite.PasswordResetViewRenderedWithData("password/reset", token, "", "passwords do not match")
+	suite.ControllersMock.AssertNotCalled(suite.T(), "ResetPassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *PasswordResetHandlerTestSuite) TestPostResetPassword_WithClientErrorResettingPassword_RendersResetPasswordViewWithError() {
+	//arrange
+	token := "token"
+	values := url.Values{
+		"token":            []string{token},
+		"password":         []string{"password"},
+		"confirm_password": []string{"password"},
+	}
+	req := suite.CreateDummyFormRequest(values)
+
+	message := "reset password error"
+	suite.ControllersMock.On("ResetPassword", mock.Anything, mock.Anything, mock.Anything).Return("", common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostResetPassword(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.AssertRenderViewResult(res)
+	suite.PasswordResetViewRenderedWithData("password/reset", token, "", message)
+}
+
+func (suite *PasswordResetHandlerTestSuite) TestPostResetPassword_WithInternalErrorResettingPassword_RendersResetPasswordViewWithInternalServerError() {
+	//arrange
+	token := "token"
+	values := url.Values{
+		"token":            []string{token},
+		"password":         []string{"password"},
+		"confirm_password": []string{"password"},
+	}
+	req := suite.CreateDummyFormRequest(values)
+
+	suite.ControllersMock.On("ResetPassword", mock.Anything, mock.Anything, mock.Anything).Return("", common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostResetPassword(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.AssertRenderViewResult(res)
+	suite.PasswordResetViewRenderedWithData("password/reset", token, "", "internal error")
+}
+
+func (suite *PasswordResetHandlerTestSuite) TestPostResetPassword_WithErrorCreatingAuditEvent_RendersResetPasswordViewWithInternalServerError() {
+	//arrange
+	suite.FailAuditEvents()
+
+	token := "token"
+	values := url.Values{
+		"token":            []string{token},
+		"password":         []string{"password"},
+		"confirm_password": []string{"password"},
+	}
+	req := suite.CreateDummyFormRequest(values)
+
+	suite.ControllersMock.On("ResetPassword", mock.Anything, mock.Anything, mock.Anything).Return("username", common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostResetPassword(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.AssertRenderViewResult(res)
+	suite.PasswordResetViewRenderedWithData("password/reset", token, "", "internal error")
+}
+
+func (suite *PasswordResetHandlerTestSuite) TestPostResetPassword_ResetsPasswordAndRendersMessage() {
+	//arrange
+	token := "token"
+	password := "password"
+	values := url.Values{
+		"token":            []string{token},
+		"password":         []string{password},
+		"confirm_password": []string{password},
+	}
+	req := suite.CreateDummyFormRequest(values)
+
+	suite.ControllersMock.On("ResetPassword", mock.Anything, mock.Anything, mock.Anything).Return("username", common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostResetPassword(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.AssertRenderViewResult(res)
+	suite.ControllersMock.AssertCalled(suite.T(), "ResetPassword", &suite.CRUDMock, token, password)
+	suite.AssertAuditEventCreated("username", models.AuditActionResetPassword, "username")
+
+	data := suite.RenderViewData.(handlers.PasswordResetViewData)
+	suite.Empty(data.Token)
+	suite.Contains(data.Message, "password has been reset")
+	suite.Empty(data.Error)
+}
+
+func TestPasswordResetHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &PasswordResetHandlerTestSuite{})
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+)
+
+type RoleDefinitionDataResponse struct {
+	PostRoleDefinitionBody
+}
+
+func (h CoreHandlers) GetRoleDefinitions(req *http.Request, params httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//parse the client id
+	clientID, err := uuid.Parse(params.ByName("id"))
+	if err != nil {
+		log.Println(common.ChainError("error parsing client id", err))
+		return common.NewBadRequestResponse("client id is in an invalid format")
+	}
+
+	//get the role definitions
+	definitions, cerr := h.Controllers.GetRoleDefinitionsByClientUID(CRUD, clientID)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//return the data
+	data := make([]RoleDefinitionDataResponse, len(definitions))
+	for index, definition := range definitions {
+		data[index] = h.newRoleDefinitionDataResponse(definition)
+	}
+	return common.NewSuccessDataResponse(data)
+}
+
+type PostRoleDefinitionBody struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsDefault   bool   `json:"is_default"`
+}
+
+func (h CoreHandlers) PostRoleDefinition(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	var body PostRoleDefinitionBody
+
+	//parse the client id
+	clientID, err := uuid.Parse(params.ByName("id"))
+	if err != nil {
+		log.Println(common.ChainError("error parsing client id", err))
+		return common.NewBadRequestResponse("client id is in an invalid format")
+	}
+
+	//parse the body
+	err = parseJSONBody(req.Body, &body)
+	if err != nil {
+		log.Println(common.ChainError("error parsing PostRoleDefinitionBody request body", err))
+		return common.NewBadRequestResponse("invalid json body")
+	}
+
+	//create the model
+	definition := models.CreateRoleDefinition(clientID, body.Name, body.Description, body.IsDefault)
+
+	//create the role definition
+	cerr := h.Controllers.CreateRoleDefinition(CRUD, definition)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionCreateRoleDefinition, roleDefinitionAuditTarget(clientID, definition.Name))
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessDataResponse(h.newRoleDefinitionDataResponse(definition))
+}
+
+func (h CoreHandlers) PutRoleDefinition(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	var body PostRoleDefinitionBody
+
+	//parse the client id
+	clientID, err := uuid.Parse(params.ByName("id"))
+	if err != nil {
+		log.Println(common.ChainError("error parsing client id", err))
+		return common.NewBadRequestResponse("client id is in an invalid format")
+	}
+
+	//get the name
+	name := params.ByName("name")
+	if name == "" {
+		return common.NewBadRequestResponse("role name not provided")
+	}
+
+	//parse the body
+	err = parseJSONBody(req.Body, &body)
+	if err != nil {
+		log.Println(common.ChainError("error parsing PutRoleDefinition request body", err))
+		return common.NewBadRequestResponse("invalid json body")
+	}
+
+	//create the model
+	definition := models.CreateRoleDefinition(clientID, body.Name, body.Description, body.IsDefault)
+
+	//update the role definition
+	cerr := h.Controllers.UpdateRoleDefinition(CRUD, name, definition)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionUpdateRoleDefinition, roleDefinitionAuditTarget(clientID, name))
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessDataResponse(h.newRoleDefinitionDataResponse(definition))
+}
+
+func (h CoreHandlers) DeleteRoleDefinition(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//parse the client id
+	clientID, err := uuid.Parse(params.ByName("id"))
+	if err != nil {
+		log.Println(common.ChainError("error parsing client id", err))
+		return common.NewBadRequestResponse("client id is in an invalid format")
+	}
+
+	//get the name
+	name := params.ByName("name")
+	if name == "" {
+		return common.NewBadRequestResponse("role name not provided")
+	}
+
+	//delete the role definition
+	cerr := h.Controllers.DeleteRoleDefinition(CRUD, clientID, name)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionDeleteRoleDefinition, roleDefinitionAuditTarget(clientID, name))
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessResponse()
+}
+
+// roleDefinitionAuditTarget creates the audit event target for the role definition with the given client id and name.
+func roleDefinitionAuditTarget(clientID uuid.UUID, name string) string {
+	return clientID.String() + "/" + name
+}
+
+func (CoreHandlers) newRoleDefinitionDataResponse(definition *models.RoleDefinition) RoleDefinitionDataResponse {
+	return RoleDefinitionDataResponse{
+		PostRoleDefinitionBody: PostRoleDefinitionBody{
+			Name:        definition.Name,
+			Description: definition.Description,
+			IsDefault:   definition.IsDefault,
+		},
+	}
+}
@@ -0,0 +1,523 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/router/handlers"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RoleDefinitionHandlerTestSuite struct {
+	HandlersTestSuite
+}
+
+func (suite *RoleDefinitionHandlerTestSuite) TestGetRoleDefinitions_WithInvalidClientID_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: "invalid",
+		},
+	}
+
+	//act
+	status, res := suite.CoreHandlers.GetRoleDefinitions(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "client id", "invalid format")
+}
+
+func (suite *RoleDefinitionHandlerTestSuite) TestGetRoleDefinitions_WithClientErrorGettingRoleDefinitions_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	message := "get role definitions error"
+	suite.ControllersMock.On("GetRoleDefinitionsByClientUID", mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.GetRoleDefinitions(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *RoleDefinitionHandlerTestSuite) TestGetRoleDefinitions_WithInternalErrorGettingRoleDefinitions_ReturnsInternalServerError() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	suite.ControllersMock.On("GetRoleDefinitionsByClientUID", mock.Anything, mock.Anything).Return(nil, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.GetRoleDefinitions(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *RoleDefinitionHandlerTestSuite) TestGetRoleDefinitions_WithNoErrors_ReturnsRoleDefinitionData() {
+	//arrange
+	clientUID := uuid.New()
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: clientUID.String(),
+		},
+	}
+
+	definitions := []*models.RoleDefinition{
+		models.CreateRoleDefinition(clientUID, "admin", "Manages the client", false),
+		models.CreateRoleDefinition(clientUID, "member", "", true),
+	}
+	suite.ControllersMock.On("GetRoleDefinitionsByClientUID", mock.Anything, mock.Anything).Return(definitions, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.GetRoleDefinitions(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, []handlers.RoleDefinitionDataResponse{
+		{
+			PostRoleDefinitionBody: handlers.PostRoleDefinitionBody{
+				Name:        definitions[0].Name,
+				Description: definitions[0].Description,
+				IsDefault:   definitions[0].IsDefault,
+			},
+		},
+		{
+			PostRoleDefinitionBody: handlers.PostRoleDefinitionBody{
+				Name:        definitions[1].Name,
+				Description: definitions[1].Description,
+				IsDefault:   definitions[1].IsDefault,
+			},
+		},
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "GetRoleDefinitionsByClientUID", &suite.CRUDMock, clientUID)
+}
+
+func (suite *RoleDefinitionHandlerTestSuite) TestPostRoleDefinition_WithInvalidClientID_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: "invalid",
+		},
+	}
+
+	//act
+	status, res := suite.CoreHandlers.PostRoleDefinition(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "client id", "invalid format")
+}
+
+func (suite *RoleDefinitionHandlerTestSuite) TestPostRoleDefinition_WithInvalidJSONBody_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+	req := suite.CreateDummyJSONRequest("invalid")
+
+	//act
+	status, res := suite.CoreHandlers.PostRoleDefinition(req, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "invalid json body")
+}
+
+func (suite *RoleDefinitionHandlerTestSuite) TestPostRoleDefinition_WithClientErrorCreatingRoleDefinition_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	body := handlers.PostRoleDefinitionBody{
+		Name: "role",
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	message := "create role definition error"
+	suite.ControllersMock.On("CreateRoleDefinition", mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostRoleDefinition(req, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *RoleDefinitionHandlerTestSuite) TestPostRoleDefinition_WithInternalErrorCreatingRoleDefinition_ReturnsInternalServerError() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	body := handlers.PostRoleDefinitionBody{
+		Name: "role",
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	suite.ControllersMock.On("CreateRoleDefinition", mock.Anything, mock.Anything).Return(common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostRoleDefinition(req, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *RoleDefinitionHandlerTestSuite) TestPostRoleDefinition_WithErrorCreatingAuditEvent_ReturnsInternalServerError() {
+	//arrange
+	suite.FailAuditEvents()
+
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	body := handlers.PostRoleDefinitionBody{
+		Name: "role",
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	suite.ControllersMock.On("CreateRoleDefinition", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostRoleDefinition(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *RoleDefinitionHandlerTestSuite) TestPostRoleDefinition_WithNoErrors_ReturnsRoleDefinitionData() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	clientUID := uuid.New()
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: clientUID.String(),
+		},
+	}
+
+	body := handlers.PostRoleDefinitionBody{
+		Name:        "role",
+		Description: "description",
+		IsDefault:   true,
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	suite.ControllersMock.On("CreateRoleDefinition", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostRoleDefinition(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, handlers.RoleDefinitionDataResponse{
+		PostRoleDefinitionBody: body,
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateRoleDefinition", &suite.CRUDMock, models.CreateRoleDefinition(clientUID, body.Name, body.Description, body.IsDefault))
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionCreateRoleDefinition, clientUID.String()+"/"+body.Name)
+}
+
+func (suite *RoleDefinitionHandlerTestSuite) TestPutRoleDefinition_WithInvalidClientID_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: "invalid",
+		},
+	}
+
+	//act
+	status, res := suite.CoreHandlers.PutRoleDefinition(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "client id", "invalid format")
+}
+
+func (suite *RoleDefinitionHandlerTestSuite) TestPutRoleDefinition_WithMissingName_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	//act
+	status, res := suite.CoreHandlers.PutRoleDefinition(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "role name not provided")
+}
+
+func (suite *RoleDefinitionHandlerTestSuite) TestPutRoleDefinition_WithInvalidJSONBody_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+		{
+			Key:   "name",
+			Value: "role",
+		},
+	}
+	req := suite.CreateDummyJSONRequest("invalid")
+
+	//act
+	status, res := suite.CoreHandlers.PutRoleDefinition(req, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "invalid json body")
+}
+
+func (suite *RoleDefinitionHandlerTestSuite) TestPutRoleDefinition_WithClientErrorUpdatingRoleDefinition_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+		{
+			Key:   "name",
+			Value: "role",
+		},
+	}
+
+	body := handlers.PostRoleDefinitionBody{
+		Name: "new_role",
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	message := "update role definition error"
+	suite.ControllersMock.On("UpdateRoleDefinition", mock.Anything, mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PutRoleDefinition(req, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *RoleDefinitionHandlerTestSuite) TestPutRoleDefinition_WithInternalErrorUpdatingRoleDefinition_ReturnsInternalServerError() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+		{
+			Key:   "name",
+			Value: "role",
+		},
+	}
+
+	body := handlers.PostRoleDefinitionBody{
+		Name: "new_role",
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	suite.ControllersMock.On("UpdateRoleDefinition", mock.Anything, mock.Anything, mock.Anything).Return(common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PutRoleDefinition(req, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *RoleDefinitionHandlerTestSuite) TestPutRoleDefinition_WithNoErrors_ReturnsRoleDefinitionData() {
+	//arrange
+	session := models.CreateNewSession("admin", 5)
+	clientUID := uuid.New()
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: clientUID.String(),
+		},
+		{
+			Key:   "name",
+			Value: "role",
+		},
+	}
+
+	body := handlers.PostRoleDefinitionBody{
+		Name:        "new_role",
+		Description: "description",
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	suite.ControllersMock.On("UpdateRoleDefinition", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PutRoleDefinition(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, handlers.RoleDefinitionDataResponse{
+		PostRoleDefinitionBody: body,
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "UpdateRoleDefinition", &suite.CRUDMock, params[1].Value, models.CreateRoleDefinition(clientUID, body.Name, body.Description, body.IsDefault))
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionUpdateRoleDefinition, clientUID.String()+"/"+params[1].Value)
+}
+
+func (suite *RoleDefinitionHandlerTestSuite) TestDeleteRoleDefinition_WithInvalidClientID_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: "invalid",
+		},
+	}
+
+	//act
+	status, res := suite.CoreHandlers.DeleteRoleDefinition(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "client id", "invalid format")
+}
+
+func (suite *RoleDefinitionHandlerTestSuite) TestDeleteRoleDefinition_WithMissingName_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	//act
+	status, res := suite.CoreHandlers.DeleteRoleDefinition(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "role name not provided")
+}
+
+func (suite *RoleDefinitionHandlerTestSuite) TestDeleteRoleDefinition_WithClientErrorDeletingRoleDefinition_ReturnsBadRequest() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+		{
+			Key:   "name",
+			Value: "role",
+		},
+	}
+
+	message := "delete role definition error"
+	suite.ControllersMock.On("DeleteRoleDefinition", mock.Anything, mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.DeleteRoleDefinition(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *RoleDefinitionHandlerTestSuite) TestDeleteRoleDefinition_WithInternalErrorDeletingRoleDefinition_ReturnsInternalServerError() {
+	//arrange
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+		{
+			Key:   "name",
+			Value: "role",
+		},
+	}
+
+	suite.ControllersMock.On("DeleteRoleDefinition", mock.Anything, mock.Anything, mock.Anything).Return(common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteRoleDefinition(nil, params, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *RoleDefinitionHandlerTestSuite) TestDeleteRoleDefinition_WithNoErrors_ReturnsSuccess() {
+	//arrange
+	req := suite.CreateDummyJSONRequest(nil)
+	session := models.CreateNewSession("admin", 5)
+	clientUID := uuid.New()
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: clientUID.String(),
+		},
+		{
+			Key:   "name",
+			Value: "role",
+		},
+	}
+
+	suite.ControllersMock.On("DeleteRoleDefinition", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteRoleDefinition(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessResponse(res)
+
+	suite.ControllersMock.AssertCalled(suite.T(), "DeleteRoleDefinition", &suite.CRUDMock, clientUID, params[1].Value)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionDeleteRoleDefinition, clientUID.String()+"/"+params[1].Value)
+}
+
+func TestRoleDefinitionHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &RoleDefinitionHandlerTestSuite{})
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/controllers"
 	"github.com/mhogar/amber/data"
 	"github.com/mhogar/amber/models"
 
@@ -16,9 +17,19 @@ type SessionDataResponse struct {
 	Username string `json:"username"`
 }
 
+// ChallengeDataResponse is returned instead of a session when the user still needs to complete another step.
+// The step is either "two_factor", completed with a code, or "change_password", completed with a new password.
+type ChallengeDataResponse struct {
+	Challenge string `json:"challenge"`
+	Step      string `json:"step"`
+}
+
 type PostSessionBody struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+	Challenge   string `json:"challenge"`
+	Code        string `json:"code"`
+	NewPassword string `json:"new_password"`
 }
 
 func (h CoreHandlers) PostSession(req *http.Request, _ httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
@@ -32,18 +43,34 @@ func (h CoreHandlers) PostSession(req *http.Request, _ httprouter.Params, _ *mod
 	}
 
 	//create the session
-	session, cerr := h.Controllers.CreateSession(CRUD, body.Username, body.Password)
+	session, challenge, cerr := h.Controllers.CreateSession(CRUD, controllers.UserCredentials{
+		Username:    body.Username,
+		Password:    body.Password,
+		Challenge:   body.Challenge,
+		Code:        body.Code,
+		NewPassword: body.NewPassword,
+		IPAddress:   getClientIP(req),
+	})
 	if cerr.Type == common.ErrorTypeClient {
-		return common.NewBadRequestResponse(cerr.Error())
+		//failed logins are counted towards lockouts and audited, so those changes are committed even though the request fails
+		return common.NewCommittedResponse(common.NewBadRequestResponse(cerr.Error()))
 	}
 	if cerr.Type == common.ErrorTypeInternal {
 		return common.NewInternalServerErrorResponse()
 	}
 
+	//ask for the user's two-factor code or new password
+	if challenge != "" {
+		return common.NewSuccessDataResponse(ChallengeDataResponse{
+			Challenge: challenge,
+			Step:      controllers.GetChallengeStep(challenge),
+		})
+	}
+
 	return h.newSessionDataResponse(session)
 }
 
-func (h CoreHandlers) DeleteSession(_ *http.Request, _ httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+func (h CoreHandlers) DeleteSession(req *http.Request, _ httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
 	//delete the session
 	cerr := h.Controllers.DeleteSession(CRUD, session.Token)
 	if cerr.Type == common.ErrorTypeClient {
@@ -53,6 +80,12 @@ func (h CoreHandlers) DeleteSession(_ *http.Request, _ httprouter.Params, sessio
 		return common.NewInternalServerErrorResponse()
 	}
 
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionLogout, session.Username)
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
 	return common.NewSuccessResponse()
 }
 
@@ -5,9 +5,13 @@ import (
 	"testing"
 
 	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
+	"github.com/mhogar/amber/controllers"
+	controllermocks "github.com/mhogar/amber/controllers/mocks"
 	"github.com/mhogar/amber/models"
 	"github.com/mhogar/amber/router/handlers"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -28,7 +32,7 @@ func (suite *SessionHandlerTestSuite) TestPostSession_WithInvalidJSONBody_Return
 	suite.ErrorResponse(res, "invalid json body")
 }
 
-func (suite *SessionHandlerTestSuite) TestPostSession_WithClientErrorCreatingSession_ReturnsBadRequest() {
+func (suite *SessionHandlerTestSuite) TestPostSession_WithClientErrorCreatingSession_ReturnsCommittedBadRequest() {
 	//arrange
 	body := handlers.PostSessionBody{
 		Username: "username",
@@ -37,14 +41,15 @@ func (suite *SessionHandlerTestSuite) TestPostSession_WithClientErrorCreatingSes
 	req := suite.CreateDummyJSONRequest(body)
 
 	message := "create session error"
-	suite.ControllersMock.On("CreateSession", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
+	suite.ControllersMock.On("CreateSession", mock.Anything, mock.Anything).Return(nil, "", common.ClientError(message))
 
 	//act
 	status, res := suite.CoreHandlers.PostSession(req, nil, nil, &suite.CRUDMock)
 
 	//assert
 	suite.Require().Equal(http.StatusBadRequest, status)
-	suite.ErrorResponse(res, message)
+	suite.Require().IsType(common.CommittedResponse{}, res)
+	suite.ErrorResponse(res.(common.CommittedResponse).Data, message)
 }
 
 func (suite *SessionHandlerTestSuite) TestPostSession_WithInternalErrorCreatingSession_ReturnsInternalServerError() {
@@ -55,7 +60,7 @@ func (suite *SessionHandlerTestSuite) TestPostSession_WithInternalErrorCreatingS
 	}
 	req := suite.CreateDummyJSONRequest(body)
 
-	suite.ControllersMock.On("CreateSession", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.InternalError())
+	suite.ControllersMock.On("CreateSession", mock.Anything, mock.Anything).Return(nil, "", common.InternalError())
 
 	//act
 	status, res := suite.CoreHandlers.PostSession(req, nil, nil, &suite.CRUDMock)
@@ -74,7 +79,7 @@ func (suite *SessionHandlerTestSuite) TestPostSession_WithNoErrors_ReturnsSessio
 	req := suite.CreateDummyJSONRequest(body)
 
 	session := models.CreateNewSession(body.Username, 0)
-	suite.ControllersMock.On("CreateSession", mock.Anything, mock.Anything, mock.Anything).Return(session, common.NoError())
+	suite.ControllersMock.On("CreateSession", mock.Anything, mock.Anything).Return(session, "", common.NoError())
 
 	//act
 	status, res := suite.CoreHandlers.PostSession(req, nil, nil, &suite.CRUDMock)
@@ -86,7 +91,123 @@ func (suite *SessionHandlerTestSuite) TestPostSession_WithNoErrors_ReturnsSessio
 		Username: body.Username,
 	})
 
-	suite.ControllersMock.AssertCalled(suite.T(), "CreateSession", &suite.CRUDMock, body.Username, body.Password)
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateSession", &suite.CRUDMock, controllers.UserCredentials{
+		Username: body.Username,
+		Password: body.Password,
+	})
+}
+
+func (suite *SessionHandlerTestSuite) TestPostSession_PassesClientIPAddressToController() {
+	var trustedProxies int
+	var forwardedFor string
+	var expectedIPAddress string
+
+	testCase := func() {
+		//arrange
+		suite.ControllersMock = controllermocks.Controllers{}
+		viper.Set("server", config.ServerConfig{
+			TrustedProxies: trustedProxies,
+		})
+
+		req := suite.CreateDummyJSONRequest(handlers.PostSessionBody{})
+		req.RemoteAddr = "10.0.0.1:1234"
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+
+		suite.ControllersMock.On("CreateSession", mock.Anything, mock.Anything).Return(models.CreateNewSession("username", 0), "", common.NoError())
+
+		//act
+		suite.CoreHandlers.PostSession(req, nil, nil, &suite.CRUDMock)
+
+		//assert
+		suite.ControllersMock.AssertCalled(suite.T(), "CreateSession", &suite.CRUDMock, controllers.UserCredentials{
+			IPAddress: expectedIPAddress,
+		})
+	}
+
+	trustedProxies = 0
+	forwardedFor = "203.0.113.1"
+	expectedIPAddress = "10.0.0.1"
+	suite.Run("ForwardedForNotTrusted", testCase)
+
+	trustedProxies = 1
+	forwardedFor = "203.0.113.1"
+	expectedIPAddress = "203.0.113.1"
+	suite.Run("ForwardedForTrusted", testCase)
+
+	trustedProxies = 1
+	forwardedFor = "198.51.100.1, 203.0.113.1"
+	expectedIPAddress = "203.0.113.1"
+	suite.Run("SpoofedAddressBeforeTrustedProxyIsIgnored", testCase)
+
+	trustedProxies = 2
+	forwardedFor = "198.51.100.1, 203.0.113.1, 10.0.0.2"
+	expectedIPAddress = "203.0.113.1"
+	suite.Run("AddressAddedByOutermostOfSeveralProxiesIsUsed", testCase)
+
+	trustedProxies = 3
+	forwardedFor = "203.0.113.1, 10.0.0.2"
+	expectedIPAddress = "203.0.113.1"
+	suite.Run("FewerAddressesThanProxiesUsesFirst", testCase)
+
+	trustedProxies = 1
+	forwardedFor = ""
+	expectedIPAddress = "10.0.0.1"
+	suite.Run("ForwardedForTrustedButMissing", testCase)
+}
+
+func (suite *SessionHandlerTestSuite) TestPostSession_WhereTwoFactorChallengeIsReturned_ReturnsChallengeData() {
+	//arrange
+	body := handlers.PostSessionBody{
+		Username: "username",
+		Password: "password",
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	challenge := controllers.ChallengeStepTwoFactor + ".challenge"
+	suite.ControllersMock.On("CreateSession", mock.Anything, mock.Anything).Return(nil, challenge, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostSession(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, handlers.ChallengeDataResponse{
+		Challenge: challenge,
+		Step:      controllers.ChallengeStepTwoFactor,
+	})
+}
+
+func (suite *SessionHandlerTestSuite) TestPostSession_WithChallenge_PassesChallengeCredentials() {
+	//arrange
+	body := handlers.PostSessionBody{
+		Username:    "username",
+		Challenge:   "previous challenge",
+		Code:        "123456",
+		NewPassword: "new password",
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	challenge := controllers.ChallengeStepChangePassword + ".challenge"
+	suite.ControllersMock.On("CreateSession", mock.Anything, mock.Anything).Return(nil, challenge, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostSession(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, handlers.ChallengeDataResponse{
+		Challenge: challenge,
+		Step:      controllers.ChallengeStepChangePassword,
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateSession", &suite.CRUDMock, controllers.UserCredentials{
+		Username:    body.Username,
+		Challenge:   body.Challenge,
+		Code:        body.Code,
+		NewPassword: body.NewPassword,
+	})
 }
 
 func (suite *SessionHandlerTestSuite) TestDeleteSession_WithClientErrorDeletingSession_ReturnsBadRequest() {
@@ -120,18 +241,20 @@ func (suite *SessionHandlerTestSuite) TestDeleteSession_WithInternalErrorDeletin
 
 func (suite *SessionHandlerTestSuite) TestDeleteSession_WithNoErrors_ReturnsSuccess() {
 	//arrange
+	req := suite.CreateDummyJSONRequest(nil)
 	session := &models.Session{}
 
 	suite.ControllersMock.On("DeleteSession", mock.Anything, mock.Anything).Return(common.NoError())
 
 	//act
-	status, res := suite.CoreHandlers.DeleteSession(nil, nil, session, &suite.CRUDMock)
+	status, res := suite.CoreHandlers.DeleteSession(req, nil, session, &suite.CRUDMock)
 
 	//assert
 	suite.Require().Equal(http.StatusOK, status)
 	suite.SuccessResponse(res)
 
 	suite.ControllersMock.AssertCalled(suite.T(), "DeleteSession", &suite.CRUDMock, session.Token)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionLogout, session.Username)
 }
 
 func TestSessionHandlerTestSuite(t *testing.T) {
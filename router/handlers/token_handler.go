@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/controllers"
 	"github.com/mhogar/amber/data"
 	"github.com/mhogar/amber/models"
 
@@ -12,43 +13,75 @@ import (
 	"github.com/julienschmidt/httprouter"
 )
 
+// TokenViewData is the data for the login view.
+// If Challenge is set, the view asks for whatever its Step requires (the user's two-factor code or a new password) instead of their username and password.
 type TokenViewData struct {
-	ClientID string
-	Error    string
+	Action    string
+	ClientID  string
+	Params    map[string]string
+	Challenge string
+	Step      string
+	Error     string
 }
 
 func (h CoreHandlers) GetToken(req *http.Request, _ httprouter.Params, _ *models.Session, _ data.DataCRUD) (int, interface{}) {
-	return h.renderTokenView(req, req.URL.Query().Get("client_id"), "")
+	return h.renderTokenView(req, req.URL.Query().Get("client_id"), "", "")
 }
 
 func (h CoreHandlers) PostToken(req *http.Request, _ httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
 	//get the form values
 	clientIdStr := req.PostFormValue("client_id")
-	username := req.PostFormValue("username")
-	password := req.PostFormValue("password")
 
 	//parse the client id
 	clientID, err := uuid.Parse(clientIdStr)
 	if err != nil {
 		log.Println(common.ChainError("error parsing client id", err))
-		return h.renderTokenView(req, clientIdStr, "client_id is not provided or in an invalid format")
+		return h.renderTokenView(req, clientIdStr, "", "client_id is not provided or in an invalid format")
+	}
+
+	//verify the new password was confirmed
+	creds := parseUserCredentialsForm(req)
+	if creds.NewPassword != req.PostFormValue("confirm_password") {
+		return h.renderTokenView(req, clientIdStr, creds.Challenge, "passwords do not match")
 	}
 
 	//create the token redirect url
-	redirectUrl, cerr := h.Controllers.CreateTokenRedirectURL(CRUD, clientID, username, password)
+	redirectUrl, challenge, cerr := h.Controllers.CreateTokenRedirectURL(CRUD, clientID, creds)
 	if cerr.Type != common.ErrorTypeNone {
-		return h.renderTokenView(req, clientIdStr, cerr.Error())
+		return h.renderTokenView(req, clientIdStr, "", cerr.Error())
+	}
+
+	//ask for the user's two-factor code or new password
+	if challenge != "" {
+		return h.renderTokenView(req, clientIdStr, challenge, "")
 	}
 
 	//send redirect response
 	return http.StatusSeeOther, redirectUrl
 }
 
-func (h CoreHandlers) renderTokenView(req *http.Request, clientID string, errMessage string) (int, interface{}) {
+func (h CoreHandlers) GetJWKS(_ *http.Request, _ httprouter.Params, _ *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//get the key set
+	jwks, cerr := h.Controllers.GetJSONWebKeySet(CRUD)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//return the key set directly since verifiers expect the standard format
+	return http.StatusOK, jwks
+}
+
+func (h CoreHandlers) renderTokenView(req *http.Request, clientID string, challenge string, errMessage string) (int, interface{}) {
 	//fill in the data struct
 	data := TokenViewData{
-		ClientID: clientID,
-		Error:    errMessage,
+		Action:    "/token",
+		ClientID:  clientID,
+		Challenge: challenge,
+		Step:      controllers.GetChallengeStep(challenge),
+		Error:     errMessage,
 	}
 
 	//render the view
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/controllers"
+	jwthelpers "github.com/mhogar/amber/controllers/jwt_helpers"
 	"github.com/mhogar/amber/router/handlers"
 
 	"github.com/google/uuid"
@@ -17,9 +19,11 @@ type TokenHandlerTestSuite struct {
 	HandlersTestSuite
 }
 
-func (suite *TokenHandlerTestSuite) TokenViewRenderedWithData(clientID string, errSubStrings ...string) {
+func (suite *TokenHandlerTestSuite) TokenViewRenderedWithData(clientID string, challenge string, errSubStrings ...string) {
 	data := suite.RenderViewData.(handlers.TokenViewData)
 	suite.Equal(clientID, data.ClientID)
+	suite.Equal(challenge, data.Challenge)
+	suite.Equal(controllers.GetChallengeStep(challenge), data.Step)
 	suite.ContainsSubstrings(data.Error, errSubStrings...)
 
 	suite.RendererMock.AssertCalled(suite.T(), "RenderView", mock.Anything, data, "token/index")
@@ -36,7 +40,7 @@ func (suite *TokenHandlerTestSuite) TestGetToken_RendersTokenView() {
 	//assert
 	suite.Require().Equal(http.StatusOK, status)
 	suite.AssertRenderViewResult(res)
-	suite.TokenViewRenderedWithData(clientID)
+	suite.TokenViewRenderedWithData(clientID, "")
 }
 
 func (suite *TokenHandlerTestSuite) TestPostToken_WithErrorParsingClientId_RendersTokenViewWithError() {
@@ -55,7 +59,7 @@ func (suite *TokenHandlerTestSuite) TestPostToken_WithErrorParsingClientId_Rende
 	//assert
 	suite.Require().Equal(http.StatusOK, status)
 	suite.AssertRenderViewResult(res)
-	suite.TokenViewRenderedWithData(clientID, "client_id", "not provided", "invalid format")
+	suite.TokenViewRenderedWithData(clientID, "", "client_id", "not provided", "invalid format")
 }
 
 func (suite *TokenHandlerTestSuite) TestPostToken_WithClientErrorCreatingTokenRedirectURL_RendersTokenViewWithError() {
@@ -69,7 +73,7 @@ func (suite *TokenHandlerTestSuite) TestPostToken_WithClientErrorCreatingTokenRe
 	req := suite.CreateDummyFormRequest(values)
 
 	message := "create token error"
-	suite.ControllersMock.On("CreateTokenRedirectURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", common.ClientError(message))
+	suite.ControllersMock.On("CreateTokenRedirectURL", mock.Anything, mock.Anything, mock.Anything).Return("", "", common.ClientError(message))
 
 	//act
 	status, res := suite.CoreHandlers.PostToken(req, nil, nil, &suite.CRUDMock)
@@ -77,7 +81,7 @@ func (suite *TokenHandlerTestSuite) TestPostToken_WithClientErrorCreatingTokenRe
 	//assert
 	suite.Require().Equal(http.StatusOK, status)
 	suite.AssertRenderViewResult(res)
-	suite.TokenViewRenderedWithData(clientID, message)
+	suite.TokenViewRenderedWithData(clientID, "", message)
 }
 
 func (suite *TokenHandlerTestSuite) TestPostToken_WithInternalErrorCreatingTokenRedirectURL_RendersTokenViewWithError() {
@@ -90,7 +94,7 @@ func (suite *TokenHandlerTestSuite) TestPostToken_WithInternalErrorCreatingToken
 	}
 	req := suite.CreateDummyFormRequest(values)
 
-	suite.ControllersMock.On("CreateTokenRedirectURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", common.InternalError())
+	suite.ControllersMock.On("CreateTokenRedirectURL", mock.Anything, mock.Anything, mock.Anything).Return("", "", common.InternalError())
 
 	//act
 	status, res := suite.CoreHandlers.PostToken(req, nil, nil, &suite.CRUDMock)
@@ -98,7 +102,7 @@ func (suite *TokenHandlerTestSuite) TestPostToken_WithInternalErrorCreatingToken
 	//assert
 	suite.Require().Equal(http.StatusOK, status)
 	suite.AssertRenderViewResult(res)
-	suite.TokenViewRenderedWithData(clientID, "internal error")
+	suite.TokenViewRenderedWithData(clientID, "", "internal error")
 }
 
 func (suite *TokenHandlerTestSuite) TestPostToken_WithNoErrors_ReturnsRedirect() {
@@ -112,7 +116,7 @@ func (suite *TokenHandlerTestSuite) TestPostToken_WithNoErrors_ReturnsRedirect()
 	req := suite.CreateDummyFormRequest(values)
 
 	redirectUrl := "redirect.com"
-	suite.ControllersMock.On("CreateTokenRedirectURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(redirectUrl, common.NoError())
+	suite.ControllersMock.On("CreateTokenRedirectURL", mock.Anything, mock.Anything, mock.Anything).Return(redirectUrl, "", common.NoError())
 
 	//act
 	status, res := suite.CoreHandlers.PostToken(req, nil, nil, &suite.CRUDMock)
@@ -120,6 +124,146 @@ func (suite *TokenHandlerTestSuite) TestPostToken_WithNoErrors_ReturnsRedirect()
 	//assert
 	suite.Require().Equal(http.StatusSeeOther, status)
 	suite.Equal(redirectUrl, res)
+
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateTokenRedirectURL", &suite.CRUDMock, uuid.MustParse(clientID), controllers.UserCredentials{
+		Username: "username",
+		Password: "password",
+	})
+}
+
+func (suite *TokenHandlerTestSuite) TestPostToken_WhereTwoFactorChallengeIsReturned_RendersTokenViewWithChallenge() {
+	//arrange
+	clientID := uuid.New().String()
+	values := url.Values{
+		"client_id": []string{clientID},
+		"username":  []string{"username"},
+		"password":  []string{"password"},
+	}
+	req := suite.CreateDummyFormRequest(values)
+
+	challenge := controllers.ChallengeStepTwoFactor + ".challenge"
+	suite.ControllersMock.On("CreateTokenRedirectURL", mock.Anything, mock.Anything, mock.Anything).Return("", challenge, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostToken(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.AssertRenderViewResult(res)
+	suite.TokenViewRenderedWithData(clientID, challenge)
+}
+
+func (suite *TokenHandlerTestSuite) TestPostToken_WithChallengeAndCode_PassesThemToController() {
+	//arrange
+	clientID := uuid.New().String()
+	values := url.Values{
+		"client_id": []string{clientID},
+		"challenge": []string{"challenge"},
+		"code":      []string{"123456"},
+	}
+	req := suite.CreateDummyFormRequest(values)
+
+	suite.ControllersMock.On("CreateTokenRedirectURL", mock.Anything, mock.Anything, mock.Anything).Return("redirect.com", "", common.NoError())
+
+	//act
+	status, _ := suite.CoreHandlers.PostToken(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusSeeOther, status)
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateTokenRedirectURL", &suite.CRUDMock, uuid.MustParse(clientID), controllers.UserCredentials{
+		Challenge: "challenge",
+		Code:      "123456",
+	})
+}
+
+func (suite *TokenHandlerTestSuite) TestPostToken_WhereNewPasswordIsNotConfirmed_RendersTokenViewWithChallengeAndError() {
+	//arrange
+	clientID := uuid.New().String()
+	challenge := controllers.ChallengeStepChangePassword + ".challenge"
+	values := url.Values{
+		"client_id":        []string{clientID},
+		"challenge":        []string{challenge},
+		"new_password":     []string{"new password"},
+		"confirm_password": []string{"other password"},
+	}
+	req := suite.CreateDummyFormRequest(values)
+
+	//act
+	status, res := suite.CoreHandlers.PostToken(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.AssertRenderViewResult(res)
+	suite.TokenViewRenderedWithData(clientID, challenge, "passwords do not match")
+
+	suite.ControllersMock.AssertNotCalled(suite.T(), "CreateTokenRedirectURL", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TokenHandlerTestSuite) TestPostToken_WithChallengeAndNewPassword_PassesThemToController() {
+	//arrange
+	clientID := uuid.New().String()
+	values := url.Values{
+		"client_id":        []string{clientID},
+		"challenge":        []string{"challenge"},
+		"new_password":     []string{"new password"},
+		"confirm_password": []string{"new password"},
+	}
+	req := suite.CreateDummyFormRequest(values)
+
+	suite.ControllersMock.On("CreateTokenRedirectURL", mock.Anything, mock.Anything, mock.Anything).Return("redirect.com", "", common.NoError())
+
+	//act
+	status, _ := suite.CoreHandlers.PostToken(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusSeeOther, status)
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateTokenRedirectURL", &suite.CRUDMock, uuid.MustParse(clientID), controllers.UserCredentials{
+		Challenge:   "challenge",
+		NewPassword: "new password",
+	})
+}
+
+func (suite *TokenHandlerTestSuite) TestGetJWKS_WithClientErrorGettingJSONWebKeySet_ReturnsBadRequest() {
+	//arrange
+	message := "get jwks error"
+	suite.ControllersMock.On("GetJSONWebKeySet", mock.Anything).Return(nil, common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.GetJWKS(nil, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *TokenHandlerTestSuite) TestGetJWKS_WithInternalErrorGettingJSONWebKeySet_ReturnsInternalServerError() {
+	//arrange
+	suite.ControllersMock.On("GetJSONWebKeySet", mock.Anything).Return(nil, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.GetJWKS(nil, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *TokenHandlerTestSuite) TestGetJWKS_WithNoErrors_ReturnsJSONWebKeySet() {
+	//arrange
+	jwks := &jwthelpers.JWKS{
+		Keys: []jwthelpers.JWK{
+			{Kty: "RSA", Kid: "kid"},
+		},
+	}
+	suite.ControllersMock.On("GetJSONWebKeySet", mock.Anything).Return(jwks, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.GetJWKS(nil, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.Equal(jwks, res)
+	suite.ControllersMock.AssertCalled(suite.T(), "GetJSONWebKeySet", &suite.CRUDMock)
 }
 
 func TestTokenHandlerTestSuite(t *testing.T) {
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	"github.com/mhogar/amber/models"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type TOTPEnrollmentDataResponse struct {
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type RecoveryCodesDataResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type TwoFactorCodeBody struct {
+	Code string `json:"code"`
+}
+
+func (h CoreHandlers) PostUserTOTP(_ *http.Request, _ httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//begin the enrollment
+	uri, cerr := h.Controllers.BeginTOTPEnrollment(CRUD, session.Username)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessDataResponse(TOTPEnrollmentDataResponse{
+		ProvisioningURI: uri,
+	})
+}
+
+func (h CoreHandlers) PostUserTOTPConfirm(req *http.Request, _ httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//parse the body
+	var body TwoFactorCodeBody
+	err := parseJSONBody(req.Body, &body)
+	if err != nil {
+		log.Println(common.ChainError("error parsing PostUserTOTPConfirm request body", err))
+		return common.NewBadRequestResponse("invalid json body")
+	}
+
+	//confirm the enrollment
+	codes, cerr := h.Controllers.ConfirmTOTPEnrollment(CRUD, session.Username, body.Code)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionEnableTOTP, session.Username)
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessDataResponse(RecoveryCodesDataResponse{
+		RecoveryCodes: codes,
+	})
+}
+
+func (h CoreHandlers) PostUserTOTPDisable(req *http.Request, _ httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//parse the body
+	var body TwoFactorCodeBody
+	err := parseJSONBody(req.Body, &body)
+	if err != nil {
+		log.Println(common.ChainError("error parsing PostUserTOTPDisable request body", err))
+		return common.NewBadRequestResponse("invalid json body")
+	}
+
+	//disable two-factor authentication
+	cerr := h.Controllers.DisableTOTP(CRUD, session.Username, body.Code)
+	if cerr.Type == common.ErrorTypeClient {
+		return common.NewBadRequestResponse(cerr.Error())
+	}
+	if cerr.Type == common.ErrorTypeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionDisableTOTP, session.Username)
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	return common.NewSuccessResponse()
+}
@@ -0,0 +1,207 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/router/handlers"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TwoFactorHandlerTestSuite struct {
+	HandlersTestSuite
+}
+
+func (suite *TwoFactorHandlerTestSuite) TestPostUserTOTP_WithClientErrorBeginningTOTPEnrollment_ReturnsBadRequest() {
+	//arrange
+	session := models.CreateNewSession("username", 0)
+
+	message := "begin totp enrollment error"
+	suite.ControllersMock.On("BeginTOTPEnrollment", mock.Anything, mock.Anything).Return("", common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostUserTOTP(nil, nil, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *TwoFactorHandlerTestSuite) TestPostUserTOTP_WithInternalErrorBeginningTOTPEnrollment_ReturnsInternalServerError() {
+	//arrange
+	session := models.CreateNewSession("username", 0)
+	suite.ControllersMock.On("BeginTOTPEnrollment", mock.Anything, mock.Anything).Return("", common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostUserTOTP(nil, nil, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *TwoFactorHandlerTestSuite) TestPostUserTOTP_WithNoErrors_ReturnsProvisioningURI() {
+	//arrange
+	session := models.CreateNewSession("username", 0)
+
+	uri := "otpauth://totp/app:username"
+	suite.ControllersMock.On("BeginTOTPEnrollment", mock.Anything, mock.Anything).Return(uri, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostUserTOTP(nil, nil, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, handlers.TOTPEnrollmentDataResponse{
+		ProvisioningURI: uri,
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "BeginTOTPEnrollment", &suite.CRUDMock, session.Username)
+}
+
+func (suite *TwoFactorHandlerTestSuite) TestPostUserTOTPConfirm_WithInvalidJSONBody_ReturnsInvalidRequest() {
+	//arrange
+	req := suite.CreateDummyJSONRequest("invalid")
+
+	//act
+	status, res := suite.CoreHandlers.PostUserTOTPConfirm(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "invalid json body")
+}
+
+func (suite *TwoFactorHandlerTestSuite) TestPostUserTOTPConfirm_WithClientErrorConfirmingTOTPEnrollment_ReturnsBadRequest() {
+	//arrange
+	session := models.CreateNewSession("username", 0)
+	req := suite.CreateDummyJSONRequest(handlers.TwoFactorCodeBody{
+		Code: "123456",
+	})
+
+	message := "confirm totp enrollment error"
+	suite.ControllersMock.On("ConfirmTOTPEnrollment", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostUserTOTPConfirm(req, nil, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *TwoFactorHandlerTestSuite) TestPostUserTOTPConfirm_WithInternalErrorConfirmingTOTPEnrollment_ReturnsInternalServerError() {
+	//arrange
+	session := models.CreateNewSession("username", 0)
+	req := suite.CreateDummyJSONRequest(handlers.TwoFactorCodeBody{
+		Code: "123456",
+	})
+
+	suite.ControllersMock.On("ConfirmTOTPEnrollment", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostUserTOTPConfirm(req, nil, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *TwoFactorHandlerTestSuite) TestPostUserTOTPConfirm_WithNoErrors_ReturnsRecoveryCodes() {
+	//arrange
+	session := models.CreateNewSession("username", 0)
+	body := handlers.TwoFactorCodeBody{
+		Code: "123456",
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	codes := []string{"AAAA-BBBB-CCCC-DDDD", "EEEE-FFFF-GGGG-HHHH"}
+	suite.ControllersMock.On("ConfirmTOTPEnrollment", mock.Anything, mock.Anything, mock.Anything).Return(codes, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostUserTOTPConfirm(req, nil, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessDataResponse(res, handlers.RecoveryCodesDataResponse{
+		RecoveryCodes: codes,
+	})
+
+	suite.ControllersMock.AssertCalled(suite.T(), "ConfirmTOTPEnrollment", &suite.CRUDMock, session.Username, body.Code)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionEnableTOTP, session.Username)
+}
+
+func (suite *TwoFactorHandlerTestSuite) TestPostUserTOTPDisable_WithInvalidJSONBody_ReturnsInvalidRequest() {
+	//arrange
+	req := suite.CreateDummyJSONRequest("invalid")
+
+	//act
+	status, res := suite.CoreHandlers.PostUserTOTPDisable(req, nil, nil, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, "invalid json body")
+}
+
+func (suite *TwoFactorHandlerTestSuite) TestPostUserTOTPDisable_WithClientErrorDisablingTOTP_ReturnsBadRequest() {
+	//arrange
+	session := models.CreateNewSession("username", 0)
+	req := suite.CreateDummyJSONRequest(handlers.TwoFactorCodeBody{
+		Code: "123456",
+	})
+
+	message := "disable totp error"
+	suite.ControllersMock.On("DisableTOTP", mock.Anything, mock.Anything, mock.Anything).Return(common.ClientError(message))
+
+	//act
+	status, res := suite.CoreHandlers.PostUserTOTPDisable(req, nil, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusBadRequest, status)
+	suite.ErrorResponse(res, message)
+}
+
+func (suite *TwoFactorHandlerTestSuite) TestPostUserTOTPDisable_WithInternalErrorDisablingTOTP_ReturnsInternalServerError() {
+	//arrange
+	session := models.CreateNewSession("username", 0)
+	req := suite.CreateDummyJSONRequest(handlers.TwoFactorCodeBody{
+		Code: "123456",
+	})
+
+	suite.ControllersMock.On("DisableTOTP", mock.Anything, mock.Anything, mock.Anything).Return(common.InternalError())
+
+	//act
+	status, res := suite.CoreHandlers.PostUserTOTPDisable(req, nil, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
+func (suite *TwoFactorHandlerTestSuite) TestPostUserTOTPDisable_WithNoErrors_ReturnsSuccess() {
+	//arrange
+	session := models.CreateNewSession("username", 0)
+	body := handlers.TwoFactorCodeBody{
+		Code: "123456",
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	suite.ControllersMock.On("DisableTOTP", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostUserTOTPDisable(req, nil, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.SuccessResponse(res)
+
+	suite.ControllersMock.AssertCalled(suite.T(), "DisableTOTP", &suite.CRUDMock, session.Username, body.Code)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionDisableTOTP, session.Username)
+}
+
+func TestTwoFactorHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &TwoFactorHandlerTestSuite{})
+}
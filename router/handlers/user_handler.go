@@ -12,13 +12,22 @@ import (
 )
 
 type UserDataResponse struct {
-	Username string `json:"username"`
-	PutUserBody
+	Username    string `json:"username"`
+	Rank        int    `json:"rank"`
+	Email       string `json:"email"`
+	DisplayName string `json:"display_name"`
+	Enabled     bool   `json:"enabled"`
 }
 
-func (h CoreHandlers) GetUsers(_ *http.Request, _ httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+func (h CoreHandlers) GetUsers(req *http.Request, _ httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+	//parse the page query
+	query, err := parsePageQuery(req.URL.Query())
+	if err != nil {
+		return common.NewBadRequestResponse(err.Error())
+	}
+
 	//get the users
-	users, cerr := h.Controllers.GetUsersWithLesserRank(CRUD, session.Rank)
+	users, page, cerr := h.Controllers.GetUsersWithLesserRank(CRUD, session.Rank, query)
 	if cerr.Type == common.ErrorTypeClient {
 		return common.NewBadRequestResponse(cerr.Error())
 	}
@@ -31,13 +40,13 @@ func (h CoreHandlers) GetUsers(_ *http.Request, _ httprouter.Params, session *mo
 	for index, user := range users {
 		data[index] = h.newUserDataResponse(user)
 	}
-	return common.NewSuccessDataResponse(data)
+	return newPageResponse(data, page)
 }
 
 type PostUserBody struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
-	Rank     int    `json:"rank"`
+	PutUserBody
 }
 
 func (h CoreHandlers) PostUser(req *http.Request, _ httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
@@ -55,7 +64,7 @@ func (h CoreHandlers) PostUser(req *http.Request, _ httprouter.Params, session *
 	}
 
 	//create the user
-	user, cerr := h.Controllers.CreateUser(CRUD, body.Username, body.Password, body.Rank)
+	user, cerr := h.Controllers.CreateUser(CRUD, body.Username, body.Password, body.Rank, body.profile())
 	if cerr.Type == common.ErrorTypeClient {
 		return common.NewBadRequestResponse(cerr.Error())
 	}
@@ -63,11 +72,35 @@ func (h CoreHandlers) PostUser(req *http.Request, _ httprouter.Params, session *
 		return common.NewInternalServerErrorResponse()
 	}
 
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionCreateUser, user.Username)
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
 	return common.NewSuccessDataResponse(h.newUserDataResponse(user))
 }
 
 type PutUserBody struct {
 	Rank int `json:"rank"`
+
+	// The profile fields keep their current values if not provided when updating a user.
+	// When creating a user, Enabled defaults to true.
+	Email       *string `json:"email"`
+	DisplayName *string `json:"display_name"`
+	Enabled     *bool   `json:"enabled"`
+}
+
+func (body PutUserBody) profileUpdate() models.UserProfileUpdate {
+	return models.UserProfileUpdate{
+		Email:       body.Email,
+		DisplayName: body.DisplayName,
+		Enabled:     body.Enabled,
+	}
+}
+
+func (body PutUserBody) profile() models.UserProfile {
+	return body.profileUpdate().Apply(models.UserProfile{Enabled: true})
 }
 
 func (h CoreHandlers) PutUser(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
@@ -103,7 +136,7 @@ func (h CoreHandlers) PutUser(req *http.Request, params httprouter.Params, sessi
 	}
 
 	//update the user
-	user, cerr := h.Controllers.UpdateUser(CRUD, username, body.Rank)
+	user, cerr := h.Controllers.UpdateUser(CRUD, username, body.Rank, body.profileUpdate())
 	if cerr.Type == common.ErrorTypeClient {
 		return common.NewBadRequestResponse(cerr.Error())
 	}
@@ -111,6 +144,12 @@ func (h CoreHandlers) PutUser(req *http.Request, params httprouter.Params, sessi
 		return common.NewInternalServerErrorResponse()
 	}
 
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionUpdateUser, username)
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
 	return common.NewSuccessDataResponse(h.newUserDataResponse(user))
 }
 
@@ -146,6 +185,12 @@ func (h CoreHandlers) PatchPassword(req *http.Request, _ httprouter.Params, sess
 		return common.NewInternalServerErrorResponse()
 	}
 
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionUpdateUserPassword, session.Username)
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
 	return common.NewSuccessResponse()
 }
 
@@ -198,10 +243,16 @@ func (h CoreHandlers) PatchUserPassword(req *http.Request, params httprouter.Par
 		return common.NewInternalServerErrorResponse()
 	}
 
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionUpdateUserPassword, username)
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
 	return common.NewSuccessResponse()
 }
 
-func (h CoreHandlers) DeleteUser(_ *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+func (h CoreHandlers) DeleteUser(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
 	//get the username
 	username := params.ByName("username")
 	if username == "" {
@@ -229,14 +280,21 @@ func (h CoreHandlers) DeleteUser(_ *http.Request, params httprouter.Params, sess
 		return common.NewInternalServerErrorResponse()
 	}
 
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionDeleteUser, username)
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
 	return common.NewSuccessResponse()
 }
 
 func (CoreHandlers) newUserDataResponse(user *models.User) UserDataResponse {
 	return UserDataResponse{
-		Username: user.Username,
-		PutUserBody: PutUserBody{
-			Rank: user.Rank,
-		},
+		Username:    user.Username,
+		Rank:        user.Rank,
+		Email:       user.Email,
+		DisplayName: user.DisplayName,
+		Enabled:     user.Enabled,
 	}
 }
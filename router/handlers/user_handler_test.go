@@ -17,15 +17,46 @@ type UserHandlerTestSuite struct {
 	HandlersTestSuite
 }
 
+func (suite *UserHandlerTestSuite) TestGetUsers_InvalidQueryTestCases() {
+	var query string
+	var expectedErrorSubStrings []string
+
+	testCase := func() {
+		//arrange
+		req := suite.CreateRequest(http.MethodGet, "/users?"+query, "", nil)
+
+		//act
+		status, res := suite.CoreHandlers.GetUsers(req, nil, models.CreateNewSession("admin", 5), &suite.CRUDMock)
+
+		//assert
+		suite.Require().Equal(http.StatusBadRequest, status)
+		suite.ErrorResponse(res, expectedErrorSubStrings...)
+		suite.ControllersMock.AssertNotCalled(suite.T(), "GetUsersWithLesserRank", mock.Anything, mock.Anything, mock.Anything)
+	}
+
+	query = "order=up"
+	expectedErrorSubStrings = []string{"order", "asc", "desc"}
+	suite.Run("InvalidOrder", testCase)
+
+	query = "cursor=not-a-cursor"
+	expectedErrorSubStrings = []string{"cursor", "invalid"}
+	suite.Run("InvalidCursor", testCase)
+
+	query = "limit=ten"
+	expectedErrorSubStrings = []string{"limit", "integer"}
+	suite.Run("InvalidLimit", testCase)
+}
+
 func (suite *UserHandlerTestSuite) TestGetUsers_WithClientErrorGettingUsers_ReturnsBadRequest() {
 	//arrange
+	req := suite.CreateRequest(http.MethodGet, "/users", "", nil)
 	session := models.CreateNewSession("admin", 5)
 
 	message := "get users error"
-	suite.ControllersMock.On("GetUsersWithLesserRank", mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
+	suite.ControllersMock.On("GetUsersWithLesserRank", mock.Anything, mock.Anything, mock.Anything).Return(nil, models.PageInfo{}, common.ClientError(message))
 
 	//act
-	status, res := suite.CoreHandlers.GetUsers(nil, nil, session, &suite.CRUDMock)
+	status, res := suite.CoreHandlers.GetUsers(req, nil, session, &suite.CRUDMock)
 
 	//assert
 	suite.Require().Equal(http.StatusBadRequest, status)
@@ -34,11 +65,12 @@ func (suite *UserHandlerTestSuite) TestGetUsers_WithClientErrorGettingUsers_Retu
 
 func (suite *UserHandlerTestSuite) TestGetUsers_WithInternalErrorGettingUsers_ReturnsInternalServerError() {
 	//arrange
+	req := suite.CreateRequest(http.MethodGet, "/users", "", nil)
 	session := models.CreateNewSession("admin", 5)
-	suite.ControllersMock.On("GetUsersWithLesserRank", mock.Anything, mock.Anything).Return(nil, common.InternalError())
+	suite.ControllersMock.On("GetUsersWithLesserRank", mock.Anything, mock.Anything, mock.Anything).Return(nil, models.PageInfo{}, common.InternalError())
 
 	//act
-	status, res := suite.CoreHandlers.GetUsers(nil, nil, session, &suite.CRUDMock)
+	status, res := suite.CoreHandlers.GetUsers(req, nil, session, &suite.CRUDMock)
 
 	//assert
 	suite.Require().Equal(http.StatusInternalServerError, status)
@@ -47,35 +79,45 @@ func (suite *UserHandlerTestSuite) TestGetUsers_WithInternalErrorGettingUsers_Re
 
 func (suite *UserHandlerTestSuite) TestGetUsers_WithNoErrors_ReturnsUserData() {
 	//arrange
+	cursor := models.Cursor{Value: "0", Key: "user0"}
+	req := suite.CreateRequest(http.MethodGet, "/users?search=user&sort=rank&order=desc&limit=2&cursor="+cursor.Encode(), "", nil)
 	session := models.CreateNewSession("admin", 5)
 
 	users := []*models.User{
 		models.CreateUser("user1", 0, nil),
 		models.CreateUser("user2", 1, nil),
 	}
-	suite.ControllersMock.On("GetUsersWithLesserRank", mock.Anything, mock.Anything).Return(users, common.NoError())
+	page := models.PageInfo{
+		NextCursor: &models.Cursor{Value: "1", Key: "user2"},
+		Total:      10,
+	}
+	suite.ControllersMock.On("GetUsersWithLesserRank", mock.Anything, mock.Anything, mock.Anything).Return(users, page, common.NoError())
 
 	//act
-	status, res := suite.CoreHandlers.GetUsers(nil, nil, session, &suite.CRUDMock)
+	status, res := suite.CoreHandlers.GetUsers(req, nil, session, &suite.CRUDMock)
 
 	//assert
 	suite.Require().Equal(http.StatusOK, status)
-	suite.SuccessDataResponse(res, []handlers.UserDataResponse{
+	suite.SuccessPageResponse(res, []handlers.UserDataResponse{
 		{
 			Username: users[0].Username,
-			PutUserBody: handlers.PutUserBody{
-				Rank: users[0].Rank,
-			},
+			Rank:     users[0].Rank,
+			Enabled:  users[0].Enabled,
 		},
 		{
 			Username: users[1].Username,
-			PutUserBody: handlers.PutUserBody{
-				Rank: users[1].Rank,
-			},
+			Rank:     users[1].Rank,
+			Enabled:  users[1].Enabled,
 		},
+	}, page.NextCursor.Encode(), page.Total)
+
+	suite.ControllersMock.AssertCalled(suite.T(), "GetUsersWithLesserRank", &suite.CRUDMock, session.Rank, models.PageQuery{
+		Search:         "user",
+		SortBy:         models.UserSortRank,
+		SortDescending: true,
+		After:          &cursor,
+		Limit:          2,
 	})
-
-	suite.ControllersMock.AssertCalled(suite.T(), "GetUsersWithLesserRank", &suite.CRUDMock, session.Rank)
 }
 
 func (suite *UserHandlerTestSuite) TestPostUser_WithInvalidJSONBody_ReturnsBadRequest() {
@@ -97,7 +139,9 @@ func (suite *UserHandlerTestSuite) TestPostUser_WithSessionRankLessThanUser_Retu
 	body := handlers.PostUserBody{
 		Username: "username",
 		Password: "password",
-		Rank:     10,
+		PutUserBody: handlers.PutUserBody{
+			Rank: 10,
+		},
 	}
 	req := suite.CreateDummyJSONRequest(body)
 
@@ -116,12 +160,14 @@ func (suite *UserHandlerTestSuite) TestPostUser_WithClientErrorCreatingUser_Retu
 	body := handlers.PostUserBody{
 		Username: "username",
 		Password: "password",
-		Rank:     0,
+		PutUserBody: handlers.PutUserBody{
+			Rank: 0,
+		},
 	}
 	req := suite.CreateDummyJSONRequest(body)
 
 	message := "create user error"
-	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
 
 	//act
 	status, res := suite.CoreHandlers.PostUser(req, nil, session, &suite.CRUDMock)
@@ -138,11 +184,13 @@ func (suite *UserHandlerTestSuite) TestPostUser_WithInternalErrorCreatingUser_Re
 	body := handlers.PostUserBody{
 		Username: "username",
 		Password: "password",
-		Rank:     0,
+		PutUserBody: handlers.PutUserBody{
+			Rank: 0,
+		},
 	}
 	req := suite.CreateDummyJSONRequest(body)
 
-	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, common.InternalError())
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, common.InternalError())
 
 	//act
 	status, res := suite.CoreHandlers.PostUser(req, nil, session, &suite.CRUDMock)
@@ -155,16 +203,24 @@ func (suite *UserHandlerTestSuite) TestPostUser_WithInternalErrorCreatingUser_Re
 func (suite *UserHandlerTestSuite) TestPostUser_WithNoErrors_ReturnsUserData() {
 	//arrange
 	session := models.CreateNewSession("admin", 5)
+	email := "user@example.com"
+	displayName := "Display Name"
 
 	body := handlers.PostUserBody{
 		Username: "username",
 		Password: "password",
-		Rank:     0,
+		PutUserBody: handlers.PutUserBody{
+			Rank:        0,
+			Email:       &email,
+			DisplayName: &displayName,
+		},
 	}
 	req := suite.CreateDummyJSONRequest(body)
 
 	user := models.CreateUser(body.Username, body.Rank, nil)
-	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(user, common.NoError())
+	user.Email = email
+	user.DisplayName = displayName
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(user, common.NoError())
 
 	//act
 	status, res := suite.CoreHandlers.PostUser(req, nil, session, &suite.CRUDMock)
@@ -172,13 +228,20 @@ func (suite *UserHandlerTestSuite) TestPostUser_WithNoErrors_ReturnsUserData() {
 	//assert
 	suite.Require().Equal(http.StatusOK, status)
 	suite.SuccessDataResponse(res, handlers.UserDataResponse{
-		Username: user.Username,
-		PutUserBody: handlers.PutUserBody{
-			Rank: user.Rank,
-		},
+		Username:    user.Username,
+		Rank:        user.Rank,
+		Email:       user.Email,
+		DisplayName: user.DisplayName,
+		Enabled:     user.Enabled,
 	})
 
-	suite.ControllersMock.AssertCalled(suite.T(), "CreateUser", &suite.CRUDMock, body.Username, body.Password, body.Rank)
+	//enabled defaults to true when not provided
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateUser", &suite.CRUDMock, body.Username, body.Password, body.Rank, models.UserProfile{
+		Email:       email,
+		DisplayName: displayName,
+		Enabled:     true,
+	})
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionCreateUser, body.Username)
 }
 
 func (suite *UserHandlerTestSuite) TestPutUser_WithMissingUsername_ReturnsBadRequest() {
@@ -330,7 +393,7 @@ func (suite *UserHandlerTestSuite) TestPutUser_WithClientErrorCreatingUser_Retur
 	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
 
 	message := "update user error"
-	suite.ControllersMock.On("UpdateUser", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
+	suite.ControllersMock.On("UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
 
 	//act
 	status, res := suite.CoreHandlers.PutUser(req, params, session, &suite.CRUDMock)
@@ -356,7 +419,7 @@ func (suite *UserHandlerTestSuite) TestPutUser_WithInternalErrorCreatingUser_Ret
 	req := suite.CreateDummyJSONRequest(body)
 
 	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
-	suite.ControllersMock.On("UpdateUser", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.InternalError())
+	suite.ControllersMock.On("UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, common.InternalError())
 
 	//act
 	status, res := suite.CoreHandlers.PutUser(req, params, session, &suite.CRUDMock)
@@ -376,14 +439,24 @@ func (suite *UserHandlerTestSuite) TestPutUser_WithNoErrors_ReturnsUserData() {
 		},
 	}
 
+	email := "user@example.com"
+	enabled := false
 	body := handlers.PutUserBody{
-		Rank: 1,
+		Rank:    1,
+		Email:   &email,
+		Enabled: &enabled,
 	}
 	req := suite.CreateDummyJSONRequest(body)
+
 	user := models.CreateUser(params[0].Value, body.Rank, nil)
+	user.UserProfile = models.UserProfile{
+		Email:       email,
+		DisplayName: "Display Name",
+		Enabled:     enabled,
+	}
 
 	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
-	suite.ControllersMock.On("UpdateUser", mock.Anything, mock.Anything, mock.Anything).Return(user, common.NoError())
+	suite.ControllersMock.On("UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(user, common.NoError())
 
 	//act
 	status, res := suite.CoreHandlers.PutUser(req, params, session, &suite.CRUDMock)
@@ -391,14 +464,21 @@ func (suite *UserHandlerTestSuite) TestPutUser_WithNoErrors_ReturnsUserData() {
 	//assert
 	suite.Require().Equal(http.StatusOK, status)
 	suite.SuccessDataResponse(res, handlers.UserDataResponse{
-		Username: user.Username,
-		PutUserBody: handlers.PutUserBody{
-			Rank: user.Rank,
-		},
+		Username:    user.Username,
+		Rank:        user.Rank,
+		Email:       user.Email,
+		DisplayName: user.DisplayName,
+		Enabled:     user.Enabled,
 	})
 
 	suite.ControllersMock.AssertCalled(suite.T(), "VerifyUserRank", &suite.CRUDMock, params[0].Value, session.Rank)
-	suite.ControllersMock.AssertCalled(suite.T(), "UpdateUser", &suite.CRUDMock, user.Username, user.Rank)
+
+	//omitted profile fields are left unset so they keep their current values
+	suite.ControllersMock.AssertCalled(suite.T(), "UpdateUser", &suite.CRUDMock, user.Username, user.Rank, models.UserProfileUpdate{
+		Email:   &email,
+		Enabled: &enabled,
+	})
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionUpdateUser, params[0].Value)
 }
 
 func (suite *UserHandlerTestSuite) TestUpdatePassword_WithInvalidJSONBody_ReturnsBadRequest() {
@@ -522,6 +602,7 @@ func (suite *UserHandlerTestSuite) TestUpdatePassword_WithNoErrors_ReturnsSucces
 
 	suite.ControllersMock.AssertCalled(suite.T(), "UpdateUserPasswordWithAuth", &suite.CRUDMock, session.Username, body.OldPassword, body.NewPassword)
 	suite.ControllersMock.AssertCalled(suite.T(), "DeleteAllOtherUserSessions", &suite.CRUDMock, session.Username, session.Token)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionUpdateUserPassword, session.Username)
 }
 
 func (suite *UserHandlerTestSuite) TestUpdateUserPassword_WithMissingUsername_ReturnsBadRequest() {
@@ -770,6 +851,7 @@ func (suite *UserHandlerTestSuite) TestUpdateUserPassword_WithNoErrors_ReturnsSu
 	suite.ControllersMock.AssertCalled(suite.T(), "VerifyUserRank", &suite.CRUDMock, params[0].Value, session.Rank)
 	suite.ControllersMock.AssertCalled(suite.T(), "UpdateUserPassword", &suite.CRUDMock, params[0].Value, body.Password)
 	suite.ControllersMock.AssertCalled(suite.T(), "DeleteAllUserSessions", &suite.CRUDMock, params[0].Value)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionUpdateUserPassword, params[0].Value)
 }
 
 func (suite *UserHandlerTestSuite) TestDeleteUser_WithMissingUsername_ReturnsBadRequest() {
@@ -891,8 +973,33 @@ func (suite *UserHandlerTestSuite) TestDeleteUser_WithInternalErrorDeletingUser_
 	suite.InternalServerErrorResponse(res)
 }
 
+func (suite *UserHandlerTestSuite) TestDeleteUser_WithErrorCreatingAuditEvent_ReturnsInternalServerError() {
+	//arrange
+	suite.FailAuditEvents()
+
+	req := suite.CreateDummyJSONRequest(nil)
+	session := models.CreateNewSession("admin", 5)
+	params := []httprouter.Param{
+		{
+			Key:   "username",
+			Value: "username",
+		},
+	}
+
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(true, common.NoError())
+	suite.ControllersMock.On("DeleteUser", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.DeleteUser(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusInternalServerError, status)
+	suite.InternalServerErrorResponse(res)
+}
+
 func (suite *UserHandlerTestSuite) TestDeleteUser_WithNoErrors_ReturnsSuccess() {
 	//arrange
+	req := suite.CreateDummyJSONRequest(nil)
 	session := models.CreateNewSession("admin", 5)
 	params := []httprouter.Param{
 		{
@@ -905,7 +1012,7 @@ func (suite *UserHandlerTestSuite) TestDeleteUser_WithNoErrors_ReturnsSuccess()
 	suite.ControllersMock.On("DeleteUser", mock.Anything, mock.Anything).Return(common.NoError())
 
 	//act
-	status, res := suite.CoreHandlers.DeleteUser(nil, params, session, &suite.CRUDMock)
+	status, res := suite.CoreHandlers.DeleteUser(req, params, session, &suite.CRUDMock)
 
 	//assert
 	suite.Require().Equal(http.StatusOK, status)
@@ -913,6 +1020,7 @@ func (suite *UserHandlerTestSuite) TestDeleteUser_WithNoErrors_ReturnsSuccess()
 
 	suite.ControllersMock.AssertCalled(suite.T(), "VerifyUserRank", &suite.CRUDMock, params[0].Value, session.Rank)
 	suite.ControllersMock.AssertCalled(suite.T(), "DeleteUser", &suite.CRUDMock, params[0].Value)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionDeleteUser, params[0].Value)
 }
 
 func TestUserHandlerTestSuite(t *testing.T) {
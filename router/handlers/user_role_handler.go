@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"log"
+	"math"
 	"net/http"
 
 	"github.com/mhogar/amber/common"
@@ -16,7 +17,7 @@ type UserRoleDataResponse struct {
 	PostUserRoleBody
 }
 
-func (h CoreHandlers) GetUserRoles(_ *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+func (h CoreHandlers) GetUserRoles(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
 	//parse the client id
 	clientID, err := uuid.Parse(params.ByName("id"))
 	if err != nil {
@@ -24,8 +25,20 @@ func (h CoreHandlers) GetUserRoles(_ *http.Request, params httprouter.Params, se
 		return common.NewBadRequestResponse("client id is in an invalid format")
 	}
 
+	//parse the page query
+	query, err := parsePageQuery(req.URL.Query())
+	if err != nil {
+		return common.NewBadRequestResponse(err.Error())
+	}
+
+	//client admins can see every user-role of their client (ranks are stored as small ints, so every rank is less than the max)
+	rank := session.Rank
+	if session.IsClientAdmin(clientID) {
+		rank = math.MaxInt16
+	}
+
 	//get the roles
-	roles, cerr := h.Controllers.GetUserRolesWithLesserRankByClientUID(CRUD, clientID, session.Rank)
+	roles, page, cerr := h.Controllers.GetUserRolesWithLesserRankByClientUID(CRUD, clientID, rank, query)
 	if cerr.Type == common.ErrorTypeClient {
 		return common.NewBadRequestResponse(cerr.Error())
 	}
@@ -38,12 +51,12 @@ func (h CoreHandlers) GetUserRoles(_ *http.Request, params httprouter.Params, se
 	for index, role := range roles {
 		data[index] = h.newUserRoleDataResponse(role)
 	}
-	return common.NewSuccessDataResponse(data)
+	return newPageResponse(data, page)
 }
 
 type PostUserRoleBody struct {
-	Username string `json:"username"`
-	Role     string `json:"role"`
+	Username string   `json:"username"`
+	Roles    []string `json:"roles"`
 }
 
 func (h CoreHandlers) PostUserRole(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
@@ -63,8 +76,8 @@ func (h CoreHandlers) PostUserRole(req *http.Request, params httprouter.Params,
 		return common.NewBadRequestResponse("invalid json body")
 	}
 
-	//verify the session has a greater rank than the user
-	res, cerr := h.Controllers.VerifyUserRank(CRUD, body.Username, session.Rank)
+	//verify the session can manage the user's user-role
+	res, cerr := h.verifyCanManageUserRole(CRUD, session, clientID, body.Username)
 	if cerr.Type == common.ErrorTypeClient {
 		return common.NewBadRequestResponse(cerr.Error())
 	}
@@ -76,7 +89,7 @@ func (h CoreHandlers) PostUserRole(req *http.Request, params httprouter.Params,
 	}
 
 	//create the model
-	role := models.CreateUserRole(clientID, body.Username, body.Role)
+	role := models.CreateUserRole(clientID, body.Username, body.Roles...)
 
 	//create the user-role
 	cerr = h.Controllers.CreateUserRole(CRUD, role)
@@ -87,11 +100,17 @@ func (h CoreHandlers) PostUserRole(req *http.Request, params httprouter.Params,
 		return common.NewInternalServerErrorResponse()
 	}
 
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionCreateUserRole, userRoleAuditTarget(role.ClientUID, role.Username))
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
 	return common.NewSuccessDataResponse(h.newUserRoleDataResponse(role))
 }
 
 type PutUserRoleBody struct {
-	Role string `json:"role"`
+	Roles []string `json:"roles"`
 }
 
 func (h CoreHandlers) PutUserRole(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
@@ -117,8 +136,8 @@ func (h CoreHandlers) PutUserRole(req *http.Request, params httprouter.Params, s
 		return common.NewBadRequestResponse("invalid json body")
 	}
 
-	//verify the session has a greater rank than the user
-	res, cerr := h.Controllers.VerifyUserRank(CRUD, username, session.Rank)
+	//verify the session can manage the user's user-role
+	res, cerr := h.verifyCanManageUserRole(CRUD, session, clientID, username)
 	if cerr.Type == common.ErrorTypeClient {
 		return common.NewBadRequestResponse(cerr.Error())
 	}
@@ -130,7 +149,7 @@ func (h CoreHandlers) PutUserRole(req *http.Request, params httprouter.Params, s
 	}
 
 	//create the model
-	role := models.CreateUserRole(clientID, username, body.Role)
+	role := models.CreateUserRole(clientID, username, body.Roles...)
 
 	//update the user-role
 	cerr = h.Controllers.UpdateUserRole(CRUD, role)
@@ -141,10 +160,16 @@ func (h CoreHandlers) PutUserRole(req *http.Request, params httprouter.Params, s
 		return common.NewInternalServerErrorResponse()
 	}
 
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionUpdateUserRole, userRoleAuditTarget(role.ClientUID, role.Username))
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
 	return common.NewSuccessDataResponse(h.newUserRoleDataResponse(role))
 }
 
-func (h CoreHandlers) DeleteUserRole(_ *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
+func (h CoreHandlers) DeleteUserRole(req *http.Request, params httprouter.Params, session *models.Session, CRUD data.DataCRUD) (int, interface{}) {
 	//parse the client id
 	clientID, err := uuid.Parse(params.ByName("id"))
 	if err != nil {
@@ -158,8 +183,8 @@ func (h CoreHandlers) DeleteUserRole(_ *http.Request, params httprouter.Params,
 		return common.NewBadRequestResponse("username not provided")
 	}
 
-	//verify the session has a greater rank than the user
-	res, cerr := h.Controllers.VerifyUserRank(CRUD, username, session.Rank)
+	//verify the session can manage the user's user-role
+	res, cerr := h.verifyCanManageUserRole(CRUD, session, clientID, username)
 	if cerr.Type == common.ErrorTypeClient {
 		return common.NewBadRequestResponse(cerr.Error())
 	}
@@ -179,14 +204,36 @@ func (h CoreHandlers) DeleteUserRole(_ *http.Request, params httprouter.Params,
 		return common.NewInternalServerErrorResponse()
 	}
 
+	//audit the action
+	cerr = h.auditEvent(req, session, CRUD, models.AuditActionDeleteUserRole, userRoleAuditTarget(clientID, username))
+	if cerr.Type != common.ErrorTypeNone {
+		return common.NewInternalServerErrorResponse()
+	}
+
 	return common.NewSuccessResponse()
 }
 
+// verifyCanManageUserRole verifies the session can manage the user-role of the user with the given username for the client with the given id.
+// Client admins can manage every user-role of their client, otherwise the session must have a greater rank than the user.
+// Returns the result and any errors.
+func (h CoreHandlers) verifyCanManageUserRole(CRUD data.DataCRUD, session *models.Session, clientID uuid.UUID, username string) (bool, common.CustomError) {
+	if session.IsClientAdmin(clientID) {
+		return true, common.NoError()
+	}
+
+	return h.Controllers.VerifyUserRank(CRUD, username, session.Rank)
+}
+
+// userRoleAuditTarget creates the audit event target for the user-role with the given client id and username.
+func userRoleAuditTarget(clientID uuid.UUID, username string) string {
+	return clientID.String() + "/" + username
+}
+
 func (CoreHandlers) newUserRoleDataResponse(role *models.UserRole) UserRoleDataResponse {
 	return UserRoleDataResponse{
 		PostUserRoleBody: PostUserRoleBody{
 			Username: role.Username,
-			Role:     role.Role,
+			Roles:    role.Roles,
 		},
 	}
 }
@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"math"
 	"net/http"
 	"testing"
 
@@ -44,12 +45,13 @@ func (suite *UserRoleHandlerTestSuite) TestGetUserRoles_WithClientErrorGettingUs
 		},
 	}
 	session := models.CreateNewSession("admin", 5)
+	req := suite.CreateRequest(http.MethodGet, "/client/"+params[0].Value+"/roles", "", nil)
 
 	message := "get user-roles error"
-	suite.ControllersMock.On("GetUserRolesWithLesserRankByClientUID", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
+	suite.ControllersMock.On("GetUserRolesWithLesserRankByClientUID", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, models.PageInfo{}, common.ClientError(message))
 
 	//act
-	status, res := suite.CoreHandlers.GetUserRoles(nil, params, session, &suite.CRUDMock)
+	status, res := suite.CoreHandlers.GetUserRoles(req, params, session, &suite.CRUDMock)
 
 	//assert
 	suite.Require().Equal(http.StatusBadRequest, status)
@@ -65,11 +67,12 @@ func (suite *UserRoleHandlerTestSuite) TestGetUserRoles_WithInternalErrorGetting
 		},
 	}
 	session := models.CreateNewSession("admin", 5)
+	req := suite.CreateRequest(http.MethodGet, "/client/"+params[0].Value+"/roles", "", nil)
 
-	suite.ControllersMock.On("GetUserRolesWithLesserRankByClientUID", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.InternalError())
+	suite.ControllersMock.On("GetUserRolesWithLesserRankByClientUID", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, models.PageInfo{}, common.InternalError())
 
 	//act
-	status, res := suite.CoreHandlers.GetUserRoles(nil, params, session, &suite.CRUDMock)
+	status, res := suite.CoreHandlers.GetUserRoles(req, params, session, &suite.CRUDMock)
 
 	//assert
 	suite.Require().Equal(http.StatusInternalServerError, status)
@@ -86,34 +89,59 @@ func (suite *UserRoleHandlerTestSuite) TestGetUserRoles_WithNoErrors_ReturnsUser
 		},
 	}
 	session := models.CreateNewSession("admin", 5)
+	req := suite.CreateRequest(http.MethodGet, "/client/"+params[0].Value+"/roles?sort=role", "", nil)
 
 	roles := []*models.UserRole{
 		models.CreateUserRole(clientUID, "user1", "role"),
 		models.CreateUserRole(clientUID, "user2", "role"),
 	}
-	suite.ControllersMock.On("GetUserRolesWithLesserRankByClientUID", mock.Anything, mock.Anything, mock.Anything).Return(roles, common.NoError())
+	page := models.PageInfo{Total: len(roles)}
+	suite.ControllersMock.On("GetUserRolesWithLesserRankByClientUID", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(roles, page, common.NoError())
 
 	//act
-	status, res := suite.CoreHandlers.GetUserRoles(nil, params, session, &suite.CRUDMock)
+	status, res := suite.CoreHandlers.GetUserRoles(req, params, session, &suite.CRUDMock)
 
 	//assert
 	suite.Require().Equal(http.StatusOK, status)
-	suite.SuccessDataResponse(res, []handlers.UserRoleDataResponse{
+	suite.SuccessPageResponse(res, []handlers.UserRoleDataResponse{
 		{
 			PostUserRoleBody: handlers.PostUserRoleBody{
 				Username: roles[0].Username,
-				Role:     roles[0].Role,
+				Roles:    roles[0].Roles,
 			},
 		},
 		{
 			PostUserRoleBody: handlers.PostUserRoleBody{
 				Username: roles[1].Username,
-				Role:     roles[1].Role,
+				Roles:    roles[1].Roles,
 			},
 		},
-	})
+	}, "", page.Total)
+
+	suite.ControllersMock.AssertCalled(suite.T(), "GetUserRolesWithLesserRankByClientUID", &suite.CRUDMock, clientUID, session.Rank, models.PageQuery{SortBy: models.UserRoleSortRole})
+}
+
+func (suite *UserRoleHandlerTestSuite) TestGetUserRoles_WhereSessionIsClientAdmin_GetsUserRolesOfEveryRank() {
+	//arrange
+	clientUID := uuid.New()
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: clientUID.String(),
+		},
+	}
+	session := models.CreateNewSession("admin", 0)
+	session.ClientAdminUIDs = []uuid.UUID{clientUID}
+	req := suite.CreateRequest(http.MethodGet, "/client/"+params[0].Value+"/roles", "", nil)
+
+	suite.ControllersMock.On("GetUserRolesWithLesserRankByClientUID", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*models.UserRole{}, models.PageInfo{}, common.NoError())
+
+	//act
+	status, _ := suite.CoreHandlers.GetUserRoles(req, params, session, &suite.CRUDMock)
 
-	suite.ControllersMock.AssertCalled(suite.T(), "GetUserRolesWithLesserRankByClientUID", &suite.CRUDMock, clientUID, session.Rank)
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.ControllersMock.AssertCalled(suite.T(), "GetUserRolesWithLesserRankByClientUID", &suite.CRUDMock, clientUID, math.MaxInt16, mock.Anything)
 }
 
 func (suite *UserRoleHandlerTestSuite) TestPostUserRole_WithInvalidClientID_ReturnsBadRequest() {
@@ -163,7 +191,7 @@ func (suite *UserRoleHandlerTestSuite) TestPostUserRole_WithClientErrorVerifying
 
 	body := handlers.PostUserRoleBody{
 		Username: "username",
-		Role:     "role",
+		Roles:    []string{"role"},
 	}
 	req := suite.CreateDummyJSONRequest(body)
 
@@ -190,7 +218,7 @@ func (suite *UserRoleHandlerTestSuite) TestPostUserRole_WithInternalErrorVerifyi
 
 	body := handlers.PostUserRoleBody{
 		Username: "username",
-		Role:     "role",
+		Roles:    []string{"role"},
 	}
 	req := suite.CreateDummyJSONRequest(body)
 
@@ -216,7 +244,7 @@ func (suite *UserRoleHandlerTestSuite) TestPostUserRole_WithFalseResultVerifying
 
 	body := handlers.PostUserRoleBody{
 		Username: "username",
-		Role:     "role",
+		Roles:    []string{"role"},
 	}
 	req := suite.CreateDummyJSONRequest(body)
 
@@ -242,7 +270,7 @@ func (suite *UserRoleHandlerTestSuite) TestPostUserRole_WithClientErrorCreatingU
 
 	body := handlers.PostUserRoleBody{
 		Username: "username",
-		Role:     "role",
+		Roles:    []string{"role"},
 	}
 	req := suite.CreateDummyJSONRequest(body)
 
@@ -271,7 +299,7 @@ func (suite *UserRoleHandlerTestSuite) TestPostUserRole_WithInternalErrorCreatin
 
 	body := handlers.PostUserRoleBody{
 		Username: "username",
-		Role:     "role",
+		Roles:    []string{"role"},
 	}
 	req := suite.CreateDummyJSONRequest(body)
 
@@ -298,7 +326,7 @@ func (suite *UserRoleHandlerTestSuite) TestPostUserRole_WithNoErrors_ReturnsUser
 
 	body := handlers.PostUserRoleBody{
 		Username: "username",
-		Role:     "role",
+		Roles:    []string{"role"},
 	}
 	req := suite.CreateDummyJSONRequest(body)
 
@@ -317,12 +345,68 @@ func (suite *UserRoleHandlerTestSuite) TestPostUserRole_WithNoErrors_ReturnsUser
 	suite.SuccessDataResponse(res, handlers.UserRoleDataResponse{
 		PostUserRoleBody: handlers.PostUserRoleBody{
 			Username: role.Username,
-			Role:     role.Role,
+			Roles:    role.Roles,
 		},
 	})
 
 	suite.ControllersMock.AssertCalled(suite.T(), "VerifyUserRank", &suite.CRUDMock, body.Username, session.Rank)
 	suite.ControllersMock.AssertCalled(suite.T(), "CreateUserRole", &suite.CRUDMock, role)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionCreateUserRole, role.ClientUID.String()+"/"+role.Username)
+}
+
+func (suite *UserRoleHandlerTestSuite) TestPostUserRole_WhereSessionIsClientAdmin_DoesNotVerifyUserRank() {
+	//arrange
+	clientUID := uuid.New()
+	session := models.CreateNewSession("admin", 0)
+	session.ClientAdminUIDs = []uuid.UUID{clientUID}
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: clientUID.String(),
+		},
+	}
+
+	body := handlers.PostUserRoleBody{
+		Username: "username",
+		Roles:    []string{"role"},
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	suite.ControllersMock.On("CreateUserRole", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, _ := suite.CoreHandlers.PostUserRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "VerifyUserRank", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *UserRoleHandlerTestSuite) TestPostUserRole_WhereSessionIsClientAdminOfAnotherClient_VerifiesUserRank() {
+	//arrange
+	session := models.CreateNewSession("admin", 0)
+	session.ClientAdminUIDs = []uuid.UUID{uuid.New()}
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: uuid.New().String(),
+		},
+	}
+
+	body := handlers.PostUserRoleBody{
+		Username: "username",
+		Roles:    []string{"role"},
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	suite.ControllersMock.On("VerifyUserRank", mock.Anything, mock.Anything, mock.Anything).Return(false, common.NoError())
+
+	//act
+	status, res := suite.CoreHandlers.PostUserRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusForbidden, status)
+	suite.InsufficientPermissionsErrorResponse(res)
 }
 
 func (suite *UserRoleHandlerTestSuite) TestPutUserRole_WithErrorParsingClientId_ReturnsBadRequest() {
@@ -398,7 +482,7 @@ func (suite *UserRoleHandlerTestSuite) TestPutUserRole_WithClientErrorVerifyingU
 	}
 
 	body := handlers.PutUserRoleBody{
-		Role: "role",
+		Roles: []string{"role"},
 	}
 	req := suite.CreateDummyJSONRequest(body)
 
@@ -429,7 +513,7 @@ func (suite *UserRoleHandlerTestSuite) TestPutUserRole_WithInternalErrorVerifyin
 	}
 
 	body := handlers.PutUserRoleBody{
-		Role: "role",
+		Roles: []string{"role"},
 	}
 	req := suite.CreateDummyJSONRequest(body)
 
@@ -458,7 +542,7 @@ func (suite *UserRoleHandlerTestSuite) TestPutUserRole_WithFalseResultVerifyingU
 	}
 
 	body := handlers.PutUserRoleBody{
-		Role: "role",
+		Roles: []string{"role"},
 	}
 	req := suite.CreateDummyJSONRequest(body)
 
@@ -487,7 +571,7 @@ func (suite *UserRoleHandlerTestSuite) TestPutUserRole_WithClientErrorUpdatingUs
 	}
 
 	body := handlers.PutUserRoleBody{
-		Role: "role",
+		Roles: []string{"role"},
 	}
 	req := suite.CreateDummyJSONRequest(body)
 
@@ -519,7 +603,7 @@ func (suite *UserRoleHandlerTestSuite) TestPutUserRole_WithInternalErrorUpdating
 	}
 
 	body := handlers.PutUserRoleBody{
-		Role: "role",
+		Roles: []string{"role"},
 	}
 	req := suite.CreateDummyJSONRequest(body)
 
@@ -549,7 +633,7 @@ func (suite *UserRoleHandlerTestSuite) TestPutUserRole_WithNoErrors_ReturnsUserD
 	}
 
 	body := handlers.PutUserRoleBody{
-		Role: "role",
+		Roles: []string{"role"},
 	}
 	req := suite.CreateDummyJSONRequest(body)
 
@@ -568,12 +652,44 @@ func (suite *UserRoleHandlerTestSuite) TestPutUserRole_WithNoErrors_ReturnsUserD
 	suite.SuccessDataResponse(res, handlers.UserRoleDataResponse{
 		PostUserRoleBody: handlers.PostUserRoleBody{
 			Username: role.Username,
-			Role:     role.Role,
+			Roles:    role.Roles,
 		},
 	})
 
 	suite.ControllersMock.AssertCalled(suite.T(), "VerifyUserRank", &suite.CRUDMock, params[1].Value, session.Rank)
 	suite.ControllersMock.AssertCalled(suite.T(), "UpdateUserRole", &suite.CRUDMock, role)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionUpdateUserRole, role.ClientUID.String()+"/"+role.Username)
+}
+
+func (suite *UserRoleHandlerTestSuite) TestPutUserRole_WhereSessionIsClientAdmin_DoesNotVerifyUserRank() {
+	//arrange
+	clientUID := uuid.New()
+	session := models.CreateNewSession("admin", 0)
+	session.ClientAdminUIDs = []uuid.UUID{clientUID}
+	params := []httprouter.Param{
+		{
+			Key:   "id",
+			Value: clientUID.String(),
+		},
+		{
+			Key:   "username",
+			Value: "username",
+		},
+	}
+
+	body := handlers.PutUserRoleBody{
+		Roles: []string{"role"},
+	}
+	req := suite.CreateDummyJSONRequest(body)
+
+	suite.ControllersMock.On("UpdateUserRole", mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, _ := suite.CoreHandlers.PutUserRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "VerifyUserRank", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func (suite *UserRoleHandlerTestSuite) TestDeleteUserRole_WithErrorParsingClientId_ReturnsBadRequest() {
@@ -740,6 +856,7 @@ func (suite *UserRoleHandlerTestSuite) TestDeleteUserRole_WithInternalErrorDelet
 
 func (suite *UserRoleHandlerTestSuite) TestDeleteUserRole_WithNoErrors_ReturnsSuccess() {
 	//arrange
+	req := suite.CreateDummyJSONRequest(nil)
 	session := models.CreateNewSession("admin", 5)
 	clientID := uuid.New()
 	params := []httprouter.Param{
@@ -757,7 +874,7 @@ func (suite *UserRoleHandlerTestSuite) TestDeleteUserRole_WithNoErrors_ReturnsSu
 	suite.ControllersMock.On("DeleteUserRole", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
 
 	//act
-	status, res := suite.CoreHandlers.DeleteUserRole(nil, params, session, &suite.CRUDMock)
+	status, res := suite.CoreHandlers.DeleteUserRole(req, params, session, &suite.CRUDMock)
 
 	//assert
 	suite.Require().Equal(http.StatusOK, status)
@@ -765,6 +882,34 @@ func (suite *UserRoleHandlerTestSuite) TestDeleteUserRole_WithNoErrors_ReturnsSu
 
 	suite.ControllersMock.AssertCalled(suite.T(), "VerifyUserRank", &suite.CRUDMock, params[0].Value, session.Rank)
 	suite.ControllersMock.AssertCalled(suite.T(), "DeleteUserRole", &suite.CRUDMock, clientID, params[0].Value)
+	suite.AssertAuditEventCreated(session.Username, models.AuditActionDeleteUserRole, clientID.String()+"/"+params[0].Value)
+}
+
+func (suite *UserRoleHandlerTestSuite) TestDeleteUserRole_WhereSessionIsClientAdmin_DoesNotVerifyUserRank() {
+	//arrange
+	req := suite.CreateDummyJSONRequest(nil)
+	clientUID := uuid.New()
+	session := models.CreateNewSession("admin", 0)
+	session.ClientAdminUIDs = []uuid.UUID{clientUID}
+	params := []httprouter.Param{
+		{
+			Key:   "username",
+			Value: "username",
+		},
+		{
+			Key:   "id",
+			Value: clientUID.String(),
+		},
+	}
+
+	suite.ControllersMock.On("DeleteUserRole", mock.Anything, mock.Anything, mock.Anything).Return(common.NoError())
+
+	//act
+	status, _ := suite.CoreHandlers.DeleteUserRole(req, params, session, &suite.CRUDMock)
+
+	//assert
+	suite.Require().Equal(http.StatusOK, status)
+	suite.ControllersMock.AssertNotCalled(suite.T(), "VerifyUserRank", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestUserRoleHandlerTestSuite(t *testing.T) {
@@ -5,6 +5,7 @@ import (
 	"html/template"
 	"log"
 	"net/http"
+	"strings"
 
 	"github.com/mhogar/amber/config"
 )
@@ -29,7 +30,7 @@ func (r CoreRenderer) RenderView(req *http.Request, data interface{}, templates
 	//create the data object
 	d := TemplateData{
 		AppName: config.GetAppName(),
-		BaseURL: "http://" + req.Host,
+		BaseURL: GetBaseURL(req),
 		Data:    data,
 	}
 
@@ -55,3 +56,51 @@ func (r CoreRenderer) RenderView(req *http.Request, data interface{}, templates
 
 	return buffer.Bytes()
 }
+
+// GetBaseURL gets the scheme and host the request was made to.
+// The X-Forwarded-Proto and X-Forwarded-Host headers are only used if the config trusts proxies, since clients can set them to anything.
+func GetBaseURL(req *http.Request) string {
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	host := req.Host
+
+	if proto := GetTrustedForwardedValue(req, "X-Forwarded-Proto"); proto != "" {
+		scheme = strings.ToLower(proto)
+	}
+	if forwardedHost := GetTrustedForwardedValue(req, "X-Forwarded-Host"); forwardedHost != "" {
+		host = forwardedHost
+	}
+
+	return scheme + "://" + host
+}
+
+// GetTrustedForwardedValue gets the value of the forwarded header added by the outermost of the config's trusted proxies.
+// Each proxy appends to the header, so only the last values were added by trusted proxies and anything before them could have been set by the client.
+// Returns an empty string if no proxies are trusted or the header is missing.
+func GetTrustedForwardedValue(req *http.Request, name string) string {
+	proxies := config.GetServerConfig().TrustedProxies
+	if proxies <= 0 {
+		return ""
+	}
+
+	var values []string
+	for _, header := range req.Header.Values(name) {
+		for _, value := range strings.Split(header, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	if len(values) == 0 {
+		return ""
+	}
+
+	//if there are fewer values than proxies, they were all added by trusted proxies
+	index := len(values) - proxies
+	if index < 0 {
+		index = 0
+	}
+	return values[index]
+}
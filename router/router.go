@@ -4,6 +4,7 @@ import (
 	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/mhogar/amber/common"
 	"github.com/mhogar/amber/config"
@@ -41,44 +42,126 @@ func (rf CoreRouterFactory) CreateRouter() *httprouter.Router {
 	r.ServeFiles("/public/*filepath", http.Dir(config.GetAppRoot("public")))
 
 	//home routes
-	r.GET("/", rf.createHandler(rf.Handlers.GetHome, ResponseTypeRaw, false, 0))
+	r.GET("/", rf.createHandler(rf.Handlers.GetHome, ResponseTypeRaw, false, ""))
 
 	//user routes
-	r.GET("/users", rf.createHandler(rf.Handlers.GetUsers, ResponseTypeJSON, true, 0))
-	r.POST("/user", rf.createHandler(rf.Handlers.PostUser, ResponseTypeJSON, true, 0))
-	r.PUT("/user/:username", rf.createHandler(rf.Handlers.PutUser, ResponseTypeJSON, true, 0))
-	r.PATCH("/user/password", rf.createHandler(rf.Handlers.PatchPassword, ResponseTypeJSON, true, 0))
-	r.PATCH("/user/password/:username", rf.createHandler(rf.Handlers.PatchUserPassword, ResponseTypeJSON, true, 0))
-	r.DELETE("/user/:username", rf.createHandler(rf.Handlers.DeleteUser, ResponseTypeJSON, true, 0))
+	r.GET("/users", rf.createHandler(rf.Handlers.GetUsers, ResponseTypeJSON, true, models.PermissionUsersRead))
+	r.POST("/user", rf.createHandler(rf.Handlers.PostUser, ResponseTypeJSON, true, models.PermissionUsersWrite))
+	r.PUT("/user/:username", rf.createHandler(rf.Handlers.PutUser, ResponseTypeJSON, true, models.PermissionUsersWrite))
+	r.PATCH("/user/password", rf.createHandler(rf.Handlers.PatchPassword, ResponseTypeJSON, true, ""))
+	r.PATCH("/user/password/:username", rf.createHandler(rf.Handlers.PatchUserPassword, ResponseTypeJSON, true, models.PermissionUsersWrite))
+	r.DELETE("/user/:username", rf.createHandler(rf.Handlers.DeleteUser, ResponseTypeJSON, true, models.PermissionUsersWrite))
 
-	minClientRank := config.GetPermissionConfig().MinClientRank
+	//two-factor routes (exempt so users required to use two-factor authentication can enroll)
+	r.POST("/user/totp", rf.createTwoFactorExemptHandler(rf.Handlers.PostUserTOTP))
+	r.POST("/user/totp/confirm", rf.createTwoFactorExemptHandler(rf.Handlers.PostUserTOTPConfirm))
+	r.POST("/user/totp/disable", rf.createHandler(rf.Handlers.PostUserTOTPDisable, ResponseTypeJSON, true, ""))
+
+	//admin role routes
+	r.GET("/admin/roles", rf.createHandler(rf.Handlers.GetAdminRoles, ResponseTypeJSON, true, models.PermissionAdminRolesRead))
+	r.POST("/admin/role", rf.createHandler(rf.Handlers.PostAdminRole, ResponseTypeJSON, true, models.PermissionAdminRolesWrite))
+	r.PUT("/admin/role/:name", rf.createHandler(rf.Handlers.PutAdminRole, ResponseTypeJSON, true, models.PermissionAdminRolesWrite))
+	r.DELETE("/admin/role/:name", rf.createHandler(rf.Handlers.DeleteAdminRole, ResponseTypeJSON, true, models.PermissionAdminRolesWrite))
+	r.GET("/user/:username/admin-roles", rf.createHandler(rf.Handlers.GetUserAdminRoles, ResponseTypeJSON, true, models.PermissionAdminRolesRead))
+	r.PUT("/user/:username/admin-roles", rf.createHandler(rf.Handlers.PutUserAdminRoles, ResponseTypeJSON, true, models.PermissionAdminRolesWrite))
 
 	//client routes
-	r.GET("/clients", rf.createHandler(rf.Handlers.GetClients, ResponseTypeJSON, true, minClientRank))
-	r.POST("/client", rf.createHandler(rf.Handlers.PostClient, ResponseTypeJSON, true, minClientRank))
-	r.PUT("/client/:id", rf.createHandler(rf.Handlers.PutClient, ResponseTypeJSON, true, minClientRank))
-	r.DELETE("/client/:id", rf.createHandler(rf.Handlers.DeleteClient, ResponseTypeJSON, true, minClientRank))
+	r.GET("/clients", rf.createHandler(rf.Handlers.GetClients, ResponseTypeJSON, true, models.PermissionClientsRead))
+	r.POST("/client", rf.createHandler(rf.Handlers.PostClient, ResponseTypeJSON, true, models.PermissionClientsWrite))
+	r.PUT("/client/:id", rf.createClientHandler(rf.Handlers.PutClient, models.PermissionClientsWrite))
+	r.DELETE("/client/:id", rf.createHandler(rf.Handlers.DeleteClient, ResponseTypeJSON, true, models.PermissionClientsWrite))
+	r.POST("/client/:id/secret", rf.createHandler(rf.Handlers.PostClientSecret, ResponseTypeJSON, true, models.PermissionClientsWrite))
+
+	//client admin routes
+	r.GET("/client/:id/admins", rf.createClientHandler(rf.Handlers.GetClientAdmins, models.PermissionClientAdminsRead))
+	r.POST("/client/:id/admin", rf.createClientHandler(rf.Handlers.PostClientAdmin, models.PermissionClientAdminsWrite))
+	r.DELETE("/client/:id/admin/:username", rf.createClientHandler(rf.Handlers.DeleteClientAdmin, models.PermissionClientAdminsWrite))
 
 	//user-role routes
-	r.GET("/client/:id/roles", rf.createHandler(rf.Handlers.GetUserRoles, ResponseTypeJSON, true, 0))
-	r.POST("/client/:id/role", rf.createHandler(rf.Handlers.PostUserRole, ResponseTypeJSON, true, 0))
-	r.PUT("/client/:id/role/:username", rf.createHandler(rf.Handlers.PutUserRole, ResponseTypeJSON, true, 0))
-	r.DELETE("/client/:id/role/:username", rf.createHandler(rf.Handlers.DeleteUserRole, ResponseTypeJSON, true, 0))
+	r.GET("/client/:id/roles", rf.createClientHandler(rf.Handlers.GetUserRoles, models.PermissionRolesRead))
+	r.POST("/client/:id/role", rf.createClientHandler(rf.Handlers.PostUserRole, models.PermissionRolesWrite))
+	r.PUT("/client/:id/role/:username", rf.createClientHandler(rf.Handlers.PutUserRole, models.PermissionRolesWrite))
+	r.DELETE("/client/:id/role/:username", rf.createClientHandler(rf.Handlers.DeleteUserRole, models.PermissionRolesWrite))
+	r.GET("/client/:id/role/:username/effective", rf.createClientHandler(rf.Handlers.GetUserRoleAssignments, models.PermissionRolesRead))
+
+	//group routes
+	r.GET("/groups", rf.createHandler(rf.Handlers.GetGroups, ResponseTypeJSON, true, models.PermissionGroupsRead))
+	r.POST("/group", rf.createHandler(rf.Handlers.PostGroup, ResponseTypeJSON, true, models.PermissionGroupsWrite))
+	r.PUT("/group/:name", rf.createHandler(rf.Handlers.PutGroup, ResponseTypeJSON, true, models.PermissionGroupsWrite))
+	r.DELETE("/group/:name", rf.createHandler(rf.Handlers.DeleteGroup, ResponseTypeJSON, true, models.PermissionGroupsWrite))
+	r.GET("/group/:name/members", rf.createHandler(rf.Handlers.GetGroupMembers, ResponseTypeJSON, true, models.PermissionGroupsRead))
+	r.POST("/group/:name/member", rf.createHandler(rf.Handlers.PostGroupMember, ResponseTypeJSON, true, models.PermissionGroupsWrite))
+	r.DELETE("/group/:name/member/:username", rf.createHandler(rf.Handlers.DeleteGroupMember, ResponseTypeJSON, true, models.PermissionGroupsWrite))
+
+	//group-role routes
+	r.GET("/client/:id/group-roles", rf.createClientHandler(rf.Handlers.GetGroupRoles, models.PermissionRolesRead))
+	r.POST("/client/:id/group-role", rf.createClientHandler(rf.Handlers.PostGroupRole, models.PermissionRolesWrite))
+	r.PUT("/client/:id/group-role/:group", rf.createClientHandler(rf.Handlers.PutGroupRole, models.PermissionRolesWrite))
+	r.DELETE("/client/:id/group-role/:group", rf.createClientHandler(rf.Handlers.DeleteGroupRole, models.PermissionRolesWrite))
+
+	//role definition routes
+	r.GET("/client/:id/roles/definitions", rf.createClientHandler(rf.Handlers.GetRoleDefinitions, models.PermissionRolesRead))
+	r.POST("/client/:id/roles/definitions", rf.createHandler(rf.Handlers.PostRoleDefinition, ResponseTypeJSON, true, models.PermissionClientsWrite))
+	r.PUT("/client/:id/roles/definitions/:name", rf.createHandler(rf.Handlers.PutRoleDefinition, ResponseTypeJSON, true, models.PermissionClientsWrite))
+	r.DELETE("/client/:id/roles/definitions/:name", rf.createHandler(rf.Handlers.DeleteRoleDefinition, ResponseTypeJSON, true, models.PermissionClientsWrite))
+
+	//invitation routes
+	r.POST("/invitation", rf.createHandler(rf.Handlers.PostInvitation, ResponseTypeJSON, true, models.PermissionInvitationsWrite))
+	r.DELETE("/invitation/:id", rf.createHandler(rf.Handlers.DeleteInvitation, ResponseTypeJSON, true, models.PermissionInvitationsWrite))
+	r.GET("/invitation/accept", rf.createHandler(rf.Handlers.GetAcceptInvitation, ResponseTypeRaw, false, ""))
+	r.POST("/invitation/accept", rf.createHandler(rf.Handlers.PostAcceptInvitation, ResponseTypeRaw, false, ""))
+
+	//lockout routes
+	r.GET("/lockouts", rf.createHandler(rf.Handlers.GetLockouts, ResponseTypeJSON, true, models.PermissionLockoutsRead))
+	r.DELETE("/lockout/:type/:key", rf.createHandler(rf.Handlers.DeleteLockout, ResponseTypeJSON, true, models.PermissionLockoutsWrite))
+
+	//audit routes
+	r.GET("/audit", rf.createHandler(rf.Handlers.GetAuditEvents, ResponseTypeJSON, true, models.PermissionAuditRead))
 
 	//session routes
-	r.POST("/session", rf.createHandler(rf.Handlers.PostSession, ResponseTypeJSON, false, 0))
-	r.DELETE("/session", rf.createHandler(rf.Handlers.DeleteSession, ResponseTypeJSON, true, 0))
+	r.POST("/session", rf.createHandler(rf.Handlers.PostSession, ResponseTypeJSON, false, ""))
+	r.DELETE("/session", rf.createTwoFactorExemptHandler(rf.Handlers.DeleteSession))
 
 	//token routes
-	r.GET("/token", rf.createHandler(rf.Handlers.GetToken, ResponseTypeRaw, false, 0))
-	r.POST("/token", rf.createHandler(rf.Handlers.PostToken, ResponseTypeRaw, false, 0))
+	r.GET("/token", rf.createHandler(rf.Handlers.GetToken, ResponseTypeRaw, false, ""))
+	r.POST("/token", rf.createHandler(rf.Handlers.PostToken, ResponseTypeRaw, false, ""))
+
+	//password reset routes
+	r.GET("/password/forgot", rf.createHandler(rf.Handlers.GetForgotPassword, ResponseTypeRaw, false, ""))
+	r.POST("/password/forgot", rf.createHandler(rf.Handlers.PostForgotPassword, ResponseTypeRaw, false, ""))
+	r.GET("/password/reset", rf.createHandler(rf.Handlers.GetResetPassword, ResponseTypeRaw, false, ""))
+	r.POST("/password/reset", rf.createHandler(rf.Handlers.PostResetPassword, ResponseTypeRaw, false, ""))
+
+	//oauth routes
+	r.GET("/authorize", rf.createHandler(rf.Handlers.GetAuthorize, ResponseTypeRaw, false, ""))
+	r.POST("/authorize", rf.createHandler(rf.Handlers.PostAuthorize, ResponseTypeRaw, false, ""))
+	r.POST("/oauth/token", rf.createHandler(rf.Handlers.PostOAuthToken, ResponseTypeJSON, false, ""))
+
+	//well-known routes
+	r.GET("/.well-known/jwks.json", rf.createHandler(rf.Handlers.GetJWKS, ResponseTypeJSON, false, ""))
+	r.GET("/.well-known/openid-configuration", rf.createHandler(rf.Handlers.GetOpenIDConfiguration, ResponseTypeJSON, false, ""))
 
 	return r
 }
 
 type handlerFunc func(*http.Request, httprouter.Params, *models.Session, data.DataCRUD) (int, interface{})
 
-func (rf CoreRouterFactory) createHandler(handler handlerFunc, responseType int, authenticateUser bool, minRank int) httprouter.Handle {
+// createHandler creates a handler for the route. If the user is authenticated, their session must have the permission to access the route, unless the permission is empty.
+func (rf CoreRouterFactory) createHandler(handler handlerFunc, responseType int, authenticateUser bool, permission string) httprouter.Handle {
+	return rf.newHandler(handler, responseType, authenticateUser, permission, false, true)
+}
+
+// createClientHandler creates an authenticated JSON handler for a route with a client id param. The permission may also be held scoped to that client.
+func (rf CoreRouterFactory) createClientHandler(handler handlerFunc, permission string) httprouter.Handle {
+	return rf.newHandler(handler, ResponseTypeJSON, true, permission, true, true)
+}
+
+// createTwoFactorExemptHandler creates an authenticated JSON handler that is accessible even if the user has not enabled two-factor authentication when their rank requires it.
+func (rf CoreRouterFactory) createTwoFactorExemptHandler(handler handlerFunc) httprouter.Handle {
+	return rf.newHandler(handler, ResponseTypeJSON, true, "", false, false)
+}
+
+func (rf CoreRouterFactory) newHandler(handler handlerFunc, responseType int, authenticateUser bool, permission string, clientScoped bool, enforceTwoFactor bool) httprouter.Handle {
 	return func(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
 		var session *models.Session
 		var cerr common.CustomError
@@ -96,17 +179,44 @@ func (rf CoreRouterFactory) createHandler(handler handlerFunc, responseType int,
 					return nil
 				}
 
-				//verify the user has at least the min rank required to access the route
-				if session.Rank < minRank {
+				//load the permissions granted by the user's admin roles and client admins
+				cerr = rf.loadPermissions(exec, session)
+				if cerr.Type != common.ErrorTypeNone {
+					sendInternalErrorResponse(w)
+					return nil
+				}
+
+				//verify the user has the permission required to access the route
+				if permission != "" && !session.HasPermission(permission, rf.getClientScope(params, clientScoped)) {
 					sendInsufficientPermissionsErrorResponse(w)
 					return nil
 				}
+
+				//verify the user has enabled two-factor authentication if their rank requires it
+				if enforceTwoFactor {
+					cerr = rf.verifyTwoFactor(exec, session)
+					if cerr.Type == common.ErrorTypeClient {
+						sendErrorResponse(w, http.StatusForbidden, cerr.Error())
+						return nil
+					}
+					if cerr.Type == common.ErrorTypeInternal {
+						sendInternalErrorResponse(w)
+						return nil
+					}
+				}
 			}
 
 			//handle route in transaction scope
 			return rf.ScopeFactory.CreateTransactionScope(exec, func(tx data.Transaction) (bool, error) {
 				status, data := handler(req, params, session, tx)
 
+				//only commit OK responses, unless the handler asks for its changes to be committed whatever the status (e.g. counting failed logins)
+				commit := status == http.StatusOK
+				if res, ok := data.(common.CommittedResponse); ok {
+					data = res.Data
+					commit = true
+				}
+
 				//handle special redirect case
 				if status == http.StatusSeeOther {
 					w.Header().Set("Location", data.(string))
@@ -121,7 +231,7 @@ func (rf CoreRouterFactory) createHandler(handler handlerFunc, responseType int,
 					sendRawResponse(w, status, data.([]byte))
 				}
 
-				return status == http.StatusOK, nil
+				return commit, nil
 			})
 		})
 
@@ -158,5 +268,88 @@ func (CoreRouterFactory) getSession(CRUD models.SessionCRUD, req *http.Request)
 		return nil, common.ClientError("bearer token invalid or expired")
 	}
 
+	cfg := config.GetSessionConfig()
+	now := time.Now().UTC()
+
+	//delete the session if it has expired
+	if session.IsExpired(now, time.Duration(cfg.Lifetime)*time.Second, time.Duration(cfg.IdleTimeout)*time.Second) {
+		_, err = CRUD.DeleteSession(token)
+		if err != nil {
+			log.Println(common.ChainError("error deleting expired session", err))
+			return nil, common.InternalError()
+		}
+
+		return nil, common.ClientError("bearer token invalid or expired")
+	}
+
+	//slide the idle timeout forward
+	_, err = CRUD.UpdateSessionLastUsedAt(token, now)
+	if err != nil {
+		log.Println(common.ChainError("error updating session last used at", err))
+		return nil, common.InternalError()
+	}
+	session.LastUsedAt = now
+
 	return session, common.NoError()
 }
+
+func (CoreRouterFactory) loadPermissions(CRUD data.DataCRUD, session *models.Session) common.CustomError {
+	roles, err := CRUD.GetAdminRolesByUsername(session.Username)
+	if err != nil {
+		log.Println(common.ChainError("error getting admin roles by username", err))
+		return common.InternalError()
+	}
+	session.Permissions = models.MergeAdminRolePermissions(roles)
+
+	//client admins also get the permissions for their clients
+	admins, err := CRUD.GetClientAdminsByUsername(session.Username)
+	if err != nil {
+		log.Println(common.ChainError("error getting client admins by username", err))
+		return common.InternalError()
+	}
+
+	session.ClientAdminUIDs = make([]uuid.UUID, len(admins))
+	for index, admin := range admins {
+		session.Permissions = append(session.Permissions, admin.Permissions()...)
+		session.ClientAdminUIDs[index] = admin.ClientUID
+	}
+
+	return common.NoError()
+}
+
+// getClientScope returns the client id the route's permission can be scoped to, or the nil uuid if there is none.
+func (CoreRouterFactory) getClientScope(params httprouter.Params, clientScoped bool) uuid.UUID {
+	if !clientScoped {
+		return uuid.Nil
+	}
+
+	//an invalid id can't match a scoped permission, and the handler reports the error
+	clientUID, err := uuid.Parse(params.ByName("id"))
+	if err != nil {
+		return uuid.Nil
+	}
+
+	return clientUID
+}
+
+func (CoreRouterFactory) verifyTwoFactor(CRUD models.UserCRUD, session *models.Session) common.CustomError {
+	//check if two-factor authentication is required for the session's rank
+	requiredRank := config.GetTwoFactorConfig().RequiredRank
+	if requiredRank <= 0 || session.Rank < requiredRank {
+		return common.NoError()
+	}
+
+	//get the user
+	user, err := CRUD.GetUserByUsername(session.Username)
+	if err != nil {
+		log.Println(common.ChainError("error getting user by username", err))
+		return common.InternalError()
+	}
+
+	//verify the user has enabled it
+	if user == nil || !user.TOTPEnabled {
+		return common.ClientError("two-factor authentication must be enabled to access this resource")
+	}
+
+	return common.NoError()
+}
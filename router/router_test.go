@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/mhogar/amber/common"
 	"github.com/mhogar/amber/config"
@@ -20,7 +21,8 @@ import (
 	"github.com/stretchr/testify/suite"
 )
 
-const MinClientRank = 5
+// ClientID is the client id used by the routes that check client-scoped permissions.
+const ClientID = "2d8fb5c8-7a33-4a51-8c9a-9b1d2a86e4f1"
 
 type RouterTestSuite struct {
 	helpers.ScopeFactorySuite
@@ -33,22 +35,27 @@ type RouterTestSuite struct {
 	Handler      string
 	ResponseType int
 
-	Session *models.Session
-	TokenId string
+	Session      *models.Session
+	TokenId      string
+	AdminRoles   []*models.AdminRole
+	ClientAdmins []*models.ClientAdmin
 }
 
 func (suite *RouterTestSuite) SetupSuite() {
-	viper.Set("permission", config.PermissionConfig{
-		MinClientRank: MinClientRank,
+	viper.Set("session", config.SessionConfig{
+		Lifetime:    100,
+		IdleTimeout: 10,
 	})
 }
 
 func (suite *RouterTestSuite) SetupTest() {
 	suite.ScopeFactorySuite.SetupTest()
+	viper.Set("two_factor", config.TwoFactorConfig{})
 	suite.HandlersMock = handlermocks.Handlers{}
 
 	suite.Session = nil
 	suite.TokenId = ""
+	suite.AdminRoles = nil
 
 	rf := router.CoreRouterFactory{
 		ScopeFactory: &suite.ScopeFactoryMock,
@@ -87,6 +94,9 @@ func (suite *RouterTestSuite) TestRoute_WithErrorFromTransactionScope_ReturnsErr
 		suite.Contains(err.Error(), message)
 	})
 	suite.DataExecutorMock.On("GetSessionByToken", mock.Anything).Return(suite.Session, nil)
+	suite.DataExecutorMock.On("UpdateSessionLastUsedAt", mock.Anything, mock.Anything).Return(true, nil)
+	suite.DataExecutorMock.On("GetAdminRolesByUsername", mock.Anything).Return(suite.AdminRoles, nil)
+	suite.DataExecutorMock.On("GetClientAdminsByUsername", mock.Anything).Return(suite.ClientAdmins, nil)
 	suite.SetupScopeFactoryMock_CreateTransactionScope(errors.New(message))
 
 	//act
@@ -100,6 +110,9 @@ func (suite *RouterTestSuite) TestRoute_WithNonOKStatusFromHandler_SendsResponse
 
 	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
 	suite.DataExecutorMock.On("GetSessionByToken", mock.Anything).Return(suite.Session, nil)
+	suite.DataExecutorMock.On("UpdateSessionLastUsedAt", mock.Anything, mock.Anything).Return(true, nil)
+	suite.DataExecutorMock.On("GetAdminRolesByUsername", mock.Anything).Return(suite.AdminRoles, nil)
+	suite.DataExecutorMock.On("GetClientAdminsByUsername", mock.Anything).Return(suite.ClientAdmins, nil)
 	suite.SetupScopeFactoryMock_CreateTransactionScope_WithCallback(nil, func(result bool, err error) {
 		suite.False(result)
 		suite.NoError(err)
@@ -130,12 +143,54 @@ func (suite *RouterTestSuite) TestRoute_WithNonOKStatusFromHandler_SendsResponse
 	suite.HandlersMock.AssertCalled(suite.T(), suite.Handler, mock.Anything, mock.Anything, mock.Anything, &suite.TransactionMock)
 }
 
+func (suite *RouterTestSuite) TestRoute_WithCommittedResponseFromHandler_SendsWrappedResponseAndReturnsSuccessToTransactionScope() {
+	//arrange
+	req := suite.CreateJSONRequest(suite.Method, suite.Server.URL+suite.Route, suite.TokenId, nil)
+
+	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
+	suite.DataExecutorMock.On("GetSessionByToken", mock.Anything).Return(suite.Session, nil)
+	suite.DataExecutorMock.On("UpdateSessionLastUsedAt", mock.Anything, mock.Anything).Return(true, nil)
+	suite.DataExecutorMock.On("GetAdminRolesByUsername", mock.Anything).Return(suite.AdminRoles, nil)
+	suite.DataExecutorMock.On("GetClientAdminsByUsername", mock.Anything).Return(suite.ClientAdmins, nil)
+	suite.SetupScopeFactoryMock_CreateTransactionScope_WithCallback(nil, func(result bool, err error) {
+		suite.True(result)
+		suite.NoError(err)
+	})
+
+	status := http.StatusBadRequest
+	message := "error response"
+
+	var body interface{}
+	if suite.ResponseType == router.ResponseTypeJSON {
+		body = common.NewErrorResponse(message)
+	} else {
+		body = []byte(message)
+	}
+
+	suite.HandlersMock.On(suite.Handler, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(common.NewCommittedResponse(status, body))
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	if suite.ResponseType == router.ResponseTypeJSON {
+		suite.ParseAndAssertErrorResponse(res, status, message)
+	} else {
+		suite.ReadAndAssertRawResponse(res, status, body.([]byte))
+	}
+	suite.HandlersMock.AssertCalled(suite.T(), suite.Handler, mock.Anything, mock.Anything, mock.Anything, &suite.TransactionMock)
+}
+
 func (suite *RouterTestSuite) TestRoute_WithRedirectStatusFromHandler_SendsRedirectResponseAndReturnsSuccessToTransactionScope() {
 	//arrange
 	req := suite.CreateJSONRequest(suite.Method, suite.Server.URL+suite.Route, suite.TokenId, nil)
 
 	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
 	suite.DataExecutorMock.On("GetSessionByToken", mock.Anything).Return(suite.Session, nil)
+	suite.DataExecutorMock.On("UpdateSessionLastUsedAt", mock.Anything, mock.Anything).Return(true, nil)
+	suite.DataExecutorMock.On("GetAdminRolesByUsername", mock.Anything).Return(suite.AdminRoles, nil)
+	suite.DataExecutorMock.On("GetClientAdminsByUsername", mock.Anything).Return(suite.ClientAdmins, nil)
 	suite.SetupScopeFactoryMock_CreateTransactionScope_WithCallback(nil, func(result bool, err error) {
 		suite.True(result)
 		suite.NoError(err)
@@ -160,6 +215,9 @@ func (suite *RouterTestSuite) TestRoute_WithOKStatusFromHandler_SendsResponseAnd
 
 	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
 	suite.DataExecutorMock.On("GetSessionByToken", mock.Anything).Return(suite.Session, nil)
+	suite.DataExecutorMock.On("UpdateSessionLastUsedAt", mock.Anything, mock.Anything).Return(true, nil)
+	suite.DataExecutorMock.On("GetAdminRolesByUsername", mock.Anything).Return(suite.AdminRoles, nil)
+	suite.DataExecutorMock.On("GetClientAdminsByUsername", mock.Anything).Return(suite.ClientAdmins, nil)
 	suite.SetupScopeFactoryMock_CreateTransactionScope_WithCallback(nil, func(result bool, err error) {
 		suite.True(result)
 		suite.NoError(err)
@@ -194,6 +252,9 @@ func (suite *RouterTestSuite) TestRoute_WhereHandlerPanics_ReturnsInternalServer
 
 	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
 	suite.DataExecutorMock.On("GetSessionByToken", mock.Anything).Return(suite.Session, nil)
+	suite.DataExecutorMock.On("UpdateSessionLastUsedAt", mock.Anything, mock.Anything).Return(true, nil)
+	suite.DataExecutorMock.On("GetAdminRolesByUsername", mock.Anything).Return(suite.AdminRoles, nil)
+	suite.DataExecutorMock.On("GetClientAdminsByUsername", mock.Anything).Return(suite.ClientAdmins, nil)
 	suite.SetupScopeFactoryMock_CreateTransactionScope(nil)
 
 	suite.HandlersMock.On(suite.Handler, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, nil).Run(func(_ mock.Arguments) {
@@ -210,15 +271,28 @@ func (suite *RouterTestSuite) TestRoute_WhereHandlerPanics_ReturnsInternalServer
 
 type RouterAuthTestSuite struct {
 	RouterTestSuite
-	MinRank int
+	Permission      string
+	ClientScoped    bool
+	TwoFactorExempt bool
 }
 
 func (suite *RouterAuthTestSuite) SetupTest() {
 	suite.RouterTestSuite.SetupTest()
 
 	token := uuid.New()
-	suite.Session = models.CreateSession(token, "username", suite.MinRank)
+	suite.Session = models.CreateSession(token, "username", 0)
 	suite.TokenId = token.String()
+
+	//give the session the permission required by the route
+	suite.AdminRoles = []*models.AdminRole{suite.createAdminRole(suite.Permission)}
+}
+
+// createAdminRole creates an admin role with the permission, or no permissions if it is empty.
+func (suite *RouterAuthTestSuite) createAdminRole(permission string) *models.AdminRole {
+	if permission == "" {
+		return models.CreateAdminRole("role", "")
+	}
+	return models.CreateAdminRole("role", "", permission)
 }
 
 func (suite *RouterAuthTestSuite) TestRoute_WithNoBearerToken_ReturnsUnauthorized() {
@@ -291,13 +365,138 @@ func (suite *RouterAuthTestSuite) TestRoute_WhereSessionWithIDisNotFound_Returns
 	suite.ParseAndAssertErrorResponse(res, http.StatusUnauthorized, "bearer token", "invalid", "expired")
 }
 
-func (suite *RouterAuthTestSuite) TestRoute_WithSessionRankLessThanMinRank_ReturnsForbidden() {
+func (suite *RouterAuthTestSuite) TestRoute_WhereSessionIsExpired_DeletesSessionAndReturnsUnauthorized() {
+	//arrange
+	req := suite.CreateJSONRequest(suite.Method, suite.Server.URL+suite.Route, suite.TokenId, nil)
+	suite.Session.LastUsedAt = suite.Session.LastUsedAt.Add(-time.Minute)
+
+	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
+	suite.DataExecutorMock.On("GetSessionByToken", mock.Anything).Return(suite.Session, nil)
+	suite.DataExecutorMock.On("DeleteSession", mock.Anything).Return(true, nil)
+	suite.SetupScopeFactoryMock_CreateTransactionScope(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ParseAndAssertErrorResponse(res, http.StatusUnauthorized, "bearer token", "invalid", "expired")
+	suite.DataExecutorMock.AssertCalled(suite.T(), "DeleteSession", suite.Session.Token)
+	suite.DataExecutorMock.AssertNotCalled(suite.T(), "UpdateSessionLastUsedAt", mock.Anything, mock.Anything)
+}
+
+func (suite *RouterAuthTestSuite) TestRoute_WithErrorDeletingExpiredSession_ReturnsInternalServerError() {
+	//arrange
+	req := suite.CreateJSONRequest(suite.Method, suite.Server.URL+suite.Route, suite.TokenId, nil)
+	suite.Session.CreatedAt = suite.Session.CreatedAt.Add(-time.Hour)
+
+	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
+	suite.DataExecutorMock.On("GetSessionByToken", mock.Anything).Return(suite.Session, nil)
+	suite.DataExecutorMock.On("DeleteSession", mock.Anything).Return(false, errors.New(""))
+	suite.SetupScopeFactoryMock_CreateTransactionScope(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ParseAndAssertInternalServerErrorResponse(res)
+}
+
+func (suite *RouterAuthTestSuite) TestRoute_WithErrorUpdatingSessionLastUsedAt_ReturnsInternalServerError() {
+	//arrange
+	req := suite.CreateJSONRequest(suite.Method, suite.Server.URL+suite.Route, suite.TokenId, nil)
+
+	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
+	suite.DataExecutorMock.On("GetSessionByToken", mock.Anything).Return(suite.Session, nil)
+	suite.DataExecutorMock.On("UpdateSessionLastUsedAt", mock.Anything, mock.Anything).Return(false, errors.New(""))
+	suite.SetupScopeFactoryMock_CreateTransactionScope(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ParseAndAssertInternalServerErrorResponse(res)
+}
+
+func (suite *RouterAuthTestSuite) TestRoute_WithErrorGettingAdminRoles_ReturnsInternalServerError() {
+	//arrange
+	req := suite.CreateJSONRequest(suite.Method, suite.Server.URL+suite.Route, suite.TokenId, nil)
+
+	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
+	suite.DataExecutorMock.On("GetSessionByToken", mock.Anything).Return(suite.Session, nil)
+	suite.DataExecutorMock.On("UpdateSessionLastUsedAt", mock.Anything, mock.Anything).Return(true, nil)
+	suite.DataExecutorMock.On("GetAdminRolesByUsername", mock.Anything).Return(nil, errors.New(""))
+	suite.SetupScopeFactoryMock_CreateTransactionScope(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ParseAndAssertInternalServerErrorResponse(res)
+	suite.DataExecutorMock.AssertCalled(suite.T(), "GetAdminRolesByUsername", suite.Session.Username)
+}
+
+func (suite *RouterAuthTestSuite) TestRoute_WithErrorGettingClientAdmins_ReturnsInternalServerError() {
+	//arrange
+	req := suite.CreateJSONRequest(suite.Method, suite.Server.URL+suite.Route, suite.TokenId, nil)
+
+	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
+	suite.DataExecutorMock.On("GetSessionByToken", mock.Anything).Return(suite.Session, nil)
+	suite.DataExecutorMock.On("UpdateSessionLastUsedAt", mock.Anything, mock.Anything).Return(true, nil)
+	suite.DataExecutorMock.On("GetAdminRolesByUsername", mock.Anything).Return(suite.AdminRoles, nil)
+	suite.DataExecutorMock.On("GetClientAdminsByUsername", mock.Anything).Return(nil, errors.New(""))
+	suite.SetupScopeFactoryMock_CreateTransactionScope(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ParseAndAssertInternalServerErrorResponse(res)
+	suite.DataExecutorMock.AssertCalled(suite.T(), "GetClientAdminsByUsername", suite.Session.Username)
+}
+
+func (suite *RouterAuthTestSuite) TestRoute_WhereSessionDoesNotHavePermission_ReturnsForbidden() {
+	if suite.Permission == "" {
+		suite.T().Skip("route does not require a permission")
+	}
+
+	//arrange
+	req := suite.CreateJSONRequest(suite.Method, suite.Server.URL+suite.Route, suite.TokenId, nil)
+
+	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
+	suite.DataExecutorMock.On("GetSessionByToken", mock.Anything).Return(suite.Session, nil)
+	suite.DataExecutorMock.On("UpdateSessionLastUsedAt", mock.Anything, mock.Anything).Return(true, nil)
+	suite.DataExecutorMock.On("GetAdminRolesByUsername", mock.Anything).Return([]*models.AdminRole{suite.createAdminRole("")}, nil)
+	suite.DataExecutorMock.On("GetClientAdminsByUsername", mock.Anything).Return(suite.ClientAdmins, nil)
+	suite.SetupScopeFactoryMock_CreateTransactionScope(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+	suite.HandlersMock.AssertNotCalled(suite.T(), suite.Handler, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *RouterAuthTestSuite) TestRoute_WherePermissionIsScopedToAnotherClient_ReturnsForbidden() {
+	if suite.Permission == "" {
+		suite.T().Skip("route does not require a permission")
+	}
+
 	//arrange
 	req := suite.CreateJSONRequest(suite.Method, suite.Server.URL+suite.Route, suite.TokenId, nil)
-	session := models.CreateNewSession("username", suite.MinRank-1)
+	role := suite.createAdminRole(models.ScopePermission(suite.Permission, uuid.New()))
 
 	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
-	suite.DataExecutorMock.On("GetSessionByToken", mock.Anything).Return(session, nil)
+	suite.DataExecutorMock.On("GetSessionByToken", mock.Anything).Return(suite.Session, nil)
+	suite.DataExecutorMock.On("UpdateSessionLastUsedAt", mock.Anything, mock.Anything).Return(true, nil)
+	suite.DataExecutorMock.On("GetAdminRolesByUsername", mock.Anything).Return([]*models.AdminRole{role}, nil)
+	suite.DataExecutorMock.On("GetClientAdminsByUsername", mock.Anything).Return(suite.ClientAdmins, nil)
 	suite.SetupScopeFactoryMock_CreateTransactionScope(nil)
 
 	//act
@@ -308,6 +507,159 @@ func (suite *RouterAuthTestSuite) TestRoute_WithSessionRankLessThanMinRank_Retur
 	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
 }
 
+func (suite *RouterAuthTestSuite) TestRoute_WherePermissionIsScopedToClient_CallsHandlerIfClientScoped() {
+	if suite.Permission == "" {
+		suite.T().Skip("route does not require a permission")
+	}
+
+	//arrange
+	req := suite.CreateJSONRequest(suite.Method, suite.Server.URL+suite.Route, suite.TokenId, nil)
+	role := suite.createAdminRole(models.ScopePermission(suite.Permission, uuid.MustParse(ClientID)))
+
+	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
+	suite.DataExecutorMock.On("GetSessionByToken", mock.Anything).Return(suite.Session, nil)
+	suite.DataExecutorMock.On("UpdateSessionLastUsedAt", mock.Anything, mock.Anything).Return(true, nil)
+	suite.DataExecutorMock.On("GetAdminRolesByUsername", mock.Anything).Return([]*models.AdminRole{role}, nil)
+	suite.DataExecutorMock.On("GetClientAdminsByUsername", mock.Anything).Return(suite.ClientAdmins, nil)
+	suite.SetupScopeFactoryMock_CreateTransactionScope(nil)
+
+	_, body := common.NewSuccessResponse()
+	suite.HandlersMock.On(suite.Handler, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(http.StatusOK, body)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	if suite.ClientScoped {
+		suite.ParseAndAssertOKSuccessResponse(res)
+	} else {
+		suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+	}
+}
+
+func (suite *RouterAuthTestSuite) TestRoute_WhereSessionIsClientAdmin_CallsHandlerIfClientScopedAndPermissionIsDelegated() {
+	if suite.Permission == "" {
+		suite.T().Skip("route does not require a permission")
+	}
+
+	//arrange
+	req := suite.CreateJSONRequest(suite.Method, suite.Server.URL+suite.Route, suite.TokenId, nil)
+	admin := models.CreateClientAdmin(uuid.MustParse(ClientID), suite.Session.Username, true)
+
+	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
+	suite.DataExecutorMock.On("GetSessionByToken", mock.Anything).Return(suite.Session, nil)
+	suite.DataExecutorMock.On("UpdateSessionLastUsedAt", mock.Anything, mock.Anything).Return(true, nil)
+	suite.DataExecutorMock.On("GetAdminRolesByUsername", mock.Anything).Return([]*models.AdminRole{}, nil)
+	suite.DataExecutorMock.On("GetClientAdminsByUsername", mock.Anything).Return([]*models.ClientAdmin{admin}, nil)
+	suite.SetupScopeFactoryMock_CreateTransactionScope(nil)
+
+	_, body := common.NewSuccessResponse()
+	suite.HandlersMock.On(suite.Handler, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(http.StatusOK, body)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	if suite.ClientScoped && models.HasPermission(admin.Permissions(), suite.Permission, admin.ClientUID) {
+		suite.ParseAndAssertOKSuccessResponse(res)
+	} else {
+		suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+	}
+}
+
+// requireTwoFactor requires two-factor authentication for the rank of the suite's session.
+func (suite *RouterAuthTestSuite) requireTwoFactor() {
+	suite.Session.Rank = 1
+	viper.Set("two_factor", config.TwoFactorConfig{
+		RequiredRank: suite.Session.Rank,
+	})
+}
+
+func (suite *RouterAuthTestSuite) TestRoute_WithErrorGettingUserToVerifyTwoFactor_ReturnsInternalServerError() {
+	if suite.TwoFactorExempt {
+		suite.T().Skip("route is exempt from two-factor enforcement")
+	}
+
+	//arrange
+	req := suite.CreateJSONRequest(suite.Method, suite.Server.URL+suite.Route, suite.TokenId, nil)
+	suite.requireTwoFactor()
+
+	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
+	suite.DataExecutorMock.On("GetSessionByToken", mock.Anything).Return(suite.Session, nil)
+	suite.DataExecutorMock.On("UpdateSessionLastUsedAt", mock.Anything, mock.Anything).Return(true, nil)
+	suite.DataExecutorMock.On("GetAdminRolesByUsername", mock.Anything).Return(suite.AdminRoles, nil)
+	suite.DataExecutorMock.On("GetClientAdminsByUsername", mock.Anything).Return(suite.ClientAdmins, nil)
+	suite.DataExecutorMock.On("GetUserByUsername", mock.Anything).Return(nil, errors.New(""))
+	suite.SetupScopeFactoryMock_CreateTransactionScope(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ParseAndAssertInternalServerErrorResponse(res)
+}
+
+func (suite *RouterAuthTestSuite) TestRoute_WhereTwoFactorIsRequiredButNotEnabled_ReturnsForbiddenUnlessExempt() {
+	//arrange
+	req := suite.CreateJSONRequest(suite.Method, suite.Server.URL+suite.Route, suite.TokenId, nil)
+	suite.requireTwoFactor()
+
+	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
+	suite.DataExecutorMock.On("GetSessionByToken", mock.Anything).Return(suite.Session, nil)
+	suite.DataExecutorMock.On("UpdateSessionLastUsedAt", mock.Anything, mock.Anything).Return(true, nil)
+	suite.DataExecutorMock.On("GetAdminRolesByUsername", mock.Anything).Return(suite.AdminRoles, nil)
+	suite.DataExecutorMock.On("GetClientAdminsByUsername", mock.Anything).Return(suite.ClientAdmins, nil)
+	suite.DataExecutorMock.On("GetUserByUsername", mock.Anything).Return(models.CreateUser(suite.Session.Username, suite.Session.Rank, nil), nil)
+	suite.SetupScopeFactoryMock_CreateTransactionScope(nil)
+
+	_, body := common.NewSuccessResponse()
+	suite.HandlersMock.On(suite.Handler, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(http.StatusOK, body)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	if suite.TwoFactorExempt {
+		suite.ParseAndAssertOKSuccessResponse(res)
+		suite.DataExecutorMock.AssertNotCalled(suite.T(), "GetUserByUsername", mock.Anything)
+	} else {
+		suite.ParseAndAssertErrorResponse(res, http.StatusForbidden, "two-factor authentication", "must be enabled")
+		suite.HandlersMock.AssertNotCalled(suite.T(), suite.Handler, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	}
+}
+
+func (suite *RouterAuthTestSuite) TestRoute_WhereTwoFactorIsRequiredAndEnabled_CallsHandler() {
+	//arrange
+	req := suite.CreateJSONRequest(suite.Method, suite.Server.URL+suite.Route, suite.TokenId, nil)
+	suite.requireTwoFactor()
+
+	user := models.CreateUser(suite.Session.Username, suite.Session.Rank, nil)
+	user.TOTPEnabled = true
+
+	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
+	suite.DataExecutorMock.On("GetSessionByToken", mock.Anything).Return(suite.Session, nil)
+	suite.DataExecutorMock.On("UpdateSessionLastUsedAt", mock.Anything, mock.Anything).Return(true, nil)
+	suite.DataExecutorMock.On("GetAdminRolesByUsername", mock.Anything).Return(suite.AdminRoles, nil)
+	suite.DataExecutorMock.On("GetClientAdminsByUsername", mock.Anything).Return(suite.ClientAdmins, nil)
+	suite.DataExecutorMock.On("GetUserByUsername", mock.Anything).Return(user, nil)
+	suite.SetupScopeFactoryMock_CreateTransactionScope(nil)
+
+	_, body := common.NewSuccessResponse()
+	suite.HandlersMock.On(suite.Handler, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(http.StatusOK, body)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ParseAndAssertOKSuccessResponse(res)
+	suite.HandlersMock.AssertCalled(suite.T(), suite.Handler, mock.Anything, mock.Anything, mock.Anything, &suite.TransactionMock)
+}
+
 func TestGetHomeTestSuite(t *testing.T) {
 	suite.Run(t, &RouterTestSuite{
 		Method:       "GET",
@@ -325,7 +677,7 @@ func TestGetUsersTestSuite(t *testing.T) {
 			Handler:      "GetUsers",
 			ResponseType: router.ResponseTypeJSON,
 		},
-		MinRank: 0,
+		Permission: models.PermissionUsersRead,
 	})
 }
 
@@ -337,7 +689,7 @@ func TestPostUserTestSuite(t *testing.T) {
 			Handler:      "PostUser",
 			ResponseType: router.ResponseTypeJSON,
 		},
-		MinRank: 0,
+		Permission: models.PermissionUsersWrite,
 	})
 }
 
@@ -349,7 +701,7 @@ func TestPutUserTestSuite(t *testing.T) {
 			Handler:      "PutUser",
 			ResponseType: router.ResponseTypeJSON,
 		},
-		MinRank: 0,
+		Permission: models.PermissionUsersWrite,
 	})
 }
 
@@ -361,7 +713,6 @@ func TestPatchPasswordTestSuite(t *testing.T) {
 			Handler:      "PatchPassword",
 			ResponseType: router.ResponseTypeJSON,
 		},
-		MinRank: 0,
 	})
 }
 
@@ -373,7 +724,7 @@ func TestPatchUserPasswordTestSuite(t *testing.T) {
 			Handler:      "PatchUserPassword",
 			ResponseType: router.ResponseTypeJSON,
 		},
-		MinRank: 0,
+		Permission: models.PermissionUsersWrite,
 	})
 }
 
@@ -385,103 +736,542 @@ func TestDeleteUserTestSuite(t *testing.T) {
 			Handler:      "DeleteUser",
 			ResponseType: router.ResponseTypeJSON,
 		},
-		MinRank: 0,
+		Permission: models.PermissionUsersWrite,
 	})
 }
 
-func TestGetClientsTestSuite(t *testing.T) {
+func TestPostUserTOTPTestSuite(t *testing.T) {
 	suite.Run(t, &RouterAuthTestSuite{
 		RouterTestSuite: RouterTestSuite{
-			Method:       "GET",
-			Route:        "/clients",
-			Handler:      "GetClients",
+			Method:       "POST",
+			Route:        "/user/totp",
+			Handler:      "PostUserTOTP",
 			ResponseType: router.ResponseTypeJSON,
 		},
-		MinRank: MinClientRank,
+		TwoFactorExempt: true,
 	})
 }
 
-func TestPostClientTestSuite(t *testing.T) {
+func TestPostUserTOTPConfirmTestSuite(t *testing.T) {
 	suite.Run(t, &RouterAuthTestSuite{
 		RouterTestSuite: RouterTestSuite{
 			Method:       "POST",
-			Route:        "/client",
-			Handler:      "PostClient",
+			Route:        "/user/totp/confirm",
+			Handler:      "PostUserTOTPConfirm",
 			ResponseType: router.ResponseTypeJSON,
 		},
-		MinRank: MinClientRank,
+		TwoFactorExempt: true,
 	})
 }
 
-func TestPutClientTestSuite(t *testing.T) {
+func TestPostUserTOTPDisableTestSuite(t *testing.T) {
 	suite.Run(t, &RouterAuthTestSuite{
 		RouterTestSuite: RouterTestSuite{
-			Method:       "PUT",
-			Route:        "/client/0",
-			Handler:      "PutClient",
+			Method:       "POST",
+			Route:        "/user/totp/disable",
+			Handler:      "PostUserTOTPDisable",
 			ResponseType: router.ResponseTypeJSON,
 		},
-		MinRank: MinClientRank,
 	})
 }
 
-func TestDeleteClientTestSuite(t *testing.T) {
+func TestGetAdminRolesTestSuite(t *testing.T) {
 	suite.Run(t, &RouterAuthTestSuite{
 		RouterTestSuite: RouterTestSuite{
-			Method:       "DELETE",
-			Route:        "/client/0",
-			Handler:      "DeleteClient",
+			Method:       "GET",
+			Route:        "/admin/roles",
+			Handler:      "GetAdminRoles",
 			ResponseType: router.ResponseTypeJSON,
 		},
-		MinRank: MinClientRank,
+		Permission: models.PermissionAdminRolesRead,
 	})
 }
 
-func TestGetUserRolesTestSuite(t *testing.T) {
+func TestPostAdminRoleTestSuite(t *testing.T) {
 	suite.Run(t, &RouterAuthTestSuite{
 		RouterTestSuite: RouterTestSuite{
-			Method:       "GET",
-			Route:        "/client/0/roles",
-			Handler:      "GetUserRoles",
+			Method:       "POST",
+			Route:        "/admin/role",
+			Handler:      "PostAdminRole",
 			ResponseType: router.ResponseTypeJSON,
 		},
-		MinRank: 0,
+		Permission: models.PermissionAdminRolesWrite,
 	})
 }
 
-func TestPostUserRoleTestSuite(t *testing.T) {
+func TestPutAdminRoleTestSuite(t *testing.T) {
 	suite.Run(t, &RouterAuthTestSuite{
 		RouterTestSuite: RouterTestSuite{
-			Method:       "POST",
-			Route:        "/client/0/role",
-			Handler:      "PostUserRole",
+			Method:       "PUT",
+			Route:        "/admin/role/name",
+			Handler:      "PutAdminRole",
 			ResponseType: router.ResponseTypeJSON,
 		},
-		MinRank: 0,
+		Permission: models.PermissionAdminRolesWrite,
 	})
 }
 
-func TestPutUserRoleTestSuite(t *testing.T) {
+func TestDeleteAdminRoleTestSuite(t *testing.T) {
 	suite.Run(t, &RouterAuthTestSuite{
 		RouterTestSuite: RouterTestSuite{
-			Method:       "PUT",
-			Route:        "/client/0/role/username",
-			Handler:      "PutUserRole",
+			Method:       "DELETE",
+			Route:        "/admin/role/name",
+			Handler:      "DeleteAdminRole",
 			ResponseType: router.ResponseTypeJSON,
 		},
-		MinRank: 0,
+		Permission: models.PermissionAdminRolesWrite,
 	})
 }
 
-func TestDeleteUserRoleTestSuite(t *testing.T) {
+func TestGetUserAdminRolesTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "GET",
+			Route:        "/user/username/admin-roles",
+			Handler:      "GetUserAdminRoles",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission: models.PermissionAdminRolesRead,
+	})
+}
+
+func TestPutUserAdminRolesTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "PUT",
+			Route:        "/user/username/admin-roles",
+			Handler:      "PutUserAdminRoles",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission: models.PermissionAdminRolesWrite,
+	})
+}
+
+func TestGetClientsTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "GET",
+			Route:        "/clients",
+			Handler:      "GetClients",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission: models.PermissionClientsRead,
+	})
+}
+
+func TestPostClientTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "POST",
+			Route:        "/client",
+			Handler:      "PostClient",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission: models.PermissionClientsWrite,
+	})
+}
+
+func TestPutClientTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "PUT",
+			Route:        "/client/" + ClientID,
+			Handler:      "PutClient",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission:   models.PermissionClientsWrite,
+		ClientScoped: true,
+	})
+}
+
+func TestDeleteClientTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "DELETE",
+			Route:        "/client/" + ClientID,
+			Handler:      "DeleteClient",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission: models.PermissionClientsWrite,
+	})
+}
+
+func TestPostClientSecretTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "POST",
+			Route:        "/client/" + ClientID + "/secret",
+			Handler:      "PostClientSecret",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission: models.PermissionClientsWrite,
+	})
+}
+
+func TestGetClientAdminsTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "GET",
+			Route:        "/client/" + ClientID + "/admins",
+			Handler:      "GetClientAdmins",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission:   models.PermissionClientAdminsRead,
+		ClientScoped: true,
+	})
+}
+
+func TestPostClientAdminTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "POST",
+			Route:        "/client/" + ClientID + "/admin",
+			Handler:      "PostClientAdmin",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission:   models.PermissionClientAdminsWrite,
+		ClientScoped: true,
+	})
+}
+
+func TestDeleteClientAdminTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "DELETE",
+			Route:        "/client/" + ClientID + "/admin/username",
+			Handler:      "DeleteClientAdmin",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission:   models.PermissionClientAdminsWrite,
+		ClientScoped: true,
+	})
+}
+
+func TestGetUserRolesTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "GET",
+			Route:        "/client/" + ClientID + "/roles",
+			Handler:      "GetUserRoles",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission:   models.PermissionRolesRead,
+		ClientScoped: true,
+	})
+}
+
+func TestPostUserRoleTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "POST",
+			Route:        "/client/" + ClientID + "/role",
+			Handler:      "PostUserRole",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission:   models.PermissionRolesWrite,
+		ClientScoped: true,
+	})
+}
+
+func TestPutUserRoleTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "PUT",
+			Route:        "/client/" + ClientID + "/role/username",
+			Handler:      "PutUserRole",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission:   models.PermissionRolesWrite,
+		ClientScoped: true,
+	})
+}
+
+func TestDeleteUserRoleTestSuite(t *testing.T) {
 	suite.Run(t, &RouterAuthTestSuite{
 		RouterTestSuite: RouterTestSuite{
 			Method:       "DELETE",
-			Route:        "/client/0/role/username",
+			Route:        "/client/" + ClientID + "/role/username",
 			Handler:      "DeleteUserRole",
 			ResponseType: router.ResponseTypeJSON,
 		},
-		MinRank: 0,
+		Permission:   models.PermissionRolesWrite,
+		ClientScoped: true,
+	})
+}
+
+func TestGetUserRoleAssignmentsTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "GET",
+			Route:        "/client/" + ClientID + "/role/username/effective",
+			Handler:      "GetUserRoleAssignments",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission:   models.PermissionRolesRead,
+		ClientScoped: true,
+	})
+}
+
+func TestGetRoleDefinitionsTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "GET",
+			Route:        "/client/" + ClientID + "/roles/definitions",
+			Handler:      "GetRoleDefinitions",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission:   models.PermissionRolesRead,
+		ClientScoped: true,
+	})
+}
+
+func TestPostRoleDefinitionTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "POST",
+			Route:        "/client/" + ClientID + "/roles/definitions",
+			Handler:      "PostRoleDefinition",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission: models.PermissionClientsWrite,
+	})
+}
+
+func TestPutRoleDefinitionTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "PUT",
+			Route:        "/client/" + ClientID + "/roles/definitions/name",
+			Handler:      "PutRoleDefinition",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission: models.PermissionClientsWrite,
+	})
+}
+
+func TestDeleteRoleDefinitionTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "DELETE",
+			Route:        "/client/" + ClientID + "/roles/definitions/name",
+			Handler:      "DeleteRoleDefinition",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission: models.PermissionClientsWrite,
+	})
+}
+
+func TestGetGroupsTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "GET",
+			Route:        "/groups",
+			Handler:      "GetGroups",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission: models.PermissionGroupsRead,
+	})
+}
+
+func TestPostGroupTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "POST",
+			Route:        "/group",
+			Handler:      "PostGroup",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission: models.PermissionGroupsWrite,
+	})
+}
+
+func TestPutGroupTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "PUT",
+			Route:        "/group/name",
+			Handler:      "PutGroup",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission: models.PermissionGroupsWrite,
+	})
+}
+
+func TestDeleteGroupTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "DELETE",
+			Route:        "/group/name",
+			Handler:      "DeleteGroup",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission: models.PermissionGroupsWrite,
+	})
+}
+
+func TestGetGroupMembersTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "GET",
+			Route:        "/group/name/members",
+			Handler:      "GetGroupMembers",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission: models.PermissionGroupsRead,
+	})
+}
+
+func TestPostGroupMemberTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "POST",
+			Route:        "/group/name/member",
+			Handler:      "PostGroupMember",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission: models.PermissionGroupsWrite,
+	})
+}
+
+func TestDeleteGroupMemberTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "DELETE",
+			Route:        "/group/name/member/username",
+			Handler:      "DeleteGroupMember",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission: models.PermissionGroupsWrite,
+	})
+}
+
+func TestGetGroupRolesTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "GET",
+			Route:        "/client/" + ClientID + "/group-roles",
+			Handler:      "GetGroupRoles",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission:   models.PermissionRolesRead,
+		ClientScoped: true,
+	})
+}
+
+func TestPostGroupRoleTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "POST",
+			Route:        "/client/" + ClientID + "/group-role",
+			Handler:      "PostGroupRole",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission:   models.PermissionRolesWrite,
+		ClientScoped: true,
+	})
+}
+
+func TestPutGroupRoleTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "PUT",
+			Route:        "/client/" + ClientID + "/group-role/group",
+			Handler:      "PutGroupRole",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission:   models.PermissionRolesWrite,
+		ClientScoped: true,
+	})
+}
+
+func TestDeleteGroupRoleTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "DELETE",
+			Route:        "/client/" + ClientID + "/group-role/group",
+			Handler:      "DeleteGroupRole",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission:   models.PermissionRolesWrite,
+		ClientScoped: true,
+	})
+}
+
+func TestPostInvitationTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "POST",
+			Route:        "/invitation",
+			Handler:      "PostInvitation",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission: models.PermissionInvitationsWrite,
+	})
+}
+
+func TestDeleteInvitationTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "DELETE",
+			Route:        "/invitation/0",
+			Handler:      "DeleteInvitation",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission: models.PermissionInvitationsWrite,
+	})
+}
+
+func TestGetAcceptInvitationTestSuite(t *testing.T) {
+	suite.Run(t, &RouterTestSuite{
+		Method:       "GET",
+		Route:        "/invitation/accept",
+		Handler:      "GetAcceptInvitation",
+		ResponseType: router.ResponseTypeRaw,
+	})
+}
+
+func TestPostAcceptInvitationTestSuite(t *testing.T) {
+	suite.Run(t, &RouterTestSuite{
+		Method:       "POST",
+		Route:        "/invitation/accept",
+		Handler:      "PostAcceptInvitation",
+		ResponseType: router.ResponseTypeRaw,
+	})
+}
+
+func TestGetLockoutsTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "GET",
+			Route:        "/lockouts",
+			Handler:      "GetLockouts",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission: models.PermissionLockoutsRead,
+	})
+}
+
+func TestDeleteLockoutTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "DELETE",
+			Route:        "/lockout/username/username",
+			Handler:      "DeleteLockout",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission: models.PermissionLockoutsWrite,
+	})
+}
+
+func TestGetAuditEventsTestSuite(t *testing.T) {
+	suite.Run(t, &RouterAuthTestSuite{
+		RouterTestSuite: RouterTestSuite{
+			Method:       "GET",
+			Route:        "/audit",
+			Handler:      "GetAuditEvents",
+			ResponseType: router.ResponseTypeJSON,
+		},
+		Permission: models.PermissionAuditRead,
 	})
 }
 
@@ -502,7 +1292,7 @@ func TestDeleteSessionTestSuite(t *testing.T) {
 			Handler:      "DeleteSession",
 			ResponseType: router.ResponseTypeJSON,
 		},
-		MinRank: 0,
+		TwoFactorExempt: true,
 	})
 }
 
@@ -523,3 +1313,84 @@ func TestPostTokenTestSuite(t *testing.T) {
 		ResponseType: router.ResponseTypeRaw,
 	})
 }
+
+func TestGetJWKSTestSuite(t *testing.T) {
+	suite.Run(t, &RouterTestSuite{
+		Method:       "GET",
+		Route:        "/.well-known/jwks.json",
+		Handler:      "GetJWKS",
+		ResponseType: router.ResponseTypeJSON,
+	})
+}
+
+func TestGetForgotPasswordTestSuite(t *testing.T) {
+	suite.Run(t, &RouterTestSuite{
+		Method:       "GET",
+		Route:        "/password/forgot",
+		Handler:      "GetForgotPassword",
+		ResponseType: router.ResponseTypeRaw,
+	})
+}
+
+func TestPostForgotPasswordTestSuite(t *testing.T) {
+	suite.Run(t, &RouterTestSuite{
+		Method:       "POST",
+		Route:        "/password/forgot",
+		Handler:      "PostForgotPassword",
+		ResponseType: router.ResponseTypeRaw,
+	})
+}
+
+func TestGetResetPasswordTestSuite(t *testing.T) {
+	suite.Run(t, &RouterTestSuite{
+		Method:       "GET",
+		Route:        "/password/reset",
+		Handler:      "GetResetPassword",
+		ResponseType: router.ResponseTypeRaw,
+	})
+}
+
+func TestPostResetPasswordTestSuite(t *testing.T) {
+	suite.Run(t, &RouterTestSuite{
+		Method:       "POST",
+		Route:        "/password/reset",
+		Handler:      "PostResetPassword",
+		ResponseType: router.ResponseTypeRaw,
+	})
+}
+
+func TestGetAuthorizeTestSuite(t *testing.T) {
+	suite.Run(t, &RouterTestSuite{
+		Method:       "GET",
+		Route:        "/authorize",
+		Handler:      "GetAuthorize",
+		ResponseType: router.ResponseTypeRaw,
+	})
+}
+
+func TestPostAuthorizeTestSuite(t *testing.T) {
+	suite.Run(t, &RouterTestSuite{
+		Method:       "POST",
+		Route:        "/authorize",
+		Handler:      "PostAuthorize",
+		ResponseType: router.ResponseTypeRaw,
+	})
+}
+
+func TestPostOAuthTokenTestSuite(t *testing.T) {
+	suite.Run(t, &RouterTestSuite{
+		Method:       "POST",
+		Route:        "/oauth/token",
+		Handler:      "PostOAuthToken",
+		ResponseType: router.ResponseTypeJSON,
+	})
+}
+
+func TestGetOpenIDConfigurationTestSuite(t *testing.T) {
+	suite.Run(t, &RouterTestSuite{
+		Method:       "GET",
+		Route:        "/.well-known/openid-configuration",
+		Handler:      "GetOpenIDConfiguration",
+		ResponseType: router.ResponseTypeJSON,
+	})
+}
@@ -1,41 +1,114 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"os"
 
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
 	"github.com/mhogar/amber/router"
 )
 
 // HTTPServer is a wrapper for an http server that implements the server interface.
+// If the server's TLSConfig is set it serves https, otherwise it serves http.
 type HTTPServer struct {
 	http.Server
+
+	// RedirectServer is an optional http server that redirects to the https server. It is started and shut down alongside it.
+	RedirectServer *http.Server
 }
 
 // CreateHTTPServerRunner creates a new Runner using an HTTPServer.
-func CreateHTTPServerRunner(routerFactory router.RouterFactory) Runner {
+// The server uses https if it is enabled in the tls config. Returns the runner and any errors.
+func CreateHTTPServerRunner(routerFactory router.RouterFactory) (Runner, error) {
 	//get port from env variable (default 8080)
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
 	}
 
-	return Runner{
-		Server: &HTTPServer{
-			Server: http.Server{
-				Addr:    ":" + port,
-				Handler: routerFactory.CreateRouter(),
-			},
+	server := &HTTPServer{
+		Server: http.Server{
+			Addr:    ":" + port,
+			Handler: routerFactory.CreateRouter(),
 		},
 	}
+
+	cfg := config.GetTLSConfig()
+	if cfg.Enabled {
+		tlsConfig, err := CreateTLSConfig(cfg)
+		if err != nil {
+			return Runner{}, common.ChainError("error creating tls config", err)
+		}
+		server.TLSConfig = tlsConfig
+
+		if cfg.RedirectPort != "" {
+			server.RedirectServer = &http.Server{
+				Addr:    ":" + cfg.RedirectPort,
+				Handler: CreateHTTPSRedirectHandler(port),
+			}
+		}
+	}
+
+	return Runner{
+		Server: server,
+	}, nil
 }
 
-// Start starts the http server. Always returns a non-nil error.
+// Start starts the http server and blocks until it stops.
+// Returns nil if it was stopped by Shutdown, otherwise returns the error that stopped it.
 func (s *HTTPServer) Start() error {
-	fmt.Println("Server is running on port", s.Addr)
-	return s.ListenAndServe()
+	if s.RedirectServer != nil {
+		redirectStopped := make(chan struct{})
+		go func() {
+			defer close(redirectStopped)
+
+			err := s.RedirectServer.ListenAndServe()
+			if !errors.Is(err, http.ErrServerClosed) {
+				log.Println(common.ChainError("error running redirect server", err))
+			}
+		}()
+
+		//the redirect server is closed if the main server stops on its own
+		defer func() {
+			s.RedirectServer.Close()
+			<-redirectStopped
+		}()
+
+		fmt.Println("Redirecting http to https on port", s.RedirectServer.Addr)
+	}
+
+	var err error
+	if s.TLSConfig != nil {
+		fmt.Println("Server is running with https on port", s.Addr)
+
+		//the certificate is provided by the tls config
+		err = s.ListenAndServeTLS("", "")
+	} else {
+		fmt.Println("Server is running on port", s.Addr)
+		err = s.ListenAndServe()
+	}
+
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
 }
 
-// Close does nothing but exists to satisfy the server interface.
-func (*HTTPServer) Close() {}
+// Shutdown stops the http server from accepting new connections and waits for in-flight requests to finish.
+// If the context expires first, the remaining connections are closed. Returns any errors.
+func (s *HTTPServer) Shutdown(ctx context.Context) error {
+	if s.RedirectServer != nil {
+		s.RedirectServer.Shutdown(ctx)
+	}
+
+	err := s.Server.Shutdown(ctx)
+	if err != nil {
+		s.Server.Close()
+	}
+	return err
+}
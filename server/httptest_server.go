@@ -1,8 +1,10 @@
 package server
 
 import (
+	"context"
 	"net/http/httptest"
 
+	"github.com/mhogar/amber/data"
 	"github.com/mhogar/amber/router"
 )
 
@@ -12,16 +14,37 @@ type HTTPTestServer struct {
 }
 
 // CreateHTTPTestServerRunner creates a new Runner using an HTTPTestServer.
-func CreateHTTPTestServerRunner(routerFactory router.RouterFactory) Runner {
+// The runner cleans up the provided data adapter once the server has shut down. The data adapter can be nil.
+func CreateHTTPTestServerRunner(routerFactory router.RouterFactory, dataAdapter data.DataAdapter) Runner {
 	return Runner{
 		Server: &HTTPTestServer{
 			Server: httptest.NewUnstartedServer(routerFactory.CreateRouter()),
 		},
+		DataAdapter: dataAdapter,
 	}
 }
 
-// Start start the server. Always returns a nil error.
+// Start starts the server in the background. Always returns a nil error.
 func (s *HTTPTestServer) Start() error {
 	s.Server.Start()
 	return nil
 }
+
+// Shutdown stops the server from accepting new connections and waits for in-flight requests to finish.
+// If the context expires first, the remaining connections are closed. Returns any errors.
+func (s *HTTPTestServer) Shutdown(ctx context.Context) error {
+	//closing the httptest server blocks until all requests have finished
+	done := make(chan struct{})
+	go func() {
+		s.Server.Close()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.Server.CloseClientConnections()
+		return ctx.Err()
+	}
+}
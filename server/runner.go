@@ -1,18 +1,109 @@
 package server
 
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/data"
+	"github.com/mhogar/amber/sweeper"
+)
+
 type Server interface {
 	// Start starts the server and returns any errors encountered while it is running.
 	Start() error
 
-	// Close closes the server.
-	Close()
+	// Shutdown stops the server from accepting new connections and waits for in-flight requests to finish.
+	// If the context expires first, the remaining connections are closed and the context's error is returned.
+	Shutdown(ctx context.Context) error
 }
 
 type Runner struct {
 	Server Server
+
+	// Sweepers are stopped once the server has shut down, before the data adapter is closed.
+	Sweepers []sweeper.Sweeper
+
+	// DataAdapter is closed once the server has shut down. Nothing is closed if it is nil.
+	DataAdapter data.DataAdapter
+
+	// ShutdownTimeout is the length of time to wait for in-flight requests to finish when shutting down.
+	// A value of zero means wait indefinitely.
+	ShutdownTimeout time.Duration
 }
 
-// Run runs the server and returns any errors.
+// Run runs the server until it receives a SIGINT or SIGTERM, then shuts it down.
+// Returns any errors.
 func (s Runner) Run() error {
-	return s.Server.Start()
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	return s.RunUntil(signals)
+}
+
+// RunUntil runs the server until a signal is received on the stop channel, then shuts it down.
+// If the server fails to start or stops with an error, the sweepers and data adapter are still released.
+// Returns any errors.
+func (s Runner) RunUntil(stop <-chan os.Signal) error {
+	errs := make(chan error, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		err := s.Server.Start()
+		if err != nil {
+			errs <- err
+		}
+	}()
+
+	select {
+	case err := <-errs:
+		s.release()
+		return common.ChainError("server stopped with an error", err)
+	case <-stop:
+		err := s.Shutdown()
+
+		//wait for the server to finish stopping
+		<-done
+		return err
+	}
+}
+
+// Shutdown gracefully shuts down the server, waiting up to the shutdown timeout for in-flight requests to finish.
+// The sweepers and data adapter are released afterwards, even if the timeout is reached.
+// Returns any errors.
+func (s Runner) Shutdown() error {
+	ctx := context.Background()
+	if s.ShutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.ShutdownTimeout)
+		defer cancel()
+	}
+
+	err := s.Server.Shutdown(ctx)
+	s.release()
+
+	if err != nil {
+		return common.ChainError("error shutting down server", err)
+	}
+	return nil
+}
+
+// release stops the sweepers then closes the data adapter.
+func (s Runner) release() {
+	for _, sw := range s.Sweepers {
+		sw.Stop()
+	}
+
+	if s.DataAdapter != nil {
+		err := s.DataAdapter.CleanUp()
+		if err != nil {
+			log.Println(common.ChainError("error cleaning up data adapter", err))
+		}
+	}
 }
@@ -1,53 +1,143 @@
 package server_test
 
 import (
+	"context"
 	"errors"
+	"os"
+	"syscall"
 	"testing"
+	"time"
 
+	datamocks "github.com/mhogar/amber/data/mocks"
 	"github.com/mhogar/amber/server"
 	"github.com/mhogar/amber/server/mocks"
+	sweepermocks "github.com/mhogar/amber/sweeper/mocks"
 	"github.com/mhogar/amber/testing/helpers"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
 type RunnerTestSuite struct {
 	helpers.CustomSuite
-	ServerMock mocks.Server
-	Runner     *server.Runner
+	ServerMock      mocks.Server
+	SweeperMock     sweepermocks.Sweeper
+	DataAdapterMock datamocks.DataAdapter
+	Runner          *server.Runner
 }
 
 func (suite *RunnerTestSuite) SetupTest() {
 	suite.ServerMock = mocks.Server{}
+	suite.SweeperMock = sweepermocks.Sweeper{}
+	suite.DataAdapterMock = datamocks.DataAdapter{}
+
+	suite.SweeperMock.On("Stop")
+	suite.DataAdapterMock.On("CleanUp").Return(nil)
 
 	suite.Runner = &server.Runner{
-		Server: &suite.ServerMock,
+		Server:          &suite.ServerMock,
+		DataAdapter:     &suite.DataAdapterMock,
+		ShutdownTimeout: time.Second,
 	}
+	suite.Runner.Sweepers = append(suite.Runner.Sweepers, &suite.SweeperMock)
 }
 
-func (suite *RunnerTestSuite) TestRun_WithErrorStartingServer_ReturnsError() {
+func (suite *RunnerTestSuite) TestRunUntil_WithErrorStartingServer_ReleasesResourcesAndReturnsError() {
 	//arrange
 	message := "Start mock error"
 	suite.ServerMock.On("Start").Return(errors.New(message))
 
 	//act
-	err := suite.Runner.Run()
+	err := suite.Runner.RunUntil(make(chan os.Signal))
 
 	//assert
 	suite.Require().Error(err)
 	suite.Contains(err.Error(), message)
+
+	suite.ServerMock.AssertNotCalled(suite.T(), "Shutdown", mock.Anything)
+	suite.SweeperMock.AssertCalled(suite.T(), "Stop")
+	suite.DataAdapterMock.AssertCalled(suite.T(), "CleanUp")
 }
 
-func (suite *RunnerTestSuite) TestRun_StartsServer() {
+func (suite *RunnerTestSuite) TestRunUntil_OnStopSignal_ShutsDownServerAndReleasesResources() {
 	//arrange
 	suite.ServerMock.On("Start").Return(nil)
+	suite.ServerMock.On("Shutdown", mock.Anything).Return(nil)
+
+	stop := make(chan os.Signal, 1)
+	stop <- syscall.SIGTERM
+
+	//act
+	err := suite.Runner.RunUntil(stop)
+
+	//assert
+	suite.Require().NoError(err)
+
+	suite.ServerMock.AssertCalled(suite.T(), "Shutdown", mock.Anything)
+	suite.SweeperMock.AssertCalled(suite.T(), "Stop")
+	suite.DataAdapterMock.AssertCalled(suite.T(), "CleanUp")
+}
+
+func (suite *RunnerTestSuite) TestShutdown_WithErrorShuttingDownServer_ReleasesResourcesAndReturnsError() {
+	//arrange
+	message := "Shutdown mock error"
+	suite.ServerMock.On("Shutdown", mock.Anything).Return(errors.New(message))
+
+	//act
+	err := suite.Runner.Shutdown()
+
+	//assert
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), message)
+
+	suite.SweeperMock.AssertCalled(suite.T(), "Stop")
+	suite.DataAdapterMock.AssertCalled(suite.T(), "CleanUp")
+}
+
+func (suite *RunnerTestSuite) TestShutdown_ShutsDownServerWithShutdownTimeout() {
+	//arrange
+	var deadline time.Time
+	var hasDeadline bool
+	suite.ServerMock.On("Shutdown", mock.Anything).Run(func(args mock.Arguments) {
+		deadline, hasDeadline = args.Get(0).(context.Context).Deadline()
+	}).Return(nil)
+
+	//act
+	err := suite.Runner.Shutdown()
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Require().True(hasDeadline)
+	suite.WithinDuration(time.Now().Add(suite.Runner.ShutdownTimeout), deadline, 100*time.Millisecond)
+}
+
+func (suite *RunnerTestSuite) TestShutdown_WithZeroShutdownTimeout_ShutsDownServerWithoutDeadline() {
+	//arrange
+	suite.Runner.ShutdownTimeout = 0
+
+	var hasDeadline bool
+	suite.ServerMock.On("Shutdown", mock.Anything).Run(func(args mock.Arguments) {
+		_, hasDeadline = args.Get(0).(context.Context).Deadline()
+	}).Return(nil)
+
+	//act
+	err := suite.Runner.Shutdown()
+
+	//assert
+	suite.Require().NoError(err)
+	suite.False(hasDeadline)
+}
+
+func (suite *RunnerTestSuite) TestShutdown_WithNilDataAdapter_DoesNotPanic() {
+	//arrange
+	suite.Runner.DataAdapter = nil
+	suite.ServerMock.On("Shutdown", mock.Anything).Return(nil)
 
 	//act
-	err := suite.Runner.Run()
+	err := suite.Runner.Shutdown()
 
 	//assert
 	suite.Require().NoError(err)
-	suite.ServerMock.AssertCalled(suite.T(), "Start")
 }
 
 func TestRunnerTestSuite(t *testing.T) {
@@ -1,12 +1,19 @@
 package server_test
 
 import (
+	"context"
+	"net/http"
 	"testing"
+	"time"
 
+	"github.com/mhogar/amber/config"
+	memoryadapter "github.com/mhogar/amber/data/memory_adapter"
 	routermocks "github.com/mhogar/amber/router/mocks"
 	"github.com/mhogar/amber/server"
 	"github.com/mhogar/amber/testing/helpers"
 
+	"github.com/julienschmidt/httprouter"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -21,28 +28,146 @@ func (suite *ServerTestSuite) SetupTest() {
 
 func (suite *ServerTestSuite) TestCreateHTTPServerRunner_CreatesRunnerUsingHTTPServer() {
 	//arrange
+	viper.Set("tls", config.TLSConfig{})
 	suite.RouterFactoryMock.On("CreateRouter").Return(nil)
 
 	//act
-	runner := server.CreateHTTPServerRunner(&suite.RouterFactoryMock)
+	runner, err := server.CreateHTTPServerRunner(&suite.RouterFactoryMock)
 
 	//assert
-	suite.IsType(&server.HTTPServer{}, runner.Server)
+	suite.Require().NoError(err)
+	suite.Require().IsType(&server.HTTPServer{}, runner.Server)
+	suite.Nil(runner.Server.(*server.HTTPServer).TLSConfig)
+	suite.Nil(runner.Server.(*server.HTTPServer).RedirectServer)
 	suite.RouterFactoryMock.AssertCalled(suite.T(), "CreateRouter")
 }
 
+func (suite *ServerTestSuite) TestCreateHTTPServerRunner_WithTLSEnabled_CreatesHTTPServerWithTLSConfigAndRedirectServer() {
+	//arrange
+	viper.Set("root_dir", CreateTempDir(suite.T()))
+	WriteCertificate(suite.T(), config.GetAppRoot(), "cert.pem", "key.pem", "localhost")
+
+	viper.Set("tls", config.TLSConfig{
+		Enabled:      true,
+		CertFile:     "cert.pem",
+		KeyFile:      "key.pem",
+		RedirectPort: "8081",
+	})
+	suite.RouterFactoryMock.On("CreateRouter").Return(nil)
+
+	//act
+	runner, err := server.CreateHTTPServerRunner(&suite.RouterFactoryMock)
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Require().IsType(&server.HTTPServer{}, runner.Server)
+
+	httpServer := runner.Server.(*server.HTTPServer)
+	suite.NotNil(httpServer.TLSConfig)
+	suite.Require().NotNil(httpServer.RedirectServer)
+	suite.Equal(":8081", httpServer.RedirectServer.Addr)
+}
+
+func (suite *ServerTestSuite) TestCreateHTTPServerRunner_WithErrorCreatingTLSConfig_ReturnsError() {
+	//arrange
+	viper.Set("root_dir", CreateTempDir(suite.T()))
+	viper.Set("tls", config.TLSConfig{
+		Enabled:  true,
+		CertFile: "missing.pem",
+		KeyFile:  "missing.pem",
+	})
+	suite.RouterFactoryMock.On("CreateRouter").Return(nil)
+
+	//act
+	_, err := server.CreateHTTPServerRunner(&suite.RouterFactoryMock)
+
+	//assert
+	suite.ContainsSubstrings(err.Error(), "error creating tls config")
+}
+
 func (suite *ServerTestSuite) TestCreateHTTPTestServerRunner_CreatesRunnerUsingHTTPTestServer() {
 	//arrange
 	suite.RouterFactoryMock.On("CreateRouter").Return(nil)
 
+	dataAdapter := &memoryadapter.MemoryAdapter{}
+
 	//act
-	runner := server.CreateHTTPTestServerRunner(&suite.RouterFactoryMock)
+	runner := server.CreateHTTPTestServerRunner(&suite.RouterFactoryMock, dataAdapter)
 
 	//assert
 	suite.IsType(&server.HTTPTestServer{}, runner.Server)
+	suite.Equal(dataAdapter, runner.DataAdapter)
 	suite.RouterFactoryMock.AssertCalled(suite.T(), "CreateRouter")
 }
 
+func (suite *ServerTestSuite) TestHTTPTestServerShutdown_WaitsForInFlightRequestsToFinish() {
+	//arrange
+	started := make(chan struct{})
+	release := make(chan struct{})
+	router := httprouter.New()
+	router.GET("/", func(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+	suite.RouterFactoryMock.On("CreateRouter").Return(router)
+
+	runner := server.CreateHTTPTestServerRunner(&suite.RouterFactoryMock, nil)
+	suite.Require().NoError(runner.Server.Start())
+
+	statuses := make(chan int, 1)
+	go func() {
+		res, err := http.Get(runner.Server.(*server.HTTPTestServer).URL)
+		if err != nil {
+			statuses <- 0
+			return
+		}
+		res.Body.Close()
+		statuses <- res.StatusCode
+	}()
+	<-started
+
+	//act
+	shutdownErrs := make(chan error, 1)
+	go func() {
+		shutdownErrs <- runner.Server.Shutdown(context.Background())
+	}()
+	close(release)
+
+	//assert
+	suite.Equal(http.StatusOK, <-statuses)
+	suite.NoError(<-shutdownErrs)
+}
+
+func (suite *ServerTestSuite) TestHTTPTestServerShutdown_WhereContextExpires_ReturnsContextError() {
+	//arrange
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	router := httprouter.New()
+	router.GET("/", func(_ http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+		close(started)
+		<-release
+	})
+	suite.RouterFactoryMock.On("CreateRouter").Return(router)
+
+	runner := server.CreateHTTPTestServerRunner(&suite.RouterFactoryMock, nil)
+	suite.Require().NoError(runner.Server.Start())
+
+	go http.Get(runner.Server.(*server.HTTPTestServer).URL)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	//act
+	err := runner.Server.Shutdown(ctx)
+
+	//assert
+	suite.ErrorIs(err, context.DeadlineExceeded)
+}
+
 func TestServerTestSuite(t *testing.T) {
 	suite.Run(t, &ServerTestSuite{})
 }
@@ -0,0 +1,180 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
+)
+
+// CreateTLSConfig creates the tls config for the server using the provided config.
+// Returns the tls config and any errors.
+func CreateTLSConfig(cfg config.TLSConfig) (*tls.Config, error) {
+	minVersion, err := parseTLSVersion(cfg.MinVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	//load the certificate
+	reloader := &CertificateReloader{
+		CertFile:       config.GetAppRoot(cfg.CertFile),
+		KeyFile:        config.GetAppRoot(cfg.KeyFile),
+		ReloadInterval: time.Duration(cfg.ReloadInterval) * time.Second,
+	}
+	err = reloader.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:     minVersion,
+		GetCertificate: reloader.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+	}
+
+	//require client certificates if a client CA is configured
+	if cfg.ClientCAFile != "" {
+		pem, err := ioutil.ReadFile(config.GetAppRoot(cfg.ClientCAFile))
+		if err != nil {
+			return nil, common.ChainError("error reading client CA file", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("client CA file does not contain any certificates")
+		}
+
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
+
+func parseTLSVersion(version string) (uint16, error) {
+	switch version {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	}
+
+	return 0, fmt.Errorf("unsupported tls min version %q, must be 1.2 or 1.3", version)
+}
+
+// CertificateReloader serves a certificate loaded from files, reloading it when the files change.
+type CertificateReloader struct {
+	CertFile string
+	KeyFile  string
+
+	// ReloadInterval is the minimum length of time between checks for changes to the files.
+	// A value of zero disables reloading.
+	ReloadInterval time.Duration
+
+	mutex       sync.Mutex
+	certificate *tls.Certificate
+	modTime     time.Time
+	lastCheck   time.Time
+}
+
+// Load loads the certificate from the files. Returns any errors.
+func (r *CertificateReloader) Load() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	modTime, err := r.getModTime()
+	if err != nil {
+		return err
+	}
+
+	return r.load(modTime)
+}
+
+// GetCertificate returns the current certificate, first reloading it if the files have changed since it was loaded.
+// If reloading fails the error is logged and the previous certificate is still returned. Has the signature of tls.Config's GetCertificate.
+func (r *CertificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.ReloadInterval > 0 && time.Since(r.lastCheck) >= r.ReloadInterval {
+		err := r.reload()
+		if err != nil {
+			log.Println(common.ChainError("error reloading certificate", err))
+		}
+	}
+
+	if r.certificate == nil {
+		return nil, errors.New("no certificate loaded")
+	}
+	return r.certificate, nil
+}
+
+func (r *CertificateReloader) reload() error {
+	r.lastCheck = time.Now()
+
+	modTime, err := r.getModTime()
+	if err != nil {
+		return err
+	}
+
+	if modTime.Equal(r.modTime) {
+		return nil
+	}
+	return r.load(modTime)
+}
+
+func (r *CertificateReloader) load(modTime time.Time) error {
+	certificate, err := tls.LoadX509KeyPair(r.CertFile, r.KeyFile)
+	if err != nil {
+		return common.ChainError("error loading certificate", err)
+	}
+
+	r.certificate = &certificate
+	r.modTime = modTime
+	r.lastCheck = time.Now()
+	return nil
+}
+
+// getModTime gets the latest modification time of the certificate and key files.
+func (r *CertificateReloader) getModTime() (time.Time, error) {
+	var modTime time.Time
+	for _, file := range []string{r.CertFile, r.KeyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, common.ChainError("error reading certificate file info", err)
+		}
+
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+
+	return modTime, nil
+}
+
+// CreateHTTPSRedirectHandler creates a handler that redirects every request to the same url using https.
+// The redirect uses the provided https port, which is left out of the url if it is the default.
+func CreateHTTPSRedirectHandler(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		host, _, err := net.SplitHostPort(req.Host)
+		if err != nil {
+			host = req.Host
+		}
+		if httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+
+		//permanent redirect so form posts are resent with the same method and body
+		http.Redirect(w, req, "https://"+host+req.URL.RequestURI(), http.StatusPermanentRedirect)
+	})
+}
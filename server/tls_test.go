@@ -0,0 +1,278 @@
+package server_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/config"
+	"github.com/mhogar/amber/server"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// CreateTempDir creates a temporary directory that is removed when the test finishes.
+func CreateTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "amber")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+	return dir
+}
+
+// WriteCertificate writes a new self-signed certificate and its key to the given files in the directory.
+func WriteCertificate(t *testing.T, dir string, certFile string, keyFile string, commonName string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	err = ioutil.WriteFile(path.Join(dir, certFile), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	require.NoError(t, err)
+
+	err = ioutil.WriteFile(path.Join(dir, keyFile), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+	require.NoError(t, err)
+}
+
+type TLSTestSuite struct {
+	helpers.CustomSuite
+	Dir string
+}
+
+func (suite *TLSTestSuite) SetupTest() {
+	suite.Dir = CreateTempDir(suite.T())
+	viper.Set("root_dir", suite.Dir)
+
+	WriteCertificate(suite.T(), suite.Dir, "cert.pem", "key.pem", "original")
+}
+
+// GetCertificateCommonName gets the common name of the certificate returned by the function.
+func (suite *TLSTestSuite) GetCertificateCommonName(getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)) string {
+	certificate, err := getCertificate(nil)
+	suite.Require().NoError(err)
+
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+	suite.Require().NoError(err)
+
+	return leaf.Subject.CommonName
+}
+
+// TouchFiles sets the modification time of the files to the future so they are seen as changed.
+func (suite *TLSTestSuite) TouchFiles(files ...string) {
+	modTime := time.Now().Add(time.Minute)
+	for _, file := range files {
+		err := os.Chtimes(path.Join(suite.Dir, file), modTime, modTime)
+		suite.Require().NoError(err)
+	}
+}
+
+func (suite *TLSTestSuite) TestCreateTLSConfig_WithInvalidMinVersion_ReturnsError() {
+	//act
+	_, err := server.CreateTLSConfig(config.TLSConfig{
+		CertFile:   "cert.pem",
+		KeyFile:    "key.pem",
+		MinVersion: "1.1",
+	})
+
+	//assert
+	suite.ContainsSubstrings(err.Error(), "unsupported tls min version")
+}
+
+func (suite *TLSTestSuite) TestCreateTLSConfig_WithMissingCertificate_ReturnsError() {
+	//act
+	_, err := server.CreateTLSConfig(config.TLSConfig{
+		CertFile: "missing.pem",
+		KeyFile:  "key.pem",
+	})
+
+	//assert
+	suite.ContainsSubstrings(err.Error(), "error reading certificate file info")
+}
+
+func (suite *TLSTestSuite) TestCreateTLSConfig_CreatesTLSConfigWithMinVersionAndCertificate() {
+	var minVersion string
+	var expectedMinVersion uint16
+
+	testCase := func() {
+		//act
+		tlsConfig, err := server.CreateTLSConfig(config.TLSConfig{
+			CertFile:   "cert.pem",
+			KeyFile:    "key.pem",
+			MinVersion: minVersion,
+		})
+
+		//assert
+		suite.Require().NoError(err)
+		suite.Equal(expectedMinVersion, tlsConfig.MinVersion)
+		suite.Equal(tls.NoClientCert, tlsConfig.ClientAuth)
+		suite.Contains(tlsConfig.NextProtos, "h2")
+		suite.Equal("original", suite.GetCertificateCommonName(tlsConfig.GetCertificate))
+	}
+
+	minVersion = ""
+	expectedMinVersion = tls.VersionTLS12
+	suite.Run("EmptyDefaultsTo1.2", testCase)
+
+	minVersion = "1.2"
+	expectedMinVersion = tls.VersionTLS12
+	suite.Run("1.2", testCase)
+
+	minVersion = "1.3"
+	expectedMinVersion = tls.VersionTLS13
+	suite.Run("1.3", testCase)
+}
+
+func (suite *TLSTestSuite) TestCreateTLSConfig_WithClientCAFile_RequiresClientCertificates() {
+	//arrange
+	WriteCertificate(suite.T(), suite.Dir, "ca.pem", "ca-key.pem", "ca")
+
+	//act
+	tlsConfig, err := server.CreateTLSConfig(config.TLSConfig{
+		CertFile:     "cert.pem",
+		KeyFile:      "key.pem",
+		ClientCAFile: "ca.pem",
+	})
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Equal(tls.RequireAndVerifyClientCert, tlsConfig.ClientAuth)
+	suite.NotNil(tlsConfig.ClientCAs)
+}
+
+func (suite *TLSTestSuite) TestCreateTLSConfig_WithClientCAFileWithoutCertificates_ReturnsError() {
+	//arrange
+	err := ioutil.WriteFile(path.Join(suite.Dir, "ca.pem"), []byte("not a certificate"), 0600)
+	suite.Require().NoError(err)
+
+	//act
+	_, err = server.CreateTLSConfig(config.TLSConfig{
+		CertFile:     "cert.pem",
+		KeyFile:      "key.pem",
+		ClientCAFile: "ca.pem",
+	})
+
+	//assert
+	suite.ContainsSubstrings(err.Error(), "client CA file does not contain any certificates")
+}
+
+func (suite *TLSTestSuite) TestCertificateReloader_WhereFilesChange_ReloadsCertificate() {
+	//arrange
+	reloader := &server.CertificateReloader{
+		CertFile:       path.Join(suite.Dir, "cert.pem"),
+		KeyFile:        path.Join(suite.Dir, "key.pem"),
+		ReloadInterval: time.Nanosecond,
+	}
+	suite.Require().NoError(reloader.Load())
+
+	WriteCertificate(suite.T(), suite.Dir, "cert.pem", "key.pem", "reloaded")
+	suite.TouchFiles("cert.pem", "key.pem")
+
+	//act
+	commonName := suite.GetCertificateCommonName(reloader.GetCertificate)
+
+	//assert
+	suite.Equal("reloaded", commonName)
+}
+
+func (suite *TLSTestSuite) TestCertificateReloader_WithZeroReloadInterval_DoesNotReloadCertificate() {
+	//arrange
+	reloader := &server.CertificateReloader{
+		CertFile: path.Join(suite.Dir, "cert.pem"),
+		KeyFile:  path.Join(suite.Dir, "key.pem"),
+	}
+	suite.Require().NoError(reloader.Load())
+
+	WriteCertificate(suite.T(), suite.Dir, "cert.pem", "key.pem", "reloaded")
+	suite.TouchFiles("cert.pem", "key.pem")
+
+	//act
+	commonName := suite.GetCertificateCommonName(reloader.GetCertificate)
+
+	//assert
+	suite.Equal("original", commonName)
+}
+
+func (suite *TLSTestSuite) TestCertificateReloader_WithErrorReloadingCertificate_KeepsPreviousCertificate() {
+	//arrange
+	reloader := &server.CertificateReloader{
+		CertFile:       path.Join(suite.Dir, "cert.pem"),
+		KeyFile:        path.Join(suite.Dir, "key.pem"),
+		ReloadInterval: time.Nanosecond,
+	}
+	suite.Require().NoError(reloader.Load())
+
+	err := ioutil.WriteFile(path.Join(suite.Dir, "cert.pem"), []byte("not a certificate"), 0600)
+	suite.Require().NoError(err)
+	suite.TouchFiles("cert.pem")
+
+	//act
+	commonName := suite.GetCertificateCommonName(reloader.GetCertificate)
+
+	//assert
+	suite.Equal("original", commonName)
+}
+
+func (suite *TLSTestSuite) TestHTTPSRedirectHandler_RedirectsToHTTPS() {
+	var httpsPort string
+	var target string
+	var expectedLocation string
+
+	testCase := func() {
+		//arrange
+		handler := server.CreateHTTPSRedirectHandler(httpsPort)
+		req := httptest.NewRequest(http.MethodPost, target, nil)
+		w := httptest.NewRecorder()
+
+		//act
+		handler.ServeHTTP(w, req)
+
+		//assert
+		suite.Equal(http.StatusPermanentRedirect, w.Code)
+		suite.Equal(expectedLocation, w.Header().Get("Location"))
+	}
+
+	httpsPort = "443"
+	target = "http://example.com/token?client_id=id"
+	expectedLocation = "https://example.com/token?client_id=id"
+	suite.Run("DefaultPortIsLeftOut", testCase)
+
+	httpsPort = "8443"
+	target = "http://example.com:8080/token"
+	expectedLocation = "https://example.com:8443/token"
+	suite.Run("OtherPortReplacesHTTPPort", testCase)
+}
+
+func TestTLSTestSuite(t *testing.T) {
+	suite.Run(t, &TLSTestSuite{})
+}
@@ -0,0 +1,79 @@
+package sweeper
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/controllers"
+	"github.com/mhogar/amber/data"
+)
+
+// Sweeper periodically performs cleanup in the background.
+type Sweeper interface {
+	// Start starts the sweeper in a new goroutine.
+	Start()
+
+	// Stop stops the sweeper.
+	Stop()
+}
+
+// SessionSweeper is a Sweeper that deletes expired sessions.
+type SessionSweeper struct {
+	ScopeFactory      data.ScopeFactory
+	SessionController controllers.SessionController
+	Interval          time.Duration
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// Start starts sweeping expired sessions every interval.
+func (s *SessionSweeper) Start() {
+	stop := make(chan struct{})
+	s.stop = stop
+	ticker := time.NewTicker(s.Interval)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				err := s.Sweep()
+				if err != nil {
+					log.Println(common.ChainError("error sweeping sessions", err))
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the sweeper and waits for any in progress sweep to finish.
+// Does nothing if the sweeper was not started.
+func (s *SessionSweeper) Stop() {
+	if s.stop != nil {
+		close(s.stop)
+		s.wg.Wait()
+		s.stop = nil
+	}
+}
+
+// Sweep deletes the expired sessions once. Returns any errors.
+func (s *SessionSweeper) Sweep() error {
+	return s.ScopeFactory.CreateDataExecutorScope(func(exec data.DataExecutor) error {
+		return s.ScopeFactory.CreateTransactionScope(exec, func(tx data.Transaction) (bool, error) {
+			cerr := s.SessionController.DeleteExpiredSessions(tx)
+			if cerr.Type != common.ErrorTypeNone {
+				return false, errors.New("error deleting expired sessions")
+			}
+
+			return true, nil
+		})
+	})
+}
@@ -0,0 +1,104 @@
+package sweeper_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/common"
+	controllermocks "github.com/mhogar/amber/controllers/mocks"
+	"github.com/mhogar/amber/sweeper"
+	"github.com/mhogar/amber/testing/helpers"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type SessionSweeperTestSuite struct {
+	helpers.ScopeFactorySuite
+	ControllersMock controllermocks.Controllers
+	Sweeper         *sweeper.SessionSweeper
+}
+
+func (suite *SessionSweeperTestSuite) SetupTest() {
+	suite.ScopeFactorySuite.SetupTest()
+	suite.ControllersMock = controllermocks.Controllers{}
+
+	suite.Sweeper = &sweeper.SessionSweeper{
+		ScopeFactory:      &suite.ScopeFactoryMock,
+		SessionController: &suite.ControllersMock,
+		Interval:          time.Millisecond,
+	}
+}
+
+func (suite *SessionSweeperTestSuite) TestSweep_WithErrorFromDataExecutorScope_ReturnsError() {
+	//arrange
+	message := "CreateDataExecutorScope error"
+	suite.ScopeFactoryMock.On("CreateDataExecutorScope", mock.Anything).Return(errors.New(message))
+
+	//act
+	err := suite.Sweeper.Sweep()
+
+	//assert
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), message)
+}
+
+func (suite *SessionSweeperTestSuite) TestSweep_WithErrorDeletingExpiredSessions_ReturnsFailureToTransactionScope() {
+	//arrange
+	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
+	suite.SetupScopeFactoryMock_CreateTransactionScope_WithCallback(nil, func(result bool, err error) {
+		suite.False(result)
+		suite.Error(err)
+	})
+	suite.ControllersMock.On("DeleteExpiredSessions", mock.Anything).Return(common.InternalError())
+
+	//act
+	suite.Sweeper.Sweep()
+}
+
+func (suite *SessionSweeperTestSuite) TestSweep_WithNoErrors_DeletesExpiredSessions() {
+	//arrange
+	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
+	suite.SetupScopeFactoryMock_CreateTransactionScope_WithCallback(nil, func(result bool, err error) {
+		suite.True(result)
+		suite.NoError(err)
+	})
+	suite.ControllersMock.On("DeleteExpiredSessions", mock.Anything).Return(common.NoError())
+
+	//act
+	err := suite.Sweeper.Sweep()
+
+	//assert
+	suite.NoError(err)
+	suite.ControllersMock.AssertCalled(suite.T(), "DeleteExpiredSessions", &suite.TransactionMock)
+}
+
+func (suite *SessionSweeperTestSuite) TestStart_SweepsUntilStopped() {
+	//arrange
+	swept := make(chan struct{}, 1)
+
+	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
+	suite.SetupScopeFactoryMock_CreateTransactionScope(nil)
+	suite.ControllersMock.On("DeleteExpiredSessions", mock.Anything).Return(common.NoError()).Run(func(_ mock.Arguments) {
+		select {
+		case swept <- struct{}{}:
+		default:
+		}
+	})
+
+	//act
+	suite.Sweeper.Start()
+	defer suite.Sweeper.Stop()
+
+	//assert
+	select {
+	case <-swept:
+	case <-time.After(time.Second):
+		suite.Fail("sweeper did not sweep sessions")
+	}
+}
+
+func TestSessionSweeperTestSuite(t *testing.T) {
+	suite.Run(t, &SessionSweeperTestSuite{})
+}
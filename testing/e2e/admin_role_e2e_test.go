@@ -0,0 +1,291 @@
+package e2e_test
+
+import (
+	"net/http"
+	"path"
+	"testing"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/controllers"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/router/handlers"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *E2ETestSuite) SendGetAdminRolesRequest(token string) *http.Response {
+	return suite.SendJSONRequest(http.MethodGet, "/admin/roles", token, nil)
+}
+
+func (suite *E2ETestSuite) SendCreateAdminRoleRequest(token string, body handlers.PostAdminRoleBody) *http.Response {
+	return suite.SendJSONRequest(http.MethodPost, "/admin/role", token, body)
+}
+
+func (suite *E2ETestSuite) SendUpdateAdminRoleRequest(token string, name string, body handlers.PutAdminRoleBody) *http.Response {
+	return suite.SendJSONRequest(http.MethodPut, path.Join("/admin/role", name), token, body)
+}
+
+func (suite *E2ETestSuite) SendDeleteAdminRoleRequest(token string, name string) *http.Response {
+	return suite.SendJSONRequest(http.MethodDelete, path.Join("/admin/role", name), token, nil)
+}
+
+func (suite *E2ETestSuite) SendGetUserAdminRolesRequest(token string, username string) *http.Response {
+	return suite.SendJSONRequest(http.MethodGet, path.Join("/user", username, "admin-roles"), token, nil)
+}
+
+func (suite *E2ETestSuite) SendUpdateUserAdminRolesRequest(token string, username string, names ...string) *http.Response {
+	body := handlers.PutUserAdminRolesBody{
+		AdminRoles: names,
+	}
+	return suite.SendJSONRequest(http.MethodPut, path.Join("/user", username, "admin-roles"), token, body)
+}
+
+func (suite *E2ETestSuite) UpdateUserAdminRoles(token string, username string, names ...string) {
+	res := suite.SendUpdateUserAdminRolesRequest(token, username, names...)
+	suite.ParseResponseOK(res, &common.DataResponse{})
+}
+
+type AdminRoleE2ETestSuite struct {
+	E2ETestSuite
+	User     UserCredentials
+	ClientID uuid.UUID
+}
+
+func (suite *AdminRoleE2ETestSuite) SetupSuite() {
+	suite.E2ETestSuite.SetupSuite()
+
+	suite.User = suite.CreateUser(suite.AdminToken, "admin_role_user", 0)
+	suite.ClientID = suite.CreateClient(suite.AdminToken, 0, "key.pem")
+}
+
+func (suite *AdminRoleE2ETestSuite) TearDownSuite() {
+	suite.DeleteClient(suite.AdminToken, suite.ClientID)
+	suite.DeleteUser(suite.AdminToken, suite.User.Username)
+
+	suite.E2ETestSuite.TearDownSuite()
+}
+
+func (suite *AdminRoleE2ETestSuite) getAdminRoleNames(res *http.Response) []string {
+	var result struct {
+		Data []handlers.AdminRoleDataResponse `json:"data"`
+	}
+	suite.ParseResponseOK(res, &result)
+
+	names := make([]string, len(result.Data))
+	for index, role := range result.Data {
+		names[index] = role.Name
+	}
+	return names
+}
+
+func (suite *AdminRoleE2ETestSuite) TestGetAdminRoles_WithInvalidSession_ReturnsUnauthorized() {
+	res := suite.SendGetAdminRolesRequest("")
+	suite.ParseAndAssertErrorResponse(res, http.StatusUnauthorized)
+}
+
+func (suite *AdminRoleE2ETestSuite) TestGetAdminRoles_WithoutPermission_ReturnsForbidden() {
+	token := suite.Login(suite.User)
+	defer suite.Logout(token)
+
+	res := suite.SendGetAdminRolesRequest(token)
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+}
+
+func (suite *AdminRoleE2ETestSuite) TestGetAdminRoles_ContainsBuiltInAdminRoles() {
+	res := suite.SendGetAdminRolesRequest(suite.AdminToken)
+
+	names := suite.getAdminRoleNames(res)
+	suite.Contains(names, controllers.AdminRoleAdmin)
+	suite.Contains(names, controllers.AdminRoleUserAdmin)
+	suite.Contains(names, controllers.AdminRoleAuditor)
+}
+
+func (suite *AdminRoleE2ETestSuite) TestGetUserAdminRoles_ForAdmin_ContainsAdminRole() {
+	res := suite.SendGetUserAdminRolesRequest(suite.AdminToken, suite.Admin.Username)
+	suite.Contains(suite.getAdminRoleNames(res), controllers.AdminRoleAdmin)
+}
+
+func (suite *AdminRoleE2ETestSuite) TestCreateAdminRole_WithInvalidPermission_ReturnsBadRequest() {
+	res := suite.SendCreateAdminRoleRequest(suite.AdminToken, handlers.PostAdminRoleBody{
+		Name:        "invalid_role",
+		Permissions: []string{"invalid"},
+	})
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "permissions", "known permissions")
+}
+
+func (suite *AdminRoleE2ETestSuite) TestCreateAdminRole_WhereNameAlreadyExists_ReturnsBadRequest() {
+	res := suite.SendCreateAdminRoleRequest(suite.AdminToken, handlers.PostAdminRoleBody{
+		Name: controllers.AdminRoleAdmin,
+	})
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "admin role", "already exists")
+}
+
+func (suite *AdminRoleE2ETestSuite) TestUpdateAdminRole_WhereNameDoesNotExist_ReturnsBadRequest() {
+	res := suite.SendUpdateAdminRoleRequest(suite.AdminToken, "DNE", handlers.PutAdminRoleBody{})
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "admin role", "not found")
+}
+
+func (suite *AdminRoleE2ETestSuite) TestDeleteAdminRole_WhereNameDoesNotExist_ReturnsBadRequest() {
+	res := suite.SendDeleteAdminRoleRequest(suite.AdminToken, "DNE")
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "admin role", "not found")
+}
+
+func (suite *AdminRoleE2ETestSuite) TestUpdateAdminRole_WithBuiltInAdminRole_ReturnsBadRequest() {
+	res := suite.SendUpdateAdminRoleRequest(suite.AdminToken, controllers.AdminRoleAuditor, handlers.PutAdminRoleBody{})
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "built-in admin role", "cannot be updated")
+}
+
+func (suite *AdminRoleE2ETestSuite) TestDeleteAdminRole_WithBuiltInAdminRole_ReturnsBadRequest() {
+	res := suite.SendDeleteAdminRoleRequest(suite.AdminToken, controllers.AdminRoleAuditor)
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "built-in admin role", "cannot be deleted")
+}
+
+func (suite *AdminRoleE2ETestSuite) TestUpdateUserAdminRoles_WhereUserRankIsNotLess_ReturnsForbidden() {
+	res := suite.SendUpdateUserAdminRolesRequest(suite.AdminToken, suite.Admin.Username)
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+}
+
+func (suite *AdminRoleE2ETestSuite) TestUpdateUserAdminRoles_WhereAdminRoleDoesNotExist_ReturnsBadRequest() {
+	res := suite.SendUpdateUserAdminRolesRequest(suite.AdminToken, suite.User.Username, "DNE")
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "admin role", "not found")
+}
+
+func (suite *AdminRoleE2ETestSuite) TestAdminRole_WithPermissionsScopedToClient_OnlyGrantsAccessToThatClient() {
+	name := "scoped_role"
+
+	//create an admin role that may only read the roles of the one client
+	res := suite.SendCreateAdminRoleRequest(suite.AdminToken, handlers.PostAdminRoleBody{
+		Name:        name,
+		Permissions: []string{models.ScopePermission(models.PermissionRolesRead, suite.ClientID)},
+	})
+	suite.ParseDataResponseOK(res)
+
+	//give it to the user
+	suite.UpdateUserAdminRoles(suite.AdminToken, suite.User.Username, name)
+
+	token := suite.Login(suite.User)
+	defer suite.Logout(token)
+
+	//the user can read the client's roles
+	res = suite.SendGetUserRolesRequest(token, suite.ClientID.String())
+	suite.ParseResponseOK(res, &common.DataResponse{})
+
+	//but not the roles of another client
+	res = suite.SendGetUserRolesRequest(token, uuid.New().String())
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+
+	//or anything else
+	res = suite.SendGetUsersRequest(token)
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+
+	//deleting the admin role takes the permission away immediately
+	res = suite.SendDeleteAdminRoleRequest(suite.AdminToken, name)
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	res = suite.SendGetUserRolesRequest(token, suite.ClientID.String())
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+}
+
+func (suite *AdminRoleE2ETestSuite) TestAdminRole_UpdatingPermissions_ChangesAccess() {
+	name := "updated_role"
+
+	//create an admin role without permissions and give it to the user
+	res := suite.SendCreateAdminRoleRequest(suite.AdminToken, handlers.PostAdminRoleBody{
+		Name: name,
+	})
+	suite.ParseDataResponseOK(res)
+	suite.UpdateUserAdminRoles(suite.AdminToken, suite.User.Username, name)
+
+	token := suite.Login(suite.User)
+	defer suite.Logout(token)
+
+	res = suite.SendGetClientsRequest(token)
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+
+	//grant the role the permission to read clients
+	res = suite.SendUpdateAdminRoleRequest(suite.AdminToken, name, handlers.PutAdminRoleBody{
+		Permissions: []string{models.PermissionClientsRead},
+	})
+	suite.ParseDataResponseOK(res)
+
+	res = suite.SendGetClientsRequest(token)
+	suite.ParseResponseOK(res, &common.DataResponse{})
+
+	//clean up
+	res = suite.SendDeleteAdminRoleRequest(suite.AdminToken, name)
+	suite.ParseAndAssertOKSuccessResponse(res)
+}
+
+func (suite *AdminRoleE2ETestSuite) TestAdminRole_WithPermissionsSessionDoesNotHave_ReturnsForbidden() {
+	name := "admin_role_manager"
+
+	//create an admin role that may only manage admin roles and give it to the user
+	res := suite.SendCreateAdminRoleRequest(suite.AdminToken, handlers.PostAdminRoleBody{
+		Name:        name,
+		Permissions: []string{models.PermissionAdminRolesRead, models.PermissionAdminRolesWrite},
+	})
+	suite.ParseDataResponseOK(res)
+	suite.UpdateUserAdminRoles(suite.AdminToken, suite.User.Username, name)
+
+	token := suite.Login(suite.User)
+	defer suite.Logout(token)
+
+	//the user cannot create an admin role with permissions they do not have
+	res = suite.SendCreateAdminRoleRequest(token, handlers.PostAdminRoleBody{
+		Name:        "escalated_role",
+		Permissions: []string{models.PermissionUsersWrite},
+	})
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+
+	//or give them to their own admin role
+	res = suite.SendUpdateAdminRoleRequest(token, name, handlers.PutAdminRoleBody{
+		Permissions: []string{models.PermissionAdminRolesRead, models.PermissionAdminRolesWrite, models.PermissionUsersWrite},
+	})
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+
+	//but can update it with the permissions they have
+	res = suite.SendUpdateAdminRoleRequest(token, name, handlers.PutAdminRoleBody{
+		Permissions: []string{models.PermissionAdminRolesRead, models.PermissionAdminRolesWrite},
+	})
+	suite.ParseDataResponseOK(res)
+
+	//but not change an admin role with permissions they do not have
+	other := suite.SendCreateAdminRoleRequest(suite.AdminToken, handlers.PostAdminRoleBody{
+		Name:        "other_role",
+		Permissions: []string{models.PermissionAdminRolesRead, models.PermissionAuditRead},
+	})
+	suite.ParseDataResponseOK(other)
+
+	res = suite.SendUpdateAdminRoleRequest(token, "other_role", handlers.PutAdminRoleBody{
+		Permissions: []string{models.PermissionAdminRolesRead},
+	})
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+
+	res = suite.SendDeleteAdminRoleRequest(token, "other_role")
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+
+	res = suite.SendDeleteAdminRoleRequest(suite.AdminToken, "other_role")
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	//or one given to a user who does not have a lesser rank
+	higher := suite.CreateUser(suite.AdminToken, "admin_role_higher", 1)
+	defer suite.DeleteUser(suite.AdminToken, higher.Username)
+	suite.UpdateUserAdminRoles(suite.AdminToken, higher.Username, name)
+
+	res = suite.SendUpdateAdminRoleRequest(token, name, handlers.PutAdminRoleBody{
+		Permissions: []string{models.PermissionAdminRolesRead},
+	})
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+
+	res = suite.SendDeleteAdminRoleRequest(token, name)
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+
+	//clean up
+	res = suite.SendDeleteAdminRoleRequest(suite.AdminToken, name)
+	suite.ParseAndAssertOKSuccessResponse(res)
+}
+
+func TestAdminRoleE2ETestSuite(t *testing.T) {
+	suite.Run(t, &AdminRoleE2ETestSuite{})
+}
@@ -0,0 +1,111 @@
+package e2e_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/router/handlers"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *E2ETestSuite) SendGetAuditEventsRequest(token string, query url.Values) *http.Response {
+	return suite.SendJSONRequest(http.MethodGet, "/audit?"+query.Encode(), token, nil)
+}
+
+type AuditE2ETestSuite struct {
+	E2ETestSuite
+	User  UserCredentials
+	Since time.Time
+}
+
+func (suite *AuditE2ETestSuite) SetupTest() {
+	//audit events are never deleted, so only look at the ones from this test
+	suite.Since = time.Now()
+	suite.User = suite.CreateUser(suite.AdminToken, "audit_user", 0)
+}
+
+func (suite *AuditE2ETestSuite) TearDownTest() {
+	suite.DeleteUser(suite.AdminToken, suite.User.Username)
+
+	//clear the failed logins counted against the test server's ip address
+	suite.SendDeleteLockoutRequest(suite.AdminToken, models.LoginThrottleTypeIP, "127.0.0.1")
+}
+
+func (suite *AuditE2ETestSuite) getAuditEvents(query url.Values) []handlers.AuditEventDataResponse {
+	var body struct {
+		Data []handlers.AuditEventDataResponse `json:"data"`
+	}
+
+	query.Set("since", suite.Since.Format(time.RFC3339Nano))
+
+	res := suite.SendGetAuditEventsRequest(suite.AdminToken, query)
+	suite.ParseResponseOK(res, &body)
+
+	return body.Data
+}
+
+func (suite *AuditE2ETestSuite) TestGetAuditEvents_WithoutPermission_ReturnsForbidden() {
+	token := suite.Login(suite.User)
+	defer suite.Logout(token)
+
+	res := suite.SendGetAuditEventsRequest(token, url.Values{})
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+}
+
+func (suite *AuditE2ETestSuite) TestGetAuditEvents_WithInvalidLimit_ReturnsBadRequest() {
+	res := suite.SendGetAuditEventsRequest(suite.AdminToken, url.Values{"limit": []string{"-1"}})
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "limit")
+}
+
+func (suite *AuditE2ETestSuite) TestGetAuditEvents_IncludesAdministrativeActions() {
+	suite.SendUpdateUserRequest(suite.AdminToken, suite.User.Username, 1)
+
+	events := suite.getAuditEvents(url.Values{
+		"actor":  []string{suite.Admin.Username},
+		"target": []string{suite.User.Username},
+	})
+
+	suite.Require().Len(events, 2)
+	suite.Equal(models.AuditActionUpdateUser, events[0].Action)
+	suite.Equal(models.AuditActionCreateUser, events[1].Action)
+}
+
+func (suite *AuditE2ETestSuite) TestGetAuditEvents_IncludesCompletedAndFailedLogins() {
+	res := suite.SendCreateSessionRequest(suite.User.Username, "wrong password")
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "invalid username and/or password")
+
+	token := suite.Login(suite.User)
+	suite.Logout(token)
+
+	events := suite.getAuditEvents(url.Values{
+		"actor": []string{suite.User.Username},
+	})
+
+	suite.Require().Len(events, 3)
+	suite.Equal(models.AuditActionLogout, events[0].Action)
+	suite.Equal(models.AuditActionLogin, events[1].Action)
+	suite.Equal(models.AuditActionLoginFailed, events[2].Action)
+	suite.Equal("127.0.0.1", events[2].IPAddress)
+}
+
+func (suite *AuditE2ETestSuite) TestGetAuditEvents_WithLimit_ReturnsPage() {
+	token := suite.Login(suite.User)
+	suite.Logout(token)
+
+	events := suite.getAuditEvents(url.Values{
+		"actor":  []string{suite.User.Username},
+		"limit":  []string{"1"},
+		"offset": []string{"1"},
+	})
+
+	suite.Require().Len(events, 1)
+	suite.Equal(models.AuditActionLogin, events[0].Action)
+}
+
+func TestAuditE2ETestSuite(t *testing.T) {
+	suite.Run(t, &AuditE2ETestSuite{})
+}
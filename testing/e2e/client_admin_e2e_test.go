@@ -0,0 +1,208 @@
+package e2e_test
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"testing"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/router/handlers"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *E2ETestSuite) SendGetClientAdminsRequest(token string, clientID string) *http.Response {
+	return suite.SendJSONRequest(http.MethodGet, path.Join("/client", clientID, "admins"), token, nil)
+}
+
+func (suite *E2ETestSuite) SendCreateClientAdminRequest(token string, clientID string, username string, isOwner bool) *http.Response {
+	postClientAdminBody := handlers.PostClientAdminBody{
+		Username: username,
+		IsOwner:  isOwner,
+	}
+	return suite.SendJSONRequest(http.MethodPost, path.Join("/client", clientID, "admin"), token, postClientAdminBody)
+}
+
+func (suite *E2ETestSuite) CreateClientAdmin(token string, clientID uuid.UUID, username string, isOwner bool) {
+	res := suite.SendCreateClientAdminRequest(token, clientID.String(), username, isOwner)
+	suite.ParseAndAssertOKSuccessResponse(res)
+}
+
+func (suite *E2ETestSuite) SendDeleteClientAdminRequest(token string, clientID string, username string) *http.Response {
+	return suite.SendJSONRequest(http.MethodDelete, path.Join("/client", clientID, "admin", username), token, nil)
+}
+
+type ClientAdminE2ETestSuite struct {
+	E2ETestSuite
+	Owner         UserCredentials
+	User          UserCredentials
+	ClientID      uuid.UUID
+	OtherClientID uuid.UUID
+}
+
+func (suite *ClientAdminE2ETestSuite) SetupSuite() {
+	suite.E2ETestSuite.SetupSuite()
+
+	suite.Owner = suite.CreateUser(suite.AdminToken, "client_owner", 0)
+	suite.User = suite.CreateUser(suite.AdminToken, "client_user", 0)
+
+	suite.ClientID = suite.CreateClient(suite.AdminToken, 0, "key.pem")
+	suite.OtherClientID = suite.CreateClient(suite.AdminToken, 0, "key.pem")
+	suite.CreateRoleDefinition(suite.AdminToken, suite.ClientID, "role")
+	suite.CreateRoleDefinition(suite.AdminToken, suite.OtherClientID, "role")
+
+	suite.CreateClientAdmin(suite.AdminToken, suite.ClientID, suite.Owner.Username, true)
+}
+
+func (suite *ClientAdminE2ETestSuite) TearDownSuite() {
+	suite.DeleteClient(suite.AdminToken, suite.ClientID)
+	suite.DeleteClient(suite.AdminToken, suite.OtherClientID)
+	suite.DeleteUser(suite.AdminToken, suite.Owner.Username)
+	suite.DeleteUser(suite.AdminToken, suite.User.Username)
+
+	suite.E2ETestSuite.TearDownSuite()
+}
+
+func (suite *ClientAdminE2ETestSuite) getClientAdmins(token string, clientID uuid.UUID) []handlers.ClientAdminDataResponse {
+	var result struct {
+		Data []handlers.ClientAdminDataResponse `json:"data"`
+	}
+
+	res := suite.SendGetClientAdminsRequest(token, clientID.String())
+	suite.ParseResponseOK(res, &result)
+
+	return result.Data
+}
+
+func (suite *ClientAdminE2ETestSuite) TestGetClientAdmins_WithInvalidSession_ReturnsUnauthorized() {
+	res := suite.SendGetClientAdminsRequest("", suite.ClientID.String())
+	suite.ParseAndAssertErrorResponse(res, http.StatusUnauthorized)
+}
+
+func (suite *ClientAdminE2ETestSuite) TestGetClientAdmins_WithoutPermission_ReturnsForbidden() {
+	token := suite.Login(suite.User)
+	defer suite.Logout(token)
+
+	res := suite.SendGetClientAdminsRequest(token, suite.ClientID.String())
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+}
+
+func (suite *ClientAdminE2ETestSuite) TestGetClientAdmins_WhereClientDoesNotExist_ReturnsBadRequest() {
+	res := suite.SendGetClientAdminsRequest(suite.AdminToken, uuid.New().String())
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "client", "not found")
+}
+
+func (suite *ClientAdminE2ETestSuite) TestCreateClientAdmin_WhereUserIsAlreadyClientAdmin_ReturnsBadRequest() {
+	res := suite.SendCreateClientAdminRequest(suite.AdminToken, suite.ClientID.String(), suite.Owner.Username, false)
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, suite.Owner.Username, "already a client admin")
+}
+
+func (suite *ClientAdminE2ETestSuite) TestCreateClientAdmin_WhereUserDoesNotExist_ReturnsBadRequest() {
+	res := suite.SendCreateClientAdminRequest(suite.AdminToken, suite.ClientID.String(), "DNE", false)
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "user", "not found")
+}
+
+func (suite *ClientAdminE2ETestSuite) TestDeleteClientAdmin_WhereClientAdminDoesNotExist_ReturnsBadRequest() {
+	res := suite.SendDeleteClientAdminRequest(suite.AdminToken, suite.ClientID.String(), suite.User.Username)
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "not a client admin")
+}
+
+func (suite *ClientAdminE2ETestSuite) TestClientAdmin_CanOnlyManageTheirClient() {
+	token := suite.Login(suite.Owner)
+	defer suite.Logout(token)
+
+	//the owner can manage the user-roles of their client, whatever the users' ranks
+	suite.CreateUserRole(token, suite.ClientID, suite.User.Username, "role")
+
+	res := suite.SendGetUserRolesRequest(token, suite.ClientID.String())
+	suite.ParseResponseOK(res, &common.DataResponse{})
+
+	res = suite.SendUpdateUserRoleRequest(token, suite.ClientID.String(), suite.User.Username, "role")
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	suite.DeleteUserRole(token, suite.ClientID, suite.User.Username)
+
+	//and update the client
+	res = suite.SendUpdateClientRequest(token, suite.ClientID.String(), 0, "key.pem")
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	//but not the other client
+	res = suite.SendCreateUserRoleRequest(token, suite.OtherClientID.String(), suite.User.Username, "role")
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+
+	res = suite.SendUpdateClientRequest(token, suite.OtherClientID.String(), 0, "key.pem")
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+
+	//or delete their own client
+	res = suite.SendDeleteClientRequest(token, suite.ClientID.String())
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+}
+
+func (suite *ClientAdminE2ETestSuite) TestClientAdmin_OwnerCanDelegateTheClient() {
+	ownerToken := suite.Login(suite.Owner)
+	defer suite.Logout(ownerToken)
+
+	//the owner makes the user a client admin
+	suite.CreateClientAdmin(ownerToken, suite.ClientID, suite.User.Username, false)
+
+	suite.ElementsMatch([]handlers.ClientAdminDataResponse{
+		{
+			PostClientAdminBody: handlers.PostClientAdminBody{
+				Username: suite.Owner.Username,
+				IsOwner:  true,
+			},
+		},
+		{
+			PostClientAdminBody: handlers.PostClientAdminBody{
+				Username: suite.User.Username,
+				IsOwner:  false,
+			},
+		},
+	}, suite.getClientAdmins(ownerToken, suite.ClientID))
+
+	userToken := suite.Login(suite.User)
+	defer suite.Logout(userToken)
+
+	//the new client admin can see the client admins, but only owners can change them
+	suite.getClientAdmins(userToken, suite.ClientID)
+
+	res := suite.SendDeleteClientAdminRequest(userToken, suite.ClientID.String(), suite.Owner.Username)
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+
+	//removing the client admin takes their access away immediately
+	res = suite.SendDeleteClientAdminRequest(ownerToken, suite.ClientID.String(), suite.User.Username)
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	res = suite.SendGetClientAdminsRequest(userToken, suite.ClientID.String())
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+}
+
+func (suite *ClientAdminE2ETestSuite) TestClientAdmin_DelegationsAreAudited() {
+	ownerToken := suite.Login(suite.Owner)
+	defer suite.Logout(ownerToken)
+
+	suite.CreateClientAdmin(ownerToken, suite.ClientID, suite.User.Username, false)
+
+	res := suite.SendDeleteClientAdminRequest(ownerToken, suite.ClientID.String(), suite.User.Username)
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	var body struct {
+		Data []handlers.AuditEventDataResponse `json:"data"`
+	}
+	res = suite.SendGetAuditEventsRequest(suite.AdminToken, url.Values{
+		"actor":  []string{suite.Owner.Username},
+		"target": []string{suite.ClientID.String() + "/" + suite.User.Username},
+	})
+	suite.ParseResponseOK(res, &body)
+
+	suite.Require().GreaterOrEqual(len(body.Data), 2)
+	suite.Equal(models.AuditActionDeleteClientAdmin, body.Data[0].Action)
+	suite.Equal(models.AuditActionCreateClientAdmin, body.Data[1].Action)
+}
+
+func TestClientAdminE2ETestSuite(t *testing.T) {
+	suite.Run(t, &ClientAdminE2ETestSuite{})
+}
@@ -75,7 +75,7 @@ func (suite *ClientE2ETestSuite) TestGetClients_WithInvalidSession_ReturnsUnauth
 	suite.ParseAndAssertErrorResponse(res, http.StatusUnauthorized)
 }
 
-func (suite *ClientE2ETestSuite) TestGetClients_WithRankLessThanMin_ReturnsForbidden() {
+func (suite *ClientE2ETestSuite) TestGetClients_WithoutPermission_ReturnsForbidden() {
 	//login
 	token := suite.Login(suite.User)
 
@@ -92,7 +92,7 @@ func (suite *ClientE2ETestSuite) TestCreateClient_WithInvalidSession_ReturnsUnau
 	suite.ParseAndAssertErrorResponse(res, http.StatusUnauthorized)
 }
 
-func (suite *ClientE2ETestSuite) TestCreateClient_WithRankLessThanMin_ReturnsForbidden() {
+func (suite *ClientE2ETestSuite) TestCreateClient_WithoutPermission_ReturnsForbidden() {
 	//login
 	token := suite.Login(suite.User)
 
@@ -114,7 +114,7 @@ func (suite *ClientE2ETestSuite) TestUpdateClient_WithInvalidSession_ReturnsUnau
 	suite.ParseAndAssertErrorResponse(res, http.StatusUnauthorized)
 }
 
-func (suite *ClientE2ETestSuite) TestUpdateClient_WithRankLessThanMin_ReturnsForbidden() {
+func (suite *ClientE2ETestSuite) TestUpdateClient_WithoutPermission_ReturnsForbidden() {
 	//login
 	token := suite.Login(suite.User)
 
@@ -151,7 +151,7 @@ func (suite *ClientE2ETestSuite) TestDeleteClient_WithInvalidSession_ReturnsUnau
 	suite.ParseAndAssertErrorResponse(res, http.StatusUnauthorized)
 }
 
-func (suite *ClientE2ETestSuite) TestDeleteClient_WithRankLessThanMin_ReturnsForbidden() {
+func (suite *ClientE2ETestSuite) TestDeleteClient_WithoutPermission_ReturnsForbidden() {
 	//login
 	token := suite.Login(suite.User)
 
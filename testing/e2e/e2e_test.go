@@ -8,7 +8,6 @@ import (
 	"os"
 
 	"github.com/mhogar/amber/config"
-	"github.com/mhogar/amber/dependencies"
 	"github.com/mhogar/amber/server"
 	"github.com/mhogar/amber/testing/helpers"
 
@@ -22,6 +21,7 @@ type UserCredentials struct {
 
 type E2ETestSuite struct {
 	helpers.CustomSuite
+	Runner server.Runner
 	Server *httptest.Server
 
 	AdminToken string
@@ -36,25 +36,26 @@ func (suite *E2ETestSuite) SetupSuite() {
 	os.Setenv("FIRESTORE_EMULATOR_HOST", "localhost:3000")
 	fmt.Println("Data Adapter: " + config.GetDataAdapter())
 
-	//create the test server
-	runner := server.CreateHTTPTestServerRunner(dependencies.ResolveRouterFactory())
-	suite.Server = runner.Server.(*server.HTTPTestServer).Server
-
-	//run the server
-	err = runner.Run()
-	suite.Require().NoError(err)
-
-	//login as the max admin
 	suite.Admin = UserCredentials{
 		Username: "admin",
 		Password: "Admin123!",
 	}
+
+	//start the whole app on a test server
+	suite.Runner, err = helpers.StartTestApp(suite.Admin.Username, suite.Admin.Password, 10)
+	suite.Require().NoError(err)
+	suite.Server = suite.Runner.Server.(*server.HTTPTestServer).Server
+
+	//login as the max admin
 	suite.AdminToken = suite.Login(suite.Admin)
 }
 
 func (suite *E2ETestSuite) TearDownSuite() {
 	suite.Logout(suite.AdminToken)
-	suite.Server.Close()
+
+	//shut down the server and close the data adapter
+	err := suite.Runner.Shutdown()
+	suite.Require().NoError(err)
 }
 
 func (suite *E2ETestSuite) SendRequest(req *http.Request) *http.Response {
@@ -0,0 +1,281 @@
+package e2e_test
+
+import (
+	"net/http"
+	"path"
+	"testing"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/router/handlers"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *E2ETestSuite) SendGetGroupsRequest(token string) *http.Response {
+	return suite.SendJSONRequest(http.MethodGet, "/groups", token, nil)
+}
+
+func (suite *E2ETestSuite) SendCreateGroupRequest(token string, name string, priority int) *http.Response {
+	postGroupBody := handlers.PostGroupBody{
+		Name:     name,
+		Priority: priority,
+	}
+	return suite.SendJSONRequest(http.MethodPost, "/group", token, postGroupBody)
+}
+
+func (suite *E2ETestSuite) CreateGroup(token string, name string, priority int) {
+	res := suite.SendCreateGroupRequest(token, name, priority)
+	suite.ParseAndAssertOKSuccessResponse(res)
+}
+
+func (suite *E2ETestSuite) SendUpdateGroupRequest(token string, name string, body handlers.PutGroupBody) *http.Response {
+	return suite.SendJSONRequest(http.MethodPut, path.Join("/group", name), token, body)
+}
+
+func (suite *E2ETestSuite) SendDeleteGroupRequest(token string, name string) *http.Response {
+	return suite.SendJSONRequest(http.MethodDelete, path.Join("/group", name), token, nil)
+}
+
+func (suite *E2ETestSuite) DeleteGroup(token string, name string) {
+	res := suite.SendDeleteGroupRequest(token, name)
+	suite.ParseAndAssertOKSuccessResponse(res)
+}
+
+func (suite *E2ETestSuite) SendGetGroupMembersRequest(token string, name string) *http.Response {
+	return suite.SendJSONRequest(http.MethodGet, path.Join("/group", name, "members"), token, nil)
+}
+
+func (suite *E2ETestSuite) SendCreateGroupMemberRequest(token string, name string, username string) *http.Response {
+	postGroupMemberBody := handlers.PostGroupMemberBody{
+		Username: username,
+	}
+	return suite.SendJSONRequest(http.MethodPost, path.Join("/group", name, "member"), token, postGroupMemberBody)
+}
+
+func (suite *E2ETestSuite) SendDeleteGroupMemberRequest(token string, name string, username string) *http.Response {
+	return suite.SendJSONRequest(http.MethodDelete, path.Join("/group", name, "member", username), token, nil)
+}
+
+func (suite *E2ETestSuite) SendGetGroupRolesRequest(token string, clientID string) *http.Response {
+	return suite.SendJSONRequest(http.MethodGet, path.Join("/client", clientID, "group-roles"), token, nil)
+}
+
+func (suite *E2ETestSuite) SendCreateGroupRoleRequest(token string, clientID string, group string, roles ...string) *http.Response {
+	postGroupRoleBody := handlers.PostGroupRoleBody{
+		Group: group,
+		Roles: roles,
+	}
+	return suite.SendJSONRequest(http.MethodPost, path.Join("/client", clientID, "group-role"), token, postGroupRoleBody)
+}
+
+func (suite *E2ETestSuite) SendUpdateGroupRoleRequest(token string, clientID string, group string, roles ...string) *http.Response {
+	putGroupRoleBody := handlers.PutGroupRoleBody{
+		Roles: roles,
+	}
+	return suite.SendJSONRequest(http.MethodPut, path.Join("/client", clientID, "group-role", group), token, putGroupRoleBody)
+}
+
+func (suite *E2ETestSuite) SendDeleteGroupRoleRequest(token string, clientID string, group string) *http.Response {
+	return suite.SendJSONRequest(http.MethodDelete, path.Join("/client", clientID, "group-role", group), token, nil)
+}
+
+func (suite *E2ETestSuite) SendGetUserRoleAssignmentsRequest(token string, clientID string, username string) *http.Response {
+	return suite.SendJSONRequest(http.MethodGet, path.Join("/client", clientID, "role", username, "effective"), token, nil)
+}
+
+type GroupE2ETestSuite struct {
+	E2ETestSuite
+	User     UserCredentials
+	ClientID uuid.UUID
+}
+
+func (suite *GroupE2ETestSuite) SetupSuite() {
+	suite.E2ETestSuite.SetupSuite()
+
+	suite.User = suite.CreateUser(suite.AdminToken, "group_user", 0)
+
+	suite.ClientID = suite.CreateClient(suite.AdminToken, 0, "key.pem")
+	suite.CreateRoleDefinition(suite.AdminToken, suite.ClientID, "reader")
+	suite.CreateRoleDefinition(suite.AdminToken, suite.ClientID, "writer")
+	suite.CreateRoleDefinition(suite.AdminToken, suite.ClientID, "owner")
+}
+
+func (suite *GroupE2ETestSuite) TearDownSuite() {
+	suite.DeleteClient(suite.AdminToken, suite.ClientID)
+	suite.DeleteUser(suite.AdminToken, suite.User.Username)
+
+	suite.E2ETestSuite.TearDownSuite()
+}
+
+func (suite *GroupE2ETestSuite) getUserRoleAssignments(token string) handlers.UserRoleAssignmentsDataResponse {
+	var result struct {
+		Data handlers.UserRoleAssignmentsDataResponse `json:"data"`
+	}
+
+	res := suite.SendGetUserRoleAssignmentsRequest(token, suite.ClientID.String(), suite.User.Username)
+	suite.ParseResponseOK(res, &result)
+
+	return result.Data
+}
+
+func (suite *GroupE2ETestSuite) TestGetGroups_WithInvalidSession_ReturnsUnauthorized() {
+	res := suite.SendGetGroupsRequest("")
+	suite.ParseAndAssertErrorResponse(res, http.StatusUnauthorized)
+}
+
+func (suite *GroupE2ETestSuite) TestGetGroups_WithoutPermission_ReturnsForbidden() {
+	token := suite.Login(suite.User)
+	defer suite.Logout(token)
+
+	res := suite.SendGetGroupsRequest(token)
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+}
+
+func (suite *GroupE2ETestSuite) TestCreateGroup_WithInvalidSession_ReturnsUnauthorized() {
+	res := suite.SendCreateGroupRequest("", "group", 0)
+	suite.ParseAndAssertErrorResponse(res, http.StatusUnauthorized)
+}
+
+func (suite *GroupE2ETestSuite) TestCreateGroup_WithoutPermission_ReturnsForbidden() {
+	token := suite.Login(suite.User)
+	defer suite.Logout(token)
+
+	res := suite.SendCreateGroupRequest(token, "group", 0)
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+}
+
+func (suite *GroupE2ETestSuite) TestCreateGroupMember_WhereGroupDoesNotExist_ReturnsBadRequest() {
+	res := suite.SendCreateGroupMemberRequest(suite.AdminToken, "DNE", suite.User.Username)
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "group", "not found")
+}
+
+func (suite *GroupE2ETestSuite) TestCreateGroupRole_WhereGroupDoesNotExist_ReturnsBadRequest() {
+	res := suite.SendCreateGroupRoleRequest(suite.AdminToken, suite.ClientID.String(), "DNE", "reader")
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "group", "not found")
+}
+
+func (suite *GroupE2ETestSuite) TestGetUserRoleAssignments_WithInvalidSession_ReturnsUnauthorized() {
+	res := suite.SendGetUserRoleAssignmentsRequest("", suite.ClientID.String(), suite.User.Username)
+	suite.ParseAndAssertErrorResponse(res, http.StatusUnauthorized)
+}
+
+func (suite *GroupE2ETestSuite) TestGroupLifecycle() {
+	//create the groups
+	suite.CreateGroup(suite.AdminToken, "readers", 1)
+	suite.CreateGroup(suite.AdminToken, "writers", 5)
+
+	var groups struct {
+		Data []handlers.GroupDataResponse `json:"data"`
+	}
+	res := suite.SendGetGroupsRequest(suite.AdminToken)
+	suite.ParseResponseOK(res, &groups)
+
+	suite.Contains(groups.Data, handlers.GroupDataResponse{
+		PostGroupBody: handlers.PostGroupBody{
+			Name:     "writers",
+			Priority: 5,
+		},
+	})
+
+	res = suite.SendCreateGroupRequest(suite.AdminToken, "readers", 0)
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "readers", "already exists")
+
+	//add the user to both groups
+	for _, group := range []string{"readers", "writers"} {
+		res = suite.SendCreateGroupMemberRequest(suite.AdminToken, group, suite.User.Username)
+		suite.ParseAndAssertOKSuccessResponse(res)
+	}
+
+	var members struct {
+		Data []handlers.PostGroupMemberBody `json:"data"`
+	}
+	res = suite.SendGetGroupMembersRequest(suite.AdminToken, "readers")
+	suite.ParseResponseOK(res, &members)
+	suite.Equal([]handlers.PostGroupMemberBody{{Username: suite.User.Username}}, members.Data)
+
+	//give the groups roles for the client
+	res = suite.SendCreateGroupRoleRequest(suite.AdminToken, suite.ClientID.String(), "readers", "reader")
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	res = suite.SendCreateGroupRoleRequest(suite.AdminToken, suite.ClientID.String(), "writers", "reader", "writer")
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	res = suite.SendCreateGroupRoleRequest(suite.AdminToken, suite.ClientID.String(), "writers", "reader")
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "writers", "already has a role")
+
+	res = suite.SendGetGroupRolesRequest(suite.AdminToken, suite.ClientID.String())
+	suite.ParseResponseOK(res, &common.DataResponse{})
+
+	//the highest priority group decides the user's roles
+	assignments := suite.getUserRoleAssignments(suite.AdminToken)
+	suite.Equal(suite.User.Username, assignments.Username)
+	suite.Equal([]string{"reader", "writer"}, assignments.EffectiveRoles)
+	suite.Equal([]handlers.RoleAssignmentDataResponse{
+		{
+			Source:   models.RoleAssignmentSourceGroup,
+			Group:    "writers",
+			Priority: 5,
+			Roles:    []string{"reader", "writer"},
+		},
+		{
+			Source:   models.RoleAssignmentSourceGroup,
+			Group:    "readers",
+			Priority: 1,
+			Roles:    []string{"reader"},
+		},
+	}, assignments.Assignments)
+
+	//raising a group's priority changes the user's roles
+	res = suite.SendUpdateGroupRequest(suite.AdminToken, "readers", handlers.PutGroupBody{Priority: 10})
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	suite.Equal([]string{"reader"}, suite.getUserRoleAssignments(suite.AdminToken).EffectiveRoles)
+
+	//a direct user-role overrides the groups
+	suite.CreateUserRole(suite.AdminToken, suite.ClientID, suite.User.Username, "owner")
+
+	assignments = suite.getUserRoleAssignments(suite.AdminToken)
+	suite.Equal([]string{"owner"}, assignments.EffectiveRoles)
+	suite.Require().Len(assignments.Assignments, 3)
+	suite.Equal(models.RoleAssignmentSourceUser, assignments.Assignments[0].Source)
+
+	suite.DeleteUserRole(suite.AdminToken, suite.ClientID, suite.User.Username)
+
+	//roles given to groups cannot be deleted
+	res = suite.SendDeleteRoleDefinitionRequest(suite.AdminToken, suite.ClientID.String(), "writer")
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "writer", "still given to 1 groups")
+
+	res = suite.SendUpdateGroupRoleRequest(suite.AdminToken, suite.ClientID.String(), "writers", "reader")
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	res = suite.SendDeleteRoleDefinitionRequest(suite.AdminToken, suite.ClientID.String(), "writer")
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	//removing the user from a group removes its roles
+	res = suite.SendDeleteGroupMemberRequest(suite.AdminToken, "readers", suite.User.Username)
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	assignments = suite.getUserRoleAssignments(suite.AdminToken)
+	suite.Require().Len(assignments.Assignments, 1)
+	suite.Equal("writers", assignments.Assignments[0].Group)
+
+	//deleting a group removes its group-roles
+	res = suite.SendDeleteGroupRoleRequest(suite.AdminToken, suite.ClientID.String(), "readers")
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	suite.DeleteGroup(suite.AdminToken, "readers")
+	suite.DeleteGroup(suite.AdminToken, "writers")
+
+	assignments = suite.getUserRoleAssignments(suite.AdminToken)
+	suite.Empty(assignments.EffectiveRoles)
+	suite.Empty(assignments.Assignments)
+
+	//restore the deleted role definition for the other tests
+	suite.CreateRoleDefinition(suite.AdminToken, suite.ClientID, "writer")
+}
+
+func TestGroupE2ETestSuite(t *testing.T) {
+	suite.Run(t, &GroupE2ETestSuite{})
+}
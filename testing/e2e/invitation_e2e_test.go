@@ -0,0 +1,187 @@
+package e2e_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"testing"
+
+	"github.com/mhogar/amber/controllers"
+	"github.com/mhogar/amber/router/handlers"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *E2ETestSuite) SendCreateInvitationRequest(token string, body handlers.PostInvitationBody) *http.Response {
+	return suite.SendJSONRequest(http.MethodPost, "/invitation", token, body)
+}
+
+func (suite *E2ETestSuite) SendDeleteInvitationRequest(token string, id string) *http.Response {
+	return suite.SendJSONRequest(http.MethodDelete, path.Join("/invitation", id), token, nil)
+}
+
+func (suite *E2ETestSuite) SendAcceptInvitationRequest(token string, username string, password string) *http.Response {
+	values := url.Values{
+		"token":            []string{token},
+		"username":         []string{username},
+		"password":         []string{password},
+		"confirm_password": []string{password},
+	}
+	return suite.SendFormRequest(http.MethodPost, "/invitation/accept", "", values)
+}
+
+type InvitationE2ETestSuite struct {
+	E2ETestSuite
+	User     UserCredentials
+	ClientID uuid.UUID
+}
+
+func (suite *InvitationE2ETestSuite) SetupSuite() {
+	suite.E2ETestSuite.SetupSuite()
+
+	suite.User = suite.CreateUser(suite.AdminToken, "inviting_user", 5)
+	suite.UpdateUserAdminRoles(suite.AdminToken, suite.User.Username, controllers.AdminRoleUserAdmin)
+	suite.ClientID = suite.CreateClient(suite.AdminToken, 0, "key.pem")
+	suite.CreateRoleDefinition(suite.AdminToken, suite.ClientID, "role")
+}
+
+func (suite *InvitationE2ETestSuite) TearDownSuite() {
+	suite.DeleteClient(suite.AdminToken, suite.ClientID)
+	suite.DeleteUser(suite.AdminToken, suite.User.Username)
+
+	suite.E2ETestSuite.TearDownSuite()
+}
+
+func (suite *InvitationE2ETestSuite) readBody(res *http.Response) string {
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	suite.Require().NoError(err)
+
+	return string(body)
+}
+
+// createInvitation creates the invitation and returns its id and the token from its link.
+func (suite *InvitationE2ETestSuite) createInvitation(body handlers.PostInvitationBody) (string, string) {
+	var result struct {
+		Data handlers.InvitationDataResponse `json:"data"`
+	}
+
+	res := suite.SendCreateInvitationRequest(suite.AdminToken, body)
+	suite.ParseResponseOK(res, &result)
+
+	link, err := url.Parse(result.Data.Link)
+	suite.Require().NoError(err)
+
+	return result.Data.ID, link.Query().Get("token")
+}
+
+func (suite *InvitationE2ETestSuite) TestCreateInvitation_WithInvalidSession_ReturnsUnauthorized() {
+	res := suite.SendCreateInvitationRequest("", handlers.PostInvitationBody{})
+	suite.ParseAndAssertErrorResponse(res, http.StatusUnauthorized)
+}
+
+func (suite *InvitationE2ETestSuite) TestCreateInvitation_WithRankGreaterThanSession_ReturnsForbidden() {
+	token := suite.Login(suite.User)
+
+	res := suite.SendCreateInvitationRequest(token, handlers.PostInvitationBody{
+		Rank: 6,
+	})
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+
+	suite.Logout(token)
+}
+
+func (suite *InvitationE2ETestSuite) TestCreateInvitation_WithInvalidClientID_ReturnsBadRequest() {
+	res := suite.SendCreateInvitationRequest(suite.AdminToken, handlers.PostInvitationBody{
+		Roles: []handlers.InvitationRoleBody{
+			{
+				ClientID: "invalid",
+				Role:     "role",
+			},
+		},
+	})
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "client id", "invalid format")
+}
+
+func (suite *InvitationE2ETestSuite) TestDeleteInvitation_WhereInvitationNotFound_ReturnsBadRequest() {
+	res := suite.SendDeleteInvitationRequest(suite.AdminToken, uuid.New().String())
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "invitation", "not found")
+}
+
+func (suite *InvitationE2ETestSuite) TestDeleteInvitation_WithRankLessThanInvitation_ReturnsForbidden() {
+	id, _ := suite.createInvitation(handlers.PostInvitationBody{
+		Rank: 6,
+	})
+
+	token := suite.Login(suite.User)
+
+	res := suite.SendDeleteInvitationRequest(token, id)
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+
+	suite.Logout(token)
+
+	//clean up
+	res = suite.SendDeleteInvitationRequest(suite.AdminToken, id)
+	suite.ParseAndAssertOKSuccessResponse(res)
+}
+
+func (suite *InvitationE2ETestSuite) TestAcceptInvitation_WithRevokedInvitation_ShowsError() {
+	id, token := suite.createInvitation(handlers.PostInvitationBody{})
+
+	res := suite.SendDeleteInvitationRequest(suite.AdminToken, id)
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	res = suite.SendAcceptInvitationRequest(token, "invited_user", "Password123!")
+	suite.Equal(http.StatusBadRequest, res.StatusCode)
+	suite.Contains(suite.readBody(res), "invalid or has expired")
+}
+
+func (suite *InvitationE2ETestSuite) TestAcceptInvitation_CreatesUserWithRoles() {
+	invitee := UserCredentials{
+		Username: "invited_user",
+		Password: "Password123!",
+	}
+
+	_, token := suite.createInvitation(handlers.PostInvitationBody{
+		Email: "invited_user@example.com",
+		Rank:  1,
+		Roles: []handlers.InvitationRoleBody{
+			{
+				ClientID: suite.ClientID.String(),
+				Role:     "role",
+			},
+		},
+	})
+
+	//password must meet the criteria, and the invitation is kept when it doesn't
+	res := suite.SendAcceptInvitationRequest(token, invitee.Username, "password")
+	suite.Equal(http.StatusBadRequest, res.StatusCode)
+	suite.Contains(suite.readBody(res), "password")
+
+	//accept the invitation
+	res = suite.SendAcceptInvitationRequest(token, invitee.Username, invitee.Password)
+	suite.Equal(http.StatusOK, res.StatusCode)
+	suite.Contains(suite.readBody(res), "account has been created")
+
+	//invitee can login and has the role
+	suite.Logout(suite.Login(invitee))
+
+	res = suite.SendCreateUserRoleRequest(suite.AdminToken, suite.ClientID.String(), invitee.Username, "role")
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "user", "already has a role", "client")
+
+	//invitation can only be accepted once
+	res = suite.SendAcceptInvitationRequest(token, "other_user", invitee.Password)
+	suite.Equal(http.StatusBadRequest, res.StatusCode)
+	suite.Contains(suite.readBody(res), "invalid or has expired")
+
+	//clean up
+	suite.DeleteUserRole(suite.AdminToken, suite.ClientID, invitee.Username)
+	suite.DeleteUser(suite.AdminToken, invitee.Username)
+}
+
+func TestInvitationE2ETestSuite(t *testing.T) {
+	suite.Run(t, &InvitationE2ETestSuite{})
+}
@@ -0,0 +1,97 @@
+package e2e_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/mhogar/amber/config"
+	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/router/handlers"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *E2ETestSuite) SendGetLockoutsRequest(token string) *http.Response {
+	return suite.SendJSONRequest(http.MethodGet, "/lockouts", token, nil)
+}
+
+func (suite *E2ETestSuite) SendDeleteLockoutRequest(token string, throttleType string, key string) *http.Response {
+	return suite.SendJSONRequest(http.MethodDelete, "/lockout/"+throttleType+"/"+url.PathEscape(key), token, nil)
+}
+
+type LockoutE2ETestSuite struct {
+	E2ETestSuite
+	User UserCredentials
+}
+
+func (suite *LockoutE2ETestSuite) SetupTest() {
+	suite.User = suite.CreateUser(suite.AdminToken, "lockout_user", 0)
+}
+
+func (suite *LockoutE2ETestSuite) TearDownTest() {
+	suite.DeleteUser(suite.AdminToken, suite.User.Username)
+
+	//the test server's failures are also counted against its ip address, so clear them so other tests aren't locked out
+	suite.SendDeleteLockoutRequest(suite.AdminToken, models.LoginThrottleTypeIP, "127.0.0.1")
+}
+
+func (suite *LockoutE2ETestSuite) getLockouts() []handlers.LockoutDataResponse {
+	var body struct {
+		Data []handlers.LockoutDataResponse `json:"data"`
+	}
+
+	res := suite.SendGetLockoutsRequest(suite.AdminToken)
+	suite.ParseResponseOK(res, &body)
+
+	return body.Data
+}
+
+func (suite *LockoutE2ETestSuite) TestGetLockouts_WithoutPermission_ReturnsForbidden() {
+	token := suite.Login(suite.User)
+	defer suite.Logout(token)
+
+	res := suite.SendGetLockoutsRequest(token)
+	suite.ParseAndAssertInsufficientPermissionsErrorResponse(res)
+}
+
+func (suite *LockoutE2ETestSuite) TestDeleteLockout_WhereLockoutIsNotFound_ReturnsBadRequest() {
+	res := suite.SendDeleteLockoutRequest(suite.AdminToken, models.LoginThrottleTypeUsername, "DNE")
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "no lockout found")
+}
+
+func (suite *LockoutE2ETestSuite) TestCreateSession_WithTooManyFailedAttempts_LocksUserOutUntilCleared() {
+	maxAttempts := config.GetLockoutConfig().MaxUserAttempts
+	suite.Require().NotZero(maxAttempts)
+
+	//fail until locked out
+	for i := 0; i < maxAttempts; i++ {
+		res := suite.SendCreateSessionRequest(suite.User.Username, "incorrect")
+		suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "invalid username and/or password")
+	}
+
+	//even the correct password is refused
+	res := suite.SendCreateSessionRequest(suite.User.Username, suite.User.Password)
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "too many failed login attempts")
+
+	//the lockout is visible to admins
+	found := false
+	for _, lockout := range suite.getLockouts() {
+		if lockout.Type == models.LoginThrottleTypeUsername && lockout.Key == suite.User.Username {
+			suite.Equal(maxAttempts, lockout.FailedAttempts)
+			found = true
+		}
+	}
+	suite.True(found)
+
+	//clearing the lockout lets the user log in again
+	res = suite.SendDeleteLockoutRequest(suite.AdminToken, models.LoginThrottleTypeUsername, suite.User.Username)
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	token := suite.Login(suite.User)
+	suite.Logout(token)
+}
+
+func TestLockoutE2ETestSuite(t *testing.T) {
+	suite.Run(t, &LockoutE2ETestSuite{})
+}
@@ -0,0 +1,121 @@
+package e2e_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/config"
+	"github.com/mhogar/amber/controllers"
+	"github.com/mhogar/amber/router/handlers"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *E2ETestSuite) SendCompleteChangePasswordSessionRequest(challenge string, newPassword string) *http.Response {
+	body := handlers.PostSessionBody{
+		Challenge:   challenge,
+		NewPassword: newPassword,
+	}
+	return suite.SendJSONRequest(http.MethodPost, "/session", "", body)
+}
+
+type PasswordPolicyE2ETestSuite struct {
+	E2ETestSuite
+	User     UserCredentials
+	Criteria config.PasswordCriteriaConfig
+}
+
+func (suite *PasswordPolicyE2ETestSuite) SetupTest() {
+	suite.Criteria = config.GetPasswordCriteriaConfig()
+	suite.User = suite.CreateUser(suite.AdminToken, "policy_user", 0)
+}
+
+func (suite *PasswordPolicyE2ETestSuite) TearDownTest() {
+	viper.Set("password_criteria", suite.Criteria)
+	suite.DeleteUser(suite.AdminToken, suite.User.Username)
+}
+
+// setCriteria updates the password criteria with the provided function, keeping the rest of the test config.
+func (suite *PasswordPolicyE2ETestSuite) setCriteria(update func(criteria *config.PasswordCriteriaConfig)) {
+	criteria := suite.Criteria
+	update(&criteria)
+	viper.Set("password_criteria", criteria)
+}
+
+func (suite *PasswordPolicyE2ETestSuite) TestUpdatePassword_CannotReuseRecentPasswords() {
+	token := suite.Login(suite.User)
+	defer suite.Logout(token)
+
+	//the current password can't be reused
+	res := suite.SendUpdatePasswordRequest(token, suite.User.Password, suite.User.Password)
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "password cannot match any of the last 3 passwords")
+
+	//neither can a replaced one still in the history
+	res = suite.SendUpdatePasswordRequest(token, suite.User.Password, "NewPassword1!")
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	res = suite.SendUpdatePasswordRequest(token, "NewPassword1!", suite.User.Password)
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "password cannot match any of the last 3 passwords")
+
+	//but it can be once it is older than the history
+	res = suite.SendUpdatePasswordRequest(token, "NewPassword1!", "NewPassword2!")
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	res = suite.SendUpdatePasswordRequest(token, "NewPassword2!", "NewPassword3!")
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	res = suite.SendUpdatePasswordRequest(token, "NewPassword3!", suite.User.Password)
+	suite.ParseAndAssertOKSuccessResponse(res)
+}
+
+func (suite *PasswordPolicyE2ETestSuite) TestUpdatePassword_WithinMinAge_ReturnsBadRequest() {
+	suite.setCriteria(func(criteria *config.PasswordCriteriaConfig) {
+		criteria.MinAge = 3600
+	})
+
+	token := suite.Login(suite.User)
+	res := suite.SendUpdatePasswordRequest(token, suite.User.Password, "NewPassword123!")
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "password was changed too recently")
+	suite.Logout(token)
+
+	//admins can still set the password
+	res = suite.SendUpdateUserPasswordRequest(suite.AdminToken, suite.User.Username, "NewPassword123!")
+	suite.ParseAndAssertOKSuccessResponse(res)
+}
+
+func (suite *PasswordPolicyE2ETestSuite) TestCreateSession_WithExpiredPassword_RequiresNewPassword() {
+	suite.setCriteria(func(criteria *config.PasswordCriteriaConfig) {
+		criteria.MaxAge = 1
+	})
+	time.Sleep(2 * time.Second)
+
+	//the password is correct but expired
+	res := suite.SendCreateSessionRequest(suite.User.Username, suite.User.Password)
+	data := suite.ParseDataResponseOK(res)
+	suite.Equal(controllers.ChallengeStepChangePassword, data["step"])
+	challenge := data["challenge"].(string)
+
+	//the new password still has to follow the policy
+	res = suite.SendCompleteChangePasswordSessionRequest(challenge, suite.User.Password)
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "password cannot match any of the last 3 passwords")
+
+	//a valid new password completes the login
+	res = suite.SendCompleteChangePasswordSessionRequest(challenge, "NewPassword123!")
+	token := suite.ParseDataResponseOK(res)["token"].(string)
+	suite.Logout(token)
+
+	//the challenge can't be used again now that the password has changed
+	res = suite.SendCompleteChangePasswordSessionRequest(challenge, "OtherPassword123!")
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "change password challenge invalid or expired")
+
+	//the new password logs in normally
+	suite.setCriteria(func(criteria *config.PasswordCriteriaConfig) {})
+	token = suite.Login(UserCredentials{Username: suite.User.Username, Password: "NewPassword123!"})
+	suite.Logout(token)
+}
+
+func TestPasswordPolicyE2ETestSuite(t *testing.T) {
+	suite.Run(t, &PasswordPolicyE2ETestSuite{})
+}
@@ -0,0 +1,141 @@
+package e2e_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/mhogar/amber/config"
+	"github.com/mhogar/amber/router/handlers"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *E2ETestSuite) SendForgotPasswordRequest(email string) *http.Response {
+	values := url.Values{
+		"email": []string{email},
+	}
+	return suite.SendFormRequest(http.MethodPost, "/password/forgot", "", values)
+}
+
+func (suite *E2ETestSuite) SendResetPasswordRequest(token string, password string) *http.Response {
+	values := url.Values{
+		"token":            []string{token},
+		"password":         []string{password},
+		"confirm_password": []string{password},
+	}
+	return suite.SendFormRequest(http.MethodPost, "/password/reset", "", values)
+}
+
+type PasswordResetE2ETestSuite struct {
+	E2ETestSuite
+	User  UserCredentials
+	Email string
+}
+
+func (suite *PasswordResetE2ETestSuite) SetupTest() {
+	suite.User = suite.CreateUser(suite.AdminToken, "password_reset_user", 0)
+	suite.Email = "password_reset_user@example.com"
+
+	res := suite.SendUpdateUserProfileRequest(suite.AdminToken, suite.User.Username, handlers.PutUserBody{
+		Email: &suite.Email,
+	})
+	suite.ParseAndAssertOKSuccessResponse(res)
+}
+
+func (suite *PasswordResetE2ETestSuite) TearDownTest() {
+	suite.DeleteUser(suite.AdminToken, suite.User.Username)
+}
+
+func (suite *PasswordResetE2ETestSuite) readBody(res *http.Response) string {
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	suite.Require().NoError(err)
+
+	return string(body)
+}
+
+// lastResetLink reads the most recent reset link written by the file mailer.
+func (suite *PasswordResetE2ETestSuite) lastResetLink() *url.URL {
+	mail, err := ioutil.ReadFile(config.GetAppRoot(config.GetMailConfig().File))
+	suite.Require().NoError(err)
+
+	matches := regexp.MustCompile(`\S+/password/reset\?token=\S+`).FindAllString(string(mail), -1)
+	suite.Require().NotEmpty(matches)
+
+	link, err := url.Parse(matches[len(matches)-1])
+	suite.Require().NoError(err)
+
+	return link
+}
+
+// lastResetToken reads the token from the most recent reset link written by the file mailer.
+func (suite *PasswordResetE2ETestSuite) lastResetToken() string {
+	return suite.lastResetLink().Query().Get("token")
+}
+
+func (suite *PasswordResetE2ETestSuite) TestForgotPassword_WhereEmailIsNotFound_ShowsSameMessage() {
+	res := suite.SendForgotPasswordRequest("dne@example.com")
+	suite.Equal(http.StatusOK, res.StatusCode)
+	suite.Contains(suite.readBody(res), "has been sent")
+}
+
+func (suite *PasswordResetE2ETestSuite) TestForgotPassword_WithSpoofedHost_EmailsLinkToPublicURL() {
+	values := url.Values{
+		"email": []string{suite.Email},
+	}
+	req := suite.CreateFormRequest(http.MethodPost, suite.Server.URL+"/password/forgot", "", values)
+	req.Host = "evil.example"
+
+	res := suite.SendRequest(req)
+	suite.Equal(http.StatusOK, res.StatusCode)
+
+	link := suite.lastResetLink()
+	suite.Equal(config.GetServerConfig().PublicURL, link.Scheme+"://"+link.Host)
+}
+
+func (suite *PasswordResetE2ETestSuite) TestResetPassword_WithInvalidToken_ShowsError() {
+	res := suite.SendResetPasswordRequest("invalid", "NewPassword123!")
+	suite.Equal(http.StatusOK, res.StatusCode)
+	suite.Contains(suite.readBody(res), "invalid or has expired")
+}
+
+func (suite *PasswordResetE2ETestSuite) TestResetPassword_WithEmailedLink_ResetsPasswordAndRevokesSessions() {
+	sessionToken := suite.Login(suite.User)
+
+	//request the reset link
+	res := suite.SendForgotPasswordRequest(suite.Email)
+	suite.Equal(http.StatusOK, res.StatusCode)
+	suite.Contains(suite.readBody(res), "has been sent")
+
+	//reset the password using the emailed token
+	token := suite.lastResetToken()
+	newPassword := "NewPassword123!"
+
+	res = suite.SendResetPasswordRequest(token, newPassword)
+	suite.Equal(http.StatusOK, res.StatusCode)
+	suite.Contains(suite.readBody(res), "has been reset")
+
+	//existing session was revoked
+	res = suite.SendUpdatePasswordRequest(sessionToken, suite.User.Password, newPassword)
+	suite.ParseAndAssertErrorResponse(res, http.StatusUnauthorized)
+
+	//old password no longer works but the new one does
+	res = suite.SendCreateSessionRequest(suite.User.Username, suite.User.Password)
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "invalid username and/or password")
+
+	suite.User.Password = newPassword
+	suite.Logout(suite.Login(suite.User))
+
+	//token can only be used once
+	res = suite.SendResetPasswordRequest(token, "OtherPassword123!")
+	suite.Equal(http.StatusOK, res.StatusCode)
+	suite.Contains(suite.readBody(res), "invalid or has expired")
+}
+
+func TestPasswordResetE2ETestSuite(t *testing.T) {
+	suite.Run(t, &PasswordResetE2ETestSuite{})
+}
@@ -0,0 +1,143 @@
+package e2e_test
+
+import (
+	"net/http"
+	"path"
+	"testing"
+
+	"github.com/mhogar/amber/router/handlers"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *E2ETestSuite) SendGetRoleDefinitionsRequest(token string, clientID string) *http.Response {
+	return suite.SendJSONRequest(http.MethodGet, path.Join("/client", clientID, "roles", "definitions"), token, nil)
+}
+
+func (suite *E2ETestSuite) SendCreateRoleDefinitionRequest(token string, clientID string, body handlers.PostRoleDefinitionBody) *http.Response {
+	return suite.SendJSONRequest(http.MethodPost, path.Join("/client", clientID, "roles", "definitions"), token, body)
+}
+
+func (suite *E2ETestSuite) CreateRoleDefinition(token string, clientID uuid.UUID, name string) {
+	res := suite.SendCreateRoleDefinitionRequest(token, clientID.String(), handlers.PostRoleDefinitionBody{
+		Name: name,
+	})
+	suite.ParseDataResponseOK(res)
+}
+
+func (suite *E2ETestSuite) SendUpdateRoleDefinitionRequest(token string, clientID string, name string, body handlers.PostRoleDefinitionBody) *http.Response {
+	return suite.SendJSONRequest(http.MethodPut, path.Join("/client", clientID, "roles", "definitions", name), token, body)
+}
+
+func (suite *E2ETestSuite) SendDeleteRoleDefinitionRequest(token string, clientID string, name string) *http.Response {
+	return suite.SendJSONRequest(http.MethodDelete, path.Join("/client", clientID, "roles", "definitions", name), token, nil)
+}
+
+type RoleDefinitionE2ETestSuite struct {
+	E2ETestSuite
+	User     UserCredentials
+	ClientID uuid.UUID
+}
+
+func (suite *RoleDefinitionE2ETestSuite) SetupSuite() {
+	suite.E2ETestSuite.SetupSuite()
+
+	suite.User = suite.CreateUser(suite.AdminToken, "user", 0)
+	suite.ClientID = suite.CreateClient(suite.AdminToken, 0, "key.pem")
+}
+
+func (suite *RoleDefinitionE2ETestSuite) TearDownSuite() {
+	suite.DeleteClient(suite.AdminToken, suite.ClientID)
+	suite.DeleteUser(suite.AdminToken, suite.User.Username)
+
+	suite.E2ETestSuite.TearDownSuite()
+}
+
+func (suite *RoleDefinitionE2ETestSuite) TestGetRoleDefinitions_WithInvalidSession_ReturnsUnauthorized() {
+	res := suite.SendGetRoleDefinitionsRequest("", suite.ClientID.String())
+	suite.ParseAndAssertErrorResponse(res, http.StatusUnauthorized)
+}
+
+func (suite *RoleDefinitionE2ETestSuite) TestGetRoleDefinitions_WhereClientNotFound_ReturnsBadRequest() {
+	res := suite.SendGetRoleDefinitionsRequest(suite.AdminToken, uuid.New().String())
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "client", "not found")
+}
+
+func (suite *RoleDefinitionE2ETestSuite) TestCreateRoleDefinition_WithInvalidSession_ReturnsUnauthorized() {
+	res := suite.SendCreateRoleDefinitionRequest("", suite.ClientID.String(), handlers.PostRoleDefinitionBody{})
+	suite.ParseAndAssertErrorResponse(res, http.StatusUnauthorized)
+}
+
+func (suite *RoleDefinitionE2ETestSuite) TestCreateRoleDefinition_WithInvalidBody_ReturnsBadRequest() {
+	res := suite.SendCreateRoleDefinitionRequest(suite.AdminToken, suite.ClientID.String(), handlers.PostRoleDefinitionBody{})
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "role name", "cannot be empty")
+}
+
+func (suite *RoleDefinitionE2ETestSuite) TestDeleteRoleDefinition_WhereRoleIsNotDefined_ReturnsBadRequest() {
+	res := suite.SendDeleteRoleDefinitionRequest(suite.AdminToken, suite.ClientID.String(), "DNE")
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "role", "not defined")
+}
+
+func (suite *RoleDefinitionE2ETestSuite) TestRoleDefinitionLifecycle() {
+	//create the definitions
+	res := suite.SendCreateRoleDefinitionRequest(suite.AdminToken, suite.ClientID.String(), handlers.PostRoleDefinitionBody{
+		Name:        "member",
+		Description: "Uses the client",
+		IsDefault:   true,
+	})
+	suite.ParseDataResponseOK(res)
+	suite.CreateRoleDefinition(suite.AdminToken, suite.ClientID, "editor")
+
+	//the same role cannot be defined twice
+	res = suite.SendCreateRoleDefinitionRequest(suite.AdminToken, suite.ClientID.String(), handlers.PostRoleDefinitionBody{
+		Name: "editor",
+	})
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "role", "already defined")
+
+	//only defined roles can be given
+	res = suite.SendCreateUserRoleRequest(suite.AdminToken, suite.ClientID.String(), suite.User.Username, "owner")
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "role", "not defined")
+
+	//no roles gives the default
+	res = suite.SendCreateUserRoleRequest(suite.AdminToken, suite.ClientID.String(), suite.User.Username)
+	suite.Equal([]interface{}{"member"}, suite.ParseDataResponseOK(res)["roles"])
+
+	//renaming the definition renames the given roles
+	res = suite.SendUpdateRoleDefinitionRequest(suite.AdminToken, suite.ClientID.String(), "member", handlers.PostRoleDefinitionBody{
+		Name:      "viewer",
+		IsDefault: true,
+	})
+	suite.ParseDataResponseOK(res)
+
+	res = suite.SendCreateUserRoleRequest(suite.AdminToken, suite.ClientID.String(), suite.User.Username, "viewer")
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "user", "already has a role", "client")
+
+	var result struct {
+		Data []handlers.RoleDefinitionDataResponse `json:"data"`
+	}
+	res = suite.SendGetRoleDefinitionsRequest(suite.AdminToken, suite.ClientID.String())
+	suite.ParseResponseOK(res, &result)
+
+	suite.Require().Len(result.Data, 2)
+	suite.Equal("editor", result.Data[0].Name)
+	suite.Equal("viewer", result.Data[1].Name)
+	suite.True(result.Data[1].IsDefault)
+
+	//a role that is still given cannot be deleted
+	res = suite.SendDeleteRoleDefinitionRequest(suite.AdminToken, suite.ClientID.String(), "viewer")
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "role", "still given to 1 users")
+
+	//clean up
+	suite.DeleteUserRole(suite.AdminToken, suite.ClientID, suite.User.Username)
+
+	res = suite.SendDeleteRoleDefinitionRequest(suite.AdminToken, suite.ClientID.String(), "viewer")
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	res = suite.SendDeleteRoleDefinitionRequest(suite.AdminToken, suite.ClientID.String(), "editor")
+	suite.ParseAndAssertOKSuccessResponse(res)
+}
+
+func TestRoleDefinitionE2ETestSuite(t *testing.T) {
+	suite.Run(t, &RoleDefinitionE2ETestSuite{})
+}
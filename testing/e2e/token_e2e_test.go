@@ -1,6 +1,10 @@
 package e2e_test
 
 import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
 	"net/http"
 	"net/url"
 	"testing"
@@ -8,6 +12,7 @@ import (
 	jwthelpers "github.com/mhogar/amber/controllers/jwt_helpers"
 	"github.com/mhogar/amber/dependencies"
 	"github.com/mhogar/amber/models"
+	"github.com/mhogar/amber/router/handlers"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/google/uuid"
@@ -43,6 +48,7 @@ func (suite *TokenE2ETestSuite) TestCreateToken_UsingDefaultTokenType_RedirectsT
 	clientId := suite.CreateClient(suite.AdminToken, models.ClientTokenTypeDefault, "keys/test.private.pem")
 
 	//create user-role
+	suite.CreateRoleDefinition(suite.AdminToken, clientId, "role")
 	role := "role"
 	suite.CreateUserRole(suite.AdminToken, clientId, suite.User.Username, "role")
 
@@ -53,7 +59,11 @@ func (suite *TokenE2ETestSuite) TestCreateToken_UsingDefaultTokenType_RedirectsT
 	//parse token from url
 	claims := suite.parseDefaultTokenClaims("keys/test.public.pem", res.Request.URL.Query().Get("token"))
 	suite.Equal(suite.User.Username, claims.Username)
-	suite.Equal(role, claims.Role)
+	suite.Equal([]string{role}, claims.Roles)
+	suite.Empty(claims.Role)
+
+	//refresh tokens are never put in the url
+	suite.Empty(res.Request.URL.Query().Get("refresh_token"))
 
 	//delete client
 	suite.DeleteClient(suite.AdminToken, clientId)
@@ -77,6 +87,309 @@ func (suite *TokenE2ETestSuite) parseDefaultTokenClaims(keyUri string, tokenStri
 	return claims
 }
 
+func (suite *TokenE2ETestSuite) TestGetJWKS_ContainsKeyThatVerifiesDefaultToken() {
+	//create client
+	clientId := suite.CreateClient(suite.AdminToken, models.ClientTokenTypeDefault, "keys/test.private.pem")
+
+	//create user-role
+	suite.CreateRoleDefinition(suite.AdminToken, clientId, "role")
+	suite.CreateUserRole(suite.AdminToken, clientId, suite.User.Username, "role")
+
+	//create token
+	res := suite.SendCreateTokenRequest(clientId, suite.User.Username, suite.User.Password)
+	suite.Require().Equal(http.StatusOK, res.StatusCode)
+	tokenString := res.Request.URL.Query().Get("token")
+
+	//get the key set
+	res = suite.SendJSONRequest(http.MethodGet, "/.well-known/jwks.json", "", nil)
+	suite.Require().Equal(http.StatusOK, res.StatusCode)
+
+	var jwks jwthelpers.JWKS
+	err := json.NewDecoder(res.Body).Decode(&jwks)
+	suite.Require().NoError(err)
+
+	//verify the token using the key matching its kid
+	_, err = jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		for _, jwk := range jwks.Keys {
+			if jwk.Kid == token.Header["kid"] {
+				return suite.parseJWK(jwk), nil
+			}
+		}
+
+		suite.FailNow("no key found with matching kid")
+		return nil, nil
+	})
+	suite.NoError(err)
+
+	//delete client
+	suite.DeleteClient(suite.AdminToken, clientId)
+}
+
+func (suite *TokenE2ETestSuite) parseJWK(jwk jwthelpers.JWK) *rsa.PublicKey {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	suite.Require().NoError(err)
+
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	suite.Require().NoError(err)
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}
+}
+
+// The PKCE code verifier and its S256 challenge used to authorize.
+const (
+	codeVerifier  = "abcdefghijklmnopqrstuvwxyz-0123456789-ABCDEFGHIJ"
+	codeChallenge = "f4GlO33Knmhaikoyah1U7W_9AIdhM7QWrRd_ACqg6Yo"
+)
+
+// authorize logs the user in through the authorize view for the client, returning the url it redirects to without following it.
+func (suite *TokenE2ETestSuite) authorize(clientID uuid.UUID) *url.URL {
+	values := url.Values{
+		"response_type":         []string{"code"},
+		"client_id":             []string{clientID.String()},
+		"redirect_uri":          []string{"https://mhogar.dev"},
+		"scope":                 []string{"openid"},
+		"state":                 []string{"state"},
+		"nonce":                 []string{"nonce"},
+		"code_challenge":        []string{codeChallenge},
+		"code_challenge_method": []string{"S256"},
+		"username":              []string{suite.User.Username},
+		"password":              []string{suite.User.Password},
+	}
+	client := http.Client{
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	res, err := client.Do(suite.CreateFormRequest(http.MethodPost, suite.Server.URL+"/authorize", "", values))
+	suite.Require().NoError(err)
+	suite.Require().Equal(http.StatusSeeOther, res.StatusCode)
+
+	redirectUrl, err := url.Parse(res.Header.Get("Location"))
+	suite.Require().NoError(err)
+
+	return redirectUrl
+}
+
+func (suite *TokenE2ETestSuite) TestAuthorizationCodeFlow_ExchangesCodeForAccessAndIDTokens() {
+	//create client
+	clientId := suite.CreateClient(suite.AdminToken, models.ClientTokenTypeDefault, "keys/test.private.pem")
+
+	//create user-role
+	suite.CreateRoleDefinition(suite.AdminToken, clientId, "role")
+	role := "role"
+	suite.CreateUserRole(suite.AdminToken, clientId, suite.User.Username, role)
+
+	//authorize and parse the code from the redirect url
+	redirectUrl := suite.authorize(clientId)
+	suite.Equal("state", redirectUrl.Query().Get("state"))
+
+	//exchange the code
+	values := url.Values{
+		"grant_type":    []string{"authorization_code"},
+		"client_id":     []string{clientId.String()},
+		"code":          []string{redirectUrl.Query().Get("code")},
+		"code_verifier": []string{codeVerifier},
+	}
+
+	//the redirect uri must be sent again since it was sent to authorize
+	res := suite.SendFormRequest(http.MethodPost, "/oauth/token", "", values)
+	suite.Equal(http.StatusBadRequest, res.StatusCode)
+
+	values.Set("redirect_uri", "https://mhogar.dev")
+	res = suite.SendFormRequest(http.MethodPost, "/oauth/token", "", values)
+
+	var tokens handlers.OAuthTokenResponse
+	suite.ParseJSONResponse(res, http.StatusOK, &tokens)
+
+	//parse the tokens
+	claims := suite.parseDefaultTokenClaims("keys/test.public.pem", tokens.AccessToken)
+	suite.Equal(suite.User.Username, claims.Username)
+	suite.Equal([]string{role}, claims.Roles)
+	suite.Empty(claims.Role)
+
+	var idClaims jwthelpers.IDTokenClaims
+	_, err := jwt.ParseWithClaims(tokens.IDToken, &idClaims, func(_ *jwt.Token) (interface{}, error) {
+		bytes, err := dependencies.ResolveRawDataLoader().Load("keys/test.public.pem")
+		suite.Require().NoError(err)
+
+		return jwt.ParseRSAPublicKeyFromPEM(bytes)
+	})
+	suite.Require().NoError(err)
+	suite.Equal(suite.User.Username, idClaims.Subject)
+	suite.Equal("nonce", idClaims.Nonce)
+
+	//the code cannot be used twice
+	res = suite.SendFormRequest(http.MethodPost, "/oauth/token", "", values)
+	suite.Equal(http.StatusBadRequest, res.StatusCode)
+
+	//delete client
+	suite.DeleteClient(suite.AdminToken, clientId)
+}
+
+func (suite *TokenE2ETestSuite) SendRefreshTokenRequest(clientID uuid.UUID, refreshToken string) *http.Response {
+	values := url.Values{
+		"grant_type":    []string{"refresh_token"},
+		"client_id":     []string{clientID.String()},
+		"refresh_token": []string{refreshToken},
+	}
+	return suite.SendFormRequest(http.MethodPost, "/oauth/token", "", values)
+}
+
+// createRefreshToken authorizes the user for the client and exchanges the code, returning the refresh token it was issued.
+func (suite *TokenE2ETestSuite) createRefreshToken(clientID uuid.UUID) string {
+	values := url.Values{
+		"grant_type":    []string{"authorization_code"},
+		"client_id":     []string{clientID.String()},
+		"code":          []string{suite.authorize(clientID).Query().Get("code")},
+		"code_verifier": []string{codeVerifier},
+		"redirect_uri":  []string{"https://mhogar.dev"},
+	}
+	res := suite.SendFormRequest(http.MethodPost, "/oauth/token", "", values)
+
+	var tokens handlers.OAuthTokenResponse
+	suite.ParseJSONResponse(res, http.StatusOK, &tokens)
+
+	return tokens.RefreshToken
+}
+
+func (suite *TokenE2ETestSuite) TestRefreshToken_RotatesAndRevokesFamilyOnReuse() {
+	//create client
+	clientId := suite.CreateClient(suite.AdminToken, models.ClientTokenTypeDefault, "keys/test.private.pem")
+
+	//create user-role
+	suite.CreateRoleDefinition(suite.AdminToken, clientId, "role")
+	role := "role"
+	suite.CreateUserRole(suite.AdminToken, clientId, suite.User.Username, role)
+
+	//create token
+	refreshToken := suite.createRefreshToken(clientId)
+	suite.Require().NotEmpty(refreshToken)
+
+	//redeem the refresh token
+	var tokens handlers.OAuthTokenResponse
+	suite.ParseJSONResponse(suite.SendRefreshTokenRequest(clientId, refreshToken), http.StatusOK, &tokens)
+
+	claims := suite.parseDefaultTokenClaims("keys/test.public.pem", tokens.AccessToken)
+	suite.Equal(suite.User.Username, claims.Username)
+	suite.Equal([]string{role}, claims.Roles)
+	suite.Empty(claims.Role)
+	suite.Require().NotEmpty(tokens.RefreshToken)
+	suite.NotEqual(refreshToken, tokens.RefreshToken)
+
+	//reuse the rotated refresh token
+	res := suite.SendRefreshTokenRequest(clientId, refreshToken)
+	suite.Equal(http.StatusBadRequest, res.StatusCode)
+
+	//the newest refresh token in the family is also revoked, even though the request that revoked it failed
+	var oauthErr handlers.OAuthErrorResponse
+	suite.ParseJSONResponse(suite.SendRefreshTokenRequest(clientId, tokens.RefreshToken), http.StatusBadRequest, &oauthErr)
+	suite.Equal("invalid_grant", oauthErr.Error)
+	suite.Contains(oauthErr.ErrorDescription, "invalid or expired")
+
+	//delete client
+	suite.DeleteClient(suite.AdminToken, clientId)
+}
+
+func (suite *TokenE2ETestSuite) TestClientCredentials_WithRotatedSecret_CreatesTokenForClient() {
+	//create client
+	clientId := suite.CreateClient(suite.AdminToken, models.ClientTokenTypeDefault, "keys/test.private.pem")
+
+	//generate the client secret
+	res := suite.SendJSONRequest(http.MethodPost, "/client/"+clientId.String()+"/secret", suite.AdminToken, nil)
+	secret := suite.ParseDataResponseOK(res)["secret"].(string)
+
+	//create the token
+	values := url.Values{
+		"grant_type":    []string{"client_credentials"},
+		"client_id":     []string{clientId.String()},
+		"client_secret": []string{secret},
+	}
+	res = suite.SendFormRequest(http.MethodPost, "/oauth/token", "", values)
+
+	var tokens handlers.OAuthTokenResponse
+	suite.ParseJSONResponse(res, http.StatusOK, &tokens)
+
+	//parse the token
+	claims := suite.parseDefaultTokenClaims("keys/test.public.pem", tokens.AccessToken)
+	suite.Equal(clientId.String(), claims.Subject)
+	suite.Empty(claims.Username)
+	suite.Empty(claims.Roles)
+
+	//rotate the secret, invalidating the old one
+	res = suite.SendJSONRequest(http.MethodPost, "/client/"+clientId.String()+"/secret", suite.AdminToken, nil)
+	suite.ParseDataResponseOK(res)
+
+	res = suite.SendFormRequest(http.MethodPost, "/oauth/token", "", values)
+	suite.Equal(http.StatusUnauthorized, res.StatusCode)
+
+	//delete client
+	suite.DeleteClient(suite.AdminToken, clientId)
+}
+
+func (suite *TokenE2ETestSuite) TestAuthorizationCodeFlow_WithClientSecret_RequiresClientAuthentication() {
+	//create client
+	clientId := suite.CreateClient(suite.AdminToken, models.ClientTokenTypeDefault, "keys/test.private.pem")
+
+	//create user-role
+	suite.CreateRoleDefinition(suite.AdminToken, clientId, "role")
+	suite.CreateUserRole(suite.AdminToken, clientId, suite.User.Username, "role")
+
+	//generate the client secret
+	res := suite.SendJSONRequest(http.MethodPost, "/client/"+clientId.String()+"/secret", suite.AdminToken, nil)
+	secret := suite.ParseDataResponseOK(res)["secret"].(string)
+
+	//the code cannot be exchanged with a missing or wrong secret
+	values := url.Values{
+		"grant_type":    []string{"authorization_code"},
+		"client_id":     []string{clientId.String()},
+		"code":          []string{suite.authorize(clientId).Query().Get("code")},
+		"code_verifier": []string{codeVerifier},
+		"redirect_uri":  []string{"https://mhogar.dev"},
+	}
+
+	var oauthErr handlers.OAuthErrorResponse
+	suite.ParseJSONResponse(suite.SendFormRequest(http.MethodPost, "/oauth/token", "", values), http.StatusUnauthorized, &oauthErr)
+	suite.Equal("invalid_client", oauthErr.Error)
+
+	values.Set("client_secret", "wrong secret")
+	suite.ParseJSONResponse(suite.SendFormRequest(http.MethodPost, "/oauth/token", "", values), http.StatusUnauthorized, &oauthErr)
+	suite.Equal("invalid_client", oauthErr.Error)
+
+	//exchange the code with the secret in the form body
+	values.Set("client_secret", secret)
+
+	var tokens handlers.OAuthTokenResponse
+	suite.ParseJSONResponse(suite.SendFormRequest(http.MethodPost, "/oauth/token", "", values), http.StatusOK, &tokens)
+	suite.Require().NotEmpty(tokens.RefreshToken)
+
+	//the refresh token cannot be redeemed with a missing or wrong secret
+	suite.ParseJSONResponse(suite.SendRefreshTokenRequest(clientId, tokens.RefreshToken), http.StatusUnauthorized, &oauthErr)
+	suite.Equal("invalid_client", oauthErr.Error)
+
+	values = url.Values{
+		"grant_type":    []string{"refresh_token"},
+		"refresh_token": []string{tokens.RefreshToken},
+	}
+	req := suite.CreateFormRequest(http.MethodPost, suite.Server.URL+"/oauth/token", "", values)
+	req.SetBasicAuth(clientId.String(), "wrong secret")
+	suite.ParseJSONResponse(suite.SendRequest(req), http.StatusUnauthorized, &oauthErr)
+	suite.Equal("invalid_client", oauthErr.Error)
+
+	//redeem the refresh token with the secret in basic auth
+	req = suite.CreateFormRequest(http.MethodPost, suite.Server.URL+"/oauth/token", "", values)
+	req.SetBasicAuth(clientId.String(), secret)
+
+	var refreshed handlers.OAuthTokenResponse
+	suite.ParseJSONResponse(suite.SendRequest(req), http.StatusOK, &refreshed)
+	suite.NotEmpty(refreshed.AccessToken)
+
+	//delete client
+	suite.DeleteClient(suite.AdminToken, clientId)
+}
+
 func (suite *TokenE2ETestSuite) TestCreateToken_UsingFirebaseTokenType_RedirectsToURLWithToken() {
 	keyUri := "keys/firebase-test.json"
 
@@ -84,8 +397,9 @@ func (suite *TokenE2ETestSuite) TestCreateToken_UsingFirebaseTokenType_Redirects
 	clientId := suite.CreateClient(suite.AdminToken, models.ClientTokenTypeFirebase, keyUri)
 
 	//create user-role
-	role := "role"
-	suite.CreateUserRole(suite.AdminToken, clientId, suite.User.Username, "role")
+	suite.CreateRoleDefinition(suite.AdminToken, clientId, "editor")
+	suite.CreateRoleDefinition(suite.AdminToken, clientId, "billing")
+	suite.CreateUserRole(suite.AdminToken, clientId, suite.User.Username, "editor", "billing")
 
 	//create token
 	res := suite.SendCreateTokenRequest(clientId, suite.User.Username, suite.User.Password)
@@ -94,7 +408,8 @@ func (suite *TokenE2ETestSuite) TestCreateToken_UsingFirebaseTokenType_Redirects
 	//parse token from url
 	claims := suite.parseFirebaseTokenClaims(keyUri, res.Request.URL.Query().Get("token"))
 	suite.Equal(suite.User.Username, claims.UID)
-	suite.Equal(role, claims.Claims["role"])
+	suite.Equal([]interface{}{"billing", "editor"}, claims.Claims["roles"])
+	suite.NotContains(claims.Claims, "role")
 
 	//delete client
 	suite.DeleteClient(suite.AdminToken, clientId)
@@ -0,0 +1,167 @@
+package e2e_test
+
+import (
+	"encoding/base32"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	totphelpers "github.com/mhogar/amber/controllers/totp_helpers"
+	"github.com/mhogar/amber/router/handlers"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *E2ETestSuite) SendBeginTOTPEnrollmentRequest(token string) *http.Response {
+	return suite.SendJSONRequest(http.MethodPost, "/user/totp", token, nil)
+}
+
+func (suite *E2ETestSuite) SendConfirmTOTPEnrollmentRequest(token string, code string) *http.Response {
+	body := handlers.TwoFactorCodeBody{
+		Code: code,
+	}
+	return suite.SendJSONRequest(http.MethodPost, "/user/totp/confirm", token, body)
+}
+
+func (suite *E2ETestSuite) SendDisableTOTPRequest(token string, code string) *http.Response {
+	body := handlers.TwoFactorCodeBody{
+		Code: code,
+	}
+	return suite.SendJSONRequest(http.MethodPost, "/user/totp/disable", token, body)
+}
+
+func (suite *E2ETestSuite) SendCompleteTwoFactorSessionRequest(challenge string, code string) *http.Response {
+	body := handlers.PostSessionBody{
+		Challenge: challenge,
+		Code:      code,
+	}
+	return suite.SendJSONRequest(http.MethodPost, "/session", "", body)
+}
+
+// BeginLoginWithTwoFactor sends the user's password and returns the challenge for the second step.
+func (suite *E2ETestSuite) BeginLoginWithTwoFactor(creds UserCredentials) string {
+	res := suite.SendCreateSessionRequest(creds.Username, creds.Password)
+	return suite.ParseDataResponseOK(res)["challenge"].(string)
+}
+
+type TwoFactorE2ETestSuite struct {
+	E2ETestSuite
+	User      UserCredentials
+	UserToken string
+}
+
+func (suite *TwoFactorE2ETestSuite) SetupTest() {
+	suite.User = suite.CreateUser(suite.AdminToken, "totp_user", 0)
+	suite.UserToken = suite.Login(suite.User)
+}
+
+func (suite *TwoFactorE2ETestSuite) TearDownTest() {
+	suite.DeleteUser(suite.AdminToken, suite.User.Username)
+}
+
+// enroll enables two-factor authentication for the suite's user and returns the decoded secret and the recovery codes.
+func (suite *TwoFactorE2ETestSuite) enroll() ([]byte, []string) {
+	res := suite.SendBeginTOTPEnrollmentRequest(suite.UserToken)
+	uri, err := url.Parse(suite.ParseDataResponseOK(res)["provisioning_uri"].(string))
+	suite.Require().NoError(err)
+
+	secret, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(uri.Query().Get("secret"))
+	suite.Require().NoError(err)
+
+	//use the previous step's code so the current step's code is still unused after enrolling
+	res = suite.SendConfirmTOTPEnrollmentRequest(suite.UserToken, suite.generateCode(secret, -1))
+	data := suite.ParseDataResponseOK(res)
+
+	var codes []string
+	for _, code := range data["recovery_codes"].([]interface{}) {
+		codes = append(codes, code.(string))
+	}
+
+	return secret, codes
+}
+
+// generateCode generates the code for the current time step plus the offset.
+func (suite *TwoFactorE2ETestSuite) generateCode(secret []byte, offset int64) string {
+	return totphelpers.GenerateCode(secret, time.Now().Unix()/totphelpers.TOTPPeriod+offset)
+}
+
+func (suite *TwoFactorE2ETestSuite) TestBeginTOTPEnrollment_WithInvalidSession_ReturnsUnauthorized() {
+	res := suite.SendBeginTOTPEnrollmentRequest("")
+	suite.ParseAndAssertErrorResponse(res, http.StatusUnauthorized)
+}
+
+func (suite *TwoFactorE2ETestSuite) TestConfirmTOTPEnrollment_WhereEnrollmentHasNotStarted_ReturnsBadRequest() {
+	res := suite.SendConfirmTOTPEnrollmentRequest(suite.UserToken, "123456")
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "enrollment", "not been started")
+}
+
+func (suite *TwoFactorE2ETestSuite) TestDisableTOTP_WhereTwoFactorIsNotEnabled_ReturnsBadRequest() {
+	res := suite.SendDisableTOTPRequest(suite.UserToken, "123456")
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "not enabled")
+}
+
+func (suite *TwoFactorE2ETestSuite) TestCreateSession_WithTwoFactorEnabled_RequiresCode() {
+	secret, _ := suite.enroll()
+
+	//wrong code
+	challenge := suite.BeginLoginWithTwoFactor(suite.User)
+	res := suite.SendCompleteTwoFactorSessionRequest(challenge, "000000")
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "invalid two-factor code")
+
+	//correct code
+	res = suite.SendCompleteTwoFactorSessionRequest(challenge, suite.generateCode(secret, 0))
+	token := suite.ParseDataResponseOK(res)["token"].(string)
+	suite.Logout(token)
+}
+
+func (suite *TwoFactorE2ETestSuite) TestCreateSession_WithTOTPCode_CanOnlyUseCodeOnce() {
+	secret, _ := suite.enroll()
+	code := suite.generateCode(secret, 0)
+
+	//first use succeeds
+	challenge := suite.BeginLoginWithTwoFactor(suite.User)
+	res := suite.SendCompleteTwoFactorSessionRequest(challenge, code)
+	token := suite.ParseDataResponseOK(res)["token"].(string)
+	suite.Logout(token)
+
+	//second use fails
+	challenge = suite.BeginLoginWithTwoFactor(suite.User)
+	res = suite.SendCompleteTwoFactorSessionRequest(challenge, code)
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "already been used")
+
+	//code for a later step succeeds
+	res = suite.SendCompleteTwoFactorSessionRequest(challenge, suite.generateCode(secret, 1))
+	token = suite.ParseDataResponseOK(res)["token"].(string)
+	suite.Logout(token)
+}
+
+func (suite *TwoFactorE2ETestSuite) TestCreateSession_WithRecoveryCode_CanOnlyUseCodeOnce() {
+	_, codes := suite.enroll()
+	suite.Require().NotEmpty(codes)
+
+	//first use succeeds
+	challenge := suite.BeginLoginWithTwoFactor(suite.User)
+	res := suite.SendCompleteTwoFactorSessionRequest(challenge, codes[0])
+	token := suite.ParseDataResponseOK(res)["token"].(string)
+	suite.Logout(token)
+
+	//second use fails
+	challenge = suite.BeginLoginWithTwoFactor(suite.User)
+	res = suite.SendCompleteTwoFactorSessionRequest(challenge, codes[0])
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "invalid two-factor code")
+}
+
+func (suite *TwoFactorE2ETestSuite) TestDisableTOTP_WithValidCode_NoLongerRequiresCode() {
+	secret, _ := suite.enroll()
+
+	res := suite.SendDisableTOTPRequest(suite.UserToken, suite.generateCode(secret, 0))
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	token := suite.Login(suite.User)
+	suite.Logout(token)
+}
+
+func TestTwoFactorE2ETestSuite(t *testing.T) {
+	suite.Run(t, &TwoFactorE2ETestSuite{})
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/mhogar/amber/controllers"
 	"github.com/mhogar/amber/router/handlers"
 
 	"github.com/stretchr/testify/suite"
@@ -17,7 +18,9 @@ func (suite *E2ETestSuite) SendCreateUserRequest(token string, username string,
 	postUserBody := handlers.PostUserBody{
 		Username: username,
 		Password: password,
-		Rank:     rank,
+		PutUserBody: handlers.PutUserBody{
+			Rank: rank,
+		},
 	}
 	return suite.SendJSONRequest(http.MethodPost, "/user", token, postUserBody)
 }
@@ -41,6 +44,10 @@ func (suite *E2ETestSuite) SendUpdateUserRequest(token string, username string,
 	return suite.SendJSONRequest(http.MethodPut, "/user/"+username, token, putUserBody)
 }
 
+func (suite *E2ETestSuite) SendUpdateUserProfileRequest(token string, username string, body handlers.PutUserBody) *http.Response {
+	return suite.SendJSONRequest(http.MethodPut, "/user/"+username, token, body)
+}
+
 func (suite *E2ETestSuite) SendUpdatePasswordRequest(token string, oldPassword string, newPassword string) *http.Response {
 	patchPasswordBody := handlers.PatchPasswordBody{
 		OldPassword: oldPassword,
@@ -73,6 +80,7 @@ type UserE2ETestSuite struct {
 func (suite *UserE2ETestSuite) SetupSuite() {
 	suite.E2ETestSuite.SetupSuite()
 	suite.ExistingUser = suite.CreateUser(suite.AdminToken, "user", 5)
+	suite.UpdateUserAdminRoles(suite.AdminToken, suite.ExistingUser.Username, controllers.AdminRoleUserAdmin)
 }
 
 func (suite *UserE2ETestSuite) TearDownSuite() {
@@ -147,6 +155,90 @@ func (suite *UserE2ETestSuite) TestUpdateUser_WithValidRequest_ReturnsSuccess()
 	suite.ParseAndAssertOKSuccessResponse(res)
 }
 
+func (suite *UserE2ETestSuite) TestUpdateUser_WithInvalidEmail_ReturnsBadRequest() {
+	email := "invalid"
+	res := suite.SendUpdateUserProfileRequest(suite.AdminToken, suite.ExistingUser.Username, handlers.PutUserBody{
+		Rank:  5,
+		Email: &email,
+	})
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "email", "invalid")
+}
+
+func (suite *UserE2ETestSuite) TestUpdateUser_WithNonUniqueEmail_ReturnsBadRequest() {
+	//create a user with the email
+	user := suite.CreateUser(suite.AdminToken, "email_user", 0)
+	email := "user@example.com"
+	res := suite.SendUpdateUserProfileRequest(suite.AdminToken, user.Username, handlers.PutUserBody{
+		Email: &email,
+	})
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	//update another user with the same email, ignoring case
+	email = "USER@example.com"
+	res = suite.SendUpdateUserProfileRequest(suite.AdminToken, suite.ExistingUser.Username, handlers.PutUserBody{
+		Rank:  5,
+		Email: &email,
+	})
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "email", "already in use")
+
+	//delete the user
+	suite.DeleteUser(suite.AdminToken, user.Username)
+}
+
+func (suite *UserE2ETestSuite) TestUpdateUser_WhereUserIsDisabled_RevokesSessionsAndPreventsLogin() {
+	//create and login a user
+	user := suite.CreateUser(suite.AdminToken, "disabled_user", 0)
+	token := suite.Login(user)
+
+	//disable the user
+	enabled := false
+	res := suite.SendUpdateUserProfileRequest(suite.AdminToken, user.Username, handlers.PutUserBody{
+		Enabled: &enabled,
+	})
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	//the existing session is revoked
+	res = suite.SendUpdatePasswordRequest(token, user.Password, user.Password)
+	suite.ParseAndAssertErrorResponse(res, http.StatusUnauthorized)
+
+	//the user can no longer login
+	res = suite.SendCreateSessionRequest(user.Username, user.Password)
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "user is disabled")
+
+	//delete the user
+	suite.DeleteUser(suite.AdminToken, user.Username)
+}
+
+func (suite *UserE2ETestSuite) TestUpdateUser_WithOmittedProfileFields_KeepsCurrentValues() {
+	//create a user with a profile and disable them
+	user := suite.CreateUser(suite.AdminToken, "profile_user", 0)
+
+	email := "profile_user@example.com"
+	displayName := "Profile User"
+	enabled := false
+	res := suite.SendUpdateUserProfileRequest(suite.AdminToken, user.Username, handlers.PutUserBody{
+		Email:       &email,
+		DisplayName: &displayName,
+		Enabled:     &enabled,
+	})
+	suite.ParseAndAssertOKSuccessResponse(res)
+
+	//update only the rank
+	res = suite.SendUpdateUserRequest(suite.AdminToken, user.Username, 1)
+	data := suite.ParseDataResponseOK(res)
+
+	//the profile is unchanged and the user is still disabled
+	suite.Equal(email, data["email"])
+	suite.Equal(displayName, data["display_name"])
+	suite.Equal(false, data["enabled"])
+
+	res = suite.SendCreateSessionRequest(user.Username, user.Password)
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "user is disabled")
+
+	//delete the user
+	suite.DeleteUser(suite.AdminToken, user.Username)
+}
+
 func (suite *UserE2ETestSuite) TestUpdatePassword_WhereOldPasswordIsIncorrect_ReturnsBadRequest() {
 	//login
 	token := suite.Login(suite.ExistingUser)
@@ -172,11 +264,15 @@ func (suite *UserE2ETestSuite) TestUpdatePassword_WhereNewPasswordDoesNotMeetCri
 }
 
 func (suite *UserE2ETestSuite) TestUpdatePassword_WithValidRequest_ReturnsSuccess() {
+	//create a user whose password can change without affecting the other tests
+	user := suite.CreateUser(suite.AdminToken, "password_user", 0)
+	defer suite.DeleteUser(suite.AdminToken, user.Username)
+
 	//login
-	token := suite.Login(suite.ExistingUser)
+	token := suite.Login(user)
 
 	//update user password
-	res := suite.SendUpdatePasswordRequest(token, suite.ExistingUser.Password, suite.ExistingUser.Password)
+	res := suite.SendUpdatePasswordRequest(token, user.Password, "NewPassword123!")
 	suite.ParseAndAssertOKSuccessResponse(res)
 
 	//logout
@@ -211,7 +307,11 @@ func (suite *UserE2ETestSuite) TestUpdateUserPassword_WherePasswordDoesNotMeetCr
 }
 
 func (suite *UserE2ETestSuite) TestUpdateUserPassword_WithValidRequest_ReturnsSuccess() {
-	res := suite.SendUpdateUserPasswordRequest(suite.AdminToken, suite.ExistingUser.Username, suite.ExistingUser.Password)
+	//create a user whose password can change without affecting the other tests
+	user := suite.CreateUser(suite.AdminToken, "password_user", 0)
+	defer suite.DeleteUser(suite.AdminToken, user.Username)
+
+	res := suite.SendUpdateUserPasswordRequest(suite.AdminToken, user.Username, "NewPassword123!")
 	suite.ParseAndAssertOKSuccessResponse(res)
 }
 
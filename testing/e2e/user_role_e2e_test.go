@@ -5,6 +5,7 @@ import (
 	"path"
 	"testing"
 
+	"github.com/mhogar/amber/controllers"
 	"github.com/mhogar/amber/router/handlers"
 
 	"github.com/google/uuid"
@@ -15,22 +16,22 @@ func (suite *E2ETestSuite) SendGetUserRolesRequest(token string, clientID string
 	return suite.SendJSONRequest(http.MethodGet, path.Join("/client", clientID, "roles"), token, nil)
 }
 
-func (suite *E2ETestSuite) SendCreateUserRoleRequest(token string, clientID string, username string, role string) *http.Response {
+func (suite *E2ETestSuite) SendCreateUserRoleRequest(token string, clientID string, username string, roles ...string) *http.Response {
 	postUserRoleBody := handlers.PostUserRoleBody{
 		Username: username,
-		Role:     role,
+		Roles:    roles,
 	}
 	return suite.SendJSONRequest(http.MethodPost, path.Join("/client", clientID, "role"), token, postUserRoleBody)
 }
 
-func (suite *E2ETestSuite) CreateUserRole(token string, clientID uuid.UUID, username string, role string) {
-	res := suite.SendCreateUserRoleRequest(token, clientID.String(), username, role)
+func (suite *E2ETestSuite) CreateUserRole(token string, clientID uuid.UUID, username string, roles ...string) {
+	res := suite.SendCreateUserRoleRequest(token, clientID.String(), username, roles...)
 	suite.ParseAndAssertOKSuccessResponse(res)
 }
 
-func (suite *E2ETestSuite) SendUpdateUserRoleRequest(token string, clientID string, username string, role string) *http.Response {
-	putUserRoleBody := handlers.PostUserRoleBody{
-		Role: role,
+func (suite *E2ETestSuite) SendUpdateUserRoleRequest(token string, clientID string, username string, roles ...string) *http.Response {
+	putUserRoleBody := handlers.PutUserRoleBody{
+		Roles: roles,
 	}
 	return suite.SendJSONRequest(http.MethodPut, path.Join("/client", clientID, "role", username), token, putUserRoleBody)
 }
@@ -54,7 +55,10 @@ func (suite *UserRoleE2ETestSuite) SetupSuite() {
 	suite.E2ETestSuite.SetupSuite()
 
 	suite.User = suite.CreateUser(suite.AdminToken, "user", 5)
+	suite.UpdateUserAdminRoles(suite.AdminToken, suite.User.Username, controllers.AdminRoleUserAdmin)
 	suite.ClientID = suite.CreateClient(suite.AdminToken, 0, "key.pem")
+	suite.CreateRoleDefinition(suite.AdminToken, suite.ClientID, "role")
+	suite.CreateRoleDefinition(suite.AdminToken, suite.ClientID, "new role")
 	suite.CreateUserRole(suite.AdminToken, suite.ClientID, suite.User.Username, "role")
 }
 
@@ -145,9 +149,14 @@ func (suite *UserRoleE2ETestSuite) TestUpdateUserRole_WithInvalidBody_ReturnsBad
 	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "role", "cannot be empty")
 }
 
-func (suite *UserRoleE2ETestSuite) TestUpdateUserRole_WhereRoleNotFound_ReturnsBadRequest() {
+func (suite *UserRoleE2ETestSuite) TestUpdateUserRole_WhereRoleIsNotDefined_ReturnsBadRequest() {
 	res := suite.SendUpdateUserRoleRequest(suite.AdminToken, uuid.New().String(), suite.User.Username, "new role")
-	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "no role found", "user", "client")
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "role", "not defined", "client")
+}
+
+func (suite *UserRoleE2ETestSuite) TestUpdateUserRole_WithDuplicateRoles_ReturnsBadRequest() {
+	res := suite.SendUpdateUserRoleRequest(suite.AdminToken, suite.ClientID.String(), suite.User.Username, "role", "role")
+	suite.ParseAndAssertErrorResponse(res, http.StatusBadRequest, "roles", "duplicates")
 }
 
 func (suite *UserRoleE2ETestSuite) TestUpdateUserRole_WithValidRequest_ReturnsSuccess() {
@@ -155,6 +164,23 @@ func (suite *UserRoleE2ETestSuite) TestUpdateUserRole_WithValidRequest_ReturnsSu
 	suite.ParseAndAssertOKSuccessResponse(res)
 }
 
+func (suite *UserRoleE2ETestSuite) TestUpdateUserRole_WithMultipleRoles_GivesUserAllRoles() {
+	//update the roles
+	res := suite.SendUpdateUserRoleRequest(suite.AdminToken, suite.ClientID.String(), suite.User.Username, "role", "new role")
+	suite.Equal([]interface{}{"new role", "role"}, suite.ParseDataResponseOK(res)["roles"])
+
+	//the user is listed once with both roles
+	var result struct {
+		Data []handlers.UserRoleDataResponse `json:"data"`
+	}
+	res = suite.SendGetUserRolesRequest(suite.AdminToken, suite.ClientID.String())
+	suite.ParseResponseOK(res, &result)
+
+	suite.Require().Len(result.Data, 1)
+	suite.Equal(suite.User.Username, result.Data[0].Username)
+	suite.Equal([]string{"new role", "role"}, result.Data[0].Roles)
+}
+
 func (suite *UserRoleE2ETestSuite) TestDeleteUserRole_WithInvalidSession_ReturnsUnauthorized() {
 	res := suite.SendDeleteUserRoleRequest("", suite.ClientID.String(), suite.User.Username)
 	suite.ParseAndAssertErrorResponse(res, http.StatusUnauthorized)
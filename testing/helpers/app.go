@@ -0,0 +1,44 @@
+package helpers
+
+import (
+	"time"
+
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/config"
+	"github.com/mhogar/amber/dependencies"
+	"github.com/mhogar/amber/server"
+	"github.com/mhogar/amber/tools/admin_creator/runner"
+)
+
+// StartTestApp sets up the app's data adapter and starts the whole app on an httptest server.
+// The memory data adapter starts out empty, so when it is used an admin is created with the provided credentials and rank.
+// The returned runner's Shutdown stops the server and cleans up the data adapter. Returns the runner and any errors.
+func StartTestApp(adminUsername string, adminPassword string, adminRank int) (server.Runner, error) {
+	//open the data adapter
+	dataAdapter := dependencies.ResolveDataAdapter()
+	err := dataAdapter.Setup()
+	if err != nil {
+		return server.Runner{}, common.ChainError("error setting up data adapter", err)
+	}
+
+	//create the admin the other adapters get from the admin creator tool
+	if config.GetDataAdapter() == "memory" {
+		err = runner.Run(dependencies.ResolveScopeFactory(), dependencies.ResolveControllers(), adminUsername, adminPassword, adminRank)
+		if err != nil {
+			dataAdapter.CleanUp()
+			return server.Runner{}, common.ChainError("error creating admin", err)
+		}
+	}
+
+	//create and start the test server
+	serverRunner := server.CreateHTTPTestServerRunner(dependencies.ResolveRouterFactory(), dataAdapter)
+	serverRunner.ShutdownTimeout = time.Duration(config.GetServerConfig().ShutdownTimeout) * time.Second
+
+	err = serverRunner.Server.Start()
+	if err != nil {
+		dataAdapter.CleanUp()
+		return server.Runner{}, common.ChainError("error starting test server", err)
+	}
+
+	return serverRunner, nil
+}
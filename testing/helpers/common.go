@@ -1,5 +1,12 @@
 package helpers
 
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+)
+
 // CreateStringOfLength creates a string of the specified length.
 func CreateStringOfLength(length int) string {
 	s := ""
@@ -10,3 +17,17 @@ func CreateStringOfLength(length int) string {
 
 	return s
 }
+
+// CreateRSAPrivateKey generates a new RSA private key and returns it along with its PEM encoding.
+// Panics if the key could not be generated.
+func CreateRSAPrivateKey() (*rsa.PrivateKey, []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		panic(err)
+	}
+
+	return key, pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+}
@@ -181,6 +181,16 @@ func (suite *CustomSuite) SuccessDataResponse(res interface{}, expectedData inte
 	suite.EqualValues(expectedData, dataRes.Data)
 }
 
+// SuccessPageResponse asserts the response's data field is equivalent to the expected data, and that it has the expected next cursor and total.
+func (suite *CustomSuite) SuccessPageResponse(res interface{}, expectedData interface{}, expectedNextCursor string, expectedTotal int) {
+	pageRes := res.(common.PageResponse)
+
+	suite.True(pageRes.Success)
+	suite.EqualValues(expectedData, pageRes.Data)
+	suite.Equal(expectedNextCursor, pageRes.NextCursor)
+	suite.Equal(expectedTotal, pageRes.Total)
+}
+
 // ParseResponseOK asserts the response has an http OK status and returns the parsed result.
 func (suite *CustomSuite) ParseResponseOK(res *http.Response, result interface{}) {
 	suite.ParseJSONResponse(res, http.StatusOK, result)
@@ -0,0 +1,278 @@
+package integration_test
+
+import (
+	"testing"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type AdminRoleCRUDTestSuite struct {
+	CRUDTestSuite
+}
+
+func (suite *AdminRoleCRUDTestSuite) TestCreateAdminRole_WithInvalidAdminRole_ReturnsError() {
+	//act
+	err := suite.Executor.CreateAdminRole(models.CreateAdminRole("", ""))
+
+	//assert
+	suite.Require().Error(err)
+	suite.ContainsSubstrings(err.Error(), "error", "admin role model")
+}
+
+func (suite *AdminRoleCRUDTestSuite) TestGetAdminRoles_GetsTheAdminRolesOrderedByName() {
+	//arrange
+	role1 := suite.SaveAdminRole(models.CreateAdminRole("test_role_b", "", models.PermissionUsersRead))
+	role2 := suite.SaveAdminRole(models.CreateAdminRole("test_role_a", "Reads the audit log", models.PermissionAuditRead, models.PermissionLockoutsRead))
+
+	//act
+	roles, err := suite.Executor.GetAdminRoles()
+
+	//assert
+	suite.NoError(err)
+
+	//other admin roles may already exist, so only check the ones created here
+	var resultRoles []*models.AdminRole
+	for _, role := range roles {
+		if role.Name == role1.Name || role.Name == role2.Name {
+			resultRoles = append(resultRoles, role)
+		}
+	}
+
+	suite.Require().Len(resultRoles, 2)
+	suite.EqualValues(role2, resultRoles[0])
+	suite.EqualValues(role1, resultRoles[1])
+
+	//clean up
+	suite.DeleteAdminRole(role1)
+	suite.DeleteAdminRole(role2)
+}
+
+func (suite *AdminRoleCRUDTestSuite) TestGetAdminRoleByName_WhereAdminRoleNotFound_ReturnsNilAdminRole() {
+	//act
+	role, err := suite.Executor.GetAdminRoleByName("DNE")
+
+	//assert
+	suite.NoError(err)
+	suite.Nil(role)
+}
+
+func (suite *AdminRoleCRUDTestSuite) TestGetAdminRoleByName_GetsTheAdminRoleWithName() {
+	//arrange
+	role := suite.SaveAdminRole(models.CreateAdminRole("test_role", "description",
+		models.PermissionUsersRead, models.ScopePermission(models.PermissionRolesWrite, uuid.New()),
+	))
+
+	//act
+	resultRole, err := suite.Executor.GetAdminRoleByName(role.Name)
+
+	//assert
+	suite.NoError(err)
+	suite.EqualValues(role, resultRole)
+
+	//clean up
+	suite.DeleteAdminRole(role)
+}
+
+func (suite *AdminRoleCRUDTestSuite) TestGetAdminRoleByName_WithNoPermissions_GetsEmptyPermissions() {
+	//arrange
+	role := suite.SaveAdminRole(models.CreateAdminRole("test_role", ""))
+
+	//act
+	resultRole, err := suite.Executor.GetAdminRoleByName(role.Name)
+
+	//assert
+	suite.NoError(err)
+	suite.Require().NotNil(resultRole)
+	suite.Empty(resultRole.Permissions)
+
+	//clean up
+	suite.DeleteAdminRole(role)
+}
+
+func (suite *AdminRoleCRUDTestSuite) TestUpdateAdminRole_WithInvalidAdminRole_ReturnsError() {
+	//act
+	res, err := suite.Executor.UpdateAdminRole(models.CreateAdminRole("", ""))
+
+	//assert
+	suite.False(res)
+	suite.Require().Error(err)
+	suite.ContainsSubstrings(err.Error(), "error", "admin role model")
+}
+
+func (suite *AdminRoleCRUDTestSuite) TestUpdateAdminRole_WhereAdminRoleIsNotFound_ReturnsFalseResult() {
+	//act
+	res, err := suite.Executor.UpdateAdminRole(models.CreateAdminRole("DNE", ""))
+
+	//assert
+	suite.False(res)
+	suite.NoError(err)
+}
+
+func (suite *AdminRoleCRUDTestSuite) TestUpdateAdminRole_UpdatesAdminRole() {
+	//arrange
+	role := suite.SaveAdminRole(models.CreateAdminRole("test_role", "", models.PermissionUsersRead))
+
+	//act
+	role.Description = "new description"
+	role.Permissions = []string{models.PermissionClientsRead, models.PermissionClientsWrite}
+	res, err := suite.Executor.UpdateAdminRole(role)
+
+	//assert
+	suite.True(res)
+	suite.Require().NoError(err)
+
+	resultRole, err := suite.Executor.GetAdminRoleByName(role.Name)
+	suite.NoError(err)
+	suite.EqualValues(role, resultRole)
+
+	//clean up
+	suite.DeleteAdminRole(role)
+}
+
+func (suite *AdminRoleCRUDTestSuite) TestDeleteAdminRole_WhereAdminRoleNotFound_ReturnsFalseResult() {
+	//act
+	res, err := suite.Executor.DeleteAdminRole("DNE")
+
+	//assert
+	suite.False(res)
+	suite.NoError(err)
+}
+
+func (suite *AdminRoleCRUDTestSuite) TestDeleteAdminRole_DeletesAdminRoleAndTakesItAwayFromUsers() {
+	//arrange
+	user := suite.SaveUser(models.CreateUser("user", 0, []byte("password")))
+	role := suite.SaveAdminRole(models.CreateAdminRole("test_role", ""))
+
+	err := suite.Executor.SetUserAdminRoles(user.Username, []string{role.Name})
+	suite.Require().NoError(err)
+
+	//act
+	res, err := suite.Executor.DeleteAdminRole(role.Name)
+
+	//assert
+	suite.True(res)
+	suite.Require().NoError(err)
+
+	resultRole, err := suite.Executor.GetAdminRoleByName(role.Name)
+	suite.NoError(err)
+	suite.Nil(resultRole)
+
+	roles, err := suite.Executor.GetAdminRolesByUsername(user.Username)
+	suite.NoError(err)
+	suite.Empty(roles)
+
+	//clean up
+	suite.DeleteUser(user)
+}
+
+func (suite *AdminRoleCRUDTestSuite) TestGetAdminRolesByUsername_WhereUserHasNoAdminRoles_ReturnsEmpty() {
+	//act
+	roles, err := suite.Executor.GetAdminRolesByUsername("DNE")
+
+	//assert
+	suite.NoError(err)
+	suite.Empty(roles)
+}
+
+func (suite *AdminRoleCRUDTestSuite) TestSetUserAdminRoles_ReplacesTheUsersAdminRoles() {
+	//arrange
+	user1 := suite.SaveUser(models.CreateUser("user1", 0, []byte("password")))
+	user2 := suite.SaveUser(models.CreateUser("user2", 0, []byte("password")))
+
+	role1 := suite.SaveAdminRole(models.CreateAdminRole("test_role_a", "", models.PermissionUsersRead))
+	role2 := suite.SaveAdminRole(models.CreateAdminRole("test_role_b", ""))
+	role3 := suite.SaveAdminRole(models.CreateAdminRole("test_role_c", "", models.PermissionAuditRead))
+
+	err := suite.Executor.SetUserAdminRoles(user1.Username, []string{role1.Name, role2.Name})
+	suite.Require().NoError(err)
+	err = suite.Executor.SetUserAdminRoles(user2.Username, []string{role1.Name})
+	suite.Require().NoError(err)
+
+	//act
+	err = suite.Executor.SetUserAdminRoles(user1.Username, []string{role3.Name, role1.Name, "DNE"})
+
+	//assert
+	suite.Require().NoError(err)
+
+	roles, err := suite.Executor.GetAdminRolesByUsername(user1.Username)
+	suite.NoError(err)
+	suite.Require().Len(roles, 2)
+	suite.EqualValues(role1, roles[0])
+	suite.EqualValues(role3, roles[1])
+
+	roles, err = suite.Executor.GetAdminRolesByUsername(user2.Username)
+	suite.NoError(err)
+	suite.Require().Len(roles, 1)
+	suite.EqualValues(role1, roles[0])
+
+	//clean up
+	suite.DeleteUser(user1)
+	suite.DeleteUser(user2)
+	suite.DeleteAdminRole(role1)
+	suite.DeleteAdminRole(role2)
+	suite.DeleteAdminRole(role3)
+}
+
+func (suite *AdminRoleCRUDTestSuite) TestGetAdminRoleUsernames_WhereAdminRoleNotFound_ReturnsEmpty() {
+	//act
+	usernames, err := suite.Executor.GetAdminRoleUsernames("DNE")
+
+	//assert
+	suite.NoError(err)
+	suite.Empty(usernames)
+}
+
+func (suite *AdminRoleCRUDTestSuite) TestGetAdminRoleUsernames_GetsTheUsernamesOrderedByUsername() {
+	//arrange
+	user1 := suite.SaveUser(models.CreateUser("user_b", 0, []byte("password")))
+	user2 := suite.SaveUser(models.CreateUser("user_a", 0, []byte("password")))
+	user3 := suite.SaveUser(models.CreateUser("user_c", 0, []byte("password")))
+
+	role1 := suite.SaveAdminRole(models.CreateAdminRole("test_role_a", ""))
+	role2 := suite.SaveAdminRole(models.CreateAdminRole("test_role_b", ""))
+
+	suite.Require().NoError(suite.Executor.SetUserAdminRoles(user1.Username, []string{role1.Name}))
+	suite.Require().NoError(suite.Executor.SetUserAdminRoles(user2.Username, []string{role1.Name, role2.Name}))
+	suite.Require().NoError(suite.Executor.SetUserAdminRoles(user3.Username, []string{role2.Name}))
+
+	//act
+	usernames, err := suite.Executor.GetAdminRoleUsernames(role1.Name)
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Equal([]string{user2.Username, user1.Username}, usernames)
+
+	//clean up
+	suite.DeleteUser(user1)
+	suite.DeleteUser(user2)
+	suite.DeleteUser(user3)
+	suite.DeleteAdminRole(role1)
+	suite.DeleteAdminRole(role2)
+}
+
+func (suite *AdminRoleCRUDTestSuite) TestDeleteUser_DeletesTheUsersAdminRoles() {
+	//arrange
+	user := suite.SaveUser(models.CreateUser("user", 0, []byte("password")))
+	role := suite.SaveAdminRole(models.CreateAdminRole("test_role", ""))
+
+	err := suite.Executor.SetUserAdminRoles(user.Username, []string{role.Name})
+	suite.Require().NoError(err)
+
+	//act
+	suite.DeleteUser(user)
+
+	//assert
+	roles, err := suite.Executor.GetAdminRolesByUsername(user.Username)
+	suite.NoError(err)
+	suite.Empty(roles)
+
+	//clean up
+	suite.DeleteAdminRole(role)
+}
+
+func TestAdminRoleCRUDTestSuite(t *testing.T) {
+	suite.Run(t, &AdminRoleCRUDTestSuite{})
+}
@@ -0,0 +1,116 @@
+package integration_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditEventCRUDTestSuite struct {
+	CRUDTestSuite
+}
+
+// createActor creates a unique actor so each test only sees its own events, since audit events are never deleted.
+func (suite *AuditEventCRUDTestSuite) createActor() string {
+	return uuid.New().String()
+}
+
+func (suite *AuditEventCRUDTestSuite) TestSaveAuditEvent_WithInvalidAuditEvent_ReturnsError() {
+	//act
+	err := suite.Executor.SaveAuditEvent(models.CreateNewAuditEvent("actor", "", "", ""))
+
+	//assert
+	suite.Require().Error(err)
+	suite.ContainsSubstrings(err.Error(), "error", "audit event model")
+}
+
+func (suite *AuditEventCRUDTestSuite) TestGetAuditEvents_GetsEventsFromNewestToOldest() {
+	//arrange
+	actor := suite.createActor()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	older := suite.SaveAuditEvent(models.CreateAuditEvent(uuid.New(), actor, models.AuditActionLogin, actor, "127.0.0.1", now.Add(-time.Minute)))
+	newer := suite.SaveAuditEvent(models.CreateAuditEvent(uuid.New(), actor, models.AuditActionLogout, actor, "127.0.0.1", now))
+
+	//act
+	events, err := suite.Executor.GetAuditEvents(models.AuditEventFilter{Actor: actor, Limit: 10})
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Equal([]*models.AuditEvent{newer, older}, events)
+}
+
+func (suite *AuditEventCRUDTestSuite) TestGetAuditEvents_FilterTestCases() {
+	actor := suite.createActor()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	login := suite.SaveAuditEvent(models.CreateAuditEvent(uuid.New(), actor, models.AuditActionLogin, actor, "127.0.0.1", now.Add(-2*time.Minute)))
+	deleteUser := suite.SaveAuditEvent(models.CreateAuditEvent(uuid.New(), actor, models.AuditActionDeleteUser, "username", "127.0.0.1", now.Add(-time.Minute)))
+	logout := suite.SaveAuditEvent(models.CreateAuditEvent(uuid.New(), actor, models.AuditActionLogout, actor, "127.0.0.1", now))
+
+	var filter models.AuditEventFilter
+	var expectedEvents []*models.AuditEvent
+
+	testCase := func() {
+		//arrange
+		filter.Actor = actor
+		filter.Limit = 10
+
+		//act
+		events, err := suite.Executor.GetAuditEvents(filter)
+
+		//assert
+		suite.Require().NoError(err)
+		suite.Equal(expectedEvents, events)
+	}
+
+	filter = models.AuditEventFilter{Action: models.AuditActionDeleteUser}
+	expectedEvents = []*models.AuditEvent{deleteUser}
+	suite.Run("Action", testCase)
+
+	filter = models.AuditEventFilter{Target: actor}
+	expectedEvents = []*models.AuditEvent{logout, login}
+	suite.Run("Target", testCase)
+
+	filter = models.AuditEventFilter{Since: deleteUser.Timestamp}
+	expectedEvents = []*models.AuditEvent{logout, deleteUser}
+	suite.Run("Since", testCase)
+
+	filter = models.AuditEventFilter{Until: deleteUser.Timestamp}
+	expectedEvents = []*models.AuditEvent{login}
+	suite.Run("Until", testCase)
+
+	filter = models.AuditEventFilter{Action: models.AuditActionLogout, Since: login.Timestamp, Until: logout.Timestamp.Add(time.Second)}
+	expectedEvents = []*models.AuditEvent{logout}
+	suite.Run("Combined", testCase)
+
+	filter = models.AuditEventFilter{Action: models.AuditActionCreateClient}
+	expectedEvents = []*models.AuditEvent{}
+	suite.Run("NoMatches", testCase)
+}
+
+func (suite *AuditEventCRUDTestSuite) TestGetAuditEvents_WithLimitAndOffset_GetsPage() {
+	//arrange
+	actor := suite.createActor()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	events := make([]*models.AuditEvent, 5)
+	for i := range events {
+		events[i] = suite.SaveAuditEvent(models.CreateAuditEvent(uuid.New(), actor, models.AuditActionLogin, actor, "127.0.0.1", now.Add(-time.Duration(i)*time.Minute)))
+	}
+
+	//act
+	page, err := suite.Executor.GetAuditEvents(models.AuditEventFilter{Actor: actor, Limit: 2, Offset: 1})
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Equal(events[1:3], page)
+}
+
+func TestAuditEventCRUDTestSuite(t *testing.T) {
+	suite.Run(t, &AuditEventCRUDTestSuite{})
+}
@@ -0,0 +1,86 @@
+package integration_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthorizationCodeCRUDTestSuite struct {
+	CRUDTestSuite
+}
+
+func (suite *AuthorizationCodeCRUDTestSuite) TestSaveAuthorizationCode_WithInvalidAuthorizationCode_ReturnsError() {
+	//act
+	err := suite.Executor.SaveAuthorizationCode(models.CreateAuthorizationCode(uuid.Nil, uuid.Nil, "", "", "", "", time.Time{}))
+
+	//assert
+	suite.Require().Error(err)
+	suite.ContainsSubstrings(err.Error(), "error", "authorization code model")
+}
+
+func (suite *AuthorizationCodeCRUDTestSuite) TestGetAuthorizationCode_WhereAuthorizationCodeNotFound_ReturnsNilAuthorizationCode() {
+	//act
+	code, err := suite.Executor.GetAuthorizationCode(uuid.New())
+
+	//assert
+	suite.NoError(err)
+	suite.Nil(code)
+}
+
+func (suite *AuthorizationCodeCRUDTestSuite) TestGetAuthorizationCode_GetsTheAuthorizationCodeWithCode() {
+	//arrange
+	user := suite.SaveUser(models.CreateUser("username", 0, []byte("password")))
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	code := suite.SaveAuthorizationCode(models.CreateNewAuthorizationCode(client.UID, user.Username, "challenge", "nonce", client.RedirectUrl, time.Minute))
+
+	//act
+	resultCode, err := suite.Executor.GetAuthorizationCode(code.Code)
+
+	//assert
+	suite.NoError(err)
+	suite.EqualValues(code, resultCode)
+
+	//clean up
+	suite.DeleteClient(client)
+	suite.DeleteUser(user)
+}
+
+func (suite *AuthorizationCodeCRUDTestSuite) TestDeleteAuthorizationCode_WhereAuthorizationCodeIsNotFound_ReturnsFalseResult() {
+	//act
+	res, err := suite.Executor.DeleteAuthorizationCode(uuid.New())
+
+	//assert
+	suite.False(res)
+	suite.NoError(err)
+}
+
+func (suite *AuthorizationCodeCRUDTestSuite) TestDeleteAuthorizationCode_DeletesAuthorizationCodeWithCode() {
+	//arrange
+	user := suite.SaveUser(models.CreateUser("username", 0, []byte("password")))
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	code := suite.SaveAuthorizationCode(models.CreateNewAuthorizationCode(client.UID, user.Username, "challenge", "nonce", client.RedirectUrl, time.Minute))
+
+	//act
+	res, err := suite.Executor.DeleteAuthorizationCode(code.Code)
+	suite.Require().NoError(err)
+
+	//assert
+	resultCode, err := suite.Executor.GetAuthorizationCode(code.Code)
+
+	suite.True(res)
+	suite.NoError(err)
+	suite.Nil(resultCode)
+
+	//clean up
+	suite.DeleteClient(client)
+	suite.DeleteUser(user)
+}
+
+func TestAuthorizationCodeCRUDTestSuite(t *testing.T) {
+	suite.Run(t, &AuthorizationCodeCRUDTestSuite{})
+}
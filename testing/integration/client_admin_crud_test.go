@@ -0,0 +1,176 @@
+package integration_test
+
+import (
+	"testing"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type ClientAdminCRUDTestSuite struct {
+	CRUDTestSuite
+}
+
+func (suite *ClientAdminCRUDTestSuite) TestCreateClientAdmin_WithInvalidClientAdmin_ReturnsError() {
+	//act
+	err := suite.Executor.CreateClientAdmin(models.CreateClientAdmin(uuid.Nil, "", false))
+
+	//assert
+	suite.Require().Error(err)
+	suite.ContainsSubstrings(err.Error(), "error", "client admin model")
+}
+
+func (suite *ClientAdminCRUDTestSuite) TestGetClientAdminsByClientUID_GetsTheClientAdminsWithClientUIDOrderedByUsername() {
+	//arrange
+	client1 := suite.SaveClient(models.CreateNewClient("name1", "redirect.com", 0, "key.pem"))
+	client2 := suite.SaveClient(models.CreateNewClient("name2", "redirect.com", 0, "key.pem"))
+	user1 := suite.SaveUser(models.CreateUser("user1", 0, []byte("password")))
+	user2 := suite.SaveUser(models.CreateUser("user2", 0, []byte("password")))
+
+	admin1 := suite.SaveClientAdmin(models.CreateClientAdmin(client1.UID, user2.Username, false))
+	admin2 := suite.SaveClientAdmin(models.CreateClientAdmin(client1.UID, user1.Username, true))
+	suite.SaveClientAdmin(models.CreateClientAdmin(client2.UID, user1.Username, false))
+
+	//act
+	admins, err := suite.Executor.GetClientAdminsByClientUID(client1.UID)
+
+	//assert
+	suite.NoError(err)
+
+	suite.Require().Len(admins, 2)
+	suite.EqualValues(admin2, admins[0])
+	suite.EqualValues(admin1, admins[1])
+
+	//clean up
+	suite.DeleteClient(client1)
+	suite.DeleteClient(client2)
+	suite.DeleteUser(user1)
+	suite.DeleteUser(user2)
+}
+
+func (suite *ClientAdminCRUDTestSuite) TestGetClientAdminsByUsername_GetsTheClientAdminsWithUsername() {
+	//arrange
+	client1 := suite.SaveClient(models.CreateNewClient("name1", "redirect.com", 0, "key.pem"))
+	client2 := suite.SaveClient(models.CreateNewClient("name2", "redirect.com", 0, "key.pem"))
+	user1 := suite.SaveUser(models.CreateUser("user1", 0, []byte("password")))
+	user2 := suite.SaveUser(models.CreateUser("user2", 0, []byte("password")))
+
+	admin1 := suite.SaveClientAdmin(models.CreateClientAdmin(client1.UID, user1.Username, false))
+	admin2 := suite.SaveClientAdmin(models.CreateClientAdmin(client2.UID, user1.Username, true))
+	suite.SaveClientAdmin(models.CreateClientAdmin(client1.UID, user2.Username, false))
+
+	//act
+	admins, err := suite.Executor.GetClientAdminsByUsername(user1.Username)
+
+	//assert
+	suite.NoError(err)
+
+	suite.Require().Len(admins, 2)
+	suite.ElementsMatch([]*models.ClientAdmin{admin1, admin2}, admins)
+
+	//clean up
+	suite.DeleteClient(client1)
+	suite.DeleteClient(client2)
+	suite.DeleteUser(user1)
+	suite.DeleteUser(user2)
+}
+
+func (suite *ClientAdminCRUDTestSuite) TestGetClientAdminByClientUIDAndUsername_WhereClientAdminNotFound_ReturnsNilClientAdmin() {
+	//act
+	admin, err := suite.Executor.GetClientAdminByClientUIDAndUsername(uuid.New(), "DNE")
+
+	//assert
+	suite.NoError(err)
+	suite.Nil(admin)
+}
+
+func (suite *ClientAdminCRUDTestSuite) TestGetClientAdminByClientUIDAndUsername_GetsTheClientAdminWithClientUIDAndUsername() {
+	//arrange
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	user := suite.SaveUser(models.CreateUser("user", 0, []byte("password")))
+	admin := suite.SaveClientAdmin(models.CreateClientAdmin(client.UID, user.Username, true))
+
+	//act
+	resultAdmin, err := suite.Executor.GetClientAdminByClientUIDAndUsername(admin.ClientUID, admin.Username)
+
+	//assert
+	suite.NoError(err)
+	suite.EqualValues(admin, resultAdmin)
+
+	//clean up
+	suite.DeleteClient(client)
+	suite.DeleteUser(user)
+}
+
+func (suite *ClientAdminCRUDTestSuite) TestDeleteClientAdmin_WhereClientAdminNotFound_ReturnsFalseResult() {
+	//act
+	res, err := suite.Executor.DeleteClientAdmin(uuid.New(), "DNE")
+
+	//assert
+	suite.False(res)
+	suite.NoError(err)
+}
+
+func (suite *ClientAdminCRUDTestSuite) TestDeleteClientAdmin_DeletesClientAdmin() {
+	//arrange
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	user := suite.SaveUser(models.CreateUser("user", 0, []byte("password")))
+	admin := suite.SaveClientAdmin(models.CreateClientAdmin(client.UID, user.Username, false))
+
+	//act
+	res, err := suite.Executor.DeleteClientAdmin(admin.ClientUID, admin.Username)
+
+	//assert
+	suite.True(res)
+	suite.Require().NoError(err)
+
+	resultAdmin, err := suite.Executor.GetClientAdminByClientUIDAndUsername(admin.ClientUID, admin.Username)
+	suite.NoError(err)
+	suite.Nil(resultAdmin)
+
+	//clean up
+	suite.DeleteClient(client)
+	suite.DeleteUser(user)
+}
+
+func (suite *ClientAdminCRUDTestSuite) TestDeleteClient_DeletesTheClientsClientAdmins() {
+	//arrange
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	user := suite.SaveUser(models.CreateUser("user", 0, []byte("password")))
+	suite.SaveClientAdmin(models.CreateClientAdmin(client.UID, user.Username, false))
+
+	//act
+	suite.DeleteClient(client)
+
+	//assert
+	admins, err := suite.Executor.GetClientAdminsByUsername(user.Username)
+	suite.NoError(err)
+	suite.Empty(admins)
+
+	//clean up
+	suite.DeleteUser(user)
+}
+
+func (suite *ClientAdminCRUDTestSuite) TestDeleteUser_DeletesTheUsersClientAdmins() {
+	//arrange
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	user := suite.SaveUser(models.CreateUser("user", 0, []byte("password")))
+	suite.SaveClientAdmin(models.CreateClientAdmin(client.UID, user.Username, false))
+
+	//act
+	suite.DeleteUser(user)
+
+	//assert
+	admins, err := suite.Executor.GetClientAdminsByClientUID(client.UID)
+	suite.NoError(err)
+	suite.Empty(admins)
+
+	//clean up
+	suite.DeleteClient(client)
+}
+
+func TestClientAdminCRUDTestSuite(t *testing.T) {
+	suite.Run(t, &ClientAdminCRUDTestSuite{})
+}
@@ -31,7 +31,7 @@ func (suite *ClientCRUDTestSuite) TestGetClients_GetsClientsOrderedByName() {
 	client2 := suite.SaveClient(models.CreateNewClient("name2", "redirect.com", 0, "key.pem"))
 
 	//act
-	clients, err := suite.Executor.GetClients()
+	clients, err := suite.Executor.GetClients(models.PageQuery{})
 
 	//assert
 	suite.NoError(err)
@@ -45,6 +45,78 @@ func (suite *ClientCRUDTestSuite) TestGetClients_GetsClientsOrderedByName() {
 	suite.DeleteClient(client2)
 }
 
+func (suite *ClientCRUDTestSuite) TestGetClients_SortedDescending_PagesUsingCursor() {
+	//arrange
+	client1 := suite.SaveClient(models.CreateNewClient("name1", "redirect.com", 0, "key.pem"))
+	client2 := suite.SaveClient(models.CreateNewClient("name2", "redirect.com", 0, "key.pem"))
+	client3 := suite.SaveClient(models.CreateNewClient("name3", "redirect.com", 0, "key.pem"))
+
+	query := models.PageQuery{
+		SortDescending: true,
+		Limit:          2,
+	}
+
+	//act
+	page1, err1 := suite.Executor.GetClients(query)
+
+	cursor := client2.GetCursor()
+	query.After = &cursor
+	page2, err2 := suite.Executor.GetClients(query)
+
+	//assert
+	suite.NoError(err1)
+	suite.Require().Len(page1, 2)
+	suite.EqualValues(page1[0], client3)
+	suite.EqualValues(page1[1], client2)
+
+	suite.NoError(err2)
+	suite.Require().Len(page2, 1)
+	suite.EqualValues(page2[0], client1)
+
+	//clean up
+	suite.DeleteClient(client1)
+	suite.DeleteClient(client2)
+	suite.DeleteClient(client3)
+}
+
+func (suite *ClientCRUDTestSuite) TestGetClients_WithSearch_GetsClientsWithNamePrefixIgnoringCase() {
+	//arrange
+	client1 := suite.SaveClient(models.CreateNewClient("App One", "redirect.com", 0, "key.pem"))
+	client2 := suite.SaveClient(models.CreateNewClient("Other App", "redirect.com", 0, "key.pem"))
+
+	//act
+	clients, err := suite.Executor.GetClients(models.PageQuery{Search: "app"})
+
+	//assert
+	suite.NoError(err)
+
+	suite.Require().Len(clients, 1)
+	suite.EqualValues(clients[0], client1)
+
+	//clean up
+	suite.DeleteClient(client1)
+	suite.DeleteClient(client2)
+}
+
+func (suite *ClientCRUDTestSuite) TestCountClients_CountsTheClientsMatchingSearch() {
+	//arrange
+	client1 := suite.SaveClient(models.CreateNewClient("name1", "redirect.com", 0, "key.pem"))
+	client2 := suite.SaveClient(models.CreateNewClient("name2", "redirect.com", 0, "key.pem"))
+	client3 := suite.SaveClient(models.CreateNewClient("other", "redirect.com", 0, "key.pem"))
+
+	//act
+	count, err := suite.Executor.CountClients("NAME")
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(2, count)
+
+	//clean up
+	suite.DeleteClient(client1)
+	suite.DeleteClient(client2)
+	suite.DeleteClient(client3)
+}
+
 func (suite *ClientCRUDTestSuite) TestGetClientByUID_WhereClientNotFound_ReturnsNilClient() {
 	//act
 	client, err := suite.Executor.GetClientByUID(uuid.New())
@@ -114,6 +186,61 @@ func (suite *ClientCRUDTestSuite) TestUpdateClient_UpdatesClientWithId() {
 	suite.DeleteClient(client)
 }
 
+func (suite *ClientCRUDTestSuite) TestUpdateClientSecretHash_WhereClientIsNotFound_ReturnsFalseResult() {
+	//act
+	res, err := suite.Executor.UpdateClientSecretHash(uuid.New(), []byte("hash"))
+
+	//assert
+	suite.False(res)
+	suite.NoError(err)
+}
+
+func (suite *ClientCRUDTestSuite) TestUpdateClientSecretHash_UpdatesClientWithId() {
+	//arrange
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	client.SecretHash = []byte("hash")
+
+	//act
+	res, err := suite.Executor.UpdateClientSecretHash(client.UID, client.SecretHash)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.True(res)
+
+	resultClient, err := suite.Executor.GetClientByUID(client.UID)
+	suite.NoError(err)
+	suite.EqualValues(client, resultClient)
+
+	//clean up
+	suite.DeleteClient(client)
+}
+
+func (suite *ClientCRUDTestSuite) TestUpdateClient_DoesNotChangeSecretHash() {
+	//arrange
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+
+	hash := []byte("hash")
+	_, err := suite.Executor.UpdateClientSecretHash(client.UID, hash)
+	suite.Require().NoError(err)
+
+	client.Name = "new name"
+
+	//act
+	res, err := suite.Executor.UpdateClient(client)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.True(res)
+
+	resultClient, err := suite.Executor.GetClientByUID(client.UID)
+	suite.NoError(err)
+	suite.Equal(client.Name, resultClient.Name)
+	suite.Equal(hash, resultClient.SecretHash)
+
+	//clean up
+	suite.DeleteClient(client)
+}
+
 func (suite *ClientCRUDTestSuite) TestDeleteClient_WhereClientIsNotFound_ReturnsFalseResult() {
 	//act
 	res, err := suite.Executor.DeleteClient(uuid.New())
@@ -83,9 +83,120 @@ func (suite *CRUDTestSuite) SaveUserRole(role *models.UserRole) *models.UserRole
 	return role
 }
 
+func (suite *CRUDTestSuite) SaveRoleDefinition(definition *models.RoleDefinition) *models.RoleDefinition {
+	err := suite.Executor.CreateRoleDefinition(definition)
+	suite.Require().NoError(err)
+
+	return definition
+}
+
+func (suite *CRUDTestSuite) SaveClientAdmin(admin *models.ClientAdmin) *models.ClientAdmin {
+	err := suite.Executor.CreateClientAdmin(admin)
+	suite.Require().NoError(err)
+
+	return admin
+}
+
+func (suite *CRUDTestSuite) SaveAdminRole(role *models.AdminRole) *models.AdminRole {
+	err := suite.Executor.CreateAdminRole(role)
+	suite.Require().NoError(err)
+
+	return role
+}
+
+func (suite *CRUDTestSuite) DeleteAdminRole(role *models.AdminRole) {
+	_, err := suite.Executor.DeleteAdminRole(role.Name)
+	suite.Require().NoError(err)
+}
+
+func (suite *CRUDTestSuite) SaveGroup(group *models.Group) *models.Group {
+	err := suite.Executor.CreateGroup(group)
+	suite.Require().NoError(err)
+
+	return group
+}
+
+func (suite *CRUDTestSuite) DeleteGroup(group *models.Group) {
+	_, err := suite.Executor.DeleteGroup(group.Name)
+	suite.Require().NoError(err)
+}
+
+func (suite *CRUDTestSuite) SaveGroupMember(group *models.Group, user *models.User) {
+	err := suite.Executor.CreateGroupMember(group.Name, user.Username)
+	suite.Require().NoError(err)
+}
+
+func (suite *CRUDTestSuite) SaveGroupRole(role *models.GroupRole) *models.GroupRole {
+	err := suite.Executor.CreateGroupRole(role)
+	suite.Require().NoError(err)
+
+	return role
+}
+
 func (suite *CRUDTestSuite) SaveSession(session *models.Session) *models.Session {
 	err := suite.Executor.SaveSession(session)
 	suite.Require().NoError(err)
 
 	return session
 }
+
+func (suite *CRUDTestSuite) SaveAuthorizationCode(code *models.AuthorizationCode) *models.AuthorizationCode {
+	err := suite.Executor.SaveAuthorizationCode(code)
+	suite.Require().NoError(err)
+
+	return code
+}
+
+func (suite *CRUDTestSuite) SaveRefreshToken(token *models.RefreshToken) *models.RefreshToken {
+	err := suite.Executor.SaveRefreshToken(token)
+	suite.Require().NoError(err)
+
+	return token
+}
+
+func (suite *CRUDTestSuite) SavePasswordResetToken(token *models.PasswordResetToken) *models.PasswordResetToken {
+	err := suite.Executor.SavePasswordResetToken(token)
+	suite.Require().NoError(err)
+
+	return token
+}
+
+func (suite *CRUDTestSuite) SaveRecoveryCode(code *models.RecoveryCode) *models.RecoveryCode {
+	err := suite.Executor.SaveRecoveryCode(code)
+	suite.Require().NoError(err)
+
+	return code
+}
+
+func (suite *CRUDTestSuite) SavePreviousPassword(password *models.PreviousPassword) *models.PreviousPassword {
+	err := suite.Executor.SavePreviousPassword(password)
+	suite.Require().NoError(err)
+
+	return password
+}
+
+func (suite *CRUDTestSuite) SaveLoginThrottle(throttle *models.LoginThrottle) *models.LoginThrottle {
+	err := suite.Executor.SaveLoginThrottle(throttle)
+	suite.Require().NoError(err)
+
+	return throttle
+}
+
+func (suite *CRUDTestSuite) SaveAuditEvent(event *models.AuditEvent) *models.AuditEvent {
+	err := suite.Executor.SaveAuditEvent(event)
+	suite.Require().NoError(err)
+
+	return event
+}
+
+func (suite *CRUDTestSuite) SaveInvitation(invitation *models.Invitation) *models.Invitation {
+	err := suite.Executor.SaveInvitation(invitation)
+	suite.Require().NoError(err)
+
+	return invitation
+}
+
+func (suite *CRUDTestSuite) DeleteInvitation(invitation *models.Invitation) {
+	_, err := suite.Executor.DeleteInvitation(invitation.ID)
+	suite.Require().NoError(err)
+}
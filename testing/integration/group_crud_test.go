@@ -0,0 +1,261 @@
+package integration_test
+
+import (
+	"testing"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type GroupCRUDTestSuite struct {
+	CRUDTestSuite
+}
+
+func (suite *GroupCRUDTestSuite) TestCreateGroup_WithInvalidGroup_ReturnsError() {
+	//act
+	err := suite.Executor.CreateGroup(models.CreateGroup("", "", 0))
+
+	//assert
+	suite.Require().Error(err)
+	suite.ContainsSubstrings(err.Error(), "error", "group model")
+}
+
+func (suite *GroupCRUDTestSuite) TestGetGroups_GetsAllGroupsOrderedByName() {
+	//arrange
+	group1 := suite.SaveGroup(models.CreateGroup("group2", "description", 1))
+	group2 := suite.SaveGroup(models.CreateGroup("group1", "", 5))
+
+	//act
+	groups, err := suite.Executor.GetGroups()
+
+	//assert
+	suite.NoError(err)
+
+	suite.Require().Len(groups, 2)
+	suite.EqualValues(group2, groups[0])
+	suite.EqualValues(group1, groups[1])
+
+	//clean up
+	suite.DeleteGroup(group1)
+	suite.DeleteGroup(group2)
+}
+
+func (suite *GroupCRUDTestSuite) TestGetGroupByName_WhereGroupNotFound_ReturnsNilGroup() {
+	//act
+	group, err := suite.Executor.GetGroupByName("DNE")
+
+	//assert
+	suite.NoError(err)
+	suite.Nil(group)
+}
+
+func (suite *GroupCRUDTestSuite) TestGetGroupByName_GetsTheGroupWithName() {
+	//arrange
+	group := suite.SaveGroup(models.CreateGroup("group", "description", 3))
+
+	//act
+	resultGroup, err := suite.Executor.GetGroupByName(group.Name)
+
+	//assert
+	suite.NoError(err)
+	suite.EqualValues(group, resultGroup)
+
+	//clean up
+	suite.DeleteGroup(group)
+}
+
+func (suite *GroupCRUDTestSuite) TestUpdateGroup_WithInvalidGroup_ReturnsError() {
+	//act
+	_, err := suite.Executor.UpdateGroup(models.CreateGroup("", "", 0))
+
+	//assert
+	suite.Require().Error(err)
+	suite.ContainsSubstrings(err.Error(), "error", "group model")
+}
+
+func (suite *GroupCRUDTestSuite) TestUpdateGroup_WhereGroupNotFound_ReturnsFalseResult() {
+	//act
+	res, err := suite.Executor.UpdateGroup(models.CreateGroup("DNE", "", 0))
+
+	//assert
+	suite.False(res)
+	suite.NoError(err)
+}
+
+func (suite *GroupCRUDTestSuite) TestUpdateGroup_UpdatesGroup() {
+	//arrange
+	group := suite.SaveGroup(models.CreateGroup("group", "description", 1))
+
+	//act
+	group.Description = "new description"
+	group.Priority = 7
+	res, err := suite.Executor.UpdateGroup(group)
+
+	//assert
+	suite.True(res)
+	suite.Require().NoError(err)
+
+	resultGroup, err := suite.Executor.GetGroupByName(group.Name)
+	suite.NoError(err)
+	suite.EqualValues(group, resultGroup)
+
+	//clean up
+	suite.DeleteGroup(group)
+}
+
+func (suite *GroupCRUDTestSuite) TestDeleteGroup_WhereGroupNotFound_ReturnsFalseResult() {
+	//act
+	res, err := suite.Executor.DeleteGroup("DNE")
+
+	//assert
+	suite.False(res)
+	suite.NoError(err)
+}
+
+func (suite *GroupCRUDTestSuite) TestDeleteGroup_DeletesGroupAndItsMembersAndGroupRoles() {
+	//arrange
+	user := suite.SaveUser(models.CreateUser("user", 0, []byte("password")))
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	group := suite.SaveGroup(models.CreateGroup("group", "", 0))
+
+	suite.SaveGroupMember(group, user)
+	suite.SaveGroupRole(models.CreateGroupRole(client.UID, group.Name, "role"))
+
+	//act
+	res, err := suite.Executor.DeleteGroup(group.Name)
+
+	//assert
+	suite.True(res)
+	suite.Require().NoError(err)
+
+	resultGroup, err := suite.Executor.GetGroupByName(group.Name)
+	suite.NoError(err)
+	suite.Nil(resultGroup)
+
+	groups, err := suite.Executor.GetGroupsByUsername(user.Username)
+	suite.NoError(err)
+	suite.Empty(groups)
+
+	roles, err := suite.Executor.GetGroupRolesByClientUID(client.UID)
+	suite.NoError(err)
+	suite.Empty(roles)
+
+	//clean up
+	suite.DeleteUser(user)
+	suite.DeleteClient(client)
+}
+
+func (suite *GroupCRUDTestSuite) TestGetGroupsByUsername_GetsTheGroupsOfUserOrderedByName() {
+	//arrange
+	user1 := suite.SaveUser(models.CreateUser("user1", 0, []byte("password")))
+	user2 := suite.SaveUser(models.CreateUser("user2", 0, []byte("password")))
+
+	group1 := suite.SaveGroup(models.CreateGroup("group2", "", 0))
+	group2 := suite.SaveGroup(models.CreateGroup("group1", "", 1))
+	group3 := suite.SaveGroup(models.CreateGroup("group3", "", 2))
+
+	suite.SaveGroupMember(group1, user1)
+	suite.SaveGroupMember(group2, user1)
+	suite.SaveGroupMember(group3, user2)
+
+	//act
+	groups, err := suite.Executor.GetGroupsByUsername(user1.Username)
+
+	//assert
+	suite.NoError(err)
+
+	suite.Require().Len(groups, 2)
+	suite.EqualValues(group2, groups[0])
+	suite.EqualValues(group1, groups[1])
+
+	//clean up
+	suite.DeleteGroup(group1)
+	suite.DeleteGroup(group2)
+	suite.DeleteGroup(group3)
+
+	suite.DeleteUser(user1)
+	suite.DeleteUser(user2)
+}
+
+func (suite *GroupCRUDTestSuite) TestGetGroupMemberUsernames_GetsTheUsernamesOfGroupMembersInOrder() {
+	//arrange
+	user1 := suite.SaveUser(models.CreateUser("user2", 0, []byte("password")))
+	user2 := suite.SaveUser(models.CreateUser("user1", 0, []byte("password")))
+	user3 := suite.SaveUser(models.CreateUser("user3", 0, []byte("password")))
+
+	group1 := suite.SaveGroup(models.CreateGroup("group1", "", 0))
+	group2 := suite.SaveGroup(models.CreateGroup("group2", "", 0))
+
+	suite.SaveGroupMember(group1, user1)
+	suite.SaveGroupMember(group1, user2)
+	suite.SaveGroupMember(group2, user3)
+
+	//act
+	usernames, err := suite.Executor.GetGroupMemberUsernames(group1.Name)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([]string{user2.Username, user1.Username}, usernames)
+
+	//clean up
+	suite.DeleteGroup(group1)
+	suite.DeleteGroup(group2)
+
+	suite.DeleteUser(user1)
+	suite.DeleteUser(user2)
+	suite.DeleteUser(user3)
+}
+
+func (suite *GroupCRUDTestSuite) TestDeleteGroupMember_WhereUserIsNotMember_ReturnsFalseResult() {
+	//act
+	res, err := suite.Executor.DeleteGroupMember("DNE", "DNE")
+
+	//assert
+	suite.False(res)
+	suite.NoError(err)
+}
+
+func (suite *GroupCRUDTestSuite) TestDeleteGroupMember_RemovesUserFromGroup() {
+	//arrange
+	user := suite.SaveUser(models.CreateUser("user", 0, []byte("password")))
+	group := suite.SaveGroup(models.CreateGroup("group", "", 0))
+	suite.SaveGroupMember(group, user)
+
+	//act
+	res, err := suite.Executor.DeleteGroupMember(group.Name, user.Username)
+
+	//assert
+	suite.True(res)
+	suite.Require().NoError(err)
+
+	usernames, err := suite.Executor.GetGroupMemberUsernames(group.Name)
+	suite.NoError(err)
+	suite.Empty(usernames)
+
+	//clean up
+	suite.DeleteGroup(group)
+	suite.DeleteUser(user)
+}
+
+func (suite *GroupCRUDTestSuite) TestDeleteUser_RemovesUserFromTheirGroups() {
+	//arrange
+	user := suite.SaveUser(models.CreateUser("user", 0, []byte("password")))
+	group := suite.SaveGroup(models.CreateGroup("group", "", 0))
+	suite.SaveGroupMember(group, user)
+
+	//act
+	suite.DeleteUser(user)
+
+	//assert
+	usernames, err := suite.Executor.GetGroupMemberUsernames(group.Name)
+	suite.NoError(err)
+	suite.Empty(usernames)
+
+	//clean up
+	suite.DeleteGroup(group)
+}
+
+func TestGroupCRUDTestSuite(t *testing.T) {
+	suite.Run(t, &GroupCRUDTestSuite{})
+}
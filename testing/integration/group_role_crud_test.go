@@ -0,0 +1,233 @@
+package integration_test
+
+import (
+	"testing"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type GroupRoleCRUDTestSuite struct {
+	CRUDTestSuite
+}
+
+func (suite *GroupRoleCRUDTestSuite) TestCreateGroupRole_WithInvalidGroupRole_ReturnsError() {
+	//act
+	err := suite.Executor.CreateGroupRole(models.CreateGroupRole(uuid.Nil, "group"))
+
+	//assert
+	suite.Require().Error(err)
+	suite.ContainsSubstrings(err.Error(), "error", "group-role model")
+}
+
+func (suite *GroupRoleCRUDTestSuite) TestGetGroupRolesByClientUID_GetsTheGroupRolesWithClientUIDOrderedByGroupName() {
+	//arrange
+	client1 := suite.SaveClient(models.CreateNewClient("name1", "redirect.com", 0, "key.pem"))
+	client2 := suite.SaveClient(models.CreateNewClient("name2", "redirect.com", 0, "key.pem"))
+	group1 := suite.SaveGroup(models.CreateGroup("group2", "", 0))
+	group2 := suite.SaveGroup(models.CreateGroup("group1", "", 0))
+
+	role1 := suite.SaveGroupRole(models.CreateGroupRole(client1.UID, group1.Name, "role", "other"))
+	role2 := suite.SaveGroupRole(models.CreateGroupRole(client1.UID, group2.Name, "role"))
+	suite.SaveGroupRole(models.CreateGroupRole(client2.UID, group1.Name, "role"))
+
+	//act
+	roles, err := suite.Executor.GetGroupRolesByClientUID(client1.UID)
+
+	//assert
+	suite.NoError(err)
+
+	suite.Require().Len(roles, 2)
+	suite.EqualValues(role2, roles[0])
+	suite.EqualValues(role1, roles[1])
+
+	//clean up
+	suite.DeleteClient(client1)
+	suite.DeleteClient(client2)
+	suite.DeleteGroup(group1)
+	suite.DeleteGroup(group2)
+}
+
+func (suite *GroupRoleCRUDTestSuite) TestGetGroupRoleByClientUIDAndGroupName_WhereGroupRoleNotFound_ReturnsNilGroupRole() {
+	//act
+	role, err := suite.Executor.GetGroupRoleByClientUIDAndGroupName(uuid.New(), "DNE")
+
+	//assert
+	suite.NoError(err)
+	suite.Nil(role)
+}
+
+func (suite *GroupRoleCRUDTestSuite) TestGetGroupRoleByClientUIDAndGroupName_GetsTheGroupRoleWithClientUIDAndGroupName() {
+	//arrange
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	group := suite.SaveGroup(models.CreateGroup("group", "", 0))
+	role := suite.SaveGroupRole(models.CreateGroupRole(client.UID, group.Name, "role", "other"))
+
+	//act
+	resultRole, err := suite.Executor.GetGroupRoleByClientUIDAndGroupName(role.ClientUID, role.GroupName)
+
+	//assert
+	suite.NoError(err)
+	suite.EqualValues(role, resultRole)
+
+	//clean up
+	suite.DeleteClient(client)
+	suite.DeleteGroup(group)
+}
+
+func (suite *GroupRoleCRUDTestSuite) TestUpdateGroupRole_WithInvalidGroupRole_ReturnsError() {
+	//act
+	_, err := suite.Executor.UpdateGroupRole(models.CreateGroupRole(uuid.Nil, "group"))
+
+	//assert
+	suite.Require().Error(err)
+	suite.ContainsSubstrings(err.Error(), "error", "group-role model")
+}
+
+func (suite *GroupRoleCRUDTestSuite) TestUpdateGroupRole_WhereGroupRoleIsNotFound_ReturnsFalseResult() {
+	//act
+	res, err := suite.Executor.UpdateGroupRole(models.CreateGroupRole(uuid.New(), "DNE", "role"))
+
+	//assert
+	suite.False(res)
+	suite.NoError(err)
+}
+
+func (suite *GroupRoleCRUDTestSuite) TestUpdateGroupRole_UpdatesGroupRole() {
+	//arrange
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	group := suite.SaveGroup(models.CreateGroup("group", "", 0))
+	role := suite.SaveGroupRole(models.CreateGroupRole(client.UID, group.Name, "role", "other"))
+
+	//act
+	role.Roles = []string{"new role", "role"}
+	res, err := suite.Executor.UpdateGroupRole(role)
+
+	//assert
+	suite.True(res)
+	suite.Require().NoError(err)
+
+	resultRole, err := suite.Executor.GetGroupRoleByClientUIDAndGroupName(role.ClientUID, role.GroupName)
+	suite.NoError(err)
+	suite.EqualValues(role, resultRole)
+
+	//clean up
+	suite.DeleteClient(client)
+	suite.DeleteGroup(group)
+}
+
+func (suite *GroupRoleCRUDTestSuite) TestCountGroupRolesByClientUIDAndRole_CountsTheGroupRolesWithClientUIDAndRole() {
+	//arrange
+	client1 := suite.SaveClient(models.CreateNewClient("name1", "redirect.com", 0, "key.pem"))
+	client2 := suite.SaveClient(models.CreateNewClient("name2", "redirect.com", 0, "key.pem"))
+	group1 := suite.SaveGroup(models.CreateGroup("group1", "", 0))
+	group2 := suite.SaveGroup(models.CreateGroup("group2", "", 0))
+	group3 := suite.SaveGroup(models.CreateGroup("group3", "", 0))
+
+	suite.SaveGroupRole(models.CreateGroupRole(client1.UID, group1.Name, "role", "other"))
+	suite.SaveGroupRole(models.CreateGroupRole(client1.UID, group2.Name, "role"))
+	suite.SaveGroupRole(models.CreateGroupRole(client1.UID, group3.Name, "other"))
+	suite.SaveGroupRole(models.CreateGroupRole(client2.UID, group1.Name, "role"))
+
+	//act
+	count, err := suite.Executor.CountGroupRolesByClientUIDAndRole(client1.UID, "role")
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(2, count)
+
+	//clean up
+	suite.DeleteClient(client1)
+	suite.DeleteClient(client2)
+
+	suite.DeleteGroup(group1)
+	suite.DeleteGroup(group2)
+	suite.DeleteGroup(group3)
+}
+
+func (suite *GroupRoleCRUDTestSuite) TestRenameGroupRoles_RenamesTheGroupRolesWithClientUIDAndRole() {
+	//arrange
+	client1 := suite.SaveClient(models.CreateNewClient("name1", "redirect.com", 0, "key.pem"))
+	client2 := suite.SaveClient(models.CreateNewClient("name2", "redirect.com", 0, "key.pem"))
+	group1 := suite.SaveGroup(models.CreateGroup("group1", "", 0))
+	group2 := suite.SaveGroup(models.CreateGroup("group2", "", 0))
+
+	role1 := suite.SaveGroupRole(models.CreateGroupRole(client1.UID, group1.Name, "role", "other"))
+	role2 := suite.SaveGroupRole(models.CreateGroupRole(client1.UID, group2.Name, "other"))
+	role3 := suite.SaveGroupRole(models.CreateGroupRole(client2.UID, group1.Name, "role"))
+
+	//act
+	err := suite.Executor.RenameGroupRoles(client1.UID, "role", "new_role")
+
+	//assert
+	suite.Require().NoError(err)
+	role1.Roles = []string{"new_role", "other"}
+
+	for _, role := range []*models.GroupRole{role1, role2, role3} {
+		resultRole, err := suite.Executor.GetGroupRoleByClientUIDAndGroupName(role.ClientUID, role.GroupName)
+		suite.NoError(err)
+		suite.EqualValues(role, resultRole)
+	}
+
+	//clean up
+	suite.DeleteClient(client1)
+	suite.DeleteClient(client2)
+
+	suite.DeleteGroup(group1)
+	suite.DeleteGroup(group2)
+}
+
+func (suite *GroupRoleCRUDTestSuite) TestDeleteGroupRole_WhereGroupRoleNotFound_ReturnsFalseResult() {
+	//act
+	res, err := suite.Executor.DeleteGroupRole(uuid.New(), "DNE")
+
+	//assert
+	suite.False(res)
+	suite.NoError(err)
+}
+
+func (suite *GroupRoleCRUDTestSuite) TestDeleteGroupRole_DeletesGroupRole() {
+	//arrange
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	group := suite.SaveGroup(models.CreateGroup("group", "", 0))
+	role := suite.SaveGroupRole(models.CreateGroupRole(client.UID, group.Name, "role", "other"))
+
+	//act
+	res, err := suite.Executor.DeleteGroupRole(role.ClientUID, role.GroupName)
+
+	//assert
+	suite.True(res)
+	suite.Require().NoError(err)
+
+	resultRole, err := suite.Executor.GetGroupRoleByClientUIDAndGroupName(role.ClientUID, role.GroupName)
+	suite.NoError(err)
+	suite.Nil(resultRole)
+
+	//clean up
+	suite.DeleteClient(client)
+	suite.DeleteGroup(group)
+}
+
+func (suite *GroupRoleCRUDTestSuite) TestDeleteClient_DeletesTheClientsGroupRoles() {
+	//arrange
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	group := suite.SaveGroup(models.CreateGroup("group", "", 0))
+	role := suite.SaveGroupRole(models.CreateGroupRole(client.UID, group.Name, "role"))
+
+	//act
+	suite.DeleteClient(client)
+
+	//assert
+	resultRole, err := suite.Executor.GetGroupRoleByClientUIDAndGroupName(role.ClientUID, role.GroupName)
+	suite.NoError(err)
+	suite.Nil(resultRole)
+
+	//clean up
+	suite.DeleteGroup(group)
+}
+
+func TestGroupRoleCRUDTestSuite(t *testing.T) {
+	suite.Run(t, &GroupRoleCRUDTestSuite{})
+}
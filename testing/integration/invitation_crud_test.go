@@ -0,0 +1,184 @@
+package integration_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type InvitationCRUDTestSuite struct {
+	CRUDTestSuite
+}
+
+func (suite *InvitationCRUDTestSuite) TestSaveInvitation_WithInvalidInvitation_ReturnsError() {
+	//act
+	err := suite.Executor.SaveInvitation(models.CreateInvitation(uuid.Nil, nil, "", 0, nil, time.Time{}))
+
+	//assert
+	suite.Require().Error(err)
+	suite.ContainsSubstrings(err.Error(), "error", "invitation model")
+}
+
+func (suite *InvitationCRUDTestSuite) TestGetInvitationByID_WhereInvitationNotFound_ReturnsNilInvitation() {
+	//act
+	invitation, err := suite.Executor.GetInvitationByID(uuid.New())
+
+	//assert
+	suite.NoError(err)
+	suite.Nil(invitation)
+}
+
+func (suite *InvitationCRUDTestSuite) TestGetInvitationByID_GetsTheInvitationWithID() {
+	//arrange
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	roles := []*models.InvitationRole{
+		models.CreateInvitationRole(client.UID, "role"),
+	}
+	invitation := suite.SaveInvitation(models.CreateNewInvitation([]byte("hash"), "user@example.com", 1, roles, time.Hour))
+
+	//act
+	resultInvitation, err := suite.Executor.GetInvitationByID(invitation.ID)
+
+	//assert
+	suite.NoError(err)
+	suite.EqualValues(invitation, resultInvitation)
+
+	//clean up
+	suite.DeleteInvitation(invitation)
+	suite.DeleteClient(client)
+}
+
+func (suite *InvitationCRUDTestSuite) TestGetInvitationByTokenHash_WhereInvitationNotFound_ReturnsNilInvitation() {
+	//act
+	invitation, err := suite.Executor.GetInvitationByTokenHash([]byte("hash"))
+
+	//assert
+	suite.NoError(err)
+	suite.Nil(invitation)
+}
+
+func (suite *InvitationCRUDTestSuite) TestGetInvitationByTokenHash_GetsTheInvitationWithTokenHash() {
+	//arrange
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	roles := []*models.InvitationRole{
+		models.CreateInvitationRole(client.UID, "role"),
+	}
+	invitation := suite.SaveInvitation(models.CreateNewInvitation([]byte("hash"), "", 0, roles, time.Hour))
+
+	//act
+	resultInvitation, err := suite.Executor.GetInvitationByTokenHash(invitation.TokenHash)
+
+	//assert
+	suite.NoError(err)
+	suite.EqualValues(invitation, resultInvitation)
+
+	//clean up
+	suite.DeleteInvitation(invitation)
+	suite.DeleteClient(client)
+}
+
+func (suite *InvitationCRUDTestSuite) TestDeleteInvitation_WhereInvitationNotFound_ReturnsFalseResult() {
+	//act
+	res, err := suite.Executor.DeleteInvitation(uuid.New())
+
+	//assert
+	suite.False(res)
+	suite.NoError(err)
+}
+
+func (suite *InvitationCRUDTestSuite) TestDeleteInvitation_DeletesInvitationWithID() {
+	//arrange
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	roles := []*models.InvitationRole{
+		models.CreateInvitationRole(client.UID, "role"),
+	}
+	invitation := suite.SaveInvitation(models.CreateNewInvitation([]byte("hash"), "", 0, roles, time.Hour))
+
+	//act
+	res, err := suite.Executor.DeleteInvitation(invitation.ID)
+
+	//assert
+	suite.True(res)
+	suite.NoError(err)
+
+	resultInvitation, err := suite.Executor.GetInvitationByID(invitation.ID)
+	suite.NoError(err)
+	suite.Nil(resultInvitation)
+
+	//clean up
+	suite.DeleteClient(client)
+}
+
+func (suite *InvitationCRUDTestSuite) TestRenameInvitationRoles_RenamesTheInvitationRolesWithClientUIDAndRole() {
+	//arrange
+	client1 := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	client2 := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+
+	roles := []*models.InvitationRole{
+		models.CreateInvitationRole(client1.UID, "role"),
+		models.CreateInvitationRole(client2.UID, "role"),
+	}
+	invitation1 := suite.SaveInvitation(models.CreateNewInvitation([]byte("hash1"), "", 0, roles, time.Hour))
+	invitation2 := suite.SaveInvitation(models.CreateNewInvitation([]byte("hash2"), "", 0, []*models.InvitationRole{
+		models.CreateInvitationRole(client1.UID, "other"),
+	}, time.Hour))
+
+	//act
+	err := suite.Executor.RenameInvitationRoles(client1.UID, "role", "new_role")
+
+	//assert
+	suite.Require().NoError(err)
+
+	resultInvitation, err := suite.Executor.GetInvitationByID(invitation1.ID)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(resultInvitation)
+	suite.ElementsMatch([]*models.InvitationRole{
+		models.CreateInvitationRole(client1.UID, "new_role"),
+		models.CreateInvitationRole(client2.UID, "role"),
+	}, resultInvitation.Roles)
+
+	resultInvitation, err = suite.Executor.GetInvitationByID(invitation2.ID)
+	suite.Require().NoError(err)
+	suite.EqualValues(invitation2, resultInvitation)
+
+	//clean up
+	suite.DeleteInvitation(invitation1)
+	suite.DeleteInvitation(invitation2)
+	suite.DeleteClient(client1)
+	suite.DeleteClient(client2)
+}
+
+func (suite *InvitationCRUDTestSuite) TestDeleteClient_DeletesTheClientsInvitationRoles() {
+	//arrange
+	client1 := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	client2 := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+
+	roles := []*models.InvitationRole{
+		models.CreateInvitationRole(client1.UID, "role"),
+		models.CreateInvitationRole(client2.UID, "role"),
+	}
+	invitation := suite.SaveInvitation(models.CreateNewInvitation([]byte("hash"), "", 0, roles, time.Hour))
+
+	//act
+	suite.DeleteClient(client1)
+
+	//assert
+	resultInvitation, err := suite.Executor.GetInvitationByID(invitation.ID)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(resultInvitation)
+
+	suite.Require().Len(resultInvitation.Roles, 1)
+	suite.EqualValues(roles[1], resultInvitation.Roles[0])
+
+	//clean up
+	suite.DeleteInvitation(invitation)
+	suite.DeleteClient(client2)
+}
+
+func TestInvitationCRUDTestSuite(t *testing.T) {
+	suite.Run(t, &InvitationCRUDTestSuite{})
+}
@@ -0,0 +1,130 @@
+package integration_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LoginThrottleCRUDTestSuite struct {
+	CRUDTestSuite
+}
+
+// now is truncated so times survive the round trip through the database unchanged.
+func (suite *LoginThrottleCRUDTestSuite) now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+func (suite *LoginThrottleCRUDTestSuite) TestSaveLoginThrottle_WithInvalidLoginThrottle_ReturnsError() {
+	//act
+	err := suite.Executor.SaveLoginThrottle(models.CreateNewLoginThrottle("", ""))
+
+	//assert
+	suite.Require().Error(err)
+	suite.ContainsSubstrings(err.Error(), "error", "login throttle model")
+}
+
+func (suite *LoginThrottleCRUDTestSuite) TestSaveLoginThrottle_WithExistingLoginThrottle_ReplacesLoginThrottle() {
+	//arrange
+	now := suite.now()
+	throttle := suite.SaveLoginThrottle(models.CreateLoginThrottle(models.LoginThrottleTypeUsername, "username", 1, now, time.Time{}))
+
+	//act
+	throttle.FailedAttempts = 5
+	throttle.LockedUntil = now.Add(time.Minute)
+	err := suite.Executor.SaveLoginThrottle(throttle)
+
+	//assert
+	suite.Require().NoError(err)
+
+	resultThrottle, err := suite.Executor.GetLoginThrottle(throttle.Type, throttle.Key)
+	suite.NoError(err)
+	suite.Equal(throttle, resultThrottle)
+
+	//clean up
+	suite.Executor.DeleteLoginThrottle(throttle.Type, throttle.Key)
+}
+
+func (suite *LoginThrottleCRUDTestSuite) TestGetLoginThrottle_WhereLoginThrottleIsNotFound_ReturnsNilLoginThrottle() {
+	//act
+	throttle, err := suite.Executor.GetLoginThrottle(models.LoginThrottleTypeIP, "not a real key")
+
+	//assert
+	suite.NoError(err)
+	suite.Nil(throttle)
+}
+
+func (suite *LoginThrottleCRUDTestSuite) TestGetLoginThrottle_GetsTheLoginThrottleWithTypeAndKey() {
+	//arrange
+	now := suite.now()
+	throttle := suite.SaveLoginThrottle(models.CreateLoginThrottle(models.LoginThrottleTypeIP, "127.0.0.1", 3, now, now.Add(time.Minute)))
+
+	//the same key with a different type is a different throttle
+	suite.SaveLoginThrottle(models.CreateLoginThrottle(models.LoginThrottleTypeUsername, throttle.Key, 1, now, time.Time{}))
+
+	//act
+	resultThrottle, err := suite.Executor.GetLoginThrottle(throttle.Type, throttle.Key)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(throttle, resultThrottle)
+
+	//clean up
+	suite.Executor.DeleteLoginThrottle(models.LoginThrottleTypeIP, throttle.Key)
+	suite.Executor.DeleteLoginThrottle(models.LoginThrottleTypeUsername, throttle.Key)
+}
+
+func (suite *LoginThrottleCRUDTestSuite) TestGetLockedLoginThrottles_GetsOnlyLockedLoginThrottlesOrderedByTypeAndKey() {
+	//arrange
+	now := suite.now()
+
+	throttle1 := suite.SaveLoginThrottle(models.CreateLoginThrottle(models.LoginThrottleTypeUsername, "user2", 5, now, now.Add(time.Minute)))
+	throttle2 := suite.SaveLoginThrottle(models.CreateLoginThrottle(models.LoginThrottleTypeUsername, "user1", 5, now, now.Add(time.Hour)))
+	throttle3 := suite.SaveLoginThrottle(models.CreateLoginThrottle(models.LoginThrottleTypeIP, "127.0.0.1", 10, now, now.Add(time.Minute)))
+	throttle4 := suite.SaveLoginThrottle(models.CreateLoginThrottle(models.LoginThrottleTypeUsername, "user3", 5, now, now.Add(-time.Minute)))
+	throttle5 := suite.SaveLoginThrottle(models.CreateLoginThrottle(models.LoginThrottleTypeUsername, "user4", 1, now, time.Time{}))
+
+	//act
+	throttles, err := suite.Executor.GetLockedLoginThrottles(now)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([]*models.LoginThrottle{throttle3, throttle2, throttle1}, throttles)
+
+	//clean up
+	for _, throttle := range []*models.LoginThrottle{throttle1, throttle2, throttle3, throttle4, throttle5} {
+		suite.Executor.DeleteLoginThrottle(throttle.Type, throttle.Key)
+	}
+}
+
+func (suite *LoginThrottleCRUDTestSuite) TestDeleteLoginThrottle_WhereLoginThrottleIsNotFound_ReturnsFalseResult() {
+	//act
+	res, err := suite.Executor.DeleteLoginThrottle(models.LoginThrottleTypeIP, "not a real key")
+
+	//assert
+	suite.False(res)
+	suite.NoError(err)
+}
+
+func (suite *LoginThrottleCRUDTestSuite) TestDeleteLoginThrottle_DeletesLoginThrottleWithTypeAndKey() {
+	//arrange
+	throttle := suite.SaveLoginThrottle(models.CreateLoginThrottle(models.LoginThrottleTypeUsername, "username", 5, suite.now(), suite.now().Add(time.Minute)))
+
+	//act
+	res, err := suite.Executor.DeleteLoginThrottle(throttle.Type, throttle.Key)
+
+	//assert
+	suite.True(res)
+	suite.Require().NoError(err)
+
+	resultThrottle, err := suite.Executor.GetLoginThrottle(throttle.Type, throttle.Key)
+	suite.NoError(err)
+	suite.Nil(resultThrottle)
+}
+
+func TestLoginThrottleCRUDTestSuite(t *testing.T) {
+	suite.Run(t, &LoginThrottleCRUDTestSuite{})
+}
@@ -0,0 +1,126 @@
+package integration_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PasswordResetTokenCRUDTestSuite struct {
+	CRUDTestSuite
+}
+
+func (suite *PasswordResetTokenCRUDTestSuite) TestSavePasswordResetToken_WithInvalidPasswordResetToken_ReturnsError() {
+	//act
+	err := suite.Executor.SavePasswordResetToken(models.CreatePasswordResetToken(nil, "", time.Time{}))
+
+	//assert
+	suite.Require().Error(err)
+	suite.ContainsSubstrings(err.Error(), "error", "password reset token model")
+}
+
+func (suite *PasswordResetTokenCRUDTestSuite) TestGetPasswordResetToken_WherePasswordResetTokenNotFound_ReturnsNilPasswordResetToken() {
+	//act
+	token, err := suite.Executor.GetPasswordResetToken([]byte("hash"))
+
+	//assert
+	suite.NoError(err)
+	suite.Nil(token)
+}
+
+func (suite *PasswordResetTokenCRUDTestSuite) TestGetPasswordResetToken_GetsThePasswordResetTokenWithHash() {
+	//arrange
+	user := suite.SaveUser(models.CreateUser("username", 0, []byte("password")))
+	token := suite.SavePasswordResetToken(models.CreateNewPasswordResetToken([]byte("hash"), user.Username, time.Hour))
+
+	//act
+	resultToken, err := suite.Executor.GetPasswordResetToken(token.TokenHash)
+
+	//assert
+	suite.NoError(err)
+	suite.EqualValues(token, resultToken)
+
+	//clean up
+	suite.DeleteUser(user)
+}
+
+func (suite *PasswordResetTokenCRUDTestSuite) TestDeletePasswordResetToken_WherePasswordResetTokenNotFound_ReturnsFalseResult() {
+	//act
+	res, err := suite.Executor.DeletePasswordResetToken([]byte("hash"))
+
+	//assert
+	suite.False(res)
+	suite.NoError(err)
+}
+
+func (suite *PasswordResetTokenCRUDTestSuite) TestDeletePasswordResetToken_DeletesPasswordResetTokenWithHash() {
+	//arrange
+	user := suite.SaveUser(models.CreateUser("username", 0, []byte("password")))
+	token := suite.SavePasswordResetToken(models.CreateNewPasswordResetToken([]byte("hash"), user.Username, time.Hour))
+
+	//act
+	res, err := suite.Executor.DeletePasswordResetToken(token.TokenHash)
+
+	//assert
+	suite.True(res)
+	suite.NoError(err)
+
+	resultToken, err := suite.Executor.GetPasswordResetToken(token.TokenHash)
+	suite.NoError(err)
+	suite.Nil(resultToken)
+
+	//clean up
+	suite.DeleteUser(user)
+}
+
+func (suite *PasswordResetTokenCRUDTestSuite) TestDeleteAllUserPasswordResetTokens_DeletesOnlyTheUsersPasswordResetTokens() {
+	//arrange
+	user1 := suite.SaveUser(models.CreateUser("user1", 0, []byte("password")))
+	user2 := suite.SaveUser(models.CreateUser("user2", 0, []byte("password")))
+
+	token1 := suite.SavePasswordResetToken(models.CreateNewPasswordResetToken([]byte("hash1"), user1.Username, time.Hour))
+	token2 := suite.SavePasswordResetToken(models.CreateNewPasswordResetToken([]byte("hash2"), user1.Username, time.Hour))
+	token3 := suite.SavePasswordResetToken(models.CreateNewPasswordResetToken([]byte("hash3"), user2.Username, time.Hour))
+
+	//act
+	err := suite.Executor.DeleteAllUserPasswordResetTokens(user1.Username)
+	suite.Require().NoError(err)
+
+	//assert
+	resultToken, err := suite.Executor.GetPasswordResetToken(token1.TokenHash)
+	suite.NoError(err)
+	suite.Nil(resultToken)
+
+	resultToken, err = suite.Executor.GetPasswordResetToken(token2.TokenHash)
+	suite.NoError(err)
+	suite.Nil(resultToken)
+
+	resultToken, err = suite.Executor.GetPasswordResetToken(token3.TokenHash)
+	suite.NoError(err)
+	suite.EqualValues(token3, resultToken)
+
+	//clean up
+	suite.DeleteUser(user1)
+	suite.DeleteUser(user2)
+}
+
+func (suite *PasswordResetTokenCRUDTestSuite) TestDeleteUser_DeletesTheUsersPasswordResetTokens() {
+	//arrange
+	user := suite.SaveUser(models.CreateUser("username", 0, []byte("password")))
+	token := suite.SavePasswordResetToken(models.CreateNewPasswordResetToken([]byte("hash"), user.Username, time.Hour))
+
+	//act
+	suite.DeleteUser(user)
+
+	//assert
+	resultToken, err := suite.Executor.GetPasswordResetToken(token.TokenHash)
+	suite.NoError(err)
+	suite.Nil(resultToken)
+}
+
+func TestPasswordResetTokenCRUDTestSuite(t *testing.T) {
+	suite.Run(t, &PasswordResetTokenCRUDTestSuite{})
+}
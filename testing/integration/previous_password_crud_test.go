@@ -0,0 +1,108 @@
+package integration_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PreviousPasswordCRUDTestSuite struct {
+	CRUDTestSuite
+}
+
+func (suite *PreviousPasswordCRUDTestSuite) TestSavePreviousPassword_WithInvalidPreviousPassword_ReturnsError() {
+	//act
+	err := suite.Executor.SavePreviousPassword(models.CreateNewPreviousPassword("", nil))
+
+	//assert
+	suite.Require().Error(err)
+	suite.ContainsSubstrings(err.Error(), "error", "previous password model")
+}
+
+func (suite *PreviousPasswordCRUDTestSuite) TestGetLatestUserPreviousPasswords_WhereUserHasNone_ReturnsEmptySlice() {
+	//act
+	passwords, err := suite.Executor.GetLatestUserPreviousPasswords("username", 3)
+
+	//assert
+	suite.NoError(err)
+	suite.Empty(passwords)
+}
+
+func (suite *PreviousPasswordCRUDTestSuite) TestGetLatestUserPreviousPasswords_GetsNewestPasswordsForUserUpToLimit() {
+	//arrange
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	user1 := suite.SaveUser(models.CreateUser("user1", 0, []byte("password")))
+	user2 := suite.SaveUser(models.CreateUser("user2", 0, []byte("password")))
+
+	password1 := suite.SavePreviousPassword(models.CreatePreviousPassword(user1.Username, []byte("hash1"), now.Add(-3*time.Hour)))
+	password2 := suite.SavePreviousPassword(models.CreatePreviousPassword(user1.Username, []byte("hash2"), now.Add(-time.Hour)))
+	password3 := suite.SavePreviousPassword(models.CreatePreviousPassword(user1.Username, []byte("hash3"), now.Add(-2*time.Hour)))
+	suite.SavePreviousPassword(models.CreatePreviousPassword(user2.Username, []byte("hash4"), now))
+
+	//act
+	passwords, err := suite.Executor.GetLatestUserPreviousPasswords(user1.Username, 2)
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Equal([]*models.PreviousPassword{password2, password3}, passwords)
+
+	passwords, err = suite.Executor.GetLatestUserPreviousPasswords(user1.Username, 5)
+	suite.Require().NoError(err)
+	suite.Equal([]*models.PreviousPassword{password2, password3, password1}, passwords)
+
+	//clean up
+	suite.DeleteUser(user1)
+	suite.DeleteUser(user2)
+}
+
+func (suite *PreviousPasswordCRUDTestSuite) TestDeleteUserPreviousPasswordsReplacedBefore_DeletesOnlyOlderPasswordsForUser() {
+	//arrange
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	user1 := suite.SaveUser(models.CreateUser("user1", 0, []byte("password")))
+	user2 := suite.SaveUser(models.CreateUser("user2", 0, []byte("password")))
+
+	suite.SavePreviousPassword(models.CreatePreviousPassword(user1.Username, []byte("hash1"), now.Add(-2*time.Hour)))
+	password2 := suite.SavePreviousPassword(models.CreatePreviousPassword(user1.Username, []byte("hash2"), now.Add(-time.Hour)))
+	password3 := suite.SavePreviousPassword(models.CreatePreviousPassword(user2.Username, []byte("hash3"), now.Add(-2*time.Hour)))
+
+	//act
+	err := suite.Executor.DeleteUserPreviousPasswordsReplacedBefore(user1.Username, password2.ReplacedAt)
+
+	//assert
+	suite.Require().NoError(err)
+
+	passwords, err := suite.Executor.GetLatestUserPreviousPasswords(user1.Username, 5)
+	suite.Require().NoError(err)
+	suite.Equal([]*models.PreviousPassword{password2}, passwords)
+
+	passwords, err = suite.Executor.GetLatestUserPreviousPasswords(user2.Username, 5)
+	suite.Require().NoError(err)
+	suite.Equal([]*models.PreviousPassword{password3}, passwords)
+
+	//clean up
+	suite.DeleteUser(user1)
+	suite.DeleteUser(user2)
+}
+
+func (suite *PreviousPasswordCRUDTestSuite) TestDeleteUser_DeletesUserPreviousPasswords() {
+	//arrange
+	user := suite.SaveUser(models.CreateUser("username", 0, []byte("password")))
+	suite.SavePreviousPassword(models.CreateNewPreviousPassword(user.Username, []byte("hash")))
+
+	//act
+	suite.DeleteUser(user)
+
+	//assert
+	passwords, err := suite.Executor.GetLatestUserPreviousPasswords(user.Username, 5)
+	suite.NoError(err)
+	suite.Empty(passwords)
+}
+
+func TestPreviousPasswordCRUDTestSuite(t *testing.T) {
+	suite.Run(t, &PreviousPasswordCRUDTestSuite{})
+}
@@ -0,0 +1,87 @@
+package integration_test
+
+import (
+	"testing"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RecoveryCodeCRUDTestSuite struct {
+	CRUDTestSuite
+}
+
+func (suite *RecoveryCodeCRUDTestSuite) TestSaveRecoveryCode_WithInvalidRecoveryCode_ReturnsError() {
+	//act
+	err := suite.Executor.SaveRecoveryCode(models.CreateRecoveryCode("", nil))
+
+	//assert
+	suite.Require().Error(err)
+	suite.ContainsSubstrings(err.Error(), "error", "recovery code model")
+}
+
+func (suite *RecoveryCodeCRUDTestSuite) TestDeleteRecoveryCode_WhereRecoveryCodeIsNotFound_ReturnsFalseResult() {
+	//act
+	res, err := suite.Executor.DeleteRecoveryCode("username", []byte("hash"))
+
+	//assert
+	suite.False(res)
+	suite.NoError(err)
+}
+
+func (suite *RecoveryCodeCRUDTestSuite) TestDeleteRecoveryCode_DeletesRecoveryCodeOnlyOnce() {
+	//arrange
+	user := suite.SaveUser(models.CreateUser("username", 0, []byte("password")))
+	code := suite.SaveRecoveryCode(models.CreateRecoveryCode(user.Username, []byte("hash")))
+
+	//act
+	res, err := suite.Executor.DeleteRecoveryCode(code.Username, code.CodeHash)
+
+	//assert
+	suite.True(res)
+	suite.Require().NoError(err)
+
+	//deleting again has no effect
+	res, err = suite.Executor.DeleteRecoveryCode(code.Username, code.CodeHash)
+	suite.False(res)
+	suite.NoError(err)
+
+	//clean up
+	suite.DeleteUser(user)
+}
+
+func (suite *RecoveryCodeCRUDTestSuite) TestDeleteAllUserRecoveryCodes_DeletesOnlyRecoveryCodesForUser() {
+	//arrange
+	user1 := suite.SaveUser(models.CreateUser("user1", 0, []byte("password")))
+	user2 := suite.SaveUser(models.CreateUser("user2", 0, []byte("password")))
+
+	code1 := suite.SaveRecoveryCode(models.CreateRecoveryCode(user1.Username, []byte("hash1")))
+	code2 := suite.SaveRecoveryCode(models.CreateRecoveryCode(user1.Username, []byte("hash2")))
+	code3 := suite.SaveRecoveryCode(models.CreateRecoveryCode(user2.Username, []byte("hash1")))
+
+	//act
+	err := suite.Executor.DeleteAllUserRecoveryCodes(user1.Username)
+	suite.Require().NoError(err)
+
+	//assert
+	res, err := suite.Executor.DeleteRecoveryCode(code1.Username, code1.CodeHash)
+	suite.NoError(err)
+	suite.False(res)
+
+	res, err = suite.Executor.DeleteRecoveryCode(code2.Username, code2.CodeHash)
+	suite.NoError(err)
+	suite.False(res)
+
+	res, err = suite.Executor.DeleteRecoveryCode(code3.Username, code3.CodeHash)
+	suite.NoError(err)
+	suite.True(res)
+
+	//clean up
+	suite.DeleteUser(user1)
+	suite.DeleteUser(user2)
+}
+
+func TestRecoveryCodeCRUDTestSuite(t *testing.T) {
+	suite.Run(t, &RecoveryCodeCRUDTestSuite{})
+}
@@ -0,0 +1,123 @@
+package integration_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type RefreshTokenCRUDTestSuite struct {
+	CRUDTestSuite
+}
+
+func (suite *RefreshTokenCRUDTestSuite) TestSaveRefreshToken_WithInvalidRefreshToken_ReturnsError() {
+	//act
+	err := suite.Executor.SaveRefreshToken(models.CreateRefreshToken(uuid.Nil, uuid.Nil, uuid.Nil, "", false, time.Time{}))
+
+	//assert
+	suite.Require().Error(err)
+	suite.ContainsSubstrings(err.Error(), "error", "refresh token model")
+}
+
+func (suite *RefreshTokenCRUDTestSuite) TestGetRefreshToken_WhereRefreshTokenNotFound_ReturnsNilRefreshToken() {
+	//act
+	token, err := suite.Executor.GetRefreshToken(uuid.New())
+
+	//assert
+	suite.NoError(err)
+	suite.Nil(token)
+}
+
+func (suite *RefreshTokenCRUDTestSuite) TestGetRefreshToken_GetsTheRefreshTokenWithToken() {
+	//arrange
+	user := suite.SaveUser(models.CreateUser("username", 0, []byte("password")))
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	token := suite.SaveRefreshToken(models.CreateNewRefreshToken(uuid.New(), client.UID, user.Username, time.Hour))
+
+	//act
+	resultToken, err := suite.Executor.GetRefreshToken(token.Token)
+
+	//assert
+	suite.NoError(err)
+	suite.EqualValues(token, resultToken)
+
+	//clean up
+	suite.DeleteClient(client)
+	suite.DeleteUser(user)
+}
+
+func (suite *RefreshTokenCRUDTestSuite) TestRotateRefreshToken_WhereRefreshTokenIsNotFound_ReturnsFalseResult() {
+	//act
+	res, err := suite.Executor.RotateRefreshToken(uuid.New())
+
+	//assert
+	suite.False(res)
+	suite.NoError(err)
+}
+
+func (suite *RefreshTokenCRUDTestSuite) TestRotateRefreshToken_MarksRefreshTokenAsRotatedOnlyOnce() {
+	//arrange
+	user := suite.SaveUser(models.CreateUser("username", 0, []byte("password")))
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	token := suite.SaveRefreshToken(models.CreateNewRefreshToken(uuid.New(), client.UID, user.Username, time.Hour))
+
+	//act
+	res, err := suite.Executor.RotateRefreshToken(token.Token)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.True(res)
+
+	resultToken, err := suite.Executor.GetRefreshToken(token.Token)
+	suite.NoError(err)
+	suite.True(resultToken.Rotated)
+
+	//rotating again has no effect
+	res, err = suite.Executor.RotateRefreshToken(token.Token)
+	suite.NoError(err)
+	suite.False(res)
+
+	//clean up
+	suite.DeleteClient(client)
+	suite.DeleteUser(user)
+}
+
+func (suite *RefreshTokenCRUDTestSuite) TestDeleteRefreshTokenFamily_DeletesOnlyTokensInFamily() {
+	//arrange
+	user := suite.SaveUser(models.CreateUser("username", 0, []byte("password")))
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+
+	familyID := uuid.New()
+	token1 := suite.SaveRefreshToken(models.CreateNewRefreshToken(familyID, client.UID, user.Username, time.Hour))
+	token2 := suite.SaveRefreshToken(models.CreateNewRefreshToken(familyID, client.UID, user.Username, time.Hour))
+	token3 := suite.SaveRefreshToken(models.CreateNewRefreshToken(uuid.New(), client.UID, user.Username, time.Hour))
+
+	//act
+	err := suite.Executor.DeleteRefreshTokenFamily(familyID)
+	suite.Require().NoError(err)
+
+	//assert
+	resultToken, err := suite.Executor.GetRefreshToken(token1.Token)
+	suite.NoError(err)
+	suite.Nil(resultToken)
+
+	resultToken, err = suite.Executor.GetRefreshToken(token2.Token)
+	suite.NoError(err)
+	suite.Nil(resultToken)
+
+	resultToken, err = suite.Executor.GetRefreshToken(token3.Token)
+	suite.NoError(err)
+	suite.EqualValues(token3, resultToken)
+
+	//clean up
+	suite.DeleteClient(client)
+	suite.DeleteUser(user)
+}
+
+func TestRefreshTokenCRUDTestSuite(t *testing.T) {
+	suite.Run(t, &RefreshTokenCRUDTestSuite{})
+}
@@ -0,0 +1,185 @@
+package integration_test
+
+import (
+	"testing"
+
+	"github.com/mhogar/amber/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type RoleDefinitionCRUDTestSuite struct {
+	CRUDTestSuite
+}
+
+func (suite *RoleDefinitionCRUDTestSuite) TestCreateRoleDefinition_WithInvalidRoleDefinition_ReturnsError() {
+	//act
+	err := suite.Executor.CreateRoleDefinition(models.CreateRoleDefinition(uuid.Nil, "", "", false))
+
+	//assert
+	suite.Require().Error(err)
+	suite.ContainsSubstrings(err.Error(), "error", "role definition model")
+}
+
+func (suite *RoleDefinitionCRUDTestSuite) TestGetRoleDefinitionsByClientUID_GetsTheRoleDefinitionsWithClientUIDOrderedByName() {
+	//arrange
+	client1 := suite.SaveClient(models.CreateNewClient("name1", "redirect.com", 0, "key.pem"))
+	client2 := suite.SaveClient(models.CreateNewClient("name2", "redirect.com", 0, "key.pem"))
+
+	definition1 := suite.SaveRoleDefinition(models.CreateRoleDefinition(client1.UID, "member", "", true))
+	definition2 := suite.SaveRoleDefinition(models.CreateRoleDefinition(client1.UID, "admin", "Manages the client", false))
+	suite.SaveRoleDefinition(models.CreateRoleDefinition(client2.UID, "admin", "", false))
+
+	//act
+	definitions, err := suite.Executor.GetRoleDefinitionsByClientUID(client1.UID)
+
+	//assert
+	suite.NoError(err)
+
+	suite.Require().Len(definitions, 2)
+	suite.EqualValues(definition2, definitions[0])
+	suite.EqualValues(definition1, definitions[1])
+
+	//clean up
+	suite.DeleteClient(client1)
+	suite.DeleteClient(client2)
+}
+
+func (suite *RoleDefinitionCRUDTestSuite) TestGetRoleDefinitionByClientUIDAndName_WhereRoleDefinitionNotFound_ReturnsNilRoleDefinition() {
+	//act
+	definition, err := suite.Executor.GetRoleDefinitionByClientUIDAndName(uuid.New(), "DNE")
+
+	//assert
+	suite.NoError(err)
+	suite.Nil(definition)
+}
+
+func (suite *RoleDefinitionCRUDTestSuite) TestGetRoleDefinitionByClientUIDAndName_GetsTheRoleDefinitionWithClientUIDAndName() {
+	//arrange
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	definition := suite.SaveRoleDefinition(models.CreateRoleDefinition(client.UID, "role", "description", true))
+
+	//act
+	resultDefinition, err := suite.Executor.GetRoleDefinitionByClientUIDAndName(definition.ClientUID, definition.Name)
+
+	//assert
+	suite.NoError(err)
+	suite.EqualValues(definition, resultDefinition)
+
+	//clean up
+	suite.DeleteClient(client)
+}
+
+func (suite *RoleDefinitionCRUDTestSuite) TestUpdateRoleDefinition_WithInvalidRoleDefinition_ReturnsError() {
+	//act
+	res, err := suite.Executor.UpdateRoleDefinition("role", models.CreateRoleDefinition(uuid.Nil, "", "", false))
+
+	//assert
+	suite.False(res)
+	suite.Require().Error(err)
+	suite.ContainsSubstrings(err.Error(), "error", "role definition model")
+}
+
+func (suite *RoleDefinitionCRUDTestSuite) TestUpdateRoleDefinition_WhereRoleDefinitionIsNotFound_ReturnsFalseResult() {
+	//act
+	res, err := suite.Executor.UpdateRoleDefinition("DNE", models.CreateRoleDefinition(uuid.New(), "role", "", false))
+
+	//assert
+	suite.False(res)
+	suite.NoError(err)
+}
+
+func (suite *RoleDefinitionCRUDTestSuite) TestUpdateRoleDefinition_UpdatesRoleDefinition() {
+	//arrange
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	definition := suite.SaveRoleDefinition(models.CreateRoleDefinition(client.UID, "role", "", false))
+
+	//act
+	definition.Description = "new description"
+	definition.IsDefault = true
+	res, err := suite.Executor.UpdateRoleDefinition(definition.Name, definition)
+
+	//assert
+	suite.True(res)
+	suite.Require().NoError(err)
+
+	resultDefinition, err := suite.Executor.GetRoleDefinitionByClientUIDAndName(definition.ClientUID, definition.Name)
+	suite.NoError(err)
+	suite.EqualValues(definition, resultDefinition)
+
+	//clean up
+	suite.DeleteClient(client)
+}
+
+func (suite *RoleDefinitionCRUDTestSuite) TestUpdateRoleDefinition_WithNewName_RenamesRoleDefinition() {
+	//arrange
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	definition := suite.SaveRoleDefinition(models.CreateRoleDefinition(client.UID, "role", "", false))
+
+	//act
+	definition.Name = "new_role"
+	res, err := suite.Executor.UpdateRoleDefinition("role", definition)
+
+	//assert
+	suite.True(res)
+	suite.Require().NoError(err)
+
+	resultDefinition, err := suite.Executor.GetRoleDefinitionByClientUIDAndName(definition.ClientUID, "role")
+	suite.NoError(err)
+	suite.Nil(resultDefinition)
+
+	resultDefinition, err = suite.Executor.GetRoleDefinitionByClientUIDAndName(definition.ClientUID, definition.Name)
+	suite.NoError(err)
+	suite.EqualValues(definition, resultDefinition)
+
+	//clean up
+	suite.DeleteClient(client)
+}
+
+func (suite *RoleDefinitionCRUDTestSuite) TestDeleteRoleDefinition_WhereRoleDefinitionNotFound_ReturnsFalseResult() {
+	//act
+	res, err := suite.Executor.DeleteRoleDefinition(uuid.New(), "DNE")
+
+	//assert
+	suite.False(res)
+	suite.NoError(err)
+}
+
+func (suite *RoleDefinitionCRUDTestSuite) TestDeleteRoleDefinition_DeletesRoleDefinition() {
+	//arrange
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	definition := suite.SaveRoleDefinition(models.CreateRoleDefinition(client.UID, "role", "", false))
+
+	//act
+	res, err := suite.Executor.DeleteRoleDefinition(definition.ClientUID, definition.Name)
+
+	//assert
+	suite.True(res)
+	suite.Require().NoError(err)
+
+	resultDefinition, err := suite.Executor.GetRoleDefinitionByClientUIDAndName(definition.ClientUID, definition.Name)
+	suite.NoError(err)
+	suite.Nil(resultDefinition)
+
+	//clean up
+	suite.DeleteClient(client)
+}
+
+func (suite *RoleDefinitionCRUDTestSuite) TestDeleteClient_DeletesTheClientsRoleDefinitions() {
+	//arrange
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+	suite.SaveRoleDefinition(models.CreateRoleDefinition(client.UID, "role", "", false))
+
+	//act
+	suite.DeleteClient(client)
+
+	//assert
+	definitions, err := suite.Executor.GetRoleDefinitionsByClientUID(client.UID)
+	suite.NoError(err)
+	suite.Empty(definitions)
+}
+
+func TestRoleDefinitionCRUDTestSuite(t *testing.T) {
+	suite.Run(t, &RoleDefinitionCRUDTestSuite{})
+}
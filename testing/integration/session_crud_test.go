@@ -2,6 +2,7 @@ package integration_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/mhogar/amber/models"
 
@@ -47,6 +48,36 @@ func (suite *SessionCRUDTestSuite) TestGetSessionById_GetsTheSessionWithId() {
 	suite.DeleteUser(user)
 }
 
+func (suite *SessionCRUDTestSuite) TestUpdateSessionLastUsedAt_WhereSessionIsNotFound_ReturnsFalseResult() {
+	//act
+	res, err := suite.Executor.UpdateSessionLastUsedAt(uuid.New(), time.Now().UTC())
+
+	//assert
+	suite.False(res)
+	suite.NoError(err)
+}
+
+func (suite *SessionCRUDTestSuite) TestUpdateSessionLastUsedAt_UpdatesSessionWithToken() {
+	//arrange
+	user := suite.SaveUser(models.CreateUser("username", 0, []byte("password")))
+	session := suite.SaveSession(models.CreateNewSession(user.Username, 0))
+	session.LastUsedAt = session.LastUsedAt.Add(time.Minute)
+
+	//act
+	res, err := suite.Executor.UpdateSessionLastUsedAt(session.Token, session.LastUsedAt)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.True(res)
+
+	resultSession, err := suite.Executor.GetSessionByToken(session.Token)
+	suite.NoError(err)
+	suite.EqualValues(session, resultSession)
+
+	//clean up
+	suite.DeleteUser(user)
+}
+
 func (suite *SessionCRUDTestSuite) TestDeleteSession_WhereSessionIsNotFound_ReturnsFalseResult() {
 	//act
 	res, err := suite.Executor.DeleteSession(uuid.New())
@@ -166,6 +197,46 @@ func (suite *SessionCRUDTestSuite) TestDeleteAllOtherUserSessions_DeletesAllOthe
 	suite.DeleteUser(user)
 }
 
+func (suite *SessionCRUDTestSuite) TestDeleteExpiredSessions_DeletesSessionsCreatedOrLastUsedBeforeCutoffs() {
+	//arrange
+	user := suite.SaveUser(models.CreateUser("username", 0, []byte("password")))
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	session1 := models.CreateNewSession(user.Username, 0)
+	session1.CreatedAt = now.Add(-time.Hour)
+	suite.SaveSession(session1)
+
+	session2 := models.CreateNewSession(user.Username, 0)
+	session2.LastUsedAt = now.Add(-time.Hour)
+	suite.SaveSession(session2)
+
+	session3 := suite.SaveSession(models.CreateNewSession(user.Username, 0))
+
+	//act
+	err := suite.Executor.DeleteExpiredSessions(now.Add(-time.Minute), now.Add(-time.Minute))
+
+	//assert
+	suite.Require().NoError(err)
+
+	//session1 was deleted
+	resultSession, err := suite.Executor.GetSessionByToken(session1.Token)
+	suite.NoError(err)
+	suite.Nil(resultSession)
+
+	//session2 was deleted
+	resultSession, err = suite.Executor.GetSessionByToken(session2.Token)
+	suite.NoError(err)
+	suite.Nil(resultSession)
+
+	//can still find session3
+	resultSession, err = suite.Executor.GetSessionByToken(session3.Token)
+	suite.NoError(err)
+	suite.EqualValues(session3, resultSession)
+
+	//clean up
+	suite.DeleteUser(user)
+}
+
 func TestSessionCRUDTestSuite(t *testing.T) {
 	suite.Run(t, &SessionCRUDTestSuite{})
 }
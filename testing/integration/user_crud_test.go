@@ -2,6 +2,7 @@ package integration_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/mhogar/amber/models"
 
@@ -37,7 +38,7 @@ func (suite *UserCRUDTestSuite) TestGetUsersWithLesserRank_GetsTheUsersWithLesse
 	user3 := suite.SaveUser(models.CreateUser("user3", 2, []byte("password")))
 
 	//act
-	users, err := suite.Executor.GetUsersWithLesserRank(2)
+	users, err := suite.Executor.GetUsersWithLesserRank(2, models.PageQuery{})
 
 	//assert
 	suite.NoError(err)
@@ -52,6 +53,83 @@ func (suite *UserCRUDTestSuite) TestGetUsersWithLesserRank_GetsTheUsersWithLesse
 	suite.DeleteUser(user3)
 }
 
+func (suite *UserCRUDTestSuite) TestGetUsersWithLesserRank_SortedByRankDescending_PagesUsingCursor() {
+	//arrange
+	user1 := suite.SaveUser(models.CreateUser("user1", 0, []byte("password")))
+	user2 := suite.SaveUser(models.CreateUser("user2", 1, []byte("password")))
+	user3 := suite.SaveUser(models.CreateUser("user3", 1, []byte("password")))
+
+	query := models.PageQuery{
+		SortBy:         models.UserSortRank,
+		SortDescending: true,
+		Limit:          2,
+	}
+
+	//act
+	page1, err1 := suite.Executor.GetUsersWithLesserRank(2, query)
+
+	cursor := user2.GetCursor(models.UserSortRank)
+	query.After = &cursor
+	page2, err2 := suite.Executor.GetUsersWithLesserRank(2, query)
+
+	//assert
+	suite.NoError(err1)
+	suite.Require().Len(page1, 2)
+	suite.EqualValues(page1[0], user3)
+	suite.EqualValues(page1[1], user2)
+
+	suite.NoError(err2)
+	suite.Require().Len(page2, 1)
+	suite.EqualValues(page2[0], user1)
+
+	//clean up
+	suite.DeleteUser(user1)
+	suite.DeleteUser(user2)
+	suite.DeleteUser(user3)
+}
+
+func (suite *UserCRUDTestSuite) TestGetUsersWithLesserRank_WithSearch_GetsUsersWithUsernamePrefixIgnoringCase() {
+	//arrange
+	user1 := suite.SaveUser(models.CreateUser("alpha1", 0, []byte("password")))
+	user2 := suite.SaveUser(models.CreateUser("Alpha2", 0, []byte("password")))
+	user3 := suite.SaveUser(models.CreateUser("beta_alpha", 0, []byte("password")))
+
+	//act
+	users, err := suite.Executor.GetUsersWithLesserRank(1, models.PageQuery{Search: "ALPHA"})
+
+	//assert
+	suite.NoError(err)
+
+	suite.Require().Len(users, 2)
+	suite.ElementsMatch([]string{user1.Username, user2.Username}, []string{users[0].Username, users[1].Username})
+
+	//clean up
+	suite.DeleteUser(user1)
+	suite.DeleteUser(user2)
+	suite.DeleteUser(user3)
+}
+
+func (suite *UserCRUDTestSuite) TestCountUsersWithLesserRank_CountsTheUsersWithLesserRankMatchingSearch() {
+	//arrange
+	user1 := suite.SaveUser(models.CreateUser("user1", 0, []byte("password")))
+	user2 := suite.SaveUser(models.CreateUser("user2", 1, []byte("password")))
+	user3 := suite.SaveUser(models.CreateUser("other", 1, []byte("password")))
+	user4 := suite.SaveUser(models.CreateUser("user4", 2, []byte("password")))
+
+	//act
+	count, err := suite.Executor.CountUsersWithLesserRank(2, "user")
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(2, count)
+
+	//clean up
+	suite.DeleteUser(user1)
+	suite.DeleteUser(user2)
+	suite.DeleteUser(user3)
+	suite.DeleteUser(user4)
+}
+
 func (suite *UserCRUDTestSuite) TestGetUserByUsername_WhereUserNotFound_ReturnsNilUser() {
 	//act
 	user, err := suite.Executor.GetUserByUsername("DNE")
@@ -76,6 +154,48 @@ func (suite *UserCRUDTestSuite) TestGetUserByUsername_GetsTheUserWithUsername()
 	suite.DeleteUser(user)
 }
 
+func (suite *UserCRUDTestSuite) TestGetUserByEmail_WhereUserNotFound_ReturnsNilUser() {
+	//arrange
+	user := suite.SaveUser(models.CreateUser("username", 0, []byte("password")))
+
+	testCase := func(email string) func() {
+		return func() {
+			//act
+			resultUser, err := suite.Executor.GetUserByEmail(email)
+
+			//assert
+			suite.NoError(err)
+			suite.Nil(resultUser)
+		}
+	}
+
+	suite.Run("EmailDoesNotExist", testCase("dne@example.com"))
+
+	//users without an email should not match an empty one
+	suite.Run("EmptyEmail", testCase(""))
+
+	//clean up
+	suite.DeleteUser(user)
+}
+
+func (suite *UserCRUDTestSuite) TestGetUserByEmail_GetsTheUserWithEmail() {
+	//arrange
+	user := models.CreateUser("username", 0, []byte("password"))
+	user.Email = "user@example.com"
+	user.DisplayName = "Display Name"
+	suite.SaveUser(user)
+
+	//act
+	resultUser, err := suite.Executor.GetUserByEmail(user.Email)
+
+	//assert
+	suite.NoError(err)
+	suite.EqualValues(user, resultUser)
+
+	//clean up
+	suite.DeleteUser(user)
+}
+
 func (suite *UserCRUDTestSuite) TestUpdateUser_WithInvalidUser_ReturnsError() {
 	//act
 	_, err := suite.Executor.UpdateUser(models.CreateUser("", -1, nil))
@@ -100,6 +220,9 @@ func (suite *UserCRUDTestSuite) TestUpdateUser_UpdatesUser() {
 
 	//act
 	user.Rank = 10
+	user.Email = "user@example.com"
+	user.DisplayName = "Display Name"
+	user.Enabled = false
 	res, err := suite.Executor.UpdateUser(user)
 
 	//assert
@@ -116,7 +239,7 @@ func (suite *UserCRUDTestSuite) TestUpdateUser_UpdatesUser() {
 
 func (suite *UserCRUDTestSuite) TestUpdateUserPassword_WithNilHash_ReturnsError() {
 	//act
-	_, err := suite.Executor.UpdateUserPassword("username", nil)
+	_, err := suite.Executor.UpdateUserPassword("username", nil, time.Now())
 
 	//assert
 	suite.Require().Error(err)
@@ -125,7 +248,7 @@ func (suite *UserCRUDTestSuite) TestUpdateUserPassword_WithNilHash_ReturnsError(
 
 func (suite *UserCRUDTestSuite) TestUpdateUserPassword_WhereUserIsNotFound_ReturnsFalseResult() {
 	//act
-	res, err := suite.Executor.UpdateUserPassword("username", []byte("password"))
+	res, err := suite.Executor.UpdateUserPassword("username", []byte("password"), time.Now())
 
 	//assert
 	suite.False(res)
@@ -135,10 +258,11 @@ func (suite *UserCRUDTestSuite) TestUpdateUserPassword_WhereUserIsNotFound_Retur
 func (suite *UserCRUDTestSuite) TestUpdateUserPassword_UpdatesUserWithUsername() {
 	//arrange
 	newPassword := []byte("new_password")
+	updatedAt := time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond)
 	user := suite.SaveUser(models.CreateUser("username", 0, []byte("password")))
 
 	//act
-	res, err := suite.Executor.UpdateUserPassword(user.Username, newPassword)
+	res, err := suite.Executor.UpdateUserPassword(user.Username, newPassword, updatedAt)
 
 	//assert
 	suite.True(res)
@@ -147,6 +271,86 @@ func (suite *UserCRUDTestSuite) TestUpdateUserPassword_UpdatesUserWithUsername()
 	resultUser, err := suite.Executor.GetUserByUsername(user.Username)
 	suite.NoError(err)
 	suite.Equal(newPassword, resultUser.PasswordHash)
+	suite.Equal(updatedAt, resultUser.PasswordUpdatedAt)
+
+	//clean up
+	suite.DeleteUser(user)
+}
+
+func (suite *UserCRUDTestSuite) TestUpdateUserTOTP_WhereUserIsNotFound_ReturnsFalseResult() {
+	//act
+	res, err := suite.Executor.UpdateUserTOTP("username", []byte("secret"), true)
+
+	//assert
+	suite.False(res)
+	suite.NoError(err)
+}
+
+func (suite *UserCRUDTestSuite) TestUpdateUserTOTP_UpdatesUserWithUsername() {
+	//arrange
+	secret := []byte("secret")
+	user := suite.SaveUser(models.CreateUser("username", 0, []byte("password")))
+
+	//act
+	res, err := suite.Executor.UpdateUserTOTP(user.Username, secret, true)
+
+	//assert
+	suite.True(res)
+	suite.Require().NoError(err)
+
+	resultUser, err := suite.Executor.GetUserByUsername(user.Username)
+	suite.NoError(err)
+	suite.Equal(secret, resultUser.TOTPSecret)
+	suite.True(resultUser.TOTPEnabled)
+
+	//clean up
+	suite.DeleteUser(user)
+}
+
+func (suite *UserCRUDTestSuite) TestUpdateUserTOTPLastTimeStep_WhereUserIsNotFound_ReturnsFalseResult() {
+	//act
+	res, err := suite.Executor.UpdateUserTOTPLastTimeStep("username", 1)
+
+	//assert
+	suite.False(res)
+	suite.NoError(err)
+}
+
+func (suite *UserCRUDTestSuite) TestUpdateUserTOTPLastTimeStep_OnlyUpdatesToLaterSteps() {
+	//arrange
+	user := suite.SaveUser(models.CreateUser("username", 0, []byte("password")))
+
+	var step int64
+	var expectedResult bool
+	var expectedStep int64
+
+	testCase := func() {
+		//act
+		res, err := suite.Executor.UpdateUserTOTPLastTimeStep(user.Username, step)
+
+		//assert
+		suite.Equal(expectedResult, res)
+		suite.Require().NoError(err)
+
+		resultUser, err := suite.Executor.GetUserByUsername(user.Username)
+		suite.NoError(err)
+		suite.Equal(expectedStep, resultUser.TOTPLastTimeStep)
+	}
+
+	step = 100
+	expectedResult = true
+	expectedStep = 100
+	suite.Run("LaterStepIsUpdated", testCase)
+
+	step = 100
+	expectedResult = false
+	expectedStep = 100
+	suite.Run("SameStepIsNotUpdated", testCase)
+
+	step = 99
+	expectedResult = false
+	expectedStep = 100
+	suite.Run("EarlierStepIsNotUpdated", testCase)
 
 	//clean up
 	suite.DeleteUser(user)
@@ -215,6 +419,23 @@ func (suite *UserCRUDTestSuite) TestDeleteUser_AlsoDeletesAllUserSessions() {
 	suite.Nil(resultSession)
 }
 
+func (suite *UserCRUDTestSuite) TestDeleteUser_AlsoDeletesAllUserRecoveryCodes() {
+	//arrange
+	user := suite.SaveUser(models.CreateUser("username", 0, []byte("password")))
+	code := suite.SaveRecoveryCode(models.CreateRecoveryCode(user.Username, []byte("hash")))
+
+	//act
+	res, err := suite.Executor.DeleteUser(user.Username)
+
+	//assert
+	suite.True(res)
+	suite.Require().NoError(err)
+
+	res, err = suite.Executor.DeleteRecoveryCode(code.Username, code.CodeHash)
+	suite.NoError(err)
+	suite.False(res)
+}
+
 func TestUserCRUDTestSuite(t *testing.T) {
 	suite.Run(t, &UserCRUDTestSuite{})
 }
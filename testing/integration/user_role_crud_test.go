@@ -15,7 +15,7 @@ type UserRoleCRUDTestSuite struct {
 
 func (suite *UserRoleCRUDTestSuite) TestCreateUserRole_WithInvalidUserRole_ReturnsError() {
 	//arrange
-	role := models.CreateUserRole(uuid.Nil, "", "")
+	role := models.CreateUserRole(uuid.Nil, "")
 
 	//act
 	err := suite.Executor.CreateUserRole(role)
@@ -34,13 +34,13 @@ func (suite *UserRoleCRUDTestSuite) TestGetUserRolesWithLesserRankByClientUID_Ge
 	client1 := suite.SaveClient(models.CreateNewClient("name1", "redirect.com", 0, "key.pem"))
 	client2 := suite.SaveClient(models.CreateNewClient("name2", "redirect.com", 0, "key.pem"))
 
-	role1 := suite.SaveUserRole(models.CreateUserRole(client1.UID, user1.Username, "role"))
+	role1 := suite.SaveUserRole(models.CreateUserRole(client1.UID, user1.Username, "role", "other"))
 	role2 := suite.SaveUserRole(models.CreateUserRole(client1.UID, user2.Username, "role"))
 	suite.SaveUserRole(models.CreateUserRole(client1.UID, user3.Username, "role"))
 	suite.SaveUserRole(models.CreateUserRole(client2.UID, user1.Username, "role"))
 
 	//act
-	roles, err := suite.Executor.GetUserRolesWithLesserRankByClientUID(client1.UID, 2)
+	roles, err := suite.Executor.GetUserRolesWithLesserRankByClientUID(client1.UID, 2, models.PageQuery{})
 
 	//assert
 	suite.NoError(err)
@@ -58,6 +58,75 @@ func (suite *UserRoleCRUDTestSuite) TestGetUserRolesWithLesserRankByClientUID_Ge
 	suite.DeleteClient(client2)
 }
 
+func (suite *UserRoleCRUDTestSuite) TestGetUserRolesWithLesserRankByClientUID_SortedByRole_PagesUsingCursor() {
+	//arrange
+	user1 := suite.SaveUser(models.CreateUser("user1", 0, []byte("password")))
+	user2 := suite.SaveUser(models.CreateUser("user2", 0, []byte("password")))
+	user3 := suite.SaveUser(models.CreateUser("user3", 0, []byte("password")))
+
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+
+	role1 := suite.SaveUserRole(models.CreateUserRole(client.UID, user1.Username, "role_b", "role_c"))
+	role2 := suite.SaveUserRole(models.CreateUserRole(client.UID, user2.Username, "role_d", "role_a"))
+	role3 := suite.SaveUserRole(models.CreateUserRole(client.UID, user3.Username, "role_b"))
+
+	query := models.PageQuery{
+		SortBy: models.UserRoleSortRole,
+		Limit:  2,
+	}
+
+	//act
+	page1, err1 := suite.Executor.GetUserRolesWithLesserRankByClientUID(client.UID, 1, query)
+
+	cursor := role1.GetCursor(models.UserRoleSortRole)
+	query.After = &cursor
+	page2, err2 := suite.Executor.GetUserRolesWithLesserRankByClientUID(client.UID, 1, query)
+
+	//assert
+	suite.NoError(err1)
+	suite.Require().Len(page1, 2)
+	suite.EqualValues(page1[0], role2)
+	suite.EqualValues(page1[1], role1)
+
+	suite.NoError(err2)
+	suite.Require().Len(page2, 1)
+	suite.EqualValues(page2[0], role3)
+
+	//clean up
+	suite.DeleteUser(user1)
+	suite.DeleteUser(user2)
+	suite.DeleteUser(user3)
+
+	suite.DeleteClient(client)
+}
+
+func (suite *UserRoleCRUDTestSuite) TestCountUserRolesWithLesserRankByClientUID_CountsTheUserRolesMatchingSearch() {
+	//arrange
+	user1 := suite.SaveUser(models.CreateUser("user1", 0, []byte("password")))
+	user2 := suite.SaveUser(models.CreateUser("other", 0, []byte("password")))
+	user3 := suite.SaveUser(models.CreateUser("user3", 2, []byte("password")))
+
+	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
+
+	suite.SaveUserRole(models.CreateUserRole(client.UID, user1.Username, "role", "other"))
+	suite.SaveUserRole(models.CreateUserRole(client.UID, user2.Username, "role"))
+	suite.SaveUserRole(models.CreateUserRole(client.UID, user3.Username, "role"))
+
+	//act
+	count, err := suite.Executor.CountUserRolesWithLesserRankByClientUID(client.UID, 2, "USER")
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(1, count)
+
+	//clean up
+	suite.DeleteUser(user1)
+	suite.DeleteUser(user2)
+	suite.DeleteUser(user3)
+
+	suite.DeleteClient(client)
+}
+
 func (suite *UserRoleCRUDTestSuite) TestGetUserRoleByUsernameAndClientUID_WhereUserRoleNotFound_ReturnsNilUserRole() {
 	//act
 	role, err := suite.Executor.GetUserRoleByClientUIDAndUsername(uuid.New(), "DNE")
@@ -71,7 +140,7 @@ func (suite *UserRoleCRUDTestSuite) TestGetUserRoleByUsernameAndClientUID_GetsUs
 	//arrange
 	user := suite.SaveUser(models.CreateUser("username", 0, []byte("password")))
 	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
-	role := suite.SaveUserRole(models.CreateUserRole(client.UID, user.Username, "role"))
+	role := suite.SaveUserRole(models.CreateUserRole(client.UID, user.Username, "role", "other"))
 
 	//act
 	resultRole, err := suite.Executor.GetUserRoleByClientUIDAndUsername(role.ClientUID, user.Username)
@@ -87,7 +156,7 @@ func (suite *UserRoleCRUDTestSuite) TestGetUserRoleByUsernameAndClientUID_GetsUs
 
 func (suite *UserRoleCRUDTestSuite) TestUpdateUserRole_WithInvalidUserRole_ReturnsError() {
 	//act
-	_, err := suite.Executor.UpdateUserRole(models.CreateUserRole(uuid.Nil, "", ""))
+	_, err := suite.Executor.UpdateUserRole(models.CreateUserRole(uuid.Nil, ""))
 
 	//assert
 	suite.Require().Error(err)
@@ -107,10 +176,10 @@ func (suite *UserRoleCRUDTestSuite) TestUpdateUserRole_UpdatesUserRole() {
 	//arrange
 	user := suite.SaveUser(models.CreateUser("username", 0, []byte("password")))
 	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
-	role := suite.SaveUserRole(models.CreateUserRole(client.UID, user.Username, "role"))
+	role := suite.SaveUserRole(models.CreateUserRole(client.UID, user.Username, "role", "other"))
 
 	//act
-	role.Role = "new role"
+	role.Roles = []string{"new role", "role"}
 	res, err := suite.Executor.UpdateUserRole(role)
 
 	//assert
@@ -139,7 +208,7 @@ func (suite *UserRoleCRUDTestSuite) TestDeleteUserRole_DeletesUserRole() {
 	//arrange
 	user := suite.SaveUser(models.CreateUser("username", 0, []byte("password")))
 	client := suite.SaveClient(models.CreateNewClient("name", "redirect.com", 0, "key.pem"))
-	role := suite.SaveUserRole(models.CreateUserRole(client.UID, user.Username, "role"))
+	role := suite.SaveUserRole(models.CreateUserRole(client.UID, user.Username, "role", "other"))
 
 	//act
 	res, err := suite.Executor.DeleteUserRole(role.ClientUID, role.Username)
@@ -157,6 +226,69 @@ func (suite *UserRoleCRUDTestSuite) TestDeleteUserRole_DeletesUserRole() {
 	suite.DeleteClient(client)
 }
 
+func (suite *UserRoleCRUDTestSuite) TestCountUserRolesByClientUIDAndRole_CountsTheUserRolesWithClientUIDAndRole() {
+	//arrange
+	user1 := suite.SaveUser(models.CreateUser("user1", 0, []byte("password")))
+	user2 := suite.SaveUser(models.CreateUser("user2", 0, []byte("password")))
+	user3 := suite.SaveUser(models.CreateUser("user3", 0, []byte("password")))
+
+	client1 := suite.SaveClient(models.CreateNewClient("name1", "redirect.com", 0, "key.pem"))
+	client2 := suite.SaveClient(models.CreateNewClient("name2", "redirect.com", 0, "key.pem"))
+
+	suite.SaveUserRole(models.CreateUserRole(client1.UID, user1.Username, "role", "other"))
+	suite.SaveUserRole(models.CreateUserRole(client1.UID, user2.Username, "role"))
+	suite.SaveUserRole(models.CreateUserRole(client1.UID, user3.Username, "other"))
+	suite.SaveUserRole(models.CreateUserRole(client2.UID, user1.Username, "role"))
+
+	//act
+	count, err := suite.Executor.CountUserRolesByClientUIDAndRole(client1.UID, "role")
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(2, count)
+
+	//clean up
+	suite.DeleteUser(user1)
+	suite.DeleteUser(user2)
+	suite.DeleteUser(user3)
+
+	suite.DeleteClient(client1)
+	suite.DeleteClient(client2)
+}
+
+func (suite *UserRoleCRUDTestSuite) TestRenameUserRoles_RenamesTheUserRolesWithClientUIDAndRole() {
+	//arrange
+	user1 := suite.SaveUser(models.CreateUser("user1", 0, []byte("password")))
+	user2 := suite.SaveUser(models.CreateUser("user2", 0, []byte("password")))
+
+	client1 := suite.SaveClient(models.CreateNewClient("name1", "redirect.com", 0, "key.pem"))
+	client2 := suite.SaveClient(models.CreateNewClient("name2", "redirect.com", 0, "key.pem"))
+
+	role1 := suite.SaveUserRole(models.CreateUserRole(client1.UID, user1.Username, "role", "other"))
+	role2 := suite.SaveUserRole(models.CreateUserRole(client1.UID, user2.Username, "other"))
+	role3 := suite.SaveUserRole(models.CreateUserRole(client2.UID, user1.Username, "role"))
+
+	//act
+	err := suite.Executor.RenameUserRoles(client1.UID, "role", "new_role")
+
+	//assert
+	suite.Require().NoError(err)
+	role1.Roles = []string{"new_role", "other"}
+
+	for _, role := range []*models.UserRole{role1, role2, role3} {
+		resultRole, err := suite.Executor.GetUserRoleByClientUIDAndUsername(role.ClientUID, role.Username)
+		suite.NoError(err)
+		suite.EqualValues(role, resultRole)
+	}
+
+	//clean up
+	suite.DeleteUser(user1)
+	suite.DeleteUser(user2)
+
+	suite.DeleteClient(client1)
+	suite.DeleteClient(client2)
+}
+
 func TestUserRoleCRUDTestSuite(t *testing.T) {
 	suite.Run(t, &UserRoleCRUDTestSuite{})
 }
@@ -26,6 +26,14 @@ func main() {
 
 	viper.Set("db_key", *dbKey)
 
+	//open the data adapter
+	dataAdapter := dependencies.ResolveDataAdapter()
+	err = dataAdapter.Setup()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer dataAdapter.CleanUp()
+
 	err = runner.Run(dependencies.ResolveScopeFactory(), dependencies.ResolveControllers(), *username, *password, *rank)
 	if err != nil {
 		log.Fatal(err)
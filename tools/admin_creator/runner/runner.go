@@ -4,18 +4,38 @@ import (
 	"github.com/mhogar/amber/common"
 	"github.com/mhogar/amber/controllers"
 	"github.com/mhogar/amber/data"
+	"github.com/mhogar/amber/models"
 )
 
+// Controllers are the controllers used by the admin creator.
+type Controllers interface {
+	controllers.UserController
+	controllers.AdminRoleController
+}
+
 // Run runs the admin creator and returns any errors.
-func Run(sf data.ScopeFactory, c controllers.UserController, username string, password string, rank int) error {
+// The admin is given the built-in admin role, which has every permission.
+func Run(sf data.ScopeFactory, c Controllers, username string, password string, rank int) error {
 	return sf.CreateDataExecutorScope(func(exec data.DataExecutor) error {
 		return sf.CreateTransactionScope(exec, func(tx data.Transaction) (bool, error) {
 			//create the user
-			_, cerr := c.CreateUser(tx, username, password, rank)
+			_, cerr := c.CreateUser(tx, username, password, rank, models.UserProfile{Enabled: true})
 			if cerr.Type != common.ErrorTypeNone {
 				return false, common.ChainError("error creating user", cerr)
 			}
 
+			//make sure the built-in admin roles exist
+			cerr = c.CreateBuiltInAdminRoles(tx)
+			if cerr.Type != common.ErrorTypeNone {
+				return false, common.ChainError("error creating built-in admin roles", cerr)
+			}
+
+			//give the user the admin role
+			_, cerr = c.UpdateUserAdminRoles(tx, username, []string{controllers.AdminRoleAdmin})
+			if cerr.Type != common.ErrorTypeNone {
+				return false, common.ChainError("error updating user admin roles", cerr)
+			}
+
 			return true, nil
 		})
 	})
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/controllers"
 	controllermocks "github.com/mhogar/amber/controllers/mocks"
 	"github.com/mhogar/amber/models"
 	"github.com/mhogar/amber/testing/helpers"
@@ -27,7 +28,48 @@ func (suite *AdminCreatorTestSuite) SetupTest() {
 func (suite *AdminCreatorTestSuite) TestRun_WithErrorCreatingUser_ReturnsError() {
 	//arrange
 	message := "create user error"
-	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
+
+	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
+	suite.SetupScopeFactoryMock_CreateTransactionScope_WithCallback(nil, func(result bool, err error) {
+		suite.False(result)
+		suite.Require().Error(err)
+		suite.Contains(err.Error(), message)
+	})
+
+	//act
+	err := runner.Run(&suite.ScopeFactoryMock, &suite.ControllersMock, "username", "password", 0)
+
+	//assert
+	suite.NoError(err)
+}
+
+func (suite *AdminCreatorTestSuite) TestRun_WithErrorCreatingBuiltInAdminRoles_ReturnsError() {
+	//arrange
+	message := "create built-in admin roles error"
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&models.User{}, common.NoError())
+	suite.ControllersMock.On("CreateBuiltInAdminRoles", mock.Anything).Return(common.ClientError(message))
+
+	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
+	suite.SetupScopeFactoryMock_CreateTransactionScope_WithCallback(nil, func(result bool, err error) {
+		suite.False(result)
+		suite.Require().Error(err)
+		suite.Contains(err.Error(), message)
+	})
+
+	//act
+	err := runner.Run(&suite.ScopeFactoryMock, &suite.ControllersMock, "username", "password", 0)
+
+	//assert
+	suite.NoError(err)
+}
+
+func (suite *AdminCreatorTestSuite) TestRun_WithErrorUpdatingUserAdminRoles_ReturnsError() {
+	//arrange
+	message := "update user admin roles error"
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&models.User{}, common.NoError())
+	suite.ControllersMock.On("CreateBuiltInAdminRoles", mock.Anything).Return(common.NoError())
+	suite.ControllersMock.On("UpdateUserAdminRoles", mock.Anything, mock.Anything, mock.Anything).Return(nil, common.ClientError(message))
 
 	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
 	suite.SetupScopeFactoryMock_CreateTransactionScope_WithCallback(nil, func(result bool, err error) {
@@ -49,7 +91,9 @@ func (suite *AdminCreatorTestSuite) TestRun_WithNoErrors_ReturnsNoErrors() {
 	password := "password"
 	rank := 0
 
-	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&models.User{}, common.NoError())
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&models.User{}, common.NoError())
+	suite.ControllersMock.On("CreateBuiltInAdminRoles", mock.Anything).Return(common.NoError())
+	suite.ControllersMock.On("UpdateUserAdminRoles", mock.Anything, mock.Anything, mock.Anything).Return([]*models.AdminRole{}, common.NoError())
 
 	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
 	suite.SetupScopeFactoryMock_CreateTransactionScope_WithCallback(nil, func(result bool, err error) {
@@ -65,7 +109,9 @@ func (suite *AdminCreatorTestSuite) TestRun_WithNoErrors_ReturnsNoErrors() {
 
 	suite.ScopeFactoryMock.AssertCalled(suite.T(), "CreateDataExecutorScope", mock.Anything)
 	suite.ScopeFactoryMock.AssertCalled(suite.T(), "CreateTransactionScope", &suite.DataExecutorMock, mock.Anything)
-	suite.ControllersMock.AssertCalled(suite.T(), "CreateUser", &suite.TransactionMock, username, password, rank)
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateUser", &suite.TransactionMock, username, password, rank, models.UserProfile{Enabled: true})
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateBuiltInAdminRoles", &suite.TransactionMock)
+	suite.ControllersMock.AssertCalled(suite.T(), "UpdateUserAdminRoles", &suite.TransactionMock, username, []string{controllers.AdminRoleAdmin})
 }
 
 func TestAdminCreatorTestSuite(t *testing.T) {
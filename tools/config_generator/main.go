@@ -1,6 +1,8 @@
 package main
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
 	"flag"
 	"fmt"
@@ -8,6 +10,7 @@ import (
 	"log"
 	"os"
 
+	"github.com/mhogar/amber/common"
 	"github.com/mhogar/amber/config"
 
 	"gopkg.in/yaml.v3"
@@ -33,16 +36,73 @@ func Run(name string) error {
 		return errors.New("file already exists")
 	}
 
+	//generate a random key for encrypting two-factor secrets
+	key := make([]byte, 32)
+	_, err = rand.Read(key)
+	if err != nil {
+		return common.ChainError("error generating two-factor encryption key", err)
+	}
+
 	//create the config struct
 	cfg := config.Config{
 		AppName:     "Amber",
 		DataAdapter: "database",
+		ServerConfig: config.ServerConfig{
+			ShutdownTimeout: 30,
+			PublicURL:       "http://localhost:8080",
+			TrustedProxies:  0,
+		},
+		TLSConfig: config.TLSConfig{
+			Enabled:        false,
+			CertFile:       "static/tls/cert.pem",
+			KeyFile:        "static/tls/key.pem",
+			MinVersion:     "1.2",
+			ReloadInterval: 60,
+			RedirectPort:   "",
+		},
 		TokenConfig: config.TokenConfig{
-			DefaultIssuer: "amber",
-			Lifetime:      60,
+			DefaultIssuer:             "amber",
+			Lifetime:                  60,
+			AuthorizationCodeLifetime: 60,
+			RefreshTokenLifetime:      2592000,
+			IncludeProfileClaims:      false,
+			IncludeSingleRoleClaim:    true,
+		},
+		SessionConfig: config.SessionConfig{
+			Lifetime:      86400,
+			IdleTimeout:   3600,
+			SweepInterval: 600,
+		},
+		TwoFactorConfig: config.TwoFactorConfig{
+			EncryptionKey:     base64.StdEncoding.EncodeToString(key),
+			ChallengeLifetime: 300,
+			RequiredRank:      0,
+		},
+		LockoutConfig: config.LockoutConfig{
+			MaxUserAttempts: 5,
+			MaxIPAttempts:   20,
+			Duration:        60,
+			MaxDuration:     3600,
+			ResetAfter:      3600,
 		},
 		PermissionConfig: config.PermissionConfig{
-			MinClientRank: 5,
+			MinClientRank:  5,
+			MinLockoutRank: 5,
+			MinAuditRank:   5,
+		},
+		PasswordResetConfig: config.PasswordResetConfig{
+			TokenLifetime: 3600,
+		},
+		InvitationConfig: config.InvitationConfig{
+			Lifetime: 604800,
+		},
+		MailConfig: config.MailConfig{
+			Type:         "smtp",
+			From:         "",
+			SMTPHost:     "",
+			SMTPPort:     "587",
+			SMTPUsername: "",
+			SMTPPassword: "",
 		},
 		DatabaseConfig: config.DatabaseConfig{
 			Driver: "postgres",
@@ -50,7 +110,10 @@ func Run(name string) error {
 				"core":        "",
 				"integration": "",
 			},
-			Timeout: 5000,
+			Timeout:               5000,
+			MaxOpenConnections:    25,
+			MaxIdleConnections:    25,
+			ConnectionMaxLifetime: 300,
 		},
 		FirestoreConfig: config.FirestoreConfig{
 			ServiceFile: "",
@@ -62,6 +125,9 @@ func Run(name string) error {
 			RequireUpperCase: true,
 			RequireDigit:     true,
 			RequireSymbol:    true,
+			HistorySize:      0,
+			MaxAge:           0,
+			MinAge:           0,
 		},
 	}
 
@@ -24,6 +24,14 @@ func main() {
 
 	viper.Set("db_key", *dbKey)
 
+	//open the data adapter
+	dataAdapter := dependencies.ResolveDataAdapter()
+	err = dataAdapter.Setup()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer dataAdapter.CleanUp()
+
 	mrf := runner.CoreMigrationRunnerFactory{
 		MigrationRepositoryFactory: dependencies.ResolveMigrationRepositoryFactory(),
 	}
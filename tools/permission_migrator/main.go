@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/mhogar/amber/config"
+	"github.com/mhogar/amber/dependencies"
+	"github.com/mhogar/amber/tools/permission_migrator/runner"
+
+	"github.com/spf13/viper"
+)
+
+func main() {
+	err := config.InitConfig(".")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	//parse flags
+	dbKey := flag.String("db", "core", "The database to run the script against")
+	flag.Parse()
+
+	viper.Set("db_key", *dbKey)
+
+	//open the data adapter
+	dataAdapter := dependencies.ResolveDataAdapter()
+	err = dataAdapter.Setup()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer dataAdapter.CleanUp()
+
+	err = runner.Run(dependencies.ResolveScopeFactory(), dependencies.ResolveControllers())
+	if err != nil {
+		log.Fatal(err)
+	}
+}
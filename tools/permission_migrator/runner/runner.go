@@ -0,0 +1,22 @@
+package runner
+
+import (
+	"github.com/mhogar/amber/common"
+	"github.com/mhogar/amber/controllers"
+	"github.com/mhogar/amber/data"
+)
+
+// Run runs the permission migrator and returns any errors.
+// Each user is given the built-in admin roles that grant the access their rank used to.
+func Run(sf data.ScopeFactory, c controllers.AdminRoleController) error {
+	return sf.CreateDataExecutorScope(func(exec data.DataExecutor) error {
+		return sf.CreateTransactionScope(exec, func(tx data.Transaction) (bool, error) {
+			cerr := c.AssignAdminRolesByRank(tx)
+			if cerr.Type != common.ErrorTypeNone {
+				return false, common.ChainError("error assigning admin roles by rank", cerr)
+			}
+
+			return true, nil
+		})
+	})
+}
@@ -0,0 +1,67 @@
+package runner_test
+
+import (
+	"testing"
+
+	"github.com/mhogar/amber/common"
+	controllermocks "github.com/mhogar/amber/controllers/mocks"
+	"github.com/mhogar/amber/testing/helpers"
+	"github.com/mhogar/amber/tools/permission_migrator/runner"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type PermissionMigratorTestSuite struct {
+	helpers.CustomSuite
+	helpers.ScopeFactorySuite
+	ControllersMock controllermocks.Controllers
+}
+
+func (suite *PermissionMigratorTestSuite) SetupTest() {
+	suite.ScopeFactorySuite.SetupTest()
+	suite.ControllersMock = controllermocks.Controllers{}
+}
+
+func (suite *PermissionMigratorTestSuite) TestRun_WithErrorAssigningAdminRoles_ReturnsError() {
+	//arrange
+	suite.ControllersMock.On("AssignAdminRolesByRank", mock.Anything).Return(common.InternalError())
+
+	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
+	suite.SetupScopeFactoryMock_CreateTransactionScope_WithCallback(nil, func(result bool, err error) {
+		suite.False(result)
+		suite.Require().Error(err)
+		suite.Contains(err.Error(), "error assigning admin roles by rank")
+	})
+
+	//act
+	err := runner.Run(&suite.ScopeFactoryMock, &suite.ControllersMock)
+
+	//assert
+	suite.NoError(err)
+}
+
+func (suite *PermissionMigratorTestSuite) TestRun_WithNoErrors_ReturnsNoErrors() {
+	//arrange
+	suite.ControllersMock.On("AssignAdminRolesByRank", mock.Anything).Return(common.NoError())
+
+	suite.SetupScopeFactoryMock_CreateDataExecutorScope(nil)
+	suite.SetupScopeFactoryMock_CreateTransactionScope_WithCallback(nil, func(result bool, err error) {
+		suite.True(result)
+		suite.NoError(err)
+	})
+
+	//act
+	err := runner.Run(&suite.ScopeFactoryMock, &suite.ControllersMock)
+
+	//assert
+	suite.NoError(err)
+
+	suite.ScopeFactoryMock.AssertCalled(suite.T(), "CreateDataExecutorScope", mock.Anything)
+	suite.ScopeFactoryMock.AssertCalled(suite.T(), "CreateTransactionScope", &suite.DataExecutorMock, mock.Anything)
+	suite.ControllersMock.AssertCalled(suite.T(), "AssignAdminRolesByRank", &suite.TransactionMock)
+}
+
+func TestPermissionMigratorTestSuite(t *testing.T) {
+	suite.Run(t, &PermissionMigratorTestSuite{})
+}